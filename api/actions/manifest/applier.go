@@ -23,6 +23,7 @@ type Applier struct {
 	routeRepo           shared.CFRouteRepository
 	serviceInstanceRepo shared.CFServiceInstanceRepository
 	serviceBindingRepo  shared.CFServiceBindingRepository
+	sidecarRepo         shared.CFSidecarRepository
 }
 
 func NewApplier(
@@ -32,6 +33,7 @@ func NewApplier(
 	routeRepo shared.CFRouteRepository,
 	serviceInstanceRepo shared.CFServiceInstanceRepository,
 	serviceBindingRepo shared.CFServiceBindingRepository,
+	sidecarRepo shared.CFSidecarRepository,
 ) *Applier {
 	return &Applier{
 		appRepo:             appRepo,
@@ -40,6 +42,7 @@ func NewApplier(
 		routeRepo:           routeRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
+		sidecarRepo:         sidecarRepo,
 	}
 }
 
@@ -57,6 +60,10 @@ func (a *Applier) Apply(ctx context.Context, authInfo authorization.Info, spaceG
 		return err
 	}
 
+	if err := a.applySidecars(ctx, authInfo, appInfo, appState); err != nil {
+		return err
+	}
+
 	return a.applyServices(ctx, authInfo, appInfo, appState)
 }
 
@@ -99,6 +106,28 @@ func (a *Applier) applyProcesses(
 	return nil
 }
 
+func (a *Applier) applySidecars(
+	ctx context.Context,
+	authInfo authorization.Info,
+	appInfo payloads.ManifestApplication,
+	appState AppState,
+) error {
+	for _, sidecarInfo := range appInfo.Sidecars {
+		if sidecar, ok := appState.Sidecars[sidecarInfo.Name]; ok {
+			if _, err := a.sidecarRepo.PatchSidecar(ctx, authInfo, sidecarInfo.ToSidecarPatchMessage(sidecar.GUID)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := a.sidecarRepo.CreateSidecar(ctx, authInfo, sidecarInfo.ToSidecarCreateMessage(appState.App.GUID, appState.App.SpaceGUID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (a *Applier) applyRoutes(ctx context.Context, authInfo authorization.Info, appInfo payloads.ManifestApplication, appState AppState) error {
 	if appInfo.NoRoute {
 		return a.deleteAppDestinations(ctx, authInfo, appState.App.GUID, appState.Routes)
@@ -27,6 +27,7 @@ var _ = Describe("Applier", func() {
 		routeRepo           *fake.CFRouteRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		serviceBindingRepo  *fake.CFServiceBindingRepository
+		sidecarRepo         *fake.CFSidecarRepository
 		applier             *manifest.Applier
 		applierErr          error
 		ctx                 context.Context
@@ -42,7 +43,8 @@ var _ = Describe("Applier", func() {
 		routeRepo = new(fake.CFRouteRepository)
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
-		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo)
+		sidecarRepo = new(fake.CFSidecarRepository)
+		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, sidecarRepo)
 		ctx = context.Background()
 		authInfo = authorization.Info{Token: "a-token"}
 		appInfo = payloads.ManifestApplication{
@@ -265,6 +267,92 @@ var _ = Describe("Applier", func() {
 		})
 	})
 
+	Describe("applying sidecars", func() {
+		BeforeEach(func() {
+			appState.App.GUID = "app-guid"
+			appState.App.SpaceGUID = "space-guid"
+			appInfo.Sidecars = []payloads.ManifestApplicationSidecar{
+				{
+					Name:         "proxy",
+					ProcessTypes: []string{"web"},
+					Command:      "bin/proxy",
+					Memory:       tools.PtrTo("64M"),
+				},
+				{
+					Name:         "agent",
+					ProcessTypes: []string{"web", "worker"},
+					Command:      "bin/agent",
+				},
+			}
+		})
+
+		It("creates each sidecar", func() {
+			Expect(applierErr).NotTo(HaveOccurred())
+			Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(0))
+			Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(2))
+
+			_, _, createMsg := sidecarRepo.CreateSidecarArgsForCall(0)
+			Expect(createMsg).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Name:         "proxy",
+				Command:      "bin/proxy",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     64,
+			}))
+
+			_, _, createMsg = sidecarRepo.CreateSidecarArgsForCall(1)
+			Expect(createMsg).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Name:         "agent",
+				Command:      "bin/agent",
+				ProcessTypes: []string{"web", "worker"},
+			}))
+		})
+
+		When("creating a sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{}, errors.New("create-sidecar-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(applierErr).To(MatchError("create-sidecar-failed"))
+			})
+		})
+
+		When("a sidecar exists", func() {
+			BeforeEach(func() {
+				appState.Sidecars = map[string]repositories.SidecarRecord{
+					"agent": {GUID: "sidecar-guid", Name: "agent"},
+				}
+			})
+
+			It("patches that sidecar", func() {
+				Expect(applierErr).NotTo(HaveOccurred())
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(1))
+				Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(1))
+
+				_, _, patchMsg := sidecarRepo.PatchSidecarArgsForCall(0)
+				Expect(patchMsg).To(Equal(repositories.PatchSidecarMessage{
+					GUID:         "sidecar-guid",
+					Command:      tools.PtrTo("bin/agent"),
+					ProcessTypes: []string{"web", "worker"},
+				}))
+			})
+
+			When("patching the sidecar fails", func() {
+				BeforeEach(func() {
+					sidecarRepo.PatchSidecarReturns(repositories.SidecarRecord{}, errors.New("sidecar-patch-error"))
+				})
+
+				It("returns the error", func() {
+					Expect(applierErr).To(MatchError("sidecar-patch-error"))
+				})
+			})
+		})
+	})
+
 	Describe("applying routes", func() {
 		BeforeEach(func() {
 			appState.App.GUID = "app-guid"
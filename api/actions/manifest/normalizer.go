@@ -83,7 +83,9 @@ func (n Normalizer) normalizeProcesses(appInfo payloads.ManifestApplication, app
 	}
 
 	if appInfo.Memory != nil || appInfo.DiskQuota != nil || appInfo.Instances != nil || appInfo.Command != nil ||
-		appInfo.HealthCheckHTTPEndpoint != nil || appInfo.HealthCheckType != nil || appInfo.HealthCheckInvocationTimeout != nil || appInfo.Timeout != nil {
+		appInfo.HealthCheckHTTPEndpoint != nil || appInfo.HealthCheckType != nil || appInfo.HealthCheckInvocationTimeout != nil || appInfo.Timeout != nil ||
+		appInfo.HealthCheckInterval != nil || appInfo.HealthCheckFailureThreshold != nil ||
+		appInfo.ReadinessHealthCheckHTTPEndpoint != nil || appInfo.ReadinessHealthCheckType != nil || appInfo.ReadinessHealthCheckInvocationTimeout != nil || appInfo.ReadinessHealthCheckInterval != nil {
 
 		webProc.Memory = procValIfSet(appInfo.Memory, webProc.Memory)
 		webProc.DiskQuota = procValIfSet(appInfo.DiskQuota, webProc.DiskQuota)
@@ -93,6 +95,12 @@ func (n Normalizer) normalizeProcesses(appInfo payloads.ManifestApplication, app
 		webProc.HealthCheckType = procValIfSet(appInfo.HealthCheckType, webProc.HealthCheckType)
 		webProc.HealthCheckInvocationTimeout = procValIfSet(appInfo.HealthCheckInvocationTimeout, webProc.HealthCheckInvocationTimeout)
 		webProc.Timeout = procValIfSet(appInfo.Timeout, webProc.Timeout)
+		webProc.HealthCheckInterval = procValIfSet(appInfo.HealthCheckInterval, webProc.HealthCheckInterval)
+		webProc.HealthCheckFailureThreshold = procValIfSet(appInfo.HealthCheckFailureThreshold, webProc.HealthCheckFailureThreshold)
+		webProc.ReadinessHealthCheckHTTPEndpoint = procValIfSet(appInfo.ReadinessHealthCheckHTTPEndpoint, webProc.ReadinessHealthCheckHTTPEndpoint)
+		webProc.ReadinessHealthCheckType = procValIfSet(appInfo.ReadinessHealthCheckType, webProc.ReadinessHealthCheckType)
+		webProc.ReadinessHealthCheckInvocationTimeout = procValIfSet(appInfo.ReadinessHealthCheckInvocationTimeout, webProc.ReadinessHealthCheckInvocationTimeout)
+		webProc.ReadinessHealthCheckInterval = procValIfSet(appInfo.ReadinessHealthCheckInterval, webProc.ReadinessHealthCheckInterval)
 	}
 
 	return processes
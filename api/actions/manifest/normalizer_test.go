@@ -19,6 +19,13 @@ type prcParams struct {
 	HealthCheckInvocationTimeout *int32
 	HealthCheckType              *string
 	Timeout                      *int32
+	HealthCheckInterval          *int32
+	HealthCheckFailureThreshold  *int32
+
+	ReadinessHealthCheckHTTPEndpoint      *string
+	ReadinessHealthCheckInvocationTimeout *int32
+	ReadinessHealthCheckInterval          *int32
+	ReadinessHealthCheckType              *string
 }
 
 type (
@@ -167,18 +174,30 @@ var _ = Describe("Normalizer", func() {
 				appInfo.HealthCheckType = app.HealthCheckType
 				appInfo.HealthCheckInvocationTimeout = app.HealthCheckInvocationTimeout
 				appInfo.Timeout = app.Timeout
+				appInfo.HealthCheckInterval = app.HealthCheckInterval
+				appInfo.HealthCheckFailureThreshold = app.HealthCheckFailureThreshold
+				appInfo.ReadinessHealthCheckHTTPEndpoint = app.ReadinessHealthCheckHTTPEndpoint
+				appInfo.ReadinessHealthCheckInvocationTimeout = app.ReadinessHealthCheckInvocationTimeout
+				appInfo.ReadinessHealthCheckInterval = app.ReadinessHealthCheckInterval
+				appInfo.ReadinessHealthCheckType = app.ReadinessHealthCheckType
 
 				if (process != prcParams{}) {
 					appInfo.Processes = append(appInfo.Processes, payloads.ManifestApplicationProcess{
-						Type:                         "web",
-						Memory:                       process.Memory,
-						DiskQuota:                    process.DiskQuota,
-						Instances:                    process.Instances,
-						Command:                      process.Command,
-						HealthCheckHTTPEndpoint:      process.HealthCheckHTTPEndpoint,
-						HealthCheckType:              process.HealthCheckType,
-						HealthCheckInvocationTimeout: process.HealthCheckInvocationTimeout,
-						Timeout:                      process.Timeout,
+						Type:                                  "web",
+						Memory:                                process.Memory,
+						DiskQuota:                             process.DiskQuota,
+						Instances:                             process.Instances,
+						Command:                               process.Command,
+						HealthCheckHTTPEndpoint:               process.HealthCheckHTTPEndpoint,
+						HealthCheckType:                       process.HealthCheckType,
+						HealthCheckInvocationTimeout:          process.HealthCheckInvocationTimeout,
+						Timeout:                               process.Timeout,
+						HealthCheckInterval:                   process.HealthCheckInterval,
+						HealthCheckFailureThreshold:           process.HealthCheckFailureThreshold,
+						ReadinessHealthCheckHTTPEndpoint:      process.ReadinessHealthCheckHTTPEndpoint,
+						ReadinessHealthCheckInvocationTimeout: process.ReadinessHealthCheckInvocationTimeout,
+						ReadinessHealthCheckInterval:          process.ReadinessHealthCheckInterval,
+						ReadinessHealthCheckType:              process.ReadinessHealthCheckType,
 					})
 				}
 
@@ -193,6 +212,12 @@ var _ = Describe("Normalizer", func() {
 				Expect(webProc.HealthCheckType).To(Equal(effective.HealthCheckType))
 				Expect(webProc.HealthCheckInvocationTimeout).To(Equal(effective.HealthCheckInvocationTimeout))
 				Expect(webProc.Timeout).To(Equal(effective.Timeout))
+				Expect(webProc.HealthCheckInterval).To(Equal(effective.HealthCheckInterval))
+				Expect(webProc.HealthCheckFailureThreshold).To(Equal(effective.HealthCheckFailureThreshold))
+				Expect(webProc.ReadinessHealthCheckHTTPEndpoint).To(Equal(effective.ReadinessHealthCheckHTTPEndpoint))
+				Expect(webProc.ReadinessHealthCheckInvocationTimeout).To(Equal(effective.ReadinessHealthCheckInvocationTimeout))
+				Expect(webProc.ReadinessHealthCheckInterval).To(Equal(effective.ReadinessHealthCheckInterval))
+				Expect(webProc.ReadinessHealthCheckType).To(Equal(effective.ReadinessHealthCheckType))
 			},
 
 			// without an explicit web process in the manifest
@@ -220,6 +245,24 @@ var _ = Describe("Normalizer", func() {
 			Entry("app-level timeout only",
 				appParams{Timeout: tools.PtrTo(int32(12))}, prcParams{},
 				expParams{Timeout: tools.PtrTo(int32(12))}),
+			Entry("app-level healthcheck interval only",
+				appParams{HealthCheckInterval: tools.PtrTo(int32(20))}, prcParams{},
+				expParams{HealthCheckInterval: tools.PtrTo(int32(20))}),
+			Entry("app-level healthcheck failure threshold only",
+				appParams{HealthCheckFailureThreshold: tools.PtrTo(int32(5))}, prcParams{},
+				expParams{HealthCheckFailureThreshold: tools.PtrTo(int32(5))}),
+			Entry("app-level readiness healthcheck endpoint only",
+				appParams{ReadinessHealthCheckHTTPEndpoint: tools.PtrTo("/ready")}, prcParams{},
+				expParams{ReadinessHealthCheckHTTPEndpoint: tools.PtrTo("/ready")}),
+			Entry("app-level readiness healthcheck type only",
+				appParams{ReadinessHealthCheckType: tools.PtrTo("http")}, prcParams{},
+				expParams{ReadinessHealthCheckType: tools.PtrTo("http")}),
+			Entry("app-level readiness healthcheck invocation timeout only",
+				appParams{ReadinessHealthCheckInvocationTimeout: tools.PtrTo(int32(3))}, prcParams{},
+				expParams{ReadinessHealthCheckInvocationTimeout: tools.PtrTo(int32(3))}),
+			Entry("app-level readiness healthcheck interval only",
+				appParams{ReadinessHealthCheckInterval: tools.PtrTo(int32(7))}, prcParams{},
+				expParams{ReadinessHealthCheckInterval: tools.PtrTo(int32(7))}),
 			Entry("a combination of fields",
 				appParams{Memory: tools.PtrTo("512M"), DiskQuota: tools.PtrTo("2G")}, prcParams{},
 				expParams{Memory: tools.PtrTo("512M"), DiskQuota: tools.PtrTo("2G")}),
@@ -291,6 +334,22 @@ var _ = Describe("Normalizer", func() {
 				appParams{Timeout: tools.PtrTo(int32(25))},
 				prcParams{Timeout: tools.PtrTo(int32(2))},
 				expParams{Timeout: tools.PtrTo(int32(2))}),
+			Entry("value from proc healthcheck interval used",
+				appParams{HealthCheckInterval: tools.PtrTo(int32(20))},
+				prcParams{HealthCheckInterval: tools.PtrTo(int32(10))},
+				expParams{HealthCheckInterval: tools.PtrTo(int32(10))}),
+			Entry("value from proc healthcheck failure threshold used",
+				appParams{HealthCheckFailureThreshold: tools.PtrTo(int32(5))},
+				prcParams{HealthCheckFailureThreshold: tools.PtrTo(int32(2))},
+				expParams{HealthCheckFailureThreshold: tools.PtrTo(int32(2))}),
+			Entry("value from proc readiness healthcheck type used",
+				appParams{ReadinessHealthCheckType: tools.PtrTo("port")},
+				prcParams{ReadinessHealthCheckType: tools.PtrTo("http")},
+				expParams{ReadinessHealthCheckType: tools.PtrTo("http")}),
+			Entry("value from proc readiness healthcheck interval used",
+				appParams{ReadinessHealthCheckInterval: tools.PtrTo(int32(10))},
+				prcParams{ReadinessHealthCheckInterval: tools.PtrTo(int32(5))},
+				expParams{ReadinessHealthCheckInterval: tools.PtrTo(int32(5))}),
 		)
 	})
 
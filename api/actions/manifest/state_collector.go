@@ -24,6 +24,7 @@ type StateCollector struct {
 	serviceInstanceRepo shared.CFServiceInstanceRepository
 	serviceBindingRepo  shared.CFServiceBindingRepository
 	dropletRepo         shared.CFDropletRepository
+	sidecarRepo         shared.CFSidecarRepository
 }
 
 type AppState struct {
@@ -31,6 +32,7 @@ type AppState struct {
 	Processes       map[string]repositories.ProcessRecord
 	Routes          map[string]repositories.RouteRecord
 	ServiceBindings map[string]repositories.ServiceBindingRecord
+	Sidecars        map[string]repositories.SidecarRecord
 	Droplet         *repositories.DropletRecord
 }
 
@@ -42,6 +44,7 @@ func NewStateCollector(
 	serviceInstanceRepo shared.CFServiceInstanceRepository,
 	serviceBindingRepo shared.CFServiceBindingRepository,
 	dropletRepo shared.CFDropletRepository,
+	sidecarRepo shared.CFSidecarRepository,
 ) StateCollector {
 	return StateCollector{
 		appRepo:             appRepo,
@@ -51,6 +54,7 @@ func NewStateCollector(
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
 		dropletRepo:         dropletRepo,
+		sidecarRepo:         sidecarRepo,
 	}
 }
 
@@ -86,6 +90,11 @@ func (s StateCollector) CollectState(ctx context.Context, authInfo authorization
 		return AppState{}, err
 	}
 
+	sidecarsByName, err := s.indexSidecarsByName(ctx, authInfo, appRecord.GUID)
+	if err != nil {
+		return AppState{}, err
+	}
+
 	dropletRecord, err := s.getDroplet(ctx, authInfo, appRecord.DropletGUID)
 	if err != nil {
 		return AppState{}, err
@@ -96,6 +105,7 @@ func (s StateCollector) CollectState(ctx context.Context, authInfo authorization
 		Processes:       processesByType,
 		Routes:          routesByURL,
 		ServiceBindings: bindingsByServiceName,
+		Sidecars:        sidecarsByName,
 		Droplet:         dropletRecord,
 	}, nil
 }
@@ -170,6 +180,19 @@ func (s StateCollector) indexBindingsByServiceName(ctx context.Context, authInfo
 	})
 }
 
+func (s StateCollector) indexSidecarsByName(ctx context.Context, authInfo authorization.Info, appGUID string) (map[string]repositories.SidecarRecord, error) {
+	sidecars, err := s.sidecarRepo.ListSidecars(ctx, authInfo, repositories.ListSidecarsMessage{
+		AppGUIDs: []string{appGUID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return index(sidecars.Records, func(s repositories.SidecarRecord) string {
+		return s.Name
+	}), nil
+}
+
 func index[T any](records []T, keyFunc func(T) string) map[string]T {
 	recordsIter := slices.Values(records)
 	return maps.Collect(it.Zip(
@@ -25,6 +25,7 @@ var _ = Describe("StateCollector", func() {
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		serviceBindingRepo  *fake.CFServiceBindingRepository
 		dropletRepo         *fake.CFDropletRepository
+		sidecarRepo         *fake.CFSidecarRepository
 		stateCollector      manifest.StateCollector
 		appState            manifest.AppState
 		collectStateErr     error
@@ -38,6 +39,7 @@ var _ = Describe("StateCollector", func() {
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
 		dropletRepo = new(fake.CFDropletRepository)
+		sidecarRepo = new(fake.CFSidecarRepository)
 		stateCollector = manifest.NewStateCollector(
 			appRepo,
 			domainRepo,
@@ -46,6 +48,7 @@ var _ = Describe("StateCollector", func() {
 			serviceInstanceRepo,
 			serviceBindingRepo,
 			dropletRepo,
+			sidecarRepo,
 		)
 	})
 
@@ -258,6 +261,42 @@ var _ = Describe("StateCollector", func() {
 		})
 	})
 
+	Describe("sidecars", func() {
+		BeforeEach(func() {
+			appRepo.ListAppsReturns(repositories.ListResult[repositories.AppRecord]{Records: []repositories.AppRecord{{GUID: "app-guid"}}}, nil)
+			sidecarRepo.ListSidecarsReturns(repositories.ListResult[repositories.SidecarRecord]{
+				Records: []repositories.SidecarRecord{
+					{GUID: "proxy-guid", Name: "proxy"},
+					{GUID: "agent-guid", Name: "agent"},
+				},
+			}, nil)
+		})
+
+		It("lists the app sidecars", func() {
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, _, listMsg := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(listMsg.AppGUIDs).To(ConsistOf("app-guid"))
+		})
+
+		It("constructs the sidecar map using sidecar name", func() {
+			Expect(collectStateErr).NotTo(HaveOccurred())
+			Expect(appState.Sidecars).To(Equal(map[string]repositories.SidecarRecord{
+				"proxy": {GUID: "proxy-guid", Name: "proxy"},
+				"agent": {GUID: "agent-guid", Name: "agent"},
+			}))
+		})
+
+		When("listing the sidecars fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListSidecarsReturns(repositories.ListResult[repositories.SidecarRecord]{}, errors.New("list-sidecars-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(collectStateErr).To(MatchError("list-sidecars-error"))
+			})
+		})
+	})
+
 	Describe("services", func() {
 		var serviceBindings repositories.ListResult[repositories.ServiceBindingRecord]
 
@@ -58,3 +58,10 @@ type CFServiceInstanceRepository interface {
 type CFDropletRepository interface {
 	GetDroplet(context.Context, authorization.Info, string) (repositories.DropletRecord, error)
 }
+
+//counterfeiter:generate -o fake -fake-name CFSidecarRepository . CFSidecarRepository
+type CFSidecarRepository interface {
+	CreateSidecar(context.Context, authorization.Info, repositories.CreateSidecarMessage) (repositories.SidecarRecord, error)
+	ListSidecars(context.Context, authorization.Info, repositories.ListSidecarsMessage) (repositories.ListResult[repositories.SidecarRecord], error)
+	PatchSidecar(context.Context, authorization.Info, repositories.PatchSidecarMessage) (repositories.SidecarRecord, error)
+}
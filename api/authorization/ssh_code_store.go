@@ -0,0 +1,154 @@
+package authorization
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create;delete;list,namespace=ROOT_NAMESPACE
+
+const (
+	SSHCodeTTL = 5 * time.Minute
+
+	SSHCodeLabelKey            = "korifi.cloudfoundry.org/ssh-code"
+	SSHCodeExpiresAtAnnotation = "korifi.cloudfoundry.org/ssh-code-expires-at"
+
+	sshCodeSecretPrefix    = "ssh-code-"
+	sshCodeIdentityNameKey = "name"
+	sshCodeIdentityKindKey = "kind"
+)
+
+var ErrInvalidSSHCode = errors.New("the ssh code is invalid, expired or has already been used")
+
+// SSHCodeStore issues short lived, single use codes that the ssh proxy
+// exchanges for the identity of the user who requested them. Only the identity
+// is kept, never the credentials of the user. It is stored in secrets in the
+// root namespace, named after the hash of the code, so that any api instance
+// can redeem codes issued by another one
+type SSHCodeStore struct {
+	privilegedClient client.Client
+	identityProvider IdentityProvider
+	rootNamespace    string
+	ttl              time.Duration
+}
+
+func NewSSHCodeStore(privilegedClient client.Client, identityProvider IdentityProvider, rootNamespace string, ttl time.Duration) *SSHCodeStore {
+	return &SSHCodeStore{
+		privilegedClient: privilegedClient,
+		identityProvider: identityProvider,
+		rootNamespace:    rootNamespace,
+		ttl:              ttl,
+	}
+}
+
+func (s *SSHCodeStore) Issue(ctx context.Context, info Info) (string, error) {
+	identity, err := s.identityProvider.GetIdentity(ctx, info)
+	if err != nil {
+		return "", fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	if err = s.deleteExpiredCodes(ctx); err != nil {
+		return "", err
+	}
+
+	codeBytes := make([]byte, 32)
+	if _, err := rand.Read(codeBytes); err != nil {
+		return "", fmt.Errorf("failed to generate ssh code: %w", err)
+	}
+	code := base64.RawURLEncoding.EncodeToString(codeBytes)
+
+	err = s.privilegedClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: s.rootNamespace,
+			Name:      sshCodeSecretName(code),
+			Labels: map[string]string{
+				SSHCodeLabelKey: "true",
+			},
+			Annotations: map[string]string{
+				SSHCodeExpiresAtAnnotation: time.Now().Add(s.ttl).UTC().Format(time.RFC3339),
+			},
+		},
+		Data: map[string][]byte{
+			sshCodeIdentityNameKey: []byte(identity.Name),
+			sshCodeIdentityKindKey: []byte(identity.Kind),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store ssh code: %w", err)
+	}
+
+	return code, nil
+}
+
+// Redeem returns the identity the code has been issued for and invalidates
+// the code. Only one of concurrent redeems of the same code succeeds.
+func (s *SSHCodeStore) Redeem(ctx context.Context, code string) (Identity, error) {
+	secret := &corev1.Secret{}
+	err := s.privilegedClient.Get(ctx, client.ObjectKey{Namespace: s.rootNamespace, Name: sshCodeSecretName(code)}, secret)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return Identity{}, apierrors.NewInvalidAuthError(ErrInvalidSSHCode)
+		}
+		return Identity{}, fmt.Errorf("failed to get ssh code: %w", err)
+	}
+
+	err = s.privilegedClient.Delete(ctx, secret, client.Preconditions{UID: &secret.UID})
+	if err != nil {
+		if k8serrors.IsNotFound(err) || k8serrors.IsConflict(err) {
+			return Identity{}, apierrors.NewInvalidAuthError(ErrInvalidSSHCode)
+		}
+		return Identity{}, fmt.Errorf("failed to delete ssh code: %w", err)
+	}
+
+	if isExpired(secret) {
+		return Identity{}, apierrors.NewInvalidAuthError(ErrInvalidSSHCode)
+	}
+
+	return Identity{
+		Name: string(secret.Data[sshCodeIdentityNameKey]),
+		Kind: string(secret.Data[sshCodeIdentityKindKey]),
+	}, nil
+}
+
+func (s *SSHCodeStore) deleteExpiredCodes(ctx context.Context) error {
+	secrets := &corev1.SecretList{}
+	err := s.privilegedClient.List(ctx, secrets, client.InNamespace(s.rootNamespace), client.MatchingLabels{SSHCodeLabelKey: "true"})
+	if err != nil {
+		return fmt.Errorf("failed to list ssh codes: %w", err)
+	}
+
+	for i := range secrets.Items {
+		if !isExpired(&secrets.Items[i]) {
+			continue
+		}
+
+		if err = s.privilegedClient.Delete(ctx, &secrets.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete expired ssh code: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func sshCodeSecretName(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return sshCodeSecretPrefix + hex.EncodeToString(hash[:])
+}
+
+func isExpired(secret *corev1.Secret) bool {
+	expiresAt, err := time.Parse(time.RFC3339, secret.Annotations[SSHCodeExpiresAtAnnotation])
+	return err != nil || time.Now().After(expiresAt)
+}
@@ -0,0 +1,166 @@
+package authorization_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SSHCodeStore", func() {
+	var (
+		ctx              context.Context
+		identityProvider *fake.IdentityProvider
+		rootNamespace    string
+		ttl              time.Duration
+		codeStore        *authorization.SSHCodeStore
+		code             string
+	)
+
+	listCodeSecrets := func() []corev1.Secret {
+		secrets := &corev1.SecretList{}
+		Expect(k8sClient.List(ctx, secrets, client.InNamespace(rootNamespace), client.MatchingLabels{
+			authorization.SSHCodeLabelKey: "true",
+		})).To(Succeed())
+		return secrets.Items
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ttl = time.Minute
+		identityProvider = new(fake.IdentityProvider)
+		identityProvider.GetIdentityReturns(authorization.Identity{Name: "the-user", Kind: "User"}, nil)
+
+		rootNamespace = uuid.NewString()
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: rootNamespace},
+		})).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		codeStore = authorization.NewSSHCodeStore(k8sClient, identityProvider, rootNamespace, ttl)
+
+		var err error
+		code, err = codeStore.Issue(ctx, authorization.Info{Token: "the-token", RawAuthHeader: "Bearer the-token"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("issues an opaque code", func() {
+		Expect(code).NotTo(BeEmpty())
+		Expect(code).NotTo(ContainSubstring("the-token"))
+	})
+
+	It("resolves the identity of the user", func() {
+		Expect(identityProvider.GetIdentityCallCount()).To(Equal(1))
+		_, actualAuthInfo := identityProvider.GetIdentityArgsForCall(0)
+		Expect(actualAuthInfo.Token).To(Equal("the-token"))
+	})
+
+	It("stores the identity in a secret that is not named after the code", func() {
+		secrets := listCodeSecrets()
+		Expect(secrets).To(HaveLen(1))
+		Expect(secrets[0].Name).NotTo(ContainSubstring(code))
+		Expect(secrets[0].Annotations).To(HaveKey(authorization.SSHCodeExpiresAtAnnotation))
+		Expect(secrets[0].Data).To(Equal(map[string][]byte{
+			"name": []byte("the-user"),
+			"kind": []byte("User"),
+		}))
+	})
+
+	It("does not store the credentials of the user", func() {
+		for _, value := range listCodeSecrets()[0].Data {
+			Expect(string(value)).NotTo(ContainSubstring("the-token"))
+		}
+	})
+
+	When("the identity of the user cannot be resolved", func() {
+		It("returns an error", func() {
+			identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("identity-err"))
+			_, err := codeStore.Issue(ctx, authorization.Info{Token: "the-token", RawAuthHeader: "Bearer the-token"})
+			Expect(err).To(MatchError(ContainSubstring("identity-err")))
+		})
+	})
+
+	It("issues a different code every time", func() {
+		anotherCode, err := codeStore.Issue(ctx, authorization.Info{RawAuthHeader: "Bearer the-token"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(anotherCode).NotTo(Equal(code))
+	})
+
+	Describe("Redeem", func() {
+		var (
+			identity  authorization.Identity
+			redeemErr error
+		)
+
+		JustBeforeEach(func() {
+			identity, redeemErr = codeStore.Redeem(ctx, code)
+		})
+
+		It("returns the identity the code has been issued for", func() {
+			Expect(redeemErr).NotTo(HaveOccurred())
+			Expect(identity).To(Equal(authorization.Identity{Name: "the-user", Kind: "User"}))
+		})
+
+		It("deletes the code", func() {
+			Expect(listCodeSecrets()).To(BeEmpty())
+		})
+
+		When("the code is redeemed again", func() {
+			It("returns an error", func() {
+				_, err := codeStore.Redeem(ctx, code)
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.InvalidAuthError{}))
+				Expect(err).To(MatchError(authorization.ErrInvalidSSHCode))
+			})
+		})
+
+		When("the code has expired", func() {
+			BeforeEach(func() {
+				ttl = -time.Second
+			})
+
+			It("returns an error", func() {
+				Expect(redeemErr).To(MatchError(authorization.ErrInvalidSSHCode))
+			})
+
+			It("deletes the code", func() {
+				Expect(listCodeSecrets()).To(BeEmpty())
+			})
+		})
+
+		When("the code has not been issued", func() {
+			JustBeforeEach(func() {
+				identity, redeemErr = codeStore.Redeem(ctx, "not-issued")
+			})
+
+			It("returns an error", func() {
+				Expect(redeemErr).To(MatchError(authorization.ErrInvalidSSHCode))
+			})
+		})
+	})
+
+	When("there are expired codes", func() {
+		BeforeEach(func() {
+			ttl = -time.Second
+		})
+
+		It("deletes them when issuing a new code", func() {
+			Expect(listCodeSecrets()).To(HaveLen(1))
+
+			_, err := authorization.NewSSHCodeStore(k8sClient, identityProvider, rootNamespace, time.Minute).Issue(ctx, authorization.Info{RawAuthHeader: "Bearer the-token"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listCodeSecrets()).To(HaveLen(1))
+		})
+	})
+})
@@ -49,8 +49,9 @@ type (
 		AuthProxyCACert string        `yaml:"authProxyCACert"`
 		LogLevel        zapcore.Level `yaml:"logLevel"`
 
-		Experimental Experimental `yaml:"experimental"`
-		List         List         `yaml:"list"`
+		Experimental Experimental  `yaml:"experimental"`
+		List         List          `yaml:"list"`
+		RouterGroups []RouterGroup `yaml:"routerGroups"`
 	}
 
 	Experimental struct {
@@ -59,6 +60,9 @@ type (
 		ExternalLogCache ExtenalLogCache `yaml:"externalLogCache"`
 		K8SClient        K8SClientConfig `yaml:"k8sClient"`
 		SecurityGroups   SecurityGroups  `yaml:"securityGroups"`
+		LogBuffer        LogBuffer       `yaml:"logBuffer"`
+		MetricsHistory   MetricsHistory  `yaml:"metricsHistory"`
+		SSHProxy         SSHProxy        `yaml:"sshProxy"`
 	}
 
 	ManagedServices struct {
@@ -85,6 +89,33 @@ type (
 		Enabled bool `yaml:"enabled"`
 	}
 
+	// LogBuffer configures the in-cluster store recent app logs are served
+	// from, so that they survive the pods that emitted them
+	LogBuffer struct {
+		Enabled           bool          `yaml:"enabled"`
+		Retention         time.Duration `yaml:"retention"`
+		MaxLogLinesPerApp int           `yaml:"maxLogLinesPerApp"`
+	}
+
+	// MetricsHistory configures the periodic sampling of app container
+	// metrics from metrics-server, which are then served as log-cache gauges
+	MetricsHistory struct {
+		Enabled        bool          `yaml:"enabled"`
+		SampleInterval time.Duration `yaml:"sampleInterval"`
+		Retention      time.Duration `yaml:"retention"`
+	}
+
+	// SSHProxy configures the SSH server that bridges `cf ssh` sessions to
+	// the app instance pods
+	SSHProxy struct {
+		Enabled bool `yaml:"enabled"`
+		Port    int  `yaml:"port"`
+		// The host:port the proxy is reachable on from outside the cluster
+		ExternalAddress string `yaml:"externalAddress"`
+		// The path of the PEM encoded private key the proxy identifies itself with
+		HostKeyPath string `yaml:"hostKeyPath"`
+	}
+
 	RoleLevel string
 
 	Role struct {
@@ -112,6 +143,17 @@ type (
 	List struct {
 		DefaultPageSize int `yaml:"defaultPageSize"`
 	}
+
+	// RouterGroup is a group of gateway ports that TCP routes can be allocated on
+	RouterGroup struct {
+		Name            string    `yaml:"name"`
+		ReservablePorts PortRange `yaml:"reservablePorts"`
+	}
+
+	PortRange struct {
+		From int32 `yaml:"from"`
+		To   int32 `yaml:"to"`
+	}
 )
 
 func LoadFromPath(path string) (*APIConfig, error) {
@@ -157,6 +199,65 @@ func (c *APIConfig) validate() error {
 		return errors.New("BuilderName must have a value")
 	}
 
+	routerGroupNames := map[string]bool{}
+	for i, routerGroup := range c.RouterGroups {
+		if routerGroup.Name == "" {
+			return errors.New("RouterGroups must have a name")
+		}
+
+		if routerGroupNames[routerGroup.Name] {
+			return fmt.Errorf("RouterGroup %q is defined more than once", routerGroup.Name)
+		}
+		routerGroupNames[routerGroup.Name] = true
+
+		ports := routerGroup.ReservablePorts
+		if ports.From < 1 || ports.To > 65535 || ports.From > ports.To {
+			return fmt.Errorf("RouterGroup %q has an invalid port range %d-%d", routerGroup.Name, ports.From, ports.To)
+		}
+
+		// all router groups share the gateway listeners, one per port
+		for _, otherGroup := range c.RouterGroups[:i] {
+			otherPorts := otherGroup.ReservablePorts
+			if ports.From <= otherPorts.To && otherPorts.From <= ports.To {
+				return fmt.Errorf("RouterGroup %q port range %d-%d overlaps with RouterGroup %q", routerGroup.Name, ports.From, ports.To, otherGroup.Name)
+			}
+		}
+	}
+
+	if c.Experimental.LogBuffer.Enabled {
+		if c.Experimental.LogBuffer.Retention <= 0 {
+			return errors.New("LogBuffer retention must be positive")
+		}
+
+		if c.Experimental.LogBuffer.MaxLogLinesPerApp <= 0 {
+			return errors.New("LogBuffer maxLogLinesPerApp must be positive")
+		}
+	}
+
+	if c.Experimental.MetricsHistory.Enabled {
+		if c.Experimental.MetricsHistory.SampleInterval <= 0 {
+			return errors.New("MetricsHistory sampleInterval must be positive")
+		}
+
+		if c.Experimental.MetricsHistory.Retention < c.Experimental.MetricsHistory.SampleInterval {
+			return errors.New("MetricsHistory retention must not be shorter than the sampleInterval")
+		}
+	}
+
+	if c.Experimental.SSHProxy.Enabled {
+		if c.Experimental.SSHProxy.Port < 1 || c.Experimental.SSHProxy.Port > 65535 {
+			return fmt.Errorf("SSHProxy has an invalid port %d", c.Experimental.SSHProxy.Port)
+		}
+
+		if c.Experimental.SSHProxy.ExternalAddress == "" {
+			return errors.New("SSHProxy requires a value for externalAddress")
+		}
+
+		if c.Experimental.SSHProxy.HostKeyPath == "" {
+			return errors.New("SSHProxy requires a value for hostKeyPath")
+		}
+	}
+
 	return nil
 }
 
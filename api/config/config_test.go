@@ -66,6 +66,22 @@ var _ = Describe("Config", func() {
 				"securityGroups": map[string]any{
 					"enabled": true,
 				},
+				"logBuffer": map[string]any{
+					"enabled":           true,
+					"retention":         "1h",
+					"maxLogLinesPerApp": 100,
+				},
+				"metricsHistory": map[string]any{
+					"enabled":        true,
+					"sampleInterval": "30s",
+					"retention":      "1h",
+				},
+				"sshProxy": map[string]any{
+					"enabled":         true,
+					"port":            2222,
+					"externalAddress": "ssh.external:2222",
+					"hostKeyPath":     "/etc/ssh-proxy/host-key",
+				},
 			},
 			"list": map[string]any{
 				"defaultPageSize": 3,
@@ -124,6 +140,22 @@ var _ = Describe("Config", func() {
 			QPS:   1.0,
 			Burst: 2,
 		}))
+		Expect(cfg.Experimental.LogBuffer).To(Equal(config.LogBuffer{
+			Enabled:           true,
+			Retention:         time.Hour,
+			MaxLogLinesPerApp: 100,
+		}))
+		Expect(cfg.Experimental.MetricsHistory).To(Equal(config.MetricsHistory{
+			Enabled:        true,
+			SampleInterval: 30 * time.Second,
+			Retention:      time.Hour,
+		}))
+		Expect(cfg.Experimental.SSHProxy).To(Equal(config.SSHProxy{
+			Enabled:         true,
+			Port:            2222,
+			ExternalAddress: "ssh.external:2222",
+			HostKeyPath:     "/etc/ssh-proxy/host-key",
+		}))
 		Expect(cfg.List.DefaultPageSize).To(Equal(3))
 	})
 
@@ -222,4 +254,202 @@ var _ = Describe("Config", func() {
 			Expect(cfg.ServerURL).To(Equal("https://api.external:1234"))
 		})
 	})
+
+	When("router groups are configured", func() {
+		BeforeEach(func() {
+			configMap["routerGroups"] = []map[string]any{{
+				"name": "default-tcp",
+				"reservablePorts": map[string]any{
+					"from": 1024,
+					"to":   1033,
+				},
+			}}
+		})
+
+		It("populates the router groups", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.RouterGroups).To(ConsistOf(config.RouterGroup{
+				Name:            "default-tcp",
+				ReservablePorts: config.PortRange{From: 1024, To: 1033},
+			}))
+		})
+
+		When("a router group has no name", func() {
+			BeforeEach(func() {
+				configMap["routerGroups"] = []map[string]any{{
+					"reservablePorts": map[string]any{"from": 1024, "to": 1033},
+				}}
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("RouterGroups must have a name"))
+			})
+		})
+
+		When("a router group is defined twice", func() {
+			BeforeEach(func() {
+				configMap["routerGroups"] = []map[string]any{
+					{"name": "default-tcp", "reservablePorts": map[string]any{"from": 1024, "to": 1033}},
+					{"name": "default-tcp", "reservablePorts": map[string]any{"from": 2024, "to": 2033}},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(`RouterGroup "default-tcp" is defined more than once`))
+			})
+		})
+
+		When("the port range is invalid", func() {
+			BeforeEach(func() {
+				configMap["routerGroups"] = []map[string]any{{
+					"name":            "default-tcp",
+					"reservablePorts": map[string]any{"from": 1033, "to": 1024},
+				}}
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(`RouterGroup "default-tcp" has an invalid port range 1033-1024`))
+			})
+		})
+
+		When("the port ranges of router groups overlap", func() {
+			BeforeEach(func() {
+				configMap["routerGroups"] = []map[string]any{
+					{"name": "default-tcp", "reservablePorts": map[string]any{"from": 1024, "to": 1033}},
+					{"name": "other-tcp", "reservablePorts": map[string]any{"from": 1033, "to": 1040}},
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError(`RouterGroup "other-tcp" port range 1033-1040 overlaps with RouterGroup "default-tcp"`))
+			})
+		})
+
+		When("the port ranges of router groups are disjoint", func() {
+			BeforeEach(func() {
+				configMap["routerGroups"] = []map[string]any{
+					{"name": "default-tcp", "reservablePorts": map[string]any{"from": 1024, "to": 1033}},
+					{"name": "other-tcp", "reservablePorts": map[string]any{"from": 1034, "to": 1040}},
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(loadErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	When("the log buffer is enabled", func() {
+		var logBuffer map[string]any
+
+		BeforeEach(func() {
+			logBuffer = configMap["experimental"].(map[string]any)["logBuffer"].(map[string]any)
+		})
+
+		When("the retention is not set", func() {
+			BeforeEach(func() {
+				delete(logBuffer, "retention")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("LogBuffer retention must be positive"))
+			})
+		})
+
+		When("the max log lines per app are not set", func() {
+			BeforeEach(func() {
+				delete(logBuffer, "maxLogLinesPerApp")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("LogBuffer maxLogLinesPerApp must be positive"))
+			})
+		})
+
+		When("the log buffer is disabled", func() {
+			BeforeEach(func() {
+				configMap["experimental"].(map[string]any)["logBuffer"] = map[string]any{"enabled": false}
+			})
+
+			It("does not validate its settings", func() {
+				Expect(loadErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	When("the metrics history is enabled", func() {
+		var metricsHistory map[string]any
+
+		BeforeEach(func() {
+			metricsHistory = configMap["experimental"].(map[string]any)["metricsHistory"].(map[string]any)
+		})
+
+		When("the sample interval is not set", func() {
+			BeforeEach(func() {
+				delete(metricsHistory, "sampleInterval")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("MetricsHistory sampleInterval must be positive"))
+			})
+		})
+
+		When("the retention is shorter than the sample interval", func() {
+			BeforeEach(func() {
+				metricsHistory["retention"] = "10s"
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("MetricsHistory retention must not be shorter than the sampleInterval"))
+			})
+		})
+	})
+
+	When("the ssh proxy is enabled", func() {
+		var sshProxy map[string]any
+
+		BeforeEach(func() {
+			sshProxy = configMap["experimental"].(map[string]any)["sshProxy"].(map[string]any)
+		})
+
+		When("the port is not set", func() {
+			BeforeEach(func() {
+				delete(sshProxy, "port")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("SSHProxy has an invalid port 0"))
+			})
+		})
+
+		When("the external address is not set", func() {
+			BeforeEach(func() {
+				delete(sshProxy, "externalAddress")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("SSHProxy requires a value for externalAddress"))
+			})
+		})
+
+		When("the host key path is not set", func() {
+			BeforeEach(func() {
+				delete(sshProxy, "hostKeyPath")
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("SSHProxy requires a value for hostKeyPath"))
+			})
+		})
+
+		When("the ssh proxy is disabled", func() {
+			BeforeEach(func() {
+				configMap["experimental"].(map[string]any)["sshProxy"] = map[string]any{"enabled": false}
+			})
+
+			It("does not validate its settings", func() {
+				Expect(loadErr).NotTo(HaveOccurred())
+			})
+		})
+	})
 })
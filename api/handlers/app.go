@@ -87,6 +87,8 @@ type App struct {
 	gaugesCollector         GaugesCollector
 	instancesStateCollector InstancesStateCollector
 	appsStateCollector      actions.StateCollector
+	auditEventRecorder      AuditEventRecorder
+	sshEnabled              bool
 }
 
 func NewApp(
@@ -103,6 +105,8 @@ func NewApp(
 	gaugesCollector GaugesCollector,
 	instancesStateCollector InstancesStateCollector,
 	appsStateCollector actions.StateCollector,
+	auditEventRecorder AuditEventRecorder,
+	sshEnabled bool,
 ) *App {
 	return &App{
 		serverURL:               serverURL,
@@ -118,6 +122,8 @@ func NewApp(
 		gaugesCollector:         gaugesCollector,
 		instancesStateCollector: instancesStateCollector,
 		appsStateCollector:      appsStateCollector,
+		auditEventRecorder:      auditEventRecorder,
+		sshEnabled:              sshEnabled,
 	}
 }
 
@@ -175,6 +181,7 @@ func (h *App) create(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create app", "App Name", payload.Name)
 	}
+	h.recordAppEvent(r.Context(), repositories.AuditEventTypeAppCreate, appRecord, nil)
 
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForApp(appRecord, h.serverURL)), nil
 }
@@ -238,6 +245,9 @@ func (h *App) setCurrentDroplet(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error setting current droplet")
 	}
+	h.recordAppEvent(r.Context(), repositories.AuditEventTypeAppDropletMapped, app, map[string]any{
+		"request": map[string]any{"droplet_guid": dropletGUID},
+	})
 
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForCurrentDroplet(currentDroplet, h.serverURL)), nil
 }
@@ -307,6 +317,7 @@ func (h *App) start(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to start app", "AppGUID", appGUID)
 	}
+	h.recordAppEvent(r.Context(), repositories.AuditEventTypeAppStart, app, nil)
 
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
@@ -342,6 +353,7 @@ func (h *App) stop(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to stop app", "AppGUID", appGUID)
 	}
+	h.recordAppEvent(r.Context(), repositories.AuditEventTypeAppStop, app, nil)
 
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
@@ -448,6 +460,9 @@ func (h *App) scaleProcess(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed due to error from Kubernetes", "appGUID", appGUID)
 	}
+	h.recordAppEvent(r.Context(), repositories.AuditEventTypeAppProcessScale, app, map[string]any{
+		"process_type": processType,
+	})
 
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcess(scaledProcessRecord, h.serverURL)), nil
 }
@@ -481,6 +496,7 @@ func (h *App) restart(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to start app", "AppGUID", appGUID)
 	}
+	h.recordAppEvent(r.Context(), repositories.AuditEventTypeAppRestart, app, nil)
 
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
@@ -502,6 +518,7 @@ func (h *App) delete(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete app", "AppGUID", appGUID)
 	}
+	h.recordAppEvent(r.Context(), repositories.AuditEventTypeAppDeleteRequest, app, nil)
 
 	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(appGUID, presenter.AppDeleteOperation, h.serverURL)), nil
 }
@@ -669,36 +686,121 @@ func (h *App) update(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch app", "AppGUID", appGUID)
 	}
+	h.recordAppEvent(r.Context(), repositories.AuditEventTypeAppUpdate, app, nil)
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
 
+func (h *App) recordAppEvent(ctx context.Context, eventType string, app repositories.AppRecord, data map[string]any) {
+	recordAuditEvent(ctx, h.auditEventRecorder, repositories.CreateAuditEventMessage{
+		Type: eventType,
+		Target: repositories.AuditEventTarget{
+			GUID: app.GUID,
+			Type: repositories.AuditEventTargetTypeApp,
+			Name: app.Name,
+		},
+		SpaceGUID: app.SpaceGUID,
+		Data:      data,
+	})
+}
+
 func (h *App) getSSHEnabled(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-ssh-enabled")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	if !h.sshEnabled {
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
+			Enabled: false,
+			Reason:  "Disabled globally",
+		}), nil
+	}
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, app.SpaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space from Kubernetes", "SpaceGUID", app.SpaceGUID)
+	}
+
+	if !space.AllowSSH {
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
+			Enabled: false,
+			Reason:  fmt.Sprintf("Disabled for space %s", space.Name),
+		}), nil
+	}
+
+	if !app.EnableSSH {
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
+			Enabled: false,
+			Reason:  "Disabled for app",
+		}), nil
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
-		Enabled: false,
-		Reason:  "Disabled globally",
+		Enabled: true,
 	}), nil
 }
 
 func (h *App) getAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-feature")
+	appGUID := routing.URLParam(r, "guid")
+
 	featureName := routing.URLParam(r, "name")
 	switch featureName {
 	case "ssh":
-		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
-			"name":        "ssh",
-			"description": "Enable SSHing into the app.",
-			"enabled":     false,
-		}), nil
+		app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+		}
+
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppSSHFeature(app)), nil
 	case "revisions":
 		return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
 			"name":        "revisions",
 			"description": "Enable versioning of an application",
-			"enabled":     false,
+			"enabled":     true,
 		}), nil
 	default:
 		return nil, apierrors.NewNotFoundError(nil, "Feature")
 	}
 }
 
+func (h *App) updateAppFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.update-feature")
+	appGUID := routing.URLParam(r, "guid")
+
+	featureName := routing.URLParam(r, "name")
+	if featureName != "ssh" {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewNotFoundError(nil, "Feature"), "feature cannot be updated", "Feature", featureName)
+	}
+
+	var payload payloads.FeatureUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	app, err = h.appRepo.PatchApp(r.Context(), authInfo, repositories.PatchAppMessage{
+		AppGUID:   app.GUID,
+		SpaceGUID: app.SpaceGUID,
+		EnableSSH: payload.Enabled,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch app", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppSSHFeature(app)), nil
+}
+
 func (h *App) restartInstance(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.restart-instance")
@@ -772,6 +874,7 @@ func (h *App) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: AppEnvPath, Handler: h.getEnvironment},
 		{Method: "GET", Pattern: AppPackagesPath, Handler: h.listPackages},
 		{Method: "GET", Pattern: AppFeaturePath, Handler: h.getAppFeature},
+		{Method: "PATCH", Pattern: AppFeaturePath, Handler: h.updateAppFeature},
 		{Method: "PATCH", Pattern: AppPath, Handler: h.update},
 		{Method: "GET", Pattern: AppSSHEnabledPath, Handler: h.getSSHEnabled},
 		{Method: "DELETE", Pattern: AppInstanceRestartPath, Handler: h.restartInstance},
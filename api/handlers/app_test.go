@@ -46,6 +46,8 @@ var _ = Describe("App", func() {
 		gaugesCollector         *fake.GaugesCollector
 		instancesStateCollector *fake.InstancesStateCollector
 		appsStateCollector      *fake.AppsStateCollector
+		auditEventRecorder      *fake.AuditEventRecorder
+		sshEnabled              bool
 		req                     *http.Request
 
 		appRecord repositories.AppRecord
@@ -64,22 +66,9 @@ var _ = Describe("App", func() {
 		gaugesCollector = new(fake.GaugesCollector)
 		instancesStateCollector = new(fake.InstancesStateCollector)
 		appsStateCollector = new(fake.AppsStateCollector)
+		auditEventRecorder = new(fake.AuditEventRecorder)
 
-		apiHandler := NewApp(
-			*serverURL,
-			appRepo,
-			dropletRepo,
-			processRepo,
-			routeRepo,
-			domainRepo,
-			spaceRepo,
-			packageRepo,
-			requestValidator,
-			podRepo,
-			gaugesCollector,
-			instancesStateCollector,
-			appsStateCollector,
-		)
+		sshEnabled = true
 
 		appRecord = repositories.AppRecord{
 			GUID:        appGUID,
@@ -101,12 +90,31 @@ var _ = Describe("App", func() {
 			Labels: map[string]string{
 				"label-key": "label-value",
 			},
+			EnableSSH: true,
 		}
 		appRepo.GetAppReturns(appRecord, nil)
-		routerBuilder.LoadRoutes(apiHandler)
 	})
 
 	JustBeforeEach(func() {
+		apiHandler := NewApp(
+			*serverURL,
+			appRepo,
+			dropletRepo,
+			processRepo,
+			routeRepo,
+			domainRepo,
+			spaceRepo,
+			packageRepo,
+			requestValidator,
+			podRepo,
+			gaugesCollector,
+			instancesStateCollector,
+			appsStateCollector,
+			auditEventRecorder,
+			sshEnabled,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
@@ -181,6 +189,21 @@ var _ = Describe("App", func() {
 			)))
 		})
 
+		It("records an app create audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.CreateAuditEventMessage{
+				Type: "audit.app.create",
+				Target: repositories.AuditEventTarget{
+					GUID: appGUID,
+					Type: "app",
+					Name: appName,
+				},
+				SpaceGUID: spaceGUID,
+			}))
+		})
+
 		It("sends an AppCreate message with default lifecycle to the repository", func() {
 			Expect(appRepo.CreateAppCallCount()).To(Equal(1))
 			_, actualAuthInfo, actualCreateMessage := appRepo.CreateAppArgsForCall(0)
@@ -836,6 +859,25 @@ var _ = Describe("App", func() {
 			)))
 		})
 
+		It("records an app start audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage.Type).To(Equal("audit.app.start"))
+			Expect(actualMessage.Target.GUID).To(Equal(appGUID))
+			Expect(actualMessage.SpaceGUID).To(Equal(spaceGUID))
+		})
+
+		When("recording the audit event fails", func() {
+			BeforeEach(func() {
+				auditEventRecorder.CreateAuditEventReturns(errors.New("record-error"))
+			})
+
+			It("still starts the app", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			})
+		})
+
 		When("getting the app is forbidden", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
@@ -875,6 +917,10 @@ var _ = Describe("App", func() {
 			It("returns an error", func() {
 				expectUnknownError()
 			})
+
+			It("does not record an audit event", func() {
+				Expect(auditEventRecorder.CreateAuditEventCallCount()).To(BeZero())
+			})
 		})
 	})
 
@@ -1759,6 +1805,13 @@ var _ = Describe("App", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/app.delete~"+appGUID))
 		})
 
+		It("records an app delete-request audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualMessage.Type).To(Equal("audit.app.delete-request"))
+			Expect(actualMessage.Target.GUID).To(Equal(appGUID))
+		})
+
 		When("fetching the app errors", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("boom"))
@@ -2042,16 +2095,95 @@ var _ = Describe("App", func() {
 	Describe("GET /v3/apps/GUID/ssh_enabled", func() {
 		BeforeEach(func() {
 			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/ssh_enabled", nil)
+
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				Name:     "the-space",
+				GUID:     spaceGUID,
+				AllowSSH: true,
+			}, nil)
 		})
 
-		It("returns false", func() {
+		It("returns true", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.enabled", BeFalse()),
-				MatchJSONPath("$.reason", Equal("Disabled globally")),
+				MatchJSONPath("$.enabled", BeTrue()),
+				MatchJSONPath("$.reason", BeEmpty()),
 			)))
 		})
+
+		It("checks the space of the app", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+		})
+
+		When("ssh is disabled globally", func() {
+			BeforeEach(func() {
+				sshEnabled = false
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled globally")),
+				)))
+			})
+		})
+
+		When("ssh is not allowed in the space", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+					Name: "the-space",
+					GUID: spaceGUID,
+				}, nil)
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled for space the-space")),
+				)))
+			})
+		})
+
+		When("ssh is disabled for the app", func() {
+			BeforeEach(func() {
+				appRecord.EnableSSH = false
+				appRepo.GetAppReturns(appRecord, nil)
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.reason", Equal("Disabled for app")),
+				)))
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		When("getting the space fails", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("get-space-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 
 	Describe("GET /v3/apps/GUID/features/NAME", func() {
@@ -2060,28 +2192,49 @@ var _ = Describe("App", func() {
 				req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/features/ssh", nil)
 			})
 
-			It("returns ssh enabled false", func() {
+			It("returns whether ssh is enabled for the app", func() {
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
 					MatchJSONPath("$.name", Equal("ssh")),
 					MatchJSONPath("$.description", Equal("Enable SSHing into the app.")),
-					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.enabled", BeTrue()),
 				)))
 			})
+
+			When("ssh is disabled for the app", func() {
+				BeforeEach(func() {
+					appRecord.EnableSSH = false
+					appRepo.GetAppReturns(appRecord, nil)
+				})
+
+				It("returns ssh enabled false", func() {
+					Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.enabled", BeFalse())))
+				})
+			})
+
+			When("the app is not accessible", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+				})
+
+				It("returns a not found error", func() {
+					expectNotFoundError("App")
+				})
+			})
 		})
 		When("feature revisions is called", func() {
 			BeforeEach(func() {
 				req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/features/revisions", nil)
 			})
 
-			It("returns revisions enabled false", func() {
+			It("returns revisions enabled true", func() {
 				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 				Expect(rr).To(HaveHTTPBody(SatisfyAll(
 					MatchJSONPath("$.name", Equal("revisions")),
 					MatchJSONPath("$.description", Equal("Enable versioning of an application")),
-					MatchJSONPath("$.enabled", BeFalse()),
+					MatchJSONPath("$.enabled", BeTrue()),
 				)))
 			})
 		})
@@ -2103,6 +2256,82 @@ var _ = Describe("App", func() {
 		})
 	})
 
+	Describe("PATCH /v3/apps/GUID/features/NAME", func() {
+		BeforeEach(func() {
+			req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/ssh", strings.NewReader("the-body"))
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.FeatureUpdate{
+				Enabled: tools.PtrTo(false),
+			})
+
+			appRepo.PatchAppReturns(repositories.AppRecord{GUID: appGUID, SpaceGUID: spaceGUID}, nil)
+		})
+
+		It("patches the app", func() {
+			Expect(appRepo.PatchAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := appRepo.PatchAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.PatchAppMessage{
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+				EnableSSH: tools.PtrTo(false),
+			}))
+		})
+
+		It("returns the updated feature", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", Equal("ssh")),
+				MatchJSONPath("$.description", Equal("Enable SSHing into the app.")),
+				MatchJSONPath("$.enabled", BeFalse()),
+			)))
+		})
+
+		When("the feature is not ssh", func() {
+			BeforeEach(func() {
+				req = createHttpRequest("PATCH", "/v3/apps/"+appGUID+"/features/revisions", strings.NewReader("the-body"))
+			})
+
+			It("returns feature not found", func() {
+				expectNotFoundError("Feature")
+				Expect(appRepo.PatchAppCallCount()).To(BeZero())
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "enabled is required"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("enabled is required")
+				Expect(appRepo.PatchAppCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+				Expect(appRepo.PatchAppCallCount()).To(BeZero())
+			})
+		})
+
+		When("patching the app fails", func() {
+			BeforeEach(func() {
+				appRepo.PatchAppReturns(repositories.AppRecord{}, errors.New("patch-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("DELETE /v3/apps/:guid/processes/:process/instances/:instance", func() {
 		BeforeEach(func() {
 			processRepo.ListProcessesReturns(repositories.ListResult[repositories.ProcessRecord]{
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppUsageEventsPath               = "/v3/app_usage_events"
+	AppUsageEventPath                = AppUsageEventsPath + "/{guid}"
+	AppUsageEventsPurgeAndReseedPath = AppUsageEventsPath + "/actions/destructively_purge_all_and_reseed"
+)
+
+//counterfeiter:generate -o fake -fake-name CFAppUsageEventRepository . CFAppUsageEventRepository
+type CFAppUsageEventRepository interface {
+	GetAppUsageEvent(context.Context, authorization.Info, string) (repositories.AppUsageEventRecord, error)
+	ListAppUsageEvents(context.Context, authorization.Info, repositories.ListAppUsageEventsMessage) (repositories.ListResult[repositories.AppUsageEventRecord], error)
+	PurgeAndReseedAppUsageEvents(context.Context, authorization.Info) error
+}
+
+type AppUsageEvent struct {
+	serverURL         url.URL
+	appUsageEventRepo CFAppUsageEventRepository
+	requestValidator  RequestValidator
+}
+
+func NewAppUsageEvent(
+	serverURL url.URL,
+	appUsageEventRepo CFAppUsageEventRepository,
+	requestValidator RequestValidator,
+) *AppUsageEvent {
+	return &AppUsageEvent{
+		serverURL:         serverURL,
+		appUsageEventRepo: appUsageEventRepo,
+		requestValidator:  requestValidator,
+	}
+}
+
+func (h *AppUsageEvent) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-usage-event.get")
+	appUsageEventGUID := routing.URLParam(r, "guid")
+
+	appUsageEvent, err := h.appUsageEventRepo.GetAppUsageEvent(r.Context(), authInfo, appUsageEventGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch app usage event from Kubernetes", "AppUsageEventGUID", appUsageEventGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppUsageEvent(appUsageEvent, h.serverURL)), nil
+}
+
+func (h *AppUsageEvent) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-usage-event.list")
+
+	payload := new(payloads.AppUsageEventList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	appUsageEvents, err := h.appUsageEventRepo.ListAppUsageEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list app usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForAppUsageEvent, appUsageEvents, h.serverURL, *r.URL)), nil
+}
+
+func (h *AppUsageEvent) purgeAndReseed(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-usage-event.purge-and-reseed")
+
+	if err := h.appUsageEventRepo.PurgeAndReseedAppUsageEvents(r.Context(), authInfo); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to purge and reseed app usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK), nil
+}
+
+func (h *AppUsageEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AppUsageEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AppUsageEventsPath, Handler: h.list},
+		{Method: "GET", Pattern: AppUsageEventPath, Handler: h.get},
+		{Method: "POST", Pattern: AppUsageEventsPurgeAndReseedPath, Handler: h.purgeAndReseed},
+	}
+}
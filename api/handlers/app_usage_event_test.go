@@ -0,0 +1,230 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppUsageEvent", func() {
+	var (
+		requestValidator  *fake.RequestValidator
+		appUsageEventRepo *fake.CFAppUsageEventRepository
+		req               *http.Request
+		reqMethod         string
+		reqPath           string
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		appUsageEventRepo = new(fake.CFAppUsageEventRepository)
+
+		apiHandler := NewAppUsageEvent(
+			*serverURL,
+			appUsageEventRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		reqMethod = http.MethodGet
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		req, err = http.NewRequestWithContext(ctx, reqMethod, reqPath, strings.NewReader(""))
+		Expect(err).NotTo(HaveOccurred())
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/app_usage_events/{guid}", func() {
+		BeforeEach(func() {
+			reqPath = "/v3/app_usage_events/app-usage-event-guid"
+
+			appUsageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{
+				GUID:                          "app-usage-event-guid",
+				State:                         "SCALED",
+				PreviousState:                 "STARTED",
+				AppGUID:                       "app-guid",
+				AppName:                       "my-app",
+				ProcessGUID:                   "process-guid",
+				ProcessType:                   "web",
+				SpaceGUID:                     "space-guid",
+				SpaceName:                     "my-space",
+				OrganizationGUID:              "org-guid",
+				InstanceCount:                 3,
+				PreviousInstanceCount:         1,
+				MemoryInMBPerInstance:         256,
+				PreviousMemoryInMBPerInstance: 256,
+				CreatedAt:                     time.UnixMilli(1000),
+			}, nil)
+		})
+
+		It("returns the app usage event", func() {
+			Expect(appUsageEventRepo.GetAppUsageEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := appUsageEventRepo.GetAppUsageEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("app-usage-event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "app-usage-event-guid"),
+				MatchJSONPath("$.state.current", "SCALED"),
+				MatchJSONPath("$.state.previous", "STARTED"),
+				MatchJSONPath("$.app.guid", "app-guid"),
+				MatchJSONPath("$.process.type", "web"),
+				MatchJSONPath("$.space.name", "my-space"),
+				MatchJSONPath("$.organization.guid", "org-guid"),
+				MatchJSONPath("$.instance_count.current", BeEquivalentTo(3)),
+				MatchJSONPath("$.instance_count.previous", BeEquivalentTo(1)),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/app_usage_events/app-usage-event-guid"),
+			)))
+		})
+
+		When("the user is not allowed to get the app usage event", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, apierrors.NewForbiddenError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("the app usage event does not exist", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, apierrors.NewNotFoundError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppUsageEventResourceType)
+			})
+		})
+
+		When("getting the app usage event fails", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, errors.New("get-app-usage-event-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/app_usage_events", func() {
+		BeforeEach(func() {
+			reqPath = "/v3/app_usage_events"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppUsageEventList{
+				GUIDs: "app-usage-event-1,app-usage-event-2",
+				CreatedAts: map[repositories.TimestampOperator]string{
+					repositories.TimestampOperatorGreaterThan: "2024-01-01T00:00:00Z",
+				},
+				Pagination: payloads.Pagination{PerPage: "16", Page: "2"},
+			})
+
+			appUsageEventRepo.ListAppUsageEventsReturns(repositories.ListResult[repositories.AppUsageEventRecord]{
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 2,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     2,
+				},
+				Records: []repositories.AppUsageEventRecord{
+					{GUID: "app-usage-event-1", State: "STARTED"},
+					{GUID: "app-usage-event-2", State: "STOPPED"},
+				},
+			}, nil)
+		})
+
+		It("lists the app usage events", func() {
+			Expect(appUsageEventRepo.ListAppUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := appUsageEventRepo.ListAppUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.ListAppUsageEventsMessage{
+				GUIDs: []string{"app-usage-event-1", "app-usage-event-2"},
+				CreatedAts: []repositories.TimestampFilter{{
+					Operator: repositories.TimestampOperatorGreaterThan,
+					Values:   []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				}},
+				OrderBy:    "created_at",
+				Pagination: repositories.Pagination{PerPage: 16, Page: 2},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "app-usage-event-1"),
+				MatchJSONPath("$.resources[1].guid", "app-usage-event-2"),
+			)))
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the app usage events fails", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.ListAppUsageEventsReturns(repositories.ListResult[repositories.AppUsageEventRecord]{}, errors.New("list-app-usage-events-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/app_usage_events/actions/destructively_purge_all_and_reseed", func() {
+		BeforeEach(func() {
+			reqMethod = http.MethodPost
+			reqPath = "/v3/app_usage_events/actions/destructively_purge_all_and_reseed"
+		})
+
+		It("purges and reseeds the app usage events", func() {
+			Expect(appUsageEventRepo.PurgeAndReseedAppUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo := appUsageEventRepo.PurgeAndReseedAppUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		When("the user is not allowed to purge the app usage events", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.PurgeAndReseedAppUsageEventsReturns(apierrors.NewForbiddenError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("purging the app usage events fails", func() {
+			BeforeEach(func() {
+				appUsageEventRepo.PurgeAndReseedAppUsageEventsReturns(errors.New("purge-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	AuditEventsPath = "/v3/audit_events"
+	AuditEventPath  = AuditEventsPath + "/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFAuditEventRepository . CFAuditEventRepository
+type CFAuditEventRepository interface {
+	GetAuditEvent(context.Context, authorization.Info, string) (repositories.AuditEventRecord, error)
+	ListAuditEvents(context.Context, authorization.Info, repositories.ListAuditEventsMessage) (repositories.ListResult[repositories.AuditEventRecord], error)
+}
+
+//counterfeiter:generate -o fake -fake-name AuditEventRecorder . AuditEventRecorder
+type AuditEventRecorder interface {
+	CreateAuditEvent(context.Context, authorization.Info, repositories.CreateAuditEventMessage) error
+}
+
+// recordAuditEvent records an audit event on behalf of the user that made the
+// request. The audited action has already happened at this point, so failures
+// are logged rather than returned to the client, which would otherwise retry
+// the action.
+func recordAuditEvent(ctx context.Context, recorder AuditEventRecorder, message repositories.CreateAuditEventMessage) {
+	authInfo, _ := authorization.InfoFromContext(ctx)
+	if err := recorder.CreateAuditEvent(ctx, authInfo, message); err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "failed to record audit event", "type", message.Type, "targetGUID", message.Target.GUID)
+	}
+}
+
+type AuditEvent struct {
+	serverURL        url.URL
+	auditEventRepo   CFAuditEventRepository
+	requestValidator RequestValidator
+}
+
+func NewAuditEvent(
+	serverURL url.URL,
+	auditEventRepo CFAuditEventRepository,
+	requestValidator RequestValidator,
+) *AuditEvent {
+	return &AuditEvent{
+		serverURL:        serverURL,
+		auditEventRepo:   auditEventRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *AuditEvent) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.audit-event.get")
+	auditEventGUID := routing.URLParam(r, "guid")
+
+	auditEvent, err := h.auditEventRepo.GetAuditEvent(r.Context(), authInfo, auditEventGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch audit event from Kubernetes", "AuditEventGUID", auditEventGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAuditEvent(auditEvent, h.serverURL)), nil
+}
+
+func (h *AuditEvent) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.audit-event.list")
+
+	payload := new(payloads.AuditEventList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	auditEvents, err := h.auditEventRepo.ListAuditEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list audit events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForAuditEvent, auditEvents, h.serverURL, *r.URL)), nil
+}
+
+func (h *AuditEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AuditEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AuditEventsPath, Handler: h.list},
+		{Method: "GET", Pattern: AuditEventPath, Handler: h.get},
+	}
+}
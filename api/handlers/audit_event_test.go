@@ -0,0 +1,186 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEvent", func() {
+	var (
+		requestValidator *fake.RequestValidator
+		auditEventRepo   *fake.CFAuditEventRepository
+		req              *http.Request
+		reqPath          string
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		auditEventRepo = new(fake.CFAuditEventRepository)
+
+		apiHandler := NewAuditEvent(
+			*serverURL,
+			auditEventRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, reqPath, strings.NewReader(""))
+		Expect(err).NotTo(HaveOccurred())
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/audit_events/{guid}", func() {
+		BeforeEach(func() {
+			reqPath = "/v3/audit_events/audit-event-guid"
+
+			auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{
+				GUID: "audit-event-guid",
+				Type: "audit.app.start",
+				Actor: repositories.AuditEventActor{
+					GUID: "alice",
+					Type: "user",
+					Name: "alice",
+				},
+				Target: repositories.AuditEventTarget{
+					GUID: "app-guid",
+					Type: "app",
+					Name: "my-app",
+				},
+				SpaceGUID:        "space-guid",
+				OrganizationGUID: "org-guid",
+				CreatedAt:        time.UnixMilli(1000),
+			}, nil)
+		})
+
+		It("returns the audit event", func() {
+			Expect(auditEventRepo.GetAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := auditEventRepo.GetAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("audit-event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "audit-event-guid"),
+				MatchJSONPath("$.type", "audit.app.start"),
+				MatchJSONPath("$.actor.name", "alice"),
+				MatchJSONPath("$.target.guid", "app-guid"),
+				MatchJSONPath("$.space.guid", "space-guid"),
+				MatchJSONPath("$.organization.guid", "org-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/audit_events/audit-event-guid"),
+			)))
+		})
+
+		When("the audit event is not accessible", func() {
+			BeforeEach(func() {
+				auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{}, apierrors.NewForbiddenError(nil, repositories.AuditEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AuditEventResourceType)
+			})
+		})
+
+		When("getting the audit event fails", func() {
+			BeforeEach(func() {
+				auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{}, errors.New("get-audit-event-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/audit_events", func() {
+		BeforeEach(func() {
+			reqPath = "/v3/audit_events"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AuditEventList{
+				TargetGUIDs:       "app-guid",
+				Types:             "audit.app.start,audit.app.stop",
+				SpaceGUIDs:        "space-guid",
+				OrganizationGUIDs: "org-guid",
+				CreatedAts: map[repositories.TimestampOperator]string{
+					repositories.TimestampOperatorGreaterThan: "2024-01-01T00:00:00Z",
+				},
+				OrderBy:    "-created_at",
+				Pagination: payloads.Pagination{PerPage: "16", Page: "2"},
+			})
+
+			auditEventRepo.ListAuditEventsReturns(repositories.ListResult[repositories.AuditEventRecord]{
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 2,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     2,
+				},
+				Records: []repositories.AuditEventRecord{
+					{GUID: "audit-event-1", Type: "audit.app.start"},
+					{GUID: "audit-event-2", Type: "audit.app.stop"},
+				},
+			}, nil)
+		})
+
+		It("lists the audit events", func() {
+			Expect(auditEventRepo.ListAuditEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := auditEventRepo.ListAuditEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.ListAuditEventsMessage{
+				TargetGUIDs:       []string{"app-guid"},
+				Types:             []string{"audit.app.start", "audit.app.stop"},
+				SpaceGUIDs:        []string{"space-guid"},
+				OrganizationGUIDs: []string{"org-guid"},
+				CreatedAts: []repositories.TimestampFilter{{
+					Operator: repositories.TimestampOperatorGreaterThan,
+					Values:   []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				}},
+				OrderBy:    "-created_at",
+				Pagination: repositories.Pagination{PerPage: 16, Page: 2},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "audit-event-1"),
+				MatchJSONPath("$.resources[1].guid", "audit-event-2"),
+			)))
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the audit events fails", func() {
+			BeforeEach(func() {
+				auditEventRepo.ListAuditEventsReturns(repositories.ListResult[repositories.AuditEventRecord]{}, errors.New("list-audit-events-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
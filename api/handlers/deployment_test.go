@@ -215,6 +215,109 @@ var _ = Describe("Deployment", func() {
 		})
 	})
 
+	Describe("POST /v3/deployments/{guid}/actions/cancel", func() {
+		BeforeEach(func() {
+			req = createHttpRequest("POST", "/v3/deployments/"+appGUID+"/actions/cancel", nil)
+		})
+
+		It("returns a HTTP 200 OK response", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		It("cancels the deployment", func() {
+			Expect(deploymentsRepo.CancelDeploymentCallCount()).To(Equal(1))
+			_, actualAuthInfo, deploymentGUID := deploymentsRepo.CancelDeploymentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(deploymentGUID).To(Equal(appGUID))
+		})
+
+		When("getting the deployment is forbidden", func() {
+			BeforeEach(func() {
+				deploymentsRepo.GetDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewForbiddenError(nil, repositories.DeploymentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DeploymentResourceType)
+			})
+
+			It("does not cancel the deployment", func() {
+				Expect(deploymentsRepo.CancelDeploymentCallCount()).To(BeZero())
+			})
+		})
+
+		When("the deployment cannot be canceled", func() {
+			BeforeEach(func() {
+				deploymentsRepo.CancelDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, "Cannot cancel a deployment with status: FINALIZED and reason: DEPLOYED."))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot cancel a deployment with status: FINALIZED and reason: DEPLOYED.")
+			})
+		})
+
+		When("canceling the deployment fails", func() {
+			BeforeEach(func() {
+				deploymentsRepo.CancelDeploymentReturns(repositories.DeploymentRecord{}, errors.New("cancel-deployment-error"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/deployments/{guid}/actions/continue", func() {
+		BeforeEach(func() {
+			deploymentsRepo.ContinueDeploymentReturns(repositories.DeploymentRecord{
+				GUID:        appGUID,
+				DropletGUID: dropletGUID,
+				Strategy:    "canary",
+				Status: repositories.DeploymentStatus{
+					Value:  "ACTIVE",
+					Reason: "DEPLOYING",
+				},
+			}, nil)
+			req = createHttpRequest("POST", "/v3/deployments/"+appGUID+"/actions/continue", nil)
+		})
+
+		It("returns the continued deployment", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", appGUID),
+				MatchJSONPath("$.strategy", "canary"),
+				MatchJSONPath("$.status.reason", "DEPLOYING"),
+			)))
+		})
+
+		It("continues the deployment", func() {
+			Expect(deploymentsRepo.ContinueDeploymentCallCount()).To(Equal(1))
+			_, actualAuthInfo, deploymentGUID := deploymentsRepo.ContinueDeploymentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(deploymentGUID).To(Equal(appGUID))
+		})
+
+		When("getting the deployment is forbidden", func() {
+			BeforeEach(func() {
+				deploymentsRepo.GetDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewForbiddenError(nil, repositories.DeploymentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DeploymentResourceType)
+			})
+		})
+
+		When("the deployment is not paused", func() {
+			BeforeEach(func() {
+				deploymentsRepo.ContinueDeploymentReturns(repositories.DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, "Cannot continue a deployment with status: ACTIVE and reason: DEPLOYING."))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot continue a deployment with status: ACTIVE and reason: DEPLOYING.")
+			})
+		})
+	})
+
 	Describe("GET /v3/deployments", func() {
 		BeforeEach(func() {
 			deploymentsRepo.ListDeploymentsReturns(repositories.ListResult[repositories.DeploymentRecord]{
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -17,8 +19,12 @@ import (
 )
 
 const (
-	DomainsPath = "/v3/domains"
-	DomainPath  = "/v3/domains/{guid}"
+	DomainsPath                     = "/v3/domains"
+	DomainPath                      = "/v3/domains/{guid}"
+	DomainSharedOrganizationsPath   = "/v3/domains/{guid}/relationships/shared_organizations"
+	DomainSharedOrganizationPath    = "/v3/domains/{guid}/relationships/shared_organizations/{org_guid}"
+	domainNotPrivateErr             = "Domains can not be shared with other organizations unless they are scoped to an organization."
+	domainSharedWithOwningOrgErrFmt = "Domain '%s' is already owned by organization '%s'."
 )
 
 //counterfeiter:generate -o fake -fake-name CFDomainRepository . CFDomainRepository
@@ -29,23 +35,31 @@ type CFDomainRepository interface {
 	UpdateDomain(context.Context, authorization.Info, repositories.UpdateDomainMessage) (repositories.DomainRecord, error)
 	ListDomains(context.Context, authorization.Info, repositories.ListDomainsMessage) (repositories.ListResult[repositories.DomainRecord], error)
 	DeleteDomain(context.Context, authorization.Info, string) error
+	ShareDomain(context.Context, authorization.Info, repositories.ShareDomainMessage) (repositories.DomainRecord, error)
+	UnshareDomain(context.Context, authorization.Info, repositories.UnshareDomainMessage) (repositories.DomainRecord, error)
 }
 
 type Domain struct {
 	serverURL        url.URL
 	requestValidator RequestValidator
 	domainRepo       CFDomainRepository
+	routerGroupRepo  RouterGroupRepository
+	orgRepo          CFOrgRepository
 }
 
 func NewDomain(
 	serverURL url.URL,
 	requestValidator RequestValidator,
 	domainRepo CFDomainRepository,
+	routerGroupRepo RouterGroupRepository,
+	orgRepo CFOrgRepository,
 ) *Domain {
 	return &Domain{
 		serverURL:        serverURL,
 		requestValidator: requestValidator,
 		domainRepo:       domainRepo,
+		routerGroupRepo:  routerGroupRepo,
+		orgRepo:          orgRepo,
 	}
 }
 
@@ -64,6 +78,38 @@ func (h *Domain) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierr, apierr.Detail())
 	}
 
+	if domainCreateMessage.RouterGroup != "" {
+		_, err = h.routerGroupRepo.GetRouterGroup(r.Context(), authInfo, domainCreateMessage.RouterGroup)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("Router group with guid '%s' not found.", domainCreateMessage.RouterGroup)),
+				"Failed to get router group",
+				"routerGroupGUID", domainCreateMessage.RouterGroup,
+			)
+		}
+	}
+
+	if domainCreateMessage.OrgGUID != "" {
+		_, err = h.orgRepo.GetOrg(r.Context(), authInfo, domainCreateMessage.OrgGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(apierrors.ForbiddenAsNotFound(err), orgNotFoundErr, apierrors.NotFoundError{}),
+				"Failed to get org",
+				"orgGUID", domainCreateMessage.OrgGUID,
+			)
+		}
+
+		if err = ensureNotOwningOrg(domainCreateMessage.Name, domainCreateMessage.OrgGUID, domainCreateMessage.SharedOrgGUIDs); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "domain cannot be shared with its owning org")
+		}
+
+		if err = h.ensureOrgsExist(r.Context(), authInfo, domainCreateMessage.SharedOrgGUIDs); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to find shared orgs for domain")
+		}
+	}
+
 	domain, err := h.domainRepo.CreateDomain(r.Context(), authInfo, domainCreateMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating domain in repository")
@@ -144,6 +190,122 @@ func (h *Domain) delete(r *http.Request) (*routing.Response, error) {
 	), nil
 }
 
+func (h *Domain) shareWithOrgs(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.domain.share-with-orgs")
+
+	domainGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.DomainShare)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	domain, err := h.domainRepo.GetDomain(r.Context(), authInfo, domainGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting domain in repository")
+	}
+
+	if domain.OrgGUID == "" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(fmt.Errorf("domain %q is not private", domainGUID), domainNotPrivateErr),
+			"Cannot share a shared domain", "domainGUID", domainGUID,
+		)
+	}
+
+	message := payload.ToMessage(domainGUID)
+	if err = ensureNotOwningOrg(domain.Name, domain.OrgGUID, message.OrgGUIDs); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "domain cannot be shared with its owning org")
+	}
+
+	if err = h.ensureOrgsExist(r.Context(), authInfo, message.OrgGUIDs); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to find orgs to share domain with")
+	}
+
+	domain, err = h.domainRepo.ShareDomain(r.Context(), authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to share domain", "domainGUID", domainGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDomainSharedOrganizations(domain)), nil
+}
+
+func (h *Domain) unshareWithOrg(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.domain.unshare-with-org")
+
+	domainGUID := routing.URLParam(r, "guid")
+	orgGUID := routing.URLParam(r, "org_guid")
+
+	domain, err := h.domainRepo.GetDomain(r.Context(), authInfo, domainGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting domain in repository")
+	}
+
+	if domain.OrgGUID == orgGUID {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(
+				fmt.Errorf("org %q owns domain %q", orgGUID, domainGUID),
+				"Unable to unshare domain from owning organization. Try deleting the domain instead.",
+			),
+			"Cannot unshare a domain from its owning org", "domainGUID", domainGUID, "orgGUID", orgGUID,
+		)
+	}
+
+	if !slices.Contains(domain.SharedOrgGUIDs, orgGUID) {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(
+				fmt.Errorf("domain %q is not shared with org %q", domainGUID, orgGUID),
+				fmt.Sprintf("Unable to unshare domain from organization with guid '%s'. Ensure the domain is shared to this organization.", orgGUID),
+			),
+			"Domain not shared with org", "domainGUID", domainGUID, "orgGUID", orgGUID,
+		)
+	}
+
+	_, err = h.domainRepo.UnshareDomain(r.Context(), authInfo, repositories.UnshareDomainMessage{
+		DomainGUID: domainGUID,
+		OrgGUID:    orgGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to unshare domain", "domainGUID", domainGUID, "orgGUID", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func ensureNotOwningOrg(domainName, owningOrgGUID string, orgGUIDs []string) error {
+	if slices.Contains(orgGUIDs, owningOrgGUID) {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("domain %q is owned by org %q", domainName, owningOrgGUID),
+			fmt.Sprintf(domainSharedWithOwningOrgErrFmt, domainName, owningOrgGUID),
+		)
+	}
+
+	return nil
+}
+
+func (h *Domain) ensureOrgsExist(ctx context.Context, authInfo authorization.Info, orgGUIDs []string) error {
+	if len(orgGUIDs) == 0 {
+		return nil
+	}
+
+	orgs, err := h.orgRepo.ListOrgs(ctx, authInfo, repositories.ListOrgsMessage{GUIDs: orgGUIDs})
+	if err != nil {
+		return err
+	}
+
+	for _, orgGUID := range orgGUIDs {
+		if !slices.ContainsFunc(orgs.Records, func(o repositories.OrgRecord) bool { return o.GUID == orgGUID }) {
+			return apierrors.NewUnprocessableEntityError(fmt.Errorf("org %q not found", orgGUID), orgNotFoundErr)
+		}
+	}
+
+	return nil
+}
+
 func (h *Domain) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -155,5 +317,7 @@ func (h *Domain) AuthenticatedRoutes() []routing.Route {
 		{Method: "PATCH", Pattern: DomainPath, Handler: h.update},
 		{Method: "GET", Pattern: DomainsPath, Handler: h.list},
 		{Method: "DELETE", Pattern: DomainPath, Handler: h.delete},
+		{Method: "POST", Pattern: DomainSharedOrganizationsPath, Handler: h.shareWithOrgs},
+		{Method: "DELETE", Pattern: DomainSharedOrganizationPath, Handler: h.unshareWithOrg},
 	}
 }
@@ -21,6 +21,8 @@ var _ = Describe("Domain", func() {
 	var (
 		apiHandler       *handlers.Domain
 		domainRepo       *fake.CFDomainRepository
+		routerGroupRepo  *fake.RouterGroupRepository
+		orgRepo          *fake.CFOrgRepository
 		requestValidator *fake.RequestValidator
 		req              *http.Request
 	)
@@ -28,10 +30,14 @@ var _ = Describe("Domain", func() {
 	BeforeEach(func() {
 		requestValidator = new(fake.RequestValidator)
 		domainRepo = new(fake.CFDomainRepository)
+		routerGroupRepo = new(fake.RouterGroupRepository)
+		orgRepo = new(fake.CFOrgRepository)
 		apiHandler = handlers.NewDomain(
 			*serverURL,
 			requestValidator,
 			domainRepo,
+			routerGroupRepo,
+			orgRepo,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -94,6 +100,112 @@ var _ = Describe("Domain", func() {
 				MatchJSONPath("$.supported_protocols", ConsistOf("http")),
 				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/domains/domain-guid"),
 			)))
+
+			Expect(routerGroupRepo.GetRouterGroupCallCount()).To(BeZero())
+		})
+
+		When("the domain has a router group", func() {
+			BeforeEach(func() {
+				payload.RouterGroup = &payloads.DomainRouterGroup{GUID: "default-tcp"}
+				domainRepo.CreateDomainReturns(repositories.DomainRecord{
+					Name:        "my.domain",
+					GUID:        "domain-guid",
+					RouterGroup: "default-tcp",
+				}, nil)
+			})
+
+			It("creates a tcp domain", func() {
+				Expect(routerGroupRepo.GetRouterGroupCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualRouterGroupGUID := routerGroupRepo.GetRouterGroupArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualRouterGroupGUID).To(Equal("default-tcp"))
+
+				Expect(domainRepo.CreateDomainCallCount()).To(Equal(1))
+				_, _, createMessage := domainRepo.CreateDomainArgsForCall(0)
+				Expect(createMessage.RouterGroup).To(Equal("default-tcp"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.router_group.guid", "default-tcp"),
+					MatchJSONPath("$.supported_protocols", ConsistOf("tcp")),
+				)))
+			})
+
+			When("the router group does not exist", func() {
+				BeforeEach(func() {
+					routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.RouterGroupResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Router group with guid 'default-tcp' not found.")
+					Expect(domainRepo.CreateDomainCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		When("the domain is owned by an org", func() {
+			BeforeEach(func() {
+				payload.Relationships = &payloads.DomainRelationships{
+					Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+					SharedOrganizations: &payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "shared-org-guid"}},
+					},
+				}
+				orgRepo.ListOrgsReturns(repositories.ListResult[repositories.OrgRecord]{
+					Records: []repositories.OrgRecord{{GUID: "shared-org-guid"}},
+				}, nil)
+			})
+
+			It("creates a private domain", func() {
+				Expect(orgRepo.GetOrgCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualOrgGUID := orgRepo.GetOrgArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualOrgGUID).To(Equal("org-guid"))
+
+				Expect(orgRepo.ListOrgsCallCount()).To(Equal(1))
+				_, _, listMessage := orgRepo.ListOrgsArgsForCall(0)
+				Expect(listMessage.GUIDs).To(ConsistOf("shared-org-guid"))
+
+				Expect(domainRepo.CreateDomainCallCount()).To(Equal(1))
+				_, _, createMessage := domainRepo.CreateDomainArgsForCall(0)
+				Expect(createMessage.OrgGUID).To(Equal("org-guid"))
+				Expect(createMessage.SharedOrgGUIDs).To(ConsistOf("shared-org-guid"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+
+			When("the owning org does not exist", func() {
+				BeforeEach(func() {
+					orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Organization does not exist, or you do not have access.")
+					Expect(domainRepo.CreateDomainCallCount()).To(BeZero())
+				})
+			})
+
+			When("a shared org does not exist", func() {
+				BeforeEach(func() {
+					orgRepo.ListOrgsReturns(repositories.ListResult[repositories.OrgRecord]{}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Organization does not exist, or you do not have access.")
+					Expect(domainRepo.CreateDomainCallCount()).To(BeZero())
+				})
+			})
+
+			When("the domain is shared with its owning org", func() {
+				BeforeEach(func() {
+					payload.Relationships.SharedOrganizations.Data = []payloads.RelationshipData{{GUID: "org-guid"}}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Domain 'my.domain' is already owned by organization 'org-guid'.")
+					Expect(domainRepo.CreateDomainCallCount()).To(BeZero())
+				})
+			})
 		})
 
 		When("decoding the payload fails", func() {
@@ -109,10 +221,11 @@ var _ = Describe("Domain", func() {
 		When("the decoded payload is not valid", func() {
 			BeforeEach(func() {
 				payload.Internal = true
+				payload.RouterGroup = &payloads.DomainRouterGroup{GUID: "default-tcp"}
 			})
 
 			It("returns an error", func() {
-				expectUnprocessableEntityError("Error converting domain payload to repository message: internal domains are not supported")
+				expectUnprocessableEntityError("Error converting domain payload to repository message: internal domains cannot have a router group")
 			})
 		})
 
@@ -387,4 +500,183 @@ var _ = Describe("Domain", func() {
 			})
 		})
 	})
+
+	Describe("POST /v3/domains/:guid/relationships/shared_organizations", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DomainShare{
+				Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+			})
+
+			domainRepo.GetDomainReturns(repositories.DomainRecord{
+				Name:    "my.domain",
+				GUID:    "domain-guid",
+				OrgGUID: "owner-org-guid",
+			}, nil)
+			orgRepo.ListOrgsReturns(repositories.ListResult[repositories.OrgRecord]{
+				Records: []repositories.OrgRecord{{GUID: "org-1"}, {GUID: "org-2"}},
+			}, nil)
+			domainRepo.ShareDomainReturns(repositories.DomainRecord{
+				GUID:           "domain-guid",
+				OrgGUID:        "owner-org-guid",
+				SharedOrgGUIDs: []string{"org-1", "org-2"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/domains/domain-guid/relationships/shared_organizations", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("shares the domain with the orgs", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(domainRepo.ShareDomainCallCount()).To(Equal(1))
+			_, actualAuthInfo, shareMessage := domainRepo.ShareDomainArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(shareMessage).To(Equal(repositories.ShareDomainMessage{
+				DomainGUID: "domain-guid",
+				OrgGUIDs:   []string{"org-1", "org-2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data[*].guid", ConsistOf("org-1", "org-2"))))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the domain is not found", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{}, apierrors.NewForbiddenError(nil, repositories.DomainResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DomainResourceType)
+			})
+		})
+
+		When("the domain is not owned by an org", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{GUID: "domain-guid"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Domains can not be shared with other organizations unless they are scoped to an organization.")
+				Expect(domainRepo.ShareDomainCallCount()).To(BeZero())
+			})
+		})
+
+		When("the domain is shared with its owning org", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DomainShare{
+					Data: []payloads.RelationshipData{{GUID: "owner-org-guid"}},
+				})
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Domain 'my.domain' is already owned by organization 'owner-org-guid'.")
+				Expect(domainRepo.ShareDomainCallCount()).To(BeZero())
+			})
+		})
+
+		When("an org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.ListOrgsReturns(repositories.ListResult[repositories.OrgRecord]{
+					Records: []repositories.OrgRecord{{GUID: "org-1"}},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Organization does not exist, or you do not have access.")
+				Expect(domainRepo.ShareDomainCallCount()).To(BeZero())
+			})
+		})
+
+		When("sharing the domain fails", func() {
+			BeforeEach(func() {
+				domainRepo.ShareDomainReturns(repositories.DomainRecord{}, errors.New("share-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/domains/:guid/relationships/shared_organizations/:org_guid", func() {
+		BeforeEach(func() {
+			domainRepo.GetDomainReturns(repositories.DomainRecord{
+				GUID:           "domain-guid",
+				OrgGUID:        "owner-org-guid",
+				SharedOrgGUIDs: []string{"org-guid"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/domains/domain-guid/relationships/shared_organizations/org-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("unshares the domain from the org", func() {
+			Expect(domainRepo.UnshareDomainCallCount()).To(Equal(1))
+			_, actualAuthInfo, unshareMessage := domainRepo.UnshareDomainArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(unshareMessage).To(Equal(repositories.UnshareDomainMessage{
+				DomainGUID: "domain-guid",
+				OrgGUID:    "org-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the domain is not found", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{}, apierrors.NewForbiddenError(nil, repositories.DomainResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DomainResourceType)
+			})
+		})
+
+		When("the org owns the domain", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{GUID: "domain-guid", OrgGUID: "org-guid"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to unshare domain from owning organization. Try deleting the domain instead.")
+				Expect(domainRepo.UnshareDomainCallCount()).To(BeZero())
+			})
+		})
+
+		When("the domain is not shared with the org", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{GUID: "domain-guid", OrgGUID: "owner-org-guid"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to unshare domain from organization with guid 'org-guid'. Ensure the domain is shared to this organization.")
+				Expect(domainRepo.UnshareDomainCallCount()).To(BeZero())
+			})
+		})
+
+		When("unsharing the domain fails", func() {
+			BeforeEach(func() {
+				domainRepo.UnshareDomainReturns(repositories.DomainRecord{}, errors.New("unshare-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -0,0 +1,116 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type AuditEventRecorder struct {
+	CreateAuditEventStub        func(context.Context, authorization.Info, repositories.CreateAuditEventMessage) error
+	createAuditEventMutex       sync.RWMutex
+	createAuditEventArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateAuditEventMessage
+	}
+	createAuditEventReturns struct {
+		result1 error
+	}
+	createAuditEventReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *AuditEventRecorder) CreateAuditEvent(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateAuditEventMessage) error {
+	fake.createAuditEventMutex.Lock()
+	ret, specificReturn := fake.createAuditEventReturnsOnCall[len(fake.createAuditEventArgsForCall)]
+	fake.createAuditEventArgsForCall = append(fake.createAuditEventArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateAuditEventMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateAuditEventStub
+	fakeReturns := fake.createAuditEventReturns
+	fake.recordInvocation("CreateAuditEvent", []interface{}{arg1, arg2, arg3})
+	fake.createAuditEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *AuditEventRecorder) CreateAuditEventCallCount() int {
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	return len(fake.createAuditEventArgsForCall)
+}
+
+func (fake *AuditEventRecorder) CreateAuditEventCalls(stub func(context.Context, authorization.Info, repositories.CreateAuditEventMessage) error) {
+	fake.createAuditEventMutex.Lock()
+	defer fake.createAuditEventMutex.Unlock()
+	fake.CreateAuditEventStub = stub
+}
+
+func (fake *AuditEventRecorder) CreateAuditEventArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateAuditEventMessage) {
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	argsForCall := fake.createAuditEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *AuditEventRecorder) CreateAuditEventReturns(result1 error) {
+	fake.createAuditEventMutex.Lock()
+	defer fake.createAuditEventMutex.Unlock()
+	fake.CreateAuditEventStub = nil
+	fake.createAuditEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *AuditEventRecorder) CreateAuditEventReturnsOnCall(i int, result1 error) {
+	fake.createAuditEventMutex.Lock()
+	defer fake.createAuditEventMutex.Unlock()
+	fake.CreateAuditEventStub = nil
+	if fake.createAuditEventReturnsOnCall == nil {
+		fake.createAuditEventReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createAuditEventReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *AuditEventRecorder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *AuditEventRecorder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.AuditEventRecorder = new(AuditEventRecorder)
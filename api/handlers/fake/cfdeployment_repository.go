@@ -11,6 +11,36 @@ import (
 )
 
 type CFDeploymentRepository struct {
+	CancelDeploymentStub        func(context.Context, authorization.Info, string) (repositories.DeploymentRecord, error)
+	cancelDeploymentMutex       sync.RWMutex
+	cancelDeploymentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	cancelDeploymentReturns struct {
+		result1 repositories.DeploymentRecord
+		result2 error
+	}
+	cancelDeploymentReturnsOnCall map[int]struct {
+		result1 repositories.DeploymentRecord
+		result2 error
+	}
+	ContinueDeploymentStub        func(context.Context, authorization.Info, string) (repositories.DeploymentRecord, error)
+	continueDeploymentMutex       sync.RWMutex
+	continueDeploymentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	continueDeploymentReturns struct {
+		result1 repositories.DeploymentRecord
+		result2 error
+	}
+	continueDeploymentReturnsOnCall map[int]struct {
+		result1 repositories.DeploymentRecord
+		result2 error
+	}
 	CreateDeploymentStub        func(context.Context, authorization.Info, repositories.CreateDeploymentMessage) (repositories.DeploymentRecord, error)
 	createDeploymentMutex       sync.RWMutex
 	createDeploymentArgsForCall []struct {
@@ -60,6 +90,138 @@ type CFDeploymentRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *CFDeploymentRepository) CancelDeployment(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.DeploymentRecord, error) {
+	fake.cancelDeploymentMutex.Lock()
+	ret, specificReturn := fake.cancelDeploymentReturnsOnCall[len(fake.cancelDeploymentArgsForCall)]
+	fake.cancelDeploymentArgsForCall = append(fake.cancelDeploymentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CancelDeploymentStub
+	fakeReturns := fake.cancelDeploymentReturns
+	fake.recordInvocation("CancelDeployment", []interface{}{arg1, arg2, arg3})
+	fake.cancelDeploymentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFDeploymentRepository) CancelDeploymentCallCount() int {
+	fake.cancelDeploymentMutex.RLock()
+	defer fake.cancelDeploymentMutex.RUnlock()
+	return len(fake.cancelDeploymentArgsForCall)
+}
+
+func (fake *CFDeploymentRepository) CancelDeploymentCalls(stub func(context.Context, authorization.Info, string) (repositories.DeploymentRecord, error)) {
+	fake.cancelDeploymentMutex.Lock()
+	defer fake.cancelDeploymentMutex.Unlock()
+	fake.CancelDeploymentStub = stub
+}
+
+func (fake *CFDeploymentRepository) CancelDeploymentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.cancelDeploymentMutex.RLock()
+	defer fake.cancelDeploymentMutex.RUnlock()
+	argsForCall := fake.cancelDeploymentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDeploymentRepository) CancelDeploymentReturns(result1 repositories.DeploymentRecord, result2 error) {
+	fake.cancelDeploymentMutex.Lock()
+	defer fake.cancelDeploymentMutex.Unlock()
+	fake.CancelDeploymentStub = nil
+	fake.cancelDeploymentReturns = struct {
+		result1 repositories.DeploymentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDeploymentRepository) CancelDeploymentReturnsOnCall(i int, result1 repositories.DeploymentRecord, result2 error) {
+	fake.cancelDeploymentMutex.Lock()
+	defer fake.cancelDeploymentMutex.Unlock()
+	fake.CancelDeploymentStub = nil
+	if fake.cancelDeploymentReturnsOnCall == nil {
+		fake.cancelDeploymentReturnsOnCall = make(map[int]struct {
+			result1 repositories.DeploymentRecord
+			result2 error
+		})
+	}
+	fake.cancelDeploymentReturnsOnCall[i] = struct {
+		result1 repositories.DeploymentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDeploymentRepository) ContinueDeployment(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.DeploymentRecord, error) {
+	fake.continueDeploymentMutex.Lock()
+	ret, specificReturn := fake.continueDeploymentReturnsOnCall[len(fake.continueDeploymentArgsForCall)]
+	fake.continueDeploymentArgsForCall = append(fake.continueDeploymentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ContinueDeploymentStub
+	fakeReturns := fake.continueDeploymentReturns
+	fake.recordInvocation("ContinueDeployment", []interface{}{arg1, arg2, arg3})
+	fake.continueDeploymentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFDeploymentRepository) ContinueDeploymentCallCount() int {
+	fake.continueDeploymentMutex.RLock()
+	defer fake.continueDeploymentMutex.RUnlock()
+	return len(fake.continueDeploymentArgsForCall)
+}
+
+func (fake *CFDeploymentRepository) ContinueDeploymentCalls(stub func(context.Context, authorization.Info, string) (repositories.DeploymentRecord, error)) {
+	fake.continueDeploymentMutex.Lock()
+	defer fake.continueDeploymentMutex.Unlock()
+	fake.ContinueDeploymentStub = stub
+}
+
+func (fake *CFDeploymentRepository) ContinueDeploymentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.continueDeploymentMutex.RLock()
+	defer fake.continueDeploymentMutex.RUnlock()
+	argsForCall := fake.continueDeploymentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDeploymentRepository) ContinueDeploymentReturns(result1 repositories.DeploymentRecord, result2 error) {
+	fake.continueDeploymentMutex.Lock()
+	defer fake.continueDeploymentMutex.Unlock()
+	fake.ContinueDeploymentStub = nil
+	fake.continueDeploymentReturns = struct {
+		result1 repositories.DeploymentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDeploymentRepository) ContinueDeploymentReturnsOnCall(i int, result1 repositories.DeploymentRecord, result2 error) {
+	fake.continueDeploymentMutex.Lock()
+	defer fake.continueDeploymentMutex.Unlock()
+	fake.ContinueDeploymentStub = nil
+	if fake.continueDeploymentReturnsOnCall == nil {
+		fake.continueDeploymentReturnsOnCall = make(map[int]struct {
+			result1 repositories.DeploymentRecord
+			result2 error
+		})
+	}
+	fake.continueDeploymentReturnsOnCall[i] = struct {
+		result1 repositories.DeploymentRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFDeploymentRepository) CreateDeployment(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateDeploymentMessage) (repositories.DeploymentRecord, error) {
 	fake.createDeploymentMutex.Lock()
 	ret, specificReturn := fake.createDeploymentReturnsOnCall[len(fake.createDeploymentArgsForCall)]
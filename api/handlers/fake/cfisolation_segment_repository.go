@@ -0,0 +1,516 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFIsolationSegmentRepository struct {
+	CreateIsolationSegmentStub        func(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	createIsolationSegmentMutex       sync.RWMutex
+	createIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateIsolationSegmentMessage
+	}
+	createIsolationSegmentReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	createIsolationSegmentReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	DeleteIsolationSegmentStub        func(context.Context, authorization.Info, string) error
+	deleteIsolationSegmentMutex       sync.RWMutex
+	deleteIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	deleteIsolationSegmentReturns struct {
+		result1 error
+	}
+	deleteIsolationSegmentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EntitleOrgsStub        func(context.Context, authorization.Info, repositories.EntitleIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	entitleOrgsMutex       sync.RWMutex
+	entitleOrgsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.EntitleIsolationSegmentMessage
+	}
+	entitleOrgsReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	entitleOrgsReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	GetIsolationSegmentStub        func(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)
+	getIsolationSegmentMutex       sync.RWMutex
+	getIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getIsolationSegmentReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	getIsolationSegmentReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	ListIsolationSegmentsStub        func(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) (repositories.ListResult[repositories.IsolationSegmentRecord], error)
+	listIsolationSegmentsMutex       sync.RWMutex
+	listIsolationSegmentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListIsolationSegmentsMessage
+	}
+	listIsolationSegmentsReturns struct {
+		result1 repositories.ListResult[repositories.IsolationSegmentRecord]
+		result2 error
+	}
+	listIsolationSegmentsReturnsOnCall map[int]struct {
+		result1 repositories.ListResult[repositories.IsolationSegmentRecord]
+		result2 error
+	}
+	RevokeOrgStub        func(context.Context, authorization.Info, repositories.RevokeIsolationSegmentMessage) error
+	revokeOrgMutex       sync.RWMutex
+	revokeOrgArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.RevokeIsolationSegmentMessage
+	}
+	revokeOrgReturns struct {
+		result1 error
+	}
+	revokeOrgReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error) {
+	fake.createIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.createIsolationSegmentReturnsOnCall[len(fake.createIsolationSegmentArgsForCall)]
+	fake.createIsolationSegmentArgsForCall = append(fake.createIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateIsolationSegmentStub
+	fakeReturns := fake.createIsolationSegmentReturns
+	fake.recordInvocation("CreateIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.createIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentCallCount() int {
+	fake.createIsolationSegmentMutex.RLock()
+	defer fake.createIsolationSegmentMutex.RUnlock()
+	return len(fake.createIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentCalls(stub func(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)) {
+	fake.createIsolationSegmentMutex.Lock()
+	defer fake.createIsolationSegmentMutex.Unlock()
+	fake.CreateIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) {
+	fake.createIsolationSegmentMutex.RLock()
+	defer fake.createIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.createIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.createIsolationSegmentMutex.Lock()
+	defer fake.createIsolationSegmentMutex.Unlock()
+	fake.CreateIsolationSegmentStub = nil
+	fake.createIsolationSegmentReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.createIsolationSegmentMutex.Lock()
+	defer fake.createIsolationSegmentMutex.Unlock()
+	fake.CreateIsolationSegmentStub = nil
+	if fake.createIsolationSegmentReturnsOnCall == nil {
+		fake.createIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.createIsolationSegmentReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.deleteIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.deleteIsolationSegmentReturnsOnCall[len(fake.deleteIsolationSegmentArgsForCall)]
+	fake.deleteIsolationSegmentArgsForCall = append(fake.deleteIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteIsolationSegmentStub
+	fakeReturns := fake.deleteIsolationSegmentReturns
+	fake.recordInvocation("DeleteIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.deleteIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentCallCount() int {
+	fake.deleteIsolationSegmentMutex.RLock()
+	defer fake.deleteIsolationSegmentMutex.RUnlock()
+	return len(fake.deleteIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.deleteIsolationSegmentMutex.Lock()
+	defer fake.deleteIsolationSegmentMutex.Unlock()
+	fake.DeleteIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.deleteIsolationSegmentMutex.RLock()
+	defer fake.deleteIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.deleteIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentReturns(result1 error) {
+	fake.deleteIsolationSegmentMutex.Lock()
+	defer fake.deleteIsolationSegmentMutex.Unlock()
+	fake.DeleteIsolationSegmentStub = nil
+	fake.deleteIsolationSegmentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentReturnsOnCall(i int, result1 error) {
+	fake.deleteIsolationSegmentMutex.Lock()
+	defer fake.deleteIsolationSegmentMutex.Unlock()
+	fake.DeleteIsolationSegmentStub = nil
+	if fake.deleteIsolationSegmentReturnsOnCall == nil {
+		fake.deleteIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteIsolationSegmentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleOrgs(arg1 context.Context, arg2 authorization.Info, arg3 repositories.EntitleIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error) {
+	fake.entitleOrgsMutex.Lock()
+	ret, specificReturn := fake.entitleOrgsReturnsOnCall[len(fake.entitleOrgsArgsForCall)]
+	fake.entitleOrgsArgsForCall = append(fake.entitleOrgsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.EntitleIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.EntitleOrgsStub
+	fakeReturns := fake.entitleOrgsReturns
+	fake.recordInvocation("EntitleOrgs", []interface{}{arg1, arg2, arg3})
+	fake.entitleOrgsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleOrgsCallCount() int {
+	fake.entitleOrgsMutex.RLock()
+	defer fake.entitleOrgsMutex.RUnlock()
+	return len(fake.entitleOrgsArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleOrgsCalls(stub func(context.Context, authorization.Info, repositories.EntitleIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)) {
+	fake.entitleOrgsMutex.Lock()
+	defer fake.entitleOrgsMutex.Unlock()
+	fake.EntitleOrgsStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleOrgsArgsForCall(i int) (context.Context, authorization.Info, repositories.EntitleIsolationSegmentMessage) {
+	fake.entitleOrgsMutex.RLock()
+	defer fake.entitleOrgsMutex.RUnlock()
+	argsForCall := fake.entitleOrgsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleOrgsReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.entitleOrgsMutex.Lock()
+	defer fake.entitleOrgsMutex.Unlock()
+	fake.EntitleOrgsStub = nil
+	fake.entitleOrgsReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleOrgsReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.entitleOrgsMutex.Lock()
+	defer fake.entitleOrgsMutex.Unlock()
+	fake.EntitleOrgsStub = nil
+	if fake.entitleOrgsReturnsOnCall == nil {
+		fake.entitleOrgsReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.entitleOrgsReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.IsolationSegmentRecord, error) {
+	fake.getIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.getIsolationSegmentReturnsOnCall[len(fake.getIsolationSegmentArgsForCall)]
+	fake.getIsolationSegmentArgsForCall = append(fake.getIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetIsolationSegmentStub
+	fakeReturns := fake.getIsolationSegmentReturns
+	fake.recordInvocation("GetIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.getIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentCallCount() int {
+	fake.getIsolationSegmentMutex.RLock()
+	defer fake.getIsolationSegmentMutex.RUnlock()
+	return len(fake.getIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentCalls(stub func(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)) {
+	fake.getIsolationSegmentMutex.Lock()
+	defer fake.getIsolationSegmentMutex.Unlock()
+	fake.GetIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getIsolationSegmentMutex.RLock()
+	defer fake.getIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.getIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.getIsolationSegmentMutex.Lock()
+	defer fake.getIsolationSegmentMutex.Unlock()
+	fake.GetIsolationSegmentStub = nil
+	fake.getIsolationSegmentReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.getIsolationSegmentMutex.Lock()
+	defer fake.getIsolationSegmentMutex.Unlock()
+	fake.GetIsolationSegmentStub = nil
+	if fake.getIsolationSegmentReturnsOnCall == nil {
+		fake.getIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.getIsolationSegmentReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegments(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListIsolationSegmentsMessage) (repositories.ListResult[repositories.IsolationSegmentRecord], error) {
+	fake.listIsolationSegmentsMutex.Lock()
+	ret, specificReturn := fake.listIsolationSegmentsReturnsOnCall[len(fake.listIsolationSegmentsArgsForCall)]
+	fake.listIsolationSegmentsArgsForCall = append(fake.listIsolationSegmentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListIsolationSegmentsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListIsolationSegmentsStub
+	fakeReturns := fake.listIsolationSegmentsReturns
+	fake.recordInvocation("ListIsolationSegments", []interface{}{arg1, arg2, arg3})
+	fake.listIsolationSegmentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsCallCount() int {
+	fake.listIsolationSegmentsMutex.RLock()
+	defer fake.listIsolationSegmentsMutex.RUnlock()
+	return len(fake.listIsolationSegmentsArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsCalls(stub func(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) (repositories.ListResult[repositories.IsolationSegmentRecord], error)) {
+	fake.listIsolationSegmentsMutex.Lock()
+	defer fake.listIsolationSegmentsMutex.Unlock()
+	fake.ListIsolationSegmentsStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) {
+	fake.listIsolationSegmentsMutex.RLock()
+	defer fake.listIsolationSegmentsMutex.RUnlock()
+	argsForCall := fake.listIsolationSegmentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsReturns(result1 repositories.ListResult[repositories.IsolationSegmentRecord], result2 error) {
+	fake.listIsolationSegmentsMutex.Lock()
+	defer fake.listIsolationSegmentsMutex.Unlock()
+	fake.ListIsolationSegmentsStub = nil
+	fake.listIsolationSegmentsReturns = struct {
+		result1 repositories.ListResult[repositories.IsolationSegmentRecord]
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsReturnsOnCall(i int, result1 repositories.ListResult[repositories.IsolationSegmentRecord], result2 error) {
+	fake.listIsolationSegmentsMutex.Lock()
+	defer fake.listIsolationSegmentsMutex.Unlock()
+	fake.ListIsolationSegmentsStub = nil
+	if fake.listIsolationSegmentsReturnsOnCall == nil {
+		fake.listIsolationSegmentsReturnsOnCall = make(map[int]struct {
+			result1 repositories.ListResult[repositories.IsolationSegmentRecord]
+			result2 error
+		})
+	}
+	fake.listIsolationSegmentsReturnsOnCall[i] = struct {
+		result1 repositories.ListResult[repositories.IsolationSegmentRecord]
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeOrg(arg1 context.Context, arg2 authorization.Info, arg3 repositories.RevokeIsolationSegmentMessage) error {
+	fake.revokeOrgMutex.Lock()
+	ret, specificReturn := fake.revokeOrgReturnsOnCall[len(fake.revokeOrgArgsForCall)]
+	fake.revokeOrgArgsForCall = append(fake.revokeOrgArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.RevokeIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.RevokeOrgStub
+	fakeReturns := fake.revokeOrgReturns
+	fake.recordInvocation("RevokeOrg", []interface{}{arg1, arg2, arg3})
+	fake.revokeOrgMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeOrgCallCount() int {
+	fake.revokeOrgMutex.RLock()
+	defer fake.revokeOrgMutex.RUnlock()
+	return len(fake.revokeOrgArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeOrgCalls(stub func(context.Context, authorization.Info, repositories.RevokeIsolationSegmentMessage) error) {
+	fake.revokeOrgMutex.Lock()
+	defer fake.revokeOrgMutex.Unlock()
+	fake.RevokeOrgStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeOrgArgsForCall(i int) (context.Context, authorization.Info, repositories.RevokeIsolationSegmentMessage) {
+	fake.revokeOrgMutex.RLock()
+	defer fake.revokeOrgMutex.RUnlock()
+	argsForCall := fake.revokeOrgArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeOrgReturns(result1 error) {
+	fake.revokeOrgMutex.Lock()
+	defer fake.revokeOrgMutex.Unlock()
+	fake.RevokeOrgStub = nil
+	fake.revokeOrgReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeOrgReturnsOnCall(i int, result1 error) {
+	fake.revokeOrgMutex.Lock()
+	defer fake.revokeOrgMutex.Unlock()
+	fake.RevokeOrgStub = nil
+	if fake.revokeOrgReturnsOnCall == nil {
+		fake.revokeOrgReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeOrgReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFIsolationSegmentRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFIsolationSegmentRepository = new(CFIsolationSegmentRepository)
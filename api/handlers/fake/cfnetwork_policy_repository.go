@@ -0,0 +1,278 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFNetworkPolicyRepository struct {
+	CreateNetworkPolicyStub        func(context.Context, authorization.Info, repositories.NetworkPolicyMessage) (repositories.NetworkPolicyRecord, error)
+	createNetworkPolicyMutex       sync.RWMutex
+	createNetworkPolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.NetworkPolicyMessage
+	}
+	createNetworkPolicyReturns struct {
+		result1 repositories.NetworkPolicyRecord
+		result2 error
+	}
+	createNetworkPolicyReturnsOnCall map[int]struct {
+		result1 repositories.NetworkPolicyRecord
+		result2 error
+	}
+	DeleteNetworkPolicyStub        func(context.Context, authorization.Info, repositories.NetworkPolicyMessage) error
+	deleteNetworkPolicyMutex       sync.RWMutex
+	deleteNetworkPolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.NetworkPolicyMessage
+	}
+	deleteNetworkPolicyReturns struct {
+		result1 error
+	}
+	deleteNetworkPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListNetworkPoliciesStub        func(context.Context, authorization.Info, repositories.ListNetworkPoliciesMessage) (repositories.ListResult[repositories.NetworkPolicyRecord], error)
+	listNetworkPoliciesMutex       sync.RWMutex
+	listNetworkPoliciesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListNetworkPoliciesMessage
+	}
+	listNetworkPoliciesReturns struct {
+		result1 repositories.ListResult[repositories.NetworkPolicyRecord]
+		result2 error
+	}
+	listNetworkPoliciesReturnsOnCall map[int]struct {
+		result1 repositories.ListResult[repositories.NetworkPolicyRecord]
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFNetworkPolicyRepository) CreateNetworkPolicy(arg1 context.Context, arg2 authorization.Info, arg3 repositories.NetworkPolicyMessage) (repositories.NetworkPolicyRecord, error) {
+	fake.createNetworkPolicyMutex.Lock()
+	ret, specificReturn := fake.createNetworkPolicyReturnsOnCall[len(fake.createNetworkPolicyArgsForCall)]
+	fake.createNetworkPolicyArgsForCall = append(fake.createNetworkPolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.NetworkPolicyMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateNetworkPolicyStub
+	fakeReturns := fake.createNetworkPolicyReturns
+	fake.recordInvocation("CreateNetworkPolicy", []interface{}{arg1, arg2, arg3})
+	fake.createNetworkPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFNetworkPolicyRepository) CreateNetworkPolicyCallCount() int {
+	fake.createNetworkPolicyMutex.RLock()
+	defer fake.createNetworkPolicyMutex.RUnlock()
+	return len(fake.createNetworkPolicyArgsForCall)
+}
+
+func (fake *CFNetworkPolicyRepository) CreateNetworkPolicyCalls(stub func(context.Context, authorization.Info, repositories.NetworkPolicyMessage) (repositories.NetworkPolicyRecord, error)) {
+	fake.createNetworkPolicyMutex.Lock()
+	defer fake.createNetworkPolicyMutex.Unlock()
+	fake.CreateNetworkPolicyStub = stub
+}
+
+func (fake *CFNetworkPolicyRepository) CreateNetworkPolicyArgsForCall(i int) (context.Context, authorization.Info, repositories.NetworkPolicyMessage) {
+	fake.createNetworkPolicyMutex.RLock()
+	defer fake.createNetworkPolicyMutex.RUnlock()
+	argsForCall := fake.createNetworkPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFNetworkPolicyRepository) CreateNetworkPolicyReturns(result1 repositories.NetworkPolicyRecord, result2 error) {
+	fake.createNetworkPolicyMutex.Lock()
+	defer fake.createNetworkPolicyMutex.Unlock()
+	fake.CreateNetworkPolicyStub = nil
+	fake.createNetworkPolicyReturns = struct {
+		result1 repositories.NetworkPolicyRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFNetworkPolicyRepository) CreateNetworkPolicyReturnsOnCall(i int, result1 repositories.NetworkPolicyRecord, result2 error) {
+	fake.createNetworkPolicyMutex.Lock()
+	defer fake.createNetworkPolicyMutex.Unlock()
+	fake.CreateNetworkPolicyStub = nil
+	if fake.createNetworkPolicyReturnsOnCall == nil {
+		fake.createNetworkPolicyReturnsOnCall = make(map[int]struct {
+			result1 repositories.NetworkPolicyRecord
+			result2 error
+		})
+	}
+	fake.createNetworkPolicyReturnsOnCall[i] = struct {
+		result1 repositories.NetworkPolicyRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFNetworkPolicyRepository) DeleteNetworkPolicy(arg1 context.Context, arg2 authorization.Info, arg3 repositories.NetworkPolicyMessage) error {
+	fake.deleteNetworkPolicyMutex.Lock()
+	ret, specificReturn := fake.deleteNetworkPolicyReturnsOnCall[len(fake.deleteNetworkPolicyArgsForCall)]
+	fake.deleteNetworkPolicyArgsForCall = append(fake.deleteNetworkPolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.NetworkPolicyMessage
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteNetworkPolicyStub
+	fakeReturns := fake.deleteNetworkPolicyReturns
+	fake.recordInvocation("DeleteNetworkPolicy", []interface{}{arg1, arg2, arg3})
+	fake.deleteNetworkPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFNetworkPolicyRepository) DeleteNetworkPolicyCallCount() int {
+	fake.deleteNetworkPolicyMutex.RLock()
+	defer fake.deleteNetworkPolicyMutex.RUnlock()
+	return len(fake.deleteNetworkPolicyArgsForCall)
+}
+
+func (fake *CFNetworkPolicyRepository) DeleteNetworkPolicyCalls(stub func(context.Context, authorization.Info, repositories.NetworkPolicyMessage) error) {
+	fake.deleteNetworkPolicyMutex.Lock()
+	defer fake.deleteNetworkPolicyMutex.Unlock()
+	fake.DeleteNetworkPolicyStub = stub
+}
+
+func (fake *CFNetworkPolicyRepository) DeleteNetworkPolicyArgsForCall(i int) (context.Context, authorization.Info, repositories.NetworkPolicyMessage) {
+	fake.deleteNetworkPolicyMutex.RLock()
+	defer fake.deleteNetworkPolicyMutex.RUnlock()
+	argsForCall := fake.deleteNetworkPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFNetworkPolicyRepository) DeleteNetworkPolicyReturns(result1 error) {
+	fake.deleteNetworkPolicyMutex.Lock()
+	defer fake.deleteNetworkPolicyMutex.Unlock()
+	fake.DeleteNetworkPolicyStub = nil
+	fake.deleteNetworkPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFNetworkPolicyRepository) DeleteNetworkPolicyReturnsOnCall(i int, result1 error) {
+	fake.deleteNetworkPolicyMutex.Lock()
+	defer fake.deleteNetworkPolicyMutex.Unlock()
+	fake.DeleteNetworkPolicyStub = nil
+	if fake.deleteNetworkPolicyReturnsOnCall == nil {
+		fake.deleteNetworkPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteNetworkPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFNetworkPolicyRepository) ListNetworkPolicies(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListNetworkPoliciesMessage) (repositories.ListResult[repositories.NetworkPolicyRecord], error) {
+	fake.listNetworkPoliciesMutex.Lock()
+	ret, specificReturn := fake.listNetworkPoliciesReturnsOnCall[len(fake.listNetworkPoliciesArgsForCall)]
+	fake.listNetworkPoliciesArgsForCall = append(fake.listNetworkPoliciesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListNetworkPoliciesMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListNetworkPoliciesStub
+	fakeReturns := fake.listNetworkPoliciesReturns
+	fake.recordInvocation("ListNetworkPolicies", []interface{}{arg1, arg2, arg3})
+	fake.listNetworkPoliciesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFNetworkPolicyRepository) ListNetworkPoliciesCallCount() int {
+	fake.listNetworkPoliciesMutex.RLock()
+	defer fake.listNetworkPoliciesMutex.RUnlock()
+	return len(fake.listNetworkPoliciesArgsForCall)
+}
+
+func (fake *CFNetworkPolicyRepository) ListNetworkPoliciesCalls(stub func(context.Context, authorization.Info, repositories.ListNetworkPoliciesMessage) (repositories.ListResult[repositories.NetworkPolicyRecord], error)) {
+	fake.listNetworkPoliciesMutex.Lock()
+	defer fake.listNetworkPoliciesMutex.Unlock()
+	fake.ListNetworkPoliciesStub = stub
+}
+
+func (fake *CFNetworkPolicyRepository) ListNetworkPoliciesArgsForCall(i int) (context.Context, authorization.Info, repositories.ListNetworkPoliciesMessage) {
+	fake.listNetworkPoliciesMutex.RLock()
+	defer fake.listNetworkPoliciesMutex.RUnlock()
+	argsForCall := fake.listNetworkPoliciesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFNetworkPolicyRepository) ListNetworkPoliciesReturns(result1 repositories.ListResult[repositories.NetworkPolicyRecord], result2 error) {
+	fake.listNetworkPoliciesMutex.Lock()
+	defer fake.listNetworkPoliciesMutex.Unlock()
+	fake.ListNetworkPoliciesStub = nil
+	fake.listNetworkPoliciesReturns = struct {
+		result1 repositories.ListResult[repositories.NetworkPolicyRecord]
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFNetworkPolicyRepository) ListNetworkPoliciesReturnsOnCall(i int, result1 repositories.ListResult[repositories.NetworkPolicyRecord], result2 error) {
+	fake.listNetworkPoliciesMutex.Lock()
+	defer fake.listNetworkPoliciesMutex.Unlock()
+	fake.ListNetworkPoliciesStub = nil
+	if fake.listNetworkPoliciesReturnsOnCall == nil {
+		fake.listNetworkPoliciesReturnsOnCall = make(map[int]struct {
+			result1 repositories.ListResult[repositories.NetworkPolicyRecord]
+			result2 error
+		})
+	}
+	fake.listNetworkPoliciesReturnsOnCall[i] = struct {
+		result1 repositories.ListResult[repositories.NetworkPolicyRecord]
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFNetworkPolicyRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFNetworkPolicyRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFNetworkPolicyRepository = new(CFNetworkPolicyRepository)
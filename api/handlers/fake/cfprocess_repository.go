@@ -99,6 +99,21 @@ type CFProcessRepository struct {
 		result1 repositories.ProcessRecord
 		result2 error
 	}
+	SetProcessAutoscalingStub        func(context.Context, authorization.Info, repositories.SetProcessAutoscalingMessage) (repositories.ProcessRecord, error)
+	setProcessAutoscalingMutex       sync.RWMutex
+	setProcessAutoscalingArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.SetProcessAutoscalingMessage
+	}
+	setProcessAutoscalingReturns struct {
+		result1 repositories.ProcessRecord
+		result2 error
+	}
+	setProcessAutoscalingReturnsOnCall map[int]struct {
+		result1 repositories.ProcessRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -496,6 +511,72 @@ func (fake *CFProcessRepository) ScaleProcessReturnsOnCall(i int, result1 reposi
 	}{result1, result2}
 }
 
+func (fake *CFProcessRepository) SetProcessAutoscaling(arg1 context.Context, arg2 authorization.Info, arg3 repositories.SetProcessAutoscalingMessage) (repositories.ProcessRecord, error) {
+	fake.setProcessAutoscalingMutex.Lock()
+	ret, specificReturn := fake.setProcessAutoscalingReturnsOnCall[len(fake.setProcessAutoscalingArgsForCall)]
+	fake.setProcessAutoscalingArgsForCall = append(fake.setProcessAutoscalingArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.SetProcessAutoscalingMessage
+	}{arg1, arg2, arg3})
+	stub := fake.SetProcessAutoscalingStub
+	fakeReturns := fake.setProcessAutoscalingReturns
+	fake.recordInvocation("SetProcessAutoscaling", []interface{}{arg1, arg2, arg3})
+	fake.setProcessAutoscalingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFProcessRepository) SetProcessAutoscalingCallCount() int {
+	fake.setProcessAutoscalingMutex.RLock()
+	defer fake.setProcessAutoscalingMutex.RUnlock()
+	return len(fake.setProcessAutoscalingArgsForCall)
+}
+
+func (fake *CFProcessRepository) SetProcessAutoscalingCalls(stub func(context.Context, authorization.Info, repositories.SetProcessAutoscalingMessage) (repositories.ProcessRecord, error)) {
+	fake.setProcessAutoscalingMutex.Lock()
+	defer fake.setProcessAutoscalingMutex.Unlock()
+	fake.SetProcessAutoscalingStub = stub
+}
+
+func (fake *CFProcessRepository) SetProcessAutoscalingArgsForCall(i int) (context.Context, authorization.Info, repositories.SetProcessAutoscalingMessage) {
+	fake.setProcessAutoscalingMutex.RLock()
+	defer fake.setProcessAutoscalingMutex.RUnlock()
+	argsForCall := fake.setProcessAutoscalingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFProcessRepository) SetProcessAutoscalingReturns(result1 repositories.ProcessRecord, result2 error) {
+	fake.setProcessAutoscalingMutex.Lock()
+	defer fake.setProcessAutoscalingMutex.Unlock()
+	fake.SetProcessAutoscalingStub = nil
+	fake.setProcessAutoscalingReturns = struct {
+		result1 repositories.ProcessRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFProcessRepository) SetProcessAutoscalingReturnsOnCall(i int, result1 repositories.ProcessRecord, result2 error) {
+	fake.setProcessAutoscalingMutex.Lock()
+	defer fake.setProcessAutoscalingMutex.Unlock()
+	fake.SetProcessAutoscalingStub = nil
+	if fake.setProcessAutoscalingReturnsOnCall == nil {
+		fake.setProcessAutoscalingReturnsOnCall = make(map[int]struct {
+			result1 repositories.ProcessRecord
+			result2 error
+		})
+	}
+	fake.setProcessAutoscalingReturnsOnCall[i] = struct {
+		result1 repositories.ProcessRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFProcessRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -82,6 +82,20 @@ type CFRouteRepository struct {
 		result1 repositories.RouteRecord
 		result2 error
 	}
+	ListRouterGroupPortsStub        func(context.Context, string) ([]int32, error)
+	listRouterGroupPortsMutex       sync.RWMutex
+	listRouterGroupPortsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	listRouterGroupPortsReturns struct {
+		result1 []int32
+		result2 error
+	}
+	listRouterGroupPortsReturnsOnCall map[int]struct {
+		result1 []int32
+		result2 error
+	}
 	ListRoutesStub        func(context.Context, authorization.Info, repositories.ListRoutesMessage) (repositories.ListResult[repositories.RouteRecord], error)
 	listRoutesMutex       sync.RWMutex
 	listRoutesArgsForCall []struct {
@@ -455,6 +469,71 @@ func (fake *CFRouteRepository) GetRouteReturnsOnCall(i int, result1 repositories
 	}{result1, result2}
 }
 
+func (fake *CFRouteRepository) ListRouterGroupPorts(arg1 context.Context, arg2 string) ([]int32, error) {
+	fake.listRouterGroupPortsMutex.Lock()
+	ret, specificReturn := fake.listRouterGroupPortsReturnsOnCall[len(fake.listRouterGroupPortsArgsForCall)]
+	fake.listRouterGroupPortsArgsForCall = append(fake.listRouterGroupPortsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ListRouterGroupPortsStub
+	fakeReturns := fake.listRouterGroupPortsReturns
+	fake.recordInvocation("ListRouterGroupPorts", []interface{}{arg1, arg2})
+	fake.listRouterGroupPortsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouteRepository) ListRouterGroupPortsCallCount() int {
+	fake.listRouterGroupPortsMutex.RLock()
+	defer fake.listRouterGroupPortsMutex.RUnlock()
+	return len(fake.listRouterGroupPortsArgsForCall)
+}
+
+func (fake *CFRouteRepository) ListRouterGroupPortsCalls(stub func(context.Context, string) ([]int32, error)) {
+	fake.listRouterGroupPortsMutex.Lock()
+	defer fake.listRouterGroupPortsMutex.Unlock()
+	fake.ListRouterGroupPortsStub = stub
+}
+
+func (fake *CFRouteRepository) ListRouterGroupPortsArgsForCall(i int) (context.Context, string) {
+	fake.listRouterGroupPortsMutex.RLock()
+	defer fake.listRouterGroupPortsMutex.RUnlock()
+	argsForCall := fake.listRouterGroupPortsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CFRouteRepository) ListRouterGroupPortsReturns(result1 []int32, result2 error) {
+	fake.listRouterGroupPortsMutex.Lock()
+	defer fake.listRouterGroupPortsMutex.Unlock()
+	fake.ListRouterGroupPortsStub = nil
+	fake.listRouterGroupPortsReturns = struct {
+		result1 []int32
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) ListRouterGroupPortsReturnsOnCall(i int, result1 []int32, result2 error) {
+	fake.listRouterGroupPortsMutex.Lock()
+	defer fake.listRouterGroupPortsMutex.Unlock()
+	fake.ListRouterGroupPortsStub = nil
+	if fake.listRouterGroupPortsReturnsOnCall == nil {
+		fake.listRouterGroupPortsReturnsOnCall = make(map[int]struct {
+			result1 []int32
+			result2 error
+		})
+	}
+	fake.listRouterGroupPortsReturnsOnCall[i] = struct {
+		result1 []int32
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFRouteRepository) ListRoutes(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListRoutesMessage) (repositories.ListResult[repositories.RouteRecord], error) {
 	fake.listRoutesMutex.Lock()
 	ret, specificReturn := fake.listRoutesReturnsOnCall[len(fake.listRoutesArgsForCall)]
@@ -11,6 +11,21 @@ import (
 )
 
 type CFServiceInstanceRepository struct {
+	CanShareServiceInstanceWithStub        func(context.Context, authorization.Info, string) (bool, error)
+	canShareServiceInstanceWithMutex       sync.RWMutex
+	canShareServiceInstanceWithArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	canShareServiceInstanceWithReturns struct {
+		result1 bool
+		result2 error
+	}
+	canShareServiceInstanceWithReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	CreateManagedServiceInstanceStub        func(context.Context, authorization.Info, repositories.CreateManagedSIMessage) (repositories.ServiceInstanceRecord, error)
 	createManagedServiceInstanceMutex       sync.RWMutex
 	createManagedServiceInstanceArgsForCall []struct {
@@ -86,6 +101,37 @@ type CFServiceInstanceRepository struct {
 		result1 map[string]any
 		result2 error
 	}
+	GetSharedServiceInstanceStub        func(context.Context, authorization.Info, string, string) (repositories.ServiceInstanceRecord, error)
+	getSharedServiceInstanceMutex       sync.RWMutex
+	getSharedServiceInstanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}
+	getSharedServiceInstanceReturns struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}
+	getSharedServiceInstanceReturnsOnCall map[int]struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}
+	GetSharedSpacesUsageSummaryStub        func(context.Context, authorization.Info, string) ([]repositories.SharedSpaceUsageRecord, error)
+	getSharedSpacesUsageSummaryMutex       sync.RWMutex
+	getSharedSpacesUsageSummaryArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getSharedSpacesUsageSummaryReturns struct {
+		result1 []repositories.SharedSpaceUsageRecord
+		result2 error
+	}
+	getSharedSpacesUsageSummaryReturnsOnCall map[int]struct {
+		result1 []repositories.SharedSpaceUsageRecord
+		result2 error
+	}
 	ListServiceInstancesStub        func(context.Context, authorization.Info, repositories.ListServiceInstanceMessage) (repositories.ListResult[repositories.ServiceInstanceRecord], error)
 	listServiceInstancesMutex       sync.RWMutex
 	listServiceInstancesArgsForCall []struct {
@@ -116,10 +162,104 @@ type CFServiceInstanceRepository struct {
 		result1 repositories.ServiceInstanceRecord
 		result2 error
 	}
+	ShareServiceInstanceStub        func(context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
+	shareServiceInstanceMutex       sync.RWMutex
+	shareServiceInstanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareServiceInstanceMessage
+	}
+	shareServiceInstanceReturns struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}
+	shareServiceInstanceReturnsOnCall map[int]struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}
+	UnshareServiceInstanceStub        func(context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) error
+	unshareServiceInstanceMutex       sync.RWMutex
+	unshareServiceInstanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareServiceInstanceMessage
+	}
+	unshareServiceInstanceReturns struct {
+		result1 error
+	}
+	unshareServiceInstanceReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *CFServiceInstanceRepository) CanShareServiceInstanceWith(arg1 context.Context, arg2 authorization.Info, arg3 string) (bool, error) {
+	fake.canShareServiceInstanceWithMutex.Lock()
+	ret, specificReturn := fake.canShareServiceInstanceWithReturnsOnCall[len(fake.canShareServiceInstanceWithArgsForCall)]
+	fake.canShareServiceInstanceWithArgsForCall = append(fake.canShareServiceInstanceWithArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CanShareServiceInstanceWithStub
+	fakeReturns := fake.canShareServiceInstanceWithReturns
+	fake.recordInvocation("CanShareServiceInstanceWith", []interface{}{arg1, arg2, arg3})
+	fake.canShareServiceInstanceWithMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceInstanceRepository) CanShareServiceInstanceWithCallCount() int {
+	fake.canShareServiceInstanceWithMutex.RLock()
+	defer fake.canShareServiceInstanceWithMutex.RUnlock()
+	return len(fake.canShareServiceInstanceWithArgsForCall)
+}
+
+func (fake *CFServiceInstanceRepository) CanShareServiceInstanceWithCalls(stub func(context.Context, authorization.Info, string) (bool, error)) {
+	fake.canShareServiceInstanceWithMutex.Lock()
+	defer fake.canShareServiceInstanceWithMutex.Unlock()
+	fake.CanShareServiceInstanceWithStub = stub
+}
+
+func (fake *CFServiceInstanceRepository) CanShareServiceInstanceWithArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.canShareServiceInstanceWithMutex.RLock()
+	defer fake.canShareServiceInstanceWithMutex.RUnlock()
+	argsForCall := fake.canShareServiceInstanceWithArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceInstanceRepository) CanShareServiceInstanceWithReturns(result1 bool, result2 error) {
+	fake.canShareServiceInstanceWithMutex.Lock()
+	defer fake.canShareServiceInstanceWithMutex.Unlock()
+	fake.CanShareServiceInstanceWithStub = nil
+	fake.canShareServiceInstanceWithReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) CanShareServiceInstanceWithReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.canShareServiceInstanceWithMutex.Lock()
+	defer fake.canShareServiceInstanceWithMutex.Unlock()
+	fake.CanShareServiceInstanceWithStub = nil
+	if fake.canShareServiceInstanceWithReturnsOnCall == nil {
+		fake.canShareServiceInstanceWithReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.canShareServiceInstanceWithReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFServiceInstanceRepository) CreateManagedServiceInstance(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateManagedSIMessage) (repositories.ServiceInstanceRecord, error) {
 	fake.createManagedServiceInstanceMutex.Lock()
 	ret, specificReturn := fake.createManagedServiceInstanceReturnsOnCall[len(fake.createManagedServiceInstanceArgsForCall)]
@@ -450,6 +590,139 @@ func (fake *CFServiceInstanceRepository) GetServiceInstanceCredentialsReturnsOnC
 	}{result1, result2}
 }
 
+func (fake *CFServiceInstanceRepository) GetSharedServiceInstance(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) (repositories.ServiceInstanceRecord, error) {
+	fake.getSharedServiceInstanceMutex.Lock()
+	ret, specificReturn := fake.getSharedServiceInstanceReturnsOnCall[len(fake.getSharedServiceInstanceArgsForCall)]
+	fake.getSharedServiceInstanceArgsForCall = append(fake.getSharedServiceInstanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetSharedServiceInstanceStub
+	fakeReturns := fake.getSharedServiceInstanceReturns
+	fake.recordInvocation("GetSharedServiceInstance", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getSharedServiceInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedServiceInstanceCallCount() int {
+	fake.getSharedServiceInstanceMutex.RLock()
+	defer fake.getSharedServiceInstanceMutex.RUnlock()
+	return len(fake.getSharedServiceInstanceArgsForCall)
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedServiceInstanceCalls(stub func(context.Context, authorization.Info, string, string) (repositories.ServiceInstanceRecord, error)) {
+	fake.getSharedServiceInstanceMutex.Lock()
+	defer fake.getSharedServiceInstanceMutex.Unlock()
+	fake.GetSharedServiceInstanceStub = stub
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedServiceInstanceArgsForCall(i int) (context.Context, authorization.Info, string, string) {
+	fake.getSharedServiceInstanceMutex.RLock()
+	defer fake.getSharedServiceInstanceMutex.RUnlock()
+	argsForCall := fake.getSharedServiceInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedServiceInstanceReturns(result1 repositories.ServiceInstanceRecord, result2 error) {
+	fake.getSharedServiceInstanceMutex.Lock()
+	defer fake.getSharedServiceInstanceMutex.Unlock()
+	fake.GetSharedServiceInstanceStub = nil
+	fake.getSharedServiceInstanceReturns = struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedServiceInstanceReturnsOnCall(i int, result1 repositories.ServiceInstanceRecord, result2 error) {
+	fake.getSharedServiceInstanceMutex.Lock()
+	defer fake.getSharedServiceInstanceMutex.Unlock()
+	fake.GetSharedServiceInstanceStub = nil
+	if fake.getSharedServiceInstanceReturnsOnCall == nil {
+		fake.getSharedServiceInstanceReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServiceInstanceRecord
+			result2 error
+		})
+	}
+	fake.getSharedServiceInstanceReturnsOnCall[i] = struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedSpacesUsageSummary(arg1 context.Context, arg2 authorization.Info, arg3 string) ([]repositories.SharedSpaceUsageRecord, error) {
+	fake.getSharedSpacesUsageSummaryMutex.Lock()
+	ret, specificReturn := fake.getSharedSpacesUsageSummaryReturnsOnCall[len(fake.getSharedSpacesUsageSummaryArgsForCall)]
+	fake.getSharedSpacesUsageSummaryArgsForCall = append(fake.getSharedSpacesUsageSummaryArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetSharedSpacesUsageSummaryStub
+	fakeReturns := fake.getSharedSpacesUsageSummaryReturns
+	fake.recordInvocation("GetSharedSpacesUsageSummary", []interface{}{arg1, arg2, arg3})
+	fake.getSharedSpacesUsageSummaryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedSpacesUsageSummaryCallCount() int {
+	fake.getSharedSpacesUsageSummaryMutex.RLock()
+	defer fake.getSharedSpacesUsageSummaryMutex.RUnlock()
+	return len(fake.getSharedSpacesUsageSummaryArgsForCall)
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedSpacesUsageSummaryCalls(stub func(context.Context, authorization.Info, string) ([]repositories.SharedSpaceUsageRecord, error)) {
+	fake.getSharedSpacesUsageSummaryMutex.Lock()
+	defer fake.getSharedSpacesUsageSummaryMutex.Unlock()
+	fake.GetSharedSpacesUsageSummaryStub = stub
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedSpacesUsageSummaryArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getSharedSpacesUsageSummaryMutex.RLock()
+	defer fake.getSharedSpacesUsageSummaryMutex.RUnlock()
+	argsForCall := fake.getSharedSpacesUsageSummaryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedSpacesUsageSummaryReturns(result1 []repositories.SharedSpaceUsageRecord, result2 error) {
+	fake.getSharedSpacesUsageSummaryMutex.Lock()
+	defer fake.getSharedSpacesUsageSummaryMutex.Unlock()
+	fake.GetSharedSpacesUsageSummaryStub = nil
+	fake.getSharedSpacesUsageSummaryReturns = struct {
+		result1 []repositories.SharedSpaceUsageRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) GetSharedSpacesUsageSummaryReturnsOnCall(i int, result1 []repositories.SharedSpaceUsageRecord, result2 error) {
+	fake.getSharedSpacesUsageSummaryMutex.Lock()
+	defer fake.getSharedSpacesUsageSummaryMutex.Unlock()
+	fake.GetSharedSpacesUsageSummaryStub = nil
+	if fake.getSharedSpacesUsageSummaryReturnsOnCall == nil {
+		fake.getSharedSpacesUsageSummaryReturnsOnCall = make(map[int]struct {
+			result1 []repositories.SharedSpaceUsageRecord
+			result2 error
+		})
+	}
+	fake.getSharedSpacesUsageSummaryReturnsOnCall[i] = struct {
+		result1 []repositories.SharedSpaceUsageRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFServiceInstanceRepository) ListServiceInstances(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListServiceInstanceMessage) (repositories.ListResult[repositories.ServiceInstanceRecord], error) {
 	fake.listServiceInstancesMutex.Lock()
 	ret, specificReturn := fake.listServiceInstancesReturnsOnCall[len(fake.listServiceInstancesArgsForCall)]
@@ -582,6 +855,135 @@ func (fake *CFServiceInstanceRepository) PatchServiceInstanceReturnsOnCall(i int
 	}{result1, result2}
 }
 
+func (fake *CFServiceInstanceRepository) ShareServiceInstance(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error) {
+	fake.shareServiceInstanceMutex.Lock()
+	ret, specificReturn := fake.shareServiceInstanceReturnsOnCall[len(fake.shareServiceInstanceArgsForCall)]
+	fake.shareServiceInstanceArgsForCall = append(fake.shareServiceInstanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareServiceInstanceMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ShareServiceInstanceStub
+	fakeReturns := fake.shareServiceInstanceReturns
+	fake.recordInvocation("ShareServiceInstance", []interface{}{arg1, arg2, arg3})
+	fake.shareServiceInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceCallCount() int {
+	fake.shareServiceInstanceMutex.RLock()
+	defer fake.shareServiceInstanceMutex.RUnlock()
+	return len(fake.shareServiceInstanceArgsForCall)
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceCalls(stub func(context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)) {
+	fake.shareServiceInstanceMutex.Lock()
+	defer fake.shareServiceInstanceMutex.Unlock()
+	fake.ShareServiceInstanceStub = stub
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceArgsForCall(i int) (context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) {
+	fake.shareServiceInstanceMutex.RLock()
+	defer fake.shareServiceInstanceMutex.RUnlock()
+	argsForCall := fake.shareServiceInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceReturns(result1 repositories.ServiceInstanceRecord, result2 error) {
+	fake.shareServiceInstanceMutex.Lock()
+	defer fake.shareServiceInstanceMutex.Unlock()
+	fake.ShareServiceInstanceStub = nil
+	fake.shareServiceInstanceReturns = struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) ShareServiceInstanceReturnsOnCall(i int, result1 repositories.ServiceInstanceRecord, result2 error) {
+	fake.shareServiceInstanceMutex.Lock()
+	defer fake.shareServiceInstanceMutex.Unlock()
+	fake.ShareServiceInstanceStub = nil
+	if fake.shareServiceInstanceReturnsOnCall == nil {
+		fake.shareServiceInstanceReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServiceInstanceRecord
+			result2 error
+		})
+	}
+	fake.shareServiceInstanceReturnsOnCall[i] = struct {
+		result1 repositories.ServiceInstanceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstance(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UnshareServiceInstanceMessage) error {
+	fake.unshareServiceInstanceMutex.Lock()
+	ret, specificReturn := fake.unshareServiceInstanceReturnsOnCall[len(fake.unshareServiceInstanceArgsForCall)]
+	fake.unshareServiceInstanceArgsForCall = append(fake.unshareServiceInstanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareServiceInstanceMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UnshareServiceInstanceStub
+	fakeReturns := fake.unshareServiceInstanceReturns
+	fake.recordInvocation("UnshareServiceInstance", []interface{}{arg1, arg2, arg3})
+	fake.unshareServiceInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceCallCount() int {
+	fake.unshareServiceInstanceMutex.RLock()
+	defer fake.unshareServiceInstanceMutex.RUnlock()
+	return len(fake.unshareServiceInstanceArgsForCall)
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceCalls(stub func(context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) error) {
+	fake.unshareServiceInstanceMutex.Lock()
+	defer fake.unshareServiceInstanceMutex.Unlock()
+	fake.UnshareServiceInstanceStub = stub
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceArgsForCall(i int) (context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) {
+	fake.unshareServiceInstanceMutex.RLock()
+	defer fake.unshareServiceInstanceMutex.RUnlock()
+	argsForCall := fake.unshareServiceInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceReturns(result1 error) {
+	fake.unshareServiceInstanceMutex.Lock()
+	defer fake.unshareServiceInstanceMutex.Unlock()
+	fake.UnshareServiceInstanceStub = nil
+	fake.unshareServiceInstanceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFServiceInstanceRepository) UnshareServiceInstanceReturnsOnCall(i int, result1 error) {
+	fake.unshareServiceInstanceMutex.Lock()
+	defer fake.unshareServiceInstanceMutex.Unlock()
+	fake.UnshareServiceInstanceStub = nil
+	if fake.unshareServiceInstanceReturnsOnCall == nil {
+		fake.unshareServiceInstanceReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unshareServiceInstanceReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *CFServiceInstanceRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
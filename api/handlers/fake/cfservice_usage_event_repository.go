@@ -0,0 +1,276 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFServiceUsageEventRepository struct {
+	GetServiceUsageEventStub        func(context.Context, authorization.Info, string) (repositories.ServiceUsageEventRecord, error)
+	getServiceUsageEventMutex       sync.RWMutex
+	getServiceUsageEventArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getServiceUsageEventReturns struct {
+		result1 repositories.ServiceUsageEventRecord
+		result2 error
+	}
+	getServiceUsageEventReturnsOnCall map[int]struct {
+		result1 repositories.ServiceUsageEventRecord
+		result2 error
+	}
+	ListServiceUsageEventsStub        func(context.Context, authorization.Info, repositories.ListServiceUsageEventsMessage) (repositories.ListResult[repositories.ServiceUsageEventRecord], error)
+	listServiceUsageEventsMutex       sync.RWMutex
+	listServiceUsageEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListServiceUsageEventsMessage
+	}
+	listServiceUsageEventsReturns struct {
+		result1 repositories.ListResult[repositories.ServiceUsageEventRecord]
+		result2 error
+	}
+	listServiceUsageEventsReturnsOnCall map[int]struct {
+		result1 repositories.ListResult[repositories.ServiceUsageEventRecord]
+		result2 error
+	}
+	PurgeAndReseedServiceUsageEventsStub        func(context.Context, authorization.Info) error
+	purgeAndReseedServiceUsageEventsMutex       sync.RWMutex
+	purgeAndReseedServiceUsageEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}
+	purgeAndReseedServiceUsageEventsReturns struct {
+		result1 error
+	}
+	purgeAndReseedServiceUsageEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFServiceUsageEventRepository) GetServiceUsageEvent(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.ServiceUsageEventRecord, error) {
+	fake.getServiceUsageEventMutex.Lock()
+	ret, specificReturn := fake.getServiceUsageEventReturnsOnCall[len(fake.getServiceUsageEventArgsForCall)]
+	fake.getServiceUsageEventArgsForCall = append(fake.getServiceUsageEventArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetServiceUsageEventStub
+	fakeReturns := fake.getServiceUsageEventReturns
+	fake.recordInvocation("GetServiceUsageEvent", []interface{}{arg1, arg2, arg3})
+	fake.getServiceUsageEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceUsageEventRepository) GetServiceUsageEventCallCount() int {
+	fake.getServiceUsageEventMutex.RLock()
+	defer fake.getServiceUsageEventMutex.RUnlock()
+	return len(fake.getServiceUsageEventArgsForCall)
+}
+
+func (fake *CFServiceUsageEventRepository) GetServiceUsageEventCalls(stub func(context.Context, authorization.Info, string) (repositories.ServiceUsageEventRecord, error)) {
+	fake.getServiceUsageEventMutex.Lock()
+	defer fake.getServiceUsageEventMutex.Unlock()
+	fake.GetServiceUsageEventStub = stub
+}
+
+func (fake *CFServiceUsageEventRepository) GetServiceUsageEventArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getServiceUsageEventMutex.RLock()
+	defer fake.getServiceUsageEventMutex.RUnlock()
+	argsForCall := fake.getServiceUsageEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceUsageEventRepository) GetServiceUsageEventReturns(result1 repositories.ServiceUsageEventRecord, result2 error) {
+	fake.getServiceUsageEventMutex.Lock()
+	defer fake.getServiceUsageEventMutex.Unlock()
+	fake.GetServiceUsageEventStub = nil
+	fake.getServiceUsageEventReturns = struct {
+		result1 repositories.ServiceUsageEventRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceUsageEventRepository) GetServiceUsageEventReturnsOnCall(i int, result1 repositories.ServiceUsageEventRecord, result2 error) {
+	fake.getServiceUsageEventMutex.Lock()
+	defer fake.getServiceUsageEventMutex.Unlock()
+	fake.GetServiceUsageEventStub = nil
+	if fake.getServiceUsageEventReturnsOnCall == nil {
+		fake.getServiceUsageEventReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServiceUsageEventRecord
+			result2 error
+		})
+	}
+	fake.getServiceUsageEventReturnsOnCall[i] = struct {
+		result1 repositories.ServiceUsageEventRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceUsageEventRepository) ListServiceUsageEvents(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListServiceUsageEventsMessage) (repositories.ListResult[repositories.ServiceUsageEventRecord], error) {
+	fake.listServiceUsageEventsMutex.Lock()
+	ret, specificReturn := fake.listServiceUsageEventsReturnsOnCall[len(fake.listServiceUsageEventsArgsForCall)]
+	fake.listServiceUsageEventsArgsForCall = append(fake.listServiceUsageEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListServiceUsageEventsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListServiceUsageEventsStub
+	fakeReturns := fake.listServiceUsageEventsReturns
+	fake.recordInvocation("ListServiceUsageEvents", []interface{}{arg1, arg2, arg3})
+	fake.listServiceUsageEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceUsageEventRepository) ListServiceUsageEventsCallCount() int {
+	fake.listServiceUsageEventsMutex.RLock()
+	defer fake.listServiceUsageEventsMutex.RUnlock()
+	return len(fake.listServiceUsageEventsArgsForCall)
+}
+
+func (fake *CFServiceUsageEventRepository) ListServiceUsageEventsCalls(stub func(context.Context, authorization.Info, repositories.ListServiceUsageEventsMessage) (repositories.ListResult[repositories.ServiceUsageEventRecord], error)) {
+	fake.listServiceUsageEventsMutex.Lock()
+	defer fake.listServiceUsageEventsMutex.Unlock()
+	fake.ListServiceUsageEventsStub = stub
+}
+
+func (fake *CFServiceUsageEventRepository) ListServiceUsageEventsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListServiceUsageEventsMessage) {
+	fake.listServiceUsageEventsMutex.RLock()
+	defer fake.listServiceUsageEventsMutex.RUnlock()
+	argsForCall := fake.listServiceUsageEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceUsageEventRepository) ListServiceUsageEventsReturns(result1 repositories.ListResult[repositories.ServiceUsageEventRecord], result2 error) {
+	fake.listServiceUsageEventsMutex.Lock()
+	defer fake.listServiceUsageEventsMutex.Unlock()
+	fake.ListServiceUsageEventsStub = nil
+	fake.listServiceUsageEventsReturns = struct {
+		result1 repositories.ListResult[repositories.ServiceUsageEventRecord]
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceUsageEventRepository) ListServiceUsageEventsReturnsOnCall(i int, result1 repositories.ListResult[repositories.ServiceUsageEventRecord], result2 error) {
+	fake.listServiceUsageEventsMutex.Lock()
+	defer fake.listServiceUsageEventsMutex.Unlock()
+	fake.ListServiceUsageEventsStub = nil
+	if fake.listServiceUsageEventsReturnsOnCall == nil {
+		fake.listServiceUsageEventsReturnsOnCall = make(map[int]struct {
+			result1 repositories.ListResult[repositories.ServiceUsageEventRecord]
+			result2 error
+		})
+	}
+	fake.listServiceUsageEventsReturnsOnCall[i] = struct {
+		result1 repositories.ListResult[repositories.ServiceUsageEventRecord]
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceUsageEventRepository) PurgeAndReseedServiceUsageEvents(arg1 context.Context, arg2 authorization.Info) error {
+	fake.purgeAndReseedServiceUsageEventsMutex.Lock()
+	ret, specificReturn := fake.purgeAndReseedServiceUsageEventsReturnsOnCall[len(fake.purgeAndReseedServiceUsageEventsArgsForCall)]
+	fake.purgeAndReseedServiceUsageEventsArgsForCall = append(fake.purgeAndReseedServiceUsageEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}{arg1, arg2})
+	stub := fake.PurgeAndReseedServiceUsageEventsStub
+	fakeReturns := fake.purgeAndReseedServiceUsageEventsReturns
+	fake.recordInvocation("PurgeAndReseedServiceUsageEvents", []interface{}{arg1, arg2})
+	fake.purgeAndReseedServiceUsageEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFServiceUsageEventRepository) PurgeAndReseedServiceUsageEventsCallCount() int {
+	fake.purgeAndReseedServiceUsageEventsMutex.RLock()
+	defer fake.purgeAndReseedServiceUsageEventsMutex.RUnlock()
+	return len(fake.purgeAndReseedServiceUsageEventsArgsForCall)
+}
+
+func (fake *CFServiceUsageEventRepository) PurgeAndReseedServiceUsageEventsCalls(stub func(context.Context, authorization.Info) error) {
+	fake.purgeAndReseedServiceUsageEventsMutex.Lock()
+	defer fake.purgeAndReseedServiceUsageEventsMutex.Unlock()
+	fake.PurgeAndReseedServiceUsageEventsStub = stub
+}
+
+func (fake *CFServiceUsageEventRepository) PurgeAndReseedServiceUsageEventsArgsForCall(i int) (context.Context, authorization.Info) {
+	fake.purgeAndReseedServiceUsageEventsMutex.RLock()
+	defer fake.purgeAndReseedServiceUsageEventsMutex.RUnlock()
+	argsForCall := fake.purgeAndReseedServiceUsageEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CFServiceUsageEventRepository) PurgeAndReseedServiceUsageEventsReturns(result1 error) {
+	fake.purgeAndReseedServiceUsageEventsMutex.Lock()
+	defer fake.purgeAndReseedServiceUsageEventsMutex.Unlock()
+	fake.PurgeAndReseedServiceUsageEventsStub = nil
+	fake.purgeAndReseedServiceUsageEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFServiceUsageEventRepository) PurgeAndReseedServiceUsageEventsReturnsOnCall(i int, result1 error) {
+	fake.purgeAndReseedServiceUsageEventsMutex.Lock()
+	defer fake.purgeAndReseedServiceUsageEventsMutex.Unlock()
+	fake.PurgeAndReseedServiceUsageEventsStub = nil
+	if fake.purgeAndReseedServiceUsageEventsReturnsOnCall == nil {
+		fake.purgeAndReseedServiceUsageEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.purgeAndReseedServiceUsageEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFServiceUsageEventRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFServiceUsageEventRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFServiceUsageEventRepository = new(CFServiceUsageEventRepository)
@@ -0,0 +1,121 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type GaugeRepository struct {
+	GetAppGaugesStub        func(context.Context, authorization.Info, repositories.GetGaugesMessage) ([]repositories.GaugeRecord, error)
+	getAppGaugesMutex       sync.RWMutex
+	getAppGaugesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.GetGaugesMessage
+	}
+	getAppGaugesReturns struct {
+		result1 []repositories.GaugeRecord
+		result2 error
+	}
+	getAppGaugesReturnsOnCall map[int]struct {
+		result1 []repositories.GaugeRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *GaugeRepository) GetAppGauges(arg1 context.Context, arg2 authorization.Info, arg3 repositories.GetGaugesMessage) ([]repositories.GaugeRecord, error) {
+	fake.getAppGaugesMutex.Lock()
+	ret, specificReturn := fake.getAppGaugesReturnsOnCall[len(fake.getAppGaugesArgsForCall)]
+	fake.getAppGaugesArgsForCall = append(fake.getAppGaugesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.GetGaugesMessage
+	}{arg1, arg2, arg3})
+	stub := fake.GetAppGaugesStub
+	fakeReturns := fake.getAppGaugesReturns
+	fake.recordInvocation("GetAppGauges", []interface{}{arg1, arg2, arg3})
+	fake.getAppGaugesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *GaugeRepository) GetAppGaugesCallCount() int {
+	fake.getAppGaugesMutex.RLock()
+	defer fake.getAppGaugesMutex.RUnlock()
+	return len(fake.getAppGaugesArgsForCall)
+}
+
+func (fake *GaugeRepository) GetAppGaugesCalls(stub func(context.Context, authorization.Info, repositories.GetGaugesMessage) ([]repositories.GaugeRecord, error)) {
+	fake.getAppGaugesMutex.Lock()
+	defer fake.getAppGaugesMutex.Unlock()
+	fake.GetAppGaugesStub = stub
+}
+
+func (fake *GaugeRepository) GetAppGaugesArgsForCall(i int) (context.Context, authorization.Info, repositories.GetGaugesMessage) {
+	fake.getAppGaugesMutex.RLock()
+	defer fake.getAppGaugesMutex.RUnlock()
+	argsForCall := fake.getAppGaugesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *GaugeRepository) GetAppGaugesReturns(result1 []repositories.GaugeRecord, result2 error) {
+	fake.getAppGaugesMutex.Lock()
+	defer fake.getAppGaugesMutex.Unlock()
+	fake.GetAppGaugesStub = nil
+	fake.getAppGaugesReturns = struct {
+		result1 []repositories.GaugeRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *GaugeRepository) GetAppGaugesReturnsOnCall(i int, result1 []repositories.GaugeRecord, result2 error) {
+	fake.getAppGaugesMutex.Lock()
+	defer fake.getAppGaugesMutex.Unlock()
+	fake.GetAppGaugesStub = nil
+	if fake.getAppGaugesReturnsOnCall == nil {
+		fake.getAppGaugesReturnsOnCall = make(map[int]struct {
+			result1 []repositories.GaugeRecord
+			result2 error
+		})
+	}
+	fake.getAppGaugesReturnsOnCall[i] = struct {
+		result1 []repositories.GaugeRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *GaugeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *GaugeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.GaugeRepository = new(GaugeRepository)
@@ -0,0 +1,118 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+)
+
+type SSHCodeIssuer struct {
+	IssueStub        func(context.Context, authorization.Info) (string, error)
+	issueMutex       sync.RWMutex
+	issueArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}
+	issueReturns struct {
+		result1 string
+		result2 error
+	}
+	issueReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SSHCodeIssuer) Issue(arg1 context.Context, arg2 authorization.Info) (string, error) {
+	fake.issueMutex.Lock()
+	ret, specificReturn := fake.issueReturnsOnCall[len(fake.issueArgsForCall)]
+	fake.issueArgsForCall = append(fake.issueArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}{arg1, arg2})
+	stub := fake.IssueStub
+	fakeReturns := fake.issueReturns
+	fake.recordInvocation("Issue", []interface{}{arg1, arg2})
+	fake.issueMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SSHCodeIssuer) IssueCallCount() int {
+	fake.issueMutex.RLock()
+	defer fake.issueMutex.RUnlock()
+	return len(fake.issueArgsForCall)
+}
+
+func (fake *SSHCodeIssuer) IssueCalls(stub func(context.Context, authorization.Info) (string, error)) {
+	fake.issueMutex.Lock()
+	defer fake.issueMutex.Unlock()
+	fake.IssueStub = stub
+}
+
+func (fake *SSHCodeIssuer) IssueArgsForCall(i int) (context.Context, authorization.Info) {
+	fake.issueMutex.RLock()
+	defer fake.issueMutex.RUnlock()
+	argsForCall := fake.issueArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *SSHCodeIssuer) IssueReturns(result1 string, result2 error) {
+	fake.issueMutex.Lock()
+	defer fake.issueMutex.Unlock()
+	fake.IssueStub = nil
+	fake.issueReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSHCodeIssuer) IssueReturnsOnCall(i int, result1 string, result2 error) {
+	fake.issueMutex.Lock()
+	defer fake.issueMutex.Unlock()
+	fake.IssueStub = nil
+	if fake.issueReturnsOnCall == nil {
+		fake.issueReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.issueReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSHCodeIssuer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SSHCodeIssuer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.SSHCodeIssuer = new(SSHCodeIssuer)
@@ -1,38 +1,259 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
 )
 
 const (
-	IsolationSegmentsPath = "/v3/isolation_segments"
+	IsolationSegmentsPath              = "/v3/isolation_segments"
+	IsolationSegmentPath               = "/v3/isolation_segments/{guid}"
+	IsolationSegmentOrganizationsPath  = "/v3/isolation_segments/{guid}/relationships/organizations"
+	IsolationSegmentOrganizationPath   = "/v3/isolation_segments/{guid}/relationships/organizations/{org_guid}"
+	isolationSegmentStillEntitledErr   = "Revoke the Organization entitlements for your Isolation Segment."
+	isolationSegmentNotEntitledErrTmpl = "Unable to assign isolation segment with guid '%s'. Ensure it has been entitled to the organization."
 )
 
+//counterfeiter:generate -o fake -fake-name CFIsolationSegmentRepository . CFIsolationSegmentRepository
+type CFIsolationSegmentRepository interface {
+	CreateIsolationSegment(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	GetIsolationSegment(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)
+	ListIsolationSegments(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) (repositories.ListResult[repositories.IsolationSegmentRecord], error)
+	DeleteIsolationSegment(context.Context, authorization.Info, string) error
+	EntitleOrgs(context.Context, authorization.Info, repositories.EntitleIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	RevokeOrg(context.Context, authorization.Info, repositories.RevokeIsolationSegmentMessage) error
+}
+
 type IsolationSegment struct {
-	apiBaseURL url.URL
+	apiBaseURL           url.URL
+	isolationSegmentRepo CFIsolationSegmentRepository
+	orgRepo              CFOrgRepository
+	spaceRepo            CFSpaceRepository
+	requestValidator     RequestValidator
 }
 
-func NewIsolationSegment(apiBaseURL url.URL) *IsolationSegment {
+func NewIsolationSegment(
+	apiBaseURL url.URL,
+	isolationSegmentRepo CFIsolationSegmentRepository,
+	orgRepo CFOrgRepository,
+	spaceRepo CFSpaceRepository,
+	requestValidator RequestValidator,
+) *IsolationSegment {
 	return &IsolationSegment{
-		apiBaseURL: apiBaseURL,
+		apiBaseURL:           apiBaseURL,
+		isolationSegmentRepo: isolationSegmentRepo,
+		orgRepo:              orgRepo,
+		spaceRepo:            spaceRepo,
+		requestValidator:     requestValidator,
+	}
+}
+
+func (h *IsolationSegment) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.create")
+
+	payload := new(payloads.IsolationSegmentCreate)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.CreateIsolationSegment(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create isolation segment", "Isolation segment name", payload.Name)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForIsolationSegment(isolationSegment, h.apiBaseURL)), nil
+}
+
+func (h *IsolationSegment) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.get")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get isolation segment", "isolationSegmentGUID", isolationSegmentGUID)
 	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegment(isolationSegment, h.apiBaseURL)), nil
 }
 
 func (h *IsolationSegment) list(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.Empty, repositories.ListResult[any]{}, h.apiBaseURL, *r.URL)), nil
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.list")
+
+	payload := new(payloads.IsolationSegmentList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	isolationSegments, err := h.isolationSegmentRepo.ListIsolationSegments(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list isolation segments")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForIsolationSegment, isolationSegments, h.apiBaseURL, *r.URL)), nil
+}
+
+func (h *IsolationSegment) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.delete")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get isolation segment", "isolationSegmentGUID", isolationSegmentGUID)
+	}
+
+	if len(isolationSegment.OrgGUIDs) > 0 {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(fmt.Errorf("isolation segment %q is entitled to orgs %v", isolationSegmentGUID, isolationSegment.OrgGUIDs), isolationSegmentStillEntitledErr),
+			"Isolation segment still entitled to orgs", "isolationSegmentGUID", isolationSegmentGUID,
+		)
+	}
+
+	err = h.isolationSegmentRepo.DeleteIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete isolation segment", "isolationSegmentGUID", isolationSegmentGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *IsolationSegment) entitleOrgs(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.entitle-orgs")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.IsolationSegmentEntitle)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get isolation segment", "isolationSegmentGUID", isolationSegmentGUID)
+	}
+
+	message := payload.ToMessage(isolationSegmentGUID)
+	if err = h.ensureOrgsExist(r.Context(), authInfo, message.OrgGUIDs); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to find orgs for isolation segment")
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.EntitleOrgs(r.Context(), authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to entitle orgs to isolation segment", "isolationSegmentGUID", isolationSegmentGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegmentOrganizations(isolationSegment, h.apiBaseURL)), nil
+}
+
+func (h *IsolationSegment) revokeOrg(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.revoke-org")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+	orgGUID := routing.URLParam(r, "org_guid")
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get isolation segment", "isolationSegmentGUID", isolationSegmentGUID)
+	}
+
+	org, err := h.orgRepo.GetOrg(r.Context(), authInfo, orgGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get org", "orgGUID", orgGUID)
+	}
+
+	spaces, err := h.spaceRepo.ListSpaces(r.Context(), authInfo, repositories.ListSpacesMessage{OrganizationGUIDs: []string{orgGUID}})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list spaces", "orgGUID", orgGUID)
+	}
+
+	spaceUsesSegment := slices.ContainsFunc(spaces.Records, func(s repositories.SpaceRecord) bool {
+		return s.IsolationSegmentGUID == isolationSegmentGUID
+	})
+	if org.DefaultIsolationSegmentGUID == isolationSegmentGUID || spaceUsesSegment {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(
+				fmt.Errorf("isolation segment %q is in use in org %q", isolationSegmentGUID, orgGUID),
+				fmt.Sprintf("Cannot remove the entitlement for isolation segment '%s' from organization '%s' as it is the default or is assigned to spaces of the organization.", isolationSegmentGUID, org.Name),
+			),
+			"Isolation segment in use", "isolationSegmentGUID", isolationSegmentGUID, "orgGUID", orgGUID,
+		)
+	}
+
+	err = h.isolationSegmentRepo.RevokeOrg(r.Context(), authInfo, repositories.RevokeIsolationSegmentMessage{
+		GUID:    isolationSegmentGUID,
+		OrgGUID: orgGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to revoke org from isolation segment", "isolationSegmentGUID", isolationSegmentGUID, "orgGUID", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *IsolationSegment) ensureOrgsExist(ctx context.Context, authInfo authorization.Info, orgGUIDs []string) error {
+	orgs, err := h.orgRepo.ListOrgs(ctx, authInfo, repositories.ListOrgsMessage{GUIDs: orgGUIDs})
+	if err != nil {
+		return err
+	}
+
+	for _, orgGUID := range orgGUIDs {
+		if !slices.ContainsFunc(orgs.Records, func(o repositories.OrgRecord) bool { return o.GUID == orgGUID }) {
+			return apierrors.NewUnprocessableEntityError(fmt.Errorf("org %q not found", orgGUID), orgNotFoundErr)
+		}
+	}
+
+	return nil
 }
 
 func (h *IsolationSegment) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
+		{Method: "POST", Pattern: IsolationSegmentsPath, Handler: h.create},
 		{Method: "GET", Pattern: IsolationSegmentsPath, Handler: h.list},
+		{Method: "GET", Pattern: IsolationSegmentPath, Handler: h.get},
+		{Method: "DELETE", Pattern: IsolationSegmentPath, Handler: h.delete},
+		{Method: "POST", Pattern: IsolationSegmentOrganizationsPath, Handler: h.entitleOrgs},
+		{Method: "DELETE", Pattern: IsolationSegmentOrganizationPath, Handler: h.revokeOrg},
 	}
 }
 
 func (h *IsolationSegment) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
+
+// ensureIsolationSegmentEntitled fails with an unprocessable entity error when
+// the isolation segment does not exist or is not entitled to the org
+func ensureIsolationSegmentEntitled(ctx context.Context, authInfo authorization.Info, isolationSegmentRepo CFIsolationSegmentRepository, isolationSegmentGUID string, orgGUID string) error {
+	isolationSegment, err := isolationSegmentRepo.GetIsolationSegment(ctx, authInfo, isolationSegmentGUID)
+	if err != nil {
+		return apierrors.AsUnprocessableEntity(err, fmt.Sprintf(isolationSegmentNotEntitledErrTmpl, isolationSegmentGUID), apierrors.NotFoundError{}, apierrors.ForbiddenError{})
+	}
+
+	if !slices.Contains(isolationSegment.OrgGUIDs, orgGUID) {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("isolation segment %q is not entitled to org %q", isolationSegmentGUID, orgGUID),
+			fmt.Sprintf(isolationSegmentNotEntitledErrTmpl, isolationSegmentGUID),
+		)
+	}
+
+	return nil
+}
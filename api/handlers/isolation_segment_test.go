@@ -0,0 +1,394 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsolationSegment", func() {
+	var (
+		requestMethod        string
+		requestPath          string
+		requestBody          string
+		isolationSegmentRepo *fake.CFIsolationSegmentRepository
+		orgRepo              *fake.CFOrgRepository
+		spaceRepo            *fake.CFSpaceRepository
+		requestValidator     *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		isolationSegmentRepo = new(fake.CFIsolationSegmentRepository)
+		orgRepo = new(fake.CFOrgRepository)
+		spaceRepo = new(fake.CFSpaceRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewIsolationSegment(
+			*serverURL,
+			isolationSegmentRepo,
+			orgRepo,
+			spaceRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(requestBody))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/isolation_segments", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/isolation_segments"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentCreate{
+				Name: "my-segment",
+			})
+
+			isolationSegmentRepo.CreateIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID: "segment-guid",
+				Name: "my-segment",
+			}, nil)
+		})
+
+		It("validates the request", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("creates the isolation segment", func() {
+			Expect(isolationSegmentRepo.CreateIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := isolationSegmentRepo.CreateIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Name).To(Equal("my-segment"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "segment-guid"),
+				MatchJSONPath("$.name", "my-segment"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/segment-guid"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("creating the isolation segment fails", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.CreateIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/isolation_segments/segment-guid"
+
+			isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID: "segment-guid",
+				Name: "my-segment",
+			}, nil)
+		})
+
+		It("returns the isolation segment", func() {
+			Expect(isolationSegmentRepo.GetIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := isolationSegmentRepo.GetIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("segment-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "segment-guid"),
+				MatchJSONPath("$.name", "my-segment"),
+			)))
+		})
+
+		When("the isolation segment is forbidden", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewForbiddenError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/isolation_segments"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.IsolationSegmentList{
+				Names:    "s1,s2",
+				OrgGUIDs: "org1",
+			})
+
+			isolationSegmentRepo.ListIsolationSegmentsReturns(repositories.ListResult[repositories.IsolationSegmentRecord]{
+				Records: []repositories.IsolationSegmentRecord{
+					{GUID: "s1-guid", Name: "s1"},
+					{GUID: "s2-guid", Name: "s2"},
+				},
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 2,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     2,
+				},
+			}, nil)
+		})
+
+		It("lists the isolation segments", func() {
+			Expect(isolationSegmentRepo.ListIsolationSegmentsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := isolationSegmentRepo.ListIsolationSegmentsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("s1", "s2"))
+			Expect(message.OrgGUIDs).To(ConsistOf("org1"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "s1-guid"),
+				MatchJSONPath("$.resources[1].guid", "s2-guid"),
+			)))
+		})
+
+		When("listing the isolation segments fails", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.ListIsolationSegmentsReturns(repositories.ListResult[repositories.IsolationSegmentRecord]{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/isolation_segments/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/isolation_segments/segment-guid"
+
+			isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID: "segment-guid",
+			}, nil)
+		})
+
+		It("deletes the isolation segment", func() {
+			Expect(isolationSegmentRepo.DeleteIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := isolationSegmentRepo.DeleteIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("segment-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the isolation segment is entitled to orgs", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+					GUID:     "segment-guid",
+					OrgGUIDs: []string{"org1"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Revoke the Organization entitlements for your Isolation Segment.")
+				Expect(isolationSegmentRepo.DeleteIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewNotFoundError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+				Expect(isolationSegmentRepo.DeleteIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+
+		When("deleting the isolation segment fails", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.DeleteIsolationSegmentReturns(errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/isolation_segments/{guid}/relationships/organizations", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/isolation_segments/segment-guid/relationships/organizations"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentEntitle{
+				Data: []payloads.RelationshipData{{GUID: "org1"}, {GUID: "org2"}},
+			})
+			orgRepo.ListOrgsReturns(repositories.ListResult[repositories.OrgRecord]{
+				Records: []repositories.OrgRecord{{GUID: "org1"}, {GUID: "org2"}},
+			}, nil)
+			isolationSegmentRepo.EntitleOrgsReturns(repositories.IsolationSegmentRecord{
+				GUID:     "segment-guid",
+				OrgGUIDs: []string{"org1", "org2"},
+			}, nil)
+		})
+
+		It("entitles the orgs to the isolation segment", func() {
+			Expect(orgRepo.ListOrgsCallCount()).To(Equal(1))
+			_, _, listOrgsMessage := orgRepo.ListOrgsArgsForCall(0)
+			Expect(listOrgsMessage.GUIDs).To(ConsistOf("org1", "org2"))
+
+			Expect(isolationSegmentRepo.EntitleOrgsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := isolationSegmentRepo.EntitleOrgsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.EntitleIsolationSegmentMessage{
+				GUID:     "segment-guid",
+				OrgGUIDs: []string{"org1", "org2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data", HaveLen(2)),
+				MatchJSONPath("$.data[0].guid", "org1"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/segment-guid/relationships/organizations"),
+			)))
+		})
+
+		When("an org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.ListOrgsReturns(repositories.ListResult[repositories.OrgRecord]{
+					Records: []repositories.OrgRecord{{GUID: "org1"}},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Organization does not exist, or you do not have access.")
+				Expect(isolationSegmentRepo.EntitleOrgsCallCount()).To(BeZero())
+			})
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewNotFoundError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+			})
+		})
+	})
+
+	Describe("DELETE /v3/isolation_segments/{guid}/relationships/organizations/{org_guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/isolation_segments/segment-guid/relationships/organizations/org-guid"
+
+			isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID:     "segment-guid",
+				OrgGUIDs: []string{"org-guid"},
+			}, nil)
+			orgRepo.GetOrgReturns(repositories.OrgRecord{GUID: "org-guid", Name: "my-org"}, nil)
+			spaceRepo.ListSpacesReturns(repositories.ListResult[repositories.SpaceRecord]{
+				Records: []repositories.SpaceRecord{{GUID: "space-guid"}},
+			}, nil)
+		})
+
+		It("revokes the org entitlement", func() {
+			Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+			_, _, listSpacesMessage := spaceRepo.ListSpacesArgsForCall(0)
+			Expect(listSpacesMessage.OrganizationGUIDs).To(ConsistOf("org-guid"))
+
+			Expect(isolationSegmentRepo.RevokeOrgCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := isolationSegmentRepo.RevokeOrgArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.RevokeIsolationSegmentMessage{
+				GUID:    "segment-guid",
+				OrgGUID: "org-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the isolation segment is the default of the org", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{
+					GUID:                        "org-guid",
+					Name:                        "my-org",
+					DefaultIsolationSegmentGUID: "segment-guid",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot remove the entitlement for isolation segment 'segment-guid' from organization 'my-org' as it is the default or is assigned to spaces of the organization.")
+				Expect(isolationSegmentRepo.RevokeOrgCallCount()).To(BeZero())
+			})
+		})
+
+		When("the isolation segment is assigned to a space of the org", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns(repositories.ListResult[repositories.SpaceRecord]{
+					Records: []repositories.SpaceRecord{{GUID: "space-guid", IsolationSegmentGUID: "segment-guid"}},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot remove the entitlement for isolation segment 'segment-guid' from organization 'my-org' as it is the default or is assigned to spaces of the organization.")
+				Expect(isolationSegmentRepo.RevokeOrgCallCount()).To(BeZero())
+			})
+		})
+
+		When("the org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgResourceType)
+			})
+		})
+
+		When("revoking the org fails", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.RevokeOrgReturns(errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -18,23 +18,29 @@ import (
 )
 
 const (
-	JobPath                             = "/v3/jobs/{guid}"
-	syncSpaceJobType                    = "space.apply_manifest"
-	spaceDeleteUnmappedRoutesJobType    = "space.delete_unapped_routes"
-	AppDeleteJobType                    = "app.delete"
-	OrgDeleteJobType                    = "org.delete"
-	RouteDeleteJobType                  = "route.delete"
-	SpaceDeleteJobType                  = "space.delete"
-	DomainDeleteJobType                 = "domain.delete"
-	RoleDeleteJobType                   = "role.delete"
-	ServiceBrokerCreateJobType          = "service_broker.create"
-	ServiceBrokerUpdateJobType          = "service_broker.update"
-	ServiceBrokerDeleteJobType          = "service_broker.delete"
-	ManagedServiceInstanceDeleteJobType = "managed_service_instance.delete"
-	ManagedServiceInstanceCreateJobType = "managed_service_instance.create"
-	ManagedServiceBindingCreateJobType  = "managed_service_binding.create"
-	ManagedServiceBindingDeleteJobType  = "managed_service_binding.delete"
-	JobTimeoutDuration                  = 120.0
+	JobPath                                 = "/v3/jobs/{guid}"
+	syncSpaceJobType                        = "space.apply_manifest"
+	spaceDeleteUnmappedRoutesJobType        = "space.delete_unapped_routes"
+	AppDeleteJobType                        = "app.delete"
+	OrgDeleteJobType                        = "org.delete"
+	RouteDeleteJobType                      = "route.delete"
+	SpaceDeleteJobType                      = "space.delete"
+	DomainDeleteJobType                     = "domain.delete"
+	RoleDeleteJobType                       = "role.delete"
+	SecurityGroupDeleteJobType              = "security_group.delete"
+	OrgQuotaDeleteJobType                   = "organization_quota.delete"
+	SpaceQuotaDeleteJobType                 = "space_quota.delete"
+	ServiceBrokerCreateJobType              = "service_broker.create"
+	ServiceBrokerUpdateJobType              = "service_broker.update"
+	ServiceBrokerDeleteJobType              = "service_broker.delete"
+	ManagedServiceInstanceDeleteJobType     = "managed_service_instance.delete"
+	ManagedServiceInstanceCreateJobType     = "managed_service_instance.create"
+	ManagedServiceInstanceUpdateJobType     = "managed_service_instance.update"
+	ManagedServiceBindingCreateJobType      = "managed_service_binding.create"
+	ManagedServiceBindingDeleteJobType      = "managed_service_binding.delete"
+	ManagedServiceRouteBindingCreateJobType = "managed_service_route_binding.create"
+	ManagedServiceRouteBindingDeleteJobType = "managed_service_route_binding.delete"
+	JobTimeoutDuration                      = 120.0
 )
 
 const JobResourceType = "Job"
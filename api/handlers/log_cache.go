@@ -2,7 +2,11 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/actions"
 	"code.cloudfoundry.org/korifi/api/authorization"
@@ -19,7 +23,10 @@ import (
 const (
 	LogCacheInfoPath = "/api/v1/info"
 	LogCacheReadPath = "/api/v1/read/{source-id}"
+	LogStreamPath    = "/v2/read"
 	logCacheVersion  = "2.11.4+cf-k8s"
+
+	logStreamHeartbeatInterval = 15 * time.Second
 )
 
 //counterfeiter:generate -o fake -fake-name ProcessStats . ProcessStats
@@ -31,6 +38,12 @@ type ProcessStats interface {
 //counterfeiter:generate -o fake -fake-name LogRepository . LogRepository
 type LogRepository interface {
 	GetAppLogs(context.Context, authorization.Info, repositories.GetLogsMessage) ([]repositories.LogRecord, error)
+	StreamAppLogs(context.Context, authorization.Info, repositories.StreamLogsMessage) (<-chan repositories.LogRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name GaugeRepository . GaugeRepository
+type GaugeRepository interface {
+	GetAppGauges(context.Context, authorization.Info, repositories.GetGaugesMessage) ([]repositories.GaugeRecord, error)
 }
 
 // LogCache implements the minimal set of log-cache API endpoints/features necessary
@@ -41,6 +54,9 @@ type LogCache struct {
 	buildRepo        CFBuildRepository
 	logRepo          LogRepository
 	processStats     ProcessStats
+	// gaugeRepo is optional. When set, gauges are read from the sampled
+	// metrics history rather than from the current metrics snapshot.
+	gaugeRepo GaugeRepository
 }
 
 func NewLogCache(
@@ -49,6 +65,7 @@ func NewLogCache(
 	buildRepository CFBuildRepository,
 	logRepo LogRepository,
 	processStats ProcessStats,
+	gaugeRepo GaugeRepository,
 ) *LogCache {
 	return &LogCache{
 		requestValidator: requestValidator,
@@ -56,6 +73,7 @@ func NewLogCache(
 		buildRepo:        buildRepository,
 		logRepo:          logRepo,
 		processStats:     processStats,
+		gaugeRepo:        gaugeRepo,
 	}
 }
 
@@ -87,6 +105,10 @@ func (h *LogCache) read(r *http.Request) (*routing.Response, error) {
 		return h.readLogs(r.Context(), authInfo, appRecord, payload)
 	}
 
+	if h.gaugeRepo != nil {
+		return h.readGauges(r.Context(), authInfo, appRecord, payload)
+	}
+
 	return h.readStats(r.Context(), authInfo, appRecord)
 }
 
@@ -121,6 +143,84 @@ func (h *LogCache) readStats(ctx context.Context, authInfo authorization.Info, a
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForStats(appRecord, stats)), nil
 }
 
+func (h *LogCache) readGauges(ctx context.Context, authInfo authorization.Info, appRecord repositories.AppRecord, payload payloads.LogCacheRead) (*routing.Response, error) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("handlers.log-cache.read.gauges").WithValues("appGUID", appRecord.GUID)
+	gauges, err := h.gaugeRepo.GetAppGauges(ctx, authInfo, repositories.GetGaugesMessage{
+		App:        appRecord,
+		StartTime:  payload.StartTime,
+		EndTime:    payload.EndTime,
+		Limit:      payload.Limit,
+		Descending: payload.Descending,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app gauges")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForGauges(appRecord, gauges)), nil
+}
+
+// stream follows the app and staging logs and sends them as server-sent events
+// in the format of the loggregator RLP gateway until the client disconnects
+func (h *LogCache) stream(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.log-cache.stream")
+
+	payload := payloads.LogStreamRead{}
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+	logger = logger.WithValues("appGUID", payload.SourceID)
+
+	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, payload.SourceID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app")
+	}
+
+	logRecords, err := h.logRepo.StreamAppLogs(r.Context(), authInfo, repositories.StreamLogsMessage{
+		App: appRecord,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to stream app logs")
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithContentType("text/event-stream").
+		WithHeader("Cache-Control", "no-cache").
+		WithStream(func(w io.Writer, flush func() error) error {
+			return writeLogStream(w, flush, logRecords)
+		}), nil
+}
+
+func writeLogStream(w io.Writer, flush func() error, logRecords <-chan repositories.LogRecord) error {
+	heartbeat := time.NewTicker(logStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case logRecord, ok := <-logRecords:
+			if !ok {
+				return nil
+			}
+
+			data, err := json.Marshal(presenter.ForLogStream(logRecord))
+			if err != nil {
+				return err
+			}
+			if _, err = fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return err
+			}
+		case t := <-heartbeat.C:
+			if _, err := fmt.Fprintf(w, "event: heartbeat\ndata: %d\n\n", t.Unix()); err != nil {
+				return err
+			}
+		}
+
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+}
+
 func (h *LogCache) UnauthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: LogCacheInfoPath, Handler: h.info},
@@ -130,5 +230,6 @@ func (h *LogCache) UnauthenticatedRoutes() []routing.Route {
 func (h *LogCache) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: LogCacheReadPath, Handler: h.read},
+		{Method: "GET", Pattern: LogStreamPath, Handler: h.stream},
 	}
 }
@@ -3,7 +3,9 @@ package handlers_test
 import (
 	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/api/actions"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -25,6 +27,7 @@ var _ = Describe("LogCache", func() {
 		buildRepo        *fake.CFBuildRepository
 		logRepo          *fake.LogRepository
 		processStats     *fake.ProcessStats
+		gaugeRepo        GaugeRepository
 		req              *http.Request
 		requestValidator *fake.RequestValidator
 	)
@@ -35,6 +38,7 @@ var _ = Describe("LogCache", func() {
 		buildRepo = new(fake.CFBuildRepository)
 		logRepo = new(fake.LogRepository)
 		processStats = new(fake.ProcessStats)
+		gaugeRepo = nil
 
 		appRepo.GetAppReturns(repositories.AppRecord{
 			GUID:      "app-guid",
@@ -44,18 +48,18 @@ var _ = Describe("LogCache", func() {
 		buildRepo.GetLatestBuildByAppGUIDReturns(repositories.BuildRecord{
 			GUID: "build-guid",
 		}, nil)
+	})
 
+	JustBeforeEach(func() {
 		apiHandler := NewLogCache(
 			requestValidator,
 			appRepo,
 			buildRepo,
 			logRepo,
 			processStats,
+			gaugeRepo,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
-	})
-
-	JustBeforeEach(func() {
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
@@ -237,6 +241,147 @@ var _ = Describe("LogCache", func() {
 					MatchJSONPath("$.envelopes.batch[0].gauge", Not(BeEmpty())),
 				)))
 			})
+
+			When("the metrics history is enabled", func() {
+				var fakeGaugeRepo *fake.GaugeRepository
+
+				BeforeEach(func() {
+					payload.EndTime = tools.PtrTo[int64](23456)
+
+					fakeGaugeRepo = new(fake.GaugeRepository)
+					fakeGaugeRepo.GetAppGaugesReturns([]repositories.GaugeRecord{
+						{Timestamp: 12345, ProcessType: "web", Mem: 1},
+						{Timestamp: 23456, ProcessType: "web", Mem: 2},
+					}, nil)
+					gaugeRepo = fakeGaugeRepo
+				})
+
+				It("does not fetch the current app metrics", func() {
+					Expect(processStats.FetchAppProcessesStatsCallCount()).To(Equal(0))
+				})
+
+				It("gets the app gauges history", func() {
+					Expect(fakeGaugeRepo.GetAppGaugesCallCount()).To(Equal(1))
+					_, actualAuthInfo, actualMessage := fakeGaugeRepo.GetAppGaugesArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(actualMessage).To(Equal(repositories.GetGaugesMessage{
+						App: repositories.AppRecord{
+							GUID:      "app-guid",
+							SpaceGUID: "app-space-guid",
+						},
+						StartTime:  tools.PtrTo[int64](12345),
+						EndTime:    tools.PtrTo[int64](23456),
+						Limit:      tools.PtrTo[int64](1000),
+						Descending: true,
+					}))
+				})
+
+				It("returns the app gauges", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(SatisfyAll(
+						MatchJSONPath("$.envelopes.batch", HaveLen(2)),
+						MatchJSONPath("$.envelopes.batch[0].timestamp", BeEquivalentTo(12345)),
+						MatchJSONPath("$.envelopes.batch[1].gauge.metrics.memory.value", BeEquivalentTo(2)),
+					)))
+				})
+
+				When("getting the app gauges fails", func() {
+					BeforeEach(func() {
+						fakeGaugeRepo.GetAppGaugesReturns(nil, errors.New("failed-to-get-gauges"))
+					})
+
+					It("returns an error", func() {
+						expectUnknownError()
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GET /v2/read", func() {
+		var payload *payloads.LogStreamRead
+
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v2/read?log&source_id=app-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			payload = &payloads.LogStreamRead{
+				SourceID: "app-guid",
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			logRecords := make(chan repositories.LogRecord, 2)
+			logRecords <- repositories.LogRecord{Timestamp: 0, Message: "log0"}
+			logRecords <- repositories.LogRecord{Timestamp: 1, Message: "log1"}
+			close(logRecords)
+			logRepo.StreamAppLogsReturns(logRecords, nil)
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+			_, actualPayload := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+			Expect(actualPayload).To(Equal(payload))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid-payload"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid-payload")
+			})
+		})
+
+		It("gets the app", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		It("streams the app logs", func() {
+			Expect(logRepo.StreamAppLogsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := logRepo.StreamAppLogsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage.App.GUID).To(Equal("app-guid"))
+		})
+
+		When("streaming the logs fails", func() {
+			BeforeEach(func() {
+				logRepo.StreamAppLogsReturns(nil, errors.New("stream-logs-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		It("returns the app logs as server-sent events", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "text/event-stream"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Cache-Control", "no-cache"))
+			Expect(rr.Flushed).To(BeTrue())
+
+			events := strings.Split(strings.TrimSpace(rr.Body.String()), "\n\n")
+			Expect(events).To(HaveLen(2))
+			for i, event := range events {
+				Expect(event).To(HavePrefix("data: "))
+				Expect(strings.TrimPrefix(event, "data: ")).To(SatisfyAll(
+					MatchJSONPath("$.batch[0].timestamp", BeEquivalentTo(i)),
+					MatchJSONPath("$.batch[0].log.payload", Equal(base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("log%d", i))))),
+				))
+			}
 		})
 	})
 })
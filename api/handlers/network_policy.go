@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	NetworkPoliciesPath       = "/networking/v1/external/policies"
+	NetworkPoliciesDeletePath = "/networking/v1/external/policies/delete"
+
+	networkPolicyAppNotFoundErr = "One or more applications cannot be found or accessed."
+)
+
+//counterfeiter:generate -o fake -fake-name CFNetworkPolicyRepository . CFNetworkPolicyRepository
+type CFNetworkPolicyRepository interface {
+	CreateNetworkPolicy(context.Context, authorization.Info, repositories.NetworkPolicyMessage) (repositories.NetworkPolicyRecord, error)
+	ListNetworkPolicies(context.Context, authorization.Info, repositories.ListNetworkPoliciesMessage) (repositories.ListResult[repositories.NetworkPolicyRecord], error)
+	DeleteNetworkPolicy(context.Context, authorization.Info, repositories.NetworkPolicyMessage) error
+}
+
+type NetworkPolicy struct {
+	networkPolicyRepo CFNetworkPolicyRepository
+	appRepo           CFAppRepository
+	requestValidator  RequestValidator
+}
+
+func NewNetworkPolicy(
+	networkPolicyRepo CFNetworkPolicyRepository,
+	appRepo CFAppRepository,
+	requestValidator RequestValidator,
+) *NetworkPolicy {
+	return &NetworkPolicy{
+		networkPolicyRepo: networkPolicyRepo,
+		appRepo:           appRepo,
+		requestValidator:  requestValidator,
+	}
+}
+
+func (h *NetworkPolicy) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.network-policy.list")
+
+	payload := new(payloads.NetworkPolicyList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	policies, err := h.networkPolicyRepo.ListNetworkPolicies(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list network policies")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForNetworkPolicies(policies.Records)), nil
+}
+
+func (h *NetworkPolicy) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.network-policy.create")
+
+	var payload payloads.NetworkPolicies
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	messages, err := h.toMessages(r.Context(), authInfo, payload)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to resolve network policy apps")
+	}
+
+	for _, message := range messages {
+		if _, err = h.networkPolicyRepo.CreateNetworkPolicy(r.Context(), authInfo, message); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to create network policy", "SourceAppGUID", message.SourceAppGUID, "DestinationAppGUID", message.DestinationAppGUID)
+		}
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(map[string]any{}), nil
+}
+
+func (h *NetworkPolicy) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.network-policy.delete")
+
+	var payload payloads.NetworkPolicies
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	messages, err := h.toMessages(r.Context(), authInfo, payload)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to resolve network policy apps")
+	}
+
+	for _, message := range messages {
+		if err = h.networkPolicyRepo.DeleteNetworkPolicy(r.Context(), authInfo, message); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to delete network policy", "SourceAppGUID", message.SourceAppGUID, "DestinationAppGUID", message.DestinationAppGUID)
+		}
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(map[string]any{}), nil
+}
+
+// toMessages resolves the spaces of the policy apps. All apps are resolved
+// before any policy is changed, so that a request with a missing app changes
+// nothing
+func (h *NetworkPolicy) toMessages(ctx context.Context, authInfo authorization.Info, payload payloads.NetworkPolicies) ([]repositories.NetworkPolicyMessage, error) {
+	messages := []repositories.NetworkPolicyMessage{}
+	for _, policy := range payload.Policies {
+		sourceApp, err := h.getApp(ctx, authInfo, policy.Source.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		destinationApp, err := h.getApp(ctx, authInfo, policy.Destination.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, policy.ToMessage(sourceApp, destinationApp))
+	}
+
+	return messages, nil
+}
+
+func (h *NetworkPolicy) getApp(ctx context.Context, authInfo authorization.Info, appGUID string) (repositories.AppRecord, error) {
+	app, err := h.appRepo.GetApp(ctx, authInfo, appGUID)
+	if err != nil {
+		return repositories.AppRecord{}, apierrors.AsUnprocessableEntity(apierrors.ForbiddenAsNotFound(err), networkPolicyAppNotFoundErr, apierrors.NotFoundError{})
+	}
+
+	return app, nil
+}
+
+func (h *NetworkPolicy) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *NetworkPolicy) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: NetworkPoliciesPath, Handler: h.list},
+		{Method: "POST", Pattern: NetworkPoliciesPath, Handler: h.create},
+		{Method: "POST", Pattern: NetworkPoliciesDeletePath, Handler: h.delete},
+	}
+}
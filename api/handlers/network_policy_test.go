@@ -0,0 +1,231 @@
+package handlers_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPolicy", func() {
+	var (
+		requestMethod string
+		requestPath   string
+
+		networkPolicyRepo *fake.CFNetworkPolicyRepository
+		appRepo           *fake.CFAppRepository
+		requestValidator  *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		networkPolicyRepo = new(fake.CFNetworkPolicyRepository)
+
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppStub = func(_ context.Context, _ authorization.Info, appGUID string) (repositories.AppRecord, error) {
+			return repositories.AppRecord{
+				GUID:      appGUID,
+				SpaceGUID: appGUID + "-space",
+			}, nil
+		}
+
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewNetworkPolicy(
+			networkPolicyRepo,
+			appRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader("the-body"))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	policiesPayload := func() *payloads.NetworkPolicies {
+		return &payloads.NetworkPolicies{
+			Policies: []payloads.NetworkPolicy{{
+				Source: payloads.NetworkPolicySource{ID: "source-app"},
+				Destination: payloads.NetworkPolicyDestination{
+					ID:       "destination-app",
+					Protocol: "tcp",
+					Ports:    payloads.NetworkPolicyPorts{Start: 8080, End: 8080},
+				},
+			}},
+		}
+	}
+
+	expectedMessage := repositories.NetworkPolicyMessage{
+		SourceAppGUID:        "source-app",
+		SourceSpaceGUID:      "source-app-space",
+		DestinationAppGUID:   "destination-app",
+		DestinationSpaceGUID: "destination-app-space",
+		Protocol:             "tcp",
+		StartPort:            8080,
+		EndPort:              8080,
+	}
+
+	Describe("GET /networking/v1/external/policies", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/networking/v1/external/policies?id=source-app"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.NetworkPolicyList{
+				IDs: "source-app",
+			})
+
+			networkPolicyRepo.ListNetworkPoliciesReturns(repositories.ListResult[repositories.NetworkPolicyRecord]{
+				Records: []repositories.NetworkPolicyRecord{{
+					SourceAppGUID:      "source-app",
+					DestinationAppGUID: "destination-app",
+					Protocol:           "tcp",
+					StartPort:          8080,
+					EndPort:            8080,
+				}},
+			}, nil)
+		})
+
+		It("lists the network policies", func() {
+			Expect(networkPolicyRepo.ListNetworkPoliciesCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := networkPolicyRepo.ListNetworkPoliciesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.ListNetworkPoliciesMessage{
+				AppGUIDs: []string{"source-app"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.total_policies", BeEquivalentTo(1)),
+				MatchJSONPath("$.policies[0].source.id", "source-app"),
+				MatchJSONPath("$.policies[0].destination.id", "destination-app"),
+			)))
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("invalid-query"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the policies fails", func() {
+			BeforeEach(func() {
+				networkPolicyRepo.ListNetworkPoliciesReturns(repositories.ListResult[repositories.NetworkPolicyRecord]{}, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /networking/v1/external/policies", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/networking/v1/external/policies"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(policiesPayload())
+		})
+
+		It("creates the network policies", func() {
+			Expect(networkPolicyRepo.CreateNetworkPolicyCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := networkPolicyRepo.CreateNetworkPolicyArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(expectedMessage))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON("{}")))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(networkPolicyRepo.CreateNetworkPolicyCallCount()).To(BeZero())
+			})
+		})
+
+		When("an app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppStub = nil
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("One or more applications cannot be found or accessed.")
+				Expect(networkPolicyRepo.CreateNetworkPolicyCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the policy fails", func() {
+			BeforeEach(func() {
+				networkPolicyRepo.CreateNetworkPolicyReturns(repositories.NetworkPolicyRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /networking/v1/external/policies/delete", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/networking/v1/external/policies/delete"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(policiesPayload())
+		})
+
+		It("deletes the network policies", func() {
+			Expect(networkPolicyRepo.DeleteNetworkPolicyCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := networkPolicyRepo.DeleteNetworkPolicyArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(expectedMessage))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON("{}")))
+		})
+
+		When("an app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppStub = nil
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("One or more applications cannot be found or accessed.")
+				Expect(networkPolicyRepo.DeleteNetworkPolicyCallCount()).To(BeZero())
+			})
+		})
+
+		When("deleting the policy fails", func() {
+			BeforeEach(func() {
+				networkPolicyRepo.DeleteNetworkPolicyReturns(errors.New("delete-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -42,19 +42,23 @@ type Org struct {
 	apiBaseURL                               url.URL
 	orgRepo                                  CFOrgRepository
 	domainRepo                               CFDomainRepository
+	isolationSegmentRepo                     CFIsolationSegmentRepository
 	requestValidator                         RequestValidator
 	userCertificateExpirationWarningDuration time.Duration
 	defaultDomainName                        string
+	auditEventRecorder                       AuditEventRecorder
 }
 
-func NewOrg(apiBaseURL url.URL, orgRepo CFOrgRepository, domainRepo CFDomainRepository, requestValidator RequestValidator, userCertificateExpirationWarningDuration time.Duration, defaultDomainName string) *Org {
+func NewOrg(apiBaseURL url.URL, orgRepo CFOrgRepository, domainRepo CFDomainRepository, isolationSegmentRepo CFIsolationSegmentRepository, requestValidator RequestValidator, userCertificateExpirationWarningDuration time.Duration, defaultDomainName string, auditEventRecorder AuditEventRecorder) *Org {
 	return &Org{
 		apiBaseURL:                               apiBaseURL,
 		orgRepo:                                  orgRepo,
 		domainRepo:                               domainRepo,
+		isolationSegmentRepo:                     isolationSegmentRepo,
 		requestValidator:                         requestValidator,
 		userCertificateExpirationWarningDuration: userCertificateExpirationWarningDuration,
 		defaultDomainName:                        defaultDomainName,
+		auditEventRecorder:                       auditEventRecorder,
 	}
 }
 
@@ -72,6 +76,15 @@ func (h *Org) create(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create org", "Org Name", payload.Name)
 	}
+	recordAuditEvent(r.Context(), h.auditEventRecorder, repositories.CreateAuditEventMessage{
+		Type: repositories.AuditEventTypeOrgCreate,
+		Target: repositories.AuditEventTarget{
+			GUID: record.GUID,
+			Type: repositories.AuditEventTargetTypeOrg,
+			Name: record.Name,
+		},
+		OrganizationGUID: record.GUID,
+	})
 
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForOrg(record, h.apiBaseURL)), nil
 }
@@ -157,7 +170,7 @@ func (h *Org) listDomains(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Unable to parse request query parameters")
 	}
 
-	listResult, err := h.domainRepo.ListDomains(r.Context(), authInfo, domainListFilter.ToMessage())
+	listResult, err := h.domainRepo.ListDomains(r.Context(), authInfo, domainListFilter.ToVisibleToOrgMessage(orgGUID))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch domain(s) from Kubernetes")
 	}
@@ -209,7 +222,52 @@ func (h *Org) UnauthenticatedRoutes() []routing.Route {
 }
 
 func (h *Org) getDefaultIsolationSegment(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(struct{}{}), nil
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org.get-default-isolation-segment")
+
+	orgGUID := routing.URLParam(r, "guid")
+
+	org, err := h.orgRepo.GetOrg(r.Context(), authInfo, orgGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get org", "OrgGUID", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgDefaultIsolationSegment(org, h.apiBaseURL)), nil
+}
+
+func (h *Org) patchDefaultIsolationSegment(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org.patch-default-isolation-segment")
+
+	orgGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentAssign
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.orgRepo.GetOrg(r.Context(), authInfo, orgGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get org", "OrgGUID", orgGUID)
+	}
+
+	isolationSegmentGUID := payload.GUID()
+	if isolationSegmentGUID != "" {
+		err = ensureIsolationSegmentEntitled(r.Context(), authInfo, h.isolationSegmentRepo, isolationSegmentGUID, orgGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Isolation segment not entitled to org", "OrgGUID", orgGUID, "isolationSegmentGUID", isolationSegmentGUID)
+		}
+	}
+
+	org, err := h.orgRepo.PatchOrg(r.Context(), authInfo, repositories.PatchOrgMessage{
+		GUID:                        orgGUID,
+		DefaultIsolationSegmentGUID: &isolationSegmentGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch org", "OrgGUID", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgDefaultIsolationSegment(org, h.apiBaseURL)), nil
 }
 
 func (h *Org) AuthenticatedRoutes() []routing.Route {
@@ -222,6 +280,7 @@ func (h *Org) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: OrgDefaultDomainPath, Handler: h.defaultDomain},
 		{Method: "GET", Pattern: OrgPath, Handler: h.get},
 		{Method: "GET", Pattern: OrgDefaultIsolationSegmentPath, Handler: h.getDefaultIsolationSegment},
+		{Method: "PATCH", Pattern: OrgDefaultIsolationSegmentPath, Handler: h.patchDefaultIsolationSegment},
 	}
 }
 
@@ -0,0 +1,368 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OrgQuota", func() {
+	var (
+		requestMethod    string
+		requestPath      string
+		requestBody      string
+		orgQuotaRepo     *fake.CFOrgQuotaRepository
+		orgRepo          *fake.CFOrgRepository
+		requestValidator *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		orgQuotaRepo = new(fake.CFOrgQuotaRepository)
+		orgRepo = new(fake.CFOrgRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewOrgQuota(
+			*serverURL,
+			orgQuotaRepo,
+			orgRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(requestBody))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/organization_quotas", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/organization_quotas"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgQuotaCreate{
+				Name: "my-quota",
+				Apps: payloads.QuotaApps{
+					TotalMemoryInMB: tools.PtrTo[int64](1024),
+				},
+				Relationships: payloads.OrgQuotaRelationships{
+					Organizations: payloads.ToManyRelationship{Data: []payloads.RelationshipData{{GUID: "org1"}}},
+				},
+			})
+
+			orgRepo.ListOrgsReturns(repositories.ListResult[repositories.OrgRecord]{
+				Records: []repositories.OrgRecord{{GUID: "org1"}},
+			}, nil)
+
+			orgQuotaRepo.CreateOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID:     "quota-guid",
+				Name:     "my-quota",
+				OrgGUIDs: []string{"org1"},
+			}, nil)
+		})
+
+		It("validates the request", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("creates the org quota", func() {
+			Expect(orgRepo.ListOrgsCallCount()).To(Equal(1))
+			_, _, listOrgsMessage := orgRepo.ListOrgsArgsForCall(0)
+			Expect(listOrgsMessage.GUIDs).To(ConsistOf("org1"))
+
+			Expect(orgQuotaRepo.CreateOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := orgQuotaRepo.CreateOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Name).To(Equal("my-quota"))
+			Expect(message.Limits.Apps.TotalMemoryInMB).To(Equal(tools.PtrTo[int64](1024)))
+			Expect(message.Limits.Services.PaidServicesAllowed).To(BeTrue())
+			Expect(message.OrgGUIDs).To(ConsistOf("org1"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.name", "my-quota"),
+				MatchJSONPath("$.relationships.organizations.data[0].guid", "org1"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/organization_quotas/quota-guid"),
+			)))
+		})
+
+		When("an org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.ListOrgsReturns(repositories.ListResult[repositories.OrgRecord]{}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Organization does not exist, or you do not have access.")
+				Expect(orgQuotaRepo.CreateOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("creating the org quota fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.CreateOrgQuotaReturns(repositories.OrgQuotaRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/organization_quotas/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/organization_quotas/quota-guid"
+
+			orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID: "quota-guid",
+				Name: "my-quota",
+			}, nil)
+		})
+
+		It("returns the org quota", func() {
+			Expect(orgQuotaRepo.GetOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := orgQuotaRepo.GetOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.name", "my-quota"),
+			)))
+		})
+
+		When("the org quota is forbidden", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/organization_quotas", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/organization_quotas"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.OrgQuotaList{
+				Names:    "q1,q2",
+				OrgGUIDs: "org1",
+			})
+
+			orgQuotaRepo.ListOrgQuotasReturns(repositories.ListResult[repositories.OrgQuotaRecord]{
+				Records: []repositories.OrgQuotaRecord{
+					{GUID: "q1-guid", Name: "q1"},
+					{GUID: "q2-guid", Name: "q2"},
+				},
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 2,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     2,
+				},
+			}, nil)
+		})
+
+		It("lists the org quotas", func() {
+			Expect(orgQuotaRepo.ListOrgQuotasCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := orgQuotaRepo.ListOrgQuotasArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("q1", "q2"))
+			Expect(message.OrgGUIDs).To(ConsistOf("org1"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "q1-guid"),
+				MatchJSONPath("$.resources[1].guid", "q2-guid"),
+			)))
+		})
+
+		When("listing the org quotas fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.ListOrgQuotasReturns(repositories.ListResult[repositories.OrgQuotaRecord]{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/organization_quotas/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/organization_quotas/quota-guid"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgQuotaUpdate{
+				Name: tools.PtrTo("new-name"),
+				Routes: payloads.QuotaRoutes{
+					TotalRoutes: tools.PtrTo[int32](5),
+				},
+			})
+
+			orgQuotaRepo.UpdateOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID: "quota-guid",
+				Name: "new-name",
+			}, nil)
+		})
+
+		It("updates the org quota", func() {
+			Expect(orgQuotaRepo.UpdateOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := orgQuotaRepo.UpdateOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdateOrgQuotaMessage{
+				GUID: "quota-guid",
+				Name: tools.PtrTo("new-name"),
+				Limits: repositories.QuotaLimitsPatch{
+					TotalRoutes: tools.PtrTo[int32](5),
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "new-name")))
+		})
+
+		When("the org quota does not exist", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+				Expect(orgQuotaRepo.UpdateOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /v3/organization_quotas/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/organization_quotas/quota-guid"
+		})
+
+		It("deletes the org quota", func() {
+			Expect(orgQuotaRepo.DeleteOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := orgQuotaRepo.DeleteOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/organization_quota.delete~quota-guid"))
+		})
+
+		When("the org quota does not exist", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+				Expect(orgQuotaRepo.DeleteOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("deleting the org quota fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.DeleteOrgQuotaReturns(errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/organization_quotas/{guid}/relationships/organizations", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/organization_quotas/quota-guid/relationships/organizations"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgQuotaApply{
+				Data: []payloads.RelationshipData{{GUID: "org1"}, {GUID: "org2"}},
+			})
+			orgRepo.ListOrgsReturns(repositories.ListResult[repositories.OrgRecord]{
+				Records: []repositories.OrgRecord{{GUID: "org1"}, {GUID: "org2"}},
+			}, nil)
+			orgQuotaRepo.ApplyOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID:     "quota-guid",
+				OrgGUIDs: []string{"org1", "org2"},
+			}, nil)
+		})
+
+		It("applies the org quota to the orgs", func() {
+			Expect(orgQuotaRepo.ApplyOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := orgQuotaRepo.ApplyOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ApplyOrgQuotaMessage{
+				GUID:     "quota-guid",
+				OrgGUIDs: []string{"org1", "org2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data", HaveLen(2)),
+				MatchJSONPath("$.data[0].guid", "org1"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/organization_quotas/quota-guid/relationships/organizations"),
+			)))
+		})
+
+		When("an org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.ListOrgsReturns(repositories.ListResult[repositories.OrgRecord]{
+					Records: []repositories.OrgRecord{{GUID: "org1"}},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Organization does not exist, or you do not have access.")
+				Expect(orgQuotaRepo.ApplyOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("the org quota does not exist", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+			})
+		})
+	})
+})
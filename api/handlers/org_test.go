@@ -22,11 +22,13 @@ import (
 
 var _ = Describe("Org", func() {
 	var (
-		apiHandler       *handlers.Org
-		orgRepo          *fake.CFOrgRepository
-		now              time.Time
-		domainRepo       *fake.CFDomainRepository
-		requestValidator *fake.RequestValidator
+		apiHandler           *handlers.Org
+		orgRepo              *fake.CFOrgRepository
+		now                  time.Time
+		domainRepo           *fake.CFDomainRepository
+		isolationSegmentRepo *fake.CFIsolationSegmentRepository
+		requestValidator     *fake.RequestValidator
+		auditEventRecorder   *fake.AuditEventRecorder
 	)
 
 	BeforeEach(func() {
@@ -34,9 +36,11 @@ var _ = Describe("Org", func() {
 
 		orgRepo = new(fake.CFOrgRepository)
 		domainRepo = new(fake.CFDomainRepository)
+		isolationSegmentRepo = new(fake.CFIsolationSegmentRepository)
 		requestValidator = new(fake.RequestValidator)
+		auditEventRecorder = new(fake.AuditEventRecorder)
 
-		apiHandler = handlers.NewOrg(*serverURL, orgRepo, domainRepo, requestValidator, time.Hour, "the-default.domain")
+		apiHandler = handlers.NewOrg(*serverURL, orgRepo, domainRepo, isolationSegmentRepo, requestValidator, time.Hour, "the-default.domain", auditEventRecorder)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -97,6 +101,21 @@ var _ = Describe("Org", func() {
 			)))
 		})
 
+		It("records an org create audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.CreateAuditEventMessage{
+				Type: "audit.organization.create",
+				Target: repositories.AuditEventTarget{
+					GUID: "org-guid",
+					Type: "organization",
+					Name: "new-org",
+				},
+				OrganizationGUID: "org-guid",
+			}))
+		})
+
 		When("the org repo returns an error", func() {
 			BeforeEach(func() {
 				orgRepo.CreateOrgReturns(repositories.OrgRecord{}, errors.New("boom"))
@@ -105,6 +124,10 @@ var _ = Describe("Org", func() {
 			It("returns unknown error", func() {
 				expectUnknownError()
 			})
+
+			It("does not record an audit event", func() {
+				Expect(auditEventRecorder.CreateAuditEventCallCount()).To(BeZero())
+			})
 		})
 
 		When("the request body is invalid", func() {
@@ -240,8 +263,9 @@ var _ = Describe("Org", func() {
 
 			newOrgName := tools.PtrTo("new-org-name")
 			orgRepo.PatchOrgReturns(repositories.OrgRecord{
-				GUID: "org-guid",
-				Name: *newOrgName,
+				GUID:      "org-guid",
+				Name:      *newOrgName,
+				Suspended: true,
 				Labels: map[string]string{
 					"env":                           "production",
 					"foo.example.com/my-identifier": "aruba",
@@ -255,7 +279,8 @@ var _ = Describe("Org", func() {
 			requestBody = "the-json-body"
 
 			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgPatch{
-				Name: newOrgName,
+				Name:      newOrgName,
+				Suspended: tools.PtrTo(true),
 				Metadata: payloads.MetadataPatch{
 					Annotations: map[string]*string{
 						"hello":                       tools.PtrTo("there"),
@@ -278,6 +303,7 @@ var _ = Describe("Org", func() {
 			_, _, msg := orgRepo.PatchOrgArgsForCall(0)
 			Expect(msg.GUID).To(Equal("org-guid"))
 			Expect(msg.Name).To(PointTo(Equal("new-org-name")))
+			Expect(msg.Suspended).To(PointTo(BeTrue()))
 			Expect(msg.Annotations).To(HaveKeyWithValue("hello", PointTo(Equal("there"))))
 			Expect(msg.Annotations).To(HaveKeyWithValue("foo.example.com/lorem-ipsum", PointTo(Equal("Lorem ipsum."))))
 			Expect(msg.Labels).To(HaveKeyWithValue("env", PointTo(Equal("production"))))
@@ -287,6 +313,7 @@ var _ = Describe("Org", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.name", "new-org-name"),
+				MatchJSONPath("$.suspended", BeTrue()),
 				MatchJSONPath("$.metadata.annotations", map[string]any{
 					"hello":                       "there",
 					"foo.example.com/lorem-ipsum": "Lorem ipsum.",
@@ -421,7 +448,8 @@ var _ = Describe("Org", func() {
 			_, actualAuthInfo, message := domainRepo.ListDomainsArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
 			Expect(message).To(Equal(repositories.ListDomainsMessage{
-				Pagination: repositories.Pagination{PerPage: 50, Page: 1},
+				VisibleToOrgGUID: "org-guid",
+				Pagination:       repositories.Pagination{PerPage: 50, Page: 1},
 			}))
 
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
@@ -446,9 +474,10 @@ var _ = Describe("Org", func() {
 				Expect(domainRepo.ListDomainsCallCount()).To(Equal(1))
 				_, _, message := domainRepo.ListDomainsArgsForCall(0)
 				Expect(message).To(Equal(repositories.ListDomainsMessage{
-					Names:      []string{"example.org", "another.org"},
-					OrderBy:    "created_at",
-					Pagination: repositories.Pagination{PerPage: 16, Page: 32},
+					Names:            []string{"example.org", "another.org"},
+					VisibleToOrgGUID: "org-guid",
+					OrderBy:          "created_at",
+					Pagination:       repositories.Pagination{PerPage: 16, Page: 32},
 				}))
 			})
 		})
@@ -623,4 +652,141 @@ var _ = Describe("Org", func() {
 			})
 		})
 	})
+
+	Describe("get the default isolation segment of an org", func() {
+		BeforeEach(func() {
+			orgRepo.GetOrgReturns(repositories.OrgRecord{
+				GUID:                        "org-guid",
+				DefaultIsolationSegmentGUID: "segment-guid",
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequestWithContext(ctx, http.MethodGet, "/v3/organizations/org-guid/relationships/default_isolation_segment", nil)
+			Expect(err).NotTo(HaveOccurred())
+			routerBuilder.Build().ServeHTTP(rr, request)
+		})
+
+		It("returns the default isolation segment relationship", func() {
+			Expect(orgRepo.GetOrgCallCount()).To(Equal(1))
+			_, _, actualOrgGUID := orgRepo.GetOrgArgsForCall(0)
+			Expect(actualOrgGUID).To(Equal("org-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data.guid", "segment-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/organizations/org-guid/relationships/default_isolation_segment"),
+			)))
+		})
+
+		When("getting the org is forbidden", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgResourceType)
+			})
+		})
+	})
+
+	Describe("patch the default isolation segment of an org", func() {
+		BeforeEach(func() {
+			orgRepo.GetOrgReturns(repositories.OrgRecord{GUID: "org-guid"}, nil)
+			isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID:     "segment-guid",
+				OrgGUIDs: []string{"org-guid"},
+			}, nil)
+			orgRepo.PatchOrgReturns(repositories.OrgRecord{
+				GUID:                        "org-guid",
+				DefaultIsolationSegmentGUID: "segment-guid",
+			}, nil)
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentAssign{
+				Data: &payloads.RelationshipData{GUID: "segment-guid"},
+			})
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequestWithContext(ctx, http.MethodPatch, "/v3/organizations/org-guid/relationships/default_isolation_segment", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+			routerBuilder.Build().ServeHTTP(rr, request)
+		})
+
+		It("sets the default isolation segment of the org", func() {
+			Expect(isolationSegmentRepo.GetIsolationSegmentCallCount()).To(Equal(1))
+			_, _, actualSegmentGUID := isolationSegmentRepo.GetIsolationSegmentArgsForCall(0)
+			Expect(actualSegmentGUID).To(Equal("segment-guid"))
+
+			Expect(orgRepo.PatchOrgCallCount()).To(Equal(1))
+			_, _, message := orgRepo.PatchOrgArgsForCall(0)
+			Expect(message.GUID).To(Equal("org-guid"))
+			Expect(message.DefaultIsolationSegmentGUID).To(PointTo(Equal("segment-guid")))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data.guid", "segment-guid"),
+				MatchJSONPath("$.links.related.href", "https://api.example.org/v3/isolation_segments/segment-guid"),
+			)))
+		})
+
+		When("the data is null", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentAssign{})
+				orgRepo.PatchOrgReturns(repositories.OrgRecord{GUID: "org-guid"}, nil)
+			})
+
+			It("unsets the default isolation segment", func() {
+				Expect(isolationSegmentRepo.GetIsolationSegmentCallCount()).To(BeZero())
+
+				Expect(orgRepo.PatchOrgCallCount()).To(Equal(1))
+				_, _, message := orgRepo.PatchOrgArgsForCall(0)
+				Expect(message.DefaultIsolationSegmentGUID).To(PointTo(BeEmpty()))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data", BeNil())))
+			})
+		})
+
+		When("the isolation segment is not entitled to the org", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{GUID: "segment-guid"}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(orgRepo.PatchOrgCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Unable to assign isolation segment with guid 'segment-guid'. Ensure it has been entitled to the organization.")
+			})
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewNotFoundError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to assign isolation segment with guid 'segment-guid'. Ensure it has been entitled to the organization.")
+			})
+		})
+
+		When("the org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgResourceType)
+			})
+		})
+
+		When("patching the org fails", func() {
+			BeforeEach(func() {
+				orgRepo.PatchOrgReturns(repositories.OrgRecord{}, errors.New("patch-err"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 
@@ -46,6 +48,7 @@ type Package struct {
 	appRepo             CFAppRepository
 	dropletRepo         CFDropletRepository
 	imageRepo           ImageRepository
+	resourceCacheRepo   ResourceCacheRepository
 	requestValidator    RequestValidator
 	registrySecretNames []string
 }
@@ -56,6 +59,7 @@ func NewPackage(
 	appRepo CFAppRepository,
 	dropletRepo CFDropletRepository,
 	imageRepo ImageRepository,
+	resourceCacheRepo ResourceCacheRepository,
 	requestValidator RequestValidator,
 	registrySecretNames []string,
 ) *Package {
@@ -65,6 +69,7 @@ func NewPackage(
 		appRepo:             appRepo,
 		dropletRepo:         dropletRepo,
 		imageRepo:           imageRepo,
+		resourceCacheRepo:   resourceCacheRepo,
 		registrySecretNames: registrySecretNames,
 		requestValidator:    requestValidator,
 	}
@@ -162,11 +167,26 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form"), "Error parsing multipart form")
 	}
 
-	bitsFile, _, err := r.FormFile("bits")
-	if err != nil {
+	bitsFile, bitsHeader, err := r.FormFile("bits")
+	if err != nil && !errors.Is(err, http.ErrMissingFile) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Upload must include bits"), "Error reading form file \"bits\"")
 	}
-	defer bitsFile.Close()
+	if bitsFile != nil {
+		defer func() {
+			if bitsFile != nil {
+				bitsFile.Close()
+			}
+		}()
+	}
+
+	var payload payloads.PackageUpload
+	if err = h.requestValidator.DecodeAndValidateURLValues(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if bitsFile == nil && len(payload.Resources) == 0 {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(http.ErrMissingFile, "Upload must include bits"), "Error reading form file \"bits\"")
+	}
 
 	packageRecord, err := h.packageRepo.GetPackage(r.Context(), authInfo, packageGUID)
 	if err != nil {
@@ -185,7 +205,27 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewPackageBitsAlreadyUploadedError(err), "Error, cannot call package upload state was not AWAITING_UPLOAD", "packageGUID", packageGUID)
 	}
 
-	uploadedImageRef, err := h.imageRepo.UploadSourceImage(r.Context(), authInfo, packageRecord.ImageRef, bitsFile, packageRecord.SpaceGUID, packageGUID)
+	var bitsSize int64
+	var srcReader io.Reader = bitsFile
+	if bitsFile != nil {
+		bitsSize = bitsHeader.Size
+	}
+
+	if len(payload.Resources) > 0 {
+		var bitsReaderAt io.ReaderAt
+		if bitsFile != nil {
+			bitsReaderAt = bitsFile
+		}
+
+		mergedBits, mergeErr := h.resourceCacheRepo.MergeResources(r.Context(), bitsReaderAt, bitsSize, payload.ToRecords())
+		if mergeErr != nil {
+			return nil, apierrors.LogAndReturn(logger, mergeErr, "Error merging cached resources into the bits")
+		}
+		defer mergedBits.Close()
+		srcReader = mergedBits
+	}
+
+	uploadedImageRef, err := h.imageRepo.UploadSourceImage(r.Context(), authInfo, packageRecord.ImageRef, srcReader, packageRecord.SpaceGUID, packageGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error calling uploadSourceImage")
 	}
@@ -200,9 +240,31 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdatePackageSource")
 	}
 
+	if bitsFile != nil {
+		h.cacheResources(r.Context(), bitsFile, bitsSize)
+		bitsFile = nil
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPackage(packageRecord, h.serverURL)), nil
 }
 
+// cacheResources caches the uploaded bits in the background so that pushing
+// them to the resource cache does not delay the upload response. It takes
+// over closing the bits file. The multipart temp file backing it is removed
+// once the request is done, but remains readable while it is open.
+func (h Package) cacheResources(ctx context.Context, bitsFile multipart.File, bitsSize int64) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("handlers.package.cache-resources")
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		defer bitsFile.Close()
+
+		if err := h.resourceCacheRepo.CacheResources(ctx, bitsFile, bitsSize); err != nil {
+			logger.Info("failed to cache the uploaded resources", "reason", err)
+		}
+	}()
+}
+
 func (h Package) listDroplets(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.list-droplets")
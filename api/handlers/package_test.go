@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -30,6 +31,7 @@ var _ = Describe("Package", func() {
 		appRepo                     *fake.CFAppRepository
 		dropletRepo                 *fake.CFDropletRepository
 		imageRepo                   *fake.ImageRepository
+		resourceCacheRepo           *fake.ResourceCacheRepository
 		requestValidator            *fake.RequestValidator
 		packageImagePullSecretNames []string
 
@@ -45,6 +47,7 @@ var _ = Describe("Package", func() {
 		appRepo = new(fake.CFAppRepository)
 		dropletRepo = new(fake.CFDropletRepository)
 		imageRepo = new(fake.ImageRepository)
+		resourceCacheRepo = new(fake.ResourceCacheRepository)
 		requestValidator = new(fake.RequestValidator)
 		packageImagePullSecretNames = []string{"package-image-pull-secret"}
 
@@ -60,6 +63,7 @@ var _ = Describe("Package", func() {
 			appRepo,
 			dropletRepo,
 			imageRepo,
+			resourceCacheRepo,
 			requestValidator,
 			packageImagePullSecretNames,
 		)
@@ -556,6 +560,134 @@ var _ = Describe("Package", func() {
 			})
 		}
 
+		It("does not merge any cached resources", func() {
+			Expect(resourceCacheRepo.MergeResourcesCallCount()).To(BeZero())
+		})
+
+		Describe("caching the uploaded resources", func() {
+			var (
+				releaseCaching chan struct{}
+				cachedContents chan string
+			)
+
+			BeforeEach(func() {
+				releaseCaching = make(chan struct{})
+				cachedContents = make(chan string, 1)
+
+				resourceCacheRepo.CacheResourcesStub = func(_ context.Context, bits io.ReaderAt, bitsSize int64) error {
+					<-releaseCaching
+
+					contents := make([]byte, bitsSize)
+					if _, err := bits.ReadAt(contents, 0); err != nil {
+						return err
+					}
+					cachedContents <- string(contents)
+					return nil
+				}
+			})
+
+			It("caches the uploaded resources in the background", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Eventually(resourceCacheRepo.CacheResourcesCallCount).Should(Equal(1))
+
+				close(releaseCaching)
+				Eventually(cachedContents).Should(Receive(Equal("the-src-file-contents")))
+			})
+		})
+
+		When("caching the uploaded resources fails", func() {
+			BeforeEach(func() {
+				resourceCacheRepo.CacheResourcesReturns(errors.New("cache-err"))
+			})
+
+			It("still uploads the package", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(1))
+				Eventually(resourceCacheRepo.CacheResourcesCallCount).Should(Equal(1))
+			})
+		})
+
+		When("the upload refers to cached resources", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.PackageUpload{
+					Resources: []payloads.Resource{{
+						Checksum:    payloads.ResourceChecksum{Value: "a9993e364706816aba3e25717850c26c9cd0d89d"},
+						SizeInBytes: 200000,
+						Path:        "cached/file",
+						Mode:        "644",
+					}},
+				})
+
+				resourceCacheRepo.MergeResourcesReturns(io.NopCloser(strings.NewReader("the-merged-contents")), nil)
+			})
+
+			It("merges the cached resources into the bits", func() {
+				Expect(resourceCacheRepo.MergeResourcesCallCount()).To(Equal(1))
+				_, actualBits, actualBitsSize, actualResources := resourceCacheRepo.MergeResourcesArgsForCall(0)
+				Expect(actualBits).NotTo(BeNil())
+				Expect(actualBitsSize).To(BeEquivalentTo(len("the-src-file-contents")))
+				Expect(actualResources).To(Equal([]repositories.ResourceRecord{{
+					SHA1:        "a9993e364706816aba3e25717850c26c9cd0d89d",
+					SizeInBytes: 200000,
+					Path:        "cached/file",
+					Mode:        "644",
+				}}))
+			})
+
+			It("uploads the merged bits", func() {
+				Expect(imageRepo.UploadSourceImageCallCount()).To(Equal(1))
+				_, _, _, srcFile, _, _ := imageRepo.UploadSourceImageArgsForCall(0)
+				actualSrcContents, err := io.ReadAll(srcFile)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(actualSrcContents)).To(Equal("the-merged-contents"))
+			})
+
+			When("no bits file is given", func() {
+				BeforeEach(func() {
+					var b bytes.Buffer
+					writer := multipart.NewWriter(&b)
+					Expect(writer.Close()).To(Succeed())
+					body = &b
+					formDataHeader = writer.FormDataContentType()
+				})
+
+				It("builds the package from the cached resources only", func() {
+					Expect(resourceCacheRepo.MergeResourcesCallCount()).To(Equal(1))
+					_, actualBits, actualBitsSize, _ := resourceCacheRepo.MergeResourcesArgsForCall(0)
+					Expect(actualBits).To(BeNil())
+					Expect(actualBitsSize).To(BeZero())
+
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				})
+
+				It("does not cache any resources", func() {
+					Expect(resourceCacheRepo.CacheResourcesCallCount()).To(BeZero())
+				})
+			})
+
+			When("merging the resources fails", func() {
+				BeforeEach(func() {
+					resourceCacheRepo.MergeResourcesReturns(nil, apierrors.NewUnprocessableEntityError(nil, "resource not cached"))
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("resource not cached")
+				})
+				itDoesntUploadSourceImage()
+				itDoesntUpdateAnyPackages()
+			})
+		})
+
+		When("the form fields are invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid resources"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid resources")
+			})
+		})
+
 		When("getting the package is forbidden", func() {
 			BeforeEach(func() {
 				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewForbiddenError(errors.New("Forbidden"), repositories.PackageResourceType))
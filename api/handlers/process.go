@@ -26,6 +26,9 @@ const (
 	ProcessStatsPath           = "/v3/processes/{guid}/stats"
 	ProcessesPath              = "/v3/processes"
 	ProcessInstanceRestartPath = "/v3/processes/{guid}/instances/{instanceID}"
+	ProcessAutoscalingPath     = "/v3/processes/{guid}/autoscaling_policy"
+
+	processAutoscalingPolicyResourceType = "Autoscaling Policy"
 )
 
 //counterfeiter:generate -o fake -fake-name CFProcessRepository . CFProcessRepository
@@ -36,6 +39,7 @@ type CFProcessRepository interface {
 	PatchProcess(context.Context, authorization.Info, repositories.PatchProcessMessage) (repositories.ProcessRecord, error)
 	CreateProcess(context.Context, authorization.Info, repositories.CreateProcessMessage) error
 	ScaleProcess(ctx context.Context, authInfo authorization.Info, scaleProcessMessage repositories.ScaleProcessMessage) (repositories.ProcessRecord, error)
+	SetProcessAutoscaling(context.Context, authorization.Info, repositories.SetProcessAutoscalingMessage) (repositories.ProcessRecord, error)
 }
 
 //counterfeiter:generate -o fake -fake-name GaugesCollector . GaugesCollector
@@ -51,6 +55,7 @@ type InstancesStateCollector interface {
 type Process struct {
 	serverURL               url.URL
 	processRepo             CFProcessRepository
+	sidecarRepo             CFSidecarRepository
 	requestValidator        RequestValidator
 	podRepo                 PodRepository
 	gaugesCollector         GaugesCollector
@@ -60,6 +65,7 @@ type Process struct {
 func NewProcess(
 	serverURL url.URL,
 	processRepo CFProcessRepository,
+	sidecarRepo CFSidecarRepository,
 	requestValidator RequestValidator,
 	podRepo PodRepository,
 	gaugesCollector GaugesCollector,
@@ -68,6 +74,7 @@ func NewProcess(
 	return &Process{
 		serverURL:               serverURL,
 		processRepo:             processRepo,
+		sidecarRepo:             sidecarRepo,
 		requestValidator:        requestValidator,
 		podRepo:                 podRepo,
 		gaugesCollector:         gaugesCollector,
@@ -140,26 +147,17 @@ func (h *Process) getSidecars(r *http.Request) (*routing.Response, error) {
 
 	processGUID := routing.URLParam(r, "guid")
 
-	_, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(map[string]interface{}{
-		"pagination": map[string]interface{}{
-			"total_results": 0,
-			"total_pages":   1,
-			"first": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"last": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"next":     nil,
-			"previous": nil,
-		},
-		"resources": []string{},
-	}), nil
+	sidecars, err := h.sidecarRepo.ListProcessSidecars(r.Context(), authInfo, process.AppGUID, process.Type)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list process sidecars", "ProcessGUID", processGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSidecar, sidecars, h.serverURL, *r.URL)), nil
 }
 
 func (h *Process) scale(r *http.Request) (*routing.Response, error) {
@@ -255,6 +253,70 @@ func (h *Process) update(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcess(updatedProcess, h.serverURL)), nil
 }
 
+func (h *Process) getAutoscalingPolicy(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.process.get-autoscaling-policy")
+
+	processGUID := routing.URLParam(r, "guid")
+
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
+	}
+
+	if process.Autoscaling == nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewNotFoundError(nil, processAutoscalingPolicyResourceType), "Process has no autoscaling policy", "ProcessGUID", processGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcessAutoscalingPolicy(process, h.serverURL)), nil
+}
+
+func (h *Process) setAutoscalingPolicy(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.process.set-autoscaling-policy")
+
+	processGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.ProcessAutoscalingPolicy
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
+	}
+
+	updatedProcess, err := h.processRepo.SetProcessAutoscaling(r.Context(), authInfo, payload.ToMessage(process.GUID, process.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to set process autoscaling policy", "ProcessGUID", processGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForProcessAutoscalingPolicy(updatedProcess, h.serverURL)), nil
+}
+
+func (h *Process) deleteAutoscalingPolicy(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.process.delete-autoscaling-policy")
+
+	processGUID := routing.URLParam(r, "guid")
+
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
+	}
+
+	_, err = h.processRepo.SetProcessAutoscaling(r.Context(), authInfo, repositories.SetProcessAutoscalingMessage{
+		ProcessGUID: process.GUID,
+		SpaceGUID:   process.SpaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete process autoscaling policy", "ProcessGUID", processGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
 func (h *Process) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -268,5 +330,8 @@ func (h *Process) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: ProcessesPath, Handler: h.list},
 		{Method: "PATCH", Pattern: ProcessPath, Handler: h.update},
 		{Method: "DELETE", Pattern: ProcessInstanceRestartPath, Handler: h.restartProcessInstance},
+		{Method: "GET", Pattern: ProcessAutoscalingPath, Handler: h.getAutoscalingPolicy},
+		{Method: "PUT", Pattern: ProcessAutoscalingPath, Handler: h.setAutoscalingPolicy},
+		{Method: "DELETE", Pattern: ProcessAutoscalingPath, Handler: h.deleteAutoscalingPolicy},
 	}
 }
@@ -23,6 +23,7 @@ import (
 var _ = Describe("Process", func() {
 	var (
 		processRepo             *fake.CFProcessRepository
+		sidecarRepo             *fake.CFSidecarRepository
 		requestValidator        *fake.RequestValidator
 		podRepo                 *fake.PodRepository
 		gaugesCollector         *fake.GaugesCollector
@@ -31,6 +32,7 @@ var _ = Describe("Process", func() {
 
 	BeforeEach(func() {
 		processRepo = new(fake.CFProcessRepository)
+		sidecarRepo = new(fake.CFSidecarRepository)
 		requestValidator = new(fake.RequestValidator)
 		podRepo = new(fake.PodRepository)
 		gaugesCollector = new(fake.GaugesCollector)
@@ -39,6 +41,7 @@ var _ = Describe("Process", func() {
 		apiHandler := NewProcess(
 			*serverURL,
 			processRepo,
+			sidecarRepo,
 			requestValidator,
 			podRepo,
 			gaugesCollector,
@@ -98,7 +101,19 @@ var _ = Describe("Process", func() {
 
 	Describe("the GET /v3/processes/:guid/sidecars endpoint", func() {
 		BeforeEach(func() {
-			processRepo.GetProcessReturns(repositories.ProcessRecord{}, nil)
+			processRepo.GetProcessReturns(repositories.ProcessRecord{
+				GUID:    "process-guid",
+				AppGUID: "app-guid",
+				Type:    "web",
+			}, nil)
+			sidecarRepo.ListProcessSidecarsReturns(repositories.ListResult[repositories.SidecarRecord]{
+				Records: []repositories.SidecarRecord{{
+					GUID:         "sidecar-guid",
+					Name:         "my-sidecar",
+					ProcessTypes: []string{"web"},
+				}},
+				PageInfo: descriptors.SinglePageInfo(1, 1),
+			}, nil)
 		})
 
 		JustBeforeEach(func() {
@@ -107,18 +122,37 @@ var _ = Describe("Process", func() {
 			routerBuilder.Build().ServeHTTP(rr, req)
 		})
 
-		It("returns the empty list of sidecars", func() {
+		It("returns the sidecars of the process", func() {
 			Expect(processRepo.GetProcessCallCount()).To(Equal(1))
 			_, actualAuthInfo, _ := processRepo.GetProcessArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
 
+			Expect(sidecarRepo.ListProcessSidecarsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID, actualProcessType := sidecarRepo.ListProcessSidecarsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal("app-guid"))
+			Expect(actualProcessType).To(Equal("web"))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.total_results", BeZero()),
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/processes/process-guid/sidecars?page=1&per_page=1"),
+				MatchJSONPath("$.resources[0].guid", "sidecar-guid"),
+				MatchJSONPath("$.resources[0].name", "my-sidecar"),
 			)))
 		})
 
+		When("listing the sidecars fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListProcessSidecarsReturns(repositories.ListResult[repositories.SidecarRecord]{}, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
 		When("the process isn't accessible to the user", func() {
 			BeforeEach(func() {
 				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
@@ -575,4 +609,203 @@ var _ = Describe("Process", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/processes/:guid/autoscaling_policy endpoint", func() {
+		BeforeEach(func() {
+			processRepo.GetProcessReturns(repositories.ProcessRecord{
+				GUID:      "process-guid",
+				SpaceGUID: spaceGUID,
+				Autoscaling: &repositories.ProcessAutoscaling{
+					MinInstances: 1,
+					MaxInstances: 4,
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/processes/process-guid/autoscaling_policy", nil)
+			Expect(err).NotTo(HaveOccurred())
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("returns the autoscaling policy", func() {
+			Expect(processRepo.GetProcessCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualProcessGUID := processRepo.GetProcessArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualProcessGUID).To(Equal("process-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.min_instances", BeEquivalentTo(1)),
+				MatchJSONPath("$.max_instances", BeEquivalentTo(4)),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/processes/process-guid/autoscaling_policy"),
+			)))
+		})
+
+		When("the process has no autoscaling policy", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{GUID: "process-guid"}, nil)
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Autoscaling Policy")
+			})
+		})
+
+		When("the user does not have permissions to get the process", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, "Process"))
+			})
+
+			It("returns a NotFound error", func() {
+				expectNotFoundError("Process")
+			})
+		})
+	})
+
+	Describe("the PUT /v3/processes/:guid/autoscaling_policy endpoint", func() {
+		BeforeEach(func() {
+			processRepo.GetProcessReturns(repositories.ProcessRecord{
+				GUID:      "process-guid",
+				SpaceGUID: spaceGUID,
+			}, nil)
+
+			processRepo.SetProcessAutoscalingReturns(repositories.ProcessRecord{
+				GUID: "process-guid",
+				Autoscaling: &repositories.ProcessAutoscaling{
+					MinInstances: 2,
+					MaxInstances: 5,
+				},
+			}, nil)
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ProcessAutoscalingPolicy{
+				MinInstances:          2,
+				MaxInstances:          5,
+				CPUUtilizationPercent: tools.PtrTo[int32](70),
+			})
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "PUT", "/v3/processes/process-guid/autoscaling_policy", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("sets the autoscaling policy", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(processRepo.SetProcessAutoscalingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := processRepo.SetProcessAutoscalingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.SetProcessAutoscalingMessage{
+				ProcessGUID: "process-guid",
+				SpaceGUID:   spaceGUID,
+				Autoscaling: &repositories.ProcessAutoscaling{
+					MinInstances:          2,
+					MaxInstances:          5,
+					CPUUtilizationPercent: tools.PtrTo[int32](70),
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.min_instances", BeEquivalentTo(2)),
+				MatchJSONPath("$.max_instances", BeEquivalentTo(5)),
+			)))
+		})
+
+		When("the request JSON is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(processRepo.SetProcessAutoscalingCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user does not have permissions to get the process", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, "Process"))
+			})
+
+			It("returns a NotFound error", func() {
+				expectNotFoundError("Process")
+			})
+		})
+
+		When("the maximum instances exceed the quota", func() {
+			BeforeEach(func() {
+				processRepo.SetProcessAutoscalingReturns(repositories.ProcessRecord{}, apierrors.NewUnprocessableEntityError(
+					nil,
+					"You have exceeded your space's memory limit: app requested more memory than available",
+				))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("You have exceeded your space's memory limit: app requested more memory than available")
+			})
+		})
+
+		When("setting the policy fails", func() {
+			BeforeEach(func() {
+				processRepo.SetProcessAutoscalingReturns(repositories.ProcessRecord{}, errors.New("unknown!"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/processes/:guid/autoscaling_policy endpoint", func() {
+		BeforeEach(func() {
+			processRepo.GetProcessReturns(repositories.ProcessRecord{
+				GUID:      "process-guid",
+				SpaceGUID: spaceGUID,
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "DELETE", "/v3/processes/process-guid/autoscaling_policy", nil)
+			Expect(err).NotTo(HaveOccurred())
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("removes the autoscaling policy", func() {
+			Expect(processRepo.SetProcessAutoscalingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := processRepo.SetProcessAutoscalingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.SetProcessAutoscalingMessage{
+				ProcessGUID: "process-guid",
+				SpaceGUID:   spaceGUID,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the user does not have permissions to get the process", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, "Process"))
+			})
+
+			It("returns a NotFound error", func() {
+				expectNotFoundError("Process")
+			})
+		})
+
+		When("removing the policy fails", func() {
+			BeforeEach(func() {
+				processRepo.SetProcessAutoscalingReturns(repositories.ProcessRecord{}, errors.New("unknown!"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
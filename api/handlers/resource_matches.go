@@ -1,25 +1,61 @@
 package handlers
 
 import (
+	"context"
+	"io"
 	"net/http"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
 )
 
 const (
 	ResourceMatchesPath = "/v3/resource_matches"
 )
 
-type ResourceMatches struct{}
+//counterfeiter:generate -o fake -fake-name ResourceCacheRepository . ResourceCacheRepository
+type ResourceCacheRepository interface {
+	MatchResources(context.Context, authorization.Info, []repositories.ResourceRecord) ([]repositories.ResourceRecord, error)
+	CacheResources(ctx context.Context, bits io.ReaderAt, bitsSize int64) error
+	MergeResources(ctx context.Context, bits io.ReaderAt, bitsSize int64, resources []repositories.ResourceRecord) (io.ReadCloser, error)
+}
 
-func NewResourceMatches() *ResourceMatches {
-	return &ResourceMatches{}
+type ResourceMatches struct {
+	resourceCacheRepo ResourceCacheRepository
+	requestValidator  RequestValidator
+}
+
+func NewResourceMatches(
+	resourceCacheRepo ResourceCacheRepository,
+	requestValidator RequestValidator,
+) *ResourceMatches {
+	return &ResourceMatches{
+		resourceCacheRepo: resourceCacheRepo,
+		requestValidator:  requestValidator,
+	}
 }
 
 func (h *ResourceMatches) create(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusCreated).WithBody(map[string]interface{}{
-		"resources": []interface{}{},
-	}), nil
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.resource-matches.create")
+
+	var payload payloads.ResourceMatchCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	matchedResources, err := h.resourceCacheRepo.MatchResources(r.Context(), authInfo, payload.ToRecords())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to match resources")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForResourceMatches(matchedResources)), nil
 }
 
 func (h *ResourceMatches) UnauthenticatedRoutes() []routing.Route {
@@ -1,20 +1,32 @@
 package handlers_test
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("ResourceMatches", func() {
-	var req *http.Request
+	var (
+		req               *http.Request
+		resourceCacheRepo *fake.ResourceCacheRepository
+		requestValidator  *fake.RequestValidator
+	)
 
 	BeforeEach(func() {
-		apiHandler := NewResourceMatches()
+		resourceCacheRepo = new(fake.ResourceCacheRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewResourceMatches(resourceCacheRepo, requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -22,19 +34,113 @@ var _ = Describe("ResourceMatches", func() {
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
-	Describe("Get Resource Match Endpoint", func() {
+	Describe("POST /v3/resource_matches", func() {
 		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ResourceMatchCreate{
+				Resources: []payloads.Resource{
+					{
+						Checksum:    payloads.ResourceChecksum{Value: "002d760bea1be268e27077412e11a320d0f164d3"},
+						SizeInBytes: 100000,
+						Path:        "path/to/file",
+						Mode:        "644",
+					},
+					{
+						Checksum:    payloads.ResourceChecksum{Value: "a9993e364706816aba3e25717850c26c9cd0d89d"},
+						SizeInBytes: 200000,
+						Path:        "another/file",
+						Mode:        "755",
+					},
+				},
+			})
+
+			resourceCacheRepo.MatchResourcesReturns([]repositories.ResourceRecord{{
+				SHA1:        "a9993e364706816aba3e25717850c26c9cd0d89d",
+				SizeInBytes: 200000,
+				Path:        "another/file",
+				Mode:        "755",
+			}}, nil)
+
 			var err error
-			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/resource_matches", strings.NewReader("{}"))
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/resource_matches", strings.NewReader("the-json-body"))
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		It("returns an empty list", func() {
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("matches the resources against the cache", func() {
+			Expect(resourceCacheRepo.MatchResourcesCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualResources := resourceCacheRepo.MatchResourcesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualResources).To(Equal([]repositories.ResourceRecord{
+				{
+					SHA1:        "002d760bea1be268e27077412e11a320d0f164d3",
+					SizeInBytes: 100000,
+					Path:        "path/to/file",
+					Mode:        "644",
+				},
+				{
+					SHA1:        "a9993e364706816aba3e25717850c26c9cd0d89d",
+					SizeInBytes: 200000,
+					Path:        "another/file",
+					Mode:        "755",
+				},
+			}))
+		})
+
+		It("returns the matched resources", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
-				"resources": []
-			  }`)))
+				"resources": [
+					{
+						"checksum": { "value": "a9993e364706816aba3e25717850c26c9cd0d89d" },
+						"size_in_bytes": 200000,
+						"path": "another/file",
+						"mode": "755"
+					}
+				]
+			}`)))
+		})
+
+		When("no resources match", func() {
+			BeforeEach(func() {
+				resourceCacheRepo.MatchResourcesReturns(nil, nil)
+			})
+
+			It("returns an empty list", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+					"resources": []
+				}`)))
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+
+			It("does not match any resources", func() {
+				Expect(resourceCacheRepo.MatchResourcesCallCount()).To(BeZero())
+			})
+		})
+
+		When("the resource cache is unavailable", func() {
+			BeforeEach(func() {
+				resourceCacheRepo.MatchResourcesReturns(nil, apierrors.NewBlobstoreUnavailableError(errors.New("boom")))
+			})
+
+			It("returns an error", func() {
+				expectBlobstoreUnavailableError()
+			})
 		})
 	})
 })
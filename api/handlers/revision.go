@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppRevisionsPath         = "/v3/apps/{guid}/revisions"
+	AppDeployedRevisionsPath = "/v3/apps/{guid}/revisions/deployed"
+	RevisionPath             = "/v3/revisions/{guid}"
+	RevisionEnvVarsPath      = "/v3/revisions/{guid}/environment_variables"
+)
+
+//counterfeiter:generate -o fake -fake-name CFRevisionRepository . CFRevisionRepository
+type CFRevisionRepository interface {
+	GetRevision(context.Context, authorization.Info, string) (repositories.RevisionRecord, error)
+	ListRevisions(context.Context, authorization.Info, repositories.ListRevisionsMessage) (repositories.ListResult[repositories.RevisionRecord], error)
+	ListDeployedRevisions(context.Context, authorization.Info, string) (repositories.ListResult[repositories.RevisionRecord], error)
+	GetRevisionEnvironmentVariables(context.Context, authorization.Info, string) (repositories.RevisionEnvVarsRecord, error)
+}
+
+type Revision struct {
+	serverURL        url.URL
+	revisionRepo     CFRevisionRepository
+	appRepo          CFAppRepository
+	requestValidator RequestValidator
+}
+
+func NewRevision(
+	serverURL url.URL,
+	revisionRepo CFRevisionRepository,
+	appRepo CFAppRepository,
+	requestValidator RequestValidator,
+) *Revision {
+	return &Revision{
+		serverURL:        serverURL,
+		revisionRepo:     revisionRepo,
+		appRepo:          appRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *Revision) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.get")
+	revisionGUID := routing.URLParam(r, "guid")
+
+	revision, err := h.revisionRepo.GetRevision(r.Context(), authInfo, revisionGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch revision from Kubernetes", "RevisionGUID", revisionGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRevision(revision, h.serverURL)), nil
+}
+
+func (h *Revision) getEnvVars(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.get-env-vars")
+	revisionGUID := routing.URLParam(r, "guid")
+
+	_, err := h.revisionRepo.GetRevision(r.Context(), authInfo, revisionGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch revision from Kubernetes", "RevisionGUID", revisionGUID)
+	}
+
+	envVars, err := h.revisionRepo.GetRevisionEnvironmentVariables(r.Context(), authInfo, revisionGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch revision environment variables", "RevisionGUID", revisionGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRevisionEnvVars(envVars, h.serverURL)), nil
+}
+
+func (h *Revision) listForApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.list-for-app")
+	appGUID := routing.URLParam(r, "guid")
+
+	_, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	payload := new(payloads.AppRevisionsList)
+	if err = h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	revisions, err := h.revisionRepo.ListRevisions(r.Context(), authInfo, payload.ToMessage(appGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list app revisions", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRevision, revisions, h.serverURL, *r.URL)), nil
+}
+
+func (h *Revision) listDeployedForApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.list-deployed-for-app")
+	appGUID := routing.URLParam(r, "guid")
+
+	_, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	revisions, err := h.revisionRepo.ListDeployedRevisions(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list deployed app revisions", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRevision, revisions, h.serverURL, *r.URL)), nil
+}
+
+func (h *Revision) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *Revision) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: RevisionPath, Handler: h.get},
+		{Method: "GET", Pattern: RevisionEnvVarsPath, Handler: h.getEnvVars},
+		{Method: "GET", Pattern: AppRevisionsPath, Handler: h.listForApp},
+		{Method: "GET", Pattern: AppDeployedRevisionsPath, Handler: h.listDeployedForApp},
+	}
+}
@@ -0,0 +1,279 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Revision", func() {
+	var (
+		requestValidator *fake.RequestValidator
+		revisionRepo     *fake.CFRevisionRepository
+		appRepo          *fake.CFAppRepository
+		req              *http.Request
+		reqPath          string
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		revisionRepo = new(fake.CFRevisionRepository)
+		appRepo = new(fake.CFAppRepository)
+
+		appRepo.GetAppReturns(repositories.AppRecord{GUID: "app-guid"}, nil)
+		revisionRepo.GetRevisionReturns(repositories.RevisionRecord{
+			GUID:        "revision-guid",
+			AppGUID:     "app-guid",
+			Version:     2,
+			DropletGUID: "droplet-guid",
+			Processes:   map[string]string{"web": "my-command"},
+			Description: "New droplet deployed.",
+			Deployable:  true,
+			CreatedAt:   time.UnixMilli(1000),
+		}, nil)
+
+		apiHandler := NewRevision(
+			*serverURL,
+			revisionRepo,
+			appRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, reqPath, strings.NewReader(""))
+		Expect(err).NotTo(HaveOccurred())
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/revisions/{guid}", func() {
+		BeforeEach(func() {
+			reqPath = "/v3/revisions/revision-guid"
+		})
+
+		It("returns the revision", func() {
+			Expect(revisionRepo.GetRevisionCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualRevisionGUID := revisionRepo.GetRevisionArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualRevisionGUID).To(Equal("revision-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "revision-guid"),
+				MatchJSONPath("$.version", BeEquivalentTo(2)),
+				MatchJSONPath("$.droplet.guid", "droplet-guid"),
+				MatchJSONPath("$.processes.web.command", "my-command"),
+				MatchJSONPath("$.deployable", BeTrue()),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/revisions/revision-guid"),
+			)))
+		})
+
+		When("the revision is not accessible", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, apierrors.NewForbiddenError(nil, repositories.RevisionResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.RevisionResourceType)
+			})
+		})
+
+		When("getting the revision fails", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, errors.New("get-revision-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/revisions/{guid}/environment_variables", func() {
+		BeforeEach(func() {
+			reqPath = "/v3/revisions/revision-guid/environment_variables"
+
+			revisionRepo.GetRevisionEnvironmentVariablesReturns(repositories.RevisionEnvVarsRecord{
+				RevisionGUID:         "revision-guid",
+				EnvironmentVariables: map[string]string{"FOO": "bar"},
+			}, nil)
+		})
+
+		It("returns the revision environment variables", func() {
+			Expect(revisionRepo.GetRevisionEnvironmentVariablesCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualRevisionGUID := revisionRepo.GetRevisionEnvironmentVariablesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualRevisionGUID).To(Equal("revision-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.var.FOO", "bar"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/revisions/revision-guid/environment_variables"),
+				MatchJSONPath("$.links.revision.href", "https://api.example.org/v3/revisions/revision-guid"),
+			)))
+		})
+
+		When("the revision is not accessible", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, apierrors.NewForbiddenError(nil, repositories.RevisionResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.RevisionResourceType)
+			})
+
+			It("does not get the environment variables", func() {
+				Expect(revisionRepo.GetRevisionEnvironmentVariablesCallCount()).To(BeZero())
+			})
+		})
+
+		When("getting the environment variables fails", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionEnvironmentVariablesReturns(repositories.RevisionEnvVarsRecord{}, errors.New("get-env-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/revisions", func() {
+		BeforeEach(func() {
+			reqPath = "/v3/apps/app-guid/revisions"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppRevisionsList{
+				Versions:   "1,2",
+				OrderBy:    "created_at",
+				Pagination: payloads.Pagination{PerPage: "16", Page: "2"},
+			})
+
+			revisionRepo.ListRevisionsReturns(repositories.ListResult[repositories.RevisionRecord]{
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 2,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     2,
+				},
+				Records: []repositories.RevisionRecord{
+					{GUID: "revision-1", Version: 1},
+					{GUID: "revision-2", Version: 2},
+				},
+			}, nil)
+		})
+
+		It("lists the app revisions", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(revisionRepo.ListRevisionsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := revisionRepo.ListRevisionsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.ListRevisionsMessage{
+				AppGUIDs:   []string{"app-guid"},
+				Versions:   []string{"1", "2"},
+				OrderBy:    "created_at",
+				Pagination: repositories.Pagination{PerPage: 16, Page: 2},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "revision-1"),
+				MatchJSONPath("$.resources[1].guid", "revision-2"),
+			)))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the revisions fails", func() {
+			BeforeEach(func() {
+				revisionRepo.ListRevisionsReturns(repositories.ListResult[repositories.RevisionRecord]{}, errors.New("list-revisions-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/revisions/deployed", func() {
+		BeforeEach(func() {
+			reqPath = "/v3/apps/app-guid/revisions/deployed"
+
+			revisionRepo.ListDeployedRevisionsReturns(repositories.ListResult[repositories.RevisionRecord]{
+				PageInfo: descriptors.SinglePageInfo(1, 1),
+				Records: []repositories.RevisionRecord{
+					{GUID: "revision-2", Version: 2},
+				},
+			}, nil)
+		})
+
+		It("lists the deployed app revisions", func() {
+			Expect(revisionRepo.ListDeployedRevisionsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := revisionRepo.ListDeployedRevisionsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "revision-2"),
+			)))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("listing the deployed revisions fails", func() {
+			BeforeEach(func() {
+				revisionRepo.ListDeployedRevisionsReturns(repositories.ListResult[repositories.RevisionRecord]{}, errors.New("list-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -30,16 +30,18 @@ type CFRoleRepository interface {
 }
 
 type Role struct {
-	apiBaseURL       url.URL
-	roleRepo         CFRoleRepository
-	requestValidator RequestValidator
+	apiBaseURL         url.URL
+	roleRepo           CFRoleRepository
+	requestValidator   RequestValidator
+	auditEventRecorder AuditEventRecorder
 }
 
-func NewRole(apiBaseURL url.URL, roleRepo CFRoleRepository, requestValidator RequestValidator) *Role {
+func NewRole(apiBaseURL url.URL, roleRepo CFRoleRepository, requestValidator RequestValidator, auditEventRecorder AuditEventRecorder) *Role {
 	return &Role{
-		apiBaseURL:       apiBaseURL,
-		roleRepo:         roleRepo,
-		requestValidator: requestValidator,
+		apiBaseURL:         apiBaseURL,
+		roleRepo:           roleRepo,
+		requestValidator:   requestValidator,
+		auditEventRecorder: auditEventRecorder,
 	}
 }
 
@@ -59,6 +61,7 @@ func (h *Role) create(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create role", "Role Type", role.Type, "Space", role.Space, "User", role.User)
 	}
+	h.recordRoleEvent(r.Context(), record, true)
 
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForRole(record, h.apiBaseURL)), nil
 }
@@ -99,10 +102,24 @@ func (h *Role) delete(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete role", "RoleGUID", roleGUID)
 	}
+	h.recordRoleEvent(r.Context(), role, false)
 
 	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(roleGUID, presenter.RoleDeleteOperation, h.apiBaseURL)), nil
 }
 
+func (h *Role) recordRoleEvent(ctx context.Context, role repositories.RoleRecord, added bool) {
+	recordAuditEvent(ctx, h.auditEventRecorder, repositories.CreateAuditEventMessage{
+		Type: repositories.RoleAuditEventType(role.Type, added),
+		Target: repositories.AuditEventTarget{
+			GUID: role.User,
+			Type: repositories.AuditEventTargetTypeUser,
+			Name: role.User,
+		},
+		SpaceGUID:        role.Space,
+		OrganizationGUID: role.Org,
+	})
+}
+
 func (h *Role) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -24,16 +24,18 @@ const (
 
 var _ = Describe("Role", func() {
 	var (
-		apiHandler       *handlers.Role
-		roleRepo         *fake.CFRoleRepository
-		requestValidator *fake.RequestValidator
+		apiHandler         *handlers.Role
+		roleRepo           *fake.CFRoleRepository
+		requestValidator   *fake.RequestValidator
+		auditEventRecorder *fake.AuditEventRecorder
 	)
 
 	BeforeEach(func() {
 		roleRepo = new(fake.CFRoleRepository)
 		requestValidator = new(fake.RequestValidator)
+		auditEventRecorder = new(fake.AuditEventRecorder)
 
-		apiHandler = handlers.NewRole(*serverURL, roleRepo, requestValidator)
+		apiHandler = handlers.NewRole(*serverURL, roleRepo, requestValidator, auditEventRecorder)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -41,7 +43,12 @@ var _ = Describe("Role", func() {
 		var roleCreate *payloads.RoleCreate
 
 		BeforeEach(func() {
-			roleRepo.CreateRoleReturns(repositories.RoleRecord{GUID: "role-guid"}, nil)
+			roleRepo.CreateRoleReturns(repositories.RoleRecord{
+				GUID:  "role-guid",
+				Type:  "space_developer",
+				Space: "my-space",
+				User:  "my-user",
+			}, nil)
 			roleCreate = &payloads.RoleCreate{
 				Type: "space_developer",
 				Relationships: payloads.RoleRelationships{
@@ -88,6 +95,21 @@ var _ = Describe("Role", func() {
 			)))
 		})
 
+		It("records a role add audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.CreateAuditEventMessage{
+				Type: "audit.user.space_developer_add",
+				Target: repositories.AuditEventTarget{
+					GUID: "my-user",
+					Type: "user",
+					Name: "my-user",
+				},
+				SpaceGUID: "my-space",
+			}))
+		})
+
 		When("username is passed in the guid field", func() {
 			BeforeEach(func() {
 				roleCreate.Relationships.User.Data.Username = ""
@@ -255,8 +277,10 @@ var _ = Describe("Role", func() {
 		BeforeEach(func() {
 			roleRepo.GetRoleReturns(repositories.RoleRecord{
 				GUID:  "role-guid",
+				Type:  "space_developer",
 				Space: "my-space",
 				Org:   "",
+				User:  "my-user",
 			}, nil)
 		})
 
@@ -284,6 +308,14 @@ var _ = Describe("Role", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Location", ContainSubstring("jobs/role.delete~role-guid")))
 		})
 
+		It("records a role remove audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualMessage.Type).To(Equal("audit.user.space_developer_remove"))
+			Expect(actualMessage.Target.GUID).To(Equal("my-user"))
+			Expect(actualMessage.SpaceGUID).To(Equal("my-space"))
+		})
+
 		When("getting the role is forbidden", func() {
 			BeforeEach(func() {
 				roleRepo.GetRoleReturns(repositories.RoleRecord{}, apierrors.NewForbiddenError(nil, "Role"))
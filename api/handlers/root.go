@@ -14,21 +14,25 @@ const (
 )
 
 type Root struct {
-	baseURL     url.URL
-	uaaConfig   config.UAA
-	logCacheURL url.URL
+	baseURL               url.URL
+	uaaConfig             config.UAA
+	logCacheURL           url.URL
+	sshProxyConfig        config.SSHProxy
+	sshHostKeyFingerprint string
 }
 
-func NewRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) *Root {
+func NewRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL, sshProxyConfig config.SSHProxy, sshHostKeyFingerprint string) *Root {
 	return &Root{
-		baseURL:     baseURL,
-		uaaConfig:   uaaConfig,
-		logCacheURL: logCacheURL,
+		baseURL:               baseURL,
+		uaaConfig:             uaaConfig,
+		logCacheURL:           logCacheURL,
+		sshProxyConfig:        sshProxyConfig,
+		sshHostKeyFingerprint: sshHostKeyFingerprint,
 	}
 }
 
 func (h *Root) get(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoot(h.baseURL, h.uaaConfig, h.logCacheURL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoot(h.baseURL, h.uaaConfig, h.logCacheURL, h.sshProxyConfig, h.sshHostKeyFingerprint)), nil
 }
 
 func (h *Root) UnauthenticatedRoutes() []routing.Route {
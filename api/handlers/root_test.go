@@ -24,7 +24,7 @@ var _ = Describe("Root", func() {
 		logCacheURL, err = url.Parse("https://my.logcache.org")
 		Expect(err).NotTo(HaveOccurred())
 
-		apiHandler = handlers.NewRoot(*serverURL, config.UAA{}, *logCacheURL)
+		apiHandler = handlers.NewRoot(*serverURL, config.UAA{}, *logCacheURL, config.SSHProxy{}, "")
 	})
 
 	JustBeforeEach(func() {
@@ -48,6 +48,7 @@ var _ = Describe("Root", func() {
 				MatchJSONPath("$.links.self.href", "https://api.example.org"),
 				MatchJSONPath("$.links.cloud_controller_v3.href", "https://api.example.org/v3"),
 				MatchJSONPath("$.links.log_cache.href", "https://my.logcache.org"),
+				MatchJSONPath("$.links.app_ssh", BeNil()),
 			)))
 		})
 
@@ -59,7 +60,10 @@ var _ = Describe("Root", func() {
 						Enabled: true,
 						URL:     "https://my.uaa",
 					},
-					*logCacheURL)
+					*logCacheURL,
+					config.SSHProxy{},
+					"",
+				)
 			})
 
 			It("returns the uaa config", func() {
@@ -72,5 +76,30 @@ var _ = Describe("Root", func() {
 				)))
 			})
 		})
+
+		When("the ssh proxy is enabled", func() {
+			BeforeEach(func() {
+				apiHandler = handlers.NewRoot(
+					*serverURL,
+					config.UAA{},
+					*logCacheURL,
+					config.SSHProxy{
+						Enabled:         true,
+						ExternalAddress: "ssh.example.org:2222",
+					},
+					"the-fingerprint",
+				)
+			})
+
+			It("returns the app ssh link", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.links.app_ssh.href", "ssh.example.org:2222"),
+					MatchJSONPath("$.links.app_ssh.meta.host_key_fingerprint", "the-fingerprint"),
+					MatchJSONPath("$.links.app_ssh.meta.oauth_client", "ssh-proxy"),
+				)))
+			})
+		})
 	})
 })
@@ -2,6 +2,9 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
 
@@ -11,6 +14,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
@@ -20,6 +24,10 @@ const (
 	RoutesPath            = "/v3/routes"
 	RouteDestinationsPath = "/v3/routes/{guid}/destinations"
 	RouteDestinationPath  = "/v3/routes/{guid}/destinations/{destination_guid}"
+
+	// Another route may claim the randomly picked port before ours is
+	// created, so allocation is retried a few times before giving up
+	maxRandomPortAttempts = 5
 )
 
 //counterfeiter:generate -o fake -fake-name CFRouteRepository . CFRouteRepository
@@ -33,15 +41,18 @@ type CFRouteRepository interface {
 	AddDestinationsToRoute(ctx context.Context, c authorization.Info, message repositories.AddDestinationsMessage) (repositories.RouteRecord, error)
 	RemoveDestinationFromRoute(ctx context.Context, authInfo authorization.Info, message repositories.RemoveDestinationMessage) (repositories.RouteRecord, error)
 	PatchRouteMetadata(context.Context, authorization.Info, repositories.PatchRouteMetadataMessage) (repositories.RouteRecord, error)
+	ListRouterGroupPorts(context.Context, string) ([]int32, error)
 }
 
 type Route struct {
-	serverURL        url.URL
-	routeRepo        CFRouteRepository
-	domainRepo       CFDomainRepository
-	appRepo          CFAppRepository
-	spaceRepo        CFSpaceRepository
-	requestValidator RequestValidator
+	serverURL          url.URL
+	routeRepo          CFRouteRepository
+	domainRepo         CFDomainRepository
+	appRepo            CFAppRepository
+	spaceRepo          CFSpaceRepository
+	routerGroupRepo    RouterGroupRepository
+	requestValidator   RequestValidator
+	auditEventRecorder AuditEventRecorder
 }
 
 func NewRoute(
@@ -50,15 +61,19 @@ func NewRoute(
 	domainRepo CFDomainRepository,
 	appRepo CFAppRepository,
 	spaceRepo CFSpaceRepository,
+	routerGroupRepo RouterGroupRepository,
 	requestValidator RequestValidator,
+	auditEventRecorder AuditEventRecorder,
 ) *Route {
 	return &Route{
-		serverURL:        serverURL,
-		routeRepo:        routeRepo,
-		domainRepo:       domainRepo,
-		appRepo:          appRepo,
-		spaceRepo:        spaceRepo,
-		requestValidator: requestValidator,
+		serverURL:          serverURL,
+		routeRepo:          routeRepo,
+		domainRepo:         domainRepo,
+		appRepo:            appRepo,
+		spaceRepo:          spaceRepo,
+		routerGroupRepo:    routerGroupRepo,
+		requestValidator:   requestValidator,
+		auditEventRecorder: auditEventRecorder,
 	}
 }
 
@@ -147,16 +162,126 @@ func (h *Route) create(r *http.Request) (*routing.Response, error) {
 	}
 
 	createRouteMessage := payload.ToMessage(domain.Namespace, domain.Name)
-	responseRouteRecord, err := h.routeRepo.CreateRoute(r.Context(), authInfo, createRouteMessage)
+	if err = validateRouteForDomain(createRouteMessage, domain); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Invalid route for domain", "domainGUID", domainGUID)
+	}
+
+	var routerGroup repositories.RouterGroupRecord
+	createRouteMessage.Protocol = string(korifiv1alpha1.RouteProtocolHTTP)
+	if domain.RouterGroup != "" {
+		createRouteMessage.Protocol = string(korifiv1alpha1.RouteProtocolTCP)
+
+		routerGroup, err = h.routerGroupRepo.GetRouterGroup(r.Context(), authInfo, domain.RouterGroup)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Failed to get router group", "routerGroup", domain.RouterGroup)
+		}
+
+		if err = validatePortForRouterGroup(createRouteMessage.Port, routerGroup); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Invalid port for router group", "routerGroup", domain.RouterGroup)
+		}
+	}
+
+	var responseRouteRecord repositories.RouteRecord
+	if domain.RouterGroup != "" && createRouteMessage.Port == 0 {
+		responseRouteRecord, err = h.createRouteWithRandomPort(r.Context(), authInfo, domain, routerGroup, createRouteMessage)
+	} else {
+		responseRouteRecord, err = h.routeRepo.CreateRoute(r.Context(), authInfo, createRouteMessage)
+	}
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create route", "Route Host", payload.Host)
 	}
 
 	responseRouteRecord.Domain = domain
+	h.recordRouteEvent(r.Context(), repositories.AuditEventTypeRouteCreate, responseRouteRecord, map[string]any{
+		"request": map[string]any{
+			"host":        responseRouteRecord.Host,
+			"path":        responseRouteRecord.Path,
+			"port":        responseRouteRecord.Port,
+			"domain_guid": domain.GUID,
+		},
+	})
 
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForRoute(responseRouteRecord, h.serverURL)), nil
 }
 
+func validateRouteForDomain(message repositories.CreateRouteMessage, domain repositories.DomainRecord) error {
+	if domain.RouterGroup == "" {
+		if message.Port != 0 {
+			return apierrors.NewUnprocessableEntityError(nil, "Ports are only supported for TCP routes.")
+		}
+		if message.Host == "" {
+			return apierrors.NewUnprocessableEntityError(nil, "Missing host. Routes in shared domains must have a host defined.")
+		}
+		return nil
+	}
+
+	if message.Host != "" || message.Path != "" {
+		return apierrors.NewUnprocessableEntityError(nil, "Hosts and paths are not supported for TCP routes.")
+	}
+	return nil
+}
+
+func validatePortForRouterGroup(port int32, routerGroup repositories.RouterGroupRecord) error {
+	if port == 0 {
+		return nil
+	}
+
+	if port < routerGroup.ReservablePorts.From || port > routerGroup.ReservablePorts.To {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+			"Port must be between %d and %d for the router group.",
+			routerGroup.ReservablePorts.From,
+			routerGroup.ReservablePorts.To,
+		))
+	}
+	return nil
+}
+
+// createRouteWithRandomPort picks a random port out of the reservable ports of
+// the domain router group, skipping the ports of the existing routes on all
+// domains of the router group in all spaces
+func (h *Route) createRouteWithRandomPort(
+	ctx context.Context,
+	authInfo authorization.Info,
+	domain repositories.DomainRecord,
+	routerGroup repositories.RouterGroupRecord,
+	message repositories.CreateRouteMessage,
+) (repositories.RouteRecord, error) {
+	routerGroupPorts, err := h.routeRepo.ListRouterGroupPorts(ctx, domain.RouterGroup)
+	if err != nil {
+		return repositories.RouteRecord{}, fmt.Errorf("failed to list router group ports: %w", err)
+	}
+
+	usedPorts := map[int32]bool{}
+	for _, port := range routerGroupPorts {
+		usedPorts[port] = true
+	}
+
+	for range maxRandomPortAttempts {
+		freePorts := []int32{}
+		for port := routerGroup.ReservablePorts.From; port <= routerGroup.ReservablePorts.To; port++ {
+			if !usedPorts[port] {
+				freePorts = append(freePorts, port)
+			}
+		}
+		if len(freePorts) == 0 {
+			break
+		}
+
+		message.Port = freePorts[rand.IntN(len(freePorts))]
+		route, err := h.routeRepo.CreateRoute(ctx, authInfo, message)
+		if err == nil {
+			return route, nil
+		}
+
+		if !errors.As(err, &apierrors.UnprocessableEntityError{}) {
+			return repositories.RouteRecord{}, err
+		}
+		usedPorts[message.Port] = true
+	}
+
+	return repositories.RouteRecord{}, apierrors.NewUnprocessableEntityError(nil, "There are no more ports available for the router group.")
+}
+
 func (h *Route) insertDestinations(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.insert-destinations")
@@ -227,6 +352,7 @@ func (h *Route) delete(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete route", "routeGUID", routeGUID)
 	}
+	h.recordRouteEvent(r.Context(), repositories.AuditEventTypeRouteDeleteRequest, routeRecord, nil)
 
 	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(routeGUID, presenter.RouteDeleteOperation, h.serverURL)), nil
 }
@@ -252,9 +378,23 @@ func (h *Route) update(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch route metadata", "RouteGUID", routeGUID)
 	}
+	h.recordRouteEvent(r.Context(), repositories.AuditEventTypeRouteUpdate, route, nil)
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoute(route, h.serverURL)), nil
 }
 
+func (h *Route) recordRouteEvent(ctx context.Context, eventType string, route repositories.RouteRecord, data map[string]any) {
+	recordAuditEvent(ctx, h.auditEventRecorder, repositories.CreateAuditEventMessage{
+		Type: eventType,
+		Target: repositories.AuditEventTarget{
+			GUID: route.GUID,
+			Type: repositories.AuditEventTargetTypeRoute,
+			Name: route.Host,
+		},
+		SpaceGUID: route.SpaceGUID,
+		Data:      data,
+	})
+}
+
 func (h *Route) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
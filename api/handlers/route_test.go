@@ -1,11 +1,14 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/config"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -26,7 +29,9 @@ var _ = Describe("Route", func() {
 		domainRepo       *fake.CFDomainRepository
 		appRepo          *fake.CFAppRepository
 		spaceRepo        *fake.CFSpaceRepository
-		requestValidator *fake.RequestValidator
+		routerGroupRepo    *fake.RouterGroupRepository
+		requestValidator   *fake.RequestValidator
+		auditEventRecorder *fake.AuditEventRecorder
 
 		requestMethod string
 		requestPath   string
@@ -62,7 +67,10 @@ var _ = Describe("Route", func() {
 			Name: "test-space-guid",
 		}, nil)
 
+		routerGroupRepo = new(fake.RouterGroupRepository)
+
 		requestValidator = new(fake.RequestValidator)
+		auditEventRecorder = new(fake.AuditEventRecorder)
 
 		apiHandler := NewRoute(
 			*serverURL,
@@ -70,7 +78,9 @@ var _ = Describe("Route", func() {
 			domainRepo,
 			appRepo,
 			spaceRepo,
+			routerGroupRepo,
 			requestValidator,
+			auditEventRecorder,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -216,6 +226,8 @@ var _ = Describe("Route", func() {
 	})
 
 	Describe("the POST /v3/routes endpoint", func() {
+		var payload *payloads.RouteCreate
+
 		BeforeEach(func() {
 			requestMethod = http.MethodPost
 			requestPath = "/v3/routes"
@@ -238,7 +250,7 @@ var _ = Describe("Route", func() {
 
 			requestBody = "the-json-body"
 
-			payload := payloads.RouteCreate{
+			payload = &payloads.RouteCreate{
 				Host: "test-route-host",
 				Path: "/test-route-path",
 				Relationships: &payloads.RouteRelationships{
@@ -254,7 +266,7 @@ var _ = Describe("Route", func() {
 					Annotations: map[string]string{"annotation-key": "annotation-val"},
 				},
 			}
-			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payload)
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(payload)
 		})
 
 		It("creates the route", func() {
@@ -290,6 +302,185 @@ var _ = Describe("Route", func() {
 			)))
 		})
 
+		It("records a route create audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage.Type).To(Equal("audit.route.create"))
+			Expect(actualMessage.Target).To(Equal(repositories.AuditEventTarget{
+				GUID: "test-route-guid",
+				Type: "route",
+				Name: "test-route-host",
+			}))
+			Expect(actualMessage.SpaceGUID).To(Equal("test-space-guid"))
+			Expect(actualMessage.Data).To(HaveKeyWithValue("request", map[string]any{
+				"host":        "test-route-host",
+				"path":        "/test-route-path",
+				"port":        int32(0),
+				"domain_guid": "test-domain-guid",
+			}))
+		})
+
+		When("the host is empty", func() {
+			BeforeEach(func() {
+				payload.Host = ""
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Missing host. Routes in shared domains must have a host defined.")
+				Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+			})
+		})
+
+		When("a port is requested for an http domain", func() {
+			BeforeEach(func() {
+				payload.Port = tools.PtrTo[int32](1024)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Ports are only supported for TCP routes.")
+				Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+			})
+		})
+
+		When("the domain has a router group", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{
+					GUID:        "test-domain-guid",
+					Name:        "tcp.example.org",
+					RouterGroup: "default-tcp",
+				}, nil)
+
+				routeRepo.CreateRouteStub = func(_ context.Context, _ authorization.Info, message repositories.CreateRouteMessage) (repositories.RouteRecord, error) {
+					return repositories.RouteRecord{
+						GUID:     "test-route-guid",
+						Protocol: message.Protocol,
+						Port:     message.Port,
+					}, nil
+				}
+
+				routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{
+					GUID:            "default-tcp",
+					ReservablePorts: config.PortRange{From: 1024, To: 1026},
+				}, nil)
+
+				payload.Host = ""
+				payload.Path = ""
+				payload.Port = tools.PtrTo[int32](1025)
+			})
+
+			It("creates a tcp route", func() {
+				Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+				_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+				Expect(createRouteMessage.Protocol).To(Equal("tcp"))
+				Expect(createRouteMessage.Port).To(BeEquivalentTo(1025))
+
+				Expect(routerGroupRepo.GetRouterGroupCallCount()).To(Equal(1))
+				_, _, actualRouterGroupGUID := routerGroupRepo.GetRouterGroupArgsForCall(0)
+				Expect(actualRouterGroupGUID).To(Equal("default-tcp"))
+				Expect(routeRepo.ListRouterGroupPortsCallCount()).To(BeZero())
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.protocol", "tcp"),
+					MatchJSONPath("$.port", BeEquivalentTo(1025)),
+					MatchJSONPath("$.url", "tcp.example.org:1025"),
+				)))
+			})
+
+			When("a host is requested", func() {
+				BeforeEach(func() {
+					payload.Host = "my-host"
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Hosts and paths are not supported for TCP routes.")
+					Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+				})
+			})
+
+			When("the requested port is not in the reservable ports of the router group", func() {
+				BeforeEach(func() {
+					payload.Port = tools.PtrTo[int32](1027)
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Port must be between 1024 and 1026 for the router group.")
+					Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+				})
+			})
+
+			When("getting the router group fails", func() {
+				BeforeEach(func() {
+					routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{}, errors.New("boom"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+					Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+				})
+			})
+
+			When("no port is requested", func() {
+				BeforeEach(func() {
+					payload.Port = nil
+
+					routeRepo.ListRouterGroupPortsReturns([]int32{1024, 1026}, nil)
+				})
+
+				It("allocates a free port of the router group", func() {
+					Expect(routeRepo.ListRouterGroupPortsCallCount()).To(Equal(1))
+					_, actualRouterGroup := routeRepo.ListRouterGroupPortsArgsForCall(0)
+					Expect(actualRouterGroup).To(Equal("default-tcp"))
+
+					Expect(routeRepo.CreateRouteCallCount()).To(Equal(1))
+					_, _, createRouteMessage := routeRepo.CreateRouteArgsForCall(0)
+					Expect(createRouteMessage.Port).To(BeEquivalentTo(1025))
+
+					Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+					Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.port", BeEquivalentTo(1025))))
+				})
+
+				When("the allocated port gets taken by another route", func() {
+					BeforeEach(func() {
+						routeRepo.ListRouterGroupPortsReturns([]int32{1024}, nil)
+
+						routeRepo.CreateRouteReturnsOnCall(0, repositories.RouteRecord{}, apierrors.NewUnprocessableEntityError(nil, "port taken"))
+					})
+
+					It("retries with another port", func() {
+						Expect(routeRepo.CreateRouteCallCount()).To(Equal(2))
+						_, _, firstMessage := routeRepo.CreateRouteArgsForCall(0)
+						_, _, secondMessage := routeRepo.CreateRouteArgsForCall(1)
+						Expect([]int32{firstMessage.Port, secondMessage.Port}).To(ConsistOf(int32(1025), int32(1026)))
+
+						Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+					})
+				})
+
+				When("all ports are taken", func() {
+					BeforeEach(func() {
+						routeRepo.ListRouterGroupPortsReturns([]int32{1024, 1025, 1026}, nil)
+					})
+
+					It("returns an error", func() {
+						expectUnprocessableEntityError("There are no more ports available for the router group.")
+						Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+					})
+				})
+
+				When("listing the router group ports fails", func() {
+					BeforeEach(func() {
+						routeRepo.ListRouterGroupPortsReturns(nil, errors.New("boom"))
+					})
+
+					It("returns an error", func() {
+						expectUnknownError()
+					})
+				})
+			})
+		})
+
 		When("the request body is invalid JSON", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
@@ -416,6 +607,15 @@ var _ = Describe("Route", func() {
 			)))
 		})
 
+		It("records a route update audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualMessage.Type).To(Equal("audit.route.update"))
+			Expect(actualMessage.Target.GUID).To(Equal("test-route-guid"))
+			Expect(actualMessage.Target.Type).To(Equal("route"))
+			Expect(actualMessage.SpaceGUID).To(Equal(spaceGUID))
+		})
+
 		When("the user doesn't have permission to get the Route", func() {
 			BeforeEach(func() {
 				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewForbiddenError(nil, repositories.RouteResourceType))
@@ -716,6 +916,18 @@ var _ = Describe("Route", func() {
 			Expect(deleteMessage.SpaceGUID).To(Equal("test-space-guid"))
 		})
 
+		It("records a route delete-request audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualMessage.Type).To(Equal("audit.route.delete-request"))
+			Expect(actualMessage.Target).To(Equal(repositories.AuditEventTarget{
+				GUID: "test-route-guid",
+				Type: "route",
+				Name: "test-route-host",
+			}))
+			Expect(actualMessage.SpaceGUID).To(Equal("test-space-guid"))
+		})
+
 		When("fetching the route errors", func() {
 			BeforeEach(func() {
 				routeRepo.GetRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
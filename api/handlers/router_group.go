@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	RoutingBase      = "/routing"
+	RouterGroupsPath = RoutingBase + "/v1/router_groups"
+	RouterGroupPath  = RoutingBase + "/v1/router_groups/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name RouterGroupRepository . RouterGroupRepository
+
+type RouterGroupRepository interface {
+	ListRouterGroups(context.Context, authorization.Info, repositories.ListRouterGroupsMessage) ([]repositories.RouterGroupRecord, error)
+	GetRouterGroup(context.Context, authorization.Info, string) (repositories.RouterGroupRecord, error)
+}
+
+type RouterGroup struct {
+	routerGroupRepo  RouterGroupRepository
+	requestValidator RequestValidator
+}
+
+func NewRouterGroup(
+	routerGroupRepo RouterGroupRepository,
+	requestValidator RequestValidator,
+) *RouterGroup {
+	return &RouterGroup{
+		routerGroupRepo:  routerGroupRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *RouterGroup) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.router-group.list")
+
+	payload := new(payloads.RouterGroupList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	routerGroups, err := h.routerGroupRepo.ListRouterGroups(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list router groups")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouterGroupList(routerGroups)), nil
+}
+
+func (h *RouterGroup) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.router-group.get")
+
+	routerGroupGUID := routing.URLParam(r, "guid")
+
+	routerGroup, err := h.routerGroupRepo.GetRouterGroup(r.Context(), authInfo, routerGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to get router group", "routerGroupGUID", routerGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouterGroup(routerGroup)), nil
+}
+
+func (h *RouterGroup) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *RouterGroup) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: RouterGroupsPath, Handler: h.list},
+		{Method: "GET", Pattern: RouterGroupPath, Handler: h.get},
+	}
+}
@@ -0,0 +1,132 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/config"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouterGroup", func() {
+	var (
+		routerGroupRepo  *fake.RouterGroupRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		routerGroupRepo = new(fake.RouterGroupRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewRouterGroup(routerGroupRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /routing/v1/router_groups", func() {
+		BeforeEach(func() {
+			routerGroupRepo.ListRouterGroupsReturns([]repositories.RouterGroupRecord{{
+				GUID:            "default-tcp",
+				Name:            "default-tcp",
+				Type:            "tcp",
+				ReservablePorts: config.PortRange{From: 1024, To: 1033},
+			}}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RouterGroupList{
+				Name: "default-tcp",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/routing/v1/router_groups?name=default-tcp", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the router groups", func() {
+			Expect(routerGroupRepo.ListRouterGroupsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := routerGroupRepo.ListRouterGroupsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.ListRouterGroupsMessage{
+				Names: []string{"default-tcp"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$", HaveLen(1)),
+				MatchJSONPath("$[0].guid", "default-tcp"),
+				MatchJSONPath("$[0].type", "tcp"),
+				MatchJSONPath("$[0].reservable_ports", "1024-1033"),
+			)))
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the router groups fails", func() {
+			BeforeEach(func() {
+				routerGroupRepo.ListRouterGroupsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /routing/v1/router_groups/{guid}", func() {
+		BeforeEach(func() {
+			routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{
+				GUID:            "default-tcp",
+				Name:            "default-tcp",
+				Type:            "tcp",
+				ReservablePorts: config.PortRange{From: 1024, To: 1033},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/routing/v1/router_groups/default-tcp", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the router group", func() {
+			Expect(routerGroupRepo.GetRouterGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := routerGroupRepo.GetRouterGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("default-tcp"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "default-tcp"),
+				MatchJSONPath("$.reservable_ports", "1024-1033"),
+			)))
+		})
+
+		When("the router group does not exist", func() {
+			BeforeEach(func() {
+				routerGroupRepo.GetRouterGroupReturns(repositories.RouterGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.RouterGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.RouterGroupResourceType)
+			})
+		})
+	})
+})
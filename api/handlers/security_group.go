@@ -13,13 +13,19 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/go-logr/logr"
 )
 
 const (
-	SecurityGroupsPath = "/v3/security_groups"
-	spaceNotFoundErr   = "Space does not exist, or you do not have access."
+	SecurityGroupsPath                   = "/v3/security_groups"
+	SecurityGroupPath                    = "/v3/security_groups/{guid}"
+	SecurityGroupRunningSpacesPath       = "/v3/security_groups/{guid}/relationships/running_spaces"
+	SecurityGroupStagingSpacesPath       = "/v3/security_groups/{guid}/relationships/staging_spaces"
+	SecurityGroupRunningSpaceBindingPath = "/v3/security_groups/{guid}/relationships/running_spaces/{space_guid}"
+	SecurityGroupStagingSpaceBindingPath = "/v3/security_groups/{guid}/relationships/staging_spaces/{space_guid}"
+	spaceNotFoundErr                     = "Space does not exist, or you do not have access."
 )
 
 type SecurityGroup struct {
@@ -32,6 +38,12 @@ type SecurityGroup struct {
 //counterfeiter:generate -o fake -fake-name CFSecurityGroupRepository . CFSecurityGroupRepository
 type CFSecurityGroupRepository interface {
 	CreateSecurityGroup(context.Context, authorization.Info, repositories.CreateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	GetSecurityGroup(context.Context, authorization.Info, string) (repositories.SecurityGroupRecord, error)
+	ListSecurityGroups(context.Context, authorization.Info, repositories.ListSecurityGroupsMessage) (repositories.ListResult[repositories.SecurityGroupRecord], error)
+	UpdateSecurityGroup(context.Context, authorization.Info, repositories.UpdateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	BindSecurityGroup(context.Context, authorization.Info, repositories.BindSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	UnbindSecurityGroup(context.Context, authorization.Info, repositories.UnbindSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	DeleteSecurityGroup(context.Context, authorization.Info, string) error
 }
 
 func NewSecurityGroup(
@@ -83,6 +95,184 @@ func (h *SecurityGroup) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
 }
 
+func (h *SecurityGroup) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.get")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	securityGroup, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get security group", "securityGroupGUID", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.list")
+
+	payload := new(payloads.SecurityGroupList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	securityGroups, err := h.securityGroupRepo.ListSecurityGroups(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list security groups")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSecurityGroup, securityGroups, h.serverURL, *r.URL)), nil
+}
+
+func (h *SecurityGroup) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.update")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.SecurityGroupUpdate)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get security group", "securityGroupGUID", securityGroupGUID)
+	}
+
+	securityGroup, err := h.securityGroupRepo.UpdateSecurityGroup(r.Context(), authInfo, payload.ToMessage(securityGroupGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to update security group", "securityGroupGUID", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.delete")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get security group", "securityGroupGUID", securityGroupGUID)
+	}
+
+	err = h.securityGroupRepo.DeleteSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete security group", "securityGroupGUID", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(securityGroupGUID, presenter.SecurityGroupDeleteOperation, h.serverURL),
+	), nil
+}
+
+func (h *SecurityGroup) bindRunningSpaces(r *http.Request) (*routing.Response, error) {
+	securityGroup, err := h.bind(r, korifiv1alpha1.SecurityGroupRunningWorkloads)
+	if err != nil {
+		return nil, err
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroupRunningSpaces(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) bindStagingSpaces(r *http.Request) (*routing.Response, error) {
+	securityGroup, err := h.bind(r, korifiv1alpha1.SecurityGroupStagingWorkloads)
+	if err != nil {
+		return nil, err
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroupStagingSpaces(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) bind(r *http.Request, workloads string) (repositories.SecurityGroupRecord, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.bind")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.SecurityGroupBind)
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, payload); err != nil {
+		return repositories.SecurityGroupRecord{}, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return repositories.SecurityGroupRecord{}, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get security group", "securityGroupGUID", securityGroupGUID)
+	}
+
+	spaceGUIDs := payload.SpaceGUIDs()
+	spaces, err := h.spaceRepo.ListSpaces(r.Context(), authInfo, repositories.ListSpacesMessage{GUIDs: spaceGUIDs})
+	if err != nil {
+		return repositories.SecurityGroupRecord{}, apierrors.LogAndReturn(logger, err, "failed to list spaces for binding to security group")
+	}
+
+	for _, spaceGUID := range spaceGUIDs {
+		if !slices.ContainsFunc(spaces.Records, func(s repositories.SpaceRecord) bool { return s.GUID == spaceGUID }) {
+			return repositories.SecurityGroupRecord{}, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(fmt.Errorf("space %q not found", spaceGUID), spaceNotFoundErr),
+				spaceNotFoundErr,
+				"spaceGUID", spaceGUID,
+			)
+		}
+	}
+
+	securityGroup, err := h.securityGroupRepo.BindSecurityGroup(r.Context(), authInfo, payload.ToMessage(securityGroupGUID, workloads))
+	if err != nil {
+		return repositories.SecurityGroupRecord{}, apierrors.LogAndReturn(logger, err, "Failed to bind security group", "securityGroupGUID", securityGroupGUID)
+	}
+
+	return securityGroup, nil
+}
+
+func (h *SecurityGroup) unbindRunningSpace(r *http.Request) (*routing.Response, error) {
+	return h.unbind(r, korifiv1alpha1.SecurityGroupRunningWorkloads)
+}
+
+func (h *SecurityGroup) unbindStagingSpace(r *http.Request) (*routing.Response, error) {
+	return h.unbind(r, korifiv1alpha1.SecurityGroupStagingWorkloads)
+}
+
+func (h *SecurityGroup) unbind(r *http.Request, workloads string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.unbind")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space_guid")
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get security group", "securityGroupGUID", securityGroupGUID)
+	}
+
+	_, err = h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(apierrors.ForbiddenAsNotFound(err), spaceNotFoundErr, apierrors.NotFoundError{}),
+			"Failed to get space",
+			"spaceGUID", spaceGUID,
+		)
+	}
+
+	_, err = h.securityGroupRepo.UnbindSecurityGroup(r.Context(), authInfo, repositories.UnbindSecurityGroupMessage{
+		GUID:      securityGroupGUID,
+		SpaceGUID: spaceGUID,
+		Workloads: workloads,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to unbind security group", "securityGroupGUID", securityGroupGUID, "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
 func (h *SecurityGroup) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -90,5 +280,13 @@ func (h *SecurityGroup) UnauthenticatedRoutes() []routing.Route {
 func (h *SecurityGroup) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "POST", Pattern: SecurityGroupsPath, Handler: h.create},
+		{Method: "GET", Pattern: SecurityGroupsPath, Handler: h.list},
+		{Method: "GET", Pattern: SecurityGroupPath, Handler: h.get},
+		{Method: "PATCH", Pattern: SecurityGroupPath, Handler: h.update},
+		{Method: "DELETE", Pattern: SecurityGroupPath, Handler: h.delete},
+		{Method: "POST", Pattern: SecurityGroupRunningSpacesPath, Handler: h.bindRunningSpaces},
+		{Method: "POST", Pattern: SecurityGroupStagingSpacesPath, Handler: h.bindStagingSpaces},
+		{Method: "DELETE", Pattern: SecurityGroupRunningSpaceBindingPath, Handler: h.unbindRunningSpace},
+		{Method: "DELETE", Pattern: SecurityGroupStagingSpaceBindingPath, Handler: h.unbindStagingSpace},
 	}
 }
@@ -13,9 +13,11 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("SecurityGroup", func() {
@@ -185,4 +187,378 @@ var _ = Describe("SecurityGroup", func() {
 			})
 		})
 	})
+
+	Describe("GET /v3/security_groups/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/security_groups/sg-guid"
+
+			securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID: "sg-guid",
+				Name: "test-security-group",
+			}, nil)
+		})
+
+		It("returns the security group", func() {
+			Expect(securityGroupRepo.GetSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := securityGroupRepo.GetSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sg-guid"),
+				MatchJSONPath("$.name", "test-security-group"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/sg-guid"),
+			)))
+		})
+
+		When("getting the security group is forbidden", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+			})
+		})
+
+		When("getting the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/security_groups", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/security_groups"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SecurityGroupList{
+				Names:                  "sg1,sg2",
+				GloballyEnabledRunning: tools.PtrTo(true),
+				RunningSpaceGUIDs:      "space1",
+			})
+
+			securityGroupRepo.ListSecurityGroupsReturns(repositories.ListResult[repositories.SecurityGroupRecord]{
+				Records: []repositories.SecurityGroupRecord{
+					{GUID: "sg1-guid", Name: "sg1"},
+					{GUID: "sg2-guid", Name: "sg2"},
+				},
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 2,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     2,
+				},
+			}, nil)
+		})
+
+		It("lists the security groups", func() {
+			Expect(securityGroupRepo.ListSecurityGroupsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := securityGroupRepo.ListSecurityGroupsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("sg1", "sg2"))
+			Expect(message.GloballyEnabledRunning).To(gstruct.PointTo(BeTrue()))
+			Expect(message.RunningSpaceGUIDs).To(ConsistOf("space1"))
+			Expect(message.IncludeGloballyEnabled).To(BeFalse())
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "sg1-guid"),
+				MatchJSONPath("$.resources[1].guid", "sg2-guid"),
+			)))
+		})
+
+		When("listing the security groups fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.ListSecurityGroupsReturns(repositories.ListResult[repositories.SecurityGroupRecord]{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/security_groups/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/security_groups/sg-guid"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupUpdate{
+				DisplayName: tools.PtrTo("new-name"),
+				GloballyEnabled: payloads.SecurityGroupWorkloadsPatch{
+					Staging: tools.PtrTo(true),
+				},
+			})
+
+			securityGroupRepo.UpdateSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID: "sg-guid",
+				Name: "new-name",
+			}, nil)
+		})
+
+		It("updates the security group", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(securityGroupRepo.UpdateSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := securityGroupRepo.UpdateSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdateSecurityGroupMessage{
+				GUID:        "sg-guid",
+				DisplayName: tools.PtrTo("new-name"),
+				GloballyEnabled: repositories.UpdateSecurityGroupWorkloads{
+					Staging: tools.PtrTo(true),
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "new-name")))
+		})
+
+		When("the security group does not exist", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+				Expect(securityGroupRepo.UpdateSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("the request body is not valid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("updating the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.UpdateSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/security_groups/sg-guid"
+		})
+
+		It("deletes the security group", func() {
+			Expect(securityGroupRepo.DeleteSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := securityGroupRepo.DeleteSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/security_group.delete~sg-guid"))
+		})
+
+		When("the security group does not exist", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+				Expect(securityGroupRepo.DeleteSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("deleting the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.DeleteSecurityGroupReturns(errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/security_groups/{guid}/relationships/running_spaces", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/security_groups/sg-guid/relationships/running_spaces"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupBind{
+				Data: []payloads.RelationshipData{{GUID: "space1"}, {GUID: "space2"}},
+			})
+			spaceRepo.ListSpacesReturns(repositories.ListResult[repositories.SpaceRecord]{
+				Records: []repositories.SpaceRecord{{GUID: "space1"}, {GUID: "space2"}},
+			}, nil)
+			securityGroupRepo.BindSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID:          "sg-guid",
+				RunningSpaces: []string{"space1", "space2"},
+				StagingSpaces: []string{"space3"},
+			}, nil)
+		})
+
+		It("binds the security group to the spaces for running workloads", func() {
+			Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+			_, _, listSpacesMessage := spaceRepo.ListSpacesArgsForCall(0)
+			Expect(listSpacesMessage.GUIDs).To(ConsistOf("space1", "space2"))
+
+			Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := securityGroupRepo.BindSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.BindSecurityGroupMessage{
+				GUID:       "sg-guid",
+				SpaceGUIDs: []string{"space1", "space2"},
+				Workloads:  korifiv1alpha1.SecurityGroupRunningWorkloads,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data", HaveLen(2)),
+				MatchJSONPath("$.data[0].guid", "space1"),
+				MatchJSONPath("$.data[1].guid", "space2"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/sg-guid/relationships/running_spaces"),
+			)))
+		})
+
+		When("binding staging spaces", func() {
+			BeforeEach(func() {
+				requestPath = "/v3/security_groups/sg-guid/relationships/staging_spaces"
+			})
+
+			It("binds the security group to the spaces for staging workloads", func() {
+				Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(Equal(1))
+				_, _, message := securityGroupRepo.BindSecurityGroupArgsForCall(0)
+				Expect(message.Workloads).To(Equal(korifiv1alpha1.SecurityGroupStagingWorkloads))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.data", HaveLen(1)),
+					MatchJSONPath("$.data[0].guid", "space3"),
+					MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/sg-guid/relationships/staging_spaces"),
+				)))
+			})
+		})
+
+		When("the security group does not exist", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+				Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("one of the spaces does not exist", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns(repositories.ListResult[repositories.SpaceRecord]{
+					Records: []repositories.SpaceRecord{{GUID: "space1"}},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Space does not exist, or you do not have access.")
+				Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("the request body is not valid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("binding the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.BindSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/{guid}/relationships/running_spaces/{space_guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/security_groups/sg-guid/relationships/running_spaces/space1"
+		})
+
+		It("unbinds the security group from the space for running workloads", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("space1"))
+
+			Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := securityGroupRepo.UnbindSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UnbindSecurityGroupMessage{
+				GUID:      "sg-guid",
+				SpaceGUID: "space1",
+				Workloads: korifiv1alpha1.SecurityGroupRunningWorkloads,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("unbinding a staging space", func() {
+			BeforeEach(func() {
+				requestPath = "/v3/security_groups/sg-guid/relationships/staging_spaces/space1"
+			})
+
+			It("unbinds the security group from the space for staging workloads", func() {
+				Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(Equal(1))
+				_, _, message := securityGroupRepo.UnbindSecurityGroupArgsForCall(0)
+				Expect(message.Workloads).To(Equal(korifiv1alpha1.SecurityGroupStagingWorkloads))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+			})
+		})
+
+		When("the space does not exist", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Space does not exist, or you do not have access.")
+				Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("unbinding the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.UnbindSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/url"
+	"slices"
 
 	"github.com/go-logr/logr"
 
@@ -14,6 +16,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 )
 
 const (
@@ -29,6 +32,7 @@ type ServiceBinding struct {
 	serviceInstanceRepo CFServiceInstanceRepository
 	serverURL           url.URL
 	requestValidator    RequestValidator
+	auditEventRecorder  AuditEventRecorder
 }
 
 //counterfeiter:generate -o fake -fake-name CFServiceBindingRepository . CFServiceBindingRepository
@@ -42,13 +46,14 @@ type CFServiceBindingRepository interface {
 	GetServiceBindingParameters(context.Context, authorization.Info, string) (map[string]any, error)
 }
 
-func NewServiceBinding(serverURL url.URL, serviceBindingRepo CFServiceBindingRepository, appRepo CFAppRepository, serviceInstanceRepo CFServiceInstanceRepository, requestValidator RequestValidator) *ServiceBinding {
+func NewServiceBinding(serverURL url.URL, serviceBindingRepo CFServiceBindingRepository, appRepo CFAppRepository, serviceInstanceRepo CFServiceInstanceRepository, requestValidator RequestValidator, auditEventRecorder AuditEventRecorder) *ServiceBinding {
 	return &ServiceBinding{
 		appRepo:             appRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
 		serverURL:           serverURL,
 		requestValidator:    requestValidator,
+		auditEventRecorder:  auditEventRecorder,
 	}
 }
 
@@ -61,37 +66,68 @@ func (h *ServiceBinding) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
-	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, payload.Relationships.ServiceInstance.Data.GUID)
-	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceInstanceResourceType)
-	}
-
-	ctx := logr.NewContext(r.Context(), logger.WithValues("service-instance", serviceInstance.GUID))
+	var (
+		serviceInstance repositories.ServiceInstanceRecord
+		err             error
+	)
+	bindingSpaceGUID := ""
 
 	if payload.Type == korifiv1alpha1.CFServiceBindingTypeApp {
 		var app repositories.AppRecord
-		if app, err = h.appRepo.GetApp(ctx, authInfo, payload.Relationships.App.Data.GUID); err != nil {
+		if app, err = h.appRepo.GetApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID); err != nil {
 			return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.AppResourceType)
 		}
 
-		if app.SpaceGUID != serviceInstance.SpaceGUID {
-			return nil, apierrors.LogAndReturn(
-				logger,
-				apierrors.NewUnprocessableEntityError(nil, "The service instance and the app are in different spaces"),
-				"App and ServiceInstance in different spaces", "App GUID", app.GUID,
-				"ServiceInstance GUID", serviceInstance.GUID,
-			)
+		serviceInstance, err = h.getServiceInstanceForApp(r.Context(), authInfo, payload.Relationships.ServiceInstance.Data.GUID, app)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to get "+repositories.ServiceInstanceResourceType, "App GUID", app.GUID)
+		}
+		bindingSpaceGUID = app.SpaceGUID
+	} else {
+		serviceInstance, err = h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, payload.Relationships.ServiceInstance.Data.GUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceInstanceResourceType)
 		}
+		bindingSpaceGUID = serviceInstance.SpaceGUID
 	}
 
+	ctx := logr.NewContext(r.Context(), logger.WithValues("service-instance", serviceInstance.GUID))
+	message := payload.ToMessage(bindingSpaceGUID, serviceInstance.SpaceGUID)
+
 	if serviceInstance.Type == korifiv1alpha1.UserProvidedType {
-		return h.createUserProvided(ctx, &payload, serviceInstance)
+		return h.createUserProvided(ctx, &payload, message)
 	}
 
-	return h.createManaged(ctx, &payload, serviceInstance)
+	return h.createManaged(ctx, message)
 }
 
-func (h *ServiceBinding) createUserProvided(ctx context.Context, payload *payloads.ServiceBindingCreate, serviceInstance repositories.ServiceInstanceRecord) (*routing.Response, error) {
+// getServiceInstanceForApp returns the service instance an app is about to be
+// bound to. The instance must either be in the app space or be shared with it.
+// Developers in the app space usually cannot read the space a shared instance
+// has been created in, so shared instances are looked up separately.
+func (h *ServiceBinding) getServiceInstanceForApp(ctx context.Context, authInfo authorization.Info, serviceInstanceGUID string, app repositories.AppRecord) (repositories.ServiceInstanceRecord, error) {
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(ctx, authInfo, serviceInstanceGUID)
+	if err != nil {
+		if !errors.As(err, &apierrors.NotFoundError{}) && !errors.As(err, &apierrors.ForbiddenError{}) {
+			return repositories.ServiceInstanceRecord{}, err
+		}
+
+		sharedServiceInstance, sharedErr := h.serviceInstanceRepo.GetSharedServiceInstance(ctx, authInfo, serviceInstanceGUID, app.SpaceGUID)
+		if sharedErr != nil {
+			return repositories.ServiceInstanceRecord{}, apierrors.ForbiddenAsNotFound(err)
+		}
+
+		return sharedServiceInstance, nil
+	}
+
+	if app.SpaceGUID != serviceInstance.SpaceGUID && !slices.Contains(serviceInstance.SharedSpaceGUIDs, app.SpaceGUID) {
+		return repositories.ServiceInstanceRecord{}, apierrors.NewUnprocessableEntityError(nil, "The service instance and the app are in different spaces")
+	}
+
+	return serviceInstance, nil
+}
+
+func (h *ServiceBinding) createUserProvided(ctx context.Context, payload *payloads.ServiceBindingCreate, message repositories.CreateServiceBindingMessage) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(ctx)
 	logger := logr.FromContextOrDiscard(ctx).WithName("handlers.service-binding.create-user-provided")
 
@@ -103,22 +139,25 @@ func (h *ServiceBinding) createUserProvided(ctx context.Context, payload *payloa
 		)
 	}
 
-	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, payload.ToMessage(serviceInstance.SpaceGUID))
+	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, message)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logr.FromContextOrDiscard(ctx), err, "failed to create ServiceBinding")
 	}
+	h.recordServiceBindingEvent(ctx, serviceBinding, true)
 
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForServiceBinding(serviceBinding, h.serverURL)), nil
 }
 
-func (h *ServiceBinding) createManaged(ctx context.Context, payload *payloads.ServiceBindingCreate, serviceInstance repositories.ServiceInstanceRecord) (*routing.Response, error) {
+func (h *ServiceBinding) createManaged(ctx context.Context, message repositories.CreateServiceBindingMessage) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(ctx)
 	logger := logr.FromContextOrDiscard(ctx).WithName("handlers.service-binding.create-managed")
 
-	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, payload.ToMessage(serviceInstance.SpaceGUID))
+	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, message)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to create ServiceBinding")
 	}
+	h.recordServiceBindingEvent(ctx, serviceBinding, true)
+
 	return routing.NewResponse(http.StatusAccepted).
 		WithHeader("Location", presenter.JobURLForRedirects(serviceBinding.GUID, presenter.ManagedServiceBindingCreateOperation, h.serverURL)), nil
 }
@@ -147,6 +186,7 @@ func (h *ServiceBinding) delete(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "error when deleting service binding", "guid", serviceBindingGUID)
 	}
+	h.recordServiceBindingEvent(r.Context(), serviceBinding, false)
 
 	if serviceInstance.Type == korifiv1alpha1.ManagedType {
 		return routing.NewResponse(http.StatusAccepted).
@@ -156,6 +196,35 @@ func (h *ServiceBinding) delete(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusNoContent), nil
 }
 
+func (h *ServiceBinding) recordServiceBindingEvent(ctx context.Context, serviceBinding repositories.ServiceBindingRecord, created bool) {
+	eventType, targetType := repositories.AuditEventTypeServiceBindingDelete, repositories.AuditEventTargetTypeServiceBinding
+	if created {
+		eventType = repositories.AuditEventTypeServiceBindingCreate
+	}
+	if serviceBinding.Type == korifiv1alpha1.CFServiceBindingTypeKey {
+		eventType, targetType = repositories.AuditEventTypeServiceKeyDelete, repositories.AuditEventTargetTypeServiceKey
+		if created {
+			eventType = repositories.AuditEventTypeServiceKeyCreate
+		}
+	}
+
+	request := map[string]any{"service_instance_guid": serviceBinding.ServiceInstanceGUID}
+	if serviceBinding.AppGUID != "" {
+		request["app_guid"] = serviceBinding.AppGUID
+	}
+
+	recordAuditEvent(ctx, h.auditEventRecorder, repositories.CreateAuditEventMessage{
+		Type: eventType,
+		Target: repositories.AuditEventTarget{
+			GUID: serviceBinding.GUID,
+			Type: targetType,
+			Name: tools.ZeroIfNil(serviceBinding.Name),
+		},
+		SpaceGUID: serviceBinding.SpaceGUID,
+		Data:      map[string]any{"request": request},
+	})
+}
+
 func (h *ServiceBinding) list(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-binding.list")
@@ -31,6 +31,7 @@ var _ = Describe("ServiceBinding", func() {
 		appRepo             *fake.CFAppRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		requestValidator    *fake.RequestValidator
+		auditEventRecorder  *fake.AuditEventRecorder
 	)
 
 	BeforeEach(func() {
@@ -54,6 +55,7 @@ var _ = Describe("ServiceBinding", func() {
 		}, nil)
 
 		requestValidator = new(fake.RequestValidator)
+		auditEventRecorder = new(fake.AuditEventRecorder)
 
 		apiHandler := NewServiceBinding(
 			*serverURL,
@@ -61,6 +63,7 @@ var _ = Describe("ServiceBinding", func() {
 			appRepo,
 			serviceInstanceRepo,
 			requestValidator,
+			auditEventRecorder,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -133,6 +136,14 @@ var _ = Describe("ServiceBinding", func() {
 					Expect(createServiceBindingMessage.SpaceGUID).To(Equal("space-guid"))
 					Expect(createServiceBindingMessage.Type).To(Equal(korifiv1alpha1.CFServiceBindingTypeKey))
 				})
+
+				It("records a service key create audit event", func() {
+					Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+					_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+					Expect(actualMessage.Type).To(Equal("audit.service_key.create"))
+					Expect(actualMessage.Target.GUID).To(Equal("service-binding-guid"))
+					Expect(actualMessage.Target.Type).To(Equal("service_key"))
+				})
 			})
 		})
 
@@ -169,11 +180,36 @@ var _ = Describe("ServiceBinding", func() {
 					}, nil)
 
 					serviceBindingRepo.CreateServiceBindingReturns(repositories.ServiceBindingRecord{
-						GUID: "service-binding-guid",
-						Type: korifiv1alpha1.CFServiceBindingTypeApp,
+						GUID:                "service-binding-guid",
+						Type:                korifiv1alpha1.CFServiceBindingTypeApp,
+						Name:                tools.PtrTo("my-binding"),
+						AppGUID:             "app-guid",
+						ServiceInstanceGUID: "service-instance-guid",
+						SpaceGUID:           "space-guid",
 					}, nil)
 				})
 
+				It("records a service binding create audit event", func() {
+					Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+					_, actualAuthInfo, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(actualMessage).To(Equal(repositories.CreateAuditEventMessage{
+						Type: "audit.service_binding.create",
+						Target: repositories.AuditEventTarget{
+							GUID: "service-binding-guid",
+							Type: "service_binding",
+							Name: "my-binding",
+						},
+						SpaceGUID: "space-guid",
+						Data: map[string]any{
+							"request": map[string]any{
+								"app_guid":              "app-guid",
+								"service_instance_guid": "service-instance-guid",
+							},
+						},
+					}))
+				})
+
 				It("creates a service binding", func() {
 					Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(1))
 					_, actualAuthInfo, createServiceBindingMessage := serviceBindingRepo.CreateServiceBindingArgsForCall(0)
@@ -279,6 +315,75 @@ var _ = Describe("ServiceBinding", func() {
 					Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(0))
 				})
 			})
+
+			When("the ServiceInstance is shared with the App space", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+						GUID:             "service-instance-guid",
+						SpaceGUID:        "instance-space-guid",
+						Type:             korifiv1alpha1.ManagedType,
+						SharedSpaceGUIDs: []string{"space-guid"},
+					}, nil)
+				})
+
+				It("creates the binding in the App space", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+					Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(1))
+					_, _, createServiceBindingMessage := serviceBindingRepo.CreateServiceBindingArgsForCall(0)
+					Expect(createServiceBindingMessage.SpaceGUID).To(Equal("space-guid"))
+					Expect(createServiceBindingMessage.ServiceInstanceSpaceGUID).To(Equal("instance-space-guid"))
+				})
+			})
+
+			When("the user cannot read the space of a ServiceInstance shared with the App space", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+					serviceInstanceRepo.GetSharedServiceInstanceReturns(repositories.ServiceInstanceRecord{
+						GUID:             "service-instance-guid",
+						SpaceGUID:        "instance-space-guid",
+						Type:             korifiv1alpha1.ManagedType,
+						SharedSpaceGUIDs: []string{"space-guid"},
+					}, nil)
+				})
+
+				It("looks up the shared ServiceInstance", func() {
+					Expect(serviceInstanceRepo.GetSharedServiceInstanceCallCount()).To(Equal(1))
+					_, actualAuthInfo, actualGUID, actualSpaceGUID := serviceInstanceRepo.GetSharedServiceInstanceArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(actualGUID).To(Equal("service-instance-guid"))
+					Expect(actualSpaceGUID).To(Equal("space-guid"))
+				})
+
+				It("creates the binding in the App space", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+					Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(1))
+					_, _, createServiceBindingMessage := serviceBindingRepo.CreateServiceBindingArgsForCall(0)
+					Expect(createServiceBindingMessage.SpaceGUID).To(Equal("space-guid"))
+					Expect(createServiceBindingMessage.ServiceInstanceSpaceGUID).To(Equal("instance-space-guid"))
+				})
+
+				When("the ServiceInstance is not shared with the App space", func() {
+					BeforeEach(func() {
+						serviceInstanceRepo.GetSharedServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewNotFoundError(nil, repositories.ServiceInstanceResourceType))
+					})
+
+					It("returns a not found error", func() {
+						expectNotFoundError(repositories.ServiceInstanceResourceType)
+						Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(0))
+					})
+				})
+			})
+
+			When("getting the ServiceInstance errors", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, errors.New("boom"))
+				})
+
+				It("returns an error without looking up shared instances", func() {
+					expectUnknownError()
+					Expect(serviceInstanceRepo.GetSharedServiceInstanceCallCount()).To(Equal(0))
+				})
+			})
 		})
 	})
 
@@ -540,6 +645,23 @@ var _ = Describe("ServiceBinding", func() {
 			Expect(guid).To(Equal("service-binding-guid"))
 		})
 
+		It("records a service binding delete audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualMessage.Type).To(Equal("audit.service_binding.delete"))
+			Expect(actualMessage.Target.GUID).To(Equal("service-binding-guid"))
+		})
+
+		When("deleting the service binding fails", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.DeleteServiceBindingReturns(errors.New("delete-failed"))
+			})
+
+			It("does not record an audit event", func() {
+				Expect(auditEventRecorder.CreateAuditEventCallCount()).To(BeZero())
+			})
+		})
+
 		When("the service instance is managed", func() {
 			BeforeEach(func() {
 				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
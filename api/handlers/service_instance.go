@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
@@ -18,12 +21,19 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 
 	"github.com/go-logr/logr"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
 )
 
 const (
 	ServiceInstancesPath           = "/v3/service_instances"
 	ServiceInstancePath            = "/v3/service_instances/{guid}"
 	ServiceInstanceCredentialsPath = "/v3/service_instances/{guid}/credentials"
+
+	ServiceInstanceSharedSpacesPath             = "/v3/service_instances/{guid}/relationships/shared_spaces"
+	ServiceInstanceSharedSpacePath              = "/v3/service_instances/{guid}/relationships/shared_spaces/{space_guid}"
+	ServiceInstanceSharedSpacesUsageSummaryPath = "/v3/service_instances/{guid}/relationships/shared_spaces/usage_summary"
 )
 
 //counterfeiter:generate -o fake -fake-name CFServiceInstanceRepository . CFServiceInstanceRepository
@@ -35,13 +45,21 @@ type CFServiceInstanceRepository interface {
 	GetServiceInstance(context.Context, authorization.Info, string) (repositories.ServiceInstanceRecord, error)
 	GetServiceInstanceCredentials(context.Context, authorization.Info, string) (map[string]any, error)
 	DeleteServiceInstance(context.Context, authorization.Info, repositories.DeleteServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
+	ShareServiceInstance(context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
+	CanShareServiceInstanceWith(context.Context, authorization.Info, string) (bool, error)
+	UnshareServiceInstance(context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) error
+	GetSharedServiceInstance(context.Context, authorization.Info, string, string) (repositories.ServiceInstanceRecord, error)
+	GetSharedSpacesUsageSummary(context.Context, authorization.Info, string) ([]repositories.SharedSpaceUsageRecord, error)
 }
 
 type ServiceInstance struct {
 	serverURL           url.URL
 	serviceInstanceRepo CFServiceInstanceRepository
 	spaceRepo           CFSpaceRepository
+	servicePlanRepo     CFServicePlanRepository
+	serviceOfferingRepo CFServiceOfferingRepository
 	requestValidator    RequestValidator
+	auditEventRecorder  AuditEventRecorder
 	includeResolver     *include.IncludeResolver[
 		[]repositories.ServiceInstanceRecord,
 		repositories.ServiceInstanceRecord,
@@ -52,14 +70,20 @@ func NewServiceInstance(
 	serverURL url.URL,
 	serviceInstanceRepo CFServiceInstanceRepository,
 	spaceRepo CFSpaceRepository,
+	servicePlanRepo CFServicePlanRepository,
+	serviceOfferingRepo CFServiceOfferingRepository,
 	requestValidator RequestValidator,
 	relationshipRepo include.ResourceRelationshipRepository,
+	auditEventRecorder AuditEventRecorder,
 ) *ServiceInstance {
 	return &ServiceInstance{
 		serverURL:           serverURL,
 		serviceInstanceRepo: serviceInstanceRepo,
 		spaceRepo:           spaceRepo,
+		servicePlanRepo:     servicePlanRepo,
+		serviceOfferingRepo: serviceOfferingRepo,
 		requestValidator:    requestValidator,
+		auditEventRecorder:  auditEventRecorder,
 		includeResolver:     include.NewIncludeResolver[[]repositories.ServiceInstanceRecord](relationshipRepo, presenter.NewResource(serverURL)),
 	}
 }
@@ -150,6 +174,7 @@ func (h *ServiceInstance) createManagedServiceInstance(
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create managed service instance", "Service Instance Name", payload.Name)
 	}
+	h.recordServiceInstanceEvent(ctx, repositories.AuditEventTypeServiceInstanceStartCreate, serviceInstanceRecord, nil)
 
 	return routing.NewResponse(http.StatusAccepted).
 		WithHeader("Location", presenter.JobURLForRedirects(serviceInstanceRecord.GUID, presenter.ManagedServiceInstanceCreateOperation, h.serverURL)), nil
@@ -165,6 +190,7 @@ func (h *ServiceInstance) createUserProvidedServiceInstance(
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create user provided service instance", "Service Instance Name", payload.Name)
 	}
+	h.recordServiceInstanceEvent(ctx, repositories.AuditEventTypeUserProvidedServiceInstanceCreate, serviceInstanceRecord, nil)
 
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForServiceInstance(serviceInstanceRecord, h.serverURL)), nil
 }
@@ -185,15 +211,126 @@ func (h *ServiceInstance) patch(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance")
 	}
 
+	if payload.RouteServiceURL != nil && serviceInstance.Type != korifiv1alpha1.UserProvidedType {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Route service url can only be updated for user-provided service instances."),
+			"invalid managed service instance patch",
+		)
+	}
+
+	if payload.SyslogDrainURL != nil && serviceInstance.Type != korifiv1alpha1.UserProvidedType {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Syslog drain url can only be updated for user-provided service instances."),
+			"invalid managed service instance patch",
+		)
+	}
+
+	if payload.IsManagedUpdate() {
+		if serviceInstance.Type != korifiv1alpha1.ManagedType {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, "Service plan, parameters and maintenance info can only be updated for managed service instances."),
+				"invalid user-provided service instance patch",
+			)
+		}
+
+		if err = h.validateManagedUpdate(r.Context(), authInfo, serviceInstance, payload); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "invalid managed service instance update", "GUID", serviceInstance.GUID)
+		}
+	}
+
 	patchMessage := payload.ToServiceInstancePatchMessage(serviceInstance.SpaceGUID, serviceInstance.GUID)
 	serviceInstance, err = h.serviceInstanceRepo.PatchServiceInstance(r.Context(), authInfo, patchMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to patch service instance")
 	}
 
+	if payload.IsManagedUpdate() {
+		h.recordServiceInstanceEvent(r.Context(), repositories.AuditEventTypeServiceInstanceStartUpdate, serviceInstance, nil)
+		return routing.NewResponse(http.StatusAccepted).
+			WithHeader("Location", presenter.JobURLForRedirects(serviceInstance.GUID, presenter.ManagedServiceInstanceUpdateOperation, h.serverURL)), nil
+	}
+
+	eventType := repositories.AuditEventTypeUserProvidedServiceInstanceUpdate
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
+		eventType = repositories.AuditEventTypeServiceInstanceUpdate
+	}
+	h.recordServiceInstanceEvent(r.Context(), eventType, serviceInstance, nil)
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstance(serviceInstance, h.serverURL)), nil
 }
 
+func (h *ServiceInstance) validateManagedUpdate(
+	ctx context.Context,
+	authInfo authorization.Info,
+	serviceInstance repositories.ServiceInstanceRecord,
+	payload payloads.ServiceInstancePatch,
+) error {
+	currentPlan, err := h.servicePlanRepo.GetPlan(ctx, authInfo, serviceInstance.PlanGUID)
+	if err != nil {
+		return err
+	}
+
+	targetPlan := currentPlan
+	if planGUID := payload.PlanGUID(); planGUID != nil && *planGUID != serviceInstance.PlanGUID {
+		targetPlan, err = h.servicePlanRepo.GetPlan(ctx, authInfo, *planGUID)
+		if err != nil {
+			return apierrors.AsUnprocessableEntity(
+				err,
+				"Invalid service plan. Ensure that the service plan exists, is available, and you have access to it.",
+				apierrors.NotFoundError{}, apierrors.ForbiddenError{},
+			)
+		}
+
+		if targetPlan.ServiceOfferingGUID != currentPlan.ServiceOfferingGUID {
+			return apierrors.NewUnprocessableEntityError(nil, "The service plan relates to a different service offering.")
+		}
+
+		serviceOffering, err := h.serviceOfferingRepo.GetServiceOffering(ctx, authInfo, currentPlan.ServiceOfferingGUID)
+		if err != nil {
+			return err
+		}
+
+		if !serviceOffering.BrokerCatalog.Features.PlanUpdateable && !targetPlan.BrokerCatalog.Features.PlanUpdateable {
+			return apierrors.NewUnprocessableEntityError(nil, "The service does not support changing plans.")
+		}
+	}
+
+	if payload.MaintenanceInfo != nil && payload.MaintenanceInfo.Version != targetPlan.MaintenanceInfo.Version {
+		return apierrors.NewUnprocessableEntityError(nil, "The maintenance_info.version requested does not match the version of the service plan.")
+	}
+
+	if payload.Parameters != nil {
+		if err = validateParameters(targetPlan.Schemas.ServiceInstance.Update.Parameters, *payload.Parameters); err != nil {
+			return apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("The parameters do not match the service plan schema: %s", err.Error()))
+		}
+	}
+
+	return nil
+}
+
+// validateParameters validates parameters against the JSON schema a broker
+// has advertised for them. An empty schema accepts any parameters.
+func validateParameters(schema map[string]any, parameters map[string]any) error {
+	if len(schema) == 0 {
+		return nil
+	}
+
+	schemaBytes, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+
+	parametersSchema := new(spec.Schema)
+	if err = json.Unmarshal(schemaBytes, parametersSchema); err != nil {
+		return fmt.Errorf("invalid schema: %w", err)
+	}
+
+	return validate.AgainstSchema(parametersSchema, parameters, strfmt.Default)
+}
+
 func (h *ServiceInstance) list(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.list")
@@ -234,12 +371,198 @@ func (h *ServiceInstance) delete(r *http.Request) (*routing.Response, error) {
 	}
 
 	if serviceInstance.Type == korifiv1alpha1.ManagedType {
+		h.recordServiceInstanceEvent(r.Context(), repositories.AuditEventTypeServiceInstanceStartDelete, serviceInstance, nil)
 		return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(serviceInstance.GUID, presenter.ManagedServiceInstanceDeleteOperation, h.serverURL)), nil
 	}
 
+	h.recordServiceInstanceEvent(r.Context(), repositories.AuditEventTypeUserProvidedServiceInstanceDelete, serviceInstance, nil)
 	return routing.NewResponse(http.StatusNoContent), nil
 }
 
+func (h *ServiceInstance) getSharedSpaces(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.get-shared-spaces")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "GUID", serviceInstanceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceSharedSpaces(serviceInstance, h.serverURL)), nil
+}
+
+func (h *ServiceInstance) share(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.share")
+
+	var payload payloads.ServiceInstanceShare
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "GUID", serviceInstanceGUID)
+	}
+
+	message := payload.ToMessage(serviceInstanceGUID)
+	if err = h.validateShare(r.Context(), authInfo, serviceInstance, message.SpaceGUIDs); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "cannot share service instance", "GUID", serviceInstanceGUID)
+	}
+
+	serviceInstance, err = h.serviceInstanceRepo.ShareServiceInstance(r.Context(), authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to share service instance", "GUID", serviceInstanceGUID)
+	}
+	h.recordServiceInstanceEvent(r.Context(), repositories.AuditEventTypeServiceInstanceShare, serviceInstance, map[string]any{
+		"target_space_guids": message.SpaceGUIDs,
+	})
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceSharedSpaces(serviceInstance, h.serverURL)), nil
+}
+
+func (h *ServiceInstance) validateShare(
+	ctx context.Context,
+	authInfo authorization.Info,
+	serviceInstance repositories.ServiceInstanceRecord,
+	spaceGUIDs []string,
+) error {
+	if serviceInstance.Type != korifiv1alpha1.ManagedType {
+		return apierrors.NewUnprocessableEntityError(nil, "User-provided services cannot be shared.")
+	}
+
+	plan, err := h.servicePlanRepo.GetPlan(ctx, authInfo, serviceInstance.PlanGUID)
+	if err != nil {
+		return err
+	}
+
+	serviceOffering, err := h.serviceOfferingRepo.GetServiceOffering(ctx, authInfo, plan.ServiceOfferingGUID)
+	if err != nil {
+		return err
+	}
+
+	if shareable, _ := serviceOffering.BrokerCatalog.Metadata["shareable"].(bool); !shareable {
+		return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("The %s service does not support service instance sharing.", serviceOffering.Name))
+	}
+
+	for _, spaceGUID := range spaceGUIDs {
+		if spaceGUID == serviceInstance.SpaceGUID {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+				"Unable to share service instance '%s' with space '%s'. Service instances cannot be shared into the space where they were created.",
+				serviceInstance.Name, spaceGUID,
+			))
+		}
+
+		space, err := h.spaceRepo.GetSpace(ctx, authInfo, spaceGUID)
+		if err != nil {
+			return apierrors.AsUnprocessableEntity(
+				err,
+				fmt.Sprintf("Unable to share service instance %s with spaces ['%s']. Ensure the spaces exist and that you have access to them.", serviceInstance.Name, spaceGUID),
+				apierrors.NotFoundError{}, apierrors.ForbiddenError{},
+			)
+		}
+
+		canShare, err := h.serviceInstanceRepo.CanShareServiceInstanceWith(ctx, authInfo, spaceGUID)
+		if err != nil {
+			return err
+		}
+
+		if !canShare {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+				"Unable to share service instance %s with spaces ['%s']. Write permission is required in order to share a service instance with a space.",
+				serviceInstance.Name, spaceGUID,
+			))
+		}
+
+		sameNameInstances, err := h.serviceInstanceRepo.ListServiceInstances(ctx, authInfo, repositories.ListServiceInstanceMessage{
+			Names:      []string{serviceInstance.Name},
+			SpaceGUIDs: []string{spaceGUID},
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(sameNameInstances.Records) > 0 {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("A service instance called %s already exists in %s.", serviceInstance.Name, space.Name))
+		}
+	}
+
+	return nil
+}
+
+func (h *ServiceInstance) unshare(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.unshare")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space_guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "GUID", serviceInstanceGUID)
+	}
+
+	if !slices.Contains(serviceInstance.SharedSpaceGUIDs, spaceGUID) {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf(
+				"Unable to unshare service instance from space %s. Ensure the space exists and the service instance has been shared to this space.",
+				spaceGUID,
+			)),
+			"service instance is not shared with space", "GUID", serviceInstanceGUID, "spaceGUID", spaceGUID,
+		)
+	}
+
+	err = h.serviceInstanceRepo.UnshareServiceInstance(r.Context(), authInfo, repositories.UnshareServiceInstanceMessage{
+		GUID:      serviceInstanceGUID,
+		SpaceGUID: spaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to unshare service instance", "GUID", serviceInstanceGUID, "spaceGUID", spaceGUID)
+	}
+	h.recordServiceInstanceEvent(r.Context(), repositories.AuditEventTypeServiceInstanceUnshare, serviceInstance, map[string]any{
+		"target_space_guid": spaceGUID,
+	})
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *ServiceInstance) getSharedSpacesUsageSummary(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.get-shared-spaces-usage-summary")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	usageSummary, err := h.serviceInstanceRepo.GetSharedSpacesUsageSummary(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get shared spaces usage summary", "GUID", serviceInstanceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceSharedSpacesUsageSummary(serviceInstanceGUID, usageSummary, h.serverURL)), nil
+}
+
+func (h *ServiceInstance) recordServiceInstanceEvent(ctx context.Context, eventType string, serviceInstance repositories.ServiceInstanceRecord, data map[string]any) {
+	targetType := repositories.AuditEventTargetTypeUserProvidedServiceInstance
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
+		targetType = repositories.AuditEventTargetTypeServiceInstance
+	}
+
+	recordAuditEvent(ctx, h.auditEventRecorder, repositories.CreateAuditEventMessage{
+		Type: eventType,
+		Target: repositories.AuditEventTarget{
+			GUID: serviceInstance.GUID,
+			Type: targetType,
+			Name: serviceInstance.Name,
+		},
+		SpaceGUID: serviceInstance.SpaceGUID,
+		Data:      data,
+	})
+}
+
 func (h *ServiceInstance) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -252,5 +575,9 @@ func (h *ServiceInstance) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: ServiceInstancePath, Handler: h.get},
 		{Method: "GET", Pattern: ServiceInstanceCredentialsPath, Handler: h.getCredentials},
 		{Method: "DELETE", Pattern: ServiceInstancePath, Handler: h.delete},
+		{Method: "GET", Pattern: ServiceInstanceSharedSpacesPath, Handler: h.getSharedSpaces},
+		{Method: "POST", Pattern: ServiceInstanceSharedSpacesPath, Handler: h.share},
+		{Method: "DELETE", Pattern: ServiceInstanceSharedSpacePath, Handler: h.unshare},
+		{Method: "GET", Pattern: ServiceInstanceSharedSpacesUsageSummaryPath, Handler: h.getSharedSpacesUsageSummary},
 	}
 }
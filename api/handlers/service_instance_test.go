@@ -1,10 +1,13 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"regexp"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -19,6 +22,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("ServiceInstance", func() {
@@ -30,6 +34,7 @@ var _ = Describe("ServiceInstance", func() {
 		servicePlanRepo     *fake.CFServicePlanRepository
 		serviceBrokerRepo   *fake.CFServiceBrokerRepository
 		requestValidator    *fake.RequestValidator
+		auditEventRecorder  *fake.AuditEventRecorder
 
 		reqMethod string
 		reqPath   string
@@ -50,11 +55,14 @@ var _ = Describe("ServiceInstance", func() {
 		servicePlanRepo = new(fake.CFServicePlanRepository)
 
 		requestValidator = new(fake.RequestValidator)
+		auditEventRecorder = new(fake.AuditEventRecorder)
 
 		apiHandler := NewServiceInstance(
 			*serverURL,
 			serviceInstanceRepo,
 			spaceRepo,
+			servicePlanRepo,
+			serviceOfferingRepo,
 			requestValidator,
 			relationships.NewResourseRelationshipsRepo(
 				serviceOfferingRepo,
@@ -63,6 +71,7 @@ var _ = Describe("ServiceInstance", func() {
 				spaceRepo,
 				orgRepo,
 			),
+			auditEventRecorder,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 
@@ -486,7 +495,25 @@ var _ = Describe("ServiceInstance", func() {
 					},
 				})
 
-				serviceInstanceRepo.CreateUserProvidedServiceInstanceReturns(repositories.ServiceInstanceRecord{GUID: "service-instance-guid"}, nil)
+				serviceInstanceRepo.CreateUserProvidedServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					Name:      "service-instance-name",
+					SpaceGUID: "space-guid",
+					Type:      korifiv1alpha1.UserProvidedType,
+				}, nil)
+			})
+
+			It("records a user provided service instance create audit event", func() {
+				Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualMessage.Type).To(Equal("audit.user_provided_service_instance.create"))
+				Expect(actualMessage.Target).To(Equal(repositories.AuditEventTarget{
+					GUID: "service-instance-guid",
+					Type: "user_provided_service_instance",
+					Name: "service-instance-name",
+				}))
+				Expect(actualMessage.SpaceGUID).To(Equal("space-guid"))
 			})
 
 			It("creates a user provided service instance with the repository", func() {
@@ -507,6 +534,10 @@ var _ = Describe("ServiceInstance", func() {
 				It("returns unknown error", func() {
 					expectUnknownError()
 				})
+
+				It("does not record an audit event", func() {
+					Expect(auditEventRecorder.CreateAuditEventCallCount()).To(BeZero())
+				})
 			})
 
 			It("returns HTTP 201 Created response", func() {
@@ -538,7 +569,24 @@ var _ = Describe("ServiceInstance", func() {
 					},
 				})
 
-				serviceInstanceRepo.CreateManagedServiceInstanceReturns(repositories.ServiceInstanceRecord{GUID: "service-instance-guid"}, nil)
+				serviceInstanceRepo.CreateManagedServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					Name:      "service-instance-name",
+					SpaceGUID: "space-guid",
+					Type:      korifiv1alpha1.ManagedType,
+				}, nil)
+			})
+
+			It("records a service instance start_create audit event", func() {
+				Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+				_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+				Expect(actualMessage.Type).To(Equal("audit.service_instance.start_create"))
+				Expect(actualMessage.Target).To(Equal(repositories.AuditEventTarget{
+					GUID: "service-instance-guid",
+					Type: "service_instance",
+					Name: "service-instance-name",
+				}))
+				Expect(actualMessage.SpaceGUID).To(Equal("space-guid"))
 			})
 
 			It("creates a managed service instance with the repository", func() {
@@ -889,6 +937,14 @@ var _ = Describe("ServiceInstance", func() {
 			)))
 		})
 
+		It("records a user provided service instance update audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualMessage.Type).To(Equal("audit.user_provided_service_instance.update"))
+			Expect(actualMessage.Target.GUID).To(Equal("service-instance-guid"))
+			Expect(actualMessage.Target.Type).To(Equal("user_provided_service_instance"))
+		})
+
 		When("decoding the payload fails", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
@@ -934,6 +990,256 @@ var _ = Describe("ServiceInstance", func() {
 				expectUnknownError()
 			})
 		})
+
+		When("the patch sets the route service url", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+					RouteServiceURL: tools.PtrTo("https://route-service.example.com"),
+				})
+			})
+
+			It("passes the route service url to the repository", func() {
+				Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(Equal(1))
+				_, _, patchMessage := serviceInstanceRepo.PatchServiceInstanceArgsForCall(0)
+				Expect(patchMessage.RouteServiceURL).To(PointTo(Equal("https://route-service.example.com")))
+			})
+
+			When("the service instance is managed", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+						GUID: "service-instance-guid",
+						Type: korifiv1alpha1.ManagedType,
+					}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Route service url can only be updated for user-provided service instances.")
+					Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		When("the patch sets the syslog drain url", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+					SyslogDrainURL: tools.PtrTo("syslog-tls://logs.example.com:6514"),
+				})
+			})
+
+			It("passes the syslog drain url to the repository", func() {
+				Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(Equal(1))
+				_, _, patchMessage := serviceInstanceRepo.PatchServiceInstanceArgsForCall(0)
+				Expect(patchMessage.SyslogDrainURL).To(PointTo(Equal("syslog-tls://logs.example.com:6514")))
+			})
+
+			When("the service instance is managed", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+						GUID: "service-instance-guid",
+						Type: korifiv1alpha1.ManagedType,
+					}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Syslog drain url can only be updated for user-provided service instances.")
+					Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		When("the patch requires a broker update", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+					Parameters: &map[string]any{"size": "large"},
+					MaintenanceInfo: &payloads.ServiceInstanceMaintenanceInfo{
+						Version: "2.0.0",
+					},
+					Relationships: &payloads.ServiceInstancePatchRelationships{
+						ServicePlan: &payloads.Relationship{
+							Data: &payloads.RelationshipData{GUID: "new-plan-guid"},
+						},
+					},
+				})
+
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					SpaceGUID: "space-guid",
+					Type:      korifiv1alpha1.ManagedType,
+					PlanGUID:  "plan-guid",
+				}, nil)
+
+				serviceInstanceRepo.PatchServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID: "service-instance-guid",
+				}, nil)
+
+				servicePlanRepo.GetPlanStub = func(_ context.Context, _ authorization.Info, guid string) (repositories.ServicePlanRecord, error) {
+					return repositories.ServicePlanRecord{
+						GUID:                guid,
+						ServiceOfferingGUID: "offering-guid",
+						MaintenanceInfo: repositories.MaintenanceInfo{
+							Version: "2.0.0",
+						},
+						Schemas: repositories.ServicePlanSchemas{
+							ServiceInstance: repositories.ServiceInstanceSchema{
+								Update: repositories.InputParameterSchema{
+									Parameters: map[string]any{
+										"type": "object",
+										"properties": map[string]any{
+											"size": map[string]any{
+												"type": "string",
+												"enum": []any{"small", "large"},
+											},
+										},
+									},
+								},
+							},
+						},
+					}, nil
+				}
+
+				serviceOfferingRepo.GetServiceOfferingReturns(repositories.ServiceOfferingRecord{
+					GUID: "offering-guid",
+					BrokerCatalog: repositories.ServiceBrokerCatalog{
+						Features: repositories.BrokerCatalogFeatures{
+							PlanUpdateable: true,
+						},
+					},
+				}, nil)
+			})
+
+			It("checks the current and the new plans", func() {
+				Expect(servicePlanRepo.GetPlanCallCount()).To(Equal(2))
+				_, _, actualCurrentPlanGUID := servicePlanRepo.GetPlanArgsForCall(0)
+				Expect(actualCurrentPlanGUID).To(Equal("plan-guid"))
+				_, _, actualNewPlanGUID := servicePlanRepo.GetPlanArgsForCall(1)
+				Expect(actualNewPlanGUID).To(Equal("new-plan-guid"))
+
+				Expect(serviceOfferingRepo.GetServiceOfferingCallCount()).To(Equal(1))
+				_, _, actualOfferingGUID := serviceOfferingRepo.GetServiceOfferingArgsForCall(0)
+				Expect(actualOfferingGUID).To(Equal("offering-guid"))
+			})
+
+			It("patches the service instance", func() {
+				Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(Equal(1))
+				_, _, patchMessage := serviceInstanceRepo.PatchServiceInstanceArgsForCall(0)
+				Expect(patchMessage.PlanGUID).To(PointTo(Equal("new-plan-guid")))
+				Expect(patchMessage.Parameters).To(PointTo(Equal(map[string]any{"size": "large"})))
+				Expect(patchMessage.MaintenanceInfo).To(PointTo(Equal(repositories.MaintenanceInfo{Version: "2.0.0"})))
+			})
+
+			It("returns a job to track the update", func() {
+				Expect(rr).To(SatisfyAll(
+					HaveHTTPStatus(http.StatusAccepted),
+					HaveHTTPHeaderWithValue("Location", ContainSubstring("/v3/jobs/managed_service_instance.update~service-instance-guid")),
+				))
+			})
+
+			It("records a service instance start_update audit event", func() {
+				Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+				_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+				Expect(actualMessage.Type).To(Equal("audit.service_instance.start_update"))
+				Expect(actualMessage.Target.GUID).To(Equal("service-instance-guid"))
+			})
+
+			When("the service instance is user-provided", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+						GUID:      "service-instance-guid",
+						SpaceGUID: "space-guid",
+						Type:      korifiv1alpha1.UserProvidedType,
+					}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Service plan, parameters and maintenance info can only be updated for managed service instances.")
+				})
+			})
+
+			When("the new plan does not exist", func() {
+				BeforeEach(func() {
+					servicePlanRepo.GetPlanStub = func(_ context.Context, _ authorization.Info, guid string) (repositories.ServicePlanRecord, error) {
+						if guid == "new-plan-guid" {
+							return repositories.ServicePlanRecord{}, apierrors.NewNotFoundError(nil, repositories.ServicePlanResourceType)
+						}
+						return repositories.ServicePlanRecord{GUID: guid}, nil
+					}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Invalid service plan. Ensure that the service plan exists, is available, and you have access to it.")
+				})
+			})
+
+			When("the new plan belongs to a different offering", func() {
+				BeforeEach(func() {
+					servicePlanRepo.GetPlanStub = func(_ context.Context, _ authorization.Info, guid string) (repositories.ServicePlanRecord, error) {
+						return repositories.ServicePlanRecord{
+							GUID:                guid,
+							ServiceOfferingGUID: guid + "-offering",
+						}, nil
+					}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("The service plan relates to a different service offering.")
+				})
+			})
+
+			When("the service offering does not support changing plans", func() {
+				BeforeEach(func() {
+					serviceOfferingRepo.GetServiceOfferingReturns(repositories.ServiceOfferingRecord{}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("The service does not support changing plans.")
+				})
+			})
+
+			When("the maintenance info version does not match the plan", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+						MaintenanceInfo: &payloads.ServiceInstanceMaintenanceInfo{
+							Version: "3.0.0",
+						},
+					})
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("The maintenance_info.version requested does not match the version of the service plan.")
+				})
+			})
+
+			When("the parameters do not match the plan schema", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+						Parameters: &map[string]any{"size": "huge"},
+					})
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusUnprocessableEntity))
+					Expect(rr).To(HaveHTTPBody(SatisfyAll(
+						MatchJSONPath("$.errors[0].title", "CF-UnprocessableEntity"),
+						MatchJSONPath("$.errors[0].detail", ContainSubstring("The parameters do not match the service plan schema")),
+					)))
+				})
+
+				It("does not patch the service instance", func() {
+					Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(BeZero())
+				})
+			})
+
+			When("getting the current plan fails", func() {
+				BeforeEach(func() {
+					servicePlanRepo.GetPlanReturns(repositories.ServicePlanRecord{}, errors.New("get-plan-err"))
+					servicePlanRepo.GetPlanStub = nil
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
 	})
 
 	Describe("DELETE /v3/service_instances/:guid", func() {
@@ -952,6 +1258,12 @@ var _ = Describe("ServiceInstance", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
 		})
 
+		It("records a user provided service instance delete audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualMessage.Type).To(Equal("audit.user_provided_service_instance.delete"))
+		})
+
 		When("the service instance is managed", func() {
 			BeforeEach(func() {
 				serviceInstanceRepo.DeleteServiceInstanceReturns(repositories.ServiceInstanceRecord{
@@ -973,6 +1285,15 @@ var _ = Describe("ServiceInstance", func() {
 					HaveHTTPHeaderWithValue("Location", ContainSubstring("/v3/jobs/managed_service_instance.delete~service-instance-guid")),
 				))
 			})
+
+			It("records a service instance start_delete audit event", func() {
+				Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+				_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+				Expect(actualMessage.Type).To(Equal("audit.service_instance.start_delete"))
+				Expect(actualMessage.Target.GUID).To(Equal("service-instance-guid"))
+				Expect(actualMessage.Target.Type).To(Equal("service_instance"))
+				Expect(actualMessage.SpaceGUID).To(Equal("space-guid"))
+			})
 		})
 
 		When("purging is set to true", func() {
@@ -1041,4 +1362,328 @@ var _ = Describe("ServiceInstance", func() {
 			})
 		})
 	})
+
+	Describe("GET /v3/service_instances/:guid/relationships/shared_spaces", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:             "service-instance-guid",
+				SpaceGUID:        "space-guid",
+				Type:             korifiv1alpha1.ManagedType,
+				SharedSpaceGUIDs: []string{"shared-space-guid"},
+			}, nil)
+
+			reqPath += "/service-instance-guid/relationships/shared_spaces"
+		})
+
+		It("returns the spaces the service instance is shared with", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceInstanceRepo.GetServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "shared-space-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"),
+			)))
+		})
+
+		When("the service instance is forbidden", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceInstanceResourceType)
+			})
+		})
+	})
+
+	Describe("POST /v3/service_instances/:guid/relationships/shared_spaces", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstanceShare{
+				Data: []payloads.RelationshipData{{GUID: "other-space-guid"}},
+			})
+
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:      "service-instance-guid",
+				Name:      "my-db",
+				SpaceGUID: "space-guid",
+				PlanGUID:  "plan-guid",
+				Type:      korifiv1alpha1.ManagedType,
+			}, nil)
+			servicePlanRepo.GetPlanReturns(repositories.ServicePlanRecord{
+				GUID:                "plan-guid",
+				ServiceOfferingGUID: "offering-guid",
+			}, nil)
+			serviceOfferingRepo.GetServiceOfferingReturns(repositories.ServiceOfferingRecord{
+				Name: "db-service",
+				BrokerCatalog: repositories.ServiceBrokerCatalog{
+					Metadata: map[string]any{"shareable": true},
+				},
+			}, nil)
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				GUID: "other-space-guid",
+				Name: "other-space",
+			}, nil)
+			serviceInstanceRepo.CanShareServiceInstanceWithReturns(true, nil)
+			serviceInstanceRepo.ShareServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:             "service-instance-guid",
+				SpaceGUID:        "space-guid",
+				Type:             korifiv1alpha1.ManagedType,
+				SharedSpaceGUIDs: []string{"other-space-guid"},
+			}, nil)
+
+			reqMethod = http.MethodPost
+			reqPath += "/service-instance-guid/relationships/shared_spaces"
+		})
+
+		It("shares the service instance", func() {
+			Expect(servicePlanRepo.GetPlanCallCount()).To(Equal(1))
+			_, _, actualPlanGUID := servicePlanRepo.GetPlanArgsForCall(0)
+			Expect(actualPlanGUID).To(Equal("plan-guid"))
+
+			Expect(serviceOfferingRepo.GetServiceOfferingCallCount()).To(Equal(1))
+			_, _, actualOfferingGUID := serviceOfferingRepo.GetServiceOfferingArgsForCall(0)
+			Expect(actualOfferingGUID).To(Equal("offering-guid"))
+
+			Expect(serviceInstanceRepo.CanShareServiceInstanceWithCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := serviceInstanceRepo.CanShareServiceInstanceWithArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("other-space-guid"))
+
+			Expect(serviceInstanceRepo.ListServiceInstancesCallCount()).To(Equal(1))
+			_, _, listMessage := serviceInstanceRepo.ListServiceInstancesArgsForCall(0)
+			Expect(listMessage.Names).To(ConsistOf("my-db"))
+			Expect(listMessage.SpaceGUIDs).To(ConsistOf("other-space-guid"))
+
+			Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceInstanceRepo.ShareServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ShareServiceInstanceMessage{
+				GUID:       "service-instance-guid",
+				SpaceGUIDs: []string{"other-space-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "other-space-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"),
+			)))
+		})
+
+		It("records a service instance share audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualMessage.Type).To(Equal("audit.service_instance.share"))
+			Expect(actualMessage.Target.GUID).To(Equal("service-instance-guid"))
+			Expect(actualMessage.Target.Type).To(Equal("service_instance"))
+			Expect(actualMessage.SpaceGUID).To(Equal("space-guid"))
+			Expect(actualMessage.Data).To(HaveKeyWithValue("target_space_guids", []string{"other-space-guid"}))
+		})
+
+		When("the service instance is user-provided", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID: "service-instance-guid",
+					Type: korifiv1alpha1.UserProvidedType,
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("User-provided services cannot be shared.")
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("the service offering is not shareable", func() {
+			BeforeEach(func() {
+				serviceOfferingRepo.GetServiceOfferingReturns(repositories.ServiceOfferingRecord{
+					Name: "db-service",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The db-service service does not support service instance sharing.")
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("sharing into the space of the service instance", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstanceShare{
+					Data: []payloads.RelationshipData{{GUID: "space-guid"}},
+				})
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to share service instance 'my-db' with space 'space-guid'. Service instances cannot be shared into the space where they were created.")
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("the target space is not accessible", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError(regexp.QuoteMeta("Unable to share service instance my-db with spaces ['other-space-guid']. Ensure the spaces exist and that you have access to them."))
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user cannot write to the target space", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.CanShareServiceInstanceWithReturns(false, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError(regexp.QuoteMeta("Unable to share service instance my-db with spaces ['other-space-guid']. Write permission is required in order to share a service instance with a space."))
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("checking the permissions in the target space fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.CanShareServiceInstanceWithReturns(false, errors.New("ssar-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("a service instance with the same name exists in the target space", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.ListServiceInstancesReturns(repositories.ListResult[repositories.ServiceInstanceRecord]{
+					Records: []repositories.ServiceInstanceRecord{{GUID: "other-instance-guid"}},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("A service instance called my-db already exists in other-space.")
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("getting the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceInstanceResourceType)
+			})
+		})
+
+		When("sharing the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.ShareServiceInstanceReturns(repositories.ServiceInstanceRecord{}, errors.New("share-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/service_instances/:guid/relationships/shared_spaces/:space_guid", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:             "service-instance-guid",
+				SpaceGUID:        "space-guid",
+				Type:             korifiv1alpha1.ManagedType,
+				SharedSpaceGUIDs: []string{"other-space-guid"},
+			}, nil)
+
+			reqMethod = http.MethodDelete
+			reqPath += "/service-instance-guid/relationships/shared_spaces/other-space-guid"
+		})
+
+		It("unshares the service instance", func() {
+			Expect(serviceInstanceRepo.UnshareServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceInstanceRepo.UnshareServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UnshareServiceInstanceMessage{
+				GUID:      "service-instance-guid",
+				SpaceGUID: "other-space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		It("records a service instance unshare audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualMessage.Type).To(Equal("audit.service_instance.unshare"))
+			Expect(actualMessage.Target.GUID).To(Equal("service-instance-guid"))
+			Expect(actualMessage.Data).To(HaveKeyWithValue("target_space_guid", "other-space-guid"))
+		})
+
+		When("the service instance is not shared with the space", func() {
+			BeforeEach(func() {
+				reqPath = "/v3/service_instances/service-instance-guid/relationships/shared_spaces/another-space-guid"
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Unable to unshare service instance from space another-space-guid. Ensure the space exists and the service instance has been shared to this space.")
+				Expect(serviceInstanceRepo.UnshareServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("getting the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceInstanceResourceType)
+			})
+		})
+
+		When("unsharing the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.UnshareServiceInstanceReturns(errors.New("unshare-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_instances/:guid/relationships/shared_spaces/usage_summary", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetSharedSpacesUsageSummaryReturns([]repositories.SharedSpaceUsageRecord{
+				{SpaceGUID: "other-space-guid", BoundAppCount: 2},
+			}, nil)
+
+			reqPath += "/service-instance-guid/relationships/shared_spaces/usage_summary"
+		})
+
+		It("returns the usage summary", func() {
+			Expect(serviceInstanceRepo.GetSharedSpacesUsageSummaryCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceInstanceRepo.GetSharedSpacesUsageSummaryArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.usage_summary[0].space.guid", "other-space-guid"),
+				MatchJSONPath("$.usage_summary[0].bound_app_count", BeEquivalentTo(2)),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces/usage_summary"),
+			)))
+		})
+
+		When("getting the usage summary fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetSharedSpacesUsageSummaryReturns(nil, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceInstanceResourceType)
+			})
+		})
+	})
 })
@@ -1,42 +1,209 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"net/url"
+	"slices"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
-	"code.cloudfoundry.org/korifi/api/repositories/include"
-	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/go-logr/logr"
 )
 
 const (
 	ServiceRouteBindingsPath = "/v3/service_route_bindings"
+	ServiceRouteBindingPath  = "/v3/service_route_bindings/{guid}"
 )
 
+//counterfeiter:generate -o fake -fake-name CFServiceRouteBindingRepository . CFServiceRouteBindingRepository
+type CFServiceRouteBindingRepository interface {
+	CreateServiceRouteBinding(context.Context, authorization.Info, repositories.CreateServiceRouteBindingMessage) (repositories.ServiceRouteBindingRecord, error)
+	GetServiceRouteBinding(context.Context, authorization.Info, string) (repositories.ServiceRouteBindingRecord, error)
+	ListServiceRouteBindings(context.Context, authorization.Info, repositories.ListServiceRouteBindingsMessage) (repositories.ListResult[repositories.ServiceRouteBindingRecord], error)
+	DeleteServiceRouteBinding(context.Context, authorization.Info, string) error
+}
+
 type ServiceRouteBinding struct {
-	serverURL url.URL
+	serverURL               url.URL
+	serviceRouteBindingRepo CFServiceRouteBindingRepository
+	serviceInstanceRepo     CFServiceInstanceRepository
+	routeRepo               CFRouteRepository
+	servicePlanRepo         CFServicePlanRepository
+	serviceOfferingRepo     CFServiceOfferingRepository
+	requestValidator        RequestValidator
 }
 
 func NewServiceRouteBinding(
 	serverURL url.URL,
+	serviceRouteBindingRepo CFServiceRouteBindingRepository,
+	serviceInstanceRepo CFServiceInstanceRepository,
+	routeRepo CFRouteRepository,
+	servicePlanRepo CFServicePlanRepository,
+	serviceOfferingRepo CFServiceOfferingRepository,
+	requestValidator RequestValidator,
 ) *ServiceRouteBinding {
 	return &ServiceRouteBinding{
-		serverURL: serverURL,
+		serverURL:               serverURL,
+		serviceRouteBindingRepo: serviceRouteBindingRepo,
+		serviceInstanceRepo:     serviceInstanceRepo,
+		routeRepo:               routeRepo,
+		servicePlanRepo:         servicePlanRepo,
+		serviceOfferingRepo:     serviceOfferingRepo,
+		requestValidator:        requestValidator,
+	}
+}
+
+func (h *ServiceRouteBinding) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.create")
+
+	var payload payloads.ServiceRouteBindingCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, payload.Relationships.ServiceInstance.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(err, "The service instance could not be found: "+payload.Relationships.ServiceInstance.Data.GUID, apierrors.ForbiddenError{}, apierrors.NotFoundError{}),
+			"failed to get "+repositories.ServiceInstanceResourceType,
+		)
+	}
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, payload.Relationships.Route.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(err, "The route could not be found: "+payload.Relationships.Route.Data.GUID, apierrors.ForbiddenError{}, apierrors.NotFoundError{}),
+			"failed to get "+repositories.RouteResourceType,
+		)
+	}
+
+	if route.SpaceGUID != serviceInstance.SpaceGUID {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "The service instance and the route are in different spaces."),
+			"invalid service route binding",
+		)
+	}
+
+	supported, err := h.supportsRouteBinding(r.Context(), authInfo, serviceInstance)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to check whether the service instance supports route bindings")
+	}
+	if !supported {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "This service instance does not support route binding."),
+			"invalid service route binding",
+		)
+	}
+
+	routeBinding, err := h.serviceRouteBindingRepo.CreateServiceRouteBinding(r.Context(), authInfo, payload.ToMessage(serviceInstance.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create "+repositories.ServiceRouteBindingResourceType)
+	}
+
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
+		return routing.NewResponse(http.StatusAccepted).
+			WithHeader("Location", presenter.JobURLForRedirects(routeBinding.GUID, presenter.ManagedServiceRouteBindingCreateOperation, h.serverURL)), nil
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForServiceRouteBinding(routeBinding, h.serverURL)), nil
+}
+
+// supportsRouteBinding returns true if the service instance can be bound to
+// routes. User-provided service instances need a route service url, managed
+// service instances need an offering that requires route forwarding
+func (h *ServiceRouteBinding) supportsRouteBinding(ctx context.Context, authInfo authorization.Info, serviceInstance repositories.ServiceInstanceRecord) (bool, error) {
+	if serviceInstance.Type == korifiv1alpha1.UserProvidedType {
+		return serviceInstance.RouteServiceURL != "", nil
+	}
+
+	plan, err := h.servicePlanRepo.GetPlan(ctx, authInfo, serviceInstance.PlanGUID)
+	if err != nil {
+		return false, err
 	}
+
+	offering, err := h.serviceOfferingRepo.GetServiceOffering(ctx, authInfo, plan.ServiceOfferingGUID)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(offering.Requires, korifiv1alpha1.RouteForwardingRequirement), nil
+}
+
+func (h *ServiceRouteBinding) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.get")
+
+	routeBindingGUID := routing.URLParam(r, "guid")
+
+	routeBinding, err := h.serviceRouteBindingRepo.GetServiceRouteBinding(r.Context(), authInfo, routeBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceRouteBindingResourceType)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBinding(routeBinding, h.serverURL)), nil
 }
 
 func (h *ServiceRouteBinding) list(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(
-		func(a any, _ url.URL, includes ...include.Resource) any { return a },
-		repositories.ListResult[any]{
-			PageInfo: descriptors.SinglePageInfo(0, 0),
-			Records:  []any{},
-		},
-		h.serverURL,
-		*r.URL,
-	)), nil
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.list")
+
+	listFilter := new(payloads.ServiceRouteBindingList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	routeBindings, err := h.serviceRouteBindingRepo.ListServiceRouteBindings(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list "+repositories.ServiceRouteBindingResourceType)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForServiceRouteBinding, routeBindings, h.serverURL, *r.URL)), nil
+}
+
+func (h *ServiceRouteBinding) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.delete")
+
+	routeBindingGUID := routing.URLParam(r, "guid")
+
+	routeBinding, err := h.serviceRouteBindingRepo.GetServiceRouteBinding(r.Context(), authInfo, routeBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceRouteBindingResourceType)
+	}
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, routeBinding.ServiceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(err, "failed to get service instance"),
+			"failed to get "+repositories.ServiceInstanceResourceType,
+			"instance-guid", routeBinding.ServiceInstanceGUID,
+		)
+	}
+
+	err = h.serviceRouteBindingRepo.DeleteServiceRouteBinding(r.Context(), authInfo, routeBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "error when deleting service route binding", "guid", routeBindingGUID)
+	}
+
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
+		return routing.NewResponse(http.StatusAccepted).
+			WithHeader("Location", presenter.JobURLForRedirects(routeBinding.GUID, presenter.ManagedServiceRouteBindingDeleteOperation, h.serverURL)), nil
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
 }
 
 func (h *ServiceRouteBinding) UnauthenticatedRoutes() []routing.Route {
@@ -45,6 +212,9 @@ func (h *ServiceRouteBinding) UnauthenticatedRoutes() []routing.Route {
 
 func (h *ServiceRouteBinding) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
+		{Method: "POST", Pattern: ServiceRouteBindingsPath, Handler: h.create},
 		{Method: "GET", Pattern: ServiceRouteBindingsPath, Handler: h.list},
+		{Method: "GET", Pattern: ServiceRouteBindingPath, Handler: h.get},
+		{Method: "DELETE", Pattern: ServiceRouteBindingPath, Handler: h.delete},
 	}
 }
@@ -1,9 +1,17 @@
 package handlers_test
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
-	"code.cloudfoundry.org/korifi/api/handlers"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -11,24 +19,439 @@ import (
 )
 
 var _ = Describe("ServiceRouteBinding", func() {
-	Describe("the GET /v3/service_route_binding endpoint", func() {
+	var (
+		requestMethod string
+		requestPath   string
+
+		serviceRouteBindingRepo *fake.CFServiceRouteBindingRepository
+		serviceInstanceRepo     *fake.CFServiceInstanceRepository
+		routeRepo               *fake.CFRouteRepository
+		servicePlanRepo         *fake.CFServicePlanRepository
+		serviceOfferingRepo     *fake.CFServiceOfferingRepository
+		requestValidator        *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		serviceRouteBindingRepo = new(fake.CFServiceRouteBindingRepository)
+		serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{
+			GUID:                "service-route-binding-guid",
+			ServiceInstanceGUID: "service-instance-guid",
+			RouteGUID:           "route-guid",
+		}, nil)
+
+		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
+		serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+			GUID:            "service-instance-guid",
+			SpaceGUID:       "space-guid",
+			Type:            korifiv1alpha1.UserProvidedType,
+			RouteServiceURL: "https://route-service.example.com",
+		}, nil)
+
+		routeRepo = new(fake.CFRouteRepository)
+		routeRepo.GetRouteReturns(repositories.RouteRecord{
+			GUID:      "route-guid",
+			SpaceGUID: "space-guid",
+		}, nil)
+
+		servicePlanRepo = new(fake.CFServicePlanRepository)
+		servicePlanRepo.GetPlanReturns(repositories.ServicePlanRecord{
+			GUID:                "plan-guid",
+			ServiceOfferingGUID: "offering-guid",
+		}, nil)
+
+		serviceOfferingRepo = new(fake.CFServiceOfferingRepository)
+		serviceOfferingRepo.GetServiceOfferingReturns(repositories.ServiceOfferingRecord{
+			GUID:     "offering-guid",
+			Requires: []string{"route_forwarding"},
+		}, nil)
+
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewServiceRouteBinding(
+			*serverURL,
+			serviceRouteBindingRepo,
+			serviceInstanceRepo,
+			routeRepo,
+			servicePlanRepo,
+			serviceOfferingRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader("the-body"))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/service_route_bindings", func() {
+		var payload payloads.ServiceRouteBindingCreate
+
 		BeforeEach(func() {
-			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/service_route_bindings", nil)
-			Expect(err).NotTo(HaveOccurred())
+			requestMethod = http.MethodPost
+			requestPath = "/v3/service_route_bindings"
 
-			apiHandler := handlers.NewServiceRouteBinding(
-				*serverURL)
+			payload = payloads.ServiceRouteBindingCreate{
+				Relationships: &payloads.ServiceRouteBindingRelationships{
+					ServiceInstance: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "service-instance-guid"},
+					},
+					Route: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "route-guid"},
+					},
+				},
+				Parameters: map[string]any{"foo": "bar"},
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payload)
 
-			routerBuilder.LoadRoutes(apiHandler)
-			routerBuilder.Build().ServeHTTP(rr, req)
+			serviceRouteBindingRepo.CreateServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{
+				GUID:                "service-route-binding-guid",
+				ServiceInstanceGUID: "service-instance-guid",
+				RouteGUID:           "route-guid",
+				RouteServiceURL:     "https://route-service.example.com",
+			}, nil)
 		})
 
-		It("returns an empty list", func() {
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-body"))
+		})
+
+		It("creates the service route binding", func() {
+			Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualCreate := serviceRouteBindingRepo.CreateServiceRouteBindingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualCreate).To(Equal(repositories.CreateServiceRouteBindingMessage{
+				ServiceInstanceGUID: "service-instance-guid",
+				RouteGUID:           "route-guid",
+				SpaceGUID:           "space-guid",
+				Parameters:          map[string]any{"foo": "bar"},
+			}))
+		})
+
+		It("returns the created service route binding", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "service-route-binding-guid"),
+				MatchJSONPath("$.route_service_url", "https://route-service.example.com"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_route_bindings/service-route-binding-guid"),
+			)))
+		})
+
+		When("the user-provided service instance has no route service url", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					SpaceGUID: "space-guid",
+					Type:      korifiv1alpha1.UserProvidedType,
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("This service instance does not support route binding.")
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					SpaceGUID: "space-guid",
+					Type:      korifiv1alpha1.ManagedType,
+					PlanGUID:  "plan-guid",
+				}, nil)
+			})
+
+			It("looks up the offering of the instance plan", func() {
+				Expect(servicePlanRepo.GetPlanCallCount()).To(Equal(1))
+				_, _, actualPlanGUID := servicePlanRepo.GetPlanArgsForCall(0)
+				Expect(actualPlanGUID).To(Equal("plan-guid"))
+
+				Expect(serviceOfferingRepo.GetServiceOfferingCallCount()).To(Equal(1))
+				_, _, actualOfferingGUID := serviceOfferingRepo.GetServiceOfferingArgsForCall(0)
+				Expect(actualOfferingGUID).To(Equal("offering-guid"))
+			})
+
+			It("returns a job location header", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location",
+					ContainSubstring("/v3/jobs/managed_service_route_binding.create~service-route-binding-guid")))
+			})
+
+			When("the service offering does not require route forwarding", func() {
+				BeforeEach(func() {
+					serviceOfferingRepo.GetServiceOfferingReturns(repositories.ServiceOfferingRecord{
+						GUID: "offering-guid",
+					}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("This service instance does not support route binding.")
+					Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+				})
+			})
+
+			When("getting the plan fails", func() {
+				BeforeEach(func() {
+					servicePlanRepo.GetPlanReturns(repositories.ServicePlanRecord{}, errors.New("get-plan-error"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+
+			When("getting the offering fails", func() {
+				BeforeEach(func() {
+					serviceOfferingRepo.GetServiceOfferingReturns(repositories.ServiceOfferingRecord{}, errors.New("get-offering-error"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
+		When("the service instance and the route are in different spaces", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{
+					GUID:      "route-guid",
+					SpaceGUID: "another-space-guid",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The service instance and the route are in different spaces.")
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the service instance is not accessible", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The service instance could not be found: service-instance-guid")
+			})
+		})
+
+		When("getting the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the route is not accessible", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The route could not be found: route-guid")
+			})
+		})
+
+		When("creating the service route binding fails", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.CreateServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_route_bindings/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_route_bindings/service-route-binding-guid"
+		})
+
+		It("returns the service route binding", func() {
+			Expect(serviceRouteBindingRepo.GetServiceRouteBindingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceRouteBindingRepo.GetServiceRouteBindingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-route-binding-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "service-route-binding-guid"),
+				MatchJSONPath("$.relationships.route.data.guid", "route-guid"),
+			)))
+		})
+
+		When("the service route binding is not accessible", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceRouteBindingResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceRouteBindingResourceType)
+			})
+		})
+
+		When("getting the service route binding fails", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_route_bindings", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_route_bindings?foo=bar"
+
+			serviceRouteBindingRepo.ListServiceRouteBindingsReturns(repositories.ListResult[repositories.ServiceRouteBindingRecord]{
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 1,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     1,
+				},
+				Records: []repositories.ServiceRouteBindingRecord{
+					{GUID: "service-route-binding-guid", RouteGUID: "route-guid"},
+				},
+			}, nil)
+
+			payload := payloads.ServiceRouteBindingList{
+				ServiceInstanceGUIDs: "s1,s2",
+				RouteGUIDs:           "r1,r2",
+				LabelSelector:        "label=value",
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payload)
+		})
+
+		It("returns the list of service route bindings", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+			Expect(actualReq.URL.String()).To(HaveSuffix(requestPath))
+
+			Expect(serviceRouteBindingRepo.ListServiceRouteBindingsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := serviceRouteBindingRepo.ListServiceRouteBindingsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.ListServiceRouteBindingsMessage{
+				ServiceInstanceGUIDs: []string{"s1", "s2"},
+				RouteGUIDs:           []string{"r1", "r2"},
+				LabelSelector:        "label=value",
+				Pagination:           repositories.Pagination{PerPage: 50, Page: 1},
+			}))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.total_results", BeZero()),
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "service-route-binding-guid"),
 			)))
 		})
+
+		When("decoding the query parameters fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the service route bindings fails", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.ListServiceRouteBindingsReturns(repositories.ListResult[repositories.ServiceRouteBindingRecord]{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/service_route_bindings/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/service_route_bindings/service-route-binding-guid"
+		})
+
+		It("deletes the service route binding", func() {
+			Expect(serviceRouteBindingRepo.DeleteServiceRouteBindingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceRouteBindingRepo.DeleteServiceRouteBindingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-route-binding-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID: "service-instance-guid",
+					Type: korifiv1alpha1.ManagedType,
+				}, nil)
+			})
+
+			It("returns a job location header", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location",
+					ContainSubstring("/v3/jobs/managed_service_route_binding.delete~service-route-binding-guid")))
+			})
+		})
+
+		When("the service route binding is not accessible", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceRouteBindingResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceRouteBindingResourceType)
+				Expect(serviceRouteBindingRepo.DeleteServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("getting the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("failed to get service instance")
+				Expect(serviceRouteBindingRepo.DeleteServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("deleting the service route binding fails", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.DeleteServiceRouteBindingReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 })
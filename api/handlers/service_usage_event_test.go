@@ -0,0 +1,209 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceUsageEvent", func() {
+	var (
+		requestValidator      *fake.RequestValidator
+		serviceUsageEventRepo *fake.CFServiceUsageEventRepository
+		req                   *http.Request
+		reqMethod             string
+		reqPath               string
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		serviceUsageEventRepo = new(fake.CFServiceUsageEventRepository)
+
+		apiHandler := NewServiceUsageEvent(
+			*serverURL,
+			serviceUsageEventRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		reqMethod = http.MethodGet
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		req, err = http.NewRequestWithContext(ctx, reqMethod, reqPath, strings.NewReader(""))
+		Expect(err).NotTo(HaveOccurred())
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/service_usage_events/{guid}", func() {
+		BeforeEach(func() {
+			reqPath = "/v3/service_usage_events/service-usage-event-guid"
+
+			serviceUsageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{
+				GUID:                "service-usage-event-guid",
+				State:               "CREATED",
+				ServiceInstanceGUID: "service-instance-guid",
+				ServiceInstanceName: "my-service-instance",
+				ServiceInstanceType: "managed_service_instance",
+				SpaceGUID:           "space-guid",
+				SpaceName:           "my-space",
+				OrganizationGUID:    "org-guid",
+				ServicePlan:         &repositories.ServiceUsageEventResource{GUID: "plan-guid", Name: "my-plan"},
+				CreatedAt:           time.UnixMilli(1000),
+			}, nil)
+		})
+
+		It("returns the service usage event", func() {
+			Expect(serviceUsageEventRepo.GetServiceUsageEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceUsageEventRepo.GetServiceUsageEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-usage-event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "service-usage-event-guid"),
+				MatchJSONPath("$.state", "CREATED"),
+				MatchJSONPath("$.service_instance.guid", "service-instance-guid"),
+				MatchJSONPath("$.service_instance.type", "managed_service_instance"),
+				MatchJSONPath("$.service_plan.name", "my-plan"),
+				MatchJSONPath("$.service_offering", BeNil()),
+				MatchJSONPath("$.space.name", "my-space"),
+				MatchJSONPath("$.organization.guid", "org-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_usage_events/service-usage-event-guid"),
+			)))
+		})
+
+		When("the user is not allowed to get the service usage event", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceUsageEventResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("getting the service usage event fails", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{}, errors.New("get-service-usage-event-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_usage_events", func() {
+		BeforeEach(func() {
+			reqPath = "/v3/service_usage_events"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ServiceUsageEventList{
+				ServiceInstanceTypes: "managed_service_instance",
+				ServiceOfferingGUIDs: "offering-guid",
+				OrderBy:              "-created_at",
+			})
+
+			serviceUsageEventRepo.ListServiceUsageEventsReturns(repositories.ListResult[repositories.ServiceUsageEventRecord]{
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 2,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     2,
+				},
+				Records: []repositories.ServiceUsageEventRecord{
+					{GUID: "service-usage-event-1", State: "CREATED"},
+					{GUID: "service-usage-event-2", State: "DELETED"},
+				},
+			}, nil)
+		})
+
+		It("lists the service usage events", func() {
+			Expect(serviceUsageEventRepo.ListServiceUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := serviceUsageEventRepo.ListServiceUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.ListServiceUsageEventsMessage{
+				ServiceInstanceTypes: []string{"managed_service_instance"},
+				ServiceOfferingGUIDs: []string{"offering-guid"},
+				OrderBy:              "-created_at",
+				Pagination:           repositories.Pagination{PerPage: 50, Page: 1},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "service-usage-event-1"),
+				MatchJSONPath("$.resources[1].guid", "service-usage-event-2"),
+			)))
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the service usage events fails", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.ListServiceUsageEventsReturns(repositories.ListResult[repositories.ServiceUsageEventRecord]{}, errors.New("list-service-usage-events-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/service_usage_events/actions/destructively_purge_all_and_reseed", func() {
+		BeforeEach(func() {
+			reqMethod = http.MethodPost
+			reqPath = "/v3/service_usage_events/actions/destructively_purge_all_and_reseed"
+		})
+
+		It("purges and reseeds the service usage events", func() {
+			Expect(serviceUsageEventRepo.PurgeAndReseedServiceUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo := serviceUsageEventRepo.PurgeAndReseedServiceUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		When("the user is not allowed to purge the service usage events", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.PurgeAndReseedServiceUsageEventsReturns(apierrors.NewForbiddenError(nil, repositories.ServiceUsageEventResourceType))
+			})
+
+			It("returns a not authorized error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+
+		When("purging the service usage events fails", func() {
+			BeforeEach(func() {
+				serviceUsageEventRepo.PurgeAndReseedServiceUsageEventsReturns(errors.New("purge-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppSidecarsPath = "/v3/apps/{guid}/sidecars"
+	SidecarPath     = "/v3/sidecars/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFSidecarRepository . CFSidecarRepository
+type CFSidecarRepository interface {
+	CreateSidecar(context.Context, authorization.Info, repositories.CreateSidecarMessage) (repositories.SidecarRecord, error)
+	GetSidecar(context.Context, authorization.Info, string) (repositories.SidecarRecord, error)
+	ListSidecars(context.Context, authorization.Info, repositories.ListSidecarsMessage) (repositories.ListResult[repositories.SidecarRecord], error)
+	ListProcessSidecars(ctx context.Context, authInfo authorization.Info, appGUID, processType string) (repositories.ListResult[repositories.SidecarRecord], error)
+	PatchSidecar(context.Context, authorization.Info, repositories.PatchSidecarMessage) (repositories.SidecarRecord, error)
+	DeleteSidecar(context.Context, authorization.Info, string) error
+}
+
+type Sidecar struct {
+	serverURL        url.URL
+	sidecarRepo      CFSidecarRepository
+	appRepo          CFAppRepository
+	requestValidator RequestValidator
+}
+
+func NewSidecar(
+	serverURL url.URL,
+	sidecarRepo CFSidecarRepository,
+	appRepo CFAppRepository,
+	requestValidator RequestValidator,
+) *Sidecar {
+	return &Sidecar{
+		serverURL:        serverURL,
+		sidecarRepo:      sidecarRepo,
+		appRepo:          appRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *Sidecar) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.create")
+	appGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SidecarCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	sidecar, err := h.sidecarRepo.CreateSidecar(r.Context(), authInfo, payload.ToMessage(appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to create sidecar", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSidecar(sidecar, h.serverURL)), nil
+}
+
+func (h *Sidecar) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.get")
+	sidecarGUID := routing.URLParam(r, "guid")
+
+	sidecar, err := h.sidecarRepo.GetSidecar(r.Context(), authInfo, sidecarGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch sidecar from Kubernetes", "SidecarGUID", sidecarGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSidecar(sidecar, h.serverURL)), nil
+}
+
+func (h *Sidecar) listForApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.list-for-app")
+	appGUID := routing.URLParam(r, "guid")
+
+	_, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch app from Kubernetes", "AppGUID", appGUID)
+	}
+
+	payload := new(payloads.AppSidecarsList)
+	if err = h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	sidecars, err := h.sidecarRepo.ListSidecars(r.Context(), authInfo, payload.ToMessage(appGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list app sidecars", "AppGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSidecar, sidecars, h.serverURL, *r.URL)), nil
+}
+
+func (h *Sidecar) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.update")
+	sidecarGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SidecarUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.sidecarRepo.GetSidecar(r.Context(), authInfo, sidecarGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch sidecar from Kubernetes", "SidecarGUID", sidecarGUID)
+	}
+
+	sidecar, err := h.sidecarRepo.PatchSidecar(r.Context(), authInfo, payload.ToMessage(sidecarGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch sidecar", "SidecarGUID", sidecarGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSidecar(sidecar, h.serverURL)), nil
+}
+
+func (h *Sidecar) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.delete")
+	sidecarGUID := routing.URLParam(r, "guid")
+
+	_, err := h.sidecarRepo.GetSidecar(r.Context(), authInfo, sidecarGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch sidecar from Kubernetes", "SidecarGUID", sidecarGUID)
+	}
+
+	err = h.sidecarRepo.DeleteSidecar(r.Context(), authInfo, sidecarGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete sidecar", "SidecarGUID", sidecarGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *Sidecar) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *Sidecar) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: AppSidecarsPath, Handler: h.create},
+		{Method: "GET", Pattern: AppSidecarsPath, Handler: h.listForApp},
+		{Method: "GET", Pattern: SidecarPath, Handler: h.get},
+		{Method: "PATCH", Pattern: SidecarPath, Handler: h.update},
+		{Method: "DELETE", Pattern: SidecarPath, Handler: h.delete},
+	}
+}
@@ -0,0 +1,379 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sidecar", func() {
+	var (
+		requestMethod string
+		requestPath   string
+
+		sidecarRepo      *fake.CFSidecarRepository
+		appRepo          *fake.CFAppRepository
+		requestValidator *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		sidecarRepo = new(fake.CFSidecarRepository)
+		sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{
+			GUID:         "sidecar-guid",
+			Name:         "my-sidecar",
+			Command:      "bin/my-sidecar",
+			ProcessTypes: []string{"web"},
+			AppGUID:      "app-guid",
+		}, nil)
+
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      "app-guid",
+			SpaceGUID: "space-guid",
+		}, nil)
+
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewSidecar(
+			*serverURL,
+			sidecarRepo,
+			appRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader("the-body"))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/apps/{guid}/sidecars", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/apps/app-guid/sidecars"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SidecarCreate{
+				Name:         "my-sidecar",
+				Command:      "bin/my-sidecar",
+				ProcessTypes: []string{"web", "worker"},
+				MemoryInMB:   tools.PtrTo[int64](64),
+			})
+
+			sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{
+				GUID:         "sidecar-guid",
+				Name:         "my-sidecar",
+				Command:      "bin/my-sidecar",
+				ProcessTypes: []string{"web", "worker"},
+				MemoryMB:     64,
+				AppGUID:      "app-guid",
+			}, nil)
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-body"))
+		})
+
+		It("creates the sidecar", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := sidecarRepo.CreateSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Name:         "my-sidecar",
+				Command:      "bin/my-sidecar",
+				ProcessTypes: []string{"web", "worker"},
+				MemoryMB:     64,
+			}))
+		})
+
+		It("returns the created sidecar", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sidecar-guid"),
+				MatchJSONPath("$.name", "my-sidecar"),
+				MatchJSONPath("$.command", "bin/my-sidecar"),
+				MatchJSONPath("$.process_types", ConsistOf("web", "worker")),
+				MatchJSONPath("$.memory_in_mb", BeEquivalentTo(64)),
+				MatchJSONPath("$.relationships.app.data.guid", "app-guid"),
+			)))
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/sidecars", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/apps/app-guid/sidecars"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppSidecarsList{
+				OrderBy:    "name",
+				Pagination: payloads.Pagination{PerPage: "16", Page: "2"},
+			})
+
+			sidecarRepo.ListSidecarsReturns(repositories.ListResult[repositories.SidecarRecord]{
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 2,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     2,
+				},
+				Records: []repositories.SidecarRecord{
+					{GUID: "sidecar-1"},
+					{GUID: "sidecar-2"},
+				},
+			}, nil)
+		})
+
+		It("lists the app sidecars", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.ListSidecarsMessage{
+				AppGUIDs:   []string{"app-guid"},
+				OrderBy:    "name",
+				Pagination: repositories.Pagination{PerPage: 16, Page: 2},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "sidecar-1"),
+				MatchJSONPath("$.resources[1].guid", "sidecar-2"),
+			)))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+				Expect(sidecarRepo.ListSidecarsCallCount()).To(BeZero())
+			})
+		})
+
+		When("the query parameters are invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the sidecars fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListSidecarsReturns(repositories.ListResult[repositories.SidecarRecord]{}, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/sidecars/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/sidecars/sidecar-guid"
+		})
+
+		It("returns the sidecar", func() {
+			Expect(sidecarRepo.GetSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := sidecarRepo.GetSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sidecar-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sidecar-guid"),
+				MatchJSONPath("$.name", "my-sidecar"),
+				MatchJSONPath("$.command", "bin/my-sidecar"),
+			)))
+		})
+
+		When("the sidecar is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+			})
+		})
+
+		When("getting the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, errors.New("get-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/sidecars/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/sidecars/sidecar-guid"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SidecarUpdate{
+				Command: tools.PtrTo("bin/new-command"),
+			})
+
+			sidecarRepo.PatchSidecarReturns(repositories.SidecarRecord{
+				GUID:    "sidecar-guid",
+				Name:    "my-sidecar",
+				Command: "bin/new-command",
+			}, nil)
+		})
+
+		It("updates the sidecar", func() {
+			Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := sidecarRepo.PatchSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.PatchSidecarMessage{
+				GUID:    "sidecar-guid",
+				Command: tools.PtrTo("bin/new-command"),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sidecar-guid"),
+				MatchJSONPath("$.command", "bin/new-command"),
+			)))
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(sidecarRepo.PatchSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("the sidecar is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+				Expect(sidecarRepo.PatchSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("patching the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.PatchSidecarReturns(repositories.SidecarRecord{}, errors.New("patch-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/sidecars/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/sidecars/sidecar-guid"
+		})
+
+		It("deletes the sidecar", func() {
+			Expect(sidecarRepo.DeleteSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := sidecarRepo.DeleteSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sidecar-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the sidecar is not accessible", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+				Expect(sidecarRepo.DeleteSidecarCallCount()).To(BeZero())
+			})
+		})
+
+		When("deleting the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.DeleteSidecarReturns(errors.New("delete-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -14,6 +14,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/include"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
@@ -40,25 +41,31 @@ type CFSpaceRepository interface {
 }
 
 type Space struct {
-	spaceRepo        CFSpaceRepository
-	routeRepo        CFRouteRepository
-	orgRepo          CFOrgRepository
-	apiBaseURL       url.URL
-	requestValidator RequestValidator
-	includeResolver  *include.IncludeResolver[
+	spaceRepo            CFSpaceRepository
+	routeRepo            CFRouteRepository
+	orgRepo              CFOrgRepository
+	securityGroupRepo    CFSecurityGroupRepository
+	isolationSegmentRepo CFIsolationSegmentRepository
+	apiBaseURL           url.URL
+	requestValidator     RequestValidator
+	auditEventRecorder   AuditEventRecorder
+	includeResolver      *include.IncludeResolver[
 		[]repositories.SpaceRecord,
 		repositories.SpaceRecord,
 	]
 }
 
-func NewSpace(apiBaseURL url.URL, spaceRepo CFSpaceRepository, orgRepo CFOrgRepository, routeRepo CFRouteRepository, requestValidator RequestValidator, relationshipRepo include.ResourceRelationshipRepository) *Space {
+func NewSpace(apiBaseURL url.URL, spaceRepo CFSpaceRepository, orgRepo CFOrgRepository, routeRepo CFRouteRepository, securityGroupRepo CFSecurityGroupRepository, isolationSegmentRepo CFIsolationSegmentRepository, requestValidator RequestValidator, relationshipRepo include.ResourceRelationshipRepository, auditEventRecorder AuditEventRecorder) *Space {
 	return &Space{
-		apiBaseURL:       apiBaseURL,
-		spaceRepo:        spaceRepo,
-		orgRepo:          orgRepo,
-		routeRepo:        routeRepo,
-		requestValidator: requestValidator,
-		includeResolver:  include.NewIncludeResolver[[]repositories.SpaceRecord](relationshipRepo, presenter.NewResource(apiBaseURL)),
+		apiBaseURL:           apiBaseURL,
+		spaceRepo:            spaceRepo,
+		orgRepo:              orgRepo,
+		routeRepo:            routeRepo,
+		securityGroupRepo:    securityGroupRepo,
+		isolationSegmentRepo: isolationSegmentRepo,
+		requestValidator:     requestValidator,
+		auditEventRecorder:   auditEventRecorder,
+		includeResolver:      include.NewIncludeResolver[[]repositories.SpaceRecord](relationshipRepo, presenter.NewResource(apiBaseURL)),
 	}
 }
 
@@ -81,6 +88,12 @@ func (h *Space) create(r *http.Request) (*routing.Response, error) {
 			"Space Name", space.Name,
 		)
 	}
+	recordAuditEvent(r.Context(), h.auditEventRecorder, repositories.CreateAuditEventMessage{
+		Type:             repositories.AuditEventTypeSpaceCreate,
+		Target:           spaceAuditEventTarget(record),
+		SpaceGUID:        record.GUID,
+		OrganizationGUID: record.OrganizationGUID,
+	})
 
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSpace(record, h.apiBaseURL)), nil
 }
@@ -151,10 +164,25 @@ func (h *Space) delete(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to delete space", "SpaceGUID", spaceGUID)
 	}
+	// the event is scoped to the org so that it is still visible once the
+	// space is gone
+	recordAuditEvent(r.Context(), h.auditEventRecorder, repositories.CreateAuditEventMessage{
+		Type:             repositories.AuditEventTypeSpaceDeleteRequest,
+		Target:           spaceAuditEventTarget(spaceRecord),
+		OrganizationGUID: spaceRecord.OrganizationGUID,
+	})
 
 	return routing.NewResponse(http.StatusAccepted).WithHeader("Location", presenter.JobURLForRedirects(spaceGUID, presenter.SpaceDeleteOperation, h.apiBaseURL)), nil
 }
 
+func spaceAuditEventTarget(space repositories.SpaceRecord) repositories.AuditEventTarget {
+	return repositories.AuditEventTarget{
+		GUID: space.GUID,
+		Type: repositories.AuditEventTargetTypeSpace,
+		Name: space.Name,
+	}
+}
+
 func (h *Space) get(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.get")
@@ -203,18 +231,88 @@ func (h *Space) deleteUnmappedRoutes(r *http.Request) (*routing.Response, error)
 }
 
 func (h *Space) getIsolationSegment(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(struct{}{}), nil
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.get-isolation-segment")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceIsolationSegment(space, h.apiBaseURL)), nil
+}
+
+func (h *Space) patchIsolationSegment(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.patch-isolation-segment")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentAssign
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "spaceGUID", spaceGUID)
+	}
+
+	isolationSegmentGUID := payload.GUID()
+	if isolationSegmentGUID != "" {
+		err = ensureIsolationSegmentEntitled(r.Context(), authInfo, h.isolationSegmentRepo, isolationSegmentGUID, space.OrganizationGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Isolation segment not entitled to space org", "spaceGUID", spaceGUID, "isolationSegmentGUID", isolationSegmentGUID)
+		}
+	}
+
+	space, err = h.spaceRepo.PatchSpace(r.Context(), authInfo, repositories.PatchSpaceMessage{
+		GUID:                 spaceGUID,
+		OrgGUID:              space.OrganizationGUID,
+		IsolationSegmentGUID: &isolationSegmentGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch space", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceIsolationSegment(space, h.apiBaseURL)), nil
 }
 
 func (h *Space) getRunningSecurityGroups(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.Empty, repositories.ListResult[any]{}, h.apiBaseURL, *r.URL)), nil
+	return h.listSecurityGroups(r, korifiv1alpha1.SecurityGroupRunningWorkloads)
 }
 
 func (h *Space) getStagingSecurityGroups(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.Empty, repositories.ListResult[any]{}, h.apiBaseURL, *r.URL)), nil
+	return h.listSecurityGroups(r, korifiv1alpha1.SecurityGroupStagingWorkloads)
+}
+
+func (h *Space) listSecurityGroups(r *http.Request, workloads string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.list-security-groups")
+
+	payload := new(payloads.SpaceSecurityGroupList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	spaceGUID := routing.URLParam(r, "guid")
+	_, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "spaceGUID", spaceGUID)
+	}
+
+	securityGroups, err := h.securityGroupRepo.ListSecurityGroups(r.Context(), authInfo, payload.ToMessage(spaceGUID, workloads))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list security groups for space", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSecurityGroup, securityGroups, h.apiBaseURL, *r.URL)), nil
 }
 
 func (h *Space) getSpaceFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.get-feature")
 
 	featureName := routing.URLParam(r, "name")
@@ -222,11 +320,45 @@ func (h *Space) getSpaceFeature(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("feature %q is not supported", featureName)), "feature not supported")
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(map[string]any{
-		"name":        "ssh",
-		"description": "Enable SSHing into apps in the space.",
-		"enabled":     false,
-	}), nil
+	spaceGUID := routing.URLParam(r, "guid")
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceSSHFeature(space)), nil
+}
+
+func (h *Space) updateSpaceFeature(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space.update-feature")
+
+	featureName := routing.URLParam(r, "name")
+	if featureName != "ssh" {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("feature %q is not supported", featureName)), "feature not supported")
+	}
+
+	var payload payloads.FeatureUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	spaceGUID := routing.URLParam(r, "guid")
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch space", "spaceGUID", spaceGUID)
+	}
+
+	space, err = h.spaceRepo.PatchSpace(r.Context(), authInfo, repositories.PatchSpaceMessage{
+		GUID:     spaceGUID,
+		OrgGUID:  space.OrganizationGUID,
+		AllowSSH: payload.Enabled,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch space", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceSSHFeature(space)), nil
 }
 
 func (h *Space) UnauthenticatedRoutes() []routing.Route {
@@ -242,8 +374,10 @@ func (h *Space) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: SpacePath, Handler: h.get},
 		{Method: "DELETE", Pattern: RoutesForSpacePath, Handler: h.deleteUnmappedRoutes},
 		{Method: "GET", Pattern: IsolationSegmentForSpacePath, Handler: h.getIsolationSegment},
+		{Method: "PATCH", Pattern: IsolationSegmentForSpacePath, Handler: h.patchIsolationSegment},
 		{Method: "GET", Pattern: RunningSecurityGroupsForSpacePath, Handler: h.getRunningSecurityGroups},
 		{Method: "GET", Pattern: StagingSecurityGroupsForSpacePath, Handler: h.getStagingSecurityGroups},
 		{Method: "GET", Pattern: SpaceFeaturePath, Handler: h.getSpaceFeature},
+		{Method: "PATCH", Pattern: SpaceFeaturePath, Handler: h.updateSpaceFeature},
 	}
 }
@@ -0,0 +1,359 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpaceQuota", func() {
+	var (
+		requestMethod    string
+		requestPath      string
+		requestBody      string
+		spaceQuotaRepo   *fake.CFSpaceQuotaRepository
+		orgRepo          *fake.CFOrgRepository
+		spaceRepo        *fake.CFSpaceRepository
+		requestValidator *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		spaceQuotaRepo = new(fake.CFSpaceQuotaRepository)
+		orgRepo = new(fake.CFOrgRepository)
+		spaceRepo = new(fake.CFSpaceRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewSpaceQuota(
+			*serverURL,
+			spaceQuotaRepo,
+			orgRepo,
+			spaceRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{
+			GUID:    "quota-guid",
+			OrgGUID: "org-guid",
+		}, nil)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(requestBody))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/space_quotas", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/space_quotas"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceQuotaCreate{
+				Name: "my-quota",
+				Apps: payloads.QuotaApps{
+					TotalInstances: tools.PtrTo[int32](3),
+				},
+				Relationships: &payloads.SpaceQuotaRelationships{
+					Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+					Spaces:       payloads.ToManyRelationship{Data: []payloads.RelationshipData{{GUID: "space1"}}},
+				},
+			})
+
+			spaceRepo.ListSpacesReturns(repositories.ListResult[repositories.SpaceRecord]{
+				Records: []repositories.SpaceRecord{{GUID: "space1"}},
+			}, nil)
+
+			spaceQuotaRepo.CreateSpaceQuotaReturns(repositories.SpaceQuotaRecord{
+				GUID:       "quota-guid",
+				Name:       "my-quota",
+				OrgGUID:    "org-guid",
+				SpaceGUIDs: []string{"space1"},
+			}, nil)
+		})
+
+		It("creates the space quota", func() {
+			Expect(orgRepo.GetOrgCallCount()).To(Equal(1))
+			_, _, actualOrgGUID := orgRepo.GetOrgArgsForCall(0)
+			Expect(actualOrgGUID).To(Equal("org-guid"))
+
+			Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+			_, _, listSpacesMessage := spaceRepo.ListSpacesArgsForCall(0)
+			Expect(listSpacesMessage.GUIDs).To(ConsistOf("space1"))
+			Expect(listSpacesMessage.OrganizationGUIDs).To(ConsistOf("org-guid"))
+
+			Expect(spaceQuotaRepo.CreateSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := spaceQuotaRepo.CreateSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Name).To(Equal("my-quota"))
+			Expect(message.OrgGUID).To(Equal("org-guid"))
+			Expect(message.Limits.Apps.TotalInstances).To(Equal(tools.PtrTo[int32](3)))
+			Expect(message.SpaceGUIDs).To(ConsistOf("space1"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.relationships.organization.data.guid", "org-guid"),
+				MatchJSONPath("$.relationships.spaces.data[0].guid", "space1"),
+			)))
+		})
+
+		When("the org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Organization does not exist, or you do not have access.")
+				Expect(spaceQuotaRepo.CreateSpaceQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("a space is not in the org", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns(repositories.ListResult[repositories.SpaceRecord]{}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Space does not exist, or you do not have access.")
+				Expect(spaceQuotaRepo.CreateSpaceQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("creating the space quota fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.CreateSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/space_quotas/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/space_quotas/quota-guid"
+		})
+
+		It("returns the space quota", func() {
+			Expect(spaceQuotaRepo.GetSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := spaceQuotaRepo.GetSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "quota-guid")))
+		})
+
+		When("the space quota is forbidden", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceQuotaResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/space_quotas", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/space_quotas"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SpaceQuotaList{
+				OrgGUIDs:   "org-guid",
+				SpaceGUIDs: "space1",
+			})
+
+			spaceQuotaRepo.ListSpaceQuotasReturns(repositories.ListResult[repositories.SpaceQuotaRecord]{
+				Records: []repositories.SpaceQuotaRecord{
+					{GUID: "q1-guid", Name: "q1"},
+				},
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 1,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     1,
+				},
+			}, nil)
+		})
+
+		It("lists the space quotas", func() {
+			Expect(spaceQuotaRepo.ListSpaceQuotasCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := spaceQuotaRepo.ListSpaceQuotasArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.OrgGUIDs).To(ConsistOf("org-guid"))
+			Expect(message.SpaceGUIDs).To(ConsistOf("space1"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "q1-guid"),
+			)))
+		})
+	})
+
+	Describe("PATCH /v3/space_quotas/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/space_quotas/quota-guid"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceQuotaUpdate{
+				Name: tools.PtrTo("new-name"),
+			})
+
+			spaceQuotaRepo.UpdateSpaceQuotaReturns(repositories.SpaceQuotaRecord{
+				GUID: "quota-guid",
+				Name: "new-name",
+			}, nil)
+		})
+
+		It("updates the space quota", func() {
+			Expect(spaceQuotaRepo.UpdateSpaceQuotaCallCount()).To(Equal(1))
+			_, _, message := spaceQuotaRepo.UpdateSpaceQuotaArgsForCall(0)
+			Expect(message.GUID).To(Equal("quota-guid"))
+			Expect(message.Name).To(Equal(tools.PtrTo("new-name")))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "new-name")))
+		})
+	})
+
+	Describe("DELETE /v3/space_quotas/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/space_quotas/quota-guid"
+		})
+
+		It("deletes the space quota", func() {
+			Expect(spaceQuotaRepo.DeleteSpaceQuotaCallCount()).To(Equal(1))
+			_, _, actualGUID := spaceQuotaRepo.DeleteSpaceQuotaArgsForCall(0)
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/space_quota.delete~quota-guid"))
+		})
+
+		When("the space quota does not exist", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceQuotaResourceType)
+				Expect(spaceQuotaRepo.DeleteSpaceQuotaCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /v3/space_quotas/{guid}/relationships/spaces", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPost
+			requestPath = "/v3/space_quotas/quota-guid/relationships/spaces"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceQuotaApply{
+				Data: []payloads.RelationshipData{{GUID: "space1"}, {GUID: "space2"}},
+			})
+			spaceRepo.ListSpacesReturns(repositories.ListResult[repositories.SpaceRecord]{
+				Records: []repositories.SpaceRecord{{GUID: "space1"}, {GUID: "space2"}},
+			}, nil)
+			spaceQuotaRepo.ApplySpaceQuotaReturns(repositories.SpaceQuotaRecord{
+				GUID:       "quota-guid",
+				SpaceGUIDs: []string{"space1", "space2"},
+			}, nil)
+		})
+
+		It("applies the space quota to the spaces", func() {
+			Expect(spaceRepo.ListSpacesCallCount()).To(Equal(1))
+			_, _, listSpacesMessage := spaceRepo.ListSpacesArgsForCall(0)
+			Expect(listSpacesMessage.OrganizationGUIDs).To(ConsistOf("org-guid"))
+
+			Expect(spaceQuotaRepo.ApplySpaceQuotaCallCount()).To(Equal(1))
+			_, _, message := spaceQuotaRepo.ApplySpaceQuotaArgsForCall(0)
+			Expect(message).To(Equal(repositories.ApplySpaceQuotaMessage{
+				GUID:       "quota-guid",
+				SpaceGUIDs: []string{"space1", "space2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data", HaveLen(2)),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/space_quotas/quota-guid/relationships/spaces"),
+			)))
+		})
+
+		When("a space is not in the quota org", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesReturns(repositories.ListResult[repositories.SpaceRecord]{
+					Records: []repositories.SpaceRecord{{GUID: "space1"}},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Space does not exist, or you do not have access.")
+				Expect(spaceQuotaRepo.ApplySpaceQuotaCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /v3/space_quotas/{guid}/relationships/spaces/{space_guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/space_quotas/quota-guid/relationships/spaces/space1"
+		})
+
+		It("removes the space quota from the space", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("space1"))
+
+			Expect(spaceQuotaRepo.RemoveSpaceQuotaCallCount()).To(Equal(1))
+			_, _, message := spaceQuotaRepo.RemoveSpaceQuotaArgsForCall(0)
+			Expect(message).To(Equal(repositories.RemoveSpaceQuotaMessage{
+				GUID:      "quota-guid",
+				SpaceGUID: "space1",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the space does not exist", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Space does not exist, or you do not have access.")
+			})
+		})
+
+		When("removing the space quota fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.RemoveSpaceQuotaReturns(errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -23,16 +23,19 @@ import (
 
 var _ = Describe("Space", func() {
 	var (
-		apiHandler          *handlers.Space
-		serviceOfferingRepo *fake.CFServiceOfferingRepository
-		serviceBrokerRepo   *fake.CFServiceBrokerRepository
-		servicePlanRepo     *fake.CFServicePlanRepository
-		spaceRepo           *fake.CFSpaceRepository
-		routeRepo           *fake.CFRouteRepository
-		orgRepo             *fake.CFOrgRepository
-		requestValidator    *fake.RequestValidator
-		requestMethod       string
-		requestPath         string
+		apiHandler           *handlers.Space
+		serviceOfferingRepo  *fake.CFServiceOfferingRepository
+		serviceBrokerRepo    *fake.CFServiceBrokerRepository
+		servicePlanRepo      *fake.CFServicePlanRepository
+		spaceRepo            *fake.CFSpaceRepository
+		routeRepo            *fake.CFRouteRepository
+		orgRepo              *fake.CFOrgRepository
+		securityGroupRepo    *fake.CFSecurityGroupRepository
+		isolationSegmentRepo *fake.CFIsolationSegmentRepository
+		requestValidator     *fake.RequestValidator
+		auditEventRecorder   *fake.AuditEventRecorder
+		requestMethod        string
+		requestPath          string
 	)
 
 	BeforeEach(func() {
@@ -52,12 +55,17 @@ var _ = Describe("Space", func() {
 		serviceBrokerRepo = new(fake.CFServiceBrokerRepository)
 		servicePlanRepo = new(fake.CFServicePlanRepository)
 		orgRepo = new(fake.CFOrgRepository)
+		securityGroupRepo = new(fake.CFSecurityGroupRepository)
+		isolationSegmentRepo = new(fake.CFIsolationSegmentRepository)
+		auditEventRecorder = new(fake.AuditEventRecorder)
 
 		apiHandler = handlers.NewSpace(
 			*serverURL,
 			spaceRepo,
 			orgRepo,
 			routeRepo,
+			securityGroupRepo,
+			isolationSegmentRepo,
 			requestValidator,
 			relationships.NewResourseRelationshipsRepo(
 				serviceOfferingRepo,
@@ -66,6 +74,7 @@ var _ = Describe("Space", func() {
 				spaceRepo,
 				orgRepo,
 			),
+			auditEventRecorder,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -117,6 +126,22 @@ var _ = Describe("Space", func() {
 			)))
 		})
 
+		It("records a space create audit event", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.CreateAuditEventMessage{
+				Type: "audit.space.create",
+				Target: repositories.AuditEventTarget{
+					GUID: "the-space-guid",
+					Type: "space",
+					Name: "the-space",
+				},
+				SpaceGUID:        "the-space-guid",
+				OrganizationGUID: "the-org-guid",
+			}))
+		})
+
 		When("the parent org does not exist (and the repo returns a not found error)", func() {
 			BeforeEach(func() {
 				spaceRepo.CreateSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(errors.New("nope"), repositories.OrgResourceType))
@@ -302,6 +327,15 @@ var _ = Describe("Space", func() {
 			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/space.delete~the-space-guid"))
 		})
 
+		It("records a space delete-request audit event scoped to the org", func() {
+			Expect(auditEventRecorder.CreateAuditEventCallCount()).To(Equal(1))
+			_, _, actualMessage := auditEventRecorder.CreateAuditEventArgsForCall(0)
+			Expect(actualMessage.Type).To(Equal("audit.space.delete-request"))
+			Expect(actualMessage.Target.GUID).To(Equal("the-space-guid"))
+			Expect(actualMessage.SpaceGUID).To(BeEmpty())
+			Expect(actualMessage.OrganizationGUID).To(Equal("the-org-guid"))
+		})
+
 		When("fetching the space errors", func() {
 			BeforeEach(func() {
 				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("boom"))
@@ -497,9 +531,90 @@ var _ = Describe("Space", func() {
 		BeforeEach(func() {
 			requestMethod = http.MethodGet
 			requestPath += "/the-space-guid/features/ssh"
+
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				Name:             "the-space",
+				GUID:             "the-space-guid",
+				OrganizationGUID: "the-org-guid",
+				AllowSSH:         true,
+			}, nil)
 		})
 
 		It("returns the correct response body", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "ssh"),
+				MatchJSONPath("$.description", "Enable SSHing into apps in the space."),
+				MatchJSONPath("$.enabled", true),
+			)))
+		})
+
+		It("gets the space", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+		})
+
+		When("ssh is not allowed in the space", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{GUID: "the-space-guid"}, nil)
+			})
+
+			It("returns ssh disabled", func() {
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.enabled", false)))
+			})
+		})
+
+		When("the space is not accessible", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+
+		When("the feature is not ssh", func() {
+			BeforeEach(func() {
+				requestPath = "/v3/spaces/the-space-guid/features/invalid-feature"
+			})
+
+			It("returns error", func() {
+				expectUnprocessableEntityError("feature \"invalid-feature\" is not supported")
+			})
+		})
+	})
+
+	Describe("Update space feature", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath += "/the-space-guid/features/ssh"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.FeatureUpdate{
+				Enabled: tools.PtrTo(false),
+			})
+
+			spaceRepo.PatchSpaceReturns(repositories.SpaceRecord{
+				Name: "the-space",
+				GUID: "the-space-guid",
+			}, nil)
+		})
+
+		It("patches the space", func() {
+			Expect(spaceRepo.PatchSpaceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := spaceRepo.PatchSpaceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.PatchSpaceMessage{
+				GUID:     "the-space-guid",
+				OrgGUID:  "the-org-guid",
+				AllowSSH: tools.PtrTo(false),
+			}))
+		})
+
+		It("returns the updated feature", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
@@ -516,6 +631,39 @@ var _ = Describe("Space", func() {
 
 			It("returns error", func() {
 				expectUnprocessableEntityError("feature \"invalid-feature\" is not supported")
+				Expect(spaceRepo.PatchSpaceCallCount()).To(BeZero())
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "enabled is required"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("enabled is required")
+				Expect(spaceRepo.PatchSpaceCallCount()).To(BeZero())
+			})
+		})
+
+		When("the space is not accessible", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+				Expect(spaceRepo.PatchSpaceCallCount()).To(BeZero())
+			})
+		})
+
+		When("patching the space fails", func() {
+			BeforeEach(func() {
+				spaceRepo.PatchSpaceReturns(repositories.SpaceRecord{}, errors.New("patch-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
 			})
 		})
 	})
@@ -574,4 +722,204 @@ var _ = Describe("Space", func() {
 			})
 		})
 	})
+
+	Describe("List running security groups for a space", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath += "/the-space-guid/running_security_groups"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SpaceSecurityGroupList{
+				Names: "sg1,sg2",
+			})
+
+			securityGroupRepo.ListSecurityGroupsReturns(repositories.ListResult[repositories.SecurityGroupRecord]{
+				Records: []repositories.SecurityGroupRecord{{
+					GUID: "sg-guid",
+					Name: "sg1",
+				}},
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 1,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     1,
+				},
+			}, nil)
+		})
+
+		It("lists the security groups that apply to running workloads in the space", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+
+			Expect(securityGroupRepo.ListSecurityGroupsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := securityGroupRepo.ListSecurityGroupsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("sg1", "sg2"))
+			Expect(message.RunningSpaceGUIDs).To(ConsistOf("the-space-guid"))
+			Expect(message.StagingSpaceGUIDs).To(BeEmpty())
+			Expect(message.IncludeGloballyEnabled).To(BeTrue())
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "sg-guid"),
+				MatchJSONPath("$.resources[0].name", "sg1"),
+			)))
+		})
+
+		When("fetching the space is forbidden", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+
+		When("listing the security groups fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.ListSecurityGroupsReturns(repositories.ListResult[repositories.SecurityGroupRecord]{}, errors.New("boom"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("List staging security groups for a space", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath += "/the-space-guid/staging_security_groups"
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SpaceSecurityGroupList{})
+		})
+
+		It("lists the security groups that apply to staging workloads in the space", func() {
+			Expect(securityGroupRepo.ListSecurityGroupsCallCount()).To(Equal(1))
+			_, _, message := securityGroupRepo.ListSecurityGroupsArgsForCall(0)
+			Expect(message.StagingSpaceGUIDs).To(ConsistOf("the-space-guid"))
+			Expect(message.RunningSpaceGUIDs).To(BeEmpty())
+			Expect(message.IncludeGloballyEnabled).To(BeTrue())
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		})
+	})
+
+	Describe("Get the isolation segment of a space", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath += "/the-space-guid/relationships/isolation_segment"
+
+			spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+				GUID:                 "the-space-guid",
+				IsolationSegmentGUID: "segment-guid",
+			}, nil)
+		})
+
+		It("returns the isolation segment relationship", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data.guid", "segment-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/spaces/the-space-guid/relationships/isolation_segment"),
+			)))
+		})
+
+		When("fetching the space is forbidden", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+	})
+
+	Describe("Patch the isolation segment of a space", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath += "/the-space-guid/relationships/isolation_segment"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentAssign{
+				Data: &payloads.RelationshipData{GUID: "segment-guid"},
+			})
+			isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID:     "segment-guid",
+				OrgGUIDs: []string{"the-org-guid"},
+			}, nil)
+			spaceRepo.PatchSpaceReturns(repositories.SpaceRecord{
+				GUID:                 "the-space-guid",
+				IsolationSegmentGUID: "segment-guid",
+			}, nil)
+		})
+
+		It("assigns the isolation segment to the space", func() {
+			Expect(isolationSegmentRepo.GetIsolationSegmentCallCount()).To(Equal(1))
+			_, _, actualSegmentGUID := isolationSegmentRepo.GetIsolationSegmentArgsForCall(0)
+			Expect(actualSegmentGUID).To(Equal("segment-guid"))
+
+			Expect(spaceRepo.PatchSpaceCallCount()).To(Equal(1))
+			_, _, message := spaceRepo.PatchSpaceArgsForCall(0)
+			Expect(message.GUID).To(Equal("the-space-guid"))
+			Expect(message.OrgGUID).To(Equal("the-org-guid"))
+			Expect(message.IsolationSegmentGUID).To(PointTo(Equal("segment-guid")))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data.guid", "segment-guid")))
+		})
+
+		When("the data is null", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentAssign{})
+			})
+
+			It("resets the isolation segment of the space", func() {
+				Expect(isolationSegmentRepo.GetIsolationSegmentCallCount()).To(BeZero())
+
+				Expect(spaceRepo.PatchSpaceCallCount()).To(Equal(1))
+				_, _, message := spaceRepo.PatchSpaceArgsForCall(0)
+				Expect(message.IsolationSegmentGUID).To(PointTo(BeEmpty()))
+			})
+		})
+
+		When("the isolation segment is not entitled to the org of the space", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+					GUID:     "segment-guid",
+					OrgGUIDs: []string{"another-org-guid"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(spaceRepo.PatchSpaceCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Unable to assign isolation segment with guid 'segment-guid'. Ensure it has been entitled to the organization.")
+			})
+		})
+
+		When("fetching the space is forbidden", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+
+		When("patching the space fails", func() {
+			BeforeEach(func() {
+				spaceRepo.PatchSpaceReturns(repositories.SpaceRecord{}, errors.New("patch-err"))
+			})
+
+			It("returns an unknown error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"github.com/go-logr/logr"
+)
+
+const (
+	SSHCodePath = "/oauth/authorize"
+)
+
+//counterfeiter:generate -o fake -fake-name SSHCodeIssuer . SSHCodeIssuer
+type SSHCodeIssuer interface {
+	Issue(context.Context, authorization.Info) (string, error)
+}
+
+// SSHCode implements the authorization code request `cf ssh-code` and
+// `cf ssh` make to the login endpoint. The code is an opaque, single use
+// reference to the credentials of the user, which the ssh proxy exchanges for
+// them to authenticate the session
+type SSHCode struct {
+	apiBaseURL    url.URL
+	sshCodeIssuer SSHCodeIssuer
+}
+
+func NewSSHCode(apiBaseURL url.URL, sshCodeIssuer SSHCodeIssuer) *SSHCode {
+	return &SSHCode{
+		apiBaseURL:    apiBaseURL,
+		sshCodeIssuer: sshCodeIssuer,
+	}
+}
+
+func (h *SSHCode) authorize(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.ssh-code.authorize")
+
+	query := r.URL.Query()
+	if query.Get("response_type") != "code" || query.Get("client_id") != presenter.SSHOAuthClient {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(errors.New("unsupported authorization request"), "Only ssh codes for the ssh-proxy client can be requested"),
+			"unsupported authorization request",
+			"query", query,
+		)
+	}
+
+	code, err := h.sshCodeIssuer.Issue(r.Context(), authInfo)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to issue ssh code")
+	}
+
+	redirectURL := h.apiBaseURL.JoinPath("login")
+	redirectURL.RawQuery = url.Values{"code": {code}}.Encode()
+
+	return routing.NewResponse(http.StatusFound).WithHeader("Location", redirectURL.String()), nil
+}
+
+func (h *SSHCode) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *SSHCode) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: SSHCodePath, Handler: h.authorize},
+	}
+}
@@ -0,0 +1,85 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SSHCode", func() {
+	var (
+		requestPath   string
+		userInfo      authorization.Info
+		sshCodeIssuer *fake.SSHCodeIssuer
+	)
+
+	BeforeEach(func() {
+		userInfo = authorization.Info{Token: "the-token", RawAuthHeader: "Bearer the-token"}
+		ctx = authorization.NewContext(ctx, &userInfo)
+		requestPath = "/oauth/authorize?response_type=code&client_id=ssh-proxy"
+		sshCodeIssuer = new(fake.SSHCodeIssuer)
+		sshCodeIssuer.IssueReturns("the-code", nil)
+		routerBuilder.LoadRoutes(handlers.NewSSHCode(*serverURL, sshCodeIssuer))
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestPath, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	It("issues a code for the user credentials", func() {
+		Expect(sshCodeIssuer.IssueCallCount()).To(Equal(1))
+		_, actualAuthInfo := sshCodeIssuer.IssueArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(userInfo))
+	})
+
+	It("redirects with the issued code", func() {
+		Expect(rr).To(HaveHTTPStatus(http.StatusFound))
+
+		location, err := url.Parse(rr.Header().Get("Location"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(location.Host).To(Equal("api.example.org"))
+		Expect(location.Path).To(Equal("/login"))
+		Expect(location.Query().Get("code")).To(Equal("the-code"))
+	})
+
+	When("issuing the code fails", func() {
+		BeforeEach(func() {
+			sshCodeIssuer.IssueReturns("", errors.New("issue-err"))
+		})
+
+		It("returns an error", func() {
+			expectUnknownError()
+		})
+	})
+
+	When("the code is requested for another client", func() {
+		BeforeEach(func() {
+			requestPath = "/oauth/authorize?response_type=code&client_id=cf"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError("Only ssh codes for the ssh-proxy client can be requested")
+			Expect(sshCodeIssuer.IssueCallCount()).To(BeZero())
+		})
+	})
+
+	When("a token is requested", func() {
+		BeforeEach(func() {
+			requestPath = "/oauth/authorize?response_type=token&client_id=ssh-proxy"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError("Only ssh codes for the ssh-proxy client can be requested")
+		})
+	})
+})
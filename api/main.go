@@ -27,6 +27,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
 	"code.cloudfoundry.org/korifi/api/repositories/relationships"
 	"code.cloudfoundry.org/korifi/api/routing"
+	"code.cloudfoundry.org/korifi/api/sshproxy"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/tools"
@@ -36,6 +37,7 @@ import (
 	"code.cloudfoundry.org/korifi/version"
 
 	chiMiddlewares "github.com/go-chi/chi/middleware"
+	"github.com/go-logr/logr"
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/cache"
@@ -193,20 +195,56 @@ func main() {
 	dropletRepo := repositories.NewDropletRepo(spaceScopedKlient)
 	domainRepo := repositories.NewDomainRepo(
 		rootNSKlient,
+		k8sClient,
+		userClientFactory,
+		nsPermissions,
 		cfg.RootNamespace,
 	)
-	routeRepo := repositories.NewRouteRepo(spaceScopedKlient, domainRepo)
+	routerGroupRepo := repositories.NewRouterGroupRepo(cfg.RouterGroups)
+	routeRepo := repositories.NewRouteRepo(spaceScopedKlient, domainRepo, k8sClient)
 	deploymentRepo := repositories.NewDeploymentRepo(
 		spaceScopedKlient,
 	)
+	revisionRepo := repositories.NewRevisionRepo(spaceScopedKlient)
+	sidecarRepo := repositories.NewSidecarRepo(spaceScopedKlient)
+	networkPolicyRepo := repositories.NewNetworkPolicyRepo(spaceScopedKlient)
 	buildRepo := repositories.NewBuildRepo(
 		spaceScopedKlient,
 	)
+	var logBuffer *repositories.LogBuffer
+	if cfg.Experimental.LogBuffer.Enabled {
+		logBuffer = repositories.NewLogBuffer(
+			k8sClient,
+			clientset,
+			repositories.DefaultLogStreamer,
+			cfg.Experimental.LogBuffer.Retention,
+			cfg.Experimental.LogBuffer.MaxLogLinesPerApp,
+		)
+		go logBuffer.Start(logr.NewContext(context.Background(), ctrl.Log))
+	}
+	userClientsetFactory := authorization.NewUnprivilegedClientsetFactory(k8sClientConfig)
 	logRepo := repositories.NewLogRepo(
 		userClientFactory,
-		authorization.NewUnprivilegedClientsetFactory(k8sClientConfig),
+		userClientsetFactory,
 		repositories.DefaultLogStreamer,
+		logBuffer,
+		repositories.NewLogFollowers(
+			userClientFactory,
+			userClientsetFactory,
+			repositories.DefaultLogStreamer,
+			time.Minute,
+		),
 	)
+	var gaugeRepo handlers.GaugeRepository
+	if cfg.Experimental.MetricsHistory.Enabled {
+		metricsBuffer := repositories.NewMetricsBuffer(
+			k8sClient,
+			cfg.Experimental.MetricsHistory.SampleInterval,
+			cfg.Experimental.MetricsHistory.Retention,
+		)
+		go metricsBuffer.Start(logr.NewContext(context.Background(), ctrl.Log))
+		gaugeRepo = repositories.NewGaugeRepo(userClientFactory, metricsBuffer)
+	}
 	runnerInfoRepo := repositories.NewRunnerInfoRepository(
 		rootNSKlient,
 		cfg.RunnerName,
@@ -220,6 +258,8 @@ func main() {
 	)
 	serviceInstanceRepo := repositories.NewServiceInstanceRepo(
 		spaceScopedKlient,
+		k8sClient,
+		userClientFactory,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceInstance, korifiv1alpha1.CFServiceInstanceList](conditionTimeout),
 		cfg.RootNamespace,
 	)
@@ -229,6 +269,10 @@ func main() {
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFApp, korifiv1alpha1.CFAppList](conditionTimeout),
 		paramsClient,
 	)
+	serviceRouteBindingRepo := repositories.NewServiceRouteBindingRepo(
+		spaceScopedKlient,
+		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceRouteBinding, korifiv1alpha1.CFServiceRouteBindingList](conditionTimeout),
+	)
 	stackRepo := repositories.NewStackRepository(
 		rootNSKlient,
 		cfg.BuilderName,
@@ -256,6 +300,13 @@ func main() {
 		cfg.PackageRegistrySecretNames,
 		cfg.RootNamespace,
 	)
+	resourceCacheRepo := repositories.NewResourceCacheRepo(
+		imageClient,
+		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType),
+		cfg.ContainerRepositoryPrefix,
+		cfg.PackageRegistrySecretNames,
+		cfg.RootNamespace,
+	)
 	taskRepo := repositories.NewTaskRepo(
 		spaceScopedKlient,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList](conditionTimeout),
@@ -264,10 +315,16 @@ func main() {
 	serviceBrokerRepo := repositories.NewServiceBrokerRepo(rootNSKlient, cfg.RootNamespace)
 	serviceOfferingRepo := repositories.NewServiceOfferingRepo(rootNSKlient, spaceScopedKlient, cfg.RootNamespace)
 	servicePlanRepo := repositories.NewServicePlanRepo(rootNSKlient, cfg.RootNamespace, orgRepo)
-	securityGroupRepo := repositories.NewSecurityGroupRepo(rootNSKlient, cfg.RootNamespace)
+	securityGroupRepo := repositories.NewSecurityGroupRepo(rootNSKlient, k8sClient, nsPermissions, cfg.RootNamespace, repositories.NewSecurityGroupSorter())
+	orgQuotaRepo := repositories.NewOrgQuotaRepo(rootNSKlient, cfg.RootNamespace)
+	spaceQuotaRepo := repositories.NewSpaceQuotaRepo(spaceScopedKlient, nsPermissions)
+	isolationSegmentRepo := repositories.NewIsolationSegmentRepo(rootNSKlient, cfg.RootNamespace)
+	auditEventRepo := repositories.NewAuditEventRepo(rootNSKlient, k8sClient, nsPermissions, cachingIdentityProvider, cfg.RootNamespace)
+	appUsageEventRepo := repositories.NewAppUsageEventRepo(rootNSKlient, userClientFactory, k8sClient, cfg.RootNamespace)
+	serviceUsageEventRepo := repositories.NewServiceUsageEventRepo(rootNSKlient, userClientFactory, k8sClient, cfg.RootNamespace)
 	userRepo := repositories.NewUserRepository()
 
-	appsStateCollector := manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, dropletRepo)
+	appsStateCollector := manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, dropletRepo, sidecarRepo)
 
 	processStats := actions.NewProcessStats(processRepo, appRepo, metricsRepo)
 	manifest := actions.NewManifest(
@@ -275,7 +332,7 @@ func main() {
 		cfg.DefaultDomainName,
 		appsStateCollector,
 		manifest.NewNormalizer(cfg.DefaultDomainName),
-		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
+		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, sidecarRepo),
 	)
 
 	requestValidator := validation.NewDefaultDecoderValidator()
@@ -323,15 +380,33 @@ func main() {
 		panic(fmt.Sprintf("could not create log cache client: %v", err))
 	}
 
+	sshCodeStore := authorization.NewSSHCodeStore(k8sClient, cachingIdentityProvider, cfg.RootNamespace, authorization.SSHCodeTTL)
+	sshHostKeyFingerprint := ""
+	if cfg.Experimental.SSHProxy.Enabled {
+		sshHostKeyFingerprint = startSSHProxy(
+			cfg.Experimental.SSHProxy,
+			k8sClient,
+			k8sClientConfig,
+			clientset,
+			sshCodeStore,
+			cachingIdentityProvider,
+			nsPermissions,
+		)
+	}
+
 	instancesStateCollector := stats.NewProcessInstanceStateCollector(processRepo)
 	apiHandlers := []routing.Routable{
 		handlers.NewRootV3(*serverURL),
-		handlers.NewRoot(*serverURL, cfg.Experimental.UAA, *logCacheURL),
+		handlers.NewRoot(*serverURL, cfg.Experimental.UAA, *logCacheURL, cfg.Experimental.SSHProxy, sshHostKeyFingerprint),
+		handlers.NewSSHCode(*serverURL, sshCodeStore),
 		handlers.NewInfoV3(
 			*serverURL,
 			cfg.InfoConfig,
 		),
-		handlers.NewResourceMatches(),
+		handlers.NewResourceMatches(
+			resourceCacheRepo,
+			requestValidator,
+		),
 		handlers.NewApp(
 			*serverURL,
 			appRepo,
@@ -346,6 +421,8 @@ func main() {
 			gaugesCollector,
 			instancesStateCollector,
 			appsStateCollector,
+			auditEventRepo,
+			cfg.Experimental.SSHProxy.Enabled,
 		),
 		handlers.NewRoute(
 			*serverURL,
@@ -353,10 +430,18 @@ func main() {
 			domainRepo,
 			appRepo,
 			spaceRepo,
+			routerGroupRepo,
 			requestValidator,
+			auditEventRepo,
 		),
 		handlers.NewServiceRouteBinding(
 			*serverURL,
+			serviceRouteBindingRepo,
+			serviceInstanceRepo,
+			routeRepo,
+			servicePlanRepo,
+			serviceOfferingRepo,
+			requestValidator,
 		),
 		handlers.NewPackage(
 			*serverURL,
@@ -364,6 +449,7 @@ func main() {
 			appRepo,
 			dropletRepo,
 			imageRepo,
+			resourceCacheRepo,
 			requestValidator,
 			cfg.PackageRegistrySecretNames,
 		),
@@ -382,6 +468,7 @@ func main() {
 		handlers.NewProcess(
 			*serverURL,
 			processRepo,
+			sidecarRepo,
 			requestValidator,
 			podRepo,
 			gaugesCollector,
@@ -391,6 +478,12 @@ func main() {
 			*serverURL,
 			requestValidator,
 			domainRepo,
+			routerGroupRepo,
+			orgRepo,
+		),
+		handlers.NewRouterGroup(
+			routerGroupRepo,
+			requestValidator,
 		),
 		handlers.NewDeployment(
 			*serverURL,
@@ -399,6 +492,38 @@ func main() {
 			runnerInfoRepo,
 			cfg.RunnerName,
 		),
+		handlers.NewRevision(
+			*serverURL,
+			revisionRepo,
+			appRepo,
+			requestValidator,
+		),
+		handlers.NewSidecar(
+			*serverURL,
+			sidecarRepo,
+			appRepo,
+			requestValidator,
+		),
+		handlers.NewNetworkPolicy(
+			networkPolicyRepo,
+			appRepo,
+			requestValidator,
+		),
+		handlers.NewAuditEvent(
+			*serverURL,
+			auditEventRepo,
+			requestValidator,
+		),
+		handlers.NewAppUsageEvent(
+			*serverURL,
+			appUsageEventRepo,
+			requestValidator,
+		),
+		handlers.NewServiceUsageEvent(
+			*serverURL,
+			serviceUsageEventRepo,
+			requestValidator,
+		),
 		handlers.NewStack(
 			*serverURL,
 			stackRepo,
@@ -407,21 +532,27 @@ func main() {
 		handlers.NewJob(
 			*serverURL,
 			map[string]handlers.DeletionRepository{
-				handlers.OrgDeleteJobType:                    orgRepo,
-				handlers.SpaceDeleteJobType:                  spaceRepo,
-				handlers.AppDeleteJobType:                    appRepo,
-				handlers.RouteDeleteJobType:                  routeRepo,
-				handlers.DomainDeleteJobType:                 domainRepo,
-				handlers.RoleDeleteJobType:                   roleRepo,
-				handlers.ServiceBrokerDeleteJobType:          serviceBrokerRepo,
-				handlers.ManagedServiceInstanceDeleteJobType: serviceInstanceRepo,
-				handlers.ManagedServiceBindingDeleteJobType:  serviceBindingRepo,
+				handlers.OrgDeleteJobType:                        orgRepo,
+				handlers.SpaceDeleteJobType:                      spaceRepo,
+				handlers.AppDeleteJobType:                        appRepo,
+				handlers.RouteDeleteJobType:                      routeRepo,
+				handlers.DomainDeleteJobType:                     domainRepo,
+				handlers.RoleDeleteJobType:                       roleRepo,
+				handlers.SecurityGroupDeleteJobType:              securityGroupRepo,
+				handlers.OrgQuotaDeleteJobType:                   orgQuotaRepo,
+				handlers.SpaceQuotaDeleteJobType:                 spaceQuotaRepo,
+				handlers.ServiceBrokerDeleteJobType:              serviceBrokerRepo,
+				handlers.ManagedServiceInstanceDeleteJobType:     serviceInstanceRepo,
+				handlers.ManagedServiceBindingDeleteJobType:      serviceBindingRepo,
+				handlers.ManagedServiceRouteBindingDeleteJobType: serviceRouteBindingRepo,
 			},
 			map[string]handlers.StateRepository{
-				handlers.ServiceBrokerCreateJobType:          serviceBrokerRepo,
-				handlers.ServiceBrokerUpdateJobType:          serviceBrokerRepo,
-				handlers.ManagedServiceInstanceCreateJobType: serviceInstanceRepo,
-				handlers.ManagedServiceBindingCreateJobType:  serviceBindingRepo,
+				handlers.ServiceBrokerCreateJobType:              serviceBrokerRepo,
+				handlers.ServiceBrokerUpdateJobType:              serviceBrokerRepo,
+				handlers.ManagedServiceInstanceCreateJobType:     serviceInstanceRepo,
+				handlers.ManagedServiceInstanceUpdateJobType:     serviceInstanceRepo,
+				handlers.ManagedServiceBindingCreateJobType:      serviceBindingRepo,
+				handlers.ManagedServiceRouteBindingCreateJobType: serviceRouteBindingRepo,
 			},
 			routeRepo,
 			500*time.Millisecond,
@@ -430,17 +561,22 @@ func main() {
 			*serverURL,
 			orgRepo,
 			domainRepo,
+			isolationSegmentRepo,
 			requestValidator,
 			cfg.UserCertificateExpirationWarningDuration,
 			cfg.DefaultDomainName,
+			auditEventRepo,
 		),
 		handlers.NewSpace(
 			*serverURL,
 			spaceRepo,
 			orgRepo,
 			routeRepo,
+			securityGroupRepo,
+			isolationSegmentRepo,
 			requestValidator,
 			relationshipsRepo,
+			auditEventRepo,
 		),
 		handlers.NewSpaceManifest(
 			*serverURL,
@@ -452,6 +588,7 @@ func main() {
 			*serverURL,
 			roleRepo,
 			requestValidator,
+			auditEventRepo,
 		),
 		handlers.NewWhoAmI(cachingIdentityProvider, *serverURL),
 		handlers.NewUser(*serverURL, userRepo, requestValidator),
@@ -464,8 +601,11 @@ func main() {
 			*serverURL,
 			serviceInstanceRepo,
 			spaceRepo,
+			servicePlanRepo,
+			serviceOfferingRepo,
 			requestValidator,
 			relationshipsRepo,
+			auditEventRepo,
 		),
 		handlers.NewServiceBinding(
 			*serverURL,
@@ -473,6 +613,7 @@ func main() {
 			appRepo,
 			serviceInstanceRepo,
 			requestValidator,
+			auditEventRepo,
 		),
 		handlers.NewTask(
 			*serverURL,
@@ -506,9 +647,23 @@ func main() {
 		),
 		handlers.NewOrgQuota(
 			*serverURL,
+			orgQuotaRepo,
+			orgRepo,
+			requestValidator,
+		),
+		handlers.NewSpaceQuota(
+			*serverURL,
+			spaceQuotaRepo,
+			orgRepo,
+			spaceRepo,
+			requestValidator,
 		),
 		handlers.NewIsolationSegment(
 			*serverURL,
+			isolationSegmentRepo,
+			orgRepo,
+			spaceRepo,
+			requestValidator,
 		),
 	}
 
@@ -519,6 +674,7 @@ func main() {
 			buildRepo,
 			logRepo,
 			processStats,
+			gaugeRepo,
 		))
 	}
 
@@ -580,6 +736,36 @@ func createCertWatcher(envVar string) *certwatcher.CertWatcher {
 	return certWatcher
 }
 
+func startSSHProxy(
+	sshProxyConfig config.SSHProxy,
+	k8sClient client.Client,
+	k8sClientConfig *rest.Config,
+	clientset k8sclient.Interface,
+	sshCodeStore *authorization.SSHCodeStore,
+	identityProvider authorization.IdentityProvider,
+	nsPermissions *authorization.NamespacePermissions,
+) string {
+	hostKey, err := sshproxy.LoadHostKey(sshProxyConfig.HostKeyPath)
+	if err != nil {
+		panic(fmt.Sprintf("could not load ssh proxy host key: %v", err))
+	}
+
+	sshProxy := sshproxy.NewServer(
+		hostKey,
+		sshproxy.NewAuthenticator(k8sClient, authorization.NewInfoParser(), sshCodeStore, identityProvider, nsPermissions),
+		sshproxy.NewPodStreamer(k8sClientConfig, clientset),
+	)
+
+	go func() {
+		if err := sshProxy.ListenAndServe(logr.NewContext(context.Background(), ctrl.Log.WithName("ssh-proxy")), sshProxyConfig.Port); err != nil {
+			ctrl.Log.Error(err, "error serving ssh proxy")
+			os.Exit(1)
+		}
+	}()
+
+	return sshproxy.Fingerprint(hostKey.PublicKey())
+}
+
 func wireIdentityProvider(client client.Client, restConfig *rest.Config) authorization.IdentityProvider {
 	tokenReviewer := authorization.NewTokenReviewer(client)
 	certInspector := authorization.NewCertInspector(restConfig)
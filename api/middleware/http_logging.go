@@ -28,6 +28,12 @@ func (w *responseWriterWrapper) WriteHeader(statusCode int) {
 	w.status = statusCode
 }
 
+// Unwrap gives http.ResponseController access to the underlying writer so
+// that streamed responses can be flushed
+func (w *responseWriterWrapper) Unwrap() http.ResponseWriter {
+	return w.writer
+}
+
 func HTTPLogging(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t1 := time.Now()
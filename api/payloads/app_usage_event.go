@@ -0,0 +1,66 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type AppUsageEventList struct {
+	GUIDs      string
+	CreatedAts map[repositories.TimestampOperator]string
+	OrderBy    string
+	Pagination Pagination
+}
+
+func (l *AppUsageEventList) SupportedKeys() []string {
+	return []string{
+		"guids",
+		"created_ats",
+		"created_ats[lt]",
+		"created_ats[lte]",
+		"created_ats[gt]",
+		"created_ats[gte]",
+		"order_by",
+		"page",
+		"per_page",
+	}
+}
+
+func (l AppUsageEventList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.CreatedAts, jellidation.Each(jellidation.By(validateTimestamps))),
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func (l *AppUsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.CreatedAts = decodeCreatedAts(values)
+	l.OrderBy = values.Get("order_by")
+
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func (l *AppUsageEventList) ToMessage() repositories.ListAppUsageEventsMessage {
+	return repositories.ListAppUsageEventsMessage{
+		GUIDs:      parse.ArrayParam(l.GUIDs),
+		CreatedAts: toTimestampFilters(l.CreatedAts),
+		OrderBy:    usageEventsOrderBy(l.OrderBy),
+		Pagination: l.Pagination.ToMessage(DefaultPageSize),
+	}
+}
+
+// Usage events are consumed in the order they occurred, which is why they are
+// ordered by creation time unless requested otherwise
+func usageEventsOrderBy(orderBy string) string {
+	if orderBy == "" {
+		return "created_at"
+	}
+
+	return orderBy
+}
@@ -0,0 +1,74 @@
+package payloads_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppUsageEventList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedAppUsageEventList payloads.AppUsageEventList) {
+			actualAppUsageEventList, decodeErr := decodeQuery[payloads.AppUsageEventList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualAppUsageEventList).To(Equal(expectedAppUsageEventList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.AppUsageEventList{GUIDs: "g1,g2"}),
+		Entry("created_ats with operators", "created_ats[gte]=2024-01-01T00:00:00Z", payloads.AppUsageEventList{
+			CreatedAts: map[repositories.TimestampOperator]string{
+				repositories.TimestampOperatorGreaterThanOrEqual: "2024-01-01T00:00:00Z",
+			},
+		}),
+		Entry("order_by -created_at", "order_by=-created_at", payloads.AppUsageEventList{OrderBy: "-created_at"}),
+		Entry("pagination", "page=3", payloads.AppUsageEventList{Pagination: payloads.Pagination{Page: "3"}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AppUsageEventList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid created_ats", "created_ats=yesterday", "is not a valid timestamp"),
+		Entry("invalid order_by", "order_by=foo", "value must be one of"),
+		Entry("after_guid", "after_guid=g1", "unsupported query parameter: after_guid"),
+		Entry("invalid pagination", "per_page=foo", "value must be an integer"),
+	)
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			appUsageEventList := payloads.AppUsageEventList{
+				GUIDs: "g1,g2",
+				CreatedAts: map[repositories.TimestampOperator]string{
+					repositories.TimestampOperatorLessThan: "2024-02-01T00:00:00Z",
+				},
+				OrderBy: "-created_at",
+				Pagination: payloads.Pagination{
+					PerPage: "3",
+					Page:    "2",
+				},
+			}
+			Expect(appUsageEventList.ToMessage()).To(Equal(repositories.ListAppUsageEventsMessage{
+				GUIDs: []string{"g1", "g2"},
+				CreatedAts: []repositories.TimestampFilter{{
+					Operator: repositories.TimestampOperatorLessThan,
+					Values:   []time.Time{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+				}},
+				OrderBy: "-created_at",
+				Pagination: repositories.Pagination{
+					Page:    2,
+					PerPage: 3,
+				},
+			}))
+		})
+
+		It("orders by creation time by default", func() {
+			appUsageEventList := payloads.AppUsageEventList{}
+			Expect(appUsageEventList.ToMessage().OrderBy).To(Equal("created_at"))
+		})
+	})
+})
@@ -0,0 +1,136 @@
+package payloads
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+var timestampOperators = []repositories.TimestampOperator{
+	repositories.TimestampOperatorEqual,
+	repositories.TimestampOperatorLessThan,
+	repositories.TimestampOperatorLessThanOrEqual,
+	repositories.TimestampOperatorGreaterThan,
+	repositories.TimestampOperatorGreaterThanOrEqual,
+}
+
+type AuditEventList struct {
+	TargetGUIDs       string
+	Types             string
+	SpaceGUIDs        string
+	OrganizationGUIDs string
+	// Comma separated timestamps keyed by the relational operator, e.g.
+	// created_ats[gt]=2024-01-01T00:00:00Z is stored under "gt"
+	CreatedAts map[repositories.TimestampOperator]string
+	OrderBy    string
+	Pagination Pagination
+}
+
+func (l *AuditEventList) SupportedKeys() []string {
+	return []string{
+		"target_guids",
+		"types",
+		"space_guids",
+		"organization_guids",
+		"created_ats",
+		"created_ats[lt]",
+		"created_ats[lte]",
+		"created_ats[gt]",
+		"created_ats[gte]",
+		"order_by",
+		"page",
+		"per_page",
+	}
+}
+
+func (l AuditEventList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.CreatedAts, jellidation.Each(jellidation.By(validateTimestamps))),
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func validateTimestamps(value any) error {
+	timestamps, ok := value.(string)
+	if !ok {
+		return errors.New("must be a string")
+	}
+
+	for _, timestamp := range parse.ArrayParam(timestamps) {
+		if _, err := time.Parse(time.RFC3339, timestamp); err != nil {
+			return fmt.Errorf("%q is not a valid timestamp", timestamp)
+		}
+	}
+
+	return nil
+}
+
+func (l *AuditEventList) DecodeFromURLValues(values url.Values) error {
+	l.TargetGUIDs = values.Get("target_guids")
+	l.Types = values.Get("types")
+	l.SpaceGUIDs = values.Get("space_guids")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	l.OrderBy = values.Get("order_by")
+
+	l.CreatedAts = decodeCreatedAts(values)
+
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func decodeCreatedAts(values url.Values) map[repositories.TimestampOperator]string {
+	var createdAts map[repositories.TimestampOperator]string
+	for _, operator := range timestampOperators {
+		key := "created_ats"
+		if operator != repositories.TimestampOperatorEqual {
+			key = fmt.Sprintf("created_ats[%s]", operator)
+		}
+
+		if values.Has(key) {
+			if createdAts == nil {
+				createdAts = map[repositories.TimestampOperator]string{}
+			}
+			createdAts[operator] = values.Get(key)
+		}
+	}
+
+	return createdAts
+}
+
+func (l *AuditEventList) ToMessage() repositories.ListAuditEventsMessage {
+	return repositories.ListAuditEventsMessage{
+		TargetGUIDs:       parse.ArrayParam(l.TargetGUIDs),
+		Types:             parse.ArrayParam(l.Types),
+		SpaceGUIDs:        parse.ArrayParam(l.SpaceGUIDs),
+		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+		CreatedAts:        toTimestampFilters(l.CreatedAts),
+		OrderBy:           l.OrderBy,
+		Pagination:        l.Pagination.ToMessage(DefaultPageSize),
+	}
+}
+
+func toTimestampFilters(timestampsByOperator map[repositories.TimestampOperator]string) []repositories.TimestampFilter {
+	var filters []repositories.TimestampFilter
+	for _, operator := range timestampOperators {
+		timestamps, ok := timestampsByOperator[operator]
+		if !ok {
+			continue
+		}
+
+		filter := repositories.TimestampFilter{Operator: operator}
+		for _, timestamp := range parse.ArrayParam(timestamps) {
+			// the timestamps have already been validated
+			t, _ := time.Parse(time.RFC3339, timestamp)
+			filter.Values = append(filter.Values, t)
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters
+}
@@ -0,0 +1,92 @@
+package payloads_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEventList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedAuditEventList payloads.AuditEventList) {
+			actualAuditEventList, decodeErr := decodeQuery[payloads.AuditEventList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualAuditEventList).To(Equal(expectedAuditEventList))
+		},
+		Entry("target_guids", "target_guids=t1,t2", payloads.AuditEventList{TargetGUIDs: "t1,t2"}),
+		Entry("types", "types=audit.app.create,audit.app.start", payloads.AuditEventList{Types: "audit.app.create,audit.app.start"}),
+		Entry("space_guids", "space_guids=s1,s2", payloads.AuditEventList{SpaceGUIDs: "s1,s2"}),
+		Entry("organization_guids", "organization_guids=o1,o2", payloads.AuditEventList{OrganizationGUIDs: "o1,o2"}),
+		Entry("created_ats", "created_ats=2024-01-01T00:00:00Z", payloads.AuditEventList{
+			CreatedAts: map[repositories.TimestampOperator]string{
+				repositories.TimestampOperatorEqual: "2024-01-01T00:00:00Z",
+			},
+		}),
+		Entry("created_ats with operators", "created_ats[gt]=2024-01-01T00:00:00Z&created_ats[lte]=2024-02-01T00:00:00Z", payloads.AuditEventList{
+			CreatedAts: map[repositories.TimestampOperator]string{
+				repositories.TimestampOperatorGreaterThan:     "2024-01-01T00:00:00Z",
+				repositories.TimestampOperatorLessThanOrEqual: "2024-02-01T00:00:00Z",
+			},
+		}),
+		Entry("order_by created_at", "order_by=created_at", payloads.AuditEventList{OrderBy: "created_at"}),
+		Entry("order_by -updated_at", "order_by=-updated_at", payloads.AuditEventList{OrderBy: "-updated_at"}),
+		Entry("pagination", "page=3", payloads.AuditEventList{Pagination: payloads.Pagination{Page: "3"}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AuditEventList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid created_ats", "created_ats[lt]=yesterday", "is not a valid timestamp"),
+		Entry("invalid order_by", "order_by=foo", "value must be one of"),
+		Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+		Entry("invalid pagination", "per_page=foo", "value must be an integer"),
+	)
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			auditEventList := payloads.AuditEventList{
+				TargetGUIDs:       "t1,t2",
+				Types:             "audit.app.create",
+				SpaceGUIDs:        "s1",
+				OrganizationGUIDs: "o1",
+				CreatedAts: map[repositories.TimestampOperator]string{
+					repositories.TimestampOperatorLessThan:    "2024-02-01T00:00:00Z",
+					repositories.TimestampOperatorGreaterThan: "2024-01-01T00:00:00Z",
+				},
+				OrderBy: "-created_at",
+				Pagination: payloads.Pagination{
+					PerPage: "3",
+					Page:    "2",
+				},
+			}
+			Expect(auditEventList.ToMessage()).To(Equal(repositories.ListAuditEventsMessage{
+				TargetGUIDs:       []string{"t1", "t2"},
+				Types:             []string{"audit.app.create"},
+				SpaceGUIDs:        []string{"s1"},
+				OrganizationGUIDs: []string{"o1"},
+				CreatedAts: []repositories.TimestampFilter{
+					{
+						Operator: repositories.TimestampOperatorLessThan,
+						Values:   []time.Time{time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+					},
+					{
+						Operator: repositories.TimestampOperatorGreaterThan,
+						Values:   []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+					},
+				},
+				OrderBy: "-created_at",
+				Pagination: repositories.Pagination{
+					Page:    2,
+					PerPage: 3,
+				},
+			}))
+		})
+	})
+})
@@ -16,21 +16,112 @@ type DropletGUID struct {
 	Guid string `json:"guid"`
 }
 
+type RevisionGUID struct {
+	Guid string `json:"guid"`
+}
+
+func (g RevisionGUID) Validate() error {
+	return jellidation.ValidateStruct(&g,
+		jellidation.Field(&g.Guid, jellidation.Required),
+	)
+}
+
 type DeploymentCreate struct {
 	Droplet       DropletGUID              `json:"droplet"`
+	Revision      *RevisionGUID            `json:"revision"`
+	Strategy      string                   `json:"strategy"`
+	Options       *DeploymentOptions       `json:"options"`
 	Relationships *DeploymentRelationships `json:"relationships"`
 }
 
 func (c DeploymentCreate) Validate() error {
 	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Revision, jellidation.By(func(value any) error {
+			revision, ok := value.(*RevisionGUID)
+			if !ok || revision == nil || c.Droplet.Guid == "" {
+				return nil
+			}
+
+			return jellidation.NewError("validation_droplet_and_revision", "cannot set both droplet and revision")
+		})),
+		jellidation.Field(&c.Strategy, validation.OneOf("rolling", "canary")),
+		jellidation.Field(&c.Options, jellidation.By(func(value any) error {
+			options, ok := value.(*DeploymentOptions)
+			if !ok || options == nil || options.Canary == nil || c.Strategy == "canary" {
+				return nil
+			}
+
+			return jellidation.NewError("validation_canary_strategy", "canary options are only valid for canary deployments")
+		})),
 		jellidation.Field(&c.Relationships, jellidation.NotNil))
 }
 
 func (c *DeploymentCreate) ToMessage() repositories.CreateDeploymentMessage {
-	return repositories.CreateDeploymentMessage{
+	message := repositories.CreateDeploymentMessage{
 		AppGUID:     c.Relationships.App.Data.GUID,
 		DropletGUID: c.Droplet.Guid,
+		Strategy:    c.Strategy,
 	}
+
+	if c.Revision != nil {
+		message.RevisionGUID = c.Revision.Guid
+	}
+
+	if c.Options != nil {
+		message.MaxInFlight = c.Options.MaxInFlight
+		if c.Options.Canary != nil {
+			message.CanarySteps = slices.Collect(it.Map(slices.Values(c.Options.Canary.Steps), func(step DeploymentCanaryStep) repositories.DeploymentCanaryStep {
+				return repositories.DeploymentCanaryStep{InstanceWeight: step.InstanceWeight}
+			}))
+		}
+	}
+
+	return message
+}
+
+type DeploymentOptions struct {
+	MaxInFlight *int32                   `json:"max_in_flight"`
+	Canary      *DeploymentCanaryOptions `json:"canary"`
+}
+
+func (o DeploymentOptions) Validate() error {
+	return jellidation.ValidateStruct(&o,
+		jellidation.Field(&o.MaxInFlight, jellidation.NilOrNotEmpty, jellidation.Min(1)),
+		jellidation.Field(&o.Canary),
+	)
+}
+
+type DeploymentCanaryOptions struct {
+	Steps []DeploymentCanaryStep `json:"steps"`
+}
+
+func (o DeploymentCanaryOptions) Validate() error {
+	return jellidation.ValidateStruct(&o,
+		jellidation.Field(&o.Steps, jellidation.By(func(value any) error {
+			steps, ok := value.([]DeploymentCanaryStep)
+			if !ok {
+				return fmt.Errorf("%T is not supported, []DeploymentCanaryStep is expected", value)
+			}
+
+			if !slices.IsSortedFunc(steps, func(s1, s2 DeploymentCanaryStep) int {
+				return int(s1.InstanceWeight - s2.InstanceWeight)
+			}) {
+				return jellidation.NewError("validation_canary_steps_order", "instance weights must be sorted in ascending order")
+			}
+
+			return nil
+		})),
+	)
+}
+
+type DeploymentCanaryStep struct {
+	InstanceWeight int32 `json:"instance_weight"`
+}
+
+func (s DeploymentCanaryStep) Validate() error {
+	return jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.InstanceWeight, jellidation.Required, jellidation.Min(1), jellidation.Max(100)),
+	)
 }
 
 type DeploymentRelationships struct {
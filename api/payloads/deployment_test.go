@@ -3,6 +3,7 @@ package payloads_test
 import (
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 	"github.com/onsi/gomega/gstruct"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -57,6 +58,107 @@ var _ = Describe("DeploymentCreate", func() {
 			})
 		})
 
+		When("a revision is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = &payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedDeploymentPayload).To(gstruct.PointTo(Equal(createDeployment)))
+			})
+
+			When("the revision guid is empty", func() {
+				BeforeEach(func() {
+					createDeployment.Revision.Guid = ""
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+				})
+			})
+
+			When("a droplet is specified as well", func() {
+				BeforeEach(func() {
+					createDeployment.Droplet = payloads.DropletGUID{Guid: "the-droplet"}
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError(validatorErr, "cannot set both droplet and revision")
+				})
+			})
+		})
+
+		When("a canary strategy with options is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Strategy = "canary"
+				createDeployment.Options = &payloads.DeploymentOptions{
+					MaxInFlight: tools.PtrTo[int32](2),
+					Canary: &payloads.DeploymentCanaryOptions{
+						Steps: []payloads.DeploymentCanaryStep{{InstanceWeight: 20}, {InstanceWeight: 80}},
+					},
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedDeploymentPayload).To(gstruct.PointTo(Equal(createDeployment)))
+			})
+
+			When("the canary steps are not in ascending order", func() {
+				BeforeEach(func() {
+					createDeployment.Options.Canary.Steps = []payloads.DeploymentCanaryStep{{InstanceWeight: 80}, {InstanceWeight: 20}}
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError(validatorErr, "instance weights must be sorted in ascending order")
+				})
+			})
+
+			When("a canary step instance weight is out of range", func() {
+				BeforeEach(func() {
+					createDeployment.Options.Canary.Steps = []payloads.DeploymentCanaryStep{{InstanceWeight: 101}}
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError(validatorErr, "instance_weight must be no greater than 100")
+				})
+			})
+
+			When("the strategy is rolling", func() {
+				BeforeEach(func() {
+					createDeployment.Strategy = "rolling"
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError(validatorErr, "canary options are only valid for canary deployments")
+				})
+			})
+		})
+
+		When("the strategy is invalid", func() {
+			BeforeEach(func() {
+				createDeployment.Strategy = "blue-green"
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "strategy value must be one of: rolling, canary")
+			})
+		})
+
+		When("max_in_flight is less than 1", func() {
+			BeforeEach(func() {
+				createDeployment.Options = &payloads.DeploymentOptions{
+					MaxInFlight: tools.PtrTo[int32](-1),
+				}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "max_in_flight must be no less than 1")
+			})
+		})
+
 		When("the relationship is not specified", func() {
 			BeforeEach(func() {
 				createDeployment.Relationships = nil
@@ -101,6 +203,42 @@ var _ = Describe("DeploymentCreate", func() {
 				DropletGUID: "the-droplet",
 			}))
 		})
+
+		When("a revision is specified", func() {
+			BeforeEach(func() {
+				createDeployment.Droplet = payloads.DropletGUID{}
+				createDeployment.Revision = &payloads.RevisionGUID{Guid: "the-revision"}
+			})
+
+			It("sets it on the message", func() {
+				Expect(createMessage).To(Equal(repositories.CreateDeploymentMessage{
+					AppGUID:      "the-app",
+					RevisionGUID: "the-revision",
+				}))
+			})
+		})
+
+		When("a strategy and options are specified", func() {
+			BeforeEach(func() {
+				createDeployment.Strategy = "canary"
+				createDeployment.Options = &payloads.DeploymentOptions{
+					MaxInFlight: tools.PtrTo[int32](2),
+					Canary: &payloads.DeploymentCanaryOptions{
+						Steps: []payloads.DeploymentCanaryStep{{InstanceWeight: 20}},
+					},
+				}
+			})
+
+			It("sets them on the message", func() {
+				Expect(createMessage).To(Equal(repositories.CreateDeploymentMessage{
+					AppGUID:     "the-app",
+					DropletGUID: "the-droplet",
+					Strategy:    "canary",
+					MaxInFlight: tools.PtrTo[int32](2),
+					CanarySteps: []repositories.DeploymentCanaryStep{{InstanceWeight: 20}},
+				}))
+			})
+		})
 	})
 })
 
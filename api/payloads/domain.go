@@ -11,10 +11,35 @@ import (
 )
 
 type DomainCreate struct {
-	Name          string                  `json:"name"`
-	Internal      bool                    `json:"internal"`
-	Metadata      Metadata                `json:"metadata"`
-	Relationships map[string]Relationship `json:"relationships"`
+	Name          string               `json:"name"`
+	Internal      bool                 `json:"internal"`
+	Metadata      Metadata             `json:"metadata"`
+	Relationships *DomainRelationships `json:"relationships"`
+	RouterGroup   *DomainRouterGroup   `json:"router_group"`
+}
+
+type DomainRelationships struct {
+	Organization        *Relationship       `json:"organization"`
+	SharedOrganizations *ToManyRelationship `json:"shared_organizations"`
+}
+
+func (r DomainRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Organization),
+		jellidation.Field(&r.SharedOrganizations,
+			jellidation.When(r.Organization == nil, jellidation.Nil.Error("cannot be set without an organization")),
+		),
+	)
+}
+
+type DomainRouterGroup struct {
+	GUID string `json:"guid"`
+}
+
+func (g DomainRouterGroup) Validate() error {
+	return jellidation.ValidateStruct(&g,
+		jellidation.Field(&g.GUID, validation.StrictlyRequired),
+	)
 }
 
 func (c DomainCreate) Validate() error {
@@ -22,25 +47,49 @@ func (c DomainCreate) Validate() error {
 		jellidation.Field(&c.Name, validation.StrictlyRequired),
 		jellidation.Field(&c.Metadata),
 		jellidation.Field(&c.Relationships),
+		jellidation.Field(&c.RouterGroup),
 	)
 }
 
 func (c *DomainCreate) ToMessage() (repositories.CreateDomainMessage, error) {
-	if c.Internal {
-		return repositories.CreateDomainMessage{}, errors.New("internal domains are not supported")
+	if c.Internal && c.RouterGroup != nil {
+		return repositories.CreateDomainMessage{}, errors.New("internal domains cannot have a router group")
 	}
 
-	if len(c.Relationships) > 0 {
-		return repositories.CreateDomainMessage{}, errors.New("private domains are not supported")
-	}
-
-	return repositories.CreateDomainMessage{
-		Name: c.Name,
+	message := repositories.CreateDomainMessage{
+		Name:     c.Name,
+		Internal: c.Internal,
 		Metadata: repositories.Metadata{
 			Labels:      c.Metadata.Labels,
 			Annotations: c.Metadata.Annotations,
 		},
-	}, nil
+	}
+	if c.RouterGroup != nil {
+		message.RouterGroup = c.RouterGroup.GUID
+	}
+	if c.Relationships != nil && c.Relationships.Organization != nil {
+		message.OrgGUID = c.Relationships.Organization.Data.GUID
+	}
+	if c.Relationships != nil && c.Relationships.SharedOrganizations != nil {
+		message.SharedOrgGUIDs = relationshipGUIDs(*c.Relationships.SharedOrganizations)
+	}
+
+	return message, nil
+}
+
+type DomainShare ToManyRelationship
+
+func (s DomainShare) Validate() error {
+	return jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.Data, jellidation.Required),
+	)
+}
+
+func (s DomainShare) ToMessage(domainGUID string) repositories.ShareDomainMessage {
+	return repositories.ShareDomainMessage{
+		DomainGUID: domainGUID,
+		OrgGUIDs:   relationshipGUIDs(ToManyRelationship(s)),
+	}
 }
 
 type DomainUpdate struct {
@@ -69,6 +118,14 @@ type DomainList struct {
 	Pagination Pagination
 }
 
+// ToVisibleToOrgMessage lists the domains that the spaces of the given org
+// can create routes on
+func (d *DomainList) ToVisibleToOrgMessage(orgGUID string) repositories.ListDomainsMessage {
+	message := d.ToMessage()
+	message.VisibleToOrgGUID = orgGUID
+	return message
+}
+
 func (d *DomainList) ToMessage() repositories.ListDomainsMessage {
 	return repositories.ListDomainsMessage{
 		Names:      parse.ArrayParam(d.Names),
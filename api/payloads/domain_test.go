@@ -68,8 +68,8 @@ var _ = Describe("DomainCreate", func() {
 
 		When("relationship is invalid", func() {
 			BeforeEach(func() {
-				createPayload.Relationships = map[string]payloads.Relationship{
-					"foo": {Data: nil},
+				createPayload.Relationships = &payloads.DomainRelationships{
+					Organization: &payloads.Relationship{Data: nil},
 				}
 			})
 
@@ -77,6 +77,46 @@ var _ = Describe("DomainCreate", func() {
 				expectUnprocessableEntityError(validatorErr, "data is required")
 			})
 		})
+
+		When("the domain has an owning org and shared orgs", func() {
+			BeforeEach(func() {
+				createPayload.Relationships = &payloads.DomainRelationships{
+					Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+					SharedOrganizations: &payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "shared-org-guid"}},
+					},
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedDomainPayload).To(gstruct.PointTo(Equal(createPayload)))
+			})
+		})
+
+		When("the domain has shared orgs but no owning org", func() {
+			BeforeEach(func() {
+				createPayload.Relationships = &payloads.DomainRelationships{
+					SharedOrganizations: &payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "shared-org-guid"}},
+					},
+				}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "shared_organizations cannot be set without an organization")
+			})
+		})
+
+		When("the router group guid is empty", func() {
+			BeforeEach(func() {
+				createPayload.RouterGroup = &payloads.DomainRouterGroup{}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "router_group.guid cannot be blank")
+			})
+		})
 	})
 
 	Describe("ToMessage", func() {
@@ -116,25 +156,93 @@ var _ = Describe("DomainCreate", func() {
 				createPayload.Internal = true
 			})
 
-			It("errors", func() {
-				Expect(err).To(MatchError(ContainSubstring("internal domains are not supported")))
+			It("sets internal on the message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.Internal).To(BeTrue())
+			})
+
+			When("the payload has a router group", func() {
+				BeforeEach(func() {
+					createPayload.RouterGroup = &payloads.DomainRouterGroup{GUID: "default-tcp"}
+				})
+
+				It("errors", func() {
+					Expect(err).To(MatchError(ContainSubstring("internal domains cannot have a router group")))
+				})
 			})
 		})
 
-		When("the payload has relationships", func() {
+		When("the payload has an owning org and shared orgs", func() {
 			BeforeEach(func() {
-				createPayload.Relationships = map[string]payloads.Relationship{
-					"foo": {},
+				createPayload.Relationships = &payloads.DomainRelationships{
+					Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+					SharedOrganizations: &payloads.ToManyRelationship{
+						Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+					},
 				}
 			})
 
-			It("errors", func() {
-				Expect(err).To(MatchError(ContainSubstring("private domains are not supported")))
+			It("sets the orgs on the message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.OrgGUID).To(Equal("org-guid"))
+				Expect(createMessage.SharedOrgGUIDs).To(Equal([]string{"org-1", "org-2"}))
+			})
+		})
+
+		When("the payload has a router group", func() {
+			BeforeEach(func() {
+				createPayload.RouterGroup = &payloads.DomainRouterGroup{GUID: "default-tcp"}
+			})
+
+			It("sets the router group on the message", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(createMessage.RouterGroup).To(Equal("default-tcp"))
 			})
 		})
 	})
 })
 
+var _ = Describe("DomainShare", func() {
+	var (
+		sharePayload        payloads.DomainShare
+		decodedSharePayload *payloads.DomainShare
+		validatorErr        error
+	)
+
+	BeforeEach(func() {
+		decodedSharePayload = new(payloads.DomainShare)
+		sharePayload = payloads.DomainShare{
+			Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(sharePayload), decodedSharePayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedSharePayload).To(gstruct.PointTo(Equal(sharePayload)))
+	})
+
+	It("converts to a share message", func() {
+		Expect(decodedSharePayload.ToMessage("domain-guid")).To(Equal(repositories.ShareDomainMessage{
+			DomainGUID: "domain-guid",
+			OrgGUIDs:   []string{"org-1", "org-2"},
+		}))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			sharePayload.Data = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+})
+
 var _ = Describe("DomainUpdate", func() {
 	var (
 		updatePayload        payloads.DomainUpdate
@@ -229,4 +337,15 @@ var _ = Describe("DomainList", func() {
 			}))
 		})
 	})
+
+	Describe("ToVisibleToOrgMessage", func() {
+		It("translates to a repo message listing the domains visible to the org", func() {
+			domainList := payloads.DomainList{Names: "foo"}
+			Expect(domainList.ToVisibleToOrgMessage("org-guid")).To(Equal(repositories.ListDomainsMessage{
+				Names:            []string{"foo"},
+				VisibleToOrgGUID: "org-guid",
+				Pagination:       repositories.Pagination{Page: 1, PerPage: 50},
+			}))
+		})
+	})
 })
@@ -0,0 +1,17 @@
+package payloads
+
+import (
+	"github.com/jellydator/validation"
+)
+
+// FeatureUpdate is the payload used to enable or disable an app or a space
+// feature
+type FeatureUpdate struct {
+	Enabled *bool `json:"enabled"`
+}
+
+func (u FeatureUpdate) Validate() error {
+	return validation.ValidateStruct(&u,
+		validation.Field(&u.Enabled, validation.NotNil),
+	)
+}
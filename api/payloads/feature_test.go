@@ -0,0 +1,44 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("FeatureUpdate", func() {
+	var (
+		updatePayload payloads.FeatureUpdate
+		featureUpdate *payloads.FeatureUpdate
+		validatorErr  error
+	)
+
+	BeforeEach(func() {
+		featureUpdate = new(payloads.FeatureUpdate)
+		updatePayload = payloads.FeatureUpdate{
+			Enabled: tools.PtrTo(true),
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), featureUpdate)
+	})
+
+	It("succeeds with valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(featureUpdate).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("enabled is not set", func() {
+		BeforeEach(func() {
+			updatePayload.Enabled = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "enabled is required")
+		})
+	})
+})
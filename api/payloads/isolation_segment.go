@@ -0,0 +1,97 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/jellydator/validation"
+)
+
+type IsolationSegmentCreate struct {
+	Name     string   `json:"name"`
+	Metadata Metadata `json:"metadata"`
+}
+
+func (c IsolationSegmentCreate) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Name, validation.Required),
+		validation.Field(&c.Metadata),
+	)
+}
+
+func (c IsolationSegmentCreate) ToMessage() repositories.CreateIsolationSegmentMessage {
+	return repositories.CreateIsolationSegmentMessage{
+		Name:        c.Name,
+		Labels:      c.Metadata.Labels,
+		Annotations: c.Metadata.Annotations,
+	}
+}
+
+type IsolationSegmentList struct {
+	GUIDs      string
+	Names      string
+	OrgGUIDs   string
+	Pagination Pagination
+}
+
+func (l *IsolationSegmentList) ToMessage() repositories.ListIsolationSegmentsMessage {
+	return repositories.ListIsolationSegmentsMessage{
+		GUIDs:      parse.ArrayParam(l.GUIDs),
+		Names:      parse.ArrayParam(l.Names),
+		OrgGUIDs:   parse.ArrayParam(l.OrgGUIDs),
+		Pagination: l.Pagination.ToMessage(DefaultPageSize),
+	}
+}
+
+func (l *IsolationSegmentList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "per_page", "page"}
+}
+
+func (l *IsolationSegmentList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrgGUIDs = values.Get("organization_guids")
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func (l IsolationSegmentList) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.Pagination),
+	)
+}
+
+type IsolationSegmentEntitle ToManyRelationship
+
+func (e IsolationSegmentEntitle) Validate() error {
+	return validation.ValidateStruct(&e,
+		validation.Field(&e.Data, validation.Required),
+	)
+}
+
+func (e IsolationSegmentEntitle) ToMessage(guid string) repositories.EntitleIsolationSegmentMessage {
+	return repositories.EntitleIsolationSegmentMessage{
+		GUID:     guid,
+		OrgGUIDs: relationshipGUIDs(ToManyRelationship(e)),
+	}
+}
+
+// IsolationSegmentAssign is the payload used to assign an isolation segment
+// to an org (as its default) or to a space. A null data unassigns it.
+type IsolationSegmentAssign struct {
+	Data *RelationshipData `json:"data"`
+}
+
+func (a IsolationSegmentAssign) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.Data),
+	)
+}
+
+func (a IsolationSegmentAssign) GUID() string {
+	if a.Data == nil {
+		return ""
+	}
+
+	return a.Data.GUID
+}
@@ -0,0 +1,190 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("IsolationSegmentCreate", func() {
+	var (
+		createPayload          payloads.IsolationSegmentCreate
+		isolationSegmentCreate *payloads.IsolationSegmentCreate
+		validatorErr           error
+	)
+
+	BeforeEach(func() {
+		isolationSegmentCreate = new(payloads.IsolationSegmentCreate)
+		createPayload = payloads.IsolationSegmentCreate{
+			Name: "my-segment",
+			Metadata: payloads.Metadata{
+				Labels:      map[string]string{"foo": "bar"},
+				Annotations: map[string]string{"bar": "baz"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), isolationSegmentCreate)
+	})
+
+	It("succeeds with valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(isolationSegmentCreate).To(PointTo(Equal(createPayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the metadata uses the cloudfoundry domain", func() {
+		BeforeEach(func() {
+			createPayload.Metadata.Labels = map[string]string{"foo.cloudfoundry.org/bar": "baz"}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "cannot use the cloudfoundry.org domain")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateIsolationSegmentMessage{
+				Name:        "my-segment",
+				Labels:      map[string]string{"foo": "bar"},
+				Annotations: map[string]string{"bar": "baz"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentEntitle", func() {
+	var (
+		entitlePayload          payloads.IsolationSegmentEntitle
+		isolationSegmentEntitle *payloads.IsolationSegmentEntitle
+		validatorErr            error
+	)
+
+	BeforeEach(func() {
+		isolationSegmentEntitle = new(payloads.IsolationSegmentEntitle)
+		entitlePayload = payloads.IsolationSegmentEntitle{
+			Data: []payloads.RelationshipData{{GUID: "org1"}, {GUID: "org2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(entitlePayload), isolationSegmentEntitle)
+	})
+
+	It("succeeds with valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(isolationSegmentEntitle.ToMessage("segment-guid")).To(Equal(repositories.EntitleIsolationSegmentMessage{
+			GUID:     "segment-guid",
+			OrgGUIDs: []string{"org1", "org2"},
+		}))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			entitlePayload.Data = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentAssign", func() {
+	var (
+		assignPayload          payloads.IsolationSegmentAssign
+		isolationSegmentAssign *payloads.IsolationSegmentAssign
+		validatorErr           error
+	)
+
+	BeforeEach(func() {
+		isolationSegmentAssign = new(payloads.IsolationSegmentAssign)
+		assignPayload = payloads.IsolationSegmentAssign{
+			Data: &payloads.RelationshipData{GUID: "segment-guid"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(assignPayload), isolationSegmentAssign)
+	})
+
+	It("succeeds with valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(isolationSegmentAssign.GUID()).To(Equal("segment-guid"))
+	})
+
+	When("data is null", func() {
+		BeforeEach(func() {
+			assignPayload.Data = nil
+		})
+
+		It("succeeds with an empty guid", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(isolationSegmentAssign.GUID()).To(BeEmpty())
+		})
+	})
+
+	When("the guid is empty", func() {
+		BeforeEach(func() {
+			assignPayload.Data = &payloads.RelationshipData{}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "data.guid cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedList payloads.IsolationSegmentList) {
+			actualList, decodeErr := decodeQuery[payloads.IsolationSegmentList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualList).To(Equal(expectedList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.IsolationSegmentList{GUIDs: "g1,g2"}),
+		Entry("names", "names=n1,n2", payloads.IsolationSegmentList{Names: "n1,n2"}),
+		Entry("organization_guids", "organization_guids=o1,o2", payloads.IsolationSegmentList{OrgGUIDs: "o1,o2"}),
+		Entry("page=3", "page=3", payloads.IsolationSegmentList{Pagination: payloads.Pagination{Page: "3"}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.IsolationSegmentList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unsupported key", "foo=bar", "unsupported query parameter"),
+		Entry("per_page is not a number", "per_page=foo", "value must be an integer"),
+	)
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			list := payloads.IsolationSegmentList{
+				GUIDs:      "g1,g2",
+				Names:      "n1",
+				OrgGUIDs:   "o1",
+				Pagination: payloads.Pagination{PerPage: "10", Page: "2"},
+			}
+			Expect(list.ToMessage()).To(Equal(repositories.ListIsolationSegmentsMessage{
+				GUIDs:      []string{"g1", "g2"},
+				Names:      []string{"n1"},
+				OrgGUIDs:   []string{"o1"},
+				Pagination: repositories.Pagination{PerPage: 10, Page: 2},
+			}))
+		})
+	})
+})
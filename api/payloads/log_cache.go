@@ -10,6 +10,7 @@ import (
 
 type LogCacheRead struct {
 	StartTime     *int64
+	EndTime       *int64
 	EnvelopeTypes []string
 	Limit         *int64
 	Descending    bool
@@ -32,6 +33,9 @@ func (l *LogCacheRead) DecodeFromURLValues(values url.Values) error {
 	if l.StartTime, err = getIntPtr(values, "start_time"); err != nil {
 		return err
 	}
+	if l.EndTime, err = getIntPtr(values, "end_time"); err != nil {
+		return err
+	}
 	l.EnvelopeTypes = values["envelope_types"]
 	if l.Limit, err = getIntPtr(values, "limit"); err != nil {
 		return err
@@ -61,3 +65,25 @@ func getBool(values url.Values, key string) (bool, error) {
 	}
 	return strconv.ParseBool(s)
 }
+
+// LogStreamRead is the query of the loggregator RLP gateway style stream
+// endpoint. Only log envelopes are supported, the remaining envelope type
+// selectors are accepted for compatibility with existing clients.
+type LogStreamRead struct {
+	SourceID string `json:"source_id"`
+}
+
+func (l LogStreamRead) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.SourceID, jellidation.Required),
+	)
+}
+
+func (l *LogStreamRead) SupportedKeys() []string {
+	return []string{"source_id", "log", "counter", "gauge", "timer", "event", "shard_id", "deterministic_name"}
+}
+
+func (l *LogStreamRead) DecodeFromURLValues(values url.Values) error {
+	l.SourceID = values.Get("source_id")
+	return nil
+}
@@ -19,6 +19,9 @@ var _ = Describe("LogCacheRead", func() {
 			Entry("start_time", "start_time=123", payloads.LogCacheRead{
 				StartTime: tools.PtrTo[int64](123),
 			}),
+			Entry("end_time", "end_time=456", payloads.LogCacheRead{
+				EndTime: tools.PtrTo[int64](456),
+			}),
 			Entry("envelope type LOG", "envelope_types=LOG", payloads.LogCacheRead{
 				EnvelopeTypes: []string{"LOG"},
 			}),
@@ -40,9 +43,38 @@ var _ = Describe("LogCacheRead", func() {
 				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
 			},
 			Entry("start_time", "start_time=foo", "invalid syntax"),
+			Entry("end_time", "end_time=foo", "invalid syntax"),
 			Entry("limit", "limit=foo", "invalid syntax"),
 			Entry("descending", "descending=foo", "invalid syntax"),
 			Entry("envelope type", "envelope_types=foo", "value must be one of"),
 		)
 	})
 })
+
+var _ = Describe("LogStreamRead", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedLogStreamRead payloads.LogStreamRead) {
+				actualLogStreamRead, decodeErr := decodeQuery[payloads.LogStreamRead](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualLogStreamRead).To(Equal(expectedLogStreamRead))
+			},
+			Entry("source_id", "source_id=app-guid", payloads.LogStreamRead{
+				SourceID: "app-guid",
+			}),
+			Entry("envelope type selectors", "source_id=app-guid&log&gauge&shard_id=shard", payloads.LogStreamRead{
+				SourceID: "app-guid",
+			}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.LogStreamRead](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("source_id missing", "log", "source_id cannot be blank"),
+			Entry("unsupported key", "source_id=app-guid&foo=bar", "unsupported query parameter"),
+		)
+	})
+})
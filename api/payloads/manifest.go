@@ -33,18 +33,25 @@ type ManifestApplication struct {
 	// Do not set both DiskQuota and AltDiskQuota.
 	//
 	// Deprecated: Use DiskQuota instead
-	AltDiskQuota                 *string                      `json:"disk-quota,omitempty" yaml:"disk-quota,omitempty"`
-	HealthCheckHTTPEndpoint      *string                      `yaml:"health-check-http-endpoint,omitempty"`
-	HealthCheckInvocationTimeout *int32                       `json:"health-check-invocation-timeout,omitempty" yaml:"health-check-invocation-timeout,omitempty"`
-	HealthCheckType              *string                      `json:"health-check-type,omitempty" yaml:"health-check-type,omitempty"`
-	Timeout                      *int32                       `json:"timeout,omitempty" yaml:"timeout,omitempty"`
-	Processes                    []ManifestApplicationProcess `json:"processes,omitempty" yaml:"processes,omitempty"`
-	Routes                       []ManifestRoute              `json:"routes,omitempty" yaml:"routes,omitempty"`
-	Buildpacks                   []string                     `json:"buildpacks,omitempty" yaml:"buildpacks,omitempty"`
+	AltDiskQuota                          *string                      `json:"disk-quota,omitempty" yaml:"disk-quota,omitempty"`
+	HealthCheckHTTPEndpoint               *string                      `yaml:"health-check-http-endpoint,omitempty"`
+	HealthCheckInvocationTimeout          *int32                       `json:"health-check-invocation-timeout,omitempty" yaml:"health-check-invocation-timeout,omitempty"`
+	HealthCheckType                       *string                      `json:"health-check-type,omitempty" yaml:"health-check-type,omitempty"`
+	Timeout                               *int32                       `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	HealthCheckInterval                   *int32                       `json:"health-check-interval,omitempty" yaml:"health-check-interval,omitempty"`
+	HealthCheckFailureThreshold           *int32                       `json:"health-check-failure-threshold,omitempty" yaml:"health-check-failure-threshold,omitempty"`
+	ReadinessHealthCheckHTTPEndpoint      *string                      `json:"readiness-health-check-http-endpoint,omitempty" yaml:"readiness-health-check-http-endpoint,omitempty"`
+	ReadinessHealthCheckInvocationTimeout *int32                       `json:"readiness-health-check-invocation-timeout,omitempty" yaml:"readiness-health-check-invocation-timeout,omitempty"`
+	ReadinessHealthCheckInterval          *int32                       `json:"readiness-health-check-interval,omitempty" yaml:"readiness-health-check-interval,omitempty"`
+	ReadinessHealthCheckType              *string                      `json:"readiness-health-check-type,omitempty" yaml:"readiness-health-check-type,omitempty"`
+	Processes                             []ManifestApplicationProcess `json:"processes,omitempty" yaml:"processes,omitempty"`
+	Routes                                []ManifestRoute              `json:"routes,omitempty" yaml:"routes,omitempty"`
+	Buildpacks                            []string                     `json:"buildpacks,omitempty" yaml:"buildpacks,omitempty"`
 	// Deprecated: Use Buildpacks instead
 	Buildpack *string                      `json:"buildpack,omitempty" yaml:"buildpack,omitempty"`
 	Metadata  MetadataPatch                `json:"metadata" yaml:"metadata"`
 	Services  []ManifestApplicationService `json:"services,omitempty" yaml:"services,omitempty"`
+	Sidecars  []ManifestApplicationSidecar `json:"sidecars,omitempty" yaml:"sidecars,omitempty"`
 	Docker    any                          `json:"docker,omitempty" yaml:"docker,omitempty"`
 }
 
@@ -58,13 +65,19 @@ type ManifestApplicationProcess struct {
 	// Do not set both DiskQuota and AltDiskQuota.
 	//
 	// Deprecated: Use DiskQuota instead
-	AltDiskQuota                 *string `json:"disk-quota,omitempty" yaml:"disk-quota,omitempty"`
-	HealthCheckHTTPEndpoint      *string `yaml:"health-check-http-endpoint,omitempty"`
-	HealthCheckInvocationTimeout *int32  `json:"health-check-invocation-timeout,omitempty" yaml:"health-check-invocation-timeout,omitempty"`
-	HealthCheckType              *string `json:"health-check-type,omitempty" yaml:"health-check-type,omitempty"`
-	Instances                    *int32  `json:"instances,omitempty" yaml:"instances,omitempty"`
-	Memory                       *string `json:"memory,omitempty" yaml:"memory,omitempty"`
-	Timeout                      *int32  `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	AltDiskQuota                          *string `json:"disk-quota,omitempty" yaml:"disk-quota,omitempty"`
+	HealthCheckHTTPEndpoint               *string `yaml:"health-check-http-endpoint,omitempty"`
+	HealthCheckInvocationTimeout          *int32  `json:"health-check-invocation-timeout,omitempty" yaml:"health-check-invocation-timeout,omitempty"`
+	HealthCheckType                       *string `json:"health-check-type,omitempty" yaml:"health-check-type,omitempty"`
+	Instances                             *int32  `json:"instances,omitempty" yaml:"instances,omitempty"`
+	Memory                                *string `json:"memory,omitempty" yaml:"memory,omitempty"`
+	Timeout                               *int32  `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	HealthCheckInterval                   *int32  `json:"health-check-interval,omitempty" yaml:"health-check-interval,omitempty"`
+	HealthCheckFailureThreshold           *int32  `json:"health-check-failure-threshold,omitempty" yaml:"health-check-failure-threshold,omitempty"`
+	ReadinessHealthCheckHTTPEndpoint      *string `json:"readiness-health-check-http-endpoint,omitempty" yaml:"readiness-health-check-http-endpoint,omitempty"`
+	ReadinessHealthCheckInvocationTimeout *int32  `json:"readiness-health-check-invocation-timeout,omitempty" yaml:"readiness-health-check-invocation-timeout,omitempty"`
+	ReadinessHealthCheckInterval          *int32  `json:"readiness-health-check-interval,omitempty" yaml:"readiness-health-check-interval,omitempty"`
+	ReadinessHealthCheckType              *string `json:"readiness-health-check-type,omitempty" yaml:"readiness-health-check-type,omitempty"`
 }
 
 type ManifestApplicationService struct {
@@ -88,6 +101,13 @@ func (s *ManifestApplicationService) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+type ManifestApplicationSidecar struct {
+	Name         string   `json:"name" yaml:"name"`
+	ProcessTypes []string `json:"process_types" yaml:"process_types"`
+	Command      string   `json:"command" yaml:"command"`
+	Memory       *string  `json:"memory,omitempty" yaml:"memory,omitempty"`
+}
+
 type ManifestRoute struct {
 	Route *string `json:"route" yaml:"route"`
 }
@@ -164,12 +184,30 @@ func (p ManifestApplicationProcess) ToProcessCreateMessage(appGUID, spaceGUID st
 	if p.Timeout != nil {
 		msg.HealthCheck.Data.TimeoutSeconds = *p.Timeout
 	}
+	if p.HealthCheckInterval != nil {
+		msg.HealthCheck.Data.IntervalSeconds = *p.HealthCheckInterval
+	}
+	if p.HealthCheckFailureThreshold != nil {
+		msg.HealthCheck.Data.FailureThreshold = *p.HealthCheckFailureThreshold
+	}
 	if p.HealthCheckType != nil {
 		msg.HealthCheck.Type = *p.HealthCheckType
 		if msg.HealthCheck.Type == "none" {
 			msg.HealthCheck.Type = "process"
 		}
 	}
+	if p.ReadinessHealthCheckHTTPEndpoint != nil {
+		msg.ReadinessHealthCheck.Data.HTTPEndpoint = *p.ReadinessHealthCheckHTTPEndpoint
+	}
+	if p.ReadinessHealthCheckInvocationTimeout != nil {
+		msg.ReadinessHealthCheck.Data.InvocationTimeoutSeconds = *p.ReadinessHealthCheckInvocationTimeout
+	}
+	if p.ReadinessHealthCheckInterval != nil {
+		msg.ReadinessHealthCheck.Data.IntervalSeconds = *p.ReadinessHealthCheckInterval
+	}
+	if p.ReadinessHealthCheckType != nil {
+		msg.ReadinessHealthCheck.Type = *p.ReadinessHealthCheckType
+	}
 	msg.DesiredInstances = p.Instances
 
 	if p.Memory != nil {
@@ -185,13 +223,19 @@ func (p ManifestApplicationProcess) ToProcessCreateMessage(appGUID, spaceGUID st
 
 func (p ManifestApplicationProcess) ToProcessPatchMessage(processGUID, spaceGUID string) repositories.PatchProcessMessage {
 	message := repositories.PatchProcessMessage{
-		ProcessGUID:                         processGUID,
-		SpaceGUID:                           spaceGUID,
-		Command:                             p.Command,
-		HealthCheckHTTPEndpoint:             p.HealthCheckHTTPEndpoint,
-		HealthCheckInvocationTimeoutSeconds: p.HealthCheckInvocationTimeout,
-		HealthCheckTimeoutSeconds:           p.Timeout,
-		DesiredInstances:                    p.Instances,
+		ProcessGUID:                                  processGUID,
+		SpaceGUID:                                    spaceGUID,
+		Command:                                      p.Command,
+		HealthCheckHTTPEndpoint:                      p.HealthCheckHTTPEndpoint,
+		HealthCheckInvocationTimeoutSeconds:          p.HealthCheckInvocationTimeout,
+		HealthCheckTimeoutSeconds:                    p.Timeout,
+		HealthCheckIntervalSeconds:                   p.HealthCheckInterval,
+		HealthCheckFailureThreshold:                  p.HealthCheckFailureThreshold,
+		ReadinessHealthCheckHTTPEndpoint:             p.ReadinessHealthCheckHTTPEndpoint,
+		ReadinessHealthCheckInvocationTimeoutSeconds: p.ReadinessHealthCheckInvocationTimeout,
+		ReadinessHealthCheckIntervalSeconds:          p.ReadinessHealthCheckInterval,
+		ReadinessHealthCheckType:                     p.ReadinessHealthCheckType,
+		DesiredInstances:                             p.Instances,
 	}
 	if p.HealthCheckType != nil {
 		message.HealthCheckType = p.HealthCheckType
@@ -208,6 +252,32 @@ func (p ManifestApplicationProcess) ToProcessPatchMessage(processGUID, spaceGUID
 	return message
 }
 
+func (s ManifestApplicationSidecar) ToSidecarCreateMessage(appGUID, spaceGUID string) repositories.CreateSidecarMessage {
+	message := repositories.CreateSidecarMessage{
+		AppGUID:      appGUID,
+		SpaceGUID:    spaceGUID,
+		Name:         s.Name,
+		Command:      s.Command,
+		ProcessTypes: s.ProcessTypes,
+	}
+	if s.Memory != nil {
+		message.MemoryMB = parseMegabytes(*s.Memory)
+	}
+	return message
+}
+
+func (s ManifestApplicationSidecar) ToSidecarPatchMessage(sidecarGUID string) repositories.PatchSidecarMessage {
+	message := repositories.PatchSidecarMessage{
+		GUID:         sidecarGUID,
+		Command:      tools.PtrTo(s.Command),
+		ProcessTypes: s.ProcessTypes,
+	}
+	if s.Memory != nil {
+		message.MemoryMB = tools.PtrTo(parseMegabytes(*s.Memory))
+	}
+	return message
+}
+
 func (m Manifest) Validate() error {
 	return validation.ValidateStruct(&m,
 		validation.Field(&m.Applications))
@@ -224,8 +294,14 @@ func (a ManifestApplication) Validate() error {
 		validation.Field(&a.HealthCheckType, validation.In("none", "process", "port", "http")),
 		validation.Field(&a.Memory, validation.By(validateAmountWithUnit)),
 		validation.Field(&a.Timeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&a.HealthCheckInterval, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&a.HealthCheckFailureThreshold, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&a.ReadinessHealthCheckInvocationTimeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&a.ReadinessHealthCheckInterval, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&a.ReadinessHealthCheckType, validation.In("process", "port", "http")),
 		validation.Field(&a.Processes),
 		validation.Field(&a.Routes),
+		validation.Field(&a.Sidecars),
 		validation.Field(&a.Docker, validation.When(len(a.Buildpacks) > 0 || a.Buildpack != nil,
 			validation.Nil.Error("must be blank when buildpacks are specified"),
 		)),
@@ -242,6 +318,20 @@ func (p ManifestApplicationProcess) Validate() error {
 		validation.Field(&p.Instances, validation.Min(0)),
 		validation.Field(&p.Memory, validation.By(validateAmountWithUnit)),
 		validation.Field(&p.Timeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&p.HealthCheckInterval, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&p.HealthCheckFailureThreshold, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&p.ReadinessHealthCheckInvocationTimeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&p.ReadinessHealthCheckInterval, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&p.ReadinessHealthCheckType, validation.In("process", "port", "http")),
+	)
+}
+
+func (s ManifestApplicationSidecar) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Name, validation.Required),
+		validation.Field(&s.ProcessTypes, validation.Required, validation.Each(validation.Required)),
+		validation.Field(&s.Command, validation.Required),
+		validation.Field(&s.Memory, validation.By(validateAmountWithUnit)),
 	)
 }
 
@@ -184,6 +184,56 @@ var _ = Describe("Manifest payload", func() {
 				})
 			})
 
+			When("HealthCheckInterval is not positive", func() {
+				BeforeEach(func() {
+					testManifest.HealthCheckInterval = tools.PtrTo(int32(0))
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "health-check-interval must be no less than 1")
+				})
+			})
+
+			When("HealthCheckFailureThreshold is not positive", func() {
+				BeforeEach(func() {
+					testManifest.HealthCheckFailureThreshold = tools.PtrTo(int32(0))
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "health-check-failure-threshold must be no less than 1")
+				})
+			})
+
+			When("ReadinessHealthCheckInvocationTimeout is not positive", func() {
+				BeforeEach(func() {
+					testManifest.ReadinessHealthCheckInvocationTimeout = tools.PtrTo(int32(0))
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "readiness-health-check-invocation-timeout must be no less than 1")
+				})
+			})
+
+			When("ReadinessHealthCheckInterval is not positive", func() {
+				BeforeEach(func() {
+					testManifest.ReadinessHealthCheckInterval = tools.PtrTo(int32(0))
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "readiness-health-check-interval must be no less than 1")
+				})
+			})
+
+			When("ReadinessHealthCheckType is invalid", func() {
+				BeforeEach(func() {
+					testManifest.ReadinessHealthCheckType = tools.PtrTo("none")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "readiness-health-check-type must be a valid value")
+				})
+			})
+
 			When("Timeout is not positive", func() {
 				BeforeEach(func() {
 					testManifest.Timeout = tools.PtrTo(int32(0))
@@ -517,6 +567,56 @@ var _ = Describe("Manifest payload", func() {
 				})
 			})
 
+			When("HealthCheckInterval is not positive", func() {
+				BeforeEach(func() {
+					testManifestProcess.HealthCheckInterval = tools.PtrTo(int32(0))
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "health-check-interval must be no less than 1")
+				})
+			})
+
+			When("HealthCheckFailureThreshold is not positive", func() {
+				BeforeEach(func() {
+					testManifestProcess.HealthCheckFailureThreshold = tools.PtrTo(int32(0))
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "health-check-failure-threshold must be no less than 1")
+				})
+			})
+
+			When("ReadinessHealthCheckInvocationTimeout is not positive", func() {
+				BeforeEach(func() {
+					testManifestProcess.ReadinessHealthCheckInvocationTimeout = tools.PtrTo(int32(0))
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "readiness-health-check-invocation-timeout must be no less than 1")
+				})
+			})
+
+			When("ReadinessHealthCheckInterval is not positive", func() {
+				BeforeEach(func() {
+					testManifestProcess.ReadinessHealthCheckInterval = tools.PtrTo(int32(0))
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "readiness-health-check-interval must be no less than 1")
+				})
+			})
+
+			When("ReadinessHealthCheckType is invalid", func() {
+				BeforeEach(func() {
+					testManifestProcess.ReadinessHealthCheckType = tools.PtrTo("none")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "readiness-health-check-type must be a valid value")
+				})
+			})
+
 			When("Instances is negative", func() {
 				BeforeEach(func() {
 					testManifestProcess.Instances = tools.PtrTo[int32](-1)
@@ -575,15 +675,21 @@ var _ = Describe("Manifest payload", func() {
 			When("all fields are specified", func() {
 				BeforeEach(func() {
 					processInfo = ManifestApplicationProcess{
-						Type:                         "web",
-						Command:                      tools.PtrTo("start-web.sh"),
-						DiskQuota:                    tools.PtrTo("512M"),
-						HealthCheckHTTPEndpoint:      tools.PtrTo("/stuff"),
-						HealthCheckInvocationTimeout: tools.PtrTo(int32(90)),
-						HealthCheckType:              tools.PtrTo("http"),
-						Instances:                    tools.PtrTo[int32](3),
-						Memory:                       tools.PtrTo("1G"),
-						Timeout:                      tools.PtrTo(int32(60)),
+						Type:                                  "web",
+						Command:                               tools.PtrTo("start-web.sh"),
+						DiskQuota:                             tools.PtrTo("512M"),
+						HealthCheckHTTPEndpoint:               tools.PtrTo("/stuff"),
+						HealthCheckInvocationTimeout:          tools.PtrTo(int32(90)),
+						HealthCheckType:                       tools.PtrTo("http"),
+						Instances:                             tools.PtrTo[int32](3),
+						Memory:                                tools.PtrTo("1G"),
+						Timeout:                               tools.PtrTo(int32(60)),
+						HealthCheckInterval:                   tools.PtrTo(int32(15)),
+						HealthCheckFailureThreshold:           tools.PtrTo(int32(4)),
+						ReadinessHealthCheckHTTPEndpoint:      tools.PtrTo("/ready"),
+						ReadinessHealthCheckInvocationTimeout: tools.PtrTo(int32(2)),
+						ReadinessHealthCheckInterval:          tools.PtrTo(int32(5)),
+						ReadinessHealthCheckType:              tools.PtrTo("http"),
 					}
 				})
 
@@ -602,6 +708,16 @@ var _ = Describe("Manifest payload", func() {
 								HTTPEndpoint:             "/stuff",
 								TimeoutSeconds:           60,
 								InvocationTimeoutSeconds: 90,
+								IntervalSeconds:          15,
+								FailureThreshold:         4,
+							},
+						},
+						ReadinessHealthCheck: repositories.ReadinessHealthCheck{
+							Type: "http",
+							Data: repositories.ReadinessHealthCheckData{
+								HTTPEndpoint:             "/ready",
+								InvocationTimeoutSeconds: 2,
+								IntervalSeconds:          5,
 							},
 						},
 						DesiredInstances: tools.PtrTo[int32](3),
@@ -692,6 +808,36 @@ var _ = Describe("Manifest payload", func() {
 				})
 			})
 
+			When("the health check interval and failure threshold are specified", func() {
+				BeforeEach(func() {
+					processInfo.HealthCheckInterval = tools.PtrTo(int32(15))
+					processInfo.HealthCheckFailureThreshold = tools.PtrTo(int32(4))
+				})
+
+				It("returns a message with them set", func() {
+					message := processInfo.ToProcessPatchMessage(processGUID, spaceGUID)
+					Expect(message.HealthCheckIntervalSeconds).To(PointTo(BeEquivalentTo(15)))
+					Expect(message.HealthCheckFailureThreshold).To(PointTo(BeEquivalentTo(4)))
+				})
+			})
+
+			When("the readiness health check is specified", func() {
+				BeforeEach(func() {
+					processInfo.ReadinessHealthCheckHTTPEndpoint = tools.PtrTo("/ready")
+					processInfo.ReadinessHealthCheckInvocationTimeout = tools.PtrTo(int32(2))
+					processInfo.ReadinessHealthCheckInterval = tools.PtrTo(int32(5))
+					processInfo.ReadinessHealthCheckType = tools.PtrTo("http")
+				})
+
+				It("returns a message with the readiness health check set", func() {
+					message := processInfo.ToProcessPatchMessage(processGUID, spaceGUID)
+					Expect(message.ReadinessHealthCheckHTTPEndpoint).To(PointTo(Equal("/ready")))
+					Expect(message.ReadinessHealthCheckInvocationTimeoutSeconds).To(PointTo(BeEquivalentTo(2)))
+					Expect(message.ReadinessHealthCheckIntervalSeconds).To(PointTo(BeEquivalentTo(5)))
+					Expect(message.ReadinessHealthCheckType).To(PointTo(Equal("http")))
+				})
+			})
+
 			When("DiskQuota is specified", func() {
 				BeforeEach(func() {
 					processInfo.DiskQuota = tools.PtrTo("1G")
@@ -859,4 +1005,103 @@ var _ = Describe("Manifest payload", func() {
 			})
 		})
 	})
+
+	Describe("ManifestApplicationSidecar", func() {
+		var testManifestSidecar ManifestApplicationSidecar
+
+		BeforeEach(func() {
+			testManifestSidecar = ManifestApplicationSidecar{
+				Name:         "my-sidecar",
+				ProcessTypes: []string{"web", "worker"},
+				Command:      "bin/my-sidecar",
+				Memory:       tools.PtrTo("64M"),
+			}
+		})
+
+		Describe("Validate", func() {
+			var validateErr error
+
+			JustBeforeEach(func() {
+				validateErr = validator.DecodeAndValidateYAMLPayload(createYAMLRequest(testManifestSidecar), &ManifestApplicationSidecar{})
+			})
+
+			It("validates the struct", func() {
+				Expect(validateErr).NotTo(HaveOccurred())
+			})
+
+			When("name is not specified", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Name = ""
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "name cannot be blank")
+				})
+			})
+
+			When("process_types is not specified", func() {
+				BeforeEach(func() {
+					testManifestSidecar.ProcessTypes = nil
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "process_types cannot be blank")
+				})
+			})
+
+			When("command is not specified", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Command = ""
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "command cannot be blank")
+				})
+			})
+
+			When("memory is not a valid amount", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Memory = tools.PtrTo("lots")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "memory must use a supported unit")
+				})
+			})
+		})
+
+		Describe("ToSidecarCreateMessage", func() {
+			It("converts to a create message", func() {
+				Expect(testManifestSidecar.ToSidecarCreateMessage("app-guid", "space-guid")).To(Equal(repositories.CreateSidecarMessage{
+					AppGUID:      "app-guid",
+					SpaceGUID:    "space-guid",
+					Name:         "my-sidecar",
+					Command:      "bin/my-sidecar",
+					ProcessTypes: []string{"web", "worker"},
+					MemoryMB:     64,
+				}))
+			})
+		})
+
+		Describe("ToSidecarPatchMessage", func() {
+			It("converts to a patch message", func() {
+				Expect(testManifestSidecar.ToSidecarPatchMessage("sidecar-guid")).To(Equal(repositories.PatchSidecarMessage{
+					GUID:         "sidecar-guid",
+					Command:      tools.PtrTo("bin/my-sidecar"),
+					ProcessTypes: []string{"web", "worker"},
+					MemoryMB:     tools.PtrTo[int64](64),
+				}))
+			})
+
+			When("memory is not specified", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Memory = nil
+				})
+
+				It("does not patch the memory", func() {
+					Expect(testManifestSidecar.ToSidecarPatchMessage("sidecar-guid").MemoryMB).To(BeNil())
+				})
+			})
+		})
+	})
 })
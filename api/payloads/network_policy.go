@@ -0,0 +1,99 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type NetworkPolicies struct {
+	Policies []NetworkPolicy `json:"policies"`
+}
+
+func (p NetworkPolicies) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Policies, jellidation.Required),
+	)
+}
+
+type NetworkPolicy struct {
+	Source      NetworkPolicySource      `json:"source"`
+	Destination NetworkPolicyDestination `json:"destination"`
+}
+
+func (p NetworkPolicy) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Source),
+		jellidation.Field(&p.Destination),
+	)
+}
+
+func (p NetworkPolicy) ToMessage(sourceApp, destinationApp repositories.AppRecord) repositories.NetworkPolicyMessage {
+	return repositories.NetworkPolicyMessage{
+		SourceAppGUID:        sourceApp.GUID,
+		SourceSpaceGUID:      sourceApp.SpaceGUID,
+		DestinationAppGUID:   destinationApp.GUID,
+		DestinationSpaceGUID: destinationApp.SpaceGUID,
+		Protocol:             p.Destination.Protocol,
+		StartPort:            p.Destination.Ports.Start,
+		EndPort:              p.Destination.Ports.End,
+	}
+}
+
+type NetworkPolicySource struct {
+	ID string `json:"id"`
+}
+
+func (s NetworkPolicySource) Validate() error {
+	return jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.ID, jellidation.Required),
+	)
+}
+
+type NetworkPolicyDestination struct {
+	ID       string             `json:"id"`
+	Protocol string             `json:"protocol"`
+	Ports    NetworkPolicyPorts `json:"ports"`
+}
+
+func (d NetworkPolicyDestination) Validate() error {
+	return jellidation.ValidateStruct(&d,
+		jellidation.Field(&d.ID, jellidation.Required),
+		jellidation.Field(&d.Protocol, jellidation.Required, validation.OneOf("tcp", "udp")),
+		jellidation.Field(&d.Ports),
+	)
+}
+
+type NetworkPolicyPorts struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
+func (p NetworkPolicyPorts) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Start, jellidation.Required, jellidation.Min(int32(1)), jellidation.Max(int32(65535))),
+		jellidation.Field(&p.End, jellidation.Required, jellidation.Min(p.Start), jellidation.Max(int32(65535))),
+	)
+}
+
+type NetworkPolicyList struct {
+	IDs string
+}
+
+func (l *NetworkPolicyList) SupportedKeys() []string {
+	return []string{"id"}
+}
+
+func (l *NetworkPolicyList) DecodeFromURLValues(values url.Values) error {
+	l.IDs = values.Get("id")
+	return nil
+}
+
+func (l *NetworkPolicyList) ToMessage() repositories.ListNetworkPoliciesMessage {
+	return repositories.ListNetworkPoliciesMessage{
+		AppGUIDs: parse.ArrayParam(l.IDs),
+	}
+}
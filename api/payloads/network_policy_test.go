@@ -0,0 +1,151 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("NetworkPolicies", func() {
+	var payload payloads.NetworkPolicies
+
+	BeforeEach(func() {
+		payload = payloads.NetworkPolicies{
+			Policies: []payloads.NetworkPolicy{{
+				Source: payloads.NetworkPolicySource{ID: "source-app-guid"},
+				Destination: payloads.NetworkPolicyDestination{
+					ID:       "destination-app-guid",
+					Protocol: "tcp",
+					Ports:    payloads.NetworkPolicyPorts{Start: 8080, End: 8090},
+				},
+			}},
+		}
+	})
+
+	Describe("Validate", func() {
+		var (
+			decodedPayload *payloads.NetworkPolicies
+			validatorErr   error
+		)
+
+		JustBeforeEach(func() {
+			decodedPayload = new(payloads.NetworkPolicies)
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+
+		When("there are no policies", func() {
+			BeforeEach(func() {
+				payload.Policies = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "policies cannot be blank")
+			})
+		})
+
+		When("the source id is not set", func() {
+			BeforeEach(func() {
+				payload.Policies[0].Source.ID = ""
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "id cannot be blank")
+			})
+		})
+
+		When("the destination id is not set", func() {
+			BeforeEach(func() {
+				payload.Policies[0].Destination.ID = ""
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "id cannot be blank")
+			})
+		})
+
+		When("the protocol is not supported", func() {
+			BeforeEach(func() {
+				payload.Policies[0].Destination.Protocol = "icmp"
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "protocol value must be one of: tcp, udp")
+			})
+		})
+
+		When("the start port is out of range", func() {
+			BeforeEach(func() {
+				payload.Policies[0].Destination.Ports.Start = 70000
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "start must be no greater than 65535")
+			})
+		})
+
+		When("the end port is lower than the start port", func() {
+			BeforeEach(func() {
+				payload.Policies[0].Destination.Ports.End = 8000
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "end must be no less than 8080")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			Expect(payload.Policies[0].ToMessage(
+				repositories.AppRecord{GUID: "source-app-guid", SpaceGUID: "source-space-guid"},
+				repositories.AppRecord{GUID: "destination-app-guid", SpaceGUID: "destination-space-guid"},
+			)).To(Equal(repositories.NetworkPolicyMessage{
+				SourceAppGUID:        "source-app-guid",
+				SourceSpaceGUID:      "source-space-guid",
+				DestinationAppGUID:   "destination-app-guid",
+				DestinationSpaceGUID: "destination-space-guid",
+				Protocol:             "tcp",
+				StartPort:            8080,
+				EndPort:              8090,
+			}))
+		})
+	})
+})
+
+var _ = Describe("NetworkPolicyList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedPolicyList payloads.NetworkPolicyList) {
+			actualPolicyList, decodeErr := decodeQuery[payloads.NetworkPolicyList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualPolicyList).To(Equal(expectedPolicyList))
+		},
+		Entry("id", "id=g1,g2", payloads.NetworkPolicyList{IDs: "g1,g2"}),
+		Entry("no parameters", "", payloads.NetworkPolicyList{}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.NetworkPolicyList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+	)
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			policyList := payloads.NetworkPolicyList{IDs: "g1, g2"}
+			Expect(policyList.ToMessage()).To(Equal(repositories.ListNetworkPoliciesMessage{
+				AppGUIDs: []string{"g1", "g2"},
+			}))
+		})
+	})
+})
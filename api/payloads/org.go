@@ -31,8 +31,9 @@ func (p OrgCreate) ToMessage() repositories.CreateOrgMessage {
 }
 
 type OrgPatch struct {
-	Name     *string       `json:"name"`
-	Metadata MetadataPatch `json:"metadata"`
+	Name      *string       `json:"name"`
+	Suspended *bool         `json:"suspended"`
+	Metadata  MetadataPatch `json:"metadata"`
 }
 
 func (p OrgPatch) Validate() error {
@@ -44,8 +45,9 @@ func (p OrgPatch) Validate() error {
 
 func (p OrgPatch) ToMessage(orgGUID string) repositories.PatchOrgMessage {
 	return repositories.PatchOrgMessage{
-		GUID: orgGUID,
-		Name: p.Name,
+		GUID:      orgGUID,
+		Name:      p.Name,
+		Suspended: p.Suspended,
 		MetadataPatch: repositories.MetadataPatch{
 			Labels:      p.Metadata.Labels,
 			Annotations: p.Metadata.Annotations,
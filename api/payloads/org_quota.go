@@ -0,0 +1,211 @@
+package payloads
+
+import (
+	"net/url"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	jellidation "github.com/jellydator/validation"
+)
+
+// QuotaApps holds the app limits of a quota. A null limit means unlimited.
+// The log rate limit is accepted for compatibility but is not enforced.
+type QuotaApps struct {
+	TotalMemoryInMB              *int64 `json:"total_memory_in_mb"`
+	PerProcessMemoryInMB         *int64 `json:"per_process_memory_in_mb"`
+	TotalInstances               *int32 `json:"total_instances"`
+	PerAppTasks                  *int32 `json:"per_app_tasks"`
+	LogRateLimitInBytesPerSecond *int64 `json:"log_rate_limit_in_bytes_per_second"`
+}
+
+func (a QuotaApps) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.TotalMemoryInMB, jellidation.Min(0)),
+		jellidation.Field(&a.PerProcessMemoryInMB, jellidation.Min(0)),
+		jellidation.Field(&a.TotalInstances, jellidation.Min(0)),
+		jellidation.Field(&a.PerAppTasks, jellidation.Min(0)),
+		jellidation.Field(&a.LogRateLimitInBytesPerSecond, jellidation.Min(-1)),
+	)
+}
+
+// QuotaServices holds the service limits of a quota. The service keys limit
+// is accepted for compatibility but is not enforced.
+type QuotaServices struct {
+	PaidServicesAllowed   *bool  `json:"paid_services_allowed"`
+	TotalServiceInstances *int32 `json:"total_service_instances"`
+	TotalServiceKeys      *int32 `json:"total_service_keys"`
+}
+
+func (s QuotaServices) Validate() error {
+	return jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.TotalServiceInstances, jellidation.Min(0)),
+		jellidation.Field(&s.TotalServiceKeys, jellidation.Min(0)),
+	)
+}
+
+// QuotaRoutes holds the route limits of a quota. The reserved ports limit is
+// accepted for compatibility but is not enforced.
+type QuotaRoutes struct {
+	TotalRoutes        *int32 `json:"total_routes"`
+	TotalReservedPorts *int32 `json:"total_reserved_ports"`
+}
+
+func (r QuotaRoutes) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.TotalRoutes, jellidation.Min(0)),
+		jellidation.Field(&r.TotalReservedPorts, jellidation.Min(0)),
+	)
+}
+
+// QuotaDomains is accepted for compatibility but is not enforced
+type QuotaDomains struct {
+	TotalDomains *int32 `json:"total_domains"`
+}
+
+func (d QuotaDomains) Validate() error {
+	return jellidation.ValidateStruct(&d,
+		jellidation.Field(&d.TotalDomains, jellidation.Min(0)),
+	)
+}
+
+func toQuotaLimits(apps QuotaApps, services QuotaServices, routes QuotaRoutes) repositories.QuotaLimits {
+	return repositories.QuotaLimits{
+		Apps: repositories.QuotaAppsLimits{
+			TotalMemoryInMB:      apps.TotalMemoryInMB,
+			PerProcessMemoryInMB: apps.PerProcessMemoryInMB,
+			TotalInstances:       apps.TotalInstances,
+			PerAppTasks:          apps.PerAppTasks,
+		},
+		Services: repositories.QuotaServicesLimits{
+			PaidServicesAllowed:   *tools.IfNil(services.PaidServicesAllowed, tools.PtrTo(true)),
+			TotalServiceInstances: services.TotalServiceInstances,
+		},
+		Routes: repositories.QuotaRoutesLimits{
+			TotalRoutes: routes.TotalRoutes,
+		},
+	}
+}
+
+func toQuotaLimitsPatch(apps QuotaApps, services QuotaServices, routes QuotaRoutes) repositories.QuotaLimitsPatch {
+	return repositories.QuotaLimitsPatch{
+		TotalMemoryInMB:       apps.TotalMemoryInMB,
+		PerProcessMemoryInMB:  apps.PerProcessMemoryInMB,
+		TotalInstances:        apps.TotalInstances,
+		PerAppTasks:           apps.PerAppTasks,
+		PaidServicesAllowed:   services.PaidServicesAllowed,
+		TotalServiceInstances: services.TotalServiceInstances,
+		TotalRoutes:           routes.TotalRoutes,
+	}
+}
+
+type OrgQuotaRelationships struct {
+	Organizations ToManyRelationship `json:"organizations"`
+}
+
+type OrgQuotaCreate struct {
+	Name          string                `json:"name"`
+	Apps          QuotaApps             `json:"apps"`
+	Services      QuotaServices         `json:"services"`
+	Routes        QuotaRoutes           `json:"routes"`
+	Domains       QuotaDomains          `json:"domains"`
+	Relationships OrgQuotaRelationships `json:"relationships"`
+}
+
+func (c OrgQuotaCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required, jellidation.Length(1, 250)),
+		jellidation.Field(&c.Apps),
+		jellidation.Field(&c.Services),
+		jellidation.Field(&c.Routes),
+		jellidation.Field(&c.Domains),
+	)
+}
+
+func (c OrgQuotaCreate) ToMessage() repositories.CreateOrgQuotaMessage {
+	return repositories.CreateOrgQuotaMessage{
+		Name:     c.Name,
+		Limits:   toQuotaLimits(c.Apps, c.Services, c.Routes),
+		OrgGUIDs: relationshipGUIDs(c.Relationships.Organizations),
+	}
+}
+
+type OrgQuotaUpdate struct {
+	Name     *string       `json:"name"`
+	Apps     QuotaApps     `json:"apps"`
+	Services QuotaServices `json:"services"`
+	Routes   QuotaRoutes   `json:"routes"`
+	Domains  QuotaDomains  `json:"domains"`
+}
+
+func (u OrgQuotaUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Name, jellidation.NilOrNotEmpty, jellidation.Length(1, 250)),
+		jellidation.Field(&u.Apps),
+		jellidation.Field(&u.Services),
+		jellidation.Field(&u.Routes),
+		jellidation.Field(&u.Domains),
+	)
+}
+
+func (u OrgQuotaUpdate) ToMessage(guid string) repositories.UpdateOrgQuotaMessage {
+	return repositories.UpdateOrgQuotaMessage{
+		GUID:   guid,
+		Name:   u.Name,
+		Limits: toQuotaLimitsPatch(u.Apps, u.Services, u.Routes),
+	}
+}
+
+type OrgQuotaApply ToManyRelationship
+
+func (a OrgQuotaApply) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.Data, jellidation.Required),
+	)
+}
+
+func (a OrgQuotaApply) ToMessage(guid string) repositories.ApplyOrgQuotaMessage {
+	return repositories.ApplyOrgQuotaMessage{
+		GUID:     guid,
+		OrgGUIDs: relationshipGUIDs(ToManyRelationship(a)),
+	}
+}
+
+type OrgQuotaList struct {
+	GUIDs      string
+	Names      string
+	OrgGUIDs   string
+	Pagination Pagination
+}
+
+func (l *OrgQuotaList) ToMessage() repositories.ListOrgQuotasMessage {
+	return repositories.ListOrgQuotasMessage{
+		GUIDs:      parse.ArrayParam(l.GUIDs),
+		Names:      parse.ArrayParam(l.Names),
+		OrgGUIDs:   parse.ArrayParam(l.OrgGUIDs),
+		Pagination: l.Pagination.ToMessage(DefaultPageSize),
+	}
+}
+
+func (l *OrgQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "per_page", "page"}
+}
+
+func (l *OrgQuotaList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrgGUIDs = values.Get("organization_guids")
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func (l OrgQuotaList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func relationshipGUIDs(relationship ToManyRelationship) []string {
+	return slices.Collect(it.Map(slices.Values(relationship.Data), func(d RelationshipData) string { return d.GUID }))
+}
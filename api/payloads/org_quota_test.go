@@ -0,0 +1,239 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("OrgQuotaCreate", func() {
+	var (
+		createPayload  payloads.OrgQuotaCreate
+		orgQuotaCreate *payloads.OrgQuotaCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		orgQuotaCreate = new(payloads.OrgQuotaCreate)
+		createPayload = payloads.OrgQuotaCreate{
+			Name: "my-quota",
+			Apps: payloads.QuotaApps{
+				TotalMemoryInMB:      tools.PtrTo[int64](1024),
+				PerProcessMemoryInMB: tools.PtrTo[int64](256),
+				TotalInstances:       tools.PtrTo[int32](10),
+				PerAppTasks:          tools.PtrTo[int32](2),
+			},
+			Services: payloads.QuotaServices{
+				PaidServicesAllowed:   tools.PtrTo(false),
+				TotalServiceInstances: tools.PtrTo[int32](3),
+			},
+			Routes: payloads.QuotaRoutes{
+				TotalRoutes: tools.PtrTo[int32](4),
+			},
+			Relationships: payloads.OrgQuotaRelationships{
+				Organizations: payloads.ToManyRelationship{Data: []payloads.RelationshipData{{GUID: "org-guid"}}},
+			},
+		}
+	})
+
+	Describe("Validation", func() {
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), orgQuotaCreate)
+		})
+
+		It("succeeds with valid payload", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(orgQuotaCreate).To(PointTo(Equal(createPayload)))
+		})
+
+		When("the name is empty", func() {
+			BeforeEach(func() {
+				createPayload.Name = ""
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+			})
+		})
+
+		When("a limit is negative", func() {
+			BeforeEach(func() {
+				createPayload.Apps.TotalInstances = tools.PtrTo[int32](-1)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "apps.total_instances must be no less than 0")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateOrgQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					Apps: repositories.QuotaAppsLimits{
+						TotalMemoryInMB:      tools.PtrTo[int64](1024),
+						PerProcessMemoryInMB: tools.PtrTo[int64](256),
+						TotalInstances:       tools.PtrTo[int32](10),
+						PerAppTasks:          tools.PtrTo[int32](2),
+					},
+					Services: repositories.QuotaServicesLimits{
+						PaidServicesAllowed:   false,
+						TotalServiceInstances: tools.PtrTo[int32](3),
+					},
+					Routes: repositories.QuotaRoutesLimits{
+						TotalRoutes: tools.PtrTo[int32](4),
+					},
+				},
+				OrgGUIDs: []string{"org-guid"},
+			}))
+		})
+
+		When("paid services allowed is not specified", func() {
+			BeforeEach(func() {
+				createPayload.Services.PaidServicesAllowed = nil
+			})
+
+			It("allows paid services", func() {
+				Expect(createPayload.ToMessage().Limits.Services.PaidServicesAllowed).To(BeTrue())
+			})
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaUpdate", func() {
+	var (
+		updatePayload  payloads.OrgQuotaUpdate
+		orgQuotaUpdate *payloads.OrgQuotaUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		orgQuotaUpdate = new(payloads.OrgQuotaUpdate)
+		updatePayload = payloads.OrgQuotaUpdate{
+			Name: tools.PtrTo("new-name"),
+			Apps: payloads.QuotaApps{
+				TotalInstances: tools.PtrTo[int32](5),
+			},
+			Services: payloads.QuotaServices{
+				PaidServicesAllowed: tools.PtrTo(true),
+			},
+		}
+	})
+
+	Describe("Validation", func() {
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), orgQuotaUpdate)
+		})
+
+		It("succeeds with valid payload", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(orgQuotaUpdate).To(PointTo(Equal(updatePayload)))
+		})
+
+		When("the name is empty", func() {
+			BeforeEach(func() {
+				updatePayload.Name = tools.PtrTo("")
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			Expect(updatePayload.ToMessage("quota-guid")).To(Equal(repositories.UpdateOrgQuotaMessage{
+				GUID: "quota-guid",
+				Name: tools.PtrTo("new-name"),
+				Limits: repositories.QuotaLimitsPatch{
+					TotalInstances:      tools.PtrTo[int32](5),
+					PaidServicesAllowed: tools.PtrTo(true),
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaApply", func() {
+	var (
+		applyPayload  payloads.OrgQuotaApply
+		orgQuotaApply *payloads.OrgQuotaApply
+		validatorErr  error
+	)
+
+	BeforeEach(func() {
+		orgQuotaApply = new(payloads.OrgQuotaApply)
+		applyPayload = payloads.OrgQuotaApply{
+			Data: []payloads.RelationshipData{{GUID: "org1"}, {GUID: "org2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(applyPayload), orgQuotaApply)
+	})
+
+	It("succeeds with valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(orgQuotaApply.ToMessage("quota-guid")).To(Equal(repositories.ApplyOrgQuotaMessage{
+			GUID:     "quota-guid",
+			OrgGUIDs: []string{"org1", "org2"},
+		}))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			applyPayload.Data = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedOrgQuotaList payloads.OrgQuotaList) {
+			actualOrgQuotaList, decodeErr := decodeQuery[payloads.OrgQuotaList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualOrgQuotaList).To(Equal(expectedOrgQuotaList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.OrgQuotaList{GUIDs: "g1,g2"}),
+		Entry("names", "names=n1,n2", payloads.OrgQuotaList{Names: "n1,n2"}),
+		Entry("organization_guids", "organization_guids=o1,o2", payloads.OrgQuotaList{OrgGUIDs: "o1,o2"}),
+		Entry("page=3", "page=3", payloads.OrgQuotaList{Pagination: payloads.Pagination{Page: "3"}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.OrgQuotaList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unsupported key", "foo=bar", "unsupported query parameter"),
+		Entry("per_page is not a number", "per_page=foo", "value must be an integer"),
+	)
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			orgQuotaList := payloads.OrgQuotaList{
+				GUIDs:      "g1,g2",
+				Names:      "n1",
+				OrgGUIDs:   "o1",
+				Pagination: payloads.Pagination{PerPage: "10", Page: "2"},
+			}
+			Expect(orgQuotaList.ToMessage()).To(Equal(repositories.ListOrgQuotasMessage{
+				GUIDs:      []string{"g1", "g2"},
+				Names:      []string{"n1"},
+				OrgGUIDs:   []string{"o1"},
+				Pagination: repositories.Pagination{PerPage: 10, Page: 2},
+			}))
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package payloads
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 
@@ -158,3 +159,32 @@ func (p *PackageDropletList) DecodeFromURLValues(values url.Values) error {
 	p.OrderBy = values.Get("order_by")
 	return p.Pagination.DecodeFromURLValues(values)
 }
+
+// PackageUpload holds the form fields of a package upload other than the bits
+type PackageUpload struct {
+	// The files of the app that are not part of the bits because they have
+	// been matched via the resource_matches endpoint
+	Resources []Resource
+}
+
+func (u *PackageUpload) SupportedKeys() []string {
+	return []string{"resources"}
+}
+
+func (u *PackageUpload) DecodeFromURLValues(values url.Values) error {
+	if !values.Has("resources") {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(values.Get("resources")), &u.Resources)
+}
+
+func (u PackageUpload) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Resources),
+	)
+}
+
+func (u PackageUpload) ToRecords() []repositories.ResourceRecord {
+	return toResourceRecords(u.Resources)
+}
@@ -1,6 +1,8 @@
 package payloads_test
 
 import (
+	"net/url"
+
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
@@ -309,6 +311,39 @@ var _ = Describe("PackageUpdate", func() {
 	})
 })
 
+var _ = Describe("PackageUpload", func() {
+	It("decodes the resources", func() {
+		actualUpload, decodeErr := decodeQuery[payloads.PackageUpload](
+			"resources=" + url.QueryEscape(`[{"checksum":{"value":"002d760bea1be268e27077412e11a320d0f164d3"},"size_in_bytes":100000,"path":"path/to/file","mode":"644"}]`),
+		)
+
+		Expect(decodeErr).NotTo(HaveOccurred())
+		Expect(actualUpload.ToRecords()).To(Equal([]repositories.ResourceRecord{{
+			SHA1:        "002d760bea1be268e27077412e11a320d0f164d3",
+			SizeInBytes: 100000,
+			Path:        "path/to/file",
+			Mode:        "644",
+		}}))
+	})
+
+	It("allows uploads without resources", func() {
+		actualUpload, decodeErr := decodeQuery[payloads.PackageUpload]("")
+
+		Expect(decodeErr).NotTo(HaveOccurred())
+		Expect(actualUpload.Resources).To(BeEmpty())
+	})
+
+	DescribeTable("invalid resources",
+		func(resources string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.PackageUpload]("resources=" + url.QueryEscape(resources))
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("not json", "foo", "invalid character"),
+		Entry("invalid checksum", `[{"checksum":{"value":"foo"},"size_in_bytes":1,"path":"a"}]`, "must be a SHA1 checksum"),
+		Entry("escaping path", `[{"checksum":{"value":"002d760bea1be268e27077412e11a320d0f164d3"},"size_in_bytes":1,"path":"../a"}]`, "must be a relative path within the app"),
+	)
+})
+
 var _ = Describe("PackageList", func() {
 	DescribeTable("valid query",
 		func(query string, expectedPackageList payloads.PackageList) {
@@ -24,9 +24,17 @@ func (p ProcessScale) Validate() error {
 }
 
 type ProcessPatch struct {
-	Metadata    *MetadataPatch `json:"metadata"`
-	Command     *string        `json:"command"`
-	HealthCheck *HealthCheck   `json:"health_check"`
+	Metadata             *MetadataPatch        `json:"metadata"`
+	Command              *string               `json:"command"`
+	HealthCheck          *HealthCheck          `json:"health_check"`
+	ReadinessHealthCheck *ReadinessHealthCheck `json:"readiness_health_check"`
+}
+
+func (p ProcessPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.HealthCheck),
+		jellidation.Field(&p.ReadinessHealthCheck),
+	)
 }
 
 type HealthCheck struct {
@@ -34,10 +42,50 @@ type HealthCheck struct {
 	Data *Data   `json:"data"`
 }
 
+func (c HealthCheck) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Data),
+	)
+}
+
 type Data struct {
 	Timeout           *int32  `json:"timeout"`
 	Endpoint          *string `json:"endpoint"`
 	InvocationTimeout *int32  `json:"invocation_timeout"`
+	Interval          *int32  `json:"interval"`
+	FailureThreshold  *int32  `json:"failure_threshold"`
+}
+
+func (d Data) Validate() error {
+	return jellidation.ValidateStruct(&d,
+		jellidation.Field(&d.Interval, jellidation.Min(1), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+		jellidation.Field(&d.FailureThreshold, jellidation.Min(1), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+	)
+}
+
+type ReadinessHealthCheck struct {
+	Type *string                   `json:"type"`
+	Data *ReadinessHealthCheckData `json:"data"`
+}
+
+func (c ReadinessHealthCheck) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Type, validation.OneOf("process", "port", "http")),
+		jellidation.Field(&c.Data),
+	)
+}
+
+type ReadinessHealthCheckData struct {
+	Endpoint          *string `json:"endpoint"`
+	InvocationTimeout *int32  `json:"invocation_timeout"`
+	Interval          *int32  `json:"interval"`
+}
+
+func (d ReadinessHealthCheckData) Validate() error {
+	return jellidation.ValidateStruct(&d,
+		jellidation.Field(&d.InvocationTimeout, jellidation.Min(1), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+		jellidation.Field(&d.Interval, jellidation.Min(1), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+	)
 }
 
 func (p ProcessScale) ToRecord() repositories.ProcessScaleValues {
@@ -93,6 +141,18 @@ func (p ProcessPatch) ToProcessPatchMessage(processGUID, spaceGUID string) repos
 			message.HealthCheckHTTPEndpoint = p.HealthCheck.Data.Endpoint
 			message.HealthCheckTimeoutSeconds = p.HealthCheck.Data.Timeout
 			message.HealthCheckInvocationTimeoutSeconds = p.HealthCheck.Data.InvocationTimeout
+			message.HealthCheckIntervalSeconds = p.HealthCheck.Data.Interval
+			message.HealthCheckFailureThreshold = p.HealthCheck.Data.FailureThreshold
+		}
+	}
+
+	if p.ReadinessHealthCheck != nil {
+		message.ReadinessHealthCheckType = p.ReadinessHealthCheck.Type
+
+		if p.ReadinessHealthCheck.Data != nil {
+			message.ReadinessHealthCheckHTTPEndpoint = p.ReadinessHealthCheck.Data.Endpoint
+			message.ReadinessHealthCheckInvocationTimeoutSeconds = p.ReadinessHealthCheck.Data.InvocationTimeout
+			message.ReadinessHealthCheckIntervalSeconds = p.ReadinessHealthCheck.Data.Interval
 		}
 	}
 
@@ -105,3 +165,35 @@ func (p ProcessPatch) ToProcessPatchMessage(processGUID, spaceGUID string) repos
 
 	return message
 }
+
+type ProcessAutoscalingPolicy struct {
+	MinInstances             int32  `json:"min_instances"`
+	MaxInstances             int32  `json:"max_instances"`
+	CPUUtilizationPercent    *int32 `json:"cpu_utilization_percent"`
+	MemoryUtilizationPercent *int32 `json:"memory_utilization_percent"`
+	HTTPRequestsPerSecond    *int32 `json:"http_requests_per_second"`
+}
+
+func (p ProcessAutoscalingPolicy) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.MinInstances, jellidation.Required.Error("must be no less than 1"), jellidation.Min(1)),
+		jellidation.Field(&p.MaxInstances, jellidation.Required.Error("must be no less than 1"), jellidation.Min(p.MinInstances)),
+		jellidation.Field(&p.CPUUtilizationPercent, jellidation.Min(1), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+		jellidation.Field(&p.MemoryUtilizationPercent, jellidation.Min(1), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+		jellidation.Field(&p.HTTPRequestsPerSecond, jellidation.Min(1), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+	)
+}
+
+func (p ProcessAutoscalingPolicy) ToMessage(processGUID, spaceGUID string) repositories.SetProcessAutoscalingMessage {
+	return repositories.SetProcessAutoscalingMessage{
+		ProcessGUID: processGUID,
+		SpaceGUID:   spaceGUID,
+		Autoscaling: &repositories.ProcessAutoscaling{
+			MinInstances:             p.MinInstances,
+			MaxInstances:             p.MaxInstances,
+			CPUUtilizationPercent:    p.CPUUtilizationPercent,
+			MemoryUtilizationPercent: p.MemoryUtilizationPercent,
+			HTTPRequestsPerSecond:    p.HTTPRequestsPerSecond,
+		},
+	}
+}
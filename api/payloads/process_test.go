@@ -127,4 +127,236 @@ var _ = Describe("Process payload validation", func() {
 			})
 		})
 	})
+
+	Describe("ProcessPatch", func() {
+		var (
+			payload        payloads.ProcessPatch
+			decodedPayload *payloads.ProcessPatch
+		)
+
+		BeforeEach(func() {
+			payload = payloads.ProcessPatch{
+				HealthCheck: &payloads.HealthCheck{
+					Type: tools.PtrTo("http"),
+					Data: &payloads.Data{
+						Endpoint:         tools.PtrTo("/healthz"),
+						Interval:         tools.PtrTo[int32](10),
+						FailureThreshold: tools.PtrTo[int32](3),
+					},
+				},
+				ReadinessHealthCheck: &payloads.ReadinessHealthCheck{
+					Type: tools.PtrTo("http"),
+					Data: &payloads.ReadinessHealthCheckData{
+						Endpoint:          tools.PtrTo("/ready"),
+						InvocationTimeout: tools.PtrTo[int32](2),
+						Interval:          tools.PtrTo[int32](5),
+					},
+				},
+			}
+
+			decodedPayload = new(payloads.ProcessPatch)
+		})
+
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+
+		When("the health check interval is not positive", func() {
+			BeforeEach(func() {
+				payload.HealthCheck.Data.Interval = tools.PtrTo[int32](0)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "interval must be no less than 1")
+			})
+		})
+
+		When("the health check failure threshold is not positive", func() {
+			BeforeEach(func() {
+				payload.HealthCheck.Data.FailureThreshold = tools.PtrTo[int32](0)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "failure_threshold must be no less than 1")
+			})
+		})
+
+		When("the readiness health check type is invalid", func() {
+			BeforeEach(func() {
+				payload.ReadinessHealthCheck.Type = tools.PtrTo("none")
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "type value must be one of: process, port, http")
+			})
+		})
+
+		When("the readiness health check invocation timeout is not positive", func() {
+			BeforeEach(func() {
+				payload.ReadinessHealthCheck.Data.InvocationTimeout = tools.PtrTo[int32](0)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "invocation_timeout must be no less than 1")
+			})
+		})
+
+		When("the readiness health check interval is not positive", func() {
+			BeforeEach(func() {
+				payload.ReadinessHealthCheck.Data.Interval = tools.PtrTo[int32](0)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "interval must be no less than 1")
+			})
+		})
+	})
+})
+
+var _ = Describe("ProcessPatch", func() {
+	Describe("ToProcessPatchMessage", func() {
+		It("converts the health check", func() {
+			message := payloads.ProcessPatch{
+				HealthCheck: &payloads.HealthCheck{
+					Type: tools.PtrTo("http"),
+					Data: &payloads.Data{
+						Timeout:           tools.PtrTo[int32](60),
+						Endpoint:          tools.PtrTo("/healthz"),
+						InvocationTimeout: tools.PtrTo[int32](5),
+						Interval:          tools.PtrTo[int32](10),
+						FailureThreshold:  tools.PtrTo[int32](3),
+					},
+				},
+			}.ToProcessPatchMessage("process-guid", "space-guid")
+
+			Expect(message.HealthCheckType).To(gstruct.PointTo(Equal("http")))
+			Expect(message.HealthCheckHTTPEndpoint).To(gstruct.PointTo(Equal("/healthz")))
+			Expect(message.HealthCheckTimeoutSeconds).To(gstruct.PointTo(BeEquivalentTo(60)))
+			Expect(message.HealthCheckInvocationTimeoutSeconds).To(gstruct.PointTo(BeEquivalentTo(5)))
+			Expect(message.HealthCheckIntervalSeconds).To(gstruct.PointTo(BeEquivalentTo(10)))
+			Expect(message.HealthCheckFailureThreshold).To(gstruct.PointTo(BeEquivalentTo(3)))
+		})
+
+		It("converts the readiness health check", func() {
+			message := payloads.ProcessPatch{
+				ReadinessHealthCheck: &payloads.ReadinessHealthCheck{
+					Type: tools.PtrTo("http"),
+					Data: &payloads.ReadinessHealthCheckData{
+						Endpoint:          tools.PtrTo("/ready"),
+						InvocationTimeout: tools.PtrTo[int32](2),
+						Interval:          tools.PtrTo[int32](5),
+					},
+				},
+			}.ToProcessPatchMessage("process-guid", "space-guid")
+
+			Expect(message.ProcessGUID).To(Equal("process-guid"))
+			Expect(message.SpaceGUID).To(Equal("space-guid"))
+			Expect(message.ReadinessHealthCheckType).To(gstruct.PointTo(Equal("http")))
+			Expect(message.ReadinessHealthCheckHTTPEndpoint).To(gstruct.PointTo(Equal("/ready")))
+			Expect(message.ReadinessHealthCheckInvocationTimeoutSeconds).To(gstruct.PointTo(BeEquivalentTo(2)))
+			Expect(message.ReadinessHealthCheckIntervalSeconds).To(gstruct.PointTo(BeEquivalentTo(5)))
+		})
+	})
+})
+
+var _ = Describe("ProcessAutoscalingPolicy", func() {
+	var payload payloads.ProcessAutoscalingPolicy
+
+	BeforeEach(func() {
+		payload = payloads.ProcessAutoscalingPolicy{
+			MinInstances:             2,
+			MaxInstances:             5,
+			CPUUtilizationPercent:    tools.PtrTo[int32](70),
+			MemoryUtilizationPercent: tools.PtrTo[int32](80),
+			HTTPRequestsPerSecond:    tools.PtrTo[int32](100),
+		}
+	})
+
+	Describe("Validation", func() {
+		var (
+			decodedPayload *payloads.ProcessAutoscalingPolicy
+			validatorErr   error
+		)
+
+		JustBeforeEach(func() {
+			decodedPayload = new(payloads.ProcessAutoscalingPolicy)
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+
+		When("min instances is not set", func() {
+			BeforeEach(func() {
+				payload.MinInstances = 0
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "min_instances must be no less than 1")
+			})
+		})
+
+		When("max instances is lower than min instances", func() {
+			BeforeEach(func() {
+				payload.MaxInstances = 1
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "max_instances must be no less than 2")
+			})
+		})
+
+		When("the CPU utilization is not positive", func() {
+			BeforeEach(func() {
+				payload.CPUUtilizationPercent = tools.PtrTo[int32](0)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "cpu_utilization_percent must be no less than 1")
+			})
+		})
+
+		When("the memory utilization is not positive", func() {
+			BeforeEach(func() {
+				payload.MemoryUtilizationPercent = tools.PtrTo[int32](0)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "memory_utilization_percent must be no less than 1")
+			})
+		})
+
+		When("the HTTP throughput is not positive", func() {
+			BeforeEach(func() {
+				payload.HTTPRequestsPerSecond = tools.PtrTo[int32](-1)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "http_requests_per_second must be no less than 1")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			Expect(payload.ToMessage("process-guid", "space-guid")).To(Equal(repositories.SetProcessAutoscalingMessage{
+				ProcessGUID: "process-guid",
+				SpaceGUID:   "space-guid",
+				Autoscaling: &repositories.ProcessAutoscaling{
+					MinInstances:             2,
+					MaxInstances:             5,
+					CPUUtilizationPercent:    tools.PtrTo[int32](70),
+					MemoryUtilizationPercent: tools.PtrTo[int32](80),
+					HTTPRequestsPerSecond:    tools.PtrTo[int32](100),
+				},
+			}))
+		})
+	})
 })
@@ -0,0 +1,85 @@
+package payloads
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+var sha1Regex = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+type Resource struct {
+	Checksum    ResourceChecksum `json:"checksum"`
+	SizeInBytes int64            `json:"size_in_bytes"`
+	Path        string           `json:"path"`
+	Mode        string           `json:"mode"`
+}
+
+type ResourceChecksum struct {
+	Value string `json:"value"`
+}
+
+func (r Resource) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Checksum),
+		jellidation.Field(&r.SizeInBytes, jellidation.Min(int64(0))),
+		jellidation.Field(&r.Path, jellidation.By(validateResourcePath)),
+	)
+}
+
+func (c ResourceChecksum) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Value, jellidation.Required, jellidation.Match(sha1Regex).Error("must be a SHA1 checksum")),
+	)
+}
+
+// validateResourcePath rejects paths that would end up outside of the app
+// directory once the bits are extracted
+func validateResourcePath(value any) error {
+	resourcePath, ok := value.(string)
+	if !ok {
+		return errors.New("must be a string")
+	}
+
+	if path.IsAbs(resourcePath) || path.Clean(resourcePath) == ".." || strings.HasPrefix(path.Clean(resourcePath), "../") {
+		return errors.New("must be a relative path within the app")
+	}
+
+	return nil
+}
+
+func (r Resource) ToRecord() repositories.ResourceRecord {
+	return repositories.ResourceRecord{
+		SHA1:        r.Checksum.Value,
+		SizeInBytes: r.SizeInBytes,
+		Path:        r.Path,
+		Mode:        r.Mode,
+	}
+}
+
+type ResourceMatchCreate struct {
+	Resources []Resource `json:"resources"`
+}
+
+func (c ResourceMatchCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Resources),
+	)
+}
+
+func (c ResourceMatchCreate) ToRecords() []repositories.ResourceRecord {
+	return toResourceRecords(c.Resources)
+}
+
+func toResourceRecords(resources []Resource) []repositories.ResourceRecord {
+	records := []repositories.ResourceRecord{}
+	for _, resource := range resources {
+		records = append(records, resource.ToRecord())
+	}
+
+	return records
+}
@@ -0,0 +1,101 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("ResourceMatchCreate", func() {
+	var (
+		createPayload payloads.ResourceMatchCreate
+		decodedCreate *payloads.ResourceMatchCreate
+		validatorErr  error
+	)
+
+	BeforeEach(func() {
+		createPayload = payloads.ResourceMatchCreate{
+			Resources: []payloads.Resource{{
+				Checksum:    payloads.ResourceChecksum{Value: "002d760bea1be268e27077412e11a320d0f164d3"},
+				SizeInBytes: 100000,
+				Path:        "path/to/file",
+				Mode:        "644",
+			}},
+		}
+		decodedCreate = new(payloads.ResourceMatchCreate)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedCreate)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedCreate).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("there are no resources", func() {
+		BeforeEach(func() {
+			createPayload.Resources = nil
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the checksum is missing", func() {
+		BeforeEach(func() {
+			createPayload.Resources[0].Checksum.Value = ""
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "value cannot be blank")
+		})
+	})
+
+	When("the checksum is not a SHA1", func() {
+		BeforeEach(func() {
+			createPayload.Resources[0].Checksum.Value = "not-a-sha"
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "must be a SHA1 checksum")
+		})
+	})
+
+	When("the size is negative", func() {
+		BeforeEach(func() {
+			createPayload.Resources[0].SizeInBytes = -1
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "must be no less than 0")
+		})
+	})
+
+	DescribeTable("invalid paths",
+		func(resourcePath string) {
+			createPayload.Resources[0].Path = resourcePath
+			err := validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), new(payloads.ResourceMatchCreate))
+			expectUnprocessableEntityError(err, "must be a relative path within the app")
+		},
+		Entry("absolute path", "/etc/passwd"),
+		Entry("parent directory", ".."),
+		Entry("escaping path", "foo/../../bar"),
+	)
+
+	Describe("ToRecords", func() {
+		It("converts the resources to records", func() {
+			Expect(createPayload.ToRecords()).To(Equal([]repositories.ResourceRecord{{
+				SHA1:        "002d760bea1be268e27077412e11a320d0f164d3",
+				SizeInBytes: 100000,
+				Path:        "path/to/file",
+				Mode:        "644",
+			}}))
+		})
+	})
+})
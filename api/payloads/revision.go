@@ -0,0 +1,47 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type AppRevisionsList struct {
+	Versions   string
+	OrderBy    string
+	Pagination Pagination
+}
+
+func (l *AppRevisionsList) SupportedKeys() []string {
+	return []string{
+		"versions",
+		"order_by",
+		"page",
+		"per_page",
+	}
+}
+
+func (l AppRevisionsList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func (l *AppRevisionsList) DecodeFromURLValues(values url.Values) error {
+	l.Versions = values.Get("versions")
+	l.OrderBy = values.Get("order_by")
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func (l *AppRevisionsList) ToMessage(appGUID string) repositories.ListRevisionsMessage {
+	return repositories.ListRevisionsMessage{
+		AppGUIDs:   []string{appGUID},
+		Versions:   parse.ArrayParam(l.Versions),
+		OrderBy:    l.OrderBy,
+		Pagination: l.Pagination.ToMessage(DefaultPageSize),
+	}
+}
@@ -0,0 +1,56 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppRevisionsList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedRevisionsList payloads.AppRevisionsList) {
+			actualRevisionsList, decodeErr := decodeQuery[payloads.AppRevisionsList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualRevisionsList).To(Equal(expectedRevisionsList))
+		},
+		Entry("versions", "versions=1,2", payloads.AppRevisionsList{Versions: "1,2"}),
+		Entry("order_by created_at", "order_by=created_at", payloads.AppRevisionsList{OrderBy: "created_at"}),
+		Entry("order_by -updated_at", "order_by=-updated_at", payloads.AppRevisionsList{OrderBy: "-updated_at"}),
+		Entry("pagination", "page=3", payloads.AppRevisionsList{Pagination: payloads.Pagination{Page: "3"}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AppRevisionsList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid order_by", "order_by=foo", "value must be one of"),
+		Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+		Entry("invalid pagination", "per_page=foo", "value must be an integer"),
+	)
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			revisionsList := payloads.AppRevisionsList{
+				Versions: "1,2",
+				OrderBy:  "created_at",
+				Pagination: payloads.Pagination{
+					PerPage: "3",
+					Page:    "2",
+				},
+			}
+			Expect(revisionsList.ToMessage("app-guid")).To(Equal(repositories.ListRevisionsMessage{
+				AppGUIDs: []string{"app-guid"},
+				Versions: []string{"1", "2"},
+				OrderBy:  "created_at",
+				Pagination: repositories.Pagination{
+					Page:    2,
+					PerPage: 3,
+				},
+			}))
+		})
+	})
+})
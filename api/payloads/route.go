@@ -2,25 +2,28 @@ package payloads
 
 import (
 	"net/url"
+	"slices"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
 	jellidation "github.com/jellydator/validation"
 )
 
 type RouteCreate struct {
 	Host          string              `json:"host"`
 	Path          string              `json:"path"`
-	Port          int                 `json:"port"`
+	Port          *int32              `json:"port"`
 	Relationships *RouteRelationships `json:"relationships"`
 	Metadata      Metadata            `json:"metadata"`
 }
 
 func (p RouteCreate) Validate() error {
 	return jellidation.ValidateStruct(&p,
-		jellidation.Field(&p.Host, jellidation.Required),
+		jellidation.Field(&p.Port, jellidation.NilOrNotEmpty, jellidation.Min(int32(1)), jellidation.Max(int32(65535))),
 		jellidation.Field(&p.Relationships, jellidation.NotNil),
 		jellidation.Field(&p.Metadata),
 	)
@@ -30,6 +33,7 @@ func (p RouteCreate) ToMessage(domainNamespace, domainName string) repositories.
 	return repositories.CreateRouteMessage{
 		Host:            p.Host,
 		Path:            p.Path,
+		Port:            tools.ZeroIfNil(p.Port),
 		SpaceGUID:       p.Relationships.Space.Data.GUID,
 		DomainGUID:      p.Relationships.Domain.Data.GUID,
 		DomainNamespace: domainNamespace,
@@ -57,6 +61,7 @@ type RouteList struct {
 	DomainGUIDs string
 	Hosts       string
 	Paths       string
+	Ports       string
 	OrderBy     string
 	Pagination  Pagination
 }
@@ -68,13 +73,20 @@ func (p RouteList) ToMessage() repositories.ListRoutesMessage {
 		DomainGUIDs: parse.ArrayParam(p.DomainGUIDs),
 		Hosts:       parse.ArrayParam(p.Hosts),
 		Paths:       parse.ArrayParam(p.Paths),
+		Ports:       parsePorts(p.Ports),
 		OrderBy:     p.OrderBy,
 		Pagination:  p.Pagination.ToMessage(DefaultPageSize),
 	}
 }
 
+func parsePorts(ports string) []int32 {
+	return slices.Collect(it.Map(slices.Values(parse.ArrayParam(ports)), func(port string) int32 {
+		return int32(parse.Integer(port))
+	}))
+}
+
 func (p RouteList) SupportedKeys() []string {
-	return []string{"app_guids", "space_guids", "domain_guids", "hosts", "paths", "order_by", "per_page", "page"}
+	return []string{"app_guids", "space_guids", "domain_guids", "hosts", "paths", "ports", "order_by", "per_page", "page"}
 }
 
 func (p *RouteList) DecodeFromURLValues(values url.Values) error {
@@ -83,12 +95,21 @@ func (p *RouteList) DecodeFromURLValues(values url.Values) error {
 	p.DomainGUIDs = values.Get("domain_guids")
 	p.Hosts = values.Get("hosts")
 	p.Paths = values.Get("paths")
+	p.Ports = values.Get("ports")
 	p.OrderBy = values.Get("order_by")
 	return p.Pagination.DecodeFromURLValues(values)
 }
 
 func (p RouteList) Validate() error {
 	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Ports, jellidation.By(func(value any) error {
+			for _, port := range parse.ArrayParam(p.Ports) {
+				if err := validation.IntegerMatching(jellidation.Min(1), jellidation.Max(65535))(port); err != nil {
+					return err
+				}
+			}
+			return nil
+		})),
 		jellidation.Field(&p.Pagination),
 		jellidation.Field(&p.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
 	)
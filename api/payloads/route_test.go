@@ -25,6 +25,7 @@ var _ = Describe("RouteList", func() {
 			Entry("domain_guids", "domain_guids=guid1,guid2", payloads.RouteList{DomainGUIDs: "guid1,guid2"}),
 			Entry("hosts", "hosts=h1,h2", payloads.RouteList{Hosts: "h1,h2"}),
 			Entry("paths", "paths=h1,h2", payloads.RouteList{Paths: "h1,h2"}),
+			Entry("ports", "ports=1024,1025", payloads.RouteList{Ports: "1024,1025"}),
 			Entry("order_by created_at", "order_by=created_at", payloads.RouteList{OrderBy: "created_at"}),
 			Entry("order_by -created_at", "order_by=-created_at", payloads.RouteList{OrderBy: "-created_at"}),
 			Entry("order_by updated_at", "order_by=updated_at", payloads.RouteList{OrderBy: "updated_at"}),
@@ -39,6 +40,8 @@ var _ = Describe("RouteList", func() {
 			},
 			Entry("invalid order_by", "order_by=foo", "one of"),
 			Entry("per_page is not a number", "per_page=foo", "value must be an integer"),
+			Entry("port is not a number", "ports=1024,foo", "value must be an integer"),
+			Entry("port is out of range", "ports=65536", "must be no greater than 65535"),
 		)
 	})
 
@@ -55,6 +58,7 @@ var _ = Describe("RouteList", func() {
 				DomainGUIDs: "dg1,dg2",
 				Hosts:       "h1,h2",
 				Paths:       "p1,p2",
+				Ports:       "1024,1025",
 				OrderBy:     "created_at",
 				Pagination: payloads.Pagination{
 					PerPage: "10",
@@ -74,6 +78,7 @@ var _ = Describe("RouteList", func() {
 				DomainGUIDs: []string{"dg1", "dg2"},
 				Hosts:       []string{"h1", "h2"},
 				Paths:       []string{"p1", "p2"},
+				Ports:       []int32{1024, 1025},
 				OrderBy:     "created_at",
 				Pagination: repositories.Pagination{
 					PerPage: 10,
@@ -97,7 +102,7 @@ var _ = Describe("RouteCreate", func() {
 		createPayload = payloads.RouteCreate{
 			Host: "h1",
 			Path: "p1",
-			Port: 8080,
+			Port: tools.PtrTo[int32](8080),
 			Relationships: &payloads.RouteRelationships{
 				Domain: payloads.Relationship{
 					Data: &payloads.RelationshipData{
@@ -132,9 +137,46 @@ var _ = Describe("RouteCreate", func() {
 			createPayload.Host = ""
 		})
 
+		It("succeeds, as tcp routes have no host", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("port is out of range", func() {
+		BeforeEach(func() {
+			createPayload.Port = tools.PtrTo[int32](65536)
+		})
+
 		It("fails", func() {
 			Expect(apiError).To(HaveOccurred())
-			Expect(apiError.Detail()).To(ContainSubstring("host cannot be blank"))
+			Expect(apiError.Detail()).To(ContainSubstring("port must be no greater than 65535"))
+		})
+	})
+
+	When("port is zero", func() {
+		BeforeEach(func() {
+			createPayload.Port = tools.PtrTo[int32](0)
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("port cannot be blank"))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the payload to a create route message", func() {
+			Expect(createPayload.ToMessage("domain-ns", "domain.com")).To(Equal(repositories.CreateRouteMessage{
+				Host:            "h1",
+				Path:            "p1",
+				Port:            8080,
+				SpaceGUID:       "s1",
+				DomainGUID:      "d1",
+				DomainNamespace: "domain-ns",
+				DomainName:      "domain.com",
+				Labels:          map[string]string{"l": "lv"},
+				Annotations:     map[string]string{"a": "av"},
+			}))
 		})
 	})
 
@@ -0,0 +1,32 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type RouterGroupList struct {
+	Name string
+}
+
+func (l *RouterGroupList) ToMessage() repositories.ListRouterGroupsMessage {
+	message := repositories.ListRouterGroupsMessage{}
+	if l.Name != "" {
+		message.Names = []string{l.Name}
+	}
+	return message
+}
+
+func (l *RouterGroupList) SupportedKeys() []string {
+	return []string{"name"}
+}
+
+func (l *RouterGroupList) DecodeFromURLValues(values url.Values) error {
+	l.Name = values.Get("name")
+	return nil
+}
+
+func (l RouterGroupList) Validate() error {
+	return nil
+}
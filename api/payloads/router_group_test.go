@@ -0,0 +1,29 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouterGroupList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedRouterGroupList payloads.RouterGroupList) {
+			actualRouterGroupList, decodeErr := decodeQuery[payloads.RouterGroupList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualRouterGroupList).To(Equal(expectedRouterGroupList))
+		},
+		Entry("name", "name=default-tcp", payloads.RouterGroupList{Name: "default-tcp"}),
+		Entry("empty", "", payloads.RouterGroupList{}),
+	)
+
+	DescribeTable("ToMessage",
+		func(routerGroupList payloads.RouterGroupList, expectedMessage repositories.ListRouterGroupsMessage) {
+			Expect(routerGroupList.ToMessage()).To(Equal(expectedMessage))
+		},
+		Entry("name", payloads.RouterGroupList{Name: "default-tcp"}, repositories.ListRouterGroupsMessage{Names: []string{"default-tcp"}}),
+		Entry("no name", payloads.RouterGroupList{}, repositories.ListRouterGroupsMessage{}),
+	)
+})
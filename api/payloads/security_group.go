@@ -1,9 +1,15 @@
 package payloads
 
 import (
+	"fmt"
+	"net/url"
 	"slices"
 
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	"github.com/BooleanCat/go-functional/v2/it"
 	jellidation "github.com/jellydator/validation"
 )
@@ -43,18 +49,6 @@ func (c SecurityGroupCreate) Validate() error {
 }
 
 func (c SecurityGroupCreate) ToMessage() repositories.CreateSecurityGroupMessage {
-	rules := slices.Collect(it.Map(slices.Values(c.Rules), func(r SecurityGroupRule) repositories.SecurityGroupRule {
-		return repositories.SecurityGroupRule{
-			Protocol:    r.Protocol,
-			Destination: r.Destination,
-			Ports:       r.Ports,
-			Type:        r.Type,
-			Code:        r.Code,
-			Description: r.Description,
-			Log:         r.Log,
-		}
-	}))
-
 	spaces := make(map[string]repositories.SecurityGroupWorkloads)
 	runningSpaces := slices.Collect(it.Map(slices.Values(c.Relationships.RunningSpaces.Data), func(d RelationshipData) string { return d.GUID }))
 	stagingSpaces := slices.Collect(it.Map(slices.Values(c.Relationships.StagingSpaces.Data), func(d RelationshipData) string { return d.GUID }))
@@ -73,7 +67,7 @@ func (c SecurityGroupCreate) ToMessage() repositories.CreateSecurityGroupMessage
 
 	return repositories.CreateSecurityGroupMessage{
 		DisplayName: c.DisplayName,
-		Rules:       rules,
+		Rules:       toSecurityGroupRules(c.Rules),
 		GloballyEnabled: repositories.SecurityGroupWorkloads{
 			Running: c.GloballyEnabled.Running,
 			Staging: c.GloballyEnabled.Staging,
@@ -81,3 +75,181 @@ func (c SecurityGroupCreate) ToMessage() repositories.CreateSecurityGroupMessage
 		Spaces: spaces,
 	}
 }
+
+type SecurityGroupWorkloadsPatch struct {
+	Running *bool `json:"running"`
+	Staging *bool `json:"staging"`
+}
+
+type SecurityGroupUpdate struct {
+	DisplayName     *string                     `json:"name"`
+	Rules           *[]SecurityGroupRule        `json:"rules"`
+	GloballyEnabled SecurityGroupWorkloadsPatch `json:"globally_enabled"`
+}
+
+func (u SecurityGroupUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.DisplayName, jellidation.NilOrNotEmpty),
+	)
+}
+
+func (u SecurityGroupUpdate) ToMessage(guid string) repositories.UpdateSecurityGroupMessage {
+	message := repositories.UpdateSecurityGroupMessage{
+		GUID:        guid,
+		DisplayName: u.DisplayName,
+		GloballyEnabled: repositories.UpdateSecurityGroupWorkloads{
+			Running: u.GloballyEnabled.Running,
+			Staging: u.GloballyEnabled.Staging,
+		},
+	}
+
+	if u.Rules != nil {
+		message.Rules = tools.PtrTo(toSecurityGroupRules(*u.Rules))
+	}
+
+	return message
+}
+
+type SecurityGroupBind ToManyRelationship
+
+func (b SecurityGroupBind) Validate() error {
+	return jellidation.ValidateStruct(&b,
+		jellidation.Field(&b.Data, jellidation.Required),
+	)
+}
+
+func (b SecurityGroupBind) ToMessage(guid string, workloads string) repositories.BindSecurityGroupMessage {
+	return repositories.BindSecurityGroupMessage{
+		GUID:       guid,
+		SpaceGUIDs: b.SpaceGUIDs(),
+		Workloads:  workloads,
+	}
+}
+
+func (b SecurityGroupBind) SpaceGUIDs() []string {
+	return slices.Collect(it.Map(slices.Values(b.Data), func(d RelationshipData) string { return d.GUID }))
+}
+
+type SecurityGroupList struct {
+	GUIDs                  string
+	Names                  string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	RunningSpaceGUIDs      string
+	StagingSpaceGUIDs      string
+	OrderBy                string
+	Pagination             Pagination
+}
+
+func (l *SecurityGroupList) ToMessage() repositories.ListSecurityGroupsMessage {
+	return repositories.ListSecurityGroupsMessage{
+		GUIDs:                  parse.ArrayParam(l.GUIDs),
+		Names:                  parse.ArrayParam(l.Names),
+		GloballyEnabledRunning: l.GloballyEnabledRunning,
+		GloballyEnabledStaging: l.GloballyEnabledStaging,
+		RunningSpaceGUIDs:      parse.ArrayParam(l.RunningSpaceGUIDs),
+		StagingSpaceGUIDs:      parse.ArrayParam(l.StagingSpaceGUIDs),
+		OrderBy:                l.OrderBy,
+		Pagination:             l.Pagination.ToMessage(DefaultPageSize),
+	}
+}
+
+func (l *SecurityGroupList) SupportedKeys() []string {
+	return []string{
+		"guids",
+		"names",
+		"globally_enabled_running",
+		"globally_enabled_staging",
+		"running_space_guids",
+		"staging_space_guids",
+		"order_by",
+		"per_page",
+		"page",
+	}
+}
+
+func (l *SecurityGroupList) DecodeFromURLValues(values url.Values) error {
+	var err error
+
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.RunningSpaceGUIDs = values.Get("running_space_guids")
+	l.StagingSpaceGUIDs = values.Get("staging_space_guids")
+	l.OrderBy = values.Get("order_by")
+
+	l.GloballyEnabledRunning, err = parseBool(values.Get("globally_enabled_running"))
+	if err != nil {
+		return fmt.Errorf("failed to parse 'globally_enabled_running' query parameter: %w", err)
+	}
+
+	l.GloballyEnabledStaging, err = parseBool(values.Get("globally_enabled_staging"))
+	if err != nil {
+		return fmt.Errorf("failed to parse 'globally_enabled_staging' query parameter: %w", err)
+	}
+
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func (l SecurityGroupList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "updated_at", "name")),
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+type SpaceSecurityGroupList struct {
+	GUIDs      string
+	Names      string
+	OrderBy    string
+	Pagination Pagination
+}
+
+func (l *SpaceSecurityGroupList) ToMessage(spaceGUID string, workloads string) repositories.ListSecurityGroupsMessage {
+	message := repositories.ListSecurityGroupsMessage{
+		GUIDs:                  parse.ArrayParam(l.GUIDs),
+		Names:                  parse.ArrayParam(l.Names),
+		IncludeGloballyEnabled: true,
+		OrderBy:                l.OrderBy,
+		Pagination:             l.Pagination.ToMessage(DefaultPageSize),
+	}
+
+	if workloads == korifiv1alpha1.SecurityGroupStagingWorkloads {
+		message.StagingSpaceGUIDs = []string{spaceGUID}
+	} else {
+		message.RunningSpaceGUIDs = []string{spaceGUID}
+	}
+
+	return message
+}
+
+func (l *SpaceSecurityGroupList) SupportedKeys() []string {
+	return []string{"guids", "names", "order_by", "per_page", "page"}
+}
+
+func (l *SpaceSecurityGroupList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrderBy = values.Get("order_by")
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func (l SpaceSecurityGroupList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "updated_at", "name")),
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func toSecurityGroupRules(rules []SecurityGroupRule) []repositories.SecurityGroupRule {
+	return slices.Collect(it.Map(slices.Values(rules), func(r SecurityGroupRule) repositories.SecurityGroupRule {
+		return repositories.SecurityGroupRule{
+			Protocol:    r.Protocol,
+			Destination: r.Destination,
+			Ports:       r.Ports,
+			Type:        r.Type,
+			Code:        r.Code,
+			Description: r.Description,
+			Log:         r.Log,
+		}
+	}))
+}
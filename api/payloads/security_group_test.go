@@ -4,6 +4,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
@@ -92,3 +93,210 @@ var _ = Describe("SecurityGroupCreate", func() {
 		})
 	})
 })
+
+var _ = Describe("SecurityGroupUpdate", func() {
+	var (
+		updatePayload       payloads.SecurityGroupUpdate
+		securityGroupUpdate *payloads.SecurityGroupUpdate
+		validatorErr        error
+	)
+
+	BeforeEach(func() {
+		securityGroupUpdate = new(payloads.SecurityGroupUpdate)
+		updatePayload = payloads.SecurityGroupUpdate{
+			DisplayName: tools.PtrTo("new-name"),
+			Rules: &[]payloads.SecurityGroupRule{{
+				Protocol:    korifiv1alpha1.ProtocolUDP,
+				Ports:       "53",
+				Destination: "10.0.0.1",
+			}},
+			GloballyEnabled: payloads.SecurityGroupWorkloadsPatch{
+				Running: tools.PtrTo(true),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), securityGroupUpdate)
+	})
+
+	It("succeeds with valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(securityGroupUpdate).To(PointTo(Equal(updatePayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			updatePayload.DisplayName = tools.PtrTo("")
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(updatePayload.ToMessage("sg-guid")).To(Equal(repositories.UpdateSecurityGroupMessage{
+				GUID:        "sg-guid",
+				DisplayName: tools.PtrTo("new-name"),
+				Rules: &[]repositories.SecurityGroupRule{{
+					Protocol:    korifiv1alpha1.ProtocolUDP,
+					Ports:       "53",
+					Destination: "10.0.0.1",
+				}},
+				GloballyEnabled: repositories.UpdateSecurityGroupWorkloads{
+					Running: tools.PtrTo(true),
+				},
+			}))
+		})
+
+		When("the rules are not specified", func() {
+			BeforeEach(func() {
+				updatePayload.Rules = nil
+			})
+
+			It("leaves the rules unchanged", func() {
+				Expect(updatePayload.ToMessage("sg-guid").Rules).To(BeNil())
+			})
+		})
+	})
+})
+
+var _ = Describe("SecurityGroupBind", func() {
+	var (
+		bindPayload       payloads.SecurityGroupBind
+		securityGroupBind *payloads.SecurityGroupBind
+		validatorErr      error
+	)
+
+	BeforeEach(func() {
+		securityGroupBind = new(payloads.SecurityGroupBind)
+		bindPayload = payloads.SecurityGroupBind{
+			Data: []payloads.RelationshipData{{GUID: "space1"}, {GUID: "space2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(bindPayload), securityGroupBind)
+	})
+
+	It("succeeds with valid payload", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(securityGroupBind).To(PointTo(Equal(bindPayload)))
+	})
+
+	When("the data is empty", func() {
+		BeforeEach(func() {
+			bindPayload.Data = []payloads.RelationshipData{}
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+
+	It("converts to a repo message", func() {
+		Expect(bindPayload.ToMessage("sg-guid", korifiv1alpha1.SecurityGroupStagingWorkloads)).To(Equal(repositories.BindSecurityGroupMessage{
+			GUID:       "sg-guid",
+			SpaceGUIDs: []string{"space1", "space2"},
+			Workloads:  korifiv1alpha1.SecurityGroupStagingWorkloads,
+		}))
+	})
+})
+
+var _ = Describe("SecurityGroupList", func() {
+	Describe("Validation", func() {
+		DescribeTable("valid query",
+			func(query string, expectedSecurityGroupList payloads.SecurityGroupList) {
+				actualSecurityGroupList, decodeErr := decodeQuery[payloads.SecurityGroupList](query)
+
+				Expect(decodeErr).NotTo(HaveOccurred())
+				Expect(*actualSecurityGroupList).To(Equal(expectedSecurityGroupList))
+			},
+			Entry("guids", "guids=g1,g2", payloads.SecurityGroupList{GUIDs: "g1,g2"}),
+			Entry("names", "names=n1,n2", payloads.SecurityGroupList{Names: "n1,n2"}),
+			Entry("globally_enabled_running", "globally_enabled_running=true", payloads.SecurityGroupList{GloballyEnabledRunning: tools.PtrTo(true)}),
+			Entry("globally_enabled_staging", "globally_enabled_staging=false", payloads.SecurityGroupList{GloballyEnabledStaging: tools.PtrTo(false)}),
+			Entry("running_space_guids", "running_space_guids=s1,s2", payloads.SecurityGroupList{RunningSpaceGUIDs: "s1,s2"}),
+			Entry("staging_space_guids", "staging_space_guids=s1,s2", payloads.SecurityGroupList{StagingSpaceGUIDs: "s1,s2"}),
+			Entry("order_by created_at", "order_by=created_at", payloads.SecurityGroupList{OrderBy: "created_at"}),
+			Entry("order_by -updated_at", "order_by=-updated_at", payloads.SecurityGroupList{OrderBy: "-updated_at"}),
+			Entry("order_by name", "order_by=name", payloads.SecurityGroupList{OrderBy: "name"}),
+			Entry("page=3", "page=3", payloads.SecurityGroupList{Pagination: payloads.Pagination{Page: "3"}}),
+		)
+
+		DescribeTable("invalid query",
+			func(query string, expectedErrMsg string) {
+				_, decodeErr := decodeQuery[payloads.SecurityGroupList](query)
+				Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+			},
+			Entry("invalid order_by", "order_by=foo", "value must be one of"),
+			Entry("invalid globally_enabled_running", "globally_enabled_running=foo", "failed to parse"),
+			Entry("per_page is not a number", "per_page=foo", "value must be an integer"),
+		)
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			securityGroupList := payloads.SecurityGroupList{
+				GUIDs:                  "g1,g2",
+				Names:                  "n1,n2",
+				GloballyEnabledRunning: tools.PtrTo(true),
+				GloballyEnabledStaging: tools.PtrTo(false),
+				RunningSpaceGUIDs:      "s1",
+				StagingSpaceGUIDs:      "s2",
+				OrderBy:                "name",
+				Pagination: payloads.Pagination{
+					PerPage: "3",
+					Page:    "2",
+				},
+			}
+			Expect(securityGroupList.ToMessage()).To(Equal(repositories.ListSecurityGroupsMessage{
+				GUIDs:                  []string{"g1", "g2"},
+				Names:                  []string{"n1", "n2"},
+				GloballyEnabledRunning: tools.PtrTo(true),
+				GloballyEnabledStaging: tools.PtrTo(false),
+				RunningSpaceGUIDs:      []string{"s1"},
+				StagingSpaceGUIDs:      []string{"s2"},
+				OrderBy:                "name",
+				Pagination: repositories.Pagination{
+					Page:    2,
+					PerPage: 3,
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("SpaceSecurityGroupList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedSecurityGroupList payloads.SpaceSecurityGroupList) {
+			actualSecurityGroupList, decodeErr := decodeQuery[payloads.SpaceSecurityGroupList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualSecurityGroupList).To(Equal(expectedSecurityGroupList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.SpaceSecurityGroupList{GUIDs: "g1,g2"}),
+		Entry("names", "names=n1,n2", payloads.SpaceSecurityGroupList{Names: "n1,n2"}),
+		Entry("order_by name", "order_by=name", payloads.SpaceSecurityGroupList{OrderBy: "name"}),
+	)
+
+	DescribeTable("ToMessage",
+		func(workloads string, expectedRunningSpaceGUIDs, expectedStagingSpaceGUIDs []string) {
+			securityGroupList := payloads.SpaceSecurityGroupList{Names: "n1"}
+			Expect(securityGroupList.ToMessage("space-guid", workloads)).To(Equal(repositories.ListSecurityGroupsMessage{
+				Names:                  []string{"n1"},
+				RunningSpaceGUIDs:      expectedRunningSpaceGUIDs,
+				StagingSpaceGUIDs:      expectedStagingSpaceGUIDs,
+				IncludeGloballyEnabled: true,
+				Pagination: repositories.Pagination{
+					Page:    1,
+					PerPage: 50,
+				},
+			}))
+		},
+		Entry("running", korifiv1alpha1.SecurityGroupRunningWorkloads, []string{"space-guid"}, nil),
+		Entry("staging", korifiv1alpha1.SecurityGroupStagingWorkloads, nil, []string{"space-guid"}),
+	)
+})
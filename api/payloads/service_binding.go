@@ -17,19 +17,20 @@ type ServiceBindingCreate struct {
 	Name          *string                      `json:"name"`
 }
 
-func (p ServiceBindingCreate) ToMessage(spaceGUID string) repositories.CreateServiceBindingMessage {
+func (p ServiceBindingCreate) ToMessage(spaceGUID, serviceInstanceSpaceGUID string) repositories.CreateServiceBindingMessage {
 	var appGUID string
 	if p.Relationships.App != nil {
 		appGUID = p.Relationships.App.Data.GUID
 	}
 
 	return repositories.CreateServiceBindingMessage{
-		Name:                p.Name,
-		ServiceInstanceGUID: p.Relationships.ServiceInstance.Data.GUID,
-		AppGUID:             appGUID,
-		SpaceGUID:           spaceGUID,
-		Parameters:          p.Parameters,
-		Type:                p.Type,
+		Name:                     p.Name,
+		ServiceInstanceGUID:      p.Relationships.ServiceInstance.Data.GUID,
+		ServiceInstanceSpaceGUID: serviceInstanceSpaceGUID,
+		AppGUID:                  appGUID,
+		SpaceGUID:                spaceGUID,
+		Parameters:               p.Parameters,
+		Type:                     p.Type,
 	}
 }
 
@@ -234,16 +234,17 @@ var _ = Describe("ServiceBindingCreate", func() {
 		var createMessage repositories.CreateServiceBindingMessage
 
 		JustBeforeEach(func() {
-			createMessage = createPayload.ToMessage("space-guid")
+			createMessage = createPayload.ToMessage("space-guid", "instance-space-guid")
 		})
 
 		It("creates the message", func() {
 			Expect(createMessage).To(Equal(repositories.CreateServiceBindingMessage{
-				Name:                createPayload.Name,
-				ServiceInstanceGUID: createPayload.Relationships.ServiceInstance.Data.GUID,
-				AppGUID:             createPayload.Relationships.App.Data.GUID,
-				SpaceGUID:           "space-guid",
-				Type:                "app",
+				Name:                     createPayload.Name,
+				ServiceInstanceGUID:      createPayload.Relationships.ServiceInstance.Data.GUID,
+				ServiceInstanceSpaceGUID: "instance-space-guid",
+				AppGUID:                  createPayload.Relationships.App.Data.GUID,
+				SpaceGUID:                "space-guid",
+				Type:                     "app",
 				Parameters: map[string]any{
 					"p1": "p1-value",
 				},
@@ -5,23 +5,27 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 
 	"code.cloudfoundry.org/korifi/api/payloads/params"
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 	jellidation "github.com/jellydator/validation"
 )
 
 type ServiceInstanceCreate struct {
-	Name          string                        `json:"name"`
-	Type          string                        `json:"type"`
-	Tags          []string                      `json:"tags"`
-	Credentials   map[string]any                `json:"credentials"`
-	Parameters    map[string]any                `json:"parameters"`
-	Relationships *ServiceInstanceRelationships `json:"relationships"`
-	Metadata      Metadata                      `json:"metadata"`
+	Name            string                        `json:"name"`
+	Type            string                        `json:"type"`
+	Tags            []string                      `json:"tags"`
+	Credentials     map[string]any                `json:"credentials"`
+	RouteServiceURL string                        `json:"route_service_url"`
+	SyslogDrainURL  string                        `json:"syslog_drain_url"`
+	Parameters      map[string]any                `json:"parameters"`
+	Relationships   *ServiceInstanceRelationships `json:"relationships"`
+	Metadata        Metadata                      `json:"metadata"`
 }
 
 const maxTagsLength = 2048
@@ -43,11 +47,71 @@ func validateTagLength(tags any) error {
 	return nil
 }
 
+func validateRouteServiceURL(value any) error {
+	routeServiceURL, ok := value.(string)
+	if !ok {
+		routeServiceURLPtr, ok := value.(*string)
+		if !ok {
+			return errors.New("wrong input")
+		}
+		routeServiceURL = tools.ZeroIfNil(routeServiceURLPtr)
+	}
+
+	if routeServiceURL == "" {
+		return nil
+	}
+
+	u, err := url.ParseRequestURI(routeServiceURL)
+	if err != nil || u.Host == "" {
+		return errors.New("must be a valid url")
+	}
+
+	if u.Scheme != "https" {
+		return errors.New("must use https")
+	}
+
+	return nil
+}
+
+func validateSyslogDrainURL(value any) error {
+	syslogDrainURL, ok := value.(string)
+	if !ok {
+		syslogDrainURLPtr, ok := value.(*string)
+		if !ok {
+			return errors.New("wrong input")
+		}
+		syslogDrainURL = tools.ZeroIfNil(syslogDrainURLPtr)
+	}
+
+	if syslogDrainURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(syslogDrainURL)
+	if err != nil || u.Host == "" {
+		return errors.New("must be a valid url")
+	}
+
+	if !slices.Contains([]string{"syslog", "syslog-tls", "https"}, u.Scheme) {
+		return errors.New("must use one of the syslog, syslog-tls or https schemes")
+	}
+
+	return nil
+}
+
 func (c ServiceInstanceCreate) Validate() error {
 	return jellidation.ValidateStruct(&c,
 		jellidation.Field(&c.Name, jellidation.Required),
 		jellidation.Field(&c.Type, jellidation.Required, validation.OneOf("user-provided", "managed")),
 		jellidation.Field(&c.Tags, jellidation.By(validateTagLength)),
+		jellidation.Field(&c.RouteServiceURL,
+			jellidation.When(c.Type == "managed", jellidation.Empty.Error("is only supported for user-provided service instances")),
+			jellidation.By(validateRouteServiceURL),
+		),
+		jellidation.Field(&c.SyslogDrainURL,
+			jellidation.When(c.Type == "managed", jellidation.Empty.Error("is only supported for user-provided service instances")),
+			jellidation.By(validateSyslogDrainURL),
+		),
 		jellidation.Field(&c.Relationships, jellidation.NotNil, jellidation.By(func(r any) error {
 			rel := r.(*ServiceInstanceRelationships)
 			if c.Type == "user-provided" {
@@ -62,12 +126,14 @@ func (c ServiceInstanceCreate) Validate() error {
 
 func (p ServiceInstanceCreate) ToUPSICreateMessage() repositories.CreateUPSIMessage {
 	return repositories.CreateUPSIMessage{
-		Name:        p.Name,
-		SpaceGUID:   p.Relationships.Space.Data.GUID,
-		Credentials: p.Credentials,
-		Tags:        p.Tags,
-		Labels:      p.Metadata.Labels,
-		Annotations: p.Metadata.Annotations,
+		Name:            p.Name,
+		SpaceGUID:       p.Relationships.Space.Data.GUID,
+		Credentials:     p.Credentials,
+		RouteServiceURL: p.RouteServiceURL,
+		SyslogDrainURL:  p.SyslogDrainURL,
+		Tags:            p.Tags,
+		Labels:          p.Metadata.Labels,
+		Annotations:     p.Metadata.Annotations,
 	}
 }
 
@@ -171,30 +237,85 @@ func (g *ServiceInstanceGet) DecodeFromURLValues(values url.Values) error {
 }
 
 type ServiceInstancePatch struct {
-	Name        *string         `json:"name,omitempty"`
-	Tags        *[]string       `json:"tags,omitempty"`
-	Credentials *map[string]any `json:"credentials,omitempty"`
-	Metadata    MetadataPatch   `json:"metadata"`
+	Name            *string                            `json:"name,omitempty"`
+	Tags            *[]string                          `json:"tags,omitempty"`
+	Credentials     *map[string]any                    `json:"credentials,omitempty"`
+	RouteServiceURL *string                            `json:"route_service_url,omitempty"`
+	SyslogDrainURL  *string                            `json:"syslog_drain_url,omitempty"`
+	Parameters      *map[string]any                    `json:"parameters,omitempty"`
+	MaintenanceInfo *ServiceInstanceMaintenanceInfo    `json:"maintenance_info,omitempty"`
+	Relationships   *ServiceInstancePatchRelationships `json:"relationships,omitempty"`
+	Metadata        MetadataPatch                      `json:"metadata"`
+}
+
+type ServiceInstanceMaintenanceInfo struct {
+	Version string `json:"version"`
+}
+
+func (m ServiceInstanceMaintenanceInfo) Validate() error {
+	return jellidation.ValidateStruct(&m,
+		jellidation.Field(&m.Version, jellidation.Required),
+	)
+}
+
+type ServiceInstancePatchRelationships struct {
+	ServicePlan *Relationship `json:"service_plan"`
+}
+
+func (r ServiceInstancePatchRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.ServicePlan, jellidation.NotNil),
+	)
 }
 
 func (p ServiceInstancePatch) Validate() error {
 	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.RouteServiceURL, jellidation.By(validateRouteServiceURL)),
+		jellidation.Field(&p.SyslogDrainURL, jellidation.By(validateSyslogDrainURL)),
+		jellidation.Field(&p.MaintenanceInfo),
+		jellidation.Field(&p.Relationships),
 		jellidation.Field(&p.Metadata),
 	)
 }
 
+// IsManagedUpdate returns true if the patch requires the service instance
+// to be updated by its broker
+func (p ServiceInstancePatch) IsManagedUpdate() bool {
+	return p.Parameters != nil || p.MaintenanceInfo != nil || p.PlanGUID() != nil
+}
+
+func (p ServiceInstancePatch) PlanGUID() *string {
+	if p.Relationships == nil {
+		return nil
+	}
+
+	return &p.Relationships.ServicePlan.Data.GUID
+}
+
 func (p ServiceInstancePatch) ToServiceInstancePatchMessage(spaceGUID, appGUID string) repositories.PatchServiceInstanceMessage {
-	return repositories.PatchServiceInstanceMessage{
-		SpaceGUID:   spaceGUID,
-		GUID:        appGUID,
-		Name:        p.Name,
-		Credentials: p.Credentials,
-		Tags:        p.Tags,
+	message := repositories.PatchServiceInstanceMessage{
+		SpaceGUID:       spaceGUID,
+		GUID:            appGUID,
+		Name:            p.Name,
+		Credentials:     p.Credentials,
+		RouteServiceURL: p.RouteServiceURL,
+		SyslogDrainURL:  p.SyslogDrainURL,
+		Tags:            p.Tags,
+		PlanGUID:        p.PlanGUID(),
+		Parameters:      p.Parameters,
 		MetadataPatch: repositories.MetadataPatch{
 			Labels:      p.Metadata.Labels,
 			Annotations: p.Metadata.Annotations,
 		},
 	}
+
+	if p.MaintenanceInfo != nil {
+		message.MaintenanceInfo = &repositories.MaintenanceInfo{
+			Version: p.MaintenanceInfo.Version,
+		}
+	}
+
+	return message
 }
 
 func (p *ServiceInstancePatch) UnmarshalJSON(data []byte) error {
@@ -363,3 +484,18 @@ func (d *ServiceInstanceDelete) DecodeFromURLValues(values url.Values) error {
 
 	return nil
 }
+
+type ServiceInstanceShare ToManyRelationship
+
+func (s ServiceInstanceShare) Validate() error {
+	return jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.Data, jellidation.Required),
+	)
+}
+
+func (s ServiceInstanceShare) ToMessage(guid string) repositories.ShareServiceInstanceMessage {
+	return repositories.ShareServiceInstanceMessage{
+		GUID:       guid,
+		SpaceGUIDs: relationshipGUIDs(ToManyRelationship(s)),
+	}
+}
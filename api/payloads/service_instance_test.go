@@ -294,6 +294,68 @@ var _ = Describe("ServiceInstanceCreate", func() {
 			})
 		})
 
+		When("the route service url is set", func() {
+			BeforeEach(func() {
+				createPayload.RouteServiceURL = "https://route-service.example.com"
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(serviceInstanceCreate).To(PointTo(Equal(createPayload)))
+			})
+
+			When("the route service url is not a valid url", func() {
+				BeforeEach(func() {
+					createPayload.RouteServiceURL = "not-a-url"
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "route_service_url must be a valid url")
+				})
+			})
+
+			When("the route service url does not use https", func() {
+				BeforeEach(func() {
+					createPayload.RouteServiceURL = "http://route-service.example.com"
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "route_service_url must use https")
+				})
+			})
+		})
+
+		When("the syslog drain url is set", func() {
+			BeforeEach(func() {
+				createPayload.SyslogDrainURL = "syslog-tls://logs.example.com:6514"
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(serviceInstanceCreate).To(PointTo(Equal(createPayload)))
+			})
+
+			When("the syslog drain url is not a valid url", func() {
+				BeforeEach(func() {
+					createPayload.SyslogDrainURL = "not-a-url"
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "syslog_drain_url must be a valid url")
+				})
+			})
+
+			When("the syslog drain url uses an unsupported scheme", func() {
+				BeforeEach(func() {
+					createPayload.SyslogDrainURL = "http://logs.example.com"
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "syslog_drain_url must use one of the syslog, syslog-tls or https schemes")
+				})
+			})
+		})
+
 		When("the instance type is managed", func() {
 			BeforeEach(func() {
 				createPayload.Type = "managed"
@@ -319,6 +381,26 @@ var _ = Describe("ServiceInstanceCreate", func() {
 					expectUnprocessableEntityError(validatorErr, "relationships.service_plan is required")
 				})
 			})
+
+			When("the route service url is set", func() {
+				BeforeEach(func() {
+					createPayload.RouteServiceURL = "https://route-service.example.com"
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "route_service_url is only supported for user-provided service instances")
+				})
+			})
+
+			When("the syslog drain url is set", func() {
+				BeforeEach(func() {
+					createPayload.SyslogDrainURL = "syslog://logs.example.com:514"
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "syslog_drain_url is only supported for user-provided service instances")
+				})
+			})
 		})
 	})
 
@@ -348,6 +430,8 @@ var _ = Describe("ServiceInstanceCreate", func() {
 					Annotations: map[string]string{"ann1": "val_ann1"},
 					Labels:      map[string]string{"lab1": "val_lab1"},
 				},
+				RouteServiceURL: "https://route-service.example.com",
+				SyslogDrainURL:  "syslog://logs.example.com:514",
 			}
 		})
 
@@ -359,6 +443,8 @@ var _ = Describe("ServiceInstanceCreate", func() {
 			Expect(msg.Name).To(Equal("service-instance-name"))
 			Expect(msg.SpaceGUID).To(Equal("space-guid"))
 			Expect(msg.Tags).To(ConsistOf("foo", "bar"))
+			Expect(msg.RouteServiceURL).To(Equal("https://route-service.example.com"))
+			Expect(msg.SyslogDrainURL).To(Equal("syslog://logs.example.com:514"))
 			Expect(msg.Annotations).To(HaveLen(1))
 			Expect(msg.Annotations).To(HaveKeyWithValue("ann1", "val_ann1"))
 			Expect(msg.Labels).To(HaveLen(1))
@@ -531,6 +617,85 @@ var _ = Describe("ServiceInstancePatch", func() {
 		})
 	})
 
+	When("the route service url is invalid", func() {
+		BeforeEach(func() {
+			patchPayload.RouteServiceURL = tools.PtrTo("http://route-service.example.com")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "route_service_url must use https")
+		})
+	})
+
+	When("the syslog drain url is invalid", func() {
+		BeforeEach(func() {
+			patchPayload.SyslogDrainURL = tools.PtrTo("ftp://logs.example.com")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "syslog_drain_url must use one of the syslog, syslog-tls or https schemes")
+		})
+	})
+
+	When("managed service instance fields are set", func() {
+		BeforeEach(func() {
+			patchPayload = payloads.ServiceInstancePatch{
+				Parameters: &map[string]any{"p1": "v1"},
+				MaintenanceInfo: &payloads.ServiceInstanceMaintenanceInfo{
+					Version: "1.2.3",
+				},
+				Relationships: &payloads.ServiceInstancePatchRelationships{
+					ServicePlan: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "plan-guid"},
+					},
+				},
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceInstancePatch).To(PointTo(Equal(patchPayload)))
+			Expect(serviceInstancePatch.IsManagedUpdate()).To(BeTrue())
+		})
+
+		It("converts to repo message correctly", func() {
+			msg := serviceInstancePatch.ToServiceInstancePatchMessage("space-guid", "app-guid")
+			Expect(msg.PlanGUID).To(PointTo(Equal("plan-guid")))
+			Expect(msg.Parameters).To(PointTo(Equal(map[string]any{"p1": "v1"})))
+			Expect(msg.MaintenanceInfo).To(PointTo(Equal(repositories.MaintenanceInfo{Version: "1.2.3"})))
+		})
+
+		When("the maintenance info version is empty", func() {
+			BeforeEach(func() {
+				patchPayload.MaintenanceInfo.Version = ""
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "maintenance_info.version cannot be blank")
+			})
+		})
+
+		When("the service plan relationship is missing", func() {
+			BeforeEach(func() {
+				patchPayload.Relationships.ServicePlan = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "relationships.service_plan is required")
+			})
+		})
+
+		When("the service plan guid is missing", func() {
+			BeforeEach(func() {
+				patchPayload.Relationships.ServicePlan.Data.GUID = ""
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+			})
+		})
+	})
+
 	Context("ToServiceInstancePatchMessage", func() {
 		It("converts to repo message correctly", func() {
 			msg := serviceInstancePatch.ToServiceInstancePatchMessage("space-guid", "app-guid")
@@ -549,6 +714,9 @@ var _ = Describe("ServiceInstancePatch", func() {
 					"a": Equal("b"),
 				}),
 			})))
+			Expect(msg.PlanGUID).To(BeNil())
+			Expect(msg.Parameters).To(BeNil())
+			Expect(msg.MaintenanceInfo).To(BeNil())
 		})
 	})
 })
@@ -573,3 +741,44 @@ var _ = Describe("ServiceInstanceDelete", func() {
 		Entry("invalid value for purge", "purge=foo", "invalid syntax"),
 	)
 })
+
+var _ = Describe("ServiceInstanceShare", func() {
+	var (
+		payload        payloads.ServiceInstanceShare
+		decodedPayload *payloads.ServiceInstanceShare
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.ServiceInstanceShare{
+			Data: []payloads.RelationshipData{{GUID: "space1"}, {GUID: "space2"}},
+		}
+		decodedPayload = new(payloads.ServiceInstanceShare)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(PointTo(Equal(payload)))
+	})
+
+	When("no spaces are specified", func() {
+		BeforeEach(func() {
+			payload.Data = nil
+		})
+
+		It("returns an error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+
+	It("translates to repo message", func() {
+		Expect(payload.ToMessage("instance-guid")).To(Equal(repositories.ShareServiceInstanceMessage{
+			GUID:       "instance-guid",
+			SpaceGUIDs: []string{"space1", "space2"},
+		}))
+	})
+})
@@ -0,0 +1,83 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type ServiceRouteBindingCreate struct {
+	Relationships *ServiceRouteBindingRelationships `json:"relationships"`
+	Parameters    map[string]any                    `json:"parameters"`
+	Metadata      Metadata                          `json:"metadata"`
+}
+
+func (p ServiceRouteBindingCreate) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Relationships, jellidation.NotNil),
+		jellidation.Field(&p.Metadata),
+	)
+}
+
+func (p ServiceRouteBindingCreate) ToMessage(spaceGUID string) repositories.CreateServiceRouteBindingMessage {
+	return repositories.CreateServiceRouteBindingMessage{
+		ServiceInstanceGUID: p.Relationships.ServiceInstance.Data.GUID,
+		RouteGUID:           p.Relationships.Route.Data.GUID,
+		SpaceGUID:           spaceGUID,
+		Parameters:          p.Parameters,
+		Labels:              p.Metadata.Labels,
+		Annotations:         p.Metadata.Annotations,
+	}
+}
+
+type ServiceRouteBindingRelationships struct {
+	ServiceInstance *Relationship `json:"service_instance"`
+	Route           *Relationship `json:"route"`
+}
+
+func (r ServiceRouteBindingRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.ServiceInstance, jellidation.NotNil),
+		jellidation.Field(&r.Route, jellidation.NotNil),
+	)
+}
+
+type ServiceRouteBindingList struct {
+	ServiceInstanceGUIDs string
+	RouteGUIDs           string
+	LabelSelector        string
+	OrderBy              string
+	Pagination           Pagination
+}
+
+func (l ServiceRouteBindingList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func (l *ServiceRouteBindingList) ToMessage() repositories.ListServiceRouteBindingsMessage {
+	return repositories.ListServiceRouteBindingsMessage{
+		ServiceInstanceGUIDs: parse.ArrayParam(l.ServiceInstanceGUIDs),
+		RouteGUIDs:           parse.ArrayParam(l.RouteGUIDs),
+		LabelSelector:        l.LabelSelector,
+		OrderBy:              l.OrderBy,
+		Pagination:           l.Pagination.ToMessage(DefaultPageSize),
+	}
+}
+
+func (l *ServiceRouteBindingList) SupportedKeys() []string {
+	return []string{"service_instance_guids", "route_guids", "label_selector", "order_by", "per_page", "page"}
+}
+
+func (l *ServiceRouteBindingList) DecodeFromURLValues(values url.Values) error {
+	l.ServiceInstanceGUIDs = values.Get("service_instance_guids")
+	l.RouteGUIDs = values.Get("route_guids")
+	l.LabelSelector = values.Get("label_selector")
+	l.OrderBy = values.Get("order_by")
+	return l.Pagination.DecodeFromURLValues(values)
+}
@@ -0,0 +1,151 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("ServiceRouteBindingList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedServiceRouteBindingList payloads.ServiceRouteBindingList) {
+			actualServiceRouteBindingList, decodeErr := decodeQuery[payloads.ServiceRouteBindingList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualServiceRouteBindingList).To(Equal(expectedServiceRouteBindingList))
+		},
+		Entry("service_instance_guids", "service_instance_guids=si_guid", payloads.ServiceRouteBindingList{ServiceInstanceGUIDs: "si_guid"}),
+		Entry("route_guids", "route_guids=route_guid", payloads.ServiceRouteBindingList{RouteGUIDs: "route_guid"}),
+		Entry("label_selector=foo", "label_selector=foo", payloads.ServiceRouteBindingList{LabelSelector: "foo"}),
+		Entry("order_by created_at", "order_by=created_at", payloads.ServiceRouteBindingList{OrderBy: "created_at"}),
+		Entry("order_by -updated_at", "order_by=-updated_at", payloads.ServiceRouteBindingList{OrderBy: "-updated_at"}),
+		Entry("page=3", "page=3", payloads.ServiceRouteBindingList{Pagination: payloads.Pagination{Page: "3"}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, errMatcher types.GomegaMatcher) {
+			_, decodeErr := decodeQuery[payloads.ServiceRouteBindingList](query)
+			Expect(decodeErr).To(errMatcher)
+		},
+		Entry("invalid order_by", "order_by=foo", MatchError(ContainSubstring("value must be one of"))),
+		Entry("per_page is not a number", "per_page=foo", MatchError(ContainSubstring("value must be an integer"))),
+	)
+
+	Describe("ToMessage", func() {
+		It("returns a list service route bindings message", func() {
+			payload := payloads.ServiceRouteBindingList{
+				ServiceInstanceGUIDs: "s1,s2",
+				RouteGUIDs:           "r1,r2",
+				LabelSelector:        "foo=bar",
+				OrderBy:              "created_at",
+				Pagination: payloads.Pagination{
+					Page:    "1",
+					PerPage: "20",
+				},
+			}
+
+			Expect(payload.ToMessage()).To(Equal(repositories.ListServiceRouteBindingsMessage{
+				ServiceInstanceGUIDs: []string{"s1", "s2"},
+				RouteGUIDs:           []string{"r1", "r2"},
+				LabelSelector:        "foo=bar",
+				OrderBy:              "created_at",
+				Pagination: repositories.Pagination{
+					Page:    1,
+					PerPage: 20,
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("ServiceRouteBindingCreate", func() {
+	var (
+		createPayload             payloads.ServiceRouteBindingCreate
+		serviceRouteBindingCreate *payloads.ServiceRouteBindingCreate
+		validatorErr              error
+	)
+
+	BeforeEach(func() {
+		serviceRouteBindingCreate = new(payloads.ServiceRouteBindingCreate)
+		createPayload = payloads.ServiceRouteBindingCreate{
+			Relationships: &payloads.ServiceRouteBindingRelationships{
+				ServiceInstance: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "service-instance-guid"},
+				},
+				Route: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "route-guid"},
+				},
+			},
+			Parameters: map[string]any{"foo": "bar"},
+			Metadata: payloads.Metadata{
+				Labels:      map[string]string{"lab1": "val1"},
+				Annotations: map[string]string{"ann1": "val1"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), serviceRouteBindingCreate)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(serviceRouteBindingCreate).To(PointTo(Equal(createPayload)))
+	})
+
+	When("relationships are not set", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	When("the service instance relationship is not set", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.ServiceInstance = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships.service_instance is required")
+		})
+	})
+
+	When("the route relationship is not set", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.Route = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships.route is required")
+		})
+	})
+
+	When("metadata is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Metadata.Labels["foo.cloudfoundry.org/bar"] = "baz"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "label/annotation key cannot use the cloudfoundry.org domain")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a create message", func() {
+			Expect(createPayload.ToMessage("space-guid")).To(Equal(repositories.CreateServiceRouteBindingMessage{
+				ServiceInstanceGUID: "service-instance-guid",
+				RouteGUID:           "route-guid",
+				SpaceGUID:           "space-guid",
+				Parameters:          map[string]any{"foo": "bar"},
+				Labels:              map[string]string{"lab1": "val1"},
+				Annotations:         map[string]string{"ann1": "val1"},
+			}))
+		})
+	})
+})
@@ -0,0 +1,64 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type ServiceUsageEventList struct {
+	GUIDs                string
+	ServiceInstanceTypes string
+	ServiceOfferingGUIDs string
+	CreatedAts           map[repositories.TimestampOperator]string
+	OrderBy              string
+	Pagination           Pagination
+}
+
+func (l *ServiceUsageEventList) SupportedKeys() []string {
+	return []string{
+		"guids",
+		"service_instance_types",
+		"service_offering_guids",
+		"created_ats",
+		"created_ats[lt]",
+		"created_ats[lte]",
+		"created_ats[gt]",
+		"created_ats[gte]",
+		"order_by",
+		"page",
+		"per_page",
+	}
+}
+
+func (l ServiceUsageEventList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.CreatedAts, jellidation.Each(jellidation.By(validateTimestamps))),
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "updated_at")),
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func (l *ServiceUsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.ServiceInstanceTypes = values.Get("service_instance_types")
+	l.ServiceOfferingGUIDs = values.Get("service_offering_guids")
+	l.CreatedAts = decodeCreatedAts(values)
+	l.OrderBy = values.Get("order_by")
+
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func (l *ServiceUsageEventList) ToMessage() repositories.ListServiceUsageEventsMessage {
+	return repositories.ListServiceUsageEventsMessage{
+		GUIDs:                parse.ArrayParam(l.GUIDs),
+		ServiceInstanceTypes: parse.ArrayParam(l.ServiceInstanceTypes),
+		ServiceOfferingGUIDs: parse.ArrayParam(l.ServiceOfferingGUIDs),
+		CreatedAts:           toTimestampFilters(l.CreatedAts),
+		OrderBy:              usageEventsOrderBy(l.OrderBy),
+		Pagination:           l.Pagination.ToMessage(DefaultPageSize),
+	}
+}
@@ -0,0 +1,64 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceUsageEventList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedServiceUsageEventList payloads.ServiceUsageEventList) {
+			actualServiceUsageEventList, decodeErr := decodeQuery[payloads.ServiceUsageEventList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualServiceUsageEventList).To(Equal(expectedServiceUsageEventList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.ServiceUsageEventList{GUIDs: "g1,g2"}),
+		Entry("service_instance_types", "service_instance_types=managed_service_instance", payloads.ServiceUsageEventList{ServiceInstanceTypes: "managed_service_instance"}),
+		Entry("service_offering_guids", "service_offering_guids=o1,o2", payloads.ServiceUsageEventList{ServiceOfferingGUIDs: "o1,o2"}),
+		Entry("created_ats", "created_ats=2024-01-01T00:00:00Z", payloads.ServiceUsageEventList{
+			CreatedAts: map[repositories.TimestampOperator]string{
+				repositories.TimestampOperatorEqual: "2024-01-01T00:00:00Z",
+			},
+		}),
+		Entry("order_by updated_at", "order_by=updated_at", payloads.ServiceUsageEventList{OrderBy: "updated_at"}),
+		Entry("pagination", "per_page=10", payloads.ServiceUsageEventList{Pagination: payloads.Pagination{PerPage: "10"}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.ServiceUsageEventList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid created_ats", "created_ats[gt]=tomorrow", "is not a valid timestamp"),
+		Entry("invalid order_by", "order_by=state", "value must be one of"),
+		Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+	)
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			serviceUsageEventList := payloads.ServiceUsageEventList{
+				GUIDs:                "g1",
+				ServiceInstanceTypes: "managed_service_instance,user_provided_service_instance",
+				ServiceOfferingGUIDs: "o1",
+				Pagination: payloads.Pagination{
+					PerPage: "3",
+					Page:    "2",
+				},
+			}
+			Expect(serviceUsageEventList.ToMessage()).To(Equal(repositories.ListServiceUsageEventsMessage{
+				GUIDs:                []string{"g1"},
+				ServiceInstanceTypes: []string{"managed_service_instance", "user_provided_service_instance"},
+				ServiceOfferingGUIDs: []string{"o1"},
+				OrderBy:              "created_at",
+				Pagination: repositories.Pagination{
+					Page:    2,
+					PerPage: 3,
+				},
+			}))
+		})
+	})
+})
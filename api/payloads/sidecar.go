@@ -0,0 +1,96 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	jellidation "github.com/jellydator/validation"
+)
+
+type SidecarCreate struct {
+	Name         string   `json:"name"`
+	Command      string   `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	MemoryInMB   *int64   `json:"memory_in_mb"`
+}
+
+func (c SidecarCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+		jellidation.Field(&c.Command, jellidation.Required),
+		jellidation.Field(&c.ProcessTypes, jellidation.Required, jellidation.Each(jellidation.Required)),
+		jellidation.Field(&c.MemoryInMB, jellidation.Min(int64(1)), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+	)
+}
+
+func (c SidecarCreate) ToMessage(appRecord repositories.AppRecord) repositories.CreateSidecarMessage {
+	return repositories.CreateSidecarMessage{
+		AppGUID:      appRecord.GUID,
+		SpaceGUID:    appRecord.SpaceGUID,
+		Name:         c.Name,
+		Command:      c.Command,
+		ProcessTypes: c.ProcessTypes,
+		MemoryMB:     tools.ZeroIfNil(c.MemoryInMB),
+	}
+}
+
+type SidecarUpdate struct {
+	Name         *string  `json:"name"`
+	Command      *string  `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	MemoryInMB   *int64   `json:"memory_in_mb"`
+}
+
+func (u SidecarUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.Command, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.ProcessTypes, jellidation.NilOrNotEmpty, jellidation.Each(jellidation.Required)),
+		jellidation.Field(&u.MemoryInMB, jellidation.Min(int64(1)), jellidation.NilOrNotEmpty.Error("must be no less than 1")),
+	)
+}
+
+func (u SidecarUpdate) ToMessage(sidecarGUID string) repositories.PatchSidecarMessage {
+	return repositories.PatchSidecarMessage{
+		GUID:         sidecarGUID,
+		Name:         u.Name,
+		Command:      u.Command,
+		ProcessTypes: u.ProcessTypes,
+		MemoryMB:     u.MemoryInMB,
+	}
+}
+
+type AppSidecarsList struct {
+	OrderBy    string
+	Pagination Pagination
+}
+
+func (l *AppSidecarsList) SupportedKeys() []string {
+	return []string{
+		"order_by",
+		"page",
+		"per_page",
+	}
+}
+
+func (l AppSidecarsList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at", "updated_at", "name")),
+		jellidation.Field(&l.Pagination),
+	)
+}
+
+func (l *AppSidecarsList) DecodeFromURLValues(values url.Values) error {
+	l.OrderBy = values.Get("order_by")
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func (l *AppSidecarsList) ToMessage(appGUID string) repositories.ListSidecarsMessage {
+	return repositories.ListSidecarsMessage{
+		AppGUIDs:   []string{appGUID},
+		OrderBy:    l.OrderBy,
+		Pagination: l.Pagination.ToMessage(DefaultPageSize),
+	}
+}
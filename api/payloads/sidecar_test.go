@@ -0,0 +1,227 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("SidecarCreate", func() {
+	var payload payloads.SidecarCreate
+
+	BeforeEach(func() {
+		payload = payloads.SidecarCreate{
+			Name:         "my-sidecar",
+			Command:      "bin/my-sidecar",
+			ProcessTypes: []string{"web", "worker"},
+			MemoryInMB:   tools.PtrTo[int64](64),
+		}
+	})
+
+	Describe("Validate", func() {
+		var (
+			decodedPayload *payloads.SidecarCreate
+			validatorErr   error
+		)
+
+		JustBeforeEach(func() {
+			decodedPayload = new(payloads.SidecarCreate)
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+
+		When("the name is not set", func() {
+			BeforeEach(func() {
+				payload.Name = ""
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+			})
+		})
+
+		When("the command is not set", func() {
+			BeforeEach(func() {
+				payload.Command = ""
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "command cannot be blank")
+			})
+		})
+
+		When("the process types are not set", func() {
+			BeforeEach(func() {
+				payload.ProcessTypes = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "process_types cannot be blank")
+			})
+		})
+
+		When("a process type is blank", func() {
+			BeforeEach(func() {
+				payload.ProcessTypes = []string{"web", ""}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "process_types1 cannot be blank")
+			})
+		})
+
+		When("the memory is not set", func() {
+			BeforeEach(func() {
+				payload.MemoryInMB = nil
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the memory is not positive", func() {
+			BeforeEach(func() {
+				payload.MemoryInMB = tools.PtrTo[int64](0)
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "memory_in_mb must be no less than 1")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to repo message correctly", func() {
+			Expect(payload.ToMessage(repositories.AppRecord{GUID: "app-guid", SpaceGUID: "space-guid"})).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Name:         "my-sidecar",
+				Command:      "bin/my-sidecar",
+				ProcessTypes: []string{"web", "worker"},
+				MemoryMB:     64,
+			}))
+		})
+	})
+})
+
+var _ = Describe("SidecarUpdate", func() {
+	var payload payloads.SidecarUpdate
+
+	BeforeEach(func() {
+		payload = payloads.SidecarUpdate{
+			Command:      tools.PtrTo("bin/new-command"),
+			ProcessTypes: []string{"worker"},
+		}
+	})
+
+	Describe("Validate", func() {
+		var (
+			decodedPayload *payloads.SidecarUpdate
+			validatorErr   error
+		)
+
+		JustBeforeEach(func() {
+			decodedPayload = new(payloads.SidecarUpdate)
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+
+		When("the name is blank", func() {
+			BeforeEach(func() {
+				payload.Name = tools.PtrTo("")
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+			})
+		})
+
+		When("the process types are empty", func() {
+			BeforeEach(func() {
+				payload.ProcessTypes = []string{}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "process_types cannot be blank")
+			})
+		})
+
+		When("the memory is not positive", func() {
+			BeforeEach(func() {
+				payload.MemoryInMB = tools.PtrTo[int64](0)
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "memory_in_mb must be no less than 1")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to repo message correctly", func() {
+			Expect(payload.ToMessage("sidecar-guid")).To(Equal(repositories.PatchSidecarMessage{
+				GUID:         "sidecar-guid",
+				Command:      tools.PtrTo("bin/new-command"),
+				ProcessTypes: []string{"worker"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("AppSidecarsList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedSidecarsList payloads.AppSidecarsList) {
+			actualSidecarsList, decodeErr := decodeQuery[payloads.AppSidecarsList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualSidecarsList).To(Equal(expectedSidecarsList))
+		},
+		Entry("order_by created_at", "order_by=created_at", payloads.AppSidecarsList{OrderBy: "created_at"}),
+		Entry("order_by -updated_at", "order_by=-updated_at", payloads.AppSidecarsList{OrderBy: "-updated_at"}),
+		Entry("order_by name", "order_by=name", payloads.AppSidecarsList{OrderBy: "name"}),
+		Entry("pagination", "page=3", payloads.AppSidecarsList{Pagination: payloads.Pagination{Page: "3"}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AppSidecarsList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid order_by", "order_by=foo", "value must be one of"),
+		Entry("invalid parameter", "foo=bar", "unsupported query parameter: foo"),
+		Entry("invalid pagination", "per_page=foo", "value must be an integer"),
+	)
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			sidecarsList := payloads.AppSidecarsList{
+				OrderBy: "name",
+				Pagination: payloads.Pagination{
+					PerPage: "3",
+					Page:    "2",
+				},
+			}
+			Expect(sidecarsList.ToMessage("app-guid")).To(Equal(repositories.ListSidecarsMessage{
+				AppGUIDs: []string{"app-guid"},
+				OrderBy:  "name",
+				Pagination: repositories.Pagination{
+					Page:    2,
+					PerPage: 3,
+				},
+			}))
+		})
+	})
+})
@@ -0,0 +1,122 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type SpaceQuotaRelationships struct {
+	Organization *Relationship      `json:"organization"`
+	Spaces       ToManyRelationship `json:"spaces"`
+}
+
+func (r SpaceQuotaRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Organization, jellidation.NotNil),
+	)
+}
+
+type SpaceQuotaCreate struct {
+	Name          string                   `json:"name"`
+	Apps          QuotaApps                `json:"apps"`
+	Services      QuotaServices            `json:"services"`
+	Routes        QuotaRoutes              `json:"routes"`
+	Relationships *SpaceQuotaRelationships `json:"relationships"`
+}
+
+func (c SpaceQuotaCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required, jellidation.Length(1, 250)),
+		jellidation.Field(&c.Apps),
+		jellidation.Field(&c.Services),
+		jellidation.Field(&c.Routes),
+		jellidation.Field(&c.Relationships, jellidation.NotNil),
+	)
+}
+
+func (c SpaceQuotaCreate) ToMessage() repositories.CreateSpaceQuotaMessage {
+	return repositories.CreateSpaceQuotaMessage{
+		Name:       c.Name,
+		OrgGUID:    c.Relationships.Organization.Data.GUID,
+		Limits:     toQuotaLimits(c.Apps, c.Services, c.Routes),
+		SpaceGUIDs: relationshipGUIDs(c.Relationships.Spaces),
+	}
+}
+
+type SpaceQuotaUpdate struct {
+	Name     *string       `json:"name"`
+	Apps     QuotaApps     `json:"apps"`
+	Services QuotaServices `json:"services"`
+	Routes   QuotaRoutes   `json:"routes"`
+}
+
+func (u SpaceQuotaUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Name, jellidation.NilOrNotEmpty, jellidation.Length(1, 250)),
+		jellidation.Field(&u.Apps),
+		jellidation.Field(&u.Services),
+		jellidation.Field(&u.Routes),
+	)
+}
+
+func (u SpaceQuotaUpdate) ToMessage(guid string) repositories.UpdateSpaceQuotaMessage {
+	return repositories.UpdateSpaceQuotaMessage{
+		GUID:   guid,
+		Name:   u.Name,
+		Limits: toQuotaLimitsPatch(u.Apps, u.Services, u.Routes),
+	}
+}
+
+type SpaceQuotaApply ToManyRelationship
+
+func (a SpaceQuotaApply) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.Data, jellidation.Required),
+	)
+}
+
+func (a SpaceQuotaApply) ToMessage(guid string) repositories.ApplySpaceQuotaMessage {
+	return repositories.ApplySpaceQuotaMessage{
+		GUID:       guid,
+		SpaceGUIDs: relationshipGUIDs(ToManyRelationship(a)),
+	}
+}
+
+type SpaceQuotaList struct {
+	GUIDs      string
+	Names      string
+	OrgGUIDs   string
+	SpaceGUIDs string
+	Pagination Pagination
+}
+
+func (l *SpaceQuotaList) ToMessage() repositories.ListSpaceQuotasMessage {
+	return repositories.ListSpaceQuotasMessage{
+		GUIDs:      parse.ArrayParam(l.GUIDs),
+		Names:      parse.ArrayParam(l.Names),
+		OrgGUIDs:   parse.ArrayParam(l.OrgGUIDs),
+		SpaceGUIDs: parse.ArrayParam(l.SpaceGUIDs),
+		Pagination: l.Pagination.ToMessage(DefaultPageSize),
+	}
+}
+
+func (l *SpaceQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "space_guids", "per_page", "page"}
+}
+
+func (l *SpaceQuotaList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrgGUIDs = values.Get("organization_guids")
+	l.SpaceGUIDs = values.Get("space_guids")
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func (l SpaceQuotaList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Pagination),
+	)
+}
@@ -0,0 +1,162 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("SpaceQuotaCreate", func() {
+	var (
+		createPayload    payloads.SpaceQuotaCreate
+		spaceQuotaCreate *payloads.SpaceQuotaCreate
+		validatorErr     error
+	)
+
+	BeforeEach(func() {
+		spaceQuotaCreate = new(payloads.SpaceQuotaCreate)
+		createPayload = payloads.SpaceQuotaCreate{
+			Name: "my-quota",
+			Apps: payloads.QuotaApps{
+				TotalMemoryInMB: tools.PtrTo[int64](1024),
+			},
+			Routes: payloads.QuotaRoutes{
+				TotalRoutes: tools.PtrTo[int32](4),
+			},
+			Relationships: &payloads.SpaceQuotaRelationships{
+				Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+				Spaces:       payloads.ToManyRelationship{Data: []payloads.RelationshipData{{GUID: "space-guid"}}},
+			},
+		}
+	})
+
+	Describe("Validation", func() {
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), spaceQuotaCreate)
+		})
+
+		It("succeeds with valid payload", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(spaceQuotaCreate).To(PointTo(Equal(createPayload)))
+		})
+
+		When("the name is empty", func() {
+			BeforeEach(func() {
+				createPayload.Name = ""
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+			})
+		})
+
+		When("relationships are not specified", func() {
+			BeforeEach(func() {
+				createPayload.Relationships = nil
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "relationships is required")
+			})
+		})
+
+		When("the organization relationship is not specified", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.Organization = nil
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "relationships.organization is required")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateSpaceQuotaMessage{
+				Name:    "my-quota",
+				OrgGUID: "org-guid",
+				Limits: repositories.QuotaLimits{
+					Apps: repositories.QuotaAppsLimits{
+						TotalMemoryInMB: tools.PtrTo[int64](1024),
+					},
+					Services: repositories.QuotaServicesLimits{
+						PaidServicesAllowed: true,
+					},
+					Routes: repositories.QuotaRoutesLimits{
+						TotalRoutes: tools.PtrTo[int32](4),
+					},
+				},
+				SpaceGUIDs: []string{"space-guid"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("SpaceQuotaUpdate", func() {
+	It("translates to repo message", func() {
+		updatePayload := payloads.SpaceQuotaUpdate{
+			Name: tools.PtrTo("new-name"),
+			Services: payloads.QuotaServices{
+				TotalServiceInstances: tools.PtrTo[int32](2),
+			},
+		}
+
+		Expect(updatePayload.ToMessage("quota-guid")).To(Equal(repositories.UpdateSpaceQuotaMessage{
+			GUID: "quota-guid",
+			Name: tools.PtrTo("new-name"),
+			Limits: repositories.QuotaLimitsPatch{
+				TotalServiceInstances: tools.PtrTo[int32](2),
+			},
+		}))
+	})
+})
+
+var _ = Describe("SpaceQuotaApply", func() {
+	It("translates to repo message", func() {
+		applyPayload := payloads.SpaceQuotaApply{
+			Data: []payloads.RelationshipData{{GUID: "space1"}, {GUID: "space2"}},
+		}
+
+		Expect(applyPayload.ToMessage("quota-guid")).To(Equal(repositories.ApplySpaceQuotaMessage{
+			GUID:       "quota-guid",
+			SpaceGUIDs: []string{"space1", "space2"},
+		}))
+	})
+})
+
+var _ = Describe("SpaceQuotaList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedSpaceQuotaList payloads.SpaceQuotaList) {
+			actualSpaceQuotaList, decodeErr := decodeQuery[payloads.SpaceQuotaList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualSpaceQuotaList).To(Equal(expectedSpaceQuotaList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.SpaceQuotaList{GUIDs: "g1,g2"}),
+		Entry("names", "names=n1,n2", payloads.SpaceQuotaList{Names: "n1,n2"}),
+		Entry("organization_guids", "organization_guids=o1,o2", payloads.SpaceQuotaList{OrgGUIDs: "o1,o2"}),
+		Entry("space_guids", "space_guids=s1,s2", payloads.SpaceQuotaList{SpaceGUIDs: "s1,s2"}),
+	)
+
+	Describe("ToMessage", func() {
+		It("translates to repo message", func() {
+			spaceQuotaList := payloads.SpaceQuotaList{
+				GUIDs:      "g1",
+				Names:      "n1",
+				OrgGUIDs:   "o1",
+				SpaceGUIDs: "s1,s2",
+			}
+			Expect(spaceQuotaList.ToMessage()).To(Equal(repositories.ListSpaceQuotasMessage{
+				GUIDs:      []string{"g1"},
+				Names:      []string{"n1"},
+				OrgGUIDs:   []string{"o1"},
+				SpaceGUIDs: []string{"s1", "s2"},
+				Pagination: repositories.Pagination{PerPage: 50, Page: 1},
+			}))
+		})
+	})
+})
@@ -187,3 +187,17 @@ type AppSSHEnabled struct {
 	Enabled bool   `json:"enabled"`
 	Reason  string `json:"reason"`
 }
+
+type FeatureResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func ForAppSSHFeature(app repositories.AppRecord) FeatureResponse {
+	return FeatureResponse{
+		Name:        "ssh",
+		Description: "Enable SSHing into the app.",
+		Enabled:     app.EnableSSH,
+	}
+}
@@ -18,6 +18,7 @@ func ForAppManifest(appState manifest.AppState) payloads.ManifestApplication {
 		Processes: toManifestProcesses(appState.Processes),
 		Routes:    toManifestRoutes(appState.Routes),
 		Services:  toManifestServices(appState.ServiceBindings),
+		Sidecars:  toManifestSidecars(appState.Sidecars),
 	}
 
 	if appState.Droplet != nil && appState.Droplet.Lifecycle.Type == "docker" {
@@ -37,7 +38,7 @@ func toManifestRoutes(routes map[string]repositories.RouteRecord) []payloads.Man
 
 func toManifestProcesses(processes map[string]repositories.ProcessRecord) []payloads.ManifestApplicationProcess {
 	return slices.Collect(it.Right(it.Map2(maps.All(processes), func(i string, record repositories.ProcessRecord) (string, payloads.ManifestApplicationProcess) {
-		return i, payloads.ManifestApplicationProcess{
+		manifestProcess := payloads.ManifestApplicationProcess{
 			Type:                         i,
 			Command:                      tools.PtrTo(record.Command),
 			DiskQuota:                    tools.PtrTo(strconv.FormatInt(record.DiskQuotaMB, 10)),
@@ -48,6 +49,27 @@ func toManifestProcesses(processes map[string]repositories.ProcessRecord) []payl
 			Memory:                       tools.PtrTo(strconv.FormatInt(record.MemoryMB, 10)),
 			Timeout:                      tools.PtrTo(record.HealthCheck.Data.TimeoutSeconds),
 		}
+
+		if record.HealthCheck.Data.IntervalSeconds != 0 {
+			manifestProcess.HealthCheckInterval = tools.PtrTo(record.HealthCheck.Data.IntervalSeconds)
+		}
+		if record.HealthCheck.Data.FailureThreshold != 0 {
+			manifestProcess.HealthCheckFailureThreshold = tools.PtrTo(record.HealthCheck.Data.FailureThreshold)
+		}
+		if record.ReadinessHealthCheck.Type != "" {
+			manifestProcess.ReadinessHealthCheckType = tools.PtrTo(record.ReadinessHealthCheck.Type)
+		}
+		if record.ReadinessHealthCheck.Data.HTTPEndpoint != "" {
+			manifestProcess.ReadinessHealthCheckHTTPEndpoint = tools.PtrTo(record.ReadinessHealthCheck.Data.HTTPEndpoint)
+		}
+		if record.ReadinessHealthCheck.Data.InvocationTimeoutSeconds != 0 {
+			manifestProcess.ReadinessHealthCheckInvocationTimeout = tools.PtrTo(record.ReadinessHealthCheck.Data.InvocationTimeoutSeconds)
+		}
+		if record.ReadinessHealthCheck.Data.IntervalSeconds != 0 {
+			manifestProcess.ReadinessHealthCheckInterval = tools.PtrTo(record.ReadinessHealthCheck.Data.IntervalSeconds)
+		}
+
+		return i, manifestProcess
 	})))
 }
 
@@ -59,3 +81,19 @@ func toManifestServices(serviceBindings map[string]repositories.ServiceBindingRe
 		}
 	})))
 }
+
+func toManifestSidecars(sidecars map[string]repositories.SidecarRecord) []payloads.ManifestApplicationSidecar {
+	return slices.Collect(it.Map(maps.Values(sidecars), func(record repositories.SidecarRecord) payloads.ManifestApplicationSidecar {
+		manifestSidecar := payloads.ManifestApplicationSidecar{
+			Name:         record.Name,
+			ProcessTypes: record.ProcessTypes,
+			Command:      record.Command,
+		}
+
+		if record.MemoryMB != 0 {
+			manifestSidecar.Memory = tools.PtrTo(strconv.FormatInt(record.MemoryMB, 10) + "M")
+		}
+
+		return manifestSidecar
+	}))
+}
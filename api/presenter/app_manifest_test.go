@@ -34,6 +34,14 @@ var _ = Describe("ForAppManifest", func() {
 						HTTPEndpoint:             "/health",
 						InvocationTimeoutSeconds: 60,
 						TimeoutSeconds:           20,
+						IntervalSeconds:          10,
+					},
+				},
+				ReadinessHealthCheck: repositories.ReadinessHealthCheck{
+					Type: "http",
+					Data: repositories.ReadinessHealthCheckData{
+						HTTPEndpoint:    "/ready",
+						IntervalSeconds: 5,
 					},
 				},
 			}},
@@ -43,6 +51,12 @@ var _ = Describe("ForAppManifest", func() {
 				Name:                tools.PtrTo("service-name"),
 				ServiceInstanceGUID: "instance-guid",
 			}},
+			Sidecars: map[string]repositories.SidecarRecord{"proxy": {
+				Name:         "proxy",
+				Command:      "bin/proxy",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     64,
+			}},
 			Droplet: &repositories.DropletRecord{
 				Lifecycle: repositories.Lifecycle{
 					Type: "docker",
@@ -61,12 +75,18 @@ var _ = Describe("ForAppManifest", func() {
 			"Name":   Equal("bob"),
 			"Docker": HaveKeyWithValue("image", "docker-image"),
 			"Processes": ContainElement(MatchFields(IgnoreExtras, Fields{
-				"Type":                         Equal("web"),
-				"HealthCheckInvocationTimeout": PointTo(BeEquivalentTo(60)),
-				"HealthCheckType":              PointTo(Equal("foo")),
-				"Instances":                    PointTo(Equal(int32(10))),
-				"Memory":                       PointTo(Equal("512")),
-				"Timeout":                      PointTo(Equal(int32(20))),
+				"Type":                                  Equal("web"),
+				"HealthCheckInvocationTimeout":          PointTo(BeEquivalentTo(60)),
+				"HealthCheckType":                       PointTo(Equal("foo")),
+				"Instances":                             PointTo(Equal(int32(10))),
+				"Memory":                                PointTo(Equal("512")),
+				"Timeout":                               PointTo(Equal(int32(20))),
+				"HealthCheckInterval":                   PointTo(Equal(int32(10))),
+				"HealthCheckFailureThreshold":           BeNil(),
+				"ReadinessHealthCheckType":              PointTo(Equal("http")),
+				"ReadinessHealthCheckHTTPEndpoint":      PointTo(Equal("/ready")),
+				"ReadinessHealthCheckInvocationTimeout": BeNil(),
+				"ReadinessHealthCheckInterval":          PointTo(Equal(int32(5))),
 			})),
 			"Routes": ContainElement(MatchFields(IgnoreExtras, Fields{
 				"Route": PointTo(Equal("route-url")),
@@ -75,6 +95,12 @@ var _ = Describe("ForAppManifest", func() {
 				"Name":        Equal("instance-guid"),
 				"BindingName": PointTo(Equal("service-name")),
 			})),
+			"Sidecars": ConsistOf(payloads.ManifestApplicationSidecar{
+				Name:         "proxy",
+				ProcessTypes: []string{"web"},
+				Command:      "bin/proxy",
+				Memory:       tools.PtrTo("64M"),
+			}),
 		}))
 	})
 })
@@ -0,0 +1,88 @@
+package presenter
+
+import (
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const appUsageEventsBase = "/v3/app_usage_events"
+
+type AppUsageEventResponse struct {
+	GUID                  string                   `json:"guid"`
+	CreatedAt             time.Time                `json:"created_at"`
+	UpdatedAt             time.Time                `json:"updated_at"`
+	State                 UsageEventChange[string] `json:"state"`
+	App                   UsageEventResource       `json:"app"`
+	Process               UsageEventProcess        `json:"process"`
+	Space                 UsageEventResource       `json:"space"`
+	Organization          UsageEventGUIDRef        `json:"organization"`
+	InstanceCount         UsageEventChange[int32]  `json:"instance_count"`
+	MemoryInMBPerInstance UsageEventChange[int64]  `json:"memory_in_mb_per_instance"`
+	Links                 UsageEventLinks          `json:"links"`
+}
+
+// UsageEventChange holds the current and the previous value of a usage event
+// property. The previous value is null when the event does not change it.
+type UsageEventChange[T any] struct {
+	Current  T  `json:"current"`
+	Previous *T `json:"previous"`
+}
+
+type UsageEventResource struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+}
+
+type UsageEventGUIDRef struct {
+	GUID string `json:"guid"`
+}
+
+type UsageEventProcess struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+}
+
+type UsageEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAppUsageEvent(record repositories.AppUsageEventRecord, baseURL url.URL, includes ...include.Resource) AppUsageEventResponse {
+	response := AppUsageEventResponse{
+		GUID:      record.GUID,
+		CreatedAt: tools.ZeroIfNil(toUTC(&record.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(toUTC(record.UpdatedAt)),
+		State:     UsageEventChange[string]{Current: record.State},
+		App: UsageEventResource{
+			GUID: record.AppGUID,
+			Name: record.AppName,
+		},
+		Process: UsageEventProcess{
+			GUID: record.ProcessGUID,
+			Type: record.ProcessType,
+		},
+		Space: UsageEventResource{
+			GUID: record.SpaceGUID,
+			Name: record.SpaceName,
+		},
+		Organization:          UsageEventGUIDRef{GUID: record.OrganizationGUID},
+		InstanceCount:         UsageEventChange[int32]{Current: record.InstanceCount},
+		MemoryInMBPerInstance: UsageEventChange[int64]{Current: record.MemoryInMBPerInstance},
+		Links: UsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(appUsageEventsBase, record.GUID).build(),
+			},
+		},
+	}
+
+	if record.PreviousState != "" {
+		response.State.Previous = tools.PtrTo(record.PreviousState)
+		response.InstanceCount.Previous = tools.PtrTo(record.PreviousInstanceCount)
+		response.MemoryInMBPerInstance.Previous = tools.PtrTo(record.PreviousMemoryInMBPerInstance)
+	}
+
+	return response
+}
@@ -0,0 +1,108 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppUsageEvent", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.AppUsageEventRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.AppUsageEventRecord{
+			GUID:                          "the-app-usage-event-guid",
+			State:                         "SCALED",
+			PreviousState:                 "STARTED",
+			AppGUID:                       "the-app-guid",
+			AppName:                       "my-app",
+			ProcessGUID:                   "the-process-guid",
+			ProcessType:                   "web",
+			SpaceGUID:                     "the-space-guid",
+			SpaceName:                     "my-space",
+			OrganizationGUID:              "the-org-guid",
+			InstanceCount:                 3,
+			PreviousInstanceCount:         1,
+			MemoryInMBPerInstance:         512,
+			PreviousMemoryInMBPerInstance: 256,
+			CreatedAt:                     time.UnixMilli(1000),
+			UpdatedAt:                     tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForAppUsageEvent(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected app usage event json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "the-app-usage-event-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"state": {
+				"current": "SCALED",
+				"previous": "STARTED"
+			},
+			"app": {
+				"guid": "the-app-guid",
+				"name": "my-app"
+			},
+			"process": {
+				"guid": "the-process-guid",
+				"type": "web"
+			},
+			"space": {
+				"guid": "the-space-guid",
+				"name": "my-space"
+			},
+			"organization": {
+				"guid": "the-org-guid"
+			},
+			"instance_count": {
+				"current": 3,
+				"previous": 1
+			},
+			"memory_in_mb_per_instance": {
+				"current": 512,
+				"previous": 256
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/app_usage_events/the-app-usage-event-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the event has no previous state", func() {
+		BeforeEach(func() {
+			record.PreviousState = ""
+			record.PreviousInstanceCount = 0
+			record.PreviousMemoryInMBPerInstance = 0
+		})
+
+		It("presents null previous values", func() {
+			Expect(output).To(MatchJSONPath("$.state.previous", BeNil()))
+			Expect(output).To(MatchJSONPath("$.instance_count.previous", BeNil()))
+			Expect(output).To(MatchJSONPath("$.memory_in_mb_per_instance.previous", BeNil()))
+		})
+	})
+})
@@ -0,0 +1,80 @@
+package presenter
+
+import (
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const auditEventsBase = "/v3/audit_events"
+
+type AuditEventResponse struct {
+	GUID         string             `json:"guid"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+	Type         string             `json:"type"`
+	Actor        AuditEventActor    `json:"actor"`
+	Target       AuditEventTarget   `json:"target"`
+	Data         map[string]any     `json:"data"`
+	Space        *AuditEventGUIDRef `json:"space"`
+	Organization *AuditEventGUIDRef `json:"organization"`
+	Links        AuditEventLinks    `json:"links"`
+}
+
+type AuditEventActor struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventTarget struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventGUIDRef struct {
+	GUID string `json:"guid"`
+}
+
+type AuditEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAuditEvent(record repositories.AuditEventRecord, baseURL url.URL, includes ...include.Resource) AuditEventResponse {
+	return AuditEventResponse{
+		GUID:      record.GUID,
+		CreatedAt: tools.ZeroIfNil(toUTC(&record.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(toUTC(record.UpdatedAt)),
+		Type:      record.Type,
+		Actor: AuditEventActor{
+			GUID: record.Actor.GUID,
+			Type: record.Actor.Type,
+			Name: record.Actor.Name,
+		},
+		Target: AuditEventTarget{
+			GUID: record.Target.GUID,
+			Type: record.Target.Type,
+			Name: record.Target.Name,
+		},
+		Data:         emptyMapIfNil(record.Data),
+		Space:        auditEventGUIDRef(record.SpaceGUID),
+		Organization: auditEventGUIDRef(record.OrganizationGUID),
+		Links: AuditEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(auditEventsBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func auditEventGUIDRef(guid string) *AuditEventGUIDRef {
+	if guid == "" {
+		return nil
+	}
+
+	return &AuditEventGUIDRef{GUID: guid}
+}
@@ -2,11 +2,13 @@ package presenter
 
 import (
 	"net/url"
+	"slices"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/include"
 	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
 )
 
 const (
@@ -14,8 +16,31 @@ const (
 )
 
 type DeploymentStatus struct {
-	Value  string `json:"value"`
-	Reason string `json:"reason"`
+	Value  string                  `json:"value"`
+	Reason string                  `json:"reason"`
+	Canary *DeploymentCanaryStatus `json:"canary,omitempty"`
+}
+
+type DeploymentCanaryStatus struct {
+	Steps DeploymentCanaryStatusSteps `json:"steps"`
+}
+
+type DeploymentCanaryStatusSteps struct {
+	Current int32 `json:"current"`
+	Total   int32 `json:"total"`
+}
+
+type DeploymentOptions struct {
+	MaxInFlight int32                    `json:"max_in_flight"`
+	Canary      *DeploymentCanaryOptions `json:"canary,omitempty"`
+}
+
+type DeploymentCanaryOptions struct {
+	Steps []DeploymentCanaryStep `json:"steps"`
+}
+
+type DeploymentCanaryStep struct {
+	InstanceWeight int32 `json:"instance_weight"`
 }
 
 type DropletGUID struct {
@@ -24,6 +49,8 @@ type DropletGUID struct {
 type DeploymentResponse struct {
 	GUID          string                       `json:"guid"`
 	Status        DeploymentStatus             `json:"status"`
+	Strategy      string                       `json:"strategy"`
+	Options       DeploymentOptions            `json:"options"`
 	Droplet       DropletGUID                  `json:"droplet"`
 	Relationships map[string]ToOneRelationship `json:"relationships"`
 	Links         DeploymentLinks              `json:"links"`
@@ -32,17 +59,45 @@ type DeploymentResponse struct {
 }
 
 type DeploymentLinks struct {
-	Self Link `json:"self"`
-	App  Link `json:"app"`
+	Self     Link `json:"self"`
+	App      Link `json:"app"`
+	Cancel   Link `json:"cancel"`
+	Continue Link `json:"continue"`
 }
 
 func ForDeployment(responseDeployment repositories.DeploymentRecord, baseURL url.URL, includes ...include.Resource) DeploymentResponse {
+	status := DeploymentStatus{
+		Value:  string(responseDeployment.Status.Value),
+		Reason: string(responseDeployment.Status.Reason),
+	}
+	if canaryStatus := responseDeployment.Status.Canary; canaryStatus != nil {
+		status.Canary = &DeploymentCanaryStatus{
+			Steps: DeploymentCanaryStatusSteps{
+				Current: canaryStatus.CurrentStep,
+				Total:   canaryStatus.TotalSteps,
+			},
+		}
+	}
+
+	options := DeploymentOptions{
+		MaxInFlight: responseDeployment.MaxInFlight,
+	}
+	if responseDeployment.Strategy == repositories.DeploymentStrategyCanary {
+		options.Canary = &DeploymentCanaryOptions{
+			Steps: slices.Collect(it.Map(slices.Values(responseDeployment.CanarySteps), func(step repositories.DeploymentCanaryStep) DeploymentCanaryStep {
+				return DeploymentCanaryStep{InstanceWeight: step.InstanceWeight}
+			})),
+		}
+		if options.Canary.Steps == nil {
+			options.Canary.Steps = []DeploymentCanaryStep{}
+		}
+	}
+
 	return DeploymentResponse{
-		GUID: responseDeployment.GUID,
-		Status: DeploymentStatus{
-			Value:  string(responseDeployment.Status.Value),
-			Reason: string(responseDeployment.Status.Reason),
-		},
+		GUID:     responseDeployment.GUID,
+		Status:   status,
+		Strategy: responseDeployment.Strategy,
+		Options:  options,
 		Droplet: DropletGUID{
 			Guid: responseDeployment.DropletGUID,
 		},
@@ -56,6 +111,14 @@ func ForDeployment(responseDeployment repositories.DeploymentRecord, baseURL url
 			App: Link{
 				HRef: buildURL(baseURL).appendPath(appsBase, responseDeployment.GUID).build(),
 			},
+			Cancel: Link{
+				HRef:   buildURL(baseURL).appendPath(deploymentsBase, responseDeployment.GUID, "actions", "cancel").build(),
+				Method: "POST",
+			},
+			Continue: Link{
+				HRef:   buildURL(baseURL).appendPath(deploymentsBase, responseDeployment.GUID, "actions", "continue").build(),
+				Method: "POST",
+			},
 		},
 	}
 }
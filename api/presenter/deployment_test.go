@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -33,6 +34,8 @@ var _ = Describe("Deployments", func() {
 				Value:  "deployment-status-value",
 				Reason: "deployment-status-reason",
 			},
+			Strategy:    "rolling",
+			MaxInFlight: 1,
 		}
 	})
 
@@ -50,6 +53,10 @@ var _ = Describe("Deployments", func() {
 				"value": "deployment-status-value",
 				"reason": "deployment-status-reason"
 			},
+			"strategy": "rolling",
+			"options": {
+				"max_in_flight": 1
+			},
 			"droplet": {
 				"guid": "droplet-guid"
 			},
@@ -68,8 +75,37 @@ var _ = Describe("Deployments", func() {
 				},
 				"app": {
 					"href": "https://api.example.org/v3/apps/app-guid"
+				},
+				"cancel": {
+					"href": "https://api.example.org/v3/deployments/app-guid/actions/cancel",
+					"method": "POST"
+				},
+				"continue": {
+					"href": "https://api.example.org/v3/deployments/app-guid/actions/continue",
+					"method": "POST"
 				}
 			}
 		}`))
 	})
+
+	When("the deployment is a canary", func() {
+		BeforeEach(func() {
+			record.Strategy = "canary"
+			record.MaxInFlight = 2
+			record.CanarySteps = []repositories.DeploymentCanaryStep{{InstanceWeight: 20}, {InstanceWeight: 60}}
+			record.Status.Canary = &repositories.DeploymentCanaryStatus{
+				CurrentStep: 1,
+				TotalSteps:  2,
+			}
+		})
+
+		It("presents the canary options and status", func() {
+			Expect(output).To(MatchJSONPath("$.strategy", "canary"))
+			Expect(output).To(MatchJSONPath("$.options.max_in_flight", BeEquivalentTo(2)))
+			Expect(output).To(MatchJSONPath("$.options.canary.steps[0].instance_weight", BeEquivalentTo(20)))
+			Expect(output).To(MatchJSONPath("$.options.canary.steps[1].instance_weight", BeEquivalentTo(60)))
+			Expect(output).To(MatchJSONPath("$.status.canary.steps.current", BeEquivalentTo(1)))
+			Expect(output).To(MatchJSONPath("$.status.canary.steps.total", BeEquivalentTo(2)))
+		})
+	})
 })
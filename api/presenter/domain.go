@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/include"
 	"code.cloudfoundry.org/korifi/tools"
@@ -14,11 +15,11 @@ const (
 )
 
 type DomainResponse struct {
-	Name               string   `json:"name"`
-	GUID               string   `json:"guid"`
-	Internal           bool     `json:"internal"`
-	RouterGroup        *string  `json:"router_group"`
-	SupportedProtocols []string `json:"supported_protocols"`
+	Name               string             `json:"name"`
+	GUID               string             `json:"guid"`
+	Internal           bool               `json:"internal"`
+	RouterGroup        *DomainRouterGroup `json:"router_group"`
+	SupportedProtocols []string           `json:"supported_protocols"`
 
 	CreatedAt     time.Time           `json:"created_at"`
 	UpdatedAt     time.Time           `json:"updated_at"`
@@ -39,18 +40,32 @@ type DomainRelationships struct {
 }
 
 type Organization struct {
-	Data *string `json:"data"`
+	Data *payloads.RelationshipData `json:"data"`
+}
+
+type DomainRouterGroup struct {
+	GUID string `json:"guid"`
 }
 
 type SharedOrganizations struct {
-	Data []string `json:"data"`
+	Data []payloads.RelationshipData `json:"data"`
+}
+
+type DomainSharedOrganizationsRelationshipResponse struct {
+	Data []payloads.RelationshipData `json:"data"`
+}
+
+func ForDomainSharedOrganizations(domainRecord repositories.DomainRecord) DomainSharedOrganizationsRelationshipResponse {
+	return DomainSharedOrganizationsRelationshipResponse{
+		Data: toManyRelationshipData(domainRecord.SharedOrgGUIDs),
+	}
 }
 
 func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL, includes ...include.Resource) DomainResponse {
-	return DomainResponse{
+	response := DomainResponse{
 		Name:               responseDomain.Name,
 		GUID:               responseDomain.GUID,
-		Internal:           false,
+		Internal:           responseDomain.Internal,
 		RouterGroup:        nil,
 		SupportedProtocols: []string{"http"},
 		CreatedAt:          tools.ZeroIfNil(toUTC(&responseDomain.CreatedAt)),
@@ -65,7 +80,7 @@ func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL, includ
 				Data: nil,
 			},
 			SharedOrganizations: SharedOrganizations{
-				Data: []string{},
+				Data: toManyRelationshipData(responseDomain.SharedOrgGUIDs),
 			},
 		},
 		Links: DomainLinks{
@@ -78,4 +93,18 @@ func ForDomain(responseDomain repositories.DomainRecord, baseURL url.URL, includ
 			RouterGroup: nil,
 		},
 	}
+
+	if responseDomain.OrgGUID != "" {
+		response.Relationships.Organization.Data = &payloads.RelationshipData{GUID: responseDomain.OrgGUID}
+	}
+
+	if responseDomain.RouterGroup != "" {
+		response.RouterGroup = &DomainRouterGroup{GUID: responseDomain.RouterGroup}
+		response.SupportedProtocols = []string{"tcp"}
+		response.Links.RouterGroup = &Link{
+			HRef: buildURL(baseURL).appendPath(routerGroupsBase, responseDomain.RouterGroup).build(),
+		}
+	}
+
+	return response
 }
@@ -82,6 +82,44 @@ var _ = Describe("Domains", func() {
 		}`))
 	})
 
+	When("the domain has a router group", func() {
+		BeforeEach(func() {
+			record.RouterGroup = "default-tcp"
+		})
+
+		It("presents the domain as a tcp domain", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.router_group.guid", "default-tcp"),
+				MatchJSONPath("$.supported_protocols", ConsistOf("tcp")),
+				MatchJSONPath("$.links.router_group.href", "https://api.example.org/routing/v1/router_groups/default-tcp"),
+			))
+		})
+	})
+
+	When("the domain is internal", func() {
+		BeforeEach(func() {
+			record.Internal = true
+		})
+
+		It("presents the domain as internal", func() {
+			Expect(output).To(MatchJSONPath("$.internal", BeTrue()))
+		})
+	})
+
+	When("the domain is private", func() {
+		BeforeEach(func() {
+			record.OrgGUID = "org-guid"
+			record.SharedOrgGUIDs = []string{"org-1", "org-2"}
+		})
+
+		It("presents the owning and shared orgs", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.relationships.organization.data.guid", "org-guid"),
+				MatchJSONPath("$.relationships.shared_organizations.data[*].guid", ConsistOf("org-1", "org-2")),
+			))
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
@@ -102,3 +140,20 @@ var _ = Describe("Domains", func() {
 		})
 	})
 })
+
+var _ = Describe("DomainSharedOrganizations", func() {
+	It("presents the shared orgs", func() {
+		response := presenter.ForDomainSharedOrganizations(repositories.DomainRecord{
+			GUID:           "domain-guid",
+			SharedOrgGUIDs: []string{"org-1", "org-2"},
+		})
+		output, err := json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(MatchJSON(`{
+			"data": [
+				{"guid": "org-1"},
+				{"guid": "org-2"}
+			]
+		}`))
+	})
+})
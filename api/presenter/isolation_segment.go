@@ -0,0 +1,110 @@
+package presenter
+
+import (
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const isolationSegmentsBase = "/v3/isolation_segments"
+
+type IsolationSegmentResponse struct {
+	GUID      string                `json:"guid"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+	Name      string                `json:"name"`
+	Metadata  Metadata              `json:"metadata"`
+	Links     IsolationSegmentLinks `json:"links"`
+}
+
+type IsolationSegmentLinks struct {
+	Self          Link `json:"self"`
+	Organizations Link `json:"organizations"`
+}
+
+func ForIsolationSegment(isolationSegmentRecord repositories.IsolationSegmentRecord, baseURL url.URL, includes ...include.Resource) IsolationSegmentResponse {
+	return IsolationSegmentResponse{
+		GUID:      isolationSegmentRecord.GUID,
+		CreatedAt: tools.ZeroIfNil(toUTC(&isolationSegmentRecord.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(toUTC(isolationSegmentRecord.UpdatedAt)),
+		Name:      isolationSegmentRecord.Name,
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(isolationSegmentRecord.Labels),
+			Annotations: emptyMapIfNil(isolationSegmentRecord.Annotations),
+		},
+		Links: IsolationSegmentLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegmentRecord.GUID).build(),
+			},
+			Organizations: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegmentRecord.GUID, "organizations").build(),
+			},
+		},
+	}
+}
+
+type IsolationSegmentOrganizationsRelationshipResponse struct {
+	Data  []payloads.RelationshipData       `json:"data"`
+	Links IsolationSegmentRelationshipLinks `json:"links"`
+}
+
+type IsolationSegmentRelationshipLinks struct {
+	Self    Link  `json:"self"`
+	Related *Link `json:"related,omitempty"`
+}
+
+func ForIsolationSegmentOrganizations(isolationSegmentRecord repositories.IsolationSegmentRecord, baseURL url.URL) IsolationSegmentOrganizationsRelationshipResponse {
+	return IsolationSegmentOrganizationsRelationshipResponse{
+		Data: toManyRelationshipData(isolationSegmentRecord.OrgGUIDs),
+		Links: IsolationSegmentRelationshipLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegmentRecord.GUID, "relationships", "organizations").build(),
+			},
+			Related: &Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegmentRecord.GUID, "organizations").build(),
+			},
+		},
+	}
+}
+
+type IsolationSegmentRelationshipResponse struct {
+	Data  *payloads.RelationshipData        `json:"data"`
+	Links IsolationSegmentRelationshipLinks `json:"links"`
+}
+
+func ForOrgDefaultIsolationSegment(org repositories.OrgRecord, baseURL url.URL) IsolationSegmentRelationshipResponse {
+	return forIsolationSegmentRelationship(
+		org.DefaultIsolationSegmentGUID,
+		buildURL(baseURL).appendPath(orgsBase, org.GUID, "relationships", "default_isolation_segment").build(),
+		baseURL,
+	)
+}
+
+func ForSpaceIsolationSegment(space repositories.SpaceRecord, baseURL url.URL) IsolationSegmentRelationshipResponse {
+	return forIsolationSegmentRelationship(
+		space.IsolationSegmentGUID,
+		buildURL(baseURL).appendPath(spacesBase, space.GUID, "relationships", "isolation_segment").build(),
+		baseURL,
+	)
+}
+
+func forIsolationSegmentRelationship(isolationSegmentGUID string, selfHRef string, baseURL url.URL) IsolationSegmentRelationshipResponse {
+	response := IsolationSegmentRelationshipResponse{
+		Links: IsolationSegmentRelationshipLinks{
+			Self: Link{HRef: selfHRef},
+		},
+	}
+
+	if isolationSegmentGUID != "" {
+		response.Data = &payloads.RelationshipData{GUID: isolationSegmentGUID}
+		response.Links.Related = &Link{
+			HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegmentGUID).build(),
+		}
+	}
+
+	return response
+}
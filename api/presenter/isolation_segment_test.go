@@ -0,0 +1,167 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsolationSegment", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.IsolationSegmentRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.IsolationSegmentRecord{
+			GUID:        "segment-guid",
+			Name:        "my-segment",
+			OrgGUIDs:    []string{"org-1", "org-2"},
+			Labels:      map[string]string{"foo": "bar"},
+			Annotations: map[string]string{"bar": "baz"},
+			CreatedAt:   time.UnixMilli(1000).UTC(),
+			UpdatedAt:   tools.PtrTo(time.UnixMilli(2000).UTC()),
+		}
+	})
+
+	Describe("ForIsolationSegment", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForIsolationSegment(record, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "segment-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "my-segment",
+				"metadata": {
+					"labels": { "foo": "bar" },
+					"annotations": { "bar": "baz" }
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid"
+					},
+					"organizations": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid/organizations"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForIsolationSegmentOrganizations", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForIsolationSegmentOrganizations(record, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [
+					{ "guid": "org-1" },
+					{ "guid": "org-2" }
+				],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid/relationships/organizations"
+					},
+					"related": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid/organizations"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForOrgDefaultIsolationSegment", func() {
+		var orgRecord repositories.OrgRecord
+
+		BeforeEach(func() {
+			orgRecord = repositories.OrgRecord{
+				GUID:                        "org-guid",
+				DefaultIsolationSegmentGUID: "segment-guid",
+			}
+		})
+
+		JustBeforeEach(func() {
+			response := presenter.ForOrgDefaultIsolationSegment(orgRecord, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": { "guid": "segment-guid" },
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/organizations/org-guid/relationships/default_isolation_segment"
+					},
+					"related": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid"
+					}
+				}
+			}`))
+		})
+
+		When("the org has no default isolation segment", func() {
+			BeforeEach(func() {
+				orgRecord.DefaultIsolationSegmentGUID = ""
+			})
+
+			It("returns null data", func() {
+				Expect(output).To(MatchJSON(`{
+					"data": null,
+					"links": {
+						"self": {
+							"href": "https://api.example.org/v3/organizations/org-guid/relationships/default_isolation_segment"
+						}
+					}
+				}`))
+			})
+		})
+	})
+
+	Describe("ForSpaceIsolationSegment", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForSpaceIsolationSegment(repositories.SpaceRecord{
+				GUID:                 "space-guid",
+				IsolationSegmentGUID: "segment-guid",
+			}, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": { "guid": "segment-guid" },
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/spaces/space-guid/relationships/isolation_segment"
+					},
+					"related": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid"
+					}
+				}
+			}`))
+		})
+	})
+})
@@ -24,16 +24,23 @@ const (
 	SpaceDeleteUnmappedRoutesOperation = "space.delete_unapped_routes"
 	DomainDeleteOperation              = "domain.delete"
 	RoleDeleteOperation                = "role.delete"
+	SecurityGroupDeleteOperation       = "security_group.delete"
+	OrgQuotaDeleteOperation            = "organization_quota.delete"
+	SpaceQuotaDeleteOperation          = "space_quota.delete"
 	ServiceBrokerCreateOperation       = "service_broker.create"
 	ServiceBrokerDeleteOperation       = "service_broker.delete"
 	ServiceBrokerUpdateOperation       = "service_broker.update"
 
-	ManagedServiceInstanceResourceType    = "managed_service_instance"
-	ManagedServiceBindingResourceType     = "managed_service_binding"
-	ManagedServiceInstanceCreateOperation = ManagedServiceInstanceResourceType + ".create"
-	ManagedServiceInstanceDeleteOperation = ManagedServiceInstanceResourceType + ".delete"
-	ManagedServiceBindingCreateOperation  = ManagedServiceBindingResourceType + ".create"
-	ManagedServiceBindingDeleteOperation  = ManagedServiceBindingResourceType + ".delete"
+	ManagedServiceInstanceResourceType        = "managed_service_instance"
+	ManagedServiceBindingResourceType         = "managed_service_binding"
+	ManagedServiceRouteBindingResourceType    = "managed_service_route_binding"
+	ManagedServiceInstanceCreateOperation     = ManagedServiceInstanceResourceType + ".create"
+	ManagedServiceInstanceDeleteOperation     = ManagedServiceInstanceResourceType + ".delete"
+	ManagedServiceInstanceUpdateOperation     = ManagedServiceInstanceResourceType + ".update"
+	ManagedServiceBindingCreateOperation      = ManagedServiceBindingResourceType + ".create"
+	ManagedServiceBindingDeleteOperation      = ManagedServiceBindingResourceType + ".delete"
+	ManagedServiceRouteBindingCreateOperation = ManagedServiceRouteBindingResourceType + ".create"
+	ManagedServiceRouteBindingDeleteOperation = ManagedServiceRouteBindingResourceType + ".delete"
 )
 
 var (
@@ -123,7 +130,8 @@ func forJobState(job Job, state repositories.ResourceState, errors []JobResponse
 	}
 
 	if job.ResourceType == ManagedServiceInstanceResourceType ||
-		job.ResourceType == ManagedServiceBindingResourceType {
+		job.ResourceType == ManagedServiceBindingResourceType ||
+		job.ResourceType == ManagedServiceRouteBindingResourceType {
 		return StatePolling
 	}
 
@@ -78,16 +78,7 @@ const (
 func ForLogs(logRecords []repositories.LogRecord) LogCacheReadResponse[LogEnvelope] {
 	batch := []LogEnvelope{}
 	for _, logRecord := range logRecords {
-		batch = append(batch, LogEnvelope{
-			Envelope: Envelope{
-				Timestamp: logRecord.Timestamp,
-				Tags:      logRecord.Tags,
-			},
-			Log: Log{
-				Payload: []byte(logRecord.Message),
-				Type:    LOG_OUT,
-			},
-		})
+		batch = append(batch, forLogEnvelope(logRecord))
 	}
 
 	return LogCacheReadResponse[LogEnvelope]{
@@ -97,48 +88,55 @@ func ForLogs(logRecords []repositories.LogRecord) LogCacheReadResponse[LogEnvelo
 	}
 }
 
+// ForLogStream presents a single streamed log record as an envelope batch, the
+// way the loggregator RLP gateway sends each server-sent event
+func ForLogStream(logRecord repositories.LogRecord) LogCacheReadResponseEnvelopes[LogEnvelope] {
+	return LogCacheReadResponseEnvelopes[LogEnvelope]{
+		Batch: []LogEnvelope{forLogEnvelope(logRecord)},
+	}
+}
+
+func forLogEnvelope(logRecord repositories.LogRecord) LogEnvelope {
+	return LogEnvelope{
+		Envelope: Envelope{
+			Timestamp: logRecord.Timestamp,
+			Tags:      logRecord.Tags,
+		},
+		Log: Log{
+			Payload: []byte(logRecord.Message),
+			Type:    LOG_OUT,
+		},
+	}
+}
+
 func ForStats(appRecord repositories.AppRecord, appPodStats []actions.PodStatsRecord) LogCacheReadResponse[GaugeEnvelope] {
 	batch := []GaugeEnvelope{}
 
 	for _, podStats := range appPodStats {
-		batch = append(batch, GaugeEnvelope{
-			Envelope: Envelope{
-				Timestamp: tools.ZeroIfNil(podStats.Usage.Timestamp).Unix(),
-				Tags: map[string]string{
-					"app_id":       appRecord.GUID,
-					"app_name":     appRecord.Name,
-					"instance_id":  strconv.Itoa(podStats.Index),
-					"process_type": podStats.ProcessType,
-					"process_id":   podStats.ProcessGUID,
-					"source_id":    appRecord.GUID,
-					"space_id":     appRecord.SpaceGUID,
-				},
-			},
-			Gauge: Gauge{
-				Metrics: map[string]GaugeValue{
-					"cpu": {
-						Unit:  "percentage",
-						Value: GaugeFloat(tools.ZeroIfNil(podStats.Usage.CPU)),
-					},
-					"memory": {
-						Unit:  "bytes",
-						Value: GaugeInt(tools.ZeroIfNil(podStats.Usage.Mem)),
-					},
-					"disk": {
-						Unit:  "bytes",
-						Value: GaugeInt(tools.ZeroIfNil(podStats.Usage.Disk)),
-					},
-					"memory_quota": {
-						Unit:  "bytes",
-						Value: GaugeInt(tools.ZeroIfNil(podStats.MemQuota)),
-					},
-					"disk_quota": {
-						Unit:  "bytes",
-						Value: GaugeInt(tools.ZeroIfNil(podStats.DiskQuota)),
-					},
-				},
-			},
-		})
+		batch = append(batch, forGaugeEnvelope(appRecord, repositories.GaugeRecord{
+			Timestamp:     tools.ZeroIfNil(podStats.Usage.Timestamp).Unix(),
+			ProcessGUID:   podStats.ProcessGUID,
+			ProcessType:   podStats.ProcessType,
+			InstanceIndex: podStats.Index,
+			CPU:           tools.ZeroIfNil(podStats.Usage.CPU),
+			Mem:           tools.ZeroIfNil(podStats.Usage.Mem),
+			Disk:          tools.ZeroIfNil(podStats.Usage.Disk),
+			MemQuota:      tools.ZeroIfNil(podStats.MemQuota),
+			DiskQuota:     tools.ZeroIfNil(podStats.DiskQuota),
+		}))
+	}
+
+	return LogCacheReadResponse[GaugeEnvelope]{
+		Envelopes: LogCacheReadResponseEnvelopes[GaugeEnvelope]{
+			Batch: batch,
+		},
+	}
+}
+
+func ForGauges(appRecord repositories.AppRecord, gaugeRecords []repositories.GaugeRecord) LogCacheReadResponse[GaugeEnvelope] {
+	batch := []GaugeEnvelope{}
+	for _, gaugeRecord := range gaugeRecords {
+		batch = append(batch, forGaugeEnvelope(appRecord, gaugeRecord))
 	}
 
 	return LogCacheReadResponse[GaugeEnvelope]{
@@ -147,3 +145,44 @@ func ForStats(appRecord repositories.AppRecord, appPodStats []actions.PodStatsRe
 		},
 	}
 }
+
+func forGaugeEnvelope(appRecord repositories.AppRecord, gaugeRecord repositories.GaugeRecord) GaugeEnvelope {
+	return GaugeEnvelope{
+		Envelope: Envelope{
+			Timestamp: gaugeRecord.Timestamp,
+			Tags: map[string]string{
+				"app_id":       appRecord.GUID,
+				"app_name":     appRecord.Name,
+				"instance_id":  strconv.Itoa(gaugeRecord.InstanceIndex),
+				"process_type": gaugeRecord.ProcessType,
+				"process_id":   gaugeRecord.ProcessGUID,
+				"source_id":    appRecord.GUID,
+				"space_id":     appRecord.SpaceGUID,
+			},
+		},
+		Gauge: Gauge{
+			Metrics: map[string]GaugeValue{
+				"cpu": {
+					Unit:  "percentage",
+					Value: GaugeFloat(gaugeRecord.CPU),
+				},
+				"memory": {
+					Unit:  "bytes",
+					Value: GaugeInt(gaugeRecord.Mem),
+				},
+				"disk": {
+					Unit:  "bytes",
+					Value: GaugeInt(gaugeRecord.Disk),
+				},
+				"memory_quota": {
+					Unit:  "bytes",
+					Value: GaugeInt(gaugeRecord.MemQuota),
+				},
+				"disk_quota": {
+					Unit:  "bytes",
+					Value: GaugeInt(gaugeRecord.DiskQuota),
+				},
+			},
+		},
+	}
+}
@@ -69,6 +69,47 @@ var _ = Describe("ForLogs", func() {
 	})
 })
 
+var _ = Describe("ForLogStream", func() {
+	var (
+		output []byte
+		record repositories.LogRecord
+	)
+
+	BeforeEach(func() {
+		record = repositories.LogRecord{
+			Message:   "message-1",
+			Timestamp: 123,
+			Tags: map[string]string{
+				"source_type": "APP",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForLogStream(record)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected log batch json", func() {
+		Expect(output).To(MatchJSON(`{
+			"batch": [
+				{
+					"timestamp": 123,
+					"log": {
+						"payload": "bWVzc2FnZS0x",
+						"type": 0
+					},
+					"tags": {
+						"source_type": "APP"
+					}
+				}
+			]
+		}`))
+	})
+})
+
 var _ = Describe("ForStats", func() {
 	var (
 		output []byte
@@ -150,3 +191,93 @@ var _ = Describe("ForStats", func() {
 		}`))
 	})
 })
+
+var _ = Describe("ForGauges", func() {
+	var (
+		output []byte
+		app    repositories.AppRecord
+		gauges []repositories.GaugeRecord
+	)
+
+	BeforeEach(func() {
+		app = repositories.AppRecord{
+			Name:      "my-app",
+			GUID:      "app-guid",
+			SpaceGUID: "space-guid",
+		}
+
+		gauges = []repositories.GaugeRecord{{
+			Timestamp:     1000,
+			ProcessGUID:   "process-guid",
+			ProcessType:   "web",
+			InstanceIndex: 1,
+			CPU:           1e-05,
+			Mem:           2,
+			Disk:          3,
+			MemQuota:      4,
+			DiskQuota:     5,
+		}}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForGauges(app, gauges)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected gauges json", func() {
+		Expect(output).To(MatchJSON(`{
+		  "envelopes": {
+			"batch": [
+			  {
+				"timestamp": 1000,
+				"tags": {
+				  "app_id": "app-guid",
+				  "app_name": "my-app",
+				  "instance_id": "1",
+				  "process_type": "web",
+				  "process_id": "process-guid",
+				  "source_id": "app-guid",
+				  "space_id": "space-guid"
+				},
+				"gauge": {
+				  "metrics": {
+					"cpu": {
+					  "unit": "percentage",
+					  "value": 0.00001
+					},
+					"disk": {
+					  "unit": "bytes",
+					  "value": 3
+					},
+					"disk_quota": {
+					  "unit": "bytes",
+					  "value": 5
+					},
+					"memory": {
+					  "unit": "bytes",
+					  "value": 2
+					},
+					"memory_quota": {
+					  "unit": "bytes",
+					  "value": 4
+					}
+				  }
+				}
+			  }
+			]
+		  }
+		}`))
+	})
+
+	When("there are no gauges", func() {
+		BeforeEach(func() {
+			gauges = nil
+		})
+
+		It("returns an empty batch", func() {
+			Expect(output).To(MatchJSON(`{"envelopes": {"batch": []}}`))
+		})
+	})
+})
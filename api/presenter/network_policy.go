@@ -0,0 +1,58 @@
+package presenter
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type NetworkPoliciesResponse struct {
+	TotalPolicies int                     `json:"total_policies"`
+	Policies      []NetworkPolicyResponse `json:"policies"`
+}
+
+type NetworkPolicyResponse struct {
+	Source      NetworkPolicySource      `json:"source"`
+	Destination NetworkPolicyDestination `json:"destination"`
+}
+
+type NetworkPolicySource struct {
+	ID string `json:"id"`
+}
+
+type NetworkPolicyDestination struct {
+	ID       string             `json:"id"`
+	Protocol string             `json:"protocol"`
+	Ports    NetworkPolicyPorts `json:"ports"`
+}
+
+type NetworkPolicyPorts struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
+func ForNetworkPolicies(records []repositories.NetworkPolicyRecord) NetworkPoliciesResponse {
+	policies := []NetworkPolicyResponse{}
+	for _, record := range records {
+		policies = append(policies, forNetworkPolicy(record))
+	}
+
+	return NetworkPoliciesResponse{
+		TotalPolicies: len(policies),
+		Policies:      policies,
+	}
+}
+
+func forNetworkPolicy(record repositories.NetworkPolicyRecord) NetworkPolicyResponse {
+	return NetworkPolicyResponse{
+		Source: NetworkPolicySource{
+			ID: record.SourceAppGUID,
+		},
+		Destination: NetworkPolicyDestination{
+			ID:       record.DestinationAppGUID,
+			Protocol: record.Protocol,
+			Ports: NetworkPolicyPorts{
+				Start: record.StartPort,
+				End:   record.EndPort,
+			},
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package presenter_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPolicy", func() {
+	var (
+		output  []byte
+		records []repositories.NetworkPolicyRecord
+	)
+
+	BeforeEach(func() {
+		records = []repositories.NetworkPolicyRecord{{
+			SourceAppGUID:        "source-app-guid",
+			SourceSpaceGUID:      "source-space-guid",
+			DestinationAppGUID:   "destination-app-guid",
+			DestinationSpaceGUID: "destination-space-guid",
+			Protocol:             "tcp",
+			StartPort:            8080,
+			EndPort:              8090,
+		}}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForNetworkPolicies(records)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"total_policies": 1,
+			"policies": [
+				{
+					"source": {
+						"id": "source-app-guid"
+					},
+					"destination": {
+						"id": "destination-app-guid",
+						"protocol": "tcp",
+						"ports": {
+							"start": 8080,
+							"end": 8090
+						}
+					}
+				}
+			]
+		}`))
+	})
+
+	When("there are no policies", func() {
+		BeforeEach(func() {
+			records = nil
+		})
+
+		It("presents an empty list", func() {
+			Expect(output).To(MatchJSON(`{"total_policies": 0, "policies": []}`))
+		})
+	})
+})
@@ -0,0 +1,90 @@
+package presenter
+
+import (
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const orgQuotasBase = "/v3/organization_quotas"
+
+type OrgQuotaResponse struct {
+	GUID          string                         `json:"guid"`
+	CreatedAt     time.Time                      `json:"created_at"`
+	UpdatedAt     time.Time                      `json:"updated_at"`
+	Name          string                         `json:"name"`
+	Apps          payloads.QuotaApps             `json:"apps"`
+	Services      payloads.QuotaServices         `json:"services"`
+	Routes        payloads.QuotaRoutes           `json:"routes"`
+	Domains       payloads.QuotaDomains          `json:"domains"`
+	Relationships payloads.OrgQuotaRelationships `json:"relationships"`
+	Links         OrgQuotaLinks                  `json:"links"`
+}
+
+type OrgQuotaLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForOrgQuota(orgQuotaRecord repositories.OrgQuotaRecord, baseURL url.URL, includes ...include.Resource) OrgQuotaResponse {
+	return OrgQuotaResponse{
+		GUID:      orgQuotaRecord.GUID,
+		CreatedAt: tools.ZeroIfNil(toUTC(&orgQuotaRecord.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(toUTC(orgQuotaRecord.UpdatedAt)),
+		Name:      orgQuotaRecord.Name,
+		Apps:      forQuotaApps(orgQuotaRecord.Limits.Apps),
+		Services:  forQuotaServices(orgQuotaRecord.Limits.Services),
+		Routes:    forQuotaRoutes(orgQuotaRecord.Limits.Routes),
+		Relationships: payloads.OrgQuotaRelationships{
+			Organizations: payloads.ToManyRelationship{
+				Data: toManyRelationshipData(orgQuotaRecord.OrgGUIDs),
+			},
+		},
+		Links: OrgQuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(orgQuotasBase, orgQuotaRecord.GUID).build(),
+			},
+		},
+	}
+}
+
+type OrgQuotaOrganizationsRelationshipResponse struct {
+	Data  []payloads.RelationshipData `json:"data"`
+	Links OrgQuotaLinks               `json:"links"`
+}
+
+func ForOrgQuotaOrganizations(orgQuotaRecord repositories.OrgQuotaRecord, baseURL url.URL) OrgQuotaOrganizationsRelationshipResponse {
+	return OrgQuotaOrganizationsRelationshipResponse{
+		Data: toManyRelationshipData(orgQuotaRecord.OrgGUIDs),
+		Links: OrgQuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(orgQuotasBase, orgQuotaRecord.GUID, "relationships", "organizations").build(),
+			},
+		},
+	}
+}
+
+func forQuotaApps(apps repositories.QuotaAppsLimits) payloads.QuotaApps {
+	return payloads.QuotaApps{
+		TotalMemoryInMB:      apps.TotalMemoryInMB,
+		PerProcessMemoryInMB: apps.PerProcessMemoryInMB,
+		TotalInstances:       apps.TotalInstances,
+		PerAppTasks:          apps.PerAppTasks,
+	}
+}
+
+func forQuotaServices(services repositories.QuotaServicesLimits) payloads.QuotaServices {
+	return payloads.QuotaServices{
+		PaidServicesAllowed:   tools.PtrTo(services.PaidServicesAllowed),
+		TotalServiceInstances: services.TotalServiceInstances,
+	}
+}
+
+func forQuotaRoutes(routes repositories.QuotaRoutesLimits) payloads.QuotaRoutes {
+	return payloads.QuotaRoutes{
+		TotalRoutes: routes.TotalRoutes,
+	}
+}
@@ -16,18 +16,19 @@ const (
 )
 
 type ProcessResponse struct {
-	GUID          string                       `json:"guid"`
-	Type          string                       `json:"type"`
-	Command       string                       `json:"command"`
-	Instances     int32                        `json:"instances"`
-	MemoryMB      int64                        `json:"memory_in_mb"`
-	DiskQuotaMB   int64                        `json:"disk_in_mb"`
-	HealthCheck   ProcessResponseHealthCheck   `json:"health_check"`
-	Relationships map[string]ToOneRelationship `json:"relationships"`
-	Metadata      Metadata                     `json:"metadata"`
-	CreatedAt     time.Time                    `json:"created_at"`
-	UpdatedAt     time.Time                    `json:"updated_at"`
-	Links         ProcessLinks                 `json:"links"`
+	GUID                 string                              `json:"guid"`
+	Type                 string                              `json:"type"`
+	Command              string                              `json:"command"`
+	Instances            int32                               `json:"instances"`
+	MemoryMB             int64                               `json:"memory_in_mb"`
+	DiskQuotaMB          int64                               `json:"disk_in_mb"`
+	HealthCheck          ProcessResponseHealthCheck          `json:"health_check"`
+	ReadinessHealthCheck ProcessResponseReadinessHealthCheck `json:"readiness_health_check"`
+	Relationships        map[string]ToOneRelationship        `json:"relationships"`
+	Metadata             Metadata                            `json:"metadata"`
+	CreatedAt            time.Time                           `json:"created_at"`
+	UpdatedAt            time.Time                           `json:"updated_at"`
+	Links                ProcessLinks                        `json:"links"`
 }
 
 type ProcessLinks struct {
@@ -47,6 +48,8 @@ type ProcessResponseHealthCheckData struct {
 	Type              string `json:"-"`
 	Timeout           int32  `json:"timeout"`
 	InvocationTimeout int32  `json:"invocation_timeout"`
+	Interval          int32  `json:"interval"`
+	FailureThreshold  int32  `json:"failure_threshold"`
 	HTTPEndpoint      string `json:"endpoint"`
 }
 
@@ -62,18 +65,30 @@ func (h ProcessResponseHealthCheckData) MarshalJSON() ([]byte, error) {
 	if *invocationTimeout == 0 {
 		invocationTimeout = nil
 	}
+	interval := tools.PtrTo(h.Interval)
+	if *interval == 0 {
+		interval = nil
+	}
+	failureThreshold := tools.PtrTo(h.FailureThreshold)
+	if *failureThreshold == 0 {
+		failureThreshold = nil
+	}
 
 	switch h.Type {
 	case "http":
 		return json.Marshal(ProcessResponseHTTPHealthCheckData{
 			Timeout:           timeout,
 			InvocationTimeout: invocationTimeout,
+			Interval:          interval,
+			FailureThreshold:  failureThreshold,
 			HTTPEndpoint:      h.HTTPEndpoint,
 		})
 	case "port":
 		return json.Marshal(ProcessResponsePortHealthCheckData{
 			Timeout:           timeout,
 			InvocationTimeout: invocationTimeout,
+			Interval:          interval,
+			FailureThreshold:  failureThreshold,
 		})
 	case "process":
 		return json.Marshal(ProcessResponseProcessHealthCheckData{
@@ -87,18 +102,69 @@ func (h ProcessResponseHealthCheckData) MarshalJSON() ([]byte, error) {
 type ProcessResponseHTTPHealthCheckData struct {
 	Timeout           *int32 `json:"timeout"`
 	InvocationTimeout *int32 `json:"invocation_timeout"`
+	Interval          *int32 `json:"interval"`
+	FailureThreshold  *int32 `json:"failure_threshold"`
 	HTTPEndpoint      string `json:"endpoint"`
 }
 
 type ProcessResponsePortHealthCheckData struct {
 	Timeout           *int32 `json:"timeout"`
 	InvocationTimeout *int32 `json:"invocation_timeout"`
+	Interval          *int32 `json:"interval"`
+	FailureThreshold  *int32 `json:"failure_threshold"`
 }
 
 type ProcessResponseProcessHealthCheckData struct {
 	Timeout *int32 `json:"timeout"`
 }
 
+type ProcessResponseReadinessHealthCheck struct {
+	Type string                                  `json:"type"`
+	Data ProcessResponseReadinessHealthCheckData `json:"data"`
+}
+
+type ProcessResponseReadinessHealthCheckData struct {
+	Type              string `json:"-"`
+	InvocationTimeout int32  `json:"invocation_timeout"`
+	Interval          int32  `json:"interval"`
+	HTTPEndpoint      string `json:"endpoint"`
+}
+
+func (h ProcessResponseReadinessHealthCheckData) MarshalJSON() ([]byte, error) {
+	invocationTimeout := tools.PtrTo(h.InvocationTimeout)
+	if *invocationTimeout == 0 {
+		invocationTimeout = nil
+	}
+	interval := tools.PtrTo(h.Interval)
+	if *interval == 0 {
+		interval = nil
+	}
+
+	if h.Type == "http" {
+		return json.Marshal(ProcessResponseHTTPReadinessHealthCheckData{
+			InvocationTimeout: invocationTimeout,
+			Interval:          interval,
+			HTTPEndpoint:      h.HTTPEndpoint,
+		})
+	}
+
+	return json.Marshal(ProcessResponsePortReadinessHealthCheckData{
+		InvocationTimeout: invocationTimeout,
+		Interval:          interval,
+	})
+}
+
+type ProcessResponseHTTPReadinessHealthCheckData struct {
+	InvocationTimeout *int32 `json:"invocation_timeout"`
+	Interval          *int32 `json:"interval"`
+	HTTPEndpoint      string `json:"endpoint"`
+}
+
+type ProcessResponsePortReadinessHealthCheckData struct {
+	InvocationTimeout *int32 `json:"invocation_timeout"`
+	Interval          *int32 `json:"interval"`
+}
+
 func ForProcess(responseProcess repositories.ProcessRecord, baseURL url.URL, _ ...include.Resource) ProcessResponse {
 	return ProcessResponse{
 		GUID:        responseProcess.GUID,
@@ -113,9 +179,22 @@ func ForProcess(responseProcess repositories.ProcessRecord, baseURL url.URL, _ .
 				Type:              string(responseProcess.HealthCheck.Type),
 				Timeout:           responseProcess.HealthCheck.Data.TimeoutSeconds,
 				InvocationTimeout: responseProcess.HealthCheck.Data.InvocationTimeoutSeconds,
+				Interval:          responseProcess.HealthCheck.Data.IntervalSeconds,
+				FailureThreshold:  responseProcess.HealthCheck.Data.FailureThreshold,
 				HTTPEndpoint:      responseProcess.HealthCheck.Data.HTTPEndpoint,
 			},
 		},
+		ReadinessHealthCheck: ProcessResponseReadinessHealthCheck{
+			// processes created before readiness health checks were supported
+			// have no type, which is equivalent to the "process" type
+			Type: tools.IfZero(responseProcess.ReadinessHealthCheck.Type, "process"),
+			Data: ProcessResponseReadinessHealthCheckData{
+				Type:              responseProcess.ReadinessHealthCheck.Type,
+				InvocationTimeout: responseProcess.ReadinessHealthCheck.Data.InvocationTimeoutSeconds,
+				Interval:          responseProcess.ReadinessHealthCheck.Data.IntervalSeconds,
+				HTTPEndpoint:      responseProcess.ReadinessHealthCheck.Data.HTTPEndpoint,
+			},
+		},
 		Relationships: ForRelationships(responseProcess.Relationships()),
 		Metadata: Metadata{
 			Labels:      responseProcess.Labels,
@@ -149,3 +228,55 @@ func ForProcessSanitized(responseProcess repositories.ProcessRecord, baseURL url
 	response.Command = "[PRIVATE DATA HIDDEN IN LISTS]"
 	return response
 }
+
+type ProcessAutoscalingPolicyResponse struct {
+	MinInstances             int32                             `json:"min_instances"`
+	MaxInstances             int32                             `json:"max_instances"`
+	CPUUtilizationPercent    *int32                            `json:"cpu_utilization_percent"`
+	MemoryUtilizationPercent *int32                            `json:"memory_utilization_percent"`
+	HTTPRequestsPerSecond    *int32                            `json:"http_requests_per_second"`
+	Status                   *ProcessAutoscalingStatusResponse `json:"status"`
+	Links                    ProcessAutoscalingPolicyLinks     `json:"links"`
+}
+
+type ProcessAutoscalingStatusResponse struct {
+	CurrentInstances int32      `json:"current_instances"`
+	DesiredInstances int32      `json:"desired_instances"`
+	LastScaleTime    *time.Time `json:"last_scale_time"`
+}
+
+type ProcessAutoscalingPolicyLinks struct {
+	Self    Link `json:"self"`
+	Process Link `json:"process"`
+}
+
+// ForProcessAutoscalingPolicy expects the process to have an autoscaling policy
+func ForProcessAutoscalingPolicy(responseProcess repositories.ProcessRecord, baseURL url.URL) ProcessAutoscalingPolicyResponse {
+	response := ProcessAutoscalingPolicyResponse{
+		MinInstances:             responseProcess.Autoscaling.MinInstances,
+		MaxInstances:             responseProcess.Autoscaling.MaxInstances,
+		CPUUtilizationPercent:    responseProcess.Autoscaling.CPUUtilizationPercent,
+		MemoryUtilizationPercent: responseProcess.Autoscaling.MemoryUtilizationPercent,
+		HTTPRequestsPerSecond:    responseProcess.Autoscaling.HTTPRequestsPerSecond,
+		Links: ProcessAutoscalingPolicyLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(processesBase, responseProcess.GUID, "autoscaling_policy").build(),
+			},
+			Process: Link{
+				HRef: buildURL(baseURL).appendPath(processesBase, responseProcess.GUID).build(),
+			},
+		},
+	}
+
+	if status := responseProcess.AutoscalingStatus; status != nil {
+		response.Status = &ProcessAutoscalingStatusResponse{
+			CurrentInstances: status.CurrentInstances,
+			DesiredInstances: status.DesiredInstances,
+		}
+		if status.LastScaleTime != nil {
+			response.Status.LastScaleTime = toUTC(&status.LastScaleTime.Time)
+		}
+	}
+
+	return response
+}
@@ -7,9 +7,12 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("Process", func() {
@@ -70,7 +73,16 @@ var _ = Describe("Process", func() {
 					"type": "port",
 					"data": {
 						"timeout": null,
-						"invocation_timeout": null
+						"invocation_timeout": null,
+						"interval": null,
+						"failure_threshold": null
+					}
+				},
+				"readiness_health_check": {
+					"type": "process",
+					"data": {
+						"invocation_timeout": null,
+						"interval": null
 					}
 				},
 				"relationships": {
@@ -110,5 +122,109 @@ var _ = Describe("Process", func() {
 				}
 			}`))
 		})
+
+		When("the process has an http health check with an interval and a failure threshold", func() {
+			BeforeEach(func() {
+				record.HealthCheck = repositories.HealthCheck{
+					Type: "http",
+					Data: repositories.HealthCheckData{
+						HTTPEndpoint:     "/healthz",
+						IntervalSeconds:  10,
+						FailureThreshold: 3,
+					},
+				}
+			})
+
+			It("presents them", func() {
+				Expect(output).To(MatchJSONPath("$.health_check.data.endpoint", "/healthz"))
+				Expect(output).To(MatchJSONPath("$.health_check.data.interval", BeEquivalentTo(10)))
+				Expect(output).To(MatchJSONPath("$.health_check.data.failure_threshold", BeEquivalentTo(3)))
+			})
+		})
+
+		When("the process has an http readiness health check", func() {
+			BeforeEach(func() {
+				record.ReadinessHealthCheck = repositories.ReadinessHealthCheck{
+					Type: "http",
+					Data: repositories.ReadinessHealthCheckData{
+						HTTPEndpoint:             "/ready",
+						InvocationTimeoutSeconds: 2,
+						IntervalSeconds:          5,
+					},
+				}
+			})
+
+			It("presents the readiness health check", func() {
+				Expect(output).To(MatchJSONPath("$.readiness_health_check.type", "http"))
+				Expect(output).To(MatchJSONPath("$.readiness_health_check.data.endpoint", "/ready"))
+				Expect(output).To(MatchJSONPath("$.readiness_health_check.data.invocation_timeout", BeEquivalentTo(2)))
+				Expect(output).To(MatchJSONPath("$.readiness_health_check.data.interval", BeEquivalentTo(5)))
+			})
+		})
+	})
+})
+
+var _ = Describe("Process Autoscaling Policy", func() {
+	var (
+		baseURL *url.URL
+		record  repositories.ProcessRecord
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+
+		record = repositories.ProcessRecord{
+			GUID: "process-guid",
+			Autoscaling: &repositories.ProcessAutoscaling{
+				MinInstances:          2,
+				MaxInstances:          6,
+				CPUUtilizationPercent: tools.PtrTo[int32](75),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForProcessAutoscalingPolicy(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"min_instances": 2,
+			"max_instances": 6,
+			"cpu_utilization_percent": 75,
+			"memory_utilization_percent": null,
+			"http_requests_per_second": null,
+			"status": null,
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/processes/process-guid/autoscaling_policy"
+				},
+				"process": {
+					"href": "https://api.example.org/v3/processes/process-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the autoscaler has reported its status", func() {
+		BeforeEach(func() {
+			record.AutoscalingStatus = &korifiv1alpha1.ProcessAutoscalingStatus{
+				CurrentInstances: 3,
+				DesiredInstances: 4,
+				LastScaleTime:    &metav1.Time{Time: time.UnixMilli(3000)},
+			}
+		})
+
+		It("presents it", func() {
+			Expect(output).To(MatchJSONPath("$.status.current_instances", BeEquivalentTo(3)))
+			Expect(output).To(MatchJSONPath("$.status.desired_instances", BeEquivalentTo(4)))
+			Expect(output).To(MatchJSONPath("$.status.last_scale_time", "1970-01-01T00:00:03Z"))
+		})
 	})
 })
@@ -0,0 +1,34 @@
+package presenter
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type ResourceMatchResponse struct {
+	Resources []ResourceMatchResource `json:"resources"`
+}
+
+type ResourceMatchResource struct {
+	Checksum    ResourceMatchChecksum `json:"checksum"`
+	SizeInBytes int64                 `json:"size_in_bytes"`
+	Path        string                `json:"path"`
+	Mode        string                `json:"mode"`
+}
+
+type ResourceMatchChecksum struct {
+	Value string `json:"value"`
+}
+
+func ForResourceMatches(records []repositories.ResourceRecord) ResourceMatchResponse {
+	resources := []ResourceMatchResource{}
+	for _, record := range records {
+		resources = append(resources, ResourceMatchResource{
+			Checksum:    ResourceMatchChecksum{Value: record.SHA1},
+			SizeInBytes: record.SizeInBytes,
+			Path:        record.Path,
+			Mode:        record.Mode,
+		})
+	}
+
+	return ResourceMatchResponse{Resources: resources}
+}
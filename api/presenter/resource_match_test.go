@@ -0,0 +1,57 @@
+package presenter_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resource Matches", func() {
+	var (
+		output  []byte
+		records []repositories.ResourceRecord
+	)
+
+	BeforeEach(func() {
+		records = []repositories.ResourceRecord{{
+			SHA1:        "002d760bea1be268e27077412e11a320d0f164d3",
+			SizeInBytes: 100000,
+			Path:        "path/to/file",
+			Mode:        "644",
+		}}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForResourceMatches(records)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"resources": [
+				{
+					"checksum": { "value": "002d760bea1be268e27077412e11a320d0f164d3" },
+					"size_in_bytes": 100000,
+					"path": "path/to/file",
+					"mode": "644"
+				}
+			]
+		}`))
+	})
+
+	When("there are no records", func() {
+		BeforeEach(func() {
+			records = nil
+		})
+
+		It("produces an empty list", func() {
+			Expect(output).To(MatchJSON(`{ "resources": [] }`))
+		})
+	})
+})
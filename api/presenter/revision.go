@@ -0,0 +1,121 @@
+package presenter
+
+import (
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const revisionsBase = "/v3/revisions"
+
+type RevisionResponse struct {
+	GUID          string                       `json:"guid"`
+	Version       int64                        `json:"version"`
+	Droplet       DropletGUID                  `json:"droplet"`
+	Processes     map[string]RevisionProcess   `json:"processes"`
+	Sidecars      []RevisionSidecar            `json:"sidecars"`
+	Description   string                       `json:"description"`
+	Deployable    bool                         `json:"deployable"`
+	Relationships map[string]ToOneRelationship `json:"relationships"`
+	CreatedAt     time.Time                    `json:"created_at"`
+	UpdatedAt     time.Time                    `json:"updated_at"`
+	Metadata      Metadata                     `json:"metadata"`
+	Links         RevisionLinks                `json:"links"`
+}
+
+type RevisionProcess struct {
+	Command *string `json:"command"`
+}
+
+type RevisionSidecar struct {
+	Name         string   `json:"name"`
+	Command      string   `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	MemoryInMB   *int64   `json:"memory_in_mb"`
+}
+
+type RevisionLinks struct {
+	Self                 Link `json:"self"`
+	App                  Link `json:"app"`
+	EnvironmentVariables Link `json:"environment_variables"`
+}
+
+func ForRevision(record repositories.RevisionRecord, baseURL url.URL, includes ...include.Resource) RevisionResponse {
+	processes := map[string]RevisionProcess{}
+	for processType, command := range record.Processes {
+		processes[processType] = RevisionProcess{}
+		if command != "" {
+			processes[processType] = RevisionProcess{Command: tools.PtrTo(command)}
+		}
+	}
+
+	sidecars := []RevisionSidecar{}
+	for _, sidecar := range record.Sidecars {
+		var memoryInMB *int64
+		if sidecar.MemoryMB != 0 {
+			memoryInMB = tools.PtrTo(sidecar.MemoryMB)
+		}
+
+		sidecars = append(sidecars, RevisionSidecar{
+			Name:         sidecar.Name,
+			Command:      sidecar.Command,
+			ProcessTypes: emptySliceIfNil(sidecar.ProcessTypes),
+			MemoryInMB:   memoryInMB,
+		})
+	}
+
+	return RevisionResponse{
+		GUID:          record.GUID,
+		Version:       record.Version,
+		Droplet:       DropletGUID{Guid: record.DropletGUID},
+		Processes:     processes,
+		Sidecars:      sidecars,
+		Description:   record.Description,
+		Deployable:    record.Deployable,
+		Relationships: ForRelationships(record.Relationships()),
+		CreatedAt:     tools.ZeroIfNil(toUTC(&record.CreatedAt)),
+		UpdatedAt:     tools.ZeroIfNil(toUTC(record.UpdatedAt)),
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(record.Labels),
+			Annotations: emptyMapIfNil(record.Annotations),
+		},
+		Links: RevisionLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.GUID).build(),
+			},
+			App: Link{
+				HRef: buildURL(baseURL).appendPath(appsBase, record.AppGUID).build(),
+			},
+			EnvironmentVariables: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.GUID, "environment_variables").build(),
+			},
+		},
+	}
+}
+
+type RevisionEnvVarsResponse struct {
+	Var   map[string]string    `json:"var"`
+	Links RevisionEnvVarsLinks `json:"links"`
+}
+
+type RevisionEnvVarsLinks struct {
+	Self     Link `json:"self"`
+	Revision Link `json:"revision"`
+}
+
+func ForRevisionEnvVars(record repositories.RevisionEnvVarsRecord, baseURL url.URL) RevisionEnvVarsResponse {
+	return RevisionEnvVarsResponse{
+		Var: emptyMapIfNil(record.EnvironmentVariables),
+		Links: RevisionEnvVarsLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.RevisionGUID, "environment_variables").build(),
+			},
+			Revision: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.RevisionGUID).build(),
+			},
+		},
+	}
+}
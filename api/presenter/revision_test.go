@@ -0,0 +1,157 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Revision", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.RevisionRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.RevisionRecord{
+			GUID:        "the-revision-guid",
+			AppGUID:     "the-app-guid",
+			SpaceGUID:   "the-space-guid",
+			Version:     2,
+			DropletGUID: "the-droplet-guid",
+			Processes: map[string]string{
+				"web":    "",
+				"worker": "bundle exec work",
+			},
+			Sidecars: []repositories.RevisionSidecarRecord{
+				{Name: "my-sidecar", Command: "run sidecar", ProcessTypes: []string{"web"}, MemoryMB: 64},
+				{Name: "other-sidecar", Command: "run other", ProcessTypes: []string{"worker"}},
+			},
+			Description: "New droplet deployed.",
+			Deployable:  true,
+			Labels:      map[string]string{"label-key": "label-val"},
+			Annotations: map[string]string{"annotation-key": "annotation-val"},
+			CreatedAt:   time.UnixMilli(1000),
+			UpdatedAt:   tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForRevision(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected revision json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "the-revision-guid",
+			"version": 2,
+			"droplet": {
+				"guid": "the-droplet-guid"
+			},
+			"processes": {
+				"web": {
+					"command": null
+				},
+				"worker": {
+					"command": "bundle exec work"
+				}
+			},
+			"sidecars": [
+				{
+					"name": "my-sidecar",
+					"command": "run sidecar",
+					"process_types": ["web"],
+					"memory_in_mb": 64
+				},
+				{
+					"name": "other-sidecar",
+					"command": "run other",
+					"process_types": ["worker"],
+					"memory_in_mb": null
+				}
+			],
+			"description": "New droplet deployed.",
+			"deployable": true,
+			"relationships": {
+				"app": {
+					"data": {
+						"guid": "the-app-guid"
+					}
+				}
+			},
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"metadata": {
+				"labels": {
+					"label-key": "label-val"
+				},
+				"annotations": {
+					"annotation-key": "annotation-val"
+				}
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/revisions/the-revision-guid"
+				},
+				"app": {
+					"href": "https://api.example.org/v3/apps/the-app-guid"
+				},
+				"environment_variables": {
+					"href": "https://api.example.org/v3/revisions/the-revision-guid/environment_variables"
+				}
+			}
+		}`))
+	})
+})
+
+var _ = Describe("RevisionEnvVars", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForRevisionEnvVars(repositories.RevisionEnvVarsRecord{
+			RevisionGUID:         "the-revision-guid",
+			EnvironmentVariables: map[string]string{"FOO": "bar"},
+		}, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected revision environment variables json", func() {
+		Expect(output).To(MatchJSON(`{
+			"var": {
+				"FOO": "bar"
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/revisions/the-revision-guid/environment_variables"
+				},
+				"revision": {
+					"href": "https://api.example.org/v3/revisions/the-revision-guid"
+				}
+			}
+		}`))
+	})
+})
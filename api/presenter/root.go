@@ -12,7 +12,9 @@ type APILink struct {
 }
 
 type APILinkMeta struct {
-	Version string `json:"version"`
+	Version            string `json:"version"`
+	HostKeyFingerprint string `json:"host_key_fingerprint,omitempty"`
+	OAuthClient        string `json:"oauth_client,omitempty"`
 }
 
 type RootResponse struct {
@@ -20,9 +22,14 @@ type RootResponse struct {
 	CFOnK8s bool                `json:"cf_on_k8s"`
 }
 
-const V3APIVersion = "3.117.0+cf-k8s"
+const (
+	V3APIVersion = "3.117.0+cf-k8s"
 
-func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) RootResponse {
+	// SSHOAuthClient is the client the cf cli requests one-time ssh codes for
+	SSHOAuthClient = "ssh-proxy"
+)
+
+func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL, sshProxyConfig config.SSHProxy, sshHostKeyFingerprint string) RootResponse {
 	rootResponse := RootResponse{
 		Links: map[string]*APILink{
 			"self": {
@@ -41,7 +48,11 @@ func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) RootRes
 				},
 			},
 			"network_policy_v0": nil,
-			"network_policy_v1": nil,
+			"network_policy_v1": {
+				Link: Link{
+					HRef: buildURL(baseURL).appendPath("networking", "v1", "external").build(),
+				},
+			},
 			"login": {
 				Link: Link{
 					HRef: buildURL(baseURL).build(),
@@ -49,7 +60,11 @@ func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) RootRes
 			},
 			"uaa":     nil,
 			"credhub": nil,
-			"routing": nil,
+			"routing": {
+				Link: Link{
+					HRef: buildURL(baseURL).appendPath("routing").build(),
+				},
+			},
 			"logging": nil,
 			"log_cache": {
 				Link: Link{
@@ -76,6 +91,18 @@ func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) RootRes
 		}
 	}
 
+	if sshProxyConfig.Enabled {
+		rootResponse.Links["app_ssh"] = &APILink{
+			Link: Link{
+				HRef: sshProxyConfig.ExternalAddress,
+			},
+			Meta: APILinkMeta{
+				HostKeyFingerprint: sshHostKeyFingerprint,
+				OAuthClient:        SSHOAuthClient,
+			},
+		}
+	}
+
 	return rootResponse
 }
 
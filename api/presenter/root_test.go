@@ -27,14 +27,18 @@ var _ = Describe("Root endpoints", func() {
 	})
 
 	Context("/", func() {
-		var uaaConfig config.UAA
+		var (
+			uaaConfig      config.UAA
+			sshProxyConfig config.SSHProxy
+		)
 
 		BeforeEach(func() {
 			uaaConfig = config.UAA{}
+			sshProxyConfig = config.SSHProxy{}
 		})
 
 		JustBeforeEach(func() {
-			response := presenter.ForRoot(*baseURL, uaaConfig, *logCacheURL)
+			response := presenter.ForRoot(*baseURL, uaaConfig, *logCacheURL, sshProxyConfig, "the-fingerprint")
 			var err error
 			output, err = json.Marshal(response)
 			Expect(err).NotTo(HaveOccurred())
@@ -68,8 +72,18 @@ var _ = Describe("Root endpoints", func() {
 							}
 					},
 					"network_policy_v0": null,
-					"network_policy_v1": null,
-					"routing": null,
+					"network_policy_v1": {
+							"href": "https://api.example.org/networking/v1/external",
+							"meta": {
+									"version": ""
+							}
+					},
+					"routing": {
+							"href": "https://api.example.org/routing",
+							"meta": {
+									"version": ""
+							}
+					},
 					"self": {
 							"href": "https://api.example.org",
 							"meta": {
@@ -118,8 +132,18 @@ var _ = Describe("Root endpoints", func() {
 							}
 					},
 					"network_policy_v0": null,
-					"network_policy_v1": null,
-					"routing": null,
+					"network_policy_v1": {
+							"href": "https://api.example.org/networking/v1/external",
+							"meta": {
+									"version": ""
+							}
+					},
+					"routing": {
+							"href": "https://api.example.org/routing",
+							"meta": {
+									"version": ""
+							}
+					},
 					"self": {
 							"href": "https://api.example.org",
 							"meta": {
@@ -137,6 +161,28 @@ var _ = Describe("Root endpoints", func() {
 			}`))
 			})
 		})
+
+		When("the ssh proxy is enabled", func() {
+			BeforeEach(func() {
+				sshProxyConfig = config.SSHProxy{
+					Enabled:         true,
+					ExternalAddress: "ssh.example.org:2222",
+				}
+			})
+
+			It("produces the app ssh link", func() {
+				var root map[string]any
+				Expect(json.Unmarshal(output, &root)).To(Succeed())
+				Expect(root).To(HaveKeyWithValue("links", HaveKeyWithValue("app_ssh", Equal(map[string]any{
+					"href": "ssh.example.org:2222",
+					"meta": map[string]any{
+						"version":              "",
+						"host_key_fingerprint": "the-fingerprint",
+						"oauth_client":         "ssh-proxy",
+					},
+				}))))
+			})
+		})
 	})
 
 	Context("/v3", func() {
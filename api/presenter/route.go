@@ -17,7 +17,7 @@ const (
 type RouteResponse struct {
 	GUID         string             `json:"guid"`
 	Protocol     string             `json:"protocol"`
-	Port         *int               `json:"port"`
+	Port         *int32             `json:"port"`
 	Host         string             `json:"host"`
 	Path         string             `json:"path"`
 	URL          string             `json:"url"`
@@ -72,6 +72,7 @@ func ForRoute(route repositories.RouteRecord, baseURL url.URL, includes ...inclu
 	return RouteResponse{
 		GUID:          route.GUID,
 		Protocol:      route.Protocol,
+		Port:          routePort(route),
 		Host:          route.Host,
 		Path:          route.Path,
 		URL:           routeURL(route),
@@ -133,7 +134,18 @@ func ForRouteDestinations(route repositories.RouteRecord, baseURL url.URL) Route
 	}
 }
 
+func routePort(route repositories.RouteRecord) *int32 {
+	if route.Port == 0 {
+		return nil
+	}
+	return tools.PtrTo(route.Port)
+}
+
 func routeURL(route repositories.RouteRecord) string {
+	if route.Port != 0 {
+		return fmt.Sprintf("%s:%d", route.Domain.Name, route.Port)
+	}
+
 	if route.Host != "" {
 		return fmt.Sprintf("%s.%s%s", route.Host, route.Domain.Name, route.Path)
 	} else {
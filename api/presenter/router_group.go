@@ -0,0 +1,37 @@
+package presenter
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	routerGroupsBase = "/routing/v1/router_groups"
+)
+
+// RouterGroupResponse follows the routing API rather than the V3 API, as
+// that is where clients look router groups up
+type RouterGroupResponse struct {
+	GUID            string `json:"guid"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	ReservablePorts string `json:"reservable_ports"`
+}
+
+func ForRouterGroup(routerGroup repositories.RouterGroupRecord) RouterGroupResponse {
+	return RouterGroupResponse{
+		GUID:            routerGroup.GUID,
+		Name:            routerGroup.Name,
+		Type:            routerGroup.Type,
+		ReservablePorts: fmt.Sprintf("%d-%d", routerGroup.ReservablePorts.From, routerGroup.ReservablePorts.To),
+	}
+}
+
+func ForRouterGroupList(routerGroups []repositories.RouterGroupRecord) []RouterGroupResponse {
+	responses := []RouterGroupResponse{}
+	for _, routerGroup := range routerGroups {
+		responses = append(responses, ForRouterGroup(routerGroup))
+	}
+	return responses
+}
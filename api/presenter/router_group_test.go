@@ -0,0 +1,51 @@
+package presenter_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/config"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Router Groups", func() {
+	var (
+		output []byte
+		record repositories.RouterGroupRecord
+	)
+
+	BeforeEach(func() {
+		record = repositories.RouterGroupRecord{
+			GUID:            "default-tcp",
+			Name:            "default-tcp",
+			Type:            "tcp",
+			ReservablePorts: config.PortRange{From: 1024, To: 1033},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForRouterGroup(record))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected router group json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "default-tcp",
+			"name": "default-tcp",
+			"type": "tcp",
+			"reservable_ports": "1024-1033"
+		}`))
+	})
+
+	Describe("ForRouterGroupList", func() {
+		It("presents an empty list as an empty array", func() {
+			listOutput, err := json.Marshal(presenter.ForRouterGroupList(nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listOutput).To(MatchJSON(`[]`))
+		})
+	})
+})
@@ -62,3 +62,27 @@ func toManyRelationshipData(guids []string) []payloads.RelationshipData {
 		return payloads.RelationshipData{GUID: guid}
 	}))
 }
+
+type SecurityGroupSpacesRelationshipResponse struct {
+	Data  []payloads.RelationshipData `json:"data"`
+	Links SecurityGroupLinks          `json:"links"`
+}
+
+func ForSecurityGroupRunningSpaces(securityGroupRecord repositories.SecurityGroupRecord, baseURL url.URL) SecurityGroupSpacesRelationshipResponse {
+	return forSecurityGroupSpacesRelationship(securityGroupRecord.GUID, "running_spaces", securityGroupRecord.RunningSpaces, baseURL)
+}
+
+func ForSecurityGroupStagingSpaces(securityGroupRecord repositories.SecurityGroupRecord, baseURL url.URL) SecurityGroupSpacesRelationshipResponse {
+	return forSecurityGroupSpacesRelationship(securityGroupRecord.GUID, "staging_spaces", securityGroupRecord.StagingSpaces, baseURL)
+}
+
+func forSecurityGroupSpacesRelationship(securityGroupGUID string, relationship string, spaceGUIDs []string, baseURL url.URL) SecurityGroupSpacesRelationshipResponse {
+	return SecurityGroupSpacesRelationshipResponse{
+		Data: toManyRelationshipData(spaceGUIDs),
+		Links: SecurityGroupLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(securityGroupBase, securityGroupGUID, "relationships", relationship).build(),
+			},
+		},
+	}
+}
@@ -103,3 +103,50 @@ var _ = Describe("SecurityGroup", func() {
 		})
 	})
 })
+
+var _ = Describe("SecurityGroupSpacesRelationship", func() {
+	var (
+		baseURL *url.URL
+		record  repositories.SecurityGroupRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.SecurityGroupRecord{
+			GUID:          "security-group-guid",
+			RunningSpaces: []string{"space-1", "space-2"},
+			StagingSpaces: []string{},
+		}
+	})
+
+	It("presents the running spaces", func() {
+		output, err := json.Marshal(presenter.ForSecurityGroupRunningSpaces(record, *baseURL))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(MatchJSON(`{
+            "data": [
+                { "guid": "space-1" },
+                { "guid": "space-2" }
+            ],
+            "links": {
+                "self": {
+                    "href": "https://api.example.org/v3/security_groups/security-group-guid/relationships/running_spaces"
+                }
+            }
+        }`))
+	})
+
+	It("presents the staging spaces", func() {
+		output, err := json.Marshal(presenter.ForSecurityGroupStagingSpaces(record, *baseURL))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(MatchJSON(`{
+            "data": [],
+            "links": {
+                "self": {
+                    "href": "https://api.example.org/v3/security_groups/security-group-guid/relationships/staging_spaces"
+                }
+            }
+        }`))
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/include"
 	"code.cloudfoundry.org/korifi/tools"
@@ -43,12 +44,13 @@ type lastOperation struct {
 }
 
 type ServiceInstanceLinks struct {
-	Self                      Link `json:"self"`
-	Space                     Link `json:"space"`
-	Credentials               Link `json:"credentials"`
-	ServicePlan               Link `json:"service_plan"`
-	ServiceCredentialBindings Link `json:"service_credential_bindings"`
-	ServiceRouteBindings      Link `json:"service_route_bindings"`
+	Self                      Link  `json:"self"`
+	Space                     Link  `json:"space"`
+	Credentials               Link  `json:"credentials"`
+	ServicePlan               Link  `json:"service_plan"`
+	ServiceCredentialBindings Link  `json:"service_credential_bindings"`
+	ServiceRouteBindings      Link  `json:"service_route_bindings"`
+	SharedSpaces              *Link `json:"shared_spaces,omitempty"`
 }
 
 func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord, baseURL url.URL, includes ...include.Resource) ServiceInstanceResponse {
@@ -97,7 +99,79 @@ func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord
 	if serviceInstanceRecord.Type == "managed" {
 		response.MaintenanceInfo = tools.PtrTo(MaintenanceInfo{Version: serviceInstanceRecord.MaintenanceInfo.Version})
 		response.UpgradeAvailable = tools.PtrTo(serviceInstanceRecord.UpgradeAvailable)
+		response.Links.SharedSpaces = &Link{
+			HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "relationships", "shared_spaces").build(),
+		}
+	}
+
+	if serviceInstanceRecord.RouteServiceURL != "" {
+		response.RouteServiceURL = tools.PtrTo(serviceInstanceRecord.RouteServiceURL)
+	}
+
+	if serviceInstanceRecord.SyslogDrainURL != "" {
+		response.SyslogDrainURL = tools.PtrTo(serviceInstanceRecord.SyslogDrainURL)
 	}
 
 	return response
 }
+
+type ServiceInstanceSharedSpacesLinks struct {
+	Self Link `json:"self"`
+}
+
+type ServiceInstanceSharedSpacesRelationshipResponse struct {
+	Data  []payloads.RelationshipData      `json:"data"`
+	Links ServiceInstanceSharedSpacesLinks `json:"links"`
+}
+
+func ForServiceInstanceSharedSpaces(serviceInstanceRecord repositories.ServiceInstanceRecord, baseURL url.URL) ServiceInstanceSharedSpacesRelationshipResponse {
+	return ServiceInstanceSharedSpacesRelationshipResponse{
+		Data: toManyRelationshipData(serviceInstanceRecord.SharedSpaceGUIDs),
+		Links: ServiceInstanceSharedSpacesLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "relationships", "shared_spaces").build(),
+			},
+		},
+	}
+}
+
+type SharedSpaceUsage struct {
+	Space         payloads.RelationshipData `json:"space"`
+	BoundAppCount int                       `json:"bound_app_count"`
+}
+
+type ServiceInstanceSharedSpacesUsageSummaryLinks struct {
+	Self            Link `json:"self"`
+	SharedSpaces    Link `json:"shared_spaces"`
+	ServiceInstance Link `json:"service_instance"`
+}
+
+type ServiceInstanceSharedSpacesUsageSummaryResponse struct {
+	UsageSummary []SharedSpaceUsage                           `json:"usage_summary"`
+	Links        ServiceInstanceSharedSpacesUsageSummaryLinks `json:"links"`
+}
+
+func ForServiceInstanceSharedSpacesUsageSummary(serviceInstanceGUID string, usageRecords []repositories.SharedSpaceUsageRecord, baseURL url.URL) ServiceInstanceSharedSpacesUsageSummaryResponse {
+	usageSummary := []SharedSpaceUsage{}
+	for _, usageRecord := range usageRecords {
+		usageSummary = append(usageSummary, SharedSpaceUsage{
+			Space:         payloads.RelationshipData{GUID: usageRecord.SpaceGUID},
+			BoundAppCount: usageRecord.BoundAppCount,
+		})
+	}
+
+	return ServiceInstanceSharedSpacesUsageSummaryResponse{
+		UsageSummary: usageSummary,
+		Links: ServiceInstanceSharedSpacesUsageSummaryLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceGUID, "relationships", "shared_spaces", "usage_summary").build(),
+			},
+			SharedSpaces: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceGUID, "relationships", "shared_spaces").build(),
+			},
+			ServiceInstance: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceGUID).build(),
+			},
+		},
+	}
+}
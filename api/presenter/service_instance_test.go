@@ -113,6 +113,18 @@ var _ = Describe("Service Instance", func() {
 		}`))
 	})
 
+	When("the route service and syslog drain urls are set", func() {
+		BeforeEach(func() {
+			record.RouteServiceURL = "https://route-service.example.com"
+			record.SyslogDrainURL = "syslog-tls://logs.example.com:6514"
+		})
+
+		It("presents them", func() {
+			Expect(output).To(MatchJSONPath("$.route_service_url", "https://route-service.example.com"))
+			Expect(output).To(MatchJSONPath("$.syslog_drain_url", "syslog-tls://logs.example.com:6514"))
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
@@ -149,5 +161,94 @@ var _ = Describe("Service Instance", func() {
 		It("returns upgrade_available", func() {
 			Expect(output).To(MatchJSONPath("$.upgrade_available", BeTrue()))
 		})
+
+		It("returns the shared spaces link", func() {
+			Expect(output).To(MatchJSONPath("$.links.shared_spaces.href", "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"))
+		})
+	})
+})
+
+var _ = Describe("Service Instance Shared Spaces", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.ServiceInstanceRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.ServiceInstanceRecord{
+			GUID:             "service-instance-guid",
+			SharedSpaceGUIDs: []string{"space-1", "space-2"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForServiceInstanceSharedSpaces(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"data": [
+				{ "guid": "space-1" },
+				{ "guid": "space-2" }
+			],
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"
+				}
+			}
+		}`))
+	})
+
+	When("the instance is not shared", func() {
+		BeforeEach(func() {
+			record.SharedSpaceGUIDs = nil
+		})
+
+		It("returns an empty data list", func() {
+			Expect(output).To(MatchJSONPath("$.data", BeEmpty()))
+		})
+	})
+})
+
+var _ = Describe("Service Instance Shared Spaces Usage Summary", func() {
+	var output []byte
+
+	JustBeforeEach(func() {
+		baseURL, err := url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+
+		response := presenter.ForServiceInstanceSharedSpacesUsageSummary("service-instance-guid", []repositories.SharedSpaceUsageRecord{
+			{SpaceGUID: "space-1", BoundAppCount: 2},
+			{SpaceGUID: "space-2", BoundAppCount: 0},
+		}, *baseURL)
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("returns the expected JSON", func() {
+		Expect(output).To(MatchJSON(`{
+			"usage_summary": [
+				{ "space": { "guid": "space-1" }, "bound_app_count": 2 },
+				{ "space": { "guid": "space-2" }, "bound_app_count": 0 }
+			],
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces/usage_summary"
+				},
+				"shared_spaces": {
+					"href": "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"
+				},
+				"service_instance": {
+					"href": "https://api.example.org/v3/service_instances/service-instance-guid"
+				}
+			}
+		}`))
 	})
 })
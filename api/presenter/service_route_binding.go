@@ -0,0 +1,64 @@
+package presenter
+
+import (
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+type ServiceRouteBindingResponse struct {
+	GUID            string                              `json:"guid"`
+	RouteServiceURL *string                             `json:"route_service_url"`
+	CreatedAt       time.Time                           `json:"created_at"`
+	UpdatedAt       time.Time                           `json:"updated_at"`
+	LastOperation   ServiceBindingLastOperationResponse `json:"last_operation"`
+	Relationships   map[string]ToOneRelationship        `json:"relationships"`
+	Links           ServiceRouteBindingLinks            `json:"links"`
+	Metadata        Metadata                            `json:"metadata"`
+}
+
+type ServiceRouteBindingLinks struct {
+	Self            Link `json:"self"`
+	ServiceInstance Link `json:"service_instance"`
+	Route           Link `json:"route"`
+}
+
+func ForServiceRouteBinding(record repositories.ServiceRouteBindingRecord, baseURL url.URL, includes ...include.Resource) ServiceRouteBindingResponse {
+	response := ServiceRouteBindingResponse{
+		GUID:      record.GUID,
+		CreatedAt: tools.ZeroIfNil(toUTC(&record.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(toUTC(record.UpdatedAt)),
+		LastOperation: ServiceBindingLastOperationResponse{
+			Type:        record.LastOperation.Type,
+			State:       record.LastOperation.State,
+			Description: record.LastOperation.Description,
+			CreatedAt:   tools.ZeroIfNil(toUTC(&record.LastOperation.CreatedAt)),
+			UpdatedAt:   tools.ZeroIfNil(toUTC(record.LastOperation.UpdatedAt)),
+		},
+		Relationships: ForRelationships(record.Relationships()),
+		Links: ServiceRouteBindingLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceRouteBindingsBase, record.GUID).build(),
+			},
+			ServiceInstance: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, record.ServiceInstanceGUID).build(),
+			},
+			Route: Link{
+				HRef: buildURL(baseURL).appendPath(routesBase, record.RouteGUID).build(),
+			},
+		},
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(record.Labels),
+			Annotations: emptyMapIfNil(record.Annotations),
+		},
+	}
+
+	if record.RouteServiceURL != "" {
+		response.RouteServiceURL = tools.PtrTo(record.RouteServiceURL)
+	}
+
+	return response
+}
@@ -0,0 +1,67 @@
+package presenter
+
+import (
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const serviceUsageEventsBase = "/v3/service_usage_events"
+
+type ServiceUsageEventResponse struct {
+	GUID            string                    `json:"guid"`
+	CreatedAt       time.Time                 `json:"created_at"`
+	UpdatedAt       time.Time                 `json:"updated_at"`
+	State           string                    `json:"state"`
+	ServiceInstance ServiceUsageEventInstance `json:"service_instance"`
+	ServicePlan     *UsageEventResource       `json:"service_plan"`
+	ServiceOffering *UsageEventResource       `json:"service_offering"`
+	ServiceBroker   *UsageEventResource       `json:"service_broker"`
+	Space           UsageEventResource        `json:"space"`
+	Organization    UsageEventGUIDRef         `json:"organization"`
+	Links           UsageEventLinks           `json:"links"`
+}
+
+type ServiceUsageEventInstance struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func ForServiceUsageEvent(record repositories.ServiceUsageEventRecord, baseURL url.URL, includes ...include.Resource) ServiceUsageEventResponse {
+	return ServiceUsageEventResponse{
+		GUID:      record.GUID,
+		CreatedAt: tools.ZeroIfNil(toUTC(&record.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(toUTC(record.UpdatedAt)),
+		State:     record.State,
+		ServiceInstance: ServiceUsageEventInstance{
+			GUID: record.ServiceInstanceGUID,
+			Name: record.ServiceInstanceName,
+			Type: record.ServiceInstanceType,
+		},
+		ServicePlan:     toUsageEventResource(record.ServicePlan),
+		ServiceOffering: toUsageEventResource(record.ServiceOffering),
+		ServiceBroker:   toUsageEventResource(record.ServiceBroker),
+		Space: UsageEventResource{
+			GUID: record.SpaceGUID,
+			Name: record.SpaceName,
+		},
+		Organization: UsageEventGUIDRef{GUID: record.OrganizationGUID},
+		Links: UsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceUsageEventsBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func toUsageEventResource(resource *repositories.ServiceUsageEventResource) *UsageEventResource {
+	if resource == nil {
+		return nil
+	}
+
+	return &UsageEventResource{GUID: resource.GUID, Name: resource.Name}
+}
@@ -0,0 +1,104 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceUsageEvent", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.ServiceUsageEventRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.ServiceUsageEventRecord{
+			GUID:                "the-service-usage-event-guid",
+			State:               "CREATED",
+			ServiceInstanceGUID: "the-service-instance-guid",
+			ServiceInstanceName: "my-service-instance",
+			ServiceInstanceType: "managed_service_instance",
+			SpaceGUID:           "the-space-guid",
+			SpaceName:           "my-space",
+			OrganizationGUID:    "the-org-guid",
+			ServicePlan:         &repositories.ServiceUsageEventResource{GUID: "the-plan-guid", Name: "my-plan"},
+			ServiceOffering:     &repositories.ServiceUsageEventResource{GUID: "the-offering-guid", Name: "my-offering"},
+			ServiceBroker:       &repositories.ServiceUsageEventResource{GUID: "the-broker-guid", Name: "my-broker"},
+			CreatedAt:           time.UnixMilli(1000),
+			UpdatedAt:           tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForServiceUsageEvent(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected service usage event json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "the-service-usage-event-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"state": "CREATED",
+			"service_instance": {
+				"guid": "the-service-instance-guid",
+				"name": "my-service-instance",
+				"type": "managed_service_instance"
+			},
+			"service_plan": {
+				"guid": "the-plan-guid",
+				"name": "my-plan"
+			},
+			"service_offering": {
+				"guid": "the-offering-guid",
+				"name": "my-offering"
+			},
+			"service_broker": {
+				"guid": "the-broker-guid",
+				"name": "my-broker"
+			},
+			"space": {
+				"guid": "the-space-guid",
+				"name": "my-space"
+			},
+			"organization": {
+				"guid": "the-org-guid"
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/service_usage_events/the-service-usage-event-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the service instance is user-provided", func() {
+		BeforeEach(func() {
+			record.ServiceInstanceType = "user_provided_service_instance"
+			record.ServicePlan = nil
+			record.ServiceOffering = nil
+			record.ServiceBroker = nil
+		})
+
+		It("presents null plan, offering and broker", func() {
+			Expect(output).To(MatchJSONPath("$.service_plan", BeNil()))
+			Expect(output).To(MatchJSONPath("$.service_offering", BeNil()))
+			Expect(output).To(MatchJSONPath("$.service_broker", BeNil()))
+		})
+	})
+})
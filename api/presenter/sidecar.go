@@ -0,0 +1,43 @@
+package presenter
+
+import (
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const sidecarOriginUser = "user"
+
+type SidecarResponse struct {
+	GUID          string                       `json:"guid"`
+	Name          string                       `json:"name"`
+	Command       string                       `json:"command"`
+	ProcessTypes  []string                     `json:"process_types"`
+	MemoryInMB    *int64                       `json:"memory_in_mb"`
+	Origin        string                       `json:"origin"`
+	Relationships map[string]ToOneRelationship `json:"relationships"`
+	CreatedAt     time.Time                    `json:"created_at"`
+	UpdatedAt     time.Time                    `json:"updated_at"`
+}
+
+func ForSidecar(record repositories.SidecarRecord, _ url.URL, includes ...include.Resource) SidecarResponse {
+	var memoryInMB *int64
+	if record.MemoryMB != 0 {
+		memoryInMB = tools.PtrTo(record.MemoryMB)
+	}
+
+	return SidecarResponse{
+		GUID:          record.GUID,
+		Name:          record.Name,
+		Command:       record.Command,
+		ProcessTypes:  emptySliceIfNil(record.ProcessTypes),
+		MemoryInMB:    memoryInMB,
+		Origin:        sidecarOriginUser,
+		Relationships: ForRelationships(record.Relationships()),
+		CreatedAt:     tools.ZeroIfNil(toUTC(&record.CreatedAt)),
+		UpdatedAt:     tools.ZeroIfNil(toUTC(record.UpdatedAt)),
+	}
+}
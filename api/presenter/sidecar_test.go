@@ -0,0 +1,77 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sidecar", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.SidecarRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.SidecarRecord{
+			GUID:         "the-sidecar-guid",
+			Name:         "my-sidecar",
+			Command:      "bin/my-sidecar",
+			ProcessTypes: []string{"web", "worker"},
+			MemoryMB:     64,
+			AppGUID:      "the-app-guid",
+			SpaceGUID:    "the-space-guid",
+			CreatedAt:    time.UnixMilli(1000),
+			UpdatedAt:    tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForSidecar(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected sidecar json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "the-sidecar-guid",
+			"name": "my-sidecar",
+			"command": "bin/my-sidecar",
+			"process_types": ["web", "worker"],
+			"memory_in_mb": 64,
+			"origin": "user",
+			"relationships": {
+				"app": {
+					"data": {
+						"guid": "the-app-guid"
+					}
+				}
+			},
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z"
+		}`))
+	})
+
+	When("the sidecar has no memory limit", func() {
+		BeforeEach(func() {
+			record.MemoryMB = 0
+		})
+
+		It("renders the memory as null", func() {
+			Expect(output).To(MatchJSONPath("$.memory_in_mb", BeNil()))
+		})
+	})
+})
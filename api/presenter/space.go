@@ -50,3 +50,11 @@ func ForSpace(space repositories.SpaceRecord, apiBaseURL url.URL, includes ...in
 		Included: includedResources(includes...),
 	}
 }
+
+func ForSpaceSSHFeature(space repositories.SpaceRecord) FeatureResponse {
+	return FeatureResponse{
+		Name:        "ssh",
+		Description: "Enable SSHing into apps in the space.",
+		Enabled:     space.AllowSSH,
+	}
+}
@@ -0,0 +1,70 @@
+package presenter
+
+import (
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const spaceQuotasBase = "/v3/space_quotas"
+
+type SpaceQuotaResponse struct {
+	GUID          string                           `json:"guid"`
+	CreatedAt     time.Time                        `json:"created_at"`
+	UpdatedAt     time.Time                        `json:"updated_at"`
+	Name          string                           `json:"name"`
+	Apps          payloads.QuotaApps               `json:"apps"`
+	Services      payloads.QuotaServices           `json:"services"`
+	Routes        payloads.QuotaRoutes             `json:"routes"`
+	Relationships payloads.SpaceQuotaRelationships `json:"relationships"`
+	Links         SpaceQuotaLinks                  `json:"links"`
+}
+
+type SpaceQuotaLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForSpaceQuota(spaceQuotaRecord repositories.SpaceQuotaRecord, baseURL url.URL, includes ...include.Resource) SpaceQuotaResponse {
+	return SpaceQuotaResponse{
+		GUID:      spaceQuotaRecord.GUID,
+		CreatedAt: tools.ZeroIfNil(toUTC(&spaceQuotaRecord.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(toUTC(spaceQuotaRecord.UpdatedAt)),
+		Name:      spaceQuotaRecord.Name,
+		Apps:      forQuotaApps(spaceQuotaRecord.Limits.Apps),
+		Services:  forQuotaServices(spaceQuotaRecord.Limits.Services),
+		Routes:    forQuotaRoutes(spaceQuotaRecord.Limits.Routes),
+		Relationships: payloads.SpaceQuotaRelationships{
+			Organization: &payloads.Relationship{
+				Data: &payloads.RelationshipData{GUID: spaceQuotaRecord.OrgGUID},
+			},
+			Spaces: payloads.ToManyRelationship{
+				Data: toManyRelationshipData(spaceQuotaRecord.SpaceGUIDs),
+			},
+		},
+		Links: SpaceQuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spaceQuotasBase, spaceQuotaRecord.GUID).build(),
+			},
+		},
+	}
+}
+
+type SpaceQuotaSpacesRelationshipResponse struct {
+	Data  []payloads.RelationshipData `json:"data"`
+	Links SpaceQuotaLinks             `json:"links"`
+}
+
+func ForSpaceQuotaSpaces(spaceQuotaRecord repositories.SpaceQuotaRecord, baseURL url.URL) SpaceQuotaSpacesRelationshipResponse {
+	return SpaceQuotaSpacesRelationshipResponse{
+		Data: toManyRelationshipData(spaceQuotaRecord.SpaceGUIDs),
+		Links: SpaceQuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spaceQuotasBase, spaceQuotaRecord.GUID, "relationships", "spaces").build(),
+			},
+		},
+	}
+}
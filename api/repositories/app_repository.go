@@ -65,6 +65,7 @@ type AppRecord struct {
 	UpdatedAt             *time.Time
 	DeletedAt             *time.Time
 	IsStaged              bool
+	EnableSSH             bool
 	envSecretName         string
 	vcapServiceSecretName string
 	vcapAppSecretName     string
@@ -137,6 +138,7 @@ type PatchAppMessage struct {
 	Name                 string
 	Lifecycle            *LifecyclePatch
 	EnvironmentVariables map[string]string
+	EnableSSH            *bool
 	MetadataPatch
 }
 
@@ -573,6 +575,10 @@ func (m *PatchAppMessage) Apply(app *korifiv1alpha1.CFApp) {
 		}
 	}
 
+	if m.EnableSSH != nil {
+		app.Spec.EnableSSH = tools.PtrTo(*m.EnableSSH)
+	}
+
 	m.MetadataPatch.Apply(app)
 }
 
@@ -603,6 +609,7 @@ func cfAppToAppRecord(cfApp korifiv1alpha1.CFApp) (AppRecord, error) {
 		UpdatedAt:             updatedAt,
 		DeletedAt:             golangTime(cfApp.DeletionTimestamp),
 		IsStaged:              cfApp.Spec.CurrentDropletRef.Name != "",
+		EnableSSH:             cfApp.Spec.EnableSSH == nil || *cfApp.Spec.EnableSSH,
 		envSecretName:         cfApp.Spec.EnvSecretName,
 		vcapServiceSecretName: cfApp.Status.VCAPServicesSecretName,
 		vcapAppSecretName:     cfApp.Status.VCAPApplicationSecretName,
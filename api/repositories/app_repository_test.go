@@ -482,6 +482,21 @@ var _ = Describe("AppRepository", func() {
 				Expect(cfApp.Annotations).To(HaveKeyWithValue("a", "av"))
 			})
 
+			It("keeps ssh enabled by default", func() {
+				Expect(patchedAppRecord.EnableSSH).To(BeTrue())
+			})
+
+			When("ssh is disabled", func() {
+				BeforeEach(func() {
+					appPatchMessage.EnableSSH = tools.PtrTo(false)
+				})
+
+				It("disables ssh for the app", func() {
+					Expect(patchedAppRecord.EnableSSH).To(BeFalse())
+					Expect(cfApp.Spec.EnableSSH).To(Equal(tools.PtrTo(false)))
+				})
+			})
+
 			Describe("partially patching the app", func() {
 				var originalCFApp *korifiv1alpha1.CFApp
 
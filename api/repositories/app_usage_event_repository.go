@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+	"github.com/BooleanCat/go-functional/v2/it"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfappusageevents;cfserviceusageevents,verbs=create
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfprocesses;cfserviceinstances,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces;cfserviceplans;cfserviceofferings;cfservicebrokers,verbs=get
+
+const AppUsageEventResourceType = "App Usage Event"
+
+type AppUsageEventRepo struct {
+	klient            Klient
+	userClientFactory authorization.UserClientFactory
+	privilegedClient  client.Client
+	usageRecorder     *usage.Recorder
+	rootNamespace     string
+}
+
+func NewAppUsageEventRepo(
+	klient Klient,
+	userClientFactory authorization.UserClientFactory,
+	privilegedClient client.Client,
+	rootNamespace string,
+) *AppUsageEventRepo {
+	return &AppUsageEventRepo{
+		klient:            klient,
+		userClientFactory: userClientFactory,
+		privilegedClient:  privilegedClient,
+		usageRecorder:     usage.NewRecorder(privilegedClient, rootNamespace),
+		rootNamespace:     rootNamespace,
+	}
+}
+
+type AppUsageEventRecord struct {
+	GUID                          string
+	State                         string
+	PreviousState                 string
+	AppGUID                       string
+	AppName                       string
+	ProcessGUID                   string
+	ProcessType                   string
+	SpaceGUID                     string
+	SpaceName                     string
+	OrganizationGUID              string
+	InstanceCount                 int32
+	PreviousInstanceCount         int32
+	MemoryInMBPerInstance         int64
+	PreviousMemoryInMBPerInstance int64
+	CreatedAt                     time.Time
+	UpdatedAt                     *time.Time
+}
+
+func (r AppUsageEventRecord) Relationships() map[string]string {
+	return map[string]string{}
+}
+
+type ListAppUsageEventsMessage struct {
+	GUIDs      []string
+	CreatedAts []TimestampFilter
+	OrderBy    string
+	Pagination Pagination
+}
+
+func (m *ListAppUsageEventsMessage) toListOptions() []ListOption {
+	listOptions := []ListOption{
+		WithLabelIn(korifiv1alpha1.GUIDLabelKey, m.GUIDs),
+		WithOrdering(m.OrderBy),
+		WithPaging(m.Pagination),
+	}
+
+	if len(m.CreatedAts) > 0 {
+		listOptions = append(listOptions, withCreatedAtFiltering(m.CreatedAts))
+	}
+
+	return listOptions
+}
+
+func (r *AppUsageEventRepo) GetAppUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (AppUsageEventRecord, error) {
+	usageEvent := &korifiv1alpha1.CFAppUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+	err := r.klient.Get(ctx, usageEvent)
+	if err != nil {
+		return AppUsageEventRecord{}, fmt.Errorf("failed to get app usage event: %w", apierrors.FromK8sError(err, AppUsageEventResourceType))
+	}
+
+	return toAppUsageEventRecord(*usageEvent), nil
+}
+
+func (r *AppUsageEventRepo) ListAppUsageEvents(ctx context.Context, authInfo authorization.Info, message ListAppUsageEventsMessage) (ListResult[AppUsageEventRecord], error) {
+	usageEventList := &korifiv1alpha1.CFAppUsageEventList{}
+	pageInfo, err := r.klient.List(ctx, usageEventList, message.toListOptions()...)
+	if err != nil {
+		return ListResult[AppUsageEventRecord]{}, fmt.Errorf("failed to list app usage events: %w", apierrors.FromK8sError(err, AppUsageEventResourceType))
+	}
+
+	return ListResult[AppUsageEventRecord]{
+		PageInfo: pageInfo,
+		Records:  slices.Collect(it.Map(slices.Values(usageEventList.Items), toAppUsageEventRecord)),
+	}, nil
+}
+
+// PurgeAndReseedAppUsageEvents deletes all app usage events and records a
+// STARTED event for every process of the currently started apps. The events
+// are deleted with the user client so that only admins can purge them.
+func (r *AppUsageEventRepo) PurgeAndReseedAppUsageEvents(ctx context.Context, authInfo authorization.Info) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.DeleteAllOf(ctx, &korifiv1alpha1.CFAppUsageEvent{}, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return fmt.Errorf("failed to purge app usage events: %w", apierrors.FromK8sError(err, AppUsageEventResourceType))
+	}
+
+	appList := &korifiv1alpha1.CFAppList{}
+	if err = r.privilegedClient.List(ctx, appList); err != nil {
+		return fmt.Errorf("failed to list apps: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	apps := map[types.NamespacedName]*korifiv1alpha1.CFApp{}
+	for i := range appList.Items {
+		apps[client.ObjectKeyFromObject(&appList.Items[i])] = &appList.Items[i]
+	}
+
+	processList := &korifiv1alpha1.CFProcessList{}
+	if err = r.privilegedClient.List(ctx, processList); err != nil {
+		return fmt.Errorf("failed to list processes: %w", apierrors.FromK8sError(err, ProcessResourceType))
+	}
+
+	for i := range processList.Items {
+		process := &processList.Items[i]
+		app, ok := apps[types.NamespacedName{Namespace: process.Namespace, Name: process.Spec.AppRef.Name}]
+		if !ok || !app.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if err = r.usageRecorder.SeedProcessUsage(ctx, app, process); err != nil {
+			return fmt.Errorf("failed to seed app usage events: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func toAppUsageEventRecord(usageEvent korifiv1alpha1.CFAppUsageEvent) AppUsageEventRecord {
+	return AppUsageEventRecord{
+		GUID:                          usageEvent.Name,
+		State:                         usageEvent.Spec.State,
+		PreviousState:                 usageEvent.Spec.PreviousState,
+		AppGUID:                       usageEvent.Spec.App.GUID,
+		AppName:                       usageEvent.Spec.App.Name,
+		ProcessGUID:                   usageEvent.Spec.Process.GUID,
+		ProcessType:                   usageEvent.Spec.Process.Type,
+		SpaceGUID:                     usageEvent.Spec.Space.GUID,
+		SpaceName:                     usageEvent.Spec.Space.Name,
+		OrganizationGUID:              usageEvent.Spec.OrganizationGUID,
+		InstanceCount:                 usageEvent.Spec.InstanceCount,
+		PreviousInstanceCount:         usageEvent.Spec.PreviousInstanceCount,
+		MemoryInMBPerInstance:         usageEvent.Spec.MemoryInMBPerInstance,
+		PreviousMemoryInMBPerInstance: usageEvent.Spec.PreviousMemoryInMBPerInstance,
+		CreatedAt:                     usageEvent.CreationTimestamp.Time,
+		UpdatedAt:                     getLastUpdatedTime(&usageEvent),
+	}
+}
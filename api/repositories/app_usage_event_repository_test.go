@@ -0,0 +1,249 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AppUsageEventRepository", func() {
+	var (
+		appUsageEventRepo *repositories.AppUsageEventRepo
+		org               *korifiv1alpha1.CFOrg
+		space             *korifiv1alpha1.CFSpace
+	)
+
+	createAppUsageEvent := func(state string) *korifiv1alpha1.CFAppUsageEvent {
+		GinkgoHelper()
+
+		usageEvent := &korifiv1alpha1.CFAppUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAppUsageEventSpec{
+				State:                 state,
+				App:                   korifiv1alpha1.UsageEventResource{GUID: "app-guid", Name: "my-app"},
+				Process:               korifiv1alpha1.UsageEventProcess{GUID: "process-guid", Type: "web"},
+				Space:                 korifiv1alpha1.UsageEventResource{GUID: space.Name, Name: space.Spec.DisplayName},
+				OrganizationGUID:      org.Name,
+				InstanceCount:         2,
+				MemoryInMBPerInstance: 256,
+			},
+		}
+		Expect(k8sClient.Create(ctx, usageEvent)).To(Succeed())
+
+		return usageEvent
+	}
+
+	BeforeEach(func() {
+		appUsageEventRepo = repositories.NewAppUsageEventRepo(rootNSKlient, userClientFactory, k8sClient, rootNamespace)
+
+		org = createOrgWithCleanup(ctx, uuid.NewString())
+		space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+	})
+
+	Describe("GetAppUsageEvent", func() {
+		var (
+			usageEvent *korifiv1alpha1.CFAppUsageEvent
+			record     repositories.AppUsageEventRecord
+			getErr     error
+		)
+
+		BeforeEach(func() {
+			usageEvent = createAppUsageEvent(korifiv1alpha1.AppUsageEventStateStarted)
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = appUsageEventRepo.GetAppUsageEvent(ctx, authInfo, usageEvent.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the app usage event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":                  Equal(usageEvent.Name),
+					"State":                 Equal(korifiv1alpha1.AppUsageEventStateStarted),
+					"AppGUID":               Equal("app-guid"),
+					"AppName":               Equal("my-app"),
+					"ProcessGUID":           Equal("process-guid"),
+					"ProcessType":           Equal("web"),
+					"SpaceGUID":             Equal(space.Name),
+					"OrganizationGUID":      Equal(org.Name),
+					"InstanceCount":         BeEquivalentTo(2),
+					"MemoryInMBPerInstance": BeEquivalentTo(256),
+					"CreatedAt":             BeTemporally("~", time.Now(), timeCheckThreshold),
+				}))
+			})
+
+			When("the app usage event does not exist", func() {
+				BeforeEach(func() {
+					usageEvent.Name = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListAppUsageEvents", func() {
+		var (
+			startedEvent *korifiv1alpha1.CFAppUsageEvent
+			stoppedEvent *korifiv1alpha1.CFAppUsageEvent
+			message      repositories.ListAppUsageEventsMessage
+			listResult   repositories.ListResult[repositories.AppUsageEventRecord]
+			listErr      error
+		)
+
+		BeforeEach(func() {
+			startedEvent = createAppUsageEvent(korifiv1alpha1.AppUsageEventStateStarted)
+			stoppedEvent = createAppUsageEvent(korifiv1alpha1.AppUsageEventStateStopped)
+			message = repositories.ListAppUsageEventsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			listResult, listErr = appUsageEventRepo.ListAppUsageEvents(ctx, authInfo, message)
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("lists all app usage events", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(startedEvent.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(stoppedEvent.Name)}),
+				))
+			})
+
+			When("filtering by guid", func() {
+				BeforeEach(func() {
+					message.GUIDs = []string{stoppedEvent.Name}
+				})
+
+				It("returns the matching app usage events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(listResult.Records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(stoppedEvent.Name)}),
+					))
+				})
+			})
+
+			When("filtering by creation time", func() {
+				BeforeEach(func() {
+					message.CreatedAts = []repositories.TimestampFilter{{
+						Operator: repositories.TimestampOperatorGreaterThan,
+						Values:   []time.Time{time.Now().Add(time.Hour)},
+					}}
+				})
+
+				It("returns the app usage events created in that time range", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(listResult.Records).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("PurgeAndReseedAppUsageEvents", func() {
+		var (
+			existingEvent *korifiv1alpha1.CFAppUsageEvent
+			startedApp    *korifiv1alpha1.CFApp
+			stoppedApp    *korifiv1alpha1.CFApp
+			purgeErr      error
+		)
+
+		createProcess := func(cfApp *korifiv1alpha1.CFApp) *korifiv1alpha1.CFProcess {
+			GinkgoHelper()
+
+			cfProcess := &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: cfApp.Namespace,
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					AppRef:           corev1.LocalObjectReference{Name: cfApp.Name},
+					ProcessType:      "web",
+					DesiredInstances: tools.PtrTo[int32](3),
+					MemoryMB:         512,
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfProcess)).To(Succeed())
+
+			return cfProcess
+		}
+
+		BeforeEach(func() {
+			existingEvent = createAppUsageEvent(korifiv1alpha1.AppUsageEventStateStarted)
+
+			startedApp = createAppCR(ctx, k8sClient, "started-app", uuid.NewString(), space.Name, string(korifiv1alpha1.StartedState))
+			createProcess(startedApp)
+			stoppedApp = createAppCR(ctx, k8sClient, "stopped-app", uuid.NewString(), space.Name, string(korifiv1alpha1.StoppedState))
+			createProcess(stoppedApp)
+		})
+
+		JustBeforeEach(func() {
+			purgeErr = appUsageEventRepo.PurgeAndReseedAppUsageEvents(ctx, authInfo)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(purgeErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(existingEvent), existingEvent)).To(Succeed())
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("replaces the app usage events with STARTED events for the started apps", func() {
+				Expect(purgeErr).NotTo(HaveOccurred())
+
+				Eventually(func(g Gomega) {
+					usageEventList := &korifiv1alpha1.CFAppUsageEventList{}
+					g.Expect(k8sClient.List(ctx, usageEventList, client.InNamespace(rootNamespace))).To(Succeed())
+					g.Expect(usageEventList.Items).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"ObjectMeta": MatchFields(IgnoreExtras, Fields{"Name": Equal(existingEvent.Name)}),
+					})))
+					g.Expect(usageEventList.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":                 Equal(korifiv1alpha1.AppUsageEventStateStarted),
+							"App":                   Equal(korifiv1alpha1.UsageEventResource{GUID: startedApp.Name, Name: "started-app"}),
+							"InstanceCount":         BeEquivalentTo(3),
+							"MemoryInMBPerInstance": BeEquivalentTo(512),
+						}),
+					})))
+					g.Expect(usageEventList.Items).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"App": MatchFields(IgnoreExtras, Fields{"GUID": Equal(stoppedApp.Name)}),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+	})
+})
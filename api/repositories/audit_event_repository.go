@@ -0,0 +1,360 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/filter"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=create
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+
+const (
+	AuditEventResourceType = "Audit Event"
+
+	AuditEventActorTypeUser           = "user"
+	AuditEventActorTypeServiceAccount = "service_account"
+
+	AuditEventTypeAppCreate                         = "audit.app.create"
+	AuditEventTypeAppUpdate                         = "audit.app.update"
+	AuditEventTypeAppDeleteRequest                  = "audit.app.delete-request"
+	AuditEventTypeAppStart                          = "audit.app.start"
+	AuditEventTypeAppStop                           = "audit.app.stop"
+	AuditEventTypeAppRestart                        = "audit.app.restart"
+	AuditEventTypeAppDropletMapped                  = "audit.app.droplet.mapped"
+	AuditEventTypeAppProcessScale                   = "audit.app.process.scale"
+	AuditEventTypeSpaceCreate                       = "audit.space.create"
+	AuditEventTypeSpaceDeleteRequest                = "audit.space.delete-request"
+	AuditEventTypeOrgCreate                         = "audit.organization.create"
+	AuditEventTypeServiceBindingCreate              = "audit.service_binding.create"
+	AuditEventTypeServiceBindingDelete              = "audit.service_binding.delete"
+	AuditEventTypeServiceKeyCreate                  = "audit.service_key.create"
+	AuditEventTypeServiceKeyDelete                  = "audit.service_key.delete"
+	AuditEventTypeServiceInstanceStartCreate        = "audit.service_instance.start_create"
+	AuditEventTypeServiceInstanceUpdate             = "audit.service_instance.update"
+	AuditEventTypeServiceInstanceStartUpdate        = "audit.service_instance.start_update"
+	AuditEventTypeServiceInstanceStartDelete        = "audit.service_instance.start_delete"
+	AuditEventTypeServiceInstanceShare              = "audit.service_instance.share"
+	AuditEventTypeServiceInstanceUnshare            = "audit.service_instance.unshare"
+	AuditEventTypeUserProvidedServiceInstanceCreate = "audit.user_provided_service_instance.create"
+	AuditEventTypeUserProvidedServiceInstanceUpdate = "audit.user_provided_service_instance.update"
+	AuditEventTypeUserProvidedServiceInstanceDelete = "audit.user_provided_service_instance.delete"
+	AuditEventTypeRouteCreate                       = "audit.route.create"
+	AuditEventTypeRouteUpdate                       = "audit.route.update"
+	AuditEventTypeRouteDeleteRequest                = "audit.route.delete-request"
+
+	AuditEventTargetTypeApp                         = "app"
+	AuditEventTargetTypeSpace                       = "space"
+	AuditEventTargetTypeOrg                         = "organization"
+	AuditEventTargetTypeServiceBinding              = "service_binding"
+	AuditEventTargetTypeServiceKey                  = "service_key"
+	AuditEventTargetTypeServiceInstance             = "service_instance"
+	AuditEventTargetTypeUserProvidedServiceInstance = "user_provided_service_instance"
+	AuditEventTargetTypeRoute                       = "route"
+	AuditEventTargetTypeUser                        = "user"
+)
+
+// RoleAuditEventType returns the CF audit event type for assigning (added is
+// true) or unassigning a role, e.g. audit.user.space_developer_add
+func RoleAuditEventType(roleType string, added bool) string {
+	if added {
+		return "audit.user." + roleType + "_add"
+	}
+	return "audit.user." + roleType + "_remove"
+}
+
+type AuditEventRepo struct {
+	klient           Klient
+	privilegedClient client.Client
+	nsPerms          *authorization.NamespacePermissions
+	identityProvider authorization.IdentityProvider
+	rootNamespace    string
+}
+
+func NewAuditEventRepo(
+	klient Klient,
+	privilegedClient client.Client,
+	nsPerms *authorization.NamespacePermissions,
+	identityProvider authorization.IdentityProvider,
+	rootNamespace string,
+) *AuditEventRepo {
+	return &AuditEventRepo{
+		klient:           klient,
+		privilegedClient: privilegedClient,
+		nsPerms:          nsPerms,
+		identityProvider: identityProvider,
+		rootNamespace:    rootNamespace,
+	}
+}
+
+type AuditEventActor struct {
+	GUID string
+	Type string
+	Name string
+}
+
+type AuditEventTarget struct {
+	GUID string
+	Type string
+	Name string
+}
+
+type AuditEventRecord struct {
+	GUID             string
+	Type             string
+	Actor            AuditEventActor
+	Target           AuditEventTarget
+	SpaceGUID        string
+	OrganizationGUID string
+	Data             map[string]any
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+}
+
+func (r AuditEventRecord) Relationships() map[string]string {
+	return map[string]string{}
+}
+
+type CreateAuditEventMessage struct {
+	Type   string
+	Target AuditEventTarget
+	// The space of the target. The org of the space is looked up when
+	// OrganizationGUID is not set.
+	SpaceGUID        string
+	OrganizationGUID string
+	Data             map[string]any
+}
+
+type TimestampOperator string
+
+const (
+	TimestampOperatorEqual              TimestampOperator = ""
+	TimestampOperatorLessThan           TimestampOperator = "lt"
+	TimestampOperatorLessThanOrEqual    TimestampOperator = "lte"
+	TimestampOperatorGreaterThan        TimestampOperator = "gt"
+	TimestampOperatorGreaterThanOrEqual TimestampOperator = "gte"
+)
+
+type TimestampFilter struct {
+	Operator TimestampOperator
+	Values   []time.Time
+}
+
+func (f TimestampFilter) Matches(t time.Time) bool {
+	switch f.Operator {
+	case TimestampOperatorLessThan:
+		return it.All(it.Map(slices.Values(f.Values), t.Before))
+	case TimestampOperatorLessThanOrEqual:
+		return it.All(it.Map(slices.Values(f.Values), func(v time.Time) bool { return !t.After(v) }))
+	case TimestampOperatorGreaterThan:
+		return it.All(it.Map(slices.Values(f.Values), t.After))
+	case TimestampOperatorGreaterThanOrEqual:
+		return it.All(it.Map(slices.Values(f.Values), func(v time.Time) bool { return !t.Before(v) }))
+	default:
+		return it.Any(it.Map(slices.Values(f.Values), t.Equal))
+	}
+}
+
+type ListAuditEventsMessage struct {
+	GUIDs             []string
+	TargetGUIDs       []string
+	Types             []string
+	SpaceGUIDs        []string
+	OrganizationGUIDs []string
+	CreatedAts        []TimestampFilter
+	OrderBy           string
+	Pagination        Pagination
+}
+
+func (m *ListAuditEventsMessage) toListOptions(authorizedScopeGUIDs []string) []ListOption {
+	listOptions := []ListOption{
+		WithLabelStrictlyIn(korifiv1alpha1.CFAuditEventScopeGUIDLabelKey, authorizedScopeGUIDs),
+		WithLabelIn(korifiv1alpha1.GUIDLabelKey, m.GUIDs),
+		WithLabelIn(korifiv1alpha1.CFAuditEventTargetGUIDLabelKey, m.TargetGUIDs),
+		WithLabelIn(korifiv1alpha1.CFAuditEventTypeLabelKey, m.Types),
+		WithLabelIn(korifiv1alpha1.SpaceGUIDLabelKey, m.SpaceGUIDs),
+		WithLabelIn(korifiv1alpha1.CFOrgGUIDKey, m.OrganizationGUIDs),
+		WithOrdering(m.OrderBy),
+		WithPaging(m.Pagination),
+	}
+
+	if len(m.CreatedAts) > 0 {
+		listOptions = append(listOptions, withCreatedAtFiltering(m.CreatedAts))
+	}
+
+	return listOptions
+}
+
+func withCreatedAtFiltering(createdAts []TimestampFilter) ListOption {
+	return WithFiltering("Created At", filter.CreatedAtPredicate(func(createdAt time.Time) bool {
+		return it.All(it.Map(slices.Values(createdAts), func(f TimestampFilter) bool {
+			return f.Matches(createdAt)
+		}))
+	}))
+}
+
+// CreateAuditEvent records that the user identified by authInfo performed an
+// action. Events are created with the privileged client in the root
+// namespace so that users cannot tamper with them.
+func (r *AuditEventRepo) CreateAuditEvent(ctx context.Context, authInfo authorization.Info, message CreateAuditEventMessage) error {
+	identity, err := r.identityProvider.GetIdentity(ctx, authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	orgGUID := message.OrganizationGUID
+	if orgGUID == "" && message.SpaceGUID != "" {
+		orgGUID, err = r.getSpaceOrgGUID(ctx, message.SpaceGUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	var data *runtime.RawExtension
+	if message.Data != nil {
+		data, err = korifiv1alpha1.AsRawExtension(message.Data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal audit event data: %w", err)
+		}
+	}
+
+	auditEvent := &korifiv1alpha1.CFAuditEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFAuditEventSpec{
+			Type: message.Type,
+			Actor: korifiv1alpha1.AuditEventActor{
+				GUID: identity.Name,
+				Type: actorType(identity),
+				Name: identity.Name,
+			},
+			Target: korifiv1alpha1.AuditEventTarget{
+				GUID: message.Target.GUID,
+				Type: message.Target.Type,
+				Name: message.Target.Name,
+			},
+			SpaceGUID:        message.SpaceGUID,
+			OrganizationGUID: orgGUID,
+			Data:             data,
+		},
+	}
+
+	err = r.privilegedClient.Create(ctx, auditEvent)
+	if err != nil {
+		return fmt.Errorf("failed to create audit event: %w", apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+
+	return nil
+}
+
+func (r *AuditEventRepo) getSpaceOrgGUID(ctx context.Context, spaceGUID string) (string, error) {
+	namespace := &corev1.Namespace{}
+	err := r.privilegedClient.Get(ctx, client.ObjectKey{Name: spaceGUID}, namespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to get space namespace: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	return namespace.Labels[korifiv1alpha1.CFOrgGUIDKey], nil
+}
+
+func actorType(identity authorization.Identity) string {
+	if identity.Kind == rbacv1.ServiceAccountKind {
+		return AuditEventActorTypeServiceAccount
+	}
+
+	return AuditEventActorTypeUser
+}
+
+// ListAuditEvents lists the audit events of the spaces and orgs the user has
+// a role in.
+func (r *AuditEventRepo) ListAuditEvents(ctx context.Context, authInfo authorization.Info, message ListAuditEventsMessage) (ListResult[AuditEventRecord], error) {
+	authorizedScopeGUIDs, err := r.getAuthorizedScopeGUIDs(ctx, authInfo)
+	if err != nil {
+		return ListResult[AuditEventRecord]{}, err
+	}
+
+	auditEventList := &korifiv1alpha1.CFAuditEventList{}
+	pageInfo, err := r.klient.List(ctx, auditEventList, message.toListOptions(authorizedScopeGUIDs)...)
+	if err != nil {
+		return ListResult[AuditEventRecord]{}, fmt.Errorf("failed to list audit events: %w", apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+
+	return ListResult[AuditEventRecord]{
+		PageInfo: pageInfo,
+		Records:  slices.Collect(it.Map(slices.Values(auditEventList.Items), toAuditEventRecord)),
+	}, nil
+}
+
+func (r *AuditEventRepo) GetAuditEvent(ctx context.Context, authInfo authorization.Info, guid string) (AuditEventRecord, error) {
+	listResult, err := r.ListAuditEvents(ctx, authInfo, ListAuditEventsMessage{GUIDs: []string{guid}})
+	if err != nil {
+		return AuditEventRecord{}, err
+	}
+
+	if len(listResult.Records) == 0 {
+		return AuditEventRecord{}, apierrors.NewNotFoundError(nil, AuditEventResourceType)
+	}
+
+	return listResult.Records[0], nil
+}
+
+func (r *AuditEventRepo) getAuthorizedScopeGUIDs(ctx context.Context, authInfo authorization.Info) ([]string, error) {
+	authorizedOrgNamespaces, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizedSpaceNamespaces, err := r.nsPerms.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(
+		slices.Collect(maps.Keys(authorizedOrgNamespaces)),
+		slices.Collect(maps.Keys(authorizedSpaceNamespaces)),
+	), nil
+}
+
+func toAuditEventRecord(auditEvent korifiv1alpha1.CFAuditEvent) AuditEventRecord {
+	data, err := korifiv1alpha1.AsMap(auditEvent.Spec.Data)
+	if err != nil {
+		data = map[string]any{}
+	}
+
+	return AuditEventRecord{
+		GUID: auditEvent.Name,
+		Type: auditEvent.Spec.Type,
+		Actor: AuditEventActor{
+			GUID: auditEvent.Spec.Actor.GUID,
+			Type: auditEvent.Spec.Actor.Type,
+			Name: auditEvent.Spec.Actor.Name,
+		},
+		Target: AuditEventTarget{
+			GUID: auditEvent.Spec.Target.GUID,
+			Type: auditEvent.Spec.Target.Type,
+			Name: auditEvent.Spec.Target.Name,
+		},
+		SpaceGUID:        auditEvent.Spec.SpaceGUID,
+		OrganizationGUID: auditEvent.Spec.OrganizationGUID,
+		Data:             data,
+		CreatedAt:        auditEvent.CreationTimestamp.Time,
+		UpdatedAt:        getLastUpdatedTime(&auditEvent),
+	}
+}
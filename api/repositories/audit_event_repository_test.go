@@ -0,0 +1,334 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AuditEventRepository", func() {
+	var (
+		auditEventRepo *repositories.AuditEventRepo
+		org            *korifiv1alpha1.CFOrg
+		space          *korifiv1alpha1.CFSpace
+	)
+
+	createAuditEvent := func(eventType, targetGUID, spaceGUID, orgGUID string) *korifiv1alpha1.CFAuditEvent {
+		GinkgoHelper()
+
+		auditEvent := &korifiv1alpha1.CFAuditEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAuditEventSpec{
+				Type:             eventType,
+				Actor:            korifiv1alpha1.AuditEventActor{GUID: "alice", Type: "user", Name: "alice"},
+				Target:           korifiv1alpha1.AuditEventTarget{GUID: targetGUID, Type: "app", Name: "my-app"},
+				SpaceGUID:        spaceGUID,
+				OrganizationGUID: orgGUID,
+			},
+		}
+		Expect(k8sClient.Create(ctx, auditEvent)).To(Succeed())
+
+		return auditEvent
+	}
+
+	BeforeEach(func() {
+		auditEventRepo = repositories.NewAuditEventRepo(rootNSKlient, k8sClient, nsPerms, idProvider, rootNamespace)
+
+		org = createOrgWithCleanup(ctx, uuid.NewString())
+		space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+
+		spaceNamespace := &corev1.Namespace{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Name: space.Name}, spaceNamespace)).To(Succeed())
+		Expect(k8s.PatchResource(ctx, k8sClient, spaceNamespace, func() {
+			spaceNamespace.Labels[korifiv1alpha1.CFOrgGUIDKey] = org.Name
+		})).To(Succeed())
+	})
+
+	Describe("CreateAuditEvent", func() {
+		var (
+			message   repositories.CreateAuditEventMessage
+			createErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.CreateAuditEventMessage{
+				Type: "audit.app.start",
+				Target: repositories.AuditEventTarget{
+					GUID: "app-guid",
+					Type: "app",
+					Name: "my-app",
+				},
+				SpaceGUID: space.Name,
+				Data:      map[string]any{"foo": "bar"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			createErr = auditEventRepo.CreateAuditEvent(ctx, authInfo, message)
+		})
+
+		getAuditEvents := func() []korifiv1alpha1.CFAuditEvent {
+			GinkgoHelper()
+
+			auditEvents := &korifiv1alpha1.CFAuditEventList{}
+			Expect(k8sClient.List(ctx, auditEvents, client.InNamespace(rootNamespace))).To(Succeed())
+			return auditEvents.Items
+		}
+
+		It("creates the audit event in the root namespace on behalf of the user", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+
+			auditEvents := getAuditEvents()
+			Expect(auditEvents).To(HaveLen(1))
+			Expect(auditEvents[0].Spec.Type).To(Equal("audit.app.start"))
+			Expect(auditEvents[0].Spec.Actor).To(Equal(korifiv1alpha1.AuditEventActor{
+				GUID: userName,
+				Type: repositories.AuditEventActorTypeUser,
+				Name: userName,
+			}))
+			Expect(auditEvents[0].Spec.Target).To(Equal(korifiv1alpha1.AuditEventTarget{
+				GUID: "app-guid",
+				Type: "app",
+				Name: "my-app",
+			}))
+			Expect(auditEvents[0].Spec.SpaceGUID).To(Equal(space.Name))
+			Expect(auditEvents[0].Spec.Data.Raw).To(MatchJSON(`{"foo":"bar"}`))
+		})
+
+		It("looks up the org of the space", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+
+			auditEvents := getAuditEvents()
+			Expect(auditEvents).To(HaveLen(1))
+			Expect(auditEvents[0].Spec.OrganizationGUID).To(Equal(org.Name))
+		})
+
+		When("the space does not exist", func() {
+			BeforeEach(func() {
+				message.SpaceGUID = uuid.NewString()
+			})
+
+			It("returns an error", func() {
+				Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+
+		When("the event is scoped to an org", func() {
+			BeforeEach(func() {
+				message.SpaceGUID = ""
+				message.OrganizationGUID = org.Name
+			})
+
+			It("creates the audit event without a space", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				auditEvents := getAuditEvents()
+				Expect(auditEvents).To(HaveLen(1))
+				Expect(auditEvents[0].Spec.SpaceGUID).To(BeEmpty())
+				Expect(auditEvents[0].Spec.OrganizationGUID).To(Equal(org.Name))
+			})
+		})
+	})
+
+	Describe("ListAuditEvents", func() {
+		var (
+			spaceEvent   *korifiv1alpha1.CFAuditEvent
+			orgEvent     *korifiv1alpha1.CFAuditEvent
+			otherEvent   *korifiv1alpha1.CFAuditEvent
+			message      repositories.ListAuditEventsMessage
+			listResult   repositories.ListResult[repositories.AuditEventRecord]
+			listErr      error
+			otherOrgGUID string
+		)
+
+		BeforeEach(func() {
+			otherOrg := createOrgWithCleanup(ctx, uuid.NewString())
+			otherOrgGUID = otherOrg.Name
+
+			spaceEvent = createAuditEvent("audit.app.start", "app-guid", space.Name, org.Name)
+			orgEvent = createAuditEvent("audit.space.delete-request", "deleted-space-guid", "", org.Name)
+			otherEvent = createAuditEvent("audit.app.start", "other-app-guid", "", otherOrgGUID)
+
+			message = repositories.ListAuditEventsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			listResult, listErr = auditEventRepo.ListAuditEvents(ctx, authInfo, message)
+		})
+
+		It("returns an empty list as the user has no roles", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(BeEmpty())
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the events of the spaces and orgs the user has a role in", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":             Equal(spaceEvent.Name),
+						"Type":             Equal("audit.app.start"),
+						"SpaceGUID":        Equal(space.Name),
+						"OrganizationGUID": Equal(org.Name),
+						"Actor": Equal(repositories.AuditEventActor{
+							GUID: "alice",
+							Type: "user",
+							Name: "alice",
+						}),
+						"Target": Equal(repositories.AuditEventTarget{
+							GUID: "app-guid",
+							Type: "app",
+							Name: "my-app",
+						}),
+						"CreatedAt": BeTemporally("~", time.Now(), timeCheckThreshold),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(orgEvent.Name),
+					}),
+				))
+			})
+
+			When("filtering by type", func() {
+				BeforeEach(func() {
+					message.Types = []string{"audit.space.delete-request"}
+				})
+
+				It("returns the matching events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(listResult.Records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(orgEvent.Name),
+					})))
+				})
+			})
+
+			When("filtering by target guid", func() {
+				BeforeEach(func() {
+					message.TargetGUIDs = []string{"app-guid"}
+				})
+
+				It("returns the matching events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(listResult.Records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(spaceEvent.Name),
+					})))
+				})
+			})
+
+			When("filtering by space guid", func() {
+				BeforeEach(func() {
+					message.SpaceGUIDs = []string{space.Name}
+				})
+
+				It("returns the matching events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(listResult.Records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(spaceEvent.Name),
+					})))
+				})
+			})
+
+			When("filtering by organization guid", func() {
+				BeforeEach(func() {
+					message.OrganizationGUIDs = []string{otherOrgGUID}
+				})
+
+				It("does not return events the user cannot see", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(listResult.Records).To(BeEmpty())
+				})
+			})
+
+			When("filtering by created_at", func() {
+				BeforeEach(func() {
+					message.CreatedAts = []repositories.TimestampFilter{{
+						Operator: repositories.TimestampOperatorGreaterThan,
+						Values:   []time.Time{time.Now().Add(time.Hour)},
+					}}
+				})
+
+				It("returns the matching events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(listResult.Records).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, org.Name)
+				createRoleBinding(ctx, userName, adminRole.Name, otherOrgGUID)
+			})
+
+			It("returns the events of all orgs", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"GUID": Equal(otherEvent.Name),
+				})))
+			})
+		})
+	})
+
+	Describe("GetAuditEvent", func() {
+		var (
+			auditEvent     *korifiv1alpha1.CFAuditEvent
+			auditEventGUID string
+			record         repositories.AuditEventRecord
+			getErr         error
+		)
+
+		BeforeEach(func() {
+			auditEvent = createAuditEvent("audit.app.start", "app-guid", space.Name, org.Name)
+			auditEventGUID = auditEvent.Name
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = auditEventRepo.GetAuditEvent(ctx, authInfo, auditEventGUID)
+		})
+
+		It("returns a not found error as the user has no roles", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the audit event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(auditEvent.Name))
+				Expect(record.Type).To(Equal("audit.app.start"))
+			})
+
+			When("the audit event does not exist", func() {
+				BeforeEach(func() {
+					auditEventGUID = uuid.NewString()
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+})
@@ -10,11 +10,17 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/version"
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/go-logr/logr"
+	"github.com/google/uuid"
 
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const DeploymentResourceType = "Deployment"
@@ -29,6 +35,13 @@ type DeploymentRecord struct {
 	UpdatedAt   *time.Time
 	DropletGUID string
 	Status      DeploymentStatus
+	Strategy    string
+	MaxInFlight int32
+	CanarySteps []DeploymentCanaryStep
+}
+
+type DeploymentCanaryStep struct {
+	InstanceWeight int32
 }
 
 func (r DeploymentRecord) Relationships() map[string]string {
@@ -49,16 +62,34 @@ type DeploymentStatusReason string
 const (
 	DeploymentStatusReasonDeploying DeploymentStatusReason = "DEPLOYING"
 	DeploymentStatusReasonDeployed  DeploymentStatusReason = "DEPLOYED"
+	DeploymentStatusReasonPaused    DeploymentStatusReason = "PAUSED"
+	DeploymentStatusReasonCanceling DeploymentStatusReason = "CANCELING"
+	DeploymentStatusReasonCanceled  DeploymentStatusReason = "CANCELED"
+)
+
+const (
+	DeploymentStrategyRolling = "rolling"
+	DeploymentStrategyCanary  = "canary"
 )
 
 type DeploymentStatus struct {
 	Value  DeploymentStatusValue
 	Reason DeploymentStatusReason
+	Canary *DeploymentCanaryStatus
+}
+
+type DeploymentCanaryStatus struct {
+	CurrentStep int32
+	TotalSteps  int32
 }
 
 type CreateDeploymentMessage struct {
-	AppGUID     string
-	DropletGUID string
+	AppGUID      string
+	DropletGUID  string
+	RevisionGUID string
+	Strategy     string
+	MaxInFlight  *int32
+	CanarySteps  []DeploymentCanaryStep
 }
 
 type ListDeploymentsMessage struct {
@@ -121,6 +152,24 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 		dropletGUID = message.DropletGUID
 	}
 
+	var revisionRef, previousRevisionRef corev1.LocalObjectReference
+	envSecretName := ""
+	if message.RevisionGUID != "" {
+		var revision *korifiv1alpha1.CFRevision
+		revision, envSecretName, err = r.restoreRevision(ctx, app, message.RevisionGUID)
+		if err != nil {
+			return DeploymentRecord{}, err
+		}
+		dropletGUID = revision.Spec.DropletRef.Name
+		revisionRef.Name = revision.Name
+		previousRevisionRef = app.Status.CurrentRevisionRef
+	}
+
+	strategy := korifiv1alpha1.DeploymentStrategyRolling
+	if message.Strategy != "" {
+		strategy = korifiv1alpha1.DeploymentStrategy(message.Strategy)
+	}
+
 	appRev := app.Annotations[korifiv1alpha1.CFAppRevisionKey]
 	newRev, err := bumpAppRev(appRev)
 	if err != nil {
@@ -128,7 +177,21 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 	}
 
 	err = r.klient.Patch(ctx, app, func() error {
+		app.Spec.Deployment = &korifiv1alpha1.CFAppDeployment{
+			Strategy:    strategy,
+			MaxInFlight: message.MaxInFlight,
+			CanarySteps: slices.Collect(it.Map(slices.Values(message.CanarySteps), func(step DeploymentCanaryStep) korifiv1alpha1.CanaryStep {
+				return korifiv1alpha1.CanaryStep{InstanceWeight: step.InstanceWeight}
+			})),
+			PreviousDropletRef:  app.Spec.CurrentDropletRef,
+			PreviousRevision:    appRev,
+			RevisionRef:         revisionRef,
+			PreviousRevisionRef: previousRevisionRef,
+		}
 		app.Spec.CurrentDropletRef.Name = dropletGUID
+		if envSecretName != "" {
+			app.Spec.EnvSecretName = envSecretName
+		}
 		if app.Annotations == nil {
 			app.Annotations = map[string]string{}
 		}
@@ -144,6 +207,266 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 	return appToDeploymentRecord(*app)
 }
 
+// restoreRevision brings the environment variables, the process commands and
+// the sidecars of the app back to the ones recorded in the revision. The droplet of the
+// revision is assigned by the deployment itself. Every step sets the app
+// resources to the revision values, so when restoring fails partway a retried
+// rollback completes it. It returns the name of the app env secret, which is
+// only set when the app had none and one had to be created.
+func (r *DeploymentRepo) restoreRevision(ctx context.Context, app *korifiv1alpha1.CFApp, revisionGUID string) (*korifiv1alpha1.CFRevision, string, error) {
+	revision := &korifiv1alpha1.CFRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: app.Namespace,
+			Name:      revisionGUID,
+		},
+	}
+	err := r.klient.Get(ctx, revision)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, "", apierrors.NewUnprocessableEntityError(err, "The revision does not exist")
+		}
+		return nil, "", apierrors.FromK8sError(err, RevisionResourceType)
+	}
+
+	if revision.Spec.AppRef.Name != app.Name {
+		return nil, "", apierrors.NewUnprocessableEntityError(nil, "The revision does not belong to the app")
+	}
+
+	processList := &korifiv1alpha1.CFProcessList{}
+	_, err = r.klient.List(ctx, processList, InNamespace(app.Namespace), WithLabel(korifiv1alpha1.CFAppGUIDLabelKey, app.Name))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list app processes: %w", apierrors.FromK8sError(err, ProcessResourceType))
+	}
+
+	envSecretName, err := r.restoreEnvSecret(ctx, app, revision)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to restore app environment variables: %w", apierrors.FromK8sError(err, AppEnvResourceType))
+	}
+
+	revisionCommands := map[string]string{}
+	for _, process := range revision.Spec.Processes {
+		revisionCommands[process.Type] = process.Command
+	}
+
+	for i := range processList.Items {
+		process := &processList.Items[i]
+		if process.Spec.Command == revisionCommands[process.Spec.ProcessType] {
+			continue
+		}
+
+		err = r.klient.Patch(ctx, process, func() error {
+			process.Spec.Command = revisionCommands[process.Spec.ProcessType]
+			return nil
+		})
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to restore process command: %w", apierrors.FromK8sError(err, ProcessResourceType))
+		}
+	}
+
+	if err = r.restoreSidecars(ctx, app, revision); err != nil {
+		return nil, "", fmt.Errorf("failed to restore app sidecars: %w", apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return revision, envSecretName, nil
+}
+
+// restoreSidecars deletes the app sidecars that are not part of the revision
+// and creates or updates the ones that are, matching them by name
+func (r *DeploymentRepo) restoreSidecars(ctx context.Context, app *korifiv1alpha1.CFApp, revision *korifiv1alpha1.CFRevision) error {
+	sidecarList := &korifiv1alpha1.CFSidecarList{}
+	_, err := r.klient.List(ctx, sidecarList, InNamespace(app.Namespace), WithLabel(korifiv1alpha1.CFAppGUIDLabelKey, app.Name))
+	if err != nil {
+		return err
+	}
+
+	revisionSidecars := map[string]korifiv1alpha1.RevisionSidecar{}
+	for _, sidecar := range revision.Spec.Sidecars {
+		revisionSidecars[sidecar.Name] = sidecar
+	}
+
+	appSidecars := map[string]*korifiv1alpha1.CFSidecar{}
+	for i := range sidecarList.Items {
+		sidecar := &sidecarList.Items[i]
+		if _, ok := revisionSidecars[sidecar.Spec.Name]; !ok {
+			if err = r.klient.Delete(ctx, sidecar); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+			continue
+		}
+		appSidecars[sidecar.Spec.Name] = sidecar
+	}
+
+	for _, revisionSidecar := range revision.Spec.Sidecars {
+		sidecar, ok := appSidecars[revisionSidecar.Name]
+		if !ok {
+			sidecar = &korifiv1alpha1.CFSidecar{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: app.Namespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFSidecarSpec{
+					AppRef:       corev1.LocalObjectReference{Name: app.Name},
+					Name:         revisionSidecar.Name,
+					Command:      revisionSidecar.Command,
+					ProcessTypes: revisionSidecar.ProcessTypes,
+					MemoryMB:     revisionSidecar.MemoryMB,
+				},
+			}
+			if err = controllerutil.SetOwnerReference(app, sidecar, scheme.Scheme); err != nil {
+				return fmt.Errorf("failed to set the owner of the sidecar: %w", err)
+			}
+			if err = r.klient.Create(ctx, sidecar); err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = r.klient.Patch(ctx, sidecar, func() error {
+			sidecar.Spec.Command = revisionSidecar.Command
+			sidecar.Spec.ProcessTypes = revisionSidecar.ProcessTypes
+			sidecar.Spec.MemoryMB = revisionSidecar.MemoryMB
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *DeploymentRepo) restoreEnvSecret(ctx context.Context, app *korifiv1alpha1.CFApp, revision *korifiv1alpha1.CFRevision) (string, error) {
+	revisionEnv := map[string][]byte{}
+	if revision.Spec.EnvSecretName != "" {
+		revisionEnvSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: revision.Namespace,
+				Name:      revision.Spec.EnvSecretName,
+			},
+		}
+		if err := r.klient.Get(ctx, revisionEnvSecret); err != nil {
+			return "", fmt.Errorf("failed to get revision environment variables: %w", err)
+		}
+		revisionEnv = revisionEnvSecret.Data
+	}
+
+	if app.Spec.EnvSecretName == "" {
+		if len(revisionEnv) == 0 {
+			return "", nil
+		}
+
+		envSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: app.Namespace,
+				Name:      uuid.NewString(),
+			},
+			Data: revisionEnv,
+		}
+		if err := controllerutil.SetOwnerReference(app, envSecret, scheme.Scheme); err != nil {
+			return "", fmt.Errorf("failed to set the owner of the env secret: %w", err)
+		}
+		if err := r.klient.Create(ctx, envSecret); err != nil {
+			return "", err
+		}
+		return envSecret.Name, nil
+	}
+
+	envSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: app.Namespace,
+			Name:      app.Spec.EnvSecretName,
+		},
+	}
+	err := r.klient.Get(ctx, envSecret)
+	if k8serrors.IsNotFound(err) {
+		envSecret.Data = revisionEnv
+		if err = controllerutil.SetOwnerReference(app, envSecret, scheme.Scheme); err != nil {
+			return "", fmt.Errorf("failed to set the owner of the env secret: %w", err)
+		}
+		return "", r.klient.Create(ctx, envSecret)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return "", r.klient.Patch(ctx, envSecret, func() error {
+		envSecret.Data = revisionEnv
+		return nil
+	})
+}
+
+func (r *DeploymentRepo) CancelDeployment(ctx context.Context, authInfo authorization.Info, deploymentGUID string) (DeploymentRecord, error) {
+	app := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: deploymentGUID,
+		},
+	}
+	err := r.klient.Get(ctx, app)
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	status := appToDeploymentStatus(*app)
+	if app.Spec.Deployment == nil || status.Value != DeploymentStatusValueActive || status.Reason == DeploymentStatusReasonCanceling {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Cannot cancel a deployment with status: %s and reason: %s.", status.Value, status.Reason))
+	}
+
+	envSecretName := ""
+	if app.Spec.Deployment.PreviousRevisionRef.Name != "" {
+		_, envSecretName, err = r.restoreRevision(ctx, app, app.Spec.Deployment.PreviousRevisionRef.Name)
+		if err != nil {
+			return DeploymentRecord{}, err
+		}
+	}
+
+	err = r.klient.Patch(ctx, app, func() error {
+		app.Spec.Deployment.Canceled = true
+		if envSecretName != "" {
+			app.Spec.EnvSecretName = envSecretName
+		}
+		if app.Spec.Deployment.PreviousDropletRef.Name != "" {
+			app.Spec.CurrentDropletRef = app.Spec.Deployment.PreviousDropletRef
+		}
+		if app.Spec.Deployment.PreviousRevision != "" {
+			app.Annotations[korifiv1alpha1.CFAppRevisionKey] = app.Spec.Deployment.PreviousRevision
+		}
+
+		return nil
+	})
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	return appToDeploymentRecord(*app)
+}
+
+func (r *DeploymentRepo) ContinueDeployment(ctx context.Context, authInfo authorization.Info, deploymentGUID string) (DeploymentRecord, error) {
+	app := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: deploymentGUID,
+		},
+	}
+	err := r.klient.Get(ctx, app)
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	status := appToDeploymentStatus(*app)
+	if status.Reason != DeploymentStatusReasonPaused {
+		return DeploymentRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Cannot continue a deployment with status: %s and reason: %s.", status.Value, status.Reason))
+	}
+
+	err = r.klient.Patch(ctx, app, func() error {
+		app.Spec.Deployment.CurrentStep++
+		return nil
+	})
+	if err != nil {
+		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+	}
+
+	return appToDeploymentRecord(*app)
+}
+
 func (r *DeploymentRepo) ListDeployments(ctx context.Context, authInfo authorization.Info, message ListDeploymentsMessage) (ListResult[DeploymentRecord], error) {
 	appList := &korifiv1alpha1.CFAppList{}
 	pageInfo, err := r.klient.List(ctx, appList, message.toListOptions()...)
@@ -177,29 +500,60 @@ func appToDeploymentRecord(cfApp korifiv1alpha1.CFApp) (DeploymentRecord, error)
 		return DeploymentRecord{}, err
 	}
 
-	return DeploymentRecord{
+	record := DeploymentRecord{
 		GUID:        cfApp.Name,
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
 		DropletGUID: cfApp.Spec.CurrentDropletRef.Name,
 		Status:      appToDeploymentStatus(cfApp),
-	}, nil
+		Strategy:    DeploymentStrategyRolling,
+		MaxInFlight: 1,
+	}
+
+	if deployment := cfApp.Spec.Deployment; deployment != nil {
+		record.Strategy = string(deployment.Strategy)
+		record.MaxInFlight = tools.ZeroIfNil(deployment.MaxInFlight)
+		if record.MaxInFlight == 0 {
+			record.MaxInFlight = 1
+		}
+		record.CanarySteps = slices.Collect(it.Map(slices.Values(deployment.CanarySteps), func(step korifiv1alpha1.CanaryStep) DeploymentCanaryStep {
+			return DeploymentCanaryStep{InstanceWeight: step.InstanceWeight}
+		}))
+	}
+
+	return record, nil
 }
 
 func appToDeploymentStatus(cfapp korifiv1alpha1.CFApp) DeploymentStatus {
 	deploymentStatusValue := cfapp.Labels[korifiv1alpha1.CFAppDeploymentStatusKey]
+	deployment := cfapp.Spec.Deployment
+
+	status := DeploymentStatus{
+		Value:  DeploymentStatusValueActive,
+		Reason: DeploymentStatusReasonDeploying,
+	}
 
-	if deploymentStatusValue == korifiv1alpha1.DeploymentStatusValueFinalized {
-		return DeploymentStatus{
-			Value:  DeploymentStatusValueFinalized,
-			Reason: DeploymentStatusReasonDeployed,
+	switch {
+	case deploymentStatusValue == korifiv1alpha1.DeploymentStatusValueFinalized:
+		status.Value = DeploymentStatusValueFinalized
+		status.Reason = DeploymentStatusReasonDeployed
+		if deployment != nil && deployment.Canceled {
+			status.Reason = DeploymentStatusReasonCanceled
 		}
+	case deployment != nil && deployment.Canceled:
+		status.Reason = DeploymentStatusReasonCanceling
+	case cfapp.Status.ObservedGeneration == cfapp.Generation && cfapp.Status.DeploymentState == korifiv1alpha1.DeploymentStatePaused:
+		status.Reason = DeploymentStatusReasonPaused
 	}
 
-	return DeploymentStatus{
-		Value:  DeploymentStatusValueActive,
-		Reason: DeploymentStatusReasonDeploying,
+	if deployment != nil && deployment.Strategy == korifiv1alpha1.DeploymentStrategyCanary {
+		status.Canary = &DeploymentCanaryStatus{
+			CurrentStep: min(deployment.CurrentStep+1, deployment.TotalCanarySteps()),
+			TotalSteps:  deployment.TotalCanarySteps(),
+		}
 	}
+
+	return status
 }
 
 func (r *DeploymentRepo) ensureSupport(ctx context.Context, app *korifiv1alpha1.CFApp) error {
@@ -1,6 +1,7 @@
 package repositories_test
 
 import (
+	"slices"
 	"time"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -8,8 +9,11 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"code.cloudfoundry.org/korifi/version"
+	"github.com/BooleanCat/go-functional/v2/it"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -195,6 +199,52 @@ var _ = Describe("DeploymentRepository", func() {
 				})
 			})
 
+			It("records a rolling deployment on the app", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(deployment.Strategy).To(Equal("rolling"))
+				Expect(deployment.MaxInFlight).To(BeEquivalentTo(1))
+
+				previousDropletGUID := cfApp.Spec.CurrentDropletRef.Name
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.Deployment).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Strategy":           Equal(korifiv1alpha1.DeploymentStrategyRolling),
+					"MaxInFlight":        BeNil(),
+					"PreviousDropletRef": Equal(corev1.LocalObjectReference{Name: previousDropletGUID}),
+					"PreviousRevision":   Equal(CFAppRevisionValue),
+				})))
+			})
+
+			When("a canary strategy is set on the create message", func() {
+				BeforeEach(func() {
+					createDeploymentMessage.Strategy = "canary"
+					createDeploymentMessage.MaxInFlight = tools.PtrTo[int32](2)
+					createDeploymentMessage.CanarySteps = []repositories.DeploymentCanaryStep{{InstanceWeight: 20}, {InstanceWeight: 60}}
+				})
+
+				It("records the canary deployment on the app", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.Deployment).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Strategy":    Equal(korifiv1alpha1.DeploymentStrategyCanary),
+						"MaxInFlight": PointTo(BeEquivalentTo(2)),
+						"CanarySteps": Equal([]korifiv1alpha1.CanaryStep{{InstanceWeight: 20}, {InstanceWeight: 60}}),
+						"CurrentStep": BeZero(),
+					})))
+				})
+
+				It("returns a canary deployment record", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(deployment.Strategy).To(Equal("canary"))
+					Expect(deployment.MaxInFlight).To(BeEquivalentTo(2))
+					Expect(deployment.CanarySteps).To(Equal([]repositories.DeploymentCanaryStep{{InstanceWeight: 20}, {InstanceWeight: 60}}))
+					Expect(deployment.Status.Canary).To(PointTo(Equal(repositories.DeploymentCanaryStatus{
+						CurrentStep: 1,
+						TotalSteps:  2,
+					})))
+				})
+			})
+
 			When("droplet guid is set on the create message", func() {
 				var newDropletGUID string
 
@@ -211,6 +261,245 @@ var _ = Describe("DeploymentRepository", func() {
 				})
 			})
 
+			When("revision guid is set on the create message", func() {
+				var (
+					revision        *korifiv1alpha1.CFRevision
+					process         *korifiv1alpha1.CFProcess
+					changedSidecar  *korifiv1alpha1.CFSidecar
+					addedSidecar    *korifiv1alpha1.CFSidecar
+					listAppSidecars func() []korifiv1alpha1.CFSidecarSpec
+				)
+
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      cfApp.Spec.EnvSecretName,
+						},
+						StringData: map[string]string{"FOO": "current"},
+					})).To(Succeed())
+
+					revisionEnvSecretName := uuid.NewString()
+					Expect(k8sClient.Create(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      revisionEnvSecretName,
+						},
+						StringData: map[string]string{"FOO": "previous"},
+					})).To(Succeed())
+
+					process = &korifiv1alpha1.CFProcess{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFProcessSpec{
+							AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+							ProcessType: "web",
+							Command:     "current-command",
+						},
+					}
+					Expect(k8sClient.Create(ctx, process)).To(Succeed())
+
+					changedSidecar = &korifiv1alpha1.CFSidecar{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFSidecarSpec{
+							AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+							Name:         "changed-sidecar",
+							Command:      "current-sidecar-command",
+							ProcessTypes: []string{"web", "worker"},
+						},
+					}
+					Expect(k8sClient.Create(ctx, changedSidecar)).To(Succeed())
+
+					addedSidecar = &korifiv1alpha1.CFSidecar{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFSidecarSpec{
+							AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+							Name:         "added-sidecar",
+							Command:      "added-sidecar-command",
+							ProcessTypes: []string{"web"},
+						},
+					}
+					Expect(k8sClient.Create(ctx, addedSidecar)).To(Succeed())
+
+					listAppSidecars = func() []korifiv1alpha1.CFSidecarSpec {
+						GinkgoHelper()
+
+						sidecars := &korifiv1alpha1.CFSidecarList{}
+						Expect(k8sClient.List(ctx, sidecars, client.InNamespace(cfSpace.Name))).To(Succeed())
+						return slices.Collect(it.Map(slices.Values(sidecars.Items), func(s korifiv1alpha1.CFSidecar) korifiv1alpha1.CFSidecarSpec {
+							return s.Spec
+						}))
+					}
+
+					revision = &korifiv1alpha1.CFRevision{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFRevisionSpec{
+							AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+							Version:       1,
+							DropletRef:    corev1.LocalObjectReference{Name: uuid.NewString()},
+							EnvSecretName: revisionEnvSecretName,
+							Processes: []korifiv1alpha1.RevisionProcess{
+								{Type: "web", Command: "previous-command"},
+							},
+							Sidecars: []korifiv1alpha1.RevisionSidecar{
+								{Name: "changed-sidecar", Command: "previous-sidecar-command", ProcessTypes: []string{"web"}, MemoryMB: 64},
+								{Name: "removed-sidecar", Command: "removed-sidecar-command", ProcessTypes: []string{"worker"}},
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, revision)).To(Succeed())
+
+					createDeploymentMessage.RevisionGUID = revision.Name
+				})
+
+				It("sets the revision droplet on the app", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(deployment.DropletGUID).To(Equal(revision.Spec.DropletRef.Name))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(revision.Spec.DropletRef.Name))
+					Expect(cfApp.Spec.Deployment.RevisionRef.Name).To(Equal(revision.Name))
+				})
+
+				It("restores the revision environment variables", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					envSecret := &corev1.Secret{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfSpace.Name, Name: cfApp.Spec.EnvSecretName}, envSecret)).To(Succeed())
+					Expect(envSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("previous")}))
+				})
+
+				It("restores the revision process commands", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(process), process)).To(Succeed())
+					Expect(process.Spec.Command).To(Equal("previous-command"))
+				})
+
+				It("restores the revision sidecars", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(listAppSidecars()).To(ConsistOf(
+						korifiv1alpha1.CFSidecarSpec{
+							AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+							Name:         "changed-sidecar",
+							Command:      "previous-sidecar-command",
+							ProcessTypes: []string{"web"},
+							MemoryMB:     64,
+						},
+						korifiv1alpha1.CFSidecarSpec{
+							AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+							Name:         "removed-sidecar",
+							Command:      "removed-sidecar-command",
+							ProcessTypes: []string{"worker"},
+						},
+					))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(changedSidecar), changedSidecar)).To(Succeed())
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(addedSidecar), addedSidecar)).To(MatchError(ContainSubstring("not found")))
+				})
+
+				When("the app has no env secret", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+							cfApp.Spec.EnvSecretName = ""
+						})).To(Succeed())
+					})
+
+					It("creates an env secret with the revision environment variables", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						Expect(cfApp.Spec.EnvSecretName).NotTo(BeEmpty())
+
+						envSecret := &corev1.Secret{}
+						Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfSpace.Name, Name: cfApp.Spec.EnvSecretName}, envSecret)).To(Succeed())
+						Expect(envSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("previous")}))
+						Expect(envSecret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"Kind": Equal("CFApp"),
+							"Name": Equal(cfApp.Name),
+						})))
+					})
+				})
+
+				When("the app env secret does not exist", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+							cfApp.Spec.EnvSecretName = uuid.NewString()
+						})).To(Succeed())
+					})
+
+					It("creates the app env secret owned by the app", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+
+						envSecret := &corev1.Secret{}
+						Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfSpace.Name, Name: cfApp.Spec.EnvSecretName}, envSecret)).To(Succeed())
+						Expect(envSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("previous")}))
+						Expect(envSecret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"Kind": Equal("CFApp"),
+							"Name": Equal(cfApp.Name),
+						})))
+					})
+				})
+
+				When("the app is running a revision", func() {
+					var currentRevisionGUID string
+
+					BeforeEach(func() {
+						currentRevisionGUID = uuid.NewString()
+						Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+							cfApp.Status.CurrentRevisionRef = corev1.LocalObjectReference{Name: currentRevisionGUID}
+						})).To(Succeed())
+					})
+
+					It("records it on the deployment", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						Expect(cfApp.Spec.Deployment.PreviousRevisionRef.Name).To(Equal(currentRevisionGUID))
+					})
+				})
+
+				When("the rollback is retried", func() {
+					BeforeEach(func() {
+						_, err := deploymentRepo.CreateDeployment(ctx, authInfo, createDeploymentMessage)
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("restores the revision again", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(process), process)).To(Succeed())
+						Expect(process.Spec.Command).To(Equal("previous-command"))
+						Expect(listAppSidecars()).To(HaveLen(2))
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(revision.Spec.DropletRef.Name))
+					})
+				})
+
+				When("the revision does not exist", func() {
+					BeforeEach(func() {
+						createDeploymentMessage.RevisionGUID = "i-do-not-exist"
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+			})
+
 			When("the app does not exist", func() {
 				BeforeEach(func() {
 					createDeploymentMessage.AppGUID = "i-do-not-exist"
@@ -241,6 +530,227 @@ var _ = Describe("DeploymentRepository", func() {
 		})
 	})
 
+	Describe("CancelDeployment", func() {
+		var (
+			deployment          repositories.DeploymentRecord
+			cancelErr           error
+			previousDropletGUID string
+		)
+
+		BeforeEach(func() {
+			previousDropletGUID = uuid.NewString()
+			Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+				cfApp.Annotations[CFAppRevisionKey] = "2"
+				cfApp.Spec.Deployment = &korifiv1alpha1.CFAppDeployment{
+					Strategy:           korifiv1alpha1.DeploymentStrategyCanary,
+					PreviousDropletRef: corev1.LocalObjectReference{Name: previousDropletGUID},
+					PreviousRevision:   "1",
+				}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			deployment, cancelErr = deploymentRepo.CancelDeployment(ctx, authInfo, cfApp.Name)
+		})
+
+		It("returns a forbidden error (as the user is not allowed to get apps)", func() {
+			Expect(cancelErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns a canceling deployment", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+				Expect(deployment.Status.Value).To(Equal(repositories.DeploymentStatusValueActive))
+				Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonCanceling))
+			})
+
+			It("rolls the app back to the previous droplet and revision", func() {
+				Expect(cancelErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.Deployment.Canceled).To(BeTrue())
+				Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(previousDropletGUID))
+				Expect(cfApp.Annotations).To(HaveKeyWithValue(CFAppRevisionKey, "1"))
+			})
+
+			When("the deployment rolls the app back to a revision", func() {
+				var (
+					previousRevision *korifiv1alpha1.CFRevision
+					process          *korifiv1alpha1.CFProcess
+					sidecar          *korifiv1alpha1.CFSidecar
+				)
+
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      cfApp.Spec.EnvSecretName,
+						},
+						StringData: map[string]string{"FOO": "rolled-back"},
+					})).To(Succeed())
+
+					previousEnvSecretName := uuid.NewString()
+					Expect(k8sClient.Create(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      previousEnvSecretName,
+						},
+						StringData: map[string]string{"FOO": "previous"},
+					})).To(Succeed())
+
+					process = &korifiv1alpha1.CFProcess{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFProcessSpec{
+							AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+							ProcessType: "web",
+							Command:     "rolled-back-command",
+						},
+					}
+					Expect(k8sClient.Create(ctx, process)).To(Succeed())
+
+					sidecar = &korifiv1alpha1.CFSidecar{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFSidecarSpec{
+							AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+							Name:         "my-sidecar",
+							Command:      "rolled-back-sidecar-command",
+							ProcessTypes: []string{"web"},
+						},
+					}
+					Expect(k8sClient.Create(ctx, sidecar)).To(Succeed())
+
+					previousRevision = &korifiv1alpha1.CFRevision{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Name,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFRevisionSpec{
+							AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+							Version:       2,
+							DropletRef:    corev1.LocalObjectReference{Name: previousDropletGUID},
+							EnvSecretName: previousEnvSecretName,
+							Processes: []korifiv1alpha1.RevisionProcess{
+								{Type: "web", Command: "previous-command"},
+							},
+							Sidecars: []korifiv1alpha1.RevisionSidecar{
+								{Name: "my-sidecar", Command: "previous-sidecar-command", ProcessTypes: []string{"web"}},
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, previousRevision)).To(Succeed())
+
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.Deployment.RevisionRef = corev1.LocalObjectReference{Name: uuid.NewString()}
+						cfApp.Spec.Deployment.PreviousRevisionRef = corev1.LocalObjectReference{Name: previousRevision.Name}
+					})).To(Succeed())
+				})
+
+				It("restores the revision the app was running before the deployment", func() {
+					Expect(cancelErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef.Name).To(Equal(previousDropletGUID))
+
+					envSecret := &corev1.Secret{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfSpace.Name, Name: cfApp.Spec.EnvSecretName}, envSecret)).To(Succeed())
+					Expect(envSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("previous")}))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(process), process)).To(Succeed())
+					Expect(process.Spec.Command).To(Equal("previous-command"))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(sidecar), sidecar)).To(Succeed())
+					Expect(sidecar.Spec.Command).To(Equal("previous-sidecar-command"))
+				})
+			})
+
+			When("the deployment is finalized", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						meta.SetStatusCondition(&cfApp.Status.Conditions, metav1.Condition{
+							Type:   "Ready",
+							Status: metav1.ConditionTrue,
+							Reason: "Deployed",
+						})
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(cancelErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ContinueDeployment", func() {
+		var (
+			deployment  repositories.DeploymentRecord
+			continueErr error
+		)
+
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+				cfApp.Spec.Deployment = &korifiv1alpha1.CFAppDeployment{
+					Strategy:    korifiv1alpha1.DeploymentStrategyCanary,
+					CanarySteps: []korifiv1alpha1.CanaryStep{{InstanceWeight: 20}, {InstanceWeight: 60}},
+				}
+			})).To(Succeed())
+			Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+				cfApp.Status.ObservedGeneration = cfApp.Generation
+				cfApp.Status.DeploymentState = korifiv1alpha1.DeploymentStatePaused
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			deployment, continueErr = deploymentRepo.ContinueDeployment(ctx, authInfo, cfApp.Name)
+		})
+
+		It("returns a forbidden error (as the user is not allowed to get apps)", func() {
+			Expect(continueErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("advances the deployment to the next canary step", func() {
+				Expect(continueErr).NotTo(HaveOccurred())
+				Expect(deployment.Status.Reason).To(Equal(repositories.DeploymentStatusReasonDeploying))
+				Expect(deployment.Status.Canary).To(PointTo(Equal(repositories.DeploymentCanaryStatus{
+					CurrentStep: 2,
+					TotalSteps:  2,
+				})))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Spec.Deployment.CurrentStep).To(BeEquivalentTo(1))
+			})
+
+			When("the deployment is not paused", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+						cfApp.Status.DeploymentState = korifiv1alpha1.DeploymentStateDeploying
+					})).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(continueErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
 	Describe("ListDeployments", func() {
 		var (
 			message     repositories.ListDeploymentsMessage
@@ -8,43 +8,62 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/google/uuid"
 
+	authv1 "k8s.io/api/authorization/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains,verbs=create;patch,namespace=ROOT_NAMESPACE
+
 const (
 	DomainResourceType = "Domain"
 )
 
 type DomainRepo struct {
-	klient        Klient
-	rootNamespace string
+	klient            Klient
+	privilegedClient  client.Client
+	userClientFactory authorization.UserClientFactory
+	nsPerms           *authorization.NamespacePermissions
+	rootNamespace     string
 }
 
 func NewDomainRepo(
 	klient Klient,
+	privilegedClient client.Client,
+	userClientFactory authorization.UserClientFactory,
+	nsPerms *authorization.NamespacePermissions,
 	rootNamespace string,
 ) *DomainRepo {
 	return &DomainRepo{
-		klient:        klient,
-		rootNamespace: rootNamespace,
+		klient:            klient,
+		privilegedClient:  privilegedClient,
+		userClientFactory: userClientFactory,
+		nsPerms:           nsPerms,
+		rootNamespace:     rootNamespace,
 	}
 }
 
 type DomainRecord struct {
-	Name        string
-	GUID        string
-	Labels      map[string]string
-	Annotations map[string]string
-	Namespace   string
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
-	DeletedAt   *time.Time
+	Name           string
+	GUID           string
+	RouterGroup    string
+	Internal       bool
+	OrgGUID        string
+	SharedOrgGUIDs []string
+	Labels         map[string]string
+	Annotations    map[string]string
+	Namespace      string
+	CreatedAt      time.Time
+	UpdatedAt      *time.Time
+	DeletedAt      *time.Time
 }
 
 func (r DomainRecord) GetResourceType() string {
@@ -52,8 +71,12 @@ func (r DomainRecord) GetResourceType() string {
 }
 
 type CreateDomainMessage struct {
-	Name     string
-	Metadata Metadata
+	Name           string
+	RouterGroup    string
+	Internal       bool
+	OrgGUID        string
+	SharedOrgGUIDs []string
+	Metadata       Metadata
 }
 
 type UpdateDomainMessage struct {
@@ -61,17 +84,29 @@ type UpdateDomainMessage struct {
 	MetadataPatch MetadataPatch
 }
 
+type ShareDomainMessage struct {
+	DomainGUID string
+	OrgGUIDs   []string
+}
+
+type UnshareDomainMessage struct {
+	DomainGUID string
+	OrgGUID    string
+}
+
 type ListDomainsMessage struct {
-	Names      []string
-	OrderBy    string
-	Pagination Pagination
+	Names            []string
+	VisibleToOrgGUID string
+	OrderBy          string
+	Pagination       Pagination
 }
 
+// toListOptions does not page the list: visibility depends on the shared
+// orgs list, so domains are paged in memory after filtering
 func (m *ListDomainsMessage) toListOptions() []ListOption {
 	return []ListOption{
 		WithLabelIn(korifiv1alpha1.CFEncodedDomainNameLabelKey, tools.EncodeValuesToSha224(m.Names...)),
 		WithOrdering(m.OrderBy),
-		WithPaging(m.Pagination),
 	}
 }
 
@@ -86,6 +121,15 @@ func (r *DomainRepo) GetDomain(ctx context.Context, authInfo authorization.Info,
 		return DomainRecord{}, fmt.Errorf("get-domain failed: %w", apierrors.FromK8sError(err, DomainResourceType))
 	}
 
+	isVisible, err := r.isVisibleToUser(ctx, authInfo)
+	if err != nil {
+		return DomainRecord{}, err
+	}
+
+	if !isVisible(*domain) {
+		return DomainRecord{}, apierrors.NewNotFoundError(nil, DomainResourceType)
+	}
+
 	return cfDomainToDomainRecord(*domain), nil
 }
 
@@ -98,11 +142,24 @@ func (r *DomainRepo) CreateDomain(ctx context.Context, authInfo authorization.In
 			Annotations: message.Metadata.Annotations,
 		},
 		Spec: korifiv1alpha1.CFDomainSpec{
-			Name: message.Name,
+			Name:           message.Name,
+			RouterGroup:    message.RouterGroup,
+			Internal:       message.Internal,
+			OrgGUID:        message.OrgGUID,
+			SharedOrgGUIDs: message.SharedOrgGUIDs,
 		},
 	}
 
-	err := r.klient.Create(ctx, cfDomain)
+	managesOrgs, err := r.managesOrgs(ctx, authInfo, append([]string{message.OrgGUID}, message.SharedOrgGUIDs...)...)
+	if err != nil {
+		return DomainRecord{}, err
+	}
+
+	if managesOrgs {
+		err = r.privilegedClient.Create(ctx, cfDomain)
+	} else {
+		err = r.klient.Create(ctx, cfDomain)
+	}
 	if err != nil {
 		return DomainRecord{}, fmt.Errorf("create-domain failed: %w", apierrors.FromK8sError(err, DomainResourceType))
 	}
@@ -136,7 +193,7 @@ func (r *DomainRepo) UpdateDomain(ctx context.Context, authInfo authorization.In
 
 func (r *DomainRepo) ListDomains(ctx context.Context, authInfo authorization.Info, message ListDomainsMessage) (ListResult[DomainRecord], error) {
 	cfdomainList := &korifiv1alpha1.CFDomainList{}
-	pageInfo, err := r.klient.List(ctx, cfdomainList, message.toListOptions()...)
+	_, err := r.klient.List(ctx, cfdomainList, message.toListOptions()...)
 	if err != nil {
 		if k8serrors.IsForbidden(err) {
 			return ListResult[DomainRecord]{}, nil
@@ -144,12 +201,95 @@ func (r *DomainRepo) ListDomains(ctx context.Context, authInfo authorization.Inf
 		return ListResult[DomainRecord]{}, fmt.Errorf("failed to list domains in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, DomainResourceType))
 	}
 
+	isVisibleToUser, err := r.isVisibleToUser(ctx, authInfo)
+	if err != nil {
+		return ListResult[DomainRecord]{}, err
+	}
+
+	visibleDomains := it.Filter(slices.Values(cfdomainList.Items), func(d korifiv1alpha1.CFDomain) bool {
+		return isVisibleToUser(d) && (message.VisibleToOrgGUID == "" || d.IsVisibleTo(message.VisibleToOrgGUID))
+	})
+	domainRecords := slices.Collect(it.Map(visibleDomains, cfDomainToDomainRecord))
+
+	recordsPage := descriptors.SinglePage(domainRecords, len(domainRecords))
+	if !message.Pagination.IsZero() {
+		recordsPage, err = descriptors.GetPage(domainRecords, message.Pagination.PerPage, message.Pagination.Page)
+		if err != nil {
+			return ListResult[DomainRecord]{}, fmt.Errorf("failed to page domains list: %w", err)
+		}
+	}
+
 	return ListResult[DomainRecord]{
-		Records:  slices.Collect(it.Map(slices.Values(cfdomainList.Items), cfDomainToDomainRecord)),
-		PageInfo: pageInfo,
+		Records:  recordsPage.Items,
+		PageInfo: recordsPage.PageInfo,
 	}, nil
 }
 
+func (r *DomainRepo) ShareDomain(ctx context.Context, authInfo authorization.Info, message ShareDomainMessage) (DomainRecord, error) {
+	domain := &korifiv1alpha1.CFDomain{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.DomainGUID,
+			Namespace: r.rootNamespace,
+		},
+	}
+
+	err := r.klient.Get(ctx, domain)
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("share-domain failed: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	shareWithOrgs := func() error {
+		for _, orgGUID := range message.OrgGUIDs {
+			if !slices.Contains(domain.Spec.SharedOrgGUIDs, orgGUID) {
+				domain.Spec.SharedOrgGUIDs = append(domain.Spec.SharedOrgGUIDs, orgGUID)
+			}
+		}
+		return nil
+	}
+
+	managesOrgs, err := r.managesOrgs(ctx, authInfo, append([]string{domain.Spec.OrgGUID}, message.OrgGUIDs...)...)
+	if err != nil {
+		return DomainRecord{}, err
+	}
+
+	if managesOrgs {
+		err = k8s.TryPatchResource(ctx, r.privilegedClient, domain, shareWithOrgs)
+	} else {
+		err = r.klient.Patch(ctx, domain, shareWithOrgs)
+	}
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("failed to share domain: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return cfDomainToDomainRecord(*domain), nil
+}
+
+func (r *DomainRepo) UnshareDomain(ctx context.Context, authInfo authorization.Info, message UnshareDomainMessage) (DomainRecord, error) {
+	domain := &korifiv1alpha1.CFDomain{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.DomainGUID,
+			Namespace: r.rootNamespace,
+		},
+	}
+
+	err := r.klient.Get(ctx, domain)
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("unshare-domain failed: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	err = r.klient.Patch(ctx, domain, func() error {
+		domain.Spec.SharedOrgGUIDs = slices.DeleteFunc(domain.Spec.SharedOrgGUIDs, func(orgGUID string) bool {
+			return orgGUID == message.OrgGUID
+		})
+		return nil
+	})
+	if err != nil {
+		return DomainRecord{}, fmt.Errorf("failed to unshare domain: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return cfDomainToDomainRecord(*domain), nil
+}
+
 func (r *DomainRepo) DeleteDomain(ctx context.Context, authInfo authorization.Info, domainGUID string) error {
 	cfDomain := &korifiv1alpha1.CFDomain{
 		ObjectMeta: metav1.ObjectMeta{
@@ -171,15 +311,87 @@ func (r *DomainRepo) GetDeletedAt(ctx context.Context, authInfo authorization.In
 	return domain.DeletedAt, err
 }
 
+// isVisibleToUser returns a predicate telling whether the user can see a
+// domain, i.e. whether the domain is shared or visible to one of the orgs the
+// user has a role in
+func (r *DomainRepo) isVisibleToUser(ctx context.Context, authInfo authorization.Info) (func(korifiv1alpha1.CFDomain) bool, error) {
+	authorizedOrgs, err := r.nsPerms.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authorized orgs: %w", err)
+	}
+
+	return func(d korifiv1alpha1.CFDomain) bool {
+		if !d.IsPrivate() {
+			return true
+		}
+
+		for orgGUID := range authorizedOrgs {
+			if d.IsVisibleTo(orgGUID) {
+				return true
+			}
+		}
+
+		return false
+	}, nil
+}
+
+// managesOrgs tells whether the user is an org manager of all the given orgs.
+// Org managers cannot write domains in the root namespace, so the private
+// domains of the orgs they manage are written with the privileged client
+func (r *DomainRepo) managesOrgs(ctx context.Context, authInfo authorization.Info, orgGUIDs ...string) (bool, error) {
+	for _, orgGUID := range orgGUIDs {
+		if orgGUID == "" {
+			return false, nil
+		}
+
+		canManage, err := r.canManageOrg(ctx, authInfo, orgGUID)
+		if err != nil || !canManage {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// canManageOrg tells whether the user is an org manager (or admin) of the
+// org, i.e. whether they can create spaces in it
+func (r *DomainRepo) canManageOrg(ctx context.Context, authInfo authorization.Info, orgGUID string) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: orgGUID,
+				Verb:      "create",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfspaces",
+			},
+		},
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return false, fmt.Errorf("canManageOrg: failed to build user client: %w", err)
+	}
+
+	if err := userClient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("canManageOrg: failed to create self subject access review: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
 func cfDomainToDomainRecord(cfDomain korifiv1alpha1.CFDomain) DomainRecord {
 	return DomainRecord{
-		Name:        cfDomain.Spec.Name,
-		GUID:        cfDomain.Name,
-		Namespace:   cfDomain.Namespace,
-		CreatedAt:   cfDomain.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&cfDomain),
-		DeletedAt:   golangTime(cfDomain.DeletionTimestamp),
-		Labels:      cfDomain.Labels,
-		Annotations: cfDomain.Annotations,
+		Name:           cfDomain.Spec.Name,
+		GUID:           cfDomain.Name,
+		RouterGroup:    cfDomain.Spec.RouterGroup,
+		Internal:       cfDomain.Spec.Internal,
+		OrgGUID:        cfDomain.Spec.OrgGUID,
+		SharedOrgGUIDs: cfDomain.Spec.SharedOrgGUIDs,
+		Namespace:      cfDomain.Namespace,
+		CreatedAt:      cfDomain.CreationTimestamp.Time,
+		UpdatedAt:      getLastUpdatedTime(&cfDomain),
+		DeletedAt:      golangTime(cfDomain.DeletionTimestamp),
+		Labels:         cfDomain.Labels,
+		Annotations:    cfDomain.Annotations,
 	}
 }
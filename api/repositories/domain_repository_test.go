@@ -45,7 +45,7 @@ var _ = Describe("DomainRepository", func() {
 		}
 		Expect(k8sClient.Create(ctx, cfDomain)).To(Succeed())
 
-		domainRepo = repositories.NewDomainRepo(rootNSKlient, rootNamespace)
+		domainRepo = repositories.NewDomainRepo(rootNSKlient, k8sClient, userClientFactory, nsPerms, rootNamespace)
 	})
 
 	AfterEach(func() {
@@ -83,6 +83,30 @@ var _ = Describe("DomainRepository", func() {
 				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
 			})
 		})
+
+		When("the domain is private", func() {
+			var org *korifiv1alpha1.CFOrg
+
+			BeforeEach(func() {
+				org = createOrgWithCleanup(ctx, uuid.NewString())
+				searchGUID = createPrivateDomain("private.com", org.Name).Name
+			})
+
+			It("returns a not found error to users without a role in the org", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+
+			When("the user has a role in the org", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+				})
+
+				It("returns the domain", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(domain.GUID).To(Equal(searchGUID))
+				})
+			})
+		})
 	})
 
 	Describe("CreateDomain", func() {
@@ -114,6 +138,47 @@ var _ = Describe("DomainRepository", func() {
 			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
 		})
 
+		When("the domain is owned by an org", func() {
+			var org, sharedOrg *korifiv1alpha1.CFOrg
+
+			BeforeEach(func() {
+				org = createOrgWithCleanup(ctx, uuid.NewString())
+				sharedOrg = createOrgWithCleanup(ctx, uuid.NewString())
+				domainCreate.OrgGUID = org.Name
+				domainCreate.SharedOrgGUIDs = []string{sharedOrg.Name}
+			})
+
+			It("fails because the user is not an org manager", func() {
+				Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a manager of the owning and shared orgs", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+					createRoleBinding(ctx, userName, orgManagerRole.Name, sharedOrg.Name)
+				})
+
+				It("creates the private domain", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: rootNamespace}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.OrgGUID).To(Equal(org.Name))
+					Expect(createdCFDomain.Spec.SharedOrgGUIDs).To(ConsistOf(sharedOrg.Name))
+				})
+			})
+
+			When("the user is only a manager of the owning org", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+				})
+
+				It("fails because the user cannot share the domain with the other org", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+				})
+			})
+		})
+
 		When("the user is a CFAdmin", func() {
 			BeforeEach(func() {
 				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
@@ -141,6 +206,39 @@ var _ = Describe("DomainRepository", func() {
 				Expect(createdCFDomain.Labels).To(HaveKeyWithValue("foo", "bar"))
 				Expect(createdCFDomain.Annotations).To(HaveKeyWithValue("bar", "baz"))
 			})
+
+			When("the domain is internal", func() {
+				BeforeEach(func() {
+					domainCreate.Internal = true
+				})
+
+				It("creates an internal domain", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdDomain.Internal).To(BeTrue())
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: rootNamespace}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.Internal).To(BeTrue())
+				})
+			})
+
+			When("the domain is owned by an org", func() {
+				BeforeEach(func() {
+					domainCreate.OrgGUID = "org-guid"
+					domainCreate.SharedOrgGUIDs = []string{"shared-org-guid"}
+				})
+
+				It("creates a private domain", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdDomain.OrgGUID).To(Equal("org-guid"))
+					Expect(createdDomain.SharedOrgGUIDs).To(ConsistOf("shared-org-guid"))
+
+					createdCFDomain := new(korifiv1alpha1.CFDomain)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdDomain.GUID, Namespace: rootNamespace}, createdCFDomain)).To(Succeed())
+					Expect(createdCFDomain.Spec.OrgGUID).To(Equal("org-guid"))
+					Expect(createdCFDomain.Spec.SharedOrgGUIDs).To(ConsistOf("shared-org-guid"))
+				})
+			})
 		})
 	})
 
@@ -233,7 +331,7 @@ var _ = Describe("DomainRepository", func() {
 
 			BeforeEach(func() {
 				fakeKlient = new(fake.Klient)
-				domainRepo = repositories.NewDomainRepo(fakeKlient, rootNamespace)
+				domainRepo = repositories.NewDomainRepo(fakeKlient, k8sClient, userClientFactory, nsPerms, rootNamespace)
 			})
 
 			Describe("parameters to list options", func() {
@@ -254,12 +352,95 @@ var _ = Describe("DomainRepository", func() {
 					Expect(listOptions).To(ConsistOf(
 						repositories.WithLabelIn(korifiv1alpha1.CFEncodedDomainNameLabelKey, tools.EncodeValuesToSha224("n1", "n2")),
 						repositories.WithOrdering("created_at"),
-						repositories.WithPaging(repositories.Pagination{PerPage: 4, Page: 3}),
 					))
 				})
 			})
 		})
 
+		When("there are private domains", func() {
+			var (
+				org, otherOrg                          *korifiv1alpha1.CFOrg
+				ownedDomain, sharedDomain, otherDomain *korifiv1alpha1.CFDomain
+			)
+
+			BeforeEach(func() {
+				org = createOrgWithCleanup(ctx, uuid.NewString())
+				otherOrg = createOrgWithCleanup(ctx, uuid.NewString())
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+
+				ownedDomain = createPrivateDomain("owned.com", org.Name)
+				sharedDomain = createPrivateDomain("shared.com", otherOrg.Name, org.Name)
+				otherDomain = createPrivateDomain("other.com", otherOrg.Name)
+			})
+
+			It("only returns the private domains visible to the orgs of the user", func() {
+				Expect(listResult.Records).To(ContainElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(ownedDomain.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sharedDomain.Name)}),
+				))
+				Expect(listResult.Records).NotTo(ContainElement(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(otherDomain.Name)}),
+				))
+			})
+
+			When("the user has a role in the other org", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgUserRole.Name, otherOrg.Name)
+				})
+
+				It("returns its private domains", func() {
+					Expect(listResult.Records).To(ContainElement(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(otherDomain.Name)}),
+					))
+				})
+			})
+		})
+
+		When("listing the domains visible to an org", func() {
+			var (
+				org, otherOrg                          *korifiv1alpha1.CFOrg
+				ownedDomain, sharedDomain, otherDomain *korifiv1alpha1.CFDomain
+			)
+
+			BeforeEach(func() {
+				org = createOrgWithCleanup(ctx, uuid.NewString())
+				otherOrg = createOrgWithCleanup(ctx, uuid.NewString())
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+				createRoleBinding(ctx, userName, orgUserRole.Name, otherOrg.Name)
+
+				ownedDomain = createPrivateDomain("owned.com", org.Name)
+				sharedDomain = createPrivateDomain("shared.com", otherOrg.Name, org.Name)
+				otherDomain = createPrivateDomain("other.com", otherOrg.Name)
+
+				domainListMessage.VisibleToOrgGUID = org.Name
+			})
+
+			It("returns the shared domains and the private domains visible to the org", func() {
+				Expect(listResult.Records).To(ContainElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(domainGUID)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(ownedDomain.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sharedDomain.Name)}),
+				))
+				Expect(listResult.Records).NotTo(ContainElement(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(otherDomain.Name)}),
+				))
+			})
+
+			When("paging the list", func() {
+				BeforeEach(func() {
+					domainListMessage.Pagination = repositories.Pagination{PerPage: 1, Page: 2}
+				})
+
+				It("pages the visible domains", func() {
+					Expect(listResult.Records).To(HaveLen(1))
+					Expect(listResult.PageInfo.TotalResults).To(BeNumerically(">=", 3))
+					Expect(listResult.PageInfo.PageNumber).To(Equal(2))
+					Expect(listResult.PageInfo.PageSize).To(Equal(1))
+				})
+			})
+		})
+
 		When("the user has no permission to list domains in the root namespace", func() {
 			BeforeEach(func() {
 				userName = uuid.NewString()
@@ -273,6 +454,102 @@ var _ = Describe("DomainRepository", func() {
 		})
 	})
 
+	Describe("ShareDomain and UnshareDomain", func() {
+		var (
+			domainRecord repositories.DomainRecord
+			err          error
+		)
+
+		BeforeEach(func() {
+			Expect(k8sClient.Delete(ctx, cfDomain)).To(Succeed())
+			cfDomain = createPrivateDomain(domainName, "org-guid", "org-1")
+		})
+
+		Describe("ShareDomain", func() {
+			JustBeforeEach(func() {
+				domainRecord, err = domainRepo.ShareDomain(ctx, authInfo, repositories.ShareDomainMessage{
+					DomainGUID: cfDomain.Name,
+					OrgGUIDs:   []string{"org-1", "org-2"},
+				})
+			})
+
+			It("fails because the user is not a CF admin", func() {
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a manager of the owning and shared orgs", func() {
+				var org, sharedOrg *korifiv1alpha1.CFOrg
+
+				BeforeEach(func() {
+					org = createOrgWithCleanup(ctx, uuid.NewString())
+					sharedOrg = createOrgWithCleanup(ctx, uuid.NewString())
+					Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+						cfDomain.Spec.OrgGUID = org.Name
+					})).To(Succeed())
+
+					createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+					createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+					createRoleBinding(ctx, userName, orgManagerRole.Name, sharedOrg.Name)
+				})
+
+				JustBeforeEach(func() {
+					domainRecord, err = domainRepo.ShareDomain(ctx, authInfo, repositories.ShareDomainMessage{
+						DomainGUID: cfDomain.Name,
+						OrgGUIDs:   []string{sharedOrg.Name},
+					})
+				})
+
+				It("shares the domain", func() {
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDomain), cfDomain)).To(Succeed())
+					Expect(cfDomain.Spec.SharedOrgGUIDs).To(ContainElement(sharedOrg.Name))
+				})
+			})
+
+			When("the user is a CFAdmin", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				})
+
+				It("adds the orgs to the domain shared orgs", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(domainRecord.SharedOrgGUIDs).To(ConsistOf("org-1", "org-2"))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDomain), cfDomain)).To(Succeed())
+					Expect(cfDomain.Spec.SharedOrgGUIDs).To(ConsistOf("org-1", "org-2"))
+				})
+			})
+		})
+
+		Describe("UnshareDomain", func() {
+			JustBeforeEach(func() {
+				domainRecord, err = domainRepo.UnshareDomain(ctx, authInfo, repositories.UnshareDomainMessage{
+					DomainGUID: cfDomain.Name,
+					OrgGUID:    "org-1",
+				})
+			})
+
+			It("fails because the user is not a CF admin", func() {
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a CFAdmin", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				})
+
+				It("removes the org from the domain shared orgs", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(domainRecord.SharedOrgGUIDs).To(BeEmpty())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfDomain), cfDomain)).To(Succeed())
+					Expect(cfDomain.Spec.SharedOrgGUIDs).To(BeEmpty())
+				})
+			})
+		})
+	})
+
 	Describe("Delete Domain", func() {
 		var (
 			deleteGUID string
@@ -348,3 +625,25 @@ var _ = Describe("DomainRepository", func() {
 		})
 	})
 })
+
+func createPrivateDomain(name, orgGUID string, sharedOrgGUIDs ...string) *korifiv1alpha1.CFDomain {
+	GinkgoHelper()
+
+	domain := &korifiv1alpha1.CFDomain{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFDomainSpec{
+			Name:           name,
+			OrgGUID:        orgGUID,
+			SharedOrgGUIDs: sharedOrgGUIDs,
+		},
+	}
+	Expect(k8sClient.Create(ctx, domain)).To(Succeed())
+	DeferCleanup(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, domain))).To(Succeed())
+	})
+
+	return domain
+}
@@ -0,0 +1,288 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools/image"
+)
+
+type FileImageClient struct {
+	HasTagStub        func(context.Context, image.Creds, string, string) (bool, error)
+	hasTagMutex       sync.RWMutex
+	hasTagArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+	}
+	hasTagReturns struct {
+		result1 bool
+		result2 error
+	}
+	hasTagReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	PullFileStub        func(context.Context, image.Creds, string, string) (io.ReadCloser, error)
+	pullFileMutex       sync.RWMutex
+	pullFileArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+	}
+	pullFileReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	pullFileReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	PushFileStub        func(context.Context, image.Creds, string, string, int64, func() (io.ReadCloser, error)) error
+	pushFileMutex       sync.RWMutex
+	pushFileArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+		arg5 int64
+		arg6 func() (io.ReadCloser, error)
+	}
+	pushFileReturns struct {
+		result1 error
+	}
+	pushFileReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FileImageClient) HasTag(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 string) (bool, error) {
+	fake.hasTagMutex.Lock()
+	ret, specificReturn := fake.hasTagReturnsOnCall[len(fake.hasTagArgsForCall)]
+	fake.hasTagArgsForCall = append(fake.hasTagArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.HasTagStub
+	fakeReturns := fake.hasTagReturns
+	fake.recordInvocation("HasTag", []interface{}{arg1, arg2, arg3, arg4})
+	fake.hasTagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FileImageClient) HasTagCallCount() int {
+	fake.hasTagMutex.RLock()
+	defer fake.hasTagMutex.RUnlock()
+	return len(fake.hasTagArgsForCall)
+}
+
+func (fake *FileImageClient) HasTagCalls(stub func(context.Context, image.Creds, string, string) (bool, error)) {
+	fake.hasTagMutex.Lock()
+	defer fake.hasTagMutex.Unlock()
+	fake.HasTagStub = stub
+}
+
+func (fake *FileImageClient) HasTagArgsForCall(i int) (context.Context, image.Creds, string, string) {
+	fake.hasTagMutex.RLock()
+	defer fake.hasTagMutex.RUnlock()
+	argsForCall := fake.hasTagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FileImageClient) HasTagReturns(result1 bool, result2 error) {
+	fake.hasTagMutex.Lock()
+	defer fake.hasTagMutex.Unlock()
+	fake.HasTagStub = nil
+	fake.hasTagReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FileImageClient) HasTagReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.hasTagMutex.Lock()
+	defer fake.hasTagMutex.Unlock()
+	fake.HasTagStub = nil
+	if fake.hasTagReturnsOnCall == nil {
+		fake.hasTagReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.hasTagReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FileImageClient) PullFile(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 string) (io.ReadCloser, error) {
+	fake.pullFileMutex.Lock()
+	ret, specificReturn := fake.pullFileReturnsOnCall[len(fake.pullFileArgsForCall)]
+	fake.pullFileArgsForCall = append(fake.pullFileArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.PullFileStub
+	fakeReturns := fake.pullFileReturns
+	fake.recordInvocation("PullFile", []interface{}{arg1, arg2, arg3, arg4})
+	fake.pullFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FileImageClient) PullFileCallCount() int {
+	fake.pullFileMutex.RLock()
+	defer fake.pullFileMutex.RUnlock()
+	return len(fake.pullFileArgsForCall)
+}
+
+func (fake *FileImageClient) PullFileCalls(stub func(context.Context, image.Creds, string, string) (io.ReadCloser, error)) {
+	fake.pullFileMutex.Lock()
+	defer fake.pullFileMutex.Unlock()
+	fake.PullFileStub = stub
+}
+
+func (fake *FileImageClient) PullFileArgsForCall(i int) (context.Context, image.Creds, string, string) {
+	fake.pullFileMutex.RLock()
+	defer fake.pullFileMutex.RUnlock()
+	argsForCall := fake.pullFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FileImageClient) PullFileReturns(result1 io.ReadCloser, result2 error) {
+	fake.pullFileMutex.Lock()
+	defer fake.pullFileMutex.Unlock()
+	fake.PullFileStub = nil
+	fake.pullFileReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FileImageClient) PullFileReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.pullFileMutex.Lock()
+	defer fake.pullFileMutex.Unlock()
+	fake.PullFileStub = nil
+	if fake.pullFileReturnsOnCall == nil {
+		fake.pullFileReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.pullFileReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FileImageClient) PushFile(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 string, arg5 int64, arg6 func() (io.ReadCloser, error)) error {
+	fake.pushFileMutex.Lock()
+	ret, specificReturn := fake.pushFileReturnsOnCall[len(fake.pushFileArgsForCall)]
+	fake.pushFileArgsForCall = append(fake.pushFileArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+		arg5 int64
+		arg6 func() (io.ReadCloser, error)
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.PushFileStub
+	fakeReturns := fake.pushFileReturns
+	fake.recordInvocation("PushFile", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.pushFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FileImageClient) PushFileCallCount() int {
+	fake.pushFileMutex.RLock()
+	defer fake.pushFileMutex.RUnlock()
+	return len(fake.pushFileArgsForCall)
+}
+
+func (fake *FileImageClient) PushFileCalls(stub func(context.Context, image.Creds, string, string, int64, func() (io.ReadCloser, error)) error) {
+	fake.pushFileMutex.Lock()
+	defer fake.pushFileMutex.Unlock()
+	fake.PushFileStub = stub
+}
+
+func (fake *FileImageClient) PushFileArgsForCall(i int) (context.Context, image.Creds, string, string, int64, func() (io.ReadCloser, error)) {
+	fake.pushFileMutex.RLock()
+	defer fake.pushFileMutex.RUnlock()
+	argsForCall := fake.pushFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FileImageClient) PushFileReturns(result1 error) {
+	fake.pushFileMutex.Lock()
+	defer fake.pushFileMutex.Unlock()
+	fake.PushFileStub = nil
+	fake.pushFileReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FileImageClient) PushFileReturnsOnCall(i int, result1 error) {
+	fake.pushFileMutex.Lock()
+	defer fake.pushFileMutex.Unlock()
+	fake.PushFileStub = nil
+	if fake.pushFileReturnsOnCall == nil {
+		fake.pushFileReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.pushFileReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FileImageClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FileImageClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ repositories.FileImageClient = new(FileImageClient)
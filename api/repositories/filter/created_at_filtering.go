@@ -0,0 +1,23 @@
+package filter
+
+import (
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+)
+
+func CreatedAtPredicate(matches func(time.Time) bool) func(value any) bool {
+	return func(value any) bool {
+		createdAtStr, ok := value.(string)
+		if !ok {
+			return false
+		}
+
+		createdAt, err := time.Parse(korifiv1alpha1.LabelDateFormat, createdAtStr)
+		if err != nil {
+			return false
+		}
+
+		return matches(createdAt)
+	}
+}
@@ -0,0 +1,54 @@
+package filter_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/korifi/api/repositories/filter"
+)
+
+var _ = Describe("CreatedAtPredicate", func() {
+	var (
+		matchedCreatedAt time.Time
+		value            any
+		filterResult     bool
+	)
+
+	BeforeEach(func() {
+		value = "2024-03-02T10-20-30"
+	})
+
+	JustBeforeEach(func() {
+		filterResult = filter.CreatedAtPredicate(func(createdAt time.Time) bool {
+			matchedCreatedAt = createdAt
+			return true
+		})(value)
+	})
+
+	It("matches the parsed created at label value", func() {
+		Expect(filterResult).To(BeTrue())
+		Expect(matchedCreatedAt).To(Equal(time.Date(2024, 3, 2, 10, 20, 30, 0, time.UTC)))
+	})
+
+	When("the value is not a valid label date", func() {
+		BeforeEach(func() {
+			value = "not-a-date"
+		})
+
+		It("returns false", func() {
+			Expect(filterResult).To(BeFalse())
+		})
+	})
+
+	When("the value is not set", func() {
+		BeforeEach(func() {
+			value = nil
+		})
+
+		It("returns false", func() {
+			Expect(filterResult).To(BeFalse())
+		})
+	})
+})
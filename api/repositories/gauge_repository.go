@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type GaugeRecord struct {
+	Timestamp     int64
+	ProcessGUID   string
+	ProcessType   string
+	InstanceIndex int
+	CPU           float64
+	Mem           int64
+	Disk          int64
+	MemQuota      int64
+	DiskQuota     int64
+}
+
+type GetGaugesMessage struct {
+	App AppRecord
+
+	StartTime  *int64
+	EndTime    *int64
+	Limit      *int64
+	Descending bool
+}
+
+type GaugeRepo struct {
+	userClientFactory authorization.UserClientFactory
+	metricsBuffer     *MetricsBuffer
+}
+
+func NewGaugeRepo(userClientFactory authorization.UserClientFactory, metricsBuffer *MetricsBuffer) *GaugeRepo {
+	return &GaugeRepo{
+		userClientFactory: userClientFactory,
+		metricsBuffer:     metricsBuffer,
+	}
+}
+
+// GetAppGauges returns the sampled container metrics of the app instances. As
+// the metrics are sampled with a privileged client, the user is required to
+// be allowed to list the pods in the app space, which is what getting the
+// metrics from metrics-server would require too.
+func (r *GaugeRepo) GetAppGauges(ctx context.Context, authInfo authorization.Info, message GetGaugesMessage) ([]GaugeRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.List(ctx, &corev1.PodList{}, client.InNamespace(message.App.SpaceGUID), client.Limit(1), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey: message.App.GUID,
+	})
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, PodResourceType)
+	}
+
+	gauges := slices.DeleteFunc(r.metricsBuffer.GetGauges(message.App.GUID), func(g GaugeRecord) bool {
+		if message.StartTime != nil && g.Timestamp < *message.StartTime {
+			return true
+		}
+		return message.EndTime != nil && g.Timestamp > *message.EndTime
+	})
+
+	slices.SortFunc(gauges, func(g1, g2 GaugeRecord) int {
+		if message.Descending {
+			return cmp.Compare(g2.Timestamp, g1.Timestamp)
+		}
+		return cmp.Compare(g1.Timestamp, g2.Timestamp)
+	})
+
+	if message.Limit != nil && len(gauges) > int(*message.Limit) {
+		gauges = gauges[:*message.Limit]
+	}
+
+	return gauges, nil
+}
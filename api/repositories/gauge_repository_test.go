@@ -0,0 +1,240 @@
+package repositories_test
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("GaugeRepository", func() {
+	var (
+		cfSpace     *korifiv1alpha1.CFSpace
+		appGUID     string
+		metricsTime time.Time
+		gaugeRepo   *repositories.GaugeRepo
+		message     repositories.GetGaugesMessage
+		gauges      []repositories.GaugeRecord
+		err         error
+	)
+
+	BeforeEach(func() {
+		cfOrg := createOrgWithCleanup(ctx, uuid.NewString())
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+		appGUID = uuid.NewString()
+		metricsTime = time.Now().Truncate(time.Second)
+
+		appPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Name,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Image: "dont/care",
+					Name:  "app-container",
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, appPod)).To(Succeed())
+
+		// Every instance has been sampled at a different time
+		instancePods := []corev1.Pod{}
+		instanceMetrics := []metricsv1beta1.PodMetrics{}
+		for i := range 3 {
+			podName := uuid.NewString()
+			instancePods = append(instancePods, corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: cfSpace.Name,
+					Name:      podName,
+					UID:       types.UID(podName),
+					Labels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
+						korifiv1alpha1.CFProcessTypeLabelKey: "web",
+						korifiv1alpha1.GUIDLabelKey:          "process-guid",
+						korifiv1alpha1.PodIndexLabelKey:      strconv.Itoa(i),
+					},
+				},
+			})
+			instanceMetrics = append(instanceMetrics, metricsv1beta1.PodMetrics{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: cfSpace.Name,
+					Name:      podName,
+				},
+				Timestamp: metav1.NewTime(metricsTime.Add(time.Duration(i) * time.Second)),
+				Containers: []metricsv1beta1.ContainerMetrics{{
+					Name: "application",
+					Usage: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+				}},
+			})
+		}
+
+		privilegedClient := new(controllerfake.Client)
+		privilegedClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch list := list.(type) {
+			case *corev1.PodList:
+				list.Items = instancePods
+			case *metricsv1beta1.PodMetricsList:
+				list.Items = instanceMetrics
+			case *korifiv1alpha1.CFProcessList:
+				list.Items = []korifiv1alpha1.CFProcess{{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: cfSpace.Name,
+						Name:      "process-guid",
+					},
+					Spec: korifiv1alpha1.CFProcessSpec{
+						ProcessType: "web",
+						MemoryMB:    128,
+						DiskQuotaMB: 256,
+					},
+				}}
+			default:
+				panic("TestClient List provided an unexpected object type")
+			}
+			return nil
+		}
+
+		metricsBuffer := repositories.NewMetricsBuffer(privilegedClient, time.Hour, time.Hour)
+		bufferCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			metricsBuffer.Start(bufferCtx)
+		}()
+		DeferCleanup(func() {
+			cancel()
+			Eventually(done).Should(BeClosed())
+		})
+		Eventually(func() []repositories.GaugeRecord {
+			return metricsBuffer.GetGauges(appGUID)
+		}).Should(HaveLen(3))
+
+		gaugeRepo = repositories.NewGaugeRepo(userClientFactory, metricsBuffer)
+
+		message = repositories.GetGaugesMessage{
+			App: repositories.AppRecord{
+				GUID:      appGUID,
+				SpaceGUID: cfSpace.Name,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		gauges, err = gaugeRepo.GetAppGauges(ctx, authInfo, message)
+	})
+
+	It("returns a forbidden error", func() {
+		Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+	})
+
+	When("the user is allowed to list the app pods", func() {
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+		})
+
+		It("returns the sampled gauges in ascending order", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gauges).To(HaveLen(3))
+			for i, gauge := range gauges {
+				Expect(gauge).To(MatchAllFields(Fields{
+					"Timestamp":     Equal(metricsTime.Add(time.Duration(i) * time.Second).UnixNano()),
+					"ProcessGUID":   Equal("process-guid"),
+					"ProcessType":   Equal("web"),
+					"InstanceIndex": Equal(i),
+					"CPU":           BeNumerically("~", 0.1),
+					"Mem":           BeEquivalentTo(64 * 1024 * 1024),
+					"Disk":          BeZero(),
+					"MemQuota":      BeEquivalentTo(128 * 1024 * 1024),
+					"DiskQuota":     BeEquivalentTo(256 * 1024 * 1024),
+				}))
+			}
+		})
+
+		When("start and end times are provided", func() {
+			BeforeEach(func() {
+				message.StartTime = tools.PtrTo(metricsTime.Add(time.Second).UnixNano())
+				message.EndTime = tools.PtrTo(metricsTime.Add(time.Second).UnixNano())
+			})
+
+			It("returns the gauges within the time range", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(gauges).To(HaveLen(1))
+				Expect(gauges[0].Timestamp).To(Equal(metricsTime.Add(time.Second).UnixNano()))
+			})
+		})
+
+		When("descending is requested", func() {
+			BeforeEach(func() {
+				message.Descending = true
+			})
+
+			It("returns the gauges in descending order", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(gauges).To(HaveLen(3))
+				Expect(gauges[0].InstanceIndex).To(Equal(2))
+				Expect(gauges[2].InstanceIndex).To(Equal(0))
+			})
+
+			When("a limit is provided", func() {
+				BeforeEach(func() {
+					message.Limit = tools.PtrTo[int64](2)
+				})
+
+				It("returns the most recent gauges", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(gauges).To(HaveLen(2))
+					Expect(gauges[0].InstanceIndex).To(Equal(2))
+					Expect(gauges[1].InstanceIndex).To(Equal(1))
+				})
+			})
+		})
+
+		When("the app has no sampled gauges", func() {
+			BeforeEach(func() {
+				message.App.GUID = uuid.NewString()
+				Expect(k8sClient.Create(ctx, &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: cfSpace.Name,
+						Name:      uuid.NewString(),
+						Labels: map[string]string{
+							korifiv1alpha1.CFAppGUIDLabelKey: message.App.GUID,
+						},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Image: "dont/care",
+							Name:  "app-container",
+						}},
+					},
+				})).To(Succeed())
+			})
+
+			It("returns an empty list", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(gauges).To(BeEmpty())
+			})
+		})
+	})
+})
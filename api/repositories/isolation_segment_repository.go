@@ -0,0 +1,229 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const IsolationSegmentResourceType = "Isolation Segment"
+
+type IsolationSegmentRecord struct {
+	GUID        string
+	Name        string
+	OrgGUIDs    []string
+	Labels      map[string]string
+	Annotations map[string]string
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+	DeletedAt   *time.Time
+}
+
+type CreateIsolationSegmentMessage struct {
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+type ListIsolationSegmentsMessage struct {
+	GUIDs      []string
+	Names      []string
+	OrgGUIDs   []string
+	Pagination Pagination
+}
+
+func (m *ListIsolationSegmentsMessage) matches(segment IsolationSegmentRecord) bool {
+	return tools.EmptyOrContains(m.GUIDs, segment.GUID) &&
+		tools.EmptyOrContains(m.Names, segment.Name) &&
+		(len(m.OrgGUIDs) == 0 || slices.ContainsFunc(segment.OrgGUIDs, func(org string) bool {
+			return slices.Contains(m.OrgGUIDs, org)
+		}))
+}
+
+type EntitleIsolationSegmentMessage struct {
+	GUID     string
+	OrgGUIDs []string
+}
+
+type RevokeIsolationSegmentMessage struct {
+	GUID    string
+	OrgGUID string
+}
+
+type IsolationSegmentRepo struct {
+	klient        Klient
+	rootNamespace string
+}
+
+func NewIsolationSegmentRepo(klient Klient, rootNamespace string) *IsolationSegmentRepo {
+	return &IsolationSegmentRepo{
+		klient:        klient,
+		rootNamespace: rootNamespace,
+	}
+}
+
+func (r *IsolationSegmentRepo) CreateIsolationSegment(ctx context.Context, authInfo authorization.Info, message CreateIsolationSegmentMessage) (IsolationSegmentRecord, error) {
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   r.rootNamespace,
+			Name:        uuid.NewString(),
+			Labels:      message.Labels,
+			Annotations: message.Annotations,
+		},
+		Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+			DisplayName: message.Name,
+		},
+	}
+
+	err := r.klient.Create(ctx, cfIsolationSegment)
+	if err != nil {
+		return IsolationSegmentRecord{}, isolationSegmentWebhookErrorToApiError(err)
+	}
+
+	return toIsolationSegmentRecord(*cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) GetIsolationSegment(ctx context.Context, authInfo authorization.Info, guid string) (IsolationSegmentRecord, error) {
+	cfIsolationSegment, err := r.getCFIsolationSegment(ctx, guid)
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("get-isolation-segment failed: %w", err)
+	}
+
+	return toIsolationSegmentRecord(*cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) ListIsolationSegments(ctx context.Context, authInfo authorization.Info, message ListIsolationSegmentsMessage) (ListResult[IsolationSegmentRecord], error) {
+	cfIsolationSegmentList := &korifiv1alpha1.CFIsolationSegmentList{}
+	_, err := r.klient.List(ctx, cfIsolationSegmentList, InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return ListResult[IsolationSegmentRecord]{}, nil
+		}
+		return ListResult[IsolationSegmentRecord]{}, fmt.Errorf("failed to list isolation segments in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	records := slices.Collect(it.Filter(it.Map(slices.Values(cfIsolationSegmentList.Items), toIsolationSegmentRecord), message.matches))
+	slices.SortStableFunc(records, func(s1, s2 IsolationSegmentRecord) int {
+		return s1.CreatedAt.Compare(s2.CreatedAt)
+	})
+
+	recordsPage := descriptors.SinglePage(records, len(records))
+	if !message.Pagination.IsZero() {
+		recordsPage, err = descriptors.GetPage(records, message.Pagination.PerPage, message.Pagination.Page)
+		if err != nil {
+			return ListResult[IsolationSegmentRecord]{}, fmt.Errorf("failed to page isolation segments list: %w", err)
+		}
+	}
+
+	return ListResult[IsolationSegmentRecord]{
+		PageInfo: recordsPage.PageInfo,
+		Records:  recordsPage.Items,
+	}, nil
+}
+
+func (r *IsolationSegmentRepo) DeleteIsolationSegment(ctx context.Context, authInfo authorization.Info, guid string) error {
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	err := r.klient.Delete(ctx, cfIsolationSegment)
+	if err != nil {
+		return apierrors.FromK8sError(err, IsolationSegmentResourceType)
+	}
+
+	return nil
+}
+
+func (r *IsolationSegmentRepo) EntitleOrgs(ctx context.Context, authInfo authorization.Info, message EntitleIsolationSegmentMessage) (IsolationSegmentRecord, error) {
+	cfIsolationSegment, err := r.getCFIsolationSegment(ctx, message.GUID)
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("entitle-isolation-segment failed: %w", err)
+	}
+
+	err = r.klient.Patch(ctx, cfIsolationSegment, func() error {
+		for _, orgGUID := range message.OrgGUIDs {
+			if !slices.Contains(cfIsolationSegment.Spec.Orgs, orgGUID) {
+				cfIsolationSegment.Spec.Orgs = append(cfIsolationSegment.Spec.Orgs, orgGUID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to entitle orgs: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return toIsolationSegmentRecord(*cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) RevokeOrg(ctx context.Context, authInfo authorization.Info, message RevokeIsolationSegmentMessage) error {
+	cfIsolationSegment, err := r.getCFIsolationSegment(ctx, message.GUID)
+	if err != nil {
+		return fmt.Errorf("revoke-isolation-segment failed: %w", err)
+	}
+
+	err = r.klient.Patch(ctx, cfIsolationSegment, func() error {
+		cfIsolationSegment.Spec.Orgs = slices.DeleteFunc(cfIsolationSegment.Spec.Orgs, func(org string) bool {
+			return org == message.OrgGUID
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke org: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return nil
+}
+
+func (r *IsolationSegmentRepo) getCFIsolationSegment(ctx context.Context, guid string) (*korifiv1alpha1.CFIsolationSegment, error) {
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	err := r.klient.Get(ctx, cfIsolationSegment)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, IsolationSegmentResourceType)
+	}
+
+	return cfIsolationSegment, nil
+}
+
+func isolationSegmentWebhookErrorToApiError(err error) error {
+	if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+		if validationError.Type == validation.DuplicateNameErrorType {
+			return apierrors.NewUniquenessError(err, validationError.GetMessage())
+		}
+	}
+
+	return apierrors.FromK8sError(err, IsolationSegmentResourceType)
+}
+
+func toIsolationSegmentRecord(cfIsolationSegment korifiv1alpha1.CFIsolationSegment) IsolationSegmentRecord {
+	return IsolationSegmentRecord{
+		GUID:        cfIsolationSegment.Name,
+		Name:        cfIsolationSegment.Spec.DisplayName,
+		OrgGUIDs:    cfIsolationSegment.Spec.Orgs,
+		Labels:      cfIsolationSegment.Labels,
+		Annotations: cfIsolationSegment.Annotations,
+		CreatedAt:   cfIsolationSegment.CreationTimestamp.Time,
+		UpdatedAt:   getLastUpdatedTime(&cfIsolationSegment),
+		DeletedAt:   golangTime(cfIsolationSegment.DeletionTimestamp),
+	}
+}
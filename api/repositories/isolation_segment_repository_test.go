@@ -0,0 +1,322 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("IsolationSegmentRepo", func() {
+	var (
+		repo *repositories.IsolationSegmentRepo
+		org  *korifiv1alpha1.CFOrg
+	)
+
+	createIsolationSegment := func(displayName string, orgs ...string) *korifiv1alpha1.CFIsolationSegment {
+		cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+				DisplayName:  displayName,
+				NodeSelector: map[string]string{"pool": displayName},
+				Orgs:         orgs,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfIsolationSegment)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cfIsolationSegment))).To(Succeed())
+		})
+
+		return cfIsolationSegment
+	}
+
+	BeforeEach(func() {
+		repo = repositories.NewIsolationSegmentRepo(rootNSKlient, rootNamespace)
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+	})
+
+	Describe("CreateIsolationSegment", func() {
+		var (
+			isolationSegmentRecord repositories.IsolationSegmentRecord
+			createErr              error
+		)
+
+		JustBeforeEach(func() {
+			isolationSegmentRecord, createErr = repo.CreateIsolationSegment(ctx, authInfo, repositories.CreateIsolationSegmentMessage{
+				Name:   "test-segment",
+				Labels: map[string]string{"foo": "bar"},
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates a CFIsolationSegment", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      isolationSegmentRecord.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), cfIsolationSegment)).To(Succeed())
+				Expect(cfIsolationSegment.Spec.DisplayName).To(Equal("test-segment"))
+				Expect(cfIsolationSegment.Labels).To(HaveKeyWithValue("foo", "bar"))
+			})
+
+			It("returns an isolation segment record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(isolationSegmentRecord.GUID).To(matchers.BeValidUUID())
+				Expect(isolationSegmentRecord.Name).To(Equal("test-segment"))
+				Expect(isolationSegmentRecord.OrgGUIDs).To(BeEmpty())
+				Expect(isolationSegmentRecord.Labels).To(HaveKeyWithValue("foo", "bar"))
+			})
+		})
+	})
+
+	Describe("GetIsolationSegment", func() {
+		var (
+			cfIsolationSegment     *korifiv1alpha1.CFIsolationSegment
+			isolationSegmentRecord repositories.IsolationSegmentRecord
+			getErr                 error
+		)
+
+		BeforeEach(func() {
+			cfIsolationSegment = createIsolationSegment(uuid.NewString(), org.Name)
+		})
+
+		JustBeforeEach(func() {
+			isolationSegmentRecord, getErr = repo.GetIsolationSegment(ctx, authInfo, cfIsolationSegment.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a root namespace user", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+			})
+
+			It("returns the isolation segment", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(isolationSegmentRecord.GUID).To(Equal(cfIsolationSegment.Name))
+				Expect(isolationSegmentRecord.Name).To(Equal(cfIsolationSegment.Spec.DisplayName))
+				Expect(isolationSegmentRecord.OrgGUIDs).To(ConsistOf(org.Name))
+			})
+
+			When("the isolation segment does not exist", func() {
+				BeforeEach(func() {
+					cfIsolationSegment.Name = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListIsolationSegments", func() {
+		var (
+			segment1, segment2 *korifiv1alpha1.CFIsolationSegment
+			message            repositories.ListIsolationSegmentsMessage
+			listResult         repositories.ListResult[repositories.IsolationSegmentRecord]
+			listErr            error
+		)
+
+		BeforeEach(func() {
+			segment1 = createIsolationSegment(uuid.NewString(), org.Name)
+			segment2 = createIsolationSegment(uuid.NewString())
+			message = repositories.ListIsolationSegmentsMessage{
+				GUIDs: []string{segment1.Name, segment2.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			listResult, listErr = repo.ListIsolationSegments(ctx, authInfo, message)
+		})
+
+		It("returns an empty list for users with no permissions", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(BeEmpty())
+		})
+
+		When("the user is a root namespace user", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+			})
+
+			It("returns the isolation segments", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(segment1.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(segment2.Name)}),
+				))
+			})
+
+			When("filtering by names", func() {
+				BeforeEach(func() {
+					message.Names = []string{segment2.Spec.DisplayName}
+				})
+
+				It("returns the matching isolation segments", func() {
+					Expect(listResult.Records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(segment2.Name)}),
+					))
+				})
+			})
+
+			When("filtering by org guids", func() {
+				BeforeEach(func() {
+					message.OrgGUIDs = []string{org.Name}
+				})
+
+				It("returns the isolation segments entitled to the orgs", func() {
+					Expect(listResult.Records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(segment1.Name)}),
+					))
+				})
+			})
+
+			When("paging is requested", func() {
+				BeforeEach(func() {
+					message.Pagination = repositories.Pagination{PerPage: 1, Page: 2}
+				})
+
+				It("returns the requested page", func() {
+					Expect(listResult.Records).To(HaveLen(1))
+					Expect(listResult.PageInfo.TotalResults).To(Equal(2))
+					Expect(listResult.PageInfo.PageNumber).To(Equal(2))
+				})
+			})
+		})
+	})
+
+	Describe("EntitleOrgs", func() {
+		var (
+			cfIsolationSegment     *korifiv1alpha1.CFIsolationSegment
+			anotherOrg             *korifiv1alpha1.CFOrg
+			isolationSegmentRecord repositories.IsolationSegmentRecord
+			entitleErr             error
+		)
+
+		BeforeEach(func() {
+			anotherOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfIsolationSegment = createIsolationSegment(uuid.NewString(), org.Name)
+		})
+
+		JustBeforeEach(func() {
+			isolationSegmentRecord, entitleErr = repo.EntitleOrgs(ctx, authInfo, repositories.EntitleIsolationSegmentMessage{
+				GUID:     cfIsolationSegment.Name,
+				OrgGUIDs: []string{org.Name, anotherOrg.Name},
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(entitleErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("entitles the orgs without duplicating existing entitlements", func() {
+				Expect(entitleErr).NotTo(HaveOccurred())
+				Expect(isolationSegmentRecord.OrgGUIDs).To(ConsistOf(org.Name, anotherOrg.Name))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), cfIsolationSegment)).To(Succeed())
+				Expect(cfIsolationSegment.Spec.Orgs).To(ConsistOf(org.Name, anotherOrg.Name))
+			})
+		})
+	})
+
+	Describe("RevokeOrg", func() {
+		var (
+			cfIsolationSegment *korifiv1alpha1.CFIsolationSegment
+			anotherOrg         *korifiv1alpha1.CFOrg
+			revokeErr          error
+		)
+
+		BeforeEach(func() {
+			anotherOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfIsolationSegment = createIsolationSegment(uuid.NewString(), org.Name, anotherOrg.Name)
+		})
+
+		JustBeforeEach(func() {
+			revokeErr = repo.RevokeOrg(ctx, authInfo, repositories.RevokeIsolationSegmentMessage{
+				GUID:    cfIsolationSegment.Name,
+				OrgGUID: org.Name,
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(revokeErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("revokes the org entitlement", func() {
+				Expect(revokeErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), cfIsolationSegment)).To(Succeed())
+				Expect(cfIsolationSegment.Spec.Orgs).To(ConsistOf(anotherOrg.Name))
+			})
+		})
+	})
+
+	Describe("DeleteIsolationSegment", func() {
+		var (
+			cfIsolationSegment *korifiv1alpha1.CFIsolationSegment
+			deleteErr          error
+		)
+
+		BeforeEach(func() {
+			cfIsolationSegment = createIsolationSegment(uuid.NewString())
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteIsolationSegment(ctx, authInfo, cfIsolationSegment.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the isolation segment", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), cfIsolationSegment)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+})
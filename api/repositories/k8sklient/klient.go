@@ -262,6 +262,8 @@ func getResourceType(obj client.Object) (string, error) {
 		return repositories.PackageResourceType, nil
 	case *korifiv1alpha1.CFProcess:
 		return repositories.ProcessResourceType, nil
+	case *korifiv1alpha1.CFRevision:
+		return repositories.RevisionResourceType, nil
 	case *korifiv1alpha1.CFSpace:
 		return repositories.SpaceResourceType, nil
 	case *korifiv1alpha1.CFRoute:
@@ -270,8 +272,16 @@ func getResourceType(obj client.Object) (string, error) {
 		return repositories.ServiceBindingResourceType, nil
 	case *korifiv1alpha1.CFServiceInstance:
 		return repositories.ServiceInstanceResourceType, nil
+	case *korifiv1alpha1.CFServiceRouteBinding:
+		return repositories.ServiceRouteBindingResourceType, nil
+	case *korifiv1alpha1.CFNetworkPolicy:
+		return repositories.NetworkPolicyResourceType, nil
+	case *korifiv1alpha1.CFSidecar:
+		return repositories.SidecarResourceType, nil
 	case *korifiv1alpha1.CFTask:
 		return repositories.TaskResourceType, nil
+	case *korifiv1alpha1.CFSpaceQuota:
+		return repositories.SpaceQuotaResourceType, nil
 	default:
 		return "", fmt.Errorf("unsupported resource type %T", obj)
 	}
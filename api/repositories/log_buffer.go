@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuilds,verbs=get
+
+const logBufferPruneInterval = time.Minute
+
+// LogBuffer tails the app and staging containers of all apps and keeps their
+// logs per app (the log source id) for a limited time, so that recent logs
+// are still available after the pods that emitted them are gone, e.g. when a
+// crashed app instance has been replaced or a build pod has been cleaned up.
+type LogBuffer struct {
+	privilegedClient    client.Client
+	privilegedClientset k8sclient.Interface
+	logStreamer         LogStreamer
+	retention           time.Duration
+	maxRecordsPerSource int
+
+	mu      sync.RWMutex
+	records map[string][]LogRecord
+
+	// buildApps caches the app guids of the builds whose pods are followed
+	buildApps map[string]string
+}
+
+func NewLogBuffer(
+	privilegedClient client.Client,
+	privilegedClientset k8sclient.Interface,
+	logStreamer LogStreamer,
+	retention time.Duration,
+	maxRecordsPerSource int,
+) *LogBuffer {
+	return &LogBuffer{
+		privilegedClient:    privilegedClient,
+		privilegedClientset: privilegedClientset,
+		logStreamer:         logStreamer,
+		retention:           retention,
+		maxRecordsPerSource: maxRecordsPerSource,
+		records:             map[string][]LogRecord{},
+		buildApps:           map[string]string{},
+	}
+}
+
+// Start follows the logs of the app and staging pods in all spaces until the
+// context is done. Logs within the retention window that were emitted before
+// the buffer started are collected as well.
+func (b *LogBuffer) Start(ctx context.Context) {
+	ctx = logr.NewContext(ctx, logr.FromContextOrDiscard(ctx).WithName("log-buffer"))
+
+	go b.pruneExpired(ctx)
+
+	podLogsFollower{
+		logClient:   b.privilegedClientset,
+		logStreamer: b.logStreamer,
+		listPods:    b.listPods,
+		sinceTime:   time.Now().Add(-b.retention),
+		onRecord: func(_ context.Context, p streamedPod, logRecord LogRecord) bool {
+			b.add(p.sourceID, logRecord)
+			return true
+		},
+	}.follow(ctx, nil)
+}
+
+// GetLogs returns the buffered logs of the given source id
+func (b *LogBuffer) GetLogs(sourceID string) []LogRecord {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return slices.Clone(b.records[sourceID])
+}
+
+func (b *LogBuffer) add(sourceID string, logRecord LogRecord) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	sourceRecords := append(b.records[sourceID], logRecord)
+	if len(sourceRecords) > b.maxRecordsPerSource {
+		sourceRecords = slices.Delete(sourceRecords, 0, len(sourceRecords)-b.maxRecordsPerSource)
+	}
+	b.records[sourceID] = sourceRecords
+}
+
+func (b *LogBuffer) pruneExpired(ctx context.Context) {
+	ticker := time.NewTicker(logBufferPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			b.prune(now)
+		}
+	}
+}
+
+func (b *LogBuffer) prune(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	expiry := now.Add(-b.retention).UnixNano()
+	for sourceID, sourceRecords := range b.records {
+		sourceRecords = slices.DeleteFunc(sourceRecords, func(r LogRecord) bool {
+			return r.Timestamp < expiry
+		})
+		if len(sourceRecords) == 0 {
+			delete(b.records, sourceID)
+			continue
+		}
+		b.records[sourceID] = sourceRecords
+	}
+}
+
+// listPods lists the app pods and the build pods in all namespaces. Build pods
+// are only labelled with their build guid, so the app they belong to is looked
+// up via the build.
+func (b *LogBuffer) listPods(ctx context.Context) ([]streamedPod, error) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	appPodsSelector, err := labels.Parse(fmt.Sprintf("%s,!%s", korifiv1alpha1.CFAppGUIDLabelKey, BuildWorkloadLabelKey))
+	if err != nil {
+		return nil, err
+	}
+
+	appPods := corev1.PodList{}
+	if err = b.privilegedClient.List(ctx, &appPods, client.MatchingLabelsSelector{Selector: appPodsSelector}); err != nil {
+		return nil, fmt.Errorf("failed to list app pods: %w", err)
+	}
+
+	pods := []streamedPod{}
+	for _, pod := range appPods.Items {
+		pods = append(pods, streamedPod{
+			pod:        pod,
+			sourceID:   pod.Labels[korifiv1alpha1.CFAppGUIDLabelKey],
+			sourceType: "APP",
+		})
+	}
+
+	buildPodsSelector, err := labels.Parse(BuildWorkloadLabelKey)
+	if err != nil {
+		return nil, err
+	}
+
+	buildPods := corev1.PodList{}
+	if err = b.privilegedClient.List(ctx, &buildPods, client.MatchingLabelsSelector{Selector: buildPodsSelector}); err != nil {
+		return nil, fmt.Errorf("failed to list build pods: %w", err)
+	}
+
+	buildApps := map[string]string{}
+	for _, pod := range buildPods.Items {
+		buildGUID := pod.Labels[BuildWorkloadLabelKey]
+		appGUID, err := b.getBuildAppGUID(ctx, pod.Namespace, buildGUID)
+		if err != nil {
+			logger.Info("failed to get the app of the build pod", "pod", pod.Name, "reason", err)
+			continue
+		}
+		buildApps[buildGUID] = appGUID
+
+		pods = append(pods, streamedPod{
+			pod:        pod,
+			sourceID:   appGUID,
+			sourceType: "STG",
+		})
+	}
+	b.buildApps = buildApps
+
+	return pods, nil
+}
+
+func (b *LogBuffer) getBuildAppGUID(ctx context.Context, namespace string, buildGUID string) (string, error) {
+	if appGUID, ok := b.buildApps[buildGUID]; ok {
+		return appGUID, nil
+	}
+
+	build := &korifiv1alpha1.CFBuild{}
+	if err := b.privilegedClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: buildGUID}, build); err != nil {
+		return "", err
+	}
+
+	return build.Spec.AppRef.Name, nil
+}
@@ -0,0 +1,183 @@
+package repositories_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var _ = Describe("LogBuffer", func() {
+	var (
+		appGUID     string
+		appPod      *corev1.Pod
+		buildPod    *corev1.Pod
+		logTime     time.Time
+		maxRecords  int
+		logStreamer *fake.LogStreamer
+		logBuffer   *repositories.LogBuffer
+	)
+
+	BeforeEach(func() {
+		cfOrg := createOrgWithCleanup(ctx, uuid.NewString())
+		cfSpace := createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+		logTime = time.Now()
+		maxRecords = 10
+
+		appGUID = uuid.NewString()
+		appPod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Name,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Image: "dont/care",
+					Name:  "app-container",
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, appPod)).To(Succeed())
+		Expect(k8s.Patch(ctx, k8sClient, appPod, func() {
+			appPod.Status = corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "app-container",
+				}},
+			}
+		})).To(Succeed())
+
+		build := &korifiv1alpha1.CFBuild{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Name,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFBuildSpec{
+				AppRef: corev1.LocalObjectReference{Name: appGUID},
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, build)).To(Succeed())
+
+		buildPod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Name,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					repositories.BuildWorkloadLabelKey: build.Name,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Image: "dont/care",
+					Name:  "build-container",
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, buildPod)).To(Succeed())
+		Expect(k8s.Patch(ctx, k8sClient, buildPod, func() {
+			buildPod.Status = corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "build-container",
+				}},
+			}
+		})).To(Succeed())
+
+		logStreamer = new(fake.LogStreamer)
+		logStreamer.Stub = func(_ context.Context, _ kubernetes.Interface, pod corev1.Pod, _ corev1.PodLogOptions) (io.ReadCloser, error) {
+			switch pod.Name {
+			case buildPod.Name:
+				return readerFor(map[time.Time]string{
+					logTime: "b0",
+				}), nil
+			case appPod.Name:
+				return readerFor(map[time.Time]string{
+					logTime.Add(-2 * time.Hour):  "expired",
+					logTime.Add(time.Second):     "a0",
+					logTime.Add(2 * time.Second): "a1",
+				}), nil
+			}
+			return io.NopCloser(strings.NewReader("")), nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		logBuffer = repositories.NewLogBuffer(k8sClient, nil, logStreamer.Spy, time.Hour, maxRecords)
+
+		bufferCtx, cancel := context.WithCancel(ctx)
+		DeferCleanup(cancel)
+		go logBuffer.Start(bufferCtx)
+	})
+
+	It("follows the logs of the app and build pod containers from the start of the retention window", func() {
+		Eventually(func(g Gomega) {
+			podNames := []string{}
+			for i := range logStreamer.CallCount() {
+				_, _, actualPod, actualLogOptions := logStreamer.ArgsForCall(i)
+				podNames = append(podNames, actualPod.Name)
+
+				if actualPod.Name == appPod.Name || actualPod.Name == buildPod.Name {
+					g.Expect(actualLogOptions.Follow).To(BeTrue())
+					g.Expect(actualLogOptions.SinceTime.Time).To(BeTemporally("~", time.Now().Add(-time.Hour), time.Minute))
+				}
+			}
+			g.Expect(podNames).To(ContainElements(appPod.Name, buildPod.Name))
+		}).Should(Succeed())
+	})
+
+	It("keeps the app and staging logs within the retention window per app", func() {
+		Eventually(func() []repositories.LogRecord {
+			return logBuffer.GetLogs(appGUID)
+		}).Should(ConsistOf(
+			matchLogRecord(logTime.UnixNano(), "b0", "STG"),
+			matchLogRecord(logTime.Add(time.Second).UnixNano(), "a0", "APP"),
+			matchLogRecord(logTime.Add(2*time.Second).UnixNano(), "a1", "APP"),
+		))
+	})
+
+	When("an app emits more logs than the buffer keeps", func() {
+		BeforeEach(func() {
+			maxRecords = 1
+		})
+
+		It("only keeps the most recent records", func() {
+			Eventually(func() []repositories.LogRecord {
+				return logBuffer.GetLogs(appGUID)
+			}).Should(HaveLen(1))
+			Consistently(func() []repositories.LogRecord {
+				return logBuffer.GetLogs(appGUID)
+			}, "3s").Should(HaveLen(1))
+		})
+	})
+
+	When("the app pod is gone", func() {
+		JustBeforeEach(func() {
+			Eventually(func() []repositories.LogRecord {
+				return logBuffer.GetLogs(appGUID)
+			}).Should(HaveLen(3))
+
+			Expect(k8sClient.Delete(ctx, appPod)).To(Succeed())
+		})
+
+		It("still has its logs", func() {
+			Consistently(func() []repositories.LogRecord {
+				return logBuffer.GetLogs(appGUID)
+			}, "3s").Should(HaveLen(3))
+		})
+	})
+})
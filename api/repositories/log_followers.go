@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"github.com/go-logr/logr"
+	"golang.org/x/sync/singleflight"
+)
+
+const logFollowerMaxRecords = 10000
+
+// LogFollowers follows the logs of the apps whose logs are being read in
+// ascending order, which is how clients such as `cf logs` tail logs via
+// repeated reads of the log-cache API. Subsequent reads are served the logs
+// collected from the followed containers instead of re-reading all pod logs,
+// so they include the logs of containers that have restarted in between.
+//
+// Followers use the clients of the user the logs are read by, hence there is
+// a follower per app and user. A follower stops once its logs have not been
+// read for the idle timeout.
+type LogFollowers struct {
+	userClientFactory    authorization.UserClientFactory
+	userClientsetFactory authorization.UserClientsetFactory
+	logStreamer          LogStreamer
+	idleTimeout          time.Duration
+
+	mu        sync.Mutex
+	followers map[string]*appLogsFollower
+	starts    singleflight.Group
+}
+
+func NewLogFollowers(
+	userClientFactory authorization.UserClientFactory,
+	userClientsetFactory authorization.UserClientsetFactory,
+	logStreamer LogStreamer,
+	idleTimeout time.Duration,
+) *LogFollowers {
+	return &LogFollowers{
+		userClientFactory:    userClientFactory,
+		userClientsetFactory: userClientsetFactory,
+		logStreamer:          logStreamer,
+		idleTimeout:          idleTimeout,
+		followers:            map[string]*appLogsFollower{},
+	}
+}
+
+// appLogsFollower collects the logs of the followed app containers. All logs
+// emitted since `since` are collected, older ones have either been emitted
+// before the follower started or have been pruned.
+type appLogsFollower struct {
+	mu       sync.Mutex
+	since    int64
+	records  []LogRecord
+	lastRead time.Time
+}
+
+// read returns the time since which the follower has collected the app logs
+// and the collected logs
+func (f *appLogsFollower) read(now time.Time) (int64, []LogRecord) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastRead = now
+	return f.since, slices.Clone(f.records)
+}
+
+func (f *appLogsFollower) add(logRecord LogRecord) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.records = append(f.records, logRecord)
+	if len(f.records) > logFollowerMaxRecords {
+		f.prune(f.records[len(f.records)-logFollowerMaxRecords].Timestamp)
+	}
+}
+
+func (f *appLogsFollower) prune(expiry int64) {
+	f.records = slices.DeleteFunc(f.records, func(r LogRecord) bool {
+		return r.Timestamp < expiry
+	})
+	f.since = max(f.since, expiry)
+}
+
+func (f *appLogsFollower) expire(now time.Time, idleTimeout time.Duration) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if now.Sub(f.lastRead) >= idleTimeout {
+		return true
+	}
+
+	f.prune(now.Add(-idleTimeout).UnixNano())
+	return false
+}
+
+// getOrStart returns the follower of the app logs for the user, starting one
+// if there is none yet. Concurrent reads of the same logs share the start of
+// the follower, which does not block the reads of other logs.
+func (l *LogFollowers) getOrStart(ctx context.Context, authInfo authorization.Info, app AppRecord) (*appLogsFollower, error) {
+	key := followerKey(authInfo, app)
+
+	if follower, ok := l.get(key); ok {
+		return follower, nil
+	}
+
+	follower, err, _ := l.starts.Do(key, func() (any, error) {
+		if follower, ok := l.get(key); ok {
+			return follower, nil
+		}
+
+		return l.start(ctx, authInfo, app, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return follower.(*appLogsFollower), nil
+}
+
+func (l *LogFollowers) get(key string) (*appLogsFollower, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	follower, ok := l.followers[key]
+	return follower, ok
+}
+
+func (l *LogFollowers) start(ctx context.Context, authInfo authorization.Info, app AppRecord, key string) (*appLogsFollower, error) {
+	logClient, err := l.userClientsetFactory.BuildClientset(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user clientset: %w", err)
+	}
+
+	userClient, err := l.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	pods, err := listStreamedPods(ctx, userClient, app)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime := time.Now()
+	follower := &appLogsFollower{
+		since:    startTime.UnixNano(),
+		lastRead: startTime,
+	}
+
+	l.mu.Lock()
+	l.followers[key] = follower
+	l.mu.Unlock()
+
+	// The follower outlives the request that started it
+	followCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	followCtx = logr.NewContext(followCtx, logr.FromContextOrDiscard(ctx).WithName("log-follower").WithValues("appGUID", app.GUID))
+	stop := func() {
+		cancel()
+		l.remove(key, follower)
+	}
+
+	go podLogsFollower{
+		logClient:   logClient,
+		logStreamer: l.logStreamer,
+		listPods: func(ctx context.Context) ([]streamedPod, error) {
+			pods, err := listStreamedPods(ctx, userClient, app)
+			// The user is no longer allowed to read the logs, e.g. because
+			// their token has expired, so there is no point in following them
+			if errors.As(err, &apierrors.InvalidAuthError{}) || errors.As(err, &apierrors.ForbiddenError{}) {
+				logr.FromContextOrDiscard(ctx).Info("stopping log follower", "reason", err)
+				stop()
+			}
+			return pods, err
+		},
+		sinceTime: startTime,
+		onRecord: func(_ context.Context, _ streamedPod, logRecord LogRecord) bool {
+			follower.add(logRecord)
+			return true
+		},
+	}.follow(followCtx, pods)
+
+	go l.stopWhenIdle(followCtx, stop, follower)
+
+	return follower, nil
+}
+
+// remove removes the follower, unless it has already been replaced by a new
+// one
+func (l *LogFollowers) remove(key string, follower *appLogsFollower) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.followers[key] == follower {
+		delete(l.followers, key)
+	}
+}
+
+func (l *LogFollowers) stopWhenIdle(ctx context.Context, stop func(), follower *appLogsFollower) {
+	ticker := time.NewTicker(l.idleTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if follower.expire(now, l.idleTimeout) {
+				stop()
+				return
+			}
+		}
+	}
+}
+
+func followerKey(authInfo authorization.Info, app AppRecord) string {
+	identity := sha256.Sum256(append([]byte(authInfo.Token), authInfo.CertData...))
+	return fmt.Sprintf("%s/%x", app.GUID, identity)
+}
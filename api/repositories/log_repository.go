@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/podlogs"
 	k8sclient "k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -21,10 +22,14 @@ import (
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 )
 
 const (
 	BuildWorkloadLabelKey = "korifi.cloudfoundry.org/build-workload-name"
+
+	logStreamPodsPollInterval = 2 * time.Second
 )
 
 //counterfeiter:generate -o fake -fake-name LogStreamer . LogStreamer
@@ -39,6 +44,10 @@ type GetLogsMessage struct {
 	Descending bool
 }
 
+type StreamLogsMessage struct {
+	App AppRecord
+}
+
 type LogRecord struct {
 	Message   string
 	Timestamp int64
@@ -46,14 +55,7 @@ type LogRecord struct {
 	Tags      map[string]string
 }
 
-var DefaultLogStreamer LogStreamer = func(
-	ctx context.Context,
-	logClient k8sclient.Interface,
-	pod corev1.Pod,
-	logOpts corev1.PodLogOptions,
-) (io.ReadCloser, error) {
-	return logClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &logOpts).Stream(ctx)
-}
+var DefaultLogStreamer = LogStreamer(podlogs.DefaultStreamer)
 
 type logRecordSortOrder func(LogRecord, LogRecord) int
 
@@ -69,32 +71,54 @@ type LogRepo struct {
 	userClientFactory    authorization.UserClientFactory
 	userClientsetFactory authorization.UserClientsetFactory
 	logStreamer          LogStreamer
+	// logBuffer is optional. When set, recent logs are read from it instead
+	// of from the pods.
+	logBuffer *LogBuffer
+	// logFollowers is optional. When set, logs read in ascending order are
+	// read from the followed app containers.
+	logFollowers *LogFollowers
 }
 
 func NewLogRepo(
 	userClientFactory authorization.UserClientFactory,
 	userClientsetFactory authorization.UserClientsetFactory,
 	logStreamer LogStreamer,
+	logBuffer *LogBuffer,
+	logFollowers *LogFollowers,
 ) *LogRepo {
 	return &LogRepo{
 		userClientFactory:    userClientFactory,
 		userClientsetFactory: userClientsetFactory,
 		logStreamer:          logStreamer,
+		logBuffer:            logBuffer,
+		logFollowers:         logFollowers,
 	}
 }
 
 func (r *LogRepo) GetAppLogs(ctx context.Context, authInfo authorization.Info, message GetLogsMessage) ([]LogRecord, error) {
-	buildLogs, err := r.getBuildLogs(ctx, authInfo, message.Build, message.StartTime, message.Limit)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get build logs: %w", err)
-	}
-
-	appLogs, err := r.getAppLogs(ctx, authInfo, message.App, message.StartTime, message.Limit)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get app logs: %w", err)
+	var allLogs iter.Seq[LogRecord]
+	switch {
+	case r.logBuffer != nil:
+		bufferedLogs, err := r.getBufferedLogs(ctx, authInfo, message.App)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get buffered logs: %w", err)
+		}
+		allLogs = bufferedLogs
+	case r.logFollowers != nil && !message.Descending:
+		followedLogs, err := r.getFollowedLogs(ctx, authInfo, message)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get followed logs: %w", err)
+		}
+		allLogs = followedLogs
+	default:
+		podLogs, err := r.getPodLogs(ctx, authInfo, message)
+		if err != nil {
+			return nil, err
+		}
+		allLogs = podLogs
 	}
 
-	logs := itx.From(buildLogs).Chain(appLogs).Filter(func(r LogRecord) bool {
+	logs := itx.From(allLogs).Filter(func(r LogRecord) bool {
 		// Even though we have listed logs with `SinceTime` option, ensure that
 		// there are no log entries several milliseconds before the StartTime
 		// `SinceTime` log option has a precision of a second, therefore listed
@@ -125,6 +149,200 @@ func (r *LogRepo) GetAppLogs(ctx context.Context, authInfo authorization.Info, m
 	return logs[:len(logs)-int(*message.Limit)], nil
 }
 
+// StreamAppLogs follows the logs of the app and staging pods of an app until
+// the context is done. Pods are listed periodically so that the logs of
+// restarted instances and of newly scheduled pods get streamed as well. The
+// returned channel is closed once all followed logs have been drained.
+func (r *LogRepo) StreamAppLogs(ctx context.Context, authInfo authorization.Info, message StreamLogsMessage) (<-chan LogRecord, error) {
+	logClient, err := r.userClientsetFactory.BuildClientset(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user clientset: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	pods, err := listStreamedPods(ctx, userClient, message.App)
+	if err != nil {
+		return nil, err
+	}
+
+	logRecords := make(chan LogRecord)
+	go func() {
+		defer close(logRecords)
+
+		startTime := time.Now()
+		podLogsFollower{
+			logClient:   logClient,
+			logStreamer: r.logStreamer,
+			listPods: func(ctx context.Context) ([]streamedPod, error) {
+				return listStreamedPods(ctx, userClient, message.App)
+			},
+			sinceTime: startTime,
+			onRecord: func(ctx context.Context, _ streamedPod, logRecord LogRecord) bool {
+				select {
+				case logRecords <- logRecord:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			},
+		}.follow(logr.NewContext(ctx, logr.FromContextOrDiscard(ctx).WithName("stream-logs").WithValues("appGUID", message.App.GUID)), pods)
+	}()
+
+	return logRecords, nil
+}
+
+type streamedPod struct {
+	pod        corev1.Pod
+	sourceID   string
+	sourceType string
+}
+
+// podLogsFollower follows the logs of the containers of a changing set of pods
+type podLogsFollower struct {
+	logClient   k8sclient.Interface
+	logStreamer LogStreamer
+	listPods    func(context.Context) ([]streamedPod, error)
+	// sinceTime is the time container logs are followed from
+	sinceTime time.Time
+	// onRecord is called for every log line, concurrently for different
+	// containers. Returning false stops following the container.
+	onRecord func(context.Context, streamedPod, LogRecord) bool
+}
+
+// follow follows the logs of the given pods and of the pods returned by
+// listPods, which is polled, until the context is done. It returns once all
+// followed container logs have been drained.
+func (f podLogsFollower) follow(ctx context.Context, pods []streamedPod) {
+	podlogs.Follower[streamedPod]{
+		Clientset:    f.logClient,
+		Streamer:     podlogs.Streamer(f.logStreamer),
+		ListPods:     f.listPods,
+		PollInterval: logStreamPodsPollInterval,
+		GetPod: func(p streamedPod) corev1.Pod {
+			return p.pod
+		},
+		SinceTime: f.sinceTime,
+		OnLine: func(ctx context.Context, p streamedPod, line podlogs.Line) bool {
+			return f.onRecord(ctx, p, LogRecord{
+				// trim trailing newlines so that the CLI doesn't render extra log lines for them
+				Message:   strings.TrimRight(line.Message, "\r\n"),
+				Timestamp: line.Timestamp.UnixNano(),
+				Tags: map[string]string{
+					"source_type": p.sourceType,
+				},
+			})
+		},
+	}.Follow(ctx, pods)
+}
+
+func listStreamedPods(ctx context.Context, userClient client.Client, app AppRecord) ([]streamedPod, error) {
+	appPods := corev1.PodList{}
+	err := userClient.List(ctx, &appPods, client.InNamespace(app.SpaceGUID), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey: app.GUID,
+	})
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, PodResourceType)
+	}
+
+	pods := slices.Collect(it.Map(slices.Values(appPods.Items), func(pod corev1.Pod) streamedPod {
+		return streamedPod{pod: pod, sourceID: app.GUID, sourceType: "APP"}
+	}))
+
+	builds := korifiv1alpha1.CFBuildList{}
+	err = userClient.List(ctx, &builds, client.InNamespace(app.SpaceGUID), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey: app.GUID,
+	})
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, BuildResourceType)
+	}
+
+	if len(builds.Items) == 0 {
+		return pods, nil
+	}
+
+	buildGUIDs := slices.Collect(it.Map(slices.Values(builds.Items), func(build korifiv1alpha1.CFBuild) string {
+		return build.Name
+	}))
+	buildPodsRequirement, err := labels.NewRequirement(BuildWorkloadLabelKey, selection.In, buildGUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	buildPods := corev1.PodList{}
+	err = userClient.List(ctx, &buildPods, client.InNamespace(app.SpaceGUID), client.MatchingLabelsSelector{
+		Selector: labels.NewSelector().Add(*buildPodsRequirement),
+	})
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, PodResourceType)
+	}
+
+	return append(pods, slices.Collect(it.Map(slices.Values(buildPods.Items), func(pod corev1.Pod) streamedPod {
+		return streamedPod{pod: pod, sourceID: app.GUID, sourceType: "STG"}
+	}))...), nil
+}
+
+func (r *LogRepo) getPodLogs(ctx context.Context, authInfo authorization.Info, message GetLogsMessage) (iter.Seq[LogRecord], error) {
+	buildLogs, err := r.getBuildLogs(ctx, authInfo, message.Build, message.StartTime, message.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get build logs: %w", err)
+	}
+
+	appLogs, err := r.getAppLogs(ctx, authInfo, message.App, message.StartTime, message.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app logs: %w", err)
+	}
+
+	return it.Chain(buildLogs, appLogs), nil
+}
+
+// getFollowedLogs returns the logs collected by the follower of the app logs,
+// starting it on the first read. Logs older than what the follower has
+// collected, e.g. the ones emitted before it started, are read from the pods.
+func (r *LogRepo) getFollowedLogs(ctx context.Context, authInfo authorization.Info, message GetLogsMessage) (iter.Seq[LogRecord], error) {
+	follower, err := r.logFollowers.getOrStart(ctx, authInfo, message.App)
+	if err != nil {
+		return nil, err
+	}
+
+	since, followedLogs := follower.read(time.Now())
+	if message.StartTime != nil && *message.StartTime >= since {
+		return slices.Values(followedLogs), nil
+	}
+
+	podLogs, err := r.getPodLogs(ctx, authInfo, message)
+	if err != nil {
+		return nil, err
+	}
+
+	return it.Chain(it.Filter(podLogs, func(r LogRecord) bool {
+		return r.Timestamp < since
+	}), slices.Values(followedLogs)), nil
+}
+
+// getBufferedLogs returns the app and staging logs of the app from the log
+// buffer. As the buffer reads the logs with privileged clients, the user is
+// required to be allowed to list the pods in the app space, which is what
+// reading the logs from the pods would require too.
+func (r *LogRepo) getBufferedLogs(ctx context.Context, authInfo authorization.Info, app AppRecord) (iter.Seq[LogRecord], error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.List(ctx, &corev1.PodList{}, client.InNamespace(app.SpaceGUID), client.Limit(1), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey: app.GUID,
+	})
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, PodResourceType)
+	}
+
+	return slices.Values(r.logBuffer.GetLogs(app.GUID)), nil
+}
+
 func (r *LogRepo) getBuildLogs(
 	ctx context.Context,
 	authInfo authorization.Info,
@@ -246,13 +464,15 @@ func (r *LogRepo) getContainerLogs(ctx context.Context, k8sClient k8sclient.Inte
 }
 
 func getReadyContainers(pod corev1.Pod) []string {
+	return slices.Collect(it.Map(slices.Values(getReadyContainerStatuses(pod)), func(container corev1.ContainerStatus) string {
+		return container.Name
+	}))
+}
+
+func getReadyContainerStatuses(pod corev1.Pod) []corev1.ContainerStatus {
 	containerStatuses := append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...)
-	readyContainers := it.Filter(slices.Values(containerStatuses), func(status corev1.ContainerStatus) bool {
+	return slices.Collect(it.Filter(slices.Values(containerStatuses), func(status corev1.ContainerStatus) bool {
 		return status.State.Waiting == nil
-	})
-
-	return slices.Collect(it.Map(readyContainers, func(container corev1.ContainerStatus) string {
-		return container.Name
 	}))
 }
 
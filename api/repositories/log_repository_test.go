@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
@@ -21,8 +22,10 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	"github.com/onsi/gomega/types"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("LogRepository", func() {
@@ -118,7 +121,7 @@ var _ = Describe("LogRepository", func() {
 		}
 
 		userClientsetFactory := authorization.NewUnprivilegedClientsetFactory(testEnv.Config)
-		logRepo = repositories.NewLogRepo(userClientFactory, userClientsetFactory, logStreamer.Spy)
+		logRepo = repositories.NewLogRepo(userClientFactory, userClientsetFactory, logStreamer.Spy, nil, nil)
 
 		message = repositories.GetLogsMessage{
 			App: repositories.AppRecord{
@@ -273,6 +276,338 @@ var _ = Describe("LogRepository", func() {
 				Expect(logRecords[3]).To(matchLogRecord(1000, "b1", "STG"))
 			})
 		})
+
+		When("the log buffer is configured", func() {
+			var logTime time.Time
+
+			BeforeEach(func() {
+				logTime = time.Now()
+				message.StartTime = nil
+
+				bufferLogStreamer := new(fake.LogStreamer)
+				bufferLogStreamer.Stub = func(_ context.Context, _ kubernetes.Interface, pod corev1.Pod, _ corev1.PodLogOptions) (io.ReadCloser, error) {
+					if pod.Name == appPod.Name {
+						return readerFor(map[time.Time]string{logTime: "buffered"}), nil
+					}
+					return io.NopCloser(strings.NewReader("")), nil
+				}
+
+				logBuffer := repositories.NewLogBuffer(k8sClient, nil, bufferLogStreamer.Spy, time.Hour, 10)
+				bufferCtx, cancel := context.WithCancel(ctx)
+				DeferCleanup(cancel)
+				go logBuffer.Start(bufferCtx)
+				Eventually(func() []repositories.LogRecord {
+					return logBuffer.GetLogs(message.App.GUID)
+				}).ShouldNot(BeEmpty())
+
+				logRepo = repositories.NewLogRepo(userClientFactory, authorization.NewUnprivilegedClientsetFactory(testEnv.Config), logStreamer.Spy, logBuffer, nil)
+			})
+
+			It("returns the buffered logs instead of reading them from the pods", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logStreamer.CallCount()).To(BeZero())
+				Expect(logRecords).To(ConsistOf(matchLogRecord(logTime.UnixNano(), "buffered", "APP")))
+			})
+		})
+
+		When("log followers are configured", func() {
+			var (
+				followerLogStreamer *fake.LogStreamer
+				logTime             time.Time
+			)
+
+			BeforeEach(func() {
+				logTime = time.Now().Add(time.Hour)
+
+				followerLogStreamer = new(fake.LogStreamer)
+				followerLogStreamer.Stub = func(_ context.Context, _ kubernetes.Interface, pod corev1.Pod, _ corev1.PodLogOptions) (io.ReadCloser, error) {
+					return readerFor(map[time.Time]string{logTime: "followed"}), nil
+				}
+
+				userClientsetFactory := authorization.NewUnprivilegedClientsetFactory(testEnv.Config)
+				logFollowers := repositories.NewLogFollowers(userClientFactory, userClientsetFactory, followerLogStreamer.Spy, time.Minute)
+				logRepo = repositories.NewLogRepo(userClientFactory, userClientsetFactory, logStreamer.Spy, nil, logFollowers)
+			})
+
+			It("reads the logs emitted before the follower started from the pods", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logRecords).To(ContainElements(
+					matchLogRecord(1000, "b1", "STG"),
+					matchLogRecord(1100, "a1", "APP"),
+					matchLogRecord(2000, "b2", "STG"),
+					matchLogRecord(2100, "a2", "APP"),
+				))
+			})
+
+			It("follows the app containers", func() {
+				Eventually(followerLogStreamer.CallCount).Should(Equal(1))
+
+				_, _, actualPod, actualLogOptions := followerLogStreamer.ArgsForCall(0)
+				Expect(actualPod.Name).To(Equal(appPod.Name))
+				Expect(actualLogOptions.Container).To(Equal("app-container"))
+				Expect(actualLogOptions.Follow).To(BeTrue())
+			})
+
+			When("the logs are read again", func() {
+				JustBeforeEach(func() {
+					Expect(err).NotTo(HaveOccurred())
+					message.StartTime = tools.PtrTo(time.Now().UnixNano())
+				})
+
+				It("returns the logs of the followed containers without reading the pods", func() {
+					Eventually(func(g Gomega) {
+						logRecords, err = logRepo.GetAppLogs(ctx, authInfo, message)
+						g.Expect(err).NotTo(HaveOccurred())
+						g.Expect(logRecords).To(ConsistOf(matchLogRecord(logTime.UnixNano(), "followed", "APP")))
+					}).Should(Succeed())
+
+					Expect(logStreamer.CallCount()).To(Equal(2))
+					Expect(followerLogStreamer.CallCount()).To(Equal(1))
+				})
+			})
+
+			When("the user can no longer read the logs", func() {
+				JustBeforeEach(func() {
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(followerLogStreamer.CallCount).Should(Equal(1))
+
+					Expect(k8sClient.DeleteAllOf(ctx, &rbacv1.RoleBinding{}, client.InNamespace(cfSpace.Name))).To(Succeed())
+					message.StartTime = tools.PtrTo(time.Now().UnixNano())
+				})
+
+				It("stops the follower", func() {
+					Eventually(func(g Gomega) {
+						_, err = logRepo.GetAppLogs(ctx, authInfo, message)
+						g.Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+					}).Should(Succeed())
+				})
+			})
+
+			It("starts a single follower when the logs are read concurrently", func() {
+				concurrentLogStreamer := new(fake.LogStreamer)
+				concurrentLogStreamer.Stub = followerLogStreamer.Stub
+				userClientsetFactory := authorization.NewUnprivilegedClientsetFactory(testEnv.Config)
+				logFollowers := repositories.NewLogFollowers(userClientFactory, userClientsetFactory, concurrentLogStreamer.Spy, time.Minute)
+				concurrentLogRepo := repositories.NewLogRepo(userClientFactory, userClientsetFactory, logStreamer.Spy, nil, logFollowers)
+
+				wg := sync.WaitGroup{}
+				for range 5 {
+					wg.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+
+						_, readErr := concurrentLogRepo.GetAppLogs(ctx, authInfo, message)
+						Expect(readErr).NotTo(HaveOccurred())
+					}()
+				}
+				wg.Wait()
+
+				Eventually(concurrentLogStreamer.CallCount).Should(Equal(1))
+				Consistently(concurrentLogStreamer.CallCount).Should(Equal(1))
+			})
+
+			When("descending is requested", func() {
+				BeforeEach(func() {
+					message.Descending = true
+				})
+
+				It("does not follow the app containers", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Consistently(followerLogStreamer.CallCount).Should(BeZero())
+				})
+			})
+		})
+	})
+})
+
+var _ = Describe("LogRepository StreamAppLogs", func() {
+	var (
+		appPod     *corev1.Pod
+		buildPod   *corev1.Pod
+		message    repositories.StreamLogsMessage
+		cfOrg      *korifiv1alpha1.CFOrg
+		cfSpace    *korifiv1alpha1.CFSpace
+		logTime    time.Time
+		streamCtx  context.Context
+		cancel     context.CancelFunc
+		logRecords <-chan repositories.LogRecord
+
+		logStreamer *fake.LogStreamer
+		logRepo     *repositories.LogRepo
+		err         error
+	)
+
+	BeforeEach(func() {
+		cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+		logTime = time.Now().Add(time.Hour)
+
+		appGUID := uuid.NewString()
+		appPod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Name,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Image: "dont/care",
+					Name:  "app-container",
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, appPod)).To(Succeed())
+		Expect(k8s.Patch(ctx, k8sClient, appPod, func() {
+			appPod.Status = corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "app-container",
+				}},
+			}
+		})).To(Succeed())
+
+		build := &korifiv1alpha1.CFBuild{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Name,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
+				},
+			},
+			Spec: korifiv1alpha1.CFBuildSpec{
+				AppRef: corev1.LocalObjectReference{Name: appGUID},
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, build)).To(Succeed())
+
+		buildPod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Name,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					repositories.BuildWorkloadLabelKey: build.Name,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Image: "dont/care",
+					Name:  "build-container",
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, buildPod)).To(Succeed())
+		Expect(k8s.Patch(ctx, k8sClient, buildPod, func() {
+			buildPod.Status = corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "build-container",
+				}},
+			}
+		})).To(Succeed())
+
+		logStreamer = new(fake.LogStreamer)
+		logStreamer.Stub = func(_ context.Context, _ kubernetes.Interface, pod corev1.Pod, _ corev1.PodLogOptions) (io.ReadCloser, error) {
+			switch pod.Name {
+			case buildPod.Name:
+				return readerFor(map[time.Time]string{
+					logTime: "b0",
+				}), nil
+			case appPod.Name:
+				return readerFor(map[time.Time]string{
+					logTime.Add(time.Second): "a0",
+				}), nil
+			}
+			return nil, nil
+		}
+
+		userClientsetFactory := authorization.NewUnprivilegedClientsetFactory(testEnv.Config)
+		logRepo = repositories.NewLogRepo(userClientFactory, userClientsetFactory, logStreamer.Spy, nil, nil)
+
+		message = repositories.StreamLogsMessage{
+			App: repositories.AppRecord{
+				GUID:      appGUID,
+				SpaceGUID: cfSpace.Name,
+			},
+		}
+
+		streamCtx, cancel = context.WithCancel(ctx)
+		DeferCleanup(func() {
+			cancel()
+		})
+	})
+
+	JustBeforeEach(func() {
+		logRecords, err = logRepo.StreamAppLogs(streamCtx, authInfo, message)
+	})
+
+	It("returns a forbidden error", func() {
+		Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+	})
+
+	When("the user is allowed to get logs", func() {
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+		})
+
+		It("follows the logs of the app and build pod containers", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(logStreamer.CallCount).Should(Equal(2))
+
+			for i := range 2 {
+				_, _, _, actualLogOptions := logStreamer.ArgsForCall(i)
+				Expect(actualLogOptions.Follow).To(BeTrue())
+				Expect(actualLogOptions.Timestamps).To(BeTrue())
+				Expect(actualLogOptions.SinceTime).NotTo(BeNil())
+			}
+		})
+
+		It("streams the log records", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			var records []repositories.LogRecord
+			for range 2 {
+				var record repositories.LogRecord
+				Eventually(logRecords).Should(Receive(&record))
+				records = append(records, record)
+			}
+
+			Expect(records).To(ConsistOf(
+				matchLogRecord(logTime.UnixNano(), "b0", "STG"),
+				matchLogRecord(logTime.Add(time.Second).UnixNano(), "a0", "APP"),
+			))
+		})
+
+		It("closes the stream when the context is done", func() {
+			Expect(err).NotTo(HaveOccurred())
+			cancel()
+			Eventually(logRecords).Should(BeClosed())
+		})
+
+		When("an app pod container gets restarted", func() {
+			JustBeforeEach(func() {
+				Eventually(logStreamer.CallCount).Should(Equal(2))
+
+				Expect(k8s.Patch(ctx, k8sClient, appPod, func() {
+					appPod.Status = corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{{
+							Name:         "app-container",
+							RestartCount: 1,
+						}},
+					}
+				})).To(Succeed())
+			})
+
+			It("follows the logs of the restarted container", func() {
+				Eventually(logStreamer.CallCount).Should(Equal(3))
+				_, _, actualPod, _ := logStreamer.ArgsForCall(2)
+				Expect(actualPod.Name).To(Equal(appPod.Name))
+			})
+		})
 	})
 })
 
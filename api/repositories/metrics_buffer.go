@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list
+//+kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=list
+
+// MetricsBuffer samples the container metrics of all app instances from
+// metrics-server periodically and keeps them per app (the gauge source id) for
+// a limited time, so that metrics history is available without log-cache.
+type MetricsBuffer struct {
+	privilegedClient client.Client
+	sampleInterval   time.Duration
+	retention        time.Duration
+
+	mu      sync.RWMutex
+	records map[string][]GaugeRecord
+
+	// lastSampled is the time of the latest metrics of every pod, so that
+	// metrics that have not been refreshed by metrics-server are not recorded
+	// twice
+	lastSampled map[types.UID]time.Time
+}
+
+func NewMetricsBuffer(privilegedClient client.Client, sampleInterval time.Duration, retention time.Duration) *MetricsBuffer {
+	return &MetricsBuffer{
+		privilegedClient: privilegedClient,
+		sampleInterval:   sampleInterval,
+		retention:        retention,
+		records:          map[string][]GaugeRecord{},
+		lastSampled:      map[types.UID]time.Time{},
+	}
+}
+
+// Start samples metrics until the context is done
+func (b *MetricsBuffer) Start(ctx context.Context) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("metrics-buffer")
+
+	ticker := time.NewTicker(b.sampleInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := b.sample(ctx); err != nil && ctx.Err() == nil {
+			logger.Info("failed to sample metrics", "reason", err)
+		}
+		b.prune(time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetGauges returns the sampled gauges of the given source id
+func (b *MetricsBuffer) GetGauges(sourceID string) []GaugeRecord {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return slices.Clone(b.records[sourceID])
+}
+
+func (b *MetricsBuffer) sample(ctx context.Context) error {
+	appPodsSelector, err := labels.Parse(fmt.Sprintf("%s,%s,!%s",
+		korifiv1alpha1.CFAppGUIDLabelKey,
+		korifiv1alpha1.CFProcessTypeLabelKey,
+		BuildWorkloadLabelKey,
+	))
+	if err != nil {
+		return err
+	}
+
+	pods := corev1.PodList{}
+	if err = b.privilegedClient.List(ctx, &pods, client.MatchingLabelsSelector{Selector: appPodsSelector}); err != nil {
+		return fmt.Errorf("failed to list app pods: %w", err)
+	}
+
+	podMetrics := metricsv1beta1.PodMetricsList{}
+	if err = b.privilegedClient.List(ctx, &podMetrics, client.MatchingLabelsSelector{Selector: appPodsSelector}); err != nil {
+		return fmt.Errorf("failed to list pod metrics: %w", err)
+	}
+
+	processes := korifiv1alpha1.CFProcessList{}
+	if err = b.privilegedClient.List(ctx, &processes); err != nil {
+		return fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	metricsByPod := map[types.NamespacedName]metricsv1beta1.PodMetrics{}
+	for _, m := range podMetrics.Items {
+		metricsByPod[client.ObjectKeyFromObject(&m)] = m
+	}
+
+	processesByGUID := map[types.NamespacedName]korifiv1alpha1.CFProcess{}
+	for _, p := range processes.Items {
+		processesByGUID[client.ObjectKeyFromObject(&p)] = p
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	lastSampled := map[types.UID]time.Time{}
+	for _, pod := range pods.Items {
+		metrics, ok := metricsByPod[client.ObjectKeyFromObject(&pod)]
+		if !ok {
+			continue
+		}
+
+		lastSampled[pod.UID] = metrics.Timestamp.Time
+		if previous, sampled := b.lastSampled[pod.UID]; sampled && !metrics.Timestamp.After(previous) {
+			continue
+		}
+
+		process, ok := processesByGUID[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Labels[korifiv1alpha1.GUIDLabelKey]}]
+		if !ok {
+			continue
+		}
+
+		appGUID := pod.Labels[korifiv1alpha1.CFAppGUIDLabelKey]
+		b.records[appGUID] = append(b.records[appGUID], toGaugeRecord(pod, process, metrics))
+	}
+	b.lastSampled = lastSampled
+
+	return nil
+}
+
+func (b *MetricsBuffer) prune(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	expiry := now.Add(-b.retention).UnixNano()
+	for sourceID, sourceRecords := range b.records {
+		sourceRecords = slices.DeleteFunc(sourceRecords, func(r GaugeRecord) bool {
+			return r.Timestamp < expiry
+		})
+		if len(sourceRecords) == 0 {
+			delete(b.records, sourceID)
+			continue
+		}
+		b.records[sourceID] = sourceRecords
+	}
+}
+
+func toGaugeRecord(pod corev1.Pod, process korifiv1alpha1.CFProcess, metrics metricsv1beta1.PodMetrics) GaugeRecord {
+	index, _ := strconv.Atoi(pod.Labels[korifiv1alpha1.PodIndexLabelKey])
+
+	usage := corev1.ResourceList{}
+	for _, container := range metrics.Containers {
+		for name, quantity := range container.Usage {
+			total := usage[name]
+			total.Add(quantity)
+			usage[name] = total
+		}
+	}
+
+	return GaugeRecord{
+		Timestamp:     metrics.Timestamp.UnixNano(),
+		ProcessGUID:   process.Name,
+		ProcessType:   process.Spec.ProcessType,
+		InstanceIndex: index,
+		// CF tracks CPU usage as a percentage of cores used
+		CPU:       float64(usage.Cpu().ScaledValue(resource.Nano)) / 1e9,
+		Mem:       usage.Memory().Value(),
+		Disk:      usage.Storage().Value(),
+		MemQuota:  process.Spec.MemoryMB * 1024 * 1024,
+		DiskQuota: process.Spec.DiskQuotaMB * 1024 * 1024,
+	}
+}
@@ -0,0 +1,184 @@
+package repositories_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("MetricsBuffer", func() {
+	var (
+		privilegedClient *controllerfake.Client
+		metricsBuffer    *repositories.MetricsBuffer
+		retention        time.Duration
+
+		mu          sync.Mutex
+		metricsTime time.Time
+	)
+
+	setMetricsTime := func(t time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		metricsTime = t
+	}
+
+	getGauges := func() []repositories.GaugeRecord {
+		return metricsBuffer.GetGauges("app-guid")
+	}
+
+	BeforeEach(func() {
+		retention = time.Hour
+		metricsTime = time.Now().Truncate(time.Second)
+
+		privilegedClient = new(controllerfake.Client)
+		privilegedClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch list := list.(type) {
+			case *corev1.PodList:
+				list.Items = []corev1.Pod{{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "space-guid",
+						Name:      "app-pod-0",
+						UID:       "app-pod-0-uid",
+						Labels: map[string]string{
+							korifiv1alpha1.CFAppGUIDLabelKey:     "app-guid",
+							korifiv1alpha1.CFProcessTypeLabelKey: "web",
+							korifiv1alpha1.GUIDLabelKey:          "process-guid",
+							korifiv1alpha1.PodIndexLabelKey:      "3",
+						},
+					},
+				}}
+			case *metricsv1beta1.PodMetricsList:
+				list.Items = []metricsv1beta1.PodMetrics{{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "space-guid",
+						Name:      "app-pod-0",
+					},
+					Timestamp: metav1.NewTime(metricsTime),
+					Containers: []metricsv1beta1.ContainerMetrics{
+						{
+							Name: "application",
+							Usage: corev1.ResourceList{
+								corev1.ResourceCPU:     resource.MustParse("250m"),
+								corev1.ResourceMemory:  resource.MustParse("100Mi"),
+								corev1.ResourceStorage: resource.MustParse("1Mi"),
+							},
+						},
+						{
+							Name: "sidecar",
+							Usage: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("500m"),
+								corev1.ResourceMemory: resource.MustParse("28Mi"),
+							},
+						},
+					},
+				}}
+			case *korifiv1alpha1.CFProcessList:
+				list.Items = []korifiv1alpha1.CFProcess{{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "space-guid",
+						Name:      "process-guid",
+					},
+					Spec: korifiv1alpha1.CFProcessSpec{
+						ProcessType: "web",
+						MemoryMB:    256,
+						DiskQuotaMB: 1024,
+					},
+				}}
+			default:
+				panic("TestClient List provided an unexpected object type")
+			}
+
+			return nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		metricsBuffer = repositories.NewMetricsBuffer(privilegedClient, 10*time.Millisecond, retention)
+
+		bufferCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			metricsBuffer.Start(bufferCtx)
+		}()
+		DeferCleanup(func() {
+			cancel()
+			Eventually(done).Should(BeClosed())
+		})
+	})
+
+	It("records the gauges of the app instances", func() {
+		Eventually(getGauges).Should(ConsistOf(repositories.GaugeRecord{
+			Timestamp:     metricsTime.UnixNano(),
+			ProcessGUID:   "process-guid",
+			ProcessType:   "web",
+			InstanceIndex: 3,
+			CPU:           0.75,
+			Mem:           128 * 1024 * 1024,
+			Disk:          1024 * 1024,
+			MemQuota:      256 * 1024 * 1024,
+			DiskQuota:     1024 * 1024 * 1024,
+		}))
+	})
+
+	It("does not record metrics that have not been refreshed twice", func() {
+		Eventually(getGauges).Should(HaveLen(1))
+		Consistently(getGauges, "200ms").Should(HaveLen(1))
+	})
+
+	When("metrics-server refreshes the metrics", func() {
+		JustBeforeEach(func() {
+			Eventually(getGauges).Should(HaveLen(1))
+			setMetricsTime(metricsTime.Add(15 * time.Second))
+		})
+
+		It("records the refreshed metrics", func() {
+			Eventually(getGauges).Should(HaveLen(2))
+			Consistently(getGauges, "200ms").Should(HaveLen(2))
+			Expect(getGauges()[1].Timestamp).To(Equal(metricsTime.UnixNano()))
+		})
+	})
+
+	When("the metrics are older than the retention", func() {
+		BeforeEach(func() {
+			retention = time.Minute
+			metricsTime = time.Now().Add(-2 * time.Minute)
+		})
+
+		It("prunes them", func() {
+			Eventually(privilegedClient.ListCallCount).Should(BeNumerically(">", 6))
+			Expect(getGauges()).To(BeEmpty())
+		})
+	})
+
+	When("there are no metrics for the app pods", func() {
+		BeforeEach(func() {
+			listStub := privilegedClient.ListStub
+			privilegedClient.ListStub = func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*metricsv1beta1.PodMetricsList); ok {
+					return nil
+				}
+				return listStub(ctx, list, opts...)
+			}
+		})
+
+		It("does not record any gauges", func() {
+			Eventually(privilegedClient.ListCallCount).Should(BeNumerically(">", 6))
+			Expect(getGauges()).To(BeEmpty())
+		})
+	})
+})
@@ -11,7 +11,7 @@ import (
 	"k8s.io/client-go/dynamic"
 )
 
-//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfdomains;cforgs;cfpackages;cfprocesses;cfroutes;cfsecuritygroups;cfservicebindings;cfservicebrokers;cfserviceinstances;cfserviceofferings;cfserviceplans;cfspaces;cftasks,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfdomains;cfnetworkpolicies;cforgquotas;cforgs;cfpackages;cfprocesses;cfrevisions;cfroutes;cfsecuritygroups;cfservicebindings;cfservicebrokers;cfserviceinstances;cfserviceroutebindings;cfserviceofferings;cfserviceplans;cfsidecars;cfspacequotas;cfspaces;cftasks,verbs=list
 
 var (
 	CFAppsGVR = schema.GroupVersionResource{
@@ -50,6 +50,12 @@ var (
 		Resource: "cfprocesses",
 	}
 
+	CFRevisionsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfrevisions",
+	}
+
 	CFRoutesGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -62,12 +68,30 @@ var (
 		Resource: "cfservicebindings",
 	}
 
+	CFServiceRouteBindingsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfserviceroutebindings",
+	}
+
 	CFServiceInstancesGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
 		Resource: "cfserviceinstances",
 	}
 
+	CFNetworkPoliciesGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfnetworkpolicies",
+	}
+
+	CFSidecarsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfsidecars",
+	}
+
 	CFSpacesGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -80,18 +104,29 @@ var (
 		Resource: "cftasks",
 	}
 
+	CFSpaceQuotasGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfspacequotas",
+	}
+
 	ResourceMap = map[string]schema.GroupVersionResource{
-		AppResourceType:             CFAppsGVR,
-		BuildResourceType:           CFBuildsGVR,
-		DropletResourceType:         CFDropletsGVR,
-		DomainResourceType:          CFDomainsGVR,
-		PackageResourceType:         CFPackagesGVR,
-		ProcessResourceType:         CFProcessesGVR,
-		RouteResourceType:           CFRoutesGVR,
-		ServiceBindingResourceType:  CFServiceBindingsGVR,
-		ServiceInstanceResourceType: CFServiceInstancesGVR,
-		SpaceResourceType:           CFSpacesGVR,
-		TaskResourceType:            CFTasksGVR,
+		AppResourceType:                 CFAppsGVR,
+		BuildResourceType:               CFBuildsGVR,
+		DropletResourceType:             CFDropletsGVR,
+		DomainResourceType:              CFDomainsGVR,
+		PackageResourceType:             CFPackagesGVR,
+		ProcessResourceType:             CFProcessesGVR,
+		RevisionResourceType:            CFRevisionsGVR,
+		RouteResourceType:               CFRoutesGVR,
+		ServiceBindingResourceType:      CFServiceBindingsGVR,
+		ServiceInstanceResourceType:     CFServiceInstancesGVR,
+		ServiceRouteBindingResourceType: CFServiceRouteBindingsGVR,
+		NetworkPolicyResourceType:       CFNetworkPoliciesGVR,
+		SidecarResourceType:             CFSidecarsGVR,
+		SpaceResourceType:               CFSpacesGVR,
+		SpaceQuotaResourceType:          CFSpaceQuotasGVR,
+		TaskResourceType:                CFTasksGVR,
 	}
 )
 
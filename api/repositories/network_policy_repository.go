@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/BooleanCat/go-functional/v2/it"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const NetworkPolicyResourceType = "Network Policy"
+
+type NetworkPolicyRepo struct {
+	klient Klient
+}
+
+func NewNetworkPolicyRepo(klient Klient) *NetworkPolicyRepo {
+	return &NetworkPolicyRepo{
+		klient: klient,
+	}
+}
+
+type NetworkPolicyRecord struct {
+	SourceAppGUID        string
+	SourceSpaceGUID      string
+	DestinationAppGUID   string
+	DestinationSpaceGUID string
+	Protocol             string
+	StartPort            int32
+	EndPort              int32
+}
+
+// NetworkPolicyMessage identifies a policy allowing traffic from the source
+// app to the destination app. Policies have no GUID of their own, so the
+// same message is used to create and to delete them
+type NetworkPolicyMessage struct {
+	SourceAppGUID        string
+	SourceSpaceGUID      string
+	DestinationAppGUID   string
+	DestinationSpaceGUID string
+	Protocol             string
+	StartPort            int32
+	EndPort              int32
+}
+
+// name is derived from the policy tuple so that adding an existing policy
+// again does not create a duplicate
+func (m NetworkPolicyMessage) name() string {
+	return tools.EncodeValueToSha224(fmt.Sprintf("%s:%s:%s:%s:%d:%d",
+		m.SourceAppGUID,
+		m.DestinationSpaceGUID,
+		m.DestinationAppGUID,
+		m.Protocol,
+		m.StartPort,
+		m.EndPort,
+	))
+}
+
+func (m NetworkPolicyMessage) toCFNetworkPolicy() *korifiv1alpha1.CFNetworkPolicy {
+	return &korifiv1alpha1.CFNetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.name(),
+			Namespace: m.SourceSpaceGUID,
+		},
+		Spec: korifiv1alpha1.CFNetworkPolicySpec{
+			SourceAppRef: corev1.LocalObjectReference{Name: m.SourceAppGUID},
+			DestinationAppRef: corev1.ObjectReference{
+				Name:      m.DestinationAppGUID,
+				Namespace: m.DestinationSpaceGUID,
+			},
+			Protocol:  m.Protocol,
+			StartPort: m.StartPort,
+			EndPort:   m.EndPort,
+		},
+	}
+}
+
+type ListNetworkPoliciesMessage struct {
+	// AppGUIDs filters the policies whose source or destination app is one
+	// of the given apps
+	AppGUIDs []string
+}
+
+func (m ListNetworkPoliciesMessage) matches(policy korifiv1alpha1.CFNetworkPolicy) bool {
+	return tools.EmptyOrContains(m.AppGUIDs, policy.Spec.SourceAppRef.Name) ||
+		slices.Contains(m.AppGUIDs, policy.Spec.DestinationAppRef.Name)
+}
+
+func (r *NetworkPolicyRepo) CreateNetworkPolicy(ctx context.Context, authInfo authorization.Info, message NetworkPolicyMessage) (NetworkPolicyRecord, error) {
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SourceSpaceGUID,
+			Name:      message.SourceAppGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, cfApp); err != nil {
+		return NetworkPolicyRecord{}, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	cfNetworkPolicy := message.toCFNetworkPolicy()
+	if err := controllerutil.SetOwnerReference(cfApp, cfNetworkPolicy, scheme.Scheme); err != nil {
+		return NetworkPolicyRecord{}, fmt.Errorf("failed to set the owner of the network policy: %w", err)
+	}
+
+	err := r.klient.Create(ctx, cfNetworkPolicy)
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return NetworkPolicyRecord{}, fmt.Errorf("failed to create network policy: %w", apierrors.FromK8sError(err, NetworkPolicyResourceType))
+	}
+
+	return networkPolicyToRecord(*cfNetworkPolicy), nil
+}
+
+func (r *NetworkPolicyRepo) ListNetworkPolicies(ctx context.Context, authInfo authorization.Info, message ListNetworkPoliciesMessage) (ListResult[NetworkPolicyRecord], error) {
+	policyList := &korifiv1alpha1.CFNetworkPolicyList{}
+	_, err := r.klient.List(ctx, policyList)
+	if err != nil {
+		return ListResult[NetworkPolicyRecord]{}, fmt.Errorf("failed to list network policies: %w", apierrors.FromK8sError(err, NetworkPolicyResourceType))
+	}
+
+	records := slices.Collect(it.Map(
+		it.Filter(slices.Values(policyList.Items), message.matches),
+		networkPolicyToRecord,
+	))
+
+	return ListResult[NetworkPolicyRecord]{
+		PageInfo: descriptors.SinglePageInfo(len(records), len(records)),
+		Records:  records,
+	}, nil
+}
+
+// DeleteNetworkPolicy deletes the policy matching the message. Deleting a
+// policy that does not exist is not an error
+func (r *NetworkPolicyRepo) DeleteNetworkPolicy(ctx context.Context, authInfo authorization.Info, message NetworkPolicyMessage) error {
+	err := r.klient.Delete(ctx, message.toCFNetworkPolicy())
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete network policy: %w", apierrors.FromK8sError(err, NetworkPolicyResourceType))
+	}
+
+	return nil
+}
+
+func networkPolicyToRecord(policy korifiv1alpha1.CFNetworkPolicy) NetworkPolicyRecord {
+	return NetworkPolicyRecord{
+		SourceAppGUID:        policy.Spec.SourceAppRef.Name,
+		SourceSpaceGUID:      policy.Namespace,
+		DestinationAppGUID:   policy.Spec.DestinationAppRef.Name,
+		DestinationSpaceGUID: policy.Spec.DestinationAppRef.Namespace,
+		Protocol:             policy.Spec.Protocol,
+		StartPort:            policy.Spec.StartPort,
+		EndPort:              policy.Spec.EndPort,
+	}
+}
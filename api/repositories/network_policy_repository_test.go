@@ -0,0 +1,209 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = Describe("NetworkPolicyRepository", func() {
+	var (
+		networkPolicyRepo *repositories.NetworkPolicyRepo
+		sourceSpace       *korifiv1alpha1.CFSpace
+		destinationSpace  *korifiv1alpha1.CFSpace
+		sourceApp         *korifiv1alpha1.CFApp
+		destinationApp    *korifiv1alpha1.CFApp
+		message           repositories.NetworkPolicyMessage
+	)
+
+	BeforeEach(func() {
+		networkPolicyRepo = repositories.NewNetworkPolicyRepo(spaceScopedKlient)
+
+		org := createOrgWithCleanup(ctx, uuid.NewString())
+		sourceSpace = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+		destinationSpace = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+		sourceApp = createApp(sourceSpace.Name)
+		destinationApp = createApp(destinationSpace.Name)
+
+		message = repositories.NetworkPolicyMessage{
+			SourceAppGUID:        sourceApp.Name,
+			SourceSpaceGUID:      sourceSpace.Name,
+			DestinationAppGUID:   destinationApp.Name,
+			DestinationSpaceGUID: destinationSpace.Name,
+			Protocol:             "tcp",
+			StartPort:            8080,
+			EndPort:              8090,
+		}
+	})
+
+	listPolicies := func() []korifiv1alpha1.CFNetworkPolicy {
+		GinkgoHelper()
+
+		policies := &korifiv1alpha1.CFNetworkPolicyList{}
+		Expect(k8sClient.List(ctx, policies)).To(Succeed())
+		return policies.Items
+	}
+
+	Describe("CreateNetworkPolicy", func() {
+		var (
+			record    repositories.NetworkPolicyRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			record, createErr = networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, sourceSpace.Name)
+			})
+
+			It("creates the network policy in the source space", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record).To(Equal(repositories.NetworkPolicyRecord{
+					SourceAppGUID:        sourceApp.Name,
+					SourceSpaceGUID:      sourceSpace.Name,
+					DestinationAppGUID:   destinationApp.Name,
+					DestinationSpaceGUID: destinationSpace.Name,
+					Protocol:             "tcp",
+					StartPort:            8080,
+					EndPort:              8090,
+				}))
+
+				policies := listPolicies()
+				Expect(policies).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+						"Namespace": Equal(sourceSpace.Name),
+						"OwnerReferences": ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"Kind": Equal("CFApp"),
+							"Name": Equal(sourceApp.Name),
+						})),
+					}),
+					"Spec": Equal(korifiv1alpha1.CFNetworkPolicySpec{
+						SourceAppRef: corev1.LocalObjectReference{Name: sourceApp.Name},
+						DestinationAppRef: corev1.ObjectReference{
+							Name:      destinationApp.Name,
+							Namespace: destinationSpace.Name,
+						},
+						Protocol:  "tcp",
+						StartPort: 8080,
+						EndPort:   8090,
+					}),
+				})))
+			})
+
+			When("the policy already exists", func() {
+				BeforeEach(func() {
+					_, err := networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, message)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("does not create a duplicate", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(listPolicies()).To(HaveLen(1))
+				})
+			})
+
+			When("the source app does not exist", func() {
+				BeforeEach(func() {
+					message.SourceAppGUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListNetworkPolicies", func() {
+		var (
+			listMessage repositories.ListNetworkPoliciesMessage
+			result      repositories.ListResult[repositories.NetworkPolicyRecord]
+			listErr     error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, sourceSpace.Name)
+			_, err := networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, message)
+			Expect(err).NotTo(HaveOccurred())
+
+			listMessage = repositories.ListNetworkPoliciesMessage{}
+		})
+
+		JustBeforeEach(func() {
+			result, listErr = networkPolicyRepo.ListNetworkPolicies(ctx, authInfo, listMessage)
+		})
+
+		It("lists the network policies", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(result.Records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"SourceAppGUID":      Equal(sourceApp.Name),
+				"DestinationAppGUID": Equal(destinationApp.Name),
+			})))
+		})
+
+		When("filtering by the destination app", func() {
+			BeforeEach(func() {
+				listMessage.AppGUIDs = []string{destinationApp.Name}
+			})
+
+			It("returns the policies to the app", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(result.Records).To(HaveLen(1))
+			})
+		})
+
+		When("filtering by another app", func() {
+			BeforeEach(func() {
+				listMessage.AppGUIDs = []string{"another-app"}
+			})
+
+			It("returns an empty list", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(result.Records).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DeleteNetworkPolicy", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, sourceSpace.Name)
+			_, err := networkPolicyRepo.CreateNetworkPolicy(ctx, authInfo, message)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = networkPolicyRepo.DeleteNetworkPolicy(ctx, authInfo, message)
+		})
+
+		It("deletes the network policy", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+			Expect(listPolicies()).To(BeEmpty())
+		})
+
+		When("the policy does not exist", func() {
+			BeforeEach(func() {
+				message.EndPort = 9000
+			})
+
+			It("succeeds", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				Expect(listPolicies()).To(HaveLen(1))
+			})
+		})
+	})
+})
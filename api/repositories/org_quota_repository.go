@@ -0,0 +1,362 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const OrgQuotaResourceType = "Organization Quota"
+
+type QuotaAppsLimits struct {
+	TotalMemoryInMB      *int64
+	PerProcessMemoryInMB *int64
+	TotalInstances       *int32
+	PerAppTasks          *int32
+}
+
+type QuotaServicesLimits struct {
+	PaidServicesAllowed   bool
+	TotalServiceInstances *int32
+}
+
+type QuotaRoutesLimits struct {
+	TotalRoutes *int32
+}
+
+type QuotaLimits struct {
+	Apps     QuotaAppsLimits
+	Services QuotaServicesLimits
+	Routes   QuotaRoutesLimits
+}
+
+// QuotaLimitsPatch holds the limits to update. Nil fields are left unchanged.
+type QuotaLimitsPatch struct {
+	TotalMemoryInMB       *int64
+	PerProcessMemoryInMB  *int64
+	TotalInstances        *int32
+	PerAppTasks           *int32
+	PaidServicesAllowed   *bool
+	TotalServiceInstances *int32
+	TotalRoutes           *int32
+}
+
+func (p QuotaLimitsPatch) apply(limits *korifiv1alpha1.QuotaLimits) {
+	limits.Apps.TotalMemoryInMB = tools.IfNil(p.TotalMemoryInMB, limits.Apps.TotalMemoryInMB)
+	limits.Apps.PerProcessMemoryInMB = tools.IfNil(p.PerProcessMemoryInMB, limits.Apps.PerProcessMemoryInMB)
+	limits.Apps.TotalInstances = tools.IfNil(p.TotalInstances, limits.Apps.TotalInstances)
+	limits.Apps.PerAppTasks = tools.IfNil(p.PerAppTasks, limits.Apps.PerAppTasks)
+	limits.Services.TotalServiceInstances = tools.IfNil(p.TotalServiceInstances, limits.Services.TotalServiceInstances)
+	limits.Routes.TotalRoutes = tools.IfNil(p.TotalRoutes, limits.Routes.TotalRoutes)
+
+	if p.PaidServicesAllowed != nil {
+		limits.Services.PaidServicesAllowed = *p.PaidServicesAllowed
+	}
+}
+
+type OrgQuotaRepo struct {
+	klient        Klient
+	rootNamespace string
+}
+
+func NewOrgQuotaRepo(klient Klient, rootNamespace string) *OrgQuotaRepo {
+	return &OrgQuotaRepo{
+		klient:        klient,
+		rootNamespace: rootNamespace,
+	}
+}
+
+type OrgQuotaRecord struct {
+	GUID      string
+	Name      string
+	Limits    QuotaLimits
+	OrgGUIDs  []string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+}
+
+type CreateOrgQuotaMessage struct {
+	Name     string
+	Limits   QuotaLimits
+	OrgGUIDs []string
+}
+
+type UpdateOrgQuotaMessage struct {
+	GUID   string
+	Name   *string
+	Limits QuotaLimitsPatch
+}
+
+type ApplyOrgQuotaMessage struct {
+	GUID     string
+	OrgGUIDs []string
+}
+
+type ListOrgQuotasMessage struct {
+	GUIDs      []string
+	Names      []string
+	OrgGUIDs   []string
+	Pagination Pagination
+}
+
+func (m *ListOrgQuotasMessage) matches(quota OrgQuotaRecord) bool {
+	return tools.EmptyOrContains(m.GUIDs, quota.GUID) &&
+		tools.EmptyOrContains(m.Names, quota.Name) &&
+		(len(m.OrgGUIDs) == 0 || slices.ContainsFunc(quota.OrgGUIDs, func(org string) bool {
+			return slices.Contains(m.OrgGUIDs, org)
+		}))
+}
+
+func (r *OrgQuotaRepo) CreateOrgQuota(ctx context.Context, authInfo authorization.Info, message CreateOrgQuotaMessage) (OrgQuotaRecord, error) {
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFOrgQuotaSpec{
+			DisplayName: message.Name,
+			QuotaLimits: toCFQuotaLimits(message.Limits),
+			Orgs:        message.OrgGUIDs,
+		},
+	}
+
+	err := r.klient.Create(ctx, cfOrgQuota)
+	if err != nil {
+		return OrgQuotaRecord{}, quotaWebhookErrorToApiError(err, OrgQuotaResourceType)
+	}
+
+	if err = r.removeOrgsFromOtherQuotas(ctx, cfOrgQuota.Name, message.OrgGUIDs); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return toOrgQuotaRecord(*cfOrgQuota), nil
+}
+
+func (r *OrgQuotaRepo) GetOrgQuota(ctx context.Context, authInfo authorization.Info, guid string) (OrgQuotaRecord, error) {
+	cfOrgQuota, err := r.getCFOrgQuota(ctx, guid)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("get-org-quota failed: %w", err)
+	}
+
+	return toOrgQuotaRecord(*cfOrgQuota), nil
+}
+
+func (r *OrgQuotaRepo) ListOrgQuotas(ctx context.Context, authInfo authorization.Info, message ListOrgQuotasMessage) (ListResult[OrgQuotaRecord], error) {
+	cfOrgQuotaList := &korifiv1alpha1.CFOrgQuotaList{}
+	_, err := r.klient.List(ctx, cfOrgQuotaList, InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return ListResult[OrgQuotaRecord]{}, nil
+		}
+		return ListResult[OrgQuotaRecord]{}, fmt.Errorf("failed to list org quotas in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	records := slices.Collect(it.Filter(it.Map(slices.Values(cfOrgQuotaList.Items), toOrgQuotaRecord), message.matches))
+	slices.SortStableFunc(records, func(q1, q2 OrgQuotaRecord) int {
+		return q1.CreatedAt.Compare(q2.CreatedAt)
+	})
+
+	recordsPage := descriptors.SinglePage(records, len(records))
+	if !message.Pagination.IsZero() {
+		recordsPage, err = descriptors.GetPage(records, message.Pagination.PerPage, message.Pagination.Page)
+		if err != nil {
+			return ListResult[OrgQuotaRecord]{}, fmt.Errorf("failed to page org quotas list: %w", err)
+		}
+	}
+
+	return ListResult[OrgQuotaRecord]{
+		PageInfo: recordsPage.PageInfo,
+		Records:  recordsPage.Items,
+	}, nil
+}
+
+func (r *OrgQuotaRepo) UpdateOrgQuota(ctx context.Context, authInfo authorization.Info, message UpdateOrgQuotaMessage) (OrgQuotaRecord, error) {
+	cfOrgQuota, err := r.getCFOrgQuota(ctx, message.GUID)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("update-org-quota failed: %w", err)
+	}
+
+	err = r.klient.Patch(ctx, cfOrgQuota, func() error {
+		if message.Name != nil {
+			cfOrgQuota.Spec.DisplayName = *message.Name
+		}
+		message.Limits.apply(&cfOrgQuota.Spec.QuotaLimits)
+
+		return nil
+	})
+	if err != nil {
+		return OrgQuotaRecord{}, quotaWebhookErrorToApiError(err, OrgQuotaResourceType)
+	}
+
+	return toOrgQuotaRecord(*cfOrgQuota), nil
+}
+
+// ApplyOrgQuota applies the quota to the given orgs. An org can only have
+// one quota, so the orgs are removed from any other quota.
+func (r *OrgQuotaRepo) ApplyOrgQuota(ctx context.Context, authInfo authorization.Info, message ApplyOrgQuotaMessage) (OrgQuotaRecord, error) {
+	cfOrgQuota, err := r.getCFOrgQuota(ctx, message.GUID)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("apply-org-quota failed: %w", err)
+	}
+
+	err = r.klient.Patch(ctx, cfOrgQuota, func() error {
+		for _, orgGUID := range message.OrgGUIDs {
+			if !slices.Contains(cfOrgQuota.Spec.Orgs, orgGUID) {
+				cfOrgQuota.Spec.Orgs = append(cfOrgQuota.Spec.Orgs, orgGUID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to apply org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	if err = r.removeOrgsFromOtherQuotas(ctx, cfOrgQuota.Name, message.OrgGUIDs); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return toOrgQuotaRecord(*cfOrgQuota), nil
+}
+
+func (r *OrgQuotaRepo) DeleteOrgQuota(ctx context.Context, authInfo authorization.Info, guid string) error {
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	err := r.klient.Delete(ctx, cfOrgQuota)
+	if err != nil {
+		return apierrors.FromK8sError(err, OrgQuotaResourceType)
+	}
+
+	return nil
+}
+
+func (r *OrgQuotaRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	orgQuota, err := r.GetOrgQuota(ctx, authInfo, guid)
+	return orgQuota.DeletedAt, err
+}
+
+func (r *OrgQuotaRepo) removeOrgsFromOtherQuotas(ctx context.Context, quotaGUID string, orgGUIDs []string) error {
+	if len(orgGUIDs) == 0 {
+		return nil
+	}
+
+	cfOrgQuotaList := &korifiv1alpha1.CFOrgQuotaList{}
+	_, err := r.klient.List(ctx, cfOrgQuotaList, InNamespace(r.rootNamespace))
+	if err != nil {
+		return fmt.Errorf("failed to list org quotas: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	for _, otherQuota := range cfOrgQuotaList.Items {
+		if otherQuota.Name == quotaGUID {
+			continue
+		}
+
+		remainingOrgs := slices.DeleteFunc(slices.Clone(otherQuota.Spec.Orgs), func(org string) bool {
+			return slices.Contains(orgGUIDs, org)
+		})
+		if len(remainingOrgs) == len(otherQuota.Spec.Orgs) {
+			continue
+		}
+
+		err = r.klient.Patch(ctx, &otherQuota, func() error {
+			otherQuota.Spec.Orgs = remainingOrgs
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove orgs from org quota %s: %w", otherQuota.Name, apierrors.FromK8sError(err, OrgQuotaResourceType))
+		}
+	}
+
+	return nil
+}
+
+func (r *OrgQuotaRepo) getCFOrgQuota(ctx context.Context, guid string) (*korifiv1alpha1.CFOrgQuota, error) {
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	err := r.klient.Get(ctx, cfOrgQuota)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, OrgQuotaResourceType)
+	}
+
+	return cfOrgQuota, nil
+}
+
+func quotaWebhookErrorToApiError(err error, resourceType string) error {
+	if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+		if validationError.Type == validation.DuplicateNameErrorType {
+			return apierrors.NewUniquenessError(err, validationError.GetMessage())
+		}
+	}
+
+	return apierrors.FromK8sError(err, resourceType)
+}
+
+func toCFQuotaLimits(limits QuotaLimits) korifiv1alpha1.QuotaLimits {
+	return korifiv1alpha1.QuotaLimits{
+		Apps: korifiv1alpha1.QuotaAppsLimits{
+			TotalMemoryInMB:      limits.Apps.TotalMemoryInMB,
+			PerProcessMemoryInMB: limits.Apps.PerProcessMemoryInMB,
+			TotalInstances:       limits.Apps.TotalInstances,
+			PerAppTasks:          limits.Apps.PerAppTasks,
+		},
+		Services: korifiv1alpha1.QuotaServicesLimits{
+			PaidServicesAllowed:   limits.Services.PaidServicesAllowed,
+			TotalServiceInstances: limits.Services.TotalServiceInstances,
+		},
+		Routes: korifiv1alpha1.QuotaRoutesLimits{
+			TotalRoutes: limits.Routes.TotalRoutes,
+		},
+	}
+}
+
+func toQuotaLimits(limits korifiv1alpha1.QuotaLimits) QuotaLimits {
+	return QuotaLimits{
+		Apps: QuotaAppsLimits{
+			TotalMemoryInMB:      limits.Apps.TotalMemoryInMB,
+			PerProcessMemoryInMB: limits.Apps.PerProcessMemoryInMB,
+			TotalInstances:       limits.Apps.TotalInstances,
+			PerAppTasks:          limits.Apps.PerAppTasks,
+		},
+		Services: QuotaServicesLimits{
+			PaidServicesAllowed:   limits.Services.PaidServicesAllowed,
+			TotalServiceInstances: limits.Services.TotalServiceInstances,
+		},
+		Routes: QuotaRoutesLimits{
+			TotalRoutes: limits.Routes.TotalRoutes,
+		},
+	}
+}
+
+func toOrgQuotaRecord(cfOrgQuota korifiv1alpha1.CFOrgQuota) OrgQuotaRecord {
+	return OrgQuotaRecord{
+		GUID:      cfOrgQuota.Name,
+		Name:      cfOrgQuota.Spec.DisplayName,
+		Limits:    toQuotaLimits(cfOrgQuota.Spec.QuotaLimits),
+		OrgGUIDs:  cfOrgQuota.Spec.Orgs,
+		CreatedAt: cfOrgQuota.CreationTimestamp.Time,
+		UpdatedAt: getLastUpdatedTime(&cfOrgQuota),
+		DeletedAt: golangTime(cfOrgQuota.DeletionTimestamp),
+	}
+}
@@ -0,0 +1,394 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("OrgQuotaRepo", func() {
+	var (
+		repo *repositories.OrgQuotaRepo
+		org  *korifiv1alpha1.CFOrg
+	)
+
+	createOrgQuota := func(displayName string, orgs ...string) *korifiv1alpha1.CFOrgQuota {
+		cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFOrgQuotaSpec{
+				DisplayName: displayName,
+				QuotaLimits: korifiv1alpha1.QuotaLimits{
+					Apps: korifiv1alpha1.QuotaAppsLimits{
+						TotalMemoryInMB: tools.PtrTo[int64](1024),
+					},
+					Services: korifiv1alpha1.QuotaServicesLimits{
+						PaidServicesAllowed: true,
+					},
+				},
+				Orgs: orgs,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfOrgQuota)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cfOrgQuota))).To(Succeed())
+		})
+
+		return cfOrgQuota
+	}
+
+	BeforeEach(func() {
+		repo = repositories.NewOrgQuotaRepo(rootNSKlient, rootNamespace)
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+	})
+
+	Describe("CreateOrgQuota", func() {
+		var (
+			orgQuotaRecord repositories.OrgQuotaRecord
+			createMessage  repositories.CreateOrgQuotaMessage
+			otherQuota     *korifiv1alpha1.CFOrgQuota
+			createErr      error
+		)
+
+		BeforeEach(func() {
+			otherQuota = createOrgQuota(uuid.NewString(), org.Name)
+			createMessage = repositories.CreateOrgQuotaMessage{
+				Name: "test-org-quota",
+				Limits: repositories.QuotaLimits{
+					Apps: repositories.QuotaAppsLimits{
+						TotalMemoryInMB:      tools.PtrTo[int64](2048),
+						PerProcessMemoryInMB: tools.PtrTo[int64](512),
+						TotalInstances:       tools.PtrTo[int32](10),
+						PerAppTasks:          tools.PtrTo[int32](2),
+					},
+					Services: repositories.QuotaServicesLimits{
+						PaidServicesAllowed:   true,
+						TotalServiceInstances: tools.PtrTo[int32](5),
+					},
+					Routes: repositories.QuotaRoutesLimits{
+						TotalRoutes: tools.PtrTo[int32](7),
+					},
+				},
+				OrgGUIDs: []string{org.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			orgQuotaRecord, createErr = repo.CreateOrgQuota(ctx, authInfo, createMessage)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates a CFOrgQuota", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      orgQuotaRecord.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota)).To(Succeed())
+				Expect(cfOrgQuota.Spec.DisplayName).To(Equal("test-org-quota"))
+				Expect(cfOrgQuota.Spec.Apps.TotalMemoryInMB).To(PointTo(BeEquivalentTo(2048)))
+				Expect(cfOrgQuota.Spec.Apps.PerProcessMemoryInMB).To(PointTo(BeEquivalentTo(512)))
+				Expect(cfOrgQuota.Spec.Apps.TotalInstances).To(PointTo(BeEquivalentTo(10)))
+				Expect(cfOrgQuota.Spec.Apps.PerAppTasks).To(PointTo(BeEquivalentTo(2)))
+				Expect(cfOrgQuota.Spec.Services.PaidServicesAllowed).To(BeTrue())
+				Expect(cfOrgQuota.Spec.Services.TotalServiceInstances).To(PointTo(BeEquivalentTo(5)))
+				Expect(cfOrgQuota.Spec.Routes.TotalRoutes).To(PointTo(BeEquivalentTo(7)))
+				Expect(cfOrgQuota.Spec.Orgs).To(ConsistOf(org.Name))
+			})
+
+			It("returns an org quota record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(orgQuotaRecord.GUID).To(matchers.BeValidUUID())
+				Expect(orgQuotaRecord.Name).To(Equal("test-org-quota"))
+				Expect(orgQuotaRecord.Limits).To(Equal(createMessage.Limits))
+				Expect(orgQuotaRecord.OrgGUIDs).To(ConsistOf(org.Name))
+			})
+
+			It("removes the orgs from other org quotas", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(otherQuota), otherQuota)).To(Succeed())
+				Expect(otherQuota.Spec.Orgs).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GetOrgQuota", func() {
+		var (
+			cfOrgQuota     *korifiv1alpha1.CFOrgQuota
+			orgQuotaRecord repositories.OrgQuotaRecord
+			getErr         error
+		)
+
+		BeforeEach(func() {
+			cfOrgQuota = createOrgQuota(uuid.NewString(), org.Name)
+		})
+
+		JustBeforeEach(func() {
+			orgQuotaRecord, getErr = repo.GetOrgQuota(ctx, authInfo, cfOrgQuota.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a root namespace user", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+			})
+
+			It("returns the org quota", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(orgQuotaRecord.GUID).To(Equal(cfOrgQuota.Name))
+				Expect(orgQuotaRecord.Name).To(Equal(cfOrgQuota.Spec.DisplayName))
+				Expect(orgQuotaRecord.Limits.Apps.TotalMemoryInMB).To(PointTo(BeEquivalentTo(1024)))
+				Expect(orgQuotaRecord.Limits.Apps.TotalInstances).To(BeNil())
+				Expect(orgQuotaRecord.OrgGUIDs).To(ConsistOf(org.Name))
+			})
+
+			When("the org quota does not exist", func() {
+				BeforeEach(func() {
+					cfOrgQuota.Name = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListOrgQuotas", func() {
+		var (
+			quota1, quota2 *korifiv1alpha1.CFOrgQuota
+			message        repositories.ListOrgQuotasMessage
+			listResult     repositories.ListResult[repositories.OrgQuotaRecord]
+			listErr        error
+		)
+
+		BeforeEach(func() {
+			quota1 = createOrgQuota(uuid.NewString(), org.Name)
+			quota2 = createOrgQuota(uuid.NewString())
+			message = repositories.ListOrgQuotasMessage{
+				GUIDs: []string{quota1.Name, quota2.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			listResult, listErr = repo.ListOrgQuotas(ctx, authInfo, message)
+		})
+
+		It("returns an empty list for users with no permissions", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(BeEmpty())
+		})
+
+		When("the user is a root namespace user", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+			})
+
+			It("returns the org quotas", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota1.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota2.Name)}),
+				))
+			})
+
+			When("filtering by names", func() {
+				BeforeEach(func() {
+					message.Names = []string{quota2.Spec.DisplayName}
+				})
+
+				It("returns the matching org quotas", func() {
+					Expect(listResult.Records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota2.Name)}),
+					))
+				})
+			})
+
+			When("filtering by org guids", func() {
+				BeforeEach(func() {
+					message.OrgGUIDs = []string{org.Name}
+				})
+
+				It("returns the org quotas applied to the orgs", func() {
+					Expect(listResult.Records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota1.Name)}),
+					))
+				})
+			})
+
+			When("paging is requested", func() {
+				BeforeEach(func() {
+					message.Pagination = repositories.Pagination{PerPage: 1, Page: 2}
+				})
+
+				It("returns the requested page", func() {
+					Expect(listResult.Records).To(HaveLen(1))
+					Expect(listResult.PageInfo.TotalResults).To(Equal(2))
+					Expect(listResult.PageInfo.PageNumber).To(Equal(2))
+				})
+			})
+		})
+	})
+
+	Describe("UpdateOrgQuota", func() {
+		var (
+			cfOrgQuota     *korifiv1alpha1.CFOrgQuota
+			message        repositories.UpdateOrgQuotaMessage
+			orgQuotaRecord repositories.OrgQuotaRecord
+			updateErr      error
+		)
+
+		BeforeEach(func() {
+			cfOrgQuota = createOrgQuota(uuid.NewString())
+			message = repositories.UpdateOrgQuotaMessage{
+				GUID: cfOrgQuota.Name,
+				Name: tools.PtrTo("new-name-" + uuid.NewString()),
+				Limits: repositories.QuotaLimitsPatch{
+					TotalInstances:      tools.PtrTo[int32](3),
+					PaidServicesAllowed: tools.PtrTo(false),
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			orgQuotaRecord, updateErr = repo.UpdateOrgQuota(ctx, authInfo, message)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("updates the specified fields and leaves the others unchanged", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(orgQuotaRecord.Name).To(Equal(*message.Name))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota)).To(Succeed())
+				Expect(cfOrgQuota.Spec.DisplayName).To(Equal(*message.Name))
+				Expect(cfOrgQuota.Spec.Apps.TotalInstances).To(PointTo(BeEquivalentTo(3)))
+				Expect(cfOrgQuota.Spec.Apps.TotalMemoryInMB).To(PointTo(BeEquivalentTo(1024)))
+				Expect(cfOrgQuota.Spec.Services.PaidServicesAllowed).To(BeFalse())
+			})
+
+			When("the org quota does not exist", func() {
+				BeforeEach(func() {
+					message.GUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ApplyOrgQuota", func() {
+		var (
+			cfOrgQuota     *korifiv1alpha1.CFOrgQuota
+			otherQuota     *korifiv1alpha1.CFOrgQuota
+			anotherOrg     *korifiv1alpha1.CFOrg
+			orgQuotaRecord repositories.OrgQuotaRecord
+			applyErr       error
+		)
+
+		BeforeEach(func() {
+			anotherOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfOrgQuota = createOrgQuota(uuid.NewString(), org.Name)
+			otherQuota = createOrgQuota(uuid.NewString(), anotherOrg.Name)
+		})
+
+		JustBeforeEach(func() {
+			orgQuotaRecord, applyErr = repo.ApplyOrgQuota(ctx, authInfo, repositories.ApplyOrgQuotaMessage{
+				GUID:     cfOrgQuota.Name,
+				OrgGUIDs: []string{org.Name, anotherOrg.Name},
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(applyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("applies the quota to the orgs", func() {
+				Expect(applyErr).NotTo(HaveOccurred())
+				Expect(orgQuotaRecord.OrgGUIDs).To(ConsistOf(org.Name, anotherOrg.Name))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota)).To(Succeed())
+				Expect(cfOrgQuota.Spec.Orgs).To(ConsistOf(org.Name, anotherOrg.Name))
+			})
+
+			It("removes the orgs from other org quotas", func() {
+				Expect(applyErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(otherQuota), otherQuota)).To(Succeed())
+				Expect(otherQuota.Spec.Orgs).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DeleteOrgQuota", func() {
+		var (
+			cfOrgQuota *korifiv1alpha1.CFOrgQuota
+			deleteErr  error
+		)
+
+		BeforeEach(func() {
+			cfOrgQuota = createOrgQuota(uuid.NewString())
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteOrgQuota(ctx, authInfo, cfOrgQuota.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the org quota", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+})
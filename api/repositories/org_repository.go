@@ -60,26 +60,36 @@ type DeleteOrgMessage struct {
 
 type PatchOrgMessage struct {
 	MetadataPatch
-	GUID string
-	Name *string
+	GUID      string
+	Name      *string
+	Suspended *bool
+	// An empty GUID removes the default isolation segment
+	DefaultIsolationSegmentGUID *string
 }
 
 func (p *PatchOrgMessage) Apply(org *korifiv1alpha1.CFOrg) {
 	if p.Name != nil {
 		org.Spec.DisplayName = *p.Name
 	}
+	if p.Suspended != nil {
+		org.Spec.Suspended = *p.Suspended
+	}
+	if p.DefaultIsolationSegmentGUID != nil {
+		org.Spec.DefaultIsolationSegmentGUID = *p.DefaultIsolationSegmentGUID
+	}
 	p.MetadataPatch.Apply(org)
 }
 
 type OrgRecord struct {
-	Name        string
-	GUID        string
-	Suspended   bool
-	Labels      map[string]string
-	Annotations map[string]string
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
-	DeletedAt   *time.Time
+	Name                        string
+	GUID                        string
+	Suspended                   bool
+	DefaultIsolationSegmentGUID string
+	Labels                      map[string]string
+	Annotations                 map[string]string
+	CreatedAt                   time.Time
+	UpdatedAt                   *time.Time
+	DeletedAt                   *time.Time
 }
 
 func (r OrgRecord) Relationships() map[string]string {
@@ -117,6 +127,7 @@ func (r *OrgRepo) CreateOrg(ctx context.Context, info authorization.Info, messag
 		},
 		Spec: korifiv1alpha1.CFOrgSpec{
 			DisplayName: message.Name,
+			Suspended:   message.Suspended,
 		},
 	}
 
@@ -222,13 +233,14 @@ func (r *OrgRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info,
 
 func cfOrgToOrgRecord(cfOrg korifiv1alpha1.CFOrg) OrgRecord {
 	return OrgRecord{
-		GUID:        cfOrg.Name,
-		Name:        cfOrg.Spec.DisplayName,
-		Suspended:   false,
-		Labels:      cfOrg.Labels,
-		Annotations: cfOrg.Annotations,
-		CreatedAt:   cfOrg.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&cfOrg),
-		DeletedAt:   golangTime(cfOrg.DeletionTimestamp),
+		GUID:                        cfOrg.Name,
+		Name:                        cfOrg.Spec.DisplayName,
+		Suspended:                   cfOrg.Spec.Suspended,
+		Labels:                      cfOrg.Labels,
+		Annotations:                 cfOrg.Annotations,
+		CreatedAt:                   cfOrg.CreationTimestamp.Time,
+		UpdatedAt:                   getLastUpdatedTime(&cfOrg),
+		DeletedAt:                   golangTime(cfOrg.DeletionTimestamp),
+		DefaultIsolationSegmentGUID: cfOrg.Spec.DefaultIsolationSegmentGUID,
 	}
 }
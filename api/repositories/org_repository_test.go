@@ -86,7 +86,8 @@ var _ = Describe("OrgRepository", func() {
 
 		JustBeforeEach(func() {
 			orgRecord, createErr = orgRepo.CreateOrg(ctx, authInfo, repositories.CreateOrgMessage{
-				Name: orgGUID,
+				Name:      orgGUID,
+				Suspended: true,
 				Labels: map[string]string{
 					"test-label-key": "test-label-val",
 				},
@@ -110,6 +111,7 @@ var _ = Describe("OrgRepository", func() {
 
 				Expect(orgRecord.Name).To(Equal(orgGUID))
 				Expect(orgRecord.GUID).To(matchers.BeValidUUID())
+				Expect(orgRecord.Suspended).To(BeTrue())
 				Expect(orgRecord.CreatedAt).To(BeTemporally("~", time.Now(), timeCheckThreshold))
 				Expect(orgRecord.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
 				Expect(orgRecord.DeletedAt).To(BeNil())
@@ -124,6 +126,7 @@ var _ = Describe("OrgRepository", func() {
 				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: orgRecord.GUID}, cfOrg)).To(Succeed())
 
 				Expect(cfOrg.Spec.DisplayName).To(Equal(orgGUID))
+				Expect(cfOrg.Spec.Suspended).To(BeTrue())
 				Expect(cfOrg.Labels).To(HaveKeyWithValue("test-label-key", "test-label-val"))
 				Expect(cfOrg.Annotations).To(Equal(map[string]string{"test-annotation-key": "test-annotation-val"}))
 			})
@@ -449,6 +452,7 @@ var _ = Describe("OrgRepository", func() {
 			orgGUID                       string
 			displayName                   string
 			orgNewName                    *string
+			suspended                     *bool
 			cfOrg                         *korifiv1alpha1.CFOrg
 			patchErr                      error
 			orgRecord                     repositories.OrgRecord
@@ -462,12 +466,14 @@ var _ = Describe("OrgRepository", func() {
 			labelsPatch = nil
 			annotationsPatch = nil
 			orgNewName = tools.PtrTo(uuid.NewString())
+			suspended = nil
 		})
 
 		JustBeforeEach(func() {
 			patchMsg := repositories.PatchOrgMessage{
-				GUID: orgGUID,
-				Name: orgNewName,
+				GUID:      orgGUID,
+				Name:      orgNewName,
+				Suspended: suspended,
 				MetadataPatch: repositories.MetadataPatch{
 					Annotations: annotationsPatch,
 					Labels:      labelsPatch,
@@ -529,6 +535,21 @@ var _ = Describe("OrgRepository", func() {
 				})
 			})
 
+			When("the org is suspended", func() {
+				BeforeEach(func() {
+					suspended = tools.PtrTo(true)
+				})
+
+				It("suspends the org", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(orgRecord.Suspended).To(BeTrue())
+
+					updatedCFOrg := new(korifiv1alpha1.CFOrg)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrg), updatedCFOrg)).To(Succeed())
+					Expect(updatedCFOrg.Spec.Suspended).To(BeTrue())
+				})
+			})
+
 			When("the org already has labels and annotations", func() {
 				BeforeEach(func() {
 					labelsPatch = map[string]*string{
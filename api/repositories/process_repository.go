@@ -29,20 +29,23 @@ type ProcessRepo struct {
 }
 
 type ProcessRecord struct {
-	GUID             string
-	SpaceGUID        string
-	AppGUID          string
-	Type             string
-	Command          string
-	DesiredInstances int32
-	MemoryMB         int64
-	DiskQuotaMB      int64
-	HealthCheck      HealthCheck
-	Labels           map[string]string
-	Annotations      map[string]string
-	CreatedAt        time.Time
-	UpdatedAt        *time.Time
-	InstancesStatus  map[string]korifiv1alpha1.InstanceStatus
+	GUID                 string
+	SpaceGUID            string
+	AppGUID              string
+	Type                 string
+	Command              string
+	DesiredInstances     int32
+	MemoryMB             int64
+	DiskQuotaMB          int64
+	HealthCheck          HealthCheck
+	ReadinessHealthCheck ReadinessHealthCheck
+	Labels               map[string]string
+	Annotations          map[string]string
+	CreatedAt            time.Time
+	UpdatedAt            *time.Time
+	InstancesStatus      map[string]korifiv1alpha1.InstanceStatus
+	Autoscaling          *ProcessAutoscaling
+	AutoscalingStatus    *korifiv1alpha1.ProcessAutoscalingStatus
 }
 
 func (r ProcessRecord) Relationships() map[string]string {
@@ -64,6 +67,27 @@ type HealthCheckData struct {
 	HTTPEndpoint             string
 	InvocationTimeoutSeconds int32
 	TimeoutSeconds           int32
+	IntervalSeconds          int32
+	FailureThreshold         int32
+}
+
+type ReadinessHealthCheck struct {
+	Type string
+	Data ReadinessHealthCheckData
+}
+
+type ReadinessHealthCheckData struct {
+	HTTPEndpoint             string
+	InvocationTimeoutSeconds int32
+	IntervalSeconds          int32
+}
+
+type ProcessAutoscaling struct {
+	MinInstances             int32
+	MaxInstances             int32
+	CPUUtilizationPercent    *int32
+	MemoryUtilizationPercent *int32
+	HTTPRequestsPerSecond    *int32
 }
 
 type ScaleProcessMessage struct {
@@ -79,28 +103,43 @@ type ProcessScaleValues struct {
 }
 
 type CreateProcessMessage struct {
-	AppGUID          string
-	SpaceGUID        string
-	Type             string
-	Command          string
-	DiskQuotaMB      int64
-	HealthCheck      HealthCheck
-	DesiredInstances *int32
-	MemoryMB         int64
+	AppGUID              string
+	SpaceGUID            string
+	Type                 string
+	Command              string
+	DiskQuotaMB          int64
+	HealthCheck          HealthCheck
+	ReadinessHealthCheck ReadinessHealthCheck
+	DesiredInstances     *int32
+	MemoryMB             int64
 }
 
 type PatchProcessMessage struct {
-	SpaceGUID                           string
-	ProcessGUID                         string
-	Command                             *string
-	DiskQuotaMB                         *int64
-	HealthCheckHTTPEndpoint             *string
-	HealthCheckInvocationTimeoutSeconds *int32
-	HealthCheckTimeoutSeconds           *int32
-	HealthCheckType                     *string
-	DesiredInstances                    *int32
-	MemoryMB                            *int64
-	MetadataPatch                       *MetadataPatch
+	SpaceGUID                                    string
+	ProcessGUID                                  string
+	Command                                      *string
+	DiskQuotaMB                                  *int64
+	HealthCheckHTTPEndpoint                      *string
+	HealthCheckInvocationTimeoutSeconds          *int32
+	HealthCheckTimeoutSeconds                    *int32
+	HealthCheckIntervalSeconds                   *int32
+	HealthCheckFailureThreshold                  *int32
+	HealthCheckType                              *string
+	ReadinessHealthCheckHTTPEndpoint             *string
+	ReadinessHealthCheckInvocationTimeoutSeconds *int32
+	ReadinessHealthCheckIntervalSeconds          *int32
+	ReadinessHealthCheckType                     *string
+	DesiredInstances                             *int32
+	MemoryMB                                     *int64
+	MetadataPatch                                *MetadataPatch
+}
+
+// SetProcessAutoscalingMessage removes the autoscaling policy of the process
+// when Autoscaling is nil
+type SetProcessAutoscalingMessage struct {
+	SpaceGUID   string
+	ProcessGUID string
+	Autoscaling *ProcessAutoscaling
 }
 
 type ListProcessesMessage struct {
@@ -194,6 +233,10 @@ func (r *ProcessRepo) CreateProcess(ctx context.Context, authInfo authorization.
 				Type: korifiv1alpha1.HealthCheckType(message.HealthCheck.Type),
 				Data: korifiv1alpha1.HealthCheckData(message.HealthCheck.Data),
 			},
+			ReadinessHealthCheck: korifiv1alpha1.ReadinessHealthCheck{
+				Type: korifiv1alpha1.HealthCheckType(message.ReadinessHealthCheck.Type),
+				Data: korifiv1alpha1.ReadinessHealthCheckData(message.ReadinessHealthCheck.Data),
+			},
 			DesiredInstances: message.DesiredInstances,
 			MemoryMB:         message.MemoryMB,
 			DiskQuotaMB:      message.DiskQuotaMB,
@@ -255,6 +298,24 @@ func (r *ProcessRepo) PatchProcess(ctx context.Context, authInfo authorization.I
 		if message.HealthCheckTimeoutSeconds != nil {
 			updatedProcess.Spec.HealthCheck.Data.TimeoutSeconds = *message.HealthCheckTimeoutSeconds
 		}
+		if message.HealthCheckIntervalSeconds != nil {
+			updatedProcess.Spec.HealthCheck.Data.IntervalSeconds = *message.HealthCheckIntervalSeconds
+		}
+		if message.HealthCheckFailureThreshold != nil {
+			updatedProcess.Spec.HealthCheck.Data.FailureThreshold = *message.HealthCheckFailureThreshold
+		}
+		if message.ReadinessHealthCheckType != nil {
+			updatedProcess.Spec.ReadinessHealthCheck.Type = korifiv1alpha1.HealthCheckType(*message.ReadinessHealthCheckType)
+		}
+		if message.ReadinessHealthCheckHTTPEndpoint != nil {
+			updatedProcess.Spec.ReadinessHealthCheck.Data.HTTPEndpoint = *message.ReadinessHealthCheckHTTPEndpoint
+		}
+		if message.ReadinessHealthCheckInvocationTimeoutSeconds != nil {
+			updatedProcess.Spec.ReadinessHealthCheck.Data.InvocationTimeoutSeconds = *message.ReadinessHealthCheckInvocationTimeoutSeconds
+		}
+		if message.ReadinessHealthCheckIntervalSeconds != nil {
+			updatedProcess.Spec.ReadinessHealthCheck.Data.IntervalSeconds = *message.ReadinessHealthCheckIntervalSeconds
+		}
 		if message.MetadataPatch != nil {
 			message.MetadataPatch.Apply(updatedProcess)
 		}
@@ -268,6 +329,28 @@ func (r *ProcessRepo) PatchProcess(ctx context.Context, authInfo authorization.I
 	return cfProcessToProcessRecord(*updatedProcess)
 }
 
+func (r *ProcessRepo) SetProcessAutoscaling(ctx context.Context, authInfo authorization.Info, message SetProcessAutoscalingMessage) (ProcessRecord, error) {
+	cfProcess := &korifiv1alpha1.CFProcess{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.ProcessGUID,
+			Namespace: message.SpaceGUID,
+		},
+	}
+	err := GetAndPatch(ctx, r.klient, cfProcess, func() error {
+		cfProcess.Spec.Autoscaling = nil
+		if message.Autoscaling != nil {
+			cfProcess.Spec.Autoscaling = tools.PtrTo(korifiv1alpha1.ProcessAutoscaling(*message.Autoscaling))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return ProcessRecord{}, fmt.Errorf("failed to set autoscaling of process %q: %w", message.ProcessGUID, apierrors.FromK8sError(err, ProcessResourceType))
+	}
+
+	return cfProcessToProcessRecord(*cfProcess)
+}
+
 func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) (ProcessRecord, error) {
 	createdAt, updatedAt, err := getCreatedUpdatedAt(&cfProcess)
 	if err != nil {
@@ -294,12 +377,32 @@ func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) (ProcessRecord
 				HTTPEndpoint:             cfProcess.Spec.HealthCheck.Data.HTTPEndpoint,
 				InvocationTimeoutSeconds: cfProcess.Spec.HealthCheck.Data.InvocationTimeoutSeconds,
 				TimeoutSeconds:           cfProcess.Spec.HealthCheck.Data.TimeoutSeconds,
+				IntervalSeconds:          cfProcess.Spec.HealthCheck.Data.IntervalSeconds,
+				FailureThreshold:         cfProcess.Spec.HealthCheck.Data.FailureThreshold,
+			},
+		},
+		ReadinessHealthCheck: ReadinessHealthCheck{
+			Type: string(cfProcess.Spec.ReadinessHealthCheck.Type),
+			Data: ReadinessHealthCheckData{
+				HTTPEndpoint:             cfProcess.Spec.ReadinessHealthCheck.Data.HTTPEndpoint,
+				InvocationTimeoutSeconds: cfProcess.Spec.ReadinessHealthCheck.Data.InvocationTimeoutSeconds,
+				IntervalSeconds:          cfProcess.Spec.ReadinessHealthCheck.Data.IntervalSeconds,
 			},
 		},
-		Labels:          cfProcess.Labels,
-		Annotations:     cfProcess.Annotations,
-		CreatedAt:       createdAt,
-		UpdatedAt:       updatedAt,
-		InstancesStatus: cfProcess.Status.InstancesStatus,
+		Labels:            cfProcess.Labels,
+		Annotations:       cfProcess.Annotations,
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
+		InstancesStatus:   cfProcess.Status.InstancesStatus,
+		Autoscaling:       toProcessAutoscaling(cfProcess.Spec.Autoscaling),
+		AutoscalingStatus: cfProcess.Status.Autoscaling,
 	}, nil
 }
+
+func toProcessAutoscaling(autoscaling *korifiv1alpha1.ProcessAutoscaling) *ProcessAutoscaling {
+	if autoscaling == nil {
+		return nil
+	}
+
+	return tools.PtrTo(ProcessAutoscaling(*autoscaling))
+}
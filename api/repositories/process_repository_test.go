@@ -52,6 +52,16 @@ var _ = Describe("ProcessRepo", func() {
 						HTTPEndpoint:             "/healthz",
 						InvocationTimeoutSeconds: 5,
 						TimeoutSeconds:           6,
+						IntervalSeconds:          7,
+						FailureThreshold:         8,
+					},
+				},
+				ReadinessHealthCheck: korifiv1alpha1.ReadinessHealthCheck{
+					Type: "http",
+					Data: korifiv1alpha1.ReadinessHealthCheckData{
+						HTTPEndpoint:             "/ready",
+						InvocationTimeoutSeconds: 2,
+						IntervalSeconds:          3,
 					},
 				},
 				DesiredInstances: tools.PtrTo[int32](1),
@@ -109,6 +119,12 @@ var _ = Describe("ProcessRepo", func() {
 				Expect(processRecord.HealthCheck.Data.InvocationTimeoutSeconds).To(BeEquivalentTo(5))
 				Expect(processRecord.HealthCheck.Data.TimeoutSeconds).To(BeEquivalentTo(6))
 				Expect(processRecord.HealthCheck.Data.HTTPEndpoint).To(Equal("/healthz"))
+				Expect(processRecord.HealthCheck.Data.IntervalSeconds).To(BeEquivalentTo(7))
+				Expect(processRecord.HealthCheck.Data.FailureThreshold).To(BeEquivalentTo(8))
+				Expect(processRecord.ReadinessHealthCheck.Type).To(Equal("http"))
+				Expect(processRecord.ReadinessHealthCheck.Data.HTTPEndpoint).To(Equal("/ready"))
+				Expect(processRecord.ReadinessHealthCheck.Data.InvocationTimeoutSeconds).To(BeEquivalentTo(2))
+				Expect(processRecord.ReadinessHealthCheck.Data.IntervalSeconds).To(BeEquivalentTo(3))
 				Expect(processRecord.InstancesStatus).To(Equal(map[string]korifiv1alpha1.InstanceStatus{
 					"1": {
 						State: korifiv1alpha1.InstanceStateDown,
@@ -132,6 +148,35 @@ var _ = Describe("ProcessRepo", func() {
 					Expect(processRecord.DesiredInstances).To(BeZero())
 				})
 			})
+
+			When("the process is autoscaled", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfProcess, func() {
+						cfProcess.Spec.Autoscaling = &korifiv1alpha1.ProcessAutoscaling{
+							MinInstances:             1,
+							MaxInstances:             3,
+							MemoryUtilizationPercent: tools.PtrTo[int32](60),
+						}
+						cfProcess.Status.Autoscaling = &korifiv1alpha1.ProcessAutoscalingStatus{
+							CurrentInstances: 2,
+							DesiredInstances: 3,
+						}
+					})).To(Succeed())
+				})
+
+				It("returns the autoscaling policy and status on the record", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(processRecord.Autoscaling).To(Equal(&repositories.ProcessAutoscaling{
+						MinInstances:             1,
+						MaxInstances:             3,
+						MemoryUtilizationPercent: tools.PtrTo[int32](60),
+					}))
+					Expect(processRecord.AutoscalingStatus).To(Equal(&korifiv1alpha1.ProcessAutoscalingStatus{
+						CurrentInstances: 2,
+						DesiredInstances: 3,
+					}))
+				})
+			})
 		})
 
 		When("the privileged list call fails", func() {
@@ -345,6 +390,85 @@ var _ = Describe("ProcessRepo", func() {
 		})
 	})
 
+	Describe("SetProcessAutoscaling", func() {
+		var (
+			message       repositories.SetProcessAutoscalingMessage
+			processRecord repositories.ProcessRecord
+			setErr        error
+		)
+
+		BeforeEach(func() {
+			message = repositories.SetProcessAutoscalingMessage{
+				ProcessGUID: cfProcess.Name,
+				SpaceGUID:   space.Name,
+				Autoscaling: &repositories.ProcessAutoscaling{
+					MinInstances:          2,
+					MaxInstances:          6,
+					CPUUtilizationPercent: tools.PtrTo[int32](75),
+					HTTPRequestsPerSecond: tools.PtrTo[int32](100),
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			processRecord, setErr = processRepo.SetProcessAutoscaling(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error to unauthorized users", func() {
+			Expect(setErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user has the SpaceDeveloper role", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("sets the autoscaling policy of the process", func() {
+				Expect(setErr).NotTo(HaveOccurred())
+				Expect(processRecord.Autoscaling).To(Equal(message.Autoscaling))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				Expect(cfProcess.Spec.Autoscaling).To(Equal(&korifiv1alpha1.ProcessAutoscaling{
+					MinInstances:          2,
+					MaxInstances:          6,
+					CPUUtilizationPercent: tools.PtrTo[int32](75),
+					HTTPRequestsPerSecond: tools.PtrTo[int32](100),
+				}))
+			})
+
+			When("the policy is removed", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfProcess, func() {
+						cfProcess.Spec.Autoscaling = &korifiv1alpha1.ProcessAutoscaling{
+							MinInstances: 1,
+							MaxInstances: 3,
+						}
+					})).To(Succeed())
+
+					message.Autoscaling = nil
+				})
+
+				It("removes the autoscaling policy of the process", func() {
+					Expect(setErr).NotTo(HaveOccurred())
+					Expect(processRecord.Autoscaling).To(BeNil())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+					Expect(cfProcess.Spec.Autoscaling).To(BeNil())
+				})
+			})
+
+			When("the process does not exist", func() {
+				BeforeEach(func() {
+					message.ProcessGUID = "i-dont-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(setErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
 	Describe("CreateProcess", func() {
 		var (
 			createErr    error
@@ -368,6 +492,15 @@ var _ = Describe("ProcessRepo", func() {
 						HTTPEndpoint:             "/healthz",
 						InvocationTimeoutSeconds: 20,
 						TimeoutSeconds:           10,
+						IntervalSeconds:          15,
+						FailureThreshold:         3,
+					},
+				},
+				ReadinessHealthCheck: repositories.ReadinessHealthCheck{
+					Type: "port",
+					Data: repositories.ReadinessHealthCheckData{
+						InvocationTimeoutSeconds: 2,
+						IntervalSeconds:          3,
 					},
 				},
 				DesiredInstances: tools.PtrTo[int32](42),
@@ -401,6 +534,15 @@ var _ = Describe("ProcessRepo", func() {
 							HTTPEndpoint:             "/healthz",
 							InvocationTimeoutSeconds: 20,
 							TimeoutSeconds:           10,
+							IntervalSeconds:          15,
+							FailureThreshold:         3,
+						},
+					},
+					ReadinessHealthCheck: korifiv1alpha1.ReadinessHealthCheck{
+						Type: "port",
+						Data: korifiv1alpha1.ReadinessHealthCheckData{
+							InvocationTimeoutSeconds: 2,
+							IntervalSeconds:          3,
 						},
 					},
 					DesiredInstances: tools.PtrTo[int32](42),
@@ -488,16 +630,22 @@ var _ = Describe("ProcessRepo", func() {
 
 		BeforeEach(func() {
 			message = repositories.PatchProcessMessage{
-				ProcessGUID:                         cfProcess.Name,
-				SpaceGUID:                           space.Name,
-				Command:                             tools.PtrTo("start-web"),
-				HealthCheckType:                     tools.PtrTo("http"),
-				HealthCheckHTTPEndpoint:             tools.PtrTo("/healthz"),
-				HealthCheckInvocationTimeoutSeconds: tools.PtrTo(int32(20)),
-				HealthCheckTimeoutSeconds:           tools.PtrTo(int32(10)),
-				DesiredInstances:                    tools.PtrTo[int32](42),
-				MemoryMB:                            tools.PtrTo(int64(456)),
-				DiskQuotaMB:                         tools.PtrTo(int64(123)),
+				ProcessGUID:                                  cfProcess.Name,
+				SpaceGUID:                                    space.Name,
+				Command:                                      tools.PtrTo("start-web"),
+				HealthCheckType:                              tools.PtrTo("http"),
+				HealthCheckHTTPEndpoint:                      tools.PtrTo("/healthz"),
+				HealthCheckInvocationTimeoutSeconds:          tools.PtrTo(int32(20)),
+				HealthCheckTimeoutSeconds:                    tools.PtrTo(int32(10)),
+				HealthCheckIntervalSeconds:                   tools.PtrTo(int32(15)),
+				HealthCheckFailureThreshold:                  tools.PtrTo(int32(3)),
+				ReadinessHealthCheckType:                     tools.PtrTo("http"),
+				ReadinessHealthCheckHTTPEndpoint:             tools.PtrTo("/readyz"),
+				ReadinessHealthCheckInvocationTimeoutSeconds: tools.PtrTo(int32(4)),
+				ReadinessHealthCheckIntervalSeconds:          tools.PtrTo(int32(7)),
+				DesiredInstances:                             tools.PtrTo[int32](42),
+				MemoryMB:                                     tools.PtrTo(int64(456)),
+				DiskQuotaMB:                                  tools.PtrTo(int64(123)),
 				MetadataPatch: &repositories.MetadataPatch{
 					Labels:      map[string]*string{"fool": tools.PtrTo("fool")},
 					Annotations: map[string]*string{"fooa": tools.PtrTo("fooa")},
@@ -531,6 +679,16 @@ var _ = Describe("ProcessRepo", func() {
 							"HTTPEndpoint":             BeEquivalentTo("/healthz"),
 							"InvocationTimeoutSeconds": BeEquivalentTo(20),
 							"TimeoutSeconds":           BeEquivalentTo(10),
+							"IntervalSeconds":          BeEquivalentTo(15),
+							"FailureThreshold":         BeEquivalentTo(3),
+						}),
+					}),
+					"ReadinessHealthCheck": MatchAllFields(Fields{
+						"Type": BeEquivalentTo("http"),
+						"Data": MatchAllFields(Fields{
+							"HTTPEndpoint":             BeEquivalentTo("/readyz"),
+							"InvocationTimeoutSeconds": BeEquivalentTo(4),
+							"IntervalSeconds":          BeEquivalentTo(7),
 						}),
 					}),
 					"DesiredInstances": PointTo(BeEquivalentTo(42)),
@@ -0,0 +1,297 @@
+package repositories
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha1" // #nosec G505 -- resources are identified by their SHA1 in the CF API
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/tools/image"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	ResourceResourceType = "Resource"
+
+	// Fetching a small file from the registry takes longer than uploading
+	// it, so only larger files are cached
+	MinimumCachedResourceSize = 64 * 1024
+	MaximumCachedResourceSize = 512 * 1024 * 1024
+
+	resourceCacheConcurrency = 10
+)
+
+//counterfeiter:generate -o fake -fake-name FileImageClient . FileImageClient
+
+type FileImageClient interface {
+	PushFile(ctx context.Context, creds image.Creds, repoRef, tag string, size int64, open func() (io.ReadCloser, error)) error
+	HasTag(ctx context.Context, creds image.Creds, repoRef, tag string) (bool, error)
+	PullFile(ctx context.Context, creds image.Creds, repoRef, tag string) (io.ReadCloser, error)
+}
+
+type ResourceRecord struct {
+	SHA1        string
+	SizeInBytes int64
+	Path        string
+	Mode        string
+}
+
+// cacheTag returns the tag of the cached image of the resource. As the CF API
+// identifies resources by SHA1 and size, both are part of the tag.
+func (r ResourceRecord) cacheTag() string {
+	return r.SHA1 + "-" + strconv.FormatInt(r.SizeInBytes, 10)
+}
+
+func isCacheable(size int64) bool {
+	return size >= MinimumCachedResourceSize && size <= MaximumCachedResourceSize
+}
+
+// ResourceCacheRepo caches app files as single layer images in a repository
+// of the package registry, so that they do not have to be uploaded again on
+// subsequent pushes
+type ResourceCacheRepo struct {
+	imageClient         FileImageClient
+	repositoryCreator   RepositoryCreator
+	repositoryRef       string
+	pushSecretNames     []string
+	pushSecretNamespace string
+}
+
+func NewResourceCacheRepo(
+	imageClient FileImageClient,
+	repositoryCreator RepositoryCreator,
+	repositoryPrefix string,
+	pushSecretNames []string,
+	pushSecretNamespace string,
+) *ResourceCacheRepo {
+	return &ResourceCacheRepo{
+		imageClient:         imageClient,
+		repositoryCreator:   repositoryCreator,
+		repositoryRef:       repositoryPrefix + "resource-cache",
+		pushSecretNames:     pushSecretNames,
+		pushSecretNamespace: pushSecretNamespace,
+	}
+}
+
+func (r *ResourceCacheRepo) creds() image.Creds {
+	return image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}
+}
+
+// MatchResources returns the resources that are cached
+func (r *ResourceCacheRepo) MatchResources(ctx context.Context, authInfo authorization.Info, resources []ResourceRecord) ([]ResourceRecord, error) {
+	cached := make([]bool, len(resources))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(resourceCacheConcurrency)
+	for i, resource := range resources {
+		if !isCacheable(resource.SizeInBytes) {
+			continue
+		}
+
+		group.Go(func() error {
+			var err error
+			cached[i], err = r.imageClient.HasTag(groupCtx, r.creds(), r.repositoryRef, resource.cacheTag())
+			return err
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, apierrors.NewBlobstoreUnavailableError(fmt.Errorf("failed to match resources: %w", err))
+	}
+
+	matched := []ResourceRecord{}
+	for i, resource := range resources {
+		if cached[i] {
+			matched = append(matched, resource)
+		}
+	}
+
+	return matched, nil
+}
+
+// CacheResources caches the files of the bits zip archive that are not cached
+// yet
+func (r *ResourceCacheRepo) CacheResources(ctx context.Context, bits io.ReaderAt, bitsSize int64) error {
+	zipReader, err := zip.NewReader(bits, bitsSize)
+	if err != nil {
+		return apierrors.NewUnprocessableEntityError(err, "bits must be a zip archive")
+	}
+
+	if err = r.repositoryCreator.CreateRepository(ctx, r.repositoryRef); err != nil {
+		return fmt.Errorf("failed to create resource cache repository: %w", err)
+	}
+
+	// Identical files within the same archive are cached once
+	var seen sync.Map
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(resourceCacheConcurrency)
+	for _, file := range zipReader.File {
+		size := int64(file.UncompressedSize64) // #nosec G115
+		if !file.Mode().IsRegular() || !isCacheable(size) {
+			continue
+		}
+
+		group.Go(func() error {
+			checksum, err := fileSHA1(file)
+			if err != nil {
+				return err
+			}
+
+			resource := ResourceRecord{SHA1: checksum, SizeInBytes: size}
+			if _, loaded := seen.LoadOrStore(resource.cacheTag(), true); loaded {
+				return nil
+			}
+
+			cached, err := r.imageClient.HasTag(groupCtx, r.creds(), r.repositoryRef, resource.cacheTag())
+			if err != nil || cached {
+				return err
+			}
+
+			return r.imageClient.PushFile(groupCtx, r.creds(), r.repositoryRef, resource.cacheTag(), size, func() (io.ReadCloser, error) {
+				return file.Open()
+			})
+		})
+	}
+
+	if err = group.Wait(); err != nil {
+		return fmt.Errorf("failed to cache resources: %w", err)
+	}
+
+	return nil
+}
+
+func fileSHA1(file *zip.File) (string, error) {
+	content, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open %q: %w", file.Name, err)
+	}
+	defer content.Close()
+
+	hash := sha1.New() // #nosec G401
+	if _, err = io.Copy(hash, content); err != nil {
+		return "", fmt.Errorf("failed to read %q: %w", file.Name, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// MergeResources returns a zip archive with the files of the bits archive and
+// the given cached resources. bits is nil when all files of the app are
+// cached. The returned archive is removed when closed.
+func (r *ResourceCacheRepo) MergeResources(ctx context.Context, bits io.ReaderAt, bitsSize int64, resources []ResourceRecord) (io.ReadCloser, error) {
+	mergedFile, err := os.CreateTemp("", "merged-bits-*.zip")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a temp file for the merged bits: %w", err)
+	}
+
+	merged := &removeOnCloseFile{File: mergedFile}
+	if err = r.writeMergedZip(ctx, mergedFile, bits, bitsSize, resources); err != nil {
+		merged.Close()
+		return nil, err
+	}
+
+	if _, err = mergedFile.Seek(0, io.SeekStart); err != nil {
+		merged.Close()
+		return nil, fmt.Errorf("failed to rewind the merged bits: %w", err)
+	}
+
+	return merged, nil
+}
+
+func (r *ResourceCacheRepo) writeMergedZip(ctx context.Context, out io.Writer, bits io.ReaderAt, bitsSize int64, resources []ResourceRecord) error {
+	zipWriter := zip.NewWriter(out)
+
+	if bits != nil {
+		zipReader, err := zip.NewReader(bits, bitsSize)
+		if err != nil {
+			return apierrors.NewUnprocessableEntityError(err, "bits must be a zip archive")
+		}
+
+		for _, file := range zipReader.File {
+			if err = zipWriter.Copy(file); err != nil {
+				return fmt.Errorf("failed to copy %q: %w", file.Name, err)
+			}
+		}
+	}
+
+	for _, resource := range resources {
+		if err := r.writeCachedResource(ctx, zipWriter, resource); err != nil {
+			return err
+		}
+	}
+
+	return zipWriter.Close()
+}
+
+func (r *ResourceCacheRepo) writeCachedResource(ctx context.Context, zipWriter *zip.Writer, resource ResourceRecord) error {
+	notCachedErr := apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("resource %q is not cached", resource.Path))
+	if !isCacheable(resource.SizeInBytes) {
+		return notCachedErr
+	}
+
+	cached, err := r.imageClient.HasTag(ctx, r.creds(), r.repositoryRef, resource.cacheTag())
+	if err != nil {
+		return apierrors.NewBlobstoreUnavailableError(fmt.Errorf("failed to look up resource %q: %w", resource.Path, err))
+	}
+
+	if !cached {
+		return notCachedErr
+	}
+
+	content, err := r.imageClient.PullFile(ctx, r.creds(), r.repositoryRef, resource.cacheTag())
+	if err != nil {
+		return apierrors.NewBlobstoreUnavailableError(fmt.Errorf("failed to fetch resource %q: %w", resource.Path, err))
+	}
+	defer content.Close()
+
+	header := &zip.FileHeader{
+		Name:   resource.Path,
+		Method: zip.Deflate,
+	}
+	header.SetMode(resourceFileMode(resource.Mode))
+
+	entry, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return fmt.Errorf("failed to add resource %q: %w", resource.Path, err)
+	}
+
+	if _, err = io.Copy(entry, content); err != nil {
+		return fmt.Errorf("failed to write resource %q: %w", resource.Path, err)
+	}
+
+	return nil
+}
+
+// resourceFileMode parses the octal mode of a resource, defaulting to 0644
+func resourceFileMode(mode string) os.FileMode {
+	parsed, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil {
+		return 0o644
+	}
+
+	return os.FileMode(parsed).Perm()
+}
+
+type removeOnCloseFile struct {
+	*os.File
+}
+
+func (f *removeOnCloseFile) Close() error {
+	closeErr := f.File.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		return err
+	}
+
+	return closeErr
+}
@@ -0,0 +1,322 @@
+package repositories_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha1" // #nosec G505
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	"code.cloudfoundry.org/korifi/tools/image"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceCacheRepo", func() {
+	var (
+		imageClient       *fake.FileImageClient
+		repositoryCreator *fake.RepositoryCreator
+		resourceCacheRepo *repositories.ResourceCacheRepo
+
+		largeContent string
+		largeSHA1    string
+		largeSize    int64
+	)
+
+	buildZip := func(files map[string]string) []byte {
+		GinkgoHelper()
+
+		var buf bytes.Buffer
+		zipWriter := zip.NewWriter(&buf)
+		for name, content := range files {
+			entry, err := zipWriter.Create(name)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = entry.Write([]byte(content))
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(zipWriter.Close()).To(Succeed())
+
+		return buf.Bytes()
+	}
+
+	BeforeEach(func() {
+		imageClient = new(fake.FileImageClient)
+		repositoryCreator = new(fake.RepositoryCreator)
+		resourceCacheRepo = repositories.NewResourceCacheRepo(
+			imageClient,
+			repositoryCreator,
+			"my-prefix/",
+			[]string{"push-secret-name"},
+			rootNamespace,
+		)
+
+		largeContent = strings.Repeat("a", repositories.MinimumCachedResourceSize)
+		checksum := sha1.Sum([]byte(largeContent)) // #nosec G401
+		largeSHA1 = hex.EncodeToString(checksum[:])
+		largeSize = int64(len(largeContent))
+	})
+
+	Describe("MatchResources", func() {
+		var (
+			resources []repositories.ResourceRecord
+			matched   []repositories.ResourceRecord
+			matchErr  error
+		)
+
+		BeforeEach(func() {
+			resources = []repositories.ResourceRecord{
+				{SHA1: "cached-sha", SizeInBytes: repositories.MinimumCachedResourceSize, Path: "cached"},
+				{SHA1: "uncached-sha", SizeInBytes: repositories.MinimumCachedResourceSize, Path: "uncached"},
+				{SHA1: "small-sha", SizeInBytes: 10, Path: "small"},
+			}
+
+			imageClient.HasTagStub = func(_ context.Context, _ image.Creds, _, tag string) (bool, error) {
+				return strings.HasPrefix(tag, "cached-sha-"), nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			matched, matchErr = resourceCacheRepo.MatchResources(ctx, authInfo, resources)
+		})
+
+		It("returns the cached resources", func() {
+			Expect(matchErr).NotTo(HaveOccurred())
+			Expect(matched).To(ConsistOf(resources[0]))
+		})
+
+		It("looks up the resources in the cache repository", func() {
+			Expect(imageClient.HasTagCallCount()).To(Equal(2))
+			_, creds, repoRef, _ := imageClient.HasTagArgsForCall(0)
+			Expect(creds.Namespace).To(Equal(rootNamespace))
+			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+			Expect(repoRef).To(Equal("my-prefix/resource-cache"))
+		})
+
+		When("nothing is cached", func() {
+			BeforeEach(func() {
+				imageClient.HasTagStub = nil
+				imageClient.HasTagReturns(false, nil)
+			})
+
+			It("returns an empty list", func() {
+				Expect(matchErr).NotTo(HaveOccurred())
+				Expect(matched).To(BeEmpty())
+			})
+		})
+
+		When("the registry is unavailable", func() {
+			BeforeEach(func() {
+				imageClient.HasTagStub = nil
+				imageClient.HasTagReturns(false, errors.New("boom"))
+			})
+
+			It("returns a blobstore unavailable error", func() {
+				Expect(matchErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+			})
+		})
+	})
+
+	Describe("CacheResources", func() {
+		var (
+			bits     []byte
+			cacheErr error
+		)
+
+		BeforeEach(func() {
+			bits = buildZip(map[string]string{
+				"large":      largeContent,
+				"large-copy": largeContent,
+				"small":      "small",
+			})
+		})
+
+		JustBeforeEach(func() {
+			cacheErr = resourceCacheRepo.CacheResources(ctx, bytes.NewReader(bits), int64(len(bits)))
+		})
+
+		It("creates the cache repository", func() {
+			Expect(cacheErr).NotTo(HaveOccurred())
+			Expect(repositoryCreator.CreateRepositoryCallCount()).To(Equal(1))
+			_, repoRef := repositoryCreator.CreateRepositoryArgsForCall(0)
+			Expect(repoRef).To(Equal("my-prefix/resource-cache"))
+		})
+
+		It("pushes each large file once", func() {
+			Expect(cacheErr).NotTo(HaveOccurred())
+			Expect(imageClient.PushFileCallCount()).To(Equal(1))
+
+			_, _, repoRef, tag, size, open := imageClient.PushFileArgsForCall(0)
+			Expect(repoRef).To(Equal("my-prefix/resource-cache"))
+			Expect(tag).To(Equal(largeSHA1 + "-65536"))
+			Expect(size).To(Equal(largeSize))
+
+			content, err := open()
+			Expect(err).NotTo(HaveOccurred())
+			defer content.Close()
+			actualContent, err := io.ReadAll(content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(actualContent)).To(Equal(largeContent))
+		})
+
+		When("the file is already cached", func() {
+			BeforeEach(func() {
+				imageClient.HasTagReturns(true, nil)
+			})
+
+			It("does not push it again", func() {
+				Expect(cacheErr).NotTo(HaveOccurred())
+				Expect(imageClient.PushFileCallCount()).To(BeZero())
+			})
+		})
+
+		When("the bits are not a zip archive", func() {
+			BeforeEach(func() {
+				bits = []byte("not-a-zip")
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(cacheErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+
+		When("pushing the file fails", func() {
+			BeforeEach(func() {
+				imageClient.PushFileReturns(errors.New("push-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(cacheErr).To(MatchError(ContainSubstring("push-err")))
+			})
+		})
+	})
+
+	Describe("MergeResources", func() {
+		var (
+			bits      io.ReaderAt
+			bitsSize  int64
+			resources []repositories.ResourceRecord
+			merged    io.ReadCloser
+			mergeErr  error
+		)
+
+		readMerged := func() map[string]string {
+			GinkgoHelper()
+
+			content, err := io.ReadAll(merged)
+			Expect(err).NotTo(HaveOccurred())
+
+			zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+			Expect(err).NotTo(HaveOccurred())
+
+			files := map[string]string{}
+			for _, file := range zipReader.File {
+				fileContent, err := file.Open()
+				Expect(err).NotTo(HaveOccurred())
+				fileBytes, err := io.ReadAll(fileContent)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fileContent.Close()).To(Succeed())
+				files[file.Name] = string(fileBytes)
+			}
+
+			return files
+		}
+
+		BeforeEach(func() {
+			uploaded := buildZip(map[string]string{"uploaded": "uploaded-content"})
+			bits = bytes.NewReader(uploaded)
+			bitsSize = int64(len(uploaded))
+
+			resources = []repositories.ResourceRecord{{
+				SHA1:        largeSHA1,
+				SizeInBytes: largeSize,
+				Path:        "cached/file",
+				Mode:        "755",
+			}}
+
+			imageClient.HasTagReturns(true, nil)
+
+			imageClient.PullFileStub = func(context.Context, image.Creds, string, string) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(largeContent)), nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			merged, mergeErr = resourceCacheRepo.MergeResources(ctx, bits, bitsSize, resources)
+		})
+
+		AfterEach(func() {
+			if merged != nil {
+				Expect(merged.Close()).To(Succeed())
+			}
+		})
+
+		It("merges the cached resources into the bits", func() {
+			Expect(mergeErr).NotTo(HaveOccurred())
+			Expect(readMerged()).To(Equal(map[string]string{
+				"uploaded":    "uploaded-content",
+				"cached/file": largeContent,
+			}))
+		})
+
+		It("pulls the resources from the cache repository", func() {
+			Expect(imageClient.PullFileCallCount()).To(Equal(1))
+			_, _, repoRef, tag := imageClient.PullFileArgsForCall(0)
+			Expect(repoRef).To(Equal("my-prefix/resource-cache"))
+			Expect(tag).To(Equal(largeSHA1 + "-65536"))
+		})
+
+		When("there are no bits", func() {
+			BeforeEach(func() {
+				bits = nil
+				bitsSize = 0
+			})
+
+			It("builds an archive of the cached resources", func() {
+				Expect(mergeErr).NotTo(HaveOccurred())
+				Expect(readMerged()).To(Equal(map[string]string{
+					"cached/file": largeContent,
+				}))
+			})
+		})
+
+		When("a resource is not cached", func() {
+			BeforeEach(func() {
+				imageClient.HasTagReturns(false, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(mergeErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				Expect(mergeErr.(apierrors.UnprocessableEntityError).Detail()).To(ContainSubstring(`resource "cached/file" is not cached`))
+			})
+		})
+
+		When("a resource is too small to be cached", func() {
+			BeforeEach(func() {
+				resources[0].SizeInBytes = 10
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(mergeErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				Expect(imageClient.HasTagCallCount()).To(BeZero())
+			})
+		})
+
+		When("pulling a resource fails", func() {
+			BeforeEach(func() {
+				imageClient.PullFileStub = nil
+				imageClient.PullFileReturns(nil, errors.New("pull-err"))
+			})
+
+			It("returns a blobstore unavailable error", func() {
+				Expect(mergeErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+			})
+		})
+	})
+})
@@ -0,0 +1,238 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const RevisionResourceType = "Revision"
+
+type RevisionRepo struct {
+	klient Klient
+}
+
+func NewRevisionRepo(klient Klient) *RevisionRepo {
+	return &RevisionRepo{
+		klient: klient,
+	}
+}
+
+type RevisionRecord struct {
+	GUID        string
+	AppGUID     string
+	SpaceGUID   string
+	Version     int64
+	DropletGUID string
+	Processes   map[string]string
+	Sidecars    []RevisionSidecarRecord
+	Description string
+	Deployable  bool
+	Labels      map[string]string
+	Annotations map[string]string
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+}
+
+type RevisionSidecarRecord struct {
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     int64
+}
+
+func (r RevisionRecord) Relationships() map[string]string {
+	return map[string]string{
+		"app": r.AppGUID,
+	}
+}
+
+type RevisionEnvVarsRecord struct {
+	RevisionGUID         string
+	EnvironmentVariables map[string]string
+}
+
+type ListRevisionsMessage struct {
+	AppGUIDs   []string
+	Versions   []string
+	OrderBy    string
+	Pagination Pagination
+}
+
+func (m *ListRevisionsMessage) toListOptions() []ListOption {
+	return []ListOption{
+		WithLabelIn(korifiv1alpha1.CFAppGUIDLabelKey, m.AppGUIDs),
+		WithLabelIn(korifiv1alpha1.CFRevisionVersionLabelKey, m.Versions),
+		WithOrdering(m.OrderBy),
+		WithPaging(m.Pagination),
+	}
+}
+
+func (r *RevisionRepo) GetRevision(ctx context.Context, authInfo authorization.Info, revisionGUID string) (RevisionRecord, error) {
+	revision, err := r.getCFRevision(ctx, revisionGUID)
+	if err != nil {
+		return RevisionRecord{}, err
+	}
+
+	dropletGUIDs, err := r.stagedDropletGUIDs(ctx, revision.Spec.AppRef.Name)
+	if err != nil {
+		return RevisionRecord{}, err
+	}
+
+	return toRevisionRecord(*revision, dropletGUIDs), nil
+}
+
+func (r *RevisionRepo) ListRevisions(ctx context.Context, authInfo authorization.Info, message ListRevisionsMessage) (ListResult[RevisionRecord], error) {
+	revisionList := &korifiv1alpha1.CFRevisionList{}
+	pageInfo, err := r.klient.List(ctx, revisionList, message.toListOptions()...)
+	if err != nil {
+		return ListResult[RevisionRecord]{}, fmt.Errorf("failed to list revisions: %w", apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	appGUIDs := tools.Uniq(slices.Collect(it.Map(slices.Values(revisionList.Items), func(revision korifiv1alpha1.CFRevision) string {
+		return revision.Spec.AppRef.Name
+	})))
+
+	dropletGUIDs, err := r.stagedDropletGUIDs(ctx, appGUIDs...)
+	if err != nil {
+		return ListResult[RevisionRecord]{}, err
+	}
+
+	return ListResult[RevisionRecord]{
+		PageInfo: pageInfo,
+		Records: slices.Collect(it.Map(slices.Values(revisionList.Items), func(revision korifiv1alpha1.CFRevision) RevisionRecord {
+			return toRevisionRecord(revision, dropletGUIDs)
+		})),
+	}, nil
+}
+
+func (r *RevisionRepo) ListDeployedRevisions(ctx context.Context, authInfo authorization.Info, appGUID string) (ListResult[RevisionRecord], error) {
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: appGUID,
+		},
+	}
+	err := r.klient.Get(ctx, cfApp)
+	if err != nil {
+		return ListResult[RevisionRecord]{}, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	records := []RevisionRecord{}
+	if cfApp.Spec.DesiredState == korifiv1alpha1.StartedState && cfApp.Status.CurrentRevisionRef.Name != "" {
+		record, err := r.GetRevision(ctx, authInfo, cfApp.Status.CurrentRevisionRef.Name)
+		if err != nil {
+			return ListResult[RevisionRecord]{}, fmt.Errorf("failed to get deployed revision: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	return ListResult[RevisionRecord]{
+		PageInfo: descriptors.SinglePageInfo(len(records), len(records)),
+		Records:  records,
+	}, nil
+}
+
+func (r *RevisionRepo) GetRevisionEnvironmentVariables(ctx context.Context, authInfo authorization.Info, revisionGUID string) (RevisionEnvVarsRecord, error) {
+	revision, err := r.getCFRevision(ctx, revisionGUID)
+	if err != nil {
+		return RevisionEnvVarsRecord{}, err
+	}
+
+	envSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: revision.Namespace,
+			Name:      revision.Spec.EnvSecretName,
+		},
+	}
+	err = r.klient.Get(ctx, envSecret)
+	if err != nil {
+		return RevisionEnvVarsRecord{}, fmt.Errorf("error finding environment variable Secret %q for Revision %q: %w",
+			revision.Spec.EnvSecretName,
+			revision.Name,
+			apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	return RevisionEnvVarsRecord{
+		RevisionGUID:         revision.Name,
+		EnvironmentVariables: convertByteSliceValuesToStrings(envSecret.Data),
+	}, nil
+}
+
+func (r *RevisionRepo) getCFRevision(ctx context.Context, revisionGUID string) (*korifiv1alpha1.CFRevision, error) {
+	revision := &korifiv1alpha1.CFRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: revisionGUID,
+		},
+	}
+	err := r.klient.Get(ctx, revision)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, RevisionResourceType)
+	}
+
+	return revision, nil
+}
+
+// stagedDropletGUIDs returns the staged droplets of the apps. A revision is
+// only deployable while its droplet is still around.
+func (r *RevisionRepo) stagedDropletGUIDs(ctx context.Context, appGUIDs ...string) (map[string]bool, error) {
+	if len(appGUIDs) == 0 {
+		return map[string]bool{}, nil
+	}
+
+	buildList := &korifiv1alpha1.CFBuildList{}
+	_, err := r.klient.List(ctx, buildList,
+		WithLabelIn(korifiv1alpha1.CFAppGUIDLabelKey, appGUIDs),
+		WithLabel(korifiv1alpha1.CFBuildStateLabelKey, korifiv1alpha1.BuildStateStaged),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list droplets: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	dropletGUIDs := map[string]bool{}
+	for _, build := range buildList.Items {
+		dropletGUIDs[build.Name] = true
+	}
+
+	return dropletGUIDs, nil
+}
+
+func toRevisionRecord(revision korifiv1alpha1.CFRevision, stagedDropletGUIDs map[string]bool) RevisionRecord {
+	processes := map[string]string{}
+	for _, process := range revision.Spec.Processes {
+		processes[process.Type] = process.Command
+	}
+
+	return RevisionRecord{
+		GUID:        revision.Name,
+		AppGUID:     revision.Spec.AppRef.Name,
+		SpaceGUID:   revision.Namespace,
+		Version:     revision.Spec.Version,
+		DropletGUID: revision.Spec.DropletRef.Name,
+		Processes:   processes,
+		Sidecars: slices.Collect(it.Map(slices.Values(revision.Spec.Sidecars), func(s korifiv1alpha1.RevisionSidecar) RevisionSidecarRecord {
+			return RevisionSidecarRecord{
+				Name:         s.Name,
+				Command:      s.Command,
+				ProcessTypes: s.ProcessTypes,
+				MemoryMB:     s.MemoryMB,
+			}
+		})),
+		Description: revision.Spec.Description,
+		Deployable:  stagedDropletGUIDs[revision.Spec.DropletRef.Name],
+		Labels:      revision.Labels,
+		Annotations: revision.Annotations,
+		CreatedAt:   revision.CreationTimestamp.Time,
+		UpdatedAt:   getLastUpdatedTime(&revision),
+	}
+}
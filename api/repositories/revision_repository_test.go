@@ -0,0 +1,270 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RevisionRepository", func() {
+	var (
+		revisionRepo *repositories.RevisionRepo
+		space        *korifiv1alpha1.CFSpace
+		cfApp        *korifiv1alpha1.CFApp
+		revision1    *korifiv1alpha1.CFRevision
+		revision2    *korifiv1alpha1.CFRevision
+	)
+
+	createRevision := func(version int64, dropletGUID string) *korifiv1alpha1.CFRevision {
+		GinkgoHelper()
+
+		revision := &korifiv1alpha1.CFRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: space.Name,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFRevisionSpec{
+				AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+				Version:       version,
+				DropletRef:    corev1.LocalObjectReference{Name: dropletGUID},
+				EnvSecretName: uuid.NewString(),
+				Processes: []korifiv1alpha1.RevisionProcess{
+					{Type: "web", Command: "my-command"},
+					{Type: "worker"},
+				},
+				Sidecars: []korifiv1alpha1.RevisionSidecar{
+					{Name: "my-sidecar", Command: "sidecar-command", ProcessTypes: []string{"web"}, MemoryMB: 64},
+				},
+				Description: "Initial revision.",
+			},
+		}
+		Expect(k8sClient.Create(ctx, revision)).To(Succeed())
+
+		return revision
+	}
+
+	BeforeEach(func() {
+		revisionRepo = repositories.NewRevisionRepo(spaceScopedKlient)
+
+		org := createOrgWithCleanup(ctx, uuid.NewString())
+		space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+		cfApp = createApp(space.Name)
+
+		build := &korifiv1alpha1.CFBuild{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: space.Name,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFBuildSpec{
+				AppRef:     corev1.LocalObjectReference{Name: cfApp.Name},
+				PackageRef: corev1.LocalObjectReference{Name: uuid.NewString()},
+				Lifecycle:  korifiv1alpha1.Lifecycle{Type: "buildpack"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, build)).To(Succeed())
+		Expect(k8s.Patch(ctx, k8sClient, build, func() {
+			build.Status.State = korifiv1alpha1.BuildStateStaged
+		})).To(Succeed())
+
+		revision1 = createRevision(1, uuid.NewString())
+		revision2 = createRevision(2, build.Name)
+	})
+
+	Describe("GetRevision", func() {
+		var (
+			revisionGUID string
+			record       repositories.RevisionRecord
+			getErr       error
+		)
+
+		BeforeEach(func() {
+			revisionGUID = revision2.Name
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = revisionRepo.GetRevision(ctx, authInfo, revisionGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the revision", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(revision2.Name))
+				Expect(record.AppGUID).To(Equal(cfApp.Name))
+				Expect(record.SpaceGUID).To(Equal(space.Name))
+				Expect(record.Version).To(BeEquivalentTo(2))
+				Expect(record.DropletGUID).To(Equal(revision2.Spec.DropletRef.Name))
+				Expect(record.Processes).To(Equal(map[string]string{
+					"web":    "my-command",
+					"worker": "",
+				}))
+				Expect(record.Sidecars).To(ConsistOf(repositories.RevisionSidecarRecord{
+					Name:         "my-sidecar",
+					Command:      "sidecar-command",
+					ProcessTypes: []string{"web"},
+					MemoryMB:     64,
+				}))
+				Expect(record.Description).To(Equal("Initial revision."))
+				Expect(record.Relationships()).To(Equal(map[string]string{"app": cfApp.Name}))
+			})
+
+			It("is deployable as its droplet is staged", func() {
+				Expect(record.Deployable).To(BeTrue())
+			})
+
+			When("the droplet of the revision does not exist", func() {
+				BeforeEach(func() {
+					revisionGUID = revision1.Name
+				})
+
+				It("is not deployable", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(record.Deployable).To(BeFalse())
+				})
+			})
+
+			When("the revision does not exist", func() {
+				BeforeEach(func() {
+					revisionGUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListRevisions", func() {
+		var (
+			message    repositories.ListRevisionsMessage
+			listResult repositories.ListResult[repositories.RevisionRecord]
+			listErr    error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			message = repositories.ListRevisionsMessage{
+				AppGUIDs: []string{cfApp.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			listResult, listErr = revisionRepo.ListRevisions(ctx, authInfo, message)
+		})
+
+		It("lists the app revisions", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(revision1.Name), "Deployable": BeFalse()}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(revision2.Name), "Deployable": BeTrue()}),
+			))
+		})
+
+		When("filtering by version", func() {
+			BeforeEach(func() {
+				message.Versions = []string{"2"}
+			})
+
+			It("returns the revisions with matching versions", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(revision2.Name)}),
+				))
+			})
+		})
+
+		When("filtering by another app", func() {
+			BeforeEach(func() {
+				message.AppGUIDs = []string{"another-app"}
+			})
+
+			It("returns an empty list", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ListDeployedRevisions", func() {
+		var (
+			listResult repositories.ListResult[repositories.RevisionRecord]
+			listErr    error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+		})
+
+		JustBeforeEach(func() {
+			listResult, listErr = revisionRepo.ListDeployedRevisions(ctx, authInfo, cfApp.Name)
+		})
+
+		It("returns an empty list for stopped apps", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(BeEmpty())
+		})
+
+		When("the app is started", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+					cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				})).To(Succeed())
+				Expect(k8s.Patch(ctx, k8sClient, cfApp, func() {
+					cfApp.Status.CurrentRevisionRef = corev1.LocalObjectReference{Name: revision2.Name}
+				})).To(Succeed())
+			})
+
+			It("returns the current revision of the app", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(revision2.Name)}),
+				))
+				Expect(listResult.PageInfo.TotalResults).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("GetRevisionEnvironmentVariables", func() {
+		var (
+			envVars repositories.RevisionEnvVarsRecord
+			getErr  error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: space.Name,
+					Name:      revision2.Spec.EnvSecretName,
+				},
+				StringData: map[string]string{"FOO": "bar"},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			envVars, getErr = revisionRepo.GetRevisionEnvironmentVariables(ctx, authInfo, revision2.Name)
+		})
+
+		It("returns the environment variables snapshot of the revision", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(envVars.RevisionGUID).To(Equal(revision2.Name))
+			Expect(envVars.EnvironmentVariables).To(Equal(map[string]string{"FOO": "bar"}))
+		})
+	})
+})
@@ -18,6 +18,9 @@ import (
 	"github.com/google/uuid"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -25,14 +28,16 @@ const (
 )
 
 type RouteRepo struct {
-	klient     Klient
-	domainRepo *DomainRepo
+	klient           Klient
+	domainRepo       *DomainRepo
+	privilegedClient client.Client
 }
 
-func NewRouteRepo(klient Klient, domainRepo *DomainRepo) *RouteRepo {
+func NewRouteRepo(klient Klient, domainRepo *DomainRepo, privilegedClient client.Client) *RouteRepo {
 	return &RouteRepo{
-		klient:     klient,
-		domainRepo: domainRepo,
+		klient:           klient,
+		domainRepo:       domainRepo,
+		privilegedClient: privilegedClient,
 	}
 }
 
@@ -51,6 +56,7 @@ type RouteRecord struct {
 	Domain       DomainRecord
 	Host         string
 	Path         string
+	Port         int32
 	Protocol     string
 	Destinations []DestinationRecord
 	Labels       map[string]string
@@ -104,6 +110,7 @@ type ListRoutesMessage struct {
 	DomainGUIDs []string
 	Hosts       []string
 	Paths       []string
+	Ports       []int32
 	IsUnmapped  *bool
 	OrderBy     string
 	Pagination  Pagination
@@ -115,6 +122,9 @@ func (m *ListRoutesMessage) toListOptions() []ListOption {
 		WithLabelIn(korifiv1alpha1.SpaceGUIDLabelKey, m.SpaceGUIDs),
 		WithLabelIn(korifiv1alpha1.CFRouteHostLabelKey, m.Hosts),
 		WithLabelIn(korifiv1alpha1.CFRoutePathLabelKey, tools.EncodeValuesToSha224(m.Paths...)),
+		WithLabelIn(korifiv1alpha1.CFRoutePortLabelKey, slices.Collect(it.Map(slices.Values(m.Ports), func(port int32) string {
+			return strconv.FormatInt(int64(port), 10)
+		}))),
 		WithPaging(m.Pagination),
 		WithOrdering(m.OrderBy),
 	}
@@ -133,6 +143,8 @@ func (m *ListRoutesMessage) toListOptions() []ListOption {
 type CreateRouteMessage struct {
 	Host            string
 	Path            string
+	Port            int32
+	Protocol        string
 	SpaceGUID       string
 	DomainGUID      string
 	DomainName      string
@@ -157,7 +169,8 @@ func (m CreateRouteMessage) toCFRoute() korifiv1alpha1.CFRoute {
 		Spec: korifiv1alpha1.CFRouteSpec{
 			Host:     m.Host,
 			Path:     m.Path,
-			Protocol: "http",
+			Port:     m.Port,
+			Protocol: korifiv1alpha1.Protocol(tools.IfZero(m.Protocol, string(korifiv1alpha1.RouteProtocolHTTP))),
 			DomainRef: v1.ObjectReference{
 				Name:      m.DomainGUID,
 				Namespace: m.DomainNamespace,
@@ -200,6 +213,44 @@ func (r *RouteRepo) ListRoutes(ctx context.Context, authInfo authorization.Info,
 	}, nil
 }
 
+// ListRouterGroupPorts returns the ports of all the routes on the domains of
+// the router group. All TCP routes on a port share the same gateway listener,
+// so ports are unique per router group across all domains and spaces, and the
+// routes are listed with the privileged client rather than only those visible
+// to the user
+func (r *RouteRepo) ListRouterGroupPorts(ctx context.Context, routerGroup string) ([]int32, error) {
+	cfDomainList := &korifiv1alpha1.CFDomainList{}
+	err := r.privilegedClient.List(ctx, cfDomainList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", apierrors.FromK8sError(err, DomainResourceType))
+	}
+
+	domainGUIDs := []string{}
+	for _, cfDomain := range cfDomainList.Items {
+		if cfDomain.Spec.RouterGroup == routerGroup {
+			domainGUIDs = append(domainGUIDs, cfDomain.Name)
+		}
+	}
+	if len(domainGUIDs) == 0 {
+		return []int32{}, nil
+	}
+
+	domainsRequirement, err := labels.NewRequirement(korifiv1alpha1.CFDomainGUIDLabelKey, selection.In, domainGUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build domains selector: %w", err)
+	}
+
+	cfRouteList := &korifiv1alpha1.CFRouteList{}
+	err = r.privilegedClient.List(ctx, cfRouteList, client.MatchingLabelsSelector{Selector: labels.NewSelector().Add(*domainsRequirement)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routes: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return slices.Collect(it.Map(slices.Values(cfRouteList.Items), func(cfRoute korifiv1alpha1.CFRoute) int32 {
+		return cfRoute.Spec.Port
+	})), nil
+}
+
 func (r *RouteRepo) cfRouteToRouteRecord(ctx context.Context, authInfo authorization.Info, cfRoute korifiv1alpha1.CFRoute) (RouteRecord, error) {
 	domainRecord, err := r.domainRepo.GetDomain(ctx, authInfo, cfRoute.Spec.DomainRef.Name)
 	if err != nil {
@@ -212,7 +263,8 @@ func (r *RouteRepo) cfRouteToRouteRecord(ctx context.Context, authInfo authoriza
 		Domain:       domainRecord,
 		Host:         cfRoute.Spec.Host,
 		Path:         cfRoute.Spec.Path,
-		Protocol:     "http", // TODO: Create a mutating webhook to set this default on the CFRoute
+		Port:         cfRoute.Spec.Port,
+		Protocol:     tools.IfZero(string(cfRoute.Spec.Protocol), string(korifiv1alpha1.RouteProtocolHTTP)),
 		Destinations: cfRouteDestinationsToDestinationRecords(cfRoute),
 		CreatedAt:    cfRoute.CreationTimestamp.Time,
 		UpdatedAt:    getLastUpdatedTime(&cfRoute),
@@ -43,9 +43,9 @@ var _ = Describe("RouteRepository", func() {
 		routeGUID = prefixedGUID("route1")
 		domainGUID = prefixedGUID("domain")
 
-		domainRepo = repositories.NewDomainRepo(spaceScopedKlient, rootNamespace)
+		domainRepo = repositories.NewDomainRepo(spaceScopedKlient, k8sClient, userClientFactory, nsPerms, rootNamespace)
 
-		routeRepo = repositories.NewRouteRepo(spaceScopedKlient, domainRepo)
+		routeRepo = repositories.NewRouteRepo(spaceScopedKlient, domainRepo, k8sClient)
 
 		cfDomain = &korifiv1alpha1.CFDomain{
 			ObjectMeta: metav1.ObjectMeta{
@@ -316,7 +316,7 @@ var _ = Describe("RouteRepository", func() {
 
 			BeforeEach(func() {
 				fakeKlient = new(fake.Klient)
-				routeRepo = repositories.NewRouteRepo(fakeKlient, domainRepo)
+				routeRepo = repositories.NewRouteRepo(fakeKlient, domainRepo, k8sClient)
 
 				message = repositories.ListRoutesMessage{
 					AppGUIDs:    []string{appGUID},
@@ -324,6 +324,7 @@ var _ = Describe("RouteRepository", func() {
 					DomainGUIDs: []string{"domainGUID"},
 					Hosts:       []string{"my-subdomain-1-a"},
 					Paths:       []string{"/some/path"},
+					Ports:       []int32{1234},
 					IsUnmapped:  tools.PtrTo(false),
 					OrderBy:     "created_at",
 					Pagination: repositories.Pagination{
@@ -340,6 +341,7 @@ var _ = Describe("RouteRepository", func() {
 					repositories.WithLabelIn(korifiv1alpha1.CFDomainGUIDLabelKey, []string{"domainGUID"}),
 					repositories.WithLabelIn(korifiv1alpha1.CFRouteHostLabelKey, []string{"my-subdomain-1-a"}),
 					repositories.WithLabelIn(korifiv1alpha1.CFRoutePathLabelKey, tools.EncodeValuesToSha224("/some/path")),
+					repositories.WithLabelIn(korifiv1alpha1.CFRoutePortLabelKey, []string{"1234"}),
 					repositories.WithLabelIn(korifiv1alpha1.SpaceGUIDLabelKey, []string{"sg1", "sg2"}),
 					repositories.WithLabel(korifiv1alpha1.CFRouteIsUnmappedLabelKey, "false"),
 					repositories.WithOrdering("created_at"),
@@ -356,12 +358,96 @@ var _ = Describe("RouteRepository", func() {
 		})
 	})
 
+	Describe("ListRouterGroupPorts", func() {
+		var (
+			otherSpace       *korifiv1alpha1.CFSpace
+			sameGroupDomain  *korifiv1alpha1.CFDomain
+			otherGroupDomain *korifiv1alpha1.CFDomain
+			routerGroup      string
+			ports            []int32
+		)
+
+		createDomain := func(routerGroup string) *korifiv1alpha1.CFDomain {
+			domain := &korifiv1alpha1.CFDomain{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+				},
+				Spec: korifiv1alpha1.CFDomainSpec{
+					Name:        uuid.NewString() + ".tcp.example.com",
+					RouterGroup: routerGroup,
+				},
+			}
+			Expect(k8sClient.Create(ctx, domain)).To(Succeed())
+			return domain
+		}
+
+		BeforeEach(func() {
+			routerGroup = uuid.NewString()
+			otherSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space2"))
+
+			Expect(k8s.PatchResource(ctx, k8sClient, cfDomain, func() {
+				cfDomain.Spec.RouterGroup = routerGroup
+			})).To(Succeed())
+			sameGroupDomain = createDomain(routerGroup)
+			otherGroupDomain = createDomain(uuid.NewString())
+
+			for _, route := range []struct {
+				namespace  string
+				domainGUID string
+				port       int32
+			}{
+				{namespace: space.Name, domainGUID: cfDomain.Name, port: 1024},
+				{namespace: otherSpace.Name, domainGUID: cfDomain.Name, port: 1025},
+				{namespace: space.Name, domainGUID: sameGroupDomain.Name, port: 1026},
+				{namespace: space.Name, domainGUID: otherGroupDomain.Name, port: 1027},
+			} {
+				Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: route.namespace,
+					},
+					Spec: korifiv1alpha1.CFRouteSpec{
+						Protocol: korifiv1alpha1.RouteProtocolTCP,
+						Port:     route.port,
+						DomainRef: corev1.ObjectReference{
+							Name:      route.domainGUID,
+							Namespace: rootNamespace,
+						},
+					},
+				})).To(Succeed())
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			ports, err = routeRepo.ListRouterGroupPorts(ctx, routerGroup)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the ports of the routes on all router group domains in all spaces, regardless of the user permissions", func() {
+			Expect(ports).To(ConsistOf(int32(1024), int32(1025), int32(1026)))
+		})
+
+		When("no domain belongs to the router group", func() {
+			BeforeEach(func() {
+				routerGroup = "unknown"
+			})
+
+			It("returns no ports", func() {
+				Expect(ports).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("CreateRoute", func() {
 		var (
 			createdRouteRecord repositories.RouteRecord
 			createdRouteErr    error
 			routeHost          string
 			routePath          string
+			routePort          int32
+			routeProtocol      string
 			routeNamespace     string
 		)
 
@@ -369,6 +455,8 @@ var _ = Describe("RouteRepository", func() {
 			routeNamespace = space.Name
 			routeHost = prefixedGUID("route-host-")
 			routePath = prefixedGUID("/test/route/")
+			routePort = 0
+			routeProtocol = ""
 			createdRouteRecord = repositories.RouteRecord{}
 			createdRouteErr = nil
 		})
@@ -377,6 +465,8 @@ var _ = Describe("RouteRepository", func() {
 			createdRouteRecord, createdRouteErr = routeRepo.CreateRoute(ctx, authInfo, repositories.CreateRouteMessage{
 				Host:            routeHost,
 				Path:            routePath,
+				Port:            routePort,
+				Protocol:        routeProtocol,
 				SpaceGUID:       routeNamespace,
 				DomainGUID:      domainGUID,
 				DomainNamespace: rootNamespace,
@@ -417,6 +507,32 @@ var _ = Describe("RouteRepository", func() {
 				Expect(createdRouteRecord.SpaceGUID).To(Equal(space.Name), "Route Space GUID in record did not match input")
 
 				Expect(createdRouteRecord.Domain.GUID).To(Equal(domainGUID))
+				Expect(createdRouteRecord.Protocol).To(Equal("http"))
+			})
+
+			When("the route is a tcp route", func() {
+				BeforeEach(func() {
+					routeHost = ""
+					routePath = ""
+					routeProtocol = "tcp"
+					routePort = 1234
+				})
+
+				It("creates a tcp route", func() {
+					Expect(createdRouteErr).NotTo(HaveOccurred())
+					createdCFRoute := &korifiv1alpha1.CFRoute{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: space.Name,
+							Name:      createdRouteRecord.GUID,
+						},
+					}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(createdCFRoute), createdCFRoute)).To(Succeed())
+					Expect(createdCFRoute.Spec.Protocol).To(BeEquivalentTo("tcp"))
+					Expect(createdCFRoute.Spec.Port).To(BeEquivalentTo(1234))
+
+					Expect(createdRouteRecord.Protocol).To(Equal("tcp"))
+					Expect(createdRouteRecord.Port).To(BeEquivalentTo(1234))
+				})
 			})
 
 			When("target namespace isn't set", func() {
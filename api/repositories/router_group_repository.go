@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/config"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+)
+
+const (
+	RouterGroupResourceType = "Router Group"
+	RouterGroupTypeTCP      = "tcp"
+)
+
+type RouterGroupRecord struct {
+	GUID            string
+	Name            string
+	Type            string
+	ReservablePorts config.PortRange
+}
+
+type ListRouterGroupsMessage struct {
+	Names []string
+}
+
+func (m *ListRouterGroupsMessage) matches(routerGroup RouterGroupRecord) bool {
+	return tools.EmptyOrContains(m.Names, routerGroup.Name)
+}
+
+// RouterGroupRepo serves the router groups configured for the API. Router
+// groups are identified by their name, which is also used as their GUID.
+type RouterGroupRepo struct {
+	routerGroups []RouterGroupRecord
+}
+
+func NewRouterGroupRepo(routerGroups []config.RouterGroup) *RouterGroupRepo {
+	return &RouterGroupRepo{
+		routerGroups: slices.Collect(it.Map(slices.Values(routerGroups), func(routerGroup config.RouterGroup) RouterGroupRecord {
+			return RouterGroupRecord{
+				GUID:            routerGroup.Name,
+				Name:            routerGroup.Name,
+				Type:            RouterGroupTypeTCP,
+				ReservablePorts: routerGroup.ReservablePorts,
+			}
+		})),
+	}
+}
+
+func (r *RouterGroupRepo) ListRouterGroups(ctx context.Context, authInfo authorization.Info, message ListRouterGroupsMessage) ([]RouterGroupRecord, error) {
+	return slices.Collect(it.Filter(slices.Values(r.routerGroups), message.matches)), nil
+}
+
+func (r *RouterGroupRepo) GetRouterGroup(ctx context.Context, authInfo authorization.Info, guid string) (RouterGroupRecord, error) {
+	for _, routerGroup := range r.routerGroups {
+		if routerGroup.GUID == guid {
+			return routerGroup, nil
+		}
+	}
+
+	return RouterGroupRecord{}, apierrors.NewNotFoundError(errors.New("router group not found"), RouterGroupResourceType)
+}
@@ -0,0 +1,78 @@
+package repositories_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/config"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouterGroupRepository", func() {
+	var routerGroupRepo *repositories.RouterGroupRepo
+
+	BeforeEach(func() {
+		routerGroupRepo = repositories.NewRouterGroupRepo([]config.RouterGroup{
+			{Name: "default-tcp", ReservablePorts: config.PortRange{From: 1024, To: 1033}},
+			{Name: "other-tcp", ReservablePorts: config.PortRange{From: 2024, To: 2033}},
+		})
+	})
+
+	Describe("ListRouterGroups", func() {
+		var (
+			message      repositories.ListRouterGroupsMessage
+			routerGroups []repositories.RouterGroupRecord
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListRouterGroupsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			routerGroups, err = routerGroupRepo.ListRouterGroups(ctx, authInfo, message)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns all router groups", func() {
+			Expect(routerGroups).To(ConsistOf(
+				repositories.RouterGroupRecord{
+					GUID:            "default-tcp",
+					Name:            "default-tcp",
+					Type:            "tcp",
+					ReservablePorts: config.PortRange{From: 1024, To: 1033},
+				},
+				repositories.RouterGroupRecord{
+					GUID:            "other-tcp",
+					Name:            "other-tcp",
+					Type:            "tcp",
+					ReservablePorts: config.PortRange{From: 2024, To: 2033},
+				},
+			))
+		})
+
+		When("filtering by name", func() {
+			BeforeEach(func() {
+				message.Names = []string{"other-tcp"}
+			})
+
+			It("returns the matching router groups", func() {
+				Expect(routerGroups).To(HaveLen(1))
+				Expect(routerGroups[0].Name).To(Equal("other-tcp"))
+			})
+		})
+	})
+
+	Describe("GetRouterGroup", func() {
+		It("returns the router group", func() {
+			routerGroup, err := routerGroupRepo.GetRouterGroup(ctx, authInfo, "default-tcp")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routerGroup.ReservablePorts).To(Equal(config.PortRange{From: 1024, To: 1033}))
+		})
+
+		It("returns a not found error for unknown router groups", func() {
+			_, err := routerGroupRepo.GetRouterGroup(ctx, authInfo, "unknown")
+			Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+	})
+})
@@ -2,18 +2,28 @@ package repositories
 
 import (
 	"context"
+	"fmt"
+	"maps"
 	"slices"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/compare"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tools"
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups,verbs=get;list,namespace=ROOT_NAMESPACE
+
 const SecurityGroupResourceType = "Security Group"
 
 type SecurityGroupRule struct {
@@ -32,17 +42,65 @@ type SecurityGroupWorkloads struct {
 }
 
 type SecurityGroupRepo struct {
-	klient        Klient
-	rootNamespace string
+	klient           Klient
+	privilegedClient client.Client
+	nsPerms          *authorization.NamespacePermissions
+	rootNamespace    string
+	sorter           SecurityGroupSorter
+}
+
+//counterfeiter:generate -o fake -fake-name SecurityGroupSorter . SecurityGroupSorter
+type SecurityGroupSorter interface {
+	Sort(records []SecurityGroupRecord, order string) []SecurityGroupRecord
+}
+
+type securityGroupSorter struct {
+	sorter *compare.Sorter[SecurityGroupRecord]
+}
+
+func NewSecurityGroupSorter() *securityGroupSorter {
+	return &securityGroupSorter{
+		sorter: compare.NewSorter(SecurityGroupComparator),
+	}
+}
+
+func (s *securityGroupSorter) Sort(records []SecurityGroupRecord, order string) []SecurityGroupRecord {
+	return s.sorter.Sort(records, order)
+}
+
+func SecurityGroupComparator(fieldName string) func(SecurityGroupRecord, SecurityGroupRecord) int {
+	return func(sg1, sg2 SecurityGroupRecord) int {
+		switch fieldName {
+		case "created_at":
+			return tools.CompareTimePtr(&sg1.CreatedAt, &sg2.CreatedAt)
+		case "-created_at":
+			return tools.CompareTimePtr(&sg2.CreatedAt, &sg1.CreatedAt)
+		case "updated_at":
+			return tools.CompareTimePtr(sg1.UpdatedAt, sg2.UpdatedAt)
+		case "-updated_at":
+			return tools.CompareTimePtr(sg2.UpdatedAt, sg1.UpdatedAt)
+		case "name":
+			return strings.Compare(sg1.Name, sg2.Name)
+		case "-name":
+			return strings.Compare(sg2.Name, sg1.Name)
+		}
+		return 0
+	}
 }
 
 func NewSecurityGroupRepo(
 	klient Klient,
+	privilegedClient client.Client,
+	nsPerms *authorization.NamespacePermissions,
 	rootNamespace string,
+	sorter SecurityGroupSorter,
 ) *SecurityGroupRepo {
 	return &SecurityGroupRepo{
-		klient:        klient,
-		rootNamespace: rootNamespace,
+		klient:           klient,
+		privilegedClient: privilegedClient,
+		nsPerms:          nsPerms,
+		rootNamespace:    rootNamespace,
+		sorter:           sorter,
 	}
 }
 
@@ -53,6 +111,67 @@ type CreateSecurityGroupMessage struct {
 	GloballyEnabled SecurityGroupWorkloads
 }
 
+type UpdateSecurityGroupMessage struct {
+	GUID            string
+	DisplayName     *string
+	Rules           *[]SecurityGroupRule
+	GloballyEnabled UpdateSecurityGroupWorkloads
+}
+
+type UpdateSecurityGroupWorkloads struct {
+	Running *bool
+	Staging *bool
+}
+
+type BindSecurityGroupMessage struct {
+	GUID       string
+	SpaceGUIDs []string
+	Workloads  string
+}
+
+type UnbindSecurityGroupMessage struct {
+	GUID      string
+	SpaceGUID string
+	Workloads string
+}
+
+type ListSecurityGroupsMessage struct {
+	GUIDs                  []string
+	Names                  []string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	RunningSpaceGUIDs      []string
+	StagingSpaceGUIDs      []string
+	// IncludeGloballyEnabled makes security groups that are globally enabled
+	// for running (staging) workloads match the running (staging) space filter
+	IncludeGloballyEnabled bool
+	OrderBy                string
+	Pagination             Pagination
+}
+
+func (m *ListSecurityGroupsMessage) matches(sg SecurityGroupRecord) bool {
+	return tools.EmptyOrContains(m.GUIDs, sg.GUID) &&
+		tools.EmptyOrContains(m.Names, sg.Name) &&
+		tools.NilOrEquals(m.GloballyEnabledRunning, sg.GloballyEnabled.Running) &&
+		tools.NilOrEquals(m.GloballyEnabledStaging, sg.GloballyEnabled.Staging) &&
+		m.matchesSpaces(m.RunningSpaceGUIDs, sg.RunningSpaces, sg.GloballyEnabled.Running) &&
+		m.matchesSpaces(m.StagingSpaceGUIDs, sg.StagingSpaces, sg.GloballyEnabled.Staging)
+}
+
+func (m *ListSecurityGroupsMessage) matchesSpaces(filter []string, boundSpaces []string, globallyEnabled bool) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	if m.IncludeGloballyEnabled && globallyEnabled {
+		return true
+	}
+
+	return slices.ContainsFunc(boundSpaces, func(space string) bool {
+		return slices.Contains(filter, space)
+	})
+}
+
 type SecurityGroupRecord struct {
 	GUID            string
 	CreatedAt       time.Time
@@ -73,17 +192,7 @@ func (r *SecurityGroupRepo) CreateSecurityGroup(ctx context.Context, authInfo au
 		},
 		Spec: korifiv1alpha1.CFSecurityGroupSpec{
 			DisplayName: sgCreateMessage.DisplayName,
-			Rules: slices.Collect(it.Map(slices.Values(sgCreateMessage.Rules), func(r SecurityGroupRule) korifiv1alpha1.SecurityGroupRule {
-				return korifiv1alpha1.SecurityGroupRule{
-					Protocol:    r.Protocol,
-					Destination: r.Destination,
-					Ports:       r.Ports,
-					Type:        r.Type,
-					Code:        r.Code,
-					Description: r.Description,
-					Log:         r.Log,
-				}
-			})),
+			Rules:       toCFSecurityGroupRules(sgCreateMessage.Rules),
 			Spaces: func() map[string]korifiv1alpha1.SecurityGroupWorkloads {
 				spaces := make(map[string]korifiv1alpha1.SecurityGroupWorkloads, len(sgCreateMessage.Spaces))
 				for guid, workloads := range sgCreateMessage.Spaces {
@@ -103,23 +212,279 @@ func (r *SecurityGroupRepo) CreateSecurityGroup(ctx context.Context, authInfo au
 
 	err := r.klient.Create(ctx, cfSecurityGroup)
 	if err != nil {
-		if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
-			if validationError.Type == validation.DuplicateNameErrorType {
-				return SecurityGroupRecord{}, apierrors.NewUniquenessError(err, validationError.GetMessage())
-			}
+		return SecurityGroupRecord{}, securityGroupWebhookErrorToApiError(err)
+	}
+
+	return toSecurityGroupRecord(*cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) GetSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) (SecurityGroupRecord, error) {
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	err := r.klient.Get(ctx, cfSecurityGroup)
+	if err == nil {
+		return toSecurityGroupRecord(*cfSecurityGroup), nil
+	}
+
+	if !k8serrors.IsForbidden(err) {
+		return SecurityGroupRecord{}, fmt.Errorf("get-security-group failed: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	err = r.privilegedClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("get-security-group failed: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	visibleSpaces, err := r.nsPerms.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to get authorized spaces: %w", err)
+	}
+
+	record, visible := toVisibleSecurityGroupRecord(toSecurityGroupRecord(*cfSecurityGroup), visibleSpaces)
+	if !visible {
+		return SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, SecurityGroupResourceType)
+	}
+
+	return record, nil
+}
+
+func (r *SecurityGroupRepo) ListSecurityGroups(ctx context.Context, authInfo authorization.Info, message ListSecurityGroupsMessage) (ListResult[SecurityGroupRecord], error) {
+	records, err := r.listVisibleSecurityGroups(ctx, authInfo)
+	if err != nil {
+		return ListResult[SecurityGroupRecord]{}, err
+	}
+
+	records = slices.Collect(it.Filter(slices.Values(records), message.matches))
+	records = r.sorter.Sort(records, message.OrderBy)
+
+	recordsPage := descriptors.SinglePage(records, len(records))
+	if !message.Pagination.IsZero() {
+		recordsPage, err = descriptors.GetPage(records, message.Pagination.PerPage, message.Pagination.Page)
+		if err != nil {
+			return ListResult[SecurityGroupRecord]{}, fmt.Errorf("failed to page security groups list: %w", err)
+		}
+	}
+
+	return ListResult[SecurityGroupRecord]{
+		PageInfo: recordsPage.PageInfo,
+		Records:  recordsPage.Items,
+	}, nil
+}
+
+func (r *SecurityGroupRepo) UpdateSecurityGroup(ctx context.Context, authInfo authorization.Info, message UpdateSecurityGroupMessage) (SecurityGroupRecord, error) {
+	cfSecurityGroup, err := r.getCFSecurityGroup(ctx, message.GUID)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("update-security-group failed: %w", err)
+	}
+
+	err = r.klient.Patch(ctx, cfSecurityGroup, func() error {
+		if message.DisplayName != nil {
+			cfSecurityGroup.Spec.DisplayName = *message.DisplayName
+		}
+
+		if message.Rules != nil {
+			cfSecurityGroup.Spec.Rules = toCFSecurityGroupRules(*message.Rules)
+		}
+
+		if message.GloballyEnabled.Running != nil {
+			cfSecurityGroup.Spec.GloballyEnabled.Running = *message.GloballyEnabled.Running
+		}
+
+		if message.GloballyEnabled.Staging != nil {
+			cfSecurityGroup.Spec.GloballyEnabled.Staging = *message.GloballyEnabled.Staging
+		}
+
+		return nil
+	})
+	if err != nil {
+		return SecurityGroupRecord{}, securityGroupWebhookErrorToApiError(err)
+	}
+
+	return toSecurityGroupRecord(*cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) BindSecurityGroup(ctx context.Context, authInfo authorization.Info, message BindSecurityGroupMessage) (SecurityGroupRecord, error) {
+	cfSecurityGroup, err := r.getCFSecurityGroup(ctx, message.GUID)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("bind-security-group failed: %w", err)
+	}
+
+	err = r.klient.Patch(ctx, cfSecurityGroup, func() error {
+		if cfSecurityGroup.Spec.Spaces == nil {
+			cfSecurityGroup.Spec.Spaces = map[string]korifiv1alpha1.SecurityGroupWorkloads{}
+		}
+
+		for _, spaceGUID := range message.SpaceGUIDs {
+			cfSecurityGroup.Spec.Spaces[spaceGUID] = setSecurityGroupWorkloads(cfSecurityGroup.Spec.Spaces[spaceGUID], message.Workloads, true)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to bind security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return toSecurityGroupRecord(*cfSecurityGroup), nil
+}
+
+func (r *SecurityGroupRepo) UnbindSecurityGroup(ctx context.Context, authInfo authorization.Info, message UnbindSecurityGroupMessage) (SecurityGroupRecord, error) {
+	cfSecurityGroup, err := r.getCFSecurityGroup(ctx, message.GUID)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("unbind-security-group failed: %w", err)
+	}
+
+	err = r.klient.Patch(ctx, cfSecurityGroup, func() error {
+		workloads, ok := cfSecurityGroup.Spec.Spaces[message.SpaceGUID]
+		if !ok {
+			return nil
 		}
 
-		return SecurityGroupRecord{}, apierrors.FromK8sError(err, SecurityGroupResourceType)
+		workloads = setSecurityGroupWorkloads(workloads, message.Workloads, false)
+		if !workloads.Running && !workloads.Staging {
+			delete(cfSecurityGroup.Spec.Spaces, message.SpaceGUID)
+			return nil
+		}
+
+		cfSecurityGroup.Spec.Spaces[message.SpaceGUID] = workloads
+		return nil
+	})
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to unbind security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
 	}
 
 	return toSecurityGroupRecord(*cfSecurityGroup), nil
 }
 
+func (r *SecurityGroupRepo) DeleteSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) error {
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	err := r.klient.Delete(ctx, cfSecurityGroup)
+	if err != nil {
+		return apierrors.FromK8sError(err, SecurityGroupResourceType)
+	}
+
+	return nil
+}
+
+func (r *SecurityGroupRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	securityGroup, err := r.GetSecurityGroup(ctx, authInfo, guid)
+	return securityGroup.DeletedAt, err
+}
+
+func (r *SecurityGroupRepo) getCFSecurityGroup(ctx context.Context, guid string) (*korifiv1alpha1.CFSecurityGroup, error) {
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	err := r.klient.Get(ctx, cfSecurityGroup)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, SecurityGroupResourceType)
+	}
+
+	return cfSecurityGroup, nil
+}
+
+func setSecurityGroupWorkloads(workloads korifiv1alpha1.SecurityGroupWorkloads, workloadsType string, enabled bool) korifiv1alpha1.SecurityGroupWorkloads {
+	switch workloadsType {
+	case korifiv1alpha1.SecurityGroupRunningWorkloads:
+		workloads.Running = enabled
+	case korifiv1alpha1.SecurityGroupStagingWorkloads:
+		workloads.Staging = enabled
+	}
+
+	return workloads
+}
+
+func securityGroupWebhookErrorToApiError(err error) error {
+	if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+		if validationError.Type == validation.DuplicateNameErrorType {
+			return apierrors.NewUniquenessError(err, validationError.GetMessage())
+		}
+	}
+
+	return apierrors.FromK8sError(err, SecurityGroupResourceType)
+}
+
+func toCFSecurityGroupRules(rules []SecurityGroupRule) []korifiv1alpha1.SecurityGroupRule {
+	return slices.Collect(it.Map(slices.Values(rules), func(r SecurityGroupRule) korifiv1alpha1.SecurityGroupRule {
+		return korifiv1alpha1.SecurityGroupRule{
+			Protocol:    r.Protocol,
+			Destination: r.Destination,
+			Ports:       r.Ports,
+			Type:        r.Type,
+			Code:        r.Code,
+			Description: r.Description,
+			Log:         r.Log,
+		}
+	}))
+}
+
+// listVisibleSecurityGroups returns all security groups to users that can
+// list them, i.e. admins. Other users only see the security groups that are
+// globally enabled or bound to spaces they have a role in, and only those
+// spaces among the bound ones
+func (r *SecurityGroupRepo) listVisibleSecurityGroups(ctx context.Context, authInfo authorization.Info) ([]SecurityGroupRecord, error) {
+	cfSecurityGroupList := &korifiv1alpha1.CFSecurityGroupList{}
+	_, err := r.klient.List(ctx, cfSecurityGroupList, InNamespace(r.rootNamespace))
+	if err == nil {
+		return slices.Collect(it.Map(slices.Values(cfSecurityGroupList.Items), toSecurityGroupRecord)), nil
+	}
+
+	if !k8serrors.IsForbidden(err) {
+		return nil, fmt.Errorf("failed to list security groups in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	err = r.privilegedClient.List(ctx, cfSecurityGroupList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security groups in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	visibleSpaces, err := r.nsPerms.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get authorized spaces: %w", err)
+	}
+
+	records := []SecurityGroupRecord{}
+	for _, cfSecurityGroup := range cfSecurityGroupList.Items {
+		if record, visible := toVisibleSecurityGroupRecord(toSecurityGroupRecord(cfSecurityGroup), visibleSpaces); visible {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+func toVisibleSecurityGroupRecord(record SecurityGroupRecord, visibleSpaces map[string]bool) (SecurityGroupRecord, bool) {
+	isVisible := func(space string) bool { return visibleSpaces[space] }
+
+	record.RunningSpaces = slices.Collect(it.Filter(slices.Values(record.RunningSpaces), isVisible))
+	record.StagingSpaces = slices.Collect(it.Filter(slices.Values(record.StagingSpaces), isVisible))
+
+	return record, record.GloballyEnabled.Running ||
+		record.GloballyEnabled.Staging ||
+		len(record.RunningSpaces) > 0 ||
+		len(record.StagingSpaces) > 0
+}
+
 func toSecurityGroupRecord(cfSecurityGroup korifiv1alpha1.CFSecurityGroup) SecurityGroupRecord {
 	runningSpaces := []string{}
 	stagingSpaces := []string{}
 
-	for space, workloads := range cfSecurityGroup.Spec.Spaces {
+	for _, space := range slices.Sorted(maps.Keys(cfSecurityGroup.Spec.Spaces)) {
+		workloads := cfSecurityGroup.Spec.Spaces[space]
 		if workloads.Running {
 			runningSpaces = append(runningSpaces, space)
 		}
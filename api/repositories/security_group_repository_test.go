@@ -1,25 +1,65 @@
 package repositories_test
 
 import (
+	"time"
+
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	gomega_types "github.com/onsi/gomega/types"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("SecurityGroupRepo", func() {
 	var (
-		repo  *repositories.SecurityGroupRepo
-		org   *korifiv1alpha1.CFOrg
-		space *korifiv1alpha1.CFSpace
+		repo   *repositories.SecurityGroupRepo
+		sorter *fake.SecurityGroupSorter
+		org    *korifiv1alpha1.CFOrg
+		space  *korifiv1alpha1.CFSpace
 	)
 
+	createSecurityGroup := func(displayName string, spaces map[string]korifiv1alpha1.SecurityGroupWorkloads, globallyEnabled korifiv1alpha1.SecurityGroupWorkloads) *korifiv1alpha1.CFSecurityGroup {
+		cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFSecurityGroupSpec{
+				DisplayName: displayName,
+				Rules: []korifiv1alpha1.SecurityGroupRule{{
+					Protocol:    korifiv1alpha1.ProtocolTCP,
+					Ports:       "443",
+					Destination: "10.0.0.1",
+				}},
+				Spaces:          spaces,
+				GloballyEnabled: globallyEnabled,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfSecurityGroup)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cfSecurityGroup))).To(Succeed())
+		})
+
+		return cfSecurityGroup
+	}
+
 	BeforeEach(func() {
-		repo = repositories.NewSecurityGroupRepo(rootNSKlient, rootNamespace)
+		sorter = new(fake.SecurityGroupSorter)
+		sorter.SortStub = func(records []repositories.SecurityGroupRecord, _ string) []repositories.SecurityGroupRecord {
+			return records
+		}
+
+		repo = repositories.NewSecurityGroupRepo(rootNSKlient, k8sClient, nsPerms, rootNamespace, sorter)
 		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
 		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
 	})
@@ -101,4 +141,507 @@ var _ = Describe("SecurityGroupRepo", func() {
 			})
 		})
 	})
+
+	Describe("GetSecurityGroup", func() {
+		var (
+			cfSecurityGroup     *korifiv1alpha1.CFSecurityGroup
+			securityGroupRecord repositories.SecurityGroupRecord
+			getErr              error
+		)
+
+		BeforeEach(func() {
+			cfSecurityGroup = createSecurityGroup(uuid.NewString(), map[string]korifiv1alpha1.SecurityGroupWorkloads{
+				space.Name: {Running: true},
+			}, korifiv1alpha1.SecurityGroupWorkloads{})
+		})
+
+		JustBeforeEach(func() {
+			securityGroupRecord, getErr = repo.GetSecurityGroup(ctx, authInfo, cfSecurityGroup.Name)
+		})
+
+		It("returns a not found error for users that cannot see the bound spaces", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the security group", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(securityGroupRecord.GUID).To(Equal(cfSecurityGroup.Name))
+				Expect(securityGroupRecord.Name).To(Equal(cfSecurityGroup.Spec.DisplayName))
+				Expect(securityGroupRecord.RunningSpaces).To(ConsistOf(space.Name))
+				Expect(securityGroupRecord.StagingSpaces).To(BeEmpty())
+			})
+
+			When("the security group does not exist", func() {
+				BeforeEach(func() {
+					cfSecurityGroup.Name = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		When("the user has a role in a bound space", func() {
+			var otherSpace *korifiv1alpha1.CFSpace
+
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+
+				otherSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("other-space"))
+				Expect(k8s.PatchResource(ctx, k8sClient, cfSecurityGroup, func() {
+					cfSecurityGroup.Spec.Spaces[otherSpace.Name] = korifiv1alpha1.SecurityGroupWorkloads{Running: true, Staging: true}
+				})).To(Succeed())
+			})
+
+			It("returns the security group with the visible bound spaces only", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(securityGroupRecord.GUID).To(Equal(cfSecurityGroup.Name))
+				Expect(securityGroupRecord.RunningSpaces).To(ConsistOf(space.Name))
+				Expect(securityGroupRecord.StagingSpaces).To(BeEmpty())
+			})
+		})
+
+		When("the security group is globally enabled", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfSecurityGroup, func() {
+					cfSecurityGroup.Spec.GloballyEnabled.Staging = true
+				})).To(Succeed())
+			})
+
+			It("returns the security group without the bound spaces the user cannot see", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(securityGroupRecord.GUID).To(Equal(cfSecurityGroup.Name))
+				Expect(securityGroupRecord.RunningSpaces).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ListSecurityGroups", func() {
+		var (
+			sg1, sg2, sg3 *korifiv1alpha1.CFSecurityGroup
+			message       repositories.ListSecurityGroupsMessage
+			listResult    repositories.ListResult[repositories.SecurityGroupRecord]
+			listErr       error
+		)
+
+		BeforeEach(func() {
+			sg1 = createSecurityGroup(uuid.NewString(), map[string]korifiv1alpha1.SecurityGroupWorkloads{
+				space.Name: {Running: true},
+			}, korifiv1alpha1.SecurityGroupWorkloads{})
+			sg2 = createSecurityGroup(uuid.NewString(), map[string]korifiv1alpha1.SecurityGroupWorkloads{
+				space.Name: {Staging: true},
+			}, korifiv1alpha1.SecurityGroupWorkloads{})
+			sg3 = createSecurityGroup(uuid.NewString(), nil, korifiv1alpha1.SecurityGroupWorkloads{Running: true})
+
+			message = repositories.ListSecurityGroupsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			listResult, listErr = repo.ListSecurityGroups(ctx, authInfo, message)
+		})
+
+		It("returns the globally enabled security groups only to users that cannot see the bound spaces", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg3.Name)}),
+			))
+		})
+
+		When("the user has a role in a bound space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, rootNamespaceUserRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the security groups bound to the space and the globally enabled ones", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg1.Name), "RunningSpaces": ConsistOf(space.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg2.Name), "StagingSpaces": ConsistOf(space.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg3.Name)}),
+				))
+			})
+
+			When("a security group is also bound to a space the user cannot see", func() {
+				var otherSpace *korifiv1alpha1.CFSpace
+
+				BeforeEach(func() {
+					otherSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("other-space"))
+					Expect(k8s.PatchResource(ctx, k8sClient, sg1, func() {
+						sg1.Spec.Spaces[otherSpace.Name] = korifiv1alpha1.SecurityGroupWorkloads{Running: true}
+					})).To(Succeed())
+				})
+
+				It("only returns the visible bound spaces", func() {
+					Expect(listResult.Records).To(ContainElement(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg1.Name), "RunningSpaces": ConsistOf(space.Name)}),
+					))
+				})
+			})
+
+			When("filtering by a space the user cannot see", func() {
+				BeforeEach(func() {
+					otherSpace := createSpaceWithCleanup(ctx, org.Name, prefixedGUID("other-space"))
+					Expect(k8s.PatchResource(ctx, k8sClient, sg1, func() {
+						sg1.Spec.Spaces[otherSpace.Name] = korifiv1alpha1.SecurityGroupWorkloads{Running: true}
+					})).To(Succeed())
+					message.RunningSpaceGUIDs = []string{otherSpace.Name}
+				})
+
+				It("returns no security groups", func() {
+					Expect(listResult.Records).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns all security groups", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg1.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg2.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg3.Name)}),
+				))
+			})
+
+			It("sorts the security groups", func() {
+				Expect(sorter.SortCallCount()).To(Equal(1))
+			})
+
+			When("filtering by names", func() {
+				BeforeEach(func() {
+					message.Names = []string{sg1.Spec.DisplayName, sg3.Spec.DisplayName}
+				})
+
+				It("returns the matching security groups", func() {
+					Expect(listResult.Records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg1.Name)}),
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg3.Name)}),
+					))
+				})
+			})
+
+			When("filtering by globally enabled running", func() {
+				BeforeEach(func() {
+					message.GloballyEnabledRunning = tools.PtrTo(true)
+				})
+
+				It("returns the matching security groups", func() {
+					Expect(listResult.Records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg3.Name)}),
+					))
+				})
+			})
+
+			When("filtering by running space guids", func() {
+				BeforeEach(func() {
+					message.RunningSpaceGUIDs = []string{space.Name}
+				})
+
+				It("returns the security groups bound to the space", func() {
+					Expect(listResult.Records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg1.Name)}),
+					))
+				})
+
+				When("globally enabled security groups are included", func() {
+					BeforeEach(func() {
+						message.IncludeGloballyEnabled = true
+					})
+
+					It("returns the bound and globally enabled security groups", func() {
+						Expect(listResult.Records).To(ConsistOf(
+							MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg1.Name)}),
+							MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg3.Name)}),
+						))
+					})
+				})
+			})
+
+			When("filtering by staging space guids", func() {
+				BeforeEach(func() {
+					message.StagingSpaceGUIDs = []string{space.Name}
+				})
+
+				It("returns the security groups bound to the space", func() {
+					Expect(listResult.Records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(sg2.Name)}),
+					))
+				})
+			})
+
+			When("paging is requested", func() {
+				BeforeEach(func() {
+					message.GUIDs = []string{sg1.Name, sg2.Name, sg3.Name}
+					message.Pagination = repositories.Pagination{PerPage: 2, Page: 2}
+				})
+
+				It("returns the requested page", func() {
+					Expect(listResult.Records).To(HaveLen(1))
+					Expect(listResult.PageInfo.TotalResults).To(Equal(3))
+					Expect(listResult.PageInfo.PageNumber).To(Equal(2))
+				})
+			})
+		})
+	})
+
+	Describe("UpdateSecurityGroup", func() {
+		var (
+			cfSecurityGroup     *korifiv1alpha1.CFSecurityGroup
+			message             repositories.UpdateSecurityGroupMessage
+			securityGroupRecord repositories.SecurityGroupRecord
+			updateErr           error
+		)
+
+		BeforeEach(func() {
+			cfSecurityGroup = createSecurityGroup(uuid.NewString(), nil, korifiv1alpha1.SecurityGroupWorkloads{})
+			message = repositories.UpdateSecurityGroupMessage{
+				GUID:        cfSecurityGroup.Name,
+				DisplayName: tools.PtrTo("new-name-" + uuid.NewString()),
+				Rules: &[]repositories.SecurityGroupRule{{
+					Protocol:    korifiv1alpha1.ProtocolUDP,
+					Ports:       "53",
+					Destination: "10.0.0.2",
+				}},
+				GloballyEnabled: repositories.UpdateSecurityGroupWorkloads{
+					Staging: tools.PtrTo(true),
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			securityGroupRecord, updateErr = repo.UpdateSecurityGroup(ctx, authInfo, message)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("updates the security group", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(securityGroupRecord.Name).To(Equal(*message.DisplayName))
+				Expect(securityGroupRecord.GloballyEnabled).To(Equal(repositories.SecurityGroupWorkloads{Staging: true}))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+				Expect(cfSecurityGroup.Spec.DisplayName).To(Equal(*message.DisplayName))
+				Expect(cfSecurityGroup.Spec.GloballyEnabled).To(Equal(korifiv1alpha1.SecurityGroupWorkloads{Staging: true}))
+				Expect(cfSecurityGroup.Spec.Rules).To(ConsistOf(korifiv1alpha1.SecurityGroupRule{
+					Protocol:    korifiv1alpha1.ProtocolUDP,
+					Ports:       "53",
+					Destination: "10.0.0.2",
+				}))
+			})
+
+			When("only some fields are specified", func() {
+				BeforeEach(func() {
+					message.DisplayName = nil
+					message.Rules = nil
+				})
+
+				It("leaves the other fields unchanged", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+					Expect(cfSecurityGroup.Spec.DisplayName).To(Equal(securityGroupRecord.Name))
+					Expect(cfSecurityGroup.Spec.Rules).To(HaveLen(1))
+					Expect(cfSecurityGroup.Spec.Rules[0].Protocol).To(Equal(korifiv1alpha1.ProtocolTCP))
+				})
+			})
+
+			When("the security group does not exist", func() {
+				BeforeEach(func() {
+					message.GUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("BindSecurityGroup", func() {
+		var (
+			cfSecurityGroup     *korifiv1alpha1.CFSecurityGroup
+			anotherSpace        *korifiv1alpha1.CFSpace
+			securityGroupRecord repositories.SecurityGroupRecord
+			bindErr             error
+		)
+
+		BeforeEach(func() {
+			anotherSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+			cfSecurityGroup = createSecurityGroup(uuid.NewString(), map[string]korifiv1alpha1.SecurityGroupWorkloads{
+				space.Name: {Staging: true},
+			}, korifiv1alpha1.SecurityGroupWorkloads{})
+		})
+
+		JustBeforeEach(func() {
+			securityGroupRecord, bindErr = repo.BindSecurityGroup(ctx, authInfo, repositories.BindSecurityGroupMessage{
+				GUID:       cfSecurityGroup.Name,
+				SpaceGUIDs: []string{space.Name, anotherSpace.Name},
+				Workloads:  korifiv1alpha1.SecurityGroupRunningWorkloads,
+			})
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(bindErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("binds the spaces for running workloads", func() {
+				Expect(bindErr).NotTo(HaveOccurred())
+				Expect(securityGroupRecord.RunningSpaces).To(ConsistOf(space.Name, anotherSpace.Name))
+				Expect(securityGroupRecord.StagingSpaces).To(ConsistOf(space.Name))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+				Expect(cfSecurityGroup.Spec.Spaces).To(Equal(map[string]korifiv1alpha1.SecurityGroupWorkloads{
+					space.Name:        {Running: true, Staging: true},
+					anotherSpace.Name: {Running: true},
+				}))
+			})
+		})
+	})
+
+	Describe("UnbindSecurityGroup", func() {
+		var (
+			cfSecurityGroup *korifiv1alpha1.CFSecurityGroup
+			anotherSpace    *korifiv1alpha1.CFSpace
+			unbindErr       error
+		)
+
+		BeforeEach(func() {
+			anotherSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+			cfSecurityGroup = createSecurityGroup(uuid.NewString(), map[string]korifiv1alpha1.SecurityGroupWorkloads{
+				space.Name:        {Running: true, Staging: true},
+				anotherSpace.Name: {Running: true},
+			}, korifiv1alpha1.SecurityGroupWorkloads{})
+		})
+
+		JustBeforeEach(func() {
+			for _, spaceGUID := range []string{space.Name, anotherSpace.Name} {
+				_, unbindErr = repo.UnbindSecurityGroup(ctx, authInfo, repositories.UnbindSecurityGroupMessage{
+					GUID:      cfSecurityGroup.Name,
+					SpaceGUID: spaceGUID,
+					Workloads: korifiv1alpha1.SecurityGroupRunningWorkloads,
+				})
+				if unbindErr != nil {
+					return
+				}
+			}
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(unbindErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("unbinds the spaces for running workloads and drops spaces that are no longer bound", func() {
+				Expect(unbindErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+				Expect(cfSecurityGroup.Spec.Spaces).To(Equal(map[string]korifiv1alpha1.SecurityGroupWorkloads{
+					space.Name: {Staging: true},
+				}))
+			})
+		})
+	})
+
+	Describe("DeleteSecurityGroup", func() {
+		var (
+			cfSecurityGroup *korifiv1alpha1.CFSecurityGroup
+			deleteErr       error
+		)
+
+		BeforeEach(func() {
+			cfSecurityGroup = createSecurityGroup(uuid.NewString(), nil, korifiv1alpha1.SecurityGroupWorkloads{})
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteSecurityGroup(ctx, authInfo, cfSecurityGroup.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the security group", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
 })
+
+var _ = DescribeTable("SecurityGroupSorter",
+	func(sg1, sg2 repositories.SecurityGroupRecord, field string, match gomega_types.GomegaMatcher) {
+		Expect(repositories.SecurityGroupComparator(field)(sg1, sg2)).To(match)
+	},
+	Entry("created_at",
+		repositories.SecurityGroupRecord{CreatedAt: time.UnixMilli(1)},
+		repositories.SecurityGroupRecord{CreatedAt: time.UnixMilli(2)},
+		"created_at",
+		BeNumerically("<", 0),
+	),
+	Entry("-created_at",
+		repositories.SecurityGroupRecord{CreatedAt: time.UnixMilli(1)},
+		repositories.SecurityGroupRecord{CreatedAt: time.UnixMilli(2)},
+		"-created_at",
+		BeNumerically(">", 0),
+	),
+	Entry("updated_at",
+		repositories.SecurityGroupRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(1))},
+		repositories.SecurityGroupRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(2))},
+		"updated_at",
+		BeNumerically("<", 0),
+	),
+	Entry("-updated_at",
+		repositories.SecurityGroupRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(1))},
+		repositories.SecurityGroupRecord{UpdatedAt: tools.PtrTo(time.UnixMilli(2))},
+		"-updated_at",
+		BeNumerically(">", 0),
+	),
+	Entry("name",
+		repositories.SecurityGroupRecord{Name: "first-sg"},
+		repositories.SecurityGroupRecord{Name: "second-sg"},
+		"name",
+		BeNumerically("<", 0),
+	),
+	Entry("-name",
+		repositories.SecurityGroupRecord{Name: "first-sg"},
+		repositories.SecurityGroupRecord{Name: "second-sg"},
+		"-name",
+		BeNumerically(">", 0),
+	),
+)
@@ -27,6 +27,8 @@ import (
 const (
 	LabelServiceBindingProvisionedService = "servicebinding.io/provisioned-service"
 	ServiceBindingResourceType            = "Service Binding"
+
+	unableToBindErrorMessage = "Unable to bind to instance. Ensure that the instance exists and you have access to it."
 )
 
 type ParametersClient interface {
@@ -89,12 +91,19 @@ type ServiceBindingLastOperation struct {
 }
 
 type CreateServiceBindingMessage struct {
-	Type                string
-	Name                *string
-	ServiceInstanceGUID string
-	AppGUID             string
-	SpaceGUID           string
-	Parameters          map[string]any
+	Type                     string
+	Name                     *string
+	ServiceInstanceGUID      string
+	ServiceInstanceSpaceGUID string
+	AppGUID                  string
+	SpaceGUID                string
+	Parameters               map[string]any
+}
+
+// bindsSharedServiceInstance is true when the binding is created in a space
+// the service instance has been shared with
+func (m CreateServiceBindingMessage) bindsSharedServiceInstance() bool {
+	return m.ServiceInstanceSpaceGUID != "" && m.ServiceInstanceSpaceGUID != m.SpaceGUID
 }
 
 type DeleteServiceBindingMessage struct {
@@ -151,6 +160,10 @@ func (m CreateServiceBindingMessage) toCFServiceBinding(instanceType korifiv1alp
 		},
 	}
 
+	if m.bindsSharedServiceInstance() {
+		binding.Spec.Service.Namespace = m.ServiceInstanceSpaceGUID
+	}
+
 	if instanceType == korifiv1alpha1.ManagedType {
 		binding.Spec.Parameters.Name = uuid.NewString()
 	}
@@ -217,43 +230,40 @@ func (r *ServiceBindingRepo) createAppServiceBinding(ctx context.Context, messag
 }
 
 func (r *ServiceBindingRepo) createServiceBinding(ctx context.Context, message CreateServiceBindingMessage) (ServiceBindingRecord, error) {
-	cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: message.SpaceGUID,
-			Name:      message.ServiceInstanceGUID,
-		},
-	}
-	err := r.klient.Get(ctx, cfServiceInstance)
+	instanceType, err := r.getServiceInstanceType(ctx, message)
 	if err != nil {
 		return ServiceBindingRecord{},
 			apierrors.AsUnprocessableEntity(
 				apierrors.FromK8sError(err, ServiceBindingResourceType),
-				"Unable to bind to instance. Ensure that the instance exists and you have access to it.",
+				unableToBindErrorMessage,
 				apierrors.ForbiddenError{},
 				apierrors.NotFoundError{},
 			)
 	}
 
-	cfServiceBinding := message.toCFServiceBinding(cfServiceInstance.Spec.Type)
+	cfServiceBinding := message.toCFServiceBinding(instanceType)
 	err = r.klient.Create(ctx, cfServiceBinding)
 	if err != nil {
 		if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
 			if validationError.Type == bindings.ServiceBindingErrorType {
 				return ServiceBindingRecord{}, apierrors.NewUniquenessError(err, validationError.GetMessage())
 			}
+			if validationError.Type == bindings.ServiceInstanceNotSharedErrorType {
+				return ServiceBindingRecord{}, apierrors.NewUnprocessableEntityError(err, unableToBindErrorMessage)
+			}
 		}
 
 		return ServiceBindingRecord{}, apierrors.FromK8sError(err, ServiceBindingResourceType)
 	}
 
-	if cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+	if instanceType == korifiv1alpha1.ManagedType {
 		err = r.createParametersSecret(ctx, cfServiceBinding, message.Parameters)
 		if err != nil {
 			return ServiceBindingRecord{}, apierrors.FromK8sError(err, ServiceBindingResourceType)
 		}
 	}
 
-	if cfServiceInstance.Spec.Type == korifiv1alpha1.UserProvidedType {
+	if instanceType == korifiv1alpha1.UserProvidedType {
 		cfServiceBinding, err = r.bindingConditionAwaiter.AwaitCondition(ctx, r.klient, cfServiceBinding, korifiv1alpha1.StatusConditionReady)
 		if err != nil {
 			return ServiceBindingRecord{}, err
@@ -263,6 +273,28 @@ func (r *ServiceBindingRepo) createServiceBinding(ctx context.Context, message C
 	return serviceBindingToRecord(*cfServiceBinding), nil
 }
 
+func (r *ServiceBindingRepo) getServiceInstanceType(ctx context.Context, message CreateServiceBindingMessage) (korifiv1alpha1.InstanceType, error) {
+	if message.bindsSharedServiceInstance() {
+		// Users in the spaces a service instance is shared with cannot read
+		// the instance. Only managed service instances can be shared and the
+		// binding webhook rejects bindings to instances that are not shared
+		// with the binding space.
+		return korifiv1alpha1.ManagedType, nil
+	}
+
+	cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      message.ServiceInstanceGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, cfServiceInstance); err != nil {
+		return "", err
+	}
+
+	return cfServiceInstance.Spec.Type, nil
+}
+
 func actualBindingGUIDs(cfApp *korifiv1alpha1.CFApp) []string {
 	return slices.Collect(it.Map(slices.Values(cfApp.Status.ServiceBindings), func(b korifiv1alpha1.ServiceBinding) string {
 		return b.GUID
@@ -718,6 +718,44 @@ var _ = Describe("ServiceBindingRepo", func() {
 				})
 			})
 
+			When("the service instance is shared from another space", func() {
+				var sharedInstance *korifiv1alpha1.CFServiceInstance
+
+				BeforeEach(func() {
+					ownerSpace := createSpaceWithCleanup(ctx, org.Name, prefixedGUID("owner-space"))
+					sharedInstance = &korifiv1alpha1.CFServiceInstance{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: ownerSpace.Name,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFServiceInstanceSpec{
+							Type:         korifiv1alpha1.ManagedType,
+							SharedSpaces: []string{space.Name},
+						},
+					}
+					Expect(k8sClient.Create(ctx, sharedInstance)).To(Succeed())
+
+					createMsg.ServiceInstanceGUID = sharedInstance.Name
+					createMsg.ServiceInstanceSpaceGUID = ownerSpace.Name
+				})
+
+				It("creates a binding in the app space that references the instance namespace", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(serviceBindingRecord.SpaceGUID).To(Equal(space.Name))
+					Expect(serviceBindingRecord.ServiceInstanceGUID).To(Equal(sharedInstance.Name))
+
+					serviceBinding := &korifiv1alpha1.CFServiceBinding{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      serviceBindingRecord.GUID,
+							Namespace: space.Name,
+						},
+					}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceBinding), serviceBinding)).To(Succeed())
+					Expect(serviceBinding.Spec.Service.Namespace).To(Equal(sharedInstance.Namespace))
+					Expect(serviceBinding.Spec.Parameters.Name).NotTo(BeEmpty())
+				})
+			})
+
 			When("the service binding has a name", func() {
 				BeforeEach(func() {
 					createMsg.Name = tools.PtrTo("some-name-for-a-binding")
@@ -14,6 +14,7 @@ import (
 
 	"github.com/BooleanCat/go-functional/v2/it"
 	"github.com/google/uuid"
+	authv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,30 +33,38 @@ type NamespaceGetter interface {
 }
 
 type ServiceInstanceRepo struct {
-	klient        Klient
-	awaiter       Awaiter[*korifiv1alpha1.CFServiceInstance]
-	rootNamespace string
+	klient            Klient
+	privilegedClient  client.Client
+	userClientFactory authorization.UserClientFactory
+	awaiter           Awaiter[*korifiv1alpha1.CFServiceInstance]
+	rootNamespace     string
 }
 
 func NewServiceInstanceRepo(
 	klient Klient,
+	privilegedClient client.Client,
+	userClientFactory authorization.UserClientFactory,
 	awaiter Awaiter[*korifiv1alpha1.CFServiceInstance],
 	rootNamespace string,
 ) *ServiceInstanceRepo {
 	return &ServiceInstanceRepo{
-		klient:        klient,
-		awaiter:       awaiter,
-		rootNamespace: rootNamespace,
+		klient:            klient,
+		privilegedClient:  privilegedClient,
+		userClientFactory: userClientFactory,
+		awaiter:           awaiter,
+		rootNamespace:     rootNamespace,
 	}
 }
 
 type CreateUPSIMessage struct {
-	Name        string
-	SpaceGUID   string
-	Credentials map[string]any
-	Tags        []string
-	Labels      map[string]string
-	Annotations map[string]string
+	Name            string
+	SpaceGUID       string
+	Credentials     map[string]any
+	RouteServiceURL string
+	SyslogDrainURL  string
+	Tags            []string
+	Labels          map[string]string
+	Annotations     map[string]string
 }
 
 type CreateManagedSIMessage struct {
@@ -69,11 +78,16 @@ type CreateManagedSIMessage struct {
 }
 
 type PatchServiceInstanceMessage struct {
-	GUID        string
-	SpaceGUID   string
-	Name        *string
-	Credentials *map[string]any
-	Tags        *[]string
+	GUID            string
+	SpaceGUID       string
+	Name            *string
+	Credentials     *map[string]any
+	RouteServiceURL *string
+	SyslogDrainURL  *string
+	Tags            *[]string
+	PlanGUID        *string
+	Parameters      *map[string]any
+	MaintenanceInfo *MaintenanceInfo
 	MetadataPatch
 }
 
@@ -84,6 +98,22 @@ func (p PatchServiceInstanceMessage) Apply(cfServiceInstance *korifiv1alpha1.CFS
 	if p.Tags != nil {
 		cfServiceInstance.Spec.Tags = *p.Tags
 	}
+	if p.RouteServiceURL != nil {
+		cfServiceInstance.Spec.RouteServiceURL = *p.RouteServiceURL
+	}
+	if p.SyslogDrainURL != nil {
+		cfServiceInstance.Spec.SyslogDrainURL = *p.SyslogDrainURL
+	}
+	if p.PlanGUID != nil && *p.PlanGUID != cfServiceInstance.Spec.PlanGUID {
+		cfServiceInstance.Spec.PlanGUID = *p.PlanGUID
+		// A previously requested upgrade does not apply to the new plan
+		cfServiceInstance.Spec.MaintenanceInfo = nil
+	}
+	if p.MaintenanceInfo != nil {
+		cfServiceInstance.Spec.MaintenanceInfo = &korifiv1alpha1.MaintenanceInfo{
+			Version: p.MaintenanceInfo.Version,
+		}
+	}
 	p.MetadataPatch.Apply(cfServiceInstance)
 }
 
@@ -123,6 +153,21 @@ type DeleteServiceInstanceMessage struct {
 	Purge bool
 }
 
+type ShareServiceInstanceMessage struct {
+	GUID       string
+	SpaceGUIDs []string
+}
+
+type UnshareServiceInstanceMessage struct {
+	GUID      string
+	SpaceGUID string
+}
+
+type SharedSpaceUsageRecord struct {
+	SpaceGUID     string
+	BoundAppCount int
+}
+
 type ServiceInstanceRecord struct {
 	Name             string
 	GUID             string
@@ -139,6 +184,9 @@ type ServiceInstanceRecord struct {
 	Ready            bool
 	MaintenanceInfo  MaintenanceInfo
 	UpgradeAvailable bool
+	SharedSpaceGUIDs []string
+	RouteServiceURL  string
+	SyslogDrainURL   string
 }
 
 func (r ServiceInstanceRecord) Relationships() map[string]string {
@@ -161,10 +209,12 @@ func (r *ServiceInstanceRepo) CreateUserProvidedServiceInstance(ctx context.Cont
 			Annotations: message.Annotations,
 		},
 		Spec: korifiv1alpha1.CFServiceInstanceSpec{
-			DisplayName: message.Name,
-			SecretName:  uuid.NewString(),
-			Type:        korifiv1alpha1.UserProvidedType,
-			Tags:        message.Tags,
+			DisplayName:     message.Name,
+			SecretName:      uuid.NewString(),
+			Type:            korifiv1alpha1.UserProvidedType,
+			Tags:            message.Tags,
+			RouteServiceURL: message.RouteServiceURL,
+			SyslogDrainURL:  message.SyslogDrainURL,
 		},
 	}
 	err := r.klient.Create(ctx, cfServiceInstance)
@@ -285,14 +335,39 @@ func (r *ServiceInstanceRepo) PatchServiceInstance(ctx context.Context, authInfo
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
 	}
 
+	if message.PlanGUID != nil && *message.PlanGUID != cfServiceInstance.Spec.PlanGUID {
+		planVisible, err := r.servicePlanVisible(ctx, *message.PlanGUID, message.SpaceGUID)
+		if err != nil {
+			return ServiceInstanceRecord{}, apierrors.NewUnprocessableEntityError(err, "Invalid service plan. Ensure that the service plan exists, is available, and you have access to it.")
+		}
+
+		if !planVisible {
+			return ServiceInstanceRecord{}, apierrors.NewUnprocessableEntityError(nil, "Invalid service plan. Ensure that the service plan exists, is available, and you have access to it.")
+		}
+	}
+
 	err := r.klient.Patch(ctx, cfServiceInstance, func() error {
 		message.Apply(cfServiceInstance)
+		if message.Parameters != nil {
+			// The parameters are written to a new secret so that the
+			// controller can tell they have changed
+			cfServiceInstance.Spec.Parameters = corev1.LocalObjectReference{
+				Name: uuid.NewString(),
+			}
+		}
 		return nil
 	})
 	if err != nil {
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
 	}
 
+	if message.Parameters != nil {
+		err = r.createParametersSecret(ctx, cfServiceInstance, *message.Parameters)
+		if err != nil {
+			return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
+		}
+	}
+
 	if message.Credentials != nil {
 		cfServiceInstance, err = r.migrateLegacyCredentials(ctx, cfServiceInstance)
 		if err != nil {
@@ -457,6 +532,141 @@ func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInf
 	return cfServiceInstanceToRecord(*serviceInstance), nil
 }
 
+func (r *ServiceInstanceRepo) ShareServiceInstance(ctx context.Context, authInfo authorization.Info, message ShareServiceInstanceMessage) (ServiceInstanceRecord, error) {
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: message.GUID,
+		},
+	}
+	if err := r.klient.Get(ctx, serviceInstance); err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	err := r.klient.Patch(ctx, serviceInstance, func() error {
+		for _, spaceGUID := range message.SpaceGUIDs {
+			if !slices.Contains(serviceInstance.Spec.SharedSpaces, spaceGUID) {
+				serviceInstance.Spec.SharedSpaces = append(serviceInstance.Spec.SharedSpaces, spaceGUID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to share service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return cfServiceInstanceToRecord(*serviceInstance), nil
+}
+
+// CanShareServiceInstanceWith tells whether the user can share service
+// instances with the given space, i.e. whether they are a space developer (or
+// admin) in it and can therefore bind service instances there
+func (r *ServiceInstanceRepo) CanShareServiceInstanceWith(ctx context.Context, authInfo authorization.Info, spaceGUID string) (bool, error) {
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: spaceGUID,
+				Verb:      "create",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfservicebindings",
+			},
+		},
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return false, fmt.Errorf("CanShareServiceInstanceWith: failed to build user client: %w", err)
+	}
+
+	if err := userClient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("CanShareServiceInstanceWith: failed to create self subject access review: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
+func (r *ServiceInstanceRepo) UnshareServiceInstance(ctx context.Context, authInfo authorization.Info, message UnshareServiceInstanceMessage) error {
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: message.GUID,
+		},
+	}
+	if err := r.klient.Get(ctx, serviceInstance); err != nil {
+		return fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	err := r.klient.Patch(ctx, serviceInstance, func() error {
+		serviceInstance.Spec.SharedSpaces = slices.DeleteFunc(serviceInstance.Spec.SharedSpaces, func(spaceGUID string) bool {
+			return spaceGUID == message.SpaceGUID
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unshare service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return nil
+}
+
+// GetSharedServiceInstance returns a service instance that has been shared
+// with the given space. Users in that space usually cannot read the space the
+// instance has been created in, therefore the instance is fetched with the
+// privileged client.
+func (r *ServiceInstanceRepo) GetSharedServiceInstance(ctx context.Context, authInfo authorization.Info, guid string, spaceGUID string) (ServiceInstanceRecord, error) {
+	serviceInstances := &korifiv1alpha1.CFServiceInstanceList{}
+	if err := r.privilegedClient.List(ctx, serviceInstances, client.MatchingLabels{korifiv1alpha1.GUIDLabelKey: guid}); err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to list service instances: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	for _, serviceInstance := range serviceInstances.Items {
+		if slices.Contains(serviceInstance.Spec.SharedSpaces, spaceGUID) {
+			return cfServiceInstanceToRecord(serviceInstance), nil
+		}
+	}
+
+	return ServiceInstanceRecord{}, apierrors.NewNotFoundError(nil, ServiceInstanceResourceType)
+}
+
+// GetSharedSpacesUsageSummary returns the number of apps bound to the service
+// instance in each of the spaces it is shared with. The bindings live in
+// spaces the user might not be able to read, therefore they are counted with
+// the privileged client.
+func (r *ServiceInstanceRepo) GetSharedSpacesUsageSummary(ctx context.Context, authInfo authorization.Info, guid string) ([]SharedSpaceUsageRecord, error) {
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: guid,
+		},
+	}
+	if err := r.klient.Get(ctx, serviceInstance); err != nil {
+		return nil, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	serviceBindings := &korifiv1alpha1.CFServiceBindingList{}
+	if err := r.privilegedClient.List(ctx, serviceBindings, client.MatchingLabels{
+		korifiv1alpha1.CFServiceInstanceGUIDLabelKey: guid,
+		korifiv1alpha1.CFServiceBindingTypeLabelKey:  korifiv1alpha1.CFServiceBindingTypeApp,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list service bindings: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	boundApps := map[string]map[string]bool{}
+	for _, binding := range serviceBindings.Items {
+		if binding.ServiceInstanceNamespace() != serviceInstance.Namespace {
+			continue
+		}
+		if boundApps[binding.Namespace] == nil {
+			boundApps[binding.Namespace] = map[string]bool{}
+		}
+		boundApps[binding.Namespace][binding.Spec.AppRef.Name] = true
+	}
+
+	return slices.Collect(it.Map(slices.Values(serviceInstance.Spec.SharedSpaces), func(spaceGUID string) SharedSpaceUsageRecord {
+		return SharedSpaceUsageRecord{
+			SpaceGUID:     spaceGUID,
+			BoundAppCount: len(boundApps[spaceGUID]),
+		}
+	})), nil
+}
+
 func (r ServiceInstanceRecord) GetResourceType() string {
 	return ServiceInstanceResourceType
 }
@@ -501,6 +711,9 @@ func cfServiceInstanceToRecord(cfServiceInstance korifiv1alpha1.CFServiceInstanc
 			Version: cfServiceInstance.Status.MaintenanceInfo.Version,
 		},
 		UpgradeAvailable: cfServiceInstance.Status.UpgradeAvailable,
+		SharedSpaceGUIDs: cfServiceInstance.Spec.SharedSpaces,
+		RouteServiceURL:  cfServiceInstance.Spec.RouteServiceURL,
+		SyslogDrainURL:   cfServiceInstance.Spec.SyslogDrainURL,
 	}
 }
 
@@ -53,6 +53,8 @@ var _ = Describe("ServiceInstanceRepository", func() {
 
 		serviceInstanceRepo = repositories.NewServiceInstanceRepo(
 			spaceScopedKlient,
+			k8sClient,
+			userClientFactory,
 			conditionAwaiter,
 			rootNamespace,
 		)
@@ -76,7 +78,8 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Credentials: map[string]any{
 					"object": map[string]any{"a": "b"},
 				},
-				Tags: []string{"foo", "bar"},
+				Tags:           []string{"foo", "bar"},
+				SyslogDrainURL: "syslog://logs.example.com:514",
 			}
 		})
 
@@ -101,6 +104,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(record.Name).To(Equal(serviceInstanceName))
 				Expect(record.Type).To(Equal("user-provided"))
 				Expect(record.Tags).To(ConsistOf([]string{"foo", "bar"}))
+				Expect(record.SyslogDrainURL).To(Equal("syslog://logs.example.com:514"))
 				Expect(record.Relationships()).To(Equal(map[string]string{
 					"space": space.Name,
 				}))
@@ -124,6 +128,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(cfServiceInstance.Spec.SecretName).NotTo(BeEmpty())
 				Expect(cfServiceInstance.Spec.Type).To(BeEquivalentTo(korifiv1alpha1.UserProvidedType))
 				Expect(cfServiceInstance.Spec.Tags).To(ConsistOf("foo", "bar"))
+				Expect(cfServiceInstance.Spec.SyslogDrainURL).To(Equal("syslog://logs.example.com:514"))
 			})
 
 			It("creates the credentials secret", func() {
@@ -578,6 +583,23 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				}).Should(Succeed())
 			})
 
+			When("the syslog drain url is set", func() {
+				BeforeEach(func() {
+					patchMessage.SyslogDrainURL = tools.PtrTo("syslog-tls://logs.example.com:6514")
+				})
+
+				It("updates the syslog drain url", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(serviceInstanceRecord.SyslogDrainURL).To(Equal("syslog-tls://logs.example.com:6514"))
+
+					serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+					Eventually(func(g Gomega) {
+						g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+						g.Expect(serviceInstance.Spec.SyslogDrainURL).To(Equal("syslog-tls://logs.example.com:6514"))
+					}).Should(Succeed())
+				})
+			})
+
 			When("tags is an empty list", func() {
 				BeforeEach(func() {
 					patchMessage.Tags = &[]string{}
@@ -622,6 +644,114 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				}).Should(Succeed())
 			})
 
+			When("the plan is changed", func() {
+				var servicePlan *korifiv1alpha1.CFServicePlan
+
+				BeforeEach(func() {
+					servicePlan = &korifiv1alpha1.CFServicePlan{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFServicePlanSpec{
+							Visibility: korifiv1alpha1.ServicePlanVisibility{
+								Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+							},
+						},
+					}
+					Expect(k8sClient.Create(ctx, servicePlan)).To(Succeed())
+
+					Expect(k8s.PatchResource(ctx, k8sClient, cfServiceInstance, func() {
+						cfServiceInstance.Spec.MaintenanceInfo = &korifiv1alpha1.MaintenanceInfo{Version: "1.0.0"}
+					})).To(Succeed())
+
+					patchMessage.PlanGUID = tools.PtrTo(servicePlan.Name)
+				})
+
+				It("sets the new plan and clears the requested maintenance info", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(serviceInstanceRecord.PlanGUID).To(Equal(servicePlan.Name))
+
+					serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Spec.PlanGUID).To(Equal(servicePlan.Name))
+					Expect(serviceInstance.Spec.MaintenanceInfo).To(BeNil())
+				})
+
+				When("the plan is not visible", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, servicePlan, func() {
+							servicePlan.Spec.Visibility.Type = korifiv1alpha1.AdminServicePlanVisibilityType
+						})).To(Succeed())
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+
+				When("the plan does not exist", func() {
+					BeforeEach(func() {
+						patchMessage.PlanGUID = tools.PtrTo("i-do-not-exist")
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+			})
+
+			When("a maintenance upgrade is requested", func() {
+				BeforeEach(func() {
+					patchMessage.MaintenanceInfo = &repositories.MaintenanceInfo{Version: "2.0.0"}
+				})
+
+				It("sets the maintenance info in the spec", func() {
+					Expect(err).NotTo(HaveOccurred())
+
+					serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Spec.MaintenanceInfo).To(PointTo(Equal(korifiv1alpha1.MaintenanceInfo{Version: "2.0.0"})))
+				})
+			})
+
+			When("parameters are provided", func() {
+				var previousParamsSecret *corev1.Secret
+
+				BeforeEach(func() {
+					previousParamsSecret = &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: space.Name,
+							Name:      uuid.NewString(),
+						},
+					}
+					Expect(k8sClient.Create(ctx, previousParamsSecret)).To(Succeed())
+
+					Expect(k8s.PatchResource(ctx, k8sClient, cfServiceInstance, func() {
+						cfServiceInstance.Spec.Parameters.Name = previousParamsSecret.Name
+					})).To(Succeed())
+
+					patchMessage.Parameters = &map[string]any{"p1": "v1"}
+				})
+
+				It("references a new parameters secret", func() {
+					Expect(err).NotTo(HaveOccurred())
+
+					serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Spec.Parameters.Name).NotTo(BeEmpty())
+					Expect(serviceInstance.Spec.Parameters.Name).NotTo(Equal(previousParamsSecret.Name))
+
+					paramsSecret := &corev1.Secret{}
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: space.Name, Name: serviceInstance.Spec.Parameters.Name}, paramsSecret)).To(Succeed())
+					Expect(paramsSecret.Data).To(MatchAllKeys(Keys{tools.ParametersSecretKey: MatchJSON(`{"p1":"v1"}`)}))
+					Expect(paramsSecret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Kind": Equal("CFServiceInstance"),
+						"Name": Equal(cfServiceInstance.Name),
+					})))
+				})
+			})
+
 			When("ServiceInstance credentials are provided", func() {
 				BeforeEach(func() {
 					patchMessage.Credentials = &map[string]any{
@@ -738,7 +868,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			DescribeTable("ordering",
 				func(msg repositories.ListServiceInstanceMessage, match gomega_types.GomegaMatcher) {
 					fakeKlient := new(fake.Klient)
-					instancesRepo := repositories.NewServiceInstanceRepo(fakeKlient, conditionAwaiter, rootNamespace)
+					instancesRepo := repositories.NewServiceInstanceRepo(fakeKlient, nil, userClientFactory, conditionAwaiter, rootNamespace)
 
 					_, err := instancesRepo.ListServiceInstances(ctx, authInfo, msg)
 					Expect(err).NotTo(HaveOccurred())
@@ -756,7 +886,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 
 				BeforeEach(func() {
 					fakeKlient = new(fake.Klient)
-					serviceInstanceRepo = repositories.NewServiceInstanceRepo(fakeKlient, conditionAwaiter, rootNamespace)
+					serviceInstanceRepo = repositories.NewServiceInstanceRepo(fakeKlient, nil, userClientFactory, conditionAwaiter, rootNamespace)
 					filters = repositories.ListServiceInstanceMessage{
 						Names:         []string{"instance-1", "instance-2"},
 						SpaceGUIDs:    []string{"space-guid-1", "space-guid-2"},
@@ -1080,4 +1210,245 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			})
 		})
 	})
+
+	Describe("ShareServiceInstance", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			shareMessage    repositories.ShareServiceInstanceMessage
+			record          repositories.ServiceInstanceRecord
+			shareErr        error
+		)
+
+		BeforeEach(func() {
+			serviceInstance = createServiceInstanceCR(ctx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+
+			shareMessage = repositories.ShareServiceInstanceMessage{
+				GUID:       serviceInstance.Name,
+				SpaceGUIDs: []string{"space-1", "space-2"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, shareErr = serviceInstanceRepo.ShareServiceInstance(ctx, authInfo, shareMessage)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(errors.As(shareErr, &apierrors.ForbiddenError{})).To(BeTrue())
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("shares the service instance with the spaces", func() {
+				Expect(shareErr).NotTo(HaveOccurred())
+				Expect(record.SharedSpaceGUIDs).To(ConsistOf("space-1", "space-2"))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceInstance), serviceInstance)).To(Succeed())
+				Expect(serviceInstance.Spec.SharedSpaces).To(ConsistOf("space-1", "space-2"))
+			})
+
+			When("the service instance is already shared with some of the spaces", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+						serviceInstance.Spec.SharedSpaces = []string{"space-1"}
+					})).To(Succeed())
+				})
+
+				It("does not duplicate them", func() {
+					Expect(shareErr).NotTo(HaveOccurred())
+					Expect(record.SharedSpaceGUIDs).To(Equal([]string{"space-1", "space-2"}))
+				})
+			})
+
+			When("the service instance does not exist", func() {
+				BeforeEach(func() {
+					shareMessage.GUID = "does-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(errors.As(shareErr, &apierrors.NotFoundError{})).To(BeTrue())
+				})
+			})
+		})
+	})
+
+	Describe("CanShareServiceInstanceWith", func() {
+		var (
+			canShare bool
+			err      error
+		)
+
+		JustBeforeEach(func() {
+			canShare, err = serviceInstanceRepo.CanShareServiceInstanceWith(ctx, authInfo, space.Name)
+		})
+
+		It("returns false", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(canShare).To(BeFalse())
+		})
+
+		When("the user is a space manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceManagerRole.Name, space.Name)
+			})
+
+			It("returns false", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(canShare).To(BeFalse())
+			})
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns true", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(canShare).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("UnshareServiceInstance", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			unshareErr      error
+		)
+
+		BeforeEach(func() {
+			serviceInstance = createServiceInstanceCR(ctx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+			Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+				serviceInstance.Spec.SharedSpaces = []string{"space-1", "space-2"}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			unshareErr = serviceInstanceRepo.UnshareServiceInstance(ctx, authInfo, repositories.UnshareServiceInstanceMessage{
+				GUID:      serviceInstance.Name,
+				SpaceGUID: "space-1",
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(errors.As(unshareErr, &apierrors.ForbiddenError{})).To(BeTrue())
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("removes the space from the shared spaces", func() {
+				Expect(unshareErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceInstance), serviceInstance)).To(Succeed())
+				Expect(serviceInstance.Spec.SharedSpaces).To(ConsistOf("space-2"))
+			})
+		})
+	})
+
+	Describe("GetSharedServiceInstance", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			spaceGUID       string
+			record          repositories.ServiceInstanceRecord
+			getErr          error
+		)
+
+		BeforeEach(func() {
+			serviceInstance = createServiceInstanceCR(ctx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+			Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+				serviceInstance.Spec.SharedSpaces = []string{"shared-space"}
+			})).To(Succeed())
+
+			spaceGUID = "shared-space"
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = serviceInstanceRepo.GetSharedServiceInstance(ctx, authInfo, serviceInstance.Name, spaceGUID)
+		})
+
+		It("returns the instance although the user cannot access its space", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record.GUID).To(Equal(serviceInstance.Name))
+			Expect(record.SpaceGUID).To(Equal(space.Name))
+			Expect(record.SharedSpaceGUIDs).To(ConsistOf("shared-space"))
+		})
+
+		When("the instance is not shared with the space", func() {
+			BeforeEach(func() {
+				spaceGUID = "another-space"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("GetSharedSpacesUsageSummary", func() {
+		var (
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			sharedSpace     *korifiv1alpha1.CFSpace
+			usageSummary    []repositories.SharedSpaceUsageRecord
+			getErr          error
+		)
+
+		createBinding := func(namespace, appGUID string) {
+			GinkgoHelper()
+
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Type: korifiv1alpha1.CFServiceBindingTypeApp,
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+						Name:       serviceInstance.Name,
+						Namespace:  serviceInstance.Namespace,
+					},
+					AppRef: corev1.LocalObjectReference{Name: appGUID},
+				},
+			})).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			sharedSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("shared-space"))
+			serviceInstance = createServiceInstanceCR(ctx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+			Expect(k8s.PatchResource(ctx, k8sClient, serviceInstance, func() {
+				serviceInstance.Spec.SharedSpaces = []string{sharedSpace.Name, "unused-space"}
+			})).To(Succeed())
+
+			createBinding(sharedSpace.Name, "app-1")
+			createBinding(sharedSpace.Name, "app-2")
+		})
+
+		JustBeforeEach(func() {
+			usageSummary, getErr = serviceInstanceRepo.GetSharedSpacesUsageSummary(ctx, authInfo, serviceInstance.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(errors.As(getErr, &apierrors.ForbiddenError{})).To(BeTrue())
+		})
+
+		When("the user can read the service instance", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("counts the bound apps in each shared space", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(usageSummary).To(ConsistOf(
+					repositories.SharedSpaceUsageRecord{SpaceGUID: sharedSpace.Name, BoundAppCount: 2},
+					repositories.SharedSpaceUsageRecord{SpaceGUID: "unused-space", BoundAppCount: 0},
+				))
+			})
+		})
+	})
 })
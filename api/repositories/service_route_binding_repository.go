@@ -0,0 +1,318 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const ServiceRouteBindingResourceType = "Service Route Binding"
+
+type ServiceRouteBindingRepo struct {
+	klient                  Klient
+	bindingConditionAwaiter Awaiter[*korifiv1alpha1.CFServiceRouteBinding]
+}
+
+func NewServiceRouteBindingRepo(
+	klient Klient,
+	bindingConditionAwaiter Awaiter[*korifiv1alpha1.CFServiceRouteBinding],
+) *ServiceRouteBindingRepo {
+	return &ServiceRouteBindingRepo{
+		klient:                  klient,
+		bindingConditionAwaiter: bindingConditionAwaiter,
+	}
+}
+
+type ServiceRouteBindingRecord struct {
+	GUID                string
+	RouteServiceURL     string
+	ServiceInstanceGUID string
+	RouteGUID           string
+	SpaceGUID           string
+	Labels              map[string]string
+	Annotations         map[string]string
+	CreatedAt           time.Time
+	UpdatedAt           *time.Time
+	DeletedAt           *time.Time
+	LastOperation       ServiceBindingLastOperation
+	Ready               bool
+}
+
+func (r ServiceRouteBindingRecord) Relationships() map[string]string {
+	return map[string]string{
+		"service_instance": r.ServiceInstanceGUID,
+		"route":            r.RouteGUID,
+	}
+}
+
+type CreateServiceRouteBindingMessage struct {
+	ServiceInstanceGUID string
+	RouteGUID           string
+	SpaceGUID           string
+	Parameters          map[string]any
+	Labels              map[string]string
+	Annotations         map[string]string
+}
+
+func (m CreateServiceRouteBindingMessage) toCFServiceRouteBinding(instanceType korifiv1alpha1.InstanceType) *korifiv1alpha1.CFServiceRouteBinding {
+	routeBinding := &korifiv1alpha1.CFServiceRouteBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   m.SpaceGUID,
+			Labels:      m.Labels,
+			Annotations: m.Annotations,
+		},
+		Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+			ServiceInstanceRef: corev1.LocalObjectReference{Name: m.ServiceInstanceGUID},
+			RouteRef:           corev1.LocalObjectReference{Name: m.RouteGUID},
+		},
+	}
+
+	if instanceType == korifiv1alpha1.ManagedType {
+		routeBinding.Spec.Parameters.Name = uuid.NewString()
+	}
+
+	return routeBinding
+}
+
+type ListServiceRouteBindingsMessage struct {
+	ServiceInstanceGUIDs []string
+	RouteGUIDs           []string
+	LabelSelector        string
+	OrderBy              string
+	Pagination           Pagination
+}
+
+func (m *ListServiceRouteBindingsMessage) toListOptions() []ListOption {
+	return []ListOption{
+		WithLabelSelector(m.LabelSelector),
+		WithLabelIn(korifiv1alpha1.CFServiceInstanceGUIDLabelKey, m.ServiceInstanceGUIDs),
+		WithLabelIn(korifiv1alpha1.CFRouteGUIDLabelKey, m.RouteGUIDs),
+		WithOrdering(m.OrderBy),
+		WithPaging(m.Pagination),
+	}
+}
+
+func (r *ServiceRouteBindingRepo) CreateServiceRouteBinding(ctx context.Context, authInfo authorization.Info, message CreateServiceRouteBindingMessage) (ServiceRouteBindingRecord, error) {
+	cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      message.ServiceInstanceGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, cfServiceInstance); err != nil {
+		return ServiceRouteBindingRecord{},
+			apierrors.AsUnprocessableEntity(
+				apierrors.FromK8sError(err, ServiceRouteBindingResourceType),
+				unableToBindErrorMessage,
+				apierrors.ForbiddenError{},
+				apierrors.NotFoundError{},
+			)
+	}
+
+	cfRouteBinding := message.toCFServiceRouteBinding(cfServiceInstance.Spec.Type)
+	err := r.klient.Create(ctx, cfRouteBinding)
+	if err != nil {
+		if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+			if validationError.Type == validation.DuplicateNameErrorType {
+				return ServiceRouteBindingRecord{}, apierrors.NewUniquenessError(err, validationError.GetMessage())
+			}
+		}
+
+		return ServiceRouteBindingRecord{}, apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+	}
+
+	if cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		err = r.createParametersSecret(ctx, cfRouteBinding, message.Parameters)
+		if err != nil {
+			return ServiceRouteBindingRecord{}, apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+		}
+
+		return serviceRouteBindingToRecord(*cfRouteBinding), nil
+	}
+
+	cfRouteBinding, err = r.bindingConditionAwaiter.AwaitCondition(ctx, r.klient, cfRouteBinding, korifiv1alpha1.StatusConditionReady)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, err
+	}
+
+	return serviceRouteBindingToRecord(*cfRouteBinding), nil
+}
+
+func (r *ServiceRouteBindingRepo) createParametersSecret(ctx context.Context, cfRouteBinding *korifiv1alpha1.CFServiceRouteBinding, parameters map[string]any) error {
+	parametersData, err := tools.ToParametersSecretData(parameters)
+	if err != nil {
+		return err
+	}
+
+	paramsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfRouteBinding.Namespace,
+			Name:      cfRouteBinding.Spec.Parameters.Name,
+		},
+		Data: parametersData,
+	}
+
+	_ = controllerutil.SetOwnerReference(cfRouteBinding, paramsSecret, scheme.Scheme)
+
+	return r.klient.Create(ctx, paramsSecret)
+}
+
+func (r *ServiceRouteBindingRepo) GetServiceRouteBinding(ctx context.Context, authInfo authorization.Info, guid string) (ServiceRouteBindingRecord, error) {
+	routeBinding := &korifiv1alpha1.CFServiceRouteBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: guid,
+		},
+	}
+	err := r.klient.Get(ctx, routeBinding)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, fmt.Errorf("failed to get service route binding: %w", apierrors.FromK8sError(err, ServiceRouteBindingResourceType))
+	}
+
+	return serviceRouteBindingToRecord(*routeBinding), nil
+}
+
+// nolint:dupl
+func (r *ServiceRouteBindingRepo) ListServiceRouteBindings(ctx context.Context, authInfo authorization.Info, message ListServiceRouteBindingsMessage) (ListResult[ServiceRouteBindingRecord], error) {
+	routeBindingList := new(korifiv1alpha1.CFServiceRouteBindingList)
+	pageInfo, err := r.klient.List(ctx, routeBindingList, message.toListOptions()...)
+	if err != nil {
+		return ListResult[ServiceRouteBindingRecord]{}, fmt.Errorf("failed to list service route bindings: %w",
+			apierrors.FromK8sError(err, ServiceRouteBindingResourceType),
+		)
+	}
+
+	records := slices.Collect(it.Map(slices.Values(routeBindingList.Items), serviceRouteBindingToRecord))
+	return ListResult[ServiceRouteBindingRecord]{
+		PageInfo: pageInfo,
+		Records:  records,
+	}, nil
+}
+
+func (r *ServiceRouteBindingRepo) DeleteServiceRouteBinding(ctx context.Context, authInfo authorization.Info, guid string) error {
+	routeBinding := &korifiv1alpha1.CFServiceRouteBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: guid,
+		},
+	}
+
+	err := r.klient.Get(ctx, routeBinding)
+	if err != nil {
+		return apierrors.ForbiddenAsNotFound(apierrors.FromK8sError(err, ServiceRouteBindingResourceType))
+	}
+
+	err = r.klient.Delete(ctx, routeBinding)
+	if err != nil {
+		return apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+	}
+
+	return nil
+}
+
+func (r *ServiceRouteBindingRepo) GetState(ctx context.Context, authInfo authorization.Info, guid string) (ResourceState, error) {
+	routeBindingRecord, err := r.GetServiceRouteBinding(ctx, authInfo, guid)
+	if err != nil {
+		return ResourceStateUnknown, err
+	}
+
+	if routeBindingRecord.Ready {
+		return ResourceStateReady, nil
+	}
+
+	return ResourceStateUnknown, nil
+}
+
+func (r *ServiceRouteBindingRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	routeBindingRecord, err := r.GetServiceRouteBinding(ctx, authInfo, guid)
+	if err != nil {
+		return nil, err
+	}
+	return routeBindingRecord.DeletedAt, nil
+}
+
+func serviceRouteBindingToRecord(routeBinding korifiv1alpha1.CFServiceRouteBinding) ServiceRouteBindingRecord {
+	return ServiceRouteBindingRecord{
+		GUID:                routeBinding.Name,
+		RouteServiceURL:     routeBinding.Status.RouteServiceURL,
+		ServiceInstanceGUID: routeBinding.Spec.ServiceInstanceRef.Name,
+		RouteGUID:           routeBinding.Spec.RouteRef.Name,
+		SpaceGUID:           routeBinding.Namespace,
+		Labels:              routeBinding.Labels,
+		Annotations:         routeBinding.Annotations,
+		CreatedAt:           routeBinding.CreationTimestamp.Time,
+		UpdatedAt:           getLastUpdatedTime(&routeBinding),
+		DeletedAt:           golangTime(routeBinding.DeletionTimestamp),
+		LastOperation:       serviceRouteBindingRecordLastOperation(routeBinding),
+		Ready:               isServiceRouteBindingReady(routeBinding),
+	}
+}
+
+func isServiceRouteBindingReady(routeBinding korifiv1alpha1.CFServiceRouteBinding) bool {
+	if routeBinding.Generation != routeBinding.Status.ObservedGeneration {
+		return false
+	}
+
+	return meta.IsStatusConditionTrue(routeBinding.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+}
+
+func serviceRouteBindingRecordLastOperation(routeBinding korifiv1alpha1.CFServiceRouteBinding) ServiceBindingLastOperation {
+	if routeBinding.DeletionTimestamp != nil {
+		return ServiceBindingLastOperation{
+			Type:      "delete",
+			State:     "in progress",
+			CreatedAt: routeBinding.DeletionTimestamp.Time,
+			UpdatedAt: getLastUpdatedTime(&routeBinding),
+		}
+	}
+
+	readyCondition := meta.FindStatusCondition(routeBinding.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+	if readyCondition == nil {
+		return ServiceBindingLastOperation{
+			Type:      "create",
+			State:     "initial",
+			CreatedAt: routeBinding.CreationTimestamp.Time,
+			UpdatedAt: getLastUpdatedTime(&routeBinding),
+		}
+	}
+
+	if readyCondition.Status == metav1.ConditionTrue {
+		return ServiceBindingLastOperation{
+			Type:      "create",
+			State:     "succeeded",
+			CreatedAt: routeBinding.CreationTimestamp.Time,
+			UpdatedAt: getLastUpdatedTime(&routeBinding),
+		}
+	}
+
+	if meta.IsStatusConditionTrue(routeBinding.Status.Conditions, korifiv1alpha1.BindingFailedCondition) {
+		return ServiceBindingLastOperation{
+			Type:        "create",
+			State:       "failed",
+			Description: tools.PtrTo(readyCondition.Message),
+			CreatedAt:   routeBinding.CreationTimestamp.Time,
+			UpdatedAt:   tools.PtrTo(readyCondition.LastTransitionTime.Time),
+		}
+	}
+
+	return ServiceBindingLastOperation{
+		Type:      "create",
+		State:     "in progress",
+		CreatedAt: routeBinding.CreationTimestamp.Time,
+		UpdatedAt: tools.PtrTo(readyCondition.LastTransitionTime.Time),
+	}
+}
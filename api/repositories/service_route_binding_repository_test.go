@@ -0,0 +1,384 @@
+package repositories_test
+
+import (
+	"context"
+	"errors"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServiceRouteBindingRepo", func() {
+	var (
+		repo  *repositories.ServiceRouteBindingRepo
+		org   *korifiv1alpha1.CFOrg
+		space *korifiv1alpha1.CFSpace
+
+		routeGUID               string
+		bindingConditionAwaiter *fakeawaiter.FakeAwaiter[
+			*korifiv1alpha1.CFServiceRouteBinding,
+			korifiv1alpha1.CFServiceRouteBindingList,
+			*korifiv1alpha1.CFServiceRouteBindingList,
+		]
+	)
+
+	BeforeEach(func() {
+		bindingConditionAwaiter = &fakeawaiter.FakeAwaiter[
+			*korifiv1alpha1.CFServiceRouteBinding,
+			korifiv1alpha1.CFServiceRouteBindingList,
+			*korifiv1alpha1.CFServiceRouteBindingList,
+		]{}
+
+		repo = repositories.NewServiceRouteBindingRepo(spaceScopedKlient, bindingConditionAwaiter)
+
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space1"))
+		routeGUID = prefixedGUID("route")
+	})
+
+	Describe("CreateServiceRouteBinding", func() {
+		var (
+			cfServiceInstance *korifiv1alpha1.CFServiceInstance
+			record            repositories.ServiceRouteBindingRecord
+			createErr         error
+		)
+
+		BeforeEach(func() {
+			cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: space.Name,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					Type:            korifiv1alpha1.UserProvidedType,
+					RouteServiceURL: "https://route-service.example.com",
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfServiceInstance)).To(Succeed())
+
+			bindingConditionAwaiter.AwaitConditionStub = func(ctx context.Context, _ repositories.Klient, object client.Object, _ string) (*korifiv1alpha1.CFServiceRouteBinding, error) {
+				cfRouteBinding, ok := object.(*korifiv1alpha1.CFServiceRouteBinding)
+				Expect(ok).To(BeTrue())
+
+				Expect(k8s.Patch(ctx, k8sClient, cfRouteBinding, func() {
+					cfRouteBinding.Status.RouteServiceURL = "https://route-service.example.com"
+					cfRouteBinding.Status.ObservedGeneration = cfRouteBinding.Generation
+					meta.SetStatusCondition(&cfRouteBinding.Status.Conditions, metav1.Condition{
+						Type:    korifiv1alpha1.StatusConditionReady,
+						Status:  metav1.ConditionTrue,
+						Reason:  "blah",
+						Message: "blah",
+					})
+				})).To(Succeed())
+
+				return cfRouteBinding, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = repo.CreateServiceRouteBinding(ctx, authInfo, repositories.CreateServiceRouteBindingMessage{
+				ServiceInstanceGUID: cfServiceInstance.Name,
+				RouteGUID:           routeGUID,
+				SpaceGUID:           space.Name,
+				Parameters:          map[string]any{"p1": "v1"},
+				Labels:              map[string]string{"lab": "val"},
+			})
+		})
+
+		It("returns an unprocessable entity error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates a CFServiceRouteBinding and returns a record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(matchers.BeValidUUID())
+				Expect(record.ServiceInstanceGUID).To(Equal(cfServiceInstance.Name))
+				Expect(record.RouteGUID).To(Equal(routeGUID))
+				Expect(record.SpaceGUID).To(Equal(space.Name))
+				Expect(record.RouteServiceURL).To(Equal("https://route-service.example.com"))
+				Expect(record.Labels).To(HaveKeyWithValue("lab", "val"))
+				Expect(record.Ready).To(BeTrue())
+				Expect(record.Relationships()).To(Equal(map[string]string{
+					"service_instance": cfServiceInstance.Name,
+					"route":            routeGUID,
+				}))
+
+				cfRouteBinding := new(korifiv1alpha1.CFServiceRouteBinding)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: record.GUID}, cfRouteBinding)).To(Succeed())
+				Expect(cfRouteBinding.Spec).To(Equal(korifiv1alpha1.CFServiceRouteBindingSpec{
+					ServiceInstanceRef: corev1.LocalObjectReference{Name: cfServiceInstance.Name},
+					RouteRef:           corev1.LocalObjectReference{Name: routeGUID},
+				}))
+			})
+
+			It("awaits the binding to become ready", func() {
+				Expect(bindingConditionAwaiter.AwaitConditionCallCount()).To(Equal(1))
+				obj, conditionType := bindingConditionAwaiter.AwaitConditionArgsForCall(0)
+				Expect(obj.GetName()).To(Equal(record.GUID))
+				Expect(conditionType).To(Equal(korifiv1alpha1.StatusConditionReady))
+			})
+
+			When("the binding never becomes ready", func() {
+				BeforeEach(func() {
+					bindingConditionAwaiter.AwaitConditionReturns(nil, errors.New("time-out-err"))
+				})
+
+				It("errors", func() {
+					Expect(createErr).To(MatchError(ContainSubstring("time-out-err")))
+				})
+			})
+
+			When("the service instance is managed", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfServiceInstance, func() {
+						cfServiceInstance.Spec.Type = korifiv1alpha1.ManagedType
+						cfServiceInstance.Spec.RouteServiceURL = ""
+					})).To(Succeed())
+				})
+
+				It("does not await the binding", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(bindingConditionAwaiter.AwaitConditionCallCount()).To(BeZero())
+				})
+
+				It("creates the parameters secret", func() {
+					cfRouteBinding := new(korifiv1alpha1.CFServiceRouteBinding)
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: record.GUID}, cfRouteBinding)).To(Succeed())
+					Expect(cfRouteBinding.Spec.Parameters.Name).NotTo(BeEmpty())
+
+					paramsSecret := new(corev1.Secret)
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: cfRouteBinding.Spec.Parameters.Name}, paramsSecret)).To(Succeed())
+					Expect(paramsSecret.Data).To(Equal(map[string][]byte{
+						tools.ParametersSecretKey: []byte(`{"p1":"v1"}`),
+					}))
+				})
+			})
+
+			When("the service instance does not exist", func() {
+				BeforeEach(func() {
+					cfServiceInstance.Name = "i-do-not-exist"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetServiceRouteBinding", func() {
+		var (
+			cfRouteBinding *korifiv1alpha1.CFServiceRouteBinding
+			record         repositories.ServiceRouteBindingRecord
+			getErr         error
+		)
+
+		BeforeEach(func() {
+			cfRouteBinding = &korifiv1alpha1.CFServiceRouteBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+					ServiceInstanceRef: corev1.LocalObjectReference{Name: "instance-guid"},
+					RouteRef:           corev1.LocalObjectReference{Name: routeGUID},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfRouteBinding)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetServiceRouteBinding(ctx, authInfo, cfRouteBinding.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the binding", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(cfRouteBinding.Name))
+				Expect(record.ServiceInstanceGUID).To(Equal("instance-guid"))
+				Expect(record.RouteGUID).To(Equal(routeGUID))
+				Expect(record.Ready).To(BeFalse())
+				Expect(record.LastOperation.Type).To(Equal("create"))
+				Expect(record.LastOperation.State).To(Equal("initial"))
+			})
+
+			When("the binding has failed", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfRouteBinding, func() {
+						meta.SetStatusCondition(&cfRouteBinding.Status.Conditions, metav1.Condition{
+							Type:    korifiv1alpha1.StatusConditionReady,
+							Status:  metav1.ConditionFalse,
+							Reason:  "Failed",
+							Message: "binding failed",
+						})
+						meta.SetStatusCondition(&cfRouteBinding.Status.Conditions, metav1.Condition{
+							Type:   korifiv1alpha1.BindingFailedCondition,
+							Status: metav1.ConditionTrue,
+							Reason: "Failed",
+						})
+					})).To(Succeed())
+				})
+
+				It("returns a failed last operation", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(record.LastOperation.State).To(Equal("failed"))
+					Expect(record.LastOperation.Description).To(Equal(tools.PtrTo("binding failed")))
+				})
+			})
+		})
+	})
+
+	Describe("ListServiceRouteBindings", func() {
+		var (
+			binding1, binding2 *korifiv1alpha1.CFServiceRouteBinding
+			message            repositories.ListServiceRouteBindingsMessage
+			result             repositories.ListResult[repositories.ServiceRouteBindingRecord]
+			listErr            error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+
+			binding1 = &korifiv1alpha1.CFServiceRouteBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+					ServiceInstanceRef: corev1.LocalObjectReference{Name: "instance-1"},
+					RouteRef:           corev1.LocalObjectReference{Name: "route-1"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, binding1)).To(Succeed())
+
+			binding2 = &korifiv1alpha1.CFServiceRouteBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+					ServiceInstanceRef: corev1.LocalObjectReference{Name: "instance-2"},
+					RouteRef:           corev1.LocalObjectReference{Name: "route-2"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, binding2)).To(Succeed())
+
+			message = repositories.ListServiceRouteBindingsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			result, listErr = repo.ListServiceRouteBindings(ctx, authInfo, message)
+		})
+
+		It("returns all the bindings", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(result.Records).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(binding1.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(binding2.Name)}),
+			))
+		})
+
+		Describe("list parameters", func() {
+			var fakeKlient *fake.Klient
+
+			BeforeEach(func() {
+				fakeKlient = new(fake.Klient)
+				repo = repositories.NewServiceRouteBindingRepo(fakeKlient, nil)
+				message = repositories.ListServiceRouteBindingsMessage{
+					ServiceInstanceGUIDs: []string{"s1", "s2"},
+					RouteGUIDs:           []string{"r1", "r2"},
+					LabelSelector:        "foo=bar",
+					OrderBy:              "created_at",
+					Pagination: repositories.Pagination{
+						PerPage: 10,
+						Page:    1,
+					},
+				}
+			})
+
+			It("translates filter parameters to klient list options", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(fakeKlient.ListCallCount()).To(Equal(1))
+				_, _, listOptions := fakeKlient.ListArgsForCall(0)
+				Expect(listOptions).To(ConsistOf(
+					repositories.WithLabelSelector("foo=bar"),
+					repositories.WithLabelIn(korifiv1alpha1.CFServiceInstanceGUIDLabelKey, []string{"s1", "s2"}),
+					repositories.WithLabelIn(korifiv1alpha1.CFRouteGUIDLabelKey, []string{"r1", "r2"}),
+					repositories.WithOrdering("created_at"),
+					repositories.WithPaging(repositories.Pagination{
+						PerPage: 10,
+						Page:    1,
+					}),
+				))
+			})
+		})
+	})
+
+	Describe("DeleteServiceRouteBinding", func() {
+		var (
+			cfRouteBinding *korifiv1alpha1.CFServiceRouteBinding
+			deleteErr      error
+		)
+
+		BeforeEach(func() {
+			cfRouteBinding = &korifiv1alpha1.CFServiceRouteBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+					ServiceInstanceRef: corev1.LocalObjectReference{Name: "instance-guid"},
+					RouteRef:           corev1.LocalObjectReference{Name: routeGUID},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfRouteBinding)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteServiceRouteBinding(ctx, authInfo, cfRouteBinding.Name)
+		})
+
+		It("returns a not-found error for users with no role in the space", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("deletes the binding", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRouteBinding), cfRouteBinding)).To(MatchError(ContainSubstring("not found")))
+			})
+		})
+	})
+})
@@ -0,0 +1,178 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+	"github.com/BooleanCat/go-functional/v2/it"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const ServiceUsageEventResourceType = "Service Usage Event"
+
+type ServiceUsageEventRepo struct {
+	klient            Klient
+	userClientFactory authorization.UserClientFactory
+	privilegedClient  client.Client
+	usageRecorder     *usage.Recorder
+	rootNamespace     string
+}
+
+func NewServiceUsageEventRepo(
+	klient Klient,
+	userClientFactory authorization.UserClientFactory,
+	privilegedClient client.Client,
+	rootNamespace string,
+) *ServiceUsageEventRepo {
+	return &ServiceUsageEventRepo{
+		klient:            klient,
+		userClientFactory: userClientFactory,
+		privilegedClient:  privilegedClient,
+		usageRecorder:     usage.NewRecorder(privilegedClient, rootNamespace),
+		rootNamespace:     rootNamespace,
+	}
+}
+
+type ServiceUsageEventResource struct {
+	GUID string
+	Name string
+}
+
+type ServiceUsageEventRecord struct {
+	GUID                string
+	State               string
+	ServiceInstanceGUID string
+	ServiceInstanceName string
+	ServiceInstanceType string
+	SpaceGUID           string
+	SpaceName           string
+	OrganizationGUID    string
+	ServicePlan         *ServiceUsageEventResource
+	ServiceOffering     *ServiceUsageEventResource
+	ServiceBroker       *ServiceUsageEventResource
+	CreatedAt           time.Time
+	UpdatedAt           *time.Time
+}
+
+func (r ServiceUsageEventRecord) Relationships() map[string]string {
+	return map[string]string{}
+}
+
+type ListServiceUsageEventsMessage struct {
+	GUIDs                []string
+	ServiceInstanceTypes []string
+	ServiceOfferingGUIDs []string
+	CreatedAts           []TimestampFilter
+	OrderBy              string
+	Pagination           Pagination
+}
+
+func (m *ListServiceUsageEventsMessage) toListOptions() []ListOption {
+	listOptions := []ListOption{
+		WithLabelIn(korifiv1alpha1.GUIDLabelKey, m.GUIDs),
+		WithLabelIn(korifiv1alpha1.CFServiceUsageEventInstanceTypeLabelKey, m.ServiceInstanceTypes),
+		WithLabelIn(korifiv1alpha1.CFServiceUsageEventOfferingGUIDLabelKey, m.ServiceOfferingGUIDs),
+		WithOrdering(m.OrderBy),
+		WithPaging(m.Pagination),
+	}
+
+	if len(m.CreatedAts) > 0 {
+		listOptions = append(listOptions, withCreatedAtFiltering(m.CreatedAts))
+	}
+
+	return listOptions
+}
+
+func (r *ServiceUsageEventRepo) GetServiceUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (ServiceUsageEventRecord, error) {
+	usageEvent := &korifiv1alpha1.CFServiceUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+	err := r.klient.Get(ctx, usageEvent)
+	if err != nil {
+		return ServiceUsageEventRecord{}, fmt.Errorf("failed to get service usage event: %w", apierrors.FromK8sError(err, ServiceUsageEventResourceType))
+	}
+
+	return toServiceUsageEventRecord(*usageEvent), nil
+}
+
+func (r *ServiceUsageEventRepo) ListServiceUsageEvents(ctx context.Context, authInfo authorization.Info, message ListServiceUsageEventsMessage) (ListResult[ServiceUsageEventRecord], error) {
+	usageEventList := &korifiv1alpha1.CFServiceUsageEventList{}
+	pageInfo, err := r.klient.List(ctx, usageEventList, message.toListOptions()...)
+	if err != nil {
+		return ListResult[ServiceUsageEventRecord]{}, fmt.Errorf("failed to list service usage events: %w", apierrors.FromK8sError(err, ServiceUsageEventResourceType))
+	}
+
+	return ListResult[ServiceUsageEventRecord]{
+		PageInfo: pageInfo,
+		Records:  slices.Collect(it.Map(slices.Values(usageEventList.Items), toServiceUsageEventRecord)),
+	}, nil
+}
+
+// PurgeAndReseedServiceUsageEvents deletes all service usage events and
+// records a CREATED event for every existing service instance. The events are
+// deleted with the user client so that only admins can purge them.
+func (r *ServiceUsageEventRepo) PurgeAndReseedServiceUsageEvents(ctx context.Context, authInfo authorization.Info) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.DeleteAllOf(ctx, &korifiv1alpha1.CFServiceUsageEvent{}, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return fmt.Errorf("failed to purge service usage events: %w", apierrors.FromK8sError(err, ServiceUsageEventResourceType))
+	}
+
+	serviceInstanceList := &korifiv1alpha1.CFServiceInstanceList{}
+	if err = r.privilegedClient.List(ctx, serviceInstanceList); err != nil {
+		return fmt.Errorf("failed to list service instances: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	for i := range serviceInstanceList.Items {
+		serviceInstance := &serviceInstanceList.Items[i]
+		if !serviceInstance.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if err = r.usageRecorder.SeedServiceInstanceUsage(ctx, serviceInstance); err != nil {
+			return fmt.Errorf("failed to seed service usage events: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func toServiceUsageEventRecord(usageEvent korifiv1alpha1.CFServiceUsageEvent) ServiceUsageEventRecord {
+	return ServiceUsageEventRecord{
+		GUID:                usageEvent.Name,
+		State:               usageEvent.Spec.State,
+		ServiceInstanceGUID: usageEvent.Spec.ServiceInstance.GUID,
+		ServiceInstanceName: usageEvent.Spec.ServiceInstance.Name,
+		ServiceInstanceType: usageEvent.Spec.ServiceInstance.Type,
+		SpaceGUID:           usageEvent.Spec.Space.GUID,
+		SpaceName:           usageEvent.Spec.Space.Name,
+		OrganizationGUID:    usageEvent.Spec.OrganizationGUID,
+		ServicePlan:         toServiceUsageEventResource(usageEvent.Spec.ServicePlan),
+		ServiceOffering:     toServiceUsageEventResource(usageEvent.Spec.ServiceOffering),
+		ServiceBroker:       toServiceUsageEventResource(usageEvent.Spec.ServiceBroker),
+		CreatedAt:           usageEvent.CreationTimestamp.Time,
+		UpdatedAt:           getLastUpdatedTime(&usageEvent),
+	}
+}
+
+func toServiceUsageEventResource(resource *korifiv1alpha1.UsageEventResource) *ServiceUsageEventResource {
+	if resource == nil {
+		return nil
+	}
+
+	return &ServiceUsageEventResource{GUID: resource.GUID, Name: resource.Name}
+}
@@ -0,0 +1,204 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServiceUsageEventRepository", func() {
+	var (
+		serviceUsageEventRepo *repositories.ServiceUsageEventRepo
+		org                   *korifiv1alpha1.CFOrg
+		space                 *korifiv1alpha1.CFSpace
+	)
+
+	createServiceUsageEvent := func(instanceType, offeringGUID string) *korifiv1alpha1.CFServiceUsageEvent {
+		GinkgoHelper()
+
+		usageEvent := &korifiv1alpha1.CFServiceUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+				State: korifiv1alpha1.ServiceUsageEventStateCreated,
+				ServiceInstance: korifiv1alpha1.UsageEventServiceInstance{
+					GUID: "service-instance-guid",
+					Name: "my-service-instance",
+					Type: instanceType,
+				},
+				Space:            korifiv1alpha1.UsageEventResource{GUID: space.Name, Name: space.Spec.DisplayName},
+				OrganizationGUID: org.Name,
+			},
+		}
+		if offeringGUID != "" {
+			usageEvent.Spec.ServiceOffering = &korifiv1alpha1.UsageEventResource{GUID: offeringGUID, Name: "my-offering"}
+		}
+		Expect(k8sClient.Create(ctx, usageEvent)).To(Succeed())
+
+		return usageEvent
+	}
+
+	BeforeEach(func() {
+		serviceUsageEventRepo = repositories.NewServiceUsageEventRepo(rootNSKlient, userClientFactory, k8sClient, rootNamespace)
+
+		org = createOrgWithCleanup(ctx, uuid.NewString())
+		space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+	})
+
+	Describe("GetServiceUsageEvent", func() {
+		var (
+			usageEvent *korifiv1alpha1.CFServiceUsageEvent
+			record     repositories.ServiceUsageEventRecord
+			getErr     error
+		)
+
+		BeforeEach(func() {
+			usageEvent = createServiceUsageEvent(korifiv1alpha1.ManagedServiceInstanceUsageType, "offering-guid")
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = serviceUsageEventRepo.GetServiceUsageEvent(ctx, authInfo, usageEvent.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the service usage event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":                Equal(usageEvent.Name),
+					"State":               Equal(korifiv1alpha1.ServiceUsageEventStateCreated),
+					"ServiceInstanceGUID": Equal("service-instance-guid"),
+					"ServiceInstanceName": Equal("my-service-instance"),
+					"ServiceInstanceType": Equal(korifiv1alpha1.ManagedServiceInstanceUsageType),
+					"SpaceGUID":           Equal(space.Name),
+					"OrganizationGUID":    Equal(org.Name),
+					"ServiceOffering":     PointTo(Equal(repositories.ServiceUsageEventResource{GUID: "offering-guid", Name: "my-offering"})),
+					"ServicePlan":         BeNil(),
+				}))
+			})
+		})
+	})
+
+	Describe("ListServiceUsageEvents", func() {
+		var (
+			managedEvent      *korifiv1alpha1.CFServiceUsageEvent
+			userProvidedEvent *korifiv1alpha1.CFServiceUsageEvent
+			message           repositories.ListServiceUsageEventsMessage
+			listResult        repositories.ListResult[repositories.ServiceUsageEventRecord]
+			listErr           error
+		)
+
+		BeforeEach(func() {
+			managedEvent = createServiceUsageEvent(korifiv1alpha1.ManagedServiceInstanceUsageType, "offering-guid")
+			userProvidedEvent = createServiceUsageEvent(korifiv1alpha1.UserProvidedServiceInstanceUsageType, "")
+			message = repositories.ListServiceUsageEventsMessage{}
+
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			listResult, listErr = serviceUsageEventRepo.ListServiceUsageEvents(ctx, authInfo, message)
+		})
+
+		It("lists all service usage events", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(managedEvent.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(userProvidedEvent.Name)}),
+			))
+		})
+
+		When("filtering by service instance type", func() {
+			BeforeEach(func() {
+				message.ServiceInstanceTypes = []string{korifiv1alpha1.UserProvidedServiceInstanceUsageType}
+			})
+
+			It("returns the matching service usage events", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(userProvidedEvent.Name)}),
+				))
+			})
+		})
+
+		When("filtering by service offering guid", func() {
+			BeforeEach(func() {
+				message.ServiceOfferingGUIDs = []string{"offering-guid"}
+			})
+
+			It("returns the matching service usage events", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(managedEvent.Name)}),
+				))
+			})
+		})
+	})
+
+	Describe("PurgeAndReseedServiceUsageEvents", func() {
+		var (
+			existingEvent   *korifiv1alpha1.CFServiceUsageEvent
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			purgeErr        error
+		)
+
+		BeforeEach(func() {
+			existingEvent = createServiceUsageEvent(korifiv1alpha1.UserProvidedServiceInstanceUsageType, "")
+			serviceInstance = createServiceInstanceCR(ctx, k8sClient, uuid.NewString(), space.Name, "my-upsi", uuid.NewString())
+		})
+
+		JustBeforeEach(func() {
+			purgeErr = serviceUsageEventRepo.PurgeAndReseedServiceUsageEvents(ctx, authInfo)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(purgeErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(existingEvent), existingEvent)).To(Succeed())
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("replaces the service usage events with CREATED events for the existing instances", func() {
+				Expect(purgeErr).NotTo(HaveOccurred())
+
+				Eventually(func(g Gomega) {
+					usageEventList := &korifiv1alpha1.CFServiceUsageEventList{}
+					g.Expect(k8sClient.List(ctx, usageEventList, client.InNamespace(rootNamespace))).To(Succeed())
+					g.Expect(usageEventList.Items).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"ObjectMeta": MatchFields(IgnoreExtras, Fields{"Name": Equal(existingEvent.Name)}),
+					})))
+					g.Expect(usageEventList.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State": Equal(korifiv1alpha1.ServiceUsageEventStateCreated),
+							"ServiceInstance": Equal(korifiv1alpha1.UsageEventServiceInstance{
+								GUID: serviceInstance.Name,
+								Name: "my-upsi",
+								Type: korifiv1alpha1.UserProvidedServiceInstanceUsageType,
+							}),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+	})
+})
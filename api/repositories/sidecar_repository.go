@@ -0,0 +1,251 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const SidecarResourceType = "Sidecar"
+
+type SidecarRepo struct {
+	klient Klient
+}
+
+func NewSidecarRepo(klient Klient) *SidecarRepo {
+	return &SidecarRepo{
+		klient: klient,
+	}
+}
+
+type SidecarRecord struct {
+	GUID         string
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     int64
+	AppGUID      string
+	SpaceGUID    string
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+}
+
+func (r SidecarRecord) Relationships() map[string]string {
+	return map[string]string{
+		"app": r.AppGUID,
+	}
+}
+
+type CreateSidecarMessage struct {
+	AppGUID      string
+	SpaceGUID    string
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     int64
+}
+
+func (m CreateSidecarMessage) toCFSidecar() *korifiv1alpha1.CFSidecar {
+	return &korifiv1alpha1.CFSidecar{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: m.SpaceGUID,
+		},
+		Spec: korifiv1alpha1.CFSidecarSpec{
+			AppRef:       corev1.LocalObjectReference{Name: m.AppGUID},
+			Name:         m.Name,
+			Command:      m.Command,
+			ProcessTypes: m.ProcessTypes,
+			MemoryMB:     m.MemoryMB,
+		},
+	}
+}
+
+type PatchSidecarMessage struct {
+	GUID         string
+	Name         *string
+	Command      *string
+	ProcessTypes []string
+	MemoryMB     *int64
+}
+
+func (m PatchSidecarMessage) apply(sidecar *korifiv1alpha1.CFSidecar) {
+	if m.Name != nil {
+		sidecar.Spec.Name = *m.Name
+	}
+
+	if m.Command != nil {
+		sidecar.Spec.Command = *m.Command
+	}
+
+	if m.ProcessTypes != nil {
+		sidecar.Spec.ProcessTypes = m.ProcessTypes
+	}
+
+	if m.MemoryMB != nil {
+		sidecar.Spec.MemoryMB = *m.MemoryMB
+	}
+}
+
+type ListSidecarsMessage struct {
+	AppGUIDs   []string
+	OrderBy    string
+	Pagination Pagination
+}
+
+func (m *ListSidecarsMessage) toListOptions() []ListOption {
+	return []ListOption{
+		WithLabelIn(korifiv1alpha1.CFAppGUIDLabelKey, m.AppGUIDs),
+		WithOrdering(m.OrderBy, "name", "Name"),
+		WithPaging(m.Pagination),
+	}
+}
+
+func (r *SidecarRepo) CreateSidecar(ctx context.Context, authInfo authorization.Info, message CreateSidecarMessage) (SidecarRecord, error) {
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      message.AppGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, cfApp); err != nil {
+		return SidecarRecord{}, apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	cfSidecar := message.toCFSidecar()
+	if err := controllerutil.SetOwnerReference(cfApp, cfSidecar, scheme.Scheme); err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to set the owner of the sidecar: %w", err)
+	}
+
+	err := r.klient.Create(ctx, cfSidecar)
+	if err != nil {
+		return SidecarRecord{}, toSidecarError(err)
+	}
+
+	return sidecarToRecord(*cfSidecar), nil
+}
+
+func (r *SidecarRepo) GetSidecar(ctx context.Context, authInfo authorization.Info, guid string) (SidecarRecord, error) {
+	cfSidecar := &korifiv1alpha1.CFSidecar{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: guid,
+		},
+	}
+	err := r.klient.Get(ctx, cfSidecar)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to get sidecar: %w", apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return sidecarToRecord(*cfSidecar), nil
+}
+
+func (r *SidecarRepo) ListSidecars(ctx context.Context, authInfo authorization.Info, message ListSidecarsMessage) (ListResult[SidecarRecord], error) {
+	sidecarList := &korifiv1alpha1.CFSidecarList{}
+	pageInfo, err := r.klient.List(ctx, sidecarList, message.toListOptions()...)
+	if err != nil {
+		return ListResult[SidecarRecord]{}, fmt.Errorf("failed to list sidecars: %w", apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return ListResult[SidecarRecord]{
+		PageInfo: pageInfo,
+		Records:  slices.Collect(it.Map(slices.Values(sidecarList.Items), sidecarToRecord)),
+	}, nil
+}
+
+// ListProcessSidecars returns the sidecars of the app that run next to its
+// processes of the given type
+func (r *SidecarRepo) ListProcessSidecars(ctx context.Context, authInfo authorization.Info, appGUID, processType string) (ListResult[SidecarRecord], error) {
+	sidecarList := &korifiv1alpha1.CFSidecarList{}
+	_, err := r.klient.List(ctx, sidecarList, WithLabel(korifiv1alpha1.CFAppGUIDLabelKey, appGUID))
+	if err != nil {
+		return ListResult[SidecarRecord]{}, fmt.Errorf("failed to list sidecars: %w", apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	records := slices.Collect(it.Map(
+		it.Filter(slices.Values(sidecarList.Items), func(sidecar korifiv1alpha1.CFSidecar) bool {
+			return sidecar.RunsWith(processType)
+		}),
+		sidecarToRecord,
+	))
+
+	return ListResult[SidecarRecord]{
+		PageInfo: descriptors.SinglePageInfo(len(records), len(records)),
+		Records:  records,
+	}, nil
+}
+
+func (r *SidecarRepo) PatchSidecar(ctx context.Context, authInfo authorization.Info, message PatchSidecarMessage) (SidecarRecord, error) {
+	cfSidecar := &korifiv1alpha1.CFSidecar{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: message.GUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfSidecar, func() error {
+		message.apply(cfSidecar)
+		return nil
+	})
+	if err != nil {
+		return SidecarRecord{}, toSidecarError(err)
+	}
+
+	return sidecarToRecord(*cfSidecar), nil
+}
+
+func (r *SidecarRepo) DeleteSidecar(ctx context.Context, authInfo authorization.Info, guid string) error {
+	cfSidecar := &korifiv1alpha1.CFSidecar{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: guid,
+		},
+	}
+
+	err := r.klient.Get(ctx, cfSidecar)
+	if err != nil {
+		return apierrors.FromK8sError(err, SidecarResourceType)
+	}
+
+	err = r.klient.Delete(ctx, cfSidecar)
+	if err != nil {
+		return apierrors.FromK8sError(err, SidecarResourceType)
+	}
+
+	return nil
+}
+
+func toSidecarError(err error) error {
+	if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+		if validationError.Type == validation.DuplicateNameErrorType {
+			return apierrors.NewUniquenessError(err, validationError.GetMessage())
+		}
+	}
+
+	return apierrors.FromK8sError(err, SidecarResourceType)
+}
+
+func sidecarToRecord(sidecar korifiv1alpha1.CFSidecar) SidecarRecord {
+	return SidecarRecord{
+		GUID:         sidecar.Name,
+		Name:         sidecar.Spec.Name,
+		Command:      sidecar.Spec.Command,
+		ProcessTypes: sidecar.Spec.ProcessTypes,
+		MemoryMB:     sidecar.Spec.MemoryMB,
+		AppGUID:      sidecar.Spec.AppRef.Name,
+		SpaceGUID:    sidecar.Namespace,
+		CreatedAt:    sidecar.CreationTimestamp.Time,
+		UpdatedAt:    getLastUpdatedTime(&sidecar),
+	}
+}
@@ -0,0 +1,353 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SidecarRepository", func() {
+	var (
+		sidecarRepo *repositories.SidecarRepo
+		space       *korifiv1alpha1.CFSpace
+		cfApp       *korifiv1alpha1.CFApp
+	)
+
+	createSidecar := func(name string, processTypes ...string) *korifiv1alpha1.CFSidecar {
+		GinkgoHelper()
+
+		sidecar := &korifiv1alpha1.CFSidecar{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: space.Name,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFSidecarSpec{
+				AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+				Name:         name,
+				Command:      "bin/" + name,
+				ProcessTypes: processTypes,
+			},
+		}
+		Expect(k8sClient.Create(ctx, sidecar)).To(Succeed())
+
+		return sidecar
+	}
+
+	BeforeEach(func() {
+		sidecarRepo = repositories.NewSidecarRepo(spaceScopedKlient)
+
+		org := createOrgWithCleanup(ctx, uuid.NewString())
+		space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+		cfApp = createApp(space.Name)
+	})
+
+	Describe("CreateSidecar", func() {
+		var (
+			message   repositories.CreateSidecarMessage
+			record    repositories.SidecarRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			message = repositories.CreateSidecarMessage{
+				AppGUID:      cfApp.Name,
+				SpaceGUID:    space.Name,
+				Name:         "my-sidecar",
+				Command:      "bin/my-sidecar",
+				ProcessTypes: []string{"web", "worker"},
+				MemoryMB:     64,
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = sidecarRepo.CreateSidecar(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates the sidecar", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).NotTo(BeEmpty())
+				Expect(record.Name).To(Equal("my-sidecar"))
+				Expect(record.Command).To(Equal("bin/my-sidecar"))
+				Expect(record.ProcessTypes).To(ConsistOf("web", "worker"))
+				Expect(record.MemoryMB).To(BeEquivalentTo(64))
+				Expect(record.AppGUID).To(Equal(cfApp.Name))
+				Expect(record.SpaceGUID).To(Equal(space.Name))
+				Expect(record.Relationships()).To(Equal(map[string]string{"app": cfApp.Name}))
+
+				cfSidecar := &korifiv1alpha1.CFSidecar{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: space.Name,
+						Name:      record.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSidecar), cfSidecar)).To(Succeed())
+				Expect(cfSidecar.Spec).To(Equal(korifiv1alpha1.CFSidecarSpec{
+					AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+					Name:         "my-sidecar",
+					Command:      "bin/my-sidecar",
+					ProcessTypes: []string{"web", "worker"},
+					MemoryMB:     64,
+				}))
+				Expect(cfSidecar.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Kind": Equal("CFApp"),
+					"Name": Equal(cfApp.Name),
+				})))
+			})
+
+			When("the app does not exist", func() {
+				BeforeEach(func() {
+					message.AppGUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetSidecar", func() {
+		var (
+			sidecar     *korifiv1alpha1.CFSidecar
+			sidecarGUID string
+			record      repositories.SidecarRecord
+			getErr      error
+		)
+
+		BeforeEach(func() {
+			sidecar = createSidecar("my-sidecar", "web")
+			sidecarGUID = sidecar.Name
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = sidecarRepo.GetSidecar(ctx, authInfo, sidecarGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the sidecar", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(sidecar.Name))
+				Expect(record.Name).To(Equal("my-sidecar"))
+				Expect(record.Command).To(Equal("bin/my-sidecar"))
+				Expect(record.ProcessTypes).To(ConsistOf("web"))
+				Expect(record.AppGUID).To(Equal(cfApp.Name))
+			})
+
+			When("the sidecar does not exist", func() {
+				BeforeEach(func() {
+					sidecarGUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListSidecars", func() {
+		var (
+			sidecar1, sidecar2 *korifiv1alpha1.CFSidecar
+			message            repositories.ListSidecarsMessage
+			listResult         repositories.ListResult[repositories.SidecarRecord]
+			listErr            error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			sidecar1 = createSidecar("sidecar-1", "web")
+			sidecar2 = createSidecar("sidecar-2", "worker")
+
+			anotherApp := createApp(space.Name)
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFSidecar{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: space.Name,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFSidecarSpec{
+					AppRef:       corev1.LocalObjectReference{Name: anotherApp.Name},
+					Name:         "another-sidecar",
+					Command:      "bin/another-sidecar",
+					ProcessTypes: []string{"web"},
+				},
+			})).To(Succeed())
+
+			message = repositories.ListSidecarsMessage{
+				AppGUIDs: []string{cfApp.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			listResult, listErr = sidecarRepo.ListSidecars(ctx, authInfo, message)
+		})
+
+		It("lists the sidecars of the app", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(sidecar1.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(sidecar2.Name)}),
+			))
+		})
+
+		When("ordering by name", func() {
+			BeforeEach(func() {
+				message.OrderBy = "-name"
+			})
+
+			It("returns the sidecars in order", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(HaveExactElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sidecar2.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sidecar1.Name)}),
+				))
+			})
+		})
+
+		When("paging", func() {
+			BeforeEach(func() {
+				message.OrderBy = "name"
+				message.Pagination = repositories.Pagination{PerPage: 1, Page: 2}
+			})
+
+			It("returns the requested page", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(sidecar2.Name)}),
+				))
+				Expect(listResult.PageInfo.TotalResults).To(Equal(2))
+			})
+		})
+	})
+
+	Describe("ListProcessSidecars", func() {
+		var (
+			webSidecar, allSidecar *korifiv1alpha1.CFSidecar
+			listResult             repositories.ListResult[repositories.SidecarRecord]
+			listErr                error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			webSidecar = createSidecar("web-sidecar", "web")
+			allSidecar = createSidecar("all-sidecar", "web", "worker")
+			createSidecar("worker-sidecar", "worker")
+		})
+
+		JustBeforeEach(func() {
+			listResult, listErr = sidecarRepo.ListProcessSidecars(ctx, authInfo, cfApp.Name, "web")
+		})
+
+		It("lists the sidecars running next to the process type", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(webSidecar.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(allSidecar.Name)}),
+			))
+			Expect(listResult.PageInfo.TotalResults).To(Equal(2))
+		})
+	})
+
+	Describe("PatchSidecar", func() {
+		var (
+			sidecar  *korifiv1alpha1.CFSidecar
+			message  repositories.PatchSidecarMessage
+			record   repositories.SidecarRecord
+			patchErr error
+		)
+
+		BeforeEach(func() {
+			sidecar = createSidecar("my-sidecar", "web")
+			message = repositories.PatchSidecarMessage{
+				GUID:         sidecar.Name,
+				Command:      tools.PtrTo("bin/new-command"),
+				ProcessTypes: []string{"worker"},
+				MemoryMB:     tools.PtrTo[int64](128),
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, patchErr = sidecarRepo.PatchSidecar(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(patchErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("updates the sidecar", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal("my-sidecar"))
+				Expect(record.Command).To(Equal("bin/new-command"))
+				Expect(record.ProcessTypes).To(ConsistOf("worker"))
+				Expect(record.MemoryMB).To(BeEquivalentTo(128))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(sidecar), sidecar)).To(Succeed())
+				Expect(sidecar.Spec.Command).To(Equal("bin/new-command"))
+				Expect(sidecar.Spec.ProcessTypes).To(ConsistOf("worker"))
+				Expect(sidecar.Spec.MemoryMB).To(BeEquivalentTo(128))
+			})
+		})
+	})
+
+	Describe("DeleteSidecar", func() {
+		var (
+			sidecar   *korifiv1alpha1.CFSidecar
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			sidecar = createSidecar("my-sidecar", "web")
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = sidecarRepo.DeleteSidecar(ctx, authInfo, sidecar.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(deleteErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("deletes the sidecar", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(sidecar), sidecar)
+				Expect(err).To(MatchError(ContainSubstring("not found")))
+			})
+		})
+	})
+})
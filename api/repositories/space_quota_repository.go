@@ -0,0 +1,308 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const SpaceQuotaResourceType = "Space Quota"
+
+type SpaceQuotaRepo struct {
+	klient  Klient
+	nsPerms *authorization.NamespacePermissions
+}
+
+func NewSpaceQuotaRepo(klient Klient, nsPerms *authorization.NamespacePermissions) *SpaceQuotaRepo {
+	return &SpaceQuotaRepo{
+		klient:  klient,
+		nsPerms: nsPerms,
+	}
+}
+
+type SpaceQuotaRecord struct {
+	GUID       string
+	Name       string
+	OrgGUID    string
+	Limits     QuotaLimits
+	SpaceGUIDs []string
+	CreatedAt  time.Time
+	UpdatedAt  *time.Time
+	DeletedAt  *time.Time
+}
+
+type CreateSpaceQuotaMessage struct {
+	Name       string
+	OrgGUID    string
+	Limits     QuotaLimits
+	SpaceGUIDs []string
+}
+
+type UpdateSpaceQuotaMessage struct {
+	GUID   string
+	Name   *string
+	Limits QuotaLimitsPatch
+}
+
+type ApplySpaceQuotaMessage struct {
+	GUID       string
+	SpaceGUIDs []string
+}
+
+type RemoveSpaceQuotaMessage struct {
+	GUID      string
+	SpaceGUID string
+}
+
+type ListSpaceQuotasMessage struct {
+	GUIDs      []string
+	Names      []string
+	OrgGUIDs   []string
+	SpaceGUIDs []string
+	Pagination Pagination
+}
+
+func (m *ListSpaceQuotasMessage) matches(quota SpaceQuotaRecord) bool {
+	return tools.EmptyOrContains(m.GUIDs, quota.GUID) &&
+		tools.EmptyOrContains(m.Names, quota.Name) &&
+		(len(m.SpaceGUIDs) == 0 || slices.ContainsFunc(quota.SpaceGUIDs, func(space string) bool {
+			return slices.Contains(m.SpaceGUIDs, space)
+		}))
+}
+
+func (r *SpaceQuotaRepo) CreateSpaceQuota(ctx context.Context, authInfo authorization.Info, message CreateSpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.OrgGUID,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+			DisplayName: message.Name,
+			QuotaLimits: toCFQuotaLimits(message.Limits),
+			Spaces:      message.SpaceGUIDs,
+		},
+	}
+
+	err := r.klient.Create(ctx, cfSpaceQuota)
+	if err != nil {
+		return SpaceQuotaRecord{}, quotaWebhookErrorToApiError(err, SpaceQuotaResourceType)
+	}
+
+	if err = r.removeSpacesFromOtherQuotas(ctx, cfSpaceQuota, message.SpaceGUIDs); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return toSpaceQuotaRecord(*cfSpaceQuota), nil
+}
+
+func (r *SpaceQuotaRepo) GetSpaceQuota(ctx context.Context, authInfo authorization.Info, guid string) (SpaceQuotaRecord, error) {
+	cfSpaceQuota, err := r.getCFSpaceQuota(ctx, guid)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("get-space-quota failed: %w", err)
+	}
+
+	return toSpaceQuotaRecord(*cfSpaceQuota), nil
+}
+
+func (r *SpaceQuotaRepo) ListSpaceQuotas(ctx context.Context, authInfo authorization.Info, message ListSpaceQuotasMessage) (ListResult[SpaceQuotaRecord], error) {
+	authorizedOrgNamespaces, err := getAuthorizedOrgNamespaces(ctx, authInfo, r.nsPerms)
+	if err != nil {
+		return ListResult[SpaceQuotaRecord]{}, err
+	}
+
+	cfSpaceQuotas := []korifiv1alpha1.CFSpaceQuota{}
+	for _, org := range authorizedOrgNamespaces {
+		if !tools.EmptyOrContains(message.OrgGUIDs, org) {
+			continue
+		}
+
+		cfSpaceQuotaList := new(korifiv1alpha1.CFSpaceQuotaList)
+		_, err = r.klient.List(ctx, cfSpaceQuotaList, InNamespace(org))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return ListResult[SpaceQuotaRecord]{}, apierrors.FromK8sError(err, SpaceQuotaResourceType)
+		}
+
+		cfSpaceQuotas = append(cfSpaceQuotas, cfSpaceQuotaList.Items...)
+	}
+
+	records := slices.Collect(it.Filter(it.Map(slices.Values(cfSpaceQuotas), toSpaceQuotaRecord), message.matches))
+	slices.SortStableFunc(records, func(q1, q2 SpaceQuotaRecord) int {
+		return q1.CreatedAt.Compare(q2.CreatedAt)
+	})
+
+	recordsPage := descriptors.SinglePage(records, len(records))
+	if !message.Pagination.IsZero() {
+		recordsPage, err = descriptors.GetPage(records, message.Pagination.PerPage, message.Pagination.Page)
+		if err != nil {
+			return ListResult[SpaceQuotaRecord]{}, fmt.Errorf("failed to page space quotas list: %w", err)
+		}
+	}
+
+	return ListResult[SpaceQuotaRecord]{
+		PageInfo: recordsPage.PageInfo,
+		Records:  recordsPage.Items,
+	}, nil
+}
+
+func (r *SpaceQuotaRepo) UpdateSpaceQuota(ctx context.Context, authInfo authorization.Info, message UpdateSpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	cfSpaceQuota, err := r.getCFSpaceQuota(ctx, message.GUID)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("update-space-quota failed: %w", err)
+	}
+
+	err = r.klient.Patch(ctx, cfSpaceQuota, func() error {
+		if message.Name != nil {
+			cfSpaceQuota.Spec.DisplayName = *message.Name
+		}
+		message.Limits.apply(&cfSpaceQuota.Spec.QuotaLimits)
+
+		return nil
+	})
+	if err != nil {
+		return SpaceQuotaRecord{}, quotaWebhookErrorToApiError(err, SpaceQuotaResourceType)
+	}
+
+	return toSpaceQuotaRecord(*cfSpaceQuota), nil
+}
+
+// ApplySpaceQuota applies the quota to the given spaces. A space can only
+// have one quota, so the spaces are removed from any other quota.
+func (r *SpaceQuotaRepo) ApplySpaceQuota(ctx context.Context, authInfo authorization.Info, message ApplySpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	cfSpaceQuota, err := r.getCFSpaceQuota(ctx, message.GUID)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("apply-space-quota failed: %w", err)
+	}
+
+	err = r.klient.Patch(ctx, cfSpaceQuota, func() error {
+		for _, spaceGUID := range message.SpaceGUIDs {
+			if !slices.Contains(cfSpaceQuota.Spec.Spaces, spaceGUID) {
+				cfSpaceQuota.Spec.Spaces = append(cfSpaceQuota.Spec.Spaces, spaceGUID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to apply space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	if err = r.removeSpacesFromOtherQuotas(ctx, cfSpaceQuota, message.SpaceGUIDs); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return toSpaceQuotaRecord(*cfSpaceQuota), nil
+}
+
+func (r *SpaceQuotaRepo) RemoveSpaceQuota(ctx context.Context, authInfo authorization.Info, message RemoveSpaceQuotaMessage) error {
+	cfSpaceQuota, err := r.getCFSpaceQuota(ctx, message.GUID)
+	if err != nil {
+		return fmt.Errorf("remove-space-quota failed: %w", err)
+	}
+
+	err = r.klient.Patch(ctx, cfSpaceQuota, func() error {
+		cfSpaceQuota.Spec.Spaces = slices.DeleteFunc(cfSpaceQuota.Spec.Spaces, func(space string) bool {
+			return space == message.SpaceGUID
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return nil
+}
+
+func (r *SpaceQuotaRepo) DeleteSpaceQuota(ctx context.Context, authInfo authorization.Info, guid string) error {
+	cfSpaceQuota, err := r.getCFSpaceQuota(ctx, guid)
+	if err != nil {
+		return fmt.Errorf("delete-space-quota failed: %w", err)
+	}
+
+	err = r.klient.Delete(ctx, cfSpaceQuota)
+	if err != nil {
+		return apierrors.FromK8sError(err, SpaceQuotaResourceType)
+	}
+
+	return nil
+}
+
+func (r *SpaceQuotaRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	spaceQuota, err := r.GetSpaceQuota(ctx, authInfo, guid)
+	return spaceQuota.DeletedAt, err
+}
+
+func (r *SpaceQuotaRepo) removeSpacesFromOtherQuotas(ctx context.Context, cfSpaceQuota *korifiv1alpha1.CFSpaceQuota, spaceGUIDs []string) error {
+	if len(spaceGUIDs) == 0 {
+		return nil
+	}
+
+	cfSpaceQuotaList := &korifiv1alpha1.CFSpaceQuotaList{}
+	_, err := r.klient.List(ctx, cfSpaceQuotaList, InNamespace(cfSpaceQuota.Namespace))
+	if err != nil {
+		return fmt.Errorf("failed to list space quotas: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	for _, otherQuota := range cfSpaceQuotaList.Items {
+		if otherQuota.Name == cfSpaceQuota.Name {
+			continue
+		}
+
+		remainingSpaces := slices.DeleteFunc(slices.Clone(otherQuota.Spec.Spaces), func(space string) bool {
+			return slices.Contains(spaceGUIDs, space)
+		})
+		if len(remainingSpaces) == len(otherQuota.Spec.Spaces) {
+			continue
+		}
+
+		err = r.klient.Patch(ctx, &otherQuota, func() error {
+			otherQuota.Spec.Spaces = remainingSpaces
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove spaces from space quota %s: %w", otherQuota.Name, apierrors.FromK8sError(err, SpaceQuotaResourceType))
+		}
+	}
+
+	return nil
+}
+
+func (r *SpaceQuotaRepo) getCFSpaceQuota(ctx context.Context, guid string) (*korifiv1alpha1.CFSpaceQuota, error) {
+	cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: guid,
+		},
+	}
+
+	err := r.klient.Get(ctx, cfSpaceQuota)
+	if err != nil {
+		return nil, apierrors.FromK8sError(err, SpaceQuotaResourceType)
+	}
+
+	return cfSpaceQuota, nil
+}
+
+func toSpaceQuotaRecord(cfSpaceQuota korifiv1alpha1.CFSpaceQuota) SpaceQuotaRecord {
+	return SpaceQuotaRecord{
+		GUID:       cfSpaceQuota.Name,
+		Name:       cfSpaceQuota.Spec.DisplayName,
+		OrgGUID:    cfSpaceQuota.Namespace,
+		Limits:     toQuotaLimits(cfSpaceQuota.Spec.QuotaLimits),
+		SpaceGUIDs: cfSpaceQuota.Spec.Spaces,
+		CreatedAt:  cfSpaceQuota.CreationTimestamp.Time,
+		UpdatedAt:  getLastUpdatedTime(&cfSpaceQuota),
+		DeletedAt:  golangTime(cfSpaceQuota.DeletionTimestamp),
+	}
+}
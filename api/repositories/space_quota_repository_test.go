@@ -0,0 +1,350 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SpaceQuotaRepo", func() {
+	var (
+		repo  *repositories.SpaceQuotaRepo
+		org   *korifiv1alpha1.CFOrg
+		space *korifiv1alpha1.CFSpace
+	)
+
+	createSpaceQuota := func(displayName string, spaces ...string) *korifiv1alpha1.CFSpaceQuota {
+		cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: org.Name,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+				DisplayName: displayName,
+				QuotaLimits: korifiv1alpha1.QuotaLimits{
+					Apps: korifiv1alpha1.QuotaAppsLimits{
+						TotalInstances: tools.PtrTo[int32](5),
+					},
+				},
+				Spaces: spaces,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfSpaceQuota)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cfSpaceQuota))).To(Succeed())
+		})
+
+		return cfSpaceQuota
+	}
+
+	BeforeEach(func() {
+		repo = repositories.NewSpaceQuotaRepo(spaceScopedKlient, nsPerms)
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+	})
+
+	Describe("CreateSpaceQuota", func() {
+		var (
+			spaceQuotaRecord repositories.SpaceQuotaRecord
+			createMessage    repositories.CreateSpaceQuotaMessage
+			otherQuota       *korifiv1alpha1.CFSpaceQuota
+			createErr        error
+		)
+
+		BeforeEach(func() {
+			otherQuota = createSpaceQuota(uuid.NewString(), space.Name)
+			createMessage = repositories.CreateSpaceQuotaMessage{
+				Name:    "test-space-quota",
+				OrgGUID: org.Name,
+				Limits: repositories.QuotaLimits{
+					Apps: repositories.QuotaAppsLimits{
+						TotalMemoryInMB: tools.PtrTo[int64](2048),
+					},
+					Services: repositories.QuotaServicesLimits{
+						PaidServicesAllowed: true,
+					},
+				},
+				SpaceGUIDs: []string{space.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			spaceQuotaRecord, createErr = repo.CreateSpaceQuota(ctx, authInfo, createMessage)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an org manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+			})
+
+			It("creates a CFSpaceQuota in the org namespace", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: org.Name,
+						Name:      spaceQuotaRecord.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), cfSpaceQuota)).To(Succeed())
+				Expect(cfSpaceQuota.Spec.DisplayName).To(Equal("test-space-quota"))
+				Expect(cfSpaceQuota.Spec.Apps.TotalMemoryInMB).To(PointTo(BeEquivalentTo(2048)))
+				Expect(cfSpaceQuota.Spec.Services.PaidServicesAllowed).To(BeTrue())
+				Expect(cfSpaceQuota.Spec.Spaces).To(ConsistOf(space.Name))
+			})
+
+			It("returns a space quota record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(spaceQuotaRecord.GUID).To(matchers.BeValidUUID())
+				Expect(spaceQuotaRecord.Name).To(Equal("test-space-quota"))
+				Expect(spaceQuotaRecord.OrgGUID).To(Equal(org.Name))
+				Expect(spaceQuotaRecord.Limits).To(Equal(createMessage.Limits))
+				Expect(spaceQuotaRecord.SpaceGUIDs).To(ConsistOf(space.Name))
+			})
+
+			It("removes the spaces from other space quotas", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(otherQuota), otherQuota)).To(Succeed())
+				Expect(otherQuota.Spec.Spaces).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GetSpaceQuota", func() {
+		var (
+			cfSpaceQuota     *korifiv1alpha1.CFSpaceQuota
+			spaceQuotaRecord repositories.SpaceQuotaRecord
+			getErr           error
+		)
+
+		BeforeEach(func() {
+			cfSpaceQuota = createSpaceQuota(uuid.NewString(), space.Name)
+		})
+
+		JustBeforeEach(func() {
+			spaceQuotaRecord, getErr = repo.GetSpaceQuota(ctx, authInfo, cfSpaceQuota.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an org user", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+			})
+
+			It("returns the space quota", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(spaceQuotaRecord.GUID).To(Equal(cfSpaceQuota.Name))
+				Expect(spaceQuotaRecord.Name).To(Equal(cfSpaceQuota.Spec.DisplayName))
+				Expect(spaceQuotaRecord.OrgGUID).To(Equal(org.Name))
+				Expect(spaceQuotaRecord.Limits.Apps.TotalInstances).To(PointTo(BeEquivalentTo(5)))
+				Expect(spaceQuotaRecord.SpaceGUIDs).To(ConsistOf(space.Name))
+			})
+		})
+
+		When("the space quota does not exist", func() {
+			BeforeEach(func() {
+				cfSpaceQuota.Name = "i-do-not-exist"
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListSpaceQuotas", func() {
+		var (
+			quota1, quota2 *korifiv1alpha1.CFSpaceQuota
+			message        repositories.ListSpaceQuotasMessage
+			listResult     repositories.ListResult[repositories.SpaceQuotaRecord]
+			listErr        error
+		)
+
+		BeforeEach(func() {
+			quota1 = createSpaceQuota(uuid.NewString(), space.Name)
+			quota2 = createSpaceQuota(uuid.NewString())
+			message = repositories.ListSpaceQuotasMessage{}
+		})
+
+		JustBeforeEach(func() {
+			listResult, listErr = repo.ListSpaceQuotas(ctx, authInfo, message)
+		})
+
+		It("returns an empty list for users with no permissions", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(BeEmpty())
+		})
+
+		When("the user is an org user", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+			})
+
+			It("returns the space quotas in the org", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota1.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota2.Name)}),
+				))
+			})
+
+			When("filtering by space guids", func() {
+				BeforeEach(func() {
+					message.SpaceGUIDs = []string{space.Name}
+				})
+
+				It("returns the space quotas applied to the spaces", func() {
+					Expect(listResult.Records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(quota1.Name)}),
+					))
+				})
+			})
+
+			When("filtering by another org", func() {
+				BeforeEach(func() {
+					message.OrgGUIDs = []string{"another-org"}
+				})
+
+				It("returns an empty list", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(listResult.Records).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("UpdateSpaceQuota", func() {
+		var (
+			cfSpaceQuota *korifiv1alpha1.CFSpaceQuota
+			message      repositories.UpdateSpaceQuotaMessage
+			updateErr    error
+		)
+
+		BeforeEach(func() {
+			cfSpaceQuota = createSpaceQuota(uuid.NewString())
+			message = repositories.UpdateSpaceQuotaMessage{
+				GUID: cfSpaceQuota.Name,
+				Name: tools.PtrTo("new-name-" + uuid.NewString()),
+				Limits: repositories.QuotaLimitsPatch{
+					TotalRoutes: tools.PtrTo[int32](4),
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			_, updateErr = repo.UpdateSpaceQuota(ctx, authInfo, message)
+		})
+
+		When("the user is an org manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+			})
+
+			It("updates the specified fields and leaves the others unchanged", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), cfSpaceQuota)).To(Succeed())
+				Expect(cfSpaceQuota.Spec.DisplayName).To(Equal(*message.Name))
+				Expect(cfSpaceQuota.Spec.Routes.TotalRoutes).To(PointTo(BeEquivalentTo(4)))
+				Expect(cfSpaceQuota.Spec.Apps.TotalInstances).To(PointTo(BeEquivalentTo(5)))
+			})
+		})
+
+		When("the user is an org user", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+			})
+
+			It("errors with forbidden", func() {
+				Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("ApplySpaceQuota and RemoveSpaceQuota", func() {
+		var (
+			cfSpaceQuota *korifiv1alpha1.CFSpaceQuota
+			otherQuota   *korifiv1alpha1.CFSpaceQuota
+			anotherSpace *korifiv1alpha1.CFSpace
+		)
+
+		BeforeEach(func() {
+			anotherSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+			cfSpaceQuota = createSpaceQuota(uuid.NewString())
+			otherQuota = createSpaceQuota(uuid.NewString(), space.Name)
+			createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+		})
+
+		It("applies the quota to the spaces and removes them from other quotas", func() {
+			spaceQuotaRecord, err := repo.ApplySpaceQuota(ctx, authInfo, repositories.ApplySpaceQuotaMessage{
+				GUID:       cfSpaceQuota.Name,
+				SpaceGUIDs: []string{space.Name, anotherSpace.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spaceQuotaRecord.SpaceGUIDs).To(ConsistOf(space.Name, anotherSpace.Name))
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(otherQuota), otherQuota)).To(Succeed())
+			Expect(otherQuota.Spec.Spaces).To(BeEmpty())
+		})
+
+		It("removes the quota from a space", func() {
+			Expect(repo.RemoveSpaceQuota(ctx, authInfo, repositories.RemoveSpaceQuotaMessage{
+				GUID:      otherQuota.Name,
+				SpaceGUID: space.Name,
+			})).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(otherQuota), otherQuota)).To(Succeed())
+			Expect(otherQuota.Spec.Spaces).To(BeEmpty())
+		})
+	})
+
+	Describe("DeleteSpaceQuota", func() {
+		var (
+			cfSpaceQuota *korifiv1alpha1.CFSpaceQuota
+			deleteErr    error
+		)
+
+		BeforeEach(func() {
+			cfSpaceQuota = createSpaceQuota(uuid.NewString())
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteSpaceQuota(ctx, authInfo, cfSpaceQuota.Name)
+		})
+
+		It("errors with forbidden for users with no permissions", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an org manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+			})
+
+			It("deletes the space quota", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), cfSpaceQuota)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+})
@@ -61,24 +61,35 @@ type PatchSpaceMessage struct {
 	GUID    string
 	OrgGUID string
 	Name    *string
+	// An empty GUID removes the isolation segment of the space
+	IsolationSegmentGUID *string
+	AllowSSH             *bool
 }
 
 func (p *PatchSpaceMessage) Apply(space *korifiv1alpha1.CFSpace) {
 	if p.Name != nil {
 		space.Spec.DisplayName = *p.Name
 	}
+	if p.IsolationSegmentGUID != nil {
+		space.Spec.IsolationSegmentGUID = *p.IsolationSegmentGUID
+	}
+	if p.AllowSSH != nil {
+		space.Spec.AllowSSH = tools.PtrTo(*p.AllowSSH)
+	}
 	p.MetadataPatch.Apply(space)
 }
 
 type SpaceRecord struct {
-	Name             string
-	GUID             string
-	OrganizationGUID string
-	Labels           map[string]string
-	Annotations      map[string]string
-	CreatedAt        time.Time
-	UpdatedAt        *time.Time
-	DeletedAt        *time.Time
+	Name                 string
+	GUID                 string
+	OrganizationGUID     string
+	IsolationSegmentGUID string
+	AllowSSH             bool
+	Labels               map[string]string
+	Annotations          map[string]string
+	CreatedAt            time.Time
+	UpdatedAt            *time.Time
+	DeletedAt            *time.Time
 }
 
 func (r SpaceRecord) Relationships() map[string]string {
@@ -198,14 +209,16 @@ func (r *SpaceRepo) GetSpace(ctx context.Context, info authorization.Info, space
 
 func cfSpaceToSpaceRecord(cfSpace korifiv1alpha1.CFSpace) SpaceRecord {
 	return SpaceRecord{
-		Name:             cfSpace.Spec.DisplayName,
-		GUID:             cfSpace.Name,
-		OrganizationGUID: cfSpace.Namespace,
-		Annotations:      cfSpace.Annotations,
-		Labels:           cfSpace.Labels,
-		CreatedAt:        cfSpace.CreationTimestamp.Time,
-		UpdatedAt:        getLastUpdatedTime(&cfSpace),
-		DeletedAt:        golangTime(cfSpace.DeletionTimestamp),
+		Name:                 cfSpace.Spec.DisplayName,
+		GUID:                 cfSpace.Name,
+		OrganizationGUID:     cfSpace.Namespace,
+		IsolationSegmentGUID: cfSpace.Spec.IsolationSegmentGUID,
+		AllowSSH:             cfSpace.Spec.AllowSSH == nil || *cfSpace.Spec.AllowSSH,
+		Annotations:          cfSpace.Annotations,
+		Labels:               cfSpace.Labels,
+		CreatedAt:            cfSpace.CreationTimestamp.Time,
+		UpdatedAt:            getLastUpdatedTime(&cfSpace),
+		DeletedAt:            golangTime(cfSpace.DeletionTimestamp),
 	}
 }
 
@@ -452,6 +452,7 @@ var _ = Describe("SpaceRepository", func() {
 			spaceGUID                     string
 			displayName                   string
 			spaceNewName                  *string
+			allowSSH                      *bool
 			orgGUID                       string
 			cfSpace                       *korifiv1alpha1.CFSpace
 			cfOrg                         *korifiv1alpha1.CFOrg
@@ -469,13 +470,15 @@ var _ = Describe("SpaceRepository", func() {
 			labelsPatch = nil
 			annotationsPatch = nil
 			spaceNewName = tools.PtrTo(uuid.NewString())
+			allowSSH = nil
 		})
 
 		JustBeforeEach(func() {
 			patchMsg := repositories.PatchSpaceMessage{
-				GUID:    spaceGUID,
-				OrgGUID: orgGUID,
-				Name:    spaceNewName,
+				GUID:     spaceGUID,
+				OrgGUID:  orgGUID,
+				Name:     spaceNewName,
+				AllowSSH: allowSSH,
 				MetadataPatch: repositories.MetadataPatch{
 					Annotations: annotationsPatch,
 					Labels:      labelsPatch,
@@ -653,6 +656,26 @@ var _ = Describe("SpaceRepository", func() {
 					Expect(spaceRecord.Name).To(Equal(displayName))
 				})
 			})
+
+			It("keeps ssh allowed by default", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(spaceRecord.AllowSSH).To(BeTrue())
+			})
+
+			When("ssh is disallowed", func() {
+				BeforeEach(func() {
+					allowSSH = tools.PtrTo(false)
+				})
+
+				It("disallows ssh in the space", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(spaceRecord.AllowSSH).To(BeFalse())
+
+					updatedCFSpace := new(korifiv1alpha1.CFSpace)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), updatedCFSpace)).To(Succeed())
+					Expect(updatedCFSpace.Spec.AllowSSH).To(Equal(tools.PtrTo(false)))
+				})
+			})
 		})
 
 		When("the user is authorized but the Space does not exist", func() {
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/presenter"
@@ -16,10 +18,16 @@ import (
 type Response struct {
 	httpStatus  int
 	body        interface{}
+	stream      StreamWriter
 	headers     map[string][]string
 	contentType string
 }
 
+// StreamWriter writes the body of a streamed response. It is expected to block
+// until the stream is over and to call flush whenever the data written so far
+// should be sent to the client.
+type StreamWriter func(w io.Writer, flush func() error) error
+
 func NewResponse(httpStatus int) *Response {
 	return &Response{
 		httpStatus:  httpStatus,
@@ -38,6 +46,11 @@ func (r *Response) WithBody(body interface{}) *Response {
 	return r
 }
 
+func (r *Response) WithStream(stream StreamWriter) *Response {
+	r.stream = stream
+	return r
+}
+
 func (r *Response) WithContentType(contentType string) *Response {
 	r.contentType = contentType
 	return r
@@ -94,6 +107,10 @@ func (response *Response) writeTo(w http.ResponseWriter) error {
 		}
 	}
 
+	if response.stream != nil {
+		return response.writeStreamTo(w)
+	}
+
 	if response.body == nil {
 		w.WriteHeader(response.httpStatus)
 		return nil
@@ -108,6 +125,27 @@ func (response *Response) writeTo(w http.ResponseWriter) error {
 	return response.encodeAsJSON(w)
 }
 
+func (response *Response) writeStreamTo(w http.ResponseWriter) error {
+	responseController := http.NewResponseController(w)
+
+	// Streams are expected to outlive the server write timeout
+	if err := responseController.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("failed to clear the write deadline: %w", err)
+	}
+
+	w.Header().Set("Content-Type", response.contentType)
+	w.WriteHeader(response.httpStatus)
+	if err := responseController.Flush(); err != nil {
+		return fmt.Errorf("failed to flush response headers: %w", err)
+	}
+
+	if err := response.stream(w, responseController.Flush); err != nil {
+		return fmt.Errorf("failed to stream response: %w", err)
+	}
+
+	return nil
+}
+
 func (response *Response) encodeAsJSON(w http.ResponseWriter) error {
 	encoder := json.NewEncoder(w)
 	encoder.SetEscapeHTML(false)
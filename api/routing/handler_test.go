@@ -2,6 +2,7 @@ package routing_test
 
 import (
 	"errors"
+	"io"
 	"net/http"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -76,6 +77,37 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	When("the response is streamed", func() {
+		var flushed bool
+
+		BeforeEach(func() {
+			flushed = false
+			response = response.WithContentType("text/event-stream").WithStream(func(w io.Writer, flush func() error) error {
+				if _, err := io.WriteString(w, "hello"); err != nil {
+					return err
+				}
+				if err := flush(); err != nil {
+					return err
+				}
+				flushed = true
+
+				_, err := io.WriteString(w, " world")
+				return err
+			})
+		})
+
+		It("sets the specified content type header on the response", func() {
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "text/event-stream"))
+		})
+
+		It("writes the stream to the response", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			Expect(rr).To(HaveHTTPBody("hello world"))
+			Expect(flushed).To(BeTrue())
+			Expect(rr.Flushed).To(BeTrue())
+		})
+	})
+
 	When("the response sets header values", func() {
 		BeforeEach(func() {
 			response = response.WithHeader("Location", "/home")
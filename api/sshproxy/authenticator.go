@@ -0,0 +1,250 @@
+package sshproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const userPrefix = "cf:"
+
+var (
+	ErrInvalidUser        = errors.New("the user must have the form cf:<process-guid>/<index>")
+	ErrInstanceNotFound   = errors.New("the app instance does not exist or is not accessible")
+	ErrSSHDisabled        = errors.New("ssh is disabled for the app")
+	ErrInstanceNotRunning = errors.New("the app instance is not running")
+	ErrNotSpaceDeveloper  = errors.New("only space developers can ssh into app instances")
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+//counterfeiter:generate -o fake -fake-name NamespacePermissions . NamespacePermissions
+type NamespacePermissions interface {
+	AuthorizedIn(context.Context, authorization.Identity, string) (bool, error)
+}
+
+//counterfeiter:generate -o fake -fake-name SSHCodeRedeemer . SSHCodeRedeemer
+type SSHCodeRedeemer interface {
+	Redeem(context.Context, string) (authorization.Identity, error)
+}
+
+// Target is the app instance container an SSH session is bridged to
+type Target struct {
+	Namespace string
+	Pod       string
+	Container string
+	Identity  authorization.Identity
+}
+
+// Authenticator resolves the user and password of an SSH session to the app
+// instance the session is allowed to connect to. Users do not have access to
+// the exec and port-forward pod subresources, so the authenticator is where
+// the ssh settings of the app and space and the role of the user are enforced
+type Authenticator struct {
+	privilegedClient client.Client
+	infoParser       *authorization.InfoParser
+	sshCodeRedeemer  SSHCodeRedeemer
+	identityProvider authorization.IdentityProvider
+	nsPermissions    NamespacePermissions
+}
+
+func NewAuthenticator(
+	privilegedClient client.Client,
+	infoParser *authorization.InfoParser,
+	sshCodeRedeemer SSHCodeRedeemer,
+	identityProvider authorization.IdentityProvider,
+	nsPermissions NamespacePermissions,
+) *Authenticator {
+	return &Authenticator{
+		privilegedClient: privilegedClient,
+		infoParser:       infoParser,
+		sshCodeRedeemer:  sshCodeRedeemer,
+		identityProvider: identityProvider,
+		nsPermissions:    nsPermissions,
+	}
+}
+
+// Authenticate accepts either a code issued by the ssh code endpoint or a raw
+// Authorization header value as password
+func (a *Authenticator) Authenticate(ctx context.Context, user, password string) (Target, error) {
+	processGUID, index, err := parseUser(user)
+	if err != nil {
+		return Target{}, err
+	}
+
+	identity, err := a.getIdentity(ctx, password)
+	if err != nil {
+		return Target{}, err
+	}
+
+	process, err := a.getProcess(ctx, processGUID)
+	if err != nil {
+		return Target{}, err
+	}
+
+	authorized, err := a.nsPermissions.AuthorizedIn(ctx, identity, process.Namespace)
+	if err != nil {
+		return Target{}, fmt.Errorf("failed to check space roles: %w", err)
+	}
+
+	if !authorized {
+		return Target{}, ErrInstanceNotFound
+	}
+
+	if err = a.ensureSSHEnabled(ctx, process); err != nil {
+		return Target{}, err
+	}
+
+	if err = a.ensureSpaceDeveloper(ctx, identity, process.Namespace); err != nil {
+		return Target{}, err
+	}
+
+	pod, err := a.getInstancePod(ctx, process, index)
+	if err != nil {
+		return Target{}, err
+	}
+
+	return Target{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		// the app container is the first container of the instance pod,
+		// sidecars come after it
+		Container: pod.Spec.Containers[0].Name,
+		Identity:  identity,
+	}, nil
+}
+
+// getIdentity redeems ssh codes, which never contain a space, and
+// authenticates any other password as an Authorization header value
+func (a *Authenticator) getIdentity(ctx context.Context, password string) (authorization.Identity, error) {
+	if !strings.Contains(password, " ") {
+		identity, err := a.sshCodeRedeemer.Redeem(ctx, password)
+		if err != nil {
+			return authorization.Identity{}, fmt.Errorf("failed to redeem ssh code: %w", err)
+		}
+		return identity, nil
+	}
+
+	authInfo, err := a.infoParser.Parse(password)
+	if err != nil {
+		return authorization.Identity{}, fmt.Errorf("failed to parse credentials: %w", err)
+	}
+
+	identity, err := a.identityProvider.GetIdentity(ctx, authInfo)
+	if err != nil {
+		return authorization.Identity{}, fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	return identity, nil
+}
+
+func parseUser(user string) (string, string, error) {
+	processGUID, index, found := strings.Cut(strings.TrimPrefix(user, userPrefix), "/")
+	if !strings.HasPrefix(user, userPrefix) || !found || processGUID == "" {
+		return "", "", ErrInvalidUser
+	}
+
+	if i, err := strconv.Atoi(index); err != nil || i < 0 {
+		return "", "", ErrInvalidUser
+	}
+
+	return processGUID, index, nil
+}
+
+func (a *Authenticator) getProcess(ctx context.Context, processGUID string) (korifiv1alpha1.CFProcess, error) {
+	processes := korifiv1alpha1.CFProcessList{}
+	if err := a.privilegedClient.List(ctx, &processes, client.MatchingFields{"metadata.name": processGUID}); err != nil {
+		return korifiv1alpha1.CFProcess{}, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	if len(processes.Items) != 1 {
+		return korifiv1alpha1.CFProcess{}, ErrInstanceNotFound
+	}
+
+	return processes.Items[0], nil
+}
+
+func (a *Authenticator) ensureSSHEnabled(ctx context.Context, process korifiv1alpha1.CFProcess) error {
+	app := &korifiv1alpha1.CFApp{}
+	if err := a.privilegedClient.Get(ctx, types.NamespacedName{Namespace: process.Namespace, Name: process.Spec.AppRef.Name}, app); err != nil {
+		return fmt.Errorf("failed to get app: %w", err)
+	}
+
+	if app.Spec.EnableSSH != nil && !*app.Spec.EnableSSH {
+		return ErrSSHDisabled
+	}
+
+	spaceNamespace := &corev1.Namespace{}
+	if err := a.privilegedClient.Get(ctx, types.NamespacedName{Name: process.Namespace}, spaceNamespace); err != nil {
+		return fmt.Errorf("failed to get space namespace: %w", err)
+	}
+
+	space := &korifiv1alpha1.CFSpace{}
+	if err := a.privilegedClient.Get(ctx, types.NamespacedName{Namespace: spaceNamespace.Labels[korifiv1alpha1.CFOrgGUIDKey], Name: process.Namespace}, space); err != nil {
+		return fmt.Errorf("failed to get space: %w", err)
+	}
+
+	if space.Spec.AllowSSH != nil && !*space.Spec.AllowSSH {
+		return ErrSSHDisabled
+	}
+
+	return nil
+}
+
+// ensureSpaceDeveloper checks that the user may update the apps of the space,
+// which only space developers and admins may do
+func (a *Authenticator) ensureSpaceDeveloper(ctx context.Context, identity authorization.Identity, namespace string) error {
+	review := &authv1.SubjectAccessReview{
+		Spec: authv1.SubjectAccessReviewSpec{
+			User: identity.Name,
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "patch",
+				Group:     korifiv1alpha1.SchemeGroupVersion.Group,
+				Resource:  "cfapps",
+			},
+		},
+	}
+	if err := a.privilegedClient.Create(ctx, review); err != nil {
+		return fmt.Errorf("failed to review access: %w", err)
+	}
+
+	if !review.Status.Allowed {
+		return ErrNotSpaceDeveloper
+	}
+
+	return nil
+}
+
+func (a *Authenticator) getInstancePod(ctx context.Context, process korifiv1alpha1.CFProcess, index string) (corev1.Pod, error) {
+	pods := corev1.PodList{}
+	err := a.privilegedClient.List(ctx, &pods,
+		client.InNamespace(process.Namespace),
+		client.MatchingLabels{
+			korifiv1alpha1.GUIDLabelKey:     process.Name,
+			korifiv1alpha1.PodIndexLabelKey: index,
+		},
+	)
+	if err != nil {
+		return corev1.Pod{}, fmt.Errorf("failed to list instance pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp == nil && len(pod.Spec.Containers) > 0 {
+			return pod, nil
+		}
+	}
+
+	return corev1.Pod{}, ErrInstanceNotRunning
+}
@@ -0,0 +1,312 @@
+package sshproxy_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	authfake "code.cloudfoundry.org/korifi/api/authorization/fake"
+	"code.cloudfoundry.org/korifi/api/sshproxy"
+	"code.cloudfoundry.org/korifi/api/sshproxy/fake"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	controllersfake "code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Authenticator", func() {
+	var (
+		ctx              context.Context
+		privilegedClient *controllersfake.Client
+		identityProvider *authfake.IdentityProvider
+		nsPermissions    *fake.NamespacePermissions
+		sshCodeRedeemer  *fake.SSHCodeRedeemer
+		authenticator    *sshproxy.Authenticator
+
+		cfApp          *korifiv1alpha1.CFApp
+		cfSpace        *korifiv1alpha1.CFSpace
+		pods           []corev1.Pod
+		accessAllowed  bool
+		accessReviewed *authv1.SubjectAccessReview
+		user           string
+		password       string
+
+		target  sshproxy.Target
+		authErr error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		user = "cf:process-guid/1"
+		password = "the-code"
+		accessAllowed = true
+		accessReviewed = nil
+
+		cfApp = &korifiv1alpha1.CFApp{}
+		cfSpace = &korifiv1alpha1.CFSpace{}
+		pods = []corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "space-guid", Name: "process-pod-1"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "application"}, {Name: "sidecar"}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}}
+
+		privilegedClient = new(controllersfake.Client)
+		privilegedClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch list := list.(type) {
+			case *korifiv1alpha1.CFProcessList:
+				list.Items = []korifiv1alpha1.CFProcess{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "space-guid", Name: "process-guid"},
+					Spec: korifiv1alpha1.CFProcessSpec{
+						AppRef: corev1.LocalObjectReference{Name: "app-guid"},
+					},
+				}}
+			case *corev1.PodList:
+				list.Items = pods
+			default:
+				panic("TestClient List provided an unexpected object type")
+			}
+			return nil
+		}
+		privilegedClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *korifiv1alpha1.CFApp:
+				cfApp.DeepCopyInto(obj)
+			case *corev1.Namespace:
+				obj.Labels = map[string]string{korifiv1alpha1.CFOrgGUIDKey: "org-guid"}
+			case *korifiv1alpha1.CFSpace:
+				cfSpace.DeepCopyInto(obj)
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+			return nil
+		}
+		privilegedClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			review := obj.(*authv1.SubjectAccessReview)
+			accessReviewed = review.DeepCopy()
+			review.Status.Allowed = accessAllowed
+			return nil
+		}
+
+		sshCodeRedeemer = new(fake.SSHCodeRedeemer)
+		sshCodeRedeemer.RedeemReturns(authorization.Identity{Name: "the-user", Kind: "User"}, nil)
+		identityProvider = new(authfake.IdentityProvider)
+		identityProvider.GetIdentityReturns(authorization.Identity{Name: "the-token-user", Kind: "User"}, nil)
+		nsPermissions = new(fake.NamespacePermissions)
+		nsPermissions.AuthorizedInReturns(true, nil)
+
+		authenticator = sshproxy.NewAuthenticator(
+			privilegedClient,
+			authorization.NewInfoParser(),
+			sshCodeRedeemer,
+			identityProvider,
+			nsPermissions,
+		)
+	})
+
+	JustBeforeEach(func() {
+		target, authErr = authenticator.Authenticate(ctx, user, password)
+	})
+
+	It("returns the app container of the instance pod", func() {
+		Expect(authErr).NotTo(HaveOccurred())
+		Expect(target).To(Equal(sshproxy.Target{
+			Namespace: "space-guid",
+			Pod:       "process-pod-1",
+			Container: "application",
+			Identity:  authorization.Identity{Name: "the-user", Kind: "User"},
+		}))
+	})
+
+	It("redeems the ssh code", func() {
+		Expect(sshCodeRedeemer.RedeemCallCount()).To(Equal(1))
+		_, actualCode := sshCodeRedeemer.RedeemArgsForCall(0)
+		Expect(actualCode).To(Equal("the-code"))
+		Expect(identityProvider.GetIdentityCallCount()).To(BeZero())
+	})
+
+	It("looks up the process by guid", func() {
+		_, _, listOpts := privilegedClient.ListArgsForCall(0)
+		Expect(listOpts).To(ConsistOf(client.MatchingFields{"metadata.name": "process-guid"}))
+	})
+
+	It("checks that the user has a role in the space", func() {
+		Expect(nsPermissions.AuthorizedInCallCount()).To(Equal(1))
+		_, actualIdentity, actualNamespace := nsPermissions.AuthorizedInArgsForCall(0)
+		Expect(actualIdentity).To(Equal(authorization.Identity{Name: "the-user", Kind: "User"}))
+		Expect(actualNamespace).To(Equal("space-guid"))
+	})
+
+	It("checks that the user is a space developer", func() {
+		Expect(accessReviewed).NotTo(BeNil())
+		Expect(accessReviewed.Spec.User).To(Equal("the-user"))
+		Expect(accessReviewed.Spec.ResourceAttributes).To(Equal(&authv1.ResourceAttributes{
+			Namespace: "space-guid",
+			Verb:      "patch",
+			Group:     "korifi.cloudfoundry.org",
+			Resource:  "cfapps",
+		}))
+	})
+
+	It("lists the instance pods", func() {
+		Expect(privilegedClient.ListCallCount()).To(Equal(2))
+		_, _, listOpts := privilegedClient.ListArgsForCall(1)
+		Expect(listOpts).To(ConsistOf(
+			client.InNamespace("space-guid"),
+			client.MatchingLabels{
+				korifiv1alpha1.GUIDLabelKey:     "process-guid",
+				korifiv1alpha1.PodIndexLabelKey: "1",
+			},
+		))
+	})
+
+	It("gets the space from the org namespace", func() {
+		Expect(privilegedClient.GetCallCount()).To(Equal(3))
+		_, spaceKey, _, _ := privilegedClient.GetArgsForCall(2)
+		Expect(spaceKey).To(Equal(types.NamespacedName{Namespace: "org-guid", Name: "space-guid"}))
+	})
+
+	When("the password is an authorization header", func() {
+		BeforeEach(func() {
+			password = "Bearer the-token"
+		})
+
+		It("authenticates the user", func() {
+			Expect(authErr).NotTo(HaveOccurred())
+			Expect(target.Identity).To(Equal(authorization.Identity{Name: "the-token-user", Kind: "User"}))
+
+			Expect(identityProvider.GetIdentityCallCount()).To(Equal(1))
+			_, actualAuthInfo := identityProvider.GetIdentityArgsForCall(0)
+			Expect(actualAuthInfo.Token).To(Equal("the-token"))
+		})
+
+		It("does not redeem it as ssh code", func() {
+			Expect(sshCodeRedeemer.RedeemCallCount()).To(BeZero())
+		})
+
+		When("the user cannot be authenticated", func() {
+			BeforeEach(func() {
+				identityProvider.GetIdentityReturns(authorization.Identity{}, errors.New("invalid token"))
+			})
+
+			It("returns an error", func() {
+				Expect(authErr).To(MatchError(ContainSubstring("invalid token")))
+				Expect(privilegedClient.ListCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	When("the password is an invalid authorization header", func() {
+		BeforeEach(func() {
+			password = "Foo bar"
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("failed to parse credentials")))
+		})
+	})
+
+	When("the ssh code cannot be redeemed", func() {
+		BeforeEach(func() {
+			sshCodeRedeemer.RedeemReturns(authorization.Identity{}, authorization.ErrInvalidSSHCode)
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(authorization.ErrInvalidSSHCode))
+			Expect(privilegedClient.ListCallCount()).To(BeZero())
+		})
+	})
+
+	DescribeTable("invalid users",
+		func(invalidUser string) {
+			_, err := authenticator.Authenticate(ctx, invalidUser, password)
+			Expect(err).To(MatchError(sshproxy.ErrInvalidUser))
+		},
+		Entry("no prefix", "process-guid/0"),
+		Entry("no index", "cf:process-guid"),
+		Entry("no process guid", "cf:/0"),
+		Entry("non numeric index", "cf:process-guid/first"),
+		Entry("negative index", "cf:process-guid/-1"),
+	)
+
+	When("the process does not exist", func() {
+		BeforeEach(func() {
+			privilegedClient.ListStub = nil
+		})
+
+		It("returns a not found error", func() {
+			Expect(authErr).To(MatchError(sshproxy.ErrInstanceNotFound))
+		})
+	})
+
+	When("the user has no role in the space", func() {
+		BeforeEach(func() {
+			nsPermissions.AuthorizedInReturns(false, nil)
+		})
+
+		It("returns a not found error", func() {
+			Expect(authErr).To(MatchError(sshproxy.ErrInstanceNotFound))
+			Expect(accessReviewed).To(BeNil())
+		})
+	})
+
+	When("the user is not a space developer", func() {
+		BeforeEach(func() {
+			accessAllowed = false
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(sshproxy.ErrNotSpaceDeveloper))
+			Expect(privilegedClient.ListCallCount()).To(Equal(1))
+		})
+	})
+
+	When("ssh is disabled for the app", func() {
+		BeforeEach(func() {
+			cfApp.Spec.EnableSSH = tools.PtrTo(false)
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(sshproxy.ErrSSHDisabled))
+		})
+	})
+
+	When("ssh is not allowed in the space", func() {
+		BeforeEach(func() {
+			cfSpace.Spec.AllowSSH = tools.PtrTo(false)
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(sshproxy.ErrSSHDisabled))
+		})
+	})
+
+	When("the instance is not running", func() {
+		BeforeEach(func() {
+			pods[0].Status.Phase = corev1.PodPending
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(sshproxy.ErrInstanceNotRunning))
+		})
+	})
+
+	When("reviewing the access fails", func() {
+		BeforeEach(func() {
+			privilegedClient.CreateStub = nil
+			privilegedClient.CreateReturns(errors.New("review-err"))
+		})
+
+		It("returns an error", func() {
+			Expect(authErr).To(MatchError(ContainSubstring("review-err")))
+		})
+	})
+})
@@ -0,0 +1,118 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/sshproxy"
+)
+
+type SSHCodeRedeemer struct {
+	RedeemStub        func(context.Context, string) (authorization.Identity, error)
+	redeemMutex       sync.RWMutex
+	redeemArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	redeemReturns struct {
+		result1 authorization.Identity
+		result2 error
+	}
+	redeemReturnsOnCall map[int]struct {
+		result1 authorization.Identity
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SSHCodeRedeemer) Redeem(arg1 context.Context, arg2 string) (authorization.Identity, error) {
+	fake.redeemMutex.Lock()
+	ret, specificReturn := fake.redeemReturnsOnCall[len(fake.redeemArgsForCall)]
+	fake.redeemArgsForCall = append(fake.redeemArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.RedeemStub
+	fakeReturns := fake.redeemReturns
+	fake.recordInvocation("Redeem", []interface{}{arg1, arg2})
+	fake.redeemMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SSHCodeRedeemer) RedeemCallCount() int {
+	fake.redeemMutex.RLock()
+	defer fake.redeemMutex.RUnlock()
+	return len(fake.redeemArgsForCall)
+}
+
+func (fake *SSHCodeRedeemer) RedeemCalls(stub func(context.Context, string) (authorization.Identity, error)) {
+	fake.redeemMutex.Lock()
+	defer fake.redeemMutex.Unlock()
+	fake.RedeemStub = stub
+}
+
+func (fake *SSHCodeRedeemer) RedeemArgsForCall(i int) (context.Context, string) {
+	fake.redeemMutex.RLock()
+	defer fake.redeemMutex.RUnlock()
+	argsForCall := fake.redeemArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *SSHCodeRedeemer) RedeemReturns(result1 authorization.Identity, result2 error) {
+	fake.redeemMutex.Lock()
+	defer fake.redeemMutex.Unlock()
+	fake.RedeemStub = nil
+	fake.redeemReturns = struct {
+		result1 authorization.Identity
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSHCodeRedeemer) RedeemReturnsOnCall(i int, result1 authorization.Identity, result2 error) {
+	fake.redeemMutex.Lock()
+	defer fake.redeemMutex.Unlock()
+	fake.RedeemStub = nil
+	if fake.redeemReturnsOnCall == nil {
+		fake.redeemReturnsOnCall = make(map[int]struct {
+			result1 authorization.Identity
+			result2 error
+		})
+	}
+	fake.redeemReturnsOnCall[i] = struct {
+		result1 authorization.Identity
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SSHCodeRedeemer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SSHCodeRedeemer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ sshproxy.SSHCodeRedeemer = new(SSHCodeRedeemer)
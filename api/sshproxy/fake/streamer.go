@@ -0,0 +1,198 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/sshproxy"
+)
+
+type Streamer struct {
+	ExecStub        func(context.Context, sshproxy.Target, sshproxy.ExecRequest) (int, error)
+	execMutex       sync.RWMutex
+	execArgsForCall []struct {
+		arg1 context.Context
+		arg2 sshproxy.Target
+		arg3 sshproxy.ExecRequest
+	}
+	execReturns struct {
+		result1 int
+		result2 error
+	}
+	execReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	PortForwardStub        func(context.Context, sshproxy.Target, uint32, io.ReadWriter) error
+	portForwardMutex       sync.RWMutex
+	portForwardArgsForCall []struct {
+		arg1 context.Context
+		arg2 sshproxy.Target
+		arg3 uint32
+		arg4 io.ReadWriter
+	}
+	portForwardReturns struct {
+		result1 error
+	}
+	portForwardReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Streamer) Exec(arg1 context.Context, arg2 sshproxy.Target, arg3 sshproxy.ExecRequest) (int, error) {
+	fake.execMutex.Lock()
+	ret, specificReturn := fake.execReturnsOnCall[len(fake.execArgsForCall)]
+	fake.execArgsForCall = append(fake.execArgsForCall, struct {
+		arg1 context.Context
+		arg2 sshproxy.Target
+		arg3 sshproxy.ExecRequest
+	}{arg1, arg2, arg3})
+	stub := fake.ExecStub
+	fakeReturns := fake.execReturns
+	fake.recordInvocation("Exec", []interface{}{arg1, arg2, arg3})
+	fake.execMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Streamer) ExecCallCount() int {
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	return len(fake.execArgsForCall)
+}
+
+func (fake *Streamer) ExecCalls(stub func(context.Context, sshproxy.Target, sshproxy.ExecRequest) (int, error)) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = stub
+}
+
+func (fake *Streamer) ExecArgsForCall(i int) (context.Context, sshproxy.Target, sshproxy.ExecRequest) {
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	argsForCall := fake.execArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Streamer) ExecReturns(result1 int, result2 error) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = nil
+	fake.execReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Streamer) ExecReturnsOnCall(i int, result1 int, result2 error) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = nil
+	if fake.execReturnsOnCall == nil {
+		fake.execReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.execReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Streamer) PortForward(arg1 context.Context, arg2 sshproxy.Target, arg3 uint32, arg4 io.ReadWriter) error {
+	fake.portForwardMutex.Lock()
+	ret, specificReturn := fake.portForwardReturnsOnCall[len(fake.portForwardArgsForCall)]
+	fake.portForwardArgsForCall = append(fake.portForwardArgsForCall, struct {
+		arg1 context.Context
+		arg2 sshproxy.Target
+		arg3 uint32
+		arg4 io.ReadWriter
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.PortForwardStub
+	fakeReturns := fake.portForwardReturns
+	fake.recordInvocation("PortForward", []interface{}{arg1, arg2, arg3, arg4})
+	fake.portForwardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Streamer) PortForwardCallCount() int {
+	fake.portForwardMutex.RLock()
+	defer fake.portForwardMutex.RUnlock()
+	return len(fake.portForwardArgsForCall)
+}
+
+func (fake *Streamer) PortForwardCalls(stub func(context.Context, sshproxy.Target, uint32, io.ReadWriter) error) {
+	fake.portForwardMutex.Lock()
+	defer fake.portForwardMutex.Unlock()
+	fake.PortForwardStub = stub
+}
+
+func (fake *Streamer) PortForwardArgsForCall(i int) (context.Context, sshproxy.Target, uint32, io.ReadWriter) {
+	fake.portForwardMutex.RLock()
+	defer fake.portForwardMutex.RUnlock()
+	argsForCall := fake.portForwardArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *Streamer) PortForwardReturns(result1 error) {
+	fake.portForwardMutex.Lock()
+	defer fake.portForwardMutex.Unlock()
+	fake.PortForwardStub = nil
+	fake.portForwardReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Streamer) PortForwardReturnsOnCall(i int, result1 error) {
+	fake.portForwardMutex.Lock()
+	defer fake.portForwardMutex.Unlock()
+	fake.PortForwardStub = nil
+	if fake.portForwardReturnsOnCall == nil {
+		fake.portForwardReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.portForwardReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Streamer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Streamer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ sshproxy.Streamer = new(Streamer)
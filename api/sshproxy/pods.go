@@ -0,0 +1,172 @@
+package sshproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	k8sclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/transport/spdy"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list
+//+kubebuilder:rbac:groups="",resources=pods/exec;pods/portforward,verbs=get;create
+
+type ExecRequest struct {
+	Command       []string
+	Stdin         io.Reader
+	Stdout        io.Writer
+	Stderr        io.Writer
+	TTY           bool
+	TerminalSizes remotecommand.TerminalSizeQueue
+}
+
+// PodStreamer bridges SSH channels to the pods exec and port-forward
+// subresources. Users have no access to these subresources, so the requests
+// are made with the privileged config once the Authenticator has allowed the
+// session
+type PodStreamer struct {
+	config    *rest.Config
+	clientset k8sclient.Interface
+}
+
+func NewPodStreamer(config *rest.Config, clientset k8sclient.Interface) *PodStreamer {
+	return &PodStreamer{
+		config:    config,
+		clientset: clientset,
+	}
+}
+
+// Exec returns the exit status of the command
+func (s *PodStreamer) Exec(ctx context.Context, target Target, req ExecRequest) (int, error) {
+	execURL := s.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(target.Namespace).
+		Name(target.Pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: target.Container,
+			Command:   req.Command,
+			Stdin:     req.Stdin != nil,
+			Stdout:    req.Stdout != nil,
+			Stderr:    req.Stderr != nil && !req.TTY,
+			TTY:       req.TTY,
+		}, scheme.ParameterCodec).
+		URL()
+
+	spdyExecutor, err := remotecommand.NewSPDYExecutor(s.config, http.MethodPost, execURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create spdy executor: %w", err)
+	}
+
+	websocketExecutor, err := remotecommand.NewWebSocketExecutor(s.config, http.MethodGet, execURL.String())
+	if err != nil {
+		return 0, fmt.Errorf("failed to create websocket executor: %w", err)
+	}
+
+	executor, err := remotecommand.NewFallbackExecutor(websocketExecutor, spdyExecutor, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	streamOptions := remotecommand.StreamOptions{
+		Stdin:             req.Stdin,
+		Stdout:            req.Stdout,
+		Tty:               req.TTY,
+		TerminalSizeQueue: req.TerminalSizes,
+	}
+	if !req.TTY {
+		streamOptions.Stderr = req.Stderr
+	}
+
+	err = executor.StreamWithContext(ctx, streamOptions)
+	if err != nil {
+		var exitErr utilexec.ExitError
+		if errors.As(err, &exitErr) && exitErr.Exited() {
+			return exitErr.ExitStatus(), nil
+		}
+
+		return 0, fmt.Errorf("failed to exec into pod %s/%s: %w", target.Namespace, target.Pod, err)
+	}
+
+	return 0, nil
+}
+
+// PortForward forwards the connection to a port of the pod until either side
+// closes it
+func (s *PodStreamer) PortForward(ctx context.Context, target Target, port uint32, conn io.ReadWriter) error {
+	portForwardURL := s.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(target.Namespace).
+		Name(target.Pod).
+		SubResource("portforward").
+		URL()
+
+	transport, upgrader, err := spdy.RoundTripperFor(s.config)
+	if err != nil {
+		return fmt.Errorf("failed to create spdy round tripper: %w", err)
+	}
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, portForwardURL)
+	streamConn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	if err != nil {
+		return fmt.Errorf("failed to port-forward to pod %s/%s: %w", target.Namespace, target.Pod, err)
+	}
+	defer streamConn.Close()
+
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	headers.Set(corev1.PortHeader, strconv.FormatUint(uint64(port), 10))
+	headers.Set(corev1.PortForwardRequestIDHeader, "0")
+	errorStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		return fmt.Errorf("failed to create error stream: %w", err)
+	}
+	// the error stream is only read from
+	errorStream.Close()
+
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+	dataStream, err := streamConn.CreateStream(headers)
+	if err != nil {
+		return fmt.Errorf("failed to create data stream: %w", err)
+	}
+
+	go func() {
+		defer dataStream.Close()
+		_, _ = io.Copy(dataStream, conn)
+	}()
+
+	remoteDone := make(chan struct{})
+	go func() {
+		defer close(remoteDone)
+		_, _ = io.Copy(conn, dataStream)
+	}()
+
+	select {
+	case <-remoteDone:
+	case <-ctx.Done():
+	}
+	_ = dataStream.Reset()
+
+	message, err := io.ReadAll(errorStream)
+	if err != nil {
+		return fmt.Errorf("failed to read error stream: %w", err)
+	}
+	if len(message) > 0 {
+		return fmt.Errorf("failed to forward port %d: %s", port, message)
+	}
+
+	return nil
+}
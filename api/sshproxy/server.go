@@ -0,0 +1,327 @@
+package sshproxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// shellCommand starts a login shell, preferring bash as the cf ssh daemon does
+var shellCommand = []string{"/bin/sh", "-c", "if [ -x /bin/bash ]; then exec /bin/bash -l; fi; exec /bin/sh -l"}
+
+//counterfeiter:generate -o fake -fake-name TargetAuthenticator . TargetAuthenticator
+type TargetAuthenticator interface {
+	Authenticate(ctx context.Context, user, password string) (Target, error)
+}
+
+//counterfeiter:generate -o fake -fake-name Streamer . Streamer
+type Streamer interface {
+	Exec(context.Context, Target, ExecRequest) (int, error)
+	PortForward(context.Context, Target, uint32, io.ReadWriter) error
+}
+
+// Server is the SSH server `cf ssh` connects to. Sessions are bridged to an
+// exec into the app instance container and local port forwards are bridged
+// to port-forwards to the app instance pod
+type Server struct {
+	hostKey       ssh.Signer
+	authenticator TargetAuthenticator
+	streamer      Streamer
+}
+
+func NewServer(hostKey ssh.Signer, authenticator TargetAuthenticator, streamer Streamer) *Server {
+	return &Server{
+		hostKey:       hostKey,
+		authenticator: authenticator,
+		streamer:      streamer,
+	}
+}
+
+func LoadHostKey(path string) (ssh.Signer, error) {
+	keyBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read host key: %w", err)
+	}
+
+	hostKey, err := ssh.ParsePrivateKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse host key: %w", err)
+	}
+
+	return hostKey, nil
+}
+
+// Fingerprint returns the SHA256 fingerprint of the key in the format the cf
+// cli verifies the host key with
+func Fingerprint(key ssh.PublicKey) string {
+	sum := sha256.Sum256(key.Marshal())
+	return base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+func (s *Server) ListenAndServe(ctx context.Context, port int) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", port, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	return s.Serve(ctx, listener)
+}
+
+// Serve accepts connections until the listener is closed
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		go s.handleConn(ctx, conn)
+	}
+}
+
+func (s *Server) handleConn(ctx context.Context, netConn net.Conn) {
+	defer netConn.Close()
+	logger := logr.FromContextOrDiscard(ctx).WithName("ssh-proxy").WithValues("remoteAddr", netConn.RemoteAddr().String())
+
+	var target Target
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(meta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			var err error
+			target, err = s.authenticator.Authenticate(ctx, meta.User(), string(password))
+			if err != nil {
+				logger.Info("authentication failed", "user", meta.User(), "reason", err)
+				return nil, err
+			}
+
+			return &ssh.Permissions{}, nil
+		},
+	}
+	config.AddHostKey(s.hostKey)
+
+	conn, channels, requests, err := ssh.NewServerConn(netConn, config)
+	if err != nil {
+		logger.Info("ssh handshake failed", "reason", err)
+		return
+	}
+	defer conn.Close()
+
+	logger = logger.WithValues("namespace", target.Namespace, "pod", target.Pod, "identity", target.Identity.Name)
+	ctx, cancel := context.WithCancel(logr.NewContext(ctx, logger))
+	defer cancel()
+
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		switch newChannel.ChannelType() {
+		case "session":
+			go s.handleSession(ctx, target, newChannel)
+		case "direct-tcpip":
+			go s.handleDirectTCPIP(ctx, target, newChannel)
+		default:
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+		}
+	}
+}
+
+type ptyRequest struct {
+	Term    string
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+	Modes   string
+}
+
+type windowChangeRequest struct {
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+}
+
+type execRequest struct {
+	Command string
+}
+
+type exitStatus struct {
+	Status uint32
+}
+
+func (s *Server) handleSession(ctx context.Context, target Target, newChannel ssh.NewChannel) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		logger.Info("failed to accept session channel", "reason", err)
+		return
+	}
+	defer channel.Close()
+
+	terminalSizes := newTerminalSizeQueue()
+	defer terminalSizes.close()
+
+	tty := false
+	started := false
+	for req := range requests {
+		switch req.Type {
+		case "pty-req":
+			var pty ptyRequest
+			if err = ssh.Unmarshal(req.Payload, &pty); err != nil || started {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			tty = true
+			terminalSizes.push(pty.Columns, pty.Rows)
+			_ = req.Reply(true, nil)
+
+		case "window-change":
+			var change windowChangeRequest
+			if err = ssh.Unmarshal(req.Payload, &change); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			terminalSizes.push(change.Columns, change.Rows)
+			_ = req.Reply(true, nil)
+
+		case "shell", "exec":
+			command := shellCommand
+			if req.Type == "exec" {
+				var execReq execRequest
+				if err = ssh.Unmarshal(req.Payload, &execReq); err != nil {
+					_ = req.Reply(false, nil)
+					continue
+				}
+				command = []string{"/bin/sh", "-c", execReq.Command}
+			}
+
+			if started {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			started = true
+			_ = req.Reply(true, nil)
+
+			var sizes remotecommand.TerminalSizeQueue
+			if tty {
+				sizes = terminalSizes
+			}
+			go s.exec(ctx, target, channel, ExecRequest{
+				Command:       command,
+				Stdin:         channel,
+				Stdout:        channel,
+				Stderr:        channel.Stderr(),
+				TTY:           tty,
+				TerminalSizes: sizes,
+			})
+
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+func (s *Server) exec(ctx context.Context, target Target, channel ssh.Channel, req ExecRequest) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	status, err := s.streamer.Exec(ctx, target, req)
+	if err != nil {
+		logger.Info("exec failed", "reason", err)
+		fmt.Fprintf(channel.Stderr(), "Error: %v\r\n", err)
+		// 255 is the status ssh exits with when the session fails
+		status = 255
+	}
+
+	_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(exitStatus{Status: uint32(status)}))
+	_ = channel.Close()
+}
+
+type directTCPIPRequest struct {
+	Host           string
+	Port           uint32
+	OriginatorHost string
+	OriginatorPort uint32
+}
+
+func (s *Server) handleDirectTCPIP(ctx context.Context, target Target, newChannel ssh.NewChannel) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	var req directTCPIPRequest
+	if err := ssh.Unmarshal(newChannel.ExtraData(), &req); err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, "invalid port forward request")
+		return
+	}
+
+	if !isLocalhost(req.Host) {
+		_ = newChannel.Reject(ssh.Prohibited, "only ports on localhost of the app instance can be forwarded")
+		return
+	}
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		logger.Info("failed to accept port forward channel", "reason", err)
+		return
+	}
+	defer channel.Close()
+	go ssh.DiscardRequests(requests)
+
+	if err = s.streamer.PortForward(ctx, target, req.Port, channel); err != nil {
+		logger.Info("port forward failed", "port", req.Port, "reason", err)
+	}
+}
+
+func isLocalhost(host string) bool {
+	switch host {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	default:
+		return false
+	}
+}
+
+type terminalSizeQueue struct {
+	sizes chan remotecommand.TerminalSize
+}
+
+func newTerminalSizeQueue() *terminalSizeQueue {
+	return &terminalSizeQueue{
+		sizes: make(chan remotecommand.TerminalSize, 16),
+	}
+}
+
+// push drops the size when resizes are not consumed fast enough
+func (q *terminalSizeQueue) push(columns, rows uint32) {
+	select {
+	case q.sizes <- remotecommand.TerminalSize{Width: uint16(columns), Height: uint16(rows)}:
+	default:
+	}
+}
+
+func (q *terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q.sizes
+	if !ok {
+		return nil
+	}
+
+	return &size
+}
+
+func (q *terminalSizeQueue) close() {
+	close(q.sizes)
+}
@@ -0,0 +1,208 @@
+package sshproxy_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net"
+
+	"code.cloudfoundry.org/korifi/api/sshproxy"
+	"code.cloudfoundry.org/korifi/api/sshproxy/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"golang.org/x/crypto/ssh"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		ctx           context.Context
+		cancel        context.CancelFunc
+		hostKey       ssh.Signer
+		authenticator *fake.TargetAuthenticator
+		streamer      *fake.Streamer
+		listener      net.Listener
+		target        sshproxy.Target
+		sshClient     *ssh.Client
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(cancel)
+
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		hostKey, err = ssh.NewSignerFromKey(privateKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		target = sshproxy.Target{Namespace: "space-guid", Pod: "process-pod-0", Container: "application"}
+		authenticator = new(fake.TargetAuthenticator)
+		authenticator.AuthenticateReturns(target, nil)
+		streamer = new(fake.Streamer)
+
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(listener.Close)
+
+		server := sshproxy.NewServer(hostKey, authenticator, streamer)
+		go func() {
+			defer GinkgoRecover()
+			Expect(server.Serve(ctx, listener)).To(Succeed())
+		}()
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		sshClient, err = ssh.Dial("tcp", listener.Addr().String(), &ssh.ClientConfig{
+			User:            "cf:process-guid/0",
+			Auth:            []ssh.AuthMethod{ssh.Password("the-code")},
+			HostKeyCallback: ssh.FixedHostKey(hostKey.PublicKey()),
+		})
+		if err == nil {
+			DeferCleanup(sshClient.Close)
+		}
+	})
+
+	It("authenticates with the user and password", func() {
+		Expect(sshClient).NotTo(BeNil())
+		Expect(authenticator.AuthenticateCallCount()).To(Equal(1))
+		_, actualUser, actualPassword := authenticator.AuthenticateArgsForCall(0)
+		Expect(actualUser).To(Equal("cf:process-guid/0"))
+		Expect(actualPassword).To(Equal("the-code"))
+	})
+
+	When("authentication fails", func() {
+		BeforeEach(func() {
+			authenticator.AuthenticateReturns(sshproxy.Target{}, errors.New("unauthorized"))
+		})
+
+		It("rejects the connection", func() {
+			Expect(sshClient).To(BeNil())
+		})
+	})
+
+	Describe("exec", func() {
+		BeforeEach(func() {
+			streamer.ExecStub = func(_ context.Context, _ sshproxy.Target, req sshproxy.ExecRequest) (int, error) {
+				_, err := io.WriteString(req.Stdout, "hello")
+				Expect(err).NotTo(HaveOccurred())
+				return 3, nil
+			}
+		})
+
+		It("runs the command in the app instance container", func() {
+			session, err := sshClient.NewSession()
+			Expect(err).NotTo(HaveOccurred())
+			defer session.Close()
+
+			output, err := session.Output("echo hello")
+			var exitErr *ssh.ExitError
+			Expect(errors.As(err, &exitErr)).To(BeTrue())
+			Expect(exitErr.ExitStatus()).To(Equal(3))
+			Expect(string(output)).To(Equal("hello"))
+
+			Expect(streamer.ExecCallCount()).To(Equal(1))
+			_, actualTarget, actualReq := streamer.ExecArgsForCall(0)
+			Expect(actualTarget).To(Equal(target))
+			Expect(actualReq.Command).To(Equal([]string{"/bin/sh", "-c", "echo hello"}))
+			Expect(actualReq.TTY).To(BeFalse())
+			Expect(actualReq.TerminalSizes).To(BeNil())
+		})
+
+		When("a shell with a terminal is requested", func() {
+			It("starts a login shell with a tty", func() {
+				session, err := sshClient.NewSession()
+				Expect(err).NotTo(HaveOccurred())
+				defer session.Close()
+
+				Expect(session.RequestPty("xterm", 40, 80, ssh.TerminalModes{})).To(Succeed())
+				Expect(session.Shell()).To(Succeed())
+				_ = session.Wait()
+
+				Expect(streamer.ExecCallCount()).To(Equal(1))
+				_, _, actualReq := streamer.ExecArgsForCall(0)
+				Expect(actualReq.Command[0]).To(Equal("/bin/sh"))
+				Expect(actualReq.Command[2]).To(ContainSubstring("/bin/bash -l"))
+				Expect(actualReq.TTY).To(BeTrue())
+				Expect(actualReq.TerminalSizes.Next()).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Width":  BeEquivalentTo(80),
+					"Height": BeEquivalentTo(40),
+				})))
+			})
+		})
+
+		When("the exec fails", func() {
+			BeforeEach(func() {
+				streamer.ExecStub = nil
+				streamer.ExecReturns(0, errors.New("forbidden"))
+			})
+
+			It("exits with status 255", func() {
+				session, err := sshClient.NewSession()
+				Expect(err).NotTo(HaveOccurred())
+				defer session.Close()
+
+				err = session.Run("ls")
+				var exitErr *ssh.ExitError
+				Expect(errors.As(err, &exitErr)).To(BeTrue())
+				Expect(exitErr.ExitStatus()).To(Equal(255))
+			})
+		})
+	})
+
+	Describe("local port forwarding", func() {
+		BeforeEach(func() {
+			streamer.PortForwardStub = func(_ context.Context, _ sshproxy.Target, _ uint32, conn io.ReadWriter) error {
+				buf := make([]byte, 4)
+				if _, err := io.ReadFull(conn, buf); err != nil {
+					return err
+				}
+				_, err := conn.Write(append([]byte("pong:"), buf...))
+				return err
+			}
+		})
+
+		It("forwards the connection to the app instance port", func() {
+			conn, err := sshClient.Dial("tcp", "localhost:8080")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			_, err = conn.Write([]byte("ping"))
+			Expect(err).NotTo(HaveOccurred())
+
+			response := make([]byte, 9)
+			_, err = io.ReadFull(conn, response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(response)).To(Equal("pong:ping"))
+
+			Expect(streamer.PortForwardCallCount()).To(Equal(1))
+			_, actualTarget, actualPort, _ := streamer.PortForwardArgsForCall(0)
+			Expect(actualTarget).To(Equal(target))
+			Expect(actualPort).To(BeEquivalentTo(8080))
+		})
+
+		When("the forwarded host is not localhost", func() {
+			It("rejects the forward", func() {
+				_, err := sshClient.Dial("tcp", "example.org:80")
+				Expect(err).To(MatchError(ContainSubstring("only ports on localhost of the app instance can be forwarded")))
+				Expect(streamer.PortForwardCallCount()).To(BeZero())
+			})
+		})
+	})
+})
+
+var _ = Describe("Fingerprint", func() {
+	It("returns the unpadded base64 SHA256 fingerprint", func() {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		signer, err := ssh.NewSignerFromKey(privateKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		fingerprint := sshproxy.Fingerprint(signer.PublicKey())
+		Expect(fingerprint).To(HaveLen(43))
+		Expect("SHA256:" + fingerprint).To(Equal(ssh.FingerprintSHA256(signer.PublicKey())))
+	})
+})
@@ -0,0 +1,13 @@
+package sshproxy_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSSHProxy(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "SSH Proxy Suite")
+}
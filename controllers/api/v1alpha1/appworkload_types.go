@@ -56,6 +56,41 @@ type AppWorkloadSpec struct {
 	// Reference to service credentials secrets to be projected onto the app workload
 	// They are in the [servicebinding.io](https://servicebinding.io/spec/core/1.1.0/) format
 	Services []ServiceBinding `json:"services,omitempty"`
+
+	// Node labels the workload pods are pinned to, derived from the isolation segment of the space
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the workload pods, derived from the isolation segment of the space
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// The number of instances that are kept on the previous version while the workload is being updated.
+	// Instances with an ordinal lower than the partition are only updated once the partition is lowered.
+	// +kubebuilder:validation:Optional
+	RolloutPartition int32 `json:"rolloutPartition,omitempty"`
+
+	// The maximum number of instances that can be unavailable while the workload is being updated
+	// +kubebuilder:validation:Optional
+	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+
+	// Additional containers that run next to the workload container on the same image
+	// +kubebuilder:validation:Optional
+	Sidecars []AppWorkloadSidecar `json:"sidecars,omitempty"`
+
+	// The horizontal autoscaling policy of the workload. When set, the runner
+	// scales the instances of the workload between the policy bounds
+	// +kubebuilder:validation:Optional
+	Autoscaling *ProcessAutoscaling `json:"autoscaling,omitempty"`
+}
+
+type AppWorkloadSidecar struct {
+	// The name of the sidecar container
+	Name    string   `json:"name"`
+	Command []string `json:"command"`
+
+	// +kubebuilder:validation:Optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // AppWorkloadStatus defines the observed state of AppWorkload
@@ -69,8 +104,16 @@ type AppWorkloadStatus struct {
 	//+kubebuilder:validation:Optional
 	ActualInstances int32 `json:"actualInstances"`
 
+	// The number of instances running the current version of the workload
+	//+kubebuilder:validation:Optional
+	UpdatedInstances int32 `json:"updatedInstances"`
+
 	//+kubebuilder:validation:Optional
 	InstancesStatus map[string]InstanceStatus `json:"instancesStatus"`
+
+	// The status of the autoscaler, set when the workload has an autoscaling policy
+	//+kubebuilder:validation:Optional
+	Autoscaling *ProcessAutoscalingStatus `json:"autoscaling,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -23,6 +23,7 @@ import (
 
 const (
 	BuildWorkloadFinalizerName = "kpack-image-builder.korifi.cloudfoundry.org/buildworkload"
+	BuildWorkloadLabelKey      = "korifi.cloudfoundry.org/build-workload-name"
 )
 
 // BuildWorkloadSpec defines the desired state of BuildWorkload
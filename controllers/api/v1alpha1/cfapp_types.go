@@ -27,6 +27,15 @@ import (
 const (
 	DeploymentStatusValueActive    string = "ACTIVE"
 	DeploymentStatusValueFinalized string = "FINALIZED"
+
+	DeploymentStrategyRolling DeploymentStrategy = "rolling"
+	DeploymentStrategyCanary  DeploymentStrategy = "canary"
+
+	DeploymentStateDeploying DeploymentState = "DEPLOYING"
+	DeploymentStatePaused    DeploymentState = "PAUSED"
+	DeploymentStateCanceling DeploymentState = "CANCELING"
+	DeploymentStateDeployed  DeploymentState = "DEPLOYED"
+	DeploymentStateCanceled  DeploymentState = "CANCELED"
 )
 
 // CFAppSpec defines the desired state of CFApp
@@ -49,11 +58,71 @@ type CFAppSpec struct {
 
 	// A reference to the CFBuild currently assigned to the app. The CFBuild must be in the same namespace.
 	CurrentDropletRef corev1.LocalObjectReference `json:"currentDropletRef,omitempty"`
+
+	// The latest deployment of the app. It controls how the instances are rolled over to the current droplet.
+	// +kubebuilder:validation:Optional
+	Deployment *CFAppDeployment `json:"deployment,omitempty"`
+
+	// Whether SSH access to the app instances is enabled. SSH also has to be allowed in the space and enabled globally
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	EnableSSH *bool `json:"enableSSH,omitempty"`
 }
 
 // AppState defines the desired state of CFApp.
 type AppState string
 
+// DeploymentStrategy defines how the app instances are replaced during a deployment
+type DeploymentStrategy string
+
+// DeploymentState defines the progress of a deployment
+type DeploymentState string
+
+type CFAppDeployment struct {
+	// +kubebuilder:validation:Enum=rolling;canary
+	Strategy DeploymentStrategy `json:"strategy"`
+
+	// The maximum number of instances of each process that are replaced at the same time
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxInFlight *int32 `json:"maxInFlight,omitempty"`
+
+	// The canary steps of the deployment. When empty a canary deployment replaces a single instance before pausing.
+	// +kubebuilder:validation:Optional
+	CanarySteps []CanaryStep `json:"canarySteps,omitempty"`
+
+	// The index of the canary step the deployment is allowed to progress to. It is incremented every time the deployment is continued.
+	// +kubebuilder:validation:Optional
+	CurrentStep int32 `json:"currentStep,omitempty"`
+
+	// Canceled is set when the deployment is canceled and the app is rolled back to the previous droplet
+	// +kubebuilder:validation:Optional
+	Canceled bool `json:"canceled,omitempty"`
+
+	// The droplet the app was running before the deployment was created
+	// +kubebuilder:validation:Optional
+	PreviousDropletRef corev1.LocalObjectReference `json:"previousDropletRef,omitempty"`
+
+	// The app revision before the deployment was created
+	// +kubebuilder:validation:Optional
+	PreviousRevision string `json:"previousRevision,omitempty"`
+
+	// The CFRevision the deployment rolls the app back to
+	// +kubebuilder:validation:Optional
+	RevisionRef corev1.LocalObjectReference `json:"revisionRef,omitempty"`
+
+	// The CFRevision the app was running before the deployment was created. Canceling a deployment that rolls the app back to a revision restores it.
+	// +kubebuilder:validation:Optional
+	PreviousRevisionRef corev1.LocalObjectReference `json:"previousRevisionRef,omitempty"`
+}
+
+type CanaryStep struct {
+	// The percentage of the process instances that run the new droplet once the step is reached
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	InstanceWeight int32 `json:"instanceWeight"`
+}
+
 // CFAppStatus defines the observed state of CFApp
 type CFAppStatus struct {
 	//+kubebuilder:validation:Optional
@@ -81,6 +150,14 @@ type CFAppStatus struct {
 	// They are in the [servicebinding.io](https://servicebinding.io/spec/core/1.1.0/) format
 	//+kubebuilder:validation:Optional
 	ServiceBindings []ServiceBinding `json:"serviceBindings,omitempty"`
+
+	// The progress of the deployment in spec.deployment
+	//+kubebuilder:validation:Optional
+	DeploymentState DeploymentState `json:"deploymentState,omitempty"`
+
+	// A reference to the CFRevision of the app that is currently deployed
+	//+kubebuilder:validation:Optional
+	CurrentRevisionRef corev1.LocalObjectReference `json:"currentRevisionRef,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -126,3 +203,30 @@ func (a CFApp) UniqueName() string {
 func (a CFApp) UniqueValidationErrorMessage() string {
 	return fmt.Sprintf("App with the name '%s' already exists.", a.Spec.DisplayName)
 }
+
+// TotalCanarySteps returns the number of steps the deployment pauses at before rolling over all instances
+func (d *CFAppDeployment) TotalCanarySteps() int32 {
+	if d.Strategy != DeploymentStrategyCanary {
+		return 0
+	}
+
+	if len(d.CanarySteps) == 0 {
+		return 1
+	}
+
+	return int32(len(d.CanarySteps))
+}
+
+// UpdatedInstances returns how many of the given instances should run the new droplet at the current step of the deployment
+func (d *CFAppDeployment) UpdatedInstances(instances int32) int32 {
+	if d == nil || d.Canceled || d.CurrentStep >= d.TotalCanarySteps() {
+		return instances
+	}
+
+	if len(d.CanarySteps) == 0 {
+		return min(1, instances)
+	}
+
+	weight := d.CanarySteps[d.CurrentStep].InstanceWeight
+	return min(max((instances*weight+99)/100, 1), instances)
+}
@@ -0,0 +1,85 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AppUsageEventStateStarted = "STARTED"
+	AppUsageEventStateStopped = "STOPPED"
+	AppUsageEventStateScaled  = "SCALED"
+)
+
+// CFAppUsageEventSpec defines the desired state of CFAppUsageEvent
+type CFAppUsageEventSpec struct {
+	// The state of the process at the time of the event
+	// +kubebuilder:validation:Enum=STARTED;STOPPED;SCALED
+	State string `json:"state"`
+
+	// The state of the process before the event
+	// +optional
+	PreviousState string `json:"previousState,omitempty"`
+
+	App UsageEventResource `json:"app"`
+
+	Process UsageEventProcess `json:"process"`
+
+	Space UsageEventResource `json:"space"`
+
+	// +optional
+	OrganizationGUID string `json:"organizationGUID,omitempty"`
+
+	// The number of instances of the process at the time of the event
+	InstanceCount int32 `json:"instanceCount"`
+
+	// +optional
+	PreviousInstanceCount int32 `json:"previousInstanceCount,omitempty"`
+
+	// The memory limit of each instance of the process at the time of the event
+	MemoryInMBPerInstance int64 `json:"memoryInMBPerInstance"`
+
+	// +optional
+	PreviousMemoryInMBPerInstance int64 `json:"previousMemoryInMBPerInstance,omitempty"`
+}
+
+type UsageEventResource struct {
+	GUID string `json:"guid"`
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+type UsageEventProcess struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.app.name`
+//+kubebuilder:printcolumn:name="Process",type=string,JSONPath=`.spec.process.type`
+//+kubebuilder:printcolumn:name="Instances",type=integer,JSONPath=`.spec.instanceCount`
+//+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAppUsageEvent is the Schema for the cfappusageevents API
+type CFAppUsageEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFAppUsageEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAppUsageEventList contains a list of CFAppUsageEvent
+type CFAppUsageEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAppUsageEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAppUsageEvent{}, &CFAppUsageEventList{})
+}
@@ -0,0 +1,80 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	CFAuditEventTypeLabelKey       = "korifi.cloudfoundry.org/audit-event-type"
+	CFAuditEventTargetGUIDLabelKey = "korifi.cloudfoundry.org/audit-event-target-guid"
+	CFAuditEventScopeGUIDLabelKey  = "korifi.cloudfoundry.org/audit-event-scope-guid"
+)
+
+// CFAuditEventSpec defines the desired state of CFAuditEvent
+type CFAuditEventSpec struct {
+	// The type of the event, e.g. audit.app.create
+	Type string `json:"type"`
+
+	// The user or service account that performed the action
+	Actor AuditEventActor `json:"actor"`
+
+	// The resource the action was performed on
+	Target AuditEventTarget `json:"target"`
+
+	// The GUID of the space the target belongs to. Empty for events that are not scoped to a space
+	// +optional
+	SpaceGUID string `json:"spaceGUID,omitempty"`
+
+	// The GUID of the org the target belongs to. Empty for global events
+	// +optional
+	OrganizationGUID string `json:"organizationGUID,omitempty"`
+
+	// Additional information about the event, e.g. the request that triggered it
+	// +kubebuilder:validation:Optional
+	Data *runtime.RawExtension `json:"data,omitempty"`
+}
+
+type AuditEventActor struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventTarget struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="Actor",type=string,JSONPath=`.spec.actor.name`
+//+kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.target.guid`
+//+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
+//+kubebuilder:printcolumn:name="Updated At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/updated_at`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAuditEvent is the Schema for the cfauditevents API
+type CFAuditEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFAuditEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAuditEventList contains a list of CFAuditEvent
+type CFAuditEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAuditEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAuditEvent{}, &CFAuditEventList{})
+}
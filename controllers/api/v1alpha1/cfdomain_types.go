@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"slices"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,6 +30,27 @@ const (
 type CFDomainSpec struct {
 	// The domain name. It is required and must conform to RFC 1035
 	Name string `json:"name"`
+
+	// The name of the router group of the domain. Routes on a domain with a
+	// router group are TCP routes
+	// +optional
+	RouterGroup string `json:"routerGroup,omitempty"`
+
+	// Internal domains are only reachable from within the cluster. Routes on
+	// internal domains resolve to in-cluster Services and are not attached to
+	// the gateway
+	// +optional
+	Internal bool `json:"internal,omitempty"`
+
+	// The GUID of the CFOrg owning the domain. Domains without an owning org
+	// are shared with all orgs
+	// +optional
+	OrgGUID string `json:"orgGUID,omitempty"`
+
+	// The GUIDs of the CFOrgs, other than the owning one, that can use the
+	// domain. Only applies to domains owned by an org
+	// +optional
+	SharedOrgGUIDs []string `json:"sharedOrgGUIDs,omitempty"`
 }
 
 // CFDomainStatus defines the observed state of CFDomain
@@ -43,6 +66,8 @@ type CFDomainStatus struct {
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Namespaced
 //+kubebuilder:printcolumn:name="Domain Name",type=string,JSONPath=`.spec.name`
+//+kubebuilder:printcolumn:name="Router Group",type=string,JSONPath=`.spec.routerGroup`,priority=1
+//+kubebuilder:printcolumn:name="Org",type=string,JSONPath=`.spec.orgGUID`,priority=1
 //+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
 //+kubebuilder:printcolumn:name="Updated At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/updated_at`
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
@@ -61,6 +86,22 @@ func (d *CFDomain) StatusConditions() *[]metav1.Condition {
 	return &d.Status.Conditions
 }
 
+// IsPrivate tells whether the domain is owned by an org rather than shared
+// with all orgs
+func (d *CFDomain) IsPrivate() bool {
+	return d.Spec.OrgGUID != ""
+}
+
+// IsVisibleTo tells whether routes in the spaces of the given org can use the
+// domain
+func (d *CFDomain) IsVisibleTo(orgGUID string) bool {
+	if !d.IsPrivate() {
+		return true
+	}
+
+	return d.Spec.OrgGUID == orgGUID || slices.Contains(d.Spec.SharedOrgGUIDs, orgGUID)
+}
+
 //+kubebuilder:object:root=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
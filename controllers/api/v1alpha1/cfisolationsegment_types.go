@@ -0,0 +1,68 @@
+package v1alpha1
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFIsolationSegmentGUIDLabelKey = "korifi.cloudfoundry.org/isolation-segment-guid"
+)
+
+// CFIsolationSegmentSpec defines the desired state of CFIsolationSegment
+type CFIsolationSegmentSpec struct {
+	// The mutable, user-friendly name of the isolation segment
+	DisplayName string `json:"displayName"`
+
+	// Node labels the pods of apps and tasks running in the isolation segment are pinned to
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations set on the pods of apps and tasks running in the isolation segment, so
+	// that they can be scheduled on tainted dedicated nodes
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// GUIDs of the orgs entitled to use the isolation segment
+	// +optional
+	Orgs []string `json:"orgs,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
+//+kubebuilder:printcolumn:name="Updated At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/updated_at`
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFIsolationSegment is the Schema for the cfisolationsegments API
+type CFIsolationSegment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFIsolationSegmentSpec `json:"spec,omitempty"`
+}
+
+func (s CFIsolationSegment) UniqueName() string {
+	return strings.ToLower(s.Spec.DisplayName)
+}
+
+func (s CFIsolationSegment) UniqueValidationErrorMessage() string {
+	return "Isolation Segment names are case insensitive and must be unique"
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFIsolationSegmentList contains a list of CFIsolationSegment
+type CFIsolationSegmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFIsolationSegment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFIsolationSegment{}, &CFIsolationSegmentList{})
+}
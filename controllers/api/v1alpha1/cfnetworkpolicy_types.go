@@ -0,0 +1,88 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFNetworkPolicyGUIDLabelKey  = "korifi.cloudfoundry.org/network-policy-guid"
+	CFNetworkPolicyFinalizerName = "cfNetworkPolicy.korifi.cloudfoundry.org"
+
+	// AppIngressNetworkPolicyName is the name of the NetworkPolicy in every
+	// space namespace that denies ingress from other apps to the app pods
+	AppIngressNetworkPolicyName = "korifi-app-ingress"
+)
+
+// CFNetworkPolicySpec defines the desired state of CFNetworkPolicy
+type CFNetworkPolicySpec struct {
+	// A reference to the CFApp the traffic originates from. The CFApp must be
+	// in the same namespace
+	SourceAppRef corev1.LocalObjectReference `json:"sourceAppRef"`
+
+	// A reference to the CFApp the traffic is allowed to. The CFApp may be in
+	// the namespace of any space
+	DestinationAppRef corev1.ObjectReference `json:"destinationAppRef"`
+
+	// +kubebuilder:validation:Enum=tcp;udp
+	Protocol string `json:"protocol"`
+
+	// The first destination port traffic is allowed to
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	StartPort int32 `json:"startPort"`
+
+	// The last destination port traffic is allowed to
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	EndPort int32 `json:"endPort"`
+}
+
+// CFNetworkPolicyStatus defines the observed state of CFNetworkPolicy
+type CFNetworkPolicyStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFNetworkPolicy that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Source App",type=string,JSONPath=`.spec.sourceAppRef.name`
+//+kubebuilder:printcolumn:name="Destination App",type=string,JSONPath=`.spec.destinationAppRef.name`
+//+kubebuilder:printcolumn:name="Protocol",type=string,JSONPath=`.spec.protocol`
+//+kubebuilder:printcolumn:name="Start Port",type=integer,JSONPath=`.spec.startPort`
+//+kubebuilder:printcolumn:name="End Port",type=integer,JSONPath=`.spec.endPort`
+//+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
+//+kubebuilder:printcolumn:name="Updated At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/updated_at`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFNetworkPolicy is the Schema for the cfnetworkpolicies API. It allows
+// container to container traffic from the source app to the destination app
+type CFNetworkPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFNetworkPolicySpec   `json:"spec,omitempty"`
+	Status CFNetworkPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFNetworkPolicyList contains a list of CFNetworkPolicy
+type CFNetworkPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFNetworkPolicy `json:"items"`
+}
+
+func (p *CFNetworkPolicy) StatusConditions() *[]metav1.Condition {
+	return &p.Status.Conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&CFNetworkPolicy{}, &CFNetworkPolicyList{})
+}
@@ -35,6 +35,14 @@ type CFOrgSpec struct {
 	// The mutable, user-friendly name of the CFOrg. Unlike metadata.name, the user can change this field.
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// The GUID of the isolation segment used by the spaces of the org that have no isolation segment of their own
+	// +optional
+	DefaultIsolationSegmentGUID string `json:"defaultIsolationSegmentGUID,omitempty"`
+
+	// Suspended orgs are read-only for everyone but admins and their apps are stopped
+	// +optional
+	Suspended bool `json:"suspended,omitempty"`
 }
 
 // CFOrgStatus defines the observed state of CFOrg
@@ -53,6 +61,7 @@ type CFOrgStatus struct {
 //+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
 //+kubebuilder:printcolumn:name="Updated At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/updated_at`
 //+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Suspended",type=boolean,JSONPath=`.spec.suspended`
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -0,0 +1,99 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// QuotaAppsLimits limits the memory, instances and tasks apps can consume. A
+// nil limit means unlimited.
+type QuotaAppsLimits struct {
+	// Total memory in MB allowed for all started app instances and running tasks
+	// +optional
+	TotalMemoryInMB *int64 `json:"totalMemoryInMB,omitempty"`
+	// Maximum memory in MB a single process instance or task can use
+	// +optional
+	PerProcessMemoryInMB *int64 `json:"perProcessMemoryInMB,omitempty"`
+	// Total number of instances allowed for all started apps
+	// +optional
+	TotalInstances *int32 `json:"totalInstances,omitempty"`
+	// Maximum number of running tasks per app
+	// +optional
+	PerAppTasks *int32 `json:"perAppTasks,omitempty"`
+}
+
+// QuotaServicesLimits limits the service instances that can be created. A nil
+// limit means unlimited.
+type QuotaServicesLimits struct {
+	// Whether instances of service plans that are not free can be created
+	PaidServicesAllowed bool `json:"paidServicesAllowed"`
+	// Total number of service instances allowed
+	// +optional
+	TotalServiceInstances *int32 `json:"totalServiceInstances,omitempty"`
+}
+
+// QuotaRoutesLimits limits the routes that can be created. A nil limit means
+// unlimited.
+type QuotaRoutesLimits struct {
+	// Total number of routes allowed
+	// +optional
+	TotalRoutes *int32 `json:"totalRoutes,omitempty"`
+}
+
+// QuotaLimits is shared by CFOrgQuota and CFSpaceQuota
+type QuotaLimits struct {
+	Apps     QuotaAppsLimits     `json:"apps,omitempty"`
+	Services QuotaServicesLimits `json:"services,omitempty"`
+	Routes   QuotaRoutesLimits   `json:"routes,omitempty"`
+}
+
+// CFOrgQuotaSpec defines the desired state of CFOrgQuota
+type CFOrgQuotaSpec struct {
+	// The mutable, user-friendly name of the quota. Unlike metadata.name, the user can change this field.
+	DisplayName string `json:"displayName"`
+
+	QuotaLimits `json:",inline"`
+
+	// The GUIDs of the CFOrgs the quota is applied to
+	// +optional
+	Orgs []string `json:"orgs,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
+//+kubebuilder:printcolumn:name="Updated At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/updated_at`
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFOrgQuota is the Schema for the cforgquotas API
+type CFOrgQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFOrgQuotaSpec `json:"spec,omitempty"`
+}
+
+func (q CFOrgQuota) UniqueName() string {
+	return strings.ToLower(q.Spec.DisplayName)
+}
+
+func (q CFOrgQuota) UniqueValidationErrorMessage() string {
+	return fmt.Sprintf("Organization Quota '%s' already exists.", q.Spec.DisplayName)
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFOrgQuotaList contains a list of CFOrgQuota
+type CFOrgQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFOrgQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFOrgQuota{}, &CFOrgQuotaList{})
+}
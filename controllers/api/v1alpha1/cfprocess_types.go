@@ -42,6 +42,12 @@ type CFProcessSpec struct {
 	// Used to build the Liveness and Readiness Probes for the process' AppWorkload.
 	HealthCheck HealthCheck `json:"healthCheck"`
 
+	// Used to build the Readiness Probe for the process' AppWorkload. Instances
+	// failing the readiness health check do not receive traffic, but are not
+	// restarted.
+	// +kubebuilder:validation:Optional
+	ReadinessHealthCheck ReadinessHealthCheck `json:"readinessHealthCheck"`
+
 	// The desired number of replicas to deploy
 	DesiredInstances *int32 `json:"desiredInstances,omitempty"`
 
@@ -55,6 +61,55 @@ type CFProcessSpec struct {
 	// Deprecated: No longer used
 	// +kubebuilder:validation:Optional
 	Ports []int32 `json:"ports,omitempty"`
+
+	// The horizontal autoscaling policy of the process. When set, the number
+	// of instances is adjusted between the policy bounds and DesiredInstances
+	// is only used as the initial number of instances
+	// +kubebuilder:validation:Optional
+	Autoscaling *ProcessAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// ProcessAutoscaling defines when the instances of a process are scaled. When
+// no target is set, the instances are scaled on 80% CPU utilisation
+// +kubebuilder:validation:XValidation:rule="self.minInstances <= self.maxInstances",message="minInstances must not be greater than maxInstances"
+type ProcessAutoscaling struct {
+	// The minimum number of instances
+	// +kubebuilder:validation:Minimum=1
+	MinInstances int32 `json:"minInstances"`
+
+	// The maximum number of instances
+	// +kubebuilder:validation:Minimum=1
+	MaxInstances int32 `json:"maxInstances"`
+
+	// The target average CPU utilisation of the instances, as a percentage of their CPU request
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	CPUUtilizationPercent *int32 `json:"cpuUtilizationPercent,omitempty"`
+
+	// The target average memory utilisation of the instances, as a percentage of their memory request
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MemoryUtilizationPercent *int32 `json:"memoryUtilizationPercent,omitempty"`
+
+	// The target average number of HTTP requests per second per instance.
+	// This requires a custom metrics adapter serving the
+	// "http_requests_per_second" pods metric
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	HTTPRequestsPerSecond *int32 `json:"httpRequestsPerSecond,omitempty"`
+}
+
+// ProcessAutoscalingStatus reports the latest decision of the autoscaler
+type ProcessAutoscalingStatus struct {
+	// The number of instances currently managed by the autoscaler
+	CurrentInstances int32 `json:"currentInstances"`
+
+	// The number of instances the autoscaler last decided to scale to
+	DesiredInstances int32 `json:"desiredInstances"`
+
+	// The last time the autoscaler changed the number of instances
+	// +kubebuilder:validation:Optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
 }
 
 type HealthCheck struct {
@@ -78,6 +133,35 @@ type HealthCheckData struct {
 
 	InvocationTimeoutSeconds int32 `json:"invocationTimeoutSeconds"`
 	TimeoutSeconds           int32 `json:"timeoutSeconds"`
+
+	// The interval between liveness checks once the process has started.
+	// Defaults to 30 seconds when not set.
+	// +optional
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+
+	// The number of consecutive failed liveness checks after which the
+	// process is restarted. Defaults to 1 when not set.
+	// +optional
+	FailureThreshold int32 `json:"failureThreshold,omitempty"`
+}
+
+type ReadinessHealthCheck struct {
+	// The type of Readiness Health Check the App process will use
+	// Valid values are "http", "port", and "process". Defaults to "process",
+	// i.e. instances are ready as soon as they are running.
+	Type HealthCheckType `json:"type"`
+
+	// The input parameters for the readiness probe in kubernetes
+	Data ReadinessHealthCheckData `json:"data"`
+}
+
+// ReadinessHealthCheckData used to pass through input parameters to readiness probe
+type ReadinessHealthCheckData struct {
+	// The http endpoint to use with "http" healthchecks
+	HTTPEndpoint string `json:"httpEndpoint,omitempty"`
+
+	InvocationTimeoutSeconds int32 `json:"invocationTimeoutSeconds,omitempty"`
+	IntervalSeconds          int32 `json:"intervalSeconds,omitempty"`
 }
 
 // CFProcessStatus defines the observed state of CFProcess
@@ -93,6 +177,20 @@ type CFProcessStatus struct {
 
 	//+kubebuilder:validation:Optional
 	InstancesStatus map[string]InstanceStatus `json:"instancesStatus"`
+
+	// The usage of the process as recorded by the last app usage event
+	//+kubebuilder:validation:Optional
+	Usage *ProcessUsage `json:"usage,omitempty"`
+
+	// The status of the autoscaler, set when the process has an autoscaling policy
+	//+kubebuilder:validation:Optional
+	Autoscaling *ProcessAutoscalingStatus `json:"autoscaling,omitempty"`
+}
+
+type ProcessUsage struct {
+	State     string `json:"state"`
+	Instances int32  `json:"instances"`
+	MemoryMB  int64  `json:"memoryMB"`
 }
 
 //+kubebuilder:object:root=true
@@ -55,6 +55,7 @@ func (d *CFProcessDefaulter) Default(ctx context.Context, process *CFProcess) er
 	d.defaultResources(process)
 	d.defaultInstances(process)
 	d.defaultHealthCheck(process)
+	d.defaultReadinessHealthCheck(process)
 
 	return nil
 }
@@ -97,3 +98,11 @@ func (d *CFProcessDefaulter) defaultHealthCheck(process *CFProcess) {
 
 	process.Spec.HealthCheck.Type = "process"
 }
+
+func (d *CFProcessDefaulter) defaultReadinessHealthCheck(process *CFProcess) {
+	if process.Spec.ReadinessHealthCheck.Type != "" {
+		return
+	}
+
+	process.Spec.ReadinessHealthCheck.Type = ProcessHealthCheckType
+}
@@ -158,4 +158,20 @@ var _ = Describe("CFProcessMutatingWebhook", func() {
 			})
 		})
 	})
+
+	Describe("readiness healthcheck", func() {
+		It("defaults readiness healthcheck type to process", func() {
+			Expect(cfProcess.Spec.ReadinessHealthCheck.Type).To(BeEquivalentTo("process"))
+		})
+
+		When("the type is already set", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.ReadinessHealthCheck.Type = "http"
+			})
+
+			It("preserves the value", func() {
+				Expect(cfProcess.Spec.ReadinessHealthCheck.Type).To(BeEquivalentTo("http"))
+			})
+		})
+	})
 })
@@ -0,0 +1,93 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFRevisionVersionLabelKey = "korifi.cloudfoundry.org/revision-version"
+	CFRevisionEnvHashKey      = "korifi.cloudfoundry.org/revision-env-hash"
+	CFRevisionAppRevKey       = "korifi.cloudfoundry.org/revision-app-rev"
+)
+
+// CFRevisionSpec defines the desired state of CFRevision
+type CFRevisionSpec struct {
+	// A reference to the CFApp the revision is a snapshot of
+	AppRef corev1.LocalObjectReference `json:"appRef"`
+
+	// The version of the revision. Versions start at 1 and are incremented for every new revision of the app.
+	// +kubebuilder:validation:Minimum=1
+	Version int64 `json:"version"`
+
+	// A reference to the CFBuild the app was running when the revision was created
+	DropletRef corev1.LocalObjectReference `json:"dropletRef"`
+
+	// The name of a Secret in the same namespace containing a copy of the app environment variables
+	EnvSecretName string `json:"envSecretName"`
+
+	// The custom start commands of the app processes
+	// +optional
+	Processes []RevisionProcess `json:"processes,omitempty"`
+
+	// The sidecars of the app
+	// +optional
+	Sidecars []RevisionSidecar `json:"sidecars,omitempty"`
+
+	// A human readable description of what changed in the revision
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+type RevisionProcess struct {
+	// The type of the process
+	Type string `json:"type"`
+
+	// The custom start command of the process. Empty when the process runs the command detected by the droplet.
+	// +optional
+	Command string `json:"command,omitempty"`
+}
+
+type RevisionSidecar struct {
+	// The name of the sidecar
+	Name string `json:"name"`
+
+	// The command the sidecar runs
+	Command string `json:"command"`
+
+	// The types of the app processes the sidecar runs next to
+	ProcessTypes []string `json:"processTypes"`
+
+	// The memory limit of the sidecar container in MiB
+	// +optional
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Version",type=integer,JSONPath=`.spec.version`
+//+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
+//+kubebuilder:printcolumn:name="Updated At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/updated_at`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFRevision is the Schema for the cfrevisions API
+type CFRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFRevisionSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFRevisionList contains a list of CFRevision
+type CFRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFRevision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFRevision{}, &CFRevisionList{})
+}
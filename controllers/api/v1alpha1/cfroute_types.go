@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
@@ -56,6 +57,11 @@ type Destination struct {
 // +kubebuilder:validation:Enum=http;tcp
 type Protocol string
 
+const (
+	RouteProtocolHTTP Protocol = "http"
+	RouteProtocolTCP  Protocol = "tcp"
+)
+
 // CFRouteSpec defines the desired state of CFRoute
 type CFRouteSpec struct {
 	// The subdomain of the route within the domain. Host is optional and defaults to empty.
@@ -63,8 +69,14 @@ type CFRouteSpec struct {
 	Host string `json:"host,omitempty"`
 	// Path is optional, defaults to empty
 	Path string `json:"path,omitempty"`
-	// Protocol is optional and defaults to http. Currently only http is supported
+	// Protocol is optional and defaults to http. Routes on domains with a
+	// router group must use tcp
 	Protocol Protocol `json:"protocol,omitempty"`
+	// The port of a tcp route on the gateway. Port must only be set for tcp routes
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
 	// A reference to the CFDomain this CFRoute is assigned to, including name and namespace
 	DomainRef v1.ObjectReference `json:"domainRef"`
 	// Destinations are optional. A route can exist without any destinations, independently of any CFApps
@@ -121,11 +133,22 @@ func init() {
 	SchemeBuilder.Register(&CFRoute{}, &CFRouteList{})
 }
 
+// UniqueName of TCP routes is their port: the gateway has a single listener
+// per port shared by all domains, and router groups have disjoint reservable
+// port ranges, so a port is unique within its router group
 func (r CFRoute) UniqueName() string {
+	if r.Spec.Protocol == RouteProtocolTCP {
+		return strings.Join([]string{string(RouteProtocolTCP), strconv.Itoa(int(r.Spec.Port))}, "::")
+	}
+
 	return strings.Join([]string{strings.ToLower(r.Spec.Host), r.Spec.DomainRef.Namespace, r.Spec.DomainRef.Name, r.Spec.Path}, "::")
 }
 
 func (r CFRoute) UniqueValidationErrorMessage() string {
+	if r.Spec.Protocol == RouteProtocolTCP {
+		return fmt.Sprintf("Port %d is not available.", r.Spec.Port)
+	}
+
 	pathDetails := ""
 
 	if r.Spec.Path != "" {
@@ -13,6 +13,14 @@ const (
 	ProtocolALL    = "all"
 	ProtocolICMP   = "icmp"
 	ProtocolICMPv6 = "icmpv6"
+
+	CFSecurityGroupFinalizerName = "cfSecurityGroup.korifi.cloudfoundry.org"
+
+	CFSecurityGroupGUIDLabelKey      = "korifi.cloudfoundry.org/security-group-guid"
+	CFSecurityGroupWorkloadsLabelKey = "korifi.cloudfoundry.org/security-group-workloads"
+
+	SecurityGroupRunningWorkloads = "running"
+	SecurityGroupStagingWorkloads = "staging"
 )
 
 type SecurityGroupRule struct {
@@ -7,6 +7,9 @@ import (
 
 const (
 	CFServiceOfferingNameKey = "korifi.cloudfoundry.org/service-offering-name"
+
+	// The service offering permission required for its instances to be bound to routes
+	RouteForwardingRequirement = "route_forwarding"
 )
 
 // CFServiceOfferingSpec defines the desired state of CFServiceOffering
@@ -118,6 +118,18 @@ func (b *CFServiceBinding) StatusConditions() *[]metav1.Condition {
 	return &b.Status.Conditions
 }
 
+// ServiceInstanceNamespace returns the namespace of the bound service
+// instance. Bindings to service instances shared from another space set the
+// namespace on the service reference, all other bindings live next to the
+// instance.
+func (b CFServiceBinding) ServiceInstanceNamespace() string {
+	if b.Spec.Service.Namespace != "" {
+		return b.Spec.Service.Namespace
+	}
+
+	return b.Namespace
+}
+
 func (b CFServiceBinding) UniqueName() string {
 	return fmt.Sprintf("sb::%s::%s::%s::%s", b.Spec.AppRef.Name, b.Namespace, b.Spec.Service.Name, *tools.IfNil(b.Spec.DisplayName, &b.Spec.Service.Name))
 }
@@ -34,6 +34,7 @@ const (
 
 	ProvisioningFailedCondition   = "ProvisioningFailed"
 	DeprovisioningFailedCondition = "DeprovisioningFailed"
+	UpdateFailedCondition         = "UpdateFailed"
 )
 
 // CFServiceInstanceSpec defines the desired state of CFServiceInstance
@@ -59,6 +60,25 @@ type CFServiceInstanceSpec struct {
 	PlanGUID string `json:"planGuid"`
 
 	Parameters corev1.LocalObjectReference `json:"parameters,omitempty"`
+
+	// The maintenance info the service instance should be upgraded to. Only makes sense for managed service instances
+	// +optional
+	MaintenanceInfo *MaintenanceInfo `json:"maintenanceInfo,omitempty"`
+
+	// The GUIDs of the CFSpaces the service instance is shared with. Apps in these spaces can bind to the instance.
+	// Only makes sense for managed service instances
+	// +optional
+	SharedSpaces []string `json:"sharedSpaces,omitempty"`
+
+	// The URL of the route service requests to routes bound to the service instance are proxied through.
+	// Only makes sense for user-provided service instances
+	// +optional
+	RouteServiceURL string `json:"routeServiceURL,omitempty"`
+
+	// The URL of the syslog drain the logs of apps bound to the service instance are forwarded to.
+	// Only makes sense for user-provided service instances
+	// +optional
+	SyslogDrainURL string `json:"syslogDrainURL,omitempty"`
 }
 
 // InstanceType defines the type of the Service Instance
@@ -90,9 +110,27 @@ type CFServiceInstanceStatus struct {
 	//+kubebuilder:validation:Optional
 	MaintenanceInfo MaintenanceInfo `json:"maintenanceInfo"`
 
+	// The plan the broker has last provisioned or updated the service instance with. Only makes sense for managed service instances
+	//+kubebuilder:validation:Optional
+	PlanGUID string `json:"planGuid,omitempty"`
+
+	// A reference to the parameters secret last sent to the broker. Only makes sense for managed service instances
+	//+kubebuilder:validation:Optional
+	Parameters corev1.LocalObjectReference `json:"parameters,omitempty"`
+
 	// True if there is an upgrade available for for the service instance (i.e. the plan has a new version). Only makes seense for managed service instances
 	//+kubebuilder:validation:Optional
 	UpgradeAvailable bool `json:"upgradeAvailable"`
+
+	// The service instance as recorded by the last service usage event
+	//+kubebuilder:validation:Optional
+	Usage *ServiceInstanceUsage `json:"usage,omitempty"`
+}
+
+type ServiceInstanceUsage struct {
+	DisplayName string `json:"displayName"`
+	// +optional
+	PlanGUID string `json:"planGuid,omitempty"`
 }
 
 type LastOperation struct {
@@ -103,6 +141,10 @@ type LastOperation struct {
 
 	//+kubebuilder:validation:Optional
 	Description string `json:"description"`
+
+	// The broker operation to poll while an asynchronous operation is in progress
+	//+kubebuilder:validation:Optional
+	Operation string `json:"operation,omitempty"`
 }
 
 //+kubebuilder:object:root=true
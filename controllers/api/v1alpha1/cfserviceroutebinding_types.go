@@ -0,0 +1,86 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFServiceRouteBindingFinalizerName = "cfServiceRouteBinding.korifi.cloudfoundry.org"
+)
+
+// CFServiceRouteBindingSpec defines the desired state of CFServiceRouteBinding
+type CFServiceRouteBindingSpec struct {
+	// A reference to the CFServiceInstance requests to the route are proxied through. The CFServiceInstance must be in the same namespace
+	ServiceInstanceRef v1.LocalObjectReference `json:"serviceInstanceRef"`
+
+	// A reference to the CFRoute that is bound to the service instance. The CFRoute must be in the same namespace
+	RouteRef v1.LocalObjectReference `json:"routeRef"`
+
+	// A reference to the secret that contains the route binding parameters.
+	// Only makes sense for bindings to managed service instances
+	// +optional
+	Parameters v1.LocalObjectReference `json:"parameters,omitempty"`
+}
+
+// CFServiceRouteBindingStatus defines the observed state of CFServiceRouteBinding
+type CFServiceRouteBindingStatus struct {
+	// The URL of the route service requests to the route are proxied through.
+	// For user-provided service instances this is the route service URL of the
+	// instance, for managed service instances it is returned by the broker
+	// +optional
+	RouteServiceURL string `json:"routeServiceURL,omitempty"`
+
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFServiceRouteBinding that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
+//+kubebuilder:printcolumn:name="Updated At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/updated_at`
+//+kubebuilder:printcolumn:name="Route",type=string,JSONPath=`.spec.routeRef.name`
+//+kubebuilder:printcolumn:name="Service Instance",type=string,JSONPath=`.spec.serviceInstanceRef.name`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFServiceRouteBinding is the Schema for the cfserviceroutebindings API
+type CFServiceRouteBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFServiceRouteBindingSpec `json:"spec,omitempty"`
+
+	Status CFServiceRouteBindingStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFServiceRouteBindingList contains a list of CFServiceRouteBinding
+type CFServiceRouteBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFServiceRouteBinding `json:"items"`
+}
+
+func (b *CFServiceRouteBinding) StatusConditions() *[]metav1.Condition {
+	return &b.Status.Conditions
+}
+
+func (b CFServiceRouteBinding) UniqueName() string {
+	return fmt.Sprintf("srb::%s::%s", b.Namespace, b.Spec.RouteRef.Name)
+}
+
+func (b CFServiceRouteBinding) UniqueValidationErrorMessage() string {
+	return "A route may only be bound to a single service instance"
+}
+
+func init() {
+	SchemeBuilder.Register(&CFServiceRouteBinding{}, &CFServiceRouteBindingList{})
+}
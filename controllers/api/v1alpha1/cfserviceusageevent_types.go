@@ -0,0 +1,80 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFServiceUsageEventInstanceTypeLabelKey = "korifi.cloudfoundry.org/service-usage-event-instance-type"
+	CFServiceUsageEventOfferingGUIDLabelKey = "korifi.cloudfoundry.org/service-usage-event-offering-guid"
+
+	ServiceUsageEventStateCreated = "CREATED"
+	ServiceUsageEventStateDeleted = "DELETED"
+	ServiceUsageEventStateUpdated = "UPDATED"
+
+	ManagedServiceInstanceUsageType      = "managed_service_instance"
+	UserProvidedServiceInstanceUsageType = "user_provided_service_instance"
+)
+
+// CFServiceUsageEventSpec defines the desired state of CFServiceUsageEvent
+type CFServiceUsageEventSpec struct {
+	// The state of the service instance at the time of the event
+	// +kubebuilder:validation:Enum=CREATED;DELETED;UPDATED
+	State string `json:"state"`
+
+	ServiceInstance UsageEventServiceInstance `json:"serviceInstance"`
+
+	Space UsageEventResource `json:"space"`
+
+	// +optional
+	OrganizationGUID string `json:"organizationGUID,omitempty"`
+
+	// The plan of a managed service instance
+	// +optional
+	ServicePlan *UsageEventResource `json:"servicePlan,omitempty"`
+
+	// The offering of a managed service instance
+	// +optional
+	ServiceOffering *UsageEventResource `json:"serviceOffering,omitempty"`
+
+	// The broker of a managed service instance
+	// +optional
+	ServiceBroker *UsageEventResource `json:"serviceBroker,omitempty"`
+}
+
+type UsageEventServiceInstance struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=managed_service_instance;user_provided_service_instance
+	Type string `json:"type"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="Service Instance",type=string,JSONPath=`.spec.serviceInstance.name`
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.serviceInstance.type`
+//+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFServiceUsageEvent is the Schema for the cfserviceusageevents API
+type CFServiceUsageEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFServiceUsageEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFServiceUsageEventList contains a list of CFServiceUsageEvent
+type CFServiceUsageEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFServiceUsageEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFServiceUsageEvent{}, &CFServiceUsageEventList{})
+}
@@ -0,0 +1,75 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFSidecarSpec defines the desired state of CFSidecar
+type CFSidecarSpec struct {
+	// A reference to the CFApp the sidecar belongs to. The CFApp must be in the same namespace
+	AppRef corev1.LocalObjectReference `json:"appRef"`
+
+	// The name of the sidecar. Sidecar names are unique within an app
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// The command the sidecar runs on the droplet image of the app
+	// +kubebuilder:validation:MinLength=1
+	Command string `json:"command"`
+
+	// The types of the app processes the sidecar runs next to
+	// +kubebuilder:validation:MinItems=1
+	ProcessTypes []string `json:"processTypes"`
+
+	// The memory limit of the sidecar container in MiB. When not set the
+	// sidecar container has no memory limit of its own
+	// +optional
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.appRef.name`
+//+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
+//+kubebuilder:printcolumn:name="Updated At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/updated_at`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFSidecar is the Schema for the cfsidecars API
+type CFSidecar struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFSidecarSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFSidecarList contains a list of CFSidecar
+type CFSidecarList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFSidecar `json:"items"`
+}
+
+func (s CFSidecar) UniqueName() string {
+	return fmt.Sprintf("sidecar::%s::%s::%s", s.Namespace, s.Spec.AppRef.Name, s.Spec.Name)
+}
+
+func (s CFSidecar) UniqueValidationErrorMessage() string {
+	return fmt.Sprintf("Sidecar with name '%s' already exists for given app", s.Spec.Name)
+}
+
+// RunsWith tells whether the sidecar runs next to processes of the given type
+func (s CFSidecar) RunsWith(processType string) bool {
+	return slices.Contains(s.Spec.ProcessTypes, processType)
+}
+
+func init() {
+	SchemeBuilder.Register(&CFSidecar{}, &CFSidecarList{})
+}
@@ -35,6 +35,15 @@ type CFSpaceSpec struct {
 	// The mutable, user-friendly name of the space. Unlike metadata.name, the user can change this field
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// The GUID of the isolation segment the apps and tasks of the space run in. Falls back to the default isolation segment of the org when empty
+	// +optional
+	IsolationSegmentGUID string `json:"isolationSegmentGUID,omitempty"`
+
+	// Whether SSH access to the instances of the apps in the space is allowed
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=true
+	AllowSSH *bool `json:"allowSSH,omitempty"`
 }
 
 // CFSpaceStatus defines the observed state of CFSpace
@@ -0,0 +1,58 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFSpaceQuotaSpec defines the desired state of CFSpaceQuota
+type CFSpaceQuotaSpec struct {
+	// The mutable, user-friendly name of the quota. Unlike metadata.name, the user can change this field.
+	DisplayName string `json:"displayName"`
+
+	QuotaLimits `json:",inline"`
+
+	// The GUIDs of the CFSpaces the quota is applied to. The spaces must
+	// belong to the org the quota lives in.
+	// +optional
+	Spaces []string `json:"spaces,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
+//+kubebuilder:printcolumn:name="Updated At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/updated_at`
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFSpaceQuota is the Schema for the cfspacequotas API
+type CFSpaceQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFSpaceQuotaSpec `json:"spec,omitempty"`
+}
+
+func (q CFSpaceQuota) UniqueName() string {
+	return strings.ToLower(q.Spec.DisplayName)
+}
+
+func (q CFSpaceQuota) UniqueValidationErrorMessage() string {
+	return fmt.Sprintf("Space Quota '%s' already exists.", q.Spec.DisplayName)
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFSpaceQuotaList contains a list of CFSpaceQuota
+type CFSpaceQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFSpaceQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFSpaceQuota{}, &CFSpaceQuotaList{})
+}
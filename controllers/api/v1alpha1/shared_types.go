@@ -30,6 +30,7 @@ const (
 	CFRouteGUIDLabelKey         = "korifi.cloudfoundry.org/route-guid"
 	CFRouteHostLabelKey         = "korifi.cloudfoundry.org/route-host"
 	CFRoutePathLabelKey         = "korifi.cloudfoundry.org/route-path"
+	CFRoutePortLabelKey         = "korifi.cloudfoundry.org/route-port"
 	CFTaskGUIDLabelKey          = "korifi.cloudfoundry.org/task-guid"
 
 	ReadyLabelKey           = "korifi.cloudfoundry.org/ready"
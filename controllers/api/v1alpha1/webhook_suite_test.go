@@ -101,11 +101,16 @@ var _ = BeforeSuite(func() {
 	Expect(korifiv1alpha1.NewCFAppDefaulter().SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(apps.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType)),
+		validation.NewQuotaValidator(uncachedClient, namespace),
+		validation.NewSuspensionValidator(uncachedClient, namespace),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect(routes.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, routes.RouteEntityType)),
+		validation.NewQuotaValidator(uncachedClient, namespace),
+		validation.NewSuspensionValidator(uncachedClient, namespace),
 		namespace,
+		nil,
 		uncachedClient,
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
@@ -85,6 +85,27 @@ func (in *AppWorkloadList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppWorkloadSidecar) DeepCopyInto(out *AppWorkloadSidecar) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWorkloadSidecar.
+func (in *AppWorkloadSidecar) DeepCopy() *AppWorkloadSidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(AppWorkloadSidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppWorkloadSpec) DeepCopyInto(out *AppWorkloadSpec) {
 	*out = *in
@@ -131,6 +152,37 @@ func (in *AppWorkloadSpec) DeepCopyInto(out *AppWorkloadSpec) {
 		*out = make([]ServiceBinding, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]AppWorkloadSidecar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ProcessAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWorkloadSpec.
@@ -160,6 +212,11 @@ func (in *AppWorkloadStatus) DeepCopyInto(out *AppWorkloadStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ProcessAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWorkloadStatus.
@@ -172,6 +229,36 @@ func (in *AppWorkloadStatus) DeepCopy() *AppWorkloadStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditEventActor) DeepCopyInto(out *AuditEventActor) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditEventActor.
+func (in *AuditEventActor) DeepCopy() *AuditEventActor {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditEventActor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditEventTarget) DeepCopyInto(out *AuditEventTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditEventTarget.
+func (in *AuditEventTarget) DeepCopy() *AuditEventTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditEventTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrokerCatalogFeatures) DeepCopyInto(out *BrokerCatalogFeatures) {
 	*out = *in
@@ -519,6 +606,34 @@ func (in *CFAppDefaulter) DeepCopy() *CFAppDefaulter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppDeployment) DeepCopyInto(out *CFAppDeployment) {
+	*out = *in
+	if in.MaxInFlight != nil {
+		in, out := &in.MaxInFlight, &out.MaxInFlight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CanarySteps != nil {
+		in, out := &in.CanarySteps, &out.CanarySteps
+		*out = make([]CanaryStep, len(*in))
+		copy(*out, *in)
+	}
+	out.PreviousDropletRef = in.PreviousDropletRef
+	out.RevisionRef = in.RevisionRef
+	out.PreviousRevisionRef = in.PreviousRevisionRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppDeployment.
+func (in *CFAppDeployment) DeepCopy() *CFAppDeployment {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppDeployment)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFAppList) DeepCopyInto(out *CFAppList) {
 	*out = *in
@@ -556,6 +671,16 @@ func (in *CFAppSpec) DeepCopyInto(out *CFAppSpec) {
 	*out = *in
 	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
 	out.CurrentDropletRef = in.CurrentDropletRef
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(CFAppDeployment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnableSSH != nil {
+		in, out := &in.EnableSSH, &out.EnableSSH
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppSpec.
@@ -583,6 +708,7 @@ func (in *CFAppStatus) DeepCopyInto(out *CFAppStatus) {
 		*out = make([]ServiceBinding, len(*in))
 		copy(*out, *in)
 	}
+	out.CurrentRevisionRef = in.CurrentRevisionRef
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppStatus.
@@ -595,6 +721,162 @@ func (in *CFAppStatus) DeepCopy() *CFAppStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppUsageEvent) DeepCopyInto(out *CFAppUsageEvent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppUsageEvent.
+func (in *CFAppUsageEvent) DeepCopy() *CFAppUsageEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppUsageEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFAppUsageEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppUsageEventList) DeepCopyInto(out *CFAppUsageEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFAppUsageEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppUsageEventList.
+func (in *CFAppUsageEventList) DeepCopy() *CFAppUsageEventList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppUsageEventList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFAppUsageEventList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAppUsageEventSpec) DeepCopyInto(out *CFAppUsageEventSpec) {
+	*out = *in
+	out.App = in.App
+	out.Process = in.Process
+	out.Space = in.Space
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppUsageEventSpec.
+func (in *CFAppUsageEventSpec) DeepCopy() *CFAppUsageEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAppUsageEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAuditEvent) DeepCopyInto(out *CFAuditEvent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEvent.
+func (in *CFAuditEvent) DeepCopy() *CFAuditEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAuditEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFAuditEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAuditEventList) DeepCopyInto(out *CFAuditEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFAuditEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEventList.
+func (in *CFAuditEventList) DeepCopy() *CFAuditEventList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAuditEventList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFAuditEventList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFAuditEventSpec) DeepCopyInto(out *CFAuditEventSpec) {
+	*out = *in
+	out.Actor = in.Actor
+	out.Target = in.Target
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEventSpec.
+func (in *CFAuditEventSpec) DeepCopy() *CFAuditEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFAuditEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFBuild) DeepCopyInto(out *CFBuild) {
 	*out = *in
@@ -704,7 +986,7 @@ func (in *CFDomain) DeepCopyInto(out *CFDomain) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -761,6 +1043,11 @@ func (in *CFDomainList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFDomainSpec) DeepCopyInto(out *CFDomainSpec) {
 	*out = *in
+	if in.SharedOrgGUIDs != nil {
+		in, out := &in.SharedOrgGUIDs, &out.SharedOrgGUIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainSpec.
@@ -796,26 +1083,25 @@ func (in *CFDomainStatus) DeepCopy() *CFDomainStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrg) DeepCopyInto(out *CFOrg) {
+func (in *CFIsolationSegment) DeepCopyInto(out *CFIsolationSegment) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrg.
-func (in *CFOrg) DeepCopy() *CFOrg {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegment.
+func (in *CFIsolationSegment) DeepCopy() *CFIsolationSegment {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrg)
+	out := new(CFIsolationSegment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFOrg) DeepCopyObject() runtime.Object {
+func (in *CFIsolationSegment) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -823,31 +1109,31 @@ func (in *CFOrg) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgList) DeepCopyInto(out *CFOrgList) {
+func (in *CFIsolationSegmentList) DeepCopyInto(out *CFIsolationSegmentList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFOrg, len(*in))
+		*out = make([]CFIsolationSegment, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgList.
-func (in *CFOrgList) DeepCopy() *CFOrgList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegmentList.
+func (in *CFIsolationSegmentList) DeepCopy() *CFIsolationSegmentList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrgList)
+	out := new(CFIsolationSegmentList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFOrgList) DeepCopyObject() runtime.Object {
+func (in *CFIsolationSegmentList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -855,63 +1141,60 @@ func (in *CFOrgList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgSpec) DeepCopyInto(out *CFOrgSpec) {
+func (in *CFIsolationSegmentSpec) DeepCopyInto(out *CFIsolationSegmentSpec) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgSpec.
-func (in *CFOrgSpec) DeepCopy() *CFOrgSpec {
-	if in == nil {
-		return nil
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	out := new(CFOrgSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgStatus) DeepCopyInto(out *CFOrgStatus) {
-	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Orgs != nil {
+		in, out := &in.Orgs, &out.Orgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgStatus.
-func (in *CFOrgStatus) DeepCopy() *CFOrgStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegmentSpec.
+func (in *CFIsolationSegmentSpec) DeepCopy() *CFIsolationSegmentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrgStatus)
+	out := new(CFIsolationSegmentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackage) DeepCopyInto(out *CFPackage) {
+func (in *CFNetworkPolicy) DeepCopyInto(out *CFNetworkPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackage.
-func (in *CFPackage) DeepCopy() *CFPackage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFNetworkPolicy.
+func (in *CFNetworkPolicy) DeepCopy() *CFNetworkPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackage)
+	out := new(CFNetworkPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFPackage) DeepCopyObject() runtime.Object {
+func (in *CFNetworkPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -919,31 +1202,31 @@ func (in *CFPackage) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageList) DeepCopyInto(out *CFPackageList) {
+func (in *CFNetworkPolicyList) DeepCopyInto(out *CFNetworkPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFPackage, len(*in))
+		*out = make([]CFNetworkPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageList.
-func (in *CFPackageList) DeepCopy() *CFPackageList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFNetworkPolicyList.
+func (in *CFNetworkPolicyList) DeepCopy() *CFNetworkPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageList)
+	out := new(CFNetworkPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFPackageList) DeepCopyObject() runtime.Object {
+func (in *CFNetworkPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -951,24 +1234,24 @@ func (in *CFPackageList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageSpec) DeepCopyInto(out *CFPackageSpec) {
+func (in *CFNetworkPolicySpec) DeepCopyInto(out *CFNetworkPolicySpec) {
 	*out = *in
-	out.AppRef = in.AppRef
-	in.Source.DeepCopyInto(&out.Source)
+	out.SourceAppRef = in.SourceAppRef
+	out.DestinationAppRef = in.DestinationAppRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageSpec.
-func (in *CFPackageSpec) DeepCopy() *CFPackageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFNetworkPolicySpec.
+func (in *CFNetworkPolicySpec) DeepCopy() *CFNetworkPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageSpec)
+	out := new(CFNetworkPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
+func (in *CFNetworkPolicyStatus) DeepCopyInto(out *CFNetworkPolicyStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -979,37 +1262,37 @@ func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageStatus.
-func (in *CFPackageStatus) DeepCopy() *CFPackageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFNetworkPolicyStatus.
+func (in *CFNetworkPolicyStatus) DeepCopy() *CFNetworkPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageStatus)
+	out := new(CFNetworkPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcess) DeepCopyInto(out *CFProcess) {
+func (in *CFOrg) DeepCopyInto(out *CFOrg) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcess.
-func (in *CFProcess) DeepCopy() *CFProcess {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrg.
+func (in *CFOrg) DeepCopy() *CFOrg {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcess)
+	out := new(CFOrg)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFProcess) DeepCopyObject() runtime.Object {
+func (in *CFOrg) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1017,46 +1300,89 @@ func (in *CFProcess) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessDefaulter) DeepCopyInto(out *CFProcessDefaulter) {
+func (in *CFOrgList) DeepCopyInto(out *CFOrgList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFOrg, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessDefaulter.
-func (in *CFProcessDefaulter) DeepCopy() *CFProcessDefaulter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgList.
+func (in *CFOrgList) DeepCopy() *CFOrgList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcessDefaulter)
+	out := new(CFOrgList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrgList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessList) DeepCopyInto(out *CFProcessList) {
+func (in *CFOrgQuota) DeepCopyInto(out *CFOrgQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgQuota.
+func (in *CFOrgQuota) DeepCopy() *CFOrgQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrgQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgQuotaList) DeepCopyInto(out *CFOrgQuotaList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFProcess, len(*in))
+		*out = make([]CFOrgQuota, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessList.
-func (in *CFProcessList) DeepCopy() *CFProcessList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgQuotaList.
+func (in *CFOrgQuotaList) DeepCopy() *CFOrgQuotaList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcessList)
+	out := new(CFOrgQuotaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFProcessList) DeepCopyObject() runtime.Object {
+func (in *CFOrgQuotaList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1064,34 +1390,43 @@ func (in *CFProcessList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessSpec) DeepCopyInto(out *CFProcessSpec) {
+func (in *CFOrgQuotaSpec) DeepCopyInto(out *CFOrgQuotaSpec) {
 	*out = *in
-	out.AppRef = in.AppRef
-	out.HealthCheck = in.HealthCheck
-	if in.DesiredInstances != nil {
-		in, out := &in.DesiredInstances, &out.DesiredInstances
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Ports != nil {
-		in, out := &in.Ports, &out.Ports
-		*out = make([]int32, len(*in))
+	in.QuotaLimits.DeepCopyInto(&out.QuotaLimits)
+	if in.Orgs != nil {
+		in, out := &in.Orgs, &out.Orgs
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessSpec.
-func (in *CFProcessSpec) DeepCopy() *CFProcessSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgQuotaSpec.
+func (in *CFOrgQuotaSpec) DeepCopy() *CFOrgQuotaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcessSpec)
+	out := new(CFOrgQuotaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessStatus) DeepCopyInto(out *CFProcessStatus) {
+func (in *CFOrgSpec) DeepCopyInto(out *CFOrgSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgSpec.
+func (in *CFOrgSpec) DeepCopy() *CFOrgSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgStatus) DeepCopyInto(out *CFOrgStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1100,27 +1435,20 @@ func (in *CFProcessStatus) DeepCopyInto(out *CFProcessStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.InstancesStatus != nil {
-		in, out := &in.InstancesStatus, &out.InstancesStatus
-		*out = make(map[string]InstanceStatus, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessStatus.
-func (in *CFProcessStatus) DeepCopy() *CFProcessStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgStatus.
+func (in *CFOrgStatus) DeepCopy() *CFOrgStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcessStatus)
+	out := new(CFOrgStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFRoute) DeepCopyInto(out *CFRoute) {
+func (in *CFPackage) DeepCopyInto(out *CFPackage) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1128,18 +1456,18 @@ func (in *CFRoute) DeepCopyInto(out *CFRoute) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRoute.
-func (in *CFRoute) DeepCopy() *CFRoute {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackage.
+func (in *CFPackage) DeepCopy() *CFPackage {
 	if in == nil {
 		return nil
 	}
-	out := new(CFRoute)
+	out := new(CFPackage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFRoute) DeepCopyObject() runtime.Object {
+func (in *CFPackage) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1147,31 +1475,31 @@ func (in *CFRoute) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFRouteList) DeepCopyInto(out *CFRouteList) {
+func (in *CFPackageList) DeepCopyInto(out *CFPackageList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFRoute, len(*in))
+		*out = make([]CFPackage, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteList.
-func (in *CFRouteList) DeepCopy() *CFRouteList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageList.
+func (in *CFPackageList) DeepCopy() *CFPackageList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFRouteList)
+	out := new(CFPackageList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFRouteList) DeepCopyObject() runtime.Object {
+func (in *CFPackageList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1179,38 +1507,25 @@ func (in *CFRouteList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFRouteSpec) DeepCopyInto(out *CFRouteSpec) {
+func (in *CFPackageSpec) DeepCopyInto(out *CFPackageSpec) {
 	*out = *in
-	out.DomainRef = in.DomainRef
-	if in.Destinations != nil {
-		in, out := &in.Destinations, &out.Destinations
-		*out = make([]Destination, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	out.AppRef = in.AppRef
+	in.Source.DeepCopyInto(&out.Source)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteSpec.
-func (in *CFRouteSpec) DeepCopy() *CFRouteSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageSpec.
+func (in *CFPackageSpec) DeepCopy() *CFPackageSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFRouteSpec)
+	out := new(CFPackageSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFRouteStatus) DeepCopyInto(out *CFRouteStatus) {
+func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
 	*out = *in
-	if in.Destinations != nil {
-		in, out := &in.Destinations, &out.Destinations
-		*out = make([]Destination, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -1220,18 +1535,18 @@ func (in *CFRouteStatus) DeepCopyInto(out *CFRouteStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteStatus.
-func (in *CFRouteStatus) DeepCopy() *CFRouteStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageStatus.
+func (in *CFPackageStatus) DeepCopy() *CFPackageStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFRouteStatus)
+	out := new(CFPackageStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSecurityGroup) DeepCopyInto(out *CFSecurityGroup) {
+func (in *CFProcess) DeepCopyInto(out *CFProcess) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1239,18 +1554,18 @@ func (in *CFSecurityGroup) DeepCopyInto(out *CFSecurityGroup) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroup.
-func (in *CFSecurityGroup) DeepCopy() *CFSecurityGroup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcess.
+func (in *CFProcess) DeepCopy() *CFProcess {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSecurityGroup)
+	out := new(CFProcess)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFSecurityGroup) DeepCopyObject() runtime.Object {
+func (in *CFProcess) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1258,31 +1573,46 @@ func (in *CFSecurityGroup) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSecurityGroupList) DeepCopyInto(out *CFSecurityGroupList) {
+func (in *CFProcessDefaulter) DeepCopyInto(out *CFProcessDefaulter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessDefaulter.
+func (in *CFProcessDefaulter) DeepCopy() *CFProcessDefaulter {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessDefaulter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessList) DeepCopyInto(out *CFProcessList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFSecurityGroup, len(*in))
+		*out = make([]CFProcess, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroupList.
-func (in *CFSecurityGroupList) DeepCopy() *CFSecurityGroupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessList.
+func (in *CFProcessList) DeepCopy() *CFProcessList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSecurityGroupList)
+	out := new(CFProcessList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFSecurityGroupList) DeepCopyObject() runtime.Object {
+func (in *CFProcessList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1290,76 +1620,822 @@ func (in *CFSecurityGroupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSecurityGroupSpec) DeepCopyInto(out *CFSecurityGroupSpec) {
+func (in *CFProcessSpec) DeepCopyInto(out *CFProcessSpec) {
 	*out = *in
-	if in.Rules != nil {
-		in, out := &in.Rules, &out.Rules
-		*out = make([]SecurityGroupRule, len(*in))
+	out.AppRef = in.AppRef
+	out.HealthCheck = in.HealthCheck
+	out.ReadinessHealthCheck = in.ReadinessHealthCheck
+	if in.DesiredInstances != nil {
+		in, out := &in.DesiredInstances, &out.DesiredInstances
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]int32, len(*in))
 		copy(*out, *in)
 	}
-	if in.Spaces != nil {
-		in, out := &in.Spaces, &out.Spaces
-		*out = make(map[string]SecurityGroupWorkloads, len(*in))
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ProcessAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessSpec.
+func (in *CFProcessSpec) DeepCopy() *CFProcessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessStatus) DeepCopyInto(out *CFProcessStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InstancesStatus != nil {
+		in, out := &in.InstancesStatus, &out.InstancesStatus
+		*out = make(map[string]InstanceStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(ProcessUsage)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ProcessAutoscalingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessStatus.
+func (in *CFProcessStatus) DeepCopy() *CFProcessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRevision) DeepCopyInto(out *CFRevision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRevision.
+func (in *CFRevision) DeepCopy() *CFRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFRevision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRevisionList) DeepCopyInto(out *CFRevisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRevisionList.
+func (in *CFRevisionList) DeepCopy() *CFRevisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRevisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFRevisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRevisionSpec) DeepCopyInto(out *CFRevisionSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	out.DropletRef = in.DropletRef
+	if in.Processes != nil {
+		in, out := &in.Processes, &out.Processes
+		*out = make([]RevisionProcess, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]RevisionSidecar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRevisionSpec.
+func (in *CFRevisionSpec) DeepCopy() *CFRevisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRevisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRoute) DeepCopyInto(out *CFRoute) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRoute.
+func (in *CFRoute) DeepCopy() *CFRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFRoute) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRouteList) DeepCopyInto(out *CFRouteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteList.
+func (in *CFRouteList) DeepCopy() *CFRouteList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRouteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFRouteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRouteSpec) DeepCopyInto(out *CFRouteSpec) {
+	*out = *in
+	out.DomainRef = in.DomainRef
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]Destination, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteSpec.
+func (in *CFRouteSpec) DeepCopy() *CFRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRouteStatus) DeepCopyInto(out *CFRouteStatus) {
+	*out = *in
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]Destination, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteStatus.
+func (in *CFRouteStatus) DeepCopy() *CFRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSecurityGroup) DeepCopyInto(out *CFSecurityGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroup.
+func (in *CFSecurityGroup) DeepCopy() *CFSecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSecurityGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSecurityGroupList) DeepCopyInto(out *CFSecurityGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFSecurityGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroupList.
+func (in *CFSecurityGroupList) DeepCopy() *CFSecurityGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSecurityGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSecurityGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSecurityGroupSpec) DeepCopyInto(out *CFSecurityGroupSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]SecurityGroupRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Spaces != nil {
+		in, out := &in.Spaces, &out.Spaces
+		*out = make(map[string]SecurityGroupWorkloads, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	out.GloballyEnabled = in.GloballyEnabled
+	out.GloballyEnabled = in.GloballyEnabled
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroupSpec.
+func (in *CFSecurityGroupSpec) DeepCopy() *CFSecurityGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSecurityGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSecurityGroupStatus) DeepCopyInto(out *CFSecurityGroupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroupStatus.
+func (in *CFSecurityGroupStatus) DeepCopy() *CFSecurityGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSecurityGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBinding) DeepCopyInto(out *CFServiceBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBinding.
+func (in *CFServiceBinding) DeepCopy() *CFServiceBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBindingList) DeepCopyInto(out *CFServiceBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServiceBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingList.
+func (in *CFServiceBindingList) DeepCopy() *CFServiceBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBindingSpec) DeepCopyInto(out *CFServiceBindingSpec) {
+	*out = *in
+	if in.DisplayName != nil {
+		in, out := &in.DisplayName, &out.DisplayName
+		*out = new(string)
+		**out = **in
+	}
+	out.Service = in.Service
+	out.AppRef = in.AppRef
+	out.Parameters = in.Parameters
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingSpec.
+func (in *CFServiceBindingSpec) DeepCopy() *CFServiceBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBindingStatus) DeepCopyInto(out *CFServiceBindingStatus) {
+	*out = *in
+	out.MountSecretRef = in.MountSecretRef
+	out.EnvSecretRef = in.EnvSecretRef
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingStatus.
+func (in *CFServiceBindingStatus) DeepCopy() *CFServiceBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBroker) DeepCopyInto(out *CFServiceBroker) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBroker.
+func (in *CFServiceBroker) DeepCopy() *CFServiceBroker {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBroker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceBroker) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBrokerList) DeepCopyInto(out *CFServiceBrokerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServiceBroker, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBrokerList.
+func (in *CFServiceBrokerList) DeepCopy() *CFServiceBrokerList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBrokerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceBrokerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBrokerSpec) DeepCopyInto(out *CFServiceBrokerSpec) {
+	*out = *in
+	out.Credentials = in.Credentials
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBrokerSpec.
+func (in *CFServiceBrokerSpec) DeepCopy() *CFServiceBrokerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBrokerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBrokerStatus) DeepCopyInto(out *CFServiceBrokerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBrokerStatus.
+func (in *CFServiceBrokerStatus) DeepCopy() *CFServiceBrokerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBrokerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceInstance) DeepCopyInto(out *CFServiceInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstance.
+func (in *CFServiceInstance) DeepCopy() *CFServiceInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceInstanceList) DeepCopyInto(out *CFServiceInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServiceInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceList.
+func (in *CFServiceInstanceList) DeepCopy() *CFServiceInstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceInstanceSpec) DeepCopyInto(out *CFServiceInstanceSpec) {
+	*out = *in
+	if in.ServiceLabel != nil {
+		in, out := &in.ServiceLabel, &out.ServiceLabel
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Parameters = in.Parameters
+	if in.MaintenanceInfo != nil {
+		in, out := &in.MaintenanceInfo, &out.MaintenanceInfo
+		*out = new(MaintenanceInfo)
+		**out = **in
+	}
+	if in.SharedSpaces != nil {
+		in, out := &in.SharedSpaces, &out.SharedSpaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceSpec.
+func (in *CFServiceInstanceSpec) DeepCopy() *CFServiceInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceInstanceStatus) DeepCopyInto(out *CFServiceInstanceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.Credentials = in.Credentials
+	out.LastOperation = in.LastOperation
+	out.MaintenanceInfo = in.MaintenanceInfo
+	out.Parameters = in.Parameters
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(ServiceInstanceUsage)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceStatus.
+func (in *CFServiceInstanceStatus) DeepCopy() *CFServiceInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceOffering) DeepCopyInto(out *CFServiceOffering) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOffering.
+func (in *CFServiceOffering) DeepCopy() *CFServiceOffering {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceOffering)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceOffering) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceOfferingList) DeepCopyInto(out *CFServiceOfferingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServiceOffering, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroupSpec.
-func (in *CFSecurityGroupSpec) DeepCopy() *CFSecurityGroupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOfferingList.
+func (in *CFServiceOfferingList) DeepCopy() *CFServiceOfferingList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSecurityGroupSpec)
+	out := new(CFServiceOfferingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceOfferingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSecurityGroupStatus) DeepCopyInto(out *CFSecurityGroupStatus) {
+func (in *CFServiceOfferingSpec) DeepCopyInto(out *CFServiceOfferingSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Requires != nil {
+		in, out := &in.Requires, &out.Requires
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DocumentationURL != nil {
+		in, out := &in.DocumentationURL, &out.DocumentationURL
+		*out = new(string)
+		**out = **in
 	}
+	in.BrokerCatalog.DeepCopyInto(&out.BrokerCatalog)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroupStatus.
-func (in *CFSecurityGroupStatus) DeepCopy() *CFSecurityGroupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOfferingSpec.
+func (in *CFServiceOfferingSpec) DeepCopy() *CFServiceOfferingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSecurityGroupStatus)
+	out := new(CFServiceOfferingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBinding) DeepCopyInto(out *CFServiceBinding) {
+func (in *CFServicePlan) DeepCopyInto(out *CFServicePlan) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBinding.
-func (in *CFServiceBinding) DeepCopy() *CFServiceBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlan.
+func (in *CFServicePlan) DeepCopy() *CFServicePlan {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBinding)
+	out := new(CFServicePlan)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceBinding) DeepCopyObject() runtime.Object {
+func (in *CFServicePlan) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1367,31 +2443,31 @@ func (in *CFServiceBinding) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBindingList) DeepCopyInto(out *CFServiceBindingList) {
+func (in *CFServicePlanList) DeepCopyInto(out *CFServicePlanList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFServiceBinding, len(*in))
+		*out = make([]CFServicePlan, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingList.
-func (in *CFServiceBindingList) DeepCopy() *CFServiceBindingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlanList.
+func (in *CFServicePlanList) DeepCopy() *CFServicePlanList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBindingList)
+	out := new(CFServicePlanList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceBindingList) DeepCopyObject() runtime.Object {
+func (in *CFServicePlanList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1399,54 +2475,26 @@ func (in *CFServiceBindingList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBindingSpec) DeepCopyInto(out *CFServiceBindingSpec) {
-	*out = *in
-	if in.DisplayName != nil {
-		in, out := &in.DisplayName, &out.DisplayName
-		*out = new(string)
-		**out = **in
-	}
-	out.Service = in.Service
-	out.AppRef = in.AppRef
-	out.Parameters = in.Parameters
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingSpec.
-func (in *CFServiceBindingSpec) DeepCopy() *CFServiceBindingSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(CFServiceBindingSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBindingStatus) DeepCopyInto(out *CFServiceBindingStatus) {
+func (in *CFServicePlanSpec) DeepCopyInto(out *CFServicePlanSpec) {
 	*out = *in
-	out.MountSecretRef = in.MountSecretRef
-	out.EnvSecretRef = in.EnvSecretRef
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.BrokerCatalog.DeepCopyInto(&out.BrokerCatalog)
+	in.Schemas.DeepCopyInto(&out.Schemas)
+	out.MaintenanceInfo = in.MaintenanceInfo
+	in.Visibility.DeepCopyInto(&out.Visibility)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingStatus.
-func (in *CFServiceBindingStatus) DeepCopy() *CFServiceBindingStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlanSpec.
+func (in *CFServicePlanSpec) DeepCopy() *CFServicePlanSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBindingStatus)
+	out := new(CFServicePlanSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBroker) DeepCopyInto(out *CFServiceBroker) {
+func (in *CFServiceRouteBinding) DeepCopyInto(out *CFServiceRouteBinding) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1454,18 +2502,18 @@ func (in *CFServiceBroker) DeepCopyInto(out *CFServiceBroker) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBroker.
-func (in *CFServiceBroker) DeepCopy() *CFServiceBroker {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceRouteBinding.
+func (in *CFServiceRouteBinding) DeepCopy() *CFServiceRouteBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBroker)
+	out := new(CFServiceRouteBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceBroker) DeepCopyObject() runtime.Object {
+func (in *CFServiceRouteBinding) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1473,31 +2521,31 @@ func (in *CFServiceBroker) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBrokerList) DeepCopyInto(out *CFServiceBrokerList) {
+func (in *CFServiceRouteBindingList) DeepCopyInto(out *CFServiceRouteBindingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFServiceBroker, len(*in))
+		*out = make([]CFServiceRouteBinding, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBrokerList.
-func (in *CFServiceBrokerList) DeepCopy() *CFServiceBrokerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceRouteBindingList.
+func (in *CFServiceRouteBindingList) DeepCopy() *CFServiceRouteBindingList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBrokerList)
+	out := new(CFServiceRouteBindingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceBrokerList) DeepCopyObject() runtime.Object {
+func (in *CFServiceRouteBindingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1505,23 +2553,25 @@ func (in *CFServiceBrokerList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBrokerSpec) DeepCopyInto(out *CFServiceBrokerSpec) {
+func (in *CFServiceRouteBindingSpec) DeepCopyInto(out *CFServiceRouteBindingSpec) {
 	*out = *in
-	out.Credentials = in.Credentials
+	out.ServiceInstanceRef = in.ServiceInstanceRef
+	out.RouteRef = in.RouteRef
+	out.Parameters = in.Parameters
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBrokerSpec.
-func (in *CFServiceBrokerSpec) DeepCopy() *CFServiceBrokerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceRouteBindingSpec.
+func (in *CFServiceRouteBindingSpec) DeepCopy() *CFServiceRouteBindingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBrokerSpec)
+	out := new(CFServiceRouteBindingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBrokerStatus) DeepCopyInto(out *CFServiceBrokerStatus) {
+func (in *CFServiceRouteBindingStatus) DeepCopyInto(out *CFServiceRouteBindingStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1532,37 +2582,36 @@ func (in *CFServiceBrokerStatus) DeepCopyInto(out *CFServiceBrokerStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBrokerStatus.
-func (in *CFServiceBrokerStatus) DeepCopy() *CFServiceBrokerStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceRouteBindingStatus.
+func (in *CFServiceRouteBindingStatus) DeepCopy() *CFServiceRouteBindingStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBrokerStatus)
+	out := new(CFServiceRouteBindingStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceInstance) DeepCopyInto(out *CFServiceInstance) {
+func (in *CFServiceUsageEvent) DeepCopyInto(out *CFServiceUsageEvent) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstance.
-func (in *CFServiceInstance) DeepCopy() *CFServiceInstance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceUsageEvent.
+func (in *CFServiceUsageEvent) DeepCopy() *CFServiceUsageEvent {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceInstance)
+	out := new(CFServiceUsageEvent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceInstance) DeepCopyObject() runtime.Object {
+func (in *CFServiceUsageEvent) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1570,31 +2619,31 @@ func (in *CFServiceInstance) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceInstanceList) DeepCopyInto(out *CFServiceInstanceList) {
+func (in *CFServiceUsageEventList) DeepCopyInto(out *CFServiceUsageEventList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFServiceInstance, len(*in))
+		*out = make([]CFServiceUsageEvent, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceList.
-func (in *CFServiceInstanceList) DeepCopy() *CFServiceInstanceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceUsageEventList.
+func (in *CFServiceUsageEventList) DeepCopy() *CFServiceUsageEventList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceInstanceList)
+	out := new(CFServiceUsageEventList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceInstanceList) DeepCopyObject() runtime.Object {
+func (in *CFServiceUsageEventList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1602,76 +2651,57 @@ func (in *CFServiceInstanceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceInstanceSpec) DeepCopyInto(out *CFServiceInstanceSpec) {
+func (in *CFServiceUsageEventSpec) DeepCopyInto(out *CFServiceUsageEventSpec) {
 	*out = *in
-	if in.ServiceLabel != nil {
-		in, out := &in.ServiceLabel, &out.ServiceLabel
-		*out = new(string)
+	out.ServiceInstance = in.ServiceInstance
+	out.Space = in.Space
+	if in.ServicePlan != nil {
+		in, out := &in.ServicePlan, &out.ServicePlan
+		*out = new(UsageEventResource)
 		**out = **in
 	}
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	out.Parameters = in.Parameters
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceSpec.
-func (in *CFServiceInstanceSpec) DeepCopy() *CFServiceInstanceSpec {
-	if in == nil {
-		return nil
+	if in.ServiceOffering != nil {
+		in, out := &in.ServiceOffering, &out.ServiceOffering
+		*out = new(UsageEventResource)
+		**out = **in
 	}
-	out := new(CFServiceInstanceSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceInstanceStatus) DeepCopyInto(out *CFServiceInstanceStatus) {
-	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ServiceBroker != nil {
+		in, out := &in.ServiceBroker, &out.ServiceBroker
+		*out = new(UsageEventResource)
+		**out = **in
 	}
-	out.Credentials = in.Credentials
-	out.LastOperation = in.LastOperation
-	out.MaintenanceInfo = in.MaintenanceInfo
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceStatus.
-func (in *CFServiceInstanceStatus) DeepCopy() *CFServiceInstanceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceUsageEventSpec.
+func (in *CFServiceUsageEventSpec) DeepCopy() *CFServiceUsageEventSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceInstanceStatus)
+	out := new(CFServiceUsageEventSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceOffering) DeepCopyInto(out *CFServiceOffering) {
+func (in *CFSidecar) DeepCopyInto(out *CFSidecar) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOffering.
-func (in *CFServiceOffering) DeepCopy() *CFServiceOffering {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSidecar.
+func (in *CFSidecar) DeepCopy() *CFSidecar {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceOffering)
+	out := new(CFSidecar)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceOffering) DeepCopyObject() runtime.Object {
+func (in *CFSidecar) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1679,31 +2709,31 @@ func (in *CFServiceOffering) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceOfferingList) DeepCopyInto(out *CFServiceOfferingList) {
+func (in *CFSidecarList) DeepCopyInto(out *CFSidecarList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFServiceOffering, len(*in))
+		*out = make([]CFSidecar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOfferingList.
-func (in *CFServiceOfferingList) DeepCopy() *CFServiceOfferingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSidecarList.
+func (in *CFSidecarList) DeepCopy() *CFSidecarList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceOfferingList)
+	out := new(CFSidecarList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceOfferingList) DeepCopyObject() runtime.Object {
+func (in *CFSidecarList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1711,56 +2741,47 @@ func (in *CFServiceOfferingList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceOfferingSpec) DeepCopyInto(out *CFServiceOfferingSpec) {
+func (in *CFSidecarSpec) DeepCopyInto(out *CFSidecarSpec) {
 	*out = *in
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Requires != nil {
-		in, out := &in.Requires, &out.Requires
+	out.AppRef = in.AppRef
+	if in.ProcessTypes != nil {
+		in, out := &in.ProcessTypes, &out.ProcessTypes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.DocumentationURL != nil {
-		in, out := &in.DocumentationURL, &out.DocumentationURL
-		*out = new(string)
-		**out = **in
-	}
-	in.BrokerCatalog.DeepCopyInto(&out.BrokerCatalog)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOfferingSpec.
-func (in *CFServiceOfferingSpec) DeepCopy() *CFServiceOfferingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSidecarSpec.
+func (in *CFSidecarSpec) DeepCopy() *CFSidecarSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceOfferingSpec)
+	out := new(CFSidecarSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServicePlan) DeepCopyInto(out *CFServicePlan) {
+func (in *CFSpace) DeepCopyInto(out *CFSpace) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlan.
-func (in *CFServicePlan) DeepCopy() *CFServicePlan {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpace.
+func (in *CFSpace) DeepCopy() *CFSpace {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServicePlan)
+	out := new(CFSpace)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServicePlan) DeepCopyObject() runtime.Object {
+func (in *CFSpace) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1768,77 +2789,57 @@ func (in *CFServicePlan) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServicePlanList) DeepCopyInto(out *CFServicePlanList) {
+func (in *CFSpaceList) DeepCopyInto(out *CFSpaceList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFServicePlan, len(*in))
+		*out = make([]CFSpace, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlanList.
-func (in *CFServicePlanList) DeepCopy() *CFServicePlanList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceList.
+func (in *CFSpaceList) DeepCopy() *CFSpaceList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServicePlanList)
+	out := new(CFSpaceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServicePlanList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServicePlanSpec) DeepCopyInto(out *CFServicePlanSpec) {
-	*out = *in
-	in.BrokerCatalog.DeepCopyInto(&out.BrokerCatalog)
-	in.Schemas.DeepCopyInto(&out.Schemas)
-	out.MaintenanceInfo = in.MaintenanceInfo
-	in.Visibility.DeepCopyInto(&out.Visibility)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlanSpec.
-func (in *CFServicePlanSpec) DeepCopy() *CFServicePlanSpec {
-	if in == nil {
-		return nil
+func (in *CFSpaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(CFServicePlanSpec)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSpace) DeepCopyInto(out *CFSpace) {
+func (in *CFSpaceQuota) DeepCopyInto(out *CFSpaceQuota) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpace.
-func (in *CFSpace) DeepCopy() *CFSpace {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceQuota.
+func (in *CFSpaceQuota) DeepCopy() *CFSpaceQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSpace)
+	out := new(CFSpaceQuota)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFSpace) DeepCopyObject() runtime.Object {
+func (in *CFSpaceQuota) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1846,40 +2847,66 @@ func (in *CFSpace) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSpaceList) DeepCopyInto(out *CFSpaceList) {
+func (in *CFSpaceQuotaList) DeepCopyInto(out *CFSpaceQuotaList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFSpace, len(*in))
+		*out = make([]CFSpaceQuota, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceList.
-func (in *CFSpaceList) DeepCopy() *CFSpaceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceQuotaList.
+func (in *CFSpaceQuotaList) DeepCopy() *CFSpaceQuotaList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSpaceList)
+	out := new(CFSpaceQuotaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFSpaceList) DeepCopyObject() runtime.Object {
+func (in *CFSpaceQuotaList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceQuotaSpec) DeepCopyInto(out *CFSpaceQuotaSpec) {
+	*out = *in
+	in.QuotaLimits.DeepCopyInto(&out.QuotaLimits)
+	if in.Spaces != nil {
+		in, out := &in.Spaces, &out.Spaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceQuotaSpec.
+func (in *CFSpaceQuotaSpec) DeepCopy() *CFSpaceQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpaceQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFSpaceSpec) DeepCopyInto(out *CFSpaceSpec) {
 	*out = *in
+	if in.AllowSSH != nil {
+		in, out := &in.AllowSSH, &out.AllowSSH
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceSpec.
@@ -2012,6 +3039,21 @@ func (in *CFTaskStatus) DeepCopy() *CFTaskStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryStep) DeepCopyInto(out *CanaryStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStep.
+func (in *CanaryStep) DeepCopy() *CanaryStep {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Destination) DeepCopyInto(out *Destination) {
 	*out = *in
@@ -2190,6 +3232,55 @@ func (in *PackageSource) DeepCopy() *PackageSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessAutoscaling) DeepCopyInto(out *ProcessAutoscaling) {
+	*out = *in
+	if in.CPUUtilizationPercent != nil {
+		in, out := &in.CPUUtilizationPercent, &out.CPUUtilizationPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemoryUtilizationPercent != nil {
+		in, out := &in.MemoryUtilizationPercent, &out.MemoryUtilizationPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HTTPRequestsPerSecond != nil {
+		in, out := &in.HTTPRequestsPerSecond, &out.HTTPRequestsPerSecond
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessAutoscaling.
+func (in *ProcessAutoscaling) DeepCopy() *ProcessAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessAutoscalingStatus) DeepCopyInto(out *ProcessAutoscalingStatus) {
+	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessAutoscalingStatus.
+func (in *ProcessAutoscalingStatus) DeepCopy() *ProcessAutoscalingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessAutoscalingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProcessType) DeepCopyInto(out *ProcessType) {
 	*out = *in
@@ -2205,6 +3296,145 @@ func (in *ProcessType) DeepCopy() *ProcessType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessUsage) DeepCopyInto(out *ProcessUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessUsage.
+func (in *ProcessUsage) DeepCopy() *ProcessUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaAppsLimits) DeepCopyInto(out *QuotaAppsLimits) {
+	*out = *in
+	if in.TotalMemoryInMB != nil {
+		in, out := &in.TotalMemoryInMB, &out.TotalMemoryInMB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PerProcessMemoryInMB != nil {
+		in, out := &in.PerProcessMemoryInMB, &out.PerProcessMemoryInMB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TotalInstances != nil {
+		in, out := &in.TotalInstances, &out.TotalInstances
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PerAppTasks != nil {
+		in, out := &in.PerAppTasks, &out.PerAppTasks
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaAppsLimits.
+func (in *QuotaAppsLimits) DeepCopy() *QuotaAppsLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaAppsLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaLimits) DeepCopyInto(out *QuotaLimits) {
+	*out = *in
+	in.Apps.DeepCopyInto(&out.Apps)
+	in.Services.DeepCopyInto(&out.Services)
+	in.Routes.DeepCopyInto(&out.Routes)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaLimits.
+func (in *QuotaLimits) DeepCopy() *QuotaLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaRoutesLimits) DeepCopyInto(out *QuotaRoutesLimits) {
+	*out = *in
+	if in.TotalRoutes != nil {
+		in, out := &in.TotalRoutes, &out.TotalRoutes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaRoutesLimits.
+func (in *QuotaRoutesLimits) DeepCopy() *QuotaRoutesLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaRoutesLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaServicesLimits) DeepCopyInto(out *QuotaServicesLimits) {
+	*out = *in
+	if in.TotalServiceInstances != nil {
+		in, out := &in.TotalServiceInstances, &out.TotalServiceInstances
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaServicesLimits.
+func (in *QuotaServicesLimits) DeepCopy() *QuotaServicesLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaServicesLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessHealthCheck) DeepCopyInto(out *ReadinessHealthCheck) {
+	*out = *in
+	out.Data = in.Data
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessHealthCheck.
+func (in *ReadinessHealthCheck) DeepCopy() *ReadinessHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessHealthCheckData) DeepCopyInto(out *ReadinessHealthCheckData) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessHealthCheckData.
+func (in *ReadinessHealthCheckData) DeepCopy() *ReadinessHealthCheckData {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessHealthCheckData)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
@@ -2240,6 +3470,41 @@ func (in *RequiredLocalObjectReference) DeepCopy() *RequiredLocalObjectReference
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevisionProcess) DeepCopyInto(out *RevisionProcess) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionProcess.
+func (in *RevisionProcess) DeepCopy() *RevisionProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(RevisionProcess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevisionSidecar) DeepCopyInto(out *RevisionSidecar) {
+	*out = *in
+	if in.ProcessTypes != nil {
+		in, out := &in.ProcessTypes, &out.ProcessTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionSidecar.
+func (in *RevisionSidecar) DeepCopy() *RevisionSidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(RevisionSidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunnerInfo) DeepCopyInto(out *RunnerInfo) {
 	*out = *in
@@ -2451,6 +3716,21 @@ func (in *ServiceInstanceSchema) DeepCopy() *ServiceInstanceSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceUsage) DeepCopyInto(out *ServiceInstanceUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceUsage.
+func (in *ServiceInstanceUsage) DeepCopy() *ServiceInstanceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServicePlanBrokerCatalog) DeepCopyInto(out *ServicePlanBrokerCatalog) {
 	*out = *in
@@ -2604,6 +3884,20 @@ func (in *TaskWorkloadSpec) DeepCopyInto(out *TaskWorkloadSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskWorkloadSpec.
@@ -2638,6 +3932,51 @@ func (in *TaskWorkloadStatus) DeepCopy() *TaskWorkloadStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageEventProcess) DeepCopyInto(out *UsageEventProcess) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageEventProcess.
+func (in *UsageEventProcess) DeepCopy() *UsageEventProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageEventProcess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageEventResource) DeepCopyInto(out *UsageEventResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageEventResource.
+func (in *UsageEventResource) DeepCopy() *UsageEventResource {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageEventResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageEventServiceInstance) DeepCopyInto(out *UsageEventServiceInstance) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageEventServiceInstance.
+func (in *UsageEventServiceInstance) DeepCopy() *UsageEventServiceInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageEventServiceInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VisibilityOrganization) DeepCopyInto(out *VisibilityOrganization) {
 	*out = *in
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"net/netip"
 	"time"
 
 	"go.uber.org/zap/zapcore"
@@ -28,6 +30,9 @@ type ControllerConfig struct {
 	MaxRetainedBuildsPerApp          int                `yaml:"maxRetainedBuildsPerApp"`
 	LogLevel                         zapcore.Level      `yaml:"logLevel"`
 	SpaceFinalizerAppDeletionTimeout *int32             `yaml:"spaceFinalizerAppDeletionTimeout"`
+	// CIDRs app logs must not be forwarded to, even if a syslog drain url
+	// resolves to them
+	BlockedSyslogDrainRanges []string `yaml:"blockedSyslogDrainRanges"`
 
 	// job-task-runner
 	JobTTL time.Duration `yaml:"jobTTL"`
@@ -60,10 +65,49 @@ type CFStagingResources struct {
 type Networking struct {
 	GatewayName      string `yaml:"gatewayName"`
 	GatewayNamespace string `yaml:"gatewayNamespace"`
+	// TCP routes are bound to the gateway listener named <TCPListenerPrefix>-<port>
+	TCPListenerPrefix string `yaml:"tcpListenerPrefix"`
+	// The ConfigMap the hosts files resolving routes on internal domains are
+	// written to, one key per internal domain. No hosts files are written
+	// when unset
+	InternalDomainsDNS InternalDomainsDNS `yaml:"internalDomainsDNS"`
+	// The router groups TCP routes can be created on. The gateway only has
+	// listeners for their reservable ports
+	RouterGroups []RouterGroup `yaml:"routerGroups"`
+	// Requests to routes bound to route services are sent through the
+	// route service proxy
+	RouteServiceProxy RouteServiceProxy `yaml:"routeServiceProxy"`
+}
+
+type RouteServiceProxy struct {
+	// The port the route service proxy listens on
+	Port int32 `yaml:"port"`
+	// The in-cluster host name the gateway reaches the route service proxy on
+	Host string `yaml:"host"`
+	// The Secret holding the key the route service request signatures are
+	// encrypted with. The key is generated if the Secret does not exist
+	SigningKeySecretName      string `yaml:"signingKeySecretName"`
+	SigningKeySecretNamespace string `yaml:"signingKeySecretNamespace"`
+}
+
+type RouterGroup struct {
+	Name            string    `yaml:"name"`
+	ReservablePorts PortRange `yaml:"reservablePorts"`
+}
+
+type PortRange struct {
+	From int32 `yaml:"from"`
+	To   int32 `yaml:"to"`
+}
+
+type InternalDomainsDNS struct {
+	ConfigMapName      string `yaml:"configMapName"`
+	ConfigMapNamespace string `yaml:"configMapNamespace"`
 }
 
 const (
-	defaultTimeout int32 = 60
+	defaultTimeout           int32 = 60
+	defaultTCPListenerPrefix       = "tcp"
 )
 
 func LoadFromPath(path string) (*ControllerConfig, error) {
@@ -77,6 +121,10 @@ func LoadFromPath(path string) (*ControllerConfig, error) {
 		config.CFProcessDefaults.Timeout = tools.PtrTo(defaultTimeout)
 	}
 
+	if config.Networking.TCPListenerPrefix == "" {
+		config.Networking.TCPListenerPrefix = defaultTCPListenerPrefix
+	}
+
 	if config.SpaceFinalizerAppDeletionTimeout == nil {
 		config.SpaceFinalizerAppDeletionTimeout = tools.PtrTo(defaultTimeout)
 	}
@@ -84,6 +132,20 @@ func LoadFromPath(path string) (*ControllerConfig, error) {
 	return &config, nil
 }
 
+// ParseBlockedSyslogDrainRanges parses the blocked syslog drain CIDRs
+func (c *ControllerConfig) ParseBlockedSyslogDrainRanges() ([]netip.Prefix, error) {
+	blockedRanges := []netip.Prefix{}
+	for _, blockedRange := range c.BlockedSyslogDrainRanges {
+		prefix, err := netip.ParsePrefix(blockedRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blocked syslog drain range %q: %w", blockedRange, err)
+		}
+		blockedRanges = append(blockedRanges, prefix.Masked())
+	}
+
+	return blockedRanges, nil
+}
+
 func GetLogLevelFromPath(path string) (zapcore.Level, error) {
 	cfg, err := LoadFromPath(path)
 	if err != nil {
@@ -1,6 +1,7 @@
 package config_test
 
 import (
+	"net/netip"
 	"os"
 	"path/filepath"
 	"time"
@@ -51,9 +52,28 @@ var _ = Describe("LoadFromPath", func() {
 			"extraVCAPApplicationValues":       map[string]any{},
 			"logLevel":                         "debug",
 			"spaceFinalizerAppDeletionTimeout": 42,
+			"blockedSyslogDrainRanges":         []string{"10.0.0.0/8", "fc00::/7"},
 			"networking": map[string]any{
-				"gatewayName":      "gw-name",
-				"gatewayNamespace": "gw-ns",
+				"gatewayName":       "gw-name",
+				"gatewayNamespace":  "gw-ns",
+				"tcpListenerPrefix": "tcp-apps",
+				"internalDomainsDNS": map[string]any{
+					"configMapName":      "internal-hosts",
+					"configMapNamespace": "kube-system",
+				},
+				"routerGroups": []map[string]any{{
+					"name": "default-tcp",
+					"reservablePorts": map[string]any{
+						"from": 1024,
+						"to":   1033,
+					},
+				}},
+				"routeServiceProxy": map[string]any{
+					"port":                      8082,
+					"host":                      "rs-proxy.korifi.svc.cluster.local",
+					"signingKeySecretName":      "rs-key",
+					"signingKeySecretNamespace": "korifi",
+				},
 			},
 			"experimentalManagedServicesEnabled": true,
 			"trustInsecureServiceBrokers":        true,
@@ -106,9 +126,28 @@ var _ = Describe("LoadFromPath", func() {
 			BuilderReadinessTimeout:          2 * time.Second,
 			LogLevel:                         zapcore.DebugLevel,
 			SpaceFinalizerAppDeletionTimeout: tools.PtrTo(int32(42)),
+			BlockedSyslogDrainRanges:         []string{"10.0.0.0/8", "fc00::/7"},
 			Networking: config.Networking{
-				GatewayName:      "gw-name",
-				GatewayNamespace: "gw-ns",
+				GatewayName:       "gw-name",
+				GatewayNamespace:  "gw-ns",
+				TCPListenerPrefix: "tcp-apps",
+				InternalDomainsDNS: config.InternalDomainsDNS{
+					ConfigMapName:      "internal-hosts",
+					ConfigMapNamespace: "kube-system",
+				},
+				RouterGroups: []config.RouterGroup{{
+					Name: "default-tcp",
+					ReservablePorts: config.PortRange{
+						From: 1024,
+						To:   1033,
+					},
+				}},
+				RouteServiceProxy: config.RouteServiceProxy{
+					Port:                      8082,
+					Host:                      "rs-proxy.korifi.svc.cluster.local",
+					SigningKeySecretName:      "rs-key",
+					SigningKeySecretNamespace: "korifi",
+				},
 			},
 			ExperimentalManagedServicesEnabled: true,
 			TrustInsecureServiceBrokers:        true,
@@ -135,6 +174,19 @@ var _ = Describe("LoadFromPath", func() {
 		})
 	})
 
+	When("the tcp listener prefix is not set", func() {
+		BeforeEach(func() {
+			cfg["networking"] = map[string]any{
+				"gatewayName":      "gw-name",
+				"gatewayNamespace": "gw-ns",
+			}
+		})
+
+		It("uses the default", func() {
+			Expect(retConfig.Networking.TCPListenerPrefix).To(Equal("tcp"))
+		})
+	})
+
 	When("the space finalizer app deletion timeout is not set", func() {
 		BeforeEach(func() {
 			cfg["spaceFinalizerAppDeletionTimeout"] = nil
@@ -145,3 +197,39 @@ var _ = Describe("LoadFromPath", func() {
 		})
 	})
 })
+
+var _ = Describe("ParseBlockedSyslogDrainRanges", func() {
+	var (
+		cfg           *config.ControllerConfig
+		blockedRanges []netip.Prefix
+		err           error
+	)
+
+	BeforeEach(func() {
+		cfg = &config.ControllerConfig{
+			BlockedSyslogDrainRanges: []string{"10.1.2.3/8", "fc00::/7"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		blockedRanges, err = cfg.ParseBlockedSyslogDrainRanges()
+	})
+
+	It("parses the ranges", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(blockedRanges).To(Equal([]netip.Prefix{
+			netip.MustParsePrefix("10.0.0.0/8"),
+			netip.MustParsePrefix("fc00::/7"),
+		}))
+	})
+
+	When("a range is invalid", func() {
+		BeforeEach(func() {
+			cfg.BlockedSyslogDrainRanges = []string{"10.0.0.0"}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring(`invalid blocked syslog drain range "10.0.0.0"`)))
+		})
+	})
+})
@@ -18,44 +18,76 @@ package domains
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 type Reconciler struct {
-	client client.Client
-	scheme *runtime.Scheme
-	log    logr.Logger
+	client           client.Client
+	scheme           *runtime.Scheme
+	log              logr.Logger
+	controllerConfig *config.ControllerConfig
 }
 
 func NewReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
 	log logr.Logger,
+	controllerConfig *config.ControllerConfig,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFDomain] {
-	routeReconciler := Reconciler{client: client, scheme: scheme, log: log}
+	routeReconciler := Reconciler{client: client, scheme: scheme, log: log, controllerConfig: controllerConfig}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFDomain](log, client, &routeReconciler)
 }
 
 func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&korifiv1alpha1.CFDomain{})
+		For(&korifiv1alpha1.CFDomain{}).
+		Watches(
+			&korifiv1alpha1.CFRoute{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFRouteRequests),
+		)
+}
+
+func (r *Reconciler) enqueueCFRouteRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	cfRoute, ok := o.(*korifiv1alpha1.CFRoute)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Name:      cfRoute.Spec.DomainRef.Name,
+			Namespace: cfRoute.Spec.DomainRef.Namespace,
+		},
+	}}
 }
 
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains,verbs=get;list;watch;patch;create;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains/status,verbs=patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains/finalizers,verbs=update
 
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;patch
+
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -66,9 +98,98 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfDomain *korifiv1al
 	cfDomain.Status.ObservedGeneration = cfDomain.Generation
 	log.V(1).Info("set observed generation", "generation", cfDomain.Status.ObservedGeneration)
 
+	if cfDomain.Spec.Internal {
+		if err := r.reconcileInternalHosts(ctx, cfDomain); err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileInternalHosts")
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// reconcileInternalHosts writes a hosts file resolving the routes on the
+// internal domain to the cluster IPs of their Services, so that the cluster
+// DNS can serve it
+func (r *Reconciler) reconcileInternalHosts(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileInternalHosts")
+
+	dnsConfig := r.controllerConfig.Networking.InternalDomainsDNS
+	if dnsConfig.ConfigMapName == "" {
+		return nil
+	}
+
+	domainRoutes, err := r.listRoutesForDomain(ctx, cfDomain)
+	if err != nil {
+		log.Info("failed to list CFRoutes", "reason", err)
+		return err
+	}
+
+	hosts := []string{}
+	for _, cfRoute := range domainRoutes {
+		if cfRoute.Status.FQDN == "" || strings.HasPrefix(cfRoute.Status.FQDN, "*") {
+			continue
+		}
+
+		services := corev1.ServiceList{}
+		err = r.client.List(ctx, &services, client.InNamespace(cfRoute.Namespace), client.MatchingLabels{
+			korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+		})
+		if err != nil {
+			log.Info("failed to list route services", "route", cfRoute.Name, "reason", err)
+			return err
+		}
+
+		for _, service := range services.Items {
+			if service.Spec.ClusterIP == "" || service.Spec.ClusterIP == corev1.ClusterIPNone {
+				continue
+			}
+			hosts = append(hosts, fmt.Sprintf("%s %s", service.Spec.ClusterIP, cfRoute.Status.FQDN))
+		}
+	}
+	slices.Sort(hosts)
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dnsConfig.ConfigMapName,
+			Namespace: dnsConfig.ConfigMapNamespace,
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, r.client, configMap, func() error {
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[hostsFileKey(cfDomain)] = strings.Join(hosts, "\n")
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to create/patch internal hosts ConfigMap", "reason", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Reconciler) deleteInternalHosts(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) error {
+	dnsConfig := r.controllerConfig.Networking.InternalDomainsDNS
+	if !cfDomain.Spec.Internal || dnsConfig.ConfigMapName == "" {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: dnsConfig.ConfigMapName, Namespace: dnsConfig.ConfigMapNamespace}, configMap)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	return k8s.Patch(ctx, r.client, configMap, func() {
+		delete(configMap.Data, hostsFileKey(cfDomain))
+	})
+}
+
+func hostsFileKey(cfDomain *korifiv1alpha1.CFDomain) string {
+	return cfDomain.Spec.Name + ".hosts"
+}
+
 func (r *Reconciler) finalizeCFDomain(ctx context.Context, cfDomain *korifiv1alpha1.CFDomain) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCFDomain")
 
@@ -85,6 +206,11 @@ func (r *Reconciler) finalizeCFDomain(ctx context.Context, cfDomain *korifiv1alp
 	log.Info("routes", "len", len(domainRoutes))
 
 	if len(domainRoutes) == 0 {
+		if err = r.deleteInternalHosts(ctx, cfDomain); err != nil {
+			log.Info("failed to delete internal hosts", "reason", err)
+			return ctrl.Result{}, err
+		}
+
 		if controllerutil.RemoveFinalizer(cfDomain, korifiv1alpha1.CFDomainFinalizerName) {
 			log.V(1).Info("finalizer removed")
 		}
@@ -2,6 +2,7 @@ package domains_test
 
 import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/google/uuid"
@@ -10,6 +11,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 var _ = Describe("CFDomainReconciler Integration Tests", func() {
@@ -45,6 +47,96 @@ var _ = Describe("CFDomainReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	When("the domain is internal", func() {
+		var (
+			cfRoute *korifiv1alpha1.CFRoute
+			service *corev1.Service
+		)
+
+		BeforeEach(func() {
+			routeNamespace := uuid.NewString()
+			Expect(adminClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: routeNamespace,
+				},
+			})).To(Succeed())
+
+			cfDomain = &korifiv1alpha1.CFDomain{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: cfDomain.Namespace,
+					Finalizers: []string{
+						korifiv1alpha1.CFDomainFinalizerName,
+					},
+				},
+				Spec: korifiv1alpha1.CFDomainSpec{
+					Name:     "a" + uuid.NewString() + ".internal",
+					Internal: true,
+				},
+			}
+			Expect(adminClient.Create(ctx, cfDomain)).To(Succeed())
+
+			cfRoute = &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: routeNamespace,
+				},
+				Spec: korifiv1alpha1.CFRouteSpec{
+					Host:     "my-app",
+					Protocol: "http",
+					DomainRef: corev1.ObjectReference{
+						Name:      cfDomain.Name,
+						Namespace: cfDomain.Namespace,
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, cfRoute)).To(Succeed())
+
+			service = &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: routeNamespace,
+					Labels: map[string]string{
+						korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+					},
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Port: 8080}},
+				},
+			}
+			Expect(adminClient.Create(ctx, service)).To(Succeed())
+
+			Expect(k8s.Patch(ctx, adminClient, cfRoute, func() {
+				cfRoute.Status.FQDN = "my-app." + cfDomain.Spec.Name
+			})).To(Succeed())
+		})
+
+		It("resolves the route fqdn to the route services in the internal hosts config map", func() {
+			Eventually(func(g Gomega) {
+				configMap := &corev1.ConfigMap{}
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: internalHostsConfigMapName, Namespace: dnsNamespace}, configMap)).To(Succeed())
+				g.Expect(configMap.Data).To(HaveKeyWithValue(
+					cfDomain.Spec.Name+".hosts",
+					service.Spec.ClusterIP+" my-app."+cfDomain.Spec.Name,
+				))
+			}).Should(Succeed())
+		})
+
+		When("the domain is deleted", func() {
+			JustBeforeEach(func() {
+				Expect(adminClient.Delete(ctx, cfDomain)).To(Succeed())
+			})
+
+			It("removes the domain hosts from the internal hosts config map", func() {
+				Eventually(func(g Gomega) {
+					configMap := &corev1.ConfigMap{}
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: internalHostsConfigMapName, Namespace: dnsNamespace}, configMap)).To(Succeed())
+					g.Expect(configMap.Data).NotTo(HaveKey(cfDomain.Spec.Name + ".hosts"))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	Describe("finalization", func() {
 		var (
 			route1Namespace string
@@ -7,12 +7,15 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/domains"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,8 +31,11 @@ var (
 	testEnv         *envtest.Environment
 	adminClient     client.Client
 	ctx             context.Context
+	dnsNamespace    string
 )
 
+const internalHostsConfigMapName = "internal-hosts"
+
 func TestNetworkingControllers(t *testing.T) {
 	SetDefaultEventuallyTimeout(10 * time.Second)
 	SetDefaultEventuallyPollingInterval(250 * time.Millisecond)
@@ -61,10 +67,25 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	dnsNamespace = "kube-dns"
+	Expect(adminClient.Create(context.Background(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: dnsNamespace,
+		},
+	})).To(Succeed())
+
 	err = domains.NewReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFDomain"),
+		&config.ControllerConfig{
+			Networking: config.Networking{
+				InternalDomainsDNS: config.InternalDomainsDNS{
+					ConfigMapName:      internalHostsConfigMapName,
+					ConfigMapNamespace: dnsNamespace,
+				},
+			},
+		},
 	).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
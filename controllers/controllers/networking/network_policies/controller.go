@@ -0,0 +1,295 @@
+package networkpolicies
+
+import (
+	"context"
+	"slices"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type Reconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	log    logr.Logger
+}
+
+func NewReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFNetworkPolicy] {
+	networkPolicyReconciler := Reconciler{client: client, scheme: scheme, log: log}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFNetworkPolicy](log, client, &networkPolicyReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFNetworkPolicy{}).
+		Watches(
+			&networkingv1.NetworkPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueSecurityGroupNetworkPolicyRequests),
+		)
+}
+
+func (r *Reconciler) enqueueSecurityGroupNetworkPolicyRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	if o.GetLabels()[korifiv1alpha1.CFSecurityGroupWorkloadsLabelKey] != korifiv1alpha1.SecurityGroupRunningWorkloads {
+		return []reconcile.Request{}
+	}
+
+	cfNetworkPolicies := korifiv1alpha1.CFNetworkPolicyList{}
+	if err := r.client.List(ctx, &cfNetworkPolicies, client.InNamespace(o.GetNamespace())); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, cfNetworkPolicy := range cfNetworkPolicies.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&cfNetworkPolicy),
+		})
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfnetworkpolicies,verbs=get;list;watch;create;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfnetworkpolicies/status,verbs=get;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfnetworkpolicies/finalizers,verbs=update
+
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;patch;delete
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !cfNetworkPolicy.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, r.finalizeCFNetworkPolicy(ctx, cfNetworkPolicy)
+	}
+
+	cfNetworkPolicy.Status.ObservedGeneration = cfNetworkPolicy.Generation
+	log.V(1).Info("set observed generation", "generation", cfNetworkPolicy.Status.ObservedGeneration)
+
+	networkPolicy, err := r.createOrPatchNetworkPolicy(ctx, cfNetworkPolicy)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileNetworkPolicy")
+	}
+	desiredPolicies := []types.NamespacedName{client.ObjectKeyFromObject(networkPolicy)}
+
+	egressRestricted, err := r.isAppEgressRestricted(ctx, cfNetworkPolicy.Namespace)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ListSecurityGroupNetworkPolicies")
+	}
+
+	if egressRestricted {
+		egressNetworkPolicy, err := r.createOrPatchEgressNetworkPolicy(ctx, cfNetworkPolicy)
+		if err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileEgressNetworkPolicy")
+		}
+		desiredPolicies = append(desiredPolicies, client.ObjectKeyFromObject(egressNetworkPolicy))
+	}
+
+	if err := r.deleteOrphanedNetworkPolicies(ctx, cfNetworkPolicy, desiredPolicies...); err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("DeleteOrphanedNetworkPolicies")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) finalizeCFNetworkPolicy(ctx context.Context, cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCFNetworkPolicy")
+
+	if !controllerutil.ContainsFinalizer(cfNetworkPolicy, korifiv1alpha1.CFNetworkPolicyFinalizerName) {
+		return nil
+	}
+
+	if err := r.deleteOrphanedNetworkPolicies(ctx, cfNetworkPolicy); err != nil {
+		log.Info("failed to delete network policies", "reason", err)
+		return err
+	}
+
+	if controllerutil.RemoveFinalizer(cfNetworkPolicy, korifiv1alpha1.CFNetworkPolicyFinalizerName) {
+		log.V(1).Info("finalizer removed")
+	}
+
+	return nil
+}
+
+// createOrPatchNetworkPolicy allows ingress from the source app pods to the
+// destination app pods. The policy is enforced on the destination side so
+// that it does not restrict the egress of the source app (e.g. to DNS or the
+// internet). When security groups restrict it, the egress to the destination
+// is allowed by createOrPatchEgressNetworkPolicy. Ingress from other apps is denied by
+// the network policy the space controller creates in every space namespace.
+// As the destination app may be in another namespace, the NetworkPolicy is
+// cleaned up by the finalizer rather than owner references.
+func (r *Reconciler) createOrPatchNetworkPolicy(ctx context.Context, cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchNetworkPolicy")
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfNetworkPolicy.Name,
+			Namespace: cfNetworkPolicy.Spec.DestinationAppRef.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, networkPolicy, func() error {
+		networkPolicy.Labels = tools.SetMapValue(networkPolicy.Labels, korifiv1alpha1.CFNetworkPolicyGUIDLabelKey, cfNetworkPolicy.Name)
+
+		networkPolicy.Spec.PodSelector = metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: cfNetworkPolicy.Spec.DestinationAppRef.Name,
+			},
+		}
+		networkPolicy.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+		networkPolicy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{
+			From: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						corev1.LabelMetadataName: cfNetworkPolicy.Namespace,
+					},
+				},
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey: cfNetworkPolicy.Spec.SourceAppRef.Name,
+					},
+				},
+			}},
+			Ports: []networkingv1.NetworkPolicyPort{toNetworkPolicyPort(cfNetworkPolicy.Spec)},
+		}}
+
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to create/patch NetworkPolicy", "reason", err)
+		return nil, err
+	}
+
+	log.V(1).Info("NetworkPolicy reconciled", "operation", result)
+	return networkPolicy, nil
+}
+
+// isAppEgressRestricted returns whether the egress of the apps in the
+// namespace is restricted by the network policies of running security groups
+func (r *Reconciler) isAppEgressRestricted(ctx context.Context, namespace string) (bool, error) {
+	networkPolicies := networkingv1.NetworkPolicyList{}
+	err := r.client.List(ctx, &networkPolicies, client.InNamespace(namespace), client.MatchingLabels{
+		korifiv1alpha1.CFSecurityGroupWorkloadsLabelKey: korifiv1alpha1.SecurityGroupRunningWorkloads,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(networkPolicies.Items) > 0, nil
+}
+
+// createOrPatchEgressNetworkPolicy allows egress from the source app pods to
+// the destination app pods. It is only needed when the egress of the source
+// app is restricted by security groups, as otherwise it would isolate the
+// source app pods and deny all their other egress.
+func (r *Reconciler) createOrPatchEgressNetworkPolicy(ctx context.Context, cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchEgressNetworkPolicy")
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfNetworkPolicy.Name + "-egress",
+			Namespace: cfNetworkPolicy.Namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, networkPolicy, func() error {
+		networkPolicy.Labels = tools.SetMapValue(networkPolicy.Labels, korifiv1alpha1.CFNetworkPolicyGUIDLabelKey, cfNetworkPolicy.Name)
+
+		networkPolicy.Spec.PodSelector = metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: cfNetworkPolicy.Spec.SourceAppRef.Name,
+			},
+		}
+		networkPolicy.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}
+		networkPolicy.Spec.Egress = []networkingv1.NetworkPolicyEgressRule{{
+			To: []networkingv1.NetworkPolicyPeer{{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						corev1.LabelMetadataName: cfNetworkPolicy.Spec.DestinationAppRef.Namespace,
+					},
+				},
+				PodSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey: cfNetworkPolicy.Spec.DestinationAppRef.Name,
+					},
+				},
+			}},
+			Ports: []networkingv1.NetworkPolicyPort{toNetworkPolicyPort(cfNetworkPolicy.Spec)},
+		}}
+
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to create/patch egress NetworkPolicy", "reason", err)
+		return nil, err
+	}
+
+	log.V(1).Info("egress NetworkPolicy reconciled", "operation", result)
+	return networkPolicy, nil
+}
+
+func (r *Reconciler) deleteOrphanedNetworkPolicies(
+	ctx context.Context,
+	cfNetworkPolicy *korifiv1alpha1.CFNetworkPolicy,
+	desiredPolicies ...types.NamespacedName,
+) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("deleteOrphanedNetworkPolicies")
+
+	networkPolicies := networkingv1.NetworkPolicyList{}
+	err := r.client.List(ctx, &networkPolicies, client.MatchingLabels{
+		korifiv1alpha1.CFNetworkPolicyGUIDLabelKey: cfNetworkPolicy.Name,
+	})
+	if err != nil {
+		log.Info("failed to list network policies", "reason", err)
+		return err
+	}
+
+	for i := range networkPolicies.Items {
+		if slices.Contains(desiredPolicies, client.ObjectKeyFromObject(&networkPolicies.Items[i])) {
+			continue
+		}
+
+		err = r.client.Delete(ctx, &networkPolicies.Items[i])
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete network policy", "name", networkPolicies.Items[i].Name, "namespace", networkPolicies.Items[i].Namespace, "reason", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toNetworkPolicyPort(spec korifiv1alpha1.CFNetworkPolicySpec) networkingv1.NetworkPolicyPort {
+	port := networkingv1.NetworkPolicyPort{
+		Protocol: tools.PtrTo(corev1.ProtocolTCP),
+		Port:     tools.PtrTo(intstr.FromInt32(spec.StartPort)),
+	}
+
+	if spec.Protocol == "udp" {
+		port.Protocol = tools.PtrTo(corev1.ProtocolUDP)
+	}
+
+	if spec.EndPort > spec.StartPort {
+		port.EndPort = tools.PtrTo(spec.EndPort)
+	}
+
+	return port
+}
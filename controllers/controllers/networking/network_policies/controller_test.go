@@ -0,0 +1,231 @@
+package networkpolicies_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFNetworkPolicyReconciler Integration Tests", func() {
+	var (
+		sourceNamespace      string
+		destinationNamespace string
+		cfNetworkPolicy      *korifiv1alpha1.CFNetworkPolicy
+	)
+
+	createNamespace := func() string {
+		namespace := uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		})).To(Succeed())
+
+		return namespace
+	}
+
+	BeforeEach(func() {
+		sourceNamespace = createNamespace()
+		destinationNamespace = createNamespace()
+
+		cfNetworkPolicy = &korifiv1alpha1.CFNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: sourceNamespace,
+				Finalizers: []string{
+					korifiv1alpha1.CFNetworkPolicyFinalizerName,
+				},
+			},
+			Spec: korifiv1alpha1.CFNetworkPolicySpec{
+				SourceAppRef: corev1.LocalObjectReference{Name: "source-app-guid"},
+				DestinationAppRef: corev1.ObjectReference{
+					Name:      "destination-app-guid",
+					Namespace: destinationNamespace,
+				},
+				Protocol:  "tcp",
+				StartPort: 8080,
+				EndPort:   8080,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		helpers.EnsureCreate(adminClient, cfNetworkPolicy)
+	})
+
+	It("sets the network policy Ready status", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfNetworkPolicy), cfNetworkPolicy)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(cfNetworkPolicy.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			g.Expect(cfNetworkPolicy.Status.ObservedGeneration).To(Equal(cfNetworkPolicy.Generation))
+		}).Should(Succeed())
+	})
+
+	It("allows ingress from the source app to the destination app", func() {
+		Eventually(func(g Gomega) {
+			networkPolicy := &networkingv1.NetworkPolicy{}
+			g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: destinationNamespace, Name: cfNetworkPolicy.Name}, networkPolicy)).To(Succeed())
+
+			g.Expect(networkPolicy.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFNetworkPolicyGUIDLabelKey, cfNetworkPolicy.Name))
+			g.Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+			g.Expect(networkPolicy.Spec.PodSelector).To(Equal(metav1.LabelSelector{
+				MatchLabels: map[string]string{korifiv1alpha1.CFAppGUIDLabelKey: "destination-app-guid"},
+			}))
+			g.Expect(networkPolicy.Spec.Ingress).To(ConsistOf(networkingv1.NetworkPolicyIngressRule{
+				From: []networkingv1.NetworkPolicyPeer{{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{corev1.LabelMetadataName: sourceNamespace},
+					},
+					PodSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{korifiv1alpha1.CFAppGUIDLabelKey: "source-app-guid"},
+					},
+				}},
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: tools.PtrTo(corev1.ProtocolTCP), Port: tools.PtrTo(intstr.FromInt32(8080))},
+				},
+			}))
+		}).Should(Succeed())
+	})
+
+	It("does not restrict the egress of the source app, e.g. to DNS or the internet, when security groups do not", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: destinationNamespace, Name: cfNetworkPolicy.Name}, &networkingv1.NetworkPolicy{})).To(Succeed())
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			networkPolicies := &networkingv1.NetworkPolicyList{}
+			g.Expect(adminClient.List(ctx, networkPolicies, client.InNamespace(sourceNamespace))).To(Succeed())
+			g.Expect(networkPolicies.Items).To(BeEmpty())
+		}).Should(Succeed())
+	})
+
+	When("the egress of the source app is restricted by security groups", func() {
+		var securityGroupPolicy *networkingv1.NetworkPolicy
+
+		BeforeEach(func() {
+			securityGroupPolicy = &networkingv1.NetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: sourceNamespace,
+					Labels: map[string]string{
+						korifiv1alpha1.CFSecurityGroupGUIDLabelKey:      uuid.NewString(),
+						korifiv1alpha1.CFSecurityGroupWorkloadsLabelKey: korifiv1alpha1.SecurityGroupRunningWorkloads,
+					},
+				},
+				Spec: networkingv1.NetworkPolicySpec{
+					PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+				},
+			}
+			Expect(adminClient.Create(ctx, securityGroupPolicy)).To(Succeed())
+		})
+
+		It("allows egress from the source app to the destination app", func() {
+			Eventually(func(g Gomega) {
+				networkPolicy := &networkingv1.NetworkPolicy{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: sourceNamespace, Name: cfNetworkPolicy.Name + "-egress"}, networkPolicy)).To(Succeed())
+
+				g.Expect(networkPolicy.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFNetworkPolicyGUIDLabelKey, cfNetworkPolicy.Name))
+				g.Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+				g.Expect(networkPolicy.Spec.PodSelector).To(Equal(metav1.LabelSelector{
+					MatchLabels: map[string]string{korifiv1alpha1.CFAppGUIDLabelKey: "source-app-guid"},
+				}))
+				g.Expect(networkPolicy.Spec.Egress).To(ConsistOf(networkingv1.NetworkPolicyEgressRule{
+					To: []networkingv1.NetworkPolicyPeer{{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{corev1.LabelMetadataName: destinationNamespace},
+						},
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{korifiv1alpha1.CFAppGUIDLabelKey: "destination-app-guid"},
+						},
+					}},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: tools.PtrTo(corev1.ProtocolTCP), Port: tools.PtrTo(intstr.FromInt32(8080))},
+					},
+				}))
+			}).Should(Succeed())
+		})
+
+		It("still allows ingress to the destination app", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: destinationNamespace, Name: cfNetworkPolicy.Name}, &networkingv1.NetworkPolicy{})).To(Succeed())
+			}).Should(Succeed())
+		})
+
+		When("the security groups no longer restrict the egress", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: sourceNamespace, Name: cfNetworkPolicy.Name + "-egress"}, &networkingv1.NetworkPolicy{})).To(Succeed())
+				}).Should(Succeed())
+
+				Expect(adminClient.Delete(ctx, securityGroupPolicy)).To(Succeed())
+			})
+
+			It("deletes the egress network policy", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKey{Namespace: sourceNamespace, Name: cfNetworkPolicy.Name + "-egress"}, &networkingv1.NetworkPolicy{})
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the policy allows a udp port range", func() {
+		BeforeEach(func() {
+			cfNetworkPolicy.Spec.Protocol = "udp"
+			cfNetworkPolicy.Spec.StartPort = 1000
+			cfNetworkPolicy.Spec.EndPort = 2000
+		})
+
+		It("allows ingress to the port range", func() {
+			Eventually(func(g Gomega) {
+				networkPolicy := &networkingv1.NetworkPolicy{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: destinationNamespace, Name: cfNetworkPolicy.Name}, networkPolicy)).To(Succeed())
+
+				g.Expect(networkPolicy.Spec.Ingress).To(HaveLen(1))
+				g.Expect(networkPolicy.Spec.Ingress[0].Ports).To(ConsistOf(networkingv1.NetworkPolicyPort{
+					Protocol: tools.PtrTo(corev1.ProtocolUDP),
+					Port:     tools.PtrTo(intstr.FromInt32(1000)),
+					EndPort:  tools.PtrTo[int32](2000),
+				}))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the network policy is deleted", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: destinationNamespace, Name: cfNetworkPolicy.Name}, &networkingv1.NetworkPolicy{})).To(Succeed())
+			}).Should(Succeed())
+
+			Expect(adminClient.Delete(ctx, cfNetworkPolicy)).To(Succeed())
+		})
+
+		It("deletes the destination app network policy", func() {
+			Eventually(func(g Gomega) {
+				networkPolicies := &networkingv1.NetworkPolicyList{}
+				g.Expect(adminClient.List(ctx, networkPolicies, client.MatchingLabels{
+					korifiv1alpha1.CFNetworkPolicyGUIDLabelKey: cfNetworkPolicy.Name,
+				})).To(Succeed())
+				g.Expect(networkPolicies.Items).To(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		It("deletes the network policy", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfNetworkPolicy), cfNetworkPolicy)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+})
@@ -40,6 +40,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -66,6 +67,10 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&korifiv1alpha1.CFApp{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFAppRequests),
+		).
+		Watches(
+			&korifiv1alpha1.CFServiceRouteBinding{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFServiceRouteBindingRequests),
 		)
 }
 
@@ -106,6 +111,9 @@ func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object)
 
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes/status,verbs=get
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes,verbs=get;list;watch;create;update;patch;delete
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceroutebindings,verbs=get;list;watch
 
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 
@@ -135,14 +143,41 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alp
 		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("CreatePatchServices")
 	}
 
-	err = r.reconcileHTTPRoute(ctx, cfRoute, cfDomain)
-	if err != nil {
-		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileHTTPRoute")
-	}
+	if cfRoute.Spec.Protocol == korifiv1alpha1.RouteProtocolTCP {
+		err = r.reconcileTCPRoute(ctx, cfRoute)
+		if err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileTCPRoute")
+		}
 
-	fqdn := buildFQDN(cfRoute, cfDomain)
-	cfRoute.Status.FQDN = fqdn
-	cfRoute.Status.URI = fqdn + cfRoute.Spec.Path
+		cfRoute.Status.FQDN = cfDomain.Spec.Name
+		cfRoute.Status.URI = fmt.Sprintf("%s:%d", cfDomain.Spec.Name, cfRoute.Spec.Port)
+	} else if cfDomain.Spec.Internal {
+		// routes on internal domains are resolved directly to the route
+		// services by the cluster DNS, so they are not attached to the gateway
+		fqdn := buildFQDN(cfRoute, cfDomain)
+		cfRoute.Status.FQDN = fqdn
+		cfRoute.Status.URI = fqdn
+	} else {
+		var routeServiceURL string
+		routeServiceURL, err = GetRouteServiceURL(ctx, r.client, cfRoute)
+		if err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("GetRouteService")
+		}
+
+		err = r.reconcileRouteServiceBackend(ctx, cfRoute, routeServiceURL)
+		if err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileRouteService")
+		}
+
+		err = r.reconcileHTTPRoute(ctx, cfRoute, cfDomain, routeServiceURL)
+		if err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileHTTPRoute")
+		}
+
+		fqdn := buildFQDN(cfRoute, cfDomain)
+		cfRoute.Status.FQDN = fqdn
+		cfRoute.Status.URI = fqdn + cfRoute.Spec.Path
+	}
 
 	effectiveDestinations, err := r.buildEffectiveDestinations(ctx, cfRoute)
 	if err != nil {
@@ -178,7 +213,7 @@ func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchServices")
 
 	for _, destination := range cfRoute.Status.Destinations {
-		serviceName := generateServiceName(destination)
+		serviceName := DestinationServiceName(destination)
 		loopLog := log.WithValues("processType", destination.ProcessType, "appRef", destination.AppRef.Name, "serviceName", serviceName)
 
 		if destination.Port == nil {
@@ -288,7 +323,7 @@ func (r *Reconciler) getAppCurrentDroplet(ctx context.Context, appNamespace, app
 	return cfBuild.Status.Droplet, nil
 }
 
-func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
+func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain, routeServiceURL string) error {
 	fqdn := buildFQDN(cfRoute, cfDomain)
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchHTTPRoute").WithValues("fqdn", fqdn, "path", cfRoute.Spec.Path)
 
@@ -320,6 +355,11 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 			gatewayv1beta1.Hostname(fqdn),
 		}
 
+		if routeServiceURL != "" {
+			httpRoute.Spec.Rules = r.routeServiceRules(cfRoute)
+			return controllerutil.SetControllerReference(cfRoute, httpRoute, r.scheme)
+		}
+
 		httpRoute.Spec.Rules = []gatewayv1beta1.HTTPRouteRule{{
 			BackendRefs: toBackendRefs(cfRoute.Status.Destinations),
 		}}
@@ -343,6 +383,49 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 	return nil
 }
 
+func (r *Reconciler) reconcileTCPRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchTCPRoute").WithValues("port", cfRoute.Spec.Port)
+
+	tcpRoute := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.Name,
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	if len(cfRoute.Status.Destinations) == 0 {
+		err := r.client.Delete(ctx, tcpRoute)
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete existing TCPRoutes", "reason", err)
+			return err
+		}
+		return nil
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, tcpRoute, func() error {
+		tcpRoute.Spec.ParentRefs = []gatewayv1alpha2.ParentReference{{
+			Group:       tools.PtrTo(gatewayv1alpha2.Group("gateway.networking.k8s.io")),
+			Kind:        tools.PtrTo(gatewayv1alpha2.Kind("Gateway")),
+			Namespace:   tools.PtrTo(gatewayv1alpha2.Namespace(r.controllerConfig.Networking.GatewayNamespace)),
+			Name:        gatewayv1alpha2.ObjectName(r.controllerConfig.Networking.GatewayName),
+			SectionName: tools.PtrTo(gatewayv1alpha2.SectionName(tcpListenerName(r.controllerConfig.Networking.TCPListenerPrefix, cfRoute.Spec.Port))),
+		}}
+
+		tcpRoute.Spec.Rules = []gatewayv1alpha2.TCPRouteRule{{
+			BackendRefs: toTCPBackendRefs(cfRoute.Status.Destinations),
+		}}
+
+		return controllerutil.SetControllerReference(cfRoute, tcpRoute, r.scheme)
+	})
+	if err != nil {
+		log.Info("failed to create/patch TCPRoute", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("TCPRoute reconciled", "operation", result)
+	return nil
+}
+
 func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("deleteOrphanedServices")
 
@@ -361,7 +444,7 @@ func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifi
 
 		isOrphan := true
 		for _, destination := range cfRoute.Status.Destinations {
-			if service.Name == generateServiceName(destination) {
+			if service.Name == DestinationServiceName(destination) {
 				isOrphan = false
 				break
 			}
@@ -398,7 +481,9 @@ func (r *Reconciler) fetchServicesByMatchingLabels(ctx context.Context, labelSet
 	return &serviceList, nil
 }
 
-func generateServiceName(destination korifiv1alpha1.Destination) string {
+// DestinationServiceName returns the name of the Service the route destination
+// is reachable through
+func DestinationServiceName(destination korifiv1alpha1.Destination) string {
 	return fmt.Sprintf("s-%s", destination.GUID)
 }
 
@@ -406,6 +491,26 @@ func buildFQDN(cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomai
 	return fmt.Sprintf("%s.%s", strings.ToLower(cfRoute.Spec.Host), cfDomain.Spec.Name)
 }
 
+func tcpListenerName(prefix string, port int32) string {
+	return fmt.Sprintf("%s-%d", prefix, port)
+}
+
+func toTCPBackendRefs(destinations []korifiv1alpha1.Destination) []gatewayv1alpha2.BackendRef {
+	backendRefs := []gatewayv1alpha2.BackendRef{}
+
+	for _, destination := range destinations {
+		backendRefs = append(backendRefs, gatewayv1alpha2.BackendRef{
+			BackendObjectReference: gatewayv1alpha2.BackendObjectReference{
+				Kind: tools.PtrTo(gatewayv1alpha2.Kind("Service")),
+				Name: gatewayv1alpha2.ObjectName(DestinationServiceName(destination)),
+				Port: tools.PtrTo(gatewayv1alpha2.PortNumber(*destination.Port)),
+			},
+		})
+	}
+
+	return backendRefs
+}
+
 func toBackendRefs(destinations []korifiv1alpha1.Destination) []gatewayv1beta1.HTTPBackendRef {
 	backendRefs := []gatewayv1beta1.HTTPBackendRef{}
 
@@ -414,7 +519,7 @@ func toBackendRefs(destinations []korifiv1alpha1.Destination) []gatewayv1beta1.H
 			BackendRef: gatewayv1beta1.BackendRef{
 				BackendObjectReference: gatewayv1beta1.BackendObjectReference{
 					Kind: tools.PtrTo(gatewayv1beta1.Kind("Service")),
-					Name: gatewayv1beta1.ObjectName(generateServiceName(destination)),
+					Name: gatewayv1beta1.ObjectName(DestinationServiceName(destination)),
 					Port: tools.PtrTo(gatewayv1beta1.PortNumber(*destination.Port)),
 				},
 			},
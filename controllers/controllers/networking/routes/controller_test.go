@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
@@ -414,6 +415,241 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 				}).Should(Succeed())
 			})
 		})
+
+		When("the route is bound to a route service", func() {
+			var routeBinding *korifiv1alpha1.CFServiceRouteBinding
+
+			JustBeforeEach(func() {
+				routeBinding = &korifiv1alpha1.CFServiceRouteBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: ns.Name,
+					},
+					Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+						ServiceInstanceRef: corev1.LocalObjectReference{Name: uuid.NewString()},
+						RouteRef:           corev1.LocalObjectReference{Name: cfRoute.Name},
+					},
+				}
+				Expect(adminClient.Create(ctx, routeBinding)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, routeBinding, func() {
+					routeBinding.Status.RouteServiceURL = "https://route-service.example.com:8443/proxy"
+				})).To(Succeed())
+			})
+
+			It("creates an ExternalName service for the route service proxy", func() {
+				Eventually(func(g Gomega) {
+					service := &corev1.Service{}
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: "rs-" + cfRoute.Name, Namespace: ns.Name}, service)).To(Succeed())
+					g.Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeExternalName))
+					g.Expect(service.Spec.ExternalName).To(Equal("korifi-route-service-proxy.korifi.svc.cluster.local"))
+					g.Expect(service.Spec.Ports).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Port": BeEquivalentTo(8082),
+					})))
+					g.Expect(service.Labels).NotTo(HaveKey(korifiv1alpha1.CFRouteGUIDLabelKey))
+				}).Should(Succeed())
+			})
+
+			It("sends all requests to the route service proxy", func() {
+				Eventually(func(g Gomega) {
+					httpRoute := getHTTPRoute()
+					g.Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+
+					g.Expect(httpRoute.Spec.Rules[0].Matches).To(ConsistOf(gatewayv1beta1.HTTPRouteMatch{
+						Path: &gatewayv1beta1.HTTPPathMatch{
+							Type:  tools.PtrTo(gatewayv1.PathMatchPathPrefix),
+							Value: tools.PtrTo("/hello"),
+						},
+					}))
+					g.Expect(httpRoute.Spec.Rules[0].Filters).To(ConsistOf(gatewayv1beta1.HTTPRouteFilter{
+						Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+						RequestHeaderModifier: &gatewayv1beta1.HTTPHeaderFilter{
+							Set: []gatewayv1beta1.HTTPHeader{{
+								Name:  "X-Korifi-Route-Service-Route",
+								Value: ns.Name + "/" + cfRoute.Name,
+							}},
+						},
+					}))
+					g.Expect(httpRoute.Spec.Rules[0].BackendRefs).To(HaveLen(1))
+					g.Expect(httpRoute.Spec.Rules[0].BackendRefs[0].Name).To(BeEquivalentTo("rs-" + cfRoute.Name))
+					g.Expect(httpRoute.Spec.Rules[0].BackendRefs[0].Port).To(PointTo(BeEquivalentTo(8082)))
+				}).Should(Succeed())
+			})
+
+			When("the route binding is deleted", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(getHTTPRoute().Spec.Rules[0].Filters).NotTo(BeEmpty())
+					}).Should(Succeed())
+
+					Expect(adminClient.Delete(ctx, routeBinding)).To(Succeed())
+				})
+
+				It("sends requests straight to the route destinations", func() {
+					Eventually(func(g Gomega) {
+						httpRoute := getHTTPRoute()
+						g.Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+						g.Expect(httpRoute.Spec.Rules[0].Filters).To(BeEmpty())
+					}).Should(Succeed())
+				})
+
+				It("deletes the route service service", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, types.NamespacedName{Name: "rs-" + cfRoute.Name, Namespace: ns.Name}, new(corev1.Service))
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+		})
+	})
+
+	When("the route is a tcp route", func() {
+		BeforeEach(func() {
+			cfRoute.Spec.Host = ""
+			cfRoute.Spec.Path = ""
+			cfRoute.Spec.Protocol = korifiv1alpha1.RouteProtocolTCP
+			cfRoute.Spec.Port = 1234
+		})
+
+		It("sets the domain and port in the cfroute status", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfRoute.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				g.Expect(cfRoute.Status.FQDN).To(Equal(cfDomain.Spec.Name))
+				g.Expect(cfRoute.Status.URI).To(Equal(cfDomain.Spec.Name + ":1234"))
+			}).Should(Succeed())
+		})
+
+		When("the route has destinations", func() {
+			BeforeEach(func() {
+				cfApp := &korifiv1alpha1.CFApp{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: ns.Name,
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFAppSpec{
+						Lifecycle: korifiv1alpha1.Lifecycle{
+							Type: "buildpack",
+						},
+						DesiredState: "STARTED",
+						DisplayName:  uuid.NewString(),
+					},
+				}
+				Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
+					GUID:        uuid.NewString(),
+					AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+					ProcessType: "web",
+					Port:        tools.PtrTo[int32](5432),
+				}}
+			})
+
+			It("creates a TCPRoute bound to the listener of the port", func() {
+				tcpRoute := &gatewayv1alpha2.TCPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      cfRoute.Name,
+						Namespace: cfRoute.Namespace,
+					},
+				}
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(tcpRoute), tcpRoute)).To(Succeed())
+				}).Should(Succeed())
+
+				Expect(tcpRoute.Spec.ParentRefs).To(ConsistOf(gatewayv1alpha2.ParentReference{
+					Group:       tools.PtrTo(gatewayv1alpha2.Group("gateway.networking.k8s.io")),
+					Kind:        tools.PtrTo(gatewayv1alpha2.Kind("Gateway")),
+					Namespace:   tools.PtrTo(gatewayv1alpha2.Namespace("korifi-gateway")),
+					Name:        gatewayv1alpha2.ObjectName("korifi"),
+					SectionName: tools.PtrTo(gatewayv1alpha2.SectionName("tcp-1234")),
+				}))
+
+				Expect(tcpRoute.Spec.Rules).To(HaveLen(1))
+				Expect(tcpRoute.Spec.Rules[0].BackendRefs).To(HaveLen(1))
+				Expect(tcpRoute.Spec.Rules[0].BackendRefs[0].BackendObjectReference).To(Equal(gatewayv1alpha2.BackendObjectReference{
+					Group: tools.PtrTo(gatewayv1alpha2.Group("")),
+					Kind:  tools.PtrTo(gatewayv1alpha2.Kind("Service")),
+					Name:  gatewayv1alpha2.ObjectName(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)),
+					Port:  tools.PtrTo(gatewayv1alpha2.PortNumber(5432)),
+				}))
+
+				Expect(tcpRoute.OwnerReferences).To(ConsistOf(HaveField("Name", cfRoute.Name)))
+			})
+
+			It("does not create an HTTPRoute", func() {
+				Consistently(func(g Gomega) {
+					httpRoutes := &gatewayv1beta1.HTTPRouteList{}
+					g.Expect(adminClient.List(ctx, httpRoutes, client.InNamespace(ns.Name))).To(Succeed())
+					g.Expect(httpRoutes.Items).To(BeEmpty())
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the route is on an internal domain", func() {
+		BeforeEach(func() {
+			cfDomain = &korifiv1alpha1.CFDomain{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: ns.Name,
+				},
+				Spec: korifiv1alpha1.CFDomainSpec{
+					Name:     "a" + uuid.NewString() + ".internal",
+					Internal: true,
+				},
+			}
+			Expect(adminClient.Create(ctx, cfDomain)).To(Succeed())
+
+			cfApp := &korifiv1alpha1.CFApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: ns.Name,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFAppSpec{
+					Lifecycle: korifiv1alpha1.Lifecycle{
+						Type: "buildpack",
+					},
+					DesiredState: "STARTED",
+					DisplayName:  uuid.NewString(),
+				},
+			}
+			Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+			cfRoute.Spec.Path = ""
+			cfRoute.Spec.DomainRef.Name = cfDomain.Name
+			cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{{
+				GUID:        uuid.NewString(),
+				AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+				ProcessType: "web",
+				Port:        tools.PtrTo[int32](8080),
+			}}
+		})
+
+		It("sets the fqdn in the cfroute status", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfRoute.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				g.Expect(cfRoute.Status.FQDN).To(Equal(getCfRouteFQDN()))
+				g.Expect(cfRoute.Status.URI).To(Equal(getCfRouteFQDN()))
+			}).Should(Succeed())
+		})
+
+		It("creates a service for the destination", func() {
+			Eventually(func(g Gomega) {
+				services := &corev1.ServiceList{}
+				g.Expect(adminClient.List(ctx, services, client.InNamespace(ns.Name), client.MatchingLabels{
+					korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+				})).To(Succeed())
+				g.Expect(services.Items).To(HaveLen(1))
+			}).Should(Succeed())
+		})
+
+		It("does not create an HTTPRoute", func() {
+			Consistently(func(g Gomega) {
+				httpRoutes := &gatewayv1beta1.HTTPRouteList{}
+				g.Expect(adminClient.List(ctx, httpRoutes, client.InNamespace(ns.Name))).To(Succeed())
+				g.Expect(httpRoutes.Items).To(BeEmpty())
+			}).Should(Succeed())
+		})
 	})
 
 	When("a route has a legacy finalizer", func() {
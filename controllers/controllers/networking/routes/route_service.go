@@ -0,0 +1,146 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// RouteServiceRouteHeader is set by the gateway on requests to routes bound
+// to route services. It tells the route service proxy which route (as
+// <namespace>/<name>) the request is for
+const RouteServiceRouteHeader = "X-Korifi-Route-Service-Route"
+
+func (r *Reconciler) enqueueCFServiceRouteBindingRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	routeBinding, ok := o.(*korifiv1alpha1.CFServiceRouteBinding)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Name:      routeBinding.Spec.RouteRef.Name,
+			Namespace: routeBinding.Namespace,
+		},
+	}}
+}
+
+// GetRouteServiceURL returns the URL of the route service bound to the route,
+// or an empty string if the route is not bound to a route service
+func GetRouteServiceURL(ctx context.Context, k8sClient client.Client, cfRoute *korifiv1alpha1.CFRoute) (string, error) {
+	routeBindings := korifiv1alpha1.CFServiceRouteBindingList{}
+	err := k8sClient.List(ctx, &routeBindings,
+		client.InNamespace(cfRoute.Namespace),
+		client.MatchingFields{shared.IndexServiceRouteBindingRouteGUID: cfRoute.Name},
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to list service route bindings: %w", err)
+	}
+
+	for _, routeBinding := range routeBindings.Items {
+		if !routeBinding.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		if routeBinding.Status.RouteServiceURL != "" {
+			return routeBinding.Status.RouteServiceURL, nil
+		}
+	}
+
+	return "", nil
+}
+
+// reconcileRouteServiceBackend makes the route service proxy reachable from
+// the route namespace via an ExternalName service
+func (r *Reconciler) reconcileRouteServiceBackend(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, routeServiceURL string) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileRouteServiceBackend")
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      generateRouteServiceName(cfRoute),
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	if routeServiceURL == "" {
+		err := r.client.Delete(ctx, service)
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete route service Service", "reason", err)
+			return err
+		}
+		return nil
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
+		service.Spec.Type = corev1.ServiceTypeExternalName
+		service.Spec.ExternalName = r.controllerConfig.Networking.RouteServiceProxy.Host
+		service.Spec.Ports = []corev1.ServicePort{{
+			Port: r.controllerConfig.Networking.RouteServiceProxy.Port,
+		}}
+
+		return controllerutil.SetControllerReference(cfRoute, service, r.scheme)
+	})
+	if err != nil {
+		log.Info("failed to create/patch route service Service", "reason", err)
+		return err
+	}
+	log.V(1).Info("route service Service reconciled", "operation", result)
+
+	return nil
+}
+
+// routeServiceRules returns the HTTPRoute rules for a route bound to a route
+// service. All requests are sent to the route service proxy, which signs and
+// sends them to the route service, and forwards the ones coming back from the
+// route service with a valid signature to the route destinations
+func (r *Reconciler) routeServiceRules(cfRoute *korifiv1alpha1.CFRoute) []gatewayv1beta1.HTTPRouteRule {
+	pathPrefix := "/"
+	if cfRoute.Spec.Path != "" {
+		pathPrefix = strings.ToLower(cfRoute.Spec.Path)
+	}
+
+	return []gatewayv1beta1.HTTPRouteRule{{
+		Matches: []gatewayv1beta1.HTTPRouteMatch{{
+			Path: &gatewayv1beta1.HTTPPathMatch{
+				Type:  tools.PtrTo(gatewayv1.PathMatchPathPrefix),
+				Value: tools.PtrTo(pathPrefix),
+			},
+		}},
+		Filters: []gatewayv1beta1.HTTPRouteFilter{{
+			Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: &gatewayv1beta1.HTTPHeaderFilter{
+				Set: []gatewayv1beta1.HTTPHeader{{
+					Name:  RouteServiceRouteHeader,
+					Value: cfRoute.Namespace + "/" + cfRoute.Name,
+				}},
+			},
+		}},
+		BackendRefs: []gatewayv1beta1.HTTPBackendRef{{
+			BackendRef: gatewayv1beta1.BackendRef{
+				BackendObjectReference: gatewayv1beta1.BackendObjectReference{
+					Kind: tools.PtrTo(gatewayv1beta1.Kind("Service")),
+					Name: gatewayv1beta1.ObjectName(generateRouteServiceName(cfRoute)),
+					Port: tools.PtrTo(gatewayv1beta1.PortNumber(r.controllerConfig.Networking.RouteServiceProxy.Port)),
+				},
+			},
+		}},
+	}}
+}
+
+func generateRouteServiceName(cfRoute *korifiv1alpha1.CFRoute) string {
+	return fmt.Sprintf("rs-%s", cfRoute.Name)
+}
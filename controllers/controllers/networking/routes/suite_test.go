@@ -20,6 +20,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	//+kubebuilder:scaffold:imports
 )
@@ -59,6 +60,7 @@ var _ = BeforeSuite(func() {
 
 	Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
 	Expect(gatewayv1beta1.Install(scheme.Scheme)).To(Succeed())
+	Expect(gatewayv1alpha2.Install(scheme.Scheme)).To(Succeed())
 
 	k8sManager := helpers.NewK8sManager(testEnv, filepath.Join("helm", "korifi", "controllers", "role.yaml"))
 	Expect(shared.SetupIndexWithManager(k8sManager)).To(Succeed())
@@ -75,8 +77,13 @@ var _ = BeforeSuite(func() {
 				DiskQuotaMB: 512,
 			},
 			Networking: config.Networking{
-				GatewayName:      "korifi",
-				GatewayNamespace: "korifi-gateway",
+				GatewayName:       "korifi",
+				GatewayNamespace:  "korifi-gateway",
+				TCPListenerPrefix: "tcp",
+				RouteServiceProxy: config.RouteServiceProxy{
+					Port: 8082,
+					Host: "korifi-route-service-proxy.korifi.svc.cluster.local",
+				},
 			},
 		},
 	).SetupWithManager(k8sManager)).To(Succeed())
@@ -0,0 +1,226 @@
+package routeservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ForwardedURLHeader   = "X-CF-Forwarded-Url"
+	ProxySignatureHeader = "X-CF-Proxy-Signature"
+	ProxyMetadataHeader  = "X-CF-Proxy-Metadata"
+)
+
+// forwardedHeaders are set by the gateway and passed on to the route
+// services and destinations unchanged
+var forwardedHeaders = []string{"X-Forwarded-For", "X-Forwarded-Host", "X-Forwarded-Proto"}
+
+// Proxy is the hop between the gateway and routes bound to route services.
+// Requests without a signature are signed and sent to the route service.
+// Requests with a valid signature have been through the route service and are
+// sent to the route destinations
+type Proxy struct {
+	k8sClient client.Client
+	signer    *Signer
+	transport http.RoundTripper
+}
+
+func NewProxy(k8sClient client.Client, signer *Signer, transport http.RoundTripper) *Proxy {
+	return &Proxy{
+		k8sClient: k8sClient,
+		signer:    signer,
+		transport: transport,
+	}
+}
+
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := logr.FromContextOrDiscard(r.Context()).WithName("route-service-proxy").WithValues("host", r.Host)
+
+	routeKey := r.Header.Get(routes.RouteServiceRouteHeader)
+	r.Header.Del(routes.RouteServiceRouteHeader)
+
+	namespace, name, ok := strings.Cut(routeKey, "/")
+	if !ok {
+		log.Info("request has no route", "route", routeKey)
+		http.Error(w, "unknown route", http.StatusNotFound)
+		return
+	}
+
+	cfRoute := &korifiv1alpha1.CFRoute{}
+	err := p.k8sClient.Get(r.Context(), types.NamespacedName{Namespace: namespace, Name: name}, cfRoute)
+	if err != nil {
+		log.Info("failed to get route", "route", routeKey, "reason", err)
+		http.Error(w, "unknown route", http.StatusNotFound)
+		return
+	}
+
+	forwardedURL := getForwardedURL(r)
+
+	if r.Header.Get(ProxySignatureHeader) != "" {
+		err = p.signer.Verify(r.Header.Get(ProxySignatureHeader), r.Header.Get(ProxyMetadataHeader), forwardedURL, time.Now())
+		if err != nil {
+			log.Info("invalid route service signature", "route", routeKey, "reason", err)
+			http.Error(w, "Failed to validate Route Service Signature", http.StatusBadRequest)
+			return
+		}
+
+		p.proxyToDestination(w, r, cfRoute)
+		return
+	}
+
+	routeServiceURL, err := routes.GetRouteServiceURL(r.Context(), p.k8sClient, cfRoute)
+	if err != nil {
+		log.Info("failed to get route service", "route", routeKey, "reason", err)
+		http.Error(w, "failed to get route service", http.StatusBadGateway)
+		return
+	}
+
+	if routeServiceURL == "" {
+		// the route has been unbound from the route service, but the
+		// HTTPRoute has not been updated yet
+		p.proxyToDestination(w, r, cfRoute)
+		return
+	}
+
+	target, err := url.Parse(routeServiceURL)
+	if err != nil {
+		log.Info("invalid route service url", "route", routeKey, "reason", err)
+		http.Error(w, "invalid route service url", http.StatusBadGateway)
+		return
+	}
+
+	signature, metadata, err := p.signer.Sign(forwardedURL, time.Now())
+	if err != nil {
+		log.Info("failed to sign request", "route", routeKey, "reason", err)
+		http.Error(w, "failed to sign request", http.StatusInternalServerError)
+		return
+	}
+
+	p.reverseProxy(func(pr *httputil.ProxyRequest) {
+		pr.Out.URL = target
+		pr.Out.Host = ""
+		pr.Out.Header.Set(ForwardedURLHeader, forwardedURL)
+		pr.Out.Header.Set(ProxySignatureHeader, signature)
+		pr.Out.Header.Set(ProxyMetadataHeader, metadata)
+	}).ServeHTTP(w, r)
+}
+
+func (p *Proxy) proxyToDestination(w http.ResponseWriter, r *http.Request, cfRoute *korifiv1alpha1.CFRoute) {
+	destinations := []korifiv1alpha1.Destination{}
+	for _, destination := range cfRoute.Status.Destinations {
+		if destination.Port != nil {
+			destinations = append(destinations, destination)
+		}
+	}
+
+	if len(destinations) == 0 {
+		http.Error(w, "route has no destinations", http.StatusServiceUnavailable)
+		return
+	}
+
+	destination := destinations[rand.IntN(len(destinations))]
+	target := &url.URL{
+		Scheme: "http",
+		Host:   net.JoinHostPort(fmt.Sprintf("%s.%s.svc", routes.DestinationServiceName(destination), cfRoute.Namespace), fmt.Sprint(*destination.Port)),
+	}
+
+	p.reverseProxy(func(pr *httputil.ProxyRequest) {
+		pr.SetURL(target)
+		pr.Out.Host = pr.In.Host
+	}).ServeHTTP(w, r)
+}
+
+func (p *Proxy) reverseProxy(rewrite func(*httputil.ProxyRequest)) *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Rewrite: func(pr *httputil.ProxyRequest) {
+			rewrite(pr)
+
+			for _, header := range forwardedHeaders {
+				if values, ok := pr.In.Header[header]; ok {
+					pr.Out.Header[header] = values
+				}
+			}
+		},
+		Transport: p.transport,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			logr.FromContextOrDiscard(r.Context()).Info("failed to proxy request", "url", r.URL.String(), "reason", err)
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+}
+
+// getForwardedURL returns the URL of the original request to the gateway
+func getForwardedURL(r *http.Request) string {
+	scheme := "https"
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+
+	return (&url.URL{
+		Scheme:   scheme,
+		Host:     r.Host,
+		Path:     r.URL.Path,
+		RawPath:  r.URL.RawPath,
+		RawQuery: r.URL.RawQuery,
+	}).String()
+}
+
+// Server serves the route service proxy. It runs on every controllers replica,
+// regardless of leader election
+type Server struct {
+	port  int32
+	proxy *Proxy
+	log   logr.Logger
+}
+
+func NewServer(port int32, proxy *Proxy, log logr.Logger) *Server {
+	return &Server{
+		port:  port,
+		proxy: proxy,
+		log:   log,
+	}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", s.port),
+		Handler:           s.proxy,
+		ReadHeaderTimeout: 30 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return logr.NewContext(ctx, s.log)
+		},
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := server.Shutdown(context.Background()); err != nil {
+			s.log.Error(err, "failed to shut down the route service proxy")
+		}
+	}()
+
+	s.log.Info("starting route service proxy", "port", s.port)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,262 @@
+package routeservice_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routeservice"
+	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Proxy", func() {
+	var (
+		fakeClient         *controllerfake.Client
+		signer             *routeservice.Signer
+		cfRoute            *korifiv1alpha1.CFRoute
+		routeBindings      []korifiv1alpha1.CFServiceRouteBinding
+		getRouteErr        error
+		routeServiceServer *httptest.Server
+		routeServiceReqs   chan *http.Request
+		appServer          *httptest.Server
+		appReqs            chan *http.Request
+		proxy              *routeservice.Proxy
+		req                *http.Request
+		rr                 *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		routeServiceReqs = make(chan *http.Request, 1)
+		routeServiceServer = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			routeServiceReqs <- r
+			_, _ = io.WriteString(w, "from the route service")
+		}))
+		DeferCleanup(routeServiceServer.Close)
+
+		appReqs = make(chan *http.Request, 1)
+		appServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			appReqs <- r
+			_, _ = io.WriteString(w, "from the app")
+		}))
+		DeferCleanup(appServer.Close)
+
+		transport := routeServiceServer.Client().Transport.(*http.Transport).Clone()
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			switch addr {
+			case "example.com:443":
+				return net.Dial(network, routeServiceServer.Listener.Addr().String())
+			case "s-dest-guid.space-guid.svc:8080":
+				return net.Dial(network, appServer.Listener.Addr().String())
+			default:
+				return nil, errors.New("unexpected address " + addr)
+			}
+		}
+
+		var err error
+		signer, err = routeservice.NewSigner([]byte("my-key"))
+		Expect(err).NotTo(HaveOccurred())
+
+		cfRoute = &korifiv1alpha1.CFRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "route-guid",
+				Namespace: "space-guid",
+			},
+			Status: korifiv1alpha1.CFRouteStatus{
+				Destinations: []korifiv1alpha1.Destination{{
+					GUID: "dest-guid",
+					Port: tools.PtrTo[int32](8080),
+				}},
+			},
+		}
+		getRouteErr = nil
+
+		routeBindings = []korifiv1alpha1.CFServiceRouteBinding{{
+			Status: korifiv1alpha1.CFServiceRouteBindingStatus{
+				RouteServiceURL: "https://example.com/proxy",
+			},
+		}}
+
+		fakeClient = new(controllerfake.Client)
+		fakeClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *korifiv1alpha1.CFRoute:
+				Expect(key).To(Equal(types.NamespacedName{Namespace: "space-guid", Name: "route-guid"}))
+				cfRoute.DeepCopyInto(obj)
+				return getRouteErr
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+		}
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			switch list := list.(type) {
+			case *korifiv1alpha1.CFServiceRouteBindingList:
+				list.Items = routeBindings
+				return nil
+			default:
+				panic("TestClient List provided an unexpected object type")
+			}
+		}
+
+		proxy = routeservice.NewProxy(fakeClient, signer, transport)
+
+		req = httptest.NewRequest(http.MethodGet, "http://my-app.example.org/hello/world?foo=bar", nil)
+		req.Header.Set("X-Korifi-Route-Service-Route", "space-guid/route-guid")
+		req.Header.Set("X-Forwarded-For", "1.2.3.4")
+		rr = httptest.NewRecorder()
+	})
+
+	JustBeforeEach(func() {
+		proxy.ServeHTTP(rr, req)
+	})
+
+	It("sends the request to the route service", func() {
+		Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		Expect(rr).To(HaveHTTPBody("from the route service"))
+
+		var routeServiceReq *http.Request
+		Expect(routeServiceReqs).To(Receive(&routeServiceReq))
+		Expect(routeServiceReq.Host).To(Equal("example.com"))
+		Expect(routeServiceReq.URL.Path).To(Equal("/proxy"))
+		Expect(routeServiceReq.Header.Get("X-CF-Forwarded-Url")).To(Equal("https://my-app.example.org/hello/world?foo=bar"))
+		Expect(routeServiceReq.Header.Get("X-Forwarded-For")).To(Equal("1.2.3.4"))
+		Expect(routeServiceReq.Header).NotTo(HaveKey("X-Korifi-Route-Service-Route"))
+		Expect(signer.Verify(
+			routeServiceReq.Header.Get("X-CF-Proxy-Signature"),
+			routeServiceReq.Header.Get("X-CF-Proxy-Metadata"),
+			"https://my-app.example.org/hello/world?foo=bar",
+			time.Now(),
+		)).To(Succeed())
+
+		Expect(appReqs).NotTo(Receive())
+	})
+
+	When("the original request is not https", func() {
+		BeforeEach(func() {
+			req.Header.Set("X-Forwarded-Proto", "http")
+		})
+
+		It("forwards the original url", func() {
+			var routeServiceReq *http.Request
+			Expect(routeServiceReqs).To(Receive(&routeServiceReq))
+			Expect(routeServiceReq.Header.Get("X-CF-Forwarded-Url")).To(Equal("http://my-app.example.org/hello/world?foo=bar"))
+		})
+	})
+
+	When("the request has a valid signature", func() {
+		BeforeEach(func() {
+			signature, metadata, err := signer.Sign("https://my-app.example.org/hello/world?foo=bar", time.Now())
+			Expect(err).NotTo(HaveOccurred())
+
+			req.Header.Set("X-CF-Proxy-Signature", signature)
+			req.Header.Set("X-CF-Proxy-Metadata", metadata)
+		})
+
+		It("sends the request to the route destination", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody("from the app"))
+
+			var appReq *http.Request
+			Expect(appReqs).To(Receive(&appReq))
+			Expect(appReq.Host).To(Equal("my-app.example.org"))
+			Expect(appReq.URL.Path).To(Equal("/hello/world"))
+			Expect(appReq.URL.RawQuery).To(Equal("foo=bar"))
+			Expect(appReq.Header.Get("X-Forwarded-For")).To(Equal("1.2.3.4"))
+			Expect(appReq.Header).NotTo(HaveKey("X-Korifi-Route-Service-Route"))
+
+			Expect(routeServiceReqs).NotTo(Receive())
+		})
+
+		When("the route has no destinations", func() {
+			BeforeEach(func() {
+				cfRoute.Status.Destinations = nil
+			})
+
+			It("returns service unavailable", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusServiceUnavailable))
+			})
+		})
+	})
+
+	When("the request has an invalid signature", func() {
+		BeforeEach(func() {
+			otherSigner, err := routeservice.NewSigner([]byte("other-key"))
+			Expect(err).NotTo(HaveOccurred())
+
+			signature, metadata, err := otherSigner.Sign("https://my-app.example.org/hello/world?foo=bar", time.Now())
+			Expect(err).NotTo(HaveOccurred())
+
+			req.Header.Set("X-CF-Proxy-Signature", signature)
+			req.Header.Set("X-CF-Proxy-Metadata", metadata)
+		})
+
+		It("rejects the request", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusBadRequest))
+			Expect(appReqs).NotTo(Receive())
+			Expect(routeServiceReqs).NotTo(Receive())
+		})
+	})
+
+	When("the route is no longer bound to a route service", func() {
+		BeforeEach(func() {
+			routeBindings = nil
+		})
+
+		It("sends the request to the route destination", func() {
+			Expect(rr).To(HaveHTTPBody("from the app"))
+			Expect(routeServiceReqs).NotTo(Receive())
+		})
+	})
+
+	When("the route binding is being deleted", func() {
+		BeforeEach(func() {
+			routeBindings[0].DeletionTimestamp = tools.PtrTo(metav1.Now())
+		})
+
+		It("sends the request to the route destination", func() {
+			Expect(rr).To(HaveHTTPBody("from the app"))
+		})
+	})
+
+	When("the request does not specify the route", func() {
+		BeforeEach(func() {
+			req.Header.Del("X-Korifi-Route-Service-Route")
+		})
+
+		It("returns not found", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusNotFound))
+			Expect(fakeClient.GetCallCount()).To(BeZero())
+		})
+	})
+
+	When("the route does not exist", func() {
+		BeforeEach(func() {
+			getRouteErr = errors.New("not found")
+		})
+
+		It("returns not found", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusNotFound))
+		})
+	})
+
+	When("the route service cannot be reached", func() {
+		BeforeEach(func() {
+			routeServiceServer.Close()
+		})
+
+		It("returns bad gateway", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusBadGateway))
+		})
+	})
+})
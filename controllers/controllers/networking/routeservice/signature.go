@@ -0,0 +1,128 @@
+package routeservice
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// signatureTTL is how long a route service has to send a request back to
+// the route before its signature expires, as in the GoRouter
+const signatureTTL = time.Minute
+
+type signature struct {
+	RequestedTime time.Time `json:"requested_time"`
+	ForwardedURL  string    `json:"forwarded_url"`
+}
+
+type metadata struct {
+	Nonce []byte `json:"nonce"`
+}
+
+// Signer produces and verifies the X-CF-Proxy-Signature and
+// X-CF-Proxy-Metadata headers sent to route services. As in the GoRouter, the
+// signature is the encrypted time of the request and the URL it has to be
+// forwarded to, and the metadata carries the encryption nonce
+type Signer struct {
+	aead cipher.AEAD
+}
+
+func NewSigner(key []byte) (*Signer, error) {
+	aesKey := sha256.Sum256(key)
+	block, err := aes.NewCipher(aesKey[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcm: %w", err)
+	}
+
+	return &Signer{aead: aead}, nil
+}
+
+func (s *Signer) Sign(forwardedURL string, requestedTime time.Time) (string, string, error) {
+	plaintext, err := json.Marshal(signature{
+		RequestedTime: requestedTime,
+		ForwardedURL:  forwardedURL,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal signature: %w", err)
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	metadataJSON, err := json.Marshal(metadata{Nonce: nonce})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	return base64.URLEncoding.EncodeToString(s.aead.Seal(nil, nonce, plaintext, nil)),
+		base64.URLEncoding.EncodeToString(metadataJSON),
+		nil
+}
+
+// Verify checks that the signature was issued by the signer, has not expired
+// by now and was issued for a request to the same host as the forwarded URL.
+// Route services are allowed to change the path and query of the request
+func (s *Signer) Verify(encodedSignature, encodedMetadata, forwardedURL string, now time.Time) error {
+	metadataJSON, err := base64.URLEncoding.DecodeString(encodedMetadata)
+	if err != nil {
+		return fmt.Errorf("failed to decode metadata: %w", err)
+	}
+
+	var meta metadata
+	if err = json.Unmarshal(metadataJSON, &meta); err != nil {
+		return fmt.Errorf("failed to unmarshal metadata: %w", err)
+	}
+
+	if len(meta.Nonce) != s.aead.NonceSize() {
+		return errors.New("invalid nonce")
+	}
+
+	ciphertext, err := base64.URLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	plaintext, err := s.aead.Open(nil, meta.Nonce, ciphertext, nil)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt signature: %w", err)
+	}
+
+	var sig signature
+	if err = json.Unmarshal(plaintext, &sig); err != nil {
+		return fmt.Errorf("failed to unmarshal signature: %w", err)
+	}
+
+	if now.Sub(sig.RequestedTime) > signatureTTL {
+		return errors.New("signature has expired")
+	}
+
+	signedURL, err := url.Parse(sig.ForwardedURL)
+	if err != nil {
+		return fmt.Errorf("invalid signed url: %w", err)
+	}
+
+	requestURL, err := url.Parse(forwardedURL)
+	if err != nil {
+		return fmt.Errorf("invalid request url: %w", err)
+	}
+
+	if signedURL.Host != requestURL.Host {
+		return fmt.Errorf("signature was issued for host %q", signedURL.Host)
+	}
+
+	return nil
+}
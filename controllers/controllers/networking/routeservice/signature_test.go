@@ -0,0 +1,116 @@
+package routeservice_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routeservice"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Signer", func() {
+	var (
+		signer        *routeservice.Signer
+		requestedTime time.Time
+		signature     string
+		metadata      string
+		forwardedURL  string
+		now           time.Time
+		verifyErr     error
+	)
+
+	BeforeEach(func() {
+		var err error
+		signer, err = routeservice.NewSigner([]byte("my-key"))
+		Expect(err).NotTo(HaveOccurred())
+
+		requestedTime = time.Now()
+		now = requestedTime.Add(10 * time.Second)
+		forwardedURL = "https://my-app.example.org/hello?foo=bar"
+
+		signature, metadata, err = signer.Sign("https://my-app.example.org/hello?foo=bar", requestedTime)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		verifyErr = signer.Verify(signature, metadata, forwardedURL, now)
+	})
+
+	It("produces an opaque signature", func() {
+		Expect(signature).NotTo(ContainSubstring("my-app"))
+		Expect(metadata).NotTo(BeEmpty())
+	})
+
+	It("verifies the signature", func() {
+		Expect(verifyErr).NotTo(HaveOccurred())
+	})
+
+	When("the route service changes the path and query of the request", func() {
+		BeforeEach(func() {
+			forwardedURL = "https://my-app.example.org/other?bar=baz"
+		})
+
+		It("verifies the signature", func() {
+			Expect(verifyErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the request is for another host", func() {
+		BeforeEach(func() {
+			forwardedURL = "https://other-app.example.org/hello?foo=bar"
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(MatchError(ContainSubstring("my-app.example.org")))
+		})
+	})
+
+	When("the signature has expired", func() {
+		BeforeEach(func() {
+			now = requestedTime.Add(2 * time.Minute)
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(MatchError("signature has expired"))
+		})
+	})
+
+	When("the signature has been issued with another key", func() {
+		BeforeEach(func() {
+			otherSigner, err := routeservice.NewSigner([]byte("other-key"))
+			Expect(err).NotTo(HaveOccurred())
+
+			signature, metadata, err = otherSigner.Sign(forwardedURL, requestedTime)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(MatchError(ContainSubstring("failed to decrypt signature")))
+		})
+	})
+
+	When("the signature has been tampered with", func() {
+		BeforeEach(func() {
+			if signature[0] == 'A' {
+				signature = "B" + signature[1:]
+			} else {
+				signature = "A" + signature[1:]
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(HaveOccurred())
+		})
+	})
+
+	When("the metadata is missing", func() {
+		BeforeEach(func() {
+			metadata = ""
+		})
+
+		It("returns an error", func() {
+			Expect(verifyErr).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,64 @@
+package routeservice
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const signingKeySecretKey = "key"
+
+// EnsureSigningKey returns the route service signing key from the given
+// Secret, generating the Secret if it does not exist yet. The Secret lives in
+// the Korifi namespace, so it cannot be read by CF users
+func EnsureSigningKey(ctx context.Context, k8sClient client.Client, secretName types.NamespacedName) ([]byte, error) {
+	secret := &corev1.Secret{}
+	err := k8sClient.Get(ctx, secretName, secret)
+	if err == nil {
+		return getSigningKey(secret)
+	}
+
+	if !k8serrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get signing key secret: %w", err)
+	}
+
+	key := make([]byte, 32)
+	if _, err = rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName.Name,
+			Namespace: secretName.Namespace,
+		},
+		Data: map[string][]byte{
+			signingKeySecretKey: key,
+		},
+	}
+	err = k8sClient.Create(ctx, secret)
+	if k8serrors.IsAlreadyExists(err) {
+		// another replica created the secret first
+		return EnsureSigningKey(ctx, k8sClient, secretName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing key secret: %w", err)
+	}
+
+	return key, nil
+}
+
+func getSigningKey(secret *corev1.Secret) ([]byte, error) {
+	key := secret.Data[signingKeySecretKey]
+	if len(key) == 0 {
+		return nil, fmt.Errorf("signing key secret %s/%s has no %q key", secret.Namespace, secret.Name, signingKeySecretKey)
+	}
+
+	return key, nil
+}
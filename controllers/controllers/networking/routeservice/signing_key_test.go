@@ -0,0 +1,103 @@
+package routeservice_test
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routeservice"
+	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("EnsureSigningKey", func() {
+	var (
+		fakeClient    *controllerfake.Client
+		existingKey   []byte
+		getSecretErrs []error
+		createErr     error
+		key           []byte
+		ensureErr     error
+	)
+
+	BeforeEach(func() {
+		existingKey = []byte("existing-key")
+		getSecretErrs = []error{nil}
+		createErr = nil
+
+		fakeClient = new(controllerfake.Client)
+		fakeClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			Expect(key).To(Equal(types.NamespacedName{Namespace: "korifi", Name: "signing-key"}))
+
+			secret, ok := obj.(*corev1.Secret)
+			Expect(ok).To(BeTrue())
+
+			getErr := getSecretErrs[0]
+			getSecretErrs = getSecretErrs[1:]
+			if getErr != nil {
+				return getErr
+			}
+
+			secret.Data = map[string][]byte{"key": existingKey}
+			return nil
+		}
+		fakeClient.CreateReturns(nil)
+	})
+
+	JustBeforeEach(func() {
+		fakeClient.CreateReturns(createErr)
+		key, ensureErr = routeservice.EnsureSigningKey(context.Background(), fakeClient, types.NamespacedName{Namespace: "korifi", Name: "signing-key"})
+	})
+
+	It("returns the existing key", func() {
+		Expect(ensureErr).NotTo(HaveOccurred())
+		Expect(key).To(Equal(existingKey))
+		Expect(fakeClient.CreateCallCount()).To(BeZero())
+	})
+
+	When("the secret does not exist", func() {
+		BeforeEach(func() {
+			getSecretErrs = []error{k8serrors.NewNotFound(schema.GroupResource{}, "signing-key")}
+		})
+
+		It("generates the key", func() {
+			Expect(ensureErr).NotTo(HaveOccurred())
+			Expect(key).To(HaveLen(32))
+
+			Expect(fakeClient.CreateCallCount()).To(Equal(1))
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			secret, ok := obj.(*corev1.Secret)
+			Expect(ok).To(BeTrue())
+			Expect(secret.Namespace).To(Equal("korifi"))
+			Expect(secret.Name).To(Equal("signing-key"))
+			Expect(secret.Data).To(HaveKeyWithValue("key", key))
+		})
+
+		When("another replica creates the secret first", func() {
+			BeforeEach(func() {
+				getSecretErrs = []error{k8serrors.NewNotFound(schema.GroupResource{}, "signing-key"), nil}
+				createErr = k8serrors.NewAlreadyExists(schema.GroupResource{}, "signing-key")
+			})
+
+			It("returns the key of the other replica", func() {
+				Expect(ensureErr).NotTo(HaveOccurred())
+				Expect(key).To(Equal(existingKey))
+			})
+		})
+	})
+
+	When("the secret has no key", func() {
+		BeforeEach(func() {
+			existingKey = nil
+		})
+
+		It("returns an error", func() {
+			Expect(ensureErr).To(MatchError(ContainSubstring(`has no "key" key`)))
+		})
+	})
+})
@@ -0,0 +1,248 @@
+package securitygroups
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type Reconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	log    logr.Logger
+}
+
+func NewReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFSecurityGroup] {
+	securityGroupReconciler := Reconciler{client: client, scheme: scheme, log: log}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFSecurityGroup](log, client, &securityGroupReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFSecurityGroup{}).
+		Watches(
+			&korifiv1alpha1.CFSpace{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequests),
+		)
+}
+
+func (r *Reconciler) enqueueCFSpaceRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	cfSpace, ok := o.(*korifiv1alpha1.CFSpace)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	securityGroups := korifiv1alpha1.CFSecurityGroupList{}
+	if err := r.client.List(ctx, &securityGroups); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, securityGroup := range securityGroups.Items {
+		workloads := securityGroupWorkloadsForSpace(securityGroup, cfSpace.Name)
+		if !workloads.Running && !workloads.Staging {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      securityGroup.Name,
+				Namespace: securityGroup.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups,verbs=get;list;watch;create;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups/status,verbs=get;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups/finalizers,verbs=update
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=get;list;watch
+
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;patch;delete
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !cfSecurityGroup.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, r.finalizeCFSecurityGroup(ctx, cfSecurityGroup)
+	}
+
+	cfSecurityGroup.Status.ObservedGeneration = cfSecurityGroup.Generation
+	log.V(1).Info("set observed generation", "generation", cfSecurityGroup.Status.ObservedGeneration)
+
+	spaces := korifiv1alpha1.CFSpaceList{}
+	if err := r.client.List(ctx, &spaces); err != nil {
+		log.Info("failed to list CFSpaces", "reason", err)
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ListSpaces")
+	}
+
+	egressRules := toEgressRules(ctx, cfSecurityGroup.Spec.Rules)
+	if len(egressRules) == 0 {
+		// a policy without egress rules would block all egress of the
+		// selected workloads, DNS included
+		log.Info("security group has no rules that can be enforced, skipping network policies")
+	}
+
+	desiredPolicies := map[types.NamespacedName]bool{}
+	for _, cfSpace := range spaces.Items {
+		if cfSpace.Status.GUID == "" || len(egressRules) == 0 {
+			continue
+		}
+
+		workloads := securityGroupWorkloadsForSpace(*cfSecurityGroup, cfSpace.Name)
+
+		if workloads.Running {
+			policy, err := r.createOrPatchNetworkPolicy(ctx, cfSecurityGroup, cfSpace.Status.GUID, korifiv1alpha1.SecurityGroupRunningWorkloads, egressRules)
+			if err != nil {
+				return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileNetworkPolicy")
+			}
+			desiredPolicies[client.ObjectKeyFromObject(policy)] = true
+		}
+
+		if workloads.Staging {
+			policy, err := r.createOrPatchNetworkPolicy(ctx, cfSecurityGroup, cfSpace.Status.GUID, korifiv1alpha1.SecurityGroupStagingWorkloads, egressRules)
+			if err != nil {
+				return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileNetworkPolicy")
+			}
+			desiredPolicies[client.ObjectKeyFromObject(policy)] = true
+		}
+	}
+
+	if err := r.deleteOrphanedNetworkPolicies(ctx, cfSecurityGroup, desiredPolicies); err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("DeleteOrphanedNetworkPolicies")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) finalizeCFSecurityGroup(ctx context.Context, cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCFSecurityGroup")
+
+	if !controllerutil.ContainsFinalizer(cfSecurityGroup, korifiv1alpha1.CFSecurityGroupFinalizerName) {
+		return nil
+	}
+
+	if err := r.deleteOrphanedNetworkPolicies(ctx, cfSecurityGroup, map[types.NamespacedName]bool{}); err != nil {
+		log.Info("failed to delete network policies", "reason", err)
+		return err
+	}
+
+	if controllerutil.RemoveFinalizer(cfSecurityGroup, korifiv1alpha1.CFSecurityGroupFinalizerName) {
+		log.V(1).Info("finalizer removed")
+	}
+
+	return nil
+}
+
+func (r *Reconciler) createOrPatchNetworkPolicy(
+	ctx context.Context,
+	cfSecurityGroup *korifiv1alpha1.CFSecurityGroup,
+	namespace string,
+	workloads string,
+	egressRules []networkingv1.NetworkPolicyEgressRule,
+) (*networkingv1.NetworkPolicy, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchNetworkPolicy").WithValues("namespace", namespace, "workloads", workloads)
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", cfSecurityGroup.Name, workloads),
+			Namespace: namespace,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, networkPolicy, func() error {
+		if networkPolicy.Labels == nil {
+			networkPolicy.Labels = map[string]string{}
+		}
+		networkPolicy.Labels[korifiv1alpha1.CFSecurityGroupGUIDLabelKey] = cfSecurityGroup.Name
+		networkPolicy.Labels[korifiv1alpha1.CFSecurityGroupWorkloadsLabelKey] = workloads
+
+		networkPolicy.Spec.PodSelector = workloadsPodSelector(workloads)
+		networkPolicy.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}
+		networkPolicy.Spec.Egress = egressRules
+
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to create/patch NetworkPolicy", "reason", err)
+		return nil, err
+	}
+
+	log.V(1).Info("NetworkPolicy reconciled", "operation", result)
+	return networkPolicy, nil
+}
+
+func (r *Reconciler) deleteOrphanedNetworkPolicies(
+	ctx context.Context,
+	cfSecurityGroup *korifiv1alpha1.CFSecurityGroup,
+	desiredPolicies map[types.NamespacedName]bool,
+) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("deleteOrphanedNetworkPolicies")
+
+	networkPolicies := networkingv1.NetworkPolicyList{}
+	err := r.client.List(ctx, &networkPolicies, client.MatchingLabels{
+		korifiv1alpha1.CFSecurityGroupGUIDLabelKey: cfSecurityGroup.Name,
+	})
+	if err != nil {
+		log.Info("failed to list network policies", "reason", err)
+		return err
+	}
+
+	for i := range networkPolicies.Items {
+		if desiredPolicies[client.ObjectKeyFromObject(&networkPolicies.Items[i])] {
+			continue
+		}
+
+		err = r.client.Delete(ctx, &networkPolicies.Items[i])
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete network policy", "name", networkPolicies.Items[i].Name, "namespace", networkPolicies.Items[i].Namespace, "reason", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func securityGroupWorkloadsForSpace(cfSecurityGroup korifiv1alpha1.CFSecurityGroup, spaceGUID string) korifiv1alpha1.SecurityGroupWorkloads {
+	spaceWorkloads := cfSecurityGroup.Spec.Spaces[spaceGUID]
+
+	return korifiv1alpha1.SecurityGroupWorkloads{
+		Running: cfSecurityGroup.Spec.GloballyEnabled.Running || spaceWorkloads.Running,
+		Staging: cfSecurityGroup.Spec.GloballyEnabled.Staging || spaceWorkloads.Staging,
+	}
+}
+
+func workloadsPodSelector(workloads string) metav1.LabelSelector {
+	labelKey := korifiv1alpha1.CFAppGUIDLabelKey
+	if workloads == korifiv1alpha1.SecurityGroupStagingWorkloads {
+		labelKey = korifiv1alpha1.BuildWorkloadLabelKey
+	}
+
+	return metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      labelKey,
+			Operator: metav1.LabelSelectorOpExists,
+		}},
+	}
+}
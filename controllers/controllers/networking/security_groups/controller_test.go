@@ -0,0 +1,273 @@
+package securitygroups_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFSecurityGroupReconciler Integration Tests", func() {
+	var (
+		cfSpace         *korifiv1alpha1.CFSpace
+		cfSecurityGroup *korifiv1alpha1.CFSecurityGroup
+	)
+
+	createSpace := func() *korifiv1alpha1.CFSpace {
+		space := &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFSpaceSpec{
+				DisplayName: uuid.NewString(),
+			},
+		}
+		helpers.EnsureCreate(adminClient, space)
+
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: space.Name,
+			},
+		})).To(Succeed())
+
+		helpers.EnsurePatch(adminClient, space, func(s *korifiv1alpha1.CFSpace) {
+			s.Status.GUID = s.Name
+		})
+
+		return space
+	}
+
+	networkPolicyName := func(workloads string) string {
+		return cfSecurityGroup.Name + "-" + workloads
+	}
+
+	BeforeEach(func() {
+		cfSpace = createSpace()
+
+		cfSecurityGroup = &korifiv1alpha1.CFSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace,
+				Finalizers: []string{
+					korifiv1alpha1.CFSecurityGroupFinalizerName,
+				},
+			},
+			Spec: korifiv1alpha1.CFSecurityGroupSpec{
+				DisplayName: uuid.NewString(),
+				Rules: []korifiv1alpha1.SecurityGroupRule{
+					{
+						Protocol:    korifiv1alpha1.ProtocolTCP,
+						Destination: "10.0.0.1",
+						Ports:       "80,443",
+					},
+					{
+						Protocol:    korifiv1alpha1.ProtocolUDP,
+						Destination: "192.168.0.0/16",
+						Ports:       "1000-2000",
+					},
+					{
+						Protocol:    korifiv1alpha1.ProtocolALL,
+						Destination: "10.0.1.0-10.0.1.4",
+					},
+					{
+						Protocol:    korifiv1alpha1.ProtocolICMP,
+						Destination: "10.0.2.0/24",
+						Type:        8,
+					},
+				},
+				Spaces: map[string]korifiv1alpha1.SecurityGroupWorkloads{
+					cfSpace.Name: {Running: true},
+				},
+			},
+		}
+		helpers.EnsureCreate(adminClient, cfSecurityGroup)
+	})
+
+	It("sets the security group Ready status", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+			g.Expect(meta.IsStatusConditionTrue(cfSecurityGroup.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			g.Expect(cfSecurityGroup.Status.ObservedGeneration).To(Equal(cfSecurityGroup.Generation))
+		}).Should(Succeed())
+	})
+
+	It("creates a running workloads network policy in the bound space", func() {
+		Eventually(func(g Gomega) {
+			networkPolicy := &networkingv1.NetworkPolicy{}
+			g.Expect(adminClient.Get(ctx, client.ObjectKey{
+				Namespace: cfSpace.Name,
+				Name:      networkPolicyName(korifiv1alpha1.SecurityGroupRunningWorkloads),
+			}, networkPolicy)).To(Succeed())
+
+			g.Expect(networkPolicy.Labels).To(SatisfyAll(
+				HaveKeyWithValue(korifiv1alpha1.CFSecurityGroupGUIDLabelKey, cfSecurityGroup.Name),
+				HaveKeyWithValue(korifiv1alpha1.CFSecurityGroupWorkloadsLabelKey, korifiv1alpha1.SecurityGroupRunningWorkloads),
+			))
+			g.Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+			g.Expect(networkPolicy.Spec.PodSelector).To(Equal(metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      korifiv1alpha1.CFAppGUIDLabelKey,
+					Operator: metav1.LabelSelectorOpExists,
+				}},
+			}))
+
+			g.Expect(networkPolicy.Spec.Egress).To(ConsistOf(
+				networkingv1.NetworkPolicyEgressRule{
+					To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}}},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: tools.PtrTo(corev1.ProtocolTCP), Port: tools.PtrTo(intstr.FromInt32(80))},
+						{Protocol: tools.PtrTo(corev1.ProtocolTCP), Port: tools.PtrTo(intstr.FromInt32(443))},
+					},
+				},
+				networkingv1.NetworkPolicyEgressRule{
+					To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.0/16"}}},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: tools.PtrTo(corev1.ProtocolUDP), Port: tools.PtrTo(intstr.FromInt32(1000)), EndPort: tools.PtrTo[int32](2000)},
+					},
+				},
+				networkingv1.NetworkPolicyEgressRule{
+					To: []networkingv1.NetworkPolicyPeer{
+						{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.1.0/30"}},
+						{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.1.4/32"}},
+					},
+				},
+			))
+		}).Should(Succeed())
+	})
+
+	It("does not create a staging workloads network policy", func() {
+		Consistently(func(g Gomega) {
+			err := adminClient.Get(ctx, client.ObjectKey{
+				Namespace: cfSpace.Name,
+				Name:      networkPolicyName(korifiv1alpha1.SecurityGroupStagingWorkloads),
+			}, &networkingv1.NetworkPolicy{})
+			g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	When("the security group is bound for staging", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, cfSecurityGroup, func(sg *korifiv1alpha1.CFSecurityGroup) {
+				sg.Spec.Spaces[cfSpace.Name] = korifiv1alpha1.SecurityGroupWorkloads{Staging: true}
+			})
+		})
+
+		It("creates a staging workloads network policy targeting build pods", func() {
+			Eventually(func(g Gomega) {
+				networkPolicy := &networkingv1.NetworkPolicy{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{
+					Namespace: cfSpace.Name,
+					Name:      networkPolicyName(korifiv1alpha1.SecurityGroupStagingWorkloads),
+				}, networkPolicy)).To(Succeed())
+
+				g.Expect(networkPolicy.Spec.PodSelector).To(Equal(metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      korifiv1alpha1.BuildWorkloadLabelKey,
+						Operator: metav1.LabelSelectorOpExists,
+					}},
+				}))
+			}).Should(Succeed())
+		})
+
+		It("deletes the running workloads network policy", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKey{
+					Namespace: cfSpace.Name,
+					Name:      networkPolicyName(korifiv1alpha1.SecurityGroupRunningWorkloads),
+				}, &networkingv1.NetworkPolicy{})
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+
+	When("the security group only has rules that cannot be enforced", func() {
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{
+					Namespace: cfSpace.Name,
+					Name:      networkPolicyName(korifiv1alpha1.SecurityGroupRunningWorkloads),
+				}, &networkingv1.NetworkPolicy{})).To(Succeed())
+			}).Should(Succeed())
+
+			helpers.EnsurePatch(adminClient, cfSecurityGroup, func(sg *korifiv1alpha1.CFSecurityGroup) {
+				sg.Spec.Rules = []korifiv1alpha1.SecurityGroupRule{{
+					Protocol:    korifiv1alpha1.ProtocolICMP,
+					Destination: "10.0.2.0/24",
+					Type:        8,
+				}}
+			})
+		})
+
+		It("deletes the network policy instead of blocking all egress", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKey{
+					Namespace: cfSpace.Name,
+					Name:      networkPolicyName(korifiv1alpha1.SecurityGroupRunningWorkloads),
+				}, &networkingv1.NetworkPolicy{})
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+
+	When("the security group is globally enabled", func() {
+		var anotherSpace *korifiv1alpha1.CFSpace
+
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, cfSecurityGroup, func(sg *korifiv1alpha1.CFSecurityGroup) {
+				sg.Spec.GloballyEnabled = korifiv1alpha1.SecurityGroupWorkloads{Running: true}
+			})
+
+			anotherSpace = createSpace()
+		})
+
+		It("creates network policies in spaces created afterwards", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{
+					Namespace: anotherSpace.Name,
+					Name:      networkPolicyName(korifiv1alpha1.SecurityGroupRunningWorkloads),
+				}, &networkingv1.NetworkPolicy{})).To(Succeed())
+			}).Should(Succeed())
+		})
+	})
+
+	When("the security group is deleted", func() {
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{
+					Namespace: cfSpace.Name,
+					Name:      networkPolicyName(korifiv1alpha1.SecurityGroupRunningWorkloads),
+				}, &networkingv1.NetworkPolicy{})).To(Succeed())
+			}).Should(Succeed())
+
+			Expect(adminClient.Delete(ctx, cfSecurityGroup)).To(Succeed())
+		})
+
+		It("deletes its network policies", func() {
+			Eventually(func(g Gomega) {
+				networkPolicies := &networkingv1.NetworkPolicyList{}
+				g.Expect(adminClient.List(ctx, networkPolicies, client.MatchingLabels{
+					korifiv1alpha1.CFSecurityGroupGUIDLabelKey: cfSecurityGroup.Name,
+				})).To(Succeed())
+				g.Expect(networkPolicies.Items).To(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		It("deletes the security group", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+})
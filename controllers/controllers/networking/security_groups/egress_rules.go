@@ -0,0 +1,185 @@
+package securitygroups
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// toEgressRules translates CF security group rules into NetworkPolicy egress
+// rules. NetworkPolicies cannot express ICMP, so ICMP rules are skipped rather
+// than being widened into an allow-all rule.
+func toEgressRules(ctx context.Context, rules []korifiv1alpha1.SecurityGroupRule) []networkingv1.NetworkPolicyEgressRule {
+	log := logr.FromContextOrDiscard(ctx).WithName("toEgressRules")
+
+	egressRules := []networkingv1.NetworkPolicyEgressRule{}
+	for i, rule := range rules {
+		if rule.Protocol == korifiv1alpha1.ProtocolICMP || rule.Protocol == korifiv1alpha1.ProtocolICMPv6 {
+			log.V(1).Info("skipping rule with unsupported protocol", "index", i, "protocol", rule.Protocol)
+			continue
+		}
+
+		peers, err := toPeers(rule.Destination)
+		if err != nil {
+			log.Info("skipping rule with invalid destination", "index", i, "reason", err)
+			continue
+		}
+
+		ports, err := toPorts(rule.Protocol, rule.Ports)
+		if err != nil {
+			log.Info("skipping rule with invalid ports", "index", i, "reason", err)
+			continue
+		}
+
+		egressRules = append(egressRules, networkingv1.NetworkPolicyEgressRule{
+			To:    peers,
+			Ports: ports,
+		})
+	}
+
+	return egressRules
+}
+
+func toPeers(destination string) ([]networkingv1.NetworkPolicyPeer, error) {
+	peers := []networkingv1.NetworkPolicyPeer{}
+
+	for dest := range strings.SplitSeq(destination, ",") {
+		cidrs, err := toCIDRs(strings.TrimSpace(dest))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cidr := range cidrs {
+			peers = append(peers, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+			})
+		}
+	}
+
+	return peers, nil
+}
+
+func toCIDRs(destination string) ([]string, error) {
+	if addr, err := netip.ParseAddr(destination); err == nil {
+		return []string{netip.PrefixFrom(addr, addr.BitLen()).String()}, nil
+	}
+
+	if prefix, err := netip.ParsePrefix(destination); err == nil {
+		return []string{prefix.Masked().String()}, nil
+	}
+
+	start, end, found := strings.Cut(destination, "-")
+	if !found {
+		return nil, fmt.Errorf("invalid destination %q", destination)
+	}
+
+	startAddr, err := netip.ParseAddr(start)
+	if err != nil || !startAddr.Is4() {
+		return nil, fmt.Errorf("invalid destination range start %q", start)
+	}
+
+	endAddr, err := netip.ParseAddr(end)
+	if err != nil || !endAddr.Is4() {
+		return nil, fmt.Errorf("invalid destination range end %q", end)
+	}
+
+	if endAddr.Less(startAddr) {
+		startAddr, endAddr = endAddr, startAddr
+	}
+
+	return ipv4RangeToCIDRs(startAddr, endAddr), nil
+}
+
+// ipv4RangeToCIDRs returns the smallest set of CIDRs covering the inclusive
+// range between start and end
+func ipv4RangeToCIDRs(start, end netip.Addr) []string {
+	startBytes := start.As4()
+	endBytes := end.As4()
+	current := uint64(binary.BigEndian.Uint32(startBytes[:]))
+	last := uint64(binary.BigEndian.Uint32(endBytes[:]))
+
+	cidrs := []string{}
+	for current <= last {
+		hostBits := 32
+		if current != 0 {
+			hostBits = bits.TrailingZeros32(uint32(current))
+		}
+
+		for hostBits > 0 && current+(uint64(1)<<hostBits)-1 > last {
+			hostBits--
+		}
+
+		var addrBytes [4]byte
+		binary.BigEndian.PutUint32(addrBytes[:], uint32(current))
+		cidrs = append(cidrs, netip.PrefixFrom(netip.AddrFrom4(addrBytes), 32-hostBits).String())
+
+		current += uint64(1) << hostBits
+	}
+
+	return cidrs
+}
+
+func toPorts(protocol, ports string) ([]networkingv1.NetworkPolicyPort, error) {
+	if protocol == korifiv1alpha1.ProtocolALL {
+		return nil, nil
+	}
+
+	k8sProtocol := corev1.ProtocolTCP
+	if protocol == korifiv1alpha1.ProtocolUDP {
+		k8sProtocol = corev1.ProtocolUDP
+	}
+
+	if start, end, found := strings.Cut(ports, "-"); found {
+		startPort, err := parsePort(start)
+		if err != nil {
+			return nil, err
+		}
+
+		endPort, err := parsePort(end)
+		if err != nil {
+			return nil, err
+		}
+
+		return []networkingv1.NetworkPolicyPort{{
+			Protocol: tools.PtrTo(k8sProtocol),
+			Port:     tools.PtrTo(intstr.FromInt32(startPort)),
+			EndPort:  tools.PtrTo(endPort),
+		}}, nil
+	}
+
+	policyPorts := []networkingv1.NetworkPolicyPort{}
+	for port := range strings.SplitSeq(ports, ",") {
+		portNumber, err := parsePort(port)
+		if err != nil {
+			return nil, err
+		}
+
+		policyPorts = append(policyPorts, networkingv1.NetworkPolicyPort{
+			Protocol: tools.PtrTo(k8sProtocol),
+			Port:     tools.PtrTo(intstr.FromInt32(portNumber)),
+		})
+	}
+
+	return policyPorts, nil
+}
+
+func parsePort(port string) (int32, error) {
+	portNumber, err := strconv.ParseInt(strings.TrimSpace(port), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %q: %w", port, err)
+	}
+
+	return int32(portNumber), nil
+}
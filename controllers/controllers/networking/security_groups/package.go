@@ -1,3 +0,0 @@
-package securitygroups
-
-//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups,verbs=create;
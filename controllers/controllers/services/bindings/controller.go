@@ -75,9 +75,9 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 func (r *Reconciler) serviceInstanceToServiceBindings(ctx context.Context, o client.Object) []reconcile.Request {
 	serviceInstance := o.(*korifiv1alpha1.CFServiceInstance)
 
+	// Bindings to shared service instances live in the namespaces of the spaces the instance is shared with
 	serviceBindings := korifiv1alpha1.CFServiceBindingList{}
 	if err := r.k8sClient.List(ctx, &serviceBindings,
-		client.InNamespace(serviceInstance.Namespace),
 		client.MatchingFields{shared.IndexServiceBindingServiceInstanceGUID: serviceInstance.Name},
 	); err != nil {
 		return []reconcile.Request{}
@@ -107,7 +107,7 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBinding *ko
 	log.V(1).Info("set observed generation", "generation", cfServiceBinding.Status.ObservedGeneration)
 
 	cfServiceInstance := new(korifiv1alpha1.CFServiceInstance)
-	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, cfServiceInstance)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.ServiceInstanceNamespace()}, cfServiceInstance)
 	if err != nil {
 		log.Info("service instance not found", "service-instance", cfServiceBinding.Spec.Service.Name, "error", err)
 		return ctrl.Result{}, err
@@ -273,7 +273,7 @@ func (r *ManagedBindingsReconciler) finalizeCFServiceBinding(
 ) error {
 	serviceInstance := &korifiv1alpha1.CFServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: serviceBinding.Namespace,
+			Namespace: serviceBinding.ServiceInstanceNamespace(),
 			Name:      serviceBinding.Spec.Service.Name,
 		},
 	}
@@ -0,0 +1,248 @@
+package drains
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains/syslog"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// WriterFactory creates the syslog writer for a drain url
+type WriterFactory func(drainURL string) (syslog.Writer, error)
+
+// Reconciler forwards the logs of apps bound to user-provided service
+// instances with a syslog drain url to that drain. Every such app binding gets
+// a forwarder that runs until the binding is deleted or the service instance
+// no longer has a drain url.
+//
+// Unlike most korifi reconcilers this one does not use the PatchingReconciler
+// as it does not own any of the resources it watches and therefore must not
+// write their status.
+type Reconciler struct {
+	k8sClient     client.Client
+	clientset     kubernetes.Interface
+	logStreamer   LogStreamer
+	writerFactory WriterFactory
+	log           logr.Logger
+
+	ctx        context.Context
+	cancel     context.CancelFunc
+	mu         sync.Mutex
+	forwarders map[types.NamespacedName]*forwarder
+}
+
+func NewReconciler(
+	k8sClient client.Client,
+	clientset kubernetes.Interface,
+	logStreamer LogStreamer,
+	writerFactory WriterFactory,
+	log logr.Logger,
+) *Reconciler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Reconciler{
+		k8sClient:     k8sClient,
+		clientset:     clientset,
+		logStreamer:   logStreamer,
+		writerFactory: writerFactory,
+		log:           log.WithName("SyslogDrain"),
+		ctx:           ctx,
+		cancel:        cancel,
+		forwarders:    map[types.NamespacedName]*forwarder{},
+	}
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.Add(r); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cfservicebinding-syslog-drain").
+		For(&korifiv1alpha1.CFServiceBinding{}).
+		Watches(
+			&korifiv1alpha1.CFServiceInstance{},
+			handler.EnqueueRequestsFromMapFunc(r.serviceInstanceToServiceBindings),
+		).
+		Complete(r)
+}
+
+// Start blocks until the manager is stopped and then stops all forwarders
+func (r *Reconciler) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cancel()
+	for key, f := range r.forwarders {
+		f.stop()
+		delete(r.forwarders, key)
+	}
+
+	return nil
+}
+
+// NeedLeaderElection makes sure logs are only forwarded by the leader
+func (r *Reconciler) NeedLeaderElection() bool {
+	return true
+}
+
+func (r *Reconciler) serviceInstanceToServiceBindings(ctx context.Context, o client.Object) []reconcile.Request {
+	serviceInstance := o.(*korifiv1alpha1.CFServiceInstance)
+
+	serviceBindings := korifiv1alpha1.CFServiceBindingList{}
+	if err := r.k8sClient.List(ctx, &serviceBindings,
+		client.InNamespace(serviceInstance.Namespace),
+		client.MatchingFields{shared.IndexServiceBindingServiceInstanceGUID: serviceInstance.Name},
+	); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, sb := range serviceBindings.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      sb.Name,
+				Namespace: sb.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("namespace", req.Namespace, "name", req.Name)
+	ctx = logr.NewContext(ctx, log)
+
+	desiredDrain, err := r.desiredDrain(ctx, req.NamespacedName)
+	if err != nil {
+		log.Info("failed to determine syslog drain", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.ctx.Err() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	existingForwarder, forwarding := r.forwarders[req.NamespacedName]
+	if forwarding && desiredDrain != nil && existingForwarder.drain == *desiredDrain {
+		return ctrl.Result{}, nil
+	}
+
+	if forwarding {
+		log.V(1).Info("stopping log forwarding", "drain", existingForwarder.drain.url)
+		existingForwarder.stop()
+		delete(r.forwarders, req.NamespacedName)
+	}
+
+	if desiredDrain == nil {
+		return ctrl.Result{}, nil
+	}
+
+	writer, err := r.writerFactory(desiredDrain.url)
+	if err != nil {
+		log.Info("invalid syslog drain", "reason", err)
+		return ctrl.Result{}, nil
+	}
+
+	log.V(1).Info("starting log forwarding", "drain", desiredDrain.url)
+	r.forwarders[req.NamespacedName] = startForwarder(
+		logr.NewContext(r.ctx, log),
+		r.clientset,
+		r.logStreamer,
+		writer,
+		*desiredDrain,
+	)
+
+	return ctrl.Result{}, nil
+}
+
+// desiredDrain returns the drain the logs of the bound app should be forwarded
+// to, or nil if there is no such drain
+func (r *Reconciler) desiredDrain(ctx context.Context, bindingKey types.NamespacedName) (*drain, error) {
+	serviceBinding := &korifiv1alpha1.CFServiceBinding{}
+	err := r.k8sClient.Get(ctx, bindingKey, serviceBinding)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	if !serviceBinding.DeletionTimestamp.IsZero() || serviceBinding.Spec.Type != korifiv1alpha1.CFServiceBindingTypeApp {
+		return nil, nil
+	}
+
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	err = r.k8sClient.Get(ctx, types.NamespacedName{
+		Namespace: serviceBinding.ServiceInstanceNamespace(),
+		Name:      serviceBinding.Spec.Service.Name,
+	}, serviceInstance)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	if serviceInstance.Spec.Type != korifiv1alpha1.UserProvidedType || serviceInstance.Spec.SyslogDrainURL == "" {
+		return nil, nil
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	err = r.k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.Namespace, Name: serviceBinding.Spec.AppRef.Name}, cfApp)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+
+	hostname, err := r.hostname(ctx, cfApp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &drain{
+		url:       serviceInstance.Spec.SyslogDrainURL,
+		namespace: cfApp.Namespace,
+		appGUID:   cfApp.Name,
+		hostname:  hostname,
+	}, nil
+}
+
+// hostname identifies the app in the syslog messages the same way CF for VMs
+// does, i.e. "<org-name>.<space-name>.<app-name>"
+func (r *Reconciler) hostname(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (string, error) {
+	spaces := korifiv1alpha1.CFSpaceList{}
+	if err := r.k8sClient.List(ctx, &spaces, client.MatchingFields{
+		shared.IndexSpaceNamespaceName: cfApp.Namespace,
+	}); err != nil {
+		return "", fmt.Errorf("error listing cfSpaces: %w", err)
+	}
+	if len(spaces.Items) != 1 {
+		return "", fmt.Errorf("expected a unique CFSpace for namespace %q, got %d", cfApp.Namespace, len(spaces.Items))
+	}
+
+	orgs := korifiv1alpha1.CFOrgList{}
+	if err := r.k8sClient.List(ctx, &orgs, client.MatchingFields{
+		shared.IndexOrgNamespaceName: spaces.Items[0].Namespace,
+	}); err != nil {
+		return "", fmt.Errorf("error listing cfOrgs: %w", err)
+	}
+	if len(orgs.Items) != 1 {
+		return "", fmt.Errorf("expected a unique CFOrg for namespace %q, got %d", spaces.Items[0].Namespace, len(orgs.Items))
+	}
+
+	return fmt.Sprintf("%s.%s.%s", orgs.Items[0].Spec.DisplayName, spaces.Items[0].Spec.DisplayName, cfApp.Spec.DisplayName), nil
+}
@@ -0,0 +1,243 @@
+package drains_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains/syslog"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var _ = Describe("CFServiceBinding Syslog Drains", func() {
+	var (
+		cfOrg           *korifiv1alpha1.CFOrg
+		cfSpace         *korifiv1alpha1.CFSpace
+		cfApp           *korifiv1alpha1.CFApp
+		serviceInstance *korifiv1alpha1.CFServiceInstance
+		binding         *korifiv1alpha1.CFServiceBinding
+		pod             *corev1.Pod
+		logTime         time.Time
+	)
+
+	BeforeEach(func() {
+		rootNamespace := uuid.NewString()
+		createNamespace(rootNamespace)
+
+		cfOrg = &korifiv1alpha1.CFOrg{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFOrgSpec{
+				DisplayName: "my-org",
+			},
+		}
+		helpers.EnsureCreate(adminClient, cfOrg)
+		orgNSName := uuid.NewString()
+		helpers.EnsurePatch(adminClient, cfOrg, func(cfOrg *korifiv1alpha1.CFOrg) {
+			cfOrg.Status.GUID = orgNSName
+		})
+		createNamespace(orgNSName)
+
+		cfSpace = &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: orgNSName,
+			},
+			Spec: korifiv1alpha1.CFSpaceSpec{
+				DisplayName: "my-space",
+			},
+		}
+		helpers.EnsureCreate(adminClient, cfSpace)
+		spaceNSName := uuid.NewString()
+		helpers.EnsurePatch(adminClient, cfSpace, func(cfSpace *korifiv1alpha1.CFSpace) {
+			cfSpace.Status.GUID = spaceNSName
+		})
+		createNamespace(spaceNSName)
+
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: spaceNSName,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  "my-app",
+				DesiredState: korifiv1alpha1.StartedState,
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+			},
+		}
+		helpers.EnsureCreate(adminClient, cfApp)
+
+		serviceInstance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: spaceNSName,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFServiceInstanceSpec{
+				DisplayName:    "my-drain",
+				Type:           korifiv1alpha1.UserProvidedType,
+				SyslogDrainURL: "syslog-tls://logs.example.com:6514",
+			},
+		}
+		helpers.EnsureCreate(adminClient, serviceInstance)
+
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: spaceNSName,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+					korifiv1alpha1.CFProcessTypeLabelKey: "web",
+					korifiv1alpha1.PodIndexLabelKey:      "0",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  "application",
+					Image: "dont/care",
+				}},
+			},
+		}
+		helpers.EnsureCreate(adminClient, pod)
+		helpers.EnsurePatch(adminClient, pod, func(pod *corev1.Pod) {
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				Name: "application",
+			}}
+		})
+
+		logTime = time.Now().Add(time.Hour).UTC()
+		logStreamer.Stub = func(_ context.Context, _ kubernetes.Interface, _ corev1.Pod, _ corev1.PodLogOptions) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(fmt.Sprintf("%s hello\n", logTime.Format(time.RFC3339Nano)))), nil
+		}
+
+		binding = &korifiv1alpha1.CFServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: spaceNSName,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFServiceBindingSpec{
+				Service: corev1.ObjectReference{
+					Kind:       "CFServiceInstance",
+					APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+					Name:       serviceInstance.Name,
+				},
+				AppRef: corev1.LocalObjectReference{
+					Name: cfApp.Name,
+				},
+				Type: korifiv1alpha1.CFServiceBindingTypeApp,
+			},
+		}
+		helpers.EnsureCreate(adminClient, binding)
+	})
+
+	It("creates a writer for the drain", func() {
+		Eventually(drainURLs).Should(Receive(Equal("syslog-tls://logs.example.com:6514")))
+	})
+
+	It("follows the app pod logs", func() {
+		Eventually(logStreamer.CallCount).Should(BeNumerically(">=", 1))
+		_, _, actualPod, actualLogOptions := logStreamer.ArgsForCall(0)
+		Expect(actualPod.Name).To(Equal(pod.Name))
+		Expect(actualLogOptions.Container).To(Equal("application"))
+		Expect(actualLogOptions.Follow).To(BeTrue())
+		Expect(actualLogOptions.Timestamps).To(BeTrue())
+	})
+
+	It("forwards the logs to the drain", func() {
+		Eventually(syslogWriter.WriteCallCount).Should(BeNumerically(">=", 1))
+		_, message := syslogWriter.WriteArgsForCall(0)
+		Expect(message).To(MatchAllFields(Fields{
+			"Timestamp": BeTemporally("==", logTime),
+			"Severity":  Equal(syslog.SeverityInfo),
+			"Hostname":  Equal("my-org.my-space.my-app"),
+			"AppName":   Equal(cfApp.Name),
+			"ProcessID": Equal("[APP/PROC/WEB/0]"),
+			"Body":      Equal("hello"),
+		}))
+	})
+
+	When("the binding is deleted", func() {
+		JustBeforeEach(func() {
+			Eventually(logStreamer.CallCount).Should(BeNumerically(">=", 1))
+			helpers.EnsureDelete(adminClient, binding)
+		})
+
+		It("stops forwarding the logs", func() {
+			Eventually(syslogWriter.CloseCallCount).Should(Equal(1))
+		})
+	})
+
+	When("the drain url is removed from the service instance", func() {
+		JustBeforeEach(func() {
+			Eventually(logStreamer.CallCount).Should(BeNumerically(">=", 1))
+			helpers.EnsurePatch(adminClient, serviceInstance, func(si *korifiv1alpha1.CFServiceInstance) {
+				si.Spec.SyslogDrainURL = ""
+			})
+		})
+
+		It("stops forwarding the logs", func() {
+			Eventually(syslogWriter.CloseCallCount).Should(Equal(1))
+		})
+	})
+
+	When("the drain url changes", func() {
+		JustBeforeEach(func() {
+			Eventually(drainURLs).Should(Receive())
+			helpers.EnsurePatch(adminClient, serviceInstance, func(si *korifiv1alpha1.CFServiceInstance) {
+				si.Spec.SyslogDrainURL = "https://logs.example.com/drain"
+			})
+		})
+
+		It("forwards the logs to the new drain", func() {
+			Eventually(drainURLs).Should(Receive(Equal("https://logs.example.com/drain")))
+			Eventually(syslogWriter.CloseCallCount).Should(Equal(1))
+		})
+	})
+
+	When("the service instance has no drain url", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, serviceInstance, func(si *korifiv1alpha1.CFServiceInstance) {
+				si.Spec.SyslogDrainURL = ""
+			})
+		})
+
+		It("does not forward logs", func() {
+			Consistently(drainURLs).ShouldNot(Receive())
+			Expect(logStreamer.CallCount()).To(BeZero())
+		})
+	})
+
+	When("the binding is a service key", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, binding, func(b *korifiv1alpha1.CFServiceBinding) {
+				b.Spec.Type = korifiv1alpha1.CFServiceBindingTypeKey
+			})
+		})
+
+		It("does not forward logs", func() {
+			Consistently(drainURLs).ShouldNot(Receive())
+		})
+	})
+})
+
+func createNamespace(name string) {
+	helpers.EnsureCreate(adminClient, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	})
+}
@@ -0,0 +1,134 @@
+package drains
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains/syslog"
+	"code.cloudfoundry.org/korifi/tools/podlogs"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const podsPollInterval = 5 * time.Second
+
+//counterfeiter:generate -o fake -fake-name LogStreamer . LogStreamer
+
+type LogStreamer func(context.Context, kubernetes.Interface, corev1.Pod, corev1.PodLogOptions) (io.ReadCloser, error)
+
+var DefaultLogStreamer = LogStreamer(podlogs.DefaultStreamer)
+
+type drain struct {
+	url       string
+	namespace string
+	appGUID   string
+	hostname  string
+}
+
+type forwarder struct {
+	drain  drain
+	cancel context.CancelFunc
+}
+
+func startForwarder(
+	ctx context.Context,
+	clientset kubernetes.Interface,
+	logStreamer LogStreamer,
+	writer syslog.Writer,
+	d drain,
+) *forwarder {
+	ctx, cancel := context.WithCancel(ctx)
+	f := &forwarder{
+		drain:  d,
+		cancel: cancel,
+	}
+
+	go f.run(ctx, clientset, logStreamer, writer)
+
+	return f
+}
+
+func (f *forwarder) stop() {
+	f.cancel()
+}
+
+// run follows the logs of all app pod containers until the context is done.
+// Pods are listed periodically so that restarted and newly scheduled app
+// instances are picked up.
+func (f *forwarder) run(ctx context.Context, clientset kubernetes.Interface, logStreamer LogStreamer, writer syslog.Writer) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("appGUID", f.drain.appGUID)
+	ctx = logr.NewContext(ctx, log)
+
+	defer func() {
+		if err := writer.Close(); err != nil {
+			log.Info("failed to close drain writer", "reason", err)
+		}
+	}()
+
+	listPods := func(ctx context.Context) ([]corev1.Pod, error) {
+		pods, err := clientset.CoreV1().Pods(f.drain.namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(labels.Set{
+				korifiv1alpha1.CFAppGUIDLabelKey: f.drain.appGUID,
+			}).String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return pods.Items, nil
+	}
+
+	pods, err := listPods(ctx)
+	if err != nil && ctx.Err() == nil {
+		log.Info("failed to list app pods", "reason", err)
+	}
+
+	podlogs.Follower[corev1.Pod]{
+		Clientset:    clientset,
+		Streamer:     podlogs.Streamer(logStreamer),
+		ListPods:     listPods,
+		PollInterval: podsPollInterval,
+		GetPod: func(pod corev1.Pod) corev1.Pod {
+			return pod
+		},
+		SinceTime: time.Now(),
+		OnLine: func(ctx context.Context, pod corev1.Pod, line podlogs.Line) bool {
+			if line.Message == "" {
+				return true
+			}
+
+			err := writer.Write(ctx, syslog.Message{
+				Timestamp: line.Timestamp,
+				Severity:  syslog.SeverityInfo,
+				Hostname:  f.drain.hostname,
+				AppName:   f.drain.appGUID,
+				ProcessID: processID(pod),
+				Body:      line.Message,
+			})
+			if err != nil && ctx.Err() == nil {
+				log.V(1).Info("failed to write to syslog drain", "reason", err, "pod", pod.Name)
+			}
+
+			return true
+		},
+	}.Follow(ctx, pods)
+}
+
+// processID identifies the app instance the same way the loggregator source
+// type and instance tags do, e.g. "[APP/PROC/WEB/0]"
+func processID(pod corev1.Pod) string {
+	processType := strings.ToUpper(pod.Labels[korifiv1alpha1.CFProcessTypeLabelKey])
+	if processType == "" {
+		return "[APP]"
+	}
+
+	return fmt.Sprintf("[APP/PROC/%s/%s]", processType, pod.Labels[korifiv1alpha1.PodIndexLabelKey])
+}
@@ -0,0 +1,106 @@
+package drains_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains/fake"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains/syslog"
+	syslogfake "code.cloudfoundry.org/korifi/controllers/controllers/services/drains/syslog/fake"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var (
+	ctx             context.Context
+	stopManager     context.CancelFunc
+	stopClientCache context.CancelFunc
+	testEnv         *envtest.Environment
+	adminClient     client.Client
+	k8sManager      manager.Manager
+	logStreamer     *fake.LogStreamer
+	syslogWriter    *syslogfake.Writer
+	drainURLs       chan string
+)
+
+func TestAPIs(t *testing.T) {
+	SetDefaultEventuallyTimeout(10 * time.Second)
+	SetDefaultEventuallyPollingInterval(250 * time.Millisecond)
+	SetDefaultConsistentlyDuration(5 * time.Second)
+	SetDefaultConsistentlyPollingInterval(250 * time.Millisecond)
+
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Syslog Drains Controller Integration Suite")
+}
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+
+	ctx = context.Background()
+
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "..", "..", "helm", "korifi", "controllers", "crds"),
+		},
+		ErrorIfCRDPathMissing: true,
+	}
+
+	_, err := testEnv.Start()
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+})
+
+var _ = AfterSuite(func() {
+	Eventually(testEnv.Stop, "1m").Should(Succeed())
+})
+
+var _ = BeforeEach(func() {
+	k8sManager = helpers.NewK8sManager(testEnv, filepath.Join("helm", "korifi", "controllers", "role.yaml"))
+	Expect(shared.SetupIndexWithManager(k8sManager)).To(Succeed())
+
+	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
+
+	clientset, err := kubernetes.NewForConfig(k8sManager.GetConfig())
+	Expect(err).NotTo(HaveOccurred())
+
+	logStreamer = new(fake.LogStreamer)
+	syslogWriter = new(syslogfake.Writer)
+	drainURLs = make(chan string, 10)
+
+	err = drains.NewReconciler(
+		k8sManager.GetClient(),
+		clientset,
+		logStreamer.Spy,
+		func(drainURL string) (syslog.Writer, error) {
+			drainURLs <- drainURL
+			return syslogWriter, nil
+		},
+		ctrl.Log.WithName("controllers"),
+	).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+})
+
+var _ = JustBeforeEach(func() {
+	stopManager = helpers.StartK8sManager(k8sManager)
+})
+
+var _ = AfterEach(func() {
+	stopManager()
+	stopClientCache()
+})
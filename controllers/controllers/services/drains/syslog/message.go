@@ -0,0 +1,73 @@
+package syslog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Severity is the RFC5424 severity of a syslog message
+type Severity int
+
+const (
+	SeverityError Severity = 3
+	SeverityInfo  Severity = 6
+
+	// facilityUser is the RFC5424 "user-level messages" facility
+	facilityUser = 1
+
+	// The syslog drains of CF for VMs use the same version and timestamp format
+	rfc5424Version   = 1
+	rfc5424TimeStamp = "2006-01-02T15:04:05.000000Z07:00"
+
+	nilValue = "-"
+)
+
+// Message is a single RFC5424 syslog message
+type Message struct {
+	Timestamp time.Time
+	Severity  Severity
+	// Hostname identifies the app the logs are coming from, e.g. "org.space.app"
+	Hostname string
+	// AppName is the GUID of the app the logs are coming from
+	AppName string
+	// ProcessID is the source of the log line, e.g. "[APP/PROC/WEB/0]"
+	ProcessID string
+	Body      string
+}
+
+// Format renders the message as specified in RFC5424 section 6
+func (m Message) Format() []byte {
+	return []byte(fmt.Sprintf("<%d>%d %s %s %s %s %s %s %s\n",
+		facilityUser*8+int(m.Severity),
+		rfc5424Version,
+		m.Timestamp.UTC().Format(rfc5424TimeStamp),
+		headerField(m.Hostname, 255),
+		headerField(m.AppName, 48),
+		headerField(m.ProcessID, 128),
+		nilValue,
+		nilValue,
+		strings.TrimRight(m.Body, "\r\n"),
+	))
+}
+
+// headerField makes sure the header field consists of printable US-ASCII
+// characters only and does not exceed the maximum length defined by RFC5424
+func headerField(value string, maxLength int) string {
+	field := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, value)
+
+	if field == "" {
+		return nilValue
+	}
+
+	if len(field) > maxLength {
+		return field[:maxLength]
+	}
+
+	return field
+}
@@ -0,0 +1,69 @@
+package syslog_test
+
+import (
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains/syslog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Message", func() {
+	var message syslog.Message
+
+	BeforeEach(func() {
+		message = syslog.Message{
+			Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 678901000, time.UTC),
+			Severity:  syslog.SeverityInfo,
+			Hostname:  "org.space.app",
+			AppName:   "app-guid",
+			ProcessID: "[APP/PROC/WEB/0]",
+			Body:      "hello world\n",
+		}
+	})
+
+	It("formats the message as RFC5424", func() {
+		Expect(string(message.Format())).To(Equal("<14>1 2024-01-02T03:04:05.678901Z org.space.app app-guid [APP/PROC/WEB/0] - - hello world\n"))
+	})
+
+	When("the severity is error", func() {
+		BeforeEach(func() {
+			message.Severity = syslog.SeverityError
+		})
+
+		It("sets the priority accordingly", func() {
+			Expect(string(message.Format())).To(HavePrefix("<11>1 "))
+		})
+	})
+
+	When("header fields contain non printable characters", func() {
+		BeforeEach(func() {
+			message.Hostname = "my org.my space.my app"
+		})
+
+		It("removes them", func() {
+			Expect(string(message.Format())).To(ContainSubstring(" myorg.myspace.myapp "))
+		})
+	})
+
+	When("header fields are empty", func() {
+		BeforeEach(func() {
+			message.ProcessID = ""
+		})
+
+		It("uses the nil value", func() {
+			Expect(string(message.Format())).To(ContainSubstring(" app-guid - - - hello world"))
+		})
+	})
+
+	When("header fields are too long", func() {
+		BeforeEach(func() {
+			message.AppName = strings.Repeat("a", 50)
+		})
+
+		It("truncates them", func() {
+			Expect(string(message.Format())).To(ContainSubstring(" " + strings.Repeat("a", 48) + " "))
+		})
+	})
+})
@@ -0,0 +1,180 @@
+package syslog
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	dialTimeout  = 5 * time.Second
+	writeTimeout = 10 * time.Second
+)
+
+//counterfeiter:generate -o fake -fake-name Writer . Writer
+
+// Writer ships syslog messages to a syslog drain
+type Writer interface {
+	Write(ctx context.Context, message Message) error
+	Close() error
+}
+
+// NewWriter creates a writer for the drain url. The url scheme selects the
+// transport:
+//   - syslog: RFC5424 messages over TCP, framed as per RFC6587 octet counting
+//   - syslog-tls: same as syslog, over TLS
+//   - https: every message is sent as the body of a POST request
+//
+// Connections to addresses within the blocked ranges are refused. The check
+// is done on the address being dialed, so that drain host names cannot be
+// used to reach private or cluster internal addresses.
+func NewWriter(drainURL string, tlsConfig *tls.Config, blockedRanges []netip.Prefix) (Writer, error) {
+	u, err := url.Parse(drainURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid drain url %q: %w", drainURL, err)
+	}
+
+	dialer := newDialer(blockedRanges)
+
+	switch u.Scheme {
+	case "syslog":
+		return &tcpWriter{address: u.Host, dialer: dialer}, nil
+	case "syslog-tls":
+		return &tcpWriter{address: u.Host, dialer: dialer, tlsConfig: tlsConfig}, nil
+	case "https":
+		return &httpsWriter{
+			url: u.String(),
+			client: &http.Client{
+				Timeout: writeTimeout,
+				Transport: &http.Transport{
+					DialContext:     dialer.DialContext,
+					TLSClientConfig: tlsConfig,
+				},
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported drain url scheme %q", u.Scheme)
+}
+
+func newDialer(blockedRanges []netip.Prefix) *net.Dialer {
+	return &net.Dialer{
+		Timeout: dialTimeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+
+			addr := addrPort.Addr().Unmap()
+			for _, blockedRange := range blockedRanges {
+				if blockedRange.Contains(addr) {
+					return fmt.Errorf("address %s is within the blocked range %s", addr, blockedRange)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// tcpWriter keeps a connection to the drain open and reconnects on the next
+// write whenever writing to the connection fails
+type tcpWriter struct {
+	address   string
+	dialer    *net.Dialer
+	tlsConfig *tls.Config
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (w *tcpWriter) Write(ctx context.Context, message Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		conn, err := w.dial(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to connect to %q: %w", w.address, err)
+		}
+		w.conn = conn
+	}
+
+	formattedMessage := message.Format()
+	if err := w.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return w.closeOnError(err)
+	}
+
+	if _, err := fmt.Fprintf(w.conn, "%d %s", len(formattedMessage), formattedMessage); err != nil {
+		return w.closeOnError(err)
+	}
+
+	return nil
+}
+
+func (w *tcpWriter) dial(ctx context.Context) (net.Conn, error) {
+	if w.tlsConfig == nil {
+		return w.dialer.DialContext(ctx, "tcp", w.address)
+	}
+
+	tlsDialer := &tls.Dialer{NetDialer: w.dialer, Config: w.tlsConfig}
+	return tlsDialer.DialContext(ctx, "tcp", w.address)
+}
+
+func (w *tcpWriter) closeOnError(err error) error {
+	_ = w.conn.Close()
+	w.conn = nil
+	return fmt.Errorf("failed to write to %q: %w", w.address, err)
+}
+
+func (w *tcpWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+type httpsWriter struct {
+	url    string
+	client *http.Client
+}
+
+func (w *httpsWriter) Write(ctx context.Context, message Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(message.Format()))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to drain: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("drain responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (w *httpsWriter) Close() error {
+	w.client.CloseIdleConnections()
+	return nil
+}
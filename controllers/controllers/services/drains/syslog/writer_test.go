@@ -0,0 +1,222 @@
+package syslog_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains/syslog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Writer", func() {
+	var (
+		ctx           context.Context
+		tlsConfig     *tls.Config
+		blockedRanges []netip.Prefix
+		drainURL      string
+		writer        syslog.Writer
+		message       syslog.Message
+		err           error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		tlsConfig = nil
+		blockedRanges = []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+		message = syslog.Message{
+			Timestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Severity:  syslog.SeverityInfo,
+			Hostname:  "org.space.app",
+			AppName:   "app-guid",
+			ProcessID: "[APP/PROC/WEB/0]",
+			Body:      "hello",
+		}
+	})
+
+	JustBeforeEach(func() {
+		writer, err = syslog.NewWriter(drainURL, tlsConfig, blockedRanges)
+		Expect(err).NotTo(HaveOccurred())
+		DeferCleanup(func() {
+			Expect(writer.Close()).To(Succeed())
+		})
+	})
+
+	Describe("syslog drains", func() {
+		var received chan string
+
+		BeforeEach(func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			received = serve(listener)
+			drainURL = "syslog://" + listener.Addr().String()
+		})
+
+		It("writes octet counted messages", func() {
+			Expect(writer.Write(ctx, message)).To(Succeed())
+			Expect(writer.Write(ctx, message)).To(Succeed())
+
+			Eventually(received).Should(Receive(Equal(string(message.Format()))))
+			Eventually(received).Should(Receive(Equal(string(message.Format()))))
+		})
+
+		When("the drain address is within a blocked range", func() {
+			BeforeEach(func() {
+				blockedRanges = append(blockedRanges, netip.MustParsePrefix("127.0.0.0/8"))
+			})
+
+			It("refuses to connect", func() {
+				Expect(writer.Write(ctx, message)).To(MatchError(ContainSubstring("within the blocked range 127.0.0.0/8")))
+				Consistently(received).ShouldNot(Receive())
+			})
+		})
+	})
+
+	Describe("syslog-tls drains", func() {
+		var received chan string
+
+		BeforeEach(func() {
+			server := httptest.NewUnstartedServer(nil)
+			server.StartTLS()
+			certPool := x509.NewCertPool()
+			certPool.AddCert(server.Certificate())
+			serverTLSConfig := server.TLS
+			server.Close()
+
+			tlsConfig = &tls.Config{RootCAs: certPool}
+
+			listener, err := tls.Listen("tcp", "127.0.0.1:0", serverTLSConfig)
+			Expect(err).NotTo(HaveOccurred())
+			received = serve(listener)
+			drainURL = "syslog-tls://" + listener.Addr().String()
+		})
+
+		It("writes octet counted messages over tls", func() {
+			Expect(writer.Write(ctx, message)).To(Succeed())
+			Eventually(received).Should(Receive(Equal(string(message.Format()))))
+		})
+
+		When("the drain certificate is not trusted", func() {
+			BeforeEach(func() {
+				tlsConfig = &tls.Config{}
+			})
+
+			It("returns an error", func() {
+				Expect(writer.Write(ctx, message)).To(MatchError(ContainSubstring("certificate")))
+			})
+		})
+	})
+
+	Describe("https drains", func() {
+		var (
+			server      *httptest.Server
+			received    chan string
+			contentType chan string
+			status      int
+		)
+
+		BeforeEach(func() {
+			status = http.StatusOK
+			received = make(chan string, 10)
+			contentType = make(chan string, 10)
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				received <- string(body)
+				contentType <- r.Header.Get("Content-Type")
+				w.WriteHeader(status)
+			}))
+			DeferCleanup(server.Close)
+
+			certPool := x509.NewCertPool()
+			certPool.AddCert(server.Certificate())
+			tlsConfig = &tls.Config{RootCAs: certPool}
+			drainURL = server.URL + "/drain"
+		})
+
+		It("posts the message", func() {
+			Expect(writer.Write(ctx, message)).To(Succeed())
+			Eventually(received).Should(Receive(Equal(string(message.Format()))))
+			Eventually(contentType).Should(Receive(Equal("text/plain")))
+		})
+
+		When("the drain address is within a blocked range", func() {
+			BeforeEach(func() {
+				blockedRanges = append(blockedRanges, netip.MustParsePrefix("127.0.0.1/32"))
+			})
+
+			It("refuses to connect", func() {
+				Expect(writer.Write(ctx, message)).To(MatchError(ContainSubstring("within the blocked range 127.0.0.1/32")))
+				Expect(received).NotTo(Receive())
+			})
+		})
+
+		When("the drain responds with an error", func() {
+			BeforeEach(func() {
+				status = http.StatusInternalServerError
+			})
+
+			It("returns an error", func() {
+				Expect(writer.Write(ctx, message)).To(MatchError(ContainSubstring("500")))
+			})
+		})
+	})
+
+	When("the drain url scheme is not supported", func() {
+		It("returns an error", func() {
+			_, err := syslog.NewWriter("ftp://logs.example.com", nil, nil)
+			Expect(err).To(MatchError(ContainSubstring("unsupported drain url scheme")))
+		})
+	})
+})
+
+// serve accepts connections and parses the octet counted messages sent to them
+func serve(listener net.Listener) chan string {
+	DeferCleanup(listener.Close)
+
+	received := make(chan string, 10)
+	go func() {
+		defer GinkgoRecover()
+
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer GinkgoRecover()
+				defer conn.Close()
+
+				reader := bufio.NewReader(conn)
+				for {
+					length, err := reader.ReadString(' ')
+					if err != nil {
+						return
+					}
+
+					msgLength, err := strconv.Atoi(strings.TrimSpace(length))
+					Expect(err).NotTo(HaveOccurred())
+
+					msg := make([]byte, msgLength)
+					if _, err = io.ReadFull(reader, msg); err != nil {
+						return
+					}
+					received <- string(msg)
+				}
+			}()
+		}
+	}()
+
+	return received
+}
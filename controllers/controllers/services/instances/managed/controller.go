@@ -52,6 +52,7 @@ type Reconciler struct {
 	rootNamespace       string
 	log                 logr.Logger
 	assets              *osbapi.Assets
+	usageRecorder       instances.UsageRecorder
 }
 
 func NewReconciler(
@@ -60,6 +61,7 @@ func NewReconciler(
 	scheme *runtime.Scheme,
 	rootNamespace string,
 	log logr.Logger,
+	usageRecorder instances.UsageRecorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceInstance] {
 	return k8s.NewPatchingReconciler(log, client, &Reconciler{
 		k8sClient:           client,
@@ -68,6 +70,7 @@ func NewReconciler(
 		rootNamespace:       rootNamespace,
 		log:                 log,
 		assets:              osbapi.NewAssets(client, rootNamespace),
+		usageRecorder:       usageRecorder,
 	})
 }
 
@@ -129,6 +132,11 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, serviceInstance *kor
 		return r.finalize(ctx, serviceInstance)
 	}
 
+	if err := instances.DeleteUnsharedServiceBindings(ctx, r.k8sClient, serviceInstance); err != nil {
+		log.Error(err, "failed to delete bindings from unshared spaces")
+		return ctrl.Result{}, err
+	}
+
 	serviceInstanceAssets, err := r.assets.GetServiceInstanceAssets(ctx, serviceInstance)
 	if err != nil {
 		log.Error(err, "failed to get service instance assets")
@@ -143,8 +151,15 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, serviceInstance *kor
 
 	serviceInstance.Status.UpgradeAvailable = serviceInstance.Status.MaintenanceInfo.Version != serviceInstanceAssets.ServicePlan.Spec.MaintenanceInfo.Version
 
-	if isReady(serviceInstance) {
-		return ctrl.Result{}, nil
+	if isReady(serviceInstance) && serviceInstance.Status.PlanGUID == "" {
+		// Instances provisioned before updates were supported do not record
+		// the plan and parameters they have been provisioned with
+		serviceInstance.Status.PlanGUID = serviceInstance.Spec.PlanGUID
+		serviceInstance.Status.Parameters = serviceInstance.Spec.Parameters
+	}
+
+	if isProvisioned(serviceInstance) {
+		return r.reconcileUpdate(ctx, serviceInstance, serviceInstanceAssets, osbapiClient)
 	}
 
 	if isFailed(serviceInstance) {
@@ -177,11 +192,11 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, serviceInstance *kor
 		if err != nil {
 			return ctrl.Result{}, err
 		}
-		return r.processProvisionOperation(serviceInstance, lastOpResponse)
+		return r.processProvisionOperation(serviceInstance, serviceInstanceAssets, lastOpResponse)
 	}
 
-	serviceInstance.Status.MaintenanceInfo = serviceInstanceAssets.ServicePlan.Spec.MaintenanceInfo
 	serviceInstance.Status.LastOperation.State = "succeeded"
+	setProvisioned(serviceInstance, serviceInstanceAssets)
 	return ctrl.Result{}, nil
 }
 
@@ -246,9 +261,11 @@ func (r *Reconciler) provisionServiceInstance(
 
 func (r *Reconciler) processProvisionOperation(
 	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
 	lastOpResponse osbapi.LastOperationResponse,
 ) (ctrl.Result, error) {
 	if lastOpResponse.State == "succeeded" {
+		setProvisioned(serviceInstance, assets)
 		return ctrl.Result{}, nil
 	}
 
@@ -267,6 +284,193 @@ func (r *Reconciler) processProvisionOperation(
 	return ctrl.Result{}, k8s.NewNotReadyError().WithReason("ProvisionInProgress").WithRequeue()
 }
 
+func (r *Reconciler) reconcileUpdate(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
+	osbapiClient osbapi.BrokerClient,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if isUpdateInProgress(serviceInstance) {
+		lastOpResponse, err := r.pollLastOperation(ctx, serviceInstance, assets, osbapiClient, serviceInstance.Status.LastOperation.Operation)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.processUpdateOperation(serviceInstance, assets, lastOpResponse)
+	}
+
+	if !needsUpdate(serviceInstance) {
+		if err := r.usageRecorder.RecordServiceInstanceUsage(ctx, serviceInstance); err != nil {
+			log.Error(err, "failed to record service instance usage")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	updateResponse, err := r.updateServiceInstance(ctx, serviceInstance, assets, osbapiClient)
+	if err != nil {
+		if osbapi.IsUnrecoveralbeError(err) {
+			return failUpdate(serviceInstance, err.Error())
+		}
+
+		log.Error(err, "failed to update service instance")
+		return ctrl.Result{}, fmt.Errorf("failed to update service instance: %w", err)
+	}
+
+	if updateResponse.IsAsync {
+		serviceInstance.Status.LastOperation.State = "in progress"
+		serviceInstance.Status.LastOperation.Operation = updateResponse.Operation
+
+		lastOpResponse, err := r.pollLastOperation(ctx, serviceInstance, assets, osbapiClient, updateResponse.Operation)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.processUpdateOperation(serviceInstance, assets, lastOpResponse)
+	}
+
+	return completeUpdate(serviceInstance, assets)
+}
+
+func (r *Reconciler) updateServiceInstance(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
+	osbapiClient osbapi.BrokerClient,
+) (osbapi.UpdateResponse, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("update-service-instance")
+
+	namespace, err := r.getNamespace(ctx, serviceInstance.Namespace)
+	if err != nil {
+		log.Error(err, "failed to get namespace")
+		return osbapi.UpdateResponse{}, err
+	}
+
+	previousPlanID, err := r.getPreviousPlanID(ctx, serviceInstance)
+	if err != nil {
+		log.Error(err, "failed to get previous service plan")
+		return osbapi.UpdateResponse{}, err
+	}
+
+	updateRequest := osbapi.UpdateRequest{
+		ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+		PreviousValues: osbapi.PreviousValues{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    previousPlanID,
+			SpaceGUID: namespace.Labels[korifiv1alpha1.SpaceGUIDLabelKey],
+			OrgGUID:   namespace.Labels[korifiv1alpha1.CFOrgGUIDKey],
+		},
+	}
+
+	if serviceInstance.Status.MaintenanceInfo.Version != "" {
+		updateRequest.PreviousValues.MaintenanceInfo = &osbapi.MaintenanceInfo{
+			Version: serviceInstance.Status.MaintenanceInfo.Version,
+		}
+	}
+
+	if serviceInstance.Spec.PlanGUID != serviceInstance.Status.PlanGUID {
+		updateRequest.PlanID = assets.ServicePlan.Spec.BrokerCatalog.ID
+	}
+
+	if serviceInstance.Spec.Parameters.Name != serviceInstance.Status.Parameters.Name {
+		updateRequest.Parameters, err = r.getServiceInstanceParameters(ctx, serviceInstance)
+		if err != nil {
+			log.Error(err, "failed to get service instance parameters")
+			return osbapi.UpdateResponse{}, k8s.NewNotReadyError().WithReason("InvalidParameters")
+		}
+	}
+
+	if maintenanceInfo := desiredMaintenanceInfo(serviceInstance, assets); maintenanceInfo != nil {
+		updateRequest.MaintenanceInfo = &osbapi.MaintenanceInfo{
+			Version: maintenanceInfo.Version,
+		}
+	}
+
+	serviceInstance.Status.LastOperation = korifiv1alpha1.LastOperation{
+		Type:  "update",
+		State: "initial",
+	}
+
+	return osbapiClient.Update(ctx, osbapi.UpdatePayload{
+		InstanceID:    serviceInstance.Name,
+		UpdateRequest: updateRequest,
+	})
+}
+
+func (r *Reconciler) getPreviousPlanID(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) (string, error) {
+	previousPlan := &korifiv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      serviceInstance.Status.PlanGUID,
+		},
+	}
+
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(previousPlan), previousPlan)
+	if err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+
+	return previousPlan.Spec.BrokerCatalog.ID, nil
+}
+
+func (r *Reconciler) processUpdateOperation(
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
+	lastOpResponse osbapi.LastOperationResponse,
+) (ctrl.Result, error) {
+	if lastOpResponse.State == "succeeded" {
+		return completeUpdate(serviceInstance, assets)
+	}
+
+	if lastOpResponse.State == "failed" {
+		return failUpdate(serviceInstance, lastOpResponse.Description)
+	}
+
+	return ctrl.Result{}, k8s.NewNotReadyError().WithReason("UpdateInProgress").WithRequeue()
+}
+
+func completeUpdate(
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
+) (ctrl.Result, error) {
+	if maintenanceInfo := desiredMaintenanceInfo(serviceInstance, assets); maintenanceInfo != nil {
+		serviceInstance.Status.MaintenanceInfo = *maintenanceInfo
+	}
+	serviceInstance.Status.PlanGUID = serviceInstance.Spec.PlanGUID
+	serviceInstance.Status.Parameters = serviceInstance.Spec.Parameters
+	serviceInstance.Status.UpgradeAvailable = serviceInstance.Status.MaintenanceInfo.Version != assets.ServicePlan.Spec.MaintenanceInfo.Version
+
+	serviceInstance.Status.LastOperation.State = "succeeded"
+	serviceInstance.Status.LastOperation.Operation = ""
+	meta.RemoveStatusCondition(&serviceInstance.Status.Conditions, korifiv1alpha1.UpdateFailedCondition)
+
+	return ctrl.Result{}, nil
+}
+
+func failUpdate(serviceInstance *korifiv1alpha1.CFServiceInstance, message string) (ctrl.Result, error) {
+	serviceInstance.Status.LastOperation.State = "failed"
+	serviceInstance.Status.LastOperation.Description = message
+	serviceInstance.Status.LastOperation.Operation = ""
+	meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.UpdateFailedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: serviceInstance.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "UpdateFailed",
+		Message:            message,
+	})
+
+	// The broker keeps the instance in its previous state when an update
+	// fails, so the instance remains usable. Reverting the spec to that state
+	// makes the instance present the plan it actually runs and lets a request
+	// for the same update trigger a new attempt.
+	serviceInstance.Spec.PlanGUID = serviceInstance.Status.PlanGUID
+	serviceInstance.Spec.Parameters = serviceInstance.Status.Parameters
+	serviceInstance.Spec.MaintenanceInfo = nil
+
+	return ctrl.Result{}, nil
+}
+
 func (r *Reconciler) finalize(
 	ctx context.Context,
 	serviceInstance *korifiv1alpha1.CFServiceInstance,
@@ -279,6 +483,10 @@ func (r *Reconciler) finalize(
 		return ctrl.Result{}, err
 	}
 
+	if err := r.usageRecorder.RecordServiceInstanceDeleted(ctx, serviceInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	controllerutil.RemoveFinalizer(serviceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName)
 	logr.FromContextOrDiscard(ctx).WithName("finalizeCFServiceInstance").V(1).Info("finalizer removed")
 	return ctrl.Result{}, nil
@@ -475,6 +683,42 @@ func isFailed(instance *korifiv1alpha1.CFServiceInstance) bool {
 	return meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.ProvisioningFailedCondition)
 }
 
+func isProvisioned(instance *korifiv1alpha1.CFServiceInstance) bool {
+	return instance.Status.PlanGUID != ""
+}
+
+func isUpdateInProgress(instance *korifiv1alpha1.CFServiceInstance) bool {
+	return instance.Status.LastOperation.Type == "update" && instance.Status.LastOperation.State == "in progress"
+}
+
+func needsUpdate(instance *korifiv1alpha1.CFServiceInstance) bool {
+	return instance.Spec.PlanGUID != instance.Status.PlanGUID ||
+		instance.Spec.Parameters.Name != instance.Status.Parameters.Name ||
+		(instance.Spec.MaintenanceInfo != nil && instance.Spec.MaintenanceInfo.Version != instance.Status.MaintenanceInfo.Version)
+}
+
+// desiredMaintenanceInfo returns the maintenance info to send to the broker,
+// or nil if the instance is not being upgraded. An explicit upgrade takes
+// precedence over the maintenance info of a new plan.
+func desiredMaintenanceInfo(instance *korifiv1alpha1.CFServiceInstance, assets osbapi.ServiceInstanceAssets) *korifiv1alpha1.MaintenanceInfo {
+	if instance.Spec.MaintenanceInfo != nil && instance.Spec.MaintenanceInfo.Version != instance.Status.MaintenanceInfo.Version {
+		return instance.Spec.MaintenanceInfo
+	}
+
+	if instance.Spec.PlanGUID != instance.Status.PlanGUID && assets.ServicePlan.Spec.MaintenanceInfo.Version != "" {
+		return &assets.ServicePlan.Spec.MaintenanceInfo
+	}
+
+	return nil
+}
+
+func setProvisioned(instance *korifiv1alpha1.CFServiceInstance, assets osbapi.ServiceInstanceAssets) {
+	instance.Status.PlanGUID = instance.Spec.PlanGUID
+	instance.Status.Parameters = instance.Spec.Parameters
+	instance.Status.MaintenanceInfo = assets.ServicePlan.Spec.MaintenanceInfo
+	instance.Status.UpgradeAvailable = false
+}
+
 func isReady(instance *korifiv1alpha1.CFServiceInstance) bool {
 	return meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)
 }
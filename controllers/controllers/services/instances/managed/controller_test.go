@@ -137,6 +137,26 @@ var _ = Describe("CFServiceInstance", func() {
 		}).Should(Succeed())
 	})
 
+	It("records a CREATED service usage event", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(listServiceUsageEvents(g, instance.Name)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Spec": Equal(korifiv1alpha1.CFServiceUsageEventSpec{
+					State: korifiv1alpha1.ServiceUsageEventStateCreated,
+					ServiceInstance: korifiv1alpha1.UsageEventServiceInstance{
+						GUID: instance.Name,
+						Name: "service-instance-name",
+						Type: korifiv1alpha1.ManagedServiceInstanceUsageType,
+					},
+					Space:            korifiv1alpha1.UsageEventResource{GUID: instance.Namespace},
+					OrganizationGUID: "org-guid",
+					ServicePlan:      &korifiv1alpha1.UsageEventResource{GUID: servicePlan.Name},
+					ServiceOffering:  &korifiv1alpha1.UsageEventResource{GUID: servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel], Name: "service-offering-name"},
+					ServiceBroker:    &korifiv1alpha1.UsageEventResource{GUID: serviceBroker.Name, Name: "my-service-broker"},
+				}),
+			})))
+		}).Should(Succeed())
+	})
+
 	It("defaults the service label to the service offering name", func() {
 		Eventually(func(g Gomega) {
 			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
@@ -563,6 +583,13 @@ var _ = Describe("CFServiceInstance", func() {
 			}).Should(Succeed())
 		})
 
+		It("records the provisioned plan in the status", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+				g.Expect(instance.Status.PlanGUID).To(Equal(servicePlan.Name))
+			}).Should(Succeed())
+		})
+
 		When("the service plan becomes disabled after provisioning", func() {
 			BeforeEach(func() {
 				Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
@@ -603,6 +630,373 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 
+	When("the instance has been provisioned", func() {
+		BeforeEach(func() {
+			Expect(k8s.Patch(ctx, adminClient, instance, func() {
+				instance.Status.PlanGUID = servicePlan.Name
+				instance.Status.MaintenanceInfo.Version = "1.2.3"
+				meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+					Type:   korifiv1alpha1.StatusConditionReady,
+					Status: metav1.ConditionTrue,
+					Reason: "ready",
+				})
+			})).To(Succeed())
+
+			brokerClient.UpdateReturns(osbapi.UpdateResponse{}, nil)
+		})
+
+		It("does not request update", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(brokerClient.UpdateCallCount()).To(Equal(0))
+			}).Should(Succeed())
+		})
+
+		When("the plan is changed", func() {
+			var newServicePlan *korifiv1alpha1.CFServicePlan
+
+			BeforeEach(func() {
+				newServicePlan = &korifiv1alpha1.CFServicePlan{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: rootNamespace,
+						Labels:    servicePlan.Labels,
+					},
+					Spec: korifiv1alpha1.CFServicePlanSpec{
+						Visibility: korifiv1alpha1.ServicePlanVisibility{
+							Type: "public",
+						},
+						BrokerCatalog: korifiv1alpha1.ServicePlanBrokerCatalog{
+							ID: "new-service-plan-id",
+						},
+						MaintenanceInfo: korifiv1alpha1.MaintenanceInfo{
+							Version: "2.0.0",
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, newServicePlan)).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.PlanGUID = newServicePlan.Name
+				})).To(Succeed())
+			})
+
+			It("updates the service instance plan", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.UpdateCallCount()).NotTo(BeZero())
+					_, payload := brokerClient.UpdateArgsForCall(0)
+					g.Expect(payload).To(Equal(osbapi.UpdatePayload{
+						InstanceID: instance.Name,
+						UpdateRequest: osbapi.UpdateRequest{
+							ServiceId: "service-offering-id",
+							PlanID:    "new-service-plan-id",
+							PreviousValues: osbapi.PreviousValues{
+								ServiceId:       "service-offering-id",
+								PlanID:          "service-plan-id",
+								SpaceGUID:       "space-guid",
+								OrgGUID:         "org-guid",
+								MaintenanceInfo: &osbapi.MaintenanceInfo{Version: "1.2.3"},
+							},
+							MaintenanceInfo: &osbapi.MaintenanceInfo{Version: "2.0.0"},
+						},
+					}))
+				}).Should(Succeed())
+			})
+
+			It("records the new plan in the status", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.PlanGUID).To(Equal(newServicePlan.Name))
+					g.Expect(instance.Status.MaintenanceInfo.Version).To(Equal("2.0.0"))
+					g.Expect(instance.Status.LastOperation).To(Equal(korifiv1alpha1.LastOperation{
+						Type:  "update",
+						State: "succeeded",
+					}))
+				}).Should(Succeed())
+			})
+
+			It("updates the service instance only once", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.UpdateCallCount()).To(Equal(1))
+				}).Should(Succeed())
+				Consistently(func(g Gomega) {
+					g.Expect(brokerClient.UpdateCallCount()).To(Equal(1))
+				}).Should(Succeed())
+			})
+
+			When("the update is asynchronous", func() {
+				BeforeEach(func() {
+					brokerClient.UpdateReturns(osbapi.UpdateResponse{
+						IsAsync:   true,
+						Operation: "update-op",
+					}, nil)
+					brokerClient.GetServiceInstanceLastOperationReturns(osbapi.LastOperationResponse{
+						State: "in-progress-or-whatever",
+					}, nil)
+				})
+
+				It("sets the ready condition to false", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							HasStatus(Equal(metav1.ConditionFalse)),
+							HasReason(Equal("UpdateInProgress")),
+						)))
+					}).Should(Succeed())
+				})
+
+				It("sets in progress state in instance last operation", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.LastOperation).To(Equal(korifiv1alpha1.LastOperation{
+							Type:      "update",
+							State:     "in progress",
+							Operation: "update-op",
+						}))
+					}).Should(Succeed())
+				})
+
+				It("polls the last operation without sending the update again", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(brokerClient.GetServiceInstanceLastOperationCallCount()).To(BeNumerically(">", 1))
+						_, lastOp := brokerClient.GetServiceInstanceLastOperationArgsForCall(brokerClient.GetServiceInstanceLastOperationCallCount() - 1)
+						g.Expect(lastOp).To(Equal(osbapi.GetInstanceLastOperationRequest{
+							InstanceID: instance.Name,
+							GetLastOperationRequestParameters: osbapi.GetLastOperationRequestParameters{
+								ServiceId: "service-offering-id",
+								PlanID:    "new-service-plan-id",
+								Operation: "update-op",
+							},
+						}))
+					}).Should(Succeed())
+					Expect(brokerClient.UpdateCallCount()).To(Equal(1))
+				})
+
+				When("the last operation is succeeded", func() {
+					BeforeEach(func() {
+						brokerClient.GetServiceInstanceLastOperationReturns(osbapi.LastOperationResponse{
+							State: "succeeded",
+						}, nil)
+					})
+
+					It("records the new plan in the status", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+							g.Expect(instance.Status.PlanGUID).To(Equal(newServicePlan.Name))
+							g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+						}).Should(Succeed())
+					})
+				})
+
+				When("the last operation is failed", func() {
+					BeforeEach(func() {
+						brokerClient.GetServiceInstanceLastOperationReturns(osbapi.LastOperationResponse{
+							State:       "failed",
+							Description: "update-failed",
+						}, nil)
+					})
+
+					It("sets the update failed condition", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+							g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+								HasType(Equal(korifiv1alpha1.UpdateFailedCondition)),
+								HasStatus(Equal(metav1.ConditionTrue)),
+								HasMessage(Equal("update-failed")),
+							)))
+							g.Expect(instance.Status.PlanGUID).To(Equal(servicePlan.Name))
+						}).Should(Succeed())
+					})
+
+					It("reverts the spec to the plan the instance runs", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+							g.Expect(instance.Spec.PlanGUID).To(Equal(servicePlan.Name))
+						}).Should(Succeed())
+					})
+				})
+			})
+
+			When("the update fails with an unrecoverable error", func() {
+				BeforeEach(func() {
+					brokerClient.UpdateReturns(osbapi.UpdateResponse{}, osbapi.UnrecoverableError{Status: http.StatusBadRequest})
+				})
+
+				It("sets the update failed condition", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.UpdateFailedCondition)),
+							HasStatus(Equal(metav1.ConditionTrue)),
+						)))
+						g.Expect(instance.Status.LastOperation.Type).To(Equal("update"))
+						g.Expect(instance.Status.LastOperation.State).To(Equal("failed"))
+					}).Should(Succeed())
+				})
+
+				It("remains ready", func() {
+					Consistently(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+					}).Should(Succeed())
+				})
+
+				It("does not retry the update", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(brokerClient.UpdateCallCount()).To(Equal(1))
+					}).Should(Succeed())
+					Consistently(func(g Gomega) {
+						g.Expect(brokerClient.UpdateCallCount()).To(Equal(1))
+					}).Should(Succeed())
+				})
+
+				It("reverts the spec to the plan the instance runs", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Spec.PlanGUID).To(Equal(servicePlan.Name))
+						g.Expect(instance.Status.PlanGUID).To(Equal(servicePlan.Name))
+					}).Should(Succeed())
+				})
+
+				When("the same update is requested again", func() {
+					JustBeforeEach(func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+							g.Expect(instance.Spec.PlanGUID).To(Equal(servicePlan.Name))
+						}).Should(Succeed())
+
+						Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+							instance.Spec.PlanGUID = newServicePlan.Name
+						})).To(Succeed())
+					})
+
+					It("retries the update", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(brokerClient.UpdateCallCount()).To(Equal(2))
+							_, payload := brokerClient.UpdateArgsForCall(1)
+							g.Expect(payload.PlanID).To(Equal("new-service-plan-id"))
+						}).Should(Succeed())
+					})
+				})
+			})
+		})
+
+		When("the parameters are changed", func() {
+			BeforeEach(func() {
+				paramsSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: instance.Namespace,
+						Name:      uuid.NewString(),
+					},
+					Data: map[string][]byte{
+						tools.ParametersSecretKey: []byte(`{"p1":"p1-new-value"}`),
+					},
+				}
+				Expect(adminClient.Create(ctx, paramsSecret)).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.Parameters.Name = paramsSecret.Name
+				})).To(Succeed())
+			})
+
+			It("sends the new parameters to the broker", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.UpdateCallCount()).NotTo(BeZero())
+					_, payload := brokerClient.UpdateArgsForCall(0)
+					g.Expect(payload.PlanID).To(BeEmpty())
+					g.Expect(payload.MaintenanceInfo).To(BeNil())
+					g.Expect(payload.Parameters).To(Equal(map[string]any{
+						"p1": "p1-new-value",
+					}))
+				}).Should(Succeed())
+			})
+		})
+
+		When("a maintenance upgrade is requested", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
+					servicePlan.Spec.MaintenanceInfo.Version = "2.3.4"
+				})).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.MaintenanceInfo = &korifiv1alpha1.MaintenanceInfo{Version: "2.3.4"}
+				})).To(Succeed())
+			})
+
+			It("upgrades the service instance", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.UpdateCallCount()).NotTo(BeZero())
+					_, payload := brokerClient.UpdateArgsForCall(0)
+					g.Expect(payload.PlanID).To(BeEmpty())
+					g.Expect(payload.MaintenanceInfo).To(Equal(&osbapi.MaintenanceInfo{Version: "2.3.4"}))
+				}).Should(Succeed())
+			})
+
+			It("records the new maintenance info", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.MaintenanceInfo.Version).To(Equal("2.3.4"))
+					g.Expect(instance.Status.UpgradeAvailable).To(BeFalse())
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the instance is shared with another space", func() {
+		var binding *korifiv1alpha1.CFServiceBinding
+
+		BeforeEach(func() {
+			sharedNamespace := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: uuid.NewString(),
+				},
+			}
+			Expect(adminClient.Create(ctx, sharedNamespace)).To(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+				instance.Spec.SharedSpaces = []string{sharedNamespace.Name}
+			})).To(Succeed())
+
+			binding = &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: sharedNamespace.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Service: corev1.ObjectReference{
+						Kind:       "ServiceInstance",
+						Name:       instance.Name,
+						Namespace:  instance.Namespace,
+						APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+					},
+					Type: korifiv1alpha1.CFServiceBindingTypeApp,
+				},
+			}
+			Expect(adminClient.Create(ctx, binding)).To(Succeed())
+		})
+
+		It("keeps the bindings in the shared space", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+			}).Should(Succeed())
+		})
+
+		When("the instance is unshared from the space", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.SharedSpaces = nil
+				})).To(Succeed())
+			})
+
+			It("deletes the bindings in that space", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the instance provisioning has failed", func() {
 		BeforeEach(func() {
 			Expect(k8s.Patch(ctx, adminClient, instance, func() {
@@ -805,6 +1199,27 @@ var _ = Describe("CFServiceInstance", func() {
 			}).Should(Succeed())
 		})
 
+		When("the instance creation has been recorded", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, instance, func() {
+					instance.Status.Usage = &korifiv1alpha1.ServiceInstanceUsage{
+						DisplayName: instance.Spec.DisplayName,
+						PlanGUID:    instance.Spec.PlanGUID,
+					}
+				})).To(Succeed())
+			})
+
+			It("records a DELETED service usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listServiceUsageEvents(g, instance.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State": Equal(korifiv1alpha1.ServiceUsageEventStateDeleted),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
 		When("creating the broker is not possible (e.g. missing service plan)", func() {
 			BeforeEach(func() {
 				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
@@ -1096,3 +1511,17 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 })
+
+func listServiceUsageEvents(g Gomega, serviceInstanceGUID string) []korifiv1alpha1.CFServiceUsageEvent {
+	var usageEvents korifiv1alpha1.CFServiceUsageEventList
+	g.Expect(adminClient.List(ctx, &usageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+
+	var result []korifiv1alpha1.CFServiceUsageEvent
+	for _, usageEvent := range usageEvents.Items {
+		if usageEvent.Spec.ServiceInstance.GUID == serviceInstanceGUID {
+			result = append(result, usageEvent)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,36 @@
+package instances
+
+import (
+	"context"
+	"slices"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeleteUnsharedServiceBindings deletes the bindings to the service instance
+// from spaces the instance is no longer shared with
+func DeleteUnsharedServiceBindings(
+	ctx context.Context,
+	k8sClient client.Client,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+) error {
+	bindings, err := getBindings(ctx, k8sClient, serviceInstance)
+	if err != nil {
+		return err
+	}
+
+	for _, binding := range bindings {
+		if binding.Namespace == serviceInstance.Namespace || slices.Contains(serviceInstance.Spec.SharedSpaces, binding.Namespace) {
+			continue
+		}
+
+		logr.FromContextOrDiscard(ctx).V(1).Info("deleting binding from unshared space", "binding", binding.Name, "namespace", binding.Namespace)
+		if err = client.IgnoreNotFound(k8sClient.Delete(ctx, &binding)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
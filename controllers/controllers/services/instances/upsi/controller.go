@@ -44,17 +44,19 @@ import (
 )
 
 type Reconciler struct {
-	k8sClient client.Client
-	scheme    *runtime.Scheme
-	log       logr.Logger
+	k8sClient     client.Client
+	scheme        *runtime.Scheme
+	log           logr.Logger
+	usageRecorder instances.UsageRecorder
 }
 
 func NewReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
 	log logr.Logger,
+	usageRecorder instances.UsageRecorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceInstance] {
-	serviceInstanceReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log}
+	serviceInstanceReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, usageRecorder: usageRecorder}
 	return k8s.NewPatchingReconciler(log, client, &serviceInstanceReconciler)
 }
 
@@ -145,6 +147,11 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceInstance *k
 
 	cfServiceInstance.Status.CredentialsObservedVersion = credentialsSecret.ResourceVersion
 
+	if err = r.usageRecorder.RecordServiceInstanceUsage(ctx, cfServiceInstance); err != nil {
+		log.Info("failed to record the service instance usage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -162,6 +169,10 @@ func (r *Reconciler) finalizeCFServiceInstance(
 		return ctrl.Result{}, err
 	}
 
+	if err := r.usageRecorder.RecordServiceInstanceDeleted(ctx, serviceInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	controllerutil.RemoveFinalizer(serviceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName)
 	log.V(1).Info("finalizer removed")
 
@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -144,6 +145,46 @@ var _ = Describe("CFServiceInstance", func() {
 				}).Should(Succeed())
 			})
 
+			It("records a CREATED service usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listServiceUsageEvents(g, instance.Name)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Spec": Equal(korifiv1alpha1.CFServiceUsageEventSpec{
+							State: korifiv1alpha1.ServiceUsageEventStateCreated,
+							ServiceInstance: korifiv1alpha1.UsageEventServiceInstance{
+								GUID: instance.Name,
+								Name: "service-instance-name",
+								Type: korifiv1alpha1.UserProvidedServiceInstanceUsageType,
+							},
+							Space: korifiv1alpha1.UsageEventResource{GUID: testNamespace},
+						}),
+					})))
+				}).Should(Succeed())
+			})
+
+			When("the instance is renamed", func() {
+				BeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+						g.Expect(instance.Status.Usage).NotTo(BeNil())
+					}).Should(Succeed())
+
+					Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+						instance.Spec.DisplayName = "renamed-service-instance"
+					})).To(Succeed())
+				})
+
+				It("records an UPDATED service usage event", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(listServiceUsageEvents(g, instance.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"State":           Equal(korifiv1alpha1.ServiceUsageEventStateUpdated),
+								"ServiceInstance": MatchFields(IgnoreExtras, Fields{"Name": Equal("renamed-service-instance")}),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+			})
+
 			When("the credentials secret changes", func() {
 				var secretVersion string
 
@@ -217,6 +258,25 @@ var _ = Describe("CFServiceInstance", func() {
 					}).Should(Succeed())
 				})
 
+				When("the instance creation has been recorded", func() {
+					BeforeEach(func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+							g.Expect(instance.Status.Usage).NotTo(BeNil())
+						}).Should(Succeed())
+					})
+
+					It("records a DELETED service usage event", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(listServiceUsageEvents(g, instance.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+								"Spec": MatchFields(IgnoreExtras, Fields{
+									"State": Equal(korifiv1alpha1.ServiceUsageEventStateDeleted),
+								}),
+							})))
+						}).Should(Succeed())
+					})
+				})
+
 				When("the instance has bindings", func() {
 					var binding *korifiv1alpha1.CFServiceBinding
 
@@ -281,3 +341,17 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 })
+
+func listServiceUsageEvents(g Gomega, serviceInstanceGUID string) []korifiv1alpha1.CFServiceUsageEvent {
+	var usageEvents korifiv1alpha1.CFServiceUsageEventList
+	g.Expect(adminClient.List(ctx, &usageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+
+	var result []korifiv1alpha1.CFServiceUsageEvent
+	for _, usageEvent := range usageEvents.Items {
+		if usageEvent.Spec.ServiceInstance.GUID == serviceInstanceGUID {
+			result = append(result, usageEvent)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,12 @@
+package instances
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+)
+
+type UsageRecorder interface {
+	RecordServiceInstanceUsage(context.Context, *korifiv1alpha1.CFServiceInstance) error
+	RecordServiceInstanceDeleted(context.Context, *korifiv1alpha1.CFServiceInstance) error
+}
@@ -58,7 +58,7 @@ type ServiceBindingAssets struct {
 func (r *Assets) GetServiceBindingAssets(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) (ServiceBindingAssets, error) {
 	serviceInstance := &korifiv1alpha1.CFServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: serviceBinding.Namespace,
+			Namespace: serviceBinding.ServiceInstanceNamespace(),
 			Name:      serviceBinding.Spec.Service.Name,
 		},
 	}
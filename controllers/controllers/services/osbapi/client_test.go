@@ -236,6 +236,145 @@ var _ = Describe("OSBAPI Client", func() {
 			})
 		})
 
+		Describe("Update", func() {
+			var (
+				updateResp osbapi.UpdateResponse
+				updateErr  error
+			)
+
+			BeforeEach(func() {
+				brokerServer.WithResponse(
+					"/v2/service_instances/{id}",
+					map[string]any{},
+					http.StatusOK,
+				)
+			})
+
+			JustBeforeEach(func() {
+				updateResp, updateErr = brokerClient.Update(ctx, osbapi.UpdatePayload{
+					InstanceID: "my-service-instance",
+					UpdateRequest: osbapi.UpdateRequest{
+						ServiceId: "service-guid",
+						PlanID:    "new-plan-guid",
+						Parameters: map[string]any{
+							"foo": "bar",
+						},
+						PreviousValues: osbapi.PreviousValues{
+							ServiceId: "service-guid",
+							PlanID:    "plan-guid",
+							SpaceGUID: "space-guid",
+							OrgGUID:   "org-guid",
+							MaintenanceInfo: &osbapi.MaintenanceInfo{
+								Version: "1.0.0",
+							},
+						},
+						MaintenanceInfo: &osbapi.MaintenanceInfo{
+							Version: "2.0.0",
+						},
+					},
+				})
+			})
+
+			It("sends async update request to broker", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				requests := brokerServer.ServedRequests()
+
+				Expect(requests).To(HaveLen(1))
+
+				Expect(requests[0].Method).To(Equal(http.MethodPatch))
+				Expect(requests[0].URL.Path).To(Equal("/v2/service_instances/my-service-instance"))
+
+				Expect(requests[0].URL.Query().Get("accepts_incomplete")).To(Equal("true"))
+			})
+
+			It("sends correct request body", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				requests := brokerServer.ServedRequests()
+
+				Expect(requests).To(HaveLen(1))
+
+				requestBytes, err := io.ReadAll(requests[0].Body)
+				Expect(err).NotTo(HaveOccurred())
+				requestBody := map[string]any{}
+				Expect(json.Unmarshal(requestBytes, &requestBody)).To(Succeed())
+
+				Expect(requestBody).To(MatchAllKeys(Keys{
+					"service_id": Equal("service-guid"),
+					"plan_id":    Equal("new-plan-guid"),
+					"parameters": MatchAllKeys(Keys{
+						"foo": Equal("bar"),
+					}),
+					"previous_values": MatchAllKeys(Keys{
+						"service_id":      Equal("service-guid"),
+						"plan_id":         Equal("plan-guid"),
+						"space_id":        Equal("space-guid"),
+						"organization_id": Equal("org-guid"),
+						"maintenance_info": MatchAllKeys(Keys{
+							"version": Equal("1.0.0"),
+						}),
+					}),
+					"maintenance_info": MatchAllKeys(Keys{
+						"version": Equal("2.0.0"),
+					}),
+				}))
+			})
+
+			It("updates the service synchronously", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(updateResp).To(Equal(osbapi.UpdateResponse{}))
+			})
+
+			When("the broker accepts the update request", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{id}",
+						map[string]any{
+							"operation": "update_op1",
+						},
+						http.StatusAccepted,
+					)
+				})
+
+				It("updates the service asynchronously", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(updateResp).To(Equal(osbapi.UpdateResponse{
+						IsAsync:   true,
+						Operation: "update_op1",
+					}))
+				})
+			})
+
+			When("the update request fails with 400 BadRequest error", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse("/v2/service_instances/{id}", nil, http.StatusBadRequest)
+				})
+
+				It("returns an unrecoverable error", func() {
+					Expect(updateErr).To(Equal(osbapi.UnrecoverableError{Status: http.StatusBadRequest}))
+				})
+			})
+
+			When("the update request fails with 422 Unprocessable entity error", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse("/v2/service_instances/{id}", nil, http.StatusUnprocessableEntity)
+				})
+
+				It("returns an unrecoverable error", func() {
+					Expect(updateErr).To(Equal(osbapi.UnrecoverableError{Status: http.StatusUnprocessableEntity}))
+				})
+			})
+
+			When("the update request fails", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse("/v2/service_instances/{id}", nil, http.StatusInternalServerError)
+				})
+
+				It("returns an error", func() {
+					Expect(updateErr).To(MatchError(ContainSubstring("update request failed")))
+				})
+			})
+		})
+
 		Describe("Deprovision", func() {
 			var (
 				deprovisionResp osbapi.ProvisionResponse
@@ -540,6 +679,25 @@ var _ = Describe("OSBAPI Client", func() {
 				}))
 			})
 
+			When("the broker returns a route service url", func() {
+				BeforeEach(func() {
+					brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						map[string]any{
+							"route_service_url": "https://route-service.example.com",
+						},
+						http.StatusCreated,
+					)
+				})
+
+				It("returns the route service url", func() {
+					Expect(bindErr).NotTo(HaveOccurred())
+					Expect(bindResp).To(Equal(osbapi.BindResponse{
+						RouteServiceURL: "https://route-service.example.com",
+					}))
+				})
+			})
+
 			When("bind is asynchronous", func() {
 				BeforeEach(func() {
 					brokerServer.WithResponse(
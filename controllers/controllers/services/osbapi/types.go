@@ -83,6 +83,32 @@ type ProvisionResponse struct {
 	Operation string `json:"operation,omitempty"`
 }
 
+type UpdatePayload struct {
+	InstanceID string
+	UpdateRequest
+}
+
+type UpdateRequest struct {
+	ServiceId       string           `json:"service_id"`
+	PlanID          string           `json:"plan_id,omitempty"`
+	Parameters      map[string]any   `json:"parameters,omitempty"`
+	PreviousValues  PreviousValues   `json:"previous_values"`
+	MaintenanceInfo *MaintenanceInfo `json:"maintenance_info,omitempty"`
+}
+
+type PreviousValues struct {
+	ServiceId       string           `json:"service_id"`
+	PlanID          string           `json:"plan_id,omitempty"`
+	SpaceGUID       string           `json:"space_id"`
+	OrgGUID         string           `json:"organization_id"`
+	MaintenanceInfo *MaintenanceInfo `json:"maintenance_info,omitempty"`
+}
+
+type UpdateResponse struct {
+	IsAsync   bool
+	Operation string `json:"operation,omitempty"`
+}
+
 type GetBindingRequest struct {
 	InstanceID string
 	BindingID  string
@@ -135,9 +161,10 @@ type BindPayload struct {
 }
 
 type BindResponse struct {
-	Credentials map[string]any `json:"credentials"`
-	Operation   string         `json:"operation"`
-	IsAsync     bool
+	Credentials     map[string]any `json:"credentials"`
+	RouteServiceURL string         `json:"route_service_url"`
+	Operation       string         `json:"operation"`
+	IsAsync         bool
 }
 
 type BindingResponse struct {
@@ -146,6 +173,7 @@ type BindingResponse struct {
 
 type BindResource struct {
 	AppGUID string `json:"app_guid"`
+	Route   string `json:"route,omitempty"`
 }
 
 type UnbindPayload struct {
@@ -0,0 +1,344 @@
+package routebindings
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type Reconciler struct {
+	k8sClient           client.Client
+	osbapiClientFactory osbapi.BrokerClientFactory
+	assets              *osbapi.Assets
+	log                 logr.Logger
+}
+
+func NewReconciler(
+	k8sClient client.Client,
+	brokerClientFactory osbapi.BrokerClientFactory,
+	rootNamespace string,
+	log logr.Logger,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceRouteBinding] {
+	routeBindingReconciler := &Reconciler{
+		k8sClient:           k8sClient,
+		osbapiClientFactory: brokerClientFactory,
+		assets:              osbapi.NewAssets(k8sClient, rootNamespace),
+		log:                 log,
+	}
+	return k8s.NewPatchingReconciler(log, k8sClient, routeBindingReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFServiceRouteBinding{}).
+		Watches(
+			&korifiv1alpha1.CFServiceInstance{},
+			handler.EnqueueRequestsFromMapFunc(r.serviceInstanceToRouteBindings),
+		)
+}
+
+func (r *Reconciler) serviceInstanceToRouteBindings(ctx context.Context, o client.Object) []reconcile.Request {
+	serviceInstance := o.(*korifiv1alpha1.CFServiceInstance)
+
+	routeBindings := korifiv1alpha1.CFServiceRouteBindingList{}
+	if err := r.k8sClient.List(ctx, &routeBindings,
+		client.InNamespace(serviceInstance.Namespace),
+		client.MatchingFields{shared.IndexServiceRouteBindingServiceInstanceGUID: serviceInstance.Name},
+	); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, rb := range routeBindings.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      rb.Name,
+				Namespace: rb.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceroutebindings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceroutebindings/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceroutebindings/finalizers,verbs=update
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, routeBinding *korifiv1alpha1.CFServiceRouteBinding) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	routeBinding.Status.ObservedGeneration = routeBinding.Generation
+	log.V(1).Info("set observed generation", "generation", routeBinding.Status.ObservedGeneration)
+
+	if !routeBinding.GetDeletionTimestamp().IsZero() {
+		return r.finalize(ctx, routeBinding)
+	}
+
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: routeBinding.Namespace, Name: routeBinding.Spec.ServiceInstanceRef.Name}, serviceInstance)
+	if err != nil {
+		log.Info("service instance not found", "service-instance", routeBinding.Spec.ServiceInstanceRef.Name, "error", err)
+		return ctrl.Result{}, err
+	}
+
+	cfRoute := &korifiv1alpha1.CFRoute{}
+	err = r.k8sClient.Get(ctx, types.NamespacedName{Namespace: routeBinding.Namespace, Name: routeBinding.Spec.RouteRef.Name}, cfRoute)
+	if err != nil {
+		log.Info("route not found", "route", routeBinding.Spec.RouteRef.Name, "error", err)
+		return ctrl.Result{}, err
+	}
+
+	if serviceInstance.Spec.Type == korifiv1alpha1.UserProvidedType {
+		routeBinding.Status.RouteServiceURL = serviceInstance.Spec.RouteServiceURL
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcileManaged(ctx, routeBinding, serviceInstance, cfRoute)
+}
+
+func (r *Reconciler) reconcileManaged(
+	ctx context.Context,
+	routeBinding *korifiv1alpha1.CFServiceRouteBinding,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	cfRoute *korifiv1alpha1.CFRoute,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcile-managed-route-binding")
+
+	if isBound(routeBinding) {
+		return ctrl.Result{}, nil
+	}
+
+	if meta.IsStatusConditionTrue(routeBinding.Status.Conditions, korifiv1alpha1.BindingFailedCondition) {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingFailed").WithNoRequeue()
+	}
+
+	assets, err := r.assets.GetServiceInstanceAssets(ctx, serviceInstance)
+	if err != nil {
+		log.Error(err, "failed to get service instance assets")
+		return ctrl.Result{}, err
+	}
+
+	osbapiClient, err := r.osbapiClientFactory.CreateClient(ctx, assets.ServiceBroker)
+	if err != nil {
+		log.Error(err, "failed to create broker client", "broker", assets.ServiceBroker.Name)
+		return ctrl.Result{}, err
+	}
+
+	parameters, err := r.getParameters(ctx, routeBinding)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("InvalidParameters")
+	}
+
+	bindResponse, err := osbapiClient.Bind(ctx, osbapi.BindPayload{
+		BindingID:  routeBinding.Name,
+		InstanceID: serviceInstance.Name,
+		BindRequest: osbapi.BindRequest{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+			BindResource: osbapi.BindResource{
+				Route: cfRoute.Status.URI,
+			},
+			Parameters: parameters,
+		},
+	})
+	if err != nil {
+		log.Error(err, "failed to bind")
+
+		if osbapi.IsUnrecoveralbeError(err) {
+			setFailedCondition(routeBinding, korifiv1alpha1.BindingFailedCondition, "BindingFailed", err.Error())
+			return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingFailed")
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	if bindResponse.IsAsync {
+		lastOpResponse, err := r.pollLastOperation(ctx, routeBinding, assets, osbapiClient, bindResponse.Operation)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if lastOpResponse.State == "failed" {
+			setFailedCondition(routeBinding, korifiv1alpha1.BindingFailedCondition, "BindingFailed", lastOpResponse.Description)
+			return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingFailed").WithMessage(lastOpResponse.Description)
+		}
+
+		// Once the asynchronous binding succeeds, binding again returns the
+		// route service url synchronously
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingInProgress").WithRequeue()
+	}
+
+	routeBinding.Status.RouteServiceURL = bindResponse.RouteServiceURL
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) getParameters(ctx context.Context, routeBinding *korifiv1alpha1.CFServiceRouteBinding) (map[string]any, error) {
+	if routeBinding.Spec.Parameters.Name == "" {
+		return nil, nil
+	}
+
+	paramsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: routeBinding.Namespace,
+			Name:      routeBinding.Spec.Parameters.Name,
+		},
+	}
+
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(paramsSecret), paramsSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return tools.FromParametersSecretData(paramsSecret.Data)
+}
+
+func (r *Reconciler) finalize(ctx context.Context, routeBinding *korifiv1alpha1.CFServiceRouteBinding) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("finalize-service-route-binding")
+
+	if !controllerutil.ContainsFinalizer(routeBinding, korifiv1alpha1.CFServiceRouteBindingFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	err := r.unbind(ctx, routeBinding)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if controllerutil.RemoveFinalizer(routeBinding, korifiv1alpha1.CFServiceRouteBindingFinalizerName) {
+		log.V(1).Info("finalizer removed")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) unbind(ctx context.Context, routeBinding *korifiv1alpha1.CFServiceRouteBinding) error {
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: routeBinding.Namespace, Name: routeBinding.Spec.ServiceInstanceRef.Name}, serviceInstance)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get service instance: %w", err)
+	}
+
+	if serviceInstance.Spec.Type == korifiv1alpha1.UserProvidedType {
+		return nil
+	}
+
+	if serviceInstance.Annotations[korifiv1alpha1.DeprovisionWithoutBrokerAnnotation] == "true" {
+		return nil
+	}
+
+	assets, err := r.assets.GetServiceInstanceAssets(ctx, serviceInstance)
+	if err != nil {
+		return fmt.Errorf("failed to get service instance assets: %w", err)
+	}
+
+	osbapiClient, err := r.osbapiClientFactory.CreateClient(ctx, assets.ServiceBroker)
+	if err != nil {
+		return fmt.Errorf("failed to client for broker %q: %w", assets.ServiceBroker.Name, err)
+	}
+
+	unbindResponse, err := osbapiClient.Unbind(ctx, osbapi.UnbindPayload{
+		InstanceID: serviceInstance.Name,
+		BindingID:  routeBinding.Name,
+		UnbindRequestParameters: osbapi.UnbindRequestParameters{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+		},
+	})
+	if osbapi.IgnoreGone(err) != nil {
+		if osbapi.IsUnrecoveralbeError(err) {
+			setFailedCondition(routeBinding, korifiv1alpha1.UnbindingFailedCondition, "UnbindingFailed", err.Error())
+			return k8s.NewNotReadyError().WithReason("UnbindingFailed")
+		}
+
+		return fmt.Errorf("failed to unbind: %w", err)
+	}
+
+	if !unbindResponse.IsAsync {
+		return nil
+	}
+
+	lastOpResponse, err := r.pollLastOperation(ctx, routeBinding, assets, osbapiClient, unbindResponse.Operation)
+	if err != nil {
+		return err
+	}
+
+	if lastOpResponse.State == "succeeded" {
+		return nil
+	}
+
+	if lastOpResponse.State == "failed" {
+		setFailedCondition(routeBinding, korifiv1alpha1.UnbindingFailedCondition, "UnbindingFailed", lastOpResponse.Description)
+		return k8s.NewNotReadyError().WithReason("UnbindingFailed")
+	}
+
+	return k8s.NewNotReadyError().WithReason("UnbindingInProgress").WithRequeue()
+}
+
+func (r *Reconciler) pollLastOperation(
+	ctx context.Context,
+	routeBinding *korifiv1alpha1.CFServiceRouteBinding,
+	assets osbapi.ServiceInstanceAssets,
+	osbapiClient osbapi.BrokerClient,
+	operationID string,
+) (osbapi.LastOperationResponse, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("poll-operation")
+
+	lastOpResponse, err := osbapiClient.GetServiceBindingLastOperation(ctx, osbapi.GetBindingLastOperationRequest{
+		InstanceID: routeBinding.Spec.ServiceInstanceRef.Name,
+		BindingID:  routeBinding.Name,
+		GetLastOperationRequestParameters: osbapi.GetLastOperationRequestParameters{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+			Operation: operationID,
+		},
+	})
+	if err != nil {
+		log.Error(err, "getting service route binding last operation failed")
+		return osbapi.LastOperationResponse{}, k8s.NewNotReadyError().WithCause(err).WithReason("GetLastOperationFailed")
+	}
+
+	if lastOpResponse.State == "in progress" {
+		return osbapi.LastOperationResponse{}, k8s.NewNotReadyError().WithReason("OperationInProgress").WithRequeue()
+	}
+
+	return lastOpResponse, nil
+}
+
+func setFailedCondition(routeBinding *korifiv1alpha1.CFServiceRouteBinding, conditionType, reason, message string) {
+	meta.SetStatusCondition(&routeBinding.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: routeBinding.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+func isBound(routeBinding *korifiv1alpha1.CFServiceRouteBinding) bool {
+	return meta.IsStatusConditionTrue(routeBinding.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+}
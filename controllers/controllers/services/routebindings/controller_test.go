@@ -0,0 +1,516 @@
+package routebindings_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFServiceRouteBinding", func() {
+	var (
+		testNamespace string
+		instanceGUID  string
+		cfRoute       *korifiv1alpha1.CFRoute
+		routeBinding  *korifiv1alpha1.CFServiceRouteBinding
+	)
+
+	BeforeEach(func() {
+		testNamespace = uuid.NewString()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testNamespace,
+			},
+		})).To(Succeed())
+
+		instanceGUID = uuid.NewString()
+
+		cfRoute = &korifiv1alpha1.CFRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFRouteSpec{
+				Host: "my-app",
+				Path: "/my-path",
+				DomainRef: corev1.ObjectReference{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfRoute)).To(Succeed())
+		Expect(k8s.Patch(ctx, adminClient, cfRoute, func() {
+			cfRoute.Status.URI = "my-app.example.com/my-path"
+		})).To(Succeed())
+
+		routeBinding = &korifiv1alpha1.CFServiceRouteBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+				Finalizers: []string{
+					korifiv1alpha1.CFServiceRouteBindingFinalizerName,
+				},
+			},
+			Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+				ServiceInstanceRef: corev1.LocalObjectReference{
+					Name: instanceGUID,
+				},
+				RouteRef: corev1.LocalObjectReference{
+					Name: cfRoute.Name,
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, routeBinding)).To(Succeed())
+	})
+
+	Describe("user-provided route bindings", func() {
+		var instance *korifiv1alpha1.CFServiceInstance
+
+		BeforeEach(func() {
+			instance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      instanceGUID,
+					Namespace: testNamespace,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName:     "route-service",
+					Type:            "user-provided",
+					RouteServiceURL: "https://route-service.example.com",
+				},
+			}
+			Expect(adminClient.Create(ctx, instance)).To(Succeed())
+		})
+
+		It("sets the ObservedGeneration status field", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+				g.Expect(routeBinding.Status.ObservedGeneration).To(Equal(routeBinding.Generation))
+			}).Should(Succeed())
+		})
+
+		It("sets the route service url from the service instance", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+				g.Expect(routeBinding.Status.RouteServiceURL).To(Equal("https://route-service.example.com"))
+			}).Should(Succeed())
+		})
+
+		It("sets the Ready condition to true", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+				g.Expect(routeBinding.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionTrue)),
+				)))
+			}).Should(Succeed())
+		})
+
+		It("does not contact any broker", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(brokerClientFactory.CreateClientCallCount()).To(BeZero())
+			}).Should(Succeed())
+		})
+
+		When("the service instance route service url changes", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+					g.Expect(routeBinding.Status.RouteServiceURL).NotTo(BeEmpty())
+				}).Should(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.RouteServiceURL = "https://another-route-service.example.com"
+				})).To(Succeed())
+			})
+
+			It("updates the route binding route service url", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+					g.Expect(routeBinding.Status.RouteServiceURL).To(Equal("https://another-route-service.example.com"))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the route does not exist", func() {
+			BeforeEach(func() {
+				Expect(adminClient.Delete(ctx, cfRoute)).To(Succeed())
+			})
+
+			It("sets the Ready condition to false", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+					g.Expect(routeBinding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+					)))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the route binding is deleted", func() {
+			JustBeforeEach(func() {
+				Expect(adminClient.Delete(ctx, routeBinding)).To(Succeed())
+			})
+
+			It("is deleted", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	Describe("managed route bindings", func() {
+		var (
+			brokerClient *fake.BrokerClient
+
+			serviceBroker   *korifiv1alpha1.CFServiceBroker
+			serviceOffering *korifiv1alpha1.CFServiceOffering
+			servicePlan     *korifiv1alpha1.CFServicePlan
+			instance        *korifiv1alpha1.CFServiceInstance
+		)
+
+		BeforeEach(func() {
+			serviceBroker = &korifiv1alpha1.CFServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceBrokerSpec{
+					Name: "my-service-broker",
+					Credentials: corev1.LocalObjectReference{
+						Name: "my-broker-secret",
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, serviceBroker)).To(Succeed())
+
+			serviceOffering = &korifiv1alpha1.CFServiceOffering{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+					Labels: map[string]string{
+						korifiv1alpha1.RelServiceBrokerGUIDLabel: serviceBroker.Name,
+					},
+				},
+				Spec: korifiv1alpha1.CFServiceOfferingSpec{
+					BrokerCatalog: korifiv1alpha1.ServiceBrokerCatalog{
+						ID: "service-offering-id",
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, serviceOffering)).To(Succeed())
+
+			servicePlan = &korifiv1alpha1.CFServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+					Labels: map[string]string{
+						korifiv1alpha1.RelServiceBrokerGUIDLabel:   serviceBroker.Name,
+						korifiv1alpha1.RelServiceOfferingGUIDLabel: serviceOffering.Name,
+					},
+				},
+				Spec: korifiv1alpha1.CFServicePlanSpec{
+					Visibility: korifiv1alpha1.ServicePlanVisibility{
+						Type: "public",
+					},
+					BrokerCatalog: korifiv1alpha1.ServicePlanBrokerCatalog{
+						ID: "service-plan-id",
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, servicePlan)).To(Succeed())
+
+			brokerClient = new(fake.BrokerClient)
+			brokerClientFactory.CreateClientReturns(brokerClient, nil)
+
+			brokerClient.BindReturns(osbapi.BindResponse{
+				RouteServiceURL: "https://broker-route-service.example.com",
+			}, nil)
+
+			instance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      instanceGUID,
+					Namespace: testNamespace,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "route-service",
+					Type:        "managed",
+					PlanGUID:    servicePlan.Name,
+				},
+			}
+			Expect(adminClient.Create(ctx, instance)).To(Succeed())
+		})
+
+		It("binds the route to the service instance", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(brokerClient.BindCallCount()).To(BeNumerically(">", 0))
+				_, payload := brokerClient.BindArgsForCall(0)
+				g.Expect(payload).To(Equal(osbapi.BindPayload{
+					InstanceID: instance.Name,
+					BindingID:  routeBinding.Name,
+					BindRequest: osbapi.BindRequest{
+						ServiceId: "service-offering-id",
+						PlanID:    "service-plan-id",
+						BindResource: osbapi.BindResource{
+							Route: "my-app.example.com/my-path",
+						},
+					},
+				}))
+			}).Should(Succeed())
+		})
+
+		It("sets the route service url returned by the broker", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+				g.Expect(routeBinding.Status.RouteServiceURL).To(Equal("https://broker-route-service.example.com"))
+				g.Expect(routeBinding.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(metav1.ConditionTrue)),
+				)))
+			}).Should(Succeed())
+		})
+
+		When("the route binding has parameters", func() {
+			BeforeEach(func() {
+				paramsSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+					},
+					Data: map[string][]byte{
+						"parameters": []byte(`{"p1":"p1-value"}`),
+					},
+				}
+				Expect(adminClient.Create(ctx, paramsSecret)).To(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, routeBinding, func() {
+					routeBinding.Spec.Parameters.Name = paramsSecret.Name
+				})).To(Succeed())
+			})
+
+			It("sends them to the broker on bind", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.BindCallCount()).To(BeNumerically(">", 0))
+					_, payload := brokerClient.BindArgsForCall(brokerClient.BindCallCount() - 1)
+					g.Expect(payload.Parameters).To(Equal(map[string]any{
+						"p1": "p1-value",
+					}))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the binding is asynchronous", func() {
+			BeforeEach(func() {
+				brokerClient.BindReturns(osbapi.BindResponse{
+					IsAsync:   true,
+					Operation: "operation-1",
+				}, nil)
+				brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{
+					State: "in progress",
+				}, nil)
+			})
+
+			It("sets the ready condition to false", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+					g.Expect(routeBinding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+					)))
+				}).Should(Succeed())
+			})
+
+			It("checks the binding last operation", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.GetServiceBindingLastOperationCallCount()).To(BeNumerically(">", 0))
+					_, lastOp := brokerClient.GetServiceBindingLastOperationArgsForCall(0)
+					g.Expect(lastOp).To(Equal(osbapi.GetBindingLastOperationRequest{
+						InstanceID: instance.Name,
+						BindingID:  routeBinding.Name,
+						GetLastOperationRequestParameters: osbapi.GetLastOperationRequestParameters{
+							ServiceId: "service-offering-id",
+							PlanID:    "service-plan-id",
+							Operation: "operation-1",
+						},
+					}))
+				}).Should(Succeed())
+			})
+
+			When("the last operation has failed", func() {
+				BeforeEach(func() {
+					brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{
+						State:       "failed",
+						Description: "binding-failed",
+					}, nil)
+				})
+
+				It("fails the route binding", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+						g.Expect(routeBinding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+							HasStatus(Equal(metav1.ConditionTrue)),
+							HasMessage(Equal("binding-failed")),
+						)))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the last operation has succeeded", func() {
+				BeforeEach(func() {
+					brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{
+						State: "succeeded",
+					}, nil)
+					brokerClient.BindStub = func(context.Context, osbapi.BindPayload) (osbapi.BindResponse, error) {
+						if brokerClient.GetServiceBindingLastOperationCallCount() == 0 {
+							return osbapi.BindResponse{IsAsync: true, Operation: "operation-1"}, nil
+						}
+						return osbapi.BindResponse{RouteServiceURL: "https://broker-route-service.example.com"}, nil
+					}
+				})
+
+				It("sets the route service url returned by the broker", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+						g.Expect(routeBinding.Status.RouteServiceURL).To(Equal("https://broker-route-service.example.com"))
+						g.Expect(routeBinding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+							HasStatus(Equal(metav1.ConditionTrue)),
+						)))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("bind fails with unrecoverable error", func() {
+			BeforeEach(func() {
+				brokerClient.BindReturns(osbapi.BindResponse{}, osbapi.UnrecoverableError{Status: http.StatusUnprocessableEntity})
+			})
+
+			It("fails the route binding", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+					g.Expect(routeBinding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+				}).Should(Succeed())
+			})
+		})
+
+		When("bind fails with recoverable error", func() {
+			BeforeEach(func() {
+				brokerClient.BindReturns(osbapi.BindResponse{}, errors.New("bind-failed"))
+			})
+
+			It("keeps trying to bind", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.BindCallCount()).To(BeNumerically(">", 1))
+				}).Should(Succeed())
+			})
+		})
+
+		Describe("route binding deletion", func() {
+			BeforeEach(func() {
+				brokerClient.UnbindReturns(osbapi.UnbindResponse{}, nil)
+			})
+
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(routeBinding.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				}).Should(Succeed())
+
+				Expect(adminClient.Delete(ctx, routeBinding)).To(Succeed())
+			})
+
+			It("unbinds the route with the broker", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(brokerClient.UnbindCallCount()).NotTo(BeZero())
+					_, actualUnbindRequest := brokerClient.UnbindArgsForCall(0)
+					g.Expect(actualUnbindRequest).To(Equal(osbapi.UnbindPayload{
+						BindingID:  routeBinding.Name,
+						InstanceID: instance.Name,
+						UnbindRequestParameters: osbapi.UnbindRequestParameters{
+							ServiceId: "service-offering-id",
+							PlanID:    "service-plan-id",
+						},
+					}))
+				}).Should(Succeed())
+			})
+
+			It("deletes the route binding", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			When("deprovision without broker is requested", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+						instance.Annotations = map[string]string{
+							korifiv1alpha1.DeprovisionWithoutBrokerAnnotation: "true",
+						}
+					})).To(Succeed())
+				})
+
+				It("does not contact the broker for unbinding", func() {
+					Consistently(func(g Gomega) {
+						g.Expect(brokerClient.UnbindCallCount()).To(BeZero())
+					}).Should(Succeed())
+				})
+			})
+
+			When("the unbind is asynchronous", func() {
+				BeforeEach(func() {
+					brokerClient.UnbindReturns(osbapi.UnbindResponse{
+						IsAsync:   true,
+						Operation: "unbind-op",
+					}, nil)
+					brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{
+						State: "in progress",
+					}, nil)
+				})
+
+				It("does not delete the route binding", func() {
+					Consistently(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+					}).Should(Succeed())
+				})
+
+				When("the last operation is succeeded", func() {
+					BeforeEach(func() {
+						brokerClient.GetServiceBindingLastOperationReturns(osbapi.LastOperationResponse{
+							State: "succeeded",
+						}, nil)
+					})
+
+					It("deletes the route binding", func() {
+						Eventually(func(g Gomega) {
+							err := adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)
+							g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+						}).Should(Succeed())
+					})
+				})
+			})
+		})
+	})
+})
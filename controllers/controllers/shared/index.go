@@ -12,16 +12,18 @@ import (
 )
 
 const (
-	IndexRouteDestinationAppName              = "destinationAppName"
-	IndexRouteDomainQualifiedName             = "domainQualifiedName"
-	IndexServiceInstanceCredentialsSecretName = "serviceInstanceCredentialsSecretName"
-	IndexServiceBindingAppGUID                = "serviceBindingAppGUID"
-	IndexServiceBindingServiceInstanceGUID    = "serviceBindingServiceInstanceGUID"
-	IndexAppTasks                             = "appTasks"
-	IndexSpaceNamespaceName                   = "spaceNamespace"
-	IndexOrgNamespaceName                     = "orgNamespace"
-	IndexServiceBrokerCredentialsSecretName   = "serviceBrokerCredentialsSecretName"
-	IndexServiceInstancePlanGUID              = "serviceInstancePlanGUID"
+	IndexRouteDestinationAppName                = "destinationAppName"
+	IndexRouteDomainQualifiedName               = "domainQualifiedName"
+	IndexServiceInstanceCredentialsSecretName   = "serviceInstanceCredentialsSecretName"
+	IndexServiceBindingAppGUID                  = "serviceBindingAppGUID"
+	IndexServiceBindingServiceInstanceGUID      = "serviceBindingServiceInstanceGUID"
+	IndexAppTasks                               = "appTasks"
+	IndexSpaceNamespaceName                     = "spaceNamespace"
+	IndexOrgNamespaceName                       = "orgNamespace"
+	IndexServiceBrokerCredentialsSecretName     = "serviceBrokerCredentialsSecretName"
+	IndexServiceInstancePlanGUID                = "serviceInstancePlanGUID"
+	IndexServiceRouteBindingRouteGUID           = "serviceRouteBindingRouteGUID"
+	IndexServiceRouteBindingServiceInstanceGUID = "serviceRouteBindingServiceInstanceGUID"
 )
 
 func SetupIndexWithManager(mgr manager.Manager) error {
@@ -93,6 +95,22 @@ func SetupIndexWithManager(mgr manager.Manager) error {
 		return err
 	}
 
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &korifiv1alpha1.CFServiceRouteBinding{}, IndexServiceRouteBindingRouteGUID, func(object client.Object) []string {
+		serviceRouteBinding := object.(*korifiv1alpha1.CFServiceRouteBinding)
+		return []string{serviceRouteBinding.Spec.RouteRef.Name}
+	})
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), &korifiv1alpha1.CFServiceRouteBinding{}, IndexServiceRouteBindingServiceInstanceGUID, func(object client.Object) []string {
+		serviceRouteBinding := object.(*korifiv1alpha1.CFServiceRouteBinding)
+		return []string{serviceRouteBinding.Spec.ServiceInstanceRef.Name}
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
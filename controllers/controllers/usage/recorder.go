@@ -0,0 +1,320 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Recorder records the app and service usage events that billing systems
+// consume via the /v3/app_usage_events and /v3/service_usage_events
+// endpoints. Events are created in the root namespace.
+type Recorder struct {
+	k8sClient     client.Client
+	rootNamespace string
+	assets        *osbapi.Assets
+}
+
+func NewRecorder(k8sClient client.Client, rootNamespace string) *Recorder {
+	return &Recorder{
+		k8sClient:     k8sClient,
+		rootNamespace: rootNamespace,
+		assets:        osbapi.NewAssets(k8sClient, rootNamespace),
+	}
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfappusageevents,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceusageevents,verbs=get;list;watch;create
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceplans,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceofferings,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebrokers,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// RecordProcessUsage records an app usage event when the usage of the process
+// differs from the one recorded in its status, which is then updated. A
+// STARTED or STOPPED event is recorded when the desired state of the app
+// changes and a SCALED event when a started process is scaled.
+func (r *Recorder) RecordProcessUsage(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) error {
+	current := processUsage(cfApp, cfProcess)
+
+	state := appUsageEventState(cfProcess.Status.Usage, current)
+	if state != "" {
+		if err := r.createAppUsageEvent(ctx, cfApp, cfProcess, state, cfProcess.Status.Usage, current); err != nil {
+			return err
+		}
+	}
+
+	cfProcess.Status.Usage = &current
+	return nil
+}
+
+// RecordProcessStopped records a STOPPED app usage event for a started process
+// that stops running for a reason other than its app being stopped, e.g. the
+// app being deleted. The event name is derived from the process, so that
+// retrying to record it does not create duplicate events.
+func (r *Recorder) RecordProcessStopped(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) error {
+	previous := cfProcess.Status.Usage
+	if previous == nil || previous.State != korifiv1alpha1.AppUsageEventStateStarted {
+		return nil
+	}
+
+	current := korifiv1alpha1.ProcessUsage{
+		State:     korifiv1alpha1.AppUsageEventStateStopped,
+		Instances: previous.Instances,
+		MemoryMB:  previous.MemoryMB,
+	}
+	eventName := tools.NamespacedUUID(cfProcess.Name, string(cfProcess.UID), korifiv1alpha1.AppUsageEventStateStopped)
+	if err := r.createNamedAppUsageEvent(ctx, eventName, cfApp, cfProcess, current.State, previous, current); err != nil {
+		return err
+	}
+
+	cfProcess.Status.Usage = &current
+	return nil
+}
+
+// SeedProcessUsage records a STARTED app usage event for a process of a
+// started app regardless of the usage recorded in the process status. It is
+// used to reseed the app usage events after they have been purged.
+func (r *Recorder) SeedProcessUsage(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) error {
+	current := processUsage(cfApp, cfProcess)
+	if current.State != korifiv1alpha1.AppUsageEventStateStarted {
+		return nil
+	}
+
+	return r.createNamedAppUsageEvent(ctx, uuid.NewString(), cfApp, cfProcess, current.State, nil, current)
+}
+
+func processUsage(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) korifiv1alpha1.ProcessUsage {
+	state := korifiv1alpha1.AppUsageEventStateStopped
+	if cfApp.Spec.DesiredState == korifiv1alpha1.StartedState {
+		state = korifiv1alpha1.AppUsageEventStateStarted
+	}
+
+	return korifiv1alpha1.ProcessUsage{
+		State:     state,
+		Instances: tools.ZeroIfNil(cfProcess.Spec.DesiredInstances),
+		MemoryMB:  cfProcess.Spec.MemoryMB,
+	}
+}
+
+func appUsageEventState(previous *korifiv1alpha1.ProcessUsage, current korifiv1alpha1.ProcessUsage) string {
+	if previous == nil {
+		if current.State == korifiv1alpha1.AppUsageEventStateStarted {
+			return korifiv1alpha1.AppUsageEventStateStarted
+		}
+		return ""
+	}
+
+	if previous.State != current.State {
+		return current.State
+	}
+
+	if current.State == korifiv1alpha1.AppUsageEventStateStarted &&
+		(previous.Instances != current.Instances || previous.MemoryMB != current.MemoryMB) {
+		return korifiv1alpha1.AppUsageEventStateScaled
+	}
+
+	return ""
+}
+
+// createAppUsageEvent names the event after the usage change it records and
+// the generations of the app and the process that caused it, so that
+// recording the same change again, e.g. when the process status update
+// fails, does not create duplicate events
+func (r *Recorder) createAppUsageEvent(
+	ctx context.Context,
+	cfApp *korifiv1alpha1.CFApp,
+	cfProcess *korifiv1alpha1.CFProcess,
+	state string,
+	previous *korifiv1alpha1.ProcessUsage,
+	current korifiv1alpha1.ProcessUsage,
+) error {
+	eventName := tools.NamespacedUUID(
+		cfProcess.Name,
+		string(cfProcess.UID),
+		state,
+		strconv.Itoa(int(current.Instances)),
+		strconv.FormatInt(current.MemoryMB, 10),
+		strconv.FormatInt(cfApp.Generation, 10),
+		strconv.FormatInt(cfProcess.Generation, 10),
+	)
+
+	return r.createNamedAppUsageEvent(ctx, eventName, cfApp, cfProcess, state, previous, current)
+}
+
+func (r *Recorder) createNamedAppUsageEvent(
+	ctx context.Context,
+	name string,
+	cfApp *korifiv1alpha1.CFApp,
+	cfProcess *korifiv1alpha1.CFProcess,
+	state string,
+	previous *korifiv1alpha1.ProcessUsage,
+	current korifiv1alpha1.ProcessUsage,
+) error {
+	space, orgGUID, err := r.getSpace(ctx, cfProcess.Namespace)
+	if err != nil {
+		return err
+	}
+
+	usageEvent := &korifiv1alpha1.CFAppUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      name,
+		},
+		Spec: korifiv1alpha1.CFAppUsageEventSpec{
+			State:                 state,
+			App:                   korifiv1alpha1.UsageEventResource{GUID: cfApp.Name, Name: cfApp.Spec.DisplayName},
+			Process:               korifiv1alpha1.UsageEventProcess{GUID: cfProcess.Name, Type: cfProcess.Spec.ProcessType},
+			Space:                 space,
+			OrganizationGUID:      orgGUID,
+			InstanceCount:         current.Instances,
+			MemoryInMBPerInstance: current.MemoryMB,
+		},
+	}
+
+	if previous != nil {
+		usageEvent.Spec.PreviousState = previous.State
+		usageEvent.Spec.PreviousInstanceCount = previous.Instances
+		usageEvent.Spec.PreviousMemoryInMBPerInstance = previous.MemoryMB
+	}
+
+	if err = r.k8sClient.Create(ctx, usageEvent); err != nil {
+		if k8serrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to create app usage event: %w", err)
+	}
+
+	return nil
+}
+
+// RecordServiceInstanceUsage records a CREATED service usage event the first
+// time it is called for a service instance and an UPDATED event whenever the
+// name or the plan of the instance changes afterwards. The status of the
+// instance is updated with the recorded usage.
+func (r *Recorder) RecordServiceInstanceUsage(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	current := korifiv1alpha1.ServiceInstanceUsage{
+		DisplayName: serviceInstance.Spec.DisplayName,
+		PlanGUID:    serviceInstance.Spec.PlanGUID,
+	}
+
+	previous := serviceInstance.Status.Usage
+	if previous != nil && *previous == current {
+		return nil
+	}
+
+	state := korifiv1alpha1.ServiceUsageEventStateUpdated
+	if previous == nil {
+		state = korifiv1alpha1.ServiceUsageEventStateCreated
+	}
+
+	if err := r.createServiceUsageEvent(ctx, serviceInstance, state); err != nil {
+		return err
+	}
+
+	serviceInstance.Status.Usage = &current
+	return nil
+}
+
+// RecordServiceInstanceDeleted records a DELETED service usage event for a
+// service instance whose creation has been recorded
+func (r *Recorder) RecordServiceInstanceDeleted(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	if serviceInstance.Status.Usage == nil {
+		return nil
+	}
+
+	return r.createServiceUsageEvent(ctx, serviceInstance, korifiv1alpha1.ServiceUsageEventStateDeleted)
+}
+
+// SeedServiceInstanceUsage records a CREATED service usage event for an
+// existing service instance. It is used to reseed the service usage events
+// after they have been purged.
+func (r *Recorder) SeedServiceInstanceUsage(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	return r.createServiceUsageEvent(ctx, serviceInstance, korifiv1alpha1.ServiceUsageEventStateCreated)
+}
+
+func (r *Recorder) createServiceUsageEvent(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance, state string) error {
+	space, orgGUID, err := r.getSpace(ctx, serviceInstance.Namespace)
+	if err != nil {
+		return err
+	}
+
+	usageEvent := &korifiv1alpha1.CFServiceUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      uuid.NewString(),
+		},
+		Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+			State: state,
+			ServiceInstance: korifiv1alpha1.UsageEventServiceInstance{
+				GUID: serviceInstance.Name,
+				Name: serviceInstance.Spec.DisplayName,
+				Type: korifiv1alpha1.UserProvidedServiceInstanceUsageType,
+			},
+			Space:            space,
+			OrganizationGUID: orgGUID,
+		},
+	}
+
+	if serviceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		usageEvent.Spec.ServiceInstance.Type = korifiv1alpha1.ManagedServiceInstanceUsageType
+
+		// The plan of an instance being deleted could have been removed along
+		// with its broker, in which case the event is recorded without it
+		assets, err := r.assets.GetServiceInstanceAssets(ctx, serviceInstance)
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to get service instance assets: %w", err)
+		}
+
+		if err == nil {
+			usageEvent.Spec.ServicePlan = &korifiv1alpha1.UsageEventResource{GUID: assets.ServicePlan.Name, Name: assets.ServicePlan.Spec.Name}
+			usageEvent.Spec.ServiceOffering = &korifiv1alpha1.UsageEventResource{GUID: assets.ServiceOffering.Name, Name: assets.ServiceOffering.Spec.Name}
+			usageEvent.Spec.ServiceBroker = &korifiv1alpha1.UsageEventResource{GUID: assets.ServiceBroker.Name, Name: assets.ServiceBroker.Spec.Name}
+		}
+	}
+
+	if err = r.k8sClient.Create(ctx, usageEvent); err != nil {
+		return fmt.Errorf("failed to create service usage event: %w", err)
+	}
+
+	return nil
+}
+
+// getSpace returns the space backed by spaceNamespace and the guid of its
+// org. The space name is left empty when the space cannot be found.
+func (r *Recorder) getSpace(ctx context.Context, spaceNamespace string) (korifiv1alpha1.UsageEventResource, string, error) {
+	namespace := &corev1.Namespace{}
+	if err := r.k8sClient.Get(ctx, types.NamespacedName{Name: spaceNamespace}, namespace); err != nil {
+		return korifiv1alpha1.UsageEventResource{}, "", fmt.Errorf("failed to get namespace %q: %w", spaceNamespace, err)
+	}
+
+	space := korifiv1alpha1.UsageEventResource{GUID: spaceNamespace}
+	orgGUID := namespace.Labels[korifiv1alpha1.CFOrgGUIDKey]
+	if orgGUID == "" {
+		return space, "", nil
+	}
+
+	cfSpace := &korifiv1alpha1.CFSpace{}
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: orgGUID, Name: spaceNamespace}, cfSpace)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return space, orgGUID, nil
+		}
+		return korifiv1alpha1.UsageEventResource{}, "", fmt.Errorf("failed to get space %q: %w", spaceNamespace, err)
+	}
+
+	space.Name = cfSpace.Spec.DisplayName
+	return space, orgGUID, nil
+}
@@ -0,0 +1,451 @@
+package usage_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Recorder", func() {
+	var (
+		rootNamespace  string
+		spaceNamespace string
+		orgGUID        string
+		recorder       *usage.Recorder
+	)
+
+	listAppUsageEvents := func(g Gomega) []korifiv1alpha1.CFAppUsageEvent {
+		usageEvents := &korifiv1alpha1.CFAppUsageEventList{}
+		g.Expect(adminClient.List(ctx, usageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+		return usageEvents.Items
+	}
+
+	listServiceUsageEvents := func(g Gomega) []korifiv1alpha1.CFServiceUsageEvent {
+		usageEvents := &korifiv1alpha1.CFServiceUsageEventList{}
+		g.Expect(adminClient.List(ctx, usageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+		return usageEvents.Items
+	}
+
+	BeforeEach(func() {
+		rootNamespace = uuid.NewString()
+		createNamespace(rootNamespace, nil)
+
+		orgGUID = uuid.NewString()
+		createNamespace(orgGUID, nil)
+
+		spaceNamespace = uuid.NewString()
+		createNamespace(spaceNamespace, map[string]string{
+			korifiv1alpha1.CFOrgGUIDKey:      orgGUID,
+			korifiv1alpha1.SpaceGUIDLabelKey: spaceNamespace,
+		})
+
+		Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: orgGUID,
+				Name:      spaceNamespace,
+			},
+			Spec: korifiv1alpha1.CFSpaceSpec{
+				DisplayName: "my-space",
+			},
+		})).To(Succeed())
+
+		recorder = usage.NewRecorder(adminClient, rootNamespace)
+	})
+
+	Describe("app usage", func() {
+		var (
+			cfApp     *korifiv1alpha1.CFApp
+			cfProcess *korifiv1alpha1.CFProcess
+		)
+
+		BeforeEach(func() {
+			cfApp = &korifiv1alpha1.CFApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFAppSpec{
+					DisplayName:  "my-app",
+					DesiredState: korifiv1alpha1.StartedState,
+				},
+			}
+
+			cfProcess = &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					ProcessType:      "web",
+					DesiredInstances: tools.PtrTo[int32](2),
+					MemoryMB:         512,
+				},
+			}
+		})
+
+		Describe("RecordProcessUsage", func() {
+			var recordErr error
+
+			JustBeforeEach(func() {
+				recordErr = recorder.RecordProcessUsage(ctx, cfApp, cfProcess)
+			})
+
+			It("records a STARTED event", func() {
+				Expect(recordErr).NotTo(HaveOccurred())
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Spec": Equal(korifiv1alpha1.CFAppUsageEventSpec{
+							State:                 korifiv1alpha1.AppUsageEventStateStarted,
+							App:                   korifiv1alpha1.UsageEventResource{GUID: cfApp.Name, Name: "my-app"},
+							Process:               korifiv1alpha1.UsageEventProcess{GUID: cfProcess.Name, Type: "web"},
+							Space:                 korifiv1alpha1.UsageEventResource{GUID: spaceNamespace, Name: "my-space"},
+							OrganizationGUID:      orgGUID,
+							InstanceCount:         2,
+							MemoryInMBPerInstance: 512,
+						}),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("records the usage in the process status", func() {
+				Expect(recordErr).NotTo(HaveOccurred())
+				Expect(cfProcess.Status.Usage).To(PointTo(Equal(korifiv1alpha1.ProcessUsage{
+					State:     korifiv1alpha1.AppUsageEventStateStarted,
+					Instances: 2,
+					MemoryMB:  512,
+				})))
+			})
+
+			When("recording the event is retried", func() {
+				JustBeforeEach(func() {
+					cfProcess.Status.Usage = nil
+					Expect(recorder.RecordProcessUsage(ctx, cfApp, cfProcess)).To(Succeed())
+				})
+
+				It("does not record a duplicate event", func() {
+					Consistently(func(g Gomega) {
+						g.Expect(listAppUsageEvents(g)).To(HaveLen(1))
+					}, "1s").Should(Succeed())
+				})
+			})
+
+			When("the app is started again with the same usage", func() {
+				JustBeforeEach(func() {
+					cfProcess.Status.Usage = nil
+					cfApp.Generation++
+					Expect(recorder.RecordProcessUsage(ctx, cfApp, cfProcess)).To(Succeed())
+				})
+
+				It("records another STARTED event", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(listAppUsageEvents(g)).To(HaveLen(2))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the app is stopped", func() {
+				BeforeEach(func() {
+					cfApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+				})
+
+				It("does not record an event", func() {
+					Expect(recordErr).NotTo(HaveOccurred())
+					Consistently(func(g Gomega) {
+						g.Expect(listAppUsageEvents(g)).To(BeEmpty())
+					}, "1s").Should(Succeed())
+				})
+
+				When("the process was started", func() {
+					BeforeEach(func() {
+						cfProcess.Status.Usage = &korifiv1alpha1.ProcessUsage{
+							State:     korifiv1alpha1.AppUsageEventStateStarted,
+							Instances: 2,
+							MemoryMB:  512,
+						}
+					})
+
+					It("records a STOPPED event", func() {
+						Expect(recordErr).NotTo(HaveOccurred())
+						Eventually(func(g Gomega) {
+							g.Expect(listAppUsageEvents(g)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+								"Spec": MatchFields(IgnoreExtras, Fields{
+									"State":         Equal(korifiv1alpha1.AppUsageEventStateStopped),
+									"PreviousState": Equal(korifiv1alpha1.AppUsageEventStateStarted),
+								}),
+							})))
+						}).Should(Succeed())
+					})
+				})
+			})
+
+			When("the usage has already been recorded", func() {
+				BeforeEach(func() {
+					cfProcess.Status.Usage = &korifiv1alpha1.ProcessUsage{
+						State:     korifiv1alpha1.AppUsageEventStateStarted,
+						Instances: 2,
+						MemoryMB:  512,
+					}
+				})
+
+				It("does not record an event", func() {
+					Expect(recordErr).NotTo(HaveOccurred())
+					Consistently(func(g Gomega) {
+						g.Expect(listAppUsageEvents(g)).To(BeEmpty())
+					}, "1s").Should(Succeed())
+				})
+			})
+
+			When("the process has been scaled", func() {
+				BeforeEach(func() {
+					cfProcess.Status.Usage = &korifiv1alpha1.ProcessUsage{
+						State:     korifiv1alpha1.AppUsageEventStateStarted,
+						Instances: 1,
+						MemoryMB:  256,
+					}
+				})
+
+				It("records a SCALED event", func() {
+					Expect(recordErr).NotTo(HaveOccurred())
+					Eventually(func(g Gomega) {
+						g.Expect(listAppUsageEvents(g)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"State":                         Equal(korifiv1alpha1.AppUsageEventStateScaled),
+								"PreviousState":                 Equal(korifiv1alpha1.AppUsageEventStateStarted),
+								"InstanceCount":                 BeEquivalentTo(2),
+								"PreviousInstanceCount":         BeEquivalentTo(1),
+								"MemoryInMBPerInstance":         BeEquivalentTo(512),
+								"PreviousMemoryInMBPerInstance": BeEquivalentTo(256),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+
+				When("recording the event is retried", func() {
+					JustBeforeEach(func() {
+						cfProcess.Status.Usage = &korifiv1alpha1.ProcessUsage{
+							State:     korifiv1alpha1.AppUsageEventStateStarted,
+							Instances: 1,
+							MemoryMB:  256,
+						}
+						Expect(recorder.RecordProcessUsage(ctx, cfApp, cfProcess)).To(Succeed())
+					})
+
+					It("does not record a duplicate event", func() {
+						Consistently(func(g Gomega) {
+							g.Expect(listAppUsageEvents(g)).To(HaveLen(1))
+						}, "1s").Should(Succeed())
+					})
+				})
+			})
+		})
+
+		Describe("RecordProcessStopped", func() {
+			var recordErr error
+
+			JustBeforeEach(func() {
+				recordErr = recorder.RecordProcessStopped(ctx, cfApp, cfProcess)
+			})
+
+			It("does not record an event for a process that was never started", func() {
+				Expect(recordErr).NotTo(HaveOccurred())
+				Consistently(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g)).To(BeEmpty())
+				}, "1s").Should(Succeed())
+			})
+
+			When("the process was started", func() {
+				BeforeEach(func() {
+					cfProcess.Status.Usage = &korifiv1alpha1.ProcessUsage{
+						State:     korifiv1alpha1.AppUsageEventStateStarted,
+						Instances: 3,
+						MemoryMB:  128,
+					}
+				})
+
+				It("records a STOPPED event with the last recorded usage", func() {
+					Expect(recordErr).NotTo(HaveOccurred())
+					Eventually(func(g Gomega) {
+						g.Expect(listAppUsageEvents(g)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"State":                 Equal(korifiv1alpha1.AppUsageEventStateStopped),
+								"InstanceCount":         BeEquivalentTo(3),
+								"MemoryInMBPerInstance": BeEquivalentTo(128),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+
+				When("recording the event is retried", func() {
+					JustBeforeEach(func() {
+						cfProcess.Status.Usage.State = korifiv1alpha1.AppUsageEventStateStarted
+						Expect(recorder.RecordProcessStopped(ctx, cfApp, cfProcess)).To(Succeed())
+					})
+
+					It("does not record a duplicate event", func() {
+						Consistently(func(g Gomega) {
+							g.Expect(listAppUsageEvents(g)).To(HaveLen(1))
+						}, "1s").Should(Succeed())
+					})
+				})
+			})
+		})
+
+		Describe("SeedProcessUsage", func() {
+			BeforeEach(func() {
+				cfProcess.Status.Usage = &korifiv1alpha1.ProcessUsage{
+					State:     korifiv1alpha1.AppUsageEventStateStarted,
+					Instances: 2,
+					MemoryMB:  512,
+				}
+			})
+
+			It("records a STARTED event regardless of the recorded usage", func() {
+				Expect(recorder.SeedProcessUsage(ctx, cfApp, cfProcess)).To(Succeed())
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":         Equal(korifiv1alpha1.AppUsageEventStateStarted),
+							"PreviousState": BeEmpty(),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	Describe("service usage", func() {
+		var serviceInstance *korifiv1alpha1.CFServiceInstance
+
+		BeforeEach(func() {
+			serviceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "my-instance",
+					Type:        korifiv1alpha1.UserProvidedType,
+				},
+			}
+		})
+
+		Describe("RecordServiceInstanceUsage", func() {
+			var recordErr error
+
+			JustBeforeEach(func() {
+				recordErr = recorder.RecordServiceInstanceUsage(ctx, serviceInstance)
+			})
+
+			It("records a CREATED event", func() {
+				Expect(recordErr).NotTo(HaveOccurred())
+				Eventually(func(g Gomega) {
+					g.Expect(listServiceUsageEvents(g)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Spec": Equal(korifiv1alpha1.CFServiceUsageEventSpec{
+							State: korifiv1alpha1.ServiceUsageEventStateCreated,
+							ServiceInstance: korifiv1alpha1.UsageEventServiceInstance{
+								GUID: serviceInstance.Name,
+								Name: "my-instance",
+								Type: korifiv1alpha1.UserProvidedServiceInstanceUsageType,
+							},
+							Space:            korifiv1alpha1.UsageEventResource{GUID: spaceNamespace, Name: "my-space"},
+							OrganizationGUID: orgGUID,
+						}),
+					})))
+				}).Should(Succeed())
+				Expect(serviceInstance.Status.Usage).To(PointTo(Equal(korifiv1alpha1.ServiceInstanceUsage{
+					DisplayName: "my-instance",
+				})))
+			})
+
+			When("the usage has already been recorded", func() {
+				BeforeEach(func() {
+					serviceInstance.Status.Usage = &korifiv1alpha1.ServiceInstanceUsage{DisplayName: "my-instance"}
+				})
+
+				It("does not record an event", func() {
+					Expect(recordErr).NotTo(HaveOccurred())
+					Consistently(func(g Gomega) {
+						g.Expect(listServiceUsageEvents(g)).To(BeEmpty())
+					}, "1s").Should(Succeed())
+				})
+			})
+
+			When("the instance has been renamed", func() {
+				BeforeEach(func() {
+					serviceInstance.Status.Usage = &korifiv1alpha1.ServiceInstanceUsage{DisplayName: "old-name"}
+				})
+
+				It("records an UPDATED event", func() {
+					Expect(recordErr).NotTo(HaveOccurred())
+					Eventually(func(g Gomega) {
+						g.Expect(listServiceUsageEvents(g)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"State": Equal(korifiv1alpha1.ServiceUsageEventStateUpdated),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the instance is managed and its plan does not exist", func() {
+				BeforeEach(func() {
+					serviceInstance.Spec.Type = korifiv1alpha1.ManagedType
+					serviceInstance.Spec.PlanGUID = uuid.NewString()
+				})
+
+				It("records the event without the plan", func() {
+					Expect(recordErr).NotTo(HaveOccurred())
+					Eventually(func(g Gomega) {
+						g.Expect(listServiceUsageEvents(g)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"ServiceInstance": MatchFields(IgnoreExtras, Fields{
+									"Type": Equal(korifiv1alpha1.ManagedServiceInstanceUsageType),
+								}),
+								"ServicePlan": BeNil(),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		Describe("RecordServiceInstanceDeleted", func() {
+			var recordErr error
+
+			JustBeforeEach(func() {
+				recordErr = recorder.RecordServiceInstanceDeleted(ctx, serviceInstance)
+			})
+
+			It("does not record an event for an instance whose creation was not recorded", func() {
+				Expect(recordErr).NotTo(HaveOccurred())
+				Consistently(func(g Gomega) {
+					g.Expect(listServiceUsageEvents(g)).To(BeEmpty())
+				}, "1s").Should(Succeed())
+			})
+
+			When("the instance creation was recorded", func() {
+				BeforeEach(func() {
+					serviceInstance.Status.Usage = &korifiv1alpha1.ServiceInstanceUsage{DisplayName: "my-instance"}
+				})
+
+				It("records a DELETED event", func() {
+					Expect(recordErr).NotTo(HaveOccurred())
+					Eventually(func(g Gomega) {
+						g.Expect(listServiceUsageEvents(g)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"State": Equal(korifiv1alpha1.ServiceUsageEventStateDeleted),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+			})
+		})
+	})
+})
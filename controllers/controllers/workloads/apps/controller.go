@@ -33,21 +33,27 @@ type EnvValueBuilder interface {
 	BuildEnvValue(context.Context, *korifiv1alpha1.CFApp) (map[string][]byte, error)
 }
 
+type UsageRecorder interface {
+	RecordProcessStopped(context.Context, *korifiv1alpha1.CFApp, *korifiv1alpha1.CFProcess) error
+}
+
 type Reconciler struct {
 	log                       logr.Logger
 	k8sClient                 client.Client
 	scheme                    *runtime.Scheme
 	vcapServicesEnvBuilder    EnvValueBuilder
 	vcapApplicationEnvBuilder EnvValueBuilder
+	usageRecorder             UsageRecorder
 }
 
-func NewReconciler(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger, vcapServicesBuilder, vcapApplicationBuilder EnvValueBuilder) *k8s.PatchingReconciler[korifiv1alpha1.CFApp] {
+func NewReconciler(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger, vcapServicesBuilder, vcapApplicationBuilder EnvValueBuilder, usageRecorder UsageRecorder) *k8s.PatchingReconciler[korifiv1alpha1.CFApp] {
 	appReconciler := Reconciler{
 		log:                       log,
 		k8sClient:                 k8sClient,
 		scheme:                    scheme,
 		vcapServicesEnvBuilder:    vcapServicesBuilder,
 		vcapApplicationEnvBuilder: vcapApplicationBuilder,
+		usageRecorder:             usageRecorder,
 	}
 	return k8s.NewPatchingReconciler(log, k8sClient, &appReconciler)
 }
@@ -63,6 +69,10 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&korifiv1alpha1.CFServiceBinding{},
 			handler.EnqueueRequestsFromMapFunc(serviceBindingToApp),
+		).
+		Watches(
+			&korifiv1alpha1.AppWorkload{},
+			handler.EnqueueRequestsFromMapFunc(appWorkloadToApp),
 		)
 }
 
@@ -98,11 +108,28 @@ func serviceBindingToApp(ctx context.Context, o client.Object) []reconcile.Reque
 	}
 }
 
+func appWorkloadToApp(ctx context.Context, o client.Object) []reconcile.Request {
+	appGUID, ok := o.GetLabels()[korifiv1alpha1.CFAppGUIDLabelKey]
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{
+		{
+			NamespacedName: types.NamespacedName{
+				Name:      appGUID,
+				Namespace: o.GetNamespace(),
+			},
+		},
+	}
+}
+
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get;list;watch;create;update;patch;delete;deletecollection
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps/finalizers,verbs=update
 
-//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;patch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
@@ -159,6 +186,13 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha
 		return ctrl.Result{}, err
 	}
 
+	if cfApp.Spec.DesiredState == korifiv1alpha1.StartedState {
+		err = r.reconcileRevision(ctx, cfApp, reconciledProcesses)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	cfApp.Status.ActualState = getActualState(reconciledProcesses)
 	if cfApp.Status.ActualState != cfApp.Spec.DesiredState {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("DesiredStateNotReached")
@@ -168,9 +202,85 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("ProcessesNotReady").WithRequeue()
 	}
 
+	cfApp.Status.DeploymentState, err = r.getDeploymentState(ctx, cfApp, reconciledProcesses)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	switch cfApp.Status.DeploymentState {
+	case korifiv1alpha1.DeploymentStateDeploying, korifiv1alpha1.DeploymentStateCanceling:
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("DeploymentInProgress")
+	case korifiv1alpha1.DeploymentStatePaused:
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("DeploymentPaused").WithNoRequeue()
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// getDeploymentState works out the progress of the app deployment from the
+// instances of the app workloads that run the current app revision. A canary
+// deployment pauses once the instances of its current step have been rolled
+// over, until it is continued or canceled.
+func (r *Reconciler) getDeploymentState(ctx context.Context, cfApp *korifiv1alpha1.CFApp, processes []*korifiv1alpha1.CFProcess) (korifiv1alpha1.DeploymentState, error) {
+	deployment := cfApp.Spec.Deployment
+	if deployment == nil {
+		return "", nil
+	}
+
+	appWorkloads := &korifiv1alpha1.AppWorkloadList{}
+	err := r.k8sClient.List(ctx, appWorkloads, client.InNamespace(cfApp.Namespace), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+		korifiv1alpha1.CFAppRevisionKey:  tools.GetMapValue(cfApp.Annotations, korifiv1alpha1.CFAppRevisionKey, korifiv1alpha1.CFAppDefaultRevision),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	rolledOut := true
+	paused := false
+	for _, process := range processes {
+		instances := tools.ZeroIfNil(process.Spec.DesiredInstances)
+		updatedInstances, upToDate := getUpdatedInstances(process, appWorkloads.Items)
+		if !upToDate || updatedInstances < deployment.UpdatedInstances(instances) {
+			rolledOut = false
+		}
+		if updatedInstances < instances {
+			paused = true
+		}
+	}
+
+	switch {
+	case deployment.Canceled && rolledOut:
+		return korifiv1alpha1.DeploymentStateCanceled, nil
+	case deployment.Canceled:
+		return korifiv1alpha1.DeploymentStateCanceling, nil
+	case !rolledOut:
+		return korifiv1alpha1.DeploymentStateDeploying, nil
+	case paused:
+		return korifiv1alpha1.DeploymentStatePaused, nil
+	default:
+		return korifiv1alpha1.DeploymentStateDeployed, nil
+	}
+}
+
+func getUpdatedInstances(process *korifiv1alpha1.CFProcess, appWorkloads []korifiv1alpha1.AppWorkload) (int32, bool) {
+	instances := tools.ZeroIfNil(process.Spec.DesiredInstances)
+	if instances == 0 {
+		return 0, true
+	}
+
+	for _, appWorkload := range appWorkloads {
+		if appWorkload.Labels[korifiv1alpha1.CFProcessGUIDLabelKey] != process.Name {
+			continue
+		}
+
+		upToDate := appWorkload.Status.ObservedGeneration == appWorkload.Generation && appWorkload.Spec.Instances == instances
+		return appWorkload.Status.UpdatedInstances, upToDate
+	}
+
+	return 0, false
+}
+
 func allReady(processes []*korifiv1alpha1.CFProcess) bool {
 	return it.All(it.Map(slices.Values(processes), func(p *korifiv1alpha1.CFProcess) bool {
 		return conditions.CheckConditionIsTrue(p, korifiv1alpha1.StatusConditionReady) == nil
@@ -369,6 +479,11 @@ func (r *Reconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 		return sbFinalizationResult, nil
 	}
 
+	err = r.recordProcessesStopped(ctx, cfApp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if controllerutil.RemoveFinalizer(cfApp, korifiv1alpha1.CFAppFinalizerName) {
 		log.V(1).Info("finalizer removed")
 	}
@@ -376,6 +491,25 @@ func (r *Reconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 	return ctrl.Result{}, nil
 }
 
+func (r *Reconciler) recordProcessesStopped(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
+	cfProcessList := korifiv1alpha1.CFProcessList{}
+	err := r.k8sClient.List(ctx, &cfProcessList,
+		client.InNamespace(cfApp.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+	)
+	if err != nil {
+		return fmt.Errorf("error listing app CFProcesses: %w", err)
+	}
+
+	for i := range cfProcessList.Items {
+		if err = r.usageRecorder.RecordProcessStopped(ctx, cfApp, &cfProcessList.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *Reconciler) finalizeCFAppRoutes(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
 	cfRoutes, err := r.getCFRoutes(ctx, cfApp.Name, cfApp.Namespace)
 	if err != nil {
@@ -504,6 +504,302 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 		})
 	})
 
+	Describe("revisions", func() {
+		var envSecret *corev1.Secret
+
+		listRevisions := func(g Gomega) []korifiv1alpha1.CFRevision {
+			revisions := &korifiv1alpha1.CFRevisionList{}
+			g.Expect(adminClient.List(ctx, revisions, client.InNamespace(testNamespace), client.MatchingLabels{
+				korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+			})).To(Succeed())
+			return revisions.Items
+		}
+
+		BeforeEach(func() {
+			envSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+				},
+				StringData: map[string]string{"FOO": "bar"},
+			}
+			Expect(adminClient.Create(ctx, envSecret)).To(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+				cfApp.Spec.EnvSecretName = envSecret.Name
+			})).To(Succeed())
+		})
+
+		It("does not create revisions for stopped apps", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(listRevisions(g)).To(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		When("the app is started", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				})).To(Succeed())
+			})
+
+			It("creates an initial revision", func() {
+				Eventually(func(g Gomega) {
+					revisions := listRevisions(g)
+					g.Expect(revisions).To(HaveLen(1))
+					g.Expect(revisions[0].Spec.Version).To(BeEquivalentTo(1))
+					g.Expect(revisions[0].Spec.DropletRef.Name).To(Equal(cfBuild.Name))
+					g.Expect(revisions[0].Spec.Description).To(Equal("Initial revision."))
+					g.Expect(revisions[0].Spec.Processes).To(ConsistOf(korifiv1alpha1.RevisionProcess{Type: "web"}))
+
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					g.Expect(cfApp.Status.CurrentRevisionRef.Name).To(Equal(revisions[0].Name))
+				}).Should(Succeed())
+			})
+
+			It("snapshots the app environment variables", func() {
+				Eventually(func(g Gomega) {
+					revisions := listRevisions(g)
+					g.Expect(revisions).To(HaveLen(1))
+
+					revisionEnvSecret := &corev1.Secret{}
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: revisions[0].Spec.EnvSecretName}, revisionEnvSecret)).To(Succeed())
+					g.Expect(revisionEnvSecret.Data).To(Equal(map[string][]byte{"FOO": []byte("bar")}))
+				}).Should(Succeed())
+			})
+
+			When("the environment variables change without a restart", func() {
+				BeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(listRevisions(g)).To(HaveLen(1))
+					}).Should(Succeed())
+
+					Expect(k8s.PatchResource(ctx, adminClient, envSecret, func() {
+						envSecret.Data = map[string][]byte{"FOO": []byte("baz")}
+					})).To(Succeed())
+					Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+						cfApp.Labels = tools.SetMapValue(cfApp.Labels, "trigger", "reconcile")
+					})).To(Succeed())
+				})
+
+				It("does not create a new revision", func() {
+					Consistently(func(g Gomega) {
+						g.Expect(listRevisions(g)).To(HaveLen(1))
+					}).Should(Succeed())
+				})
+
+				When("the app is restarted", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+							cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "43"
+						})).To(Succeed())
+					})
+
+					It("creates a new revision", func() {
+						Eventually(func(g Gomega) {
+							revisions := listRevisions(g)
+							g.Expect(revisions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+								"Spec": MatchFields(IgnoreExtras, Fields{
+									"Version":     BeEquivalentTo(2),
+									"Description": Equal("New environment variables deployed."),
+								}),
+							})))
+						}).Should(Succeed())
+					})
+				})
+			})
+
+			When("the process command changes", func() {
+				BeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(listRevisions(g)).To(HaveLen(1))
+					}).Should(Succeed())
+
+					Expect(k8s.PatchResource(ctx, adminClient, defaultWebProcess, func() {
+						defaultWebProcess.Spec.Command = "my-command"
+					})).To(Succeed())
+					Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "43"
+					})).To(Succeed())
+				})
+
+				It("creates a new revision", func() {
+					Eventually(func(g Gomega) {
+						revisions := listRevisions(g)
+						g.Expect(revisions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"Version":     BeEquivalentTo(2),
+								"Description": Equal("Custom start command added for 'web' process."),
+								"Processes":   ConsistOf(korifiv1alpha1.RevisionProcess{Type: "web", Command: "my-command"}),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+			})
+
+			When("a sidecar is added", func() {
+				BeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(listRevisions(g)).To(HaveLen(1))
+					}).Should(Succeed())
+
+					Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSidecar{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      uuid.NewString(),
+							Namespace: testNamespace,
+							Labels: map[string]string{
+								korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+							},
+						},
+						Spec: korifiv1alpha1.CFSidecarSpec{
+							AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+							Name:         "my-sidecar",
+							Command:      "sidecar-command",
+							ProcessTypes: []string{"worker", "web"},
+							MemoryMB:     64,
+						},
+					})).To(Succeed())
+					Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "43"
+					})).To(Succeed())
+				})
+
+				It("creates a new revision", func() {
+					Eventually(func(g Gomega) {
+						revisions := listRevisions(g)
+						g.Expect(revisions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"Version":     BeEquivalentTo(2),
+								"Description": Equal("Sidecars updated."),
+								"Sidecars": ConsistOf(korifiv1alpha1.RevisionSidecar{
+									Name:         "my-sidecar",
+									Command:      "sidecar-command",
+									ProcessTypes: []string{"web", "worker"},
+									MemoryMB:     64,
+								}),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+			})
+		})
+	})
+
+	When("the app has a canary deployment", func() {
+		var appWorkload *korifiv1alpha1.AppWorkload
+
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, adminClient, defaultWebProcess, func() {
+				defaultWebProcess.Spec.DesiredInstances = tools.PtrTo[int32](2)
+			})).To(Succeed())
+			Expect(k8s.Patch(ctx, adminClient, defaultWebProcess, func() {
+				defaultWebProcess.Status.ActualInstances = 2
+				defaultWebProcess.Status.Conditions = []metav1.Condition{{
+					Type:               korifiv1alpha1.StatusConditionReady,
+					Status:             metav1.ConditionTrue,
+					LastTransitionTime: metav1.Now(),
+					Reason:             "Ready",
+					ObservedGeneration: defaultWebProcess.Generation,
+				}}
+			})).To(Succeed())
+
+			appWorkload = &korifiv1alpha1.AppWorkload{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+					Labels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+						korifiv1alpha1.CFAppRevisionKey:      "42",
+						korifiv1alpha1.CFProcessGUIDLabelKey: defaultWebProcess.Name,
+					},
+				},
+				Spec: korifiv1alpha1.AppWorkloadSpec{
+					GUID:       defaultWebProcess.Name,
+					Instances:  2,
+					RunnerName: "statefulset-runner",
+				},
+			}
+			Expect(adminClient.Create(ctx, appWorkload)).To(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+				cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				cfApp.Spec.Deployment = &korifiv1alpha1.CFAppDeployment{
+					Strategy:    korifiv1alpha1.DeploymentStrategyCanary,
+					CanarySteps: []korifiv1alpha1.CanaryStep{{InstanceWeight: 50}},
+				}
+			})).To(Succeed())
+		})
+
+		setUpdatedInstances := func(updatedInstances int32) {
+			Expect(k8s.Patch(ctx, adminClient, appWorkload, func() {
+				appWorkload.Status.ObservedGeneration = appWorkload.Generation
+				appWorkload.Status.UpdatedInstances = updatedInstances
+			})).To(Succeed())
+		}
+
+		expectDeploymentState := func(state korifiv1alpha1.DeploymentState, readyStatus metav1.ConditionStatus) {
+			GinkgoHelper()
+
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Status.DeploymentState).To(Equal(state))
+				g.Expect(cfApp.Status.Conditions).To(ContainElement(SatisfyAll(
+					HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					HasStatus(Equal(readyStatus)),
+				)))
+			}).Should(Succeed())
+		}
+
+		It("is deploying until the canary instances are updated", func() {
+			expectDeploymentState(korifiv1alpha1.DeploymentStateDeploying, metav1.ConditionFalse)
+		})
+
+		When("the canary instances are updated", func() {
+			BeforeEach(func() {
+				setUpdatedInstances(1)
+			})
+
+			It("pauses the deployment", func() {
+				expectDeploymentState(korifiv1alpha1.DeploymentStatePaused, metav1.ConditionFalse)
+			})
+
+			When("the deployment is continued and all instances are updated", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+						cfApp.Spec.Deployment.CurrentStep = 1
+					})).To(Succeed())
+					setUpdatedInstances(2)
+				})
+
+				It("finalizes the deployment", func() {
+					expectDeploymentState(korifiv1alpha1.DeploymentStateDeployed, metav1.ConditionTrue)
+				})
+			})
+
+			When("the deployment is canceled", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+						cfApp.Spec.Deployment.Canceled = true
+					})).To(Succeed())
+				})
+
+				It("is canceling until all instances are rolled back", func() {
+					expectDeploymentState(korifiv1alpha1.DeploymentStateCanceling, metav1.ConditionFalse)
+				})
+
+				When("all instances are rolled back", func() {
+					BeforeEach(func() {
+						setUpdatedInstances(2)
+					})
+
+					It("cancels the deployment", func() {
+						expectDeploymentState(korifiv1alpha1.DeploymentStateCanceled, metav1.ConditionTrue)
+					})
+				})
+			})
+		})
+	})
+
 	Describe("finalization", func() {
 		var (
 			cfDomainGUID string
@@ -588,5 +884,50 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 				g.Expect(sbList.Items).To(BeEmpty())
 			}).Should(Succeed())
 		})
+
+		When("the app has a started process", func() {
+			var cfProcess *korifiv1alpha1.CFProcess
+
+			BeforeEach(func() {
+				cfProcess = &korifiv1alpha1.CFProcess{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+						Labels: map[string]string{
+							korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+						},
+					},
+					Spec: korifiv1alpha1.CFProcessSpec{
+						AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+						ProcessType: "worker",
+						MemoryMB:    256,
+					},
+				}
+				Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+					cfProcess.Status.Usage = &korifiv1alpha1.ProcessUsage{
+						State:     korifiv1alpha1.AppUsageEventStateStarted,
+						Instances: 2,
+						MemoryMB:  256,
+					}
+				})).To(Succeed())
+			})
+
+			It("records that the process stopped", func() {
+				Eventually(func(g Gomega) {
+					usageEvents := korifiv1alpha1.CFAppUsageEventList{}
+					g.Expect(adminClient.List(ctx, &usageEvents, client.InNamespace(testNamespace))).To(Succeed())
+					g.Expect(usageEvents.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":                 Equal(korifiv1alpha1.AppUsageEventStateStopped),
+							"PreviousState":         Equal(korifiv1alpha1.AppUsageEventStateStarted),
+							"Process":               Equal(korifiv1alpha1.UsageEventProcess{GUID: cfProcess.Name, Type: "worker"}),
+							"InstanceCount":         BeEquivalentTo(2),
+							"MemoryInMBPerInstance": BeEquivalentTo(256),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+		})
 	})
 })
@@ -0,0 +1,261 @@
+package apps
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfrevisions,verbs=get;list;watch;create;patch
+
+type revisionSnapshot struct {
+	dropletGUID string
+	envData     map[string][]byte
+	envHash     string
+	processes   []korifiv1alpha1.RevisionProcess
+	sidecars    []korifiv1alpha1.RevisionSidecar
+}
+
+func (s revisionSnapshot) matches(revision *korifiv1alpha1.CFRevision) bool {
+	return revision.Spec.DropletRef.Name == s.dropletGUID &&
+		revision.Annotations[korifiv1alpha1.CFRevisionEnvHashKey] == s.envHash &&
+		slices.Equal(revision.Spec.Processes, s.processes) &&
+		slices.EqualFunc(revision.Spec.Sidecars, s.sidecars, sidecarsEqual)
+}
+
+func sidecarsEqual(s1, s2 korifiv1alpha1.RevisionSidecar) bool {
+	return s1.Name == s2.Name &&
+		s1.Command == s2.Command &&
+		slices.Equal(s1.ProcessTypes, s2.ProcessTypes) &&
+		s1.MemoryMB == s2.MemoryMB
+}
+
+// reconcileRevision snapshots the droplet, the environment variables, the
+// custom process commands and the sidecars of the app into a new CFRevision whenever any of
+// them differs from the latest revision of the app. Snapshots are only taken
+// once per app-rev, i.e. when the app is restarted or deployed, so that
+// changes that have not been rolled out yet are not recorded.
+func (r *Reconciler) reconcileRevision(ctx context.Context, cfApp *korifiv1alpha1.CFApp, processes []*korifiv1alpha1.CFProcess) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileRevision")
+
+	appRev := tools.GetMapValue(cfApp.Annotations, korifiv1alpha1.CFAppRevisionKey, korifiv1alpha1.CFAppDefaultRevision)
+
+	revisions := &korifiv1alpha1.CFRevisionList{}
+	err := r.k8sClient.List(ctx, revisions, client.InNamespace(cfApp.Namespace), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+	})
+	if err != nil {
+		log.Info("failed to list app revisions", "reason", err)
+		return err
+	}
+
+	var latest *korifiv1alpha1.CFRevision
+	if len(revisions.Items) > 0 {
+		latestRevision := slices.MaxFunc(revisions.Items, func(r1, r2 korifiv1alpha1.CFRevision) int {
+			return cmp.Compare(r1.Spec.Version, r2.Spec.Version)
+		})
+		latest = &latestRevision
+	}
+
+	if latest != nil && latest.Annotations[korifiv1alpha1.CFRevisionAppRevKey] == appRev {
+		cfApp.Status.CurrentRevisionRef = corev1.LocalObjectReference{Name: latest.Name}
+		return nil
+	}
+
+	snapshot, err := r.takeRevisionSnapshot(ctx, cfApp, processes)
+	if err != nil {
+		log.Info("failed to snapshot app", "reason", err)
+		return err
+	}
+
+	if latest != nil && snapshot.matches(latest) {
+		err = k8s.PatchResource(ctx, r.k8sClient, latest, func() {
+			latest.Annotations = tools.SetMapValue(latest.Annotations, korifiv1alpha1.CFRevisionAppRevKey, appRev)
+		})
+		if err != nil {
+			log.Info("failed to record app-rev on revision", "reason", err)
+			return err
+		}
+
+		cfApp.Status.CurrentRevisionRef = corev1.LocalObjectReference{Name: latest.Name}
+		return nil
+	}
+
+	version := int64(1)
+	if latest != nil {
+		version = latest.Spec.Version + 1
+	}
+	revisionName := tools.NamespacedUUID(cfApp.Name, "revision", strconv.FormatInt(version, 10))
+
+	envSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfApp.Namespace,
+			Name:      revisionName,
+		},
+	}
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, envSecret, func() error {
+		envSecret.Labels = tools.SetMapValue(envSecret.Labels, korifiv1alpha1.CFAppGUIDLabelKey, cfApp.Name)
+		envSecret.Data = snapshot.envData
+
+		return controllerutil.SetControllerReference(cfApp, envSecret, r.scheme)
+	})
+	if err != nil {
+		log.Info("failed to create revision env secret", "reason", err)
+		return err
+	}
+
+	revision := &korifiv1alpha1.CFRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfApp.Namespace,
+			Name:      revisionName,
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey:         cfApp.Name,
+				korifiv1alpha1.CFRevisionVersionLabelKey: strconv.FormatInt(version, 10),
+			},
+			Annotations: map[string]string{
+				korifiv1alpha1.CFRevisionEnvHashKey: snapshot.envHash,
+				korifiv1alpha1.CFRevisionAppRevKey:  appRev,
+			},
+		},
+		Spec: korifiv1alpha1.CFRevisionSpec{
+			AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+			Version:       version,
+			DropletRef:    corev1.LocalObjectReference{Name: snapshot.dropletGUID},
+			EnvSecretName: envSecret.Name,
+			Processes:     snapshot.processes,
+			Sidecars:      snapshot.sidecars,
+			Description:   revisionDescription(cfApp, revisions.Items, latest, snapshot),
+		},
+	}
+
+	if err = controllerutil.SetControllerReference(cfApp, revision, r.scheme); err != nil {
+		return fmt.Errorf("failed to set OwnerRef on CFRevision: %w", err)
+	}
+
+	if err = r.k8sClient.Create(ctx, revision); err != nil {
+		log.Info("failed to create revision", "reason", err)
+		return err
+	}
+
+	cfApp.Status.CurrentRevisionRef = corev1.LocalObjectReference{Name: revision.Name}
+
+	return nil
+}
+
+func (r *Reconciler) takeRevisionSnapshot(ctx context.Context, cfApp *korifiv1alpha1.CFApp, processes []*korifiv1alpha1.CFProcess) (revisionSnapshot, error) {
+	envData := map[string][]byte{}
+	if cfApp.Spec.EnvSecretName != "" {
+		envSecret := &corev1.Secret{}
+		err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: cfApp.Namespace, Name: cfApp.Spec.EnvSecretName}, envSecret)
+		if err != nil {
+			return revisionSnapshot{}, err
+		}
+		envData = envSecret.Data
+	}
+
+	envBytes, err := json.Marshal(envData)
+	if err != nil {
+		return revisionSnapshot{}, err
+	}
+
+	revisionProcesses := slices.Collect(it.Map(slices.Values(processes), func(p *korifiv1alpha1.CFProcess) korifiv1alpha1.RevisionProcess {
+		return korifiv1alpha1.RevisionProcess{
+			Type:    p.Spec.ProcessType,
+			Command: p.Spec.Command,
+		}
+	}))
+	slices.SortFunc(revisionProcesses, func(p1, p2 korifiv1alpha1.RevisionProcess) int {
+		return strings.Compare(p1.Type, p2.Type)
+	})
+
+	sidecarList := &korifiv1alpha1.CFSidecarList{}
+	err = r.k8sClient.List(ctx, sidecarList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+	})
+	if err != nil {
+		return revisionSnapshot{}, err
+	}
+
+	revisionSidecars := slices.Collect(it.Map(slices.Values(sidecarList.Items), func(s korifiv1alpha1.CFSidecar) korifiv1alpha1.RevisionSidecar {
+		return korifiv1alpha1.RevisionSidecar{
+			Name:         s.Spec.Name,
+			Command:      s.Spec.Command,
+			ProcessTypes: slices.Sorted(slices.Values(s.Spec.ProcessTypes)),
+			MemoryMB:     s.Spec.MemoryMB,
+		}
+	}))
+	slices.SortFunc(revisionSidecars, func(s1, s2 korifiv1alpha1.RevisionSidecar) int {
+		return strings.Compare(s1.Name, s2.Name)
+	})
+
+	return revisionSnapshot{
+		dropletGUID: cfApp.Spec.CurrentDropletRef.Name,
+		envData:     envData,
+		envHash:     tools.EncodeValueToSha224(string(envBytes)),
+		processes:   revisionProcesses,
+		sidecars:    revisionSidecars,
+	}, nil
+}
+
+func revisionDescription(cfApp *korifiv1alpha1.CFApp, revisions []korifiv1alpha1.CFRevision, latest *korifiv1alpha1.CFRevision, snapshot revisionSnapshot) string {
+	if latest == nil {
+		return "Initial revision."
+	}
+
+	if cfApp.Spec.Deployment != nil && cfApp.Spec.Deployment.RevisionRef.Name != "" {
+		for _, revision := range revisions {
+			if revision.Name == cfApp.Spec.Deployment.RevisionRef.Name && snapshot.matches(&revision) {
+				return fmt.Sprintf("Rolled back to revision %d.", revision.Spec.Version)
+			}
+		}
+	}
+
+	reasons := []string{}
+	if latest.Spec.DropletRef.Name != snapshot.dropletGUID {
+		reasons = append(reasons, "New droplet deployed.")
+	}
+
+	if latest.Annotations[korifiv1alpha1.CFRevisionEnvHashKey] != snapshot.envHash {
+		reasons = append(reasons, "New environment variables deployed.")
+	}
+
+	previousCommands := map[string]string{}
+	for _, p := range latest.Spec.Processes {
+		previousCommands[p.Type] = p.Command
+	}
+
+	for _, p := range snapshot.processes {
+		previousCommand := previousCommands[p.Type]
+		switch {
+		case previousCommand == p.Command:
+		case previousCommand == "":
+			reasons = append(reasons, fmt.Sprintf("Custom start command added for '%s' process.", p.Type))
+		case p.Command == "":
+			reasons = append(reasons, fmt.Sprintf("Custom start command removed for '%s' process.", p.Type))
+		default:
+			reasons = append(reasons, fmt.Sprintf("Custom start command updated for '%s' process.", p.Type))
+		}
+	}
+
+	if !slices.EqualFunc(latest.Spec.Sidecars, snapshot.sidecars, sidecarsEqual) {
+		reasons = append(reasons, "Sidecars updated.")
+	}
+
+	return strings.Join(reasons, " ")
+}
@@ -72,7 +72,7 @@ func buildSingleServiceEnv(ctx context.Context, k8sClient client.Client, service
 	serviceLabel := serviceBinding.Annotations[korifiv1alpha1.ServiceInstanceTypeAnnotation]
 
 	serviceInstance := korifiv1alpha1.CFServiceInstance{}
-	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.Namespace, Name: serviceBinding.Spec.Service.Name}, &serviceInstance)
+	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.ServiceInstanceNamespace(), Name: serviceBinding.Spec.Service.Name}, &serviceInstance)
 	if err != nil {
 		return ServiceDetails{}, "", fmt.Errorf("error fetching CFServiceInstance: %w", err)
 	}
@@ -119,6 +119,11 @@ func fromServiceBinding(
 		tags = []string{}
 	}
 
+	var syslogDrainURL *string
+	if serviceInstance.Spec.SyslogDrainURL != "" {
+		syslogDrainURL = &serviceInstance.Spec.SyslogDrainURL
+	}
+
 	creds := map[string]any{}
 	err := credentials.GetCredentials(credentialsSecret, &creds)
 	if err != nil {
@@ -134,7 +139,7 @@ func fromServiceBinding(
 		BindingGUID:    serviceBinding.Name,
 		BindingName:    bindingName,
 		Credentials:    creds,
-		SyslogDrainURL: nil,
+		SyslogDrainURL: syslogDrainURL,
 		VolumeMounts:   []string{},
 	}, nil
 }
@@ -94,10 +94,11 @@ var _ = Describe("Builder", func() {
 				Name:      "my-service-instance-guid-2",
 			},
 			Spec: korifiv1alpha1.CFServiceInstanceSpec{
-				DisplayName:  "my-service-instance-2",
-				Tags:         []string{"t1", "t2"},
-				Type:         "user-provided",
-				ServiceLabel: tools.PtrTo("custom-service-2"),
+				DisplayName:    "my-service-instance-2",
+				Tags:           []string{"t1", "t2"},
+				Type:           "user-provided",
+				ServiceLabel:   tools.PtrTo("custom-service-2"),
+				SyslogDrainURL: "syslog://logs.example.com:514",
 			},
 		})
 
@@ -178,7 +179,7 @@ var _ = Describe("Builder", func() {
 					"credentials": MatchAllKeys(Keys{
 						"foo": Equal("bar"),
 					}),
-					"syslog_drain_url": BeNil(),
+					"syslog_drain_url": Equal("syslog://logs.example.com:514"),
 					"volume_mounts":    BeEmpty(),
 				})),
 			}))
@@ -18,6 +18,7 @@ package orgs
 
 import (
 	"context"
+	"fmt"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/k8sns"
@@ -95,6 +96,8 @@ func (r *Reconciler) enqueueCFOrgRequests(ctx context.Context, object client.Obj
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgs/finalizers,verbs=update
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get;list;watch;patch
 
 //+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
@@ -127,9 +130,46 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfOrg *korifiv1alpha
 		return nsReconcileResult, err
 	}
 
+	if cfOrg.Spec.Suspended {
+		err = r.stopApps(ctx, cfOrg)
+		if err != nil {
+			logr.FromContextOrDiscard(ctx).Info("not ready yet", "reason", "error stopping apps", "error", err)
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("StopAppsFailed")
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
+func (r *Reconciler) stopApps(ctx context.Context, cfOrg *korifiv1alpha1.CFOrg) error {
+	cfSpaces := &korifiv1alpha1.CFSpaceList{}
+	if err := r.client.List(ctx, cfSpaces, client.InNamespace(cfOrg.Name)); err != nil {
+		return fmt.Errorf("failed to list spaces: %w", err)
+	}
+
+	for _, cfSpace := range cfSpaces.Items {
+		cfApps := &korifiv1alpha1.CFAppList{}
+		if err := r.client.List(ctx, cfApps, client.InNamespace(cfSpace.Name)); err != nil {
+			return fmt.Errorf("failed to list apps in space %q: %w", cfSpace.Name, err)
+		}
+
+		for i := range cfApps.Items {
+			cfApp := &cfApps.Items[i]
+			if cfApp.Spec.DesiredState == korifiv1alpha1.StoppedState {
+				continue
+			}
+
+			if err := k8s.PatchResource(ctx, r.client, cfApp, func() {
+				cfApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+			}); err != nil {
+				return fmt.Errorf("failed to stop app %q: %w", cfApp.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 type cfOrgMetadataCompiler struct {
 	labelCompiler labels.Compiler
 }
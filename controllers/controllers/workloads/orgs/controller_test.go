@@ -2,6 +2,7 @@ package orgs_test
 
 import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -65,4 +66,54 @@ var _ = Describe("CFOrgReconciler Integration Tests", func() {
 			g.Expect(meta.IsStatusConditionTrue(cfOrg.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
 		}).Should(Succeed())
 	})
+
+	When("the org is suspended", func() {
+		var cfApp *korifiv1alpha1.CFApp
+
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfOrg.Name}, &corev1.Namespace{})).To(Succeed())
+			}).Should(Succeed())
+
+			spaceGUID := uuid.NewString()
+			Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      spaceGUID,
+					Namespace: cfOrg.Name,
+				},
+				Spec: korifiv1alpha1.CFSpaceSpec{
+					DisplayName: "my-space",
+				},
+			})).To(Succeed())
+			Expect(adminClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: spaceGUID},
+			})).To(Succeed())
+
+			cfApp = &korifiv1alpha1.CFApp{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: spaceGUID,
+				},
+				Spec: korifiv1alpha1.CFAppSpec{
+					DisplayName:  "my-app",
+					DesiredState: korifiv1alpha1.StartedState,
+					Lifecycle: korifiv1alpha1.Lifecycle{
+						Type: "buildpack",
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfOrg, func() {
+				cfOrg.Spec.Suspended = true
+			})).To(Succeed())
+		})
+
+		It("stops the org apps", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				g.Expect(cfApp.Spec.DesiredState).To(Equal(korifiv1alpha1.StoppedState))
+			}).Should(Succeed())
+		})
+	})
 })
@@ -0,0 +1,90 @@
+package placement
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Placement holds the scheduling constraints of the pods running the apps and
+// tasks of a space
+type Placement struct {
+	NodeSelector map[string]string
+	Tolerations  []corev1.Toleration
+}
+
+type IsolationSegmentResolver struct {
+	k8sClient     client.Client
+	rootNamespace string
+}
+
+func NewIsolationSegmentResolver(k8sClient client.Client, rootNamespace string) *IsolationSegmentResolver {
+	return &IsolationSegmentResolver{
+		k8sClient:     k8sClient,
+		rootNamespace: rootNamespace,
+	}
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfisolationsegments,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgs,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// Resolve returns the placement defined by the isolation segment of the space
+// backed by spaceNamespace. Spaces without an isolation segment use the
+// default isolation segment of their org. A zero Placement is returned when
+// neither is set.
+func (r *IsolationSegmentResolver) Resolve(ctx context.Context, spaceNamespace string) (Placement, error) {
+	namespace := &corev1.Namespace{}
+	if err := r.k8sClient.Get(ctx, types.NamespacedName{Name: spaceNamespace}, namespace); err != nil {
+		return Placement{}, fmt.Errorf("failed to get namespace %q: %w", spaceNamespace, err)
+	}
+
+	orgGUID := namespace.Labels[korifiv1alpha1.CFOrgGUIDKey]
+	spaceGUID := namespace.Labels[korifiv1alpha1.SpaceGUIDLabelKey]
+	if orgGUID == "" || spaceGUID == "" {
+		return Placement{}, nil
+	}
+
+	segmentGUID, err := r.getIsolationSegmentGUID(ctx, orgGUID, spaceGUID)
+	if err != nil {
+		return Placement{}, err
+	}
+
+	if segmentGUID == "" {
+		return Placement{}, nil
+	}
+
+	isolationSegment := &korifiv1alpha1.CFIsolationSegment{}
+	if err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: r.rootNamespace, Name: segmentGUID}, isolationSegment); err != nil {
+		return Placement{}, fmt.Errorf("failed to get isolation segment %q: %w", segmentGUID, err)
+	}
+
+	return Placement{
+		NodeSelector: isolationSegment.Spec.NodeSelector,
+		Tolerations:  isolationSegment.Spec.Tolerations,
+	}, nil
+}
+
+func (r *IsolationSegmentResolver) getIsolationSegmentGUID(ctx context.Context, orgGUID, spaceGUID string) (string, error) {
+	cfSpace := &korifiv1alpha1.CFSpace{}
+	if err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: orgGUID, Name: spaceGUID}, cfSpace); err != nil {
+		return "", fmt.Errorf("failed to get space %q: %w", spaceGUID, err)
+	}
+
+	if cfSpace.Spec.IsolationSegmentGUID != "" {
+		return cfSpace.Spec.IsolationSegmentGUID, nil
+	}
+
+	cfOrg := &korifiv1alpha1.CFOrg{}
+	if err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: r.rootNamespace, Name: orgGUID}, cfOrg); err != nil {
+		return "", fmt.Errorf("failed to get org %q: %w", orgGUID, err)
+	}
+
+	return cfOrg.Spec.DefaultIsolationSegmentGUID, nil
+}
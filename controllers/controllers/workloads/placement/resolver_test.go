@@ -0,0 +1,140 @@
+package placement_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/placement"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("IsolationSegmentResolver", func() {
+	var (
+		rootNamespace    string
+		cfOrg            *korifiv1alpha1.CFOrg
+		cfSpace          *korifiv1alpha1.CFSpace
+		isolationSegment *korifiv1alpha1.CFIsolationSegment
+		resolver         *placement.IsolationSegmentResolver
+		spaceNamespace   string
+		result           placement.Placement
+		resolveErr       error
+	)
+
+	BeforeEach(func() {
+		rootNamespace = uuid.NewString()
+		createNamespace(rootNamespace, nil)
+
+		isolationSegment = &korifiv1alpha1.CFIsolationSegment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+				DisplayName:  "my-segment",
+				NodeSelector: map[string]string{"pool": "regulated"},
+				Tolerations: []corev1.Toleration{{
+					Key:      "pool",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "regulated",
+					Effect:   corev1.TaintEffectNoSchedule,
+				}},
+			},
+		}
+		helpers.EnsureCreate(adminClient, isolationSegment)
+
+		cfOrg = &korifiv1alpha1.CFOrg{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFOrgSpec{
+				DisplayName: uuid.NewString(),
+			},
+		}
+		helpers.EnsureCreate(adminClient, cfOrg)
+		createNamespace(cfOrg.Name, nil)
+
+		cfSpace = &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: cfOrg.Name,
+			},
+			Spec: korifiv1alpha1.CFSpaceSpec{
+				DisplayName: uuid.NewString(),
+			},
+		}
+		helpers.EnsureCreate(adminClient, cfSpace)
+
+		spaceNamespace = cfSpace.Name
+		createNamespace(spaceNamespace, map[string]string{
+			korifiv1alpha1.CFOrgGUIDKey:      cfOrg.Name,
+			korifiv1alpha1.SpaceGUIDLabelKey: cfSpace.Name,
+		})
+
+		resolver = placement.NewIsolationSegmentResolver(adminClient, rootNamespace)
+	})
+
+	JustBeforeEach(func() {
+		result, resolveErr = resolver.Resolve(ctx, spaceNamespace)
+	})
+
+	It("returns an empty placement", func() {
+		Expect(resolveErr).NotTo(HaveOccurred())
+		Expect(result).To(BeZero())
+	})
+
+	When("the space has an isolation segment", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, cfSpace, func(s *korifiv1alpha1.CFSpace) {
+				s.Spec.IsolationSegmentGUID = isolationSegment.Name
+			})
+		})
+
+		It("returns the placement of the isolation segment", func() {
+			Expect(resolveErr).NotTo(HaveOccurred())
+			Expect(result.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+			Expect(result.Tolerations).To(Equal(isolationSegment.Spec.Tolerations))
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				helpers.EnsurePatch(adminClient, cfSpace, func(s *korifiv1alpha1.CFSpace) {
+					s.Spec.IsolationSegmentGUID = "i-do-not-exist"
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(resolveErr).To(MatchError(ContainSubstring("i-do-not-exist")))
+			})
+		})
+	})
+
+	When("the org has a default isolation segment", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, cfOrg, func(o *korifiv1alpha1.CFOrg) {
+				o.Spec.DefaultIsolationSegmentGUID = isolationSegment.Name
+			})
+		})
+
+		It("returns the placement of the default isolation segment", func() {
+			Expect(resolveErr).NotTo(HaveOccurred())
+			Expect(result.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+		})
+	})
+
+	When("the namespace does not belong to a space", func() {
+		BeforeEach(func() {
+			spaceNamespace = uuid.NewString()
+			createNamespace(spaceNamespace, nil)
+		})
+
+		It("returns an empty placement", func() {
+			Expect(resolveErr).NotTo(HaveOccurred())
+			Expect(result).To(BeZero())
+		})
+	})
+})
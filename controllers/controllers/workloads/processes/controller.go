@@ -22,10 +22,12 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/placement"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/ports"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -48,16 +50,31 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	defaultLivenessPeriodSeconds    int32 = 30
+	defaultLivenessFailureThreshold int32 = 1
+)
+
 type ProcessEnvBuilder interface {
 	Build(context.Context, *korifiv1alpha1.CFApp, *korifiv1alpha1.CFProcess) ([]corev1.EnvVar, error)
 }
 
+type PlacementResolver interface {
+	Resolve(context.Context, string) (placement.Placement, error)
+}
+
+type UsageRecorder interface {
+	RecordProcessUsage(context.Context, *korifiv1alpha1.CFApp, *korifiv1alpha1.CFProcess) error
+}
+
 type Reconciler struct {
 	k8sClient        client.Client
 	scheme           *runtime.Scheme
 	log              logr.Logger
 	controllerConfig *config.ControllerConfig
 	envBuilder       ProcessEnvBuilder
+	placement        PlacementResolver
+	usageRecorder    UsageRecorder
 }
 
 func NewReconciler(
@@ -66,8 +83,10 @@ func NewReconciler(
 	log logr.Logger,
 	controllerConfig *config.ControllerConfig,
 	envBuilder ProcessEnvBuilder,
+	placement PlacementResolver,
+	usageRecorder UsageRecorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFProcess] {
-	processReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, controllerConfig: controllerConfig, envBuilder: envBuilder}
+	processReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, controllerConfig: controllerConfig, envBuilder: envBuilder, placement: placement, usageRecorder: usageRecorder}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFProcess](log, client, &processReconciler)
 }
 
@@ -82,6 +101,10 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&korifiv1alpha1.CFRoute{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForRoute),
+		).
+		Watches(
+			&korifiv1alpha1.CFSidecar{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForSidecar),
 		)
 }
 
@@ -120,10 +143,22 @@ func (r *Reconciler) enqueueCFProcessRequestsForRoute(ctx context.Context, o cli
 	return result
 }
 
+func (r *Reconciler) enqueueCFProcessRequestsForSidecar(ctx context.Context, o client.Object) []reconcile.Request {
+	cfSidecar, ok := o.(*korifiv1alpha1.CFSidecar)
+	if !ok {
+		r.log.Error(errors.New("listing CFProcesses for sidecar failed"), "expected", "CFSidecar", "got", o)
+		return []reconcile.Request{}
+	}
+
+	return r.cfProcessRequestsForAppGUID(ctx, cfSidecar.Namespace, cfSidecar.Spec.AppRef.Name)
+}
+
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses/finalizers,verbs=update
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsidecars,verbs=get;list;watch
+
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
@@ -150,6 +185,12 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 		return ctrl.Result{}, err
 	}
 
+	err = r.usageRecorder.RecordProcessUsage(ctx, cfApp, cfProcess)
+	if err != nil {
+		log.Info("error when trying to record the process usage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	if needsAppWorkload(cfApp, cfProcess) {
 		err = r.createOrPatchAppWorkload(ctx, cfApp, cfProcess)
 		if err != nil {
@@ -169,6 +210,7 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 
 	cfProcess.Status.ActualInstances = getActualInstances(appWorkloads)
 	cfProcess.Status.InstancesStatus = getCurrentInstancesStatus(getDesiredAppWorkloadName(cfApp, cfProcess), appWorkloads)
+	cfProcess.Status.Autoscaling = getCurrentAutoscalingStatus(getDesiredAppWorkloadName(cfApp, cfProcess), appWorkloads)
 
 	if !allReady(appWorkloads) {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("AppWorkloadsNotReady").WithRequeue()
@@ -191,6 +233,14 @@ func getLastStopRevision(app *korifiv1alpha1.CFApp) string {
 	return tools.GetMapValue(app.Annotations, korifiv1alpha1.CFAppLastStopRevisionKey, getRevision(app))
 }
 
+func getMaxInFlight(app *korifiv1alpha1.CFApp) *int32 {
+	if app.Spec.Deployment == nil {
+		return nil
+	}
+
+	return app.Spec.Deployment.MaxInFlight
+}
+
 func getActualInstances(appWorkloads []korifiv1alpha1.AppWorkload) int32 {
 	actualInstances := int32(0)
 	for _, w := range appWorkloads {
@@ -209,6 +259,16 @@ func getCurrentInstancesStatus(desiredAppWorkloadName string, appWorkloads []kor
 	return nil
 }
 
+func getCurrentAutoscalingStatus(desiredAppWorkloadName string, appWorkloads []korifiv1alpha1.AppWorkload) *korifiv1alpha1.ProcessAutoscalingStatus {
+	for _, workload := range appWorkloads {
+		if workload.Name == desiredAppWorkloadName {
+			return workload.Status.Autoscaling
+		}
+	}
+
+	return nil
+}
+
 func needsAppWorkload(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) bool {
 	if cfApp.Spec.DesiredState != korifiv1alpha1.StartedState {
 		return false
@@ -254,6 +314,18 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 		return err
 	}
 
+	workloadPlacement, err := r.placement.Resolve(ctx, cfProcess.Namespace)
+	if err != nil {
+		log.Info("error when trying to resolve the isolation segment placement", "namespace", cfProcess.Namespace, "reason", err)
+		return err
+	}
+
+	sidecars, err := r.sidecarsForProcess(ctx, cfApp, cfProcess)
+	if err != nil {
+		log.Info("error when trying to list the sidecars of the process", "namespace", cfProcess.Namespace, "name", cfProcess.Name, "reason", err)
+		return err
+	}
+
 	appWorkload := &korifiv1alpha1.AppWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      getDesiredAppWorkloadName(cfApp, cfProcess),
@@ -290,12 +362,20 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 
 		appWorkload.Spec.Ports = appPorts
 		appWorkload.Spec.Instances = tools.ZeroIfNil(cfProcess.Spec.DesiredInstances)
+		rolloutInstances := getRolloutInstances(cfProcess, appWorkload)
+		appWorkload.Spec.RolloutPartition = rolloutInstances - cfApp.Spec.Deployment.UpdatedInstances(rolloutInstances)
+		appWorkload.Spec.MaxUnavailable = getMaxInFlight(cfApp)
 
 		appWorkload.Spec.Env = envVars
 
 		appWorkload.Spec.StartupProbe = startupProbe(cfProcess, appPorts)
 		appWorkload.Spec.LivenessProbe = livenessProbe(cfProcess, appPorts)
+		appWorkload.Spec.ReadinessProbe = readinessProbe(cfProcess, appPorts)
 		appWorkload.Spec.RunnerName = r.controllerConfig.RunnerName
+		appWorkload.Spec.NodeSelector = workloadPlacement.NodeSelector
+		appWorkload.Spec.Tolerations = workloadPlacement.Tolerations
+		appWorkload.Spec.Sidecars = sidecars
+		appWorkload.Spec.Autoscaling = cfProcess.Spec.Autoscaling
 
 		if appWorkload.CreationTimestamp.IsZero() {
 			appWorkload.Spec.Services = cfApp.Status.ServiceBindings
@@ -310,6 +390,17 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 	return nil
 }
 
+// getRolloutInstances returns the number of instances a rollout is partitioned
+// across. The instances of autoscaled workloads are owned by the autoscaler, so
+// the partition is based on the instances it currently runs.
+func getRolloutInstances(cfProcess *korifiv1alpha1.CFProcess, appWorkload *korifiv1alpha1.AppWorkload) int32 {
+	if cfProcess.Spec.Autoscaling != nil && appWorkload.Status.Autoscaling != nil && appWorkload.Status.Autoscaling.CurrentInstances > 0 {
+		return appWorkload.Status.Autoscaling.CurrentInstances
+	}
+
+	return appWorkload.Spec.Instances
+}
+
 func (r *Reconciler) cleanUpAppWorkloads(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("cleanUpAppWorkloads")
 
@@ -373,12 +464,54 @@ func (r *Reconciler) fetchAppWorkloadsForProcess(ctx context.Context, cfProcess
 	return appWorkloadsForProcess.Items, err
 }
 
+func (r *Reconciler) sidecarsForProcess(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) ([]korifiv1alpha1.AppWorkloadSidecar, error) {
+	sidecarList := &korifiv1alpha1.CFSidecarList{}
+	err := r.k8sClient.List(ctx, sidecarList, client.InNamespace(cfProcess.Namespace), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cfSidecars := slices.SortedFunc(
+		it.Filter(slices.Values(sidecarList.Items), func(s korifiv1alpha1.CFSidecar) bool {
+			return s.RunsWith(cfProcess.Spec.ProcessType)
+		}),
+		func(s1, s2 korifiv1alpha1.CFSidecar) int {
+			return strings.Compare(s1.Name, s2.Name)
+		},
+	)
+
+	sidecars := []korifiv1alpha1.AppWorkloadSidecar{}
+	for _, cfSidecar := range cfSidecars {
+		sidecar := korifiv1alpha1.AppWorkloadSidecar{
+			Name:    cfSidecar.Name,
+			Command: launchCommand(cfSidecar.Spec.Command, cfApp),
+		}
+
+		if cfSidecar.Spec.MemoryMB > 0 {
+			sidecar.Resources = corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: mebibyteQuantity(cfSidecar.Spec.MemoryMB)},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: mebibyteQuantity(cfSidecar.Spec.MemoryMB)},
+			}
+		}
+
+		sidecars = append(sidecars, sidecar)
+	}
+
+	return sidecars, nil
+}
+
 func commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CFApp) []string {
 	cmd := process.Spec.Command
 	if cmd == "" {
 		cmd = process.Spec.DetectedCommand
 	}
 
+	return launchCommand(cmd, app)
+}
+
+func launchCommand(cmd string, app *korifiv1alpha1.CFApp) []string {
 	if cmd == "" {
 		return []string{}
 	}
@@ -390,13 +523,13 @@ func commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CF
 	return []string{"/bin/sh", "-c", cmd}
 }
 
-func makeProbeHandler(cfProcess *korifiv1alpha1.CFProcess, port int32) corev1.ProbeHandler {
+func makeProbeHandler(healthCheckType korifiv1alpha1.HealthCheckType, httpEndpoint string, port int32) corev1.ProbeHandler {
 	var probeHandler corev1.ProbeHandler
 
-	switch cfProcess.Spec.HealthCheck.Type {
+	switch healthCheckType {
 	case korifiv1alpha1.HTTPHealthCheckType:
 		probeHandler.HTTPGet = &corev1.HTTPGetAction{
-			Path: cfProcess.Spec.HealthCheck.Data.HTTPEndpoint,
+			Path: httpEndpoint,
 			Port: intstr.FromInt32(port),
 		}
 	case korifiv1alpha1.PortHealthCheckType:
@@ -418,7 +551,7 @@ func startupProbe(cfProcess *korifiv1alpha1.CFProcess, ports []int32) *corev1.Pr
 	}
 
 	return &corev1.Probe{
-		ProbeHandler:   makeProbeHandler(cfProcess, ports[0]),
+		ProbeHandler:   makeProbeHandler(cfProcess.Spec.HealthCheck.Type, cfProcess.Spec.HealthCheck.Data.HTTPEndpoint, ports[0]),
 		TimeoutSeconds: int32(cfProcess.Spec.HealthCheck.Data.InvocationTimeoutSeconds),
 		PeriodSeconds:  2,
 		FailureThreshold: int32(cfProcess.Spec.HealthCheck.Data.TimeoutSeconds/2 +
@@ -436,9 +569,30 @@ func livenessProbe(cfProcess *korifiv1alpha1.CFProcess, ports []int32) *corev1.P
 	}
 
 	return &corev1.Probe{
-		ProbeHandler:     makeProbeHandler(cfProcess, ports[0]),
+		ProbeHandler:     makeProbeHandler(cfProcess.Spec.HealthCheck.Type, cfProcess.Spec.HealthCheck.Data.HTTPEndpoint, ports[0]),
 		TimeoutSeconds:   int32(cfProcess.Spec.HealthCheck.Data.InvocationTimeoutSeconds),
-		PeriodSeconds:    30,
+		PeriodSeconds:    tools.IfZero(cfProcess.Spec.HealthCheck.Data.IntervalSeconds, defaultLivenessPeriodSeconds),
+		FailureThreshold: tools.IfZero(cfProcess.Spec.HealthCheck.Data.FailureThreshold, defaultLivenessFailureThreshold),
+	}
+}
+
+// readinessProbe removes instances failing the readiness health check from
+// the endpoints of the app service, so that they stop receiving traffic
+// without being restarted
+func readinessProbe(cfProcess *korifiv1alpha1.CFProcess, ports []int32) *corev1.Probe {
+	readinessHealthCheck := cfProcess.Spec.ReadinessHealthCheck
+	if readinessHealthCheck.Type == "" || readinessHealthCheck.Type == korifiv1alpha1.ProcessHealthCheckType {
+		return nil
+	}
+
+	if len(ports) == 0 {
+		return nil
+	}
+
+	return &corev1.Probe{
+		ProbeHandler:     makeProbeHandler(readinessHealthCheck.Type, readinessHealthCheck.Data.HTTPEndpoint, ports[0]),
+		TimeoutSeconds:   readinessHealthCheck.Data.InvocationTimeoutSeconds,
+		PeriodSeconds:    readinessHealthCheck.Data.IntervalSeconds,
 		FailureThreshold: 1,
 	}
 }
@@ -180,6 +180,21 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	It("records the usage of the stopped process without recording a usage event", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+			g.Expect(cfProcess.Status.Usage).To(PointTo(Equal(korifiv1alpha1.ProcessUsage{
+				State:     korifiv1alpha1.AppUsageEventStateStopped,
+				Instances: 1,
+				MemoryMB:  1024,
+			})))
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(BeEmpty())
+		}, "1s").Should(Succeed())
+	})
+
 	When("the process is being deleted gracefully", func() {
 		BeforeEach(func() {
 			cfProcess.Finalizers = []string{"do-not-delete-yet"}
@@ -263,6 +278,71 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		It("records a STARTED app usage event", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": Equal(korifiv1alpha1.CFAppUsageEventSpec{
+						State:                 korifiv1alpha1.AppUsageEventStateStarted,
+						App:                   korifiv1alpha1.UsageEventResource{GUID: cfApp.Name, Name: "test-app-name"},
+						Process:               korifiv1alpha1.UsageEventProcess{GUID: cfProcess.Name, Type: korifiv1alpha1.ProcessTypeWeb},
+						Space:                 korifiv1alpha1.UsageEventResource{GUID: testNamespace},
+						InstanceCount:         1,
+						MemoryInMBPerInstance: 1024,
+					}),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the process is scaled", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(HaveLen(1))
+				}).Should(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.DesiredInstances = tools.PtrTo[int32](3)
+				})).To(Succeed())
+			})
+
+			It("records a SCALED app usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":                         Equal(korifiv1alpha1.AppUsageEventStateScaled),
+							"PreviousState":                 Equal(korifiv1alpha1.AppUsageEventStateStarted),
+							"InstanceCount":                 BeEquivalentTo(3),
+							"PreviousInstanceCount":         BeEquivalentTo(1),
+							"MemoryInMBPerInstance":         BeEquivalentTo(1024),
+							"PreviousMemoryInMBPerInstance": BeEquivalentTo(1024),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the app is stopped", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(HaveLen(1))
+				}).Should(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+				})).To(Succeed())
+			})
+
+			It("records a STOPPED app usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":         Equal(korifiv1alpha1.AppUsageEventStateStopped),
+							"PreviousState": Equal(korifiv1alpha1.AppUsageEventStateStarted),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
 		When("the CFApp status is outdated", func() {
 			BeforeEach(func() {
 				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
@@ -352,6 +432,37 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the CFProcess health check has an interval and a failure threshold", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.HealthCheck = korifiv1alpha1.HealthCheck{
+					Type: "http",
+					Data: korifiv1alpha1.HealthCheckData{
+						HTTPEndpoint:             "/healthy",
+						InvocationTimeoutSeconds: 3,
+						TimeoutSeconds:           9,
+						IntervalSeconds:          10,
+						FailureThreshold:         6,
+					},
+				}
+			})
+
+			It("uses them in the liveness probe", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.LivenessProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.LivenessProbe.PeriodSeconds).To(BeEquivalentTo(10))
+					g.Expect(appWorkload.Spec.LivenessProbe.FailureThreshold).To(BeEquivalentTo(6))
+				})
+			})
+
+			It("does not change the startup probe", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.StartupProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.StartupProbe.PeriodSeconds).To(BeEquivalentTo(2))
+					g.Expect(appWorkload.Spec.StartupProbe.FailureThreshold).To(BeEquivalentTo(5))
+				})
+			})
+		})
+
 		When("the CFProcess has a process health check", func() {
 			BeforeEach(func() {
 				cfProcess.Spec.HealthCheck = korifiv1alpha1.HealthCheck{Type: "process"}
@@ -365,6 +476,53 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		It("does not set a readiness probe on the AppWorkload", func() {
+			withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+				g.Expect(appWorkload.Spec.ReadinessProbe).To(BeNil())
+			})
+		})
+
+		When("the CFProcess has an http readiness health check", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.ReadinessHealthCheck = korifiv1alpha1.ReadinessHealthCheck{
+					Type: "http",
+					Data: korifiv1alpha1.ReadinessHealthCheckData{
+						HTTPEndpoint:             "/ready",
+						InvocationTimeoutSeconds: 2,
+						IntervalSeconds:          5,
+					},
+				}
+			})
+
+			It("sets the readiness probe on the AppWorkload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.ReadinessProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe.HTTPGet).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe.HTTPGet.Path).To(Equal("/ready"))
+					g.Expect(appWorkload.Spec.ReadinessProbe.HTTPGet.Port.IntValue()).To(Equal(8080))
+					g.Expect(appWorkload.Spec.ReadinessProbe.PeriodSeconds).To(BeEquivalentTo(5))
+					g.Expect(appWorkload.Spec.ReadinessProbe.TimeoutSeconds).To(BeEquivalentTo(2))
+					g.Expect(appWorkload.Spec.ReadinessProbe.FailureThreshold).To(BeEquivalentTo(1))
+				})
+			})
+		})
+
+		When("the CFProcess has a port readiness health check", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.ReadinessHealthCheck = korifiv1alpha1.ReadinessHealthCheck{
+					Type: "port",
+				}
+			})
+
+			It("sets the readiness probe on the AppWorkload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.ReadinessProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe.TCPSocket).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe.TCPSocket.Port.IntValue()).To(Equal(8080))
+				})
+			})
+		})
+
 		When("the app workload actual instances are set", func() {
 			JustBeforeEach(func() {
 				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
@@ -407,6 +565,49 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the process has an autoscaling policy", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.Autoscaling = &korifiv1alpha1.ProcessAutoscaling{
+					MinInstances:          1,
+					MaxInstances:          5,
+					CPUUtilizationPercent: tools.PtrTo[int32](70),
+				}
+			})
+
+			It("sets the autoscaling policy on the app workload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Autoscaling).To(Equal(&korifiv1alpha1.ProcessAutoscaling{
+						MinInstances:          1,
+						MaxInstances:          5,
+						CPUUtilizationPercent: tools.PtrTo[int32](70),
+					}))
+				})
+			})
+
+			When("the app workload autoscaling status is set", func() {
+				JustBeforeEach(func() {
+					withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(k8s.Patch(ctx, adminClient, &appWorkload, func() {
+							appWorkload.Status.Autoscaling = &korifiv1alpha1.ProcessAutoscalingStatus{
+								CurrentInstances: 2,
+								DesiredInstances: 3,
+							}
+						})).To(Succeed())
+					})
+				})
+
+				It("updates the process autoscaling status", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+						g.Expect(cfProcess.Status.Autoscaling).To(Equal(&korifiv1alpha1.ProcessAutoscalingStatus{
+							CurrentInstances: 2,
+							DesiredInstances: 3,
+						}))
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("the app workload is not ready", func() {
 			JustBeforeEach(func() {
 				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
@@ -453,6 +654,232 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		It("does not set sidecars on the app workload", func() {
+			withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+				g.Expect(appWorkload.Spec.Sidecars).To(BeEmpty())
+			})
+		})
+
+		When("the app has sidecars", func() {
+			var webSidecar, workerSidecar *korifiv1alpha1.CFSidecar
+
+			BeforeEach(func() {
+				webSidecar = &korifiv1alpha1.CFSidecar{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+						Labels: map[string]string{
+							korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+						},
+					},
+					Spec: korifiv1alpha1.CFSidecarSpec{
+						AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+						Name:         "web-sidecar",
+						Command:      "bin/web-sidecar",
+						ProcessTypes: []string{korifiv1alpha1.ProcessTypeWeb, "worker"},
+						MemoryMB:     64,
+					},
+				}
+				Expect(adminClient.Create(ctx, webSidecar)).To(Succeed())
+
+				workerSidecar = &korifiv1alpha1.CFSidecar{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+						Labels: map[string]string{
+							korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+						},
+					},
+					Spec: korifiv1alpha1.CFSidecarSpec{
+						AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+						Name:         "worker-sidecar",
+						Command:      "bin/worker-sidecar",
+						ProcessTypes: []string{"worker"},
+					},
+				}
+				Expect(adminClient.Create(ctx, workerSidecar)).To(Succeed())
+			})
+
+			It("sets the sidecars running with the process type on the app workload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Sidecars).To(ConsistOf(MatchAllFields(Fields{
+						"Name":    Equal(webSidecar.Name),
+						"Command": Equal([]string{"/cnb/lifecycle/launcher", "bin/web-sidecar"}),
+						"Resources": MatchAllFields(Fields{
+							"Limits":   MatchAllKeys(Keys{corev1.ResourceMemory: matchers.RepresentResourceQuantity(64, "Mi")}),
+							"Requests": MatchAllKeys(Keys{corev1.ResourceMemory: matchers.RepresentResourceQuantity(64, "Mi")}),
+							"Claims":   BeEmpty(),
+						}),
+					})))
+				})
+			})
+
+			When("a sidecar is added to the app later", func() {
+				var newSidecar *korifiv1alpha1.CFSidecar
+
+				JustBeforeEach(func() {
+					withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.Sidecars).To(HaveLen(1))
+					})
+
+					newSidecar = &korifiv1alpha1.CFSidecar{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      uuid.NewString(),
+							Namespace: testNamespace,
+							Labels: map[string]string{
+								korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+							},
+						},
+						Spec: korifiv1alpha1.CFSidecarSpec{
+							AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+							Name:         "new-sidecar",
+							Command:      "bin/new-sidecar",
+							ProcessTypes: []string{korifiv1alpha1.ProcessTypeWeb},
+						},
+					}
+					Expect(adminClient.Create(ctx, newSidecar)).To(Succeed())
+				})
+
+				It("adds it to the app workload", func() {
+					withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.Sidecars).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Name": Equal(newSidecar.Name),
+						})))
+					})
+				})
+			})
+		})
+
+		When("the space has an isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegment := &korifiv1alpha1.CFIsolationSegment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: rootNamespace,
+					},
+					Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+						DisplayName:  uuid.NewString(),
+						NodeSelector: map[string]string{"pool": "regulated"},
+						Tolerations: []corev1.Toleration{{
+							Key:      "pool",
+							Operator: corev1.TolerationOpExists,
+							Effect:   corev1.TaintEffectNoSchedule,
+						}},
+					},
+				}
+				Expect(adminClient.Create(ctx, isolationSegment)).To(Succeed())
+
+				orgGUID := uuid.NewString()
+				Expect(adminClient.Create(ctx, &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{Name: orgGUID},
+				})).To(Succeed())
+				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      testNamespace,
+						Namespace: orgGUID,
+					},
+					Spec: korifiv1alpha1.CFSpaceSpec{
+						DisplayName:          uuid.NewString(),
+						IsolationSegmentGUID: isolationSegment.Name,
+					},
+				})).To(Succeed())
+
+				namespace := &corev1.Namespace{}
+				Expect(adminClient.Get(ctx, client.ObjectKey{Name: testNamespace}, namespace)).To(Succeed())
+				Expect(k8s.PatchResource(ctx, adminClient, namespace, func() {
+					namespace.Labels = map[string]string{
+						korifiv1alpha1.CFOrgGUIDKey:      orgGUID,
+						korifiv1alpha1.SpaceGUIDLabelKey: testNamespace,
+					}
+				})).To(Succeed())
+			})
+
+			It("sets the isolation segment placement on the app workload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+					g.Expect(appWorkload.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+						Key:      "pool",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoSchedule,
+					}))
+				})
+			})
+		})
+
+		It("rolls over all instances", func() {
+			withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+				g.Expect(appWorkload.Spec.RolloutPartition).To(BeZero())
+				g.Expect(appWorkload.Spec.MaxUnavailable).To(BeNil())
+			})
+		})
+
+		When("the app has a canary deployment", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.DesiredInstances = tools.PtrTo[int32](5)
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.Deployment = &korifiv1alpha1.CFAppDeployment{
+						Strategy:    korifiv1alpha1.DeploymentStrategyCanary,
+						MaxInFlight: tools.PtrTo[int32](2),
+						CanarySteps: []korifiv1alpha1.CanaryStep{{InstanceWeight: 40}, {InstanceWeight: 80}},
+					}
+				})).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+					cfApp.Status.ObservedGeneration = cfApp.Generation
+				})).To(Succeed())
+			})
+
+			It("only rolls over the instances of the current canary step", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.RolloutPartition).To(BeEquivalentTo(3))
+					g.Expect(appWorkload.Spec.MaxUnavailable).To(PointTo(BeEquivalentTo(2)))
+				})
+			})
+
+			When("the process is autoscaled", func() {
+				BeforeEach(func() {
+					cfProcess.Spec.Autoscaling = &korifiv1alpha1.ProcessAutoscaling{
+						MinInstances: 1,
+						MaxInstances: 20,
+					}
+				})
+
+				JustBeforeEach(func() {
+					withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(k8s.Patch(ctx, adminClient, &appWorkload, func() {
+							appWorkload.Status.Autoscaling = &korifiv1alpha1.ProcessAutoscalingStatus{
+								CurrentInstances: 10,
+								DesiredInstances: 10,
+							}
+						})).To(Succeed())
+					})
+				})
+
+				It("partitions the rollout across the instances run by the autoscaler", func() {
+					withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.RolloutPartition).To(BeEquivalentTo(6))
+					})
+				})
+			})
+
+			When("the deployment is continued past the last step", func() {
+				JustBeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+						cfApp.Spec.Deployment.CurrentStep = 2
+					})).To(Succeed())
+					Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+						cfApp.Status.ObservedGeneration = cfApp.Generation
+					})).To(Succeed())
+				})
+
+				It("rolls over all instances", func() {
+					withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.RolloutPartition).To(BeZero())
+					})
+				})
+			})
+		})
+
 		When("the app bindings change after the workload has been created", func() {
 			JustBeforeEach(func() {
 				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
@@ -618,3 +1045,17 @@ func withAppWorkload(shouldFn func(Gomega, korifiv1alpha1.AppWorkload)) {
 		shouldFn(g, appWorkloads.Items[0])
 	}).Should(Succeed())
 }
+
+func listAppUsageEvents(g Gomega, processGUID string) []korifiv1alpha1.CFAppUsageEvent {
+	var usageEvents korifiv1alpha1.CFAppUsageEventList
+	g.Expect(adminClient.List(context.Background(), &usageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+
+	var result []korifiv1alpha1.CFAppUsageEvent
+	for _, usageEvent := range usageEvents.Items {
+		if usageEvent.Spec.Process.GUID == processGUID {
+			result = append(result, usageEvent)
+		}
+	}
+
+	return result
+}
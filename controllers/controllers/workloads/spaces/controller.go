@@ -28,6 +28,7 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8s_labels "k8s.io/apimachinery/pkg/labels"
@@ -136,6 +137,7 @@ func (r *Reconciler) enqueueCFSpaceRequestsForServiceAccount(ctx context.Context
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=create;patch;delete;get;list;watch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;patch;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;patch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfSpace *korifiv1alpha1.CFSpace) (ctrl.Result, error) {
 	nsReconcileResult, err := r.namespaceReconciler.ReconcileResource(ctx, cfSpace)
@@ -151,9 +153,70 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfSpace *korifiv1alp
 		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ServiceAccountPropagation")
 	}
 
+	err = r.reconcileAppIngressNetworkPolicy(ctx, cfSpace)
+	if err != nil {
+		log.Info("not ready yet", "reason", "error reconciling app ingress network policy", "error", err)
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("AppIngressNetworkPolicy")
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// reconcileAppIngressNetworkPolicy denies ingress to the app pods of the
+// space from the app pods of any space, so that container to container
+// traffic is only allowed by CF network policies. Ingress from pods that are
+// not app pods, e.g. the gateway or the route service proxy, is still allowed.
+func (r *Reconciler) reconcileAppIngressNetworkPolicy(ctx context.Context, cfSpace *korifiv1alpha1.CFSpace) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("reconcileAppIngressNetworkPolicy")
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      korifiv1alpha1.AppIngressNetworkPolicyName,
+			Namespace: cfSpace.Name,
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, networkPolicy, func() error {
+		networkPolicy.Spec.PodSelector = metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      korifiv1alpha1.CFAppGUIDLabelKey,
+				Operator: metav1.LabelSelectorOpExists,
+			}},
+		}
+		networkPolicy.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+		networkPolicy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      korifiv1alpha1.SpaceGUIDLabelKey,
+							Operator: metav1.LabelSelectorOpDoesNotExist,
+						}},
+					},
+				},
+				{
+					NamespaceSelector: &metav1.LabelSelector{},
+					PodSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{
+							Key:      korifiv1alpha1.CFAppGUIDLabelKey,
+							Operator: metav1.LabelSelectorOpDoesNotExist,
+						}},
+					},
+				},
+			},
+		}}
+
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to create/patch NetworkPolicy", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("NetworkPolicy reconciled", "operation", result)
+	return nil
+}
+
 func (r *Reconciler) reconcileServiceAccounts(ctx context.Context, space client.Object) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("reconcileServiceAccounts").
 		WithValues("rootNamespace", r.rootNamespace, "targetNamespace", space.GetName())
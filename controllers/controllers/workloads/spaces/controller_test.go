@@ -13,6 +13,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -71,6 +72,40 @@ var _ = Describe("CFSpaceReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	It("denies ingress to the app pods from other apps", func() {
+		Eventually(func(g Gomega) {
+			networkPolicy := &networkingv1.NetworkPolicy{}
+			g.Expect(adminClient.Get(ctx, types.NamespacedName{Namespace: cfSpace.Name, Name: korifiv1alpha1.AppIngressNetworkPolicyName}, networkPolicy)).To(Succeed())
+
+			g.Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+			g.Expect(networkPolicy.Spec.PodSelector.MatchExpressions).To(ConsistOf(metav1.LabelSelectorRequirement{
+				Key:      korifiv1alpha1.CFAppGUIDLabelKey,
+				Operator: metav1.LabelSelectorOpExists,
+			}))
+			g.Expect(networkPolicy.Spec.Ingress).To(ConsistOf(networkingv1.NetworkPolicyIngressRule{
+				From: []networkingv1.NetworkPolicyPeer{
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{
+								Key:      korifiv1alpha1.SpaceGUIDLabelKey,
+								Operator: metav1.LabelSelectorOpDoesNotExist,
+							}},
+						},
+					},
+					{
+						NamespaceSelector: &metav1.LabelSelector{},
+						PodSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{
+								Key:      korifiv1alpha1.CFAppGUIDLabelKey,
+								Operator: metav1.LabelSelectorOpDoesNotExist,
+							}},
+						},
+					},
+				},
+			}))
+		}).Should(Succeed())
+	})
+
 	Describe("service account propagation", func() {
 		var serviceAccount *corev1.ServiceAccount
 
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/placement"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
@@ -49,12 +50,17 @@ type TaskEnvBuilder interface {
 	Build(context.Context, *korifiv1alpha1.CFApp) ([]corev1.EnvVar, error)
 }
 
+type PlacementResolver interface {
+	Resolve(context.Context, string) (placement.Placement, error)
+}
+
 type Reconciler struct {
 	k8sClient       client.Client
 	scheme          *runtime.Scheme
 	recorder        events.EventRecorder
 	log             logr.Logger
 	envBuilder      TaskEnvBuilder
+	placement       PlacementResolver
 	taskTTLDuration time.Duration
 }
 
@@ -64,6 +70,7 @@ func NewReconciler(
 	recorder events.EventRecorder,
 	log logr.Logger,
 	envBuilder TaskEnvBuilder,
+	placement PlacementResolver,
 	taskTTLDuration time.Duration,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFTask] {
 	taskReconciler := Reconciler{
@@ -72,6 +79,7 @@ func NewReconciler(
 		recorder:        recorder,
 		log:             log,
 		envBuilder:      envBuilder,
+		placement:       placement,
 		taskTTLDuration: taskTTLDuration,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFTask](log, client, &taskReconciler)
@@ -143,7 +151,13 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfTask *korifiv1alph
 		return r.reconcileResult(cfTask, err)
 	}
 
-	taskWorkload, err := r.createOrPatchTaskWorkload(ctx, cfTask, cfDroplet, webProcess, env)
+	taskPlacement, err := r.placement.Resolve(ctx, cfTask.Namespace)
+	if err != nil {
+		log.Info("failed to resolve isolation segment placement", "reason", err)
+		return r.reconcileResult(cfTask, err)
+	}
+
+	taskWorkload, err := r.createOrPatchTaskWorkload(ctx, cfTask, cfDroplet, webProcess, env, taskPlacement)
 	if err != nil {
 		return r.reconcileResult(cfTask, err)
 	}
@@ -248,7 +262,7 @@ func (r *Reconciler) getWebProcess(ctx context.Context, cfApp *korifiv1alpha1.CF
 	return processList.Items[0], nil
 }
 
-func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfDroplet *korifiv1alpha1.CFBuild, webProcess korifiv1alpha1.CFProcess, env []corev1.EnvVar) (*korifiv1alpha1.TaskWorkload, error) {
+func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfDroplet *korifiv1alpha1.CFBuild, webProcess korifiv1alpha1.CFProcess, env []corev1.EnvVar, taskPlacement placement.Placement) (*korifiv1alpha1.TaskWorkload, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchTaskWorkload")
 
 	taskWorkload := &korifiv1alpha1.TaskWorkload{
@@ -283,6 +297,8 @@ func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *kori
 		taskWorkload.Spec.Resources.Limits[corev1.ResourceEphemeralStorage] = *resource.NewScaledQuantity(cfTask.Status.DiskQuotaMB, resource.Mega)
 		taskWorkload.Spec.Resources.Requests[corev1.ResourceCPU] = *resource.NewScaledQuantity(calculateDefaultCPURequestMillicores(webProcess.Spec.MemoryMB), resource.Milli)
 		taskWorkload.Spec.Env = env
+		taskWorkload.Spec.NodeSelector = taskPlacement.NodeSelector
+		taskWorkload.Spec.Tolerations = taskPlacement.Tolerations
 
 		if err := ctrl.SetControllerReference(cfTask, taskWorkload, r.scheme); err != nil {
 			log.Info("failed to set owner ref", "reason", err)
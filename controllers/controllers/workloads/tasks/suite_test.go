@@ -9,6 +9,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/placement"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/tasks"
 	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
 	"code.cloudfoundry.org/korifi/tests/helpers"
@@ -78,6 +79,7 @@ var _ = BeforeSuite(func() {
 		eventRecorder,
 		ctrl.Log.WithName("controllers").WithName("CFTask"),
 		env.NewAppEnvBuilder(k8sManager.GetClient()),
+		placement.NewIsolationSegmentResolver(k8sManager.GetClient(), "cf"),
 		2*time.Second,
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
@@ -18,24 +18,33 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/cleanup"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/domains"
+	networkpolicies "code.cloudfoundry.org/korifi/controllers/controllers/networking/network_policies"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routeservice"
+	securitygroups "code.cloudfoundry.org/korifi/controllers/controllers/networking/security_groups"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings"
 	managed_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/managed"
 	upsi_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/upsi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/brokers"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/drains/syslog"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/instances/managed"
 	upsi_instances "code.cloudfoundry.org/korifi/controllers/controllers/services/instances/upsi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/routebindings"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/apps"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/buildpack"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/docker"
@@ -43,6 +52,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/labels"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/orgs"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/packages"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/placement"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/processes"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/spaces"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/tasks"
@@ -52,17 +62,25 @@ import (
 	controllers_finalizer "code.cloudfoundry.org/korifi/controllers/webhooks/finalizer"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/label_indexer"
 	domainswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/networking/domains"
+	networkpolicieswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/networking/network_policies"
 	routeswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/networking/routes"
 	routesdestwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/networking/routes/app_destinations"
 	securitygroupswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/networking/security_groups"
+	orgquotaswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/quotas/orgquotas"
+	spacequotaswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/quotas/spacequotas"
 	bindingswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/services/bindings"
 	brokerswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/services/brokers"
 	instanceswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/services/instances"
+	routebindingswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/services/routebindings"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	versionwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/version"
 	appswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/apps"
+	buildswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/builds"
+	isolationsegmentswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/isolationsegments"
 	orgswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/orgs"
 	packageswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/packages"
+	processeswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/processes"
+	sidecarswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/sidecars"
 	spaceswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/spaces"
 	taskswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/tasks"
 	jobtaskrunnercontrollers "code.cloudfoundry.org/korifi/job-task-runner/controllers"
@@ -79,6 +97,7 @@ import (
 
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	k8sclient "k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -89,6 +108,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -105,7 +126,9 @@ var (
 func init() {
 	utilruntime.Must(buildv1alpha2.AddToScheme(scheme))
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.Install(scheme))
 	utilruntime.Must(gatewayv1beta1.Install(scheme))
+	utilruntime.Must(gatewayv1alpha2.Install(scheme))
 	utilruntime.Must(korifiv1alpha1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
@@ -175,9 +198,18 @@ func main() {
 
 	controllersClient := k8s.IgnoreEmptyPatches(mgr.GetClient())
 
+	uncachedClient, err := client.New(mgr.GetConfig(), client.Options{
+		Scheme: scheme,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to create uncached client")
+		os.Exit(1)
+	}
+
 	if os.Getenv("ENABLE_CONTROLLERS") != "false" {
 		controllersLog := ctrl.Log.WithName("controllers")
 		imageClient := image.NewClient(k8sClient)
+		usageRecorder := usage.NewRecorder(controllersClient, controllerConfig.CFRootNamespace)
 
 		if err = apps.NewReconciler(
 			controllersClient,
@@ -185,6 +217,7 @@ func main() {
 			controllersLog,
 			env.NewVCAPServicesEnvValueBuilder(controllersClient),
 			env.NewVCAPApplicationEnvValueBuilder(controllersClient, controllerConfig.ExtraVCAPApplicationValues),
+			usageRecorder,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFApp")
 			os.Exit(1)
@@ -232,6 +265,8 @@ func main() {
 			controllersLog,
 			controllerConfig,
 			env.NewProcessEnvBuilder(controllersClient),
+			placement.NewIsolationSegmentResolver(controllersClient, controllerConfig.CFRootNamespace),
+			usageRecorder,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFProcess")
 			os.Exit(1)
@@ -241,6 +276,7 @@ func main() {
 			controllersClient,
 			mgr.GetScheme(),
 			controllersLog,
+			usageRecorder,
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "UPSICFServiceInstance")
 			os.Exit(1)
@@ -262,6 +298,35 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = routebindings.NewReconciler(
+			controllersClient,
+			osbapi.NewClientFactory(controllersClient, controllerConfig.TrustInsecureServiceBrokers),
+			controllerConfig.CFRootNamespace,
+			controllersLog,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFServiceRouteBinding")
+			os.Exit(1)
+		}
+
+		blockedSyslogDrainRanges, err := controllerConfig.ParseBlockedSyslogDrainRanges()
+		if err != nil {
+			setupLog.Error(err, "invalid blocked syslog drain ranges")
+			os.Exit(1)
+		}
+
+		if err = drains.NewReconciler(
+			controllersClient,
+			k8sClient,
+			drains.DefaultLogStreamer,
+			func(drainURL string) (syslog.Writer, error) {
+				return syslog.NewWriter(drainURL, &tls.Config{MinVersion: tls.VersionTLS12}, blockedSyslogDrainRanges)
+			},
+			controllersLog,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SyslogDrain")
+			os.Exit(1)
+		}
+
 		labelCompiler := labels.NewCompiler().
 			Defaults(map[string]string{
 				admission.EnforceLevelLabel: string(admission.LevelRestricted),
@@ -297,6 +362,7 @@ func main() {
 			mgr.GetEventRecorder("cftask-controller"),
 			controllersLog,
 			env.NewAppEnvBuilder(controllersClient),
+			placement.NewIsolationSegmentResolver(controllersClient, controllerConfig.CFRootNamespace),
 			controllerConfig.TaskTTL,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFTask")
@@ -307,11 +373,30 @@ func main() {
 			controllersClient,
 			mgr.GetScheme(),
 			controllersLog,
+			controllerConfig,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFDomain")
 			os.Exit(1)
 		}
 
+		if err = securitygroups.NewReconciler(
+			controllersClient,
+			mgr.GetScheme(),
+			controllersLog,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFSecurityGroup")
+			os.Exit(1)
+		}
+
+		if err = networkpolicies.NewReconciler(
+			controllersClient,
+			mgr.GetScheme(),
+			controllersLog,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFNetworkPolicy")
+			os.Exit(1)
+		}
+
 		if controllerConfig.ExperimentalManagedServicesEnabled {
 			if err = brokers.NewReconciler(
 				controllersClient,
@@ -329,6 +414,7 @@ func main() {
 				mgr.GetScheme(),
 				controllerConfig.CFRootNamespace,
 				controllersLog,
+				usageRecorder,
 			).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "ManagedCFServiceInstance")
 				os.Exit(1)
@@ -399,6 +485,8 @@ func main() {
 				mgr.GetScheme(),
 				appworkload.NewAppWorkloadToStatefulsetConverter(mgr.GetScheme()),
 				appworkload.NewPDBUpdater(controllersClient),
+				appworkload.NewRolloutDriver(controllersClient),
+				appworkload.NewHPAUpdater(controllersClient),
 				controllersLog,
 				state.NewAppWorkloadStateCollector(controllersClient),
 			).SetupWithManager(mgr); err != nil {
@@ -426,6 +514,31 @@ func main() {
 				setupLog.Error(err, "unable to create controller", "controller", "CFRoute")
 				os.Exit(1)
 			}
+
+			routeServiceProxyConfig := controllerConfig.Networking.RouteServiceProxy
+			signingKey, err := routeservice.EnsureSigningKey(context.Background(), uncachedClient, types.NamespacedName{
+				Name:      routeServiceProxyConfig.SigningKeySecretName,
+				Namespace: routeServiceProxyConfig.SigningKeySecretNamespace,
+			})
+			if err != nil {
+				setupLog.Error(err, "unable to get route service signing key")
+				os.Exit(1)
+			}
+
+			signer, err := routeservice.NewSigner(signingKey)
+			if err != nil {
+				setupLog.Error(err, "unable to create route service signer")
+				os.Exit(1)
+			}
+
+			if err = mgr.Add(routeservice.NewServer(
+				routeServiceProxyConfig.Port,
+				routeservice.NewProxy(mgr.GetClient(), signer, http.DefaultTransport),
+				ctrl.Log.WithName("route-service-proxy"),
+			)); err != nil {
+				setupLog.Error(err, "unable to add route service proxy")
+				os.Exit(1)
+			}
 		}
 	}
 
@@ -447,16 +560,13 @@ func main() {
 		os.Exit(1)
 	}
 
-	uncachedClient, err := client.New(mgr.GetConfig(), client.Options{
-		Scheme: scheme,
-	})
-	if err != nil {
-		setupLog.Error(err, "unable to create uncached client")
-		os.Exit(1)
-	}
+	quotaValidator := validation.NewQuotaValidator(uncachedClient, controllerConfig.CFRootNamespace)
+	suspensionValidator := validation.NewSuspensionValidator(uncachedClient, controllerConfig.CFRootNamespace)
 
 	if err = appswebhook.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, appswebhook.AppEntityType)),
+		quotaValidator,
+		suspensionValidator,
 	).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "CFApp")
 		os.Exit(1)
@@ -464,7 +574,10 @@ func main() {
 
 	if err = routeswebhook.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, routeswebhook.RouteEntityType)),
+		quotaValidator,
+		suspensionValidator,
 		controllerConfig.CFRootNamespace,
+		controllerConfig.Networking.RouterGroups,
 		uncachedClient,
 	).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "CFRoute")
@@ -473,18 +586,38 @@ func main() {
 
 	if err = instanceswebhook.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, instanceswebhook.ServiceInstanceEntityType)),
+		quotaValidator,
+		suspensionValidator,
 	).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceInstance")
 		os.Exit(1)
 	}
 
 	if err = bindingswebhook.NewCFServiceBindingValidator(
+		uncachedClient,
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, bindingswebhook.ServiceBindingEntityType)),
+		suspensionValidator,
 	).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceBinding")
 		os.Exit(1)
 	}
 
+	if err = routebindingswebhook.NewValidator(
+		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, routebindingswebhook.ServiceRouteBindingEntityType)),
+		suspensionValidator,
+	).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceRouteBinding")
+		os.Exit(1)
+	}
+
+	if err = sidecarswebhook.NewValidator(
+		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, sidecarswebhook.SidecarEntityType)),
+		suspensionValidator,
+	).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "CFSidecar")
+		os.Exit(1)
+	}
+
 	if err = domainswebhook.NewValidator(
 		uncachedClient,
 	).SetupWebhookWithManager(mgr); err != nil {
@@ -503,6 +636,7 @@ func main() {
 	if err = spaceswebhook.NewValidator(
 		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, spaceswebhook.CFSpaceEntityType)),
 		validation.NewPlacementValidator(uncachedClient, controllerConfig.CFRootNamespace),
+		suspensionValidator,
 	).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "CFSpace")
 		os.Exit(1)
@@ -528,22 +662,61 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = taskswebhook.NewValidator().SetupWebhookWithManager(mgr); err != nil {
+	if err = taskswebhook.NewValidator(quotaValidator, suspensionValidator, controllerConfig.CFProcessDefaults).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "CFTask")
 		os.Exit(1)
 	}
 
+	if err = processeswebhook.NewValidator(quotaValidator, suspensionValidator).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "CFProcess")
+		os.Exit(1)
+	}
+
+	if err = isolationsegmentswebhook.NewValidator(
+		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, isolationsegmentswebhook.IsolationSegmentEntityType)),
+		controllerConfig.CFRootNamespace,
+	).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "CFIsolationSegment")
+		os.Exit(1)
+	}
+
+	if err = orgquotaswebhook.NewValidator(
+		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, orgquotaswebhook.OrgQuotaEntityType)),
+		controllerConfig.CFRootNamespace,
+	).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "CFOrgQuota")
+		os.Exit(1)
+	}
+
+	if err = spacequotaswebhook.NewValidator(
+		validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, spacequotaswebhook.SpaceQuotaEntityType)),
+		uncachedClient,
+	).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "CFSpaceQuota")
+		os.Exit(1)
+	}
+
 	versionwebhook.NewVersionWebhook(version.Version).SetupWebhookWithManager(mgr)
 	controllers_finalizer.NewControllersFinalizerWebhook().SetupWebhookWithManager(mgr)
 	common_labels.NewWebhook().SetupWebhookWithManager(mgr)
 	label_indexer.NewWebhook().SetupWebhookWithManager(mgr)
 	routesdestwebhook.NewRouteAppDestinationsWebhook().SetupWebhookWithManager(mgr)
 
-	if err = packageswebhook.NewValidator().SetupWebhookWithManager(mgr); err != nil {
+	if err = packageswebhook.NewValidator(suspensionValidator).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "CFPackage")
 		os.Exit(1)
 	}
 
+	if err = buildswebhook.NewValidator(suspensionValidator).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "CFBuild")
+		os.Exit(1)
+	}
+
+	if err = networkpolicieswebhook.NewValidator(suspensionValidator).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "CFNetworkPolicy")
+		os.Exit(1)
+	}
+
 	if controllerConfig.IncludeKpackImageBuilder {
 		kpackimagebuilder_finalizer.NewKpackImageBuilderFinalizerWebhook().SetupWebhookWithManager(mgr)
 	}
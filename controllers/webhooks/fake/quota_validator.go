@@ -0,0 +1,413 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+)
+
+type QuotaValidator struct {
+	ValidateAppStartStub        func(context.Context, *v1alpha1.CFApp) error
+	validateAppStartMutex       sync.RWMutex
+	validateAppStartArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFApp
+	}
+	validateAppStartReturns struct {
+		result1 error
+	}
+	validateAppStartReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ValidateProcessScaleStub        func(context.Context, *v1alpha1.CFProcess, *v1alpha1.CFProcess) error
+	validateProcessScaleMutex       sync.RWMutex
+	validateProcessScaleArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFProcess
+		arg3 *v1alpha1.CFProcess
+	}
+	validateProcessScaleReturns struct {
+		result1 error
+	}
+	validateProcessScaleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ValidateRouteCreateStub        func(context.Context, *v1alpha1.CFRoute) error
+	validateRouteCreateMutex       sync.RWMutex
+	validateRouteCreateArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFRoute
+	}
+	validateRouteCreateReturns struct {
+		result1 error
+	}
+	validateRouteCreateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ValidateServiceInstanceCreateStub        func(context.Context, *v1alpha1.CFServiceInstance) error
+	validateServiceInstanceCreateMutex       sync.RWMutex
+	validateServiceInstanceCreateArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFServiceInstance
+	}
+	validateServiceInstanceCreateReturns struct {
+		result1 error
+	}
+	validateServiceInstanceCreateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ValidateTaskCreateStub        func(context.Context, *v1alpha1.CFTask, int64) error
+	validateTaskCreateMutex       sync.RWMutex
+	validateTaskCreateArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFTask
+		arg3 int64
+	}
+	validateTaskCreateReturns struct {
+		result1 error
+	}
+	validateTaskCreateReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *QuotaValidator) ValidateAppStart(arg1 context.Context, arg2 *v1alpha1.CFApp) error {
+	fake.validateAppStartMutex.Lock()
+	ret, specificReturn := fake.validateAppStartReturnsOnCall[len(fake.validateAppStartArgsForCall)]
+	fake.validateAppStartArgsForCall = append(fake.validateAppStartArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFApp
+	}{arg1, arg2})
+	stub := fake.ValidateAppStartStub
+	fakeReturns := fake.validateAppStartReturns
+	fake.recordInvocation("ValidateAppStart", []interface{}{arg1, arg2})
+	fake.validateAppStartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *QuotaValidator) ValidateAppStartCallCount() int {
+	fake.validateAppStartMutex.RLock()
+	defer fake.validateAppStartMutex.RUnlock()
+	return len(fake.validateAppStartArgsForCall)
+}
+
+func (fake *QuotaValidator) ValidateAppStartCalls(stub func(context.Context, *v1alpha1.CFApp) error) {
+	fake.validateAppStartMutex.Lock()
+	defer fake.validateAppStartMutex.Unlock()
+	fake.ValidateAppStartStub = stub
+}
+
+func (fake *QuotaValidator) ValidateAppStartArgsForCall(i int) (context.Context, *v1alpha1.CFApp) {
+	fake.validateAppStartMutex.RLock()
+	defer fake.validateAppStartMutex.RUnlock()
+	argsForCall := fake.validateAppStartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *QuotaValidator) ValidateAppStartReturns(result1 error) {
+	fake.validateAppStartMutex.Lock()
+	defer fake.validateAppStartMutex.Unlock()
+	fake.ValidateAppStartStub = nil
+	fake.validateAppStartReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateAppStartReturnsOnCall(i int, result1 error) {
+	fake.validateAppStartMutex.Lock()
+	defer fake.validateAppStartMutex.Unlock()
+	fake.ValidateAppStartStub = nil
+	if fake.validateAppStartReturnsOnCall == nil {
+		fake.validateAppStartReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateAppStartReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateProcessScale(arg1 context.Context, arg2 *v1alpha1.CFProcess, arg3 *v1alpha1.CFProcess) error {
+	fake.validateProcessScaleMutex.Lock()
+	ret, specificReturn := fake.validateProcessScaleReturnsOnCall[len(fake.validateProcessScaleArgsForCall)]
+	fake.validateProcessScaleArgsForCall = append(fake.validateProcessScaleArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFProcess
+		arg3 *v1alpha1.CFProcess
+	}{arg1, arg2, arg3})
+	stub := fake.ValidateProcessScaleStub
+	fakeReturns := fake.validateProcessScaleReturns
+	fake.recordInvocation("ValidateProcessScale", []interface{}{arg1, arg2, arg3})
+	fake.validateProcessScaleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *QuotaValidator) ValidateProcessScaleCallCount() int {
+	fake.validateProcessScaleMutex.RLock()
+	defer fake.validateProcessScaleMutex.RUnlock()
+	return len(fake.validateProcessScaleArgsForCall)
+}
+
+func (fake *QuotaValidator) ValidateProcessScaleCalls(stub func(context.Context, *v1alpha1.CFProcess, *v1alpha1.CFProcess) error) {
+	fake.validateProcessScaleMutex.Lock()
+	defer fake.validateProcessScaleMutex.Unlock()
+	fake.ValidateProcessScaleStub = stub
+}
+
+func (fake *QuotaValidator) ValidateProcessScaleArgsForCall(i int) (context.Context, *v1alpha1.CFProcess, *v1alpha1.CFProcess) {
+	fake.validateProcessScaleMutex.RLock()
+	defer fake.validateProcessScaleMutex.RUnlock()
+	argsForCall := fake.validateProcessScaleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *QuotaValidator) ValidateProcessScaleReturns(result1 error) {
+	fake.validateProcessScaleMutex.Lock()
+	defer fake.validateProcessScaleMutex.Unlock()
+	fake.ValidateProcessScaleStub = nil
+	fake.validateProcessScaleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateProcessScaleReturnsOnCall(i int, result1 error) {
+	fake.validateProcessScaleMutex.Lock()
+	defer fake.validateProcessScaleMutex.Unlock()
+	fake.ValidateProcessScaleStub = nil
+	if fake.validateProcessScaleReturnsOnCall == nil {
+		fake.validateProcessScaleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateProcessScaleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateRouteCreate(arg1 context.Context, arg2 *v1alpha1.CFRoute) error {
+	fake.validateRouteCreateMutex.Lock()
+	ret, specificReturn := fake.validateRouteCreateReturnsOnCall[len(fake.validateRouteCreateArgsForCall)]
+	fake.validateRouteCreateArgsForCall = append(fake.validateRouteCreateArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFRoute
+	}{arg1, arg2})
+	stub := fake.ValidateRouteCreateStub
+	fakeReturns := fake.validateRouteCreateReturns
+	fake.recordInvocation("ValidateRouteCreate", []interface{}{arg1, arg2})
+	fake.validateRouteCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *QuotaValidator) ValidateRouteCreateCallCount() int {
+	fake.validateRouteCreateMutex.RLock()
+	defer fake.validateRouteCreateMutex.RUnlock()
+	return len(fake.validateRouteCreateArgsForCall)
+}
+
+func (fake *QuotaValidator) ValidateRouteCreateCalls(stub func(context.Context, *v1alpha1.CFRoute) error) {
+	fake.validateRouteCreateMutex.Lock()
+	defer fake.validateRouteCreateMutex.Unlock()
+	fake.ValidateRouteCreateStub = stub
+}
+
+func (fake *QuotaValidator) ValidateRouteCreateArgsForCall(i int) (context.Context, *v1alpha1.CFRoute) {
+	fake.validateRouteCreateMutex.RLock()
+	defer fake.validateRouteCreateMutex.RUnlock()
+	argsForCall := fake.validateRouteCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *QuotaValidator) ValidateRouteCreateReturns(result1 error) {
+	fake.validateRouteCreateMutex.Lock()
+	defer fake.validateRouteCreateMutex.Unlock()
+	fake.ValidateRouteCreateStub = nil
+	fake.validateRouteCreateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateRouteCreateReturnsOnCall(i int, result1 error) {
+	fake.validateRouteCreateMutex.Lock()
+	defer fake.validateRouteCreateMutex.Unlock()
+	fake.ValidateRouteCreateStub = nil
+	if fake.validateRouteCreateReturnsOnCall == nil {
+		fake.validateRouteCreateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateRouteCreateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateServiceInstanceCreate(arg1 context.Context, arg2 *v1alpha1.CFServiceInstance) error {
+	fake.validateServiceInstanceCreateMutex.Lock()
+	ret, specificReturn := fake.validateServiceInstanceCreateReturnsOnCall[len(fake.validateServiceInstanceCreateArgsForCall)]
+	fake.validateServiceInstanceCreateArgsForCall = append(fake.validateServiceInstanceCreateArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFServiceInstance
+	}{arg1, arg2})
+	stub := fake.ValidateServiceInstanceCreateStub
+	fakeReturns := fake.validateServiceInstanceCreateReturns
+	fake.recordInvocation("ValidateServiceInstanceCreate", []interface{}{arg1, arg2})
+	fake.validateServiceInstanceCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *QuotaValidator) ValidateServiceInstanceCreateCallCount() int {
+	fake.validateServiceInstanceCreateMutex.RLock()
+	defer fake.validateServiceInstanceCreateMutex.RUnlock()
+	return len(fake.validateServiceInstanceCreateArgsForCall)
+}
+
+func (fake *QuotaValidator) ValidateServiceInstanceCreateCalls(stub func(context.Context, *v1alpha1.CFServiceInstance) error) {
+	fake.validateServiceInstanceCreateMutex.Lock()
+	defer fake.validateServiceInstanceCreateMutex.Unlock()
+	fake.ValidateServiceInstanceCreateStub = stub
+}
+
+func (fake *QuotaValidator) ValidateServiceInstanceCreateArgsForCall(i int) (context.Context, *v1alpha1.CFServiceInstance) {
+	fake.validateServiceInstanceCreateMutex.RLock()
+	defer fake.validateServiceInstanceCreateMutex.RUnlock()
+	argsForCall := fake.validateServiceInstanceCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *QuotaValidator) ValidateServiceInstanceCreateReturns(result1 error) {
+	fake.validateServiceInstanceCreateMutex.Lock()
+	defer fake.validateServiceInstanceCreateMutex.Unlock()
+	fake.ValidateServiceInstanceCreateStub = nil
+	fake.validateServiceInstanceCreateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateServiceInstanceCreateReturnsOnCall(i int, result1 error) {
+	fake.validateServiceInstanceCreateMutex.Lock()
+	defer fake.validateServiceInstanceCreateMutex.Unlock()
+	fake.ValidateServiceInstanceCreateStub = nil
+	if fake.validateServiceInstanceCreateReturnsOnCall == nil {
+		fake.validateServiceInstanceCreateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateServiceInstanceCreateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateTaskCreate(arg1 context.Context, arg2 *v1alpha1.CFTask, arg3 int64) error {
+	fake.validateTaskCreateMutex.Lock()
+	ret, specificReturn := fake.validateTaskCreateReturnsOnCall[len(fake.validateTaskCreateArgsForCall)]
+	fake.validateTaskCreateArgsForCall = append(fake.validateTaskCreateArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFTask
+		arg3 int64
+	}{arg1, arg2, arg3})
+	stub := fake.ValidateTaskCreateStub
+	fakeReturns := fake.validateTaskCreateReturns
+	fake.recordInvocation("ValidateTaskCreate", []interface{}{arg1, arg2, arg3})
+	fake.validateTaskCreateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *QuotaValidator) ValidateTaskCreateCallCount() int {
+	fake.validateTaskCreateMutex.RLock()
+	defer fake.validateTaskCreateMutex.RUnlock()
+	return len(fake.validateTaskCreateArgsForCall)
+}
+
+func (fake *QuotaValidator) ValidateTaskCreateCalls(stub func(context.Context, *v1alpha1.CFTask, int64) error) {
+	fake.validateTaskCreateMutex.Lock()
+	defer fake.validateTaskCreateMutex.Unlock()
+	fake.ValidateTaskCreateStub = stub
+}
+
+func (fake *QuotaValidator) ValidateTaskCreateArgsForCall(i int) (context.Context, *v1alpha1.CFTask, int64) {
+	fake.validateTaskCreateMutex.RLock()
+	defer fake.validateTaskCreateMutex.RUnlock()
+	argsForCall := fake.validateTaskCreateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *QuotaValidator) ValidateTaskCreateReturns(result1 error) {
+	fake.validateTaskCreateMutex.Lock()
+	defer fake.validateTaskCreateMutex.Unlock()
+	fake.ValidateTaskCreateStub = nil
+	fake.validateTaskCreateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateTaskCreateReturnsOnCall(i int, result1 error) {
+	fake.validateTaskCreateMutex.Lock()
+	defer fake.validateTaskCreateMutex.Unlock()
+	fake.ValidateTaskCreateStub = nil
+	if fake.validateTaskCreateReturnsOnCall == nil {
+		fake.validateTaskCreateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateTaskCreateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *QuotaValidator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ webhooks.QuotaValidator = new(QuotaValidator)
@@ -0,0 +1,112 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+)
+
+type SuspensionValidator struct {
+	ValidateWriteStub        func(context.Context, string) error
+	validateWriteMutex       sync.RWMutex
+	validateWriteArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	validateWriteReturns struct {
+		result1 error
+	}
+	validateWriteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *SuspensionValidator) ValidateWrite(arg1 context.Context, arg2 string) error {
+	fake.validateWriteMutex.Lock()
+	ret, specificReturn := fake.validateWriteReturnsOnCall[len(fake.validateWriteArgsForCall)]
+	fake.validateWriteArgsForCall = append(fake.validateWriteArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ValidateWriteStub
+	fakeReturns := fake.validateWriteReturns
+	fake.recordInvocation("ValidateWrite", []interface{}{arg1, arg2})
+	fake.validateWriteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *SuspensionValidator) ValidateWriteCallCount() int {
+	fake.validateWriteMutex.RLock()
+	defer fake.validateWriteMutex.RUnlock()
+	return len(fake.validateWriteArgsForCall)
+}
+
+func (fake *SuspensionValidator) ValidateWriteCalls(stub func(context.Context, string) error) {
+	fake.validateWriteMutex.Lock()
+	defer fake.validateWriteMutex.Unlock()
+	fake.ValidateWriteStub = stub
+}
+
+func (fake *SuspensionValidator) ValidateWriteArgsForCall(i int) (context.Context, string) {
+	fake.validateWriteMutex.RLock()
+	defer fake.validateWriteMutex.RUnlock()
+	argsForCall := fake.validateWriteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *SuspensionValidator) ValidateWriteReturns(result1 error) {
+	fake.validateWriteMutex.Lock()
+	defer fake.validateWriteMutex.Unlock()
+	fake.ValidateWriteStub = nil
+	fake.validateWriteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SuspensionValidator) ValidateWriteReturnsOnCall(i int, result1 error) {
+	fake.validateWriteMutex.Lock()
+	defer fake.validateWriteMutex.Unlock()
+	fake.ValidateWriteStub = nil
+	if fake.validateWriteReturnsOnCall == nil {
+		fake.validateWriteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateWriteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SuspensionValidator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *SuspensionValidator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ webhooks.SuspensionValidator = new(SuspensionValidator)
@@ -1,6 +1,6 @@
 package finalizer
 
-//+kubebuilder:webhook:path=/mutate-korifi-cloudfoundry-org-v1alpha1-controllers-finalizer,mutating=true,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfapps;cfspaces;cfpackages;cforgs;cfroutes;cfdomains;cfservicebindings;cfserviceinstances,verbs=create,versions=v1alpha1,name=mcffinalizer.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+//+kubebuilder:webhook:path=/mutate-korifi-cloudfoundry-org-v1alpha1-controllers-finalizer,mutating=true,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfapps;cfspaces;cfpackages;cforgs;cfroutes;cfdomains;cfservicebindings;cfserviceinstances;cfserviceroutebindings;cfsecuritygroups;cfnetworkpolicies,verbs=create,versions=v1alpha1,name=mcffinalizer.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
 import (
 	"context"
@@ -18,13 +18,16 @@ type ControllersFinalizerWebhook struct {
 func NewControllersFinalizerWebhook() *ControllersFinalizerWebhook {
 	return &ControllersFinalizerWebhook{
 		delegate: k8s.NewFinalizerWebhook(map[string]k8s.FinalizerDescriptor{
-			"CFApp":             {FinalizerName: korifiv1alpha1.CFAppFinalizerName, SetPolicy: k8s.Always},
-			"CFSpace":           {FinalizerName: korifiv1alpha1.CFSpaceFinalizerName, SetPolicy: k8s.Always},
-			"CFPackage":         {FinalizerName: korifiv1alpha1.CFPackageFinalizerName, SetPolicy: k8s.Always},
-			"CFOrg":             {FinalizerName: korifiv1alpha1.CFOrgFinalizerName, SetPolicy: k8s.Always},
-			"CFDomain":          {FinalizerName: korifiv1alpha1.CFDomainFinalizerName, SetPolicy: k8s.Always},
-			"CFServiceInstance": {FinalizerName: korifiv1alpha1.CFServiceInstanceFinalizerName, SetPolicy: k8s.Always},
-			"CFServiceBinding":  {FinalizerName: korifiv1alpha1.CFServiceBindingFinalizerName, SetPolicy: k8s.Always},
+			"CFApp":                 {FinalizerName: korifiv1alpha1.CFAppFinalizerName, SetPolicy: k8s.Always},
+			"CFSpace":               {FinalizerName: korifiv1alpha1.CFSpaceFinalizerName, SetPolicy: k8s.Always},
+			"CFPackage":             {FinalizerName: korifiv1alpha1.CFPackageFinalizerName, SetPolicy: k8s.Always},
+			"CFOrg":                 {FinalizerName: korifiv1alpha1.CFOrgFinalizerName, SetPolicy: k8s.Always},
+			"CFDomain":              {FinalizerName: korifiv1alpha1.CFDomainFinalizerName, SetPolicy: k8s.Always},
+			"CFServiceInstance":     {FinalizerName: korifiv1alpha1.CFServiceInstanceFinalizerName, SetPolicy: k8s.Always},
+			"CFServiceBinding":      {FinalizerName: korifiv1alpha1.CFServiceBindingFinalizerName, SetPolicy: k8s.Always},
+			"CFServiceRouteBinding": {FinalizerName: korifiv1alpha1.CFServiceRouteBindingFinalizerName, SetPolicy: k8s.Always},
+			"CFSecurityGroup":       {FinalizerName: korifiv1alpha1.CFSecurityGroupFinalizerName, SetPolicy: k8s.Always},
+			"CFNetworkPolicy":       {FinalizerName: korifiv1alpha1.CFNetworkPolicyFinalizerName, SetPolicy: k8s.Always},
 		}),
 	}
 }
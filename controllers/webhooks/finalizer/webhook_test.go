@@ -145,5 +145,34 @@ var _ = Describe("Controllers Finalizers Webhook", func() {
 			},
 			korifiv1alpha1.CFServiceBindingFinalizerName,
 		),
+		Entry("cfsecuritygroup",
+			&korifiv1alpha1.CFSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFSecurityGroupSpec{
+					DisplayName: uuid.NewString(),
+					Rules:       []korifiv1alpha1.SecurityGroupRule{},
+				},
+			},
+			korifiv1alpha1.CFSecurityGroupFinalizerName,
+		),
+		Entry("cfnetworkpolicy",
+			&korifiv1alpha1.CFNetworkPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-org-" + uuid.NewString(),
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFNetworkPolicySpec{
+					SourceAppRef:      corev1.LocalObjectReference{Name: uuid.NewString()},
+					DestinationAppRef: corev1.ObjectReference{Name: uuid.NewString(), Namespace: "test-space-" + uuid.NewString()},
+					Protocol:          "tcp",
+					StartPort:         8080,
+					EndPort:           8080,
+				},
+			},
+			korifiv1alpha1.CFNetworkPolicyFinalizerName,
+		),
 	)
 })
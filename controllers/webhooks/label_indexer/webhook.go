@@ -1,6 +1,6 @@
 package label_indexer
 
-//+kubebuilder:webhook:path=/mutate-korifi-cloudfoundry-org-v1alpha1-controllers-label-indexer,mutating=true,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfroutes;cfapps;cfbuilds;cfdomains;cfpackages;cfprocesses;cfservicebindings;cfserviceinstances;cftasks;cforgs;cfspaces;cfserviceofferings;cfserviceplans;cfservicebrokers,verbs=create;update,versions=v1alpha1,name=mcflabelindexer.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+//+kubebuilder:webhook:path=/mutate-korifi-cloudfoundry-org-v1alpha1-controllers-label-indexer,mutating=true,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfauditevents;cfroutes;cfapps;cfbuilds;cfdomains;cfpackages;cfprocesses;cfrevisions;cfservicebindings;cfserviceinstances;cfserviceroutebindings;cfserviceusageevents;cfsidecars;cftasks;cforgs;cfspaces;cfserviceofferings;cfserviceplans;cfservicebrokers,verbs=create;update,versions=v1alpha1,name=mcflabelindexer.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
 import (
 	"context"
@@ -28,11 +28,26 @@ type LabelIndexerWebhook struct {
 func NewWebhook() *LabelIndexerWebhook {
 	return &LabelIndexerWebhook{
 		indexingRules: map[string][]IndexingRule{
+			"CFAuditEvent": {
+				LabelRule{Label: korifiv1alpha1.CFAuditEventTypeLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.type"))},
+				LabelRule{Label: korifiv1alpha1.CFAuditEventTargetGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.target.guid"))},
+				LabelRule{Label: korifiv1alpha1.SpaceGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.spaceGUID"))},
+				LabelRule{Label: korifiv1alpha1.CFOrgGUIDKey, IndexingFunc: Unquote(JSONValue("$.spec.organizationGUID"))},
+				LabelRule{
+					Label:        korifiv1alpha1.CFAuditEventScopeGUIDLabelKey,
+					IndexingFunc: DefaultIfEmpty(Unquote(JSONValue("$.spec.spaceGUID")), Unquote(JSONValue("$.spec.organizationGUID"))),
+				},
+			},
+			"CFServiceUsageEvent": {
+				LabelRule{Label: korifiv1alpha1.CFServiceUsageEventInstanceTypeLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.serviceInstance.type"))},
+				LabelRule{Label: korifiv1alpha1.CFServiceUsageEventOfferingGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.serviceOffering.guid"))},
+			},
 			"CFRoute": {
 				LabelRule{Label: korifiv1alpha1.CFDomainGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.domainRef.name"))},
 				LabelRule{Label: korifiv1alpha1.SpaceGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.metadata.namespace"))},
 				LabelRule{Label: korifiv1alpha1.CFRouteHostLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.host"))},
 				LabelRule{Label: korifiv1alpha1.CFRoutePathLabelKey, IndexingFunc: SHA224(Unquote(JSONValue("$.spec.path")))},
+				LabelRule{Label: korifiv1alpha1.CFRoutePortLabelKey, IndexingFunc: JSONValue("$.spec.port")},
 				LabelRule{Label: korifiv1alpha1.CFRouteIsUnmappedLabelKey, IndexingFunc: IsEmptyValue(JSONValue("$.spec.destinations[*]"))},
 			},
 			"CFApp": {
@@ -74,6 +89,11 @@ func NewWebhook() *LabelIndexerWebhook {
 				LabelRule{Label: korifiv1alpha1.CFAppGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.appRef.name"))},
 				LabelRule{Label: korifiv1alpha1.CFProcessTypeLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.processType"))},
 			},
+			"CFRevision": {
+				LabelRule{Label: korifiv1alpha1.SpaceGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.metadata.namespace"))},
+				LabelRule{Label: korifiv1alpha1.CFAppGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.appRef.name"))},
+				LabelRule{Label: korifiv1alpha1.CFRevisionVersionLabelKey, IndexingFunc: JSONValue("$.spec.version")},
+			},
 			"CFServiceInstance": {
 				LabelRule{Label: korifiv1alpha1.SpaceGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.metadata.namespace"))},
 				LabelRule{Label: korifiv1alpha1.PlanGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.planGuid"))},
@@ -87,6 +107,15 @@ func NewWebhook() *LabelIndexerWebhook {
 				LabelRule{Label: korifiv1alpha1.CFAppGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.appRef.name"))},
 				LabelRule{Label: korifiv1alpha1.CFServiceBindingTypeLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.type"))},
 			},
+			"CFServiceRouteBinding": {
+				LabelRule{Label: korifiv1alpha1.SpaceGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.metadata.namespace"))},
+				LabelRule{Label: korifiv1alpha1.CFServiceInstanceGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.serviceInstanceRef.name"))},
+				LabelRule{Label: korifiv1alpha1.CFRouteGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.routeRef.name"))},
+			},
+			"CFSidecar": {
+				LabelRule{Label: korifiv1alpha1.SpaceGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.metadata.namespace"))},
+				LabelRule{Label: korifiv1alpha1.CFAppGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.appRef.name"))},
+			},
 			"CFTask": {
 				LabelRule{Label: korifiv1alpha1.SpaceGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.metadata.namespace"))},
 				LabelRule{Label: korifiv1alpha1.CFAppGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.spec.appRef.name"))},
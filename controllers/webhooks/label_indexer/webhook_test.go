@@ -48,8 +48,24 @@ var _ = Describe("LabelIndexerWebhook", func() {
 					korifiv1alpha1.CFRouteHostLabelKey:       Equal("example"),
 					korifiv1alpha1.CFRoutePathLabelKey:       Equal("4757e8253d1d2e04aa277d3b9178cf69d8383d43fd9f894f9460ebda"), // SHA224 hash of "/example"
 				}))
+				g.Expect(route.Labels).NotTo(HaveKey(korifiv1alpha1.CFRoutePortLabelKey))
 			}).Should(Succeed())
 		})
+
+		When("the route has a port", func() {
+			BeforeEach(func() {
+				route.Spec.Host = ""
+				route.Spec.Path = ""
+				route.Spec.Port = 1234
+			})
+
+			It("labels the CFRoute with the port", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(route), route)).To(Succeed())
+					g.Expect(route.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFRoutePortLabelKey, "1234"))
+				}).Should(Succeed())
+			})
+		})
 	})
 
 	Describe("CFApp", func() {
@@ -241,6 +257,125 @@ var _ = Describe("LabelIndexerWebhook", func() {
 		})
 	})
 
+	Describe("CFAuditEvent", func() {
+		var auditEvent *korifiv1alpha1.CFAuditEvent
+
+		BeforeEach(func() {
+			auditEvent = &korifiv1alpha1.CFAuditEvent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: namespace,
+				},
+				Spec: korifiv1alpha1.CFAuditEventSpec{
+					Type:             "audit.app.create",
+					Actor:            korifiv1alpha1.AuditEventActor{GUID: "alice", Type: "user", Name: "alice"},
+					Target:           korifiv1alpha1.AuditEventTarget{GUID: "app-guid", Type: "app", Name: "my-app"},
+					SpaceGUID:        "space-guid",
+					OrganizationGUID: "org-guid",
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			Expect(adminClient.Create(ctx, auditEvent)).To(Succeed())
+		})
+
+		It("labels the CFAuditEvent with the expected index labels", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(auditEvent), auditEvent)).To(Succeed())
+				g.Expect(auditEvent.Labels).To(MatchKeys(IgnoreExtras, Keys{
+					korifiv1alpha1.CFAuditEventTypeLabelKey:       Equal("audit.app.create"),
+					korifiv1alpha1.CFAuditEventTargetGUIDLabelKey: Equal("app-guid"),
+					korifiv1alpha1.SpaceGUIDLabelKey:              Equal("space-guid"),
+					korifiv1alpha1.CFOrgGUIDKey:                   Equal("org-guid"),
+					korifiv1alpha1.CFAuditEventScopeGUIDLabelKey:  Equal("space-guid"),
+				}))
+			}).Should(Succeed())
+		})
+
+		When("the event is not scoped to a space", func() {
+			BeforeEach(func() {
+				auditEvent.Spec.SpaceGUID = ""
+			})
+
+			It("scopes the event to the org", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(auditEvent), auditEvent)).To(Succeed())
+					g.Expect(auditEvent.Labels).NotTo(HaveKey(korifiv1alpha1.SpaceGUIDLabelKey))
+					g.Expect(auditEvent.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFAuditEventScopeGUIDLabelKey, "org-guid"))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	Describe("CFServiceUsageEvent", func() {
+		var usageEvent *korifiv1alpha1.CFServiceUsageEvent
+
+		BeforeEach(func() {
+			usageEvent = &korifiv1alpha1.CFServiceUsageEvent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: namespace,
+				},
+				Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+					State: korifiv1alpha1.ServiceUsageEventStateCreated,
+					ServiceInstance: korifiv1alpha1.UsageEventServiceInstance{
+						GUID: "instance-guid",
+						Name: "my-instance",
+						Type: korifiv1alpha1.ManagedServiceInstanceUsageType,
+					},
+					Space:           korifiv1alpha1.UsageEventResource{GUID: "space-guid"},
+					ServiceOffering: &korifiv1alpha1.UsageEventResource{GUID: "offering-guid"},
+				},
+			}
+			Expect(adminClient.Create(ctx, usageEvent)).To(Succeed())
+		})
+
+		It("labels the CFServiceUsageEvent with the expected index labels", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(usageEvent), usageEvent)).To(Succeed())
+				g.Expect(usageEvent.Labels).To(MatchKeys(IgnoreExtras, Keys{
+					korifiv1alpha1.CFServiceUsageEventInstanceTypeLabelKey: Equal(korifiv1alpha1.ManagedServiceInstanceUsageType),
+					korifiv1alpha1.CFServiceUsageEventOfferingGUIDLabelKey: Equal("offering-guid"),
+				}))
+			}).Should(Succeed())
+		})
+	})
+
+	Describe("CFRevision", func() {
+		var revision *korifiv1alpha1.CFRevision
+
+		BeforeEach(func() {
+			revision = &korifiv1alpha1.CFRevision{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: namespace,
+				},
+				Spec: korifiv1alpha1.CFRevisionSpec{
+					AppRef:        corev1.LocalObjectReference{Name: uuid.NewString()},
+					Version:       3,
+					DropletRef:    corev1.LocalObjectReference{Name: uuid.NewString()},
+					EnvSecretName: uuid.NewString(),
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			Expect(adminClient.Create(ctx, revision)).To(Succeed())
+		})
+
+		It("labels the CFRevision with the expected index labels", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(revision), revision)).To(Succeed())
+				g.Expect(revision.Labels).To(MatchKeys(IgnoreExtras, Keys{
+					korifiv1alpha1.SpaceGUIDLabelKey:         Equal(revision.Namespace),
+					korifiv1alpha1.CFAppGUIDLabelKey:         Equal(revision.Spec.AppRef.Name),
+					korifiv1alpha1.CFRevisionVersionLabelKey: Equal("3"),
+				}))
+			}).Should(Succeed())
+		})
+	})
+
 	Describe("CFServiceInstance", func() {
 		var instance *korifiv1alpha1.CFServiceInstance
 
@@ -315,6 +450,77 @@ var _ = Describe("LabelIndexerWebhook", func() {
 		})
 	})
 
+	Describe("CFServiceRouteBinding", func() {
+		var routeBinding *korifiv1alpha1.CFServiceRouteBinding
+
+		BeforeEach(func() {
+			routeBinding = &korifiv1alpha1.CFServiceRouteBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: namespace,
+				},
+				Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+					ServiceInstanceRef: corev1.LocalObjectReference{
+						Name: uuid.NewString(),
+					},
+					RouteRef: corev1.LocalObjectReference{
+						Name: uuid.NewString(),
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			Expect(adminClient.Create(ctx, routeBinding)).To(Succeed())
+		})
+
+		It("labels the CFServiceRouteBinding with the expected index labels", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+				g.Expect(routeBinding.Labels).To(MatchKeys(IgnoreExtras, Keys{
+					korifiv1alpha1.SpaceGUIDLabelKey:             Equal(routeBinding.Namespace),
+					korifiv1alpha1.CFServiceInstanceGUIDLabelKey: Equal(routeBinding.Spec.ServiceInstanceRef.Name),
+					korifiv1alpha1.CFRouteGUIDLabelKey:           Equal(routeBinding.Spec.RouteRef.Name),
+				}))
+			}).Should(Succeed())
+		})
+	})
+
+	Describe("CFSidecar", func() {
+		var sidecar *korifiv1alpha1.CFSidecar
+
+		BeforeEach(func() {
+			sidecar = &korifiv1alpha1.CFSidecar{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: namespace,
+				},
+				Spec: korifiv1alpha1.CFSidecarSpec{
+					AppRef: corev1.LocalObjectReference{
+						Name: uuid.NewString(),
+					},
+					Name:         "my-sidecar",
+					Command:      "bin/sidecar",
+					ProcessTypes: []string{"web"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			Expect(adminClient.Create(ctx, sidecar)).To(Succeed())
+		})
+
+		It("labels the CFSidecar with the expected index labels", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(sidecar), sidecar)).To(Succeed())
+				g.Expect(sidecar.Labels).To(MatchKeys(IgnoreExtras, Keys{
+					korifiv1alpha1.SpaceGUIDLabelKey: Equal(sidecar.Namespace),
+					korifiv1alpha1.CFAppGUIDLabelKey: Equal(sidecar.Spec.AppRef.Name),
+				}))
+			}).Should(Succeed())
+		})
+	})
+
 	Describe("CFTask", func() {
 		var task *korifiv1alpha1.CFTask
 
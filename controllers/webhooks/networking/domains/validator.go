@@ -70,6 +70,14 @@ func (v *Validator) ValidateCreate(ctx context.Context, domain *korifiv1alpha1.C
 		}.ExportJSONError()
 	}
 
+	if err = validateSharedOrgs(domain); err != nil {
+		return nil, err
+	}
+
+	if err = validateInternal(domain); err != nil {
+		return nil, err
+	}
+
 	isOverlapping, err := v.domainIsOverlapping(ctx, domain.Spec.Name)
 	if err != nil {
 		log.Info("error checking for overlapping domain", "reason", err)
@@ -93,6 +101,28 @@ func validateDomainName(domainName string) error {
 	return validation.IsFullyQualifiedDomainName(field.NewPath("CFDomain", "Spec", "Name"), domainName).ToAggregate()
 }
 
+func validateSharedOrgs(domain *korifiv1alpha1.CFDomain) error {
+	if !domain.IsPrivate() && len(domain.Spec.SharedOrgGUIDs) > 0 {
+		return validationwebhook.ValidationError{
+			Type:    InvalidDomainErrorType,
+			Message: "only domains owned by an org can be shared with other orgs",
+		}.ExportJSONError()
+	}
+
+	return nil
+}
+
+func validateInternal(domain *korifiv1alpha1.CFDomain) error {
+	if domain.Spec.Internal && domain.Spec.RouterGroup != "" {
+		return validationwebhook.ValidationError{
+			Type:    InvalidDomainErrorType,
+			Message: "internal domains cannot have a router group",
+		}.ExportJSONError()
+	}
+
+	return nil
+}
+
 func (v *Validator) ValidateUpdate(ctx context.Context, oldDomain *korifiv1alpha1.CFDomain, domain *korifiv1alpha1.CFDomain) (admission.Warnings, error) {
 	if !domain.GetDeletionTimestamp().IsZero() {
 		return nil, nil
@@ -105,6 +135,24 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldDomain *korifiv1alpha
 		}.ExportJSONError()
 	}
 
+	if oldDomain.Spec.OrgGUID != domain.Spec.OrgGUID {
+		return nil, validationwebhook.ValidationError{
+			Type:    validationwebhook.ImmutableFieldErrorType,
+			Message: fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFDomain.Spec.OrgGUID"),
+		}.ExportJSONError()
+	}
+
+	if oldDomain.Spec.Internal != domain.Spec.Internal {
+		return nil, validationwebhook.ValidationError{
+			Type:    validationwebhook.ImmutableFieldErrorType,
+			Message: fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFDomain.Spec.Internal"),
+		}.ExportJSONError()
+	}
+
+	if err := validateSharedOrgs(domain); err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
@@ -150,6 +150,44 @@ var _ = Describe("CFDomainValidator", func() {
 				))
 			})
 		})
+
+		When("a domain without an owning org is shared with orgs", func() {
+			BeforeEach(func() {
+				requestDomainCR.Spec.SharedOrgGUIDs = []string{"org-guid"}
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					domains.InvalidDomainErrorType,
+					Equal("only domains owned by an org can be shared with other orgs"),
+				))
+			})
+		})
+
+		When("an internal domain has a router group", func() {
+			BeforeEach(func() {
+				requestDomainCR.Spec.Internal = true
+				requestDomainCR.Spec.RouterGroup = "default-tcp"
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					domains.InvalidDomainErrorType,
+					Equal("internal domains cannot have a router group"),
+				))
+			})
+		})
+
+		When("a private domain is shared with orgs", func() {
+			BeforeEach(func() {
+				requestDomainCR.Spec.OrgGUID = "owner-org-guid"
+				requestDomainCR.Spec.SharedOrgGUIDs = []string{"org-guid"}
+			})
+
+			It("does not return an error", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+		})
 	})
 
 	Describe("ValidateUpdate", func() {
@@ -183,6 +221,46 @@ var _ = Describe("CFDomainValidator", func() {
 				Expect(retErr).NotTo(HaveOccurred())
 			})
 		})
+
+		When("the owning org changes", func() {
+			BeforeEach(func() {
+				updatedCFDomain.Spec.Name = oldCFDomain.Spec.Name
+				updatedCFDomain.Spec.OrgGUID = "another-org-guid"
+			})
+
+			It("returns an error", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validation.ImmutableFieldErrorType,
+					Equal("'CFDomain.Spec.OrgGUID' field is immutable"),
+				))
+			})
+		})
+
+		When("the domain becomes internal", func() {
+			BeforeEach(func() {
+				updatedCFDomain.Spec.Name = oldCFDomain.Spec.Name
+				updatedCFDomain.Spec.Internal = true
+			})
+
+			It("returns an error", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validation.ImmutableFieldErrorType,
+					Equal("'CFDomain.Spec.Internal' field is immutable"),
+				))
+			})
+		})
+
+		When("the shared orgs of a private domain change", func() {
+			BeforeEach(func() {
+				oldCFDomain.Spec.OrgGUID = "owner-org-guid"
+				updatedCFDomain = oldCFDomain.DeepCopy()
+				updatedCFDomain.Spec.SharedOrgGUIDs = []string{"org-guid"}
+			})
+
+			It("does not return an error", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+		})
 	})
 })
 
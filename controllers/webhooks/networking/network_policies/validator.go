@@ -0,0 +1,51 @@
+package networkpolicies
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"k8s.io/apimachinery/pkg/api/equality"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfnetworkpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfnetworkpolicies,verbs=create;update,versions=v1alpha1,name=vcfnetworkpolicy.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type Validator struct {
+	suspensionValidator webhooks.SuspensionValidator
+}
+
+var _ admission.Validator[*korifiv1alpha1.CFNetworkPolicy] = &Validator{}
+
+func NewValidator(suspensionValidator webhooks.SuspensionValidator) *Validator {
+	return &Validator{
+		suspensionValidator: suspensionValidator,
+	}
+}
+
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &korifiv1alpha1.CFNetworkPolicy{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *Validator) ValidateCreate(ctx context.Context, networkPolicy *korifiv1alpha1.CFNetworkPolicy) (admission.Warnings, error) {
+	return nil, v.suspensionValidator.ValidateWrite(ctx, networkPolicy.Namespace)
+}
+
+func (v *Validator) ValidateUpdate(ctx context.Context, oldNetworkPolicy, networkPolicy *korifiv1alpha1.CFNetworkPolicy) (admission.Warnings, error) {
+	if !networkPolicy.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	if equality.Semantic.DeepEqual(oldNetworkPolicy.Spec, networkPolicy.Spec) {
+		return nil, nil
+	}
+
+	return nil, v.suspensionValidator.ValidateWrite(ctx, networkPolicy.Namespace)
+}
+
+func (v *Validator) ValidateDelete(_ context.Context, _ *korifiv1alpha1.CFNetworkPolicy) (admission.Warnings, error) {
+	return nil, nil
+}
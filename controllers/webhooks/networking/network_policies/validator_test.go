@@ -0,0 +1,133 @@
+package networkpolicies_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	networkpolicies "code.cloudfoundry.org/korifi/controllers/webhooks/networking/network_policies"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFNetworkPolicyValidatingWebhook", func() {
+	const defaultNamespace = "default"
+
+	var (
+		ctx                 context.Context
+		suspensionValidator *fake.SuspensionValidator
+		networkPolicy       *korifiv1alpha1.CFNetworkPolicy
+		validatingWebhook   *networkpolicies.Validator
+		retErr              error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		networkPolicy = &korifiv1alpha1.CFNetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: defaultNamespace,
+			},
+			Spec: korifiv1alpha1.CFNetworkPolicySpec{
+				SourceAppRef: corev1.LocalObjectReference{Name: "source-app-guid"},
+				DestinationAppRef: corev1.ObjectReference{
+					Name:      "destination-app-guid",
+					Namespace: "destination-namespace",
+				},
+				Protocol:  "tcp",
+				StartPort: 8080,
+				EndPort:   8080,
+			},
+		}
+
+		suspensionValidator = new(fake.SuspensionValidator)
+		validatingWebhook = networkpolicies.NewValidator(suspensionValidator)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, networkPolicy)
+		})
+
+		It("allows the creation of the network policy", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("validates the source space is not suspended", func() {
+			Expect(suspensionValidator.ValidateWriteCallCount()).To(Equal(1))
+			_, actualNamespace := suspensionValidator.ValidateWriteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(defaultNamespace))
+		})
+
+		When("the space is suspended", func() {
+			BeforeEach(func() {
+				suspensionValidator.ValidateWriteReturns(errors.New("suspended"))
+			})
+
+			It("prevents the creation of the network policy", func() {
+				Expect(retErr).To(MatchError("suspended"))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var updatedNetworkPolicy *korifiv1alpha1.CFNetworkPolicy
+
+		BeforeEach(func() {
+			updatedNetworkPolicy = networkPolicy.DeepCopy()
+			updatedNetworkPolicy.Spec.EndPort = 9090
+		})
+
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateUpdate(ctx, networkPolicy, updatedNetworkPolicy)
+		})
+
+		It("allows the update", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("validates the source space is not suspended", func() {
+			Expect(suspensionValidator.ValidateWriteCallCount()).To(Equal(1))
+			_, actualNamespace := suspensionValidator.ValidateWriteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(defaultNamespace))
+		})
+
+		When("the space is suspended", func() {
+			BeforeEach(func() {
+				suspensionValidator.ValidateWriteReturns(errors.New("suspended"))
+			})
+
+			It("prevents the update", func() {
+				Expect(retErr).To(MatchError("suspended"))
+			})
+
+			When("the spec does not change", func() {
+				BeforeEach(func() {
+					updatedNetworkPolicy.Spec = networkPolicy.Spec
+					updatedNetworkPolicy.Labels = map[string]string{"foo": "bar"}
+				})
+
+				It("allows the update", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the network policy is being deleted", func() {
+				BeforeEach(func() {
+					updatedNetworkPolicy.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				})
+
+				It("allows the update", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+	})
+})
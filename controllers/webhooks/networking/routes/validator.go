@@ -8,10 +8,12 @@ import (
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	validationwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"github.com/hashicorp/go-multierror"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -30,6 +32,8 @@ const (
 	RoutePathValidationErrorType           = "RoutePathValidationError"
 	RouteSubdomainValidationErrorType      = "RouteSubdomainValidationError"
 	RouteSubdomainValidationErrorMessage   = "Subdomains must each be at most 63 characters"
+	RouteProtocolValidationErrorType       = "RouteProtocolValidationError"
+	RouteDomainNotVisibleErrorType         = "RouteDomainNotVisibleError"
 
 	HostEmptyError  = "host cannot be empty"
 	HostLengthError = "host is too long (maximum is 63 characters)"
@@ -39,6 +43,15 @@ const (
 	PathIsSlashError         = "Path cannot be a single slash"
 	PathHasQuestionMarkError = "Path cannot contain a question mark"
 	PathLengthExceededError  = "Path cannot exceed 128 characters"
+	InternalRoutePathError   = "Paths are not supported for routes on internal domains"
+
+	TCPRouteProtocolError  = "Routes on domains with a router group must use the tcp protocol"
+	TCPRouteHostError      = "Hosts are not supported for TCP routes"
+	TCPRoutePathError      = "Paths are not supported for TCP routes"
+	TCPRoutePortError      = "TCP routes must have a port"
+	TCPRoutePortRangeError = "Port %d is not in the reservable port range of router group %q"
+	HTTPRouteProtocolError = "Routes on domains without a router group must use the http protocol"
+	HTTPRoutePortError     = "Ports are not supported for HTTP routes"
 )
 
 var logger = logf.Log.WithName("route-validation")
@@ -46,22 +59,36 @@ var logger = logf.Log.WithName("route-validation")
 //+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfroute,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfroutes,verbs=create;update;delete,versions=v1alpha1,name=vcfroute.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
 type Validator struct {
-	duplicateValidator webhooks.NameValidator
-	rootNamespace      string
-	client             client.Client
+	duplicateValidator  webhooks.NameValidator
+	quotaValidator      webhooks.QuotaValidator
+	suspensionValidator webhooks.SuspensionValidator
+	rootNamespace       string
+	routerGroupPorts    map[string]config.PortRange
+	client              client.Client
 }
 
 var _ admission.Validator[*korifiv1alpha1.CFRoute] = &Validator{}
 
 func NewValidator(
 	nameValidator webhooks.NameValidator,
+	quotaValidator webhooks.QuotaValidator,
+	suspensionValidator webhooks.SuspensionValidator,
 	rootNamespace string,
+	routerGroups []config.RouterGroup,
 	client client.Client,
 ) *Validator {
+	routerGroupPorts := map[string]config.PortRange{}
+	for _, routerGroup := range routerGroups {
+		routerGroupPorts[routerGroup.Name] = routerGroup.ReservablePorts
+	}
+
 	return &Validator{
-		duplicateValidator: nameValidator,
-		rootNamespace:      rootNamespace,
-		client:             client,
+		duplicateValidator:  nameValidator,
+		quotaValidator:      quotaValidator,
+		suspensionValidator: suspensionValidator,
+		rootNamespace:       rootNamespace,
+		routerGroupPorts:    routerGroupPorts,
+		client:              client,
 	}
 }
 
@@ -79,6 +106,14 @@ func (v *Validator) ValidateCreate(ctx context.Context, route *korifiv1alpha1.CF
 
 	route.Status.FQDN = cfDomain.Spec.Name
 
+	if err = v.suspensionValidator.ValidateWrite(ctx, route.Namespace); err != nil {
+		return nil, err
+	}
+
+	if err = v.quotaValidator.ValidateRouteCreate(ctx, route); err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, logger, v.rootNamespace, route)
 }
 
@@ -106,6 +141,11 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldRoute, route *korifiv
 		return nil, immutableError.ExportJSONError()
 	}
 
+	if route.Spec.Port != oldRoute.Spec.Port {
+		immutableError.Message = fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFRoute.Spec.Port")
+		return nil, immutableError.ExportJSONError()
+	}
+
 	if route.Spec.DomainRef.Name != oldRoute.Spec.DomainRef.Name {
 		immutableError.Message = fmt.Sprintf(validationwebhook.ImmutableFieldErrorMessageTemplate, "CFRoute.Spec.DomainRef.Name")
 		return nil, immutableError.ExportJSONError()
@@ -129,11 +169,32 @@ func (v *Validator) validateRoute(ctx context.Context, route *korifiv1alpha1.CFR
 		return domain, err
 	}
 
+	err = v.validateDomainVisibility(ctx, route, domain)
+	if err != nil {
+		return nil, err
+	}
+
 	err = v.validateDestinations(ctx, route)
 	if err != nil {
 		return domain, err
 	}
 
+	if domain.Spec.RouterGroup != "" {
+		if err = validateTCPRoute(route); err != nil {
+			return nil, err
+		}
+
+		if err = v.validateReservablePort(route, domain.Spec.RouterGroup); err != nil {
+			return nil, err
+		}
+
+		return domain, nil
+	}
+
+	if err = validateHTTPRoute(route); err != nil {
+		return nil, err
+	}
+
 	if err = validateFQDN(route.Spec.Host, domain.Spec.Name); err != nil {
 		return nil, err
 	}
@@ -142,6 +203,13 @@ func (v *Validator) validateRoute(ctx context.Context, route *korifiv1alpha1.CFR
 		return nil, err
 	}
 
+	if domain.Spec.Internal && route.Spec.Path != "" {
+		return nil, validationwebhook.ValidationError{
+			Type:    RoutePathValidationErrorType,
+			Message: InternalRoutePathError,
+		}.ExportJSONError()
+	}
+
 	return domain, nil
 }
 
@@ -159,6 +227,31 @@ func (v *Validator) fetchDomain(ctx context.Context, route *korifiv1alpha1.CFRou
 	return domain, err
 }
 
+func (v *Validator) validateDomainVisibility(ctx context.Context, route *korifiv1alpha1.CFRoute, domain *korifiv1alpha1.CFDomain) error {
+	if !domain.IsPrivate() {
+		return nil
+	}
+
+	spaceNamespace := &corev1.Namespace{}
+	err := v.client.Get(ctx, types.NamespacedName{Name: route.Namespace}, spaceNamespace)
+	if err != nil {
+		logger.Info("error retrieving the route space namespace", "reason", err)
+		return validationwebhook.ValidationError{
+			Type:    validationwebhook.UnknownErrorType,
+			Message: validationwebhook.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	if !domain.IsVisibleTo(spaceNamespace.Labels[korifiv1alpha1.CFOrgGUIDKey]) {
+		return validationwebhook.ValidationError{
+			Type:    RouteDomainNotVisibleErrorType,
+			Message: fmt.Sprintf("The domain %q is not available to the organization of the route space", domain.Spec.Name),
+		}.ExportJSONError()
+	}
+
+	return nil
+}
+
 func (v *Validator) validateDestinations(ctx context.Context, route *korifiv1alpha1.CFRoute) error {
 	err := v.checkDestinationsExistInNamespace(ctx, *route)
 	if err != nil {
@@ -179,6 +272,67 @@ func (v *Validator) validateDestinations(ctx context.Context, route *korifiv1alp
 	return nil
 }
 
+func validateTCPRoute(route *korifiv1alpha1.CFRoute) error {
+	var errStrings []string
+
+	if route.Spec.Protocol != korifiv1alpha1.RouteProtocolTCP {
+		errStrings = append(errStrings, TCPRouteProtocolError)
+	}
+
+	if route.Spec.Host != "" {
+		errStrings = append(errStrings, TCPRouteHostError)
+	}
+
+	if route.Spec.Path != "" {
+		errStrings = append(errStrings, TCPRoutePathError)
+	}
+
+	if route.Spec.Port == 0 {
+		errStrings = append(errStrings, TCPRoutePortError)
+	}
+
+	return protocolValidationError(errStrings)
+}
+
+func (v *Validator) validateReservablePort(route *korifiv1alpha1.CFRoute, routerGroup string) error {
+	// The gateway only has listeners for the reservable ports of the
+	// configured router groups
+	ports, ok := v.routerGroupPorts[routerGroup]
+	if ok && route.Spec.Port >= ports.From && route.Spec.Port <= ports.To {
+		return nil
+	}
+
+	return validationwebhook.ValidationError{
+		Type:    RouteProtocolValidationErrorType,
+		Message: fmt.Sprintf(TCPRoutePortRangeError, route.Spec.Port, routerGroup),
+	}.ExportJSONError()
+}
+
+func validateHTTPRoute(route *korifiv1alpha1.CFRoute) error {
+	var errStrings []string
+
+	if route.Spec.Protocol == korifiv1alpha1.RouteProtocolTCP {
+		errStrings = append(errStrings, HTTPRouteProtocolError)
+	}
+
+	if route.Spec.Port != 0 {
+		errStrings = append(errStrings, HTTPRoutePortError)
+	}
+
+	return protocolValidationError(errStrings)
+}
+
+func protocolValidationError(errStrings []string) error {
+	if len(errStrings) == 0 {
+		return nil
+	}
+
+	return validationwebhook.ValidationError{
+		Type:    RouteProtocolValidationErrorType,
+		Message: strings.Join(errStrings, ", "),
+	}.ExportJSONError()
+}
+
 func validateFQDN(host, domain string) error {
 	// we only need to validate that "<host>.<domain>" is not too long and that
 	// <host> is either "*" or a valid dns label. The domain webhook already
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
 	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/networking/routes"
@@ -27,13 +28,15 @@ import (
 
 var _ = Describe("CFRouteValidator", func() {
 	var (
-		ctx                context.Context
-		duplicateValidator *fake.NameValidator
-		fakeClient         *controllerfake.Client
-		cfRoute            *korifiv1alpha1.CFRoute
-		cfDomain           *korifiv1alpha1.CFDomain
-		cfApp              *korifiv1alpha1.CFApp
-		validatingWebhook  *routes.Validator
+		ctx                 context.Context
+		duplicateValidator  *fake.NameValidator
+		quotaValidator      *fake.QuotaValidator
+		suspensionValidator *fake.SuspensionValidator
+		fakeClient          *controllerfake.Client
+		cfRoute             *korifiv1alpha1.CFRoute
+		cfDomain            *korifiv1alpha1.CFDomain
+		cfApp               *korifiv1alpha1.CFApp
+		validatingWebhook   *routes.Validator
 
 		testRouteGUID       string
 		testRouteNamespace  string
@@ -45,9 +48,11 @@ var _ = Describe("CFRouteValidator", func() {
 		testDomainNamespace string
 		rootNamespace       string
 
-		getDomainError error
-		getAppError    error
-		retErr         error
+		getDomainError    error
+		getAppError       error
+		getNamespaceError error
+		spaceNamespace    *v1.Namespace
+		retErr            error
 
 		getDomainCallCount int
 	)
@@ -70,6 +75,7 @@ var _ = Describe("CFRouteValidator", func() {
 		rootNamespace = "root-ns"
 		getDomainError = nil
 		getAppError = nil
+		getNamespaceError = nil
 		getDomainCallCount = 0
 
 		cfRoute = initializeRouteCR(testRouteProtocol, testRouteHost, testRoutePath, testRouteGUID, testRouteNamespace, testDomainGUID, testDomainNamespace)
@@ -85,7 +91,18 @@ var _ = Describe("CFRouteValidator", func() {
 
 		cfApp = &korifiv1alpha1.CFApp{}
 
+		spaceNamespace = &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: testRouteNamespace,
+				Labels: map[string]string{
+					korifiv1alpha1.CFOrgGUIDKey: "org-guid",
+				},
+			},
+		}
+
 		duplicateValidator = new(fake.NameValidator)
+		quotaValidator = new(fake.QuotaValidator)
+		suspensionValidator = new(fake.SuspensionValidator)
 		fakeClient = new(controllerfake.Client)
 
 		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
@@ -97,12 +114,18 @@ var _ = Describe("CFRouteValidator", func() {
 			case *korifiv1alpha1.CFApp:
 				cfApp.DeepCopyInto(obj)
 				return getAppError
+			case *v1.Namespace:
+				spaceNamespace.DeepCopyInto(obj)
+				return getNamespaceError
 			default:
 				panic("TestClient Get provided an unexpected object type")
 			}
 		}
 
-		validatingWebhook = routes.NewValidator(duplicateValidator, rootNamespace, fakeClient)
+		validatingWebhook = routes.NewValidator(duplicateValidator, quotaValidator, suspensionValidator, rootNamespace, []config.RouterGroup{{
+			Name:            "default-tcp",
+			ReservablePorts: config.PortRange{From: 1024, To: 1033},
+		}}, fakeClient)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -127,6 +150,34 @@ var _ = Describe("CFRouteValidator", func() {
 			Expect(getDomainCallCount).To(Equal(1), "Expected get domain call count mismatch")
 		})
 
+		It("validates the route against the quotas", func() {
+			Expect(quotaValidator.ValidateRouteCreateCallCount()).To(Equal(1))
+			actualContext, actualRoute := quotaValidator.ValidateRouteCreateArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualRoute).To(Equal(cfRoute))
+		})
+
+		When("the quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateRouteCreateReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+			})
+		})
+
+		When("the org is suspended", func() {
+			BeforeEach(func() {
+				suspensionValidator.ValidateWriteReturns(errors.New("org-suspended"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("org-suspended"))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+			})
+		})
+
 		When("the host is '*'", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Host = "*"
@@ -150,6 +201,52 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the domain is private", func() {
+			BeforeEach(func() {
+				cfDomain.Spec.OrgGUID = "another-org-guid"
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RouteDomainNotVisibleErrorType,
+					Equal(`The domain "test.domain.name" is not available to the organization of the route space`),
+				))
+			})
+
+			When("the domain is owned by the org of the route space", func() {
+				BeforeEach(func() {
+					cfDomain.Spec.OrgGUID = "org-guid"
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the domain is shared with the org of the route space", func() {
+				BeforeEach(func() {
+					cfDomain.Spec.SharedOrgGUIDs = []string{"org-guid"}
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("retrieving the route space namespace fails", func() {
+				BeforeEach(func() {
+					getNamespaceError = errors.New("get-namespace-err")
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						validationwebhook.UnknownErrorType,
+						Equal(validationwebhook.UnknownErrorMessage),
+					))
+				})
+			})
+		})
+
 		When("the host is invalid", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Host = "inVAl!dnAme?"
@@ -254,6 +351,19 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the route has a path on an internal domain", func() {
+			BeforeEach(func() {
+				cfDomain.Spec.Internal = true
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RoutePathValidationErrorType,
+					Equal(routes.InternalRoutePathError),
+				))
+			})
+		})
+
 		When("the route has destinations", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
@@ -295,6 +405,119 @@ var _ = Describe("CFRouteValidator", func() {
 				})
 			})
 		})
+		When("the route has a port", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Port = 1234
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RouteProtocolValidationErrorType,
+					Equal(routes.HTTPRoutePortError),
+				))
+			})
+		})
+
+		When("the route protocol is tcp", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Protocol = korifiv1alpha1.RouteProtocolTCP
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RouteProtocolValidationErrorType,
+					Equal(routes.HTTPRouteProtocolError),
+				))
+			})
+		})
+
+		When("the domain has a router group", func() {
+			BeforeEach(func() {
+				cfDomain.Spec.RouterGroup = "default-tcp"
+
+				cfRoute.Spec.Host = ""
+				cfRoute.Spec.Path = ""
+				cfRoute.Spec.Protocol = korifiv1alpha1.RouteProtocolTCP
+				cfRoute.Spec.Port = 1030
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			It("uses the port regardless of the domain in the duplicate validation", func() {
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+				_, _, _, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+				Expect(actualResource.UniqueName()).To(Equal("tcp::1030"))
+				Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Port 1030 is not available."))
+			})
+
+			When("the route is not a tcp route", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Protocol = korifiv1alpha1.RouteProtocolHTTP
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolValidationErrorType,
+						Equal(routes.TCPRouteProtocolError),
+					))
+				})
+			})
+
+			When("the route has a host and a path", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Host = "my-host"
+					cfRoute.Spec.Path = "/my-path"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolValidationErrorType,
+						Equal(routes.TCPRouteHostError+", "+routes.TCPRoutePathError),
+					))
+				})
+			})
+
+			When("the route has no port", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Port = 0
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolValidationErrorType,
+						Equal(routes.TCPRoutePortError),
+					))
+				})
+			})
+
+			When("the port is not in the reservable port range of the router group", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Port = 1034
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolValidationErrorType,
+						Equal(`Port 1034 is not in the reservable port range of router group "default-tcp"`),
+					))
+				})
+			})
+
+			When("the router group is not configured", func() {
+				BeforeEach(func() {
+					cfDomain.Spec.RouterGroup = "other-tcp"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteProtocolValidationErrorType,
+						Equal(`Port 1030 is not in the reservable port range of router group "other-tcp"`),
+					))
+				})
+			})
+		})
 	})
 
 	Describe("ValidateUpdate", func() {
@@ -391,6 +614,19 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the port is updated", func() {
+			BeforeEach(func() {
+				updatedCFRoute.Spec.Port = 1234
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					validationwebhook.ImmutableFieldErrorType,
+					Equal("'CFRoute.Spec.Port' field is immutable"),
+				))
+			})
+		})
+
 		When("the DomainRef is updated", func() {
 			BeforeEach(func() {
 				updatedCFRoute.Spec.DomainRef = v1.ObjectReference{Name: "newDomainRef"}
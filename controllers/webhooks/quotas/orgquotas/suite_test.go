@@ -0,0 +1,13 @@
+package orgquotas_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestOrgQuotasWebhooks(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CFOrgQuota Webhooks Suite")
+}
@@ -0,0 +1,78 @@
+package orgquotas
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	OrgQuotaEntityType = "orgquota"
+
+	InvalidOrgQuotaNameErrorMessage = "name cannot be empty and must be less than 255 characters"
+)
+
+var cforgquotalog = logf.Log.WithName("cforgquota-validate")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cforgquota,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cforgquotas,verbs=create;update;delete,versions=v1alpha1,name=vcforgquota.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type Validator struct {
+	duplicateValidator webhooks.NameValidator
+	rootNamespace      string
+}
+
+var _ admission.Validator[*korifiv1alpha1.CFOrgQuota] = &Validator{}
+
+func NewValidator(duplicateValidator webhooks.NameValidator, rootNamespace string) *Validator {
+	return &Validator{
+		duplicateValidator: duplicateValidator,
+		rootNamespace:      rootNamespace,
+	}
+}
+
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &korifiv1alpha1.CFOrgQuota{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *Validator) ValidateCreate(ctx context.Context, orgQuota *korifiv1alpha1.CFOrgQuota) (admission.Warnings, error) {
+	if err := validateName(orgQuota.Spec.DisplayName); err != nil {
+		return nil, err
+	}
+
+	return nil, v.duplicateValidator.ValidateCreate(ctx, cforgquotalog, v.rootNamespace, orgQuota)
+}
+
+func (v *Validator) ValidateUpdate(ctx context.Context, oldOrgQuota, orgQuota *korifiv1alpha1.CFOrgQuota) (admission.Warnings, error) {
+	if !orgQuota.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	if err := validateName(orgQuota.Spec.DisplayName); err != nil {
+		return nil, err
+	}
+
+	return nil, v.duplicateValidator.ValidateUpdate(ctx, cforgquotalog, v.rootNamespace, oldOrgQuota, orgQuota)
+}
+
+func (v *Validator) ValidateDelete(ctx context.Context, orgQuota *korifiv1alpha1.CFOrgQuota) (admission.Warnings, error) {
+	return nil, v.duplicateValidator.ValidateDelete(ctx, cforgquotalog, v.rootNamespace, orgQuota)
+}
+
+func validateName(name string) error {
+	if len(name) == 0 || len(name) > 255 {
+		return validation.ValidationError{
+			Type:    webhooks.InvalidFieldValueErrorType,
+			Message: InvalidOrgQuotaNameErrorMessage,
+		}.ExportJSONError()
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package orgquotas_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/quotas/orgquotas"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFOrgQuotaValidatingWebhook", func() {
+	const rootNamespace = "cf"
+
+	var (
+		ctx                context.Context
+		duplicateValidator *fake.NameValidator
+		orgQuota           *korifiv1alpha1.CFOrgQuota
+		validatingWebhook  *orgquotas.Validator
+		retErr             error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		orgQuota = &korifiv1alpha1.CFOrgQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFOrgQuotaSpec{
+				DisplayName: "my-quota",
+			},
+		}
+
+		duplicateValidator = new(fake.NameValidator)
+		validatingWebhook = orgquotas.NewValidator(duplicateValidator, rootNamespace)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, orgQuota)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("invokes the duplicate validator correctly", func() {
+			Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+			actualContext, _, actualNamespace, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualNamespace).To(Equal(rootNamespace))
+			Expect(actualResource).To(Equal(orgQuota))
+			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Organization Quota 'my-quota' already exists."))
+		})
+
+		When("the name is a duplicate", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+
+		When("the name is empty", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.DisplayName = ""
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(webhooks.InvalidFieldValueErrorType, Equal(orgquotas.InvalidOrgQuotaNameErrorMessage)))
+			})
+		})
+
+		When("the name is too long", func() {
+			BeforeEach(func() {
+				orgQuota.Spec.DisplayName = strings.Repeat("a", 256)
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(webhooks.InvalidFieldValueErrorType, Equal(orgquotas.InvalidOrgQuotaNameErrorMessage)))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var updatedOrgQuota *korifiv1alpha1.CFOrgQuota
+
+		BeforeEach(func() {
+			updatedOrgQuota = orgQuota.DeepCopy()
+			updatedOrgQuota.Spec.DisplayName = "new-name"
+		})
+
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateUpdate(ctx, orgQuota, updatedOrgQuota)
+		})
+
+		It("invokes the duplicate validator correctly", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(duplicateValidator.ValidateUpdateCallCount()).To(Equal(1))
+			_, _, actualNamespace, oldResource, newResource := duplicateValidator.ValidateUpdateArgsForCall(0)
+			Expect(actualNamespace).To(Equal(rootNamespace))
+			Expect(oldResource).To(Equal(orgQuota))
+			Expect(newResource).To(Equal(updatedOrgQuota))
+		})
+
+		When("the new name is empty", func() {
+			BeforeEach(func() {
+				updatedOrgQuota.Spec.DisplayName = ""
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(webhooks.InvalidFieldValueErrorType, Equal(orgquotas.InvalidOrgQuotaNameErrorMessage)))
+			})
+		})
+	})
+
+	Describe("ValidateDelete", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateDelete(ctx, orgQuota)
+		})
+
+		It("invokes the duplicate validator correctly", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
+			_, _, actualNamespace, actualResource := duplicateValidator.ValidateDeleteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(rootNamespace))
+			Expect(actualResource).To(Equal(orgQuota))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package spacequotas_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSpaceQuotasWebhooks(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CFSpaceQuota Webhooks Suite")
+}
@@ -0,0 +1,109 @@
+package spacequotas
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	SpaceQuotaEntityType = "spacequota"
+
+	InvalidSpaceQuotaNameErrorMessage = "name cannot be empty and must be less than 255 characters"
+	SpaceNotInQuotaOrgErrorType       = "SpaceNotInQuotaOrgError"
+	SpaceNotInQuotaOrgErrorMessage    = "Space '%s' is not in the organization of the space quota"
+)
+
+var cfspacequotalog = logf.Log.WithName("cfspacequota-validate")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfspacequota,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfspacequotas,verbs=create;update;delete,versions=v1alpha1,name=vcfspacequota.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type Validator struct {
+	duplicateValidator webhooks.NameValidator
+	client             client.Client
+}
+
+var _ admission.Validator[*korifiv1alpha1.CFSpaceQuota] = &Validator{}
+
+func NewValidator(duplicateValidator webhooks.NameValidator, client client.Client) *Validator {
+	return &Validator{
+		duplicateValidator: duplicateValidator,
+		client:             client,
+	}
+}
+
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &korifiv1alpha1.CFSpaceQuota{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *Validator) ValidateCreate(ctx context.Context, spaceQuota *korifiv1alpha1.CFSpaceQuota) (admission.Warnings, error) {
+	if err := v.validateSpaceQuota(ctx, nil, spaceQuota); err != nil {
+		return nil, err
+	}
+
+	return nil, v.duplicateValidator.ValidateCreate(ctx, cfspacequotalog, spaceQuota.Namespace, spaceQuota)
+}
+
+func (v *Validator) ValidateUpdate(ctx context.Context, oldSpaceQuota, spaceQuota *korifiv1alpha1.CFSpaceQuota) (admission.Warnings, error) {
+	if !spaceQuota.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	if err := v.validateSpaceQuota(ctx, oldSpaceQuota.Spec.Spaces, spaceQuota); err != nil {
+		return nil, err
+	}
+
+	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfspacequotalog, spaceQuota.Namespace, oldSpaceQuota, spaceQuota)
+}
+
+func (v *Validator) ValidateDelete(ctx context.Context, spaceQuota *korifiv1alpha1.CFSpaceQuota) (admission.Warnings, error) {
+	return nil, v.duplicateValidator.ValidateDelete(ctx, cfspacequotalog, spaceQuota.Namespace, spaceQuota)
+}
+
+func (v *Validator) validateSpaceQuota(ctx context.Context, oldSpaces []string, spaceQuota *korifiv1alpha1.CFSpaceQuota) error {
+	if len(spaceQuota.Spec.DisplayName) == 0 || len(spaceQuota.Spec.DisplayName) > 255 {
+		return validation.ValidationError{
+			Type:    webhooks.InvalidFieldValueErrorType,
+			Message: InvalidSpaceQuotaNameErrorMessage,
+		}.ExportJSONError()
+	}
+
+	for _, spaceGUID := range spaceQuota.Spec.Spaces {
+		if slices.Contains(oldSpaces, spaceGUID) {
+			continue
+		}
+
+		err := v.client.Get(ctx, types.NamespacedName{Namespace: spaceQuota.Namespace, Name: spaceGUID}, &korifiv1alpha1.CFSpace{})
+		if err == nil {
+			continue
+		}
+
+		if k8serrors.IsNotFound(err) {
+			return validation.ValidationError{
+				Type:    SpaceNotInQuotaOrgErrorType,
+				Message: fmt.Sprintf(SpaceNotInQuotaOrgErrorMessage, spaceGUID),
+			}.ExportJSONError()
+		}
+
+		cfspacequotalog.Info("failed to get space", "reason", err)
+		return validation.ValidationError{
+			Type:    validation.UnknownErrorType,
+			Message: validation.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	return nil
+}
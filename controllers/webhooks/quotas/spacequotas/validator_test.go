@@ -0,0 +1,173 @@
+package spacequotas_test
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/quotas/spacequotas"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFSpaceQuotaValidatingWebhook", func() {
+	const orgNamespace = "org-guid"
+
+	var (
+		ctx                context.Context
+		duplicateValidator *fake.NameValidator
+		fakeClient         *controllerfake.Client
+		spaceQuota         *korifiv1alpha1.CFSpaceQuota
+		validatingWebhook  *spacequotas.Validator
+		getSpaceErr        error
+		retErr             error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		getSpaceErr = nil
+
+		spaceQuota = &korifiv1alpha1.CFSpaceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: orgNamespace,
+			},
+			Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+				DisplayName: "my-quota",
+				Spaces:      []string{"space-guid"},
+			},
+		}
+
+		fakeClient = new(controllerfake.Client)
+		fakeClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			Expect(obj).To(BeAssignableToTypeOf(&korifiv1alpha1.CFSpace{}))
+			Expect(key.Namespace).To(Equal(orgNamespace))
+			return getSpaceErr
+		}
+
+		duplicateValidator = new(fake.NameValidator)
+		validatingWebhook = spacequotas.NewValidator(duplicateValidator, fakeClient)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, spaceQuota)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("invokes the duplicate validator correctly", func() {
+			Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+			actualContext, _, actualNamespace, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualNamespace).To(Equal(orgNamespace))
+			Expect(actualResource).To(Equal(spaceQuota))
+			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Space Quota 'my-quota' already exists."))
+		})
+
+		It("checks that the spaces belong to the quota org", func() {
+			Expect(fakeClient.GetCallCount()).To(Equal(1))
+			_, key, _, _ := fakeClient.GetArgsForCall(0)
+			Expect(key.Name).To(Equal("space-guid"))
+		})
+
+		When("the name is a duplicate", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+
+		When("the name is empty", func() {
+			BeforeEach(func() {
+				spaceQuota.Spec.DisplayName = ""
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(webhooks.InvalidFieldValueErrorType, Equal(spacequotas.InvalidSpaceQuotaNameErrorMessage)))
+			})
+		})
+
+		When("a space is not in the quota org", func() {
+			BeforeEach(func() {
+				getSpaceErr = k8serrors.NewNotFound(schema.GroupResource{}, "space-guid")
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					spacequotas.SpaceNotInQuotaOrgErrorType,
+					Equal("Space 'space-guid' is not in the organization of the space quota"),
+				))
+			})
+		})
+
+		When("getting a space fails", func() {
+			BeforeEach(func() {
+				getSpaceErr = errors.New("get-space-err")
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(validation.UnknownErrorType, Equal(validation.UnknownErrorMessage)))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var updatedSpaceQuota *korifiv1alpha1.CFSpaceQuota
+
+		BeforeEach(func() {
+			updatedSpaceQuota = spaceQuota.DeepCopy()
+			updatedSpaceQuota.Spec.Spaces = append(updatedSpaceQuota.Spec.Spaces, "another-space-guid")
+		})
+
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateUpdate(ctx, spaceQuota, updatedSpaceQuota)
+		})
+
+		It("invokes the duplicate validator correctly", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(duplicateValidator.ValidateUpdateCallCount()).To(Equal(1))
+			_, _, actualNamespace, oldResource, newResource := duplicateValidator.ValidateUpdateArgsForCall(0)
+			Expect(actualNamespace).To(Equal(orgNamespace))
+			Expect(oldResource).To(Equal(spaceQuota))
+			Expect(newResource).To(Equal(updatedSpaceQuota))
+		})
+
+		It("only checks the newly added spaces", func() {
+			Expect(fakeClient.GetCallCount()).To(Equal(1))
+			_, key, _, _ := fakeClient.GetArgsForCall(0)
+			Expect(key.Name).To(Equal("another-space-guid"))
+		})
+	})
+
+	Describe("ValidateDelete", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateDelete(ctx, spaceQuota)
+		})
+
+		It("invokes the duplicate validator correctly", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
+			_, _, actualNamespace, actualResource := duplicateValidator.ValidateDeleteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(orgNamespace))
+			Expect(actualResource).To(Equal(spaceQuota))
+		})
+	})
+})
@@ -2,12 +2,16 @@ package bindings
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	validation "code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -15,6 +19,8 @@ import (
 const (
 	ServiceBindingEntityType = "servicebinding"
 	ServiceBindingErrorType  = "ServiceBindingValidationError"
+
+	ServiceInstanceNotSharedErrorType = "ServiceInstanceNotSharedError"
 )
 
 // log is for logging in this package.
@@ -29,21 +35,68 @@ func (v *CFServiceBindingValidator) SetupWebhookWithManager(mgr ctrl.Manager) er
 }
 
 type CFServiceBindingValidator struct {
-	duplicateValidator webhooks.NameValidator
+	client              client.Client
+	duplicateValidator  webhooks.NameValidator
+	suspensionValidator webhooks.SuspensionValidator
 }
 
 var _ admission.Validator[*korifiv1alpha1.CFServiceBinding] = &CFServiceBindingValidator{}
 
-func NewCFServiceBindingValidator(duplicateValidator webhooks.NameValidator) *CFServiceBindingValidator {
+func NewCFServiceBindingValidator(
+	client client.Client,
+	duplicateValidator webhooks.NameValidator,
+	suspensionValidator webhooks.SuspensionValidator,
+) *CFServiceBindingValidator {
 	return &CFServiceBindingValidator{
-		duplicateValidator: duplicateValidator,
+		client:              client,
+		duplicateValidator:  duplicateValidator,
+		suspensionValidator: suspensionValidator,
 	}
 }
 
 func (v *CFServiceBindingValidator) ValidateCreate(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) (admission.Warnings, error) {
+	if err := v.suspensionValidator.ValidateWrite(ctx, serviceBinding.Namespace); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateServiceInstanceShared(ctx, serviceBinding); err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfservicebindinglog, serviceBinding.Namespace, serviceBinding)
 }
 
+func (v *CFServiceBindingValidator) validateServiceInstanceShared(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) error {
+	if serviceBinding.ServiceInstanceNamespace() == serviceBinding.Namespace {
+		return nil
+	}
+
+	notSharedErr := validation.ValidationError{
+		Type:    ServiceInstanceNotSharedErrorType,
+		Message: fmt.Sprintf("Service instance %s is not shared with space %s", serviceBinding.Spec.Service.Name, serviceBinding.Namespace),
+	}.ExportJSONError()
+
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{}
+	err := v.client.Get(ctx, client.ObjectKey{Namespace: serviceBinding.ServiceInstanceNamespace(), Name: serviceBinding.Spec.Service.Name}, serviceInstance)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return notSharedErr
+		}
+
+		cfservicebindinglog.Info("error getting the bound service instance", "reason", err)
+		return validation.ValidationError{
+			Type:    validation.UnknownErrorType,
+			Message: validation.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	if !slices.Contains(serviceInstance.Spec.SharedSpaces, serviceBinding.Namespace) {
+		return notSharedErr
+	}
+
+	return nil
+}
+
 func (v *CFServiceBindingValidator) ValidateUpdate(ctx context.Context, oldServiceBinding, serviceBinding *korifiv1alpha1.CFServiceBinding) (admission.Warnings, error) {
 	if !serviceBinding.GetDeletionTimestamp().IsZero() {
 		return nil, nil
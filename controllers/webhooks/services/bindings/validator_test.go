@@ -6,16 +6,23 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services/bindings"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("CFServiceBindingValidatingWebhook", func() {
@@ -28,6 +35,8 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 		serviceInstanceGUID string
 		ctx                 context.Context
 		duplicateValidator  *fake.NameValidator
+		suspensionValidator *fake.SuspensionValidator
+		fakeClient          *controllerfake.Client
 		serviceBinding      *korifiv1alpha1.CFServiceBinding
 		validatingWebhook   *bindings.CFServiceBindingValidator
 		retErr              error
@@ -58,8 +67,10 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 			},
 		}
 
+		fakeClient = new(controllerfake.Client)
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = bindings.NewCFServiceBindingValidator(duplicateValidator)
+		suspensionValidator = new(fake.SuspensionValidator)
+		validatingWebhook = bindings.NewCFServiceBindingValidator(fakeClient, duplicateValidator, suspensionValidator)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -80,6 +91,19 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Service binding already exists: App: " + appGUID + " Service Instance: " + serviceInstanceGUID + " Service Binding: " + serviceInstanceGUID))
 		})
 
+		When("the org is suspended", func() {
+			BeforeEach(func() {
+				suspensionValidator.ValidateWriteReturns(errors.New("org-suspended"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("org-suspended"))
+				_, actualNamespace := suspensionValidator.ValidateWriteArgsForCall(0)
+				Expect(actualNamespace).To(Equal(defaultNamespace))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+			})
+		})
+
 		When("the service binding has a display name", func() {
 			BeforeEach(func() {
 				serviceBinding.Spec.DisplayName = tools.PtrTo("my-binding")
@@ -104,6 +128,67 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 				Expect(retErr).To(MatchError("foo"))
 			})
 		})
+
+		It("does not look up the service instance in its own space", func() {
+			Expect(fakeClient.GetCallCount()).To(BeZero())
+		})
+
+		When("the service instance is in another space", func() {
+			var sharedSpaces []string
+
+			BeforeEach(func() {
+				sharedSpaces = []string{defaultNamespace}
+				serviceBinding.Spec.Service.Namespace = "instance-space"
+
+				fakeClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+					Expect(key).To(Equal(types.NamespacedName{Namespace: "instance-space", Name: serviceInstanceGUID}))
+					instance, ok := obj.(*korifiv1alpha1.CFServiceInstance)
+					Expect(ok).To(BeTrue())
+					instance.Spec.SharedSpaces = sharedSpaces
+					return nil
+				}
+			})
+
+			It("allows the creation of a binding to the shared service instance", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(fakeClient.GetCallCount()).To(Equal(1))
+			})
+
+			When("the service instance is not shared with the binding space", func() {
+				BeforeEach(func() {
+					sharedSpaces = []string{"another-space"}
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						bindings.ServiceInstanceNotSharedErrorType,
+						Equal("Service instance "+serviceInstanceGUID+" is not shared with space "+defaultNamespace),
+					))
+				})
+			})
+
+			When("the service instance does not exist", func() {
+				BeforeEach(func() {
+					fakeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, serviceInstanceGUID))
+					fakeClient.GetStub = nil
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(bindings.ServiceInstanceNotSharedErrorType, ContainSubstring("is not shared")))
+				})
+			})
+
+			When("getting the service instance fails", func() {
+				BeforeEach(func() {
+					fakeClient.GetReturns(errors.New("get-instance-err"))
+					fakeClient.GetStub = nil
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(validation.UnknownErrorType, Equal(validation.UnknownErrorMessage)))
+				})
+			})
+		})
 	})
 
 	Describe("ValidateUpdate", func() {
@@ -22,14 +22,22 @@ var cfserviceinstancelog = logf.Log.WithName("cfserviceinstance-validate")
 //+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfserviceinstance,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=create;update;delete,versions=v1alpha1,name=vcfserviceinstance.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
 type Validator struct {
-	duplicateValidator webhooks.NameValidator
+	duplicateValidator  webhooks.NameValidator
+	quotaValidator      webhooks.QuotaValidator
+	suspensionValidator webhooks.SuspensionValidator
 }
 
 var _ admission.Validator[*korifiv1alpha1.CFServiceInstance] = &Validator{}
 
-func NewValidator(duplicateValidator webhooks.NameValidator) *Validator {
+func NewValidator(
+	duplicateValidator webhooks.NameValidator,
+	quotaValidator webhooks.QuotaValidator,
+	suspensionValidator webhooks.SuspensionValidator,
+) *Validator {
 	return &Validator{
-		duplicateValidator: duplicateValidator,
+		duplicateValidator:  duplicateValidator,
+		quotaValidator:      quotaValidator,
+		suspensionValidator: suspensionValidator,
 	}
 }
 
@@ -40,6 +48,14 @@ func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 func (v *Validator) ValidateCreate(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) (admission.Warnings, error) {
+	if err := v.suspensionValidator.ValidateWrite(ctx, serviceInstance.Namespace); err != nil {
+		return nil, err
+	}
+
+	if err := v.quotaValidator.ValidateServiceInstanceCreate(ctx, serviceInstance); err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfserviceinstancelog, serviceInstance.Namespace, serviceInstance)
 }
 
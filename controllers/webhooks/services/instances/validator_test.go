@@ -24,11 +24,13 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 	)
 
 	var (
-		ctx                context.Context
-		duplicateValidator *fake.NameValidator
-		serviceInstance    *korifiv1alpha1.CFServiceInstance
-		validatingWebhook  *instances.Validator
-		retErr             error
+		ctx                 context.Context
+		duplicateValidator  *fake.NameValidator
+		quotaValidator      *fake.QuotaValidator
+		suspensionValidator *fake.SuspensionValidator
+		serviceInstance     *korifiv1alpha1.CFServiceInstance
+		validatingWebhook   *instances.Validator
+		retErr              error
 	)
 
 	BeforeEach(func() {
@@ -50,7 +52,9 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 		}
 
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = instances.NewValidator(duplicateValidator)
+		quotaValidator = new(fake.QuotaValidator)
+		suspensionValidator = new(fake.SuspensionValidator)
+		validatingWebhook = instances.NewValidator(duplicateValidator, quotaValidator, suspensionValidator)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -71,6 +75,36 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("The service instance name is taken: " + serviceInstance.Spec.DisplayName))
 		})
 
+		It("validates the service instance against the quotas", func() {
+			Expect(quotaValidator.ValidateServiceInstanceCreateCallCount()).To(Equal(1))
+			actualContext, actualServiceInstance := quotaValidator.ValidateServiceInstanceCreateArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualServiceInstance).To(Equal(serviceInstance))
+		})
+
+		When("the quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateServiceInstanceCreateReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+			})
+		})
+
+		When("the org is suspended", func() {
+			BeforeEach(func() {
+				suspensionValidator.ValidateWriteReturns(errors.New("org-suspended"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("org-suspended"))
+				_, actualNamespace := suspensionValidator.ValidateWriteArgsForCall(0)
+				Expect(actualNamespace).To(Equal(serviceInstance.Namespace))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+			})
+		})
+
 		When("the serviceInstance name is a duplicate", func() {
 			BeforeEach(func() {
 				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
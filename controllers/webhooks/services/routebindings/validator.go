@@ -0,0 +1,75 @@
+package routebindings
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	validation "code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const ServiceRouteBindingEntityType = "serviceroutebinding"
+
+// log is for logging in this package.
+var cfserviceroutebindinglog = logf.Log.WithName("cfserviceroutebinding-validator")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfserviceroutebinding,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfserviceroutebindings,verbs=create;update;delete,versions=v1alpha1,name=vcfserviceroutebinding.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &korifiv1alpha1.CFServiceRouteBinding{}).
+		WithValidator(v).
+		Complete()
+}
+
+type Validator struct {
+	duplicateValidator  webhooks.NameValidator
+	suspensionValidator webhooks.SuspensionValidator
+}
+
+var _ admission.Validator[*korifiv1alpha1.CFServiceRouteBinding] = &Validator{}
+
+func NewValidator(duplicateValidator webhooks.NameValidator, suspensionValidator webhooks.SuspensionValidator) *Validator {
+	return &Validator{
+		duplicateValidator:  duplicateValidator,
+		suspensionValidator: suspensionValidator,
+	}
+}
+
+func (v *Validator) ValidateCreate(ctx context.Context, routeBinding *korifiv1alpha1.CFServiceRouteBinding) (admission.Warnings, error) {
+	if err := v.suspensionValidator.ValidateWrite(ctx, routeBinding.Namespace); err != nil {
+		return nil, err
+	}
+
+	return nil, v.duplicateValidator.ValidateCreate(ctx, cfserviceroutebindinglog, routeBinding.Namespace, routeBinding)
+}
+
+func (v *Validator) ValidateUpdate(ctx context.Context, oldRouteBinding, routeBinding *korifiv1alpha1.CFServiceRouteBinding) (admission.Warnings, error) {
+	if !routeBinding.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	immutableError := validation.ValidationError{
+		Type: validation.ImmutableFieldErrorType,
+	}
+
+	if oldRouteBinding.Spec.RouteRef.Name != routeBinding.Spec.RouteRef.Name {
+		immutableError.Message = fmt.Sprintf(validation.ImmutableFieldErrorMessageTemplate, "CFServiceRouteBinding.Spec.RouteRef.Name")
+		return nil, immutableError.ExportJSONError()
+	}
+
+	if oldRouteBinding.Spec.ServiceInstanceRef.Name != routeBinding.Spec.ServiceInstanceRef.Name {
+		immutableError.Message = fmt.Sprintf(validation.ImmutableFieldErrorMessageTemplate, "CFServiceRouteBinding.Spec.ServiceInstanceRef.Name")
+		return nil, immutableError.ExportJSONError()
+	}
+
+	return nil, nil
+}
+
+func (v *Validator) ValidateDelete(ctx context.Context, routeBinding *korifiv1alpha1.CFServiceRouteBinding) (admission.Warnings, error) {
+	return nil, v.duplicateValidator.ValidateDelete(ctx, cfserviceroutebindinglog, routeBinding.Namespace, routeBinding)
+}
@@ -0,0 +1,173 @@
+package routebindings_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/services/routebindings"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFServiceRouteBindingValidatingWebhook", func() {
+	const defaultNamespace = "default"
+
+	var (
+		ctx                 context.Context
+		routeGUID           string
+		duplicateValidator  *fake.NameValidator
+		suspensionValidator *fake.SuspensionValidator
+		routeBinding        *korifiv1alpha1.CFServiceRouteBinding
+		validatingWebhook   *routebindings.Validator
+		retErr              error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		routeGUID = uuid.NewString()
+		routeBinding = &korifiv1alpha1.CFServiceRouteBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: defaultNamespace,
+			},
+			Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+				RouteRef:           v1.LocalObjectReference{Name: routeGUID},
+				ServiceInstanceRef: v1.LocalObjectReference{Name: uuid.NewString()},
+			},
+		}
+
+		duplicateValidator = new(fake.NameValidator)
+		suspensionValidator = new(fake.SuspensionValidator)
+		validatingWebhook = routebindings.NewValidator(duplicateValidator, suspensionValidator)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, routeBinding)
+		})
+
+		It("allows the creation of the route binding", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("validates the space is not suspended", func() {
+			Expect(suspensionValidator.ValidateWriteCallCount()).To(Equal(1))
+			_, actualNamespace := suspensionValidator.ValidateWriteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(defaultNamespace))
+		})
+
+		When("the space is suspended", func() {
+			BeforeEach(func() {
+				suspensionValidator.ValidateWriteReturns(errors.New("suspended"))
+			})
+
+			It("prevents the creation of the route binding", func() {
+				Expect(retErr).To(MatchError("suspended"))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(0))
+			})
+		})
+
+		It("tries to create a lock for the route", func() {
+			Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+			_, _, actualNamespace, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+			Expect(actualNamespace).To(Equal(defaultNamespace))
+			Expect(actualResource).To(Equal(routeBinding))
+			Expect(actualResource.UniqueName()).To(Equal("srb::" + defaultNamespace + "::" + routeGUID))
+			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("A route may only be bound to a single service instance"))
+		})
+
+		When("the route is already bound", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
+			})
+
+			It("prevents the creation of the route binding", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var updatedRouteBinding *korifiv1alpha1.CFServiceRouteBinding
+
+		BeforeEach(func() {
+			updatedRouteBinding = routeBinding.DeepCopy()
+			updatedRouteBinding.Labels = map[string]string{"foo": "bar"}
+		})
+
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateUpdate(ctx, routeBinding, updatedRouteBinding)
+		})
+
+		It("allows the metadata to change", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		When("the route changes", func() {
+			BeforeEach(func() {
+				updatedRouteBinding.Spec.RouteRef.Name = "another-route"
+			})
+
+			It("does not allow the change", func() {
+				Expect(retErr).To(matchers.BeValidationError(validation.ImmutableFieldErrorType, Equal("'CFServiceRouteBinding.Spec.RouteRef.Name' field is immutable")))
+			})
+
+			When("the route binding is being deleted", func() {
+				BeforeEach(func() {
+					updatedRouteBinding.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				})
+
+				It("does not return an error", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		When("the service instance changes", func() {
+			BeforeEach(func() {
+				updatedRouteBinding.Spec.ServiceInstanceRef.Name = "another-instance"
+			})
+
+			It("does not allow the change", func() {
+				Expect(retErr).To(matchers.BeValidationError(validation.ImmutableFieldErrorType, Equal("'CFServiceRouteBinding.Spec.ServiceInstanceRef.Name' field is immutable")))
+			})
+		})
+	})
+
+	Describe("ValidateDelete", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateDelete(ctx, routeBinding)
+		})
+
+		It("allows the deletion of the route binding", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("releases the lock for the route", func() {
+			Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
+			_, _, actualNamespace, actualResource := duplicateValidator.ValidateDeleteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(defaultNamespace))
+			Expect(actualResource).To(Equal(routeBinding))
+		})
+
+		When("the lock cannot be released", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateDeleteReturns(errors.New("foo"))
+			})
+
+			It("prevents the deletion of the route binding", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+	})
+})
@@ -0,0 +1,470 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	QuotaExceededErrorType = "QuotaExceededError"
+
+	TotalMemoryQuotaExceededErrorMessage      = "You have exceeded your %s's memory limit: app requested more memory than available"
+	PerProcessMemoryQuotaExceededErrorMessage = "You have exceeded the instance memory limit for your %s's quota"
+	TotalInstancesQuotaExceededErrorMessage   = "You have exceeded the instance limit for your %s's quota"
+	PerAppTasksQuotaExceededErrorMessage      = "You have exceeded the app tasks limit for your %s's quota"
+	TotalRoutesQuotaExceededErrorMessage      = "You have exceeded the total routes for your %s's quota"
+	TotalServicesQuotaExceededErrorMessage    = "You have exceeded your %s's services limit"
+	PaidServicesNotAllowedErrorMessage        = "Paid service plans are not allowed by your %s's quota"
+
+	orgQuotaScope   = "organization"
+	spaceQuotaScope = "space"
+)
+
+var quotalog = logf.Log.WithName("quota-validation")
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgquotas;cfspacequotas,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+type QuotaValidator struct {
+	client        client.Client
+	rootNamespace string
+}
+
+func NewQuotaValidator(client client.Client, rootNamespace string) *QuotaValidator {
+	return &QuotaValidator{client: client, rootNamespace: rootNamespace}
+}
+
+// quotaScope is a quota applying to a space, together with the namespaces
+// whose resources count towards it
+type quotaScope struct {
+	name       string
+	limits     korifiv1alpha1.QuotaLimits
+	namespaces []string
+}
+
+type appsUsage struct {
+	memoryMB  int64
+	instances int64
+}
+
+func (u appsUsage) add(other appsUsage) appsUsage {
+	return appsUsage{
+		memoryMB:  u.memoryMB + other.memoryMB,
+		instances: u.instances + other.instances,
+	}
+}
+
+func (v *QuotaValidator) ValidateAppStart(ctx context.Context, app *korifiv1alpha1.CFApp) error {
+	return exportQuotaError(v.validateAppStart(ctx, app))
+}
+
+func (v *QuotaValidator) validateAppStart(ctx context.Context, app *korifiv1alpha1.CFApp) error {
+	scopes, err := v.quotaScopes(ctx, app.Namespace)
+	if err != nil || len(scopes) == 0 {
+		return err
+	}
+
+	processes := &korifiv1alpha1.CFProcessList{}
+	if err = v.client.List(ctx, processes, client.InNamespace(app.Namespace)); err != nil {
+		return fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	belongsToApp := func(process korifiv1alpha1.CFProcess) bool {
+		return process.Namespace == app.Namespace && process.Spec.AppRef.Name == app.Name
+	}
+
+	requested := appsUsage{}
+	for _, process := range processes.Items {
+		if belongsToApp(process) {
+			requested = requested.add(processUsage(process))
+		}
+	}
+
+	for _, scope := range scopes {
+		for _, process := range processes.Items {
+			if belongsToApp(process) {
+				if err = checkPerProcessMemory(scope, process.Spec.MemoryMB); err != nil {
+					return err
+				}
+			}
+		}
+
+		usage, err := v.appsUsage(ctx, scope.namespaces, belongsToApp)
+		if err != nil {
+			return err
+		}
+
+		if err = checkAppsUsage(scope, usage.add(requested)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *QuotaValidator) ValidateProcessScale(ctx context.Context, oldProcess, process *korifiv1alpha1.CFProcess) error {
+	return exportQuotaError(v.validateProcessScale(ctx, oldProcess, process))
+}
+
+func (v *QuotaValidator) validateProcessScale(ctx context.Context, oldProcess, process *korifiv1alpha1.CFProcess) error {
+	if oldProcess != nil && processInstances(*process) <= processInstances(*oldProcess) && process.Spec.MemoryMB <= oldProcess.Spec.MemoryMB {
+		return nil
+	}
+
+	scopes, err := v.quotaScopes(ctx, process.Namespace)
+	if err != nil || len(scopes) == 0 {
+		return err
+	}
+
+	for _, scope := range scopes {
+		if err = checkPerProcessMemory(scope, process.Spec.MemoryMB); err != nil {
+			return err
+		}
+	}
+
+	app := &korifiv1alpha1.CFApp{}
+	err = v.client.Get(ctx, types.NamespacedName{Namespace: process.Namespace, Name: process.Spec.AppRef.Name}, app)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get app: %w", err)
+	}
+
+	if app.Spec.DesiredState != korifiv1alpha1.StartedState {
+		return nil
+	}
+
+	for _, scope := range scopes {
+		usage, err := v.appsUsage(ctx, scope.namespaces, func(p korifiv1alpha1.CFProcess) bool {
+			return p.Namespace == process.Namespace && p.Name == process.Name
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = checkAppsUsage(scope, usage.add(processUsage(*process))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *QuotaValidator) ValidateTaskCreate(ctx context.Context, task *korifiv1alpha1.CFTask, memoryMB int64) error {
+	return exportQuotaError(v.validateTaskCreate(ctx, task, memoryMB))
+}
+
+func (v *QuotaValidator) validateTaskCreate(ctx context.Context, task *korifiv1alpha1.CFTask, memoryMB int64) error {
+	scopes, err := v.quotaScopes(ctx, task.Namespace)
+	if err != nil || len(scopes) == 0 {
+		return err
+	}
+
+	tasks := &korifiv1alpha1.CFTaskList{}
+	if err = v.client.List(ctx, tasks, client.InNamespace(task.Namespace)); err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	var runningAppTasks int64
+	for _, t := range tasks.Items {
+		if t.Spec.AppRef.Name == task.Spec.AppRef.Name && isTaskRunning(t) {
+			runningAppTasks++
+		}
+	}
+
+	for _, scope := range scopes {
+		if err = checkPerProcessMemory(scope, memoryMB); err != nil {
+			return err
+		}
+
+		if exceeds(runningAppTasks+1, scope.limits.Apps.PerAppTasks) {
+			return quotaExceededError(PerAppTasksQuotaExceededErrorMessage, scope)
+		}
+
+		usage, err := v.appsUsage(ctx, scope.namespaces, func(korifiv1alpha1.CFProcess) bool { return false })
+		if err != nil {
+			return err
+		}
+
+		if err = checkAppsUsage(scope, usage.add(appsUsage{memoryMB: memoryMB})); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *QuotaValidator) ValidateRouteCreate(ctx context.Context, route *korifiv1alpha1.CFRoute) error {
+	return exportQuotaError(v.validateRouteCreate(ctx, route))
+}
+
+func (v *QuotaValidator) validateRouteCreate(ctx context.Context, route *korifiv1alpha1.CFRoute) error {
+	scopes, err := v.quotaScopes(ctx, route.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, scope := range scopes {
+		if scope.limits.Routes.TotalRoutes == nil {
+			continue
+		}
+
+		var routesCount int64
+		for _, ns := range scope.namespaces {
+			routes := &korifiv1alpha1.CFRouteList{}
+			if err = v.client.List(ctx, routes, client.InNamespace(ns)); err != nil {
+				return fmt.Errorf("failed to list routes: %w", err)
+			}
+			routesCount += int64(len(routes.Items))
+		}
+
+		if exceeds(routesCount+1, scope.limits.Routes.TotalRoutes) {
+			return quotaExceededError(TotalRoutesQuotaExceededErrorMessage, scope)
+		}
+	}
+
+	return nil
+}
+
+func (v *QuotaValidator) ValidateServiceInstanceCreate(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	return exportQuotaError(v.validateServiceInstanceCreate(ctx, serviceInstance))
+}
+
+func (v *QuotaValidator) validateServiceInstanceCreate(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	scopes, err := v.quotaScopes(ctx, serviceInstance.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, scope := range scopes {
+		if !scope.limits.Services.PaidServicesAllowed && serviceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+			paid, err := v.isPaidPlan(ctx, serviceInstance.Spec.PlanGUID)
+			if err != nil {
+				return err
+			}
+
+			if paid {
+				return quotaExceededError(PaidServicesNotAllowedErrorMessage, scope)
+			}
+		}
+
+		if scope.limits.Services.TotalServiceInstances == nil {
+			continue
+		}
+
+		var instancesCount int64
+		for _, ns := range scope.namespaces {
+			instances := &korifiv1alpha1.CFServiceInstanceList{}
+			if err = v.client.List(ctx, instances, client.InNamespace(ns)); err != nil {
+				return fmt.Errorf("failed to list service instances: %w", err)
+			}
+			instancesCount += int64(len(instances.Items))
+		}
+
+		if exceeds(instancesCount+1, scope.limits.Services.TotalServiceInstances) {
+			return quotaExceededError(TotalServicesQuotaExceededErrorMessage, scope)
+		}
+	}
+
+	return nil
+}
+
+func (v *QuotaValidator) isPaidPlan(ctx context.Context, planGUID string) (bool, error) {
+	plan := &korifiv1alpha1.CFServicePlan{}
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: v.rootNamespace, Name: planGUID}, plan)
+	if err != nil {
+		return false, fmt.Errorf("failed to get service plan: %w", err)
+	}
+
+	return !plan.Spec.Free, nil
+}
+
+// quotaScopes returns the space quota and the org quota applied to the space
+// with the given namespace, if any
+func (v *QuotaValidator) quotaScopes(ctx context.Context, spaceNamespace string) ([]quotaScope, error) {
+	namespace := &corev1.Namespace{}
+	if err := v.client.Get(ctx, types.NamespacedName{Name: spaceNamespace}, namespace); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %q: %w", spaceNamespace, err)
+	}
+
+	orgGUID := namespace.Labels[korifiv1alpha1.CFOrgGUIDKey]
+	if orgGUID == "" {
+		return nil, nil
+	}
+
+	scopes := []quotaScope{}
+
+	spaceQuotas := &korifiv1alpha1.CFSpaceQuotaList{}
+	if err := v.client.List(ctx, spaceQuotas, client.InNamespace(orgGUID)); err != nil {
+		return nil, fmt.Errorf("failed to list space quotas: %w", err)
+	}
+
+	for _, spaceQuota := range spaceQuotas.Items {
+		if slices.Contains(spaceQuota.Spec.Spaces, spaceNamespace) {
+			scopes = append(scopes, quotaScope{
+				name:       spaceQuotaScope,
+				limits:     spaceQuota.Spec.QuotaLimits,
+				namespaces: []string{spaceNamespace},
+			})
+			break
+		}
+	}
+
+	orgQuotas := &korifiv1alpha1.CFOrgQuotaList{}
+	if err := v.client.List(ctx, orgQuotas, client.InNamespace(v.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list org quotas: %w", err)
+	}
+
+	for _, orgQuota := range orgQuotas.Items {
+		if !slices.Contains(orgQuota.Spec.Orgs, orgGUID) {
+			continue
+		}
+
+		spaces := &korifiv1alpha1.CFSpaceList{}
+		if err := v.client.List(ctx, spaces, client.InNamespace(orgGUID)); err != nil {
+			return nil, fmt.Errorf("failed to list spaces: %w", err)
+		}
+
+		spaceNamespaces := []string{}
+		for _, space := range spaces.Items {
+			spaceNamespaces = append(spaceNamespaces, space.Name)
+		}
+
+		scopes = append(scopes, quotaScope{
+			name:       orgQuotaScope,
+			limits:     orgQuota.Spec.QuotaLimits,
+			namespaces: spaceNamespaces,
+		})
+		break
+	}
+
+	return scopes, nil
+}
+
+// appsUsage sums up the memory and instances of the processes of started apps
+// and the memory of running tasks in the given namespaces
+func (v *QuotaValidator) appsUsage(ctx context.Context, namespaces []string, ignoreProcess func(korifiv1alpha1.CFProcess) bool) (appsUsage, error) {
+	usage := appsUsage{}
+
+	for _, ns := range namespaces {
+		apps := &korifiv1alpha1.CFAppList{}
+		if err := v.client.List(ctx, apps, client.InNamespace(ns)); err != nil {
+			return appsUsage{}, fmt.Errorf("failed to list apps: %w", err)
+		}
+
+		startedApps := map[string]bool{}
+		for _, app := range apps.Items {
+			startedApps[app.Name] = app.Spec.DesiredState == korifiv1alpha1.StartedState
+		}
+
+		processes := &korifiv1alpha1.CFProcessList{}
+		if err := v.client.List(ctx, processes, client.InNamespace(ns)); err != nil {
+			return appsUsage{}, fmt.Errorf("failed to list processes: %w", err)
+		}
+
+		for _, process := range processes.Items {
+			if startedApps[process.Spec.AppRef.Name] && !ignoreProcess(process) {
+				usage = usage.add(processUsage(process))
+			}
+		}
+
+		tasks := &korifiv1alpha1.CFTaskList{}
+		if err := v.client.List(ctx, tasks, client.InNamespace(ns)); err != nil {
+			return appsUsage{}, fmt.Errorf("failed to list tasks: %w", err)
+		}
+
+		for _, task := range tasks.Items {
+			if isTaskRunning(task) {
+				usage.memoryMB += task.Status.MemoryMB
+			}
+		}
+	}
+
+	return usage, nil
+}
+
+func checkAppsUsage(scope quotaScope, usage appsUsage) error {
+	if exceeds(usage.memoryMB, scope.limits.Apps.TotalMemoryInMB) {
+		return quotaExceededError(TotalMemoryQuotaExceededErrorMessage, scope)
+	}
+
+	if exceeds(usage.instances, scope.limits.Apps.TotalInstances) {
+		return quotaExceededError(TotalInstancesQuotaExceededErrorMessage, scope)
+	}
+
+	return nil
+}
+
+func checkPerProcessMemory(scope quotaScope, memoryMB int64) error {
+	if exceeds(memoryMB, scope.limits.Apps.PerProcessMemoryInMB) {
+		return quotaExceededError(PerProcessMemoryQuotaExceededErrorMessage, scope)
+	}
+
+	return nil
+}
+
+func exceeds[T int32 | int64](value int64, limit *T) bool {
+	return limit != nil && value > int64(*limit)
+}
+
+// processInstances returns the number of instances the process may run. The
+// instances of autoscaled processes count towards the quotas with their
+// maximum, as the autoscaler may scale them up to it at any time.
+func processInstances(process korifiv1alpha1.CFProcess) int64 {
+	instances := tools.ZeroIfNil(process.Spec.DesiredInstances)
+	if process.Spec.Autoscaling != nil {
+		instances = max(instances, process.Spec.Autoscaling.MaxInstances)
+	}
+
+	return int64(instances)
+}
+
+func processUsage(process korifiv1alpha1.CFProcess) appsUsage {
+	instances := processInstances(process)
+	return appsUsage{
+		memoryMB:  instances * process.Spec.MemoryMB,
+		instances: instances,
+	}
+}
+
+func isTaskRunning(task korifiv1alpha1.CFTask) bool {
+	return !meta.IsStatusConditionTrue(task.Status.Conditions, korifiv1alpha1.TaskSucceededConditionType) &&
+		!meta.IsStatusConditionTrue(task.Status.Conditions, korifiv1alpha1.TaskFailedConditionType) &&
+		!meta.IsStatusConditionTrue(task.Status.Conditions, korifiv1alpha1.TaskCanceledConditionType)
+}
+
+func quotaExceededError(messageTemplate string, scope quotaScope) error {
+	return ValidationError{
+		Type:    QuotaExceededErrorType,
+		Message: fmt.Sprintf(messageTemplate, scope.name),
+	}
+}
+
+func exportQuotaError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var validationErr ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.ExportJSONError()
+	}
+
+	quotalog.Info("quota validation failed", "reason", err)
+	return ValidationError{
+		Type:    UnknownErrorType,
+		Message: UnknownErrorMessage,
+	}.ExportJSONError()
+}
@@ -0,0 +1,645 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("QuotaValidator", func() {
+	const (
+		rootNamespace = "cf"
+		orgGUID       = "org-guid"
+		spaceGUID     = "space-guid"
+		otherSpace    = "other-space-guid"
+	)
+
+	var (
+		ctx            context.Context
+		fakeClient     *fake.Client
+		quotaValidator *validation.QuotaValidator
+		validationErr  error
+
+		apps             []korifiv1alpha1.CFApp
+		processes        []korifiv1alpha1.CFProcess
+		tasks            []korifiv1alpha1.CFTask
+		routes           []korifiv1alpha1.CFRoute
+		serviceInstances []korifiv1alpha1.CFServiceInstance
+		servicePlan      korifiv1alpha1.CFServicePlan
+		orgQuotas        []korifiv1alpha1.CFOrgQuota
+		spaceQuotas      []korifiv1alpha1.CFSpaceQuota
+		listErr          error
+	)
+
+	inNamespace := func(ns string, opts []client.ListOption) bool {
+		listOpts := &client.ListOptions{}
+		listOpts.ApplyOptions(opts)
+		return listOpts.Namespace == ns
+	}
+
+	newApp := func(ns, name string, state korifiv1alpha1.AppState) korifiv1alpha1.CFApp {
+		return korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+			Spec:       korifiv1alpha1.CFAppSpec{DesiredState: state},
+		}
+	}
+
+	newProcess := func(ns, name, appName string, instances int32, memoryMB int64) korifiv1alpha1.CFProcess {
+		return korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:           corev1.LocalObjectReference{Name: appName},
+				DesiredInstances: tools.PtrTo(instances),
+				MemoryMB:         memoryMB,
+			},
+		}
+	}
+
+	newTask := func(ns, name, appName string, memoryMB int64) korifiv1alpha1.CFTask {
+		return korifiv1alpha1.CFTask{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+			Spec: korifiv1alpha1.CFTaskSpec{
+				AppRef: corev1.LocalObjectReference{Name: appName},
+			},
+			Status: korifiv1alpha1.CFTaskStatus{MemoryMB: memoryMB},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		listErr = nil
+
+		apps = []korifiv1alpha1.CFApp{
+			newApp(spaceGUID, "started-app", korifiv1alpha1.StartedState),
+			newApp(spaceGUID, "stopped-app", korifiv1alpha1.StoppedState),
+			newApp(otherSpace, "other-app", korifiv1alpha1.StartedState),
+		}
+		processes = []korifiv1alpha1.CFProcess{
+			newProcess(spaceGUID, "started-web", "started-app", 2, 256),
+			newProcess(spaceGUID, "stopped-web", "stopped-app", 2, 512),
+			newProcess(otherSpace, "other-web", "other-app", 1, 1024),
+		}
+		tasks = []korifiv1alpha1.CFTask{
+			newTask(spaceGUID, "running-task", "started-app", 128),
+		}
+		routes = []korifiv1alpha1.CFRoute{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "route"}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: otherSpace, Name: "other-route"}},
+		}
+		serviceInstances = []korifiv1alpha1.CFServiceInstance{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "instance"}},
+		}
+		servicePlan = korifiv1alpha1.CFServicePlan{
+			ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: "plan-guid"},
+			Spec:       korifiv1alpha1.CFServicePlanSpec{Free: false},
+		}
+		orgQuotas = nil
+		spaceQuotas = nil
+
+		fakeClient = new(fake.Client)
+		fakeClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *corev1.Namespace:
+				labels := map[string]string{}
+				if key.Name != "not-a-space" {
+					labels[korifiv1alpha1.CFOrgGUIDKey] = orgGUID
+				}
+				obj.Name = key.Name
+				obj.Labels = labels
+				return nil
+			case *korifiv1alpha1.CFApp:
+				for _, app := range apps {
+					if app.Namespace == key.Namespace && app.Name == key.Name {
+						app.DeepCopyInto(obj)
+						return nil
+					}
+				}
+				return k8serrors.NewNotFound(schema.GroupResource{}, key.Name)
+			case *korifiv1alpha1.CFServicePlan:
+				servicePlan.DeepCopyInto(obj)
+				return nil
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+		}
+
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			if listErr != nil {
+				return listErr
+			}
+
+			switch list := list.(type) {
+			case *korifiv1alpha1.CFOrgQuotaList:
+				if inNamespace(rootNamespace, opts) {
+					list.Items = orgQuotas
+				}
+			case *korifiv1alpha1.CFSpaceQuotaList:
+				if inNamespace(orgGUID, opts) {
+					list.Items = spaceQuotas
+				}
+			case *korifiv1alpha1.CFSpaceList:
+				if inNamespace(orgGUID, opts) {
+					list.Items = []korifiv1alpha1.CFSpace{
+						{ObjectMeta: metav1.ObjectMeta{Namespace: orgGUID, Name: spaceGUID}},
+						{ObjectMeta: metav1.ObjectMeta{Namespace: orgGUID, Name: otherSpace}},
+					}
+				}
+			case *korifiv1alpha1.CFAppList:
+				for _, app := range apps {
+					if inNamespace(app.Namespace, opts) {
+						list.Items = append(list.Items, app)
+					}
+				}
+			case *korifiv1alpha1.CFProcessList:
+				for _, process := range processes {
+					if inNamespace(process.Namespace, opts) {
+						list.Items = append(list.Items, process)
+					}
+				}
+			case *korifiv1alpha1.CFTaskList:
+				for _, task := range tasks {
+					if inNamespace(task.Namespace, opts) {
+						list.Items = append(list.Items, task)
+					}
+				}
+			case *korifiv1alpha1.CFRouteList:
+				for _, route := range routes {
+					if inNamespace(route.Namespace, opts) {
+						list.Items = append(list.Items, route)
+					}
+				}
+			case *korifiv1alpha1.CFServiceInstanceList:
+				for _, serviceInstance := range serviceInstances {
+					if inNamespace(serviceInstance.Namespace, opts) {
+						list.Items = append(list.Items, serviceInstance)
+					}
+				}
+			default:
+				panic("TestClient List provided an unexpected list type")
+			}
+
+			return nil
+		}
+
+		quotaValidator = validation.NewQuotaValidator(fakeClient, rootNamespace)
+	})
+
+	setSpaceQuota := func(limits korifiv1alpha1.QuotaLimits) {
+		spaceQuotas = []korifiv1alpha1.CFSpaceQuota{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: orgGUID, Name: "space-quota"},
+			Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+				DisplayName: "space-quota",
+				QuotaLimits: limits,
+				Spaces:      []string{spaceGUID},
+			},
+		}}
+	}
+
+	setOrgQuota := func(limits korifiv1alpha1.QuotaLimits) {
+		orgQuotas = []korifiv1alpha1.CFOrgQuota{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: "org-quota"},
+			Spec: korifiv1alpha1.CFOrgQuotaSpec{
+				DisplayName: "org-quota",
+				QuotaLimits: limits,
+				Orgs:        []string{orgGUID},
+			},
+		}}
+	}
+
+	Describe("ValidateAppStart", func() {
+		JustBeforeEach(func() {
+			app := newApp(spaceGUID, "stopped-app", korifiv1alpha1.StartedState)
+			validationErr = quotaValidator.ValidateAppStart(ctx, &app)
+		})
+
+		It("succeeds when no quota is applied", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the space quota memory is sufficient", func() {
+			BeforeEach(func() {
+				// started-web: 2*256, running task: 128, stopped-web: 2*512
+				setSpaceQuota(korifiv1alpha1.QuotaLimits{
+					Apps: korifiv1alpha1.QuotaAppsLimits{TotalMemoryInMB: tools.PtrTo[int64](1664)},
+				})
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("starting the app exceeds the space memory quota", func() {
+			BeforeEach(func() {
+				setSpaceQuota(korifiv1alpha1.QuotaLimits{
+					Apps: korifiv1alpha1.QuotaAppsLimits{TotalMemoryInMB: tools.PtrTo[int64](1663)},
+				})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("You have exceeded your space's memory limit: app requested more memory than available"),
+				))
+			})
+		})
+
+		When("starting the app exceeds the org memory quota", func() {
+			BeforeEach(func() {
+				// the other space uses 1024MB
+				setOrgQuota(korifiv1alpha1.QuotaLimits{
+					Apps: korifiv1alpha1.QuotaAppsLimits{TotalMemoryInMB: tools.PtrTo[int64](2000)},
+				})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("You have exceeded your organization's memory limit: app requested more memory than available"),
+				))
+			})
+		})
+
+		When("starting the app exceeds the instances quota", func() {
+			BeforeEach(func() {
+				setSpaceQuota(korifiv1alpha1.QuotaLimits{
+					Apps: korifiv1alpha1.QuotaAppsLimits{TotalInstances: tools.PtrTo[int32](3)},
+				})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("You have exceeded the instance limit for your space's quota"),
+				))
+			})
+		})
+
+		When("the app process memory exceeds the per process memory quota", func() {
+			BeforeEach(func() {
+				setOrgQuota(korifiv1alpha1.QuotaLimits{
+					Apps: korifiv1alpha1.QuotaAppsLimits{PerProcessMemoryInMB: tools.PtrTo[int64](256)},
+				})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("You have exceeded the instance memory limit for your organization's quota"),
+				))
+			})
+		})
+
+		When("the namespace does not belong to an org", func() {
+			JustBeforeEach(func() {
+				app := newApp("not-a-space", "app", korifiv1alpha1.StartedState)
+				validationErr = quotaValidator.ValidateAppStart(ctx, &app)
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("listing quotas fails", func() {
+			BeforeEach(func() {
+				listErr = errors.New("list-err")
+			})
+
+			It("returns an unknown error", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.UnknownErrorType,
+					Equal(validation.UnknownErrorMessage),
+				))
+			})
+		})
+	})
+
+	Describe("ValidateProcessScale", func() {
+		var (
+			oldProcess *korifiv1alpha1.CFProcess
+			process    korifiv1alpha1.CFProcess
+		)
+
+		BeforeEach(func() {
+			oldProcess = tools.PtrTo(newProcess(spaceGUID, "started-web", "started-app", 2, 256))
+			process = newProcess(spaceGUID, "started-web", "started-app", 4, 256)
+
+			setSpaceQuota(korifiv1alpha1.QuotaLimits{
+				Apps: korifiv1alpha1.QuotaAppsLimits{TotalMemoryInMB: tools.PtrTo[int64](1152)},
+			})
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateProcessScale(ctx, oldProcess, &process)
+		})
+
+		It("succeeds", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("scaling exceeds the memory quota", func() {
+			BeforeEach(func() {
+				process.Spec.DesiredInstances = tools.PtrTo[int32](5)
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("You have exceeded your space's memory limit: app requested more memory than available"),
+				))
+			})
+		})
+
+		When("an autoscaling policy is set", func() {
+			BeforeEach(func() {
+				process = newProcess(spaceGUID, "started-web", "started-app", 2, 256)
+				process.Spec.Autoscaling = &korifiv1alpha1.ProcessAutoscaling{MinInstances: 1, MaxInstances: 4}
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+
+			When("the maximum instances exceed the space memory quota", func() {
+				BeforeEach(func() {
+					process.Spec.Autoscaling.MaxInstances = 5
+				})
+
+				It("fails", func() {
+					Expect(validationErr).To(matchers.BeValidationError(
+						validation.QuotaExceededErrorType,
+						Equal("You have exceeded your space's memory limit: app requested more memory than available"),
+					))
+				})
+			})
+
+			When("the maximum instances exceed the org memory quota", func() {
+				BeforeEach(func() {
+					setOrgQuota(korifiv1alpha1.QuotaLimits{
+						Apps: korifiv1alpha1.QuotaAppsLimits{TotalMemoryInMB: tools.PtrTo[int64](2048)},
+					})
+				})
+
+				It("fails", func() {
+					Expect(validationErr).To(matchers.BeValidationError(
+						validation.QuotaExceededErrorType,
+						Equal("You have exceeded your organization's memory limit: app requested more memory than available"),
+					))
+				})
+			})
+
+			When("the maximum instances exceed the instances quota", func() {
+				BeforeEach(func() {
+					setSpaceQuota(korifiv1alpha1.QuotaLimits{
+						Apps: korifiv1alpha1.QuotaAppsLimits{TotalInstances: tools.PtrTo[int32](3)},
+					})
+				})
+
+				It("fails", func() {
+					Expect(validationErr).To(matchers.BeValidationError(
+						validation.QuotaExceededErrorType,
+						Equal("You have exceeded the instance limit for your space's quota"),
+					))
+				})
+			})
+		})
+
+		When("another process of the space is autoscaled", func() {
+			BeforeEach(func() {
+				processes = append(processes, newProcess(spaceGUID, "started-worker", "started-app", 1, 128))
+				processes[len(processes)-1].Spec.Autoscaling = &korifiv1alpha1.ProcessAutoscaling{MinInstances: 1, MaxInstances: 2}
+			})
+
+			It("counts its maximum instances towards the quota", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("You have exceeded your space's memory limit: app requested more memory than available"),
+				))
+			})
+		})
+
+		When("the process is scaled down", func() {
+			BeforeEach(func() {
+				setSpaceQuota(korifiv1alpha1.QuotaLimits{
+					Apps: korifiv1alpha1.QuotaAppsLimits{TotalMemoryInMB: tools.PtrTo[int64](1)},
+				})
+				process.Spec.DesiredInstances = tools.PtrTo[int32](1)
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the app is stopped", func() {
+			BeforeEach(func() {
+				oldProcess = nil
+				process = newProcess(spaceGUID, "stopped-web", "stopped-app", 10, 512)
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+
+			When("the process memory exceeds the per process memory quota", func() {
+				BeforeEach(func() {
+					setSpaceQuota(korifiv1alpha1.QuotaLimits{
+						Apps: korifiv1alpha1.QuotaAppsLimits{PerProcessMemoryInMB: tools.PtrTo[int64](256)},
+					})
+				})
+
+				It("fails", func() {
+					Expect(validationErr).To(matchers.BeValidationError(
+						validation.QuotaExceededErrorType,
+						Equal("You have exceeded the instance memory limit for your space's quota"),
+					))
+				})
+			})
+		})
+	})
+
+	Describe("ValidateTaskCreate", func() {
+		var task korifiv1alpha1.CFTask
+
+		BeforeEach(func() {
+			task = newTask(spaceGUID, "new-task", "started-app", 0)
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateTaskCreate(ctx, &task, 256)
+		})
+
+		It("succeeds", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the app already runs the maximum number of tasks", func() {
+			BeforeEach(func() {
+				setSpaceQuota(korifiv1alpha1.QuotaLimits{
+					Apps: korifiv1alpha1.QuotaAppsLimits{PerAppTasks: tools.PtrTo[int32](1)},
+				})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("You have exceeded the app tasks limit for your space's quota"),
+				))
+			})
+
+			When("the existing task has completed", func() {
+				BeforeEach(func() {
+					tasks[0].Status.Conditions = []metav1.Condition{{
+						Type:   korifiv1alpha1.TaskSucceededConditionType,
+						Status: metav1.ConditionTrue,
+					}}
+				})
+
+				It("succeeds", func() {
+					Expect(validationErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		When("the task exceeds the memory quota", func() {
+			BeforeEach(func() {
+				setSpaceQuota(korifiv1alpha1.QuotaLimits{
+					Apps: korifiv1alpha1.QuotaAppsLimits{TotalMemoryInMB: tools.PtrTo[int64](800)},
+				})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("You have exceeded your space's memory limit: app requested more memory than available"),
+				))
+			})
+		})
+	})
+
+	Describe("ValidateRouteCreate", func() {
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateRouteCreate(ctx, &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "new-route"},
+			})
+		})
+
+		When("the space quota allows more routes", func() {
+			BeforeEach(func() {
+				setSpaceQuota(korifiv1alpha1.QuotaLimits{
+					Routes: korifiv1alpha1.QuotaRoutesLimits{TotalRoutes: tools.PtrTo[int32](2)},
+				})
+			})
+
+			It("succeeds", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the org routes quota is reached", func() {
+			BeforeEach(func() {
+				setOrgQuota(korifiv1alpha1.QuotaLimits{
+					Routes: korifiv1alpha1.QuotaRoutesLimits{TotalRoutes: tools.PtrTo[int32](2)},
+				})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("You have exceeded the total routes for your organization's quota"),
+				))
+			})
+		})
+	})
+
+	Describe("ValidateServiceInstanceCreate", func() {
+		var serviceInstance korifiv1alpha1.CFServiceInstance
+
+		BeforeEach(func() {
+			serviceInstance = korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Namespace: spaceGUID, Name: "new-instance"},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					Type:     korifiv1alpha1.ManagedType,
+					PlanGUID: "plan-guid",
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = quotaValidator.ValidateServiceInstanceCreate(ctx, &serviceInstance)
+		})
+
+		It("succeeds", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("the service instances quota is reached", func() {
+			BeforeEach(func() {
+				setSpaceQuota(korifiv1alpha1.QuotaLimits{
+					Services: korifiv1alpha1.QuotaServicesLimits{
+						PaidServicesAllowed:   true,
+						TotalServiceInstances: tools.PtrTo[int32](1),
+					},
+				})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("You have exceeded your space's services limit"),
+				))
+			})
+		})
+
+		When("paid services are not allowed", func() {
+			BeforeEach(func() {
+				setOrgQuota(korifiv1alpha1.QuotaLimits{
+					Services: korifiv1alpha1.QuotaServicesLimits{PaidServicesAllowed: false},
+				})
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.QuotaExceededErrorType,
+					Equal("Paid service plans are not allowed by your organization's quota"),
+				))
+			})
+
+			When("the plan is free", func() {
+				BeforeEach(func() {
+					servicePlan.Spec.Free = true
+				})
+
+				It("succeeds", func() {
+					Expect(validationErr).NotTo(HaveOccurred())
+				})
+			})
+
+			When("the service instance is user-provided", func() {
+				BeforeEach(func() {
+					serviceInstance.Spec.Type = korifiv1alpha1.UserProvidedType
+				})
+
+				It("succeeds", func() {
+					Expect(validationErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+	})
+})
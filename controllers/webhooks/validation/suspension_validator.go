@@ -0,0 +1,127 @@
+package validation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	OrgSuspendedErrorType    = "OrgSuspendedError"
+	OrgSuspendedErrorMessage = "The organization is suspended"
+)
+
+var suspensionlog = logf.Log.WithName("suspension-validation")
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgs,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups="authorization.k8s.io",resources=subjectaccessreviews,verbs=create
+
+// SuspensionValidator rejects changes to the resources of suspended orgs.
+// Users that are allowed to patch orgs (i.e. admins and the korifi
+// controllers) can still change them
+type SuspensionValidator struct {
+	client        client.Client
+	rootNamespace string
+}
+
+func NewSuspensionValidator(client client.Client, rootNamespace string) *SuspensionValidator {
+	return &SuspensionValidator{client: client, rootNamespace: rootNamespace}
+}
+
+// ValidateWrite accepts either an org or a space namespace
+func (v *SuspensionValidator) ValidateWrite(ctx context.Context, namespace string) error {
+	return exportSuspensionError(v.validateWrite(ctx, namespace))
+}
+
+func (v *SuspensionValidator) validateWrite(ctx context.Context, namespace string) error {
+	ns := &corev1.Namespace{}
+	if err := v.client.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return fmt.Errorf("failed to get namespace %q: %w", namespace, err)
+	}
+
+	orgGUID := ns.Labels[korifiv1alpha1.CFOrgGUIDKey]
+	if orgGUID == "" {
+		return nil
+	}
+
+	org := &korifiv1alpha1.CFOrg{}
+	if err := v.client.Get(ctx, types.NamespacedName{Namespace: v.rootNamespace, Name: orgGUID}, org); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get org %q: %w", orgGUID, err)
+	}
+
+	if !org.Spec.Suspended {
+		return nil
+	}
+
+	canPatchOrgs, err := v.canPatchOrgs(ctx)
+	if err != nil || canPatchOrgs {
+		return err
+	}
+
+	return ValidationError{
+		Type:    OrgSuspendedErrorType,
+		Message: OrgSuspendedErrorMessage,
+	}
+}
+
+func (v *SuspensionValidator) canPatchOrgs(ctx context.Context) (bool, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return false, nil
+	}
+
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range req.UserInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			UID:    req.UserInfo.UID,
+			Groups: req.UserInfo.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: v.rootNamespace,
+				Verb:      "patch",
+				Group:     korifiv1alpha1.SchemeGroupVersion.Group,
+				Resource:  "cforgs",
+			},
+		},
+	}
+	if err = v.client.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("failed to review access of %q: %w", req.UserInfo.Username, err)
+	}
+
+	return review.Status.Allowed, nil
+}
+
+func exportSuspensionError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var validationErr ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.ExportJSONError()
+	}
+
+	suspensionlog.Info("suspension validation failed", "reason", err)
+	return ValidationError{
+		Type:    UnknownErrorType,
+		Message: UnknownErrorMessage,
+	}.ExportJSONError()
+}
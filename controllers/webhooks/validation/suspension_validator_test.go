@@ -0,0 +1,173 @@
+package validation_test
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("SuspensionValidator", func() {
+	const (
+		rootNamespace = "cf"
+		orgGUID       = "org-guid"
+		spaceGUID     = "space-guid"
+	)
+
+	var (
+		ctx                 context.Context
+		fakeClient          *fake.Client
+		suspensionValidator *validation.SuspensionValidator
+		namespace           string
+		org                 *korifiv1alpha1.CFOrg
+		accessAllowed       bool
+		validationErr       error
+	)
+
+	BeforeEach(func() {
+		ctx = admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{
+					Username: "bob",
+					Groups:   []string{"system:authenticated"},
+				},
+			},
+		})
+		namespace = spaceGUID
+		accessAllowed = false
+
+		org = &korifiv1alpha1.CFOrg{
+			Spec: korifiv1alpha1.CFOrgSpec{
+				DisplayName: "my-org",
+				Suspended:   true,
+			},
+		}
+
+		fakeClient = new(fake.Client)
+		fakeClient.GetStub = func(_ context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *corev1.Namespace:
+				obj.Name = key.Name
+				if key.Name != "not-a-cf-namespace" {
+					obj.Labels = map[string]string{korifiv1alpha1.CFOrgGUIDKey: orgGUID}
+				}
+				return nil
+			case *korifiv1alpha1.CFOrg:
+				if org == nil || key.Namespace != rootNamespace || key.Name != orgGUID {
+					return k8serrors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				org.DeepCopyInto(obj)
+				return nil
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+		}
+		fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			review, ok := obj.(*authorizationv1.SubjectAccessReview)
+			if !ok {
+				panic("TestClient Create provided an unexpected object type")
+			}
+			review.Status.Allowed = accessAllowed
+			return nil
+		}
+
+		suspensionValidator = validation.NewSuspensionValidator(fakeClient, rootNamespace)
+	})
+
+	JustBeforeEach(func() {
+		validationErr = suspensionValidator.ValidateWrite(ctx, namespace)
+	})
+
+	It("denies the write", func() {
+		Expect(validationErr).To(matchers.BeValidationError(
+			validation.OrgSuspendedErrorType,
+			Equal(validation.OrgSuspendedErrorMessage),
+		))
+	})
+
+	It("reviews whether the user can patch orgs", func() {
+		Expect(fakeClient.CreateCallCount()).To(Equal(1))
+		_, obj, _ := fakeClient.CreateArgsForCall(0)
+		review, ok := obj.(*authorizationv1.SubjectAccessReview)
+		Expect(ok).To(BeTrue())
+		Expect(review.Spec.User).To(Equal("bob"))
+		Expect(review.Spec.Groups).To(ConsistOf("system:authenticated"))
+		Expect(review.Spec.ResourceAttributes).To(Equal(&authorizationv1.ResourceAttributes{
+			Namespace: rootNamespace,
+			Verb:      "patch",
+			Group:     "korifi.cloudfoundry.org",
+			Resource:  "cforgs",
+		}))
+	})
+
+	When("the user can patch orgs", func() {
+		BeforeEach(func() {
+			accessAllowed = true
+		})
+
+		It("allows the write", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the org is not suspended", func() {
+		BeforeEach(func() {
+			org.Spec.Suspended = false
+		})
+
+		It("allows the write without reviewing access", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+			Expect(fakeClient.CreateCallCount()).To(BeZero())
+		})
+	})
+
+	When("the org does not exist", func() {
+		BeforeEach(func() {
+			org = nil
+		})
+
+		It("allows the write", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("the namespace does not belong to an org", func() {
+		BeforeEach(func() {
+			namespace = "not-a-cf-namespace"
+		})
+
+		It("allows the write", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("reviewing the access fails", func() {
+		BeforeEach(func() {
+			fakeClient.CreateReturns(errors.New("boom"))
+			fakeClient.CreateStub = nil
+		})
+
+		It("returns an unknown error", func() {
+			Expect(validationErr).To(matchers.BeValidationError(
+				validation.UnknownErrorType,
+				Equal(validation.UnknownErrorMessage),
+			))
+		})
+	})
+})
@@ -83,7 +83,11 @@ var _ = BeforeSuite(func() {
 
 	uncachedClient := helpers.NewUncachedClient(k8sManager.GetConfig())
 	appNameDuplicateValidator := validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, apps.AppEntityType))
-	Expect(apps.NewValidator(appNameDuplicateValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(apps.NewValidator(
+		appNameDuplicateValidator,
+		validation.NewQuotaValidator(uncachedClient, "cf"),
+		validation.NewSuspensionValidator(uncachedClient, "cf"),
+	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -23,14 +24,22 @@ var cfapplog = logf.Log.WithName("cfapp-validate")
 //+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfapp,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfapps,verbs=create;update;delete,versions=v1alpha1,name=vcfapp.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
 type Validator struct {
-	duplicateValidator webhooks.NameValidator
+	duplicateValidator  webhooks.NameValidator
+	quotaValidator      webhooks.QuotaValidator
+	suspensionValidator webhooks.SuspensionValidator
 }
 
 var _ admission.Validator[*korifiv1alpha1.CFApp] = &Validator{}
 
-func NewValidator(duplicateValidator webhooks.NameValidator) *Validator {
+func NewValidator(
+	duplicateValidator webhooks.NameValidator,
+	quotaValidator webhooks.QuotaValidator,
+	suspensionValidator webhooks.SuspensionValidator,
+) *Validator {
 	return &Validator{
-		duplicateValidator: duplicateValidator,
+		duplicateValidator:  duplicateValidator,
+		quotaValidator:      quotaValidator,
+		suspensionValidator: suspensionValidator,
 	}
 }
 
@@ -41,6 +50,10 @@ func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 func (v *Validator) ValidateCreate(ctx context.Context, app *korifiv1alpha1.CFApp) (admission.Warnings, error) {
+	if err := v.suspensionValidator.ValidateWrite(ctx, app.Namespace); err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfapplog, app.Namespace, app)
 }
 
@@ -56,6 +69,18 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldApp, app *korifiv1alp
 		}.ExportJSONError()
 	}
 
+	if !equality.Semantic.DeepEqual(app.Spec, oldApp.Spec) {
+		if err := v.suspensionValidator.ValidateWrite(ctx, app.Namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	if app.Spec.DesiredState == korifiv1alpha1.StartedState && oldApp.Spec.DesiredState != korifiv1alpha1.StartedState {
+		if err := v.quotaValidator.ValidateAppStart(ctx, app); err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfapplog, app.Namespace, oldApp, app)
 }
 
@@ -0,0 +1,42 @@
+package builds
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfbuild,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfbuilds,verbs=create,versions=v1alpha1,name=vcfbuild.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type Validator struct {
+	suspensionValidator webhooks.SuspensionValidator
+}
+
+var _ admission.Validator[*korifiv1alpha1.CFBuild] = &Validator{}
+
+func NewValidator(suspensionValidator webhooks.SuspensionValidator) *Validator {
+	return &Validator{
+		suspensionValidator: suspensionValidator,
+	}
+}
+
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &korifiv1alpha1.CFBuild{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *Validator) ValidateCreate(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild) (admission.Warnings, error) {
+	return nil, v.suspensionValidator.ValidateWrite(ctx, cfBuild.Namespace)
+}
+
+func (v *Validator) ValidateUpdate(_ context.Context, _, _ *korifiv1alpha1.CFBuild) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *Validator) ValidateDelete(_ context.Context, _ *korifiv1alpha1.CFBuild) (admission.Warnings, error) {
+	return nil, nil
+}
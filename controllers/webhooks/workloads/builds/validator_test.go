@@ -0,0 +1,67 @@
+package builds_test
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/builds"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFBuildValidatingWebhook", func() {
+	const defaultNamespace = "default"
+
+	var (
+		ctx                 context.Context
+		suspensionValidator *fake.SuspensionValidator
+		cfBuild             *korifiv1alpha1.CFBuild
+		validatingWebhook   *builds.Validator
+		retErr              error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		cfBuild = &korifiv1alpha1.CFBuild{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: defaultNamespace,
+			},
+		}
+
+		suspensionValidator = new(fake.SuspensionValidator)
+		validatingWebhook = builds.NewValidator(suspensionValidator)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, cfBuild)
+		})
+
+		It("allows the creation of the build", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("validates the space is not suspended", func() {
+			Expect(suspensionValidator.ValidateWriteCallCount()).To(Equal(1))
+			_, actualNamespace := suspensionValidator.ValidateWriteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(defaultNamespace))
+		})
+
+		When("the space is suspended", func() {
+			BeforeEach(func() {
+				suspensionValidator.ValidateWriteReturns(errors.New("suspended"))
+			})
+
+			It("prevents the creation of the build", func() {
+				Expect(retErr).To(MatchError("suspended"))
+			})
+		})
+	})
+})
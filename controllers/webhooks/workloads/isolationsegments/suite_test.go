@@ -0,0 +1,13 @@
+package isolationsegments_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestIsolationSegmentsWebhooks(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "CFIsolationSegment Webhooks Suite")
+}
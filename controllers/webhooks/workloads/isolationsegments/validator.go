@@ -0,0 +1,78 @@
+package isolationsegments
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	IsolationSegmentEntityType = "isolationsegment"
+
+	InvalidIsolationSegmentNameErrorMessage = "name cannot be empty and must be less than 255 characters"
+)
+
+var cfisolationsegmentlog = logf.Log.WithName("cfisolationsegment-validate")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfisolationsegment,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfisolationsegments,verbs=create;update;delete,versions=v1alpha1,name=vcfisolationsegment.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type Validator struct {
+	duplicateValidator webhooks.NameValidator
+	rootNamespace      string
+}
+
+var _ admission.Validator[*korifiv1alpha1.CFIsolationSegment] = &Validator{}
+
+func NewValidator(duplicateValidator webhooks.NameValidator, rootNamespace string) *Validator {
+	return &Validator{
+		duplicateValidator: duplicateValidator,
+		rootNamespace:      rootNamespace,
+	}
+}
+
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &korifiv1alpha1.CFIsolationSegment{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *Validator) ValidateCreate(ctx context.Context, isolationSegment *korifiv1alpha1.CFIsolationSegment) (admission.Warnings, error) {
+	if err := validateName(isolationSegment.Spec.DisplayName); err != nil {
+		return nil, err
+	}
+
+	return nil, v.duplicateValidator.ValidateCreate(ctx, cfisolationsegmentlog, v.rootNamespace, isolationSegment)
+}
+
+func (v *Validator) ValidateUpdate(ctx context.Context, oldIsolationSegment, isolationSegment *korifiv1alpha1.CFIsolationSegment) (admission.Warnings, error) {
+	if !isolationSegment.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	if err := validateName(isolationSegment.Spec.DisplayName); err != nil {
+		return nil, err
+	}
+
+	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfisolationsegmentlog, v.rootNamespace, oldIsolationSegment, isolationSegment)
+}
+
+func (v *Validator) ValidateDelete(ctx context.Context, isolationSegment *korifiv1alpha1.CFIsolationSegment) (admission.Warnings, error) {
+	return nil, v.duplicateValidator.ValidateDelete(ctx, cfisolationsegmentlog, v.rootNamespace, isolationSegment)
+}
+
+func validateName(name string) error {
+	if len(name) == 0 || len(name) > 255 {
+		return validation.ValidationError{
+			Type:    webhooks.InvalidFieldValueErrorType,
+			Message: InvalidIsolationSegmentNameErrorMessage,
+		}.ExportJSONError()
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package isolationsegments_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/isolationsegments"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFIsolationSegmentValidatingWebhook", func() {
+	const rootNamespace = "cf"
+
+	var (
+		ctx                context.Context
+		duplicateValidator *fake.NameValidator
+		isolationSegment   *korifiv1alpha1.CFIsolationSegment
+		validatingWebhook  *isolationsegments.Validator
+		retErr             error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		isolationSegment = &korifiv1alpha1.CFIsolationSegment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+				DisplayName: "my-segment",
+			},
+		}
+
+		duplicateValidator = new(fake.NameValidator)
+		validatingWebhook = isolationsegments.NewValidator(duplicateValidator, rootNamespace)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, isolationSegment)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("invokes the duplicate validator correctly", func() {
+			Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+			actualContext, _, actualNamespace, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualNamespace).To(Equal(rootNamespace))
+			Expect(actualResource).To(Equal(isolationSegment))
+			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Isolation Segment names are case insensitive and must be unique"))
+		})
+
+		When("the name is a duplicate", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+
+		When("the name is empty", func() {
+			BeforeEach(func() {
+				isolationSegment.Spec.DisplayName = ""
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(webhooks.InvalidFieldValueErrorType, Equal(isolationsegments.InvalidIsolationSegmentNameErrorMessage)))
+			})
+		})
+
+		When("the name is too long", func() {
+			BeforeEach(func() {
+				isolationSegment.Spec.DisplayName = strings.Repeat("a", 256)
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(webhooks.InvalidFieldValueErrorType, Equal(isolationsegments.InvalidIsolationSegmentNameErrorMessage)))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var updatedIsolationSegment *korifiv1alpha1.CFIsolationSegment
+
+		BeforeEach(func() {
+			updatedIsolationSegment = isolationSegment.DeepCopy()
+			updatedIsolationSegment.Spec.DisplayName = "new-name"
+		})
+
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateUpdate(ctx, isolationSegment, updatedIsolationSegment)
+		})
+
+		It("invokes the duplicate validator correctly", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(duplicateValidator.ValidateUpdateCallCount()).To(Equal(1))
+			_, _, actualNamespace, oldResource, newResource := duplicateValidator.ValidateUpdateArgsForCall(0)
+			Expect(actualNamespace).To(Equal(rootNamespace))
+			Expect(oldResource).To(Equal(isolationSegment))
+			Expect(newResource).To(Equal(updatedIsolationSegment))
+		})
+
+		When("the new name is empty", func() {
+			BeforeEach(func() {
+				updatedIsolationSegment.Spec.DisplayName = ""
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(webhooks.InvalidFieldValueErrorType, Equal(isolationsegments.InvalidIsolationSegmentNameErrorMessage)))
+			})
+		})
+	})
+
+	Describe("ValidateDelete", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateDelete(ctx, isolationSegment)
+		})
+
+		It("invokes the duplicate validator correctly", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
+			_, _, actualNamespace, actualResource := duplicateValidator.ValidateDeleteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(rootNamespace))
+			Expect(actualResource).To(Equal(isolationSegment))
+		})
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
 	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/packages"
@@ -77,7 +78,9 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
-	Expect(packages.NewValidator().SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(packages.NewValidator(
+		validation.NewSuspensionValidator(helpers.NewUncachedClient(k8sManager.GetConfig()), "cf"),
+	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
@@ -34,16 +34,19 @@ var (
 	cfpackagelog = logf.Log.WithName("cftask-resource")
 )
 
-//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfpackage,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfpackages,verbs=update,versions=v1alpha1,name=vcfpackage.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfpackage,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfpackages,verbs=create;update,versions=v1alpha1,name=vcfpackage.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
 type Validator struct {
-	client client.Client
+	client              client.Client
+	suspensionValidator webhooks.SuspensionValidator
 }
 
 var _ admission.Validator[*korifiv1alpha1.CFPackage] = &Validator{}
 
-func NewValidator() *Validator {
-	return &Validator{}
+func NewValidator(suspensionValidator webhooks.SuspensionValidator) *Validator {
+	return &Validator{
+		suspensionValidator: suspensionValidator,
+	}
 }
 
 func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -54,8 +57,8 @@ func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 		Complete()
 }
 
-func (v *Validator) ValidateCreate(_ context.Context, _ *korifiv1alpha1.CFPackage) (admission.Warnings, error) {
-	return nil, nil
+func (v *Validator) ValidateCreate(ctx context.Context, cfPackage *korifiv1alpha1.CFPackage) (admission.Warnings, error) {
+	return nil, v.suspensionValidator.ValidateWrite(ctx, cfPackage.Namespace)
 }
 
 func (v *Validator) ValidateUpdate(ctx context.Context, oldPackage, newPackage *korifiv1alpha1.CFPackage) (admission.Warnings, error) {
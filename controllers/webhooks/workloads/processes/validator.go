@@ -0,0 +1,67 @@
+package processes
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var cfprocesslog = logf.Log.WithName("cfprocess-validate")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfprocess,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=create;update,versions=v1alpha1,name=vcfprocess.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type Validator struct {
+	quotaValidator      webhooks.QuotaValidator
+	suspensionValidator webhooks.SuspensionValidator
+}
+
+var _ admission.Validator[*korifiv1alpha1.CFProcess] = &Validator{}
+
+func NewValidator(quotaValidator webhooks.QuotaValidator, suspensionValidator webhooks.SuspensionValidator) *Validator {
+	return &Validator{
+		quotaValidator:      quotaValidator,
+		suspensionValidator: suspensionValidator,
+	}
+}
+
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &korifiv1alpha1.CFProcess{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *Validator) ValidateCreate(ctx context.Context, process *korifiv1alpha1.CFProcess) (admission.Warnings, error) {
+	cfprocesslog.V(1).Info("validate process creation", "namespace", process.Namespace, "name", process.Name)
+
+	if err := v.suspensionValidator.ValidateWrite(ctx, process.Namespace); err != nil {
+		return nil, err
+	}
+
+	return nil, v.quotaValidator.ValidateProcessScale(ctx, nil, process)
+}
+
+func (v *Validator) ValidateUpdate(ctx context.Context, oldProcess, process *korifiv1alpha1.CFProcess) (admission.Warnings, error) {
+	if !process.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	cfprocesslog.V(1).Info("validate process update", "namespace", process.Namespace, "name", process.Name)
+
+	if !equality.Semantic.DeepEqual(process.Spec, oldProcess.Spec) {
+		if err := v.suspensionValidator.ValidateWrite(ctx, process.Namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, v.quotaValidator.ValidateProcessScale(ctx, oldProcess, process)
+}
+
+func (v *Validator) ValidateDelete(_ context.Context, _ *korifiv1alpha1.CFProcess) (admission.Warnings, error) {
+	return nil, nil
+}
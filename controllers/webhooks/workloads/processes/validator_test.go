@@ -0,0 +1,165 @@
+package processes_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/processes"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFProcessValidatingWebhook", func() {
+	var (
+		ctx                 context.Context
+		quotaValidator      *fake.QuotaValidator
+		suspensionValidator *fake.SuspensionValidator
+		process             *korifiv1alpha1.CFProcess
+		validatingWebhook   *processes.Validator
+		retErr              error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		process = &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				ProcessType:      "web",
+				DesiredInstances: tools.PtrTo[int32](1),
+				MemoryMB:         256,
+			},
+		}
+
+		quotaValidator = new(fake.QuotaValidator)
+		suspensionValidator = new(fake.SuspensionValidator)
+		validatingWebhook = processes.NewValidator(quotaValidator, suspensionValidator)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, process)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("validates the process against the quotas", func() {
+			Expect(quotaValidator.ValidateProcessScaleCallCount()).To(Equal(1))
+			actualContext, actualOldProcess, actualProcess := quotaValidator.ValidateProcessScaleArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualOldProcess).To(BeNil())
+			Expect(actualProcess).To(Equal(process))
+		})
+
+		It("validates the process namespace is writable", func() {
+			Expect(suspensionValidator.ValidateWriteCallCount()).To(Equal(1))
+			_, actualNamespace := suspensionValidator.ValidateWriteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(process.Namespace))
+		})
+
+		When("the quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateProcessScaleReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+			})
+		})
+
+		When("the org is suspended", func() {
+			BeforeEach(func() {
+				suspensionValidator.ValidateWriteReturns(errors.New("org-suspended"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("org-suspended"))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var updatedProcess *korifiv1alpha1.CFProcess
+
+		BeforeEach(func() {
+			updatedProcess = process.DeepCopy()
+			updatedProcess.Spec.DesiredInstances = tools.PtrTo[int32](3)
+		})
+
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateUpdate(ctx, process, updatedProcess)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("validates the scaled process against the quotas", func() {
+			Expect(quotaValidator.ValidateProcessScaleCallCount()).To(Equal(1))
+			actualContext, actualOldProcess, actualProcess := quotaValidator.ValidateProcessScaleArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualOldProcess).To(Equal(process))
+			Expect(actualProcess).To(Equal(updatedProcess))
+		})
+
+		It("validates the process namespace is writable", func() {
+			Expect(suspensionValidator.ValidateWriteCallCount()).To(Equal(1))
+			_, actualNamespace := suspensionValidator.ValidateWriteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(process.Namespace))
+		})
+
+		When("the org is suspended", func() {
+			BeforeEach(func() {
+				suspensionValidator.ValidateWriteReturns(errors.New("org-suspended"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("org-suspended"))
+			})
+
+			When("the process spec does not change", func() {
+				BeforeEach(func() {
+					updatedProcess = process.DeepCopy()
+					updatedProcess.Labels = map[string]string{"foo": "bar"}
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		When("the quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateProcessScaleReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+			})
+		})
+
+		When("the process is being deleted", func() {
+			BeforeEach(func() {
+				updatedProcess.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			})
+
+			It("does not validate the quotas", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(quotaValidator.ValidateProcessScaleCallCount()).To(BeZero())
+			})
+		})
+	})
+})
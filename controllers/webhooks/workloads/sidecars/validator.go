@@ -0,0 +1,75 @@
+package sidecars
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const SidecarEntityType = "sidecar"
+
+// log is for logging in this package.
+var cfsidecarlog = logf.Log.WithName("cfsidecar-validator")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfsidecar,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfsidecars,verbs=create;update;delete,versions=v1alpha1,name=vcfsidecar.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &korifiv1alpha1.CFSidecar{}).
+		WithValidator(v).
+		Complete()
+}
+
+type Validator struct {
+	duplicateValidator  webhooks.NameValidator
+	suspensionValidator webhooks.SuspensionValidator
+}
+
+var _ admission.Validator[*korifiv1alpha1.CFSidecar] = &Validator{}
+
+func NewValidator(duplicateValidator webhooks.NameValidator, suspensionValidator webhooks.SuspensionValidator) *Validator {
+	return &Validator{
+		duplicateValidator:  duplicateValidator,
+		suspensionValidator: suspensionValidator,
+	}
+}
+
+func (v *Validator) ValidateCreate(ctx context.Context, sidecar *korifiv1alpha1.CFSidecar) (admission.Warnings, error) {
+	if err := v.suspensionValidator.ValidateWrite(ctx, sidecar.Namespace); err != nil {
+		return nil, err
+	}
+
+	return nil, v.duplicateValidator.ValidateCreate(ctx, cfsidecarlog, sidecar.Namespace, sidecar)
+}
+
+func (v *Validator) ValidateUpdate(ctx context.Context, oldSidecar, sidecar *korifiv1alpha1.CFSidecar) (admission.Warnings, error) {
+	if !sidecar.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	if oldSidecar.Spec.AppRef.Name != sidecar.Spec.AppRef.Name {
+		return nil, validation.ValidationError{
+			Type:    validation.ImmutableFieldErrorType,
+			Message: fmt.Sprintf(validation.ImmutableFieldErrorMessageTemplate, "CFSidecar.Spec.AppRef.Name"),
+		}.ExportJSONError()
+	}
+
+	if !equality.Semantic.DeepEqual(sidecar.Spec, oldSidecar.Spec) {
+		if err := v.suspensionValidator.ValidateWrite(ctx, sidecar.Namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfsidecarlog, sidecar.Namespace, oldSidecar, sidecar)
+}
+
+func (v *Validator) ValidateDelete(ctx context.Context, sidecar *korifiv1alpha1.CFSidecar) (admission.Warnings, error) {
+	return nil, v.duplicateValidator.ValidateDelete(ctx, cfsidecarlog, sidecar.Namespace, sidecar)
+}
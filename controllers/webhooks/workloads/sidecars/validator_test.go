@@ -0,0 +1,211 @@
+package sidecars_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/sidecars"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFSidecarValidatingWebhook", func() {
+	const defaultNamespace = "default"
+
+	var (
+		ctx                 context.Context
+		appGUID             string
+		duplicateValidator  *fake.NameValidator
+		suspensionValidator *fake.SuspensionValidator
+		sidecar             *korifiv1alpha1.CFSidecar
+		validatingWebhook   *sidecars.Validator
+		retErr              error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		appGUID = uuid.NewString()
+		sidecar = &korifiv1alpha1.CFSidecar{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: defaultNamespace,
+			},
+			Spec: korifiv1alpha1.CFSidecarSpec{
+				AppRef:       corev1.LocalObjectReference{Name: appGUID},
+				Name:         "my-sidecar",
+				Command:      "bin/sidecar",
+				ProcessTypes: []string{"web"},
+			},
+		}
+
+		duplicateValidator = new(fake.NameValidator)
+		suspensionValidator = new(fake.SuspensionValidator)
+		validatingWebhook = sidecars.NewValidator(duplicateValidator, suspensionValidator)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, sidecar)
+		})
+
+		It("allows the creation of the sidecar", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("validates the space is not suspended", func() {
+			Expect(suspensionValidator.ValidateWriteCallCount()).To(Equal(1))
+			_, actualNamespace := suspensionValidator.ValidateWriteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(defaultNamespace))
+		})
+
+		When("the space is suspended", func() {
+			BeforeEach(func() {
+				suspensionValidator.ValidateWriteReturns(errors.New("suspended"))
+			})
+
+			It("prevents the creation of the sidecar", func() {
+				Expect(retErr).To(MatchError("suspended"))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(0))
+			})
+		})
+
+		It("validates the sidecar name is unique within the app", func() {
+			Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+			_, _, actualNamespace, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+			Expect(actualNamespace).To(Equal(defaultNamespace))
+			Expect(actualResource).To(Equal(sidecar))
+			Expect(actualResource.UniqueName()).To(Equal("sidecar::" + defaultNamespace + "::" + appGUID + "::my-sidecar"))
+			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Sidecar with name 'my-sidecar' already exists for given app"))
+		})
+
+		When("the sidecar name is already taken", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
+			})
+
+			It("prevents the creation of the sidecar", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var updatedSidecar *korifiv1alpha1.CFSidecar
+
+		BeforeEach(func() {
+			updatedSidecar = sidecar.DeepCopy()
+			updatedSidecar.Spec.Name = "another-sidecar"
+		})
+
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateUpdate(ctx, sidecar, updatedSidecar)
+		})
+
+		It("allows the sidecar to be renamed", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("validates the space is not suspended", func() {
+			Expect(suspensionValidator.ValidateWriteCallCount()).To(Equal(1))
+			_, actualNamespace := suspensionValidator.ValidateWriteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(defaultNamespace))
+		})
+
+		When("the space is suspended", func() {
+			BeforeEach(func() {
+				suspensionValidator.ValidateWriteReturns(errors.New("suspended"))
+			})
+
+			It("prevents the update of the sidecar", func() {
+				Expect(retErr).To(MatchError("suspended"))
+			})
+
+			When("the spec does not change", func() {
+				BeforeEach(func() {
+					updatedSidecar.Spec = sidecar.Spec
+					updatedSidecar.Labels = map[string]string{"foo": "bar"}
+				})
+
+				It("allows the update", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		It("validates the new sidecar name is unique within the app", func() {
+			Expect(duplicateValidator.ValidateUpdateCallCount()).To(Equal(1))
+			_, _, actualNamespace, actualOldResource, actualResource := duplicateValidator.ValidateUpdateArgsForCall(0)
+			Expect(actualNamespace).To(Equal(defaultNamespace))
+			Expect(actualOldResource).To(Equal(sidecar))
+			Expect(actualResource).To(Equal(updatedSidecar))
+		})
+
+		When("the new sidecar name is already taken", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateUpdateReturns(errors.New("foo"))
+			})
+
+			It("prevents the update of the sidecar", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+
+		When("the app changes", func() {
+			BeforeEach(func() {
+				updatedSidecar.Spec.AppRef.Name = "another-app"
+			})
+
+			It("does not allow the change", func() {
+				Expect(retErr).To(matchers.BeValidationError(validation.ImmutableFieldErrorType, Equal("'CFSidecar.Spec.AppRef.Name' field is immutable")))
+			})
+		})
+
+		When("the sidecar is being deleted", func() {
+			BeforeEach(func() {
+				updatedSidecar.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			})
+
+			It("does not validate the name", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(duplicateValidator.ValidateUpdateCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("ValidateDelete", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateDelete(ctx, sidecar)
+		})
+
+		It("allows the deletion of the sidecar", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("releases the sidecar name", func() {
+			Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
+			_, _, actualNamespace, actualResource := duplicateValidator.ValidateDeleteArgsForCall(0)
+			Expect(actualNamespace).To(Equal(defaultNamespace))
+			Expect(actualResource).To(Equal(sidecar))
+		})
+
+		When("the sidecar name cannot be released", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateDeleteReturns(errors.New("foo"))
+			})
+
+			It("prevents the deletion of the sidecar", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+	})
+})
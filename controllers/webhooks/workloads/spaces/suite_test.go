@@ -93,7 +93,8 @@ var _ = BeforeSuite(func() {
 
 	spaceNameDuplicateValidator := validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, spaces.CFSpaceEntityType))
 	spacePlacementValidator := validation.NewPlacementValidator(uncachedClient, rootNamespace)
-	Expect(spaces.NewValidator(spaceNameDuplicateValidator, spacePlacementValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	spaceSuspensionValidator := validation.NewSuspensionValidator(uncachedClient, rootNamespace)
+	Expect(spaces.NewValidator(spaceNameDuplicateValidator, spacePlacementValidator, spaceSuspensionValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
@@ -21,16 +21,22 @@ var spaceLogger = logf.Log.WithName("cfspace-validate")
 //+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfspace,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=create;update;delete,versions=v1alpha1,name=vcfspace.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
 type Validator struct {
-	duplicateValidator webhooks.NameValidator
-	placementValidator webhooks.NamespaceValidator
+	duplicateValidator  webhooks.NameValidator
+	placementValidator  webhooks.NamespaceValidator
+	suspensionValidator webhooks.SuspensionValidator
 }
 
 var _ admission.Validator[*korifiv1alpha1.CFSpace] = &Validator{}
 
-func NewValidator(duplicateSpaceValidator webhooks.NameValidator, placementValidator webhooks.NamespaceValidator) *Validator {
+func NewValidator(
+	duplicateSpaceValidator webhooks.NameValidator,
+	placementValidator webhooks.NamespaceValidator,
+	suspensionValidator webhooks.SuspensionValidator,
+) *Validator {
 	return &Validator{
-		duplicateValidator: duplicateSpaceValidator,
-		placementValidator: placementValidator,
+		duplicateValidator:  duplicateSpaceValidator,
+		placementValidator:  placementValidator,
+		suspensionValidator: suspensionValidator,
 	}
 }
 
@@ -45,6 +51,10 @@ func (v *Validator) ValidateCreate(ctx context.Context, space *korifiv1alpha1.CF
 		return nil, errors.New("space name cannot be longer than 63 chars")
 	}
 
+	if err := v.suspensionValidator.ValidateWrite(ctx, space.Namespace); err != nil {
+		return nil, err
+	}
+
 	err := v.duplicateValidator.ValidateCreate(ctx, spaceLogger, space.Namespace, space)
 	if err != nil {
 		return nil, err
@@ -9,6 +9,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
 	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/tasks"
@@ -78,11 +79,17 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
-	Expect(tasks.NewDefaulter(config.CFProcessDefaults{
+	cfProcessDefaults := config.CFProcessDefaults{
 		MemoryMB:    500,
 		DiskQuotaMB: 512,
-	}).SetupWebhookWithManager(k8sManager)).To(Succeed())
-	Expect(tasks.NewValidator().SetupWebhookWithManager(k8sManager)).To(Succeed())
+	}
+	Expect(tasks.NewDefaulter(cfProcessDefaults).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	uncachedClient := helpers.NewUncachedClient(k8sManager.GetConfig())
+	Expect(tasks.NewValidator(
+		validation.NewQuotaValidator(uncachedClient, "cf"),
+		validation.NewSuspensionValidator(uncachedClient, "cf"),
+		cfProcessDefaults,
+	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -40,10 +41,22 @@ var cftasklog = logf.Log.WithName("cftask-resource")
 
 var _ admission.Validator[*v1alpha1.CFTask] = &Validator{}
 
-type Validator struct{}
+type Validator struct {
+	quotaValidator      webhooks.QuotaValidator
+	suspensionValidator webhooks.SuspensionValidator
+	cfProcessDefaults   config.CFProcessDefaults
+}
 
-func NewValidator() *Validator {
-	return &Validator{}
+func NewValidator(
+	quotaValidator webhooks.QuotaValidator,
+	suspensionValidator webhooks.SuspensionValidator,
+	cfProcessDefaults config.CFProcessDefaults,
+) *Validator {
+	return &Validator{
+		quotaValidator:      quotaValidator,
+		suspensionValidator: suspensionValidator,
+		cfProcessDefaults:   cfProcessDefaults,
+	}
 }
 
 func (v *Validator) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -69,7 +82,11 @@ func (v *Validator) ValidateCreate(ctx context.Context, task *v1alpha1.CFTask) (
 		}.ExportJSONError()
 	}
 
-	return nil, nil
+	if err := v.suspensionValidator.ValidateWrite(ctx, task.Namespace); err != nil {
+		return nil, err
+	}
+
+	return nil, v.quotaValidator.ValidateTaskCreate(ctx, task, v.cfProcessDefaults.MemoryMB)
 }
 
 func (v *Validator) ValidateUpdate(ctx context.Context, oldTask *v1alpha1.CFTask, newTask *v1alpha1.CFTask) (admission.Warnings, error) {
@@ -73,6 +73,16 @@ type PDB interface {
 	Update(ctx context.Context, statefulSet *appsv1.StatefulSet) error
 }
 
+//counterfeiter:generate -o ./fake -fake-name Rollout . Rollout
+type Rollout interface {
+	Update(ctx context.Context, statefulSet *appsv1.StatefulSet) error
+}
+
+//counterfeiter:generate -o ./fake -fake-name HPA . HPA
+type HPA interface {
+	Update(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload, statefulSet *appsv1.StatefulSet) (*korifiv1alpha1.ProcessAutoscalingStatus, error)
+}
+
 //counterfeiter:generate -o ./fake -fake-name WorkloadToStatefulsetConverter . WorkloadToStatefulsetConverter
 type WorkloadToStatefulsetConverter interface {
 	Convert(appWorkload *korifiv1alpha1.AppWorkload) (*appsv1.StatefulSet, error)
@@ -84,6 +94,8 @@ type AppWorkloadReconciler struct {
 	scheme           *runtime.Scheme
 	workloadsToStSet WorkloadToStatefulsetConverter
 	pdb              PDB
+	rollout          Rollout
+	hpa              HPA
 	log              logr.Logger
 	stateCollector   *state.AppWorkloadStateCollector
 }
@@ -93,6 +105,8 @@ func NewAppWorkloadReconciler(
 	scheme *runtime.Scheme,
 	workloadsToStSet WorkloadToStatefulsetConverter,
 	pdb PDB,
+	rollout Rollout,
+	hpa HPA,
 	log logr.Logger,
 	stateCollector *state.AppWorkloadStateCollector,
 ) *k8s.PatchingReconciler[korifiv1alpha1.AppWorkload] {
@@ -101,6 +115,8 @@ func NewAppWorkloadReconciler(
 		scheme:           scheme,
 		workloadsToStSet: workloadsToStSet,
 		pdb:              pdb,
+		rollout:          rollout,
+		hpa:              hpa,
 		log:              log,
 		stateCollector:   stateCollector,
 	}
@@ -152,10 +168,12 @@ func filterAppWorkloads(object client.Object) bool {
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=create;patch;get;list;watch;deletecollection
 //+kubebuilder:rbac:groups=apps,resources=statefulsets/finalizers,verbs=update
 
-//+kubebuilder:rbac:groups="",resources=pods,verbs=list;get;watch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list;get;watch;delete
 
 //+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=create;patch;deletecollection
 
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;patch;deletecollection
+
 func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -181,7 +199,14 @@ func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorklo
 	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, createdStSet, func() error {
 		createdStSet.Labels = statefulSet.Labels
 		createdStSet.Annotations = statefulSet.Annotations
+
+		// the replicas of autoscaled workloads are owned by their autoscaler
+		// once the statefulset has been created
+		replicas := createdStSet.Spec.Replicas
 		createdStSet.Spec = statefulSet.Spec
+		if appWorkload.Spec.Autoscaling != nil && !createdStSet.CreationTimestamp.IsZero() {
+			createdStSet.Spec.Replicas = replicas
+		}
 
 		return nil
 	})
@@ -196,7 +221,20 @@ func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorklo
 		return ctrl.Result{}, err
 	}
 
+	err = r.rollout.Update(ctx, createdStSet)
+	if err != nil {
+		log.Info("error when rolling out the statefulset", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	appWorkload.Status.Autoscaling, err = r.hpa.Update(ctx, appWorkload, createdStSet)
+	if err != nil {
+		log.Info("error when creating or patching horizontal pod autoscaler", "reason", err)
+		return ctrl.Result{}, err
+	}
+
 	appWorkload.Status.ActualInstances = createdStSet.Status.ReadyReplicas
+	appWorkload.Status.UpdatedInstances = getUpdatedInstances(createdStSet)
 
 	instancesState, err := r.stateCollector.CollectState(ctx, appWorkload.Spec.GUID)
 	if err != nil {
@@ -208,6 +246,16 @@ func (r *AppWorkloadReconciler) ReconcileResource(ctx context.Context, appWorklo
 	return ctrl.Result{}, nil
 }
 
+// getUpdatedInstances returns the number of instances running the latest statefulset revision, capped by the ready ones.
+// Replicas are only counted once the statefulset controller has observed the latest spec.
+func getUpdatedInstances(statefulSet *appsv1.StatefulSet) int32 {
+	if statefulSet.Status.ObservedGeneration != statefulSet.Generation {
+		return 0
+	}
+
+	return min(statefulSet.Status.UpdatedReplicas, statefulSet.Status.ReadyReplicas)
+}
+
 func (r *AppWorkloadReconciler) finalize(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload) (ctrl.Result, error) {
 	if err := r.k8sClient.DeleteAllOf(ctx, &appsv1.StatefulSet{}, client.InNamespace(appWorkload.Namespace), client.MatchingLabels{
 		LabelAppWorkloadGUID: appWorkload.Name,
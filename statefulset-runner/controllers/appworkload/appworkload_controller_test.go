@@ -37,6 +37,8 @@ var _ = Describe("AppWorkload Reconcile", func() {
 		statefulSet            *v1.StatefulSet
 		fakeWorkloadToStSet    *fake.WorkloadToStatefulsetConverter
 		fakePDB                *fake.PDB
+		fakeRollout            *fake.Rollout
+		fakeHPA                *fake.HPA
 		getAppWorkloadError    error
 		getStatefulSetError    error
 		createStatefulSetError error
@@ -61,6 +63,8 @@ var _ = Describe("AppWorkload Reconcile", func() {
 		fakeWorkloadToStSet.ConvertReturns(statefulSet, nil)
 
 		fakePDB = new(fake.PDB)
+		fakeRollout = new(fake.Rollout)
+		fakeHPA = new(fake.HPA)
 
 		ctx = context.Background()
 		req = ctrl.Request{
@@ -106,6 +110,8 @@ var _ = Describe("AppWorkload Reconcile", func() {
 			scheme.Scheme,
 			fakeWorkloadToStSet,
 			fakePDB,
+			fakeRollout,
+			fakeHPA,
 			ctrl.Log.WithName("controllers").WithName("TestAppWorkload"),
 			state.NewAppWorkloadStateCollector(fakeClient),
 		)
@@ -209,6 +215,39 @@ var _ = Describe("AppWorkload Reconcile", func() {
 			Expect(updatedStSet.Spec.Replicas).To(Equal(tools.PtrTo(int32(2))))
 		})
 
+		When("the statefulset is rolling out", func() {
+			BeforeEach(func() {
+				statefulSet.Generation = 2
+				statefulSet.Status = v1.StatefulSetStatus{
+					ObservedGeneration: 2,
+					ReadyReplicas:      2,
+					UpdatedReplicas:    1,
+				}
+			})
+
+			It("sets the updated instances on the appworkload status", func() {
+				Expect(fakeStatusWriter.PatchCallCount()).To(Equal(1))
+				_, object, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+				patchedAppWorkload, ok := object.(*korifiv1alpha1.AppWorkload)
+				Expect(ok).To(BeTrue())
+				Expect(patchedAppWorkload.Status.ActualInstances).To(BeEquivalentTo(2))
+				Expect(patchedAppWorkload.Status.UpdatedInstances).To(BeEquivalentTo(1))
+			})
+
+			When("the statefulset controller has not observed the latest spec", func() {
+				BeforeEach(func() {
+					statefulSet.Status.ObservedGeneration = 1
+				})
+
+				It("does not count any updated instances", func() {
+					_, object, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+					patchedAppWorkload, ok := object.(*korifiv1alpha1.AppWorkload)
+					Expect(ok).To(BeTrue())
+					Expect(patchedAppWorkload.Status.UpdatedInstances).To(BeZero())
+				})
+			})
+		})
+
 		When("updating the pod disruption budget fails", func() {
 			BeforeEach(func() {
 				fakePDB.UpdateReturns(errors.New("boom"))
@@ -218,6 +257,77 @@ var _ = Describe("AppWorkload Reconcile", func() {
 				Expect(reconcileErr).To(MatchError("boom"))
 			})
 		})
+
+		It("drives the rollout of the statefulset", func() {
+			Expect(fakeRollout.UpdateCallCount()).To(Equal(1))
+			_, actualStSet := fakeRollout.UpdateArgsForCall(0)
+			Expect(actualStSet.Name).To(Equal(statefulSet.Name))
+		})
+
+		When("driving the rollout fails", func() {
+			BeforeEach(func() {
+				fakeRollout.UpdateReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(reconcileErr).To(MatchError("boom"))
+			})
+		})
+
+		It("updates the horizontal pod autoscaler", func() {
+			Expect(fakeHPA.UpdateCallCount()).To(Equal(1))
+			_, actualWorkload, actualStSet := fakeHPA.UpdateArgsForCall(0)
+			Expect(actualWorkload.Name).To(Equal(appWorkload.Name))
+			Expect(actualStSet.Name).To(Equal(statefulSet.Name))
+		})
+
+		When("the appworkload is autoscaled", func() {
+			BeforeEach(func() {
+				appWorkload.Spec.Autoscaling = &korifiv1alpha1.ProcessAutoscaling{
+					MinInstances: 1,
+					MaxInstances: 5,
+				}
+				statefulSet.CreationTimestamp = metav1.Now()
+				statefulSet.Spec.Replicas = tools.PtrTo(int32(4))
+
+				desiredStSet := statefulSet.DeepCopy()
+				desiredStSet.Labels = map[string]string{"foo": "bar"}
+				desiredStSet.Spec.Replicas = tools.PtrTo(int32(2))
+				fakeWorkloadToStSet.ConvertReturns(desiredStSet, nil)
+
+				fakeHPA.UpdateReturns(&korifiv1alpha1.ProcessAutoscalingStatus{
+					CurrentInstances: 4,
+					DesiredInstances: 5,
+				}, nil)
+			})
+
+			It("keeps the replicas set by the autoscaler", func() {
+				_, updatedObject, _, _ := fakeClient.PatchArgsForCall(0)
+				updatedStSet, ok := updatedObject.(*v1.StatefulSet)
+				Expect(ok).To(BeTrue())
+				Expect(updatedStSet.Spec.Replicas).To(Equal(tools.PtrTo(int32(4))))
+			})
+
+			It("sets the autoscaling status on the appworkload", func() {
+				_, object, _, _ := fakeStatusWriter.PatchArgsForCall(0)
+				patchedAppWorkload, ok := object.(*korifiv1alpha1.AppWorkload)
+				Expect(ok).To(BeTrue())
+				Expect(patchedAppWorkload.Status.Autoscaling).To(Equal(&korifiv1alpha1.ProcessAutoscalingStatus{
+					CurrentInstances: 4,
+					DesiredInstances: 5,
+				}))
+			})
+		})
+
+		When("updating the horizontal pod autoscaler fails", func() {
+			BeforeEach(func() {
+				fakeHPA.UpdateReturns(nil, errors.New("hpa-boom"))
+			})
+
+			It("returns an error", func() {
+				Expect(reconcileErr).To(MatchError("hpa-boom"))
+			})
+		})
 	})
 })
 
@@ -18,6 +18,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const bindingRootPath = "/bindings"
@@ -107,6 +108,24 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 		return envs[i].Name < envs[j].Name
 	})
 
+	securityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: tools.PtrTo(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+
+	volumeMounts := slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.VolumeMount {
+		return corev1.VolumeMount{
+			Name:      s.Name,
+			ReadOnly:  true,
+			MountPath: filepath.Join(bindingRootPath, s.Name),
+		}
+	}))
+
 	containers := []corev1.Container{
 		{
 			Name:            ApplicationContainerName,
@@ -117,28 +136,29 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 			Ports: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Ports), func(port int32) corev1.ContainerPort {
 				return corev1.ContainerPort{ContainerPort: port}
 			})),
-			SecurityContext: &corev1.SecurityContext{
-				AllowPrivilegeEscalation: tools.PtrTo(false),
-				Capabilities: &corev1.Capabilities{
-					Drop: []corev1.Capability{"ALL"},
-				},
-				SeccompProfile: &corev1.SeccompProfile{
-					Type: corev1.SeccompProfileTypeRuntimeDefault,
-				},
-			},
-			Resources:     appWorkload.Spec.Resources,
-			StartupProbe:  appWorkload.Spec.StartupProbe,
-			LivenessProbe: appWorkload.Spec.LivenessProbe,
-			VolumeMounts: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.VolumeMount {
-				return corev1.VolumeMount{
-					Name:      s.Name,
-					ReadOnly:  true,
-					MountPath: filepath.Join(bindingRootPath, s.Name),
-				}
-			})),
+			SecurityContext: securityContext,
+			Resources:       appWorkload.Spec.Resources,
+			StartupProbe:    appWorkload.Spec.StartupProbe,
+			LivenessProbe:   appWorkload.Spec.LivenessProbe,
+			ReadinessProbe:  appWorkload.Spec.ReadinessProbe,
+			VolumeMounts:    volumeMounts,
 		},
 	}
 
+	// Sidecars run on the droplet image next to the app container and share its environment
+	for _, sidecar := range appWorkload.Spec.Sidecars {
+		containers = append(containers, corev1.Container{
+			Name:            sidecar.Name,
+			Image:           appWorkload.Spec.Image,
+			ImagePullPolicy: corev1.PullAlways,
+			Command:         sidecar.Command,
+			Env:             envs,
+			SecurityContext: securityContext,
+			Resources:       sidecar.Resources,
+			VolumeMounts:    volumeMounts,
+		})
+	}
+
 	statefulsetName, err := getStatefulSetName(appWorkload)
 	if err != nil {
 		return nil, err
@@ -156,6 +176,8 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 				Spec: corev1.PodSpec{
 					Containers:       containers,
 					ImagePullSecrets: appWorkload.Spec.ImagePullSecrets,
+					NodeSelector:     appWorkload.Spec.NodeSelector,
+					Tolerations:      appWorkload.Spec.Tolerations,
 					SecurityContext: &corev1.PodSecurityContext{
 						RunAsNonRoot: tools.PtrTo(true),
 						SeccompProfile: &corev1.SeccompProfile{
@@ -179,6 +201,16 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 		},
 	}
 
+	statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: tools.PtrTo(appWorkload.Spec.RolloutPartition),
+		},
+	}
+	if appWorkload.Spec.MaxUnavailable != nil {
+		statefulSet.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable = tools.PtrTo(intstr.FromInt32(*appWorkload.Spec.MaxUnavailable))
+	}
+
 	statefulSet.Spec.Template.Spec.AutomountServiceAccountToken = tools.PtrTo(false)
 	statefulSet.Spec.Selector = statefulSetLabelSelector(appWorkload)
 
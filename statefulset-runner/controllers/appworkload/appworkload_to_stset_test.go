@@ -70,6 +70,15 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 					PeriodSeconds:    30,
 					FailureThreshold: 1,
 				},
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						TCPSocket: &corev1.TCPSocketAction{
+							Port: intstr.IntOrString{Type: intstr.Int, IntVal: int32(8080)},
+						},
+					},
+					PeriodSeconds:    5,
+					FailureThreshold: 1,
+				},
 				Ports:      []int32{8888, 9999},
 				Instances:  1,
 				RunnerName: "statefulset-runner",
@@ -183,6 +192,10 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Expect(statefulSet.Spec.Template.Spec.Containers[0].LivenessProbe).To(Equal(appWorkload.Spec.LivenessProbe))
 	})
 
+	It("should set the readiness probe", func() {
+		Expect(statefulSet.Spec.Template.Spec.Containers[0].ReadinessProbe).To(Equal(appWorkload.Spec.ReadinessProbe))
+	})
+
 	It("should not automount service account token", func() {
 		Expect(statefulSet.Spec.Template.Spec.AutomountServiceAccountToken).To(Equal(tools.PtrTo(false)))
 	})
@@ -305,6 +318,51 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Expect(statefulSet.Spec.Template.Spec.ServiceAccountName).To(Equal("korifi-app"))
 	})
 
+	It("should not pin the pods to any nodes", func() {
+		Expect(statefulSet.Spec.Template.Spec.NodeSelector).To(BeEmpty())
+		Expect(statefulSet.Spec.Template.Spec.Tolerations).To(BeEmpty())
+	})
+
+	When("the app workload has a node selector and tolerations", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.NodeSelector = map[string]string{"pool": "regulated"}
+			appWorkload.Spec.Tolerations = []corev1.Toleration{{
+				Key:      "pool",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "regulated",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}}
+		})
+
+		It("sets them on the pod template", func() {
+			Expect(statefulSet.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+			Expect(statefulSet.Spec.Template.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+				Key:      "pool",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "regulated",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}))
+		})
+	})
+
+	It("should roll over all instances one at a time", func() {
+		Expect(statefulSet.Spec.UpdateStrategy.Type).To(Equal(appsv1.RollingUpdateStatefulSetStrategyType))
+		Expect(statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(PointTo(BeEquivalentTo(0)))
+		Expect(statefulSet.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable).To(BeNil())
+	})
+
+	When("the app workload has a rollout partition and max unavailable", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.RolloutPartition = 2
+			appWorkload.Spec.MaxUnavailable = tools.PtrTo[int32](3)
+		})
+
+		It("sets them on the rolling update strategy", func() {
+			Expect(statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition).To(PointTo(BeEquivalentTo(2)))
+			Expect(statefulSet.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable).To(PointTo(Equal(intstr.FromInt32(3))))
+		})
+	})
+
 	When("the app has environment set", func() {
 		BeforeEach(func() {
 			appWorkload.Spec.Env = []corev1.EnvVar{
@@ -406,6 +464,39 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		})
 	})
 
+	When("the app workload has sidecars", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Services = []korifiv1alpha1.ServiceBinding{{
+				Secret: "service-secret",
+				Name:   "binding-name",
+			}}
+			appWorkload.Spec.Sidecars = []korifiv1alpha1.AppWorkloadSidecar{{
+				Name:    "my-sidecar",
+				Command: []string{"/cnb/lifecycle/launcher", "bin/sidecar"},
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+				},
+			}}
+		})
+
+		It("adds a sidecar container running on the app image", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers).To(HaveLen(2))
+			appContainer := statefulSet.Spec.Template.Spec.Containers[0]
+			sidecarContainer := statefulSet.Spec.Template.Spec.Containers[1]
+
+			Expect(sidecarContainer.Name).To(Equal("my-sidecar"))
+			Expect(sidecarContainer.Image).To(Equal(appWorkload.Spec.Image))
+			Expect(sidecarContainer.Command).To(Equal([]string{"/cnb/lifecycle/launcher", "bin/sidecar"}))
+			Expect(sidecarContainer.Resources.Limits.Memory().String()).To(Equal("64Mi"))
+			Expect(sidecarContainer.Env).To(Equal(appContainer.Env))
+			Expect(sidecarContainer.VolumeMounts).To(Equal(appContainer.VolumeMounts))
+			Expect(sidecarContainer.SecurityContext).To(Equal(appContainer.SecurityContext))
+			Expect(sidecarContainer.Ports).To(BeEmpty())
+			Expect(sidecarContainer.LivenessProbe).To(BeNil())
+			Expect(sidecarContainer.ReadinessProbe).To(BeNil())
+		})
+	})
+
 	It("should produce a stable statefulset regardless of labels iteration order", func() {
 		for i := 0; i < 100; i++ {
 			ss, err := converter.Convert(appWorkload)
@@ -0,0 +1,121 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/appworkload"
+	v1 "k8s.io/api/apps/v1"
+)
+
+type HPA struct {
+	UpdateStub        func(context.Context, *v1alpha1.AppWorkload, *v1.StatefulSet) (*v1alpha1.ProcessAutoscalingStatus, error)
+	updateMutex       sync.RWMutex
+	updateArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha1.AppWorkload
+		arg3 *v1.StatefulSet
+	}
+	updateReturns struct {
+		result1 *v1alpha1.ProcessAutoscalingStatus
+		result2 error
+	}
+	updateReturnsOnCall map[int]struct {
+		result1 *v1alpha1.ProcessAutoscalingStatus
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *HPA) Update(arg1 context.Context, arg2 *v1alpha1.AppWorkload, arg3 *v1.StatefulSet) (*v1alpha1.ProcessAutoscalingStatus, error) {
+	fake.updateMutex.Lock()
+	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
+	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha1.AppWorkload
+		arg3 *v1.StatefulSet
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateStub
+	fakeReturns := fake.updateReturns
+	fake.recordInvocation("Update", []interface{}{arg1, arg2, arg3})
+	fake.updateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *HPA) UpdateCallCount() int {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return len(fake.updateArgsForCall)
+}
+
+func (fake *HPA) UpdateCalls(stub func(context.Context, *v1alpha1.AppWorkload, *v1.StatefulSet) (*v1alpha1.ProcessAutoscalingStatus, error)) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = stub
+}
+
+func (fake *HPA) UpdateArgsForCall(i int) (context.Context, *v1alpha1.AppWorkload, *v1.StatefulSet) {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	argsForCall := fake.updateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *HPA) UpdateReturns(result1 *v1alpha1.ProcessAutoscalingStatus, result2 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	fake.updateReturns = struct {
+		result1 *v1alpha1.ProcessAutoscalingStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HPA) UpdateReturnsOnCall(i int, result1 *v1alpha1.ProcessAutoscalingStatus, result2 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	if fake.updateReturnsOnCall == nil {
+		fake.updateReturnsOnCall = make(map[int]struct {
+			result1 *v1alpha1.ProcessAutoscalingStatus
+			result2 error
+		})
+	}
+	fake.updateReturnsOnCall[i] = struct {
+		result1 *v1alpha1.ProcessAutoscalingStatus
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *HPA) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *HPA) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ appworkload.HPA = new(HPA)
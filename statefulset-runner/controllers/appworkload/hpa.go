@@ -0,0 +1,136 @@
+package appworkload
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// HTTPRequestsPerSecondMetric is the pods metric the HTTP throughput target
+	// is measured with. It has to be served by a custom metrics adapter
+	HTTPRequestsPerSecondMetric = "http_requests_per_second"
+
+	DefaultCPUUtilizationPercent = 80
+)
+
+type HPAUpdater struct {
+	client client.Client
+}
+
+func NewHPAUpdater(client client.Client) *HPAUpdater {
+	return &HPAUpdater{
+		client: client,
+	}
+}
+
+func (c *HPAUpdater) Update(ctx context.Context, appWorkload *korifiv1alpha1.AppWorkload, statefulSet *appsv1.StatefulSet) (*korifiv1alpha1.ProcessAutoscalingStatus, error) {
+	if appWorkload.Spec.Autoscaling != nil {
+		return c.createOrPatchHPA(ctx, appWorkload.Spec.Autoscaling, statefulSet)
+	}
+
+	return nil, c.deleteHPA(ctx, statefulSet)
+}
+
+func (c *HPAUpdater) createOrPatchHPA(ctx context.Context, autoscaling *korifiv1alpha1.ProcessAutoscaling, statefulSet *appsv1.StatefulSet) (*korifiv1alpha1.ProcessAutoscalingStatus, error) {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      statefulSet.Name,
+			Namespace: statefulSet.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, c.client, hpa, func() error {
+		hpa.Labels = map[string]string{
+			controllers.LabelGUID: statefulSet.Labels[controllers.LabelGUID],
+			LabelVersion:          statefulSet.Labels[LabelVersion],
+		}
+		hpa.Spec = autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "StatefulSet",
+				Name:       statefulSet.Name,
+			},
+			MinReplicas: &autoscaling.MinInstances,
+			MaxReplicas: autoscaling.MaxInstances,
+			Metrics:     hpaMetrics(autoscaling),
+		}
+
+		return controllerutil.SetControllerReference(statefulSet, hpa, scheme.Scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create or patch horizontal pod autoscaler: %w", err)
+	}
+
+	return &korifiv1alpha1.ProcessAutoscalingStatus{
+		CurrentInstances: hpa.Status.CurrentReplicas,
+		DesiredInstances: hpa.Status.DesiredReplicas,
+		LastScaleTime:    hpa.Status.LastScaleTime,
+	}, nil
+}
+
+// hpaMetrics explicitly sets the CPU target the HPA would default to when no
+// target is set, so that patching the HPA does not fight its defaulting
+func hpaMetrics(autoscaling *korifiv1alpha1.ProcessAutoscaling) []autoscalingv2.MetricSpec {
+	metrics := []autoscalingv2.MetricSpec{}
+
+	if autoscaling.CPUUtilizationPercent != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, *autoscaling.CPUUtilizationPercent))
+	}
+
+	if autoscaling.MemoryUtilizationPercent != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceMemory, *autoscaling.MemoryUtilizationPercent))
+	}
+
+	if autoscaling.HTTPRequestsPerSecond != nil {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: HTTPRequestsPerSecondMetric},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: resource.NewQuantity(int64(*autoscaling.HTTPRequestsPerSecond), resource.DecimalSI),
+				},
+			},
+		})
+	}
+
+	if len(metrics) == 0 {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, DefaultCPUUtilizationPercent))
+	}
+
+	return metrics
+}
+
+func resourceMetric(name corev1.ResourceName, utilizationPercent int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &utilizationPercent,
+			},
+		},
+	}
+}
+
+func (c *HPAUpdater) deleteHPA(ctx context.Context, statefulSet *appsv1.StatefulSet) error {
+	err := c.client.DeleteAllOf(ctx, &autoscalingv2.HorizontalPodAutoscaler{}, client.InNamespace(statefulSet.Namespace), client.MatchingFields{"metadata.name": statefulSet.Name})
+	if err != nil {
+		return fmt.Errorf("failed to delete horizontal pod autoscaler: %w", err)
+	}
+
+	return nil
+}
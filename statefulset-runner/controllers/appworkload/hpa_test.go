@@ -0,0 +1,222 @@
+package appworkload_test
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/appworkload"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("HPA", func() {
+	var (
+		updater     *appworkload.HPAUpdater
+		appWorkload *korifiv1alpha1.AppWorkload
+		stSet       *appsv1.StatefulSet
+		ctx         context.Context
+		status      *korifiv1alpha1.ProcessAutoscalingStatus
+		updateErr   error
+	)
+
+	BeforeEach(func() {
+		updater = appworkload.NewHPAUpdater(fakeClient)
+
+		appWorkload = &korifiv1alpha1.AppWorkload{
+			Spec: korifiv1alpha1.AppWorkloadSpec{
+				Autoscaling: &korifiv1alpha1.ProcessAutoscaling{
+					MinInstances:          2,
+					MaxInstances:          5,
+					CPUUtilizationPercent: tools.PtrTo[int32](70),
+				},
+			},
+		}
+
+		stSet = &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name",
+				Namespace: "namespace",
+				UID:       "uid",
+				Labels: map[string]string{
+					controllers.LabelGUID:    "label-guid",
+					appworkload.LabelVersion: "label-version",
+				},
+			},
+		}
+
+		fakeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, "name"))
+
+		ctx = context.Background()
+	})
+
+	JustBeforeEach(func() {
+		status, updateErr = updater.Update(ctx, appWorkload, stSet)
+	})
+
+	It("succeeds", func() {
+		Expect(updateErr).NotTo(HaveOccurred())
+	})
+
+	It("creates a horizontal pod autoscaler", func() {
+		Expect(fakeClient.CreateCallCount()).To(Equal(1))
+
+		_, obj, _ := fakeClient.CreateArgsForCall(0)
+		Expect(obj).To(BeAssignableToTypeOf(&autoscalingv2.HorizontalPodAutoscaler{}))
+		hpa := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+
+		Expect(hpa.Namespace).To(Equal("namespace"))
+		Expect(hpa.Name).To(Equal("name"))
+		Expect(hpa.Labels).To(HaveKeyWithValue(controllers.LabelGUID, "label-guid"))
+		Expect(hpa.Labels).To(HaveKeyWithValue(appworkload.LabelVersion, "label-version"))
+		Expect(hpa.Spec.ScaleTargetRef).To(Equal(autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "StatefulSet",
+			Name:       "name",
+		}))
+		Expect(hpa.Spec.MinReplicas).To(Equal(tools.PtrTo[int32](2)))
+		Expect(hpa.Spec.MaxReplicas).To(BeEquivalentTo(5))
+		Expect(hpa.Spec.Metrics).To(ConsistOf(autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: tools.PtrTo[int32](70),
+				},
+			},
+		}))
+		Expect(hpa.OwnerReferences).To(HaveLen(1))
+		Expect(hpa.OwnerReferences[0].Name).To(Equal(stSet.Name))
+		Expect(hpa.OwnerReferences[0].UID).To(Equal(stSet.UID))
+	})
+
+	When("the policy has memory and HTTP targets", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Autoscaling.CPUUtilizationPercent = nil
+			appWorkload.Spec.Autoscaling.MemoryUtilizationPercent = tools.PtrTo[int32](60)
+			appWorkload.Spec.Autoscaling.HTTPRequestsPerSecond = tools.PtrTo[int32](100)
+		})
+
+		It("sets the memory and HTTP metrics", func() {
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			hpa := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+			Expect(hpa.Spec.Metrics).To(ConsistOf(
+				autoscalingv2.MetricSpec{
+					Type: autoscalingv2.ResourceMetricSourceType,
+					Resource: &autoscalingv2.ResourceMetricSource{
+						Name: corev1.ResourceMemory,
+						Target: autoscalingv2.MetricTarget{
+							Type:               autoscalingv2.UtilizationMetricType,
+							AverageUtilization: tools.PtrTo[int32](60),
+						},
+					},
+				},
+				autoscalingv2.MetricSpec{
+					Type: autoscalingv2.PodsMetricSourceType,
+					Pods: &autoscalingv2.PodsMetricSource{
+						Metric: autoscalingv2.MetricIdentifier{Name: appworkload.HTTPRequestsPerSecondMetric},
+						Target: autoscalingv2.MetricTarget{
+							Type:         autoscalingv2.AverageValueMetricType,
+							AverageValue: resource.NewQuantity(100, resource.DecimalSI),
+						},
+					},
+				},
+			))
+		})
+	})
+
+	When("the policy has no targets", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Autoscaling.CPUUtilizationPercent = nil
+		})
+
+		It("sets the default CPU metric", func() {
+			_, obj, _ := fakeClient.CreateArgsForCall(0)
+			hpa := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+			Expect(hpa.Spec.Metrics).To(HaveLen(1))
+			Expect(hpa.Spec.Metrics[0].Resource.Name).To(Equal(corev1.ResourceCPU))
+			Expect(hpa.Spec.Metrics[0].Resource.Target.AverageUtilization).To(Equal(tools.PtrTo[int32](appworkload.DefaultCPUUtilizationPercent)))
+		})
+	})
+
+	When("the horizontal pod autoscaler already exists", func() {
+		var lastScaleTime metav1.Time
+
+		BeforeEach(func() {
+			lastScaleTime = metav1.Now()
+			fakeClient.GetReturns(nil)
+			fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+				hpa := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+				hpa.Name = "name"
+				hpa.Namespace = "namespace"
+				hpa.Status = autoscalingv2.HorizontalPodAutoscalerStatus{
+					CurrentReplicas: 3,
+					DesiredReplicas: 4,
+					LastScaleTime:   &lastScaleTime,
+				}
+				return nil
+			}
+		})
+
+		It("patches it", func() {
+			Expect(fakeClient.CreateCallCount()).To(BeZero())
+			Expect(fakeClient.PatchCallCount()).To(Equal(1))
+		})
+
+		It("returns the autoscaler status", func() {
+			Expect(status).To(Equal(&korifiv1alpha1.ProcessAutoscalingStatus{
+				CurrentInstances: 3,
+				DesiredInstances: 4,
+				LastScaleTime:    &lastScaleTime,
+			}))
+		})
+	})
+
+	When("creating the horizontal pod autoscaler fails", func() {
+		BeforeEach(func() {
+			fakeClient.CreateReturns(errors.New("boom"))
+		})
+
+		It("returns an error", func() {
+			Expect(updateErr).To(MatchError(ContainSubstring("boom")))
+		})
+	})
+
+	When("the appworkload is not autoscaled", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Autoscaling = nil
+		})
+
+		It("deletes the horizontal pod autoscaler", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(status).To(BeNil())
+			Expect(fakeClient.CreateCallCount()).To(BeZero())
+			Expect(fakeClient.DeleteAllOfCallCount()).To(Equal(1))
+			_, obj, _ := fakeClient.DeleteAllOfArgsForCall(0)
+			Expect(obj).To(BeAssignableToTypeOf(&autoscalingv2.HorizontalPodAutoscaler{}))
+		})
+
+		When("deleting the horizontal pod autoscaler fails", func() {
+			BeforeEach(func() {
+				fakeClient.DeleteAllOfReturns(errors.New("oops"))
+			})
+
+			It("returns an error", func() {
+				Expect(updateErr).To(MatchError(ContainSubstring("oops")))
+			})
+		})
+	})
+})
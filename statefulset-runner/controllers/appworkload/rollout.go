@@ -0,0 +1,109 @@
+package appworkload
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RolloutDriver replaces up to maxUnavailable outdated instances of a
+// statefulset at the same time. The statefulset controller only honours the
+// maxUnavailable setting of the rolling update when the alpha
+// MaxUnavailableStatefulSet feature gate is enabled and replaces instances one
+// at a time otherwise. Deleted instances whose ordinal is not lower than the
+// rollout partition are recreated on the update revision, so deleting them
+// speeds up the rollout without touching the instances kept on the previous
+// revision.
+type RolloutDriver struct {
+	client client.Client
+}
+
+func NewRolloutDriver(client client.Client) *RolloutDriver {
+	return &RolloutDriver{
+		client: client,
+	}
+}
+
+func (d *RolloutDriver) Update(ctx context.Context, statefulSet *appsv1.StatefulSet) error {
+	maxUnavailable := getMaxUnavailable(statefulSet)
+	if maxUnavailable <= 1 || !isRollingOut(statefulSet) {
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := d.client.List(ctx, pods, client.InNamespace(statefulSet.Namespace), client.MatchingLabels(statefulSet.Spec.Selector.MatchLabels)); err != nil {
+		return fmt.Errorf("failed to list statefulset pods: %w", err)
+	}
+
+	partition := int(*statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition)
+	replicas := int(*statefulSet.Spec.Replicas)
+
+	unavailable := max(replicas-len(pods.Items), 0)
+	outdated := map[int]*corev1.Pod{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		ordinal, err := getPodOrdinal(pod)
+		if err != nil || ordinal >= replicas {
+			continue
+		}
+
+		if !pod.DeletionTimestamp.IsZero() || !isPodReady(pod) {
+			unavailable++
+			continue
+		}
+
+		if ordinal >= partition && pod.Labels[appsv1.ControllerRevisionHashLabelKey] != statefulSet.Status.UpdateRevision {
+			outdated[ordinal] = pod
+		}
+	}
+
+	// Replace the instances with the highest ordinals first, which is the
+	// order the statefulset controller replaces them in
+	ordinals := slices.Sorted(maps.Keys(outdated))
+	slices.Reverse(ordinals)
+
+	for _, ordinal := range ordinals[:min(len(ordinals), max(maxUnavailable-unavailable, 0))] {
+		if err := d.client.Delete(ctx, outdated[ordinal], client.Preconditions{UID: &outdated[ordinal].UID}); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete outdated pod %q: %w", outdated[ordinal].Name, err)
+		}
+	}
+
+	return nil
+}
+
+func getMaxUnavailable(statefulSet *appsv1.StatefulSet) int {
+	rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate == nil || rollingUpdate.MaxUnavailable == nil || rollingUpdate.Partition == nil {
+		return 0
+	}
+
+	return rollingUpdate.MaxUnavailable.IntValue()
+}
+
+// isRollingOut tells whether the statefulset controller has observed the
+// latest spec and some instances are not on its update revision yet
+func isRollingOut(statefulSet *appsv1.StatefulSet) bool {
+	if statefulSet.Spec.Replicas == nil || statefulSet.Status.ObservedGeneration != statefulSet.Generation || statefulSet.Status.UpdateRevision == "" {
+		return false
+	}
+
+	return statefulSet.Status.UpdatedReplicas < *statefulSet.Spec.Replicas
+}
+
+func getPodOrdinal(pod *corev1.Pod) (int, error) {
+	return strconv.Atoi(pod.Labels[korifiv1alpha1.PodIndexLabelKey])
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	return slices.ContainsFunc(pod.Status.Conditions, func(condition corev1.PodCondition) bool {
+		return condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue
+	})
+}
@@ -0,0 +1,200 @@
+package appworkload_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/appworkload"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("RolloutDriver", func() {
+	var (
+		driver    *appworkload.RolloutDriver
+		stSet     *appsv1.StatefulSet
+		pods      []corev1.Pod
+		ctx       context.Context
+		updateErr error
+	)
+
+	newPod := func(ordinal int, revision string, ready bool) corev1.Pod {
+		readyStatus := corev1.ConditionFalse
+		if ready {
+			readyStatus = corev1.ConditionTrue
+		}
+
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "name-" + strconv.Itoa(ordinal),
+				Namespace: "namespace",
+				Labels: map[string]string{
+					korifiv1alpha1.PodIndexLabelKey:       strconv.Itoa(ordinal),
+					appsv1.ControllerRevisionHashLabelKey: revision,
+				},
+			},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+			},
+		}
+	}
+
+	deletedPodNames := func() []string {
+		var names []string
+		for i := range fakeClient.DeleteCallCount() {
+			_, obj, _ := fakeClient.DeleteArgsForCall(i)
+			names = append(names, obj.GetName())
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		driver = appworkload.NewRolloutDriver(fakeClient)
+
+		stSet = &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "name",
+				Namespace:  "namespace",
+				Generation: 2,
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: tools.PtrTo[int32](4),
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"foo": "bar"},
+				},
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+					Type: appsv1.RollingUpdateStatefulSetStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+						Partition:      tools.PtrTo[int32](0),
+						MaxUnavailable: tools.PtrTo(intstr.FromInt32(2)),
+					},
+				},
+			},
+			Status: appsv1.StatefulSetStatus{
+				ObservedGeneration: 2,
+				UpdateRevision:     "new",
+				CurrentRevision:    "old",
+				UpdatedReplicas:    0,
+			},
+		}
+
+		pods = []corev1.Pod{
+			newPod(0, "old", true),
+			newPod(1, "old", true),
+			newPod(2, "old", true),
+			newPod(3, "old", true),
+		}
+
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			podList, ok := list.(*corev1.PodList)
+			Expect(ok).To(BeTrue())
+			podList.Items = pods
+			return nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		updateErr = driver.Update(ctx, stSet)
+	})
+
+	It("replaces up to max unavailable outdated instances, highest ordinals first", func() {
+		Expect(updateErr).NotTo(HaveOccurred())
+		Expect(deletedPodNames()).To(Equal([]string{"name-3", "name-2"}))
+	})
+
+	It("lists the pods of the statefulset", func() {
+		Expect(fakeClient.ListCallCount()).To(Equal(1))
+		_, _, listOpts := fakeClient.ListArgsForCall(0)
+		Expect(listOpts).To(ContainElements(
+			client.InNamespace("namespace"),
+			client.MatchingLabels{"foo": "bar"},
+		))
+	})
+
+	When("some instances are unavailable", func() {
+		BeforeEach(func() {
+			pods[3] = newPod(3, "new", false)
+		})
+
+		It("only replaces as many instances as the remaining budget allows", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(deletedPodNames()).To(Equal([]string{"name-2"}))
+		})
+	})
+
+	When("some instances are missing", func() {
+		BeforeEach(func() {
+			pods = pods[:2]
+		})
+
+		It("counts them as unavailable", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(fakeClient.DeleteCallCount()).To(BeZero())
+		})
+	})
+
+	When("some instances are kept on the previous revision by the partition", func() {
+		BeforeEach(func() {
+			stSet.Spec.UpdateStrategy.RollingUpdate.Partition = tools.PtrTo[int32](3)
+		})
+
+		It("does not replace them", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(deletedPodNames()).To(Equal([]string{"name-3"}))
+		})
+	})
+
+	When("max unavailable is 1", func() {
+		BeforeEach(func() {
+			stSet.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable = tools.PtrTo(intstr.FromInt32(1))
+		})
+
+		It("leaves the rollout to the statefulset controller", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(fakeClient.ListCallCount()).To(BeZero())
+			Expect(fakeClient.DeleteCallCount()).To(BeZero())
+		})
+	})
+
+	When("the statefulset controller has not observed the latest spec", func() {
+		BeforeEach(func() {
+			stSet.Status.ObservedGeneration = 1
+		})
+
+		It("does not replace any instance", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(fakeClient.DeleteCallCount()).To(BeZero())
+		})
+	})
+
+	When("all instances are updated", func() {
+		BeforeEach(func() {
+			stSet.Status.UpdatedReplicas = 4
+		})
+
+		It("does not replace any instance", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(fakeClient.DeleteCallCount()).To(BeZero())
+		})
+	})
+
+	When("deleting a pod fails", func() {
+		BeforeEach(func() {
+			fakeClient.DeleteReturns(errors.New("boom"))
+		})
+
+		It("returns an error", func() {
+			Expect(updateErr).To(MatchError(ContainSubstring("boom")))
+		})
+	})
+})
@@ -0,0 +1,121 @@
+package e2e_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+type auditEventResource struct {
+	GUID   string                  `json:"guid"`
+	Type   string                  `json:"type"`
+	Actor  auditEventActorOrTarget `json:"actor"`
+	Target auditEventActorOrTarget `json:"target"`
+	Space  *struct {
+		GUID string `json:"guid"`
+	} `json:"space"`
+	Organization *struct {
+		GUID string `json:"guid"`
+	} `json:"organization"`
+}
+
+type auditEventActorOrTarget struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+var _ = Describe("Audit Events", func() {
+	var (
+		spaceGUID string
+		appGUID   string
+	)
+
+	listAuditEvents := func(g Gomega, queryParams map[string]string) []auditEventResource {
+		var result resourceList[auditEventResource]
+		resp, err := adminClient.R().
+			SetQueryParams(queryParams).
+			SetResult(&result).
+			Get("/v3/audit_events")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+
+		return result.Resources
+	}
+
+	BeforeEach(func() {
+		spaceGUID = createSpace(generateGUID("audit-events-space"), commonTestOrgGUID)
+		appGUID = createBuildpackApp(spaceGUID, generateGUID("app"))
+	})
+
+	AfterEach(func() {
+		deleteSpace(spaceGUID)
+	})
+
+	It("records the app creation", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(listAuditEvents(g, map[string]string{"target_guids": appGUID})).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Type": Equal("audit.app.create"),
+				"Actor": MatchFields(IgnoreExtras, Fields{
+					"Type": Equal("service_account"),
+				}),
+				"Target": MatchFields(IgnoreExtras, Fields{
+					"GUID": Equal(appGUID),
+					"Type": Equal("app"),
+				}),
+				"Space":        PointTo(MatchFields(IgnoreExtras, Fields{"GUID": Equal(spaceGUID)})),
+				"Organization": PointTo(MatchFields(IgnoreExtras, Fields{"GUID": Equal(commonTestOrgGUID)})),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("records the space creation", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(listAuditEvents(g, map[string]string{
+				"types":       "audit.space.create",
+				"space_guids": spaceGUID,
+			})).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Target": MatchFields(IgnoreExtras, Fields{"GUID": Equal(spaceGUID)}),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("filters audit events by creation time", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(listAuditEvents(g, map[string]string{"target_guids": appGUID})).NotTo(BeEmpty())
+		}).Should(Succeed())
+
+		Expect(listAuditEvents(Default, map[string]string{
+			"target_guids":    appGUID,
+			"created_ats[gt]": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		})).To(BeEmpty())
+	})
+
+	When("the user has no role in the space", func() {
+		var userName string
+
+		BeforeEach(func() {
+			userName = uuid.NewString()
+			createOrgRole("organization_user", userName, commonTestOrgGUID)
+		})
+
+		It("does not see the space audit events", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listAuditEvents(g, map[string]string{"target_guids": appGUID})).NotTo(BeEmpty())
+			}).Should(Succeed())
+
+			var result resourceList[auditEventResource]
+			resp, err := makeCertClientForUserName(userName, time.Hour).R().
+				SetQueryParams(map[string]string{"target_guids": appGUID}).
+				SetResult(&result).
+				Get("/v3/audit_events")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.Resources).To(BeEmpty())
+		})
+	})
+})
@@ -10,6 +10,15 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+type deploymentResource struct {
+	GUID     string `json:"guid"`
+	Strategy string `json:"strategy"`
+	Status   struct {
+		Value  string `json:"value"`
+		Reason string `json:"reason"`
+	} `json:"status"`
+}
+
 var _ = Describe("Deployments", func() {
 	var (
 		spaceGUID string
@@ -79,6 +88,80 @@ var _ = Describe("Deployments", func() {
 		})
 	})
 
+	Describe("Canary", func() {
+		var deploymentGUID string
+
+		expectDeploymentStatus := func(value, reason string) {
+			GinkgoHelper()
+
+			Eventually(func(g Gomega) {
+				var deployment deploymentResource
+				getResp, err := adminClient.R().SetResult(&deployment).Get("/v3/deployments/" + deploymentGUID)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(getResp).To(HaveRestyStatusCode(http.StatusOK))
+				g.Expect(deployment.Status.Value).To(Equal(value))
+				g.Expect(deployment.Status.Reason).To(Equal(reason))
+			}).Should(Succeed())
+		}
+
+		BeforeEach(func() {
+			scaleResp, err := adminClient.R().
+				SetBody(scaleResource{Instances: 2}).
+				Post("/v3/apps/" + appGUID + "/processes/web/actions/scale")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(scaleResp).To(HaveRestyStatusCode(http.StatusOK))
+
+			var deployment deploymentResource
+			createResp, err := adminClient.R().
+				SetBody(map[string]any{
+					"strategy": "canary",
+					"relationships": map[string]any{
+						"app": map[string]any{"data": map[string]any{"guid": appGUID}},
+					},
+				}).
+				SetResult(&deployment).
+				Post("/v3/deployments")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(createResp).To(HaveRestyStatusCode(http.StatusCreated))
+			Expect(deployment.Strategy).To(Equal("canary"))
+			deploymentGUID = deployment.GUID
+		})
+
+		It("pauses after the canary instance is deployed", func() {
+			expectDeploymentStatus("ACTIVE", "PAUSED")
+		})
+
+		When("the deployment is continued", func() {
+			BeforeEach(func() {
+				expectDeploymentStatus("ACTIVE", "PAUSED")
+
+				var err error
+				resp, err = adminClient.R().Post("/v3/deployments/" + deploymentGUID + "/actions/continue")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("finalizes the deployment", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+				expectDeploymentStatus("FINALIZED", "DEPLOYED")
+			})
+		})
+
+		When("the deployment is canceled", func() {
+			BeforeEach(func() {
+				expectDeploymentStatus("ACTIVE", "PAUSED")
+
+				var err error
+				resp, err = adminClient.R().Post("/v3/deployments/" + deploymentGUID + "/actions/cancel")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("rolls back the deployment", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+				expectDeploymentStatus("FINALIZED", "CANCELED")
+			})
+		})
+	})
+
 	Describe("List", func() {
 		var (
 			deploymentGUID    string
@@ -0,0 +1,183 @@
+package e2e_test
+
+import (
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+type isolationSegmentRelationship struct {
+	Data *resource `json:"data"`
+}
+
+func createIsolationSegment(name string) string {
+	GinkgoHelper()
+
+	var result resource
+	resp, err := adminClient.R().
+		SetBody(resource{Name: name}).
+		SetResult(&result).
+		Post("/v3/isolation_segments")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp).To(HaveRestyStatusCode(http.StatusCreated))
+
+	return result.GUID
+}
+
+var _ = Describe("Isolation Segments", func() {
+	var (
+		orgGUID     string
+		spaceGUID   string
+		segmentGUID string
+		resp        *resty.Response
+	)
+
+	BeforeEach(func() {
+		orgGUID = createOrg(generateGUID("org"))
+		DeferCleanup(func() {
+			expectJobCompletes(deleteOrg(orgGUID))
+		})
+		spaceGUID = createSpace(generateGUID("space"), orgGUID)
+
+		segmentGUID = createIsolationSegment(generateGUID("segment"))
+		DeferCleanup(func() {
+			_, err := adminClient.R().
+				SetBody(map[string]any{"data": nil}).
+				Patch("/v3/spaces/" + spaceGUID + "/relationships/isolation_segment")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = adminClient.R().
+				SetBody(map[string]any{"data": nil}).
+				Patch("/v3/organizations/" + orgGUID + "/relationships/default_isolation_segment")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = adminClient.R().Delete("/v3/isolation_segments/" + segmentGUID + "/relationships/organizations/" + orgGUID)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = adminClient.R().Delete("/v3/isolation_segments/" + segmentGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("get", func() {
+		var result resource
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().SetResult(&result).Get("/v3/isolation_segments/" + segmentGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the isolation segment", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.GUID).To(Equal(segmentGUID))
+		})
+	})
+
+	Describe("list", func() {
+		var result resourceList[resource]
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().SetResult(&result).Get("/v3/isolation_segments?guids=" + segmentGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the isolation segment", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.Resources).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(segmentGUID)})))
+		})
+	})
+
+	Describe("assign to a space", func() {
+		var result isolationSegmentRelationship
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetBody(map[string]any{"data": map[string]any{"guid": segmentGUID}}).
+				SetResult(&result).
+				Patch("/v3/spaces/" + spaceGUID + "/relationships/isolation_segment")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("fails as the isolation segment is not entitled to the org", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+		})
+
+		When("the isolation segment is entitled to the org", func() {
+			BeforeEach(func() {
+				resp, err := adminClient.R().
+					SetBody(map[string]any{"data": []map[string]any{{"guid": orgGUID}}}).
+					Post("/v3/isolation_segments/" + segmentGUID + "/relationships/organizations")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			})
+
+			It("assigns the isolation segment to the space", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+				Expect(result.Data).To(PointTo(MatchFields(IgnoreExtras, Fields{"GUID": Equal(segmentGUID)})))
+
+				var getResult isolationSegmentRelationship
+				getResp, err := adminClient.R().SetResult(&getResult).Get("/v3/spaces/" + spaceGUID + "/relationships/isolation_segment")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(getResp).To(HaveRestyStatusCode(http.StatusOK))
+				Expect(getResult.Data).To(PointTo(MatchFields(IgnoreExtras, Fields{"GUID": Equal(segmentGUID)})))
+			})
+
+			It("does not allow revoking the org entitlement", func() {
+				revokeResp, err := adminClient.R().Delete("/v3/isolation_segments/" + segmentGUID + "/relationships/organizations/" + orgGUID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revokeResp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+			})
+		})
+	})
+
+	Describe("set as the org default", func() {
+		var result isolationSegmentRelationship
+
+		BeforeEach(func() {
+			resp, err := adminClient.R().
+				SetBody(map[string]any{"data": []map[string]any{{"guid": orgGUID}}}).
+				Post("/v3/isolation_segments/" + segmentGUID + "/relationships/organizations")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetBody(map[string]any{"data": map[string]any{"guid": segmentGUID}}).
+				SetResult(&result).
+				Patch("/v3/organizations/" + orgGUID + "/relationships/default_isolation_segment")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("sets the default isolation segment of the org", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.Data).To(PointTo(MatchFields(IgnoreExtras, Fields{"GUID": Equal(segmentGUID)})))
+		})
+
+		It("does not allow deleting the isolation segment", func() {
+			deleteResp, err := adminClient.R().Delete("/v3/isolation_segments/" + segmentGUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleteResp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+		})
+	})
+
+	Describe("delete", func() {
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().Delete("/v3/isolation_segments/" + segmentGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the isolation segment", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusNoContent))
+
+			getResp, err := adminClient.R().Get("/v3/isolation_segments/" + segmentGUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getResp).To(HaveRestyStatusCode(http.StatusNotFound))
+		})
+	})
+})
@@ -0,0 +1,243 @@
+package e2e_test
+
+import (
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+type quotaResource struct {
+	GUID          string         `json:"guid,omitempty"`
+	Name          string         `json:"name"`
+	Apps          map[string]any `json:"apps,omitempty"`
+	Services      map[string]any `json:"services,omitempty"`
+	Routes        map[string]any `json:"routes,omitempty"`
+	Relationships map[string]any `json:"relationships,omitempty"`
+}
+
+func createOrgQuota(name string, routes map[string]any) string {
+	GinkgoHelper()
+
+	var result quotaResource
+	resp, err := adminClient.R().
+		SetBody(quotaResource{Name: name, Routes: routes}).
+		SetResult(&result).
+		Post("/v3/organization_quotas")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp).To(HaveRestyStatusCode(http.StatusCreated))
+
+	DeferCleanup(func() {
+		_, err := adminClient.R().Delete("/v3/organization_quotas/" + result.GUID)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	return result.GUID
+}
+
+func createSpaceQuota(name, orgGUID string, routes map[string]any) string {
+	GinkgoHelper()
+
+	var result quotaResource
+	resp, err := adminClient.R().
+		SetBody(quotaResource{
+			Name:   name,
+			Routes: routes,
+			Relationships: map[string]any{
+				"organization": map[string]any{"data": map[string]any{"guid": orgGUID}},
+			},
+		}).
+		SetResult(&result).
+		Post("/v3/space_quotas")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp).To(HaveRestyStatusCode(http.StatusCreated))
+
+	DeferCleanup(func() {
+		_, err := adminClient.R().Delete("/v3/space_quotas/" + result.GUID)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	return result.GUID
+}
+
+var _ = Describe("Organization Quotas", func() {
+	var (
+		orgGUID      string
+		orgQuotaGUID string
+		resp         *resty.Response
+	)
+
+	BeforeEach(func() {
+		orgGUID = createOrg(generateGUID("org"))
+		orgQuotaGUID = createOrgQuota(generateGUID("org-quota"), map[string]any{"total_routes": 10})
+	})
+
+	AfterEach(func() {
+		expectJobCompletes(deleteOrg(orgGUID))
+	})
+
+	Describe("get", func() {
+		var result quotaResource
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().SetResult(&result).Get("/v3/organization_quotas/" + orgQuotaGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the org quota", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.GUID).To(Equal(orgQuotaGUID))
+			Expect(result.Routes).To(HaveKeyWithValue("total_routes", BeEquivalentTo(10)))
+			Expect(result.Apps).To(HaveKeyWithValue("total_memory_in_mb", BeNil()))
+		})
+	})
+
+	Describe("list", func() {
+		var result resourceList[quotaResource]
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().SetResult(&result).Get("/v3/organization_quotas?guids=" + orgQuotaGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the org quota", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.Resources).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(orgQuotaGUID)})))
+		})
+	})
+
+	Describe("update", func() {
+		var result quotaResource
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetBody(map[string]any{"apps": map[string]any{"total_instances": 4}}).
+				SetResult(&result).
+				Patch("/v3/organization_quotas/" + orgQuotaGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the org quota", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.Apps).To(HaveKeyWithValue("total_instances", BeEquivalentTo(4)))
+			Expect(result.Routes).To(HaveKeyWithValue("total_routes", BeEquivalentTo(10)))
+		})
+	})
+
+	Describe("apply to organizations", func() {
+		var result resourceList[resource]
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetBody(map[string]any{"data": []map[string]any{{"guid": orgGUID}}}).
+				Post("/v3/organization_quotas/" + orgQuotaGUID + "/relationships/organizations")
+			Expect(err).NotTo(HaveOccurred())
+
+			resp, err = adminClient.R().SetResult(&result).Get("/v3/organization_quotas?organization_guids=" + orgGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("applies the org quota to the org", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.Resources).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(orgQuotaGUID)})))
+		})
+	})
+
+	Describe("delete", func() {
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().Delete("/v3/organization_quotas/" + orgQuotaGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the org quota", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusAccepted))
+			Expect(resp).To(HaveRestyHeaderWithValue("Location", HaveSuffix("/v3/jobs/organization_quota.delete~"+orgQuotaGUID)))
+			expectJobCompletes(resp)
+		})
+	})
+})
+
+var _ = Describe("Space Quotas", func() {
+	var (
+		spaceGUID      string
+		spaceQuotaGUID string
+		resp           *resty.Response
+	)
+
+	BeforeEach(func() {
+		spaceGUID = createSpace(generateGUID("space"), commonTestOrgGUID)
+		spaceQuotaGUID = createSpaceQuota(generateGUID("space-quota"), commonTestOrgGUID, map[string]any{"total_routes": 0})
+	})
+
+	AfterEach(func() {
+		deleteSpace(spaceGUID)
+	})
+
+	Describe("get", func() {
+		var result quotaResource
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().SetResult(&result).Get("/v3/space_quotas/" + spaceQuotaGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the space quota", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.GUID).To(Equal(spaceQuotaGUID))
+			Expect(result.Relationships).To(HaveKeyWithValue("organization", HaveKeyWithValue("data", HaveKeyWithValue("guid", commonTestOrgGUID))))
+		})
+	})
+
+	When("the space quota is applied to a space", func() {
+		BeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetBody(map[string]any{"data": []map[string]any{{"guid": spaceGUID}}}).
+				Post("/v3/space_quotas/" + spaceQuotaGUID + "/relationships/spaces")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+		})
+
+		It("does not allow creating routes beyond the quota", func() {
+			var resultErr cfErrs
+			resp, err := adminClient.R().
+				SetBody(routeResource{
+					Host: generateGUID("host"),
+					resource: resource{
+						Relationships: relationships{
+							"domain": {Data: resource{GUID: getDomainGUID(appFQDN)}},
+							"space":  {Data: resource{GUID: spaceGUID}},
+						},
+					},
+				}).
+				SetError(&resultErr).
+				Post("/v3/routes")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+			Expect(resultErr.Errors).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Detail": Equal("You have exceeded the total routes for your space's quota"),
+			})))
+		})
+
+		When("the space quota is removed from the space", func() {
+			BeforeEach(func() {
+				var err error
+				resp, err = adminClient.R().Delete("/v3/space_quotas/" + spaceQuotaGUID + "/relationships/spaces/" + spaceGUID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(HaveRestyStatusCode(http.StatusNoContent))
+			})
+
+			It("allows creating routes again", func() {
+				createRoute(generateGUID("host"), "", spaceGUID, getDomainGUID(appFQDN))
+			})
+		})
+	})
+})
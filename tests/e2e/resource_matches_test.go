@@ -0,0 +1,115 @@
+package e2e_test
+
+import (
+	"archive/zip"
+	"crypto/rand"
+	"crypto/sha1" // #nosec G505
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/go-resty/resty/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type resourceMatchResource struct {
+	Checksum struct {
+		Value string `json:"value"`
+	} `json:"checksum"`
+	SizeInBytes int64  `json:"size_in_bytes"`
+	Path        string `json:"path"`
+	Mode        string `json:"mode"`
+}
+
+type resourceMatchList struct {
+	Resources []resourceMatchResource `json:"resources"`
+}
+
+var _ = Describe("Resource Matches", func() {
+	var (
+		spaceGUID string
+		appGUID   string
+		bitsFile  string
+		resources resourceMatchList
+	)
+
+	matchResources := func(g Gomega) []resourceMatchResource {
+		var result resourceMatchList
+		resp, err := adminClient.R().
+			SetBody(resources).
+			SetResult(&result).
+			Post("/v3/resource_matches")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(resp).To(HaveRestyStatusCode(http.StatusCreated))
+
+		return result.Resources
+	}
+
+	BeforeEach(func() {
+		spaceGUID = createSpace(generateGUID("resource-matches-space"), commonTestOrgGUID)
+		appGUID = createBuildpackApp(spaceGUID, generateGUID("app"))
+
+		content := make([]byte, 128*1024)
+		_, err := rand.Read(content)
+		Expect(err).NotTo(HaveOccurred())
+		checksum := sha1.Sum(content) // #nosec G401
+
+		bitsFile = filepath.Join(GinkgoT().TempDir(), "bits.zip")
+		zipFile, err := os.Create(bitsFile)
+		Expect(err).NotTo(HaveOccurred())
+		zipWriter := zip.NewWriter(zipFile)
+		entry, err := zipWriter.Create("large-file")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = entry.Write(content)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zipWriter.Close()).To(Succeed())
+		Expect(zipFile.Close()).To(Succeed())
+
+		resource := resourceMatchResource{SizeInBytes: int64(len(content)), Path: "large-file", Mode: "644"}
+		resource.Checksum.Value = hex.EncodeToString(checksum[:])
+		resources = resourceMatchList{Resources: []resourceMatchResource{resource}}
+	})
+
+	AfterEach(func() {
+		deleteSpace(spaceGUID)
+	})
+
+	It("does not match resources that have never been uploaded", func() {
+		Expect(matchResources(Default)).To(BeEmpty())
+	})
+
+	When("the resources have been uploaded", func() {
+		BeforeEach(func() {
+			uploadTestApp(createBitsPackage(appGUID), bitsFile)
+		})
+
+		It("matches them", func() {
+			Expect(matchResources(Default)).To(ConsistOf(resources.Resources[0]))
+		})
+
+		It("allows uploading a package from the matched resources only", func() {
+			resourcesJSON, err := json.Marshal(resources.Resources)
+			Expect(err).NotTo(HaveOccurred())
+
+			var resp *resty.Response
+			resp, err = adminClient.R().
+				SetMultipartFormData(map[string]string{"resources": string(resourcesJSON)}).
+				Post("/v3/packages/" + createBitsPackage(appGUID) + "/upload")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+		})
+	})
+
+	It("rejects resources with an invalid checksum", func() {
+		resources.Resources[0].Checksum.Value = "not-a-sha"
+
+		resp, err := adminClient.R().
+			SetBody(resources).
+			Post("/v3/resource_matches")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp).To(HaveRestyStatusCode(http.StatusUnprocessableEntity))
+	})
+})
@@ -0,0 +1,130 @@
+package e2e_test
+
+import (
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+type revisionResource struct {
+	GUID        string `json:"guid"`
+	Version     int    `json:"version"`
+	Description string `json:"description"`
+	Deployable  bool   `json:"deployable"`
+	Droplet     struct {
+		GUID string `json:"guid"`
+	} `json:"droplet"`
+}
+
+var _ = Describe("Revisions", func() {
+	var (
+		spaceGUID string
+		appGUID   string
+	)
+
+	listAppRevisions := func(g Gomega) []revisionResource {
+		var result resourceList[revisionResource]
+		resp, err := adminClient.R().
+			SetResult(&result).
+			Get("/v3/apps/" + appGUID + "/revisions")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+
+		return result.Resources
+	}
+
+	BeforeEach(func() {
+		spaceGUID = createSpace(generateGUID("revisions-space"), commonTestOrgGUID)
+		appGUID, _ = pushTestApp(spaceGUID, defaultAppBitsFile)
+	})
+
+	AfterEach(func() {
+		deleteSpace(spaceGUID)
+	})
+
+	Describe("list app revisions", func() {
+		It("lists the initial revision of the app", func() {
+			Eventually(listAppRevisions).Should(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Version":     Equal(1),
+				"Description": Equal("Initial revision."),
+				"Deployable":  BeTrue(),
+			})))
+		})
+
+		When("the app environment changes and the app is restarted", func() {
+			BeforeEach(func() {
+				Eventually(listAppRevisions).Should(HaveLen(1))
+				setEnv(appGUID, map[string]interface{}{"FOO": "bar"})
+				restartApp(appGUID)
+			})
+
+			It("creates a new revision", func() {
+				Eventually(listAppRevisions).Should(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Version":     Equal(2),
+					"Description": Equal("New environment variables deployed."),
+				})))
+			})
+		})
+	})
+
+	Describe("get revision", func() {
+		var (
+			revisionGUID string
+			result       revisionResource
+			resp         *resty.Response
+		)
+
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				revisions := listAppRevisions(g)
+				g.Expect(revisions).To(HaveLen(1))
+				revisionGUID = revisions[0].GUID
+			}).Should(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetResult(&result).
+				Get("/v3/revisions/" + revisionGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the revision", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.GUID).To(Equal(revisionGUID))
+			Expect(result.Version).To(Equal(1))
+		})
+
+		It("returns the revision environment variables", func() {
+			var envVars struct {
+				Var map[string]string `json:"var"`
+			}
+			envResp, err := adminClient.R().
+				SetResult(&envVars).
+				Get("/v3/revisions/" + revisionGUID + "/environment_variables")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(envResp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(envVars.Var).To(BeEmpty())
+		})
+	})
+
+	Describe("list deployed revisions", func() {
+		It("returns the current revision of the app", func() {
+			Eventually(func(g Gomega) {
+				var result resourceList[revisionResource]
+				resp, err := adminClient.R().
+					SetResult(&result).
+					Get("/v3/apps/" + appGUID + "/revisions/deployed")
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+				g.Expect(result.Resources).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Version": Equal(1),
+				})))
+			}).Should(Succeed())
+		})
+	})
+})
@@ -8,8 +8,32 @@ import (
 	"github.com/go-resty/resty/v2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
+func createSecurityGroup(name string) string {
+	var result securityGroupResource
+	resp, err := adminClient.R().
+		SetBody(securityGroupResource{
+			Name: name,
+			Rules: []payloads.SecurityGroupRule{{
+				Protocol:    "tcp",
+				Ports:       "443",
+				Destination: "10.0.0.1",
+			}},
+		}).
+		SetResult(&result).
+		Post("/v3/security_groups")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp).To(HaveRestyStatusCode(http.StatusCreated))
+
+	DeferCleanup(func() {
+		security_group.Delete(rootNamespace, result.GUID)
+	})
+
+	return result.GUID
+}
+
 var _ = Describe("Security Group", func() {
 	var (
 		resp              *resty.Response
@@ -65,4 +89,160 @@ var _ = Describe("Security Group", func() {
 			}))
 		})
 	})
+
+	Describe("Get", func() {
+		var (
+			securityGroupGUID string
+			result            securityGroupResource
+		)
+
+		BeforeEach(func() {
+			securityGroupName = generateGUID("get")
+			securityGroupGUID = createSecurityGroup(securityGroupName)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetResult(&result).
+				Get("/v3/security_groups/" + securityGroupGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the security group", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.GUID).To(Equal(securityGroupGUID))
+			Expect(result.Name).To(Equal(securityGroupName))
+		})
+	})
+
+	Describe("List", func() {
+		var (
+			securityGroupGUID string
+			result            resourceList[securityGroupResource]
+		)
+
+		BeforeEach(func() {
+			securityGroupName = generateGUID("list")
+			securityGroupGUID = createSecurityGroup(securityGroupName)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetResult(&result).
+				Get("/v3/security_groups?names=" + securityGroupName)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the matching security groups", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.Resources).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(securityGroupGUID)}),
+			))
+		})
+	})
+
+	Describe("Update", func() {
+		var (
+			securityGroupGUID string
+			result            securityGroupResource
+		)
+
+		BeforeEach(func() {
+			securityGroupGUID = createSecurityGroup(generateGUID("update"))
+			securityGroupName = generateGUID("updated")
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				SetBody(map[string]any{"name": securityGroupName}).
+				SetResult(&result).
+				Patch("/v3/security_groups/" + securityGroupGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the security group", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.Name).To(Equal(securityGroupName))
+		})
+	})
+
+	Describe("Delete", func() {
+		var securityGroupGUID string
+
+		BeforeEach(func() {
+			securityGroupGUID = createSecurityGroup(generateGUID("delete"))
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resp, err = adminClient.R().
+				Delete("/v3/security_groups/" + securityGroupGUID)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the security group", func() {
+			Expect(resp).To(HaveRestyStatusCode(http.StatusAccepted))
+			expectJobCompletes(resp)
+		})
+	})
+
+	Describe("Bind and unbind running spaces", func() {
+		var (
+			securityGroupGUID string
+			spaceGUID         string
+			result            resourceList[securityGroupResource]
+		)
+
+		BeforeEach(func() {
+			securityGroupGUID = createSecurityGroup(generateGUID("bind"))
+			spaceGUID = createSpace(generateGUID("space"), commonTestOrgGUID)
+			DeferCleanup(func() {
+				deleteSpace(spaceGUID)
+			})
+
+			var err error
+			resp, err = adminClient.R().
+				SetBody(map[string]any{"data": []map[string]string{{"guid": spaceGUID}}}).
+				Post("/v3/security_groups/" + securityGroupGUID + "/relationships/running_spaces")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+		})
+
+		It("lists the security group as a running security group of the space", func() {
+			var err error
+			resp, err = adminClient.R().
+				SetResult(&result).
+				Get("/v3/spaces/" + spaceGUID + "/running_security_groups")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			Expect(result.Resources).To(ContainElement(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(securityGroupGUID)}),
+			))
+		})
+
+		When("the space is unbound", func() {
+			BeforeEach(func() {
+				var err error
+				resp, err = adminClient.R().
+					Delete("/v3/security_groups/" + securityGroupGUID + "/relationships/running_spaces/" + spaceGUID)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("no longer lists the security group for the space", func() {
+				Expect(resp).To(HaveRestyStatusCode(http.StatusNoContent))
+
+				var err error
+				resp, err = adminClient.R().
+					SetResult(&result).
+					Get("/v3/spaces/" + spaceGUID + "/running_security_groups")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.Resources).NotTo(ContainElement(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(securityGroupGUID)}),
+				))
+			})
+		})
+	})
 })
@@ -0,0 +1,170 @@
+package e2e_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+type usageEventResource struct {
+	GUID string `json:"guid"`
+}
+
+type appUsageEventResource struct {
+	usageEventResource
+	State struct {
+		Current string `json:"current"`
+	} `json:"state"`
+	App struct {
+		GUID string `json:"guid"`
+	} `json:"app"`
+	Process struct {
+		Type string `json:"type"`
+	} `json:"process"`
+	Space struct {
+		GUID string `json:"guid"`
+	} `json:"space"`
+	InstanceCount struct {
+		Current int `json:"current"`
+	} `json:"instance_count"`
+}
+
+type serviceUsageEventResource struct {
+	usageEventResource
+	State           string `json:"state"`
+	ServiceInstance struct {
+		GUID string `json:"guid"`
+		Type string `json:"type"`
+	} `json:"service_instance"`
+}
+
+var _ = Describe("Usage Events", func() {
+	var spaceGUID string
+
+	BeforeEach(func() {
+		spaceGUID = createSpace(generateGUID("usage-events-space"), commonTestOrgGUID)
+	})
+
+	AfterEach(func() {
+		deleteSpace(spaceGUID)
+	})
+
+	Describe("app usage events", func() {
+		var appGUID string
+
+		listAppUsageEvents := func(g Gomega) []appUsageEventResource {
+			var result resourceList[appUsageEventResource]
+			resp, err := adminClient.R().
+				SetQueryParams(map[string]string{"per_page": "5000"}).
+				SetResult(&result).
+				Get("/v3/app_usage_events")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+
+			return result.Resources
+		}
+
+		BeforeEach(func() {
+			appGUID, _ = pushTestApp(spaceGUID, defaultAppBitsFile)
+		})
+
+		It("records the app start", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listAppUsageEvents(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"State":         MatchFields(IgnoreExtras, Fields{"Current": Equal("STARTED")}),
+					"App":           MatchFields(IgnoreExtras, Fields{"GUID": Equal(appGUID)}),
+					"Process":       MatchFields(IgnoreExtras, Fields{"Type": Equal("web")}),
+					"Space":         MatchFields(IgnoreExtras, Fields{"GUID": Equal(spaceGUID)}),
+					"InstanceCount": MatchFields(IgnoreExtras, Fields{"Current": Equal(1)}),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the app is stopped", func() {
+			BeforeEach(func() {
+				appAction(appGUID, "stop")
+			})
+
+			It("records the app stop", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"State": MatchFields(IgnoreExtras, Fields{"Current": Equal("STOPPED")}),
+						"App":   MatchFields(IgnoreExtras, Fields{"GUID": Equal(appGUID)}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the app usage events are purged and reseeded", func() {
+			BeforeEach(func() {
+				resp, err := adminClient.R().Post("/v3/app_usage_events/actions/destructively_purge_all_and_reseed")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+			})
+
+			It("reseeds a STARTED event for the app", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"State": MatchFields(IgnoreExtras, Fields{"Current": Equal("STARTED")}),
+						"App":   MatchFields(IgnoreExtras, Fields{"GUID": Equal(appGUID)}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	Describe("service usage events", func() {
+		var instanceGUID string
+
+		listServiceUsageEvents := func(g Gomega) []serviceUsageEventResource {
+			var result resourceList[serviceUsageEventResource]
+			resp, err := adminClient.R().
+				SetQueryParams(map[string]string{
+					"service_instance_types": "user_provided_service_instance",
+					"per_page":               "5000",
+				}).
+				SetResult(&result).
+				Get("/v3/service_usage_events")
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
+
+			return result.Resources
+		}
+
+		BeforeEach(func() {
+			instanceGUID = createServiceInstance(spaceGUID, generateGUID("service-instance"), nil)
+		})
+
+		It("records the service instance creation", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listServiceUsageEvents(g)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"State": Equal("CREATED"),
+					"ServiceInstance": MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(instanceGUID),
+						"Type": Equal("user_provided_service_instance"),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the user is not an admin", func() {
+		var userName string
+
+		BeforeEach(func() {
+			userName = uuid.NewString()
+			createOrgRole("organization_manager", userName, commonTestOrgGUID)
+		})
+
+		It("cannot purge the usage events", func() {
+			resp, err := makeCertClientForUserName(userName, time.Hour).R().
+				Post("/v3/app_usage_events/actions/destructively_purge_all_and_reseed")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).To(HaveRestyStatusCode(http.StatusForbidden))
+		})
+	})
+})
@@ -0,0 +1,149 @@
+package image
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+// The name of the single entry in the layer of a file image
+const fileImageEntryName = "file"
+
+// PushFile pushes an image with a single layer that contains the content
+// returned by open to repoRef and tags it with tag. open may be called more
+// than once and must return content of the given size every time.
+func (c Client) PushFile(ctx context.Context, creds Creds, repoRef, tag string, size int64, open func() (io.ReadCloser, error)) error {
+	ref, err := name.ParseReference(repoRef)
+	if err != nil {
+		return fmt.Errorf("error parsing repository reference %s: %w", repoRef, err)
+	}
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return singleFileTar(size, open)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create a layer: %w", err)
+	}
+
+	image, err := mutate.AppendLayers(empty.Image, layer)
+	if err != nil {
+		return fmt.Errorf("failed to append layer: %w", err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	if err = remote.Write(ref.Context().Tag(tag), image, authOpt); err != nil {
+		return fmt.Errorf("failed to upload image: %w", err)
+	}
+
+	return nil
+}
+
+func singleFileTar(size int64, open func() (io.ReadCloser, error)) (io.ReadCloser, error) {
+	content, err := open()
+	if err != nil {
+		return nil, err
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+	go func() {
+		defer content.Close()
+
+		tarWriter := tar.NewWriter(pipeWriter)
+		err := tarWriter.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     fileImageEntryName,
+			Mode:     0o644,
+			Size:     size,
+		})
+		if err == nil {
+			_, err = io.Copy(tarWriter, content)
+		}
+		if err == nil {
+			err = tarWriter.Close()
+		}
+		pipeWriter.CloseWithError(err)
+	}()
+
+	return pipeReader, nil
+}
+
+// HasTag checks whether repoRef contains an image tagged with tag
+func (c Client) HasTag(ctx context.Context, creds Creds, repoRef, tag string) (bool, error) {
+	ref, err := name.ParseReference(repoRef)
+	if err != nil {
+		return false, fmt.Errorf("error parsing repository reference %s: %w", repoRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return false, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	_, err = remote.Head(ref.Context().Tag(tag), authOpt)
+	if err != nil {
+		var transportErr *transport.Error
+		if errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	return true, nil
+}
+
+// PullFile returns the content of an image pushed with PushFile
+func (c Client) PullFile(ctx context.Context, creds Creds, repoRef, tag string) (io.ReadCloser, error) {
+	ref, err := name.ParseReference(repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing repository reference %s: %w", repoRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref.Context().Tag(tag), authOpt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image layers: %w", err)
+	}
+
+	if len(layers) != 1 {
+		return nil, fmt.Errorf("expected a single layer, got %d", len(layers))
+	}
+
+	layerReader, err := layers[0].Uncompressed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layer: %w", err)
+	}
+
+	tarReader := tar.NewReader(layerReader)
+	if _, err = tarReader.Next(); err != nil {
+		layerReader.Close()
+		return nil, fmt.Errorf("failed to read layer entry: %w", err)
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{tarReader, layerReader}, nil
+}
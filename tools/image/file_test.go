@@ -0,0 +1,90 @@
+package image_test
+
+import (
+	"io"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/tools/image"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("File images", func() {
+	var (
+		repoRef string
+		tag     string
+		content string
+		creds   image.Creds
+		pushErr error
+	)
+
+	BeforeEach(func() {
+		repoRef = containerRegistry.ImageRef("resource-cache")
+		tag = uuid.NewString()
+		content = strings.Repeat("the-file-content", 1000)
+
+		imgClient = image.NewClient(k8sClientset)
+		creds = image.Creds{
+			Namespace:   "default",
+			SecretNames: []string{secretName},
+		}
+	})
+
+	JustBeforeEach(func() {
+		pushErr = imgClient.PushFile(ctx, creds, repoRef, tag, int64(len(content)), func() (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(content)), nil
+		})
+	})
+
+	It("pushes the file", func() {
+		Expect(pushErr).NotTo(HaveOccurred())
+
+		hasTag, err := imgClient.HasTag(ctx, creds, repoRef, tag)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hasTag).To(BeTrue())
+	})
+
+	It("can pull the file", func() {
+		fileContent, err := imgClient.PullFile(ctx, creds, repoRef, tag)
+		Expect(err).NotTo(HaveOccurred())
+		defer fileContent.Close()
+
+		actualContent, err := io.ReadAll(fileContent)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(actualContent)).To(Equal(content))
+	})
+
+	When("the tag does not exist", func() {
+		It("reports it as missing", func() {
+			hasTag, err := imgClient.HasTag(ctx, creds, repoRef, "not-a-tag")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hasTag).To(BeFalse())
+		})
+
+		It("fails to pull the file", func() {
+			_, err := imgClient.PullFile(ctx, creds, repoRef, "not-a-tag")
+			Expect(err).To(MatchError(ContainSubstring("failed to get image")))
+		})
+	})
+
+	When("the repository reference is invalid", func() {
+		BeforeEach(func() {
+			repoRef += ":bar:baz"
+		})
+
+		It("fails", func() {
+			Expect(pushErr).To(MatchError(ContainSubstring("error parsing repository reference")))
+		})
+	})
+
+	When("the secret doesn't exist", func() {
+		BeforeEach(func() {
+			creds.SecretNames = []string{"not-a-secret"}
+		})
+
+		It("fails to authenticate", func() {
+			Expect(pushErr).To(MatchError(ContainSubstring("Unauthorized")))
+		})
+	})
+})
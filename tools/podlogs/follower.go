@@ -0,0 +1,171 @@
+package podlogs
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const maxLineLength = 1024 * 1024
+
+type Streamer func(context.Context, kubernetes.Interface, corev1.Pod, corev1.PodLogOptions) (io.ReadCloser, error)
+
+var DefaultStreamer Streamer = func(ctx context.Context, clientset kubernetes.Interface, pod corev1.Pod, logOpts corev1.PodLogOptions) (io.ReadCloser, error) {
+	return clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &logOpts).Stream(ctx)
+}
+
+// Line is a log line of a followed container
+type Line struct {
+	Timestamp time.Time
+	Message   string
+}
+
+// Follower follows the logs of the containers of a changing set of pods. P is
+// the type of the listed pods, which allows callers to carry along whatever
+// they need to know about the pod a log line comes from.
+type Follower[P any] struct {
+	Clientset kubernetes.Interface
+	Streamer  Streamer
+	// ListPods lists the pods to follow. It is polled every PollInterval so
+	// that restarted and newly scheduled pods get followed as well.
+	ListPods     func(context.Context) ([]P, error)
+	PollInterval time.Duration
+	// GetPod returns the pod of a listed item
+	GetPod func(P) corev1.Pod
+	// SinceTime is the time container logs are followed from
+	SinceTime time.Time
+	// OnLine is called for every log line, concurrently for different
+	// containers. Returning false stops following the container.
+	OnLine func(context.Context, P, Line) bool
+}
+
+// Follow follows the logs of the given pods and of the pods returned by
+// ListPods until the context is done. It returns once all followed container
+// logs have been drained.
+func (f Follower[P]) Follow(ctx context.Context, pods []P) {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+
+	followedContainers := map[string]bool{}
+
+	for {
+		currentContainers := map[string]bool{}
+		for _, p := range pods {
+			pod := f.GetPod(p)
+			for _, containerStatus := range startedContainerStatuses(pod) {
+				// The restart count is part of the key so that the logs of a
+				// restarted container get followed again
+				containerKey := fmt.Sprintf("%s/%s/%d", pod.UID, containerStatus.Name, containerStatus.RestartCount)
+				currentContainers[containerKey] = true
+				if followedContainers[containerKey] {
+					continue
+				}
+				followedContainers[containerKey] = true
+
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					f.followContainerLogs(ctx, p, pod, corev1.PodLogOptions{
+						Container:  containerStatus.Name,
+						Follow:     true,
+						Timestamps: true,
+						SinceTime:  &metav1.Time{Time: f.SinceTime},
+					})
+				}()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(f.PollInterval):
+		}
+
+		listedPods, err := f.ListPods(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Info("failed to list pods", "reason", err)
+			}
+			continue
+		}
+		pods = listedPods
+
+		// Forget about containers that are gone for good so that long running
+		// followers do not accumulate them
+		followedContainers = currentContainers
+	}
+}
+
+func (f Follower[P]) followContainerLogs(ctx context.Context, p P, pod corev1.Pod, logOpts corev1.PodLogOptions) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("follow-container-logs").WithValues("pod", pod.Name, "container", logOpts.Container)
+
+	logReadCloser, err := f.Streamer(ctx, f.Clientset, pod, logOpts)
+	if err != nil {
+		logger.Info("failed to follow logs", "reason", err)
+		return
+	}
+	defer logReadCloser.Close()
+
+	scanner := bufio.NewScanner(logReadCloser)
+	scanner.Buffer(nil, maxLineLength)
+	for scanner.Scan() {
+		if len(scanner.Text()) == 0 {
+			continue
+		}
+
+		line := ParseLine(scanner.Text())
+		// `SinceTime` has a precision of a second, see
+		// https://github.com/kubernetes/kubernetes/issues/77856
+		if line.Timestamp.Before(f.SinceTime) {
+			continue
+		}
+
+		if !f.OnLine(ctx, p, line) {
+			return
+		}
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		logger.Info("failed to read logs", "reason", err)
+	}
+}
+
+// ParseLine splits a log line retrieved with the `Timestamps` option into its
+// RFC3339 timestamp and message. Lines without a timestamp are timestamped
+// with the current time.
+func ParseLine(line string) Line {
+	timestamp, message, found := strings.Cut(line, " ")
+	if !found {
+		return Line{Timestamp: time.Now(), Message: line}
+	}
+
+	logTime, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return Line{Timestamp: time.Now(), Message: line}
+	}
+
+	return Line{Timestamp: logTime, Message: message}
+}
+
+// startedContainerStatuses returns the statuses of the init and app
+// containers of the pod that are running or have terminated
+func startedContainerStatuses(pod corev1.Pod) []corev1.ContainerStatus {
+	return slices.DeleteFunc(
+		append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...),
+		func(status corev1.ContainerStatus) bool {
+			return status.State.Waiting != nil
+		},
+	)
+}
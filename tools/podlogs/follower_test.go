@@ -0,0 +1,216 @@
+package podlogs_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/korifi/tools/podlogs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var _ = Describe("Follower", func() {
+	type streamCall struct {
+		podName string
+		logOpts corev1.PodLogOptions
+	}
+
+	var (
+		ctx       context.Context
+		cancel    context.CancelFunc
+		sinceTime time.Time
+		pod       corev1.Pod
+
+		mu          sync.Mutex
+		listedPods  []corev1.Pod
+		streamCalls []streamCall
+		lines       []podlogs.Line
+
+		done chan struct{}
+	)
+
+	getStreamCalls := func() []streamCall {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]streamCall{}, streamCalls...)
+	}
+
+	getLines := func() []podlogs.Line {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]podlogs.Line{}, lines...)
+	}
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		DeferCleanup(func() {
+			cancel()
+			Eventually(done).Should(BeClosed())
+		})
+
+		sinceTime = time.Now().UTC().Round(0)
+		streamCalls = nil
+		lines = nil
+
+		pod = corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "my-pod",
+				UID:  "my-pod-uid",
+			},
+			Status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{{
+					Name: "init-container",
+				}},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "app-container"},
+					{
+						Name: "waiting-container",
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{},
+						},
+					},
+				},
+			},
+		}
+		listedPods = []corev1.Pod{pod}
+	})
+
+	JustBeforeEach(func() {
+		follower := podlogs.Follower[corev1.Pod]{
+			Streamer: func(_ context.Context, _ kubernetes.Interface, pod corev1.Pod, logOpts corev1.PodLogOptions) (io.ReadCloser, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				streamCalls = append(streamCalls, streamCall{podName: pod.Name, logOpts: logOpts})
+
+				return io.NopCloser(strings.NewReader(strings.Join([]string{
+					fmt.Sprintf("%s too-old", sinceTime.Add(-time.Second).Format(time.RFC3339Nano)),
+					"",
+					fmt.Sprintf("%s %s", sinceTime.Add(time.Second).Format(time.RFC3339Nano), logOpts.Container),
+				}, "\n"))), nil
+			},
+			ListPods: func(context.Context) ([]corev1.Pod, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				return listedPods, nil
+			},
+			PollInterval: 10 * time.Millisecond,
+			GetPod: func(pod corev1.Pod) corev1.Pod {
+				return pod
+			},
+			SinceTime: sinceTime,
+			OnLine: func(_ context.Context, _ corev1.Pod, line podlogs.Line) bool {
+				mu.Lock()
+				defer mu.Unlock()
+				lines = append(lines, line)
+				return true
+			},
+		}
+
+		done = make(chan struct{})
+		go func() {
+			defer close(done)
+			follower.Follow(ctx, []corev1.Pod{pod})
+		}()
+	})
+
+	It("follows the logs of the started containers", func() {
+		Eventually(getStreamCalls).Should(HaveLen(2))
+		Consistently(getStreamCalls).Should(HaveLen(2))
+
+		Expect(getStreamCalls()).To(ConsistOf(
+			streamCall{podName: "my-pod", logOpts: corev1.PodLogOptions{
+				Container:  "init-container",
+				Follow:     true,
+				Timestamps: true,
+				SinceTime:  &metav1.Time{Time: sinceTime},
+			}},
+			streamCall{podName: "my-pod", logOpts: corev1.PodLogOptions{
+				Container:  "app-container",
+				Follow:     true,
+				Timestamps: true,
+				SinceTime:  &metav1.Time{Time: sinceTime},
+			}},
+		))
+	})
+
+	It("passes on the non-empty log lines since the since time", func() {
+		Eventually(getLines).Should(ConsistOf(
+			podlogs.Line{Timestamp: sinceTime.Add(time.Second), Message: "init-container"},
+			podlogs.Line{Timestamp: sinceTime.Add(time.Second), Message: "app-container"},
+		))
+	})
+
+	It("returns when the context is done", func() {
+		cancel()
+		Eventually(done).Should(BeClosed())
+	})
+
+	When("a container gets restarted", func() {
+		JustBeforeEach(func() {
+			Eventually(getStreamCalls).Should(HaveLen(2))
+
+			restartedPod := pod.DeepCopy()
+			restartedPod.Status.ContainerStatuses[0].RestartCount = 1
+
+			mu.Lock()
+			defer mu.Unlock()
+			listedPods = []corev1.Pod{*restartedPod}
+		})
+
+		It("follows the logs of the restarted container", func() {
+			Eventually(getStreamCalls).Should(HaveLen(3))
+			Consistently(getStreamCalls).Should(HaveLen(3))
+			Expect(getStreamCalls()[2].logOpts.Container).To(Equal("app-container"))
+		})
+	})
+
+	When("a new pod gets scheduled", func() {
+		JustBeforeEach(func() {
+			Eventually(getStreamCalls).Should(HaveLen(2))
+
+			newPod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "new-pod",
+					UID:  "new-pod-uid",
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{{Name: "app-container"}},
+				},
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			listedPods = []corev1.Pod{pod, newPod}
+		})
+
+		It("follows the logs of the new pod", func() {
+			Eventually(getStreamCalls).Should(HaveLen(3))
+			Expect(getStreamCalls()[2].podName).To(Equal("new-pod"))
+		})
+	})
+})
+
+var _ = Describe("ParseLine", func() {
+	It("parses the timestamp and message", func() {
+		Expect(podlogs.ParseLine("2025-01-02T03:04:05.123456789Z hello world")).To(Equal(podlogs.Line{
+			Timestamp: time.Date(2025, 1, 2, 3, 4, 5, 123456789, time.UTC),
+			Message:   "hello world",
+		}))
+	})
+
+	When("the line has no timestamp", func() {
+		It("returns the whole line timestamped with the current time", func() {
+			line := podlogs.ParseLine("hello world")
+			Expect(line.Message).To(Equal("hello world"))
+			Expect(line.Timestamp).To(BeTemporally("~", time.Now(), time.Second))
+		})
+	})
+})